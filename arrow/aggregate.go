@@ -0,0 +1,137 @@
+package arrow
+
+import (
+	"math"
+
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/influxdata/flux/arrow/internal/kernels"
+)
+
+// SumFloat64 returns the sum of the valid values of vs.
+func SumFloat64(vs *array.Float64) float64 {
+	if vs.NullN() == 0 {
+		return kernels.SumFloat64(vs.Float64Values())
+	}
+	var sum float64
+	for i := 0; i < vs.Len(); i++ {
+		if vs.IsValid(i) {
+			sum += vs.Value(i)
+		}
+	}
+	return sum
+}
+
+// SumInt64 returns the sum of the valid values of vs.
+func SumInt64(vs *array.Int64) int64 {
+	if vs.NullN() == 0 {
+		return kernels.SumInt64(vs.Int64Values())
+	}
+	var sum int64
+	for i := 0; i < vs.Len(); i++ {
+		if vs.IsValid(i) {
+			sum += vs.Value(i)
+		}
+	}
+	return sum
+}
+
+// SumUint64 returns the sum of the valid values of vs.
+func SumUint64(vs *array.Uint64) uint64 {
+	var sum uint64
+	if vs.NullN() == 0 {
+		for _, v := range vs.Uint64Values() {
+			sum += v
+		}
+		return sum
+	}
+	for i := 0; i < vs.Len(); i++ {
+		if vs.IsValid(i) {
+			sum += vs.Value(i)
+		}
+	}
+	return sum
+}
+
+// MinFloat64 returns the index of the first row with the smallest valid value of vs.
+// NaN values are ignored. It returns -1 if vs has no valid values other than NaN.
+func MinFloat64(vs *array.Float64) int {
+	if vs.NullN() == 0 {
+		return kernels.MinFloat64(vs.Float64Values())
+	}
+	idx := -1
+	for i := 0; i < vs.Len(); i++ {
+		if v := vs.Value(i); vs.IsValid(i) && !math.IsNaN(v) && (idx < 0 || v < vs.Value(idx)) {
+			idx = i
+		}
+	}
+	return idx
+}
+
+// MaxFloat64 returns the index of the first row with the largest valid value of vs.
+// NaN values are ignored. It returns -1 if vs has no valid values other than NaN.
+func MaxFloat64(vs *array.Float64) int {
+	if vs.NullN() == 0 {
+		return kernels.MaxFloat64(vs.Float64Values())
+	}
+	idx := -1
+	for i := 0; i < vs.Len(); i++ {
+		if v := vs.Value(i); vs.IsValid(i) && !math.IsNaN(v) && (idx < 0 || v > vs.Value(idx)) {
+			idx = i
+		}
+	}
+	return idx
+}
+
+// MinInt64 returns the index of the first row with the smallest valid value of vs,
+// or -1 if vs has no valid values.
+func MinInt64(vs *array.Int64) int {
+	if vs.NullN() == 0 {
+		return kernels.MinInt64(vs.Int64Values())
+	}
+	idx := -1
+	for i := 0; i < vs.Len(); i++ {
+		if vs.IsValid(i) && (idx < 0 || vs.Value(i) < vs.Value(idx)) {
+			idx = i
+		}
+	}
+	return idx
+}
+
+// MaxInt64 returns the index of the first row with the largest valid value of vs,
+// or -1 if vs has no valid values.
+func MaxInt64(vs *array.Int64) int {
+	if vs.NullN() == 0 {
+		return kernels.MaxInt64(vs.Int64Values())
+	}
+	idx := -1
+	for i := 0; i < vs.Len(); i++ {
+		if vs.IsValid(i) && (idx < 0 || vs.Value(i) > vs.Value(idx)) {
+			idx = i
+		}
+	}
+	return idx
+}
+
+// MinUint64 returns the index of the first row with the smallest valid value of vs,
+// or -1 if vs has no valid values.
+func MinUint64(vs *array.Uint64) int {
+	idx := -1
+	for i := 0; i < vs.Len(); i++ {
+		if vs.IsValid(i) && (idx < 0 || vs.Value(i) < vs.Value(idx)) {
+			idx = i
+		}
+	}
+	return idx
+}
+
+// MaxUint64 returns the index of the first row with the largest valid value of vs,
+// or -1 if vs has no valid values.
+func MaxUint64(vs *array.Uint64) int {
+	idx := -1
+	for i := 0; i < vs.Len(); i++ {
+		if vs.IsValid(i) && (idx < 0 || vs.Value(i) > vs.Value(idx)) {
+			idx = i
+		}
+	}
+	return idx
+}
@@ -33,3 +33,57 @@ func (a *allocator) Free(b []byte) {
 	a.alloc.Free(cap(b))
 	a.Allocator.Free(b)
 }
+
+func newAllocator(a *memory.Allocator) arrowmemory.Allocator {
+	var alloc arrowmemory.Allocator = arrowmemory.NewGoAllocator()
+	if a != nil {
+		alloc = &allocator{
+			Allocator: alloc,
+			alloc:     a,
+		}
+	}
+	return alloc
+}
+
+// pooledAllocator allocates the buffers from the pool of the query.
+type pooledAllocator struct {
+	alloc *memory.Allocator
+}
+
+// newPooledAllocator returns an allocator for the buffers of fixed-width arrays,
+// which reuses the buffers freed by the query if its allocator has a pool.
+// The values of strings are not allocated from the pool, since strings
+// read from an array may outlive it.
+func newPooledAllocator(a *memory.Allocator) arrowmemory.Allocator {
+	if a == nil || a.Pool == nil {
+		return newAllocator(a)
+	}
+	return &pooledAllocator{alloc: a}
+}
+
+func (a *pooledAllocator) Allocate(size int) []byte {
+	if err := a.alloc.Allocate(a.alloc.Pool.Cap(size)); err != nil {
+		panic(err)
+	}
+	return a.alloc.Pool.Get(size)
+}
+
+func (a *pooledAllocator) Reallocate(size int, b []byte) []byte {
+	if size <= cap(b) {
+		n := len(b)
+		b = b[:size]
+		for i := n; i < size; i++ {
+			b[i] = 0
+		}
+		return b
+	}
+	nb := a.Allocate(size)
+	copy(nb, b)
+	a.Free(b)
+	return nb
+}
+
+func (a *pooledAllocator) Free(b []byte) {
+	a.alloc.Free(cap(b))
+	a.alloc.Pool.Put(b)
+}
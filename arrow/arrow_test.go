@@ -1,6 +1,7 @@
 package arrow_test
 
 import (
+	gomath "math"
 	"testing"
 
 	"github.com/apache/arrow/go/arrow/array"
@@ -8,6 +9,7 @@ import (
 	arrowmemory "github.com/apache/arrow/go/arrow/memory"
 	"github.com/google/go-cmp/cmp"
 	"github.com/influxdata/flux/arrow"
+	"github.com/influxdata/flux/memory"
 )
 
 func TestSum_Float64_Empty(t *testing.T) {
@@ -756,3 +758,204 @@ func TestSlice_OutOfBounds_Bool(t *testing.T) {
 		})
 	}
 }
+
+func TestDictionary(t *testing.T) {
+	alloc := &memory.Allocator{}
+	b := arrow.NewDictionaryBuilder(alloc)
+	for _, v := range []string{"a", "b", "a", "", "b", "a"} {
+		if v == "" {
+			b.AppendNull()
+			continue
+		}
+		b.AppendString(v)
+	}
+	if got, want := b.Cardinality(), 2; got != want {
+		t.Fatalf("unexpected cardinality: got=%d, want=%d", got, want)
+	}
+	d := b.NewDictionary()
+	b.Release()
+
+	if got, want := d.Values().Len(), 2; got != want {
+		t.Fatalf("unexpected number of distinct values: got=%d, want=%d", got, want)
+	}
+	if d.Index(0) != d.Index(2) || d.Index(0) == d.Index(1) {
+		t.Errorf("unexpected indices: %v", d.Indices())
+	}
+
+	decode := func(arr *array.Binary) []interface{} {
+		vs := make([]interface{}, arr.Len())
+		for i := range vs {
+			if arr.IsValid(i) {
+				vs[i] = arr.ValueString(i)
+			}
+		}
+		return vs
+	}
+	decoded := d.Decode(alloc)
+	if want, got := []interface{}{"a", "b", "a", nil, "b", "a"}, decode(decoded); !cmp.Equal(want, got) {
+		t.Errorf("unexpected decoded values -want/+got\n%s", cmp.Diff(want, got))
+	}
+	decoded.Release()
+
+	slice := arrow.DictionarySlice(d, 2, 5)
+	take := arrow.DictionaryTake(d, []int{5, 3, 1}, alloc)
+	d.Release()
+
+	// The slice and the taken rows share the values of the dictionary.
+	decoded = slice.Decode(alloc)
+	if want, got := []interface{}{"a", nil, "b"}, decode(decoded); !cmp.Equal(want, got) {
+		t.Errorf("unexpected sliced values -want/+got\n%s", cmp.Diff(want, got))
+	}
+	decoded.Release()
+	slice.Release()
+
+	decoded = take.Decode(alloc)
+	if want, got := []interface{}{"a", nil, "b"}, decode(decoded); !cmp.Equal(want, got) {
+		t.Errorf("unexpected taken values -want/+got\n%s", cmp.Diff(want, got))
+	}
+	decoded.Release()
+	take.Release()
+
+	if got := alloc.Allocated(); got != 0 {
+		t.Errorf("expected all the memory to be freed, %d bytes are still allocated", got)
+	}
+}
+
+func TestAggregate_Float64(t *testing.T) {
+	b := arrow.NewFloatBuilder(nil)
+	for i := 0; i < 40; i++ {
+		switch {
+		case i == 3:
+			b.Append(gomath.NaN())
+		case i%5 == 0:
+			b.AppendNull()
+		default:
+			b.Append(float64((i * 7) % 11))
+		}
+	}
+	arr := b.NewFloat64Array()
+	b.Release()
+	defer arr.Release()
+
+	for _, tt := range []struct {
+		interval [2]int
+		sum      float64
+		min, max int
+	}{
+		{interval: [2]int{0, 0}, min: -1, max: -1},
+		{interval: [2]int{3, 4}, sum: gomath.NaN(), min: -1, max: -1},
+		{interval: [2]int{6, 10}, sum: 23, min: 2, max: 0},
+		{interval: [2]int{1, 40}, sum: gomath.NaN(), min: 10, max: 13},
+		{interval: [2]int{4, 40}, sum: 148, min: 7, max: 10},
+	} {
+		vs := arrow.FloatSlice(arr, tt.interval[0], tt.interval[1])
+		if got := arrow.SumFloat64(vs); got != tt.sum && !(gomath.IsNaN(got) && gomath.IsNaN(tt.sum)) {
+			t.Errorf("unexpected sum of %v: want %v, got %v", tt.interval, tt.sum, got)
+		}
+		if got := arrow.MinFloat64(vs); got != tt.min {
+			t.Errorf("unexpected min index of %v: want %d, got %d", tt.interval, tt.min, got)
+		}
+		if got := arrow.MaxFloat64(vs); got != tt.max {
+			t.Errorf("unexpected max index of %v: want %d, got %d", tt.interval, tt.max, got)
+		}
+		vs.Release()
+	}
+}
+
+func TestAggregate_Int64(t *testing.T) {
+	values := []int64{3, -1, 4, -1, 5, -9, 2, 6, 5, 3, 5, 9, 7, -9, 3, 2, 9}
+	arr := arrow.NewInt(values, nil)
+	defer arr.Release()
+
+	for _, tt := range []struct {
+		interval [2]int
+		sum      int64
+		min, max int
+	}{
+		{interval: [2]int{0, 0}, min: -1, max: -1},
+		{interval: [2]int{0, 5}, sum: 10, min: 1, max: 4},
+		{interval: [2]int{0, 17}, sum: 43, min: 5, max: 11},
+		{interval: [2]int{6, 17}, sum: 42, min: 7, max: 5},
+	} {
+		vs := arrow.IntSlice(arr, tt.interval[0], tt.interval[1])
+		if got := arrow.SumInt64(vs); got != tt.sum {
+			t.Errorf("unexpected sum of %v: want %v, got %v", tt.interval, tt.sum, got)
+		}
+		if got := arrow.MinInt64(vs); got != tt.min {
+			t.Errorf("unexpected min index of %v: want %d, got %d", tt.interval, tt.min, got)
+		}
+		if got := arrow.MaxInt64(vs); got != tt.max {
+			t.Errorf("unexpected max index of %v: want %d, got %d", tt.interval, tt.max, got)
+		}
+		vs.Release()
+	}
+}
+
+func TestAggregate_Uint64(t *testing.T) {
+	b := arrow.NewUintBuilder(nil)
+	for i, v := range []uint64{3, 1, 4, 1, 5, 9, 2, 6, 5, 3, 5, 8, 9, 7, 9, 1 << 63} {
+		if i%4 == 2 {
+			b.AppendNull()
+		} else {
+			b.Append(v)
+		}
+	}
+	arr := b.NewUint64Array()
+	b.Release()
+	defer arr.Release()
+
+	for _, tt := range []struct {
+		interval [2]int
+		sum      uint64
+		min, max int
+	}{
+		{interval: [2]int{0, 0}, min: -1, max: -1},
+		{interval: [2]int{2, 3}, min: -1, max: -1},
+		{interval: [2]int{0, 6}, sum: 19, min: 1, max: 5},
+		{interval: [2]int{4, 10}, sum: 28, min: 5, max: 1},
+		{interval: [2]int{7, 16}, sum: 1<<63 + 38, min: 2, max: 8},
+	} {
+		vs := arrow.UintSlice(arr, tt.interval[0], tt.interval[1])
+		if got := arrow.SumUint64(vs); got != tt.sum {
+			t.Errorf("unexpected sum of %v: want %v, got %v", tt.interval, tt.sum, got)
+		}
+		if got := arrow.MinUint64(vs); got != tt.min {
+			t.Errorf("unexpected min index of %v: want %d, got %d", tt.interval, tt.min, got)
+		}
+		if got := arrow.MaxUint64(vs); got != tt.max {
+			t.Errorf("unexpected max index of %v: want %d, got %d", tt.interval, tt.max, got)
+		}
+		vs.Release()
+	}
+}
+
+func TestPooledAllocator(t *testing.T) {
+	alloc := &memory.Allocator{Pool: memory.NewPool(memory.DefaultPoolLimit)}
+	values := make([]float64, 1000)
+	for i := range values {
+		values[i] = float64(i)
+	}
+
+	arr := arrow.NewFloat(values, alloc)
+	if got := alloc.Allocated(); got == 0 {
+		t.Fatal("expected the array to be accounted for")
+	}
+	buf := arr.Float64Values()
+	arr.Release()
+	if got := alloc.Allocated(); got != 0 {
+		t.Fatalf("unexpected allocated memory after release: %d", got)
+	}
+	if got := alloc.Pool.Retained(); got == 0 {
+		t.Fatal("expected the buffers of the array to be retained by the pool")
+	}
+
+	// A new array of the same size reuses the buffers of the released array.
+	arr = arrow.NewFloat(values[:900], alloc)
+	defer arr.Release()
+	if &arr.Float64Values()[0] != &buf[0] {
+		t.Error("expected the values buffer to be reused")
+	}
+	if !cmp.Equal(values[:900], arr.Float64Values()) {
+		t.Errorf("unexpected values -want/+got:\n%s", cmp.Diff(values[:900], arr.Float64Values()))
+	}
+}
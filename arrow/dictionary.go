@@ -0,0 +1,195 @@
+package arrow
+
+import (
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/influxdata/flux/memory"
+)
+
+// Dictionary is a dictionary-encoded array of strings.
+// It is an array of indices into an array of the distinct values, the dictionary.
+// As an array.Interface, a Dictionary is its array of indices.
+//
+// Two valid rows of a Dictionary have the same value if and only if they have the same index.
+type Dictionary struct {
+	indices *array.Int32
+	values  *array.Binary
+}
+
+// NewDictionary returns a dictionary-encoded array from its indices and its distinct values.
+// The Dictionary takes ownership of the references to indices and values.
+func NewDictionary(indices *array.Int32, values *array.Binary) *Dictionary {
+	return &Dictionary{
+		indices: indices,
+		values:  values,
+	}
+}
+
+// Indices returns the array of indices into the dictionary.
+func (d *Dictionary) Indices() *array.Int32 {
+	return d.indices
+}
+
+// Values returns the distinct values of the array.
+func (d *Dictionary) Values() *array.Binary {
+	return d.values
+}
+
+// Index returns the index of the value of row i in the dictionary.
+func (d *Dictionary) Index(i int) int {
+	return int(d.indices.Value(i))
+}
+
+// Value returns the value of row i. It should not be mutated.
+func (d *Dictionary) Value(i int) []byte {
+	return d.values.Value(d.Index(i))
+}
+
+// ValueString returns the value of row i without performing additional allocations.
+// The string is only valid for the lifetime of the Dictionary.
+func (d *Dictionary) ValueString(i int) string {
+	return d.values.ValueString(d.Index(i))
+}
+
+func (d *Dictionary) DataType() arrow.DataType {
+	return d.indices.DataType()
+}
+
+func (d *Dictionary) NullN() int {
+	return d.indices.NullN()
+}
+
+func (d *Dictionary) NullBitmapBytes() []byte {
+	return d.indices.NullBitmapBytes()
+}
+
+func (d *Dictionary) IsNull(i int) bool {
+	return d.indices.IsNull(i)
+}
+
+func (d *Dictionary) IsValid(i int) bool {
+	return d.indices.IsValid(i)
+}
+
+func (d *Dictionary) Data() *array.Data {
+	return d.indices.Data()
+}
+
+func (d *Dictionary) Len() int {
+	return d.indices.Len()
+}
+
+func (d *Dictionary) Retain() {
+	d.indices.Retain()
+	d.values.Retain()
+}
+
+func (d *Dictionary) Release() {
+	d.indices.Release()
+	d.values.Release()
+}
+
+// Decode returns the values of the rows as a plain array of strings.
+func (d *Dictionary) Decode(alloc *memory.Allocator) *array.Binary {
+	b := NewStringBuilder(alloc)
+	defer b.Release()
+	b.Reserve(d.Len())
+	for i := 0; i < d.Len(); i++ {
+		if d.IsNull(i) {
+			b.AppendNull()
+			continue
+		}
+		b.Append(d.Value(i))
+	}
+	return b.NewBinaryArray()
+}
+
+// DictionarySlice returns the rows [i, j) of a Dictionary, which share its indices and values.
+func DictionarySlice(d *Dictionary, i, j int) *Dictionary {
+	data := array.NewSliceData(d.indices.Data(), int64(i), int64(j))
+	defer data.Release()
+	d.values.Retain()
+	return NewDictionary(array.NewInt32Data(data), d.values)
+}
+
+// DictionaryTake returns a Dictionary of the given rows of d, which shares its values.
+func DictionaryTake(d *Dictionary, rows []int, alloc *memory.Allocator) *Dictionary {
+	b := array.NewInt32Builder(newAllocator(alloc))
+	defer b.Release()
+	b.Reserve(len(rows))
+	for _, i := range rows {
+		if d.IsNull(i) {
+			b.AppendNull()
+			continue
+		}
+		b.Append(d.indices.Value(i))
+	}
+	d.values.Retain()
+	return NewDictionary(b.NewInt32Array(), d.values)
+}
+
+// DictionaryBuilder builds a Dictionary, adding the distinct values to its dictionary as they are appended.
+type DictionaryBuilder struct {
+	indices *array.Int32Builder
+	values  *array.BinaryBuilder
+	lookup  map[string]int32
+}
+
+func NewDictionaryBuilder(a *memory.Allocator) *DictionaryBuilder {
+	return &DictionaryBuilder{
+		indices: array.NewInt32Builder(newAllocator(a)),
+		values:  NewStringBuilder(a),
+		lookup:  make(map[string]int32),
+	}
+}
+
+// Len returns the number of rows appended so far.
+func (b *DictionaryBuilder) Len() int {
+	return b.indices.Len()
+}
+
+// Cardinality returns the number of distinct values appended so far.
+func (b *DictionaryBuilder) Cardinality() int {
+	return len(b.lookup)
+}
+
+func (b *DictionaryBuilder) Reserve(n int) {
+	b.indices.Reserve(n)
+}
+
+func (b *DictionaryBuilder) AppendNull() {
+	b.indices.AppendNull()
+}
+
+func (b *DictionaryBuilder) Append(v []byte) {
+	// The conversion of the map key does not allocate.
+	idx, ok := b.lookup[string(v)]
+	if !ok {
+		idx = int32(len(b.lookup))
+		b.values.Append(v)
+		b.lookup[string(v)] = idx
+	}
+	b.indices.Append(idx)
+}
+
+func (b *DictionaryBuilder) AppendString(v string) {
+	idx, ok := b.lookup[v]
+	if !ok {
+		idx = int32(len(b.lookup))
+		b.values.AppendString(v)
+		b.lookup[v] = idx
+	}
+	b.indices.Append(idx)
+}
+
+// NewDictionary returns the Dictionary of the appended rows and resets the builder.
+func (b *DictionaryBuilder) NewDictionary() *Dictionary {
+	d := NewDictionary(b.indices.NewInt32Array(), b.values.NewBinaryArray())
+	b.lookup = make(map[string]int32)
+	return d
+}
+
+func (b *DictionaryBuilder) Release() {
+	b.indices.Release()
+	b.values.Release()
+}
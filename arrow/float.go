@@ -2,7 +2,6 @@ package arrow
 
 import (
 	"github.com/apache/arrow/go/arrow/array"
-	arrowmemory "github.com/apache/arrow/go/arrow/memory"
 	"github.com/influxdata/flux/memory"
 )
 
@@ -24,12 +23,5 @@ func FloatSlice(arr *array.Float64, i, j int) *array.Float64 {
 }
 
 func NewFloatBuilder(a *memory.Allocator) *array.Float64Builder {
-	var alloc arrowmemory.Allocator = arrowmemory.NewGoAllocator()
-	if a != nil {
-		alloc = &allocator{
-			Allocator: alloc,
-			alloc:     a,
-		}
-	}
-	return array.NewFloat64Builder(alloc)
+	return array.NewFloat64Builder(newPooledAllocator(a))
 }
@@ -0,0 +1,85 @@
+// Package kernels implements the hot loops of the aggregates over slices of values.
+//
+// The kernels are implemented with the vector instructions of the architecture
+// when they are available, AVX2 on amd64 and NEON on arm64, and in Go otherwise.
+// The implementation is selected when the package is initialized.
+// Building with the noasm tag selects the Go implementation on every architecture.
+package kernels
+
+var (
+	sumFloat64 = sumFloat64Go
+	sumInt64   = sumInt64Go
+	minFloat64 = minFloat64Go
+	maxFloat64 = maxFloat64Go
+	minInt64   = minInt64Go
+	maxInt64   = maxInt64Go
+)
+
+// SumFloat64 returns the sum of vs.
+// The order in which the values are added depends on the implementation,
+// so the result may differ in the last bits from a sequential sum.
+func SumFloat64(vs []float64) float64 {
+	return sumFloat64(vs)
+}
+
+// SumInt64 returns the sum of vs.
+func SumInt64(vs []int64) int64 {
+	return sumInt64(vs)
+}
+
+// MinFloat64 returns the index of the first occurrence of the smallest value of vs.
+// NaN values are ignored. It returns -1 if vs has no values other than NaN.
+func MinFloat64(vs []float64) int {
+	if len(vs) == 0 {
+		return -1
+	}
+	return indexFloat64(vs, minFloat64(vs))
+}
+
+// MaxFloat64 returns the index of the first occurrence of the largest value of vs.
+// NaN values are ignored. It returns -1 if vs has no values other than NaN.
+func MaxFloat64(vs []float64) int {
+	if len(vs) == 0 {
+		return -1
+	}
+	return indexFloat64(vs, maxFloat64(vs))
+}
+
+// MinInt64 returns the index of the first occurrence of the smallest value of vs,
+// or -1 if vs is empty.
+func MinInt64(vs []int64) int {
+	if len(vs) == 0 {
+		return -1
+	}
+	return indexInt64(vs, minInt64(vs))
+}
+
+// MaxInt64 returns the index of the first occurrence of the largest value of vs,
+// or -1 if vs is empty.
+func MaxInt64(vs []int64) int {
+	if len(vs) == 0 {
+		return -1
+	}
+	return indexInt64(vs, maxInt64(vs))
+}
+
+// indexFloat64 returns the index of the first value of vs equal to v, or -1.
+// The kernels only compute the extreme value, which is cheaper to reduce
+// across the lanes of a vector than its position.
+func indexFloat64(vs []float64, v float64) int {
+	for i, x := range vs {
+		if x == v {
+			return i
+		}
+	}
+	return -1
+}
+
+func indexInt64(vs []int64, v int64) int {
+	for i, x := range vs {
+		if x == v {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,59 @@
+//go:build !noasm
+
+package kernels
+
+func init() {
+	if hasAVX2() {
+		sumFloat64 = sumFloat64AVX2
+		sumInt64 = sumInt64AVX2
+		minFloat64 = minFloat64AVX2
+		maxFloat64 = maxFloat64AVX2
+		minInt64 = minInt64AVX2
+		maxInt64 = maxInt64AVX2
+	}
+}
+
+// hasAVX2 reports whether the processor supports AVX2
+// and the operating system saves the state of the YMM registers.
+func hasAVX2() bool {
+	maxID, _, _, _ := cpuid(0, 0)
+	if maxID < 7 {
+		return false
+	}
+	_, _, ecx1, _ := cpuid(1, 0)
+	const (
+		osxsave = 1 << 27
+		avx     = 1 << 28
+	)
+	if ecx1&(osxsave|avx) != osxsave|avx {
+		return false
+	}
+	// The XMM and YMM state must both be enabled in XCR0.
+	if eax, _ := xgetbv(); eax&6 != 6 {
+		return false
+	}
+	_, ebx7, _, _ := cpuid(7, 0)
+	return ebx7&(1<<5) != 0
+}
+
+func cpuid(eaxArg, ecxArg uint32) (eax, ebx, ecx, edx uint32)
+
+func xgetbv() (eax, edx uint32)
+
+//go:noescape
+func sumFloat64AVX2(vs []float64) float64
+
+//go:noescape
+func sumInt64AVX2(vs []int64) int64
+
+//go:noescape
+func minFloat64AVX2(vs []float64) float64
+
+//go:noescape
+func maxFloat64AVX2(vs []float64) float64
+
+//go:noescape
+func minInt64AVX2(vs []int64) int64
+
+//go:noescape
+func maxInt64AVX2(vs []int64) int64
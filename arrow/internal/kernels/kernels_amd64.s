@@ -0,0 +1,346 @@
+//go:build !noasm
+
+#include "textflag.h"
+
+// func cpuid(eaxArg, ecxArg uint32) (eax, ebx, ecx, edx uint32)
+TEXT ·cpuid(SB), NOSPLIT, $0-24
+	MOVL eaxArg+0(FP), AX
+	MOVL ecxArg+4(FP), CX
+	CPUID
+	MOVL AX, eax+8(FP)
+	MOVL BX, ebx+12(FP)
+	MOVL CX, ecx+16(FP)
+	MOVL DX, edx+20(FP)
+	RET
+
+// func xgetbv() (eax, edx uint32)
+TEXT ·xgetbv(SB), NOSPLIT, $0-8
+	MOVL $0, CX
+	XGETBV
+	MOVL AX, eax+0(FP)
+	MOVL DX, edx+4(FP)
+	RET
+
+// func sumFloat64AVX2(vs []float64) float64
+TEXT ·sumFloat64AVX2(SB), NOSPLIT, $0-32
+	MOVQ   vs_base+0(FP), SI
+	MOVQ   vs_len+8(FP), CX
+	VXORPD Y0, Y0, Y0
+	VXORPD Y1, Y1, Y1
+	VXORPD Y2, Y2, Y2
+	VXORPD Y3, Y3, Y3
+
+sum16:
+	CMPQ   CX, $16
+	JL     sum4
+	VADDPD (SI), Y0, Y0
+	VADDPD 32(SI), Y1, Y1
+	VADDPD 64(SI), Y2, Y2
+	VADDPD 96(SI), Y3, Y3
+	ADDQ   $128, SI
+	SUBQ   $16, CX
+	JMP    sum16
+
+sum4:
+	CMPQ   CX, $4
+	JL     reduce
+	VADDPD (SI), Y0, Y0
+	ADDQ   $32, SI
+	SUBQ   $4, CX
+	JMP    sum4
+
+reduce:
+	VADDPD       Y1, Y0, Y0
+	VADDPD       Y3, Y2, Y2
+	VADDPD       Y2, Y0, Y0
+	VEXTRACTF128 $1, Y0, X1
+	VADDPD       X1, X0, X0
+	VPERMILPD    $1, X0, X1
+	VADDSD       X1, X0, X0
+
+tail:
+	CMPQ   CX, $0
+	JE     done
+	VADDSD (SI), X0, X0
+	ADDQ   $8, SI
+	DECQ   CX
+	JMP    tail
+
+done:
+	VZEROUPPER
+	MOVSD X0, ret+24(FP)
+	RET
+
+// func sumInt64AVX2(vs []int64) int64
+TEXT ·sumInt64AVX2(SB), NOSPLIT, $0-32
+	MOVQ  vs_base+0(FP), SI
+	MOVQ  vs_len+8(FP), CX
+	VPXOR Y0, Y0, Y0
+	VPXOR Y1, Y1, Y1
+	VPXOR Y2, Y2, Y2
+	VPXOR Y3, Y3, Y3
+
+sum16:
+	CMPQ   CX, $16
+	JL     sum4
+	VPADDQ (SI), Y0, Y0
+	VPADDQ 32(SI), Y1, Y1
+	VPADDQ 64(SI), Y2, Y2
+	VPADDQ 96(SI), Y3, Y3
+	ADDQ   $128, SI
+	SUBQ   $16, CX
+	JMP    sum16
+
+sum4:
+	CMPQ   CX, $4
+	JL     reduce
+	VPADDQ (SI), Y0, Y0
+	ADDQ   $32, SI
+	SUBQ   $4, CX
+	JMP    sum4
+
+reduce:
+	VPADDQ       Y1, Y0, Y0
+	VPADDQ       Y3, Y2, Y2
+	VPADDQ       Y2, Y0, Y0
+	VEXTRACTI128 $1, Y0, X1
+	VPADDQ       X1, X0, X0
+	VPSHUFD      $0x4e, X0, X1
+	VPADDQ       X1, X0, X0
+	VMOVQ        X0, AX
+
+tail:
+	CMPQ CX, $0
+	JE   done
+	ADDQ (SI), AX
+	ADDQ $8, SI
+	DECQ CX
+	JMP  tail
+
+done:
+	VZEROUPPER
+	MOVQ AX, ret+24(FP)
+	RET
+
+// The floating point minimum and maximum keep the accumulator as the second source
+// operand of VMINPD and VMAXPD, which is the result when either operand is NaN.
+// The accumulators start at +Inf or -Inf so that they never hold a NaN.
+
+// func minFloat64AVX2(vs []float64) float64
+TEXT ·minFloat64AVX2(SB), NOSPLIT, $0-32
+	MOVQ         vs_base+0(FP), SI
+	MOVQ         vs_len+8(FP), CX
+	MOVQ         $0x7ff0000000000000, AX
+	VMOVQ        AX, X0
+	VBROADCASTSD X0, Y0
+	VMOVAPD      Y0, Y1
+
+min8:
+	CMPQ    CX, $8
+	JL      min4
+	VMOVUPD (SI), Y2
+	VMOVUPD 32(SI), Y3
+	VMINPD  Y0, Y2, Y0
+	VMINPD  Y1, Y3, Y1
+	ADDQ    $64, SI
+	SUBQ    $8, CX
+	JMP     min8
+
+min4:
+	CMPQ    CX, $4
+	JL      reduce
+	VMOVUPD (SI), Y2
+	VMINPD  Y0, Y2, Y0
+	ADDQ    $32, SI
+	SUBQ    $4, CX
+	JMP     min4
+
+reduce:
+	VMINPD       Y1, Y0, Y0
+	VEXTRACTF128 $1, Y0, X1
+	VMINPD       X1, X0, X0
+	VPERMILPD    $1, X0, X1
+	VMINSD       X1, X0, X0
+
+tail:
+	CMPQ   CX, $0
+	JE     done
+	VMOVSD (SI), X1
+	VMINSD X0, X1, X0
+	ADDQ   $8, SI
+	DECQ   CX
+	JMP    tail
+
+done:
+	VZEROUPPER
+	MOVSD X0, ret+24(FP)
+	RET
+
+// func maxFloat64AVX2(vs []float64) float64
+TEXT ·maxFloat64AVX2(SB), NOSPLIT, $0-32
+	MOVQ         vs_base+0(FP), SI
+	MOVQ         vs_len+8(FP), CX
+	MOVQ         $0xfff0000000000000, AX
+	VMOVQ        AX, X0
+	VBROADCASTSD X0, Y0
+	VMOVAPD      Y0, Y1
+
+max8:
+	CMPQ    CX, $8
+	JL      max4
+	VMOVUPD (SI), Y2
+	VMOVUPD 32(SI), Y3
+	VMAXPD  Y0, Y2, Y0
+	VMAXPD  Y1, Y3, Y1
+	ADDQ    $64, SI
+	SUBQ    $8, CX
+	JMP     max8
+
+max4:
+	CMPQ    CX, $4
+	JL      reduce
+	VMOVUPD (SI), Y2
+	VMAXPD  Y0, Y2, Y0
+	ADDQ    $32, SI
+	SUBQ    $4, CX
+	JMP     max4
+
+reduce:
+	VMAXPD       Y1, Y0, Y0
+	VEXTRACTF128 $1, Y0, X1
+	VMAXPD       X1, X0, X0
+	VPERMILPD    $1, X0, X1
+	VMAXSD       X1, X0, X0
+
+tail:
+	CMPQ   CX, $0
+	JE     done
+	VMOVSD (SI), X1
+	VMAXSD X0, X1, X0
+	ADDQ   $8, SI
+	DECQ   CX
+	JMP    tail
+
+done:
+	VZEROUPPER
+	MOVSD X0, ret+24(FP)
+	RET
+
+// AVX2 has no minimum or maximum of 64-bit integers,
+// so the integer kernels blend the lanes selected by a comparison.
+
+// func minInt64AVX2(vs []int64) int64
+TEXT ·minInt64AVX2(SB), NOSPLIT, $0-32
+	MOVQ         vs_base+0(FP), SI
+	MOVQ         vs_len+8(FP), CX
+	MOVQ         $0x7fffffffffffffff, AX
+	VMOVQ        AX, X0
+	VPBROADCASTQ X0, Y0
+	VMOVDQA      Y0, Y1
+
+min8:
+	CMPQ      CX, $8
+	JL        min4
+	VMOVDQU   (SI), Y2
+	VMOVDQU   32(SI), Y3
+	VPCMPGTQ  Y2, Y0, Y4
+	VPCMPGTQ  Y3, Y1, Y5
+	VPBLENDVB Y4, Y2, Y0, Y0
+	VPBLENDVB Y5, Y3, Y1, Y1
+	ADDQ      $64, SI
+	SUBQ      $8, CX
+	JMP       min8
+
+min4:
+	CMPQ      CX, $4
+	JL        reduce
+	VMOVDQU   (SI), Y2
+	VPCMPGTQ  Y2, Y0, Y4
+	VPBLENDVB Y4, Y2, Y0, Y0
+	ADDQ      $32, SI
+	SUBQ      $4, CX
+	JMP       min4
+
+reduce:
+	VPCMPGTQ     Y1, Y0, Y4
+	VPBLENDVB    Y4, Y1, Y0, Y0
+	VEXTRACTI128 $1, Y0, X1
+	VPCMPGTQ     X1, X0, X4
+	VPBLENDVB    X4, X1, X0, X0
+	VPSHUFD      $0x4e, X0, X1
+	VPCMPGTQ     X1, X0, X4
+	VPBLENDVB    X4, X1, X0, X0
+	VMOVQ        X0, AX
+
+tail:
+	CMPQ    CX, $0
+	JE      done
+	MOVQ    (SI), DX
+	CMPQ    DX, AX
+	CMOVQLT DX, AX
+	ADDQ    $8, SI
+	DECQ    CX
+	JMP     tail
+
+done:
+	VZEROUPPER
+	MOVQ AX, ret+24(FP)
+	RET
+
+// func maxInt64AVX2(vs []int64) int64
+TEXT ·maxInt64AVX2(SB), NOSPLIT, $0-32
+	MOVQ         vs_base+0(FP), SI
+	MOVQ         vs_len+8(FP), CX
+	MOVQ         $0x8000000000000000, AX
+	VMOVQ        AX, X0
+	VPBROADCASTQ X0, Y0
+	VMOVDQA      Y0, Y1
+
+max8:
+	CMPQ      CX, $8
+	JL        max4
+	VMOVDQU   (SI), Y2
+	VMOVDQU   32(SI), Y3
+	VPCMPGTQ  Y0, Y2, Y4
+	VPCMPGTQ  Y1, Y3, Y5
+	VPBLENDVB Y4, Y2, Y0, Y0
+	VPBLENDVB Y5, Y3, Y1, Y1
+	ADDQ      $64, SI
+	SUBQ      $8, CX
+	JMP       max8
+
+max4:
+	CMPQ      CX, $4
+	JL        reduce
+	VMOVDQU   (SI), Y2
+	VPCMPGTQ  Y0, Y2, Y4
+	VPBLENDVB Y4, Y2, Y0, Y0
+	ADDQ      $32, SI
+	SUBQ      $4, CX
+	JMP       max4
+
+reduce:
+	VPCMPGTQ     Y0, Y1, Y4
+	VPBLENDVB    Y4, Y1, Y0, Y0
+	VEXTRACTI128 $1, Y0, X1
+	VPCMPGTQ     X0, X1, X4
+	VPBLENDVB    X4, X1, X0, X0
+	VPSHUFD      $0x4e, X0, X1
+	VPCMPGTQ     X0, X1, X4
+	VPBLENDVB    X4, X1, X0, X0
+	VMOVQ        X0, AX
+
+tail:
+	CMPQ    CX, $0
+	JE      done
+	MOVQ    (SI), DX
+	CMPQ    DX, AX
+	CMOVQGT DX, AX
+	ADDQ    $8, SI
+	DECQ    CX
+	JMP     tail
+
+done:
+	VZEROUPPER
+	MOVQ AX, ret+24(FP)
+	RET
@@ -0,0 +1,31 @@
+//go:build !noasm
+
+package kernels
+
+// NEON is part of the arm64 baseline, so the vector kernels are always selected.
+func init() {
+	sumFloat64 = sumFloat64NEON
+	sumInt64 = sumInt64NEON
+	minFloat64 = minFloat64NEON
+	maxFloat64 = maxFloat64NEON
+	minInt64 = minInt64NEON
+	maxInt64 = maxInt64NEON
+}
+
+//go:noescape
+func sumFloat64NEON(vs []float64) float64
+
+//go:noescape
+func sumInt64NEON(vs []int64) int64
+
+//go:noescape
+func minFloat64NEON(vs []float64) float64
+
+//go:noescape
+func maxFloat64NEON(vs []float64) float64
+
+//go:noescape
+func minInt64NEON(vs []int64) int64
+
+//go:noescape
+func maxInt64NEON(vs []int64) int64
@@ -0,0 +1,226 @@
+//go:build !noasm
+
+#include "textflag.h"
+
+// func sumFloat64NEON(vs []float64) float64
+TEXT ·sumFloat64NEON(SB), NOSPLIT, $0-32
+	MOVD vs_base+0(FP), R0
+	MOVD vs_len+8(FP), R1
+	VEOR V0.B16, V0.B16, V0.B16
+	VEOR V1.B16, V1.B16, V1.B16
+	VEOR V2.B16, V2.B16, V2.B16
+	VEOR V3.B16, V3.B16, V3.B16
+
+sum8:
+	CMP    $8, R1
+	BLT    reduce
+	VLD1.P 64(R0), [V4.D2, V5.D2, V6.D2, V7.D2]
+	VFADD  V4.D2, V0.D2, V0.D2
+	VFADD  V5.D2, V1.D2, V1.D2
+	VFADD  V6.D2, V2.D2, V2.D2
+	VFADD  V7.D2, V3.D2, V3.D2
+	SUB    $8, R1
+	B      sum8
+
+reduce:
+	VFADD  V1.D2, V0.D2, V0.D2
+	VFADD  V3.D2, V2.D2, V2.D2
+	VFADD  V2.D2, V0.D2, V0.D2
+	VFADDP V0.D2, V0.D2, V0.D2
+
+tail:
+	CBZ     R1, done
+	FMOVD.P 8(R0), F1
+	FADDD   F1, F0, F0
+	SUB     $1, R1
+	B       tail
+
+done:
+	FMOVD F0, ret+24(FP)
+	RET
+
+// func sumInt64NEON(vs []int64) int64
+TEXT ·sumInt64NEON(SB), NOSPLIT, $0-32
+	MOVD vs_base+0(FP), R0
+	MOVD vs_len+8(FP), R1
+	VEOR V0.B16, V0.B16, V0.B16
+	VEOR V1.B16, V1.B16, V1.B16
+	VEOR V2.B16, V2.B16, V2.B16
+	VEOR V3.B16, V3.B16, V3.B16
+
+sum8:
+	CMP    $8, R1
+	BLT    reduce
+	VLD1.P 64(R0), [V4.D2, V5.D2, V6.D2, V7.D2]
+	VADD   V4.D2, V0.D2, V0.D2
+	VADD   V5.D2, V1.D2, V1.D2
+	VADD   V6.D2, V2.D2, V2.D2
+	VADD   V7.D2, V3.D2, V3.D2
+	SUB    $8, R1
+	B      sum8
+
+reduce:
+	VADD  V1.D2, V0.D2, V0.D2
+	VADD  V3.D2, V2.D2, V2.D2
+	VADD  V2.D2, V0.D2, V0.D2
+	VADDP V0.D2, V0.D2, V0.D2
+	VMOV  V0.D[0], R2
+
+tail:
+	CBZ    R1, done
+	MOVD.P 8(R0), R3
+	ADD    R3, R2, R2
+	SUB    $1, R1
+	B      tail
+
+done:
+	MOVD R2, ret+24(FP)
+	RET
+
+// FMINNM and FMAXNM return the other operand when one of them is NaN,
+// so the accumulators, which start at +Inf or -Inf, never hold a NaN.
+
+// func minFloat64NEON(vs []float64) float64
+TEXT ·minFloat64NEON(SB), NOSPLIT, $0-32
+	MOVD vs_base+0(FP), R0
+	MOVD vs_len+8(FP), R1
+	MOVD $0x7ff0000000000000, R2
+	VDUP R2, V0.D2
+	VDUP R2, V1.D2
+
+min4:
+	CMP     $4, R1
+	BLT     reduce
+	VLD1.P  32(R0), [V4.D2, V5.D2]
+	VFMINNM V4.D2, V0.D2, V0.D2
+	VFMINNM V5.D2, V1.D2, V1.D2
+	SUB     $4, R1
+	B       min4
+
+reduce:
+	VFMINNM  V1.D2, V0.D2, V0.D2
+	VFMINNMP V0.D2, V0.D2, V0.D2
+
+tail:
+	CBZ     R1, done
+	FMOVD.P 8(R0), F1
+	FMINNMD F1, F0, F0
+	SUB     $1, R1
+	B       tail
+
+done:
+	FMOVD F0, ret+24(FP)
+	RET
+
+// func maxFloat64NEON(vs []float64) float64
+TEXT ·maxFloat64NEON(SB), NOSPLIT, $0-32
+	MOVD vs_base+0(FP), R0
+	MOVD vs_len+8(FP), R1
+	MOVD $0xfff0000000000000, R2
+	VDUP R2, V0.D2
+	VDUP R2, V1.D2
+
+max4:
+	CMP     $4, R1
+	BLT     reduce
+	VLD1.P  32(R0), [V4.D2, V5.D2]
+	VFMAXNM V4.D2, V0.D2, V0.D2
+	VFMAXNM V5.D2, V1.D2, V1.D2
+	SUB     $4, R1
+	B       max4
+
+reduce:
+	VFMAXNM  V1.D2, V0.D2, V0.D2
+	VFMAXNMP V0.D2, V0.D2, V0.D2
+
+tail:
+	CBZ     R1, done
+	FMOVD.P 8(R0), F1
+	FMAXNMD F1, F0, F0
+	SUB     $1, R1
+	B       tail
+
+done:
+	FMOVD F0, ret+24(FP)
+	RET
+
+// NEON has no minimum or maximum of 64-bit integers,
+// so the integer kernels insert the lanes selected by a comparison.
+
+// func minInt64NEON(vs []int64) int64
+TEXT ·minInt64NEON(SB), NOSPLIT, $0-32
+	MOVD vs_base+0(FP), R0
+	MOVD vs_len+8(FP), R1
+	MOVD $0x7fffffffffffffff, R2
+	VDUP R2, V0.D2
+	VDUP R2, V1.D2
+
+min4:
+	CMP    $4, R1
+	BLT    reduce
+	VLD1.P 32(R0), [V4.D2, V5.D2]
+	VCMGT  V4.D2, V0.D2, V6.D2
+	VCMGT  V5.D2, V1.D2, V7.D2
+	VBIT   V6.B16, V4.B16, V0.B16
+	VBIT   V7.B16, V5.B16, V1.B16
+	SUB    $4, R1
+	B      min4
+
+reduce:
+	VCMGT V1.D2, V0.D2, V6.D2
+	VBIT  V6.B16, V1.B16, V0.B16
+	VMOV  V0.D[0], R2
+	VMOV  V0.D[1], R3
+	CMP   R2, R3
+	CSEL  LT, R3, R2, R2
+
+tail:
+	CBZ    R1, done
+	MOVD.P 8(R0), R3
+	CMP    R2, R3
+	CSEL   LT, R3, R2, R2
+	SUB    $1, R1
+	B      tail
+
+done:
+	MOVD R2, ret+24(FP)
+	RET
+
+// func maxInt64NEON(vs []int64) int64
+TEXT ·maxInt64NEON(SB), NOSPLIT, $0-32
+	MOVD vs_base+0(FP), R0
+	MOVD vs_len+8(FP), R1
+	MOVD $0x8000000000000000, R2
+	VDUP R2, V0.D2
+	VDUP R2, V1.D2
+
+max4:
+	CMP    $4, R1
+	BLT    reduce
+	VLD1.P 32(R0), [V4.D2, V5.D2]
+	VCMGT  V0.D2, V4.D2, V6.D2
+	VCMGT  V1.D2, V5.D2, V7.D2
+	VBIT   V6.B16, V4.B16, V0.B16
+	VBIT   V7.B16, V5.B16, V1.B16
+	SUB    $4, R1
+	B      max4
+
+reduce:
+	VCMGT V0.D2, V1.D2, V6.D2
+	VBIT  V6.B16, V1.B16, V0.B16
+	VMOV  V0.D[0], R2
+	VMOV  V0.D[1], R3
+	CMP   R2, R3
+	CSEL  GT, R3, R2, R2
+
+tail:
+	CBZ    R1, done
+	MOVD.P 8(R0), R3
+	CMP    R2, R3
+	CSEL   GT, R3, R2, R2
+	SUB    $1, R1
+	B      tail
+
+done:
+	MOVD R2, ret+24(FP)
+	RET
@@ -0,0 +1,63 @@
+package kernels
+
+import "math"
+
+func sumFloat64Go(vs []float64) float64 {
+	var sum float64
+	for _, v := range vs {
+		sum += v
+	}
+	return sum
+}
+
+func sumInt64Go(vs []int64) int64 {
+	var sum int64
+	for _, v := range vs {
+		sum += v
+	}
+	return sum
+}
+
+// minFloat64Go returns the smallest value of vs other than NaN,
+// or +Inf if there is none, like the vector implementations.
+func minFloat64Go(vs []float64) float64 {
+	min := math.Inf(1)
+	for _, v := range vs {
+		if v < min {
+			min = v
+		}
+	}
+	return min
+}
+
+// maxFloat64Go returns the largest value of vs other than NaN,
+// or -Inf if there is none, like the vector implementations.
+func maxFloat64Go(vs []float64) float64 {
+	max := math.Inf(-1)
+	for _, v := range vs {
+		if v > max {
+			max = v
+		}
+	}
+	return max
+}
+
+func minInt64Go(vs []int64) int64 {
+	min := int64(math.MaxInt64)
+	for _, v := range vs {
+		if v < min {
+			min = v
+		}
+	}
+	return min
+}
+
+func maxInt64Go(vs []int64) int64 {
+	max := int64(math.MinInt64)
+	for _, v := range vs {
+		if v > max {
+			max = v
+		}
+	}
+	return max
+}
@@ -0,0 +1,163 @@
+package kernels_test
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/influxdata/flux/arrow/internal/kernels"
+)
+
+// lengths exercise the unrolled loops, the vector loops and the scalar tails.
+var lengths = []int{0, 1, 3, 4, 7, 8, 15, 16, 17, 33, 100, 1023}
+
+func floats(r *rand.Rand, n int) []float64 {
+	vs := make([]float64, n)
+	for i := range vs {
+		vs[i] = math.Round(r.NormFloat64()*1000) / 8
+	}
+	return vs
+}
+
+func ints(r *rand.Rand, n int) []int64 {
+	vs := make([]int64, n)
+	for i := range vs {
+		vs[i] = r.Int63() - math.MaxInt64/2
+	}
+	return vs
+}
+
+func minIndex(vs []float64, less func(a, b float64) bool) int {
+	idx := -1
+	for i, v := range vs {
+		if math.IsNaN(v) {
+			continue
+		}
+		if idx < 0 || less(v, vs[idx]) {
+			idx = i
+		}
+	}
+	return idx
+}
+
+func TestSumFloat64(t *testing.T) {
+	r := rand.New(rand.NewSource(0))
+	for _, n := range lengths {
+		// The values are multiples of 1/8 small enough to be added exactly in any order.
+		vs := floats(r, n)
+		var want float64
+		for _, v := range vs {
+			want += v
+		}
+		if got := kernels.SumFloat64(vs); got != want {
+			t.Errorf("unexpected sum of %d values: want %v, got %v", n, want, got)
+		}
+	}
+}
+
+func TestSumInt64(t *testing.T) {
+	r := rand.New(rand.NewSource(0))
+	for _, n := range lengths {
+		vs := ints(r, n)
+		var want int64
+		for _, v := range vs {
+			want += v
+		}
+		if got := kernels.SumInt64(vs); got != want {
+			t.Errorf("unexpected sum of %d values: want %v, got %v", n, want, got)
+		}
+	}
+}
+
+func TestMinMaxFloat64(t *testing.T) {
+	r := rand.New(rand.NewSource(0))
+	for _, n := range lengths {
+		vs := floats(r, n)
+		for i := range vs {
+			switch r.Intn(10) {
+			case 0:
+				vs[i] = math.NaN()
+			case 1:
+				// Repeated values select their first occurrence.
+				vs[i] = vs[r.Intn(i+1)]
+			}
+		}
+		if want, got := minIndex(vs, func(a, b float64) bool { return a < b }), kernels.MinFloat64(vs); want != got {
+			t.Errorf("unexpected min index of %v: want %d, got %d", vs, want, got)
+		}
+		if want, got := minIndex(vs, func(a, b float64) bool { return a > b }), kernels.MaxFloat64(vs); want != got {
+			t.Errorf("unexpected max index of %v: want %d, got %d", vs, want, got)
+		}
+	}
+
+	for _, vs := range [][]float64{
+		{math.NaN()},
+		{math.NaN(), math.NaN(), math.NaN(), math.NaN(), math.NaN()},
+	} {
+		if got := kernels.MinFloat64(vs); got != -1 {
+			t.Errorf("unexpected min index of %v: want -1, got %d", vs, got)
+		}
+		if got := kernels.MaxFloat64(vs); got != -1 {
+			t.Errorf("unexpected max index of %v: want -1, got %d", vs, got)
+		}
+	}
+
+	inf := []float64{math.NaN(), math.Inf(1), math.NaN(), math.Inf(-1), 0, 0, 0, 0, 0}
+	if got := kernels.MinFloat64(inf); got != 3 {
+		t.Errorf("unexpected min index of %v: want 3, got %d", inf, got)
+	}
+	if got := kernels.MaxFloat64(inf); got != 1 {
+		t.Errorf("unexpected max index of %v: want 1, got %d", inf, got)
+	}
+}
+
+func TestMinMaxInt64(t *testing.T) {
+	r := rand.New(rand.NewSource(0))
+	for _, n := range lengths {
+		vs := ints(r, n)
+		for i := range vs {
+			if r.Intn(10) == 0 {
+				vs[i] = vs[r.Intn(i+1)]
+			}
+		}
+		wantMin, wantMax := -1, -1
+		for i, v := range vs {
+			if wantMin < 0 || v < vs[wantMin] {
+				wantMin = i
+			}
+			if wantMax < 0 || v > vs[wantMax] {
+				wantMax = i
+			}
+		}
+		if got := kernels.MinInt64(vs); got != wantMin {
+			t.Errorf("unexpected min index of %d values: want %d, got %d", n, wantMin, got)
+		}
+		if got := kernels.MaxInt64(vs); got != wantMax {
+			t.Errorf("unexpected max index of %d values: want %d, got %d", n, wantMax, got)
+		}
+	}
+
+	extremes := []int64{0, math.MaxInt64, 1, math.MinInt64, -1, math.MinInt64, math.MaxInt64, 0}
+	if got := kernels.MinInt64(extremes); got != 3 {
+		t.Errorf("unexpected min index of %v: want 3, got %d", extremes, got)
+	}
+	if got := kernels.MaxInt64(extremes); got != 1 {
+		t.Errorf("unexpected max index of %v: want 1, got %d", extremes, got)
+	}
+}
+
+func BenchmarkSumFloat64(b *testing.B) {
+	vs := floats(rand.New(rand.NewSource(0)), 1000)
+	b.SetBytes(int64(len(vs) * 8))
+	for i := 0; i < b.N; i++ {
+		kernels.SumFloat64(vs)
+	}
+}
+
+func BenchmarkMinInt64(b *testing.B) {
+	vs := ints(rand.New(rand.NewSource(0)), 1000)
+	b.SetBytes(int64(len(vs) * 8))
+	for i := 0; i < b.N; i++ {
+		kernels.MinInt64(vs)
+	}
+}
@@ -7,6 +7,8 @@ import (
 	"regexp"
 	"strconv"
 	"time"
+
+	"github.com/influxdata/flux/errors"
 )
 
 // Position represents a specific location in the source
@@ -155,6 +157,11 @@ func (e Error) Error() string {
 	return e.Msg
 }
 
+// Code implements errors.Coder, a script that does not parse is not valid.
+func (e Error) Code() errors.Code {
+	return errors.Invalid
+}
+
 // Package represents a complete package source tree
 type Package struct {
 	BaseNode
@@ -1235,7 +1242,25 @@ func DurationFrom(l *DurationLiteral, _ time.Time) (time.Duration, error) {
 	return d, nil
 }
 
-// TODO: we need a "duration from" that takes a time and a durationliteral, and gives an exact time.Duration instead of an approximation
+// DurationParts splits a DurationLiteral into its months, from the y and mo units,
+// and its nanoseconds, from all the other units. Unlike DurationFrom it is exact.
+func DurationParts(l *DurationLiteral) (months int64, nsecs time.Duration, err error) {
+	for _, d := range l.Values {
+		switch d.Unit {
+		case "y":
+			months += d.Magnitude * 12
+		case "mo":
+			months += d.Magnitude
+		default:
+			ns, err := toDuration(d)
+			if err != nil {
+				return 0, 0, err
+			}
+			nsecs += ns
+		}
+	}
+	return months, nsecs, nil
+}
 
 // DateTimeLiteral represents an instant in time with nanosecond precision using
 // the syntax of golang's RFC3339 Nanosecond variant
@@ -0,0 +1,295 @@
+// Package astutil provides functions to rewrite Flux ASTs.
+//
+// Apply and its Cursor follow the API of the astutil package of the Go tools,
+// which rewrites Go ASTs in the same way.
+package astutil
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/influxdata/flux/ast"
+)
+
+// An ApplyFunc is invoked by Apply for each non nil node n,
+// before and/or after the node's children, using a Cursor describing
+// the current node and providing operations on it.
+//
+// The return value of ApplyFunc controls the syntax tree traversal.
+// See Apply for details.
+type ApplyFunc func(*Cursor) bool
+
+// Apply traverses a syntax tree recursively, starting with root,
+// and calling pre and post for each node as described below.
+// Apply returns the syntax tree, possibly modified.
+//
+// If pre is not nil, it is called for each node before the node's
+// children are traversed (pre-order). If pre returns false, no
+// children are traversed, and post is not called for that node.
+//
+// If post is not nil, and a prior call of pre didn't return false,
+// post is called for each node after its children are traversed
+// (post-order). If post returns false, traversal is terminated and
+// Apply returns immediately.
+//
+// Only fields that refer to AST nodes are considered children,
+// and nil nodes are not traversed.
+// Children are traversed in the same order as ast.Walk traverses them.
+//
+// The nodes may be modified through the Cursor; modifications of the
+// children of the current node are seen by the rest of the traversal,
+// while a replacement node is not traversed.
+func Apply(root ast.Node, pre, post ApplyFunc) (result ast.Node) {
+	parent := &struct{ ast.Node }{root}
+	defer func() {
+		if r := recover(); r != nil && r != abort {
+			panic(r)
+		}
+		result = parent.Node
+	}()
+	a := &application{pre: pre, post: post}
+	a.apply(parent, "Node", nil, root)
+	return
+}
+
+var abort = new(int) // singleton, to signal termination of Apply
+
+// A Cursor describes a node encountered during Apply.
+// Information about the node and its parent is available
+// from the Node, Parent, Name, and Index methods.
+type Cursor struct {
+	parent ast.Node
+	name   string
+	iter   *iterator // valid if non-nil
+	node   ast.Node
+}
+
+// Node returns the current Node.
+func (c *Cursor) Node() ast.Node { return c.node }
+
+// Parent returns the parent of the current Node.
+func (c *Cursor) Parent() ast.Node { return c.parent }
+
+// Name returns the name of the parent Node field that contains the current Node.
+// If the parent is a *ast.Package and the current Node is a *ast.File, Name returns
+// "Files".
+func (c *Cursor) Name() string { return c.name }
+
+// Index reports the index >= 0 of the current Node in the slice of Nodes that
+// contains it, or a value < 0 if the current Node is not part of a slice.
+// The index of the current node changes if InsertBefore is called while
+// processing the current node.
+func (c *Cursor) Index() int {
+	if c.iter != nil {
+		return c.iter.index
+	}
+	return -1
+}
+
+// field returns the current node's parent field value.
+func (c *Cursor) field() reflect.Value {
+	return reflect.Indirect(reflect.ValueOf(c.parent)).FieldByName(c.name)
+}
+
+// Replace replaces the current Node with n.
+// The replacement node is not walked by Apply.
+// Replace panics if n cannot be assigned to the field of the parent that contains the current Node.
+func (c *Cursor) Replace(n ast.Node) {
+	v := c.field()
+	if i := c.Index(); i >= 0 {
+		v = v.Index(i)
+	}
+	v.Set(reflect.ValueOf(n))
+	c.node = n
+}
+
+// Delete deletes the current Node from its containing slice.
+// If the current Node is not part of a slice, Delete panics.
+func (c *Cursor) Delete() {
+	i := c.Index()
+	if i < 0 {
+		panic("Delete node not contained in slice")
+	}
+	v := c.field()
+	l := v.Len()
+	reflect.Copy(v.Slice(i, l), v.Slice(i+1, l))
+	v.Index(l - 1).Set(reflect.Zero(v.Type().Elem()))
+	v.SetLen(l - 1)
+	c.iter.step--
+}
+
+// InsertAfter inserts n after the current Node in its containing slice.
+// If the current Node is not part of a slice, InsertAfter panics.
+// Apply does not walk n.
+func (c *Cursor) InsertAfter(n ast.Node) {
+	i := c.Index()
+	if i < 0 {
+		panic("InsertAfter node not contained in slice")
+	}
+	v := c.field()
+	v.Set(reflect.Append(v, reflect.Zero(v.Type().Elem())))
+	l := v.Len()
+	reflect.Copy(v.Slice(i+2, l), v.Slice(i+1, l))
+	v.Index(i + 1).Set(reflect.ValueOf(n))
+	c.iter.step++
+}
+
+// InsertBefore inserts n before the current Node in its containing slice.
+// If the current Node is not part of a slice, InsertBefore panics.
+// Apply will not walk n.
+func (c *Cursor) InsertBefore(n ast.Node) {
+	i := c.Index()
+	if i < 0 {
+		panic("InsertBefore node not contained in slice")
+	}
+	v := c.field()
+	v.Set(reflect.Append(v, reflect.Zero(v.Type().Elem())))
+	l := v.Len()
+	reflect.Copy(v.Slice(i+1, l), v.Slice(i, l))
+	v.Index(i).Set(reflect.ValueOf(n))
+	c.iter.index++
+}
+
+// application carries all the shared data so we can pass it around cheaply.
+type application struct {
+	pre, post ApplyFunc
+	cursor    Cursor
+	iter      iterator
+}
+
+func (a *application) apply(parent ast.Node, name string, iter *iterator, n ast.Node) {
+	// convert typed nil into untyped nil
+	if v := reflect.ValueOf(n); v.Kind() == reflect.Ptr && v.IsNil() {
+		n = nil
+	}
+	if n == nil {
+		return
+	}
+
+	// avoid heap-allocating a new cursor for each apply call; reuse a.cursor instead
+	saved := a.cursor
+	a.cursor.parent = parent
+	a.cursor.name = name
+	a.cursor.iter = iter
+	a.cursor.node = n
+
+	if a.pre != nil && !a.pre(&a.cursor) {
+		a.cursor = saved
+		return
+	}
+
+	// walk children
+	// (the order of the cases matches the order of the corresponding node types in ast/walk.go)
+	switch n := n.(type) {
+	case *ast.Package:
+		a.applyList(n, "Files")
+	case *ast.File:
+		a.apply(n, "Package", nil, n.Package)
+		a.applyList(n, "Imports")
+		a.applyList(n, "Body")
+	case *ast.PackageClause:
+		a.apply(n, "Name", nil, n.Name)
+	case *ast.ImportDeclaration:
+		a.apply(n, "As", nil, n.As)
+		a.apply(n, "Path", nil, n.Path)
+	case *ast.BadStatement:
+		// nothing to do
+	case *ast.Block:
+		a.applyList(n, "Body")
+	case *ast.OptionStatement:
+		a.apply(n, "Assignment", nil, n.Assignment)
+	case *ast.BuiltinStatement:
+		a.apply(n, "ID", nil, n.ID)
+	case *ast.TestStatement:
+		a.apply(n, "Assignment", nil, n.Assignment)
+	case *ast.ExpressionStatement:
+		a.apply(n, "Expression", nil, n.Expression)
+	case *ast.ReturnStatement:
+		a.apply(n, "Argument", nil, n.Argument)
+	case *ast.VariableAssignment:
+		a.apply(n, "ID", nil, n.ID)
+		a.apply(n, "Init", nil, n.Init)
+	case *ast.MemberAssignment:
+		a.apply(n, "Member", nil, n.Member)
+		a.apply(n, "Init", nil, n.Init)
+	case *ast.CallExpression:
+		a.apply(n, "Callee", nil, n.Callee)
+		a.applyList(n, "Arguments")
+	case *ast.PipeExpression:
+		a.apply(n, "Argument", nil, n.Argument)
+		a.apply(n, "Call", nil, n.Call)
+	case *ast.MemberExpression:
+		a.apply(n, "Object", nil, n.Object)
+		a.apply(n, "Property", nil, n.Property)
+	case *ast.IndexExpression:
+		a.apply(n, "Array", nil, n.Array)
+		a.apply(n, "Index", nil, n.Index)
+	case *ast.BinaryExpression:
+		a.apply(n, "Left", nil, n.Left)
+		a.apply(n, "Right", nil, n.Right)
+	case *ast.UnaryExpression:
+		a.apply(n, "Argument", nil, n.Argument)
+	case *ast.LogicalExpression:
+		a.apply(n, "Left", nil, n.Left)
+		a.apply(n, "Right", nil, n.Right)
+	case *ast.ObjectExpression:
+		a.applyList(n, "Properties")
+	case *ast.ConditionalExpression:
+		a.apply(n, "Test", nil, n.Test)
+		a.apply(n, "Alternate", nil, n.Alternate)
+		a.apply(n, "Consequent", nil, n.Consequent)
+	case *ast.ArrayExpression:
+		a.applyList(n, "Elements")
+	case *ast.FunctionExpression:
+		a.applyList(n, "Params")
+		a.apply(n, "Body", nil, n.Body)
+	case *ast.Property:
+		a.apply(n, "Key", nil, n.Key)
+		a.apply(n, "Value", nil, n.Value)
+	case *ast.Identifier,
+		*ast.PipeLiteral,
+		*ast.StringLiteral,
+		*ast.BooleanLiteral,
+		*ast.FloatLiteral,
+		*ast.IntegerLiteral,
+		*ast.UnsignedIntegerLiteral,
+		*ast.RegexpLiteral,
+		*ast.DurationLiteral,
+		*ast.DateTimeLiteral:
+		// nothing to do
+	default:
+		panic(fmt.Errorf("Apply not defined for node %T", n))
+	}
+
+	if a.post != nil && !a.post(&a.cursor) {
+		panic(abort)
+	}
+
+	a.cursor = saved
+}
+
+// An iterator controls iteration over a slice of nodes.
+type iterator struct {
+	index, step int
+}
+
+func (a *application) applyList(parent ast.Node, name string) {
+	// avoid heap-allocating a new iterator for each applyList call; reuse a.iter instead
+	saved := a.iter
+	a.iter.index = 0
+	for {
+		// must reload parent.name each time, since cursor modifications might change it
+		v := reflect.Indirect(reflect.ValueOf(parent)).FieldByName(name)
+		if a.iter.index >= v.Len() {
+			break
+		}
+
+		// element x may be nil in a bad AST - be cautious
+		x, _ := v.Index(a.iter.index).Interface().(ast.Node)
+
+		a.iter.step = 1
+		a.apply(parent, name, &a.iter, x)
+		a.iter.index += a.iter.step
+	}
+	a.iter = saved
+}
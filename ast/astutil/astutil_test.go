@@ -0,0 +1,189 @@
+package astutil_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/flux/ast"
+	"github.com/influxdata/flux/ast/astutil"
+	"github.com/influxdata/flux/parser"
+)
+
+func parse(t *testing.T, script string) *ast.File {
+	t.Helper()
+	pkg := parser.ParseSource(script)
+	if ast.Check(pkg) > 0 {
+		t.Fatal(ast.GetError(pkg))
+	}
+	return pkg.Files[0]
+}
+
+func TestApply(t *testing.T) {
+	testCases := []struct {
+		name   string
+		script string
+		pre    astutil.ApplyFunc
+		post   astutil.ApplyFunc
+		want   string
+	}{
+		{
+			name:   "replace",
+			script: `from(bucket: "a") |> range(start: -1h)`,
+			pre: func(c *astutil.Cursor) bool {
+				if lit, ok := c.Node().(*ast.StringLiteral); ok && lit.Value == "a" {
+					c.Replace(&ast.StringLiteral{Value: "b"})
+				}
+				return true
+			},
+			want: `from(bucket: "b")
+	|> range(start: -1h)`,
+		},
+		{
+			name: "delete statement",
+			script: `a = 1
+b = 2
+c = 3`,
+			pre: func(c *astutil.Cursor) bool {
+				if va, ok := c.Node().(*ast.VariableAssignment); ok && va.ID.Name == "b" {
+					c.Delete()
+				}
+				return true
+			},
+			want: `a = 1
+c = 3`,
+		},
+		{
+			name: "insert statements",
+			script: `a = 1
+c = 3`,
+			pre: func(c *astutil.Cursor) bool {
+				if va, ok := c.Node().(*ast.VariableAssignment); ok && va.ID.Name == "c" {
+					c.InsertBefore(&ast.VariableAssignment{
+						ID:   &ast.Identifier{Name: "b"},
+						Init: &ast.IntegerLiteral{Value: 2},
+					})
+					c.InsertAfter(&ast.VariableAssignment{
+						ID:   &ast.Identifier{Name: "d"},
+						Init: &ast.IntegerLiteral{Value: 4},
+					})
+				}
+				return true
+			},
+			want: `a = 1
+b = 2
+c = 3
+d = 4`,
+		},
+		{
+			name:   "skip children",
+			script: `f = (r) => r.a + r.b`,
+			pre: func(c *astutil.Cursor) bool {
+				if _, ok := c.Node().(*ast.FunctionExpression); ok {
+					return false
+				}
+				if id, ok := c.Node().(*ast.Identifier); ok {
+					c.Replace(&ast.Identifier{Name: id.Name + "2"})
+				}
+				return true
+			},
+			want: `f2 = (r) =>
+	(r.a + r.b)`,
+		},
+		{
+			name: "abort in post",
+			script: `a = 1
+b = 2`,
+			post: func(c *astutil.Cursor) bool {
+				if lit, ok := c.Node().(*ast.IntegerLiteral); ok {
+					c.Replace(&ast.IntegerLiteral{Value: lit.Value * 10})
+					return false
+				}
+				return true
+			},
+			want: `a = 10
+b = 2`,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			got := ast.Format(astutil.Apply(parse(t, tc.script), tc.pre, tc.post))
+			if !cmp.Equal(tc.want, got) {
+				t.Errorf("unexpected script -want/+got\n%s", cmp.Diff(tc.want, got))
+			}
+		})
+	}
+}
+
+func TestApply_Cursor(t *testing.T) {
+	file := parse(t, `from(bucket: "a") |> range(start: -1h)`)
+	var names []string
+	astutil.Apply(file, func(c *astutil.Cursor) bool {
+		if _, ok := c.Node().(*ast.CallExpression); ok {
+			if _, ok := c.Parent().(*ast.PipeExpression); !ok {
+				t.Errorf("unexpected parent %T", c.Parent())
+			}
+			names = append(names, c.Name())
+		}
+		if _, ok := c.Node().(*ast.ExpressionStatement); ok && c.Index() != 0 {
+			t.Errorf("unexpected index %d", c.Index())
+		}
+		return true
+	}, nil)
+	if want := []string{"Argument", "Call"}; !cmp.Equal(want, names) {
+		t.Errorf("unexpected names -want/+got\n%s", cmp.Diff(want, names))
+	}
+}
+
+func TestRewrite(t *testing.T) {
+	file := parse(t, `x = 1 + 2 * 3`)
+	got := ast.Format(astutil.Rewrite(file, func(n ast.Node) ast.Node {
+		if lit, ok := n.(*ast.IntegerLiteral); ok {
+			return &ast.FloatLiteral{Value: float64(lit.Value)}
+		}
+		return n
+	}))
+	if want := `x = 1.0 + 2.0 * 3.0`; want != got {
+		t.Errorf("unexpected script -want/+got\n%s", cmp.Diff(want, got))
+	}
+}
+
+func TestPipeline(t *testing.T) {
+	stmt := parse(t, `from(bucket: "a") |> filter(fn: (r) => r._value > 0) |> mean()`).Body[0].(*ast.ExpressionStatement)
+	p := astutil.Split(stmt.Expression)
+	if got := ast.Format(p.Source); got != `from(bucket: "a")` {
+		t.Errorf("unexpected source %s", got)
+	}
+	if got := p.Index("mean"); got != 1 {
+		t.Errorf("unexpected index of mean %d", got)
+	}
+	if got := p.Index("max"); got != -1 {
+		t.Errorf("unexpected index of max %d", got)
+	}
+
+	if err := p.Insert(0, astutil.Call("range", astutil.Property("start", &ast.DurationLiteral{
+		Values: []ast.Duration{{Magnitude: -1, Unit: "h"}},
+	}))); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Replace(p.Index("mean"), astutil.Call("max")); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Insert(len(p.Stages), astutil.Call("strings.toUpper")); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Remove(p.Index("filter")); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Remove(len(p.Stages)); err == nil {
+		t.Error("expected an error removing a stage out of bounds")
+	}
+
+	want := `from(bucket: "a")
+	|> range(start: -1h)
+	|> max()
+	|> strings.toUpper()`
+	if got := ast.Format(p.Expression()); want != got {
+		t.Errorf("unexpected pipeline -want/+got\n%s", cmp.Diff(want, got))
+	}
+}
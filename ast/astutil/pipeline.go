@@ -0,0 +1,139 @@
+package astutil
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/influxdata/flux/ast"
+)
+
+// Rewrite calls f for each node of the tree rooted at root, children first,
+// and replaces every node with the node that f returns.
+// Rewrite returns the rewritten root.
+func Rewrite(root ast.Node, f func(ast.Node) ast.Node) ast.Node {
+	return Apply(root, nil, func(c *Cursor) bool {
+		if n := f(c.Node()); n != c.Node() {
+			c.Replace(n)
+		}
+		return true
+	})
+}
+
+// Pipeline is an expression split into the stages of a chain of pipe expressions.
+// The expression `from(bucket: "b") |> range(start: -1h) |> mean()` has the call to from as its source,
+// and the calls to range and mean as its stages.
+type Pipeline struct {
+	Source ast.Expression
+	Stages []*ast.CallExpression
+}
+
+// Split splits an expression into a pipeline.
+// An expression that is not a pipe expression is the source of a pipeline without stages.
+func Split(e ast.Expression) *Pipeline {
+	p := new(Pipeline)
+	for {
+		pipe, ok := e.(*ast.PipeExpression)
+		if !ok {
+			break
+		}
+		p.Stages = append(p.Stages, pipe.Call)
+		e = pipe.Argument
+	}
+	p.Source = e
+	for i, j := 0, len(p.Stages)-1; i < j; i, j = i+1, j-1 {
+		p.Stages[i], p.Stages[j] = p.Stages[j], p.Stages[i]
+	}
+	return p
+}
+
+// Expression joins the source and the stages of the pipeline with pipe expressions.
+func (p *Pipeline) Expression() ast.Expression {
+	e := p.Source
+	for _, call := range p.Stages {
+		e = &ast.PipeExpression{
+			Argument: e,
+			Call:     call,
+		}
+	}
+	return e
+}
+
+// Index returns the index of the first stage that calls the function with the given name,
+// or -1 if no stage calls it.
+// The name of a function of a package is the name of its member, for example "toUpper" for strings.toUpper.
+func (p *Pipeline) Index(name string) int {
+	for i, call := range p.Stages {
+		if FunctionName(call) == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// Insert inserts a stage at index i, so that it receives the output of the stage before it.
+// Inserting at index 0 pipes the source into the stage, and inserting at len(p.Stages) appends the stage.
+func (p *Pipeline) Insert(i int, call *ast.CallExpression) error {
+	if i < 0 || i > len(p.Stages) {
+		return fmt.Errorf("cannot insert stage at index %d of a pipeline of %d stages", i, len(p.Stages))
+	}
+	p.Stages = append(p.Stages, nil)
+	copy(p.Stages[i+1:], p.Stages[i:])
+	p.Stages[i] = call
+	return nil
+}
+
+// Replace replaces the stage at index i.
+func (p *Pipeline) Replace(i int, call *ast.CallExpression) error {
+	if i < 0 || i >= len(p.Stages) {
+		return fmt.Errorf("cannot replace stage at index %d of a pipeline of %d stages", i, len(p.Stages))
+	}
+	p.Stages[i] = call
+	return nil
+}
+
+// Remove removes the stage at index i.
+func (p *Pipeline) Remove(i int) error {
+	if i < 0 || i >= len(p.Stages) {
+		return fmt.Errorf("cannot remove stage at index %d of a pipeline of %d stages", i, len(p.Stages))
+	}
+	p.Stages = append(p.Stages[:i], p.Stages[i+1:]...)
+	return nil
+}
+
+// FunctionName returns the name of the function called by call.
+// The name of a function of a package is the name of its member.
+// It returns an empty string for calls to anonymous functions.
+func FunctionName(call *ast.CallExpression) string {
+	switch callee := call.Callee.(type) {
+	case *ast.Identifier:
+		return callee.Name
+	case *ast.MemberExpression:
+		return callee.Property.Key()
+	}
+	return ""
+}
+
+// Call creates a call to the function with the given name, passing the properties as arguments.
+// A name with a dot, such as "strings.toUpper", calls a function of a package.
+func Call(name string, properties ...*ast.Property) *ast.CallExpression {
+	var callee ast.Expression = &ast.Identifier{Name: name}
+	if i := strings.LastIndex(name, "."); i > 0 {
+		callee = &ast.MemberExpression{
+			Object:   &ast.Identifier{Name: name[:i]},
+			Property: &ast.Identifier{Name: name[i+1:]},
+		}
+	}
+	call := &ast.CallExpression{Callee: callee}
+	if len(properties) > 0 {
+		call.Arguments = []ast.Expression{&ast.ObjectExpression{Properties: properties}}
+	}
+	return call
+}
+
+// Property creates a property of a record with the given key.
+func Property(key string, value ast.Expression) *ast.Property {
+	return &ast.Property{
+		Key:   &ast.Identifier{Name: key},
+		Value: value,
+	}
+}
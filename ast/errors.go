@@ -28,8 +28,8 @@ func check(n Node) int {
 		})
 		return len(n.Errors)
 	}
-
-	return 0
+	// Errors reported by the parser are already attached to the node.
+	return len(n.Errs())
 }
 
 // GetError will return the first error within an AST.
@@ -253,23 +253,63 @@ func (f *formatter) formatFunctionExpression(n *FunctionExpression) {
 
 func (f *formatter) formatUnaryExpression(n *UnaryExpression) {
 	f.writeString(n.Operator.String())
-	f.formatNode(n.Argument)
+	if n.Operator == NotOperator {
+		f.writeRune(' ')
+	}
+	f.formatChildWithParens(n, n.Argument, false)
 }
 
 func (f *formatter) formatBinaryExpression(n *BinaryExpression) {
-	f.formatNode(n.Left)
+	f.formatChildWithParens(n, n.Left, false)
 	f.writeRune(' ')
 	f.writeString(n.Operator.String())
 	f.writeRune(' ')
-	f.formatNode(n.Right)
+	f.formatChildWithParens(n, n.Right, true)
 }
 
 func (f *formatter) formatLogicalExpression(n *LogicalExpression) {
-	f.formatNode(n.Left)
+	f.formatChildWithParens(n, n.Left, false)
 	f.writeRune(' ')
 	f.writeString(n.Operator.String())
 	f.writeRune(' ')
-	f.formatNode(n.Right)
+	f.formatChildWithParens(n, n.Right, true)
+}
+
+// formatChildWithParens formats an operand of an operator, wrapped in parenthesis
+// when the operator binds tighter than the operand, since the AST has no node for parenthesis.
+// The operators are left associative, so a right operand of the same precedence needs parenthesis too.
+func (f *formatter) formatChildWithParens(parent, child Node, right bool) {
+	pp, cp := precedence(parent), precedence(child)
+	if cp > pp || right && cp == pp && cp > 0 {
+		f.writeRune('(')
+		f.formatNode(child)
+		f.writeRune(')')
+		return
+	}
+	f.formatNode(child)
+}
+
+// precedence returns the precedence of the operator of an expression, as in the SPEC,
+// where a lower number is a higher precedence. It is 0 for expressions that are not operations.
+func precedence(n Node) int {
+	switch n := n.(type) {
+	case *BinaryExpression:
+		switch n.Operator {
+		case MultiplicationOperator, DivisionOperator:
+			return 2
+		case AdditionOperator, SubtractionOperator:
+			return 3
+		}
+		return 4
+	case *UnaryExpression:
+		if n.Operator == NotOperator {
+			return 5
+		}
+		return 1
+	case *LogicalExpression:
+		return 6
+	}
+	return 0
 }
 
 func (f *formatter) formatCallExpression(n *CallExpression) {
@@ -54,6 +54,18 @@ func TestFormat(t *testing.T) {
 			name:   "object with mixed keys",
 			script: `{"a": 1, b: 2}`,
 		},
+		{
+			name:   "operator precedence",
+			script: `(a + b) * (c - d) - (e - f)`,
+		},
+		{
+			name:   "logical precedence",
+			script: `a and (b or c) or not (d == 1 and e)`,
+		},
+		{
+			name:   "unary minus",
+			script: `-(a + 1) * -b`,
+		},
 		{
 			name:   "member ident",
 			script: `object.property`,
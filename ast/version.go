@@ -0,0 +1,114 @@
+package ast
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Version is the version of the JSON encoding written by MarshalVersionedJSON.
+// It must be incremented, along with a new entry in upgrades,
+// whenever a structural change of the AST changes its JSON encoding.
+const Version = 1
+
+// versionedJSON is the envelope holding a versioned JSON encoding of a package.
+type versionedJSON struct {
+	Version *int            `json:"version"`
+	AST     json.RawMessage `json:"ast"`
+}
+
+// upgrades maps each version of the encoding to the function
+// that upgrades an AST of that version to the next version.
+var upgrades = map[int]func(json.RawMessage) (json.RawMessage, error){
+	0: upgradeV0,
+}
+
+// MarshalVersionedJSON encodes the package with the current version of the JSON encoding.
+// Clients that persist an AST should use it, so that the AST can be decoded
+// by UnmarshalVersionedJSON after the structure of the AST changes.
+func MarshalVersionedJSON(pkg *Package) ([]byte, error) {
+	data, err := json.Marshal(pkg)
+	if err != nil {
+		return nil, err
+	}
+	v := Version
+	return json.Marshal(versionedJSON{
+		Version: &v,
+		AST:     data,
+	})
+}
+
+// UnmarshalVersionedJSON decodes a package encoded by MarshalVersionedJSON
+// with the current or any previous version of the encoding, upgrading it as needed.
+// Data without a version is decoded as version 0, which is the JSON encoding of a
+// Package, a File, or a Program, the root node of the AST before the introduction of packages.
+func UnmarshalVersionedJSON(data []byte) (*Package, error) {
+	var env versionedJSON
+	if err := json.Unmarshal(data, &env); err != nil {
+		return nil, err
+	}
+	version, raw := 0, json.RawMessage(data)
+	if env.Version != nil {
+		version, raw = *env.Version, env.AST
+	}
+	if version > Version {
+		return nil, fmt.Errorf("unsupported AST version %d, the latest supported version is %d", version, Version)
+	}
+	for ; version < Version; version++ {
+		upgrade, ok := upgrades[version]
+		if !ok {
+			return nil, fmt.Errorf("unsupported AST version %d", version)
+		}
+		var err error
+		if raw, err = upgrade(raw); err != nil {
+			return nil, fmt.Errorf("failed to upgrade AST from version %d: %v", version, err)
+		}
+	}
+
+	pkg := new(Package)
+	if err := json.Unmarshal(raw, pkg); err != nil {
+		return nil, err
+	}
+	return pkg, nil
+}
+
+// upgradeV0 wraps the unversioned root nodes into a package.
+func upgradeV0(raw json.RawMessage) (json.RawMessage, error) {
+	var root struct {
+		Type string            `json:"type"`
+		Body []json.RawMessage `json:"body"`
+	}
+	if err := json.Unmarshal(raw, &root); err != nil {
+		return nil, err
+	}
+
+	var file *File
+	switch root.Type {
+	case "Package":
+		return raw, nil
+	case "File":
+		file = new(File)
+		if err := json.Unmarshal(raw, file); err != nil {
+			return nil, err
+		}
+	case "Program":
+		file = &File{Body: make([]Statement, len(root.Body))}
+		for i, r := range root.Body {
+			s, err := unmarshalStatement(r)
+			if err != nil {
+				return nil, err
+			}
+			file.Body[i] = s
+		}
+	default:
+		return nil, fmt.Errorf("unexpected root node type %q", root.Type)
+	}
+
+	pkg := &Package{
+		Package: "main",
+		Files:   []*File{file},
+	}
+	if file.Package != nil && file.Package.Name != nil {
+		pkg.Package = file.Package.Name.Name
+	}
+	return json.Marshal(pkg)
+}
@@ -0,0 +1,106 @@
+package ast_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/flux/ast"
+	"github.com/influxdata/flux/ast/asttest"
+)
+
+func TestUnmarshalVersionedJSON(t *testing.T) {
+	body := []ast.Statement{
+		&ast.ExpressionStatement{
+			Expression: &ast.IntegerLiteral{Value: 1},
+		},
+	}
+	testCases := []struct {
+		name    string
+		data    string
+		want    *ast.Package
+		wantErr bool
+	}{
+		{
+			name: "current version",
+			data: `{"version":1,"ast":{"type":"Package","package":"foo","files":[{"type":"File","package":null,"imports":null,"body":[{"type":"ExpressionStatement","expression":{"type":"IntegerLiteral","value":"1"}}]}]}}`,
+			want: &ast.Package{
+				Package: "foo",
+				Files:   []*ast.File{{Body: body}},
+			},
+		},
+		{
+			name: "unversioned package",
+			data: `{"type":"Package","package":"foo","files":[{"type":"File","package":null,"imports":null,"body":[{"type":"ExpressionStatement","expression":{"type":"IntegerLiteral","value":"1"}}]}]}`,
+			want: &ast.Package{
+				Package: "foo",
+				Files:   []*ast.File{{Body: body}},
+			},
+		},
+		{
+			name: "unversioned file",
+			data: `{"type":"File","package":{"type":"PackageClause","name":{"type":"Identifier","name":"foo"}},"imports":null,"body":[{"type":"ExpressionStatement","expression":{"type":"IntegerLiteral","value":"1"}}]}`,
+			want: &ast.Package{
+				Package: "foo",
+				Files: []*ast.File{{
+					Package: &ast.PackageClause{Name: &ast.Identifier{Name: "foo"}},
+					Body:    body,
+				}},
+			},
+		},
+		{
+			name: "unversioned program",
+			data: `{"type":"Program","body":[{"type":"ExpressionStatement","expression":{"type":"IntegerLiteral","value":"1"}}]}`,
+			want: &ast.Package{
+				Package: "main",
+				Files:   []*ast.File{{Body: body}},
+			},
+		},
+		{
+			name:    "future version",
+			data:    `{"version":1000,"ast":{"type":"Package","package":"foo","files":null}}`,
+			wantErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ast.UnmarshalVersionedJSON([]byte(tc.data))
+			if tc.wantErr {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !cmp.Equal(tc.want, got, asttest.CompareOptions...) {
+				t.Errorf("unexpected package: -want/+got:\n%s", cmp.Diff(tc.want, got, asttest.CompareOptions...))
+			}
+		})
+	}
+}
+
+func TestMarshalVersionedJSON(t *testing.T) {
+	pkg := &ast.Package{
+		Package: "foo",
+		Files: []*ast.File{{
+			Body: []ast.Statement{
+				&ast.ExpressionStatement{
+					Expression: &ast.StringLiteral{Value: "a"},
+				},
+			},
+		}},
+	}
+	data, err := ast.MarshalVersionedJSON(pkg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := ast.UnmarshalVersionedJSON(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cmp.Equal(pkg, got, asttest.CompareOptions...) {
+		t.Errorf("unexpected package after round trip: -want/+got:\n%s", cmp.Diff(pkg, got, asttest.CompareOptions...))
+	}
+}
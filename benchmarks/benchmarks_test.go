@@ -0,0 +1,74 @@
+package benchmarks_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/influxdata/flux/benchmarks"
+	_ "github.com/influxdata/flux/builtin"
+)
+
+var start = time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// schema returns a dataset of a day of points with two fields
+// and the given cardinalities of the tags t0 and t1.
+func schema(t0, t1 int, every time.Duration) benchmarks.Schema {
+	return benchmarks.Schema{
+		Measurement: "m0",
+		Tags: []benchmarks.Tag{
+			{Name: "t0", Cardinality: t0},
+			{Name: "t1", Cardinality: t1},
+		},
+		Fields: []string{"f0", "f1"},
+		Start:  start,
+		Every:  every,
+		Points: int(24 * time.Hour / every),
+		Seed:   1,
+	}
+}
+
+func benchmarkPipeline(b *testing.B, pipeline string, schemas []benchmarks.Schema) {
+	for _, s := range schemas {
+		s := s
+		script := fmt.Sprintf(`data = from(bucket: "bench") |> range(start: %s, stop: %s)
+%s`, start.Format(time.RFC3339), s.Stop().Format(time.RFC3339), pipeline)
+		name := fmt.Sprintf("series=%d/points=%d", s.SeriesN(), s.Points)
+		b.Run(name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if err := benchmarks.Execute(context.Background(), script, s.Stop(), s); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkWindowAggregate(b *testing.B) {
+	benchmarkPipeline(b, `data |> aggregateWindow(every: 1h, fn: mean)`, []benchmarks.Schema{
+		schema(1, 5, time.Second),
+		schema(10, 10, time.Minute),
+		schema(100, 10, 10*time.Minute),
+	})
+}
+
+func BenchmarkPivotJoin(b *testing.B) {
+	benchmarkPipeline(b, `
+f0 = data |> filter(fn: (r) => r._field == "f0") |> pivot(rowKey: ["_time"], columnKey: ["_field"], valueColumn: "_value")
+f1 = data |> filter(fn: (r) => r._field == "f1") |> pivot(rowKey: ["_time"], columnKey: ["_field"], valueColumn: "_value")
+join(tables: {f0: f0, f1: f1}, on: ["_start", "_stop", "_time", "_measurement", "t0", "t1"])`,
+		[]benchmarks.Schema{
+			schema(1, 5, time.Minute),
+			schema(10, 10, 10*time.Minute),
+		})
+}
+
+func BenchmarkHighCardinalityGroup(b *testing.B) {
+	benchmarkPipeline(b, `data |> group(columns: ["t0"]) |> sum()`, []benchmarks.Schema{
+		schema(100, 10, time.Hour),
+		schema(1000, 10, time.Hour),
+		schema(10000, 10, time.Hour),
+	})
+}
@@ -0,0 +1,186 @@
+// Package benchmarks measures the performance of the executor on common pipelines.
+//
+// The pipelines read synthetic datasets from a StorageReader that generates
+// the series of a Schema on the fly, so that the benchmarks do not depend on
+// an external storage engine and the size of the data is configurable.
+// The benchmarks are run with
+//
+//	go test -run XXX -bench . ./benchmarks
+package benchmarks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/memory"
+	"github.com/influxdata/flux/stdlib/influxdata/influxdb"
+	"github.com/influxdata/flux/values"
+)
+
+// Tag describes a tag of a Schema.
+type Tag struct {
+	Name string
+	// Cardinality is the number of distinct values of the tag.
+	Cardinality int
+}
+
+// Schema describes a synthetic dataset.
+// The dataset contains a series for every combination of the tag values and fields,
+// each of which has Points float values spaced Every apart starting at Start.
+type Schema struct {
+	Measurement string
+	Tags        []Tag
+	Fields      []string
+	Start       time.Time
+	Every       time.Duration
+	Points      int
+	// Seed initializes the random values of the series.
+	// The same seed always produces the same dataset.
+	Seed int64
+}
+
+// SeriesN returns the number of series in the dataset.
+func (s Schema) SeriesN() int {
+	n := len(s.Fields)
+	for _, t := range s.Tags {
+		n *= t.Cardinality
+	}
+	return n
+}
+
+// Stop returns the time following the last point of the dataset.
+func (s Schema) Stop() time.Time {
+	return s.Start.Add(time.Duration(s.Points) * s.Every)
+}
+
+// Tables generates the series of the dataset that fall within bounds.
+// Every series is a table grouped by `_start`, `_stop`, `_measurement`, `_field` and the tags,
+// the same way a storage engine returns them.
+func (s Schema) Tables(bounds execute.Bounds, alloc *memory.Allocator) ([]flux.Table, error) {
+	cols := []flux.ColMeta{
+		{Label: execute.DefaultStartColLabel, Type: flux.TTime},
+		{Label: execute.DefaultStopColLabel, Type: flux.TTime},
+		{Label: execute.DefaultTimeColLabel, Type: flux.TTime},
+		{Label: execute.DefaultValueColLabel, Type: flux.TFloat},
+		{Label: "_field", Type: flux.TString},
+		{Label: "_measurement", Type: flux.TString},
+	}
+	for _, t := range s.Tags {
+		cols = append(cols, flux.ColMeta{Label: t.Name, Type: flux.TString})
+	}
+	keyCols := []flux.ColMeta{cols[0], cols[1]}
+	keyCols = append(keyCols, cols[4:]...)
+
+	r := rand.New(rand.NewSource(s.Seed))
+	tables := make([]flux.Table, 0, s.SeriesN())
+	tagValues := make([]int, len(s.Tags))
+	for {
+		for _, field := range s.Fields {
+			keyValues := []values.Value{
+				values.NewTime(bounds.Start),
+				values.NewTime(bounds.Stop),
+				values.NewString(field),
+				values.NewString(s.Measurement),
+			}
+			for i, t := range s.Tags {
+				keyValues = append(keyValues, values.NewString(fmt.Sprintf("%s%d", t.Name, tagValues[i])))
+			}
+			tbl, err := s.series(execute.NewGroupKey(keyCols, keyValues), cols, bounds, r, alloc)
+			if err != nil {
+				return nil, err
+			}
+			tables = append(tables, tbl)
+		}
+		if !nextTagValues(tagValues, s.Tags) {
+			return tables, nil
+		}
+	}
+}
+
+// nextTagValues advances the tag value indexes to the next combination
+// and reports whether there was one.
+func nextTagValues(indexes []int, tags []Tag) bool {
+	for i := len(indexes) - 1; i >= 0; i-- {
+		indexes[i]++
+		if indexes[i] < tags[i].Cardinality {
+			return true
+		}
+		indexes[i] = 0
+	}
+	return false
+}
+
+func (s Schema) series(key flux.GroupKey, cols []flux.ColMeta, bounds execute.Bounds, r *rand.Rand, alloc *memory.Allocator) (flux.Table, error) {
+	builder := execute.NewColListTableBuilder(key, alloc)
+	for _, c := range cols {
+		if _, err := builder.AddCol(c); err != nil {
+			return nil, err
+		}
+	}
+	for i := 0; i < s.Points; i++ {
+		v := r.Float64() * 100
+		t := values.ConvertTime(s.Start.Add(time.Duration(i) * s.Every))
+		if t < bounds.Start || t >= bounds.Stop {
+			continue
+		}
+		if err := builder.AppendTime(2, t); err != nil {
+			return nil, err
+		}
+		if err := builder.AppendFloat(3, v); err != nil {
+			return nil, err
+		}
+		if err := execute.AppendKeyValues(key, builder); err != nil {
+			return nil, err
+		}
+	}
+	return builder.Table()
+}
+
+// StorageReader is an influxdb.StorageReader that reads the dataset of a Schema.
+// Every bucket contains the same dataset.
+type StorageReader struct {
+	Schema Schema
+}
+
+func (r *StorageReader) Capabilities() influxdb.StorageCapabilities {
+	return influxdb.StorageCapabilities{}
+}
+
+func (r *StorageReader) ReadFilter(ctx context.Context, spec influxdb.ReadFilterSpec, alloc *memory.Allocator) (flux.TableIterator, error) {
+	if spec.Predicate != nil {
+		return nil, errors.New("predicates are not supported")
+	}
+	tables, err := r.Schema.Tables(spec.Bounds, alloc)
+	if err != nil {
+		return nil, err
+	}
+	return tableIterator(tables), nil
+}
+
+func (r *StorageReader) ReadGroup(ctx context.Context, spec influxdb.ReadGroupSpec, alloc *memory.Allocator) (flux.TableIterator, error) {
+	return nil, errors.New("group is not supported")
+}
+
+func (r *StorageReader) ReadWindowAggregate(ctx context.Context, spec influxdb.ReadWindowAggregateSpec, alloc *memory.Allocator) (flux.TableIterator, error) {
+	return nil, errors.New("window aggregate is not supported")
+}
+
+type tableIterator []flux.Table
+
+func (ti tableIterator) Do(f func(flux.Table) error) error {
+	for _, tbl := range ti {
+		if err := f(tbl); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (ti tableIterator) Statistics() flux.Statistics {
+	return flux.Statistics{}
+}
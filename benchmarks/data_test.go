@@ -0,0 +1,51 @@
+package benchmarks_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/benchmarks"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+)
+
+func TestSchema_Tables(t *testing.T) {
+	s := benchmarks.Schema{
+		Measurement: "m0",
+		Tags:        []benchmarks.Tag{{Name: "t0", Cardinality: 2}},
+		Fields:      []string{"f0"},
+		Start:       start,
+		Every:       time.Minute,
+		Points:      3,
+	}
+	bounds := execute.Bounds{
+		Start: execute.Time(start.Add(time.Minute).UnixNano()),
+		Stop:  execute.Time(s.Stop().UnixNano()),
+	}
+
+	tables, err := s.Tables(bounds, executetest.UnlimitedAllocator)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := len(tables), s.SeriesN(); got != want {
+		t.Fatalf("unexpected number of tables: got %d want %d", got, want)
+	}
+	for i, tbl := range tables {
+		if got, want := tbl.Key().ValueString(4), []string{"t00", "t01"}[i]; got != want {
+			t.Errorf("unexpected tag value of table %d: got %s want %s", i, got, want)
+		}
+		err := tbl.Do(func(cr flux.ColReader) error {
+			if cr.Len() != 2 {
+				t.Errorf("unexpected number of rows in table %d: got %d want 2", i, cr.Len())
+			}
+			if got := execute.Time(cr.Times(2).Value(0)); got != bounds.Start {
+				t.Errorf("unexpected first time in table %d: got %v want %v", i, got, bounds.Start)
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package benchmarks
+
+import (
+	"context"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/memory"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/stdlib/influxdata/influxdb"
+)
+
+// Execute compiles, plans and executes a script that reads the dataset of the schema
+// with from() and reads all of its results.
+func Execute(ctx context.Context, script string, now time.Time, s Schema) error {
+	spec, err := flux.Compile(ctx, script, now)
+	if err != nil {
+		return err
+	}
+	lp := plan.NewLogicalPlanner()
+	initPlan, err := lp.CreateInitialPlan(spec)
+	if err != nil {
+		return err
+	}
+	logicalPlan, err := lp.Plan(initPlan)
+	if err != nil {
+		return err
+	}
+	physicalPlan, err := plan.NewPhysicalPlanner().Plan(logicalPlan)
+	if err != nil {
+		return err
+	}
+
+	deps := execute.Dependencies{
+		influxdb.StorageDependencyKey: &StorageReader{Schema: s},
+	}
+	results, err := execute.NewExecutor(deps, nil).Execute(ctx, physicalPlan, new(memory.Allocator))
+	if err != nil {
+		return err
+	}
+	for _, r := range results {
+		if err := r.Tables().Do(func(tbl flux.Table) error {
+			return tbl.Do(func(flux.ColReader) error {
+				return nil
+			})
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	_ "github.com/influxdata/flux/builtin"
+	"github.com/influxdata/flux/docs"
+	"github.com/spf13/cobra"
+)
+
+// docCmd represents the doc command
+var docCmd = &cobra.Command{
+	Use:   "doc [package | function]",
+	Short: "Show the documentation of the Flux standard library",
+	Long: `Show the documentation of a package or a value of the Flux standard library.
+The value is either a name of the prelude, such as range, or qualified by its package, such as strings.title.
+Without arguments the documentation of all packages is shown.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: doc,
+}
+
+var docJSON bool
+
+func init() {
+	rootCmd.AddCommand(docCmd)
+	docCmd.Flags().BoolVar(&docJSON, "json", false, "print the documentation as JSON")
+}
+
+func doc(cmd *cobra.Command, args []string) error {
+	var v interface{}
+	switch {
+	case len(args) == 0:
+		v = docs.Packages()
+	default:
+		if pkg, ok := docs.LookupPackage(args[0]); ok {
+			v = pkg
+			break
+		}
+		value, err := docs.Lookup(args[0])
+		if err != nil {
+			return err
+		}
+		v = value
+	}
+
+	if docJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", " ")
+		return enc.Encode(v)
+	}
+	switch v := v.(type) {
+	case []docs.Package:
+		for _, pkg := range v {
+			writePackageDoc(os.Stdout, pkg)
+		}
+	case docs.Package:
+		writePackageDoc(os.Stdout, v)
+	case docs.Value:
+		writeValueDoc(os.Stdout, v.Name, v)
+	}
+	return nil
+}
+
+func writePackageDoc(w io.Writer, pkg docs.Package) {
+	fmt.Fprintf(w, "package %s // import %q\n", pkg.Name, pkg.Path)
+	writeComment(w, pkg.Doc)
+	fmt.Fprintln(w)
+	for _, v := range pkg.Values {
+		writeValueDoc(w, pkg.Name+"."+v.Name, v)
+	}
+}
+
+func writeValueDoc(w io.Writer, name string, v docs.Value) {
+	fmt.Fprintf(w, "%s: %s\n", name, v.Type)
+	writeComment(w, v.Doc)
+	fmt.Fprintln(w)
+}
+
+func writeComment(w io.Writer, comment string) {
+	if comment == "" {
+		return
+	}
+	for _, line := range strings.Split(comment, "\n") {
+		fmt.Fprintln(w, "    "+line)
+	}
+}
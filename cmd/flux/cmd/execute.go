@@ -17,14 +17,14 @@ var executeCmd = &cobra.Command{
 	Short: "Execute a Flux script",
 	Long:  "Execute a Flux script from string or file (use @ as prefix to the file)",
 	Args:  cobra.ExactArgs(1),
-	RunE:  execute,
+	RunE:  executeScript,
 }
 
 func init() {
 	rootCmd.AddCommand(executeCmd)
 }
 
-func execute(cmd *cobra.Command, args []string) error {
+func executeScript(cmd *cobra.Command, args []string) error {
 	scriptSource := args[0]
 
 	var script string
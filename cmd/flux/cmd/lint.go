@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/influxdata/flux/lint"
+	"github.com/spf13/cobra"
+)
+
+// lintCmd represents the lint command
+var lintCmd = &cobra.Command{
+	Use:   "lint [file...]",
+	Short: "Report anti-patterns in Flux scripts",
+	Long: `Analyze the given Flux scripts for anti-patterns and print the findings.
+The command fails when any finding is reported, so that it can be used to check scripts in CI.`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: lintFiles,
+}
+
+var lintFlags struct {
+	disable []string
+	json    bool
+}
+
+func init() {
+	lintCmd.Flags().StringSliceVar(&lintFlags.disable, "disable", nil, "names of the lint rules not to apply")
+	lintCmd.Flags().BoolVar(&lintFlags.json, "json", false, "print the findings as JSON")
+	rootCmd.AddCommand(lintCmd)
+}
+
+func lintFiles(cmd *cobra.Command, args []string) error {
+	l, err := lint.New(lint.Config{Disabled: lintFlags.disable})
+	if err != nil {
+		return err
+	}
+
+	var all []lint.Finding
+	for _, file := range args {
+		script, err := ioutil.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		findings, err := l.LintSource(string(script))
+		if err != nil {
+			return fmt.Errorf("%s: %v", file, err)
+		}
+		for _, f := range findings {
+			f.Location.File = file
+			all = append(all, f)
+		}
+	}
+
+	w := cmd.OutOrStdout()
+	if lintFlags.json {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", " ")
+		if err := enc.Encode(all); err != nil {
+			return err
+		}
+	} else {
+		for _, f := range all {
+			fmt.Fprintf(w, "%s:%v\n", f.Location.File, f)
+		}
+	}
+	if len(all) > 0 {
+		return fmt.Errorf("found %d lint problems", len(all))
+	}
+	return nil
+}
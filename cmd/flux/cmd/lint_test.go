@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLint(t *testing.T) {
+	testCases := []struct {
+		name    string
+		script  string
+		want    string
+		wantErr string
+	}{
+		{
+			name:   "no findings",
+			script: `from(bucket: "telegraf") |> range(start: -1h)`,
+		},
+		{
+			name:    "findings",
+			script:  `from(bucket: "telegraf") |> filter(fn: (r) => r._measurement == "cpu")`,
+			want:    "script.flux:1:1: missing-range: ",
+			wantErr: "found 1 lint problems",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "flux-lint")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(dir)
+			file := filepath.Join(dir, "script.flux")
+			if err := ioutil.WriteFile(file, []byte(tc.script), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			var out bytes.Buffer
+			rootCmd.SetOutput(&out)
+			rootCmd.SetArgs([]string{"lint", file})
+			// Execute exits with a non-zero status when the command returns an error.
+			err = rootCmd.Execute()
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatal(err)
+				}
+				if out.Len() > 0 {
+					t.Errorf("unexpected output %q", out.String())
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("expected an error containing %q, got %v", tc.wantErr, err)
+			}
+			if !strings.HasPrefix(out.String(), filepath.Join(dir, tc.want)) {
+				t.Errorf("unexpected output, want a line starting with %q, got %q", filepath.Join(dir, tc.want), out.String())
+			}
+		})
+	}
+}
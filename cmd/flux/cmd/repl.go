@@ -2,12 +2,18 @@ package cmd
 
 import (
 	"context"
+	"fmt"
 	"math"
+	"os"
+	"strings"
 
 	"github.com/influxdata/flux"
 	_ "github.com/influxdata/flux/builtin"
 	"github.com/influxdata/flux/control"
+	"github.com/influxdata/flux/dependencies"
 	"github.com/influxdata/flux/repl"
+	"github.com/influxdata/flux/stdlib/influxdata/influxdb/memstore"
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 )
 
@@ -15,23 +21,70 @@ import (
 var replCmd = &cobra.Command{
 	Use:   "repl",
 	Short: "Launch a Flux REPL",
-	Long:  "Launch a Flux REPL (Run-Execute-Print-Loop)",
-	Run: func(cmd *cobra.Command, args []string) {
+	Long: `Launch a Flux REPL (Run-Execute-Print-Loop)
+
+Scripts read and write the buckets of a local storage in memory
+with from(bucket: ...), buckets() and to(bucket: ...).
+Line protocol can be loaded into its buckets with --load.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := loadLocalStorage(); err != nil {
+			return err
+		}
 		q := NewQuerier()
 		r := repl.New(q)
 		r.Run()
+		return nil
 	},
 }
 
 func init() {
+	addLoadFlag(replCmd)
 	rootCmd.AddCommand(replCmd)
 }
 
+// localStorage is the storage that from(), buckets() and to() use in the scripts run from the command line,
+// so that Flux can be tried out without an InfluxDB.
+var localStorage = memstore.New()
+
+var loadFlags []string
+
+func addLoadFlag(cmd *cobra.Command) {
+	cmd.Flags().StringArrayVar(&loadFlags, "load", nil, "load a file of line protocol into a bucket of the local storage, as bucket=file (may be repeated)")
+}
+
+// loadLocalStorage loads the files given with --load into the local storage.
+func loadLocalStorage() error {
+	for _, l := range loadFlags {
+		i := strings.Index(l, "=")
+		if i <= 0 {
+			return fmt.Errorf("invalid load %q, expected bucket=file", l)
+		}
+		bucket, name := l[:i], l[i+1:]
+		if err := loadFile(bucket, name); err != nil {
+			return errors.Wrapf(err, "failed to load %s into bucket %q", name, bucket)
+		}
+	}
+	return nil
+}
+
+func loadFile(bucket, name string) error {
+	f, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return localStorage.Load(bucket, f)
+}
+
+// Querier runs the queries of the command line.
+// Scripts run from the command line are trusted and may access any external resource.
 type Querier struct {
-	c *control.Controller
+	c    *control.Controller
+	deps dependencies.Dependencies
 }
 
 func (q *Querier) Query(ctx context.Context, c flux.Compiler) (flux.ResultIterator, error) {
+	ctx = dependencies.Inject(ctx, q.deps)
 	qry, err := q.c.Query(ctx, c)
 	if err != nil {
 		return nil, err
@@ -40,14 +93,23 @@ func (q *Querier) Query(ctx context.Context, c flux.Compiler) (flux.ResultIterat
 }
 
 func NewQuerier() *Querier {
+	return newQuerier(dependencies.Unrestricted())
+}
+
+// newQuerier returns a querier that compiles and executes the queries with deps.
+// The points written by the queries are written to the local storage.
+func newQuerier(deps dependencies.Dependencies) *Querier {
+	deps.PointsWriter = localStorage
 	config := control.Config{
-		ConcurrencyQuota: 1,
-		MemoryBytesQuota: math.MaxInt64,
+		ConcurrencyQuota:     1,
+		MemoryBytesQuota:     math.MaxInt64,
+		ExecutorDependencies: localStorage.Dependencies(),
 	}
 
 	c := control.New(config)
 
 	return &Querier{
-		c: c,
+		c:    c,
+		deps: deps,
 	}
 }
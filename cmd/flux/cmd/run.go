@@ -0,0 +1,247 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"time"
+
+	"github.com/influxdata/flux"
+	_ "github.com/influxdata/flux/builtin"
+	"github.com/influxdata/flux/csv"
+	"github.com/influxdata/flux/dependencies"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/lang"
+	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/flux/values"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// runCmd represents the run command
+var runCmd = &cobra.Command{
+	Use:   "run [file]",
+	Short: "Run a Flux script",
+	Long: `Run a Flux script read from a file, or from stdin when the file is omitted or "-".
+
+Data can be read from local files with csv.from(file: ...),
+relative paths are resolved from the current working directory.
+Data can also be read from the buckets of a local storage in memory with from(bucket: ...),
+after loading files of line protocol into them with --load.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: run,
+}
+
+var runFlags struct {
+	format      string
+	now         string
+	timeout     time.Duration
+	memoryLimit int64
+	explain     string
+}
+
+func init() {
+	runCmd.Flags().StringVar(&runFlags.format, "format", "table", "output format of the results, one of table, csv or json")
+	runCmd.Flags().StringVar(&runFlags.now, "now", "", "the time used as now by the script, in RFC3339 format (default the current time)")
+	runCmd.Flags().DurationVar(&runFlags.timeout, "timeout", 0, "maximum duration of the script execution (default no timeout)")
+	runCmd.Flags().Int64Var(&runFlags.memoryLimit, "memory-limit", 0, "maximum number of bytes the script may allocate (default no limit)")
+	runCmd.Flags().StringVar(&runFlags.explain, "explain-analyze", "", "instead of the results, write the physical plan annotated with the rows, time and memory of every node, in one of the formats text, json or graphviz")
+	addLoadFlag(runCmd)
+	rootCmd.AddCommand(runCmd)
+}
+
+func run(cmd *cobra.Command, args []string) error {
+	encode, ok := resultEncoders[runFlags.format]
+	if !ok {
+		return fmt.Errorf("unknown format %q, expected one of table, csv or json", runFlags.format)
+	}
+
+	script, err := readScript(args)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	if runFlags.now != "" {
+		if now, err = time.Parse(time.RFC3339Nano, runFlags.now); err != nil {
+			return errors.Wrap(err, "invalid now")
+		}
+	}
+	if runFlags.memoryLimit < 0 {
+		return errors.New("memory limit must not be negative")
+	}
+	if err := loadLocalStorage(); err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	var profiler *execute.Profiler
+	if runFlags.explain != "" {
+		switch format := execute.ExplainFormat(runFlags.explain); format {
+		case execute.ExplainText, execute.ExplainJSON, execute.ExplainGraphviz:
+			encode = drainResults
+		default:
+			return fmt.Errorf("unknown explain format %q, expected one of text, json or graphviz", format)
+		}
+		profiler = execute.NewProfiler()
+		ctx = execute.WithProfiler(ctx, profiler)
+	}
+	if runFlags.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, runFlags.timeout)
+		defer cancel()
+	}
+
+	// The script is compiled by the controller, with the same dependencies as it is executed with,
+	// so that the tables it reads while it is compiled, with tableFind() for example, can be read.
+	deps := dependencies.Unrestricted()
+	deps.Now = func() time.Time { return now }
+	if runFlags.memoryLimit > 0 {
+		deps.Sandbox = &dependencies.Sandbox{
+			AllowFilesystem: true,
+			AllowNetwork:    true,
+			AllowModels:     true,
+			AllowWrites:     true,
+			AllowSecrets:    true,
+			MemoryBytes:     runFlags.memoryLimit,
+		}
+	}
+	querier := newQuerier(deps)
+	results, err := querier.Query(ctx, lang.FluxCompiler{Query: script})
+	if err != nil {
+		return err
+	}
+	defer results.Release()
+
+	out := cmd.OutOrStdout()
+	if err := encode(out, results); err != nil {
+		return err
+	}
+	if err := results.Err(); err != nil {
+		return err
+	}
+	if profiler != nil {
+		return profiler.Explain(out, execute.ExplainFormat(runFlags.explain))
+	}
+	return nil
+}
+
+// drainResults reads the results to completion without writing them,
+// so that the operators of the query are fully profiled.
+func drainResults(w io.Writer, results flux.ResultIterator) error {
+	for results.More() {
+		err := results.Next().Tables().Do(func(tbl flux.Table) error {
+			return tbl.Do(func(flux.ColReader) error {
+				return nil
+			})
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readScript reads the script from the file named by args or from stdin.
+func readScript(args []string) (string, error) {
+	var (
+		src []byte
+		err error
+	)
+	if len(args) == 0 || args[0] == "-" {
+		src, err = ioutil.ReadAll(os.Stdin)
+	} else {
+		src, err = ioutil.ReadFile(args[0])
+	}
+	if err != nil {
+		return "", err
+	}
+	return string(src), nil
+}
+
+// resultEncoders maps the values of the format flag to the encoders of the results.
+var resultEncoders = map[string]func(w io.Writer, results flux.ResultIterator) error{
+	"table": encodeTable,
+	"csv":   encodeCSV,
+	"json":  encodeJSON,
+}
+
+func encodeTable(w io.Writer, results flux.ResultIterator) error {
+	for results.More() {
+		result := results.Next()
+		fmt.Fprintln(w, "Result:", result.Name())
+		err := result.Tables().Do(func(tbl flux.Table) error {
+			_, err := execute.NewFormatter(tbl, nil).WriteTo(w)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func encodeCSV(w io.Writer, results flux.ResultIterator) error {
+	encoder := csv.NewMultiResultEncoder(csv.DefaultEncoderConfig())
+	_, err := encoder.Encode(w, results)
+	return err
+}
+
+// encodeJSON writes every row of the results as a JSON object on its own line.
+// Each object holds the name of the result, the index of the table within
+// the result and a property per column.
+func encodeJSON(w io.Writer, results flux.ResultIterator) error {
+	enc := json.NewEncoder(w)
+	for results.More() {
+		result := results.Next()
+		table := 0
+		err := result.Tables().Do(func(tbl flux.Table) error {
+			err := tbl.Do(func(cr flux.ColReader) error {
+				for i := 0; i < cr.Len(); i++ {
+					row := map[string]interface{}{
+						"result": result.Name(),
+						"table":  table,
+					}
+					for j, c := range cr.Cols() {
+						row[c.Label] = jsonValue(execute.ValueForRow(cr, i, j))
+					}
+					if err := enc.Encode(row); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+			table++
+			return err
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func jsonValue(v values.Value) interface{} {
+	if v.IsNull() {
+		return nil
+	}
+	switch v.Type() {
+	case semantic.String:
+		return v.Str()
+	case semantic.Int:
+		return v.Int()
+	case semantic.UInt:
+		return v.UInt()
+	case semantic.Float:
+		return v.Float()
+	case semantic.Bool:
+		return v.Bool()
+	case semantic.Time:
+		return v.Time().Time().Format(time.RFC3339Nano)
+	default:
+		return nil
+	}
+}
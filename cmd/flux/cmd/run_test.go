@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const runData = `
+data = "#datatype,string,long,dateTime:RFC3339,double
+#group,false,false,false,false
+#default,_result,,,
+,result,table,_time,_value
+,,0,2018-12-31T23:30:00Z,1
+,,0,2018-12-31T22:00:00Z,2
+"
+`
+
+func TestRun(t *testing.T) {
+	testCases := []struct {
+		name    string
+		script  string
+		args    []string
+		want    string
+		wantErr string
+	}{
+		{
+			name:   "now",
+			script: `csv.from(csv: data) |> range(start: -1h) |> keep(columns: ["_value"])`,
+			args:   []string{"--now", "2019-01-01T00:00:00Z"},
+			want:   `{"_value":1,"result":"_result","table":0}` + "\n",
+		},
+		{
+			name: "tables read while compiling",
+			script: `x = csv.from(csv: data) |> tableFind(fn: (key) => true) |> getColumn(column: "_value")
+csv.from(csv: data) |> filter(fn: (r) => r._value > x[0]) |> keep(columns: ["_value"])`,
+			args: []string{"--now", "2019-01-01T00:00:00Z"},
+			want: `{"_value":2,"result":"_result","table":0}` + "\n",
+		},
+		{
+			name:    "memory limit",
+			script:  `csv.from(csv: data) |> sort(columns: ["_value"], desc: true)`,
+			args:    []string{"--memory-limit", "1"},
+			wantErr: "allocation limit reached",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "flux-run")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(dir)
+			file := filepath.Join(dir, "script.flux")
+			if err := ioutil.WriteFile(file, []byte("import \"csv\"\n"+runData+tc.script), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			var out bytes.Buffer
+			rootCmd.SetOutput(&out)
+			rootCmd.SetArgs(append([]string{"run", file, "--format", "json"}, tc.args...))
+			err = rootCmd.Execute()
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("expected an error containing %q, got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := out.String(); got != tc.want {
+				t.Errorf("unexpected output, want %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,170 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/ast"
+	_ "github.com/influxdata/flux/builtin"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/internal/token"
+	"github.com/influxdata/flux/lang"
+	"github.com/influxdata/flux/parser"
+	"github.com/influxdata/flux/stdlib"
+	"github.com/spf13/cobra"
+)
+
+// testFileSuffix is the suffix of the files that contain Flux test cases.
+const testFileSuffix = "_test.flux"
+
+// testCmd represents the test command
+var testCmd = &cobra.Command{
+	Use:   "test [path...]",
+	Short: "Run Flux tests",
+	Long: `Run the test cases of the *_test.flux files found in the given files and directories.
+Directories are searched recursively, the current directory is searched when no path is given.
+
+Every test statement of a file is run with testing.run.
+When a file fails, its test cases are run again with testing.inspect and the
+resulting tables, including the diff between the wanted and the produced tables, are printed.`,
+	RunE: runTests,
+}
+
+var testFlags struct {
+	verbose bool
+}
+
+func init() {
+	testCmd.Flags().BoolVarP(&testFlags.verbose, "verbose", "v", false, "print the tables of passing tests as well")
+	rootCmd.AddCommand(testCmd)
+}
+
+func runTests(cmd *cobra.Command, args []string) error {
+	if len(args) == 0 {
+		args = []string{"."}
+	}
+	files, err := findTestFiles(args)
+	if err != nil {
+		return err
+	}
+
+	w := cmd.OutOrStdout()
+	querier := NewQuerier()
+	failed := 0
+	for _, file := range files {
+		if !runTestFile(w, querier, file) {
+			failed++
+		}
+	}
+	if failed > 0 {
+		fmt.Fprintln(w, "FAIL")
+		return fmt.Errorf("%d of %d test files failed", failed, len(files))
+	}
+	fmt.Fprintln(w, "PASS")
+	return nil
+}
+
+// findTestFiles returns the test files named by paths or contained in the directories named by paths.
+func findTestFiles(paths []string) ([]string, error) {
+	var files []string
+	for _, p := range paths {
+		err := filepath.Walk(p, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if !info.IsDir() && (path == p || strings.HasSuffix(path, testFileSuffix)) {
+				files = append(files, path)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return files, nil
+}
+
+// runTestFile runs the test cases of a file and reports whether they passed.
+func runTestFile(w io.Writer, querier *Querier, path string) bool {
+	start := time.Now()
+	pkg, err := parseTestFile(path)
+	if err != nil {
+		fmt.Fprintf(w, "--- FAIL: %s\n\t%v\n", path, err)
+		return false
+	}
+
+	calls := stdlib.TestingRunCalls(pkg)
+	if len(calls.Body) == 0 {
+		fmt.Fprintf(w, "?   \t%s\t[no test cases]\n", path)
+		return true
+	}
+
+	pkg.Files = append(pkg.Files, calls)
+	err = runTestQuery(context.Background(), querier, pkg, nil)
+	elapsed := time.Since(start).Seconds()
+	if err == nil && !testFlags.verbose {
+		fmt.Fprintf(w, "ok  \t%s\t%.3fs\n", path, elapsed)
+		return true
+	}
+	if err == nil {
+		fmt.Fprintf(w, "=== PASS: %s (%.3fs)\n", path, elapsed)
+	} else {
+		fmt.Fprintf(w, "--- FAIL: %s (%.3fs)\n\t%v\n", path, elapsed, err)
+	}
+
+	// Rerun the test cases using testing.inspect to print their tables.
+	pkg.Files[len(pkg.Files)-1] = stdlib.TestingInspectCalls(pkg)
+	if err := runTestQuery(context.Background(), querier, pkg, w); err != nil {
+		fmt.Fprintf(w, "\t%v\n", err)
+	}
+	return err == nil
+}
+
+func parseTestFile(path string) (*ast.Package, error) {
+	file, err := parser.ParseFile(new(token.FileSet), path)
+	if err != nil {
+		return nil, err
+	}
+	pkg := &ast.Package{
+		Package: "main",
+		Files:   []*ast.File{file},
+	}
+	if file.Package != nil && file.Package.Name != nil {
+		pkg.Package = file.Package.Name.Name
+	}
+	if ast.Check(pkg) > 0 {
+		return nil, ast.GetError(pkg)
+	}
+	return pkg, nil
+}
+
+// runTestQuery executes the test package and reads all of its results.
+// The tables of the results are printed to w, unless w is nil.
+func runTestQuery(ctx context.Context, querier *Querier, pkg *ast.Package, w io.Writer) error {
+	results, err := querier.Query(ctx, lang.ASTCompiler{AST: pkg})
+	if err != nil {
+		return err
+	}
+	defer results.Release()
+
+	for results.More() {
+		res := results.Next()
+		if w != nil {
+			err = execute.FormatResult(w, res)
+		} else {
+			err = res.Tables().Do(func(flux.Table) error {
+				return nil
+			})
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return results.Err()
+}
@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testData = `
+import "testing"
+
+inData = "
+#datatype,string,long,dateTime:RFC3339,double
+#group,false,false,false,false
+#default,_result,,,
+,result,table,_time,_value
+,,0,2018-12-31T23:30:00Z,1
+"
+`
+
+func TestTest(t *testing.T) {
+	testCases := []struct {
+		name    string
+		fn      string
+		wantOut []string
+		wantErr bool
+	}{
+		{
+			name:    "passing suite",
+			fn:      `(table=<-) => table`,
+			wantOut: []string{"ok  \t", "PASS\n"},
+		},
+		{
+			name:    "failing suite",
+			fn:      `(table=<-) => table |> filter(fn: (r) => r._value > 1.0)`,
+			wantOut: []string{"--- FAIL: ", "Result: diff", "FAIL\n"},
+			wantErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "flux-test")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(dir)
+			script := testData + "t_fn = " + tc.fn + `
+test _fn = () => ({input: testing.loadMem(csv: inData), want: testing.loadMem(csv: inData), fn: t_fn})
+`
+			if err := ioutil.WriteFile(filepath.Join(dir, "fn"+testFileSuffix), []byte(script), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			var out bytes.Buffer
+			rootCmd.SetOutput(&out)
+			rootCmd.SetArgs([]string{"test", dir})
+			// Execute exits with a non-zero status when the command returns an error.
+			err = rootCmd.Execute()
+			if tc.wantErr && err == nil {
+				t.Fatal("expected the command to fail")
+			} else if !tc.wantErr && err != nil {
+				t.Fatal(err)
+			}
+			for _, want := range tc.wantOut {
+				if !strings.Contains(out.String(), want) {
+					t.Errorf("expected the output to contain %q, got %q", want, out.String())
+				}
+			}
+		})
+	}
+}
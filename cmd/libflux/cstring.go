@@ -0,0 +1,13 @@
+//go:build cgo
+// +build cgo
+
+package main
+
+// #include <stdlib.h>
+import "C"
+
+// cString and goString convert strings across the C boundary.
+// They exist for the tests, which cannot use cgo themselves.
+func cString(s string) *C.char { return C.CString(s) }
+
+func goString(s *C.char) string { return C.GoString(s) }
@@ -0,0 +1,116 @@
+//go:build cgo
+// +build cgo
+
+// Command libflux builds the Flux front-end as a C library,
+// so that programs written in other languages use the same parser and
+// semantic analysis as Flux itself.
+//
+// The library and its header are built with
+//
+//	go build -buildmode=c-shared -o libflux.so ./cmd/libflux
+//
+// Parsed packages are referred to by handles returned by flux_parse,
+// which must be released with flux_free_ast.
+// Strings returned by the library must be released with flux_free.
+package main
+
+// #include <stdlib.h>
+import "C"
+
+import (
+	"encoding/json"
+	"sync"
+	"unsafe"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/ast"
+	_ "github.com/influxdata/flux/builtin"
+	"github.com/influxdata/flux/lang"
+	"github.com/influxdata/flux/parser"
+	"github.com/influxdata/flux/semantic"
+)
+
+// handles holds the parsed packages referenced from C,
+// since Go pointers cannot be retained by C code.
+var handles = struct {
+	sync.Mutex
+	next uint64
+	pkgs map[uint64]*ast.Package
+}{pkgs: make(map[uint64]*ast.Package)}
+
+func lookup(h C.ulonglong) *ast.Package {
+	handles.Lock()
+	defer handles.Unlock()
+	return handles.pkgs[uint64(h)]
+}
+
+// flux_parse parses the Flux source and returns a handle to the parsed package.
+// Syntax errors are reported by flux_semantic_analyze.
+//
+//export flux_parse
+func flux_parse(src *C.char) C.ulonglong {
+	pkg := parser.ParseSource(C.GoString(src))
+
+	handles.Lock()
+	defer handles.Unlock()
+	handles.next++
+	handles.pkgs[handles.next] = pkg
+	return C.ulonglong(handles.next)
+}
+
+// flux_free_ast releases the package referenced by the handle.
+//
+//export flux_free_ast
+func flux_free_ast(h C.ulonglong) {
+	handles.Lock()
+	defer handles.Unlock()
+	delete(handles.pkgs, uint64(h))
+}
+
+// flux_ast_marshal_json returns the JSON encoding of the package referenced by the handle,
+// or NULL if the handle is invalid.
+//
+//export flux_ast_marshal_json
+func flux_ast_marshal_json(h C.ulonglong) *C.char {
+	pkg := lookup(h)
+	if pkg == nil {
+		return nil
+	}
+	data, err := json.Marshal(pkg)
+	if err != nil {
+		return nil
+	}
+	return C.CString(string(data))
+}
+
+// flux_semantic_analyze checks the package referenced by the handle for syntax errors,
+// creates its semantic graph and infers its types against the Flux standard library.
+// It returns NULL on success and the error message otherwise.
+//
+//export flux_semantic_analyze
+func flux_semantic_analyze(h C.ulonglong) *C.char {
+	pkg := lookup(h)
+	if pkg == nil {
+		return C.CString("invalid handle")
+	}
+	if ast.Check(pkg) > 0 {
+		return C.CString(ast.GetError(pkg).Error())
+	}
+	semPkg, err := semantic.New(pkg)
+	if err != nil {
+		return C.CString(err.Error())
+	}
+	if _, err := semantic.InferTypes(lang.WithPrelude(semPkg), flux.StdLib()); err != nil {
+		return C.CString(err.Error())
+	}
+	return nil
+}
+
+// flux_free releases a string returned by the library.
+//
+//export flux_free
+func flux_free(s *C.char) {
+	C.free(unsafe.Pointer(s))
+}
+
+func main() {}
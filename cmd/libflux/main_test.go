@@ -0,0 +1,96 @@
+//go:build cgo
+// +build cgo
+
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestSemanticAnalyze(t *testing.T) {
+	testCases := []struct {
+		name    string
+		src     string
+		wantErr string
+	}{
+		{
+			name: "valid",
+			src:  `from(bucket: "telegraf") |> range(start: -5m) |> mean()`,
+		},
+		{
+			name:    "syntax error",
+			src:     `from(bucket: "telegraf"`,
+			wantErr: "expected RPAREN",
+		},
+		{
+			name:    "type error",
+			src:     `1 + "a"`,
+			wantErr: "int != string",
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			s := cString(tc.src)
+			defer flux_free(s)
+			h := flux_parse(s)
+			defer flux_free_ast(h)
+
+			msg := flux_semantic_analyze(h)
+			if msg == nil {
+				if tc.wantErr != "" {
+					t.Fatalf("expected an error containing %q", tc.wantErr)
+				}
+				return
+			}
+			defer flux_free(msg)
+			if got := goString(msg); tc.wantErr == "" || !strings.Contains(got, tc.wantErr) {
+				t.Fatalf("unexpected error: %s", got)
+			}
+		})
+	}
+}
+
+func TestASTMarshalJSON(t *testing.T) {
+	s := cString(`x = 1`)
+	defer flux_free(s)
+	h := flux_parse(s)
+
+	data := flux_ast_marshal_json(h)
+	if data == nil {
+		t.Fatal("expected the package to be marshaled")
+	}
+	var pkg struct {
+		Type  string `json:"type"`
+		Files []struct {
+			Body []struct {
+				Type string `json:"type"`
+			} `json:"body"`
+		} `json:"files"`
+	}
+	err := json.Unmarshal([]byte(goString(data)), &pkg)
+	flux_free(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pkg.Type != "Package" || len(pkg.Files) != 1 || len(pkg.Files[0].Body) != 1 || pkg.Files[0].Body[0].Type != "VariableAssignment" {
+		t.Fatalf("unexpected package: %+v", pkg)
+	}
+
+	// The handle is invalid once it has been released.
+	flux_free_ast(h)
+	if data := flux_ast_marshal_json(h); data != nil {
+		flux_free(data)
+		t.Fatal("expected no package for a released handle")
+	}
+	if msg := flux_semantic_analyze(h); msg == nil {
+		t.Fatal("expected an error for a released handle")
+	} else {
+		defer flux_free(msg)
+		if got := goString(msg); got != "invalid handle" {
+			t.Fatalf("unexpected error: %s", got)
+		}
+	}
+}
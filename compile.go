@@ -6,11 +6,12 @@ import (
 	"log"
 	"path"
 	"regexp"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/influxdata/flux/ast"
-
+	fluxerrors "github.com/influxdata/flux/errors"
 	"github.com/influxdata/flux/interpreter"
 	"github.com/influxdata/flux/parser"
 	"github.com/influxdata/flux/semantic"
@@ -53,6 +54,37 @@ func Compile(ctx context.Context, q string, now time.Time, opts ...Option) (*Spe
 // CompileAST evaluates a Flux AST and produces a query Spec.
 // now parameter must be non-zero, that is the default now time should be set before compiling.
 func CompileAST(ctx context.Context, astPkg *ast.Package, now time.Time, opts ...Option) (*Spec, error) {
+	semPkg, err := semantic.New(astPkg)
+	if err != nil {
+		return nil, invalid(err)
+	}
+	return CompileAnalyzed(ctx, &Analyzed{AST: astPkg, Semantic: semPkg}, now, opts...)
+}
+
+// Analyzed is a Flux script that has been parsed and analyzed into its semantic graph.
+// The analysis of a script does not depend on the time it is compiled at, nor on the data it reads,
+// so an Analyzed script may be compiled any number of times, concurrently.
+type Analyzed struct {
+	AST      *ast.Package
+	Semantic *semantic.Package
+}
+
+// Analyze parses and analyzes a Flux script.
+func Analyze(q string) (*Analyzed, error) {
+	astPkg, err := Parse(q)
+	if err != nil {
+		return nil, err
+	}
+	semPkg, err := semantic.New(astPkg)
+	if err != nil {
+		return nil, invalid(err)
+	}
+	return &Analyzed{AST: astPkg, Semantic: semPkg}, nil
+}
+
+// CompileAnalyzed evaluates an analyzed Flux script and produces a query Spec.
+// now parameter must be non-zero, that is the default now time should be set before compiling.
+func CompileAnalyzed(ctx context.Context, a *Analyzed, now time.Time, opts ...Option) (*Spec, error) {
 	o := new(options)
 	for _, opt := range opts {
 		opt(o)
@@ -60,7 +92,7 @@ func CompileAST(ctx context.Context, astPkg *ast.Package, now time.Time, opts ..
 
 	s, _ := opentracing.StartSpanFromContext(ctx, "parse")
 
-	sideEffects, scope, err := EvalAST(astPkg, SetOption(nowOption, nowFunc(now)))
+	sideEffects, scope, err := evalSemantic(ctx, a.Semantic, SetOption(nowOption, nowFunc(now)))
 	if err != nil {
 		return nil, err
 	}
@@ -84,6 +116,8 @@ func CompileAST(ctx context.Context, astPkg *ast.Package, now time.Time, opts ..
 		return nil, err
 	}
 
+	spec.Warnings = deprecationWarnings(a.AST)
+
 	if o.verbose {
 		log.Println("Query Spec: ", Formatted(spec, FmtJSON))
 	}
@@ -100,6 +134,15 @@ func Parse(flux string) (*ast.Package, error) {
 	return astPkg, nil
 }
 
+// invalid classifies an error of the analysis or the evaluation of a script
+// as invalid, unless it already has a code.
+func invalid(err error) error {
+	if fluxerrors.CodeOf(err) != fluxerrors.Unknown {
+		return err
+	}
+	return fluxerrors.Wrap(err, fluxerrors.Invalid, "")
+}
+
 // Eval accepts a Flux script and evaluates it to produce a set of side effects (as a slice of values) and a scope.
 func Eval(flux string, opts ...ScopeMutator) ([]values.Value, interpreter.Scope, error) {
 	astPkg, err := Parse(flux)
@@ -111,21 +154,38 @@ func Eval(flux string, opts ...ScopeMutator) ([]values.Value, interpreter.Scope,
 
 // EvalAST accepts a Flux AST and evaluates it to produce a set of side effects (as a slice of values) and a scope.
 func EvalAST(astPkg *ast.Package, opts ...ScopeMutator) ([]values.Value, interpreter.Scope, error) {
+	return evalAST(context.Background(), astPkg, opts...)
+}
+
+// evalAST evaluates a Flux AST, passing ctx to the functions that use the context of the script.
+func evalAST(ctx context.Context, astPkg *ast.Package, opts ...ScopeMutator) ([]values.Value, interpreter.Scope, error) {
 	semPkg, err := semantic.New(astPkg)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, invalid(err)
 	}
+	return evalSemantic(ctx, semPkg, opts...)
+}
 
-	itrp := interpreter.NewInterpreter()
-	universe := Prelude()
+// evalSemantic evaluates the semantic graph of a Flux script, passing ctx to the functions that use the context of the script.
+// The semantic graph is not modified, the types inferred for its nodes are kept by the interpreter.
+func evalSemantic(ctx context.Context, semPkg *semantic.Package, opts ...ScopeMutator) ([]values.Value, interpreter.Scope, error) {
+	// The prelude of the script uses the packages that it imports,
+	// so that an option set through a qualified import, for example universe.now,
+	// is seen by the names of the prelude.
+	importer := stdlib.Copy()
+	prelude := importer.prelude()
 
 	for _, opt := range opts {
-		opt(universe)
+		opt(prelude)
 	}
 
-	sideEffects, err := itrp.Eval(semPkg, universe, StdLib())
+	universe := prelude.Nest(nil)
+	// Table objects read while evaluating the script resolve relative times
+	// against the now option as it is set at that point of the script.
+	itrp := interpreter.NewInterpreterWithContext(withNowScope(ctx, universe))
+	sideEffects, err := itrp.Eval(semPkg, universe, importer)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, invalid(err)
 	}
 
 	return sideEffects, universe, nil
@@ -214,8 +274,16 @@ func (s *scopeSet) Lookup(name string) (values.Value, bool) {
 	return nil, false
 }
 
+// Set replaces the value of a name of the prelude, for example the default of an option.
+// It panics if the name is not defined by one of the packages of the prelude.
 func (s *scopeSet) Set(name string, v values.Value) {
-	panic("cannot mutate the universe block")
+	for _, pkg := range s.packages {
+		if _, ok := pkg.Get(name); ok {
+			pkg.Set(name, v)
+			return
+		}
+	}
+	panic(fmt.Errorf("cannot add %q to the universe block", name))
 }
 
 func (s *scopeSet) Nest(obj values.Object) interpreter.Scope {
@@ -267,6 +335,24 @@ func StdLib() interpreter.Importer {
 	return stdlib.Copy()
 }
 
+// StdLibPackagePaths returns the sorted import paths of the packages in the Flux standard library.
+func StdLibPackagePaths() []string {
+	paths := make([]string, 0, len(stdlib.pkgs))
+	for pkgpath := range stdlib.pkgs {
+		paths = append(paths, pkgpath)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// PreludePackagePaths returns the import paths of the packages of the prelude,
+// in the order that the names of the prelude are looked up in them.
+func PreludePackagePaths() []string {
+	paths := make([]string, len(prelude))
+	copy(paths, prelude)
+	return paths
+}
+
 // Prelude returns a scope object representing the Flux universe block
 func Prelude() interpreter.Scope {
 	return preludeScope.Nest(nil)
@@ -329,7 +415,7 @@ func FunctionValueWithSideEffect(name string, c CreateOperationSpec, sig semanti
 func functionValue(name string, c CreateOperationSpec, sig semantic.FunctionPolySignature, sideEffects bool) values.Value {
 	if c == nil {
 		c = func(args Arguments, a *Administration) (OperationSpec, error) {
-			return nil, fmt.Errorf("function %q is not implemented", name)
+			return nil, fluxerrors.Newf(fluxerrors.Unimplemented, "function %q is not implemented", name)
 		}
 	}
 	return &function{
@@ -359,6 +445,9 @@ func FinalizeBuiltIns() {
 	if err := evalBuiltInPackages(); err != nil {
 		panic(err)
 	}
+	if err := validateDeprecations(); err != nil {
+		panic(err)
+	}
 }
 
 func evalBuiltInPackages() error {
@@ -394,7 +483,8 @@ func evalBuiltInPackages() error {
 	return nil
 }
 
-// validatePackageBuiltins ensures that all package builtins have both an AST builtin statement and a registered value.
+// validatePackageBuiltins ensures that all package builtins have both an AST builtin statement and a registered value,
+// and that the signatures of the builtin functions are consistent.
 func validatePackageBuiltins(pkg *interpreter.Package, astPkg *ast.Package) error {
 	builtinStmts := make(map[string]*ast.BuiltinStatement)
 	ast.Walk(ast.CreateVisitor(func(n ast.Node) {
@@ -406,12 +496,20 @@ func validatePackageBuiltins(pkg *interpreter.Package, astPkg *ast.Package) erro
 	missing := make([]string, 0, len(builtinStmts))
 	extra := make([]string, 0, len(builtinStmts))
 
+	var badSignatures []string
+
 	for n := range builtinStmts {
-		if _, ok := pkg.Get(n); !ok {
+		v, ok := pkg.Get(n)
+		if !ok {
 			missing = append(missing, n)
 			continue
 		}
 		// TODO(nathanielc): Ensure that the value's type matches the type expression
+		if t := v.PolyType(); t.Nature() == semantic.Function {
+			if err := semantic.ValidateFunctionPolyType(t); err != nil {
+				badSignatures = append(badSignatures, fmt.Sprintf("%s: %v", n, err))
+			}
+		}
 	}
 	pkg.Range(func(k string, v values.Value) {
 		if _, ok := builtinStmts[k]; !ok {
@@ -422,6 +520,10 @@ func validatePackageBuiltins(pkg *interpreter.Package, astPkg *ast.Package) erro
 	if len(missing) > 0 || len(extra) > 0 {
 		return fmt.Errorf("missing builtin values %v, extra builtin values %v", missing, extra)
 	}
+	if len(badSignatures) > 0 {
+		sort.Strings(badSignatures)
+		return fmt.Errorf("builtin values with invalid signatures %v", badSignatures)
+	}
 	return nil
 }
 
@@ -462,6 +564,8 @@ type TableObject struct {
 	Kind    OperationKind
 	Spec    OperationSpec
 	Parents values.Array
+	// Location is the location in the script of the call that produced the table object, if it is known.
+	Location *ast.SourceLocation
 }
 
 func (t *TableObject) Operation(ider IDer) *Operation {
@@ -470,8 +574,17 @@ func (t *TableObject) Operation(ider IDer) *Operation {
 	}
 
 	return &Operation{
-		ID:   ider.ID(t),
-		Spec: t.Spec,
+		ID:       ider.ID(t),
+		Spec:     t.Spec,
+		Location: t.Location,
+	}
+}
+
+// SetLocation implements interpreter.Locatable.
+// A table object returned by a user defined function keeps the location of the builtin call that produced it.
+func (t *TableObject) SetLocation(loc ast.SourceLocation) {
+	if t.Location == nil && loc.IsValid() {
+		t.Location = &loc
 	}
 }
 func (t *TableObject) IsNull() bool {
@@ -610,6 +723,11 @@ func (t *TableObject) Object() values.Object {
 	return t
 }
 func (t *TableObject) Equal(rhs values.Value) bool {
+	// The parents of a table object are compared both as parents and as the tables argument,
+	// so comparing a long pipeline to itself property by property takes exponential time.
+	if rhs, ok := rhs.(*TableObject); ok && rhs == t {
+		return true
+	}
 	if t.Type() != rhs.Type() {
 		return false
 	}
@@ -735,7 +853,10 @@ type function struct {
 
 func (f *function) Type() semantic.Type {
 	// TODO(nathanielc): Update values.Value interface to use PolyTypes
-	t, _ := f.t.MonoType()
+	t, ok := f.t.MonoType()
+	if !ok {
+		return semantic.Invalid
+	}
 	return t
 }
 func (f *function) PolyType() semantic.PolyType {
@@ -842,18 +963,18 @@ func (a Arguments) GetRequiredTime(name string) (Time, error) {
 func (a Arguments) GetDuration(name string) (Duration, bool, error) {
 	v, ok := a.Get(name)
 	if !ok {
-		return 0, false, nil
+		return Duration{}, false, nil
 	}
-	return Duration(v.Duration()), true, nil
+	return v.Duration(), true, nil
 }
 
 func (a Arguments) GetRequiredDuration(name string) (Duration, error) {
 	d, ok, err := a.GetDuration(name)
 	if err != nil {
-		return 0, err
+		return Duration{}, err
 	}
 	if !ok {
-		return 0, fmt.Errorf("missing required keyword argument %q", name)
+		return Duration{}, fmt.Errorf("missing required keyword argument %q", name)
 	}
 	return d, nil
 }
@@ -865,9 +986,11 @@ func ToQueryTime(value values.Value) (Time, error) {
 			Absolute: value.Time().Time(),
 		}, nil
 	case semantic.Duration:
+		d := value.Duration()
 		return Time{
-			Relative:   value.Duration().Duration(),
-			IsRelative: true,
+			Relative:       time.Duration(d.Nanoseconds()),
+			RelativeMonths: d.Months(),
+			IsRelative:     true,
 		}, nil
 	case semantic.Int:
 		return Time{
@@ -892,6 +1015,15 @@ func (imp *importer) Copy() *importer {
 	}
 }
 
+// prelude returns the scope of the packages of the prelude of the importer.
+func (imp *importer) prelude() *scopeSet {
+	packages := make([]*interpreter.Package, len(prelude))
+	for i, path := range prelude {
+		packages[i] = imp.pkgs[path]
+	}
+	return &scopeSet{packages: packages}
+}
+
 func (imp *importer) Import(path string) (semantic.PackageType, bool) {
 	p, ok := imp.pkgs[path]
 	if !ok {
@@ -7,6 +7,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/influxdata/flux/ast"
 	"github.com/influxdata/flux/interpreter"
+	"github.com/influxdata/flux/parser"
 	"github.com/influxdata/flux/values"
 )
 
@@ -95,3 +96,67 @@ func TestValidatePackageBuiltins(t *testing.T) {
 		})
 	}
 }
+
+func TestDeprecationWarnings(t *testing.T) {
+	saved := deprecations
+	defer func() { deprecations = saved }()
+	deprecations = map[string]map[string]*deprecation{
+		"universe": {
+			"range": {value: "use window instead", params: map[string]string{}},
+			"now":   {value: "use system.time instead", params: map[string]string{}},
+		},
+		"strings": {
+			"trim": {params: map[string]string{"cutset": "use trimSpace instead"}},
+		},
+	}
+
+	testCases := []struct {
+		name string
+		q    string
+		want []string
+	}{
+		{
+			name: "function",
+			q:    `range(start: -1h)`,
+			want: []string{"range is deprecated: use window instead"},
+		},
+		{
+			name: "shadowed",
+			q: `range = (start) => start
+range(start: -1h)`,
+		},
+		{
+			name: "option",
+			q:    `option now = () => 2019-01-01T00:00:00Z`,
+			want: []string{"option now is deprecated: use system.time instead"},
+		},
+		{
+			name: "parameter",
+			q: `import "strings"
+strings.trim(v: " a ", cutset: " ")`,
+			want: []string{`parameter "cutset" of strings.trim is deprecated: use trimSpace instead`},
+		},
+		{
+			name: "parameter of alias",
+			q: `import s "strings"
+s.trim(v: " a ", cutset: " ")
+s.trim(v: " a ")`,
+			want: []string{`parameter "cutset" of s.trim is deprecated: use trimSpace instead`},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			var got []string
+			for _, w := range deprecationWarnings(parser.ParseSource(tc.q)) {
+				if w.Location == nil {
+					t.Errorf("warning %q has no location", w.Message)
+				}
+				got = append(got, w.Message)
+			}
+			if !cmp.Equal(tc.want, got) {
+				t.Errorf("unexpected warnings -want/+got:\n%s", cmp.Diff(tc.want, got))
+			}
+		})
+	}
+}
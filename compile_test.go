@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/errors"
 )
 
 func TestCompile(t *testing.T) {
@@ -28,3 +29,70 @@ func TestCompile(t *testing.T) {
 		}
 	}
 }
+
+func TestCompile_ErrorCode(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	for _, tc := range []struct {
+		q    string
+		code errors.Code
+	}{
+		{q: `1 +`, code: errors.Invalid},
+		{q: `"a" + 1`, code: errors.Invalid},
+		{q: `a + 1`, code: errors.NotFound},
+		{q: `import "nope"`, code: errors.NotFound},
+		{q: `option strings.x = 1`, code: errors.Invalid},
+	} {
+		_, err := flux.Compile(ctx, tc.q, now)
+		if err == nil {
+			t.Errorf("expected query %q to compile with error but got no error", tc.q)
+		} else if got := errors.CodeOf(err); got != tc.code {
+			t.Errorf("unexpected code of the error %v of query %q: want %v, got %v", err, tc.q, tc.code, got)
+		}
+	}
+}
+
+func TestCompile_Now(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for _, tc := range []struct {
+		name string
+		q    string
+		want time.Time
+	}{
+		{
+			name: "default",
+			q:    `x = 1`,
+			want: now,
+		},
+		{
+			name: "option",
+			q:    `option now = () => 2018-01-01T00:00:00Z`,
+			want: time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "qualified option",
+			q: `import "universe"
+option universe.now = () => 2017-01-01T00:00:00Z`,
+			want: time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "option shadows qualified option",
+			q: `import "universe"
+option universe.now = () => 2017-01-01T00:00:00Z
+option now = () => 2018-01-01T00:00:00Z`,
+			want: time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+	} {
+		spec, err := flux.Compile(ctx, tc.q, now)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tc.name, err)
+			continue
+		}
+		if !spec.Now.Equal(tc.want) {
+			t.Errorf("%s: unexpected now: want %v, got %v", tc.name, tc.want, spec.Now)
+		}
+	}
+}
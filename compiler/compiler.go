@@ -286,6 +286,7 @@ func compile(n semantic.Node, typeSol semantic.TypeSolution, builtIns Scope, fun
 		}
 		return &callEvaluator{
 			t:      monoType(typeSol.TypeOf(n)),
+			loc:    n.Location(),
 			callee: callee,
 			args:   args,
 		}, nil
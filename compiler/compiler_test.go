@@ -1,12 +1,14 @@
 package compiler_test
 
 import (
+	"errors"
 	"reflect"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/influxdata/flux/ast"
 	"github.com/influxdata/flux/compiler"
+	"github.com/influxdata/flux/parser"
 	"github.com/influxdata/flux/semantic"
 	"github.com/influxdata/flux/semantic/semantictest"
 	"github.com/influxdata/flux/values"
@@ -469,3 +471,48 @@ func TestCompileAndEval(t *testing.T) {
 		})
 	}
 }
+
+func TestCompile_CallError(t *testing.T) {
+	pkg, err := semantic.New(parser.ParseSource(`(r) => r.a + fail(v: r.a)`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	fn := pkg.Files[0].Body[0].(*semantic.ExpressionStatement).Expression.(*semantic.FunctionExpression)
+
+	builtins := compiler.Scope{
+		"fail": values.NewFunction(
+			"fail",
+			semantic.NewFunctionPolyType(semantic.FunctionPolySignature{
+				Parameters: map[string]semantic.PolyType{"v": semantic.Int},
+				Required:   semantic.LabelSet{"v"},
+				Return:     semantic.Int,
+			}),
+			func(args values.Object) (values.Value, error) {
+				return nil, errors.New("failed")
+			},
+			false,
+		),
+	}
+	inType := semantic.NewObjectType(map[string]semantic.Type{
+		"r": semantic.NewObjectType(map[string]semantic.Type{"a": semantic.Int}),
+	})
+	f, err := compiler.Compile(fn, inType, builtins)
+	if err != nil {
+		t.Fatal(err)
+	}
+	input := values.NewObjectWithValues(map[string]values.Value{
+		"r": values.NewObjectWithValues(map[string]values.Value{"a": values.NewInt(1)}),
+	})
+	_, err = f.Eval(input)
+	want := &compiler.Error{
+		Location: ast.SourceLocation{
+			Start:  ast.Position{Line: 1, Column: 14},
+			End:    ast.Position{Line: 1, Column: 26},
+			Source: "fail(v: r.a)",
+		},
+		Err: errors.New("failed"),
+	}
+	if got, ok := err.(*compiler.Error); !ok || got.Location != want.Location || got.Err.Error() != want.Err.Error() {
+		t.Errorf("unexpected error: want %v, got %v", want, err)
+	}
+}
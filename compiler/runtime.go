@@ -5,6 +5,7 @@ import (
 	"regexp"
 
 	"github.com/influxdata/flux/ast"
+	fluxerrors "github.com/influxdata/flux/errors"
 	"github.com/influxdata/flux/semantic"
 	"github.com/influxdata/flux/values"
 	"github.com/pkg/errors"
@@ -71,11 +72,41 @@ func (c compiledFn) buildScope(input values.Object) error {
 	return nil
 }
 
+// Error is the error of an expression of a compiled function that failed while it was evaluated.
+type Error struct {
+	// Location is the location in the script of the expression.
+	Location ast.SourceLocation
+	Err      error
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("error at %v: %v", e.Location, e.Err)
+}
+
+// Code implements errors.Coder, the error has the code of the error of the expression.
+func (e *Error) Code() fluxerrors.Code {
+	return fluxerrors.CodeOf(e.Err)
+}
+
+// catch recovers from the panic of an evaluator that failed with an *Error,
+// which the evaluators raise since they do not return errors, and sets err to it.
+// Any other panic is not recovered.
+func catch(err *error) {
+	if r := recover(); r != nil {
+		e, ok := r.(*Error)
+		if !ok {
+			panic(r)
+		}
+		*err = e
+	}
+}
+
 func (c compiledFn) Type() semantic.Type {
 	return c.fnType.FunctionSignature().Return
 }
 
-func (c compiledFn) Eval(input values.Object) (values.Value, error) {
+func (c compiledFn) Eval(input values.Object) (_ values.Value, err error) {
+	defer catch(&err)
 	if err := c.buildScope(input); err != nil {
 		return nil, err
 	}
@@ -107,67 +138,78 @@ func (c compiledFn) Eval(input values.Object) (values.Value, error) {
 	}
 }
 
-func (c compiledFn) EvalString(input values.Object) (string, error) {
+func (c compiledFn) EvalString(input values.Object) (_ string, err error) {
+	defer catch(&err)
 	if err := c.buildScope(input); err != nil {
 		return "", err
 	}
 	return c.root.EvalString(c.inputScope), nil
 }
-func (c compiledFn) EvalBool(input values.Object) (bool, error) {
+func (c compiledFn) EvalBool(input values.Object) (_ bool, err error) {
+	defer catch(&err)
 	if err := c.buildScope(input); err != nil {
 		return false, err
 	}
 	return c.root.EvalBool(c.inputScope), nil
 }
-func (c compiledFn) EvalInt(input values.Object) (int64, error) {
+func (c compiledFn) EvalInt(input values.Object) (_ int64, err error) {
+	defer catch(&err)
 	if err := c.buildScope(input); err != nil {
 		return 0, err
 	}
 	return c.root.EvalInt(c.inputScope), nil
 }
-func (c compiledFn) EvalUInt(input values.Object) (uint64, error) {
+func (c compiledFn) EvalUInt(input values.Object) (_ uint64, err error) {
+	defer catch(&err)
 	if err := c.buildScope(input); err != nil {
 		return 0, err
 	}
 	return c.root.EvalUInt(c.inputScope), nil
 }
-func (c compiledFn) EvalFloat(input values.Object) (float64, error) {
+func (c compiledFn) EvalFloat(input values.Object) (_ float64, err error) {
+	defer catch(&err)
 	if err := c.buildScope(input); err != nil {
 		return 0, err
 	}
 	return c.root.EvalFloat(c.inputScope), nil
 }
-func (c compiledFn) EvalTime(input values.Object) (values.Time, error) {
+func (c compiledFn) EvalTime(input values.Object) (_ values.Time, err error) {
+	defer catch(&err)
 	if err := c.buildScope(input); err != nil {
 		return 0, err
 	}
 	return c.root.EvalTime(c.inputScope), nil
 }
-func (c compiledFn) EvalDuration(input values.Object) (values.Duration, error) {
+func (c compiledFn) EvalDuration(input values.Object) (_ values.Duration, err error) {
+	defer catch(&err)
 	if err := c.buildScope(input); err != nil {
-		return 0, err
+		return values.Duration{}, err
 	}
 	return c.root.EvalDuration(c.inputScope), nil
 }
-func (c compiledFn) EvalRegexp(input values.Object) (*regexp.Regexp, error) {
+func (c compiledFn) EvalRegexp(input values.Object) (_ *regexp.Regexp, err error) {
+	defer catch(&err)
 	if err := c.buildScope(input); err != nil {
 		return nil, err
 	}
 	return c.root.EvalRegexp(c.inputScope), nil
 }
-func (c compiledFn) EvalArray(input values.Object) (values.Array, error) {
+func (c compiledFn) EvalArray(input values.Object) (_ values.Array, err error) {
+	defer catch(&err)
 	if err := c.buildScope(input); err != nil {
 		return nil, err
 	}
 	return c.root.EvalArray(c.inputScope), nil
 }
-func (c compiledFn) EvalObject(input values.Object) (values.Object, error) {
+func (c compiledFn) EvalObject(input values.Object) (_ values.Object, err error) {
+	defer catch(&err)
 	if err := c.buildScope(input); err != nil {
 		return nil, err
 	}
 	return c.root.EvalObject(c.inputScope), nil
 }
-func (c compiledFn) EvalFunction(input values.Object) (values.Function, error) {
+func (c compiledFn) EvalFunction(input values.Object) (_ values.Function, err error) {
+	defer catch(&err)
 	if err := c.buildScope(input); err != nil {
 		return nil, err
 	}
@@ -615,7 +657,7 @@ func (e *unaryEvaluator) EvalTime(scope Scope) values.Time {
 }
 func (e *unaryEvaluator) EvalDuration(scope Scope) values.Duration {
 	// There is only one duration unary operator
-	return -e.node.EvalDuration(scope)
+	return e.node.EvalDuration(scope).Neg()
 }
 func (e *unaryEvaluator) EvalRegexp(scope Scope) *regexp.Regexp {
 	panic(values.UnexpectedKind(e.t.Nature(), semantic.Regexp))
@@ -1085,6 +1127,7 @@ func (e *arrayIndexEvaluator) EvalFunction(scope Scope) values.Function {
 
 type callEvaluator struct {
 	t      semantic.Type
+	loc    ast.SourceLocation
 	callee Evaluator
 	args   Evaluator
 }
@@ -1096,8 +1139,14 @@ func (e *callEvaluator) Type() semantic.Type {
 func (e *callEvaluator) eval(scope Scope) values.Value {
 	args := e.args.EvalObject(scope)
 	f := e.callee.EvalFunction(scope)
-	//TODO(nathanielc): What to do about error when calling functions?
-	v, _ := f.Call(args)
+	v, err := f.Call(args)
+	if err != nil {
+		// The error of a compiled function already has the location of the expression that failed.
+		if e, ok := err.(*Error); ok {
+			panic(e)
+		}
+		panic(&Error{Location: e.loc, Err: err})
+	}
 	return v
 }
 
@@ -1251,7 +1300,8 @@ func (f *functionValue) HasSideEffect() bool {
 	return false
 }
 
-func (f *functionValue) Call(args values.Object) (values.Value, error) {
+func (f *functionValue) Call(args values.Object) (_ values.Value, err error) {
+	defer catch(&err)
 	scope := f.scope.Copy()
 	for _, p := range f.params {
 		v, ok := args.Get(p.Key)
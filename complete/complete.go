@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"sort"
+	"strings"
 
 	"github.com/influxdata/flux"
 	"github.com/influxdata/flux/interpreter"
@@ -42,15 +43,53 @@ func (c Completer) Names() []string {
 }
 
 // Value returns a value based on the expression name, if one exists.
+// The name may be a member expression such as "strings.title",
+// in which case the properties of the records and packages in scope are traversed.
 func (c Completer) Value(name string) (values.Value, error) {
-	v, ok := c.scope.Lookup(name)
+	parts := strings.Split(name, ".")
+	v, ok := c.scope.Lookup(parts[0])
 	if !ok {
 		return nil, errors.New("could not find value")
 	}
+	for _, p := range parts[1:] {
+		obj, ok := v.(values.Object)
+		if !ok {
+			return nil, fmt.Errorf("name ( %s ) is not a record", name)
+		}
+		if v, ok = obj.Get(p); !ok {
+			return nil, errors.New("could not find value")
+		}
+	}
 
 	return v, nil
 }
 
+// Properties returns the sorted property names of the record or package with the given name.
+func (c Completer) Properties(name string) ([]string, error) {
+	v, err := c.Value(name)
+	if err != nil {
+		return nil, err
+	}
+
+	obj, ok := v.(values.Object)
+	if !ok {
+		return nil, fmt.Errorf("name ( %s ) is not a record", name)
+	}
+
+	props := make([]string, 0, obj.Len())
+	obj.Range(func(k string, v values.Value) {
+		props = append(props, k)
+	})
+	sort.Strings(props)
+
+	return props, nil
+}
+
+// PackagePaths returns the import paths of the packages in the standard library.
+func (c Completer) PackagePaths() []string {
+	return flux.StdLibPackagePaths()
+}
+
 // FunctionNames returns the names of all function.
 func (c Completer) FunctionNames() []string {
 	funcs := []string{}
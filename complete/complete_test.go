@@ -91,3 +91,55 @@ func TestFunctionSuggestion(t *testing.T) {
 		t.Error(cmp.Diff(result, expected), "does not match expected suggestion")
 	}
 }
+
+func TestValue_Member(t *testing.T) {
+	value := values.NewInt(5)
+	obj := values.NewObject()
+	obj.Set("b", value)
+	pkg := interpreter.NewPackage("foo")
+	pkg.Set("a", obj)
+	scope := interpreter.NewScope()
+	scope.Set("foo", pkg)
+
+	c := complete.NewCompleter(scope)
+
+	v, err := c.Value("foo.a.b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cmp.Equal(value, v) {
+		t.Error(cmp.Diff(value, v), "unexpected value for member")
+	}
+
+	if _, err := c.Value("foo.a.b.c"); err == nil {
+		t.Error("expected error for member of a non-record value")
+	}
+}
+
+func TestProperties(t *testing.T) {
+	v := values.NewInt(0)
+	pkg := interpreter.NewPackage("foo")
+	pkg.Set("tick", v)
+	pkg.Set("boom", v)
+	scope := interpreter.NewScope()
+	scope.Set("foo", pkg)
+	scope.Set("bar", v)
+
+	c := complete.NewCompleter(scope)
+
+	results, err := c.Properties("foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{
+		"boom",
+		"tick",
+	}
+	if !cmp.Equal(results, expected) {
+		t.Error(cmp.Diff(results, expected), "unexpected properties")
+	}
+
+	if _, err := c.Properties("bar"); err == nil {
+		t.Error("expected error for properties of a non-record value")
+	}
+}
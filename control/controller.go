@@ -29,6 +29,8 @@ import (
 	"time"
 
 	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/dependencies"
+	fluxerrors "github.com/influxdata/flux/errors"
 	"github.com/influxdata/flux/execute"
 	"github.com/influxdata/flux/memory"
 	"github.com/influxdata/flux/plan"
@@ -61,15 +63,21 @@ type Controller struct {
 	executor execute.Executor
 	logger   *zap.Logger
 
+	hooksMu sync.RWMutex
+	hooks   hooks
+
 	maxConcurrency       int
 	availableConcurrency int
 	availableMemory      int64
+
+	usageResult bool
 }
 
 type Config struct {
 	ConcurrencyQuota     int
 	MemoryBytesQuota     int64
 	ExecutorDependencies execute.Dependencies
+	ExecutorOptions      []execute.ExecutorOption
 	PPlannerOptions      []plan.PhysicalOption
 	LPlannerOptions      []plan.LogicalOption
 	Logger               *zap.Logger
@@ -77,10 +85,16 @@ type Config struct {
 	// The value for a given key will be read off the context.
 	// The context value must be a string or an implementation of the Stringer interface.
 	MetricLabelKeys []string
+	// UsageResult appends a result named UsageResultName to the results of every query,
+	// with the data that the sources of the query scanned.
+	UsageResult bool
 }
 
 type QueryID uint64
 
+// errShutdown is the error of the queries that are rejected or canceled because the controller is shutting down.
+var errShutdown = fluxerrors.New(fluxerrors.Unavailable, "server shutting down")
+
 func New(c Config) *Controller {
 	logger := c.Logger
 	if logger == nil {
@@ -97,10 +111,11 @@ func New(c Config) *Controller {
 		availableMemory:      c.MemoryBytesQuota,
 		lplanner:             plan.NewLogicalPlanner(c.LPlannerOptions...),
 		pplanner:             plan.NewPhysicalPlanner(c.PPlannerOptions...),
-		executor:             execute.NewExecutor(c.ExecutorDependencies, logger),
+		executor:             execute.NewExecutor(c.ExecutorDependencies, logger, c.ExecutorOptions...),
 		logger:               logger,
 		metrics:              newControllerMetrics(c.MetricLabelKeys),
 		labelKeys:            c.MetricLabelKeys,
+		usageResult:          c.UsageResult,
 	}
 	ctrl.shutdownCtx, ctrl.shutdown = context.WithCancel(context.Background())
 	go ctrl.run()
@@ -110,7 +125,19 @@ func New(c Config) *Controller {
 // Query submits a query for execution returning immediately.
 // Done must be called on any returned Query objects.
 func (c *Controller) Query(ctx context.Context, compiler flux.Compiler) (flux.Query, error) {
+	// Do not bother compiling queries that would be rejected when they are queued.
+	select {
+	case <-c.shutdownCtx.Done():
+		return nil, errShutdown
+	default:
+	}
+	ctx, err := c.preCompile(ctx, compiler)
 	q := c.createQuery(ctx, compiler.CompilerType())
+	if err != nil {
+		q.setErr(err)
+		c.countQueryRequest(q, labelCompileError)
+		return nil, q.Err()
+	}
 	if err := c.compileQuery(q, compiler); err != nil {
 		q.setErr(err)
 		c.countQueryRequest(q, labelCompileError)
@@ -147,7 +174,30 @@ func (c *Controller) createQuery(ctx context.Context, ct flux.CompilerType) *Que
 	}
 	compileLabelValues[len(compileLabelValues)-1] = string(ct)
 
-	cctx, cancel := context.WithCancel(ctx)
+	// The flags consulted by the query are recorded for its statistics,
+	// and the logs of its functions are correlated with the query.
+	deps := dependencies.Get(ctx)
+	flags := dependencies.NewFlagRecorder(deps.Flagger)
+	deps.Flagger = flags
+	deps.Logger = deps.Logger.With(zap.Uint64("query_id", uint64(id)))
+	ctx = dependencies.Inject(ctx, deps)
+	// The data scanned while the script is compiled, for example by tableFind(), is part of the usage.
+	usage := execute.NewUsageRecorder()
+	ctx = execute.WithUsageRecorder(ctx, usage)
+	writes := execute.NewWriteRecorder()
+	ctx = execute.WithWriteRecorder(ctx, writes)
+	metadata := execute.NewMetadataRecorder()
+	ctx = execute.WithMetadataRecorder(ctx, metadata)
+
+	var (
+		cctx   context.Context
+		cancel context.CancelFunc
+	)
+	if sb := dependencies.Get(ctx).Sandbox; sb != nil && sb.WallTimeout > 0 {
+		cctx, cancel = context.WithTimeout(ctx, sb.WallTimeout)
+	} else {
+		cctx, cancel = context.WithCancel(ctx)
+	}
 	parentSpan, parentCtx := StartSpanFromContext(
 		cctx,
 		"all",
@@ -160,11 +210,15 @@ func (c *Controller) createQuery(ctx context.Context, ct flux.CompilerType) *Que
 		compileLabelValues: compileLabelValues,
 		state:              Created,
 		c:                  c,
-		now:                time.Now().UTC(),
+		now:                dependencies.Get(ctx).Now().UTC(),
 		ready:              make(chan map[string]flux.Result, 1),
 		parentCtx:          parentCtx,
 		parentSpan:         parentSpan,
 		cancel:             cancel,
+		flags:              flags,
+		usage:              usage,
+		writes:             writes,
+		metadata:           metadata,
 	}
 }
 
@@ -172,7 +226,8 @@ func (c *Controller) compileQuery(q *Query, compiler flux.Compiler) error {
 	if !q.tryCompile() {
 		return errors.New("failed to transition query to compiling state")
 	}
-	spec, err := compiler.Compile(q.currentCtx)
+	// The tables of a script may be read while it is compiled, for example by tableFind().
+	spec, err := compiler.Compile(flux.WithTablesEvaluator(q.currentCtx, c))
 	if err != nil {
 		return errors.Wrap(err, "failed to compile query")
 	}
@@ -191,6 +246,7 @@ func (c *Controller) compileQuery(q *Query, compiler flux.Compiler) error {
 		if err != nil {
 			return errors.Wrap(err, "failed to create initial logical plan")
 		}
+		ip.Flagger = q.flags
 		lp, err := c.lplanner.Plan(ip)
 		if err != nil {
 			return errors.Wrap(err, "failed to create logical plan")
@@ -203,12 +259,20 @@ func (c *Controller) compileQuery(q *Query, compiler flux.Compiler) error {
 		if err != nil {
 			return errors.Wrap(err, "failed to create physical plan")
 		}
+		if err := c.postPlan(q.currentCtx, p); err != nil {
+			return err
+		}
 		q.plan = p
 		q.concurrency = p.Resources.ConcurrencyQuota
 		if q.concurrency > c.maxConcurrency {
 			q.concurrency = c.maxConcurrency
 		}
 		q.memory = p.Resources.MemoryBytesQuota
+		if sb := dependencies.Get(q.parentCtx).Sandbox; sb != nil && sb.MemoryBytes > 0 && sb.MemoryBytes < q.memory {
+			// The executor limits the allocator of the query to the quota of the plan.
+			q.memory = sb.MemoryBytes
+			p.Resources.MemoryBytesQuota = q.memory
+		}
 		if entry := c.logger.Check(zapcore.DebugLevel, "physical plan"); entry != nil {
 			entry.Write(zap.String("plan", fmt.Sprint(plan.Formatted(q.plan))))
 		}
@@ -216,6 +280,51 @@ func (c *Controller) compileQuery(q *Query, compiler flux.Compiler) error {
 	return nil
 }
 
+// EvalTables plans and executes spec to completion, and calls f for every table it produces.
+// It executes the pipelines whose tables are read while a script is compiled or executed,
+// outside of the resources accounted to queries.
+func (c *Controller) EvalTables(ctx context.Context, spec *flux.Spec, f func(flux.Table) error) error {
+	// The sources of these pipelines are not part of the plan of the query, so they are not recorded,
+	// and their operators are not profiled.
+	ctx = execute.WithSourceRecorder(ctx, nil)
+	ctx = execute.WithProfiler(ctx, nil)
+	ip, err := c.lplanner.CreateInitialPlan(spec)
+	if err != nil {
+		return errors.Wrap(err, "failed to create initial logical plan")
+	}
+	ip.Flagger = dependencies.Get(ctx).Flagger
+	lp, err := c.lplanner.Plan(ip)
+	if err != nil {
+		return errors.Wrap(err, "failed to create logical plan")
+	}
+	p, err := c.pplanner.Plan(lp)
+	if err != nil {
+		return errors.Wrap(err, "failed to create physical plan")
+	}
+
+	alloc := &memory.Allocator{
+		Pool: memory.NewPool(memory.DefaultPoolLimit),
+	}
+	if sb := dependencies.Get(ctx).Sandbox; sb != nil && sb.MemoryBytes > 0 {
+		limit := sb.MemoryBytes
+		alloc.Limit = &limit
+	}
+
+	// Cancel the execution when f stops reading the tables early.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	results, err := c.executor.Execute(ctx, p, alloc)
+	if err != nil {
+		return errors.Wrap(err, "failed to execute query")
+	}
+	for _, r := range results {
+		if err := r.Tables().Do(f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (c *Controller) enqueueQuery(q *Query) error {
 	if entry := c.logger.Check(zapcore.DebugLevel, "queueing query"); entry != nil {
 		entry.Write(zap.String("spec", fmt.Sprint(flux.Formatted(&q.spec, flux.FmtJSON))))
@@ -225,7 +334,7 @@ func (c *Controller) enqueueQuery(q *Query) error {
 		return errors.New("failed to transition query to queueing state")
 	}
 	if err := q.spec.Validate(); err != nil {
-		return errors.Wrap(err, "invalid query")
+		return fluxerrors.Wrap(err, fluxerrors.Invalid, "invalid query")
 	}
 
 	// Count functions in query
@@ -236,7 +345,7 @@ func (c *Controller) enqueueQuery(q *Query) error {
 	case c.newQueries <- q:
 		return nil
 	case <-c.shutdownCtx.Done():
-		return fmt.Errorf("query controller shutdown")
+		return errShutdown
 	case <-q.parentCtx.Done():
 		return q.parentCtx.Err()
 	}
@@ -286,6 +395,11 @@ func (c *Controller) Queries() []*Query {
 // new queries and that it should finish executing any existing queries.
 // This will return once the Controller's run loop has been exited and all
 // queries have been finished or until the Context has been canceled.
+//
+// New queries are rejected with an error with the Unavailable code.
+// If the Context is canceled before the existing queries have finished,
+// they are canceled and they report an error with the Unavailable code too,
+// so that they can be told apart from the queries canceled by their users.
 func (c *Controller) Shutdown(ctx context.Context) error {
 	// Initiate the shutdown procedure by signaling to the run thread.
 	c.shutdown()
@@ -295,16 +409,21 @@ func (c *Controller) Shutdown(ctx context.Context) error {
 	case <-c.done:
 		return nil
 	case <-ctx.Done():
-		c.CancelAll()
+		c.cancelAll(errShutdown)
 		return ctx.Err()
 	}
 }
 
 // CancelAll cancels all executing queries.
 func (c *Controller) CancelAll() {
+	c.cancelAll(nil)
+}
+
+// cancelAll cancels all executing queries with the error, or with the error of their context if it is nil.
+func (c *Controller) cancelAll(err error) {
 	c.queriesMu.RLock()
 	for _, q := range c.queries {
-		q.Cancel()
+		q.cancelWithErr(err)
 	}
 	c.queriesMu.RUnlock()
 }
@@ -399,9 +518,9 @@ func (c *Controller) processQuery(q *Query) (pop bool, err error) {
 			// error type and create an error if it isn't.
 			switch e := e.(type) {
 			case error:
-				err = errors.Wrap(e, "panic")
+				err = fluxerrors.Wrap(e, fluxerrors.Internal, "panic")
 			default:
-				err = fmt.Errorf("panic: %s", e)
+				err = fluxerrors.Newf(fluxerrors.Internal, "panic: %s", e)
 			}
 			if entry := c.logger.Check(zapcore.InfoLevel, "Controller panic"); entry != nil {
 				entry.Stack = string(debug.Stack())
@@ -422,12 +541,24 @@ func (c *Controller) processQuery(q *Query) (pop bool, err error) {
 		if !q.tryExec() {
 			return true, errors.New("failed to transition query into executing state")
 		}
-		q.alloc = new(memory.Allocator)
+		q.alloc = &memory.Allocator{
+			Pool: memory.NewPool(memory.DefaultPoolLimit),
+		}
+		if sb := dependencies.Get(q.parentCtx).Sandbox; sb != nil && sb.MemoryBytes > 0 {
+			limit := sb.MemoryBytes
+			q.alloc.Limit = &limit
+		}
+		q.allocations = execute.NewKindAllocations()
 		// TODO: pass the plan to the executor here
-		r, err := c.executor.Execute(q.currentCtx, q.plan, q.alloc)
+		// Transformations such as experimental.tableMap() execute pipelines of their own.
+		ctx := execute.WithKindAllocations(flux.WithTablesEvaluator(q.currentCtx, c), q.allocations)
+		r, err := c.executor.Execute(ctx, q.plan, q.alloc)
 		if err != nil {
 			return true, errors.Wrap(err, "failed to execute query")
 		}
+		if c.usageResult {
+			r[UsageResultName] = &usageResult{usage: q.usage, alloc: q.alloc}
+		}
 		q.setResults(r)
 	} else {
 		// update state to queueing
@@ -481,6 +612,8 @@ type Query struct {
 	state   State
 	err     error
 	cancel  func()
+	// cancelErr is the error the query reports when it has been canceled, instead of the error of its context.
+	cancelErr error
 
 	parentCtx, currentCtx   context.Context
 	parentSpan, currentSpan *span
@@ -493,6 +626,17 @@ type Query struct {
 	memory      int64
 
 	alloc *memory.Allocator
+	// allocations records the memory of the operators of the query by their kind.
+	allocations *execute.KindAllocations
+
+	// flags records the feature flags consulted by the query.
+	flags *dependencies.FlagRecorder
+	// usage records the data scanned by the sources of the query.
+	usage *execute.UsageRecorder
+	// writes records the writes of the query to external systems.
+	writes *execute.WriteRecorder
+	// metadata records the metadata reported by the operators of the query.
+	metadata *execute.MetadataRecorder
 }
 
 // ID reports an ephemeral unique ID for the query.
@@ -516,6 +660,17 @@ func (q *Query) Cancel() {
 	q.cancel()
 }
 
+// cancelWithErr cancels the query, which reports err instead of the error of its context.
+// The first error a query is canceled with wins, and a nil error is ignored.
+func (q *Query) cancelWithErr(err error) {
+	q.stateMu.Lock()
+	if q.cancelErr == nil {
+		q.cancelErr = err
+	}
+	q.stateMu.Unlock()
+	q.cancel()
+}
+
 // Ready returns a channel that will deliver the query results.
 //
 // It's possible that the channel is closed before any results arrive.
@@ -528,12 +683,25 @@ func (q *Query) Ready() <-chan map[string]flux.Result {
 	return q.ready
 }
 
+// ResultNames reports the names of the results of the query in the order they were declared,
+// followed by UsageResultName if the controller appends the usage result. It implements flux.ResultNamer and is only complete once the query has been planned.
+func (q *Query) ResultNames() []string {
+	if q.plan == nil {
+		return nil
+	}
+	if q.c.usageResult {
+		return append(q.plan.Results[:len(q.plan.Results):len(q.plan.Results)], UsageResultName)
+	}
+	return q.plan.Results
+}
+
 // Done signals to the Controller that this query is no longer
 // being used and resources related to the query may be freed.
 //
 // The Ready method must have returned a result before calling
 // this method either by the query executing, being canceled, or
-// an error occurring.
+// an error occurring. Once the query is finished, Done calls the
+// post-execute hooks of the Controller.
 func (q *Query) Done() {
 	// We are not considered to be in the run loop anymore once
 	// this is called.
@@ -543,6 +711,7 @@ func (q *Query) Done() {
 		q.stateMu.Unlock()
 
 		q.c.queryDone <- q
+		q.c.postExecute(q)
 	})
 }
 
@@ -559,6 +728,22 @@ func (q *Query) Statistics() flux.Statistics {
 	if q.alloc != nil {
 		stats.MaxAllocated = q.alloc.MaxAllocated()
 	}
+	if flags := q.flags.Flags(); len(flags) > 0 {
+		stats.Flags = flags
+	}
+	stats.Metadata = make(flux.Metadata)
+	if q.allocations != nil {
+		stats.Metadata.AddAll(q.allocations.Metadata())
+	}
+	if q.usage != nil {
+		stats.Metadata.AddAll(q.usage.Usage().Metadata())
+	}
+	if q.metadata != nil {
+		stats.Metadata.AddAll(q.metadata.Metadata())
+	}
+	if q.writes != nil {
+		stats.Writes = q.writes.Writes()
+	}
 	return stats
 }
 
@@ -683,9 +868,14 @@ func (q *Query) isOK() bool {
 }
 
 // Err reports any error the query may have encountered.
+// A query that has been canceled because the controller is shutting down
+// reports an error with the Unavailable code.
 func (q *Query) Err() error {
 	q.stateMu.Lock()
 	err := q.err
+	if err == nil {
+		err = q.cancelErr
+	}
 	q.stateMu.Unlock()
 	return err
 }
@@ -707,6 +897,9 @@ func (q *Query) setErr(err error) {
 	case <-q.parentCtx.Done():
 		q.transitionTo(Canceled)
 		err = q.parentCtx.Err()
+		if q.cancelErr != nil {
+			err = q.cancelErr
+		}
 	default:
 		q.transitionTo(Errored)
 	}
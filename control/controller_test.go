@@ -2,12 +2,18 @@ package control
 
 import (
 	"context"
+	"fmt"
+	"math"
 	"sync"
 	"testing"
 	"time"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/influxdata/flux"
 	_ "github.com/influxdata/flux/builtin"
+	"github.com/influxdata/flux/dependencies"
+	fluxerrors "github.com/influxdata/flux/errors"
+	"github.com/influxdata/flux/execute"
 	"github.com/influxdata/flux/internal/pkg/syncutil"
 	"github.com/influxdata/flux/memory"
 	"github.com/influxdata/flux/mock"
@@ -17,6 +23,8 @@ import (
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
 	dto "github.com/prometheus/client_model/go"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
 )
 
 var mockCompiler *mock.Compiler
@@ -515,6 +523,58 @@ func TestController_CancelledContextPropagatesToExecutor(t *testing.T) {
 	}
 }
 
+func TestController_Sandbox(t *testing.T) {
+	t.Parallel()
+
+	var (
+		hasDeadline bool
+		limit       *int64
+		quota       int64
+	)
+	executor := mock.NewExecutor()
+	executor.ExecuteFn = func(ctx context.Context, p *plan.PlanSpec, alloc *memory.Allocator) (map[string]flux.Result, error) {
+		_, hasDeadline = ctx.Deadline()
+		limit = alloc.Limit
+		quota = p.Resources.MemoryBytesQuota
+		return nil, nil
+	}
+
+	ctrl := New(Config{
+		ConcurrencyQuota: 1,
+		MemoryBytesQuota: math.MaxInt64,
+	})
+	ctrl.executor = executor
+
+	cctx, ccancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer func() {
+		if err := ctrl.Shutdown(cctx); err != nil {
+			t.Fatal(err)
+		}
+		ccancel()
+	}()
+
+	ctx := dependencies.Inject(context.Background(), dependencies.NewBuilder().
+		WithSandbox(dependencies.Sandbox{WallTimeout: time.Minute, MemoryBytes: 1024}).
+		Build())
+	q, err := ctrl.Query(ctx, mockCompiler)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	<-q.Ready()
+	q.Done()
+
+	if !hasDeadline {
+		t.Error("expected the query context to have a deadline")
+	}
+	if limit == nil || *limit != 1024 {
+		t.Errorf("unexpected allocator limit: %v", limit)
+	}
+	// The executor sets the limit of the allocator to the quota of the plan.
+	if quota != 1024 {
+		t.Errorf("unexpected memory quota of the plan: %d", quota)
+	}
+}
+
 func TestController_Shutdown(t *testing.T) {
 	// Create a wait group that finishes when it attempts to execute.
 	// This is used to ensure that it is in the list of queries.
@@ -601,6 +661,39 @@ func TestController_Shutdown(t *testing.T) {
 	}
 }
 
+func TestController_Shutdown_Deadline(t *testing.T) {
+	ctrl := New(Config{})
+	ctrl.executor = mock.NewExecutor()
+
+	q, err := ctrl.Query(context.Background(), mockCompiler)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	<-q.Ready()
+
+	// The query is not released in time, so it is canceled when the deadline passes.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if got, want := ctrl.Shutdown(ctx), context.DeadlineExceeded; got != want {
+		t.Fatalf("unexpected error: got=%v want=%v", got, want)
+	}
+
+	if _, err := ctrl.Query(context.Background(), mockCompiler); fluxerrors.CodeOf(err) != fluxerrors.Unavailable {
+		t.Fatalf("expected an unavailable error for a new query, got %v", err)
+	}
+	if err := q.Err(); fluxerrors.CodeOf(err) != fluxerrors.Unavailable {
+		t.Fatalf("expected an unavailable error for the canceled query, got %v", err)
+	}
+
+	// The controller exits once the canceled query is released.
+	q.Done()
+	select {
+	case <-ctrl.done:
+	case <-time.After(time.Second):
+		t.Fatal("controller did not exit after the last query was released")
+	}
+}
+
 func TestController_Statistics(t *testing.T) {
 	ctrl := New(Config{})
 	ctrl.executor = mock.NewExecutor()
@@ -634,3 +727,420 @@ func TestController_Statistics(t *testing.T) {
 		t.Fatal("total duration should be greater than zero")
 	}
 }
+
+func TestController_StatisticsFlags(t *testing.T) {
+	executor := mock.NewExecutor()
+	executor.ExecuteFn = func(ctx context.Context, p *plan.PlanSpec, a *memory.Allocator) (map[string]flux.Result, error) {
+		if !p.Flagger.Enabled("planFlag") {
+			return nil, errors.New("expected the flag of the plan to be enabled")
+		}
+		// Flags that are not enabled are recorded too.
+		if dependencies.Get(ctx).Flagger.Enabled("executeFlag") {
+			return nil, errors.New("expected the flag of the execution to be disabled")
+		}
+		return nil, nil
+	}
+
+	ctrl := New(Config{})
+	ctrl.executor = executor
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer func() {
+		if err := ctrl.Shutdown(ctx); err != nil {
+			t.Fatal(err)
+		}
+		cancel()
+	}()
+
+	qctx := dependencies.Inject(context.Background(), dependencies.NewBuilder().
+		WithFlagger(dependencies.Flags{"planFlag": true, "unusedFlag": true}).
+		Build())
+	q, err := ctrl.Query(qctx, mockCompiler)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	<-q.Ready()
+	q.Done()
+	if err := q.Err(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	want := map[string]bool{"planFlag": true, "executeFlag": false}
+	if got := q.Statistics().Flags; !cmp.Equal(want, got) {
+		t.Fatalf("unexpected flags -want/+got\n%s", cmp.Diff(want, got))
+	}
+}
+
+func TestController_StatisticsMaxAllocatedByKind(t *testing.T) {
+	compiler := new(mock.Compiler)
+	compiler.CompileFn = func(ctx context.Context) (*flux.Spec, error) {
+		return flux.Compile(ctx, `import "generate" generate.from(count: 100, fn: (n) => n) |> sort(desc: true)`, time.Now())
+	}
+
+	ctrl := New(Config{
+		ConcurrencyQuota: 1,
+		MemoryBytesQuota: math.MaxInt64,
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer func() {
+		if err := ctrl.Shutdown(ctx); err != nil {
+			t.Fatal(err)
+		}
+		cancel()
+	}()
+
+	q, err := ctrl.Query(context.Background(), compiler)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	for _, r := range <-q.Ready() {
+		if err := r.Tables().Do(func(tbl flux.Table) error {
+			return tbl.Do(func(flux.ColReader) error { return nil })
+		}); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+	q.Done()
+	if err := q.Err(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	stats := q.Statistics()
+	values := stats.Metadata[execute.MaxAllocatedMetadataPrefix+string(universe.SortKind)]
+	if len(values) != 1 {
+		t.Fatalf("expected the memory of the sort operators in the metadata, got %v", stats.Metadata)
+	}
+	if n := values[0].(int64); n == 0 || n > stats.MaxAllocated {
+		t.Fatalf("unexpected memory of the sort operators: %d bytes, the query allocated %d bytes", n, stats.MaxAllocated)
+	}
+}
+
+func TestController_Usage(t *testing.T) {
+	const csv = `
+#datatype,string,long,string,long
+#group,false,false,true,false
+#default,_result,,,
+,result,table,host,_value
+,,0,a,1
+,,0,a,2
+,,1,b,3
+`
+	compiler := new(mock.Compiler)
+	compiler.CompileFn = func(ctx context.Context) (*flux.Spec, error) {
+		return flux.Compile(ctx, fmt.Sprintf(`import "csv" csv.from(csv: %q)`, csv), time.Now())
+	}
+
+	ctrl := New(Config{
+		ConcurrencyQuota: 1,
+		MemoryBytesQuota: math.MaxInt64,
+		UsageResult:      true,
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer func() {
+		if err := ctrl.Shutdown(ctx); err != nil {
+			t.Fatal(err)
+		}
+		cancel()
+	}()
+
+	q, err := ctrl.Query(context.Background(), compiler)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	var (
+		names []string
+		usage []int64
+	)
+	results := flux.NewResultIteratorFromQuery(q)
+	for results.More() {
+		r := results.Next()
+		names = append(names, r.Name())
+		if err := r.Tables().Do(func(tbl flux.Table) error {
+			return tbl.Do(func(cr flux.ColReader) error {
+				if r.Name() != UsageResultName {
+					return nil
+				}
+				for j := range cr.Cols() {
+					usage = append(usage, cr.Ints(j).Value(0))
+				}
+				return nil
+			})
+		}); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+	results.Release()
+	if err := results.Err(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if want := []string{"_result", UsageResultName}; !cmp.Equal(want, names) {
+		t.Fatalf("unexpected results -want/+got\n%s", cmp.Diff(want, names))
+	}
+	want := []int64{int64(len(csv)), 2, 3}
+	if !cmp.Equal(want, usage) {
+		t.Fatalf("unexpected usage result -want/+got\n%s", cmp.Diff(want, usage))
+	}
+	md := q.Statistics().Metadata
+	got := []int64{
+		md[execute.BytesScannedMetadataKey][0].(int64),
+		md[execute.SeriesScannedMetadataKey][0].(int64),
+		md[execute.PointsScannedMetadataKey][0].(int64),
+	}
+	if !cmp.Equal(want, got) {
+		t.Fatalf("unexpected usage metadata -want/+got\n%s", cmp.Diff(want, got))
+	}
+}
+
+func TestController_StatisticsWrites(t *testing.T) {
+	write := flux.Write{Function: "to", Destination: "org/bucket", Rows: 10, Status: "ok"}
+	executor := mock.NewExecutor()
+	executor.ExecuteFn = func(ctx context.Context, p *plan.PlanSpec, a *memory.Allocator) (map[string]flux.Result, error) {
+		execute.RecordWrite(ctx, write)
+		return nil, nil
+	}
+
+	ctrl := New(Config{})
+	ctrl.executor = executor
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer func() {
+		if err := ctrl.Shutdown(ctx); err != nil {
+			t.Fatal(err)
+		}
+		cancel()
+	}()
+
+	q, err := ctrl.Query(context.Background(), mockCompiler)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	<-q.Ready()
+	q.Done()
+	if err := q.Err(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if want, got := []flux.Write{write}, q.Statistics().Writes; !cmp.Equal(want, got) {
+		t.Fatalf("unexpected writes -want/+got\n%s", cmp.Diff(want, got))
+	}
+}
+
+func TestController_StatisticsGroup(t *testing.T) {
+	const csv = `
+#datatype,string,long,string,string,long
+#group,false,false,true,false,false
+#default,_result,,,,
+,result,table,host,region,_value
+,,0,a,east,1
+,,0,a,west,2
+,,1,b,east,3
+`
+	compiler := new(mock.Compiler)
+	compiler.CompileFn = func(ctx context.Context) (*flux.Spec, error) {
+		return flux.Compile(ctx, fmt.Sprintf(`import "csv" csv.from(csv: %q) |> group(columns: ["region"])`, csv), time.Now())
+	}
+
+	ctrl := New(Config{
+		ConcurrencyQuota: 1,
+		MemoryBytesQuota: math.MaxInt64,
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer func() {
+		if err := ctrl.Shutdown(ctx); err != nil {
+			t.Fatal(err)
+		}
+		cancel()
+	}()
+
+	q, err := ctrl.Query(context.Background(), compiler)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	results := flux.NewResultIteratorFromQuery(q)
+	for results.More() {
+		if err := results.Next().Tables().Do(func(flux.Table) error { return nil }); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+	results.Release()
+	if err := results.Err(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	md := q.Statistics().Metadata
+	want := []interface{}{2, 3, 2}
+	got := []interface{}{
+		md[universe.GroupTablesMetadataKey],
+		md[universe.GroupRowsMetadataKey],
+		md[universe.GroupGroupsMetadataKey],
+	}
+	for i := range got {
+		if values, ok := got[i].([]interface{}); ok && len(values) == 1 {
+			got[i] = values[0]
+		}
+	}
+	if !cmp.Equal(want, got) {
+		t.Fatalf("unexpected group metadata -want/+got\n%s", cmp.Diff(want, got))
+	}
+}
+
+func TestController_LoggerQueryID(t *testing.T) {
+	executor := mock.NewExecutor()
+	executor.ExecuteFn = func(ctx context.Context, p *plan.PlanSpec, a *memory.Allocator) (map[string]flux.Result, error) {
+		dependencies.Get(ctx).Logger.Info("executing")
+		return nil, nil
+	}
+
+	ctrl := New(Config{})
+	ctrl.executor = executor
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer func() {
+		if err := ctrl.Shutdown(ctx); err != nil {
+			t.Fatal(err)
+		}
+		cancel()
+	}()
+
+	core, logs := observer.New(zap.InfoLevel)
+	qctx := dependencies.Inject(context.Background(), dependencies.NewBuilder().
+		WithLogger(zap.New(core)).
+		Build())
+	q, err := ctrl.Query(qctx, mockCompiler)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	<-q.Ready()
+	q.Done()
+	if err := q.Err(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	entries := logs.FilterMessage("executing").All()
+	if len(entries) != 1 {
+		t.Fatalf("expected one log entry, got %v", logs.All())
+	}
+	if want, got := uint64(q.(*Query).ID()), entries[0].ContextMap()["query_id"]; want != got {
+		t.Fatalf("unexpected query ID: want %v, got %v", want, got)
+	}
+}
+
+func TestController_Hooks(t *testing.T) {
+	type auditKey struct{}
+
+	executor := mock.NewExecutor()
+	executor.ExecuteFn = func(ctx context.Context, p *plan.PlanSpec, a *memory.Allocator) (map[string]flux.Result, error) {
+		if got := ctx.Value(auditKey{}); got != "alice" {
+			return nil, errors.Errorf("unexpected context value %v", got)
+		}
+		return nil, nil
+	}
+
+	ctrl := New(Config{})
+	ctrl.executor = executor
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer func() {
+		if err := ctrl.Shutdown(ctx); err != nil {
+			t.Fatal(err)
+		}
+		cancel()
+	}()
+
+	var (
+		mu      sync.Mutex
+		planned []plan.NodeID
+		audited []string
+	)
+	ctrl.AddPreCompileHook(func(ctx context.Context, compiler flux.Compiler) (context.Context, error) {
+		if compiler == nil {
+			return nil, errors.New("no compiler")
+		}
+		return context.WithValue(ctx, auditKey{}, "alice"), nil
+	})
+	ctrl.AddPostPlanHook(func(ctx context.Context, p *plan.PlanSpec) error {
+		mu.Lock()
+		defer mu.Unlock()
+		for root := range p.Roots {
+			planned = append(planned, root.ID())
+		}
+		if len(p.Roots) != 1 {
+			return errors.New("expected a single root")
+		}
+		return nil
+	})
+	ctrl.AddPostExecuteHook(func(ctx context.Context, q *Query) {
+		mu.Lock()
+		defer mu.Unlock()
+		audited = append(audited, fmt.Sprintf("%v %v %v", ctx.Value(auditKey{}), q.State(), q.Err()))
+	})
+
+	q, err := ctrl.Query(context.Background(), mockCompiler)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	<-q.Ready()
+	q.Done()
+	if err := q.Err(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(planned) != 1 {
+		t.Fatalf("expected the post-plan hook to be called once, got %v", planned)
+	}
+	if want := []string{"alice finished <nil>"}; !cmp.Equal(want, audited) {
+		t.Fatalf("unexpected audit records -want/+got\n%s", cmp.Diff(want, audited))
+	}
+}
+
+func TestController_Hooks_Reject(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		add  func(ctrl *Controller)
+		want string
+	}{
+		{
+			name: "pre-compile",
+			add: func(ctrl *Controller) {
+				ctrl.AddPreCompileHook(func(ctx context.Context, compiler flux.Compiler) (context.Context, error) {
+					return nil, errors.New("unauthorized")
+				})
+			},
+			want: "query rejected: unauthorized",
+		},
+		{
+			name: "post-plan",
+			add: func(ctrl *Controller) {
+				ctrl.AddPostPlanHook(func(ctx context.Context, p *plan.PlanSpec) error {
+					return errors.New("too expensive")
+				})
+			},
+			want: "plan rejected: too expensive",
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := New(Config{})
+			ctrl.executor = mock.NewExecutor()
+			tc.add(ctrl)
+
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer func() {
+				if err := ctrl.Shutdown(ctx); err != nil {
+					t.Fatal(err)
+				}
+				cancel()
+			}()
+
+			if _, err := ctrl.Query(context.Background(), mockCompiler); err == nil || err.Error() != tc.want {
+				t.Fatalf("unexpected error: want %q, got %v", tc.want, err)
+			}
+			if want, got := 0, len(ctrl.Queries()); want != got {
+				t.Fatalf("unexpected query count: want %d, got %d", want, got)
+			}
+		})
+	}
+}
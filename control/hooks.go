@@ -0,0 +1,88 @@
+package control
+
+import (
+	"context"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/plan"
+	"github.com/pkg/errors"
+)
+
+// PreCompileHook is called before a query is compiled.
+// It may return a context that replaces the context of the query,
+// for example to inject modified dependencies with dependencies.Inject.
+// An error rejects the query before it is compiled.
+type PreCompileHook func(ctx context.Context, compiler flux.Compiler) (context.Context, error)
+
+// PostPlanHook is called with the physical plan of a query before it is queued.
+// An error rejects the query, and the plan is not executed.
+type PostPlanHook func(ctx context.Context, p *plan.PlanSpec) error
+
+// PostExecuteHook is called once a query returned by Query has been released with Done,
+// for example to record its statistics and error for auditing.
+// The context is the context of the query, which may have been canceled.
+type PostExecuteHook func(ctx context.Context, q *Query)
+
+// hooks are the hooks registered on a controller, which are called in the order they were added.
+type hooks struct {
+	preCompile  []PreCompileHook
+	postPlan    []PostPlanHook
+	postExecute []PostExecuteHook
+}
+
+// AddPreCompileHook registers a hook that is called before every query is compiled.
+func (c *Controller) AddPreCompileHook(h PreCompileHook) {
+	c.hooksMu.Lock()
+	defer c.hooksMu.Unlock()
+	c.hooks.preCompile = append(c.hooks.preCompile, h)
+}
+
+// AddPostPlanHook registers a hook that is called with the physical plan of every query.
+func (c *Controller) AddPostPlanHook(h PostPlanHook) {
+	c.hooksMu.Lock()
+	defer c.hooksMu.Unlock()
+	c.hooks.postPlan = append(c.hooks.postPlan, h)
+}
+
+// AddPostExecuteHook registers a hook that is called once every query is done.
+func (c *Controller) AddPostExecuteHook(h PostExecuteHook) {
+	c.hooksMu.Lock()
+	defer c.hooksMu.Unlock()
+	c.hooks.postExecute = append(c.hooks.postExecute, h)
+}
+
+func (c *Controller) currentHooks() hooks {
+	c.hooksMu.RLock()
+	defer c.hooksMu.RUnlock()
+	return c.hooks
+}
+
+// preCompile calls the pre-compile hooks in turn, each with the context returned by the previous one.
+// It returns the context returned by the last successful hook along with any error.
+func (c *Controller) preCompile(ctx context.Context, compiler flux.Compiler) (context.Context, error) {
+	for _, h := range c.currentHooks().preCompile {
+		hctx, err := h(ctx, compiler)
+		if err != nil {
+			return ctx, errors.Wrap(err, "query rejected")
+		}
+		if hctx != nil {
+			ctx = hctx
+		}
+	}
+	return ctx, nil
+}
+
+func (c *Controller) postPlan(ctx context.Context, p *plan.PlanSpec) error {
+	for _, h := range c.currentHooks().postPlan {
+		if err := h(ctx, p); err != nil {
+			return errors.Wrap(err, "plan rejected")
+		}
+	}
+	return nil
+}
+
+func (c *Controller) postExecute(q *Query) {
+	for _, h := range c.currentHooks().postExecute {
+		h(q.parentCtx, q)
+	}
+}
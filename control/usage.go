@@ -0,0 +1,58 @@
+package control
+
+import (
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/memory"
+)
+
+// UsageResultName is the name of the result that the controller appends to the results of the queries
+// when Config.UsageResult is set.
+const UsageResultName = "_usage"
+
+// The columns of the table of the usage result.
+const (
+	BytesScannedColLabel  = "bytes_scanned"
+	SeriesScannedColLabel = "series_scanned"
+	PointsScannedColLabel = "points_scanned"
+)
+
+// usageResult has a single table with a single row, with the data that the sources of the query
+// had scanned when the table is read. The results of a query are ordered with the usage result last,
+// so that the other results have been read, and their data scanned, before it is.
+type usageResult struct {
+	usage *execute.UsageRecorder
+	alloc *memory.Allocator
+}
+
+func (r *usageResult) Name() string {
+	return UsageResultName
+}
+
+func (r *usageResult) Tables() flux.TableIterator {
+	return r
+}
+
+func (r *usageResult) Do(f func(flux.Table) error) error {
+	builder := execute.NewColListTableBuilder(execute.NewGroupKey(nil, nil), r.alloc)
+	usage := r.usage.Usage()
+	for _, label := range []string{BytesScannedColLabel, SeriesScannedColLabel, PointsScannedColLabel} {
+		if _, err := builder.AddCol(flux.ColMeta{Label: label, Type: flux.TInt}); err != nil {
+			return err
+		}
+	}
+	for j, v := range []int64{usage.BytesScanned, usage.SeriesScanned, usage.PointsScanned} {
+		if err := builder.AppendInt(j, v); err != nil {
+			return err
+		}
+	}
+	tbl, err := builder.Table()
+	if err != nil {
+		return err
+	}
+	return f(tbl)
+}
+
+func (r *usageResult) Statistics() flux.Statistics {
+	return flux.Statistics{}
+}
@@ -11,6 +11,7 @@ import (
 	"time"
 	"unicode/utf8"
 
+	"github.com/apache/arrow/go/arrow/array"
 	"github.com/influxdata/flux"
 	"github.com/influxdata/flux/execute"
 	"github.com/influxdata/flux/iocounter"
@@ -31,6 +32,7 @@ const (
 	datatypeAnnotation = "datatype"
 	groupAnnotation    = "group"
 	defaultAnnotation  = "default"
+	warningAnnotation  = "warning"
 
 	resultLabel = "result"
 	tableLabel  = "table"
@@ -44,9 +46,24 @@ const (
 	intDatatype    = "long"
 	uintDatatype   = "unsignedLong"
 
-	timeDataTypeWithFmt = "dateTime:RFC3339"
+	timeDataTypeWithFmt     = "dateTime:RFC3339"
+	timeDataTypeWithNanoFmt = "dateTime:RFC3339Nano"
 
 	nullValue = ""
+
+	// DefaultChunkSize is the default number of bytes the encoder buffers
+	// before writing them to the underlying writer.
+	DefaultChunkSize = 32 * 1024
+)
+
+// DateTimeFormat is the format used to encode time values.
+type DateTimeFormat string
+
+const (
+	// RFC3339 encodes times with second precision.
+	RFC3339 DateTimeFormat = "RFC3339"
+	// RFC3339Nano encodes times with nanosecond precision.
+	RFC3339Nano DateTimeFormat = "RFC3339Nano"
 )
 
 // ResultDecoder decodes a csv representation of a result.
@@ -305,6 +322,10 @@ func readMetadata(r *csv.Reader, c ResultDecoderConfig, extraLine []string) (tab
 			}
 			line = l
 		}
+		if len(line) > annotationIdx && line[annotationIdx] == commentPrefix+warningAnnotation {
+			// Warnings belong to the preceding result and carry no table metadata.
+			continue
+		}
 		if n == -1 {
 			n = len(line)
 		}
@@ -683,17 +704,32 @@ type ResultEncoderConfig struct {
 	// Delimiter is the character to delimite columns.
 	// It must not be \r, \n, or the Unicode replacement character (0xFFFD).
 	Delimiter rune
+
+	// DateTimeFormat is the format of time values.
+	// When empty, times are annotated as RFC3339 and encoded with nanosecond precision.
+	DateTimeFormat DateTimeFormat
+
+	// ChunkSize is the maximum number of bytes buffered before they are written to the underlying writer.
+	// If the writer implements Flush, it is flushed after every chunk.
+	// A value of zero means DefaultChunkSize is used.
+	ChunkSize int
+
+	// NaN is the policy for the NaN and infinite values of the float columns.
+	// With execute.NaNDrop they are encoded as nulls, with execute.NaNError the encoding fails.
+	NaN execute.NaNPolicy
 }
 
 func (c ResultEncoderConfig) MarshalJSON() ([]byte, error) {
 	request := struct {
-		Header      bool     `json:"header,omitempty"`
-		Delimiter   string   `json:"delimiter"`
-		Annotations []string `json:"annotations,omitempty"`
+		Header         bool           `json:"header,omitempty"`
+		Delimiter      string         `json:"delimiter"`
+		Annotations    []string       `json:"annotations,omitempty"`
+		DateTimeFormat DateTimeFormat `json:"dateTimeFormat,omitempty"`
 	}{
-		Delimiter:   string(c.Delimiter),
-		Annotations: c.Annotations,
-		Header:      !c.NoHeader,
+		Delimiter:      string(c.Delimiter),
+		Annotations:    c.Annotations,
+		Header:         !c.NoHeader,
+		DateTimeFormat: c.DateTimeFormat,
 	}
 
 	return json.Marshal(request)
@@ -701,15 +737,38 @@ func (c ResultEncoderConfig) MarshalJSON() ([]byte, error) {
 
 func (c *ResultEncoderConfig) UnmarshalJSON(b []byte) error {
 	request := &struct {
-		Header      *bool    `json:"header,omitempty"`
-		Delimiter   string   `json:"delimiter"`
-		Annotations []string `json:"annotations,omitempty"`
+		Header         *bool          `json:"header,omitempty"`
+		Delimiter      string         `json:"delimiter"`
+		Annotations    []string       `json:"annotations,omitempty"`
+		DateTimeFormat DateTimeFormat `json:"dateTimeFormat,omitempty"`
+		NaN            string         `json:"nan,omitempty"`
 	}{}
 
 	if err := json.Unmarshal(b, request); err != nil {
 		return err
 	}
 
+	switch request.DateTimeFormat {
+	case "", RFC3339, RFC3339Nano:
+		c.DateTimeFormat = request.DateTimeFormat
+	default:
+		return fmt.Errorf("unsupported dateTimeFormat %q", request.DateTimeFormat)
+	}
+
+	p, err := execute.ParseNaNPolicy(request.NaN)
+	if err != nil {
+		return err
+	}
+	c.NaN = p
+
+	for _, anno := range request.Annotations {
+		switch anno {
+		case datatypeAnnotation, groupAnnotation, defaultAnnotation:
+		default:
+			return fmt.Errorf("unsupported annotation %q", anno)
+		}
+	}
+
 	if request.Delimiter == "" {
 		request.Delimiter = ","
 	}
@@ -738,6 +797,18 @@ func NewResultEncoder(c ResultEncoderConfig) *ResultEncoder {
 	}
 }
 
+// timeFormat reports the layout used to encode times and the matching datatype annotation.
+func (c *ResultEncoderConfig) timeFormat() (layout, datatype string) {
+	switch c.DateTimeFormat {
+	case RFC3339:
+		return time.RFC3339, timeDataTypeWithFmt
+	case RFC3339Nano:
+		return time.RFC3339Nano, timeDataTypeWithNanoFmt
+	default:
+		return time.RFC3339Nano, timeDataTypeWithFmt
+	}
+}
+
 func (e *ResultEncoder) csvWriter(w io.Writer) *csv.Writer {
 	writer := csv.NewWriter(w)
 	if e.c.Delimiter != 0 {
@@ -778,7 +849,13 @@ func (e *ResultEncoder) Encode(w io.Writer, result flux.Result) (int64, error) {
 		{ColMeta: flux.ColMeta{Label: tableLabel, Type: flux.TInt}},
 	}
 	writeCounter := &iocounter.Writer{Writer: w}
-	writer := e.csvWriter(writeCounter)
+	chunkSize := e.c.ChunkSize
+	if chunkSize <= 0 {
+		chunkSize = DefaultChunkSize
+	}
+	chunks := newChunkWriter(writeCounter, chunkSize)
+	writer := e.csvWriter(chunks)
+	timeFmt, _ := e.c.timeFormat()
 
 	var lastCols []colMeta
 	var lastEmpty bool
@@ -790,7 +867,7 @@ func (e *ResultEncoder) Encode(w io.Writer, result flux.Result) (int64, error) {
 		for _, c := range tbl.Cols() {
 			cm := colMeta{ColMeta: c}
 			if c.Type == flux.TTime {
-				cm.fmt = time.RFC3339Nano
+				cm.fmt = timeFmt
 			}
 			cols = append(cols, cm)
 		}
@@ -823,9 +900,19 @@ func (e *ResultEncoder) Encode(w io.Writer, result flux.Result) (int64, error) {
 					row[j] = ""
 				}
 			}
+		} else {
+			// Without default annotations every row must carry its result name and table ID.
+			row[annotationIdx] = ""
+			row[resultIdx] = result.Name()
+			row[tableIdx] = tableIDStr
 		}
 
 		err := tbl.Do(func(cr flux.ColReader) error {
+			cr, release, err := applyNaNPolicy(e.c.NaN, cr)
+			if err != nil {
+				return err
+			}
+			defer release()
 			record := row[recordStartIdx:]
 			l := cr.Len()
 			for i := 0; i < l; i++ {
@@ -856,6 +943,14 @@ func (e *ResultEncoder) Encode(w io.Writer, result flux.Result) (int64, error) {
 		}
 		return nil
 	})
+	if err == nil {
+		err = writeWarnings(writer, result, len(lastCols) > 0)
+	}
+	// Write out anything that is still buffered, even when encoding failed,
+	// so that the output is complete up to the point of the error.
+	if ferr := chunks.Flush(); ferr != nil && err == nil {
+		err = wrapEncodingError(ferr)
+	}
 	return writeCounter.Count(), err
 }
 
@@ -883,6 +978,30 @@ func (e *ResultEncoder) EncodeError(w io.Writer, err error) error {
 	return writer.Error()
 }
 
+// writeWarnings writes any warnings of the result as warning annotations following its tables.
+func writeWarnings(writer *csv.Writer, result flux.Result, written bool) error {
+	w, ok := result.(flux.Warner)
+	if !ok {
+		return nil
+	}
+	warnings := w.Warnings()
+	if len(warnings) == 0 {
+		return nil
+	}
+	if written {
+		// Write out empty line to separate the warnings from the last table
+		writer.Write(nil)
+	}
+	for _, warning := range warnings {
+		writer.Write([]string{commentPrefix + warningAnnotation, result.Name(), warning.Node, warning.Message})
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return wrapEncodingError(err)
+	}
+	return nil
+}
+
 func writeSchema(writer *csv.Writer, c *ResultEncoderConfig, row []string, cols []colMeta, useKeyDefaults bool, key flux.GroupKey, resultName, tableID string) error {
 	defaults := make([]string, len(row))
 	for j, c := range cols {
@@ -914,7 +1033,8 @@ func writeSchema(writer *csv.Writer, c *ResultEncoderConfig, row []string, cols
 		}
 	}
 	// TODO: use real result name
-	if err := writeAnnotations(writer, c.Annotations, row, defaults, cols, key); err != nil {
+	_, timeDatatype := c.timeFormat()
+	if err := writeAnnotations(writer, c.Annotations, timeDatatype, row, defaults, cols, key); err != nil {
 		return err
 	}
 
@@ -928,11 +1048,11 @@ func writeSchema(writer *csv.Writer, c *ResultEncoderConfig, row []string, cols
 	return writer.Error()
 }
 
-func writeAnnotations(writer *csv.Writer, annotations []string, row, defaults []string, cols []colMeta, key flux.GroupKey) error {
+func writeAnnotations(writer *csv.Writer, annotations []string, timeDatatype string, row, defaults []string, cols []colMeta, key flux.GroupKey) error {
 	for _, annotation := range annotations {
 		switch annotation {
 		case datatypeAnnotation:
-			if err := writeDatatypes(writer, row, cols); err != nil {
+			if err := writeDatatypes(writer, row, cols, timeDatatype); err != nil {
 				return err
 			}
 		case groupAnnotation:
@@ -950,7 +1070,7 @@ func writeAnnotations(writer *csv.Writer, annotations []string, row, defaults []
 	return writer.Error()
 }
 
-func writeDatatypes(writer *csv.Writer, row []string, cols []colMeta) error {
+func writeDatatypes(writer *csv.Writer, row []string, cols []colMeta, timeDatatype string) error {
 	for j, c := range cols {
 		if j == annotationIdx {
 			row[j] = commentPrefix + datatypeAnnotation
@@ -968,7 +1088,7 @@ func writeDatatypes(writer *csv.Writer, row []string, cols []colMeta) error {
 		case flux.TString:
 			row[j] = stringDatatype
 		case flux.TTime:
-			row[j] = timeDataTypeWithFmt
+			row[j] = timeDatatype
 		default:
 			return fmt.Errorf("unknown column type %v", c.Type)
 		}
@@ -1083,6 +1203,47 @@ func decodeValueInto(j int, c colMeta, value string, builder execute.TableBuilde
 	}
 }
 
+// floatsColReader replaces the float columns of a ColReader.
+type floatsColReader struct {
+	flux.ColReader
+	floats map[int]*array.Float64
+}
+
+func (cr floatsColReader) Floats(j int) *array.Float64 {
+	if vs, ok := cr.floats[j]; ok {
+		return vs
+	}
+	return cr.ColReader.Floats(j)
+}
+
+// applyNaNPolicy applies a NaN policy to the float columns of a ColReader.
+// The returned function releases the columns it has replaced.
+func applyNaNPolicy(p execute.NaNPolicy, cr flux.ColReader) (flux.ColReader, func(), error) {
+	floats := make(map[int]*array.Float64)
+	release := func() {
+		for _, vs := range floats {
+			vs.Release()
+		}
+	}
+	for j, c := range cr.Cols() {
+		if c.Type != flux.TFloat {
+			continue
+		}
+		vs, err := p.NullFloats(c.Label, cr.Floats(j))
+		if err != nil {
+			release()
+			return nil, nil, err
+		}
+		if vs != cr.Floats(j) {
+			floats[j] = vs
+		}
+	}
+	if len(floats) == 0 {
+		return cr, release, nil
+	}
+	return floatsColReader{ColReader: cr, floats: floats}, release, nil
+}
+
 func encodeValue(value values.Value, c colMeta) (string, error) {
 	if value.IsNull() {
 		return nullValue, nil
@@ -1196,6 +1357,50 @@ func equalCols(a, b []colMeta) bool {
 	return true
 }
 
+// chunkWriter buffers writes and passes them on to the underlying writer
+// in chunks of at most size bytes, flushing the writer after each chunk.
+type chunkWriter struct {
+	w   *iocounter.Writer
+	buf []byte
+}
+
+func newChunkWriter(w *iocounter.Writer, size int) *chunkWriter {
+	return &chunkWriter{
+		w:   w,
+		buf: make([]byte, 0, size),
+	}
+}
+
+func (w *chunkWriter) Write(p []byte) (int, error) {
+	written := 0
+	for len(p) > 0 {
+		n := copy(w.buf[len(w.buf):cap(w.buf)], p)
+		w.buf = w.buf[:len(w.buf)+n]
+		p = p[n:]
+		written += n
+		if len(w.buf) == cap(w.buf) {
+			if err := w.Flush(); err != nil {
+				return written, err
+			}
+		}
+	}
+	return written, nil
+}
+
+// Flush writes any buffered data to the underlying writer and flushes it.
+func (w *chunkWriter) Flush() error {
+	if len(w.buf) == 0 {
+		return nil
+	}
+	_, err := w.w.Write(w.buf)
+	w.buf = w.buf[:0]
+	if err != nil {
+		return err
+	}
+	w.w.Flush()
+	return nil
+}
+
 func NewMultiResultEncoder(c ResultEncoderConfig) flux.MultiResultEncoder {
 	return &flux.DelimitedMultiResultEncoder{
 		Delimiter: []byte("\r\n"),
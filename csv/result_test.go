@@ -2,7 +2,9 @@ package csv_test
 
 import (
 	"bytes"
+	"encoding/json"
 	"io/ioutil"
+	"math"
 	"regexp"
 	"testing"
 	"time"
@@ -11,6 +13,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/influxdata/flux"
 	"github.com/influxdata/flux/csv"
+	"github.com/influxdata/flux/execute"
 	"github.com/influxdata/flux/execute/executetest"
 	"github.com/influxdata/flux/values"
 	"github.com/pkg/errors"
@@ -554,6 +557,30 @@ func TestResultDecoder(t *testing.T) {
 				}},
 			},
 		},
+		{
+			name:          "single table with warnings",
+			encoderConfig: csv.DefaultEncoderConfig(),
+			encoded: toCRLF(`#datatype,string,long,dateTime:RFC3339,double
+#group,false,false,false,false
+#default,_result,0,,
+,result,table,_time,_value
+,,,2018-04-17T00:00:00Z,42.0
+
+#warning,_result,filter0,ignored 2 rows with null values
+`),
+			result: &executetest.Result{
+				Nm: "_result",
+				Tbls: []*executetest.Table{{
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_value", Type: flux.TFloat},
+					},
+					Data: [][]interface{}{
+						{values.ConvertTime(time.Date(2018, 4, 17, 0, 0, 0, 0, time.UTC)), 42.0},
+					},
+				}},
+			},
+		},
 		{
 			name:          "single table with unnecessary default tableID",
 			encoderConfig: csv.DefaultEncoderConfig(),
@@ -670,6 +697,102 @@ func TestResultDecoder(t *testing.T) {
 func TestResultEncoder(t *testing.T) {
 	testCases := []TestCase{
 		// Add tests cases specific to encoding here
+		{
+			name: "no annotations with semicolon delimiter",
+			encoderConfig: csv.ResultEncoderConfig{
+				Delimiter: ';',
+			},
+			encoded: toCRLF(`;result;table;_time;_value
+;_result;0;2018-04-17T00:00:00.5Z;42
+`),
+			result: &executetest.Result{
+				Nm: "_result",
+				Tbls: []*executetest.Table{{
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_value", Type: flux.TFloat},
+					},
+					Data: [][]interface{}{
+						{values.ConvertTime(time.Date(2018, 4, 17, 0, 0, 0, 5e8, time.UTC)), 42.0},
+					},
+				}},
+			},
+		},
+		{
+			name: "warnings after tables",
+			encoderConfig: csv.ResultEncoderConfig{
+				Annotations: []string{"datatype"},
+			},
+			encoded: toCRLF(`#datatype,string,long,dateTime:RFC3339,double
+,result,table,_time,_value
+,_result,0,2018-04-17T00:00:00Z,42
+
+#warning,_result,filter0,ignored 2 rows with null values
+#warning,_result,map1,"value was truncated, precision lost"
+`),
+			result: &executetest.Result{
+				Nm: "_result",
+				Tbls: []*executetest.Table{{
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_value", Type: flux.TFloat},
+					},
+					Data: [][]interface{}{
+						{values.ConvertTime(time.Date(2018, 4, 17, 0, 0, 0, 0, time.UTC)), 42.0},
+					},
+				}},
+				Warns: []flux.Warning{
+					{Node: "filter0", Message: "ignored 2 rows with null values"},
+					{Node: "map1", Message: "value was truncated, precision lost"},
+				},
+			},
+		},
+		{
+			name: "RFC3339 date time format",
+			encoderConfig: csv.ResultEncoderConfig{
+				Annotations:    []string{"datatype"},
+				NoHeader:       true,
+				DateTimeFormat: csv.RFC3339,
+			},
+			encoded: toCRLF(`#datatype,string,long,dateTime:RFC3339,double
+,_result,0,2018-04-17T00:00:00Z,42
+`),
+			result: &executetest.Result{
+				Nm: "_result",
+				Tbls: []*executetest.Table{{
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_value", Type: flux.TFloat},
+					},
+					Data: [][]interface{}{
+						{values.ConvertTime(time.Date(2018, 4, 17, 0, 0, 0, 5e8, time.UTC)), 42.0},
+					},
+				}},
+			},
+		},
+		{
+			name: "RFC3339Nano date time format",
+			encoderConfig: csv.ResultEncoderConfig{
+				Annotations:    []string{"datatype"},
+				NoHeader:       true,
+				DateTimeFormat: csv.RFC3339Nano,
+			},
+			encoded: toCRLF(`#datatype,string,long,dateTime:RFC3339Nano,double
+,_result,0,2018-04-17T00:00:00.5Z,42
+`),
+			result: &executetest.Result{
+				Nm: "_result",
+				Tbls: []*executetest.Table{{
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_value", Type: flux.TFloat},
+					},
+					Data: [][]interface{}{
+						{values.ConvertTime(time.Date(2018, 4, 17, 0, 0, 0, 5e8, time.UTC)), 42.0},
+					},
+				}},
+			},
+		},
 	}
 	testCases = append(testCases, symmetricalTestCases...)
 	for _, tc := range testCases {
@@ -695,6 +818,172 @@ func TestResultEncoder(t *testing.T) {
 	}
 }
 
+// chunkRecorder records the writes it receives and how often it is flushed.
+type chunkRecorder struct {
+	bytes.Buffer
+	writes  []int
+	flushes int
+}
+
+func (r *chunkRecorder) Write(p []byte) (int, error) {
+	r.writes = append(r.writes, len(p))
+	return r.Buffer.Write(p)
+}
+
+func (r *chunkRecorder) Flush() {
+	r.flushes++
+}
+
+func TestResultEncoder_Chunks(t *testing.T) {
+	data := make([][]interface{}, 100)
+	for i := range data {
+		data[i] = []interface{}{values.Time(i), float64(i)}
+	}
+	result := &executetest.Result{
+		Nm: "_result",
+		Tbls: []*executetest.Table{{
+			ColMeta: []flux.ColMeta{
+				{Label: "_time", Type: flux.TTime},
+				{Label: "_value", Type: flux.TFloat},
+			},
+			Data: data,
+		}},
+	}
+
+	config := csv.DefaultEncoderConfig()
+	config.ChunkSize = 64
+	encoder := csv.NewResultEncoder(config)
+	var got chunkRecorder
+	n, err := encoder.Encode(&got, result)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g, w := n, int64(got.Len()); g != w {
+		t.Errorf("unexpected encoding count -want/+got:\n%s", cmp.Diff(w, g))
+	}
+	if len(got.writes) < 2 {
+		t.Fatalf("expected the result to be written in multiple chunks, got %d", len(got.writes))
+	}
+	for i, size := range got.writes {
+		if size > config.ChunkSize {
+			t.Errorf("chunk %d exceeds chunk size: %d > %d", i, size, config.ChunkSize)
+		}
+	}
+	if g, w := got.flushes, len(got.writes); g != w {
+		t.Errorf("unexpected number of flushes -want/+got:\n%s", cmp.Diff(w, g))
+	}
+}
+
+func TestResultEncoder_NaNPolicy(t *testing.T) {
+	result := func() *executetest.Result {
+		return &executetest.Result{
+			Nm: "_result",
+			Tbls: []*executetest.Table{{
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_value", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{values.Time(0), 1.5},
+					{values.Time(1), math.NaN()},
+					{values.Time(2), math.Inf(-1)},
+				},
+			}},
+		}
+	}
+	config := csv.DefaultEncoderConfig()
+	config.Annotations = nil
+
+	var buf bytes.Buffer
+	if _, err := csv.NewResultEncoder(config).Encode(&buf, result()); err != nil {
+		t.Fatal(err)
+	}
+	want := ",result,table,_time,_value\r\n" +
+		",_result,0,1970-01-01T00:00:00Z,1.5\r\n" +
+		",_result,0,1970-01-01T00:00:00.000000001Z,NaN\r\n" +
+		",_result,0,1970-01-01T00:00:00.000000002Z,-Inf\r\n"
+	if got := buf.String(); !cmp.Equal(want, got) {
+		t.Errorf("unexpected propagated encoding -want/+got:\n%s", cmp.Diff(want, got))
+	}
+
+	buf.Reset()
+	config.NaN = execute.NaNDrop
+	if _, err := csv.NewResultEncoder(config).Encode(&buf, result()); err != nil {
+		t.Fatal(err)
+	}
+	want = ",result,table,_time,_value\r\n" +
+		",_result,0,1970-01-01T00:00:00Z,1.5\r\n" +
+		",_result,0,1970-01-01T00:00:00.000000001Z,\r\n" +
+		",_result,0,1970-01-01T00:00:00.000000002Z,\r\n"
+	if got := buf.String(); !cmp.Equal(want, got) {
+		t.Errorf("unexpected dropped encoding -want/+got:\n%s", cmp.Diff(want, got))
+	}
+
+	config.NaN = execute.NaNError
+	if _, err := csv.NewResultEncoder(config).Encode(ioutil.Discard, result()); err == nil {
+		t.Error("expected an error encoding a NaN value")
+	}
+}
+
+func TestResultEncoderConfig_JSON(t *testing.T) {
+	testCases := []struct {
+		name    string
+		json    string
+		want    csv.ResultEncoderConfig
+		wantErr bool
+	}{
+		{
+			name: "defaults",
+			json: `{}`,
+			want: csv.ResultEncoderConfig{
+				Delimiter: ',',
+			},
+		},
+		{
+			name: "all options",
+			json: `{"header":false,"delimiter":"\t","annotations":["group","datatype"],"dateTimeFormat":"RFC3339Nano","nan":"drop"}`,
+			want: csv.ResultEncoderConfig{
+				NoHeader:       true,
+				Delimiter:      '\t',
+				Annotations:    []string{"group", "datatype"},
+				DateTimeFormat: csv.RFC3339Nano,
+				NaN:            execute.NaNDrop,
+			},
+		},
+		{
+			name:    "bad date time format",
+			json:    `{"dateTimeFormat":"unix"}`,
+			wantErr: true,
+		},
+		{
+			name:    "bad NaN policy",
+			json:    `{"nan":"ignore"}`,
+			wantErr: true,
+		},
+		{
+			name:    "bad annotation",
+			json:    `{"annotations":["unknown"]}`,
+			wantErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			var got csv.ResultEncoderConfig
+			err := json.Unmarshal([]byte(tc.json), &got)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tc.wantErr {
+				return
+			}
+			if !cmp.Equal(tc.want, got) {
+				t.Errorf("unexpected config -want/+got:\n%s", cmp.Diff(tc.want, got))
+			}
+		})
+	}
+}
+
 func TestMultiResultEncoder(t *testing.T) {
 	testCases := []struct {
 		name    string
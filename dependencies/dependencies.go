@@ -0,0 +1,270 @@
+// Package dependencies provides the services through which Flux functions
+// access resources outside of the query, such as the network, the filesystem,
+// secrets, the models scored by the query, the storage its points are written to,
+// the current time and the feature flags of the query, and the logger
+// through which Flux functions report the failures of the requests they send.
+//
+// Embedders attach the Dependencies of a query to the context it is executed with.
+// When no Dependencies are attached, or a service is left unset, the default
+// service denies any access to external resources, so that scripts cannot reach
+// outside of the host application unless it explicitly allows them to.
+//
+// The reader and the catalog of the storage engine of the influxdata/influxdb package
+// are not among these services. Their interfaces are defined in terms of the execution engine,
+// which depends on this package, so embedders provide them to the executor
+// in the execute.Dependencies of the query instead.
+package dependencies
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// HTTPClient sends HTTP requests on behalf of Flux functions.
+// An *http.Client is an HTTPClient.
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Filesystem gives access to the files read by Flux functions.
+type Filesystem interface {
+	Open(name string) (io.ReadCloser, error)
+}
+
+// SecretService retrieves the secrets referenced by Flux functions.
+type SecretService interface {
+	LoadSecret(ctx context.Context, key string) (string, error)
+}
+
+// URLValidator validates the URLs accessed by Flux functions.
+type URLValidator interface {
+	Validate(u *url.URL) error
+}
+
+// Dependencies are the services used by Flux functions to access external resources.
+type Dependencies struct {
+	HTTPClient   HTTPClient
+	Filesystem   Filesystem
+	Secrets      SecretService
+	URLValidator URLValidator
+	// Models loads the models scored by the query.
+	Models ModelRuntime
+	// PointsWriter writes the points of the query to the storage of the embedder.
+	PointsWriter PointsWriter
+	// Flagger decides which feature flags are enabled for the query.
+	Flagger Flagger
+	// Now returns the current time.
+	Now func() time.Time
+	// Logger logs the events of Flux functions, such as the failures of their requests.
+	// The controller adds the ID of the query to the fields of the logger.
+	Logger *zap.Logger
+	// Sandbox restricts the query, unless it is nil.
+	// The services the sandbox denies access to are replaced by the default ones.
+	Sandbox *Sandbox
+}
+
+// Default returns the dependencies that deny any access to external resources and discard the logs.
+func Default() Dependencies {
+	return Dependencies{
+		HTTPClient:   denyHTTPClient{},
+		Filesystem:   denyFilesystem{},
+		Secrets:      denySecretService{},
+		URLValidator: denyURLValidator{},
+		Models:       denyModelRuntime{},
+		PointsWriter: denyPointsWriter{},
+		Flagger:      noFlags{},
+		Now:          time.Now,
+		Logger:       zap.NewNop(),
+	}
+}
+
+// Unrestricted returns the dependencies that allow access to all external resources,
+// using the default HTTP client and the filesystem of the host.
+// It is meant for trusted scripts, such as those run from the command line.
+func Unrestricted() Dependencies {
+	return Dependencies{
+		HTTPClient:   http.DefaultClient,
+		Filesystem:   OSFilesystem{},
+		Secrets:      denySecretService{},
+		URLValidator: AllowAllURLs{},
+		Models:       denyModelRuntime{},
+		PointsWriter: denyPointsWriter{},
+		Flagger:      noFlags{},
+		Now:          time.Now,
+		Logger:       zap.NewNop(),
+	}
+}
+
+// withDefaults replaces the unset services of d, and those its sandbox denies access to, with the default ones.
+func (d Dependencies) withDefaults() Dependencies {
+	def := Default()
+	if d.HTTPClient == nil {
+		d.HTTPClient = def.HTTPClient
+	}
+	if d.Filesystem == nil {
+		d.Filesystem = def.Filesystem
+	}
+	if d.Secrets == nil {
+		d.Secrets = def.Secrets
+	}
+	if d.URLValidator == nil {
+		d.URLValidator = def.URLValidator
+	}
+	if d.Models == nil {
+		d.Models = def.Models
+	}
+	if d.PointsWriter == nil {
+		d.PointsWriter = def.PointsWriter
+	}
+	if d.Flagger == nil {
+		d.Flagger = def.Flagger
+	}
+	if d.Now == nil {
+		d.Now = def.Now
+	}
+	if d.Logger == nil {
+		d.Logger = def.Logger
+	}
+	if d.Sandbox != nil {
+		d = d.Sandbox.apply(d)
+	}
+	return d
+}
+
+type key int
+
+const dependenciesKey key = iota
+
+// Inject returns a context that carries the dependencies.
+func Inject(ctx context.Context, deps Dependencies) context.Context {
+	return context.WithValue(ctx, dependenciesKey, deps)
+}
+
+// Get returns the dependencies carried by the context.
+// Services that are not set are replaced by the default ones.
+func Get(ctx context.Context) Dependencies {
+	deps, _ := ctx.Value(dependenciesKey).(Dependencies)
+	return deps.withDefaults()
+}
+
+// Builder builds Dependencies, starting from the default ones.
+type Builder struct {
+	deps Dependencies
+}
+
+// NewBuilder returns a builder of the default dependencies.
+func NewBuilder() *Builder {
+	return &Builder{deps: Default()}
+}
+
+func (b *Builder) WithHTTPClient(c HTTPClient) *Builder {
+	b.deps.HTTPClient = c
+	return b
+}
+
+func (b *Builder) WithFilesystem(fs Filesystem) *Builder {
+	b.deps.Filesystem = fs
+	return b
+}
+
+func (b *Builder) WithSecretService(s SecretService) *Builder {
+	b.deps.Secrets = s
+	return b
+}
+
+func (b *Builder) WithURLValidator(v URLValidator) *Builder {
+	b.deps.URLValidator = v
+	return b
+}
+
+func (b *Builder) WithModelRuntime(r ModelRuntime) *Builder {
+	b.deps.Models = r
+	return b
+}
+
+func (b *Builder) WithPointsWriter(w PointsWriter) *Builder {
+	b.deps.PointsWriter = w
+	return b
+}
+
+func (b *Builder) WithFlagger(f Flagger) *Builder {
+	b.deps.Flagger = f
+	return b
+}
+
+func (b *Builder) WithNow(now func() time.Time) *Builder {
+	b.deps.Now = now
+	return b
+}
+
+func (b *Builder) WithLogger(l *zap.Logger) *Builder {
+	b.deps.Logger = l
+	return b
+}
+
+func (b *Builder) WithSandbox(s Sandbox) *Builder {
+	b.deps.Sandbox = &s
+	return b
+}
+
+// Build returns the dependencies.
+func (b *Builder) Build() Dependencies {
+	return b.deps.withDefaults()
+}
+
+// OSFilesystem is a Filesystem that opens files from the filesystem of the host.
+type OSFilesystem struct{}
+
+func (OSFilesystem) Open(name string) (io.ReadCloser, error) {
+	return os.Open(name)
+}
+
+// AllowAllURLs is a URLValidator that accepts every URL.
+type AllowAllURLs struct{}
+
+func (AllowAllURLs) Validate(*url.URL) error {
+	return nil
+}
+
+var (
+	ErrHTTPDenied       = errors.New("http access is not allowed")
+	ErrFilesystemDenied = errors.New("filesystem access is not allowed")
+	ErrSecretsDenied    = errors.New("secret access is not allowed")
+	ErrURLDenied        = errors.New("url access is not allowed")
+)
+
+type denyHTTPClient struct{}
+
+func (denyHTTPClient) Do(*http.Request) (*http.Response, error) {
+	return nil, ErrHTTPDenied
+}
+
+type denyFilesystem struct{}
+
+func (denyFilesystem) Open(string) (io.ReadCloser, error) {
+	return nil, ErrFilesystemDenied
+}
+
+type denySecretService struct{}
+
+func (denySecretService) LoadSecret(context.Context, string) (string, error) {
+	return "", ErrSecretsDenied
+}
+
+type denyURLValidator struct{}
+
+func (denyURLValidator) Validate(*url.URL) error {
+	return ErrURLDenied
+}
+
+func (denyURLValidator) CheckAddress(net.IP) error {
+	return ErrURLDenied
+}
@@ -0,0 +1,145 @@
+package dependencies_test
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/influxdata/flux/dependencies"
+)
+
+func TestGet_Default(t *testing.T) {
+	deps := dependencies.Get(context.Background())
+	if _, err := deps.HTTPClient.Do(&http.Request{}); err != dependencies.ErrHTTPDenied {
+		t.Errorf("unexpected http error: %v", err)
+	}
+	if _, err := deps.Filesystem.Open("/etc/hosts"); err != dependencies.ErrFilesystemDenied {
+		t.Errorf("unexpected filesystem error: %v", err)
+	}
+	if _, err := deps.Secrets.LoadSecret(context.Background(), "token"); err != dependencies.ErrSecretsDenied {
+		t.Errorf("unexpected secret error: %v", err)
+	}
+	if err := deps.URLValidator.Validate(&url.URL{Scheme: "http", Host: "localhost"}); err != dependencies.ErrURLDenied {
+		t.Errorf("unexpected url error: %v", err)
+	}
+	if _, err := deps.Models.LoadModel(context.Background(), "onnx", "model.onnx"); err != dependencies.ErrModelsDenied {
+		t.Errorf("unexpected model error: %v", err)
+	}
+	if err := deps.PointsWriter.WritePoints(context.Background(), dependencies.WriteDestination{Bucket: "telegraf"}, nil); err != dependencies.ErrPointsWriterDenied {
+		t.Errorf("unexpected write error: %v", err)
+	}
+	if deps.Now == nil {
+		t.Error("expected a now function")
+	}
+	if deps.Logger == nil {
+		t.Error("expected a logger")
+	}
+}
+
+func TestInject(t *testing.T) {
+	now := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	ctx := dependencies.Inject(context.Background(), dependencies.NewBuilder().
+		WithURLValidator(dependencies.AllowAllURLs{}).
+		WithNow(func() time.Time { return now }).
+		Build())
+
+	deps := dependencies.Get(ctx)
+	if err := deps.URLValidator.Validate(&url.URL{Scheme: "http", Host: "localhost"}); err != nil {
+		t.Errorf("unexpected url error: %v", err)
+	}
+	if got := deps.Now(); !got.Equal(now) {
+		t.Errorf("unexpected now: want %v, got %v", now, got)
+	}
+	// Services that were not set by the builder keep denying access.
+	if _, err := deps.Filesystem.Open("/etc/hosts"); err != dependencies.ErrFilesystemDenied {
+		t.Errorf("unexpected filesystem error: %v", err)
+	}
+}
+
+func TestGet_Sandbox(t *testing.T) {
+	ctx := dependencies.Inject(context.Background(), dependencies.NewBuilder().
+		WithHTTPClient(http.DefaultClient).
+		WithFilesystem(dependencies.OSFilesystem{}).
+		WithURLValidator(dependencies.AllowAllURLs{}).
+		WithModelRuntime(modelRuntime{}).
+		WithPointsWriter(pointsWriter{}).
+		WithSecretService(secretService{}).
+		WithSandbox(dependencies.Sandbox{AllowNetwork: true}).
+		Build())
+
+	deps := dependencies.Get(ctx)
+	if _, err := deps.Filesystem.Open("/etc/hosts"); err != dependencies.ErrFilesystemDenied {
+		t.Errorf("unexpected filesystem error: %v", err)
+	}
+	if _, err := deps.Models.LoadModel(context.Background(), "onnx", "model.onnx"); err != dependencies.ErrModelsDenied {
+		t.Errorf("unexpected model error: %v", err)
+	}
+	if err := deps.PointsWriter.WritePoints(context.Background(), dependencies.WriteDestination{}, nil); err != dependencies.ErrPointsWriterDenied {
+		t.Errorf("unexpected points writer error: %v", err)
+	}
+	if _, err := deps.Secrets.LoadSecret(context.Background(), "token"); err != dependencies.ErrSecretsDenied {
+		t.Errorf("unexpected secret error: %v", err)
+	}
+	if err := deps.URLValidator.Validate(&url.URL{Scheme: "http", Host: "localhost"}); err != nil {
+		t.Errorf("unexpected url error: %v", err)
+	}
+
+	ctx = dependencies.Inject(context.Background(), dependencies.NewBuilder().
+		WithPointsWriter(pointsWriter{}).
+		WithSecretService(secretService{}).
+		WithSandbox(dependencies.Sandbox{AllowWrites: true, AllowSecrets: true}).
+		Build())
+	deps = dependencies.Get(ctx)
+	if err := deps.PointsWriter.WritePoints(context.Background(), dependencies.WriteDestination{}, nil); err != nil {
+		t.Errorf("unexpected points writer error: %v", err)
+	}
+	if _, err := deps.Secrets.LoadSecret(context.Background(), "token"); err != nil {
+		t.Errorf("unexpected secret error: %v", err)
+	}
+}
+
+// pointsWriter discards the points, but does not deny writing them.
+type pointsWriter struct{}
+
+func (pointsWriter) WritePoints(context.Context, dependencies.WriteDestination, []dependencies.Point) error {
+	return nil
+}
+
+// secretService loads empty secrets, but does not deny access to them.
+type secretService struct{}
+
+func (secretService) LoadSecret(context.Context, string) (string, error) {
+	return "", nil
+}
+
+// modelRuntime loads no model, but does not deny access to them.
+type modelRuntime struct{}
+
+func (modelRuntime) LoadModel(context.Context, string, string) (dependencies.Model, error) {
+	return nil, nil
+}
+
+func TestFlagRecorder(t *testing.T) {
+	ctx := dependencies.Inject(context.Background(), dependencies.NewBuilder().
+		WithFlagger(dependencies.Flags{"a": true}).
+		Build())
+
+	r := dependencies.NewFlagRecorder(dependencies.Get(ctx).Flagger)
+	if !r.Enabled("a") {
+		t.Error("expected flag a to be enabled")
+	}
+	if r.Enabled("b") {
+		t.Error("expected flag b to be disabled")
+	}
+	if want, got := map[string]bool{"a": true, "b": false}, r.Flags(); !reflect.DeepEqual(want, got) {
+		t.Errorf("unexpected flags: want %v, got %v", want, got)
+	}
+
+	// No flag is enabled by default.
+	if dependencies.Get(context.Background()).Flagger.Enabled("a") {
+		t.Error("expected flag a to be disabled by default")
+	}
+}
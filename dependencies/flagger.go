@@ -0,0 +1,65 @@
+package dependencies
+
+import "sync"
+
+// Flagger decides which feature flags are enabled for a query.
+// Planner rules, transformations and functions consult it through the dependencies of the query,
+// so that new behaviors can be enabled per query, or per tenant, without recompiling.
+type Flagger interface {
+	// Enabled reports whether the flag is enabled.
+	Enabled(flag string) bool
+}
+
+// Flags is a Flagger that enables the flags set to true.
+type Flags map[string]bool
+
+func (f Flags) Enabled(flag string) bool {
+	return f[flag]
+}
+
+// FlagRecorder is a Flagger that records the flags it is asked about and their values.
+// It is safe for concurrent use.
+type FlagRecorder struct {
+	flagger Flagger
+
+	mu    sync.Mutex
+	flags map[string]bool
+}
+
+// NewFlagRecorder returns a recorder of the flags decided by flagger.
+func NewFlagRecorder(flagger Flagger) *FlagRecorder {
+	return &FlagRecorder{
+		flagger: flagger,
+		flags:   make(map[string]bool),
+	}
+}
+
+// Enabled returns the value of the flag, which is decided by the recorded flagger
+// the first time the flag is asked about and kept for the next times.
+func (r *FlagRecorder) Enabled(flag string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if v, ok := r.flags[flag]; ok {
+		return v
+	}
+	v := r.flagger.Enabled(flag)
+	r.flags[flag] = v
+	return v
+}
+
+// Flags returns the flags that were asked about and their values.
+func (r *FlagRecorder) Flags() map[string]bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	flags := make(map[string]bool, len(r.flags))
+	for flag, v := range r.flags {
+		flags[flag] = v
+	}
+	return flags
+}
+
+type noFlags struct{}
+
+func (noFlags) Enabled(string) bool {
+	return false
+}
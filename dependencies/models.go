@@ -0,0 +1,32 @@
+package dependencies
+
+import (
+	"context"
+	"errors"
+)
+
+// ModelRuntime loads the models scored by Flux functions, such as ONNX or PMML models trained offline.
+// Flux does not evaluate models itself, embedders provide a runtime for the formats they support.
+type ModelRuntime interface {
+	// LoadModel loads the model of the format, for example "onnx" or "pmml", found at the location.
+	LoadModel(ctx context.Context, format, location string) (Model, error)
+}
+
+// Model is a model loaded by a ModelRuntime.
+// It is safe for concurrent use.
+type Model interface {
+	// Inputs returns the names of the features of the model.
+	Inputs() []string
+	// Outputs returns the names of the values computed by the model.
+	Outputs() []string
+	// Score computes the outputs of the model, in order, from the values of its inputs, in order.
+	Score(ctx context.Context, inputs []float64) ([]float64, error)
+}
+
+var ErrModelsDenied = errors.New("no model runtime is available")
+
+type denyModelRuntime struct{}
+
+func (denyModelRuntime) LoadModel(context.Context, string, string) (Model, error) {
+	return nil, ErrModelsDenied
+}
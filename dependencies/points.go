@@ -0,0 +1,93 @@
+package dependencies
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Tag is a tag of a Point.
+type Tag struct {
+	Key   string
+	Value string
+}
+
+// Field is a field of a Point.
+// The value is an int64, a uint64, a float64, a string or a bool.
+type Field struct {
+	Key   string
+	Value interface{}
+}
+
+// Point is a point written by the to() function of the influxdata/influxdb package.
+type Point struct {
+	Measurement string
+	// Tags are sorted by key.
+	Tags   []Tag
+	Fields []Field
+	Time   time.Time
+}
+
+// WriteDestination identifies the bucket points are written to.
+// One of Bucket and BucketID is set, and at most one of Org and OrgID.
+type WriteDestination struct {
+	Org      string
+	OrgID    string
+	Bucket   string
+	BucketID string
+}
+
+// String returns the organization and the bucket, by their names or by their IDs.
+func (d WriteDestination) String() string {
+	org, bucket := d.Org, d.Bucket
+	if org == "" {
+		org = d.OrgID
+	}
+	if bucket == "" {
+		bucket = d.BucketID
+	}
+	if org == "" {
+		return bucket
+	}
+	return org + "/" + bucket
+}
+
+// PointsWriter writes the points produced by to().
+// Embedders that provide a PointsWriter accept the writes of the pipelines
+// that call to() without a host.
+// The default PointsWriter rejects every write.
+type PointsWriter interface {
+	// WritePoints writes a batch of points to a bucket.
+	// When only some of the points are rejected, the others are written
+	// and the rejected ones are reported with a *PartialWriteError.
+	// The points must not be retained after WritePoints returns.
+	WritePoints(ctx context.Context, dest WriteDestination, points []Point) error
+}
+
+// PointError is the error of a single point of a batch.
+type PointError struct {
+	// Index is the index of the point in the batch.
+	Index int
+	Err   error
+}
+
+// PartialWriteError reports the points of a batch that a PointsWriter rejected.
+type PartialWriteError struct {
+	Errors []PointError
+}
+
+func (e *PartialWriteError) Error() string {
+	if len(e.Errors) == 1 {
+		return fmt.Sprintf("failed to write point %d: %v", e.Errors[0].Index, e.Errors[0].Err)
+	}
+	return fmt.Sprintf("failed to write %d points: point %d: %v", len(e.Errors), e.Errors[0].Index, e.Errors[0].Err)
+}
+
+var ErrPointsWriterDenied = errors.New("no points writer is available")
+
+type denyPointsWriter struct{}
+
+func (denyPointsWriter) WritePoints(context.Context, WriteDestination, []Point) error {
+	return ErrPointsWriterDenied
+}
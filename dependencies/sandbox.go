@@ -0,0 +1,51 @@
+package dependencies
+
+import "time"
+
+// Sandbox restricts the resources a query may use, so that untrusted scripts can be executed safely.
+// The zero value denies access to the filesystem, the network, the models, the points writer and the secrets,
+// and does not bound the query.
+type Sandbox struct {
+	// AllowFilesystem allows functions to read files through the Filesystem of the dependencies.
+	AllowFilesystem bool
+	// AllowNetwork allows functions to access the network through the HTTPClient and
+	// the URLValidator of the dependencies.
+	AllowNetwork bool
+	// AllowModels allows functions to load the models they score through the Models of the dependencies.
+	AllowModels bool
+	// AllowWrites allows functions to write points through the PointsWriter of the dependencies, as to() does.
+	AllowWrites bool
+	// AllowSecrets allows functions to read secrets through the SecretService of the dependencies.
+	AllowSecrets bool
+	// WallTimeout bounds the wall-clock time the query may take, from its compilation to the end of its execution.
+	// The query is cancelled when the timeout expires.
+	// It does not bound the CPU time of the query, which is not accounted per query:
+	// the time the query spends waiting for its sources or for other queries counts toward the timeout.
+	// A zero timeout does not bound the query.
+	WallTimeout time.Duration
+	// MemoryBytes bounds the memory the query may allocate while executing.
+	// Zero does not bound the memory of the query.
+	MemoryBytes int64
+}
+
+// apply replaces the services of d the sandbox denies access to with the default ones.
+func (s Sandbox) apply(d Dependencies) Dependencies {
+	def := Default()
+	if !s.AllowFilesystem {
+		d.Filesystem = def.Filesystem
+	}
+	if !s.AllowNetwork {
+		d.HTTPClient = def.HTTPClient
+		d.URLValidator = def.URLValidator
+	}
+	if !s.AllowModels {
+		d.Models = def.Models
+	}
+	if !s.AllowWrites {
+		d.PointsWriter = def.PointsWriter
+	}
+	if !s.AllowSecrets {
+		d.Secrets = def.Secrets
+	}
+	return d
+}
@@ -0,0 +1,191 @@
+package dependencies
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// privateNetworks are the address ranges that are not reachable from the public internet,
+// and the special purpose ranges that can reach the internal network of a deployment.
+var privateNetworks = mustParseCIDRs(
+	"0.0.0.0/8",
+	"10.0.0.0/8",
+	"100.64.0.0/10",
+	"127.0.0.0/8",
+	"169.254.0.0/16",
+	"172.16.0.0/12",
+	"192.0.0.0/24",
+	"192.168.0.0/16",
+	"198.18.0.0/15",
+	"224.0.0.0/4",
+	"::/128",
+	"::1/128",
+	"64:ff9b::/96",
+	"fc00::/7",
+	"fe80::/10",
+)
+
+func mustParseCIDRs(cidrs ...string) []*net.IPNet {
+	nets := make([]*net.IPNet, len(cidrs))
+	for i, cidr := range cidrs {
+		_, n, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		nets[i] = n
+	}
+	return nets
+}
+
+// isPrivateIP reports whether ip is a private, loopback, link local, multicast,
+// unspecified or other special purpose address.
+func isPrivateIP(ip net.IP) bool {
+	for _, n := range privateNetworks {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// RestrictedURLs is a URLValidator for deployments that run scripts of untrusted users.
+// By default it rejects the URLs whose host is, or resolves to, a private address,
+// so that scripts cannot reach the internal network of the deployment.
+type RestrictedURLs struct {
+	// AllowedHosts restricts the hosts of the URLs to the listed ones, unless it is empty.
+	// An entry of the form "*.example.com" matches every subdomain of example.com.
+	AllowedHosts []string
+	// AllowPrivateIPs allows the URLs whose host is a private address.
+	AllowPrivateIPs bool
+}
+
+func (v RestrictedURLs) Validate(u *url.URL) error {
+	host := strings.ToLower(u.Hostname())
+	if host == "" {
+		return fmt.Errorf("url %q has no host", u.String())
+	}
+	if len(v.AllowedHosts) > 0 && !v.isAllowedHost(host) {
+		return fmt.Errorf("host %q is not allowed", host)
+	}
+	if v.AllowPrivateIPs {
+		return nil
+	}
+
+	ips := []net.IP{net.ParseIP(host)}
+	if ips[0] == nil {
+		var err error
+		if ips, err = net.LookupIP(host); err != nil {
+			return err
+		}
+	}
+	for _, ip := range ips {
+		if isPrivateIP(ip) {
+			return fmt.Errorf("host %q resolves to the private address %s", host, ip)
+		}
+	}
+	return nil
+}
+
+func (v RestrictedURLs) isAllowedHost(host string) bool {
+	for _, allowed := range v.AllowedHosts {
+		allowed = strings.ToLower(allowed)
+		if strings.HasPrefix(allowed, "*.") {
+			if strings.HasSuffix(host, allowed[1:]) {
+				return true
+			}
+		} else if host == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckAddress rejects the private addresses, unless v allows them.
+func (v RestrictedURLs) CheckAddress(ip net.IP) error {
+	if !v.AllowPrivateIPs && isPrivateIP(ip) {
+		return fmt.Errorf("connections to the private address %s are not allowed", ip)
+	}
+	return nil
+}
+
+// AddressChecker is implemented by the URLValidators that also check the IP addresses
+// connections are opened to, once the host of a validated URL has been resolved.
+// Checking the address when connecting prevents a host name
+// from resolving to a private address after its URL was validated.
+type AddressChecker interface {
+	CheckAddress(ip net.IP) error
+}
+
+// CheckAddress returns an error if v does not allow connections to the IP address.
+// Validators that do not implement AddressChecker allow every address.
+func CheckAddress(v URLValidator, ip net.IP) error {
+	if c, ok := v.(AddressChecker); ok {
+		return c.CheckAddress(ip)
+	}
+	return nil
+}
+
+// NewDialer returns a dialer for the connections to the URLs validated by v.
+// When v is an AddressChecker, the Control function of the dialer checks the address
+// it connects to once the host has been resolved, and rejects the addresses
+// that are not IP addresses, such as the paths of unix sockets.
+// Flux functions that open connections themselves use it, so that they cannot reach
+// the addresses v rejects.
+func NewDialer(v URLValidator) *net.Dialer {
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+		DualStack: true,
+	}
+	if c, ok := v.(AddressChecker); ok {
+		dialer.Control = func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return fmt.Errorf("connections to %s are not allowed", address)
+			}
+			ip := net.ParseIP(host)
+			if ip == nil {
+				return fmt.Errorf("connections to %s are not allowed", address)
+			}
+			return c.CheckAddress(ip)
+		}
+	}
+	return dialer
+}
+
+// DefaultMaxRedirects is the number of redirects followed by the clients of NewHTTPClient
+// when maxRedirects is negative.
+const DefaultMaxRedirects = 10
+
+// NewHTTPClient returns an HTTP client that validates the URL of every redirect it follows with v
+// and follows at most maxRedirects redirects.
+// The client connects with the dialer of NewDialer, so the addresses it connects to are validated as well.
+// The client does not use the proxies of the environment, which could reach the addresses
+// the validator rejects.
+func NewHTTPClient(v URLValidator, maxRedirects int) *http.Client {
+	if maxRedirects < 0 {
+		maxRedirects = DefaultMaxRedirects
+	}
+	dialer := NewDialer(v)
+	return &http.Client{
+		Transport: &http.Transport{
+			DialContext:           dialer.DialContext,
+			MaxIdleConns:          100,
+			IdleConnTimeout:       90 * time.Second,
+			TLSHandshakeTimeout:   10 * time.Second,
+			ExpectContinueTimeout: 1 * time.Second,
+		},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) > maxRedirects {
+				return errors.New("too many redirects")
+			}
+			return v.Validate(req.URL)
+		},
+	}
+}
@@ -0,0 +1,172 @@
+package dependencies_test
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/influxdata/flux/dependencies"
+)
+
+func TestRestrictedURLs_Validate(t *testing.T) {
+	testcases := []struct {
+		name      string
+		validator dependencies.RestrictedURLs
+		url       string
+		wantErr   bool
+	}{
+		{
+			name: "public address",
+			url:  "http://8.8.8.8/write",
+		},
+		{
+			name:    "loopback address",
+			url:     "http://127.0.0.1:8086/write",
+			wantErr: true,
+		},
+		{
+			name:    "private address",
+			url:     "http://10.1.2.3/write",
+			wantErr: true,
+		},
+		{
+			name:    "link local address",
+			url:     "http://169.254.169.254/latest/meta-data",
+			wantErr: true,
+		},
+		{
+			name:    "ipv6 loopback address",
+			url:     "http://[::1]:8086/write",
+			wantErr: true,
+		},
+		{
+			name:      "private addresses allowed",
+			validator: dependencies.RestrictedURLs{AllowPrivateIPs: true},
+			url:       "http://127.0.0.1:8086/write",
+		},
+		{
+			name:    "no host",
+			url:     "unix:///var/run/influxdb.sock",
+			wantErr: true,
+		},
+		{
+			name: "allowed host",
+			validator: dependencies.RestrictedURLs{
+				AllowedHosts:    []string{"*.example.com", "influxdata.com"},
+				AllowPrivateIPs: true,
+			},
+			url: "https://api.example.com/write",
+		},
+		{
+			name: "host not allowed",
+			validator: dependencies.RestrictedURLs{
+				AllowedHosts:    []string{"*.example.com", "influxdata.com"},
+				AllowPrivateIPs: true,
+			},
+			url:     "https://example.org/write",
+			wantErr: true,
+		},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			u, err := url.Parse(tc.url)
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = tc.validator.Validate(u)
+			if tc.wantErr && err == nil {
+				t.Fatal("expected error, got none")
+			} else if !tc.wantErr && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestNewHTTPClient_Redirects(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/loop", http.StatusFound)
+	}))
+	defer server.Close()
+
+	client := dependencies.NewHTTPClient(dependencies.AllowAllURLs{}, 2)
+	if _, err := client.Get(server.URL); err == nil {
+		t.Fatal("expected too many redirects error, got none")
+	}
+}
+
+func TestNewHTTPClient_PrivateAddress(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	client := dependencies.NewHTTPClient(dependencies.RestrictedURLs{}, 0)
+	if _, err := client.Get(server.URL); err == nil {
+		t.Fatal("expected error connecting to a private address, got none")
+	}
+}
+
+func TestNewDialer_PrivateAddress(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	testCases := []struct {
+		name      string
+		validator dependencies.URLValidator
+		wantErr   bool
+	}{
+		{name: "restricted", validator: dependencies.RestrictedURLs{}, wantErr: true},
+		{name: "restricted pointer", validator: &dependencies.RestrictedURLs{}, wantErr: true},
+		{name: "restricted allowing private addresses", validator: dependencies.RestrictedURLs{AllowPrivateIPs: true}},
+		{name: "allow all", validator: dependencies.AllowAllURLs{}},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			conn, err := dependencies.NewDialer(tc.validator).Dial("tcp", l.Addr().String())
+			if tc.wantErr {
+				if err == nil {
+					conn.Close()
+					t.Fatal("expected error connecting to a private address, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			conn.Close()
+		})
+	}
+}
+
+func TestCheckAddress(t *testing.T) {
+	testCases := []struct {
+		ip        string
+		validator dependencies.URLValidator
+		wantErr   bool
+	}{
+		{ip: "10.1.2.3", validator: dependencies.RestrictedURLs{}, wantErr: true},
+		{ip: "::1", validator: dependencies.RestrictedURLs{}, wantErr: true},
+		{ip: "224.0.0.1", validator: dependencies.RestrictedURLs{}, wantErr: true},
+		{ip: "198.18.0.1", validator: dependencies.RestrictedURLs{}, wantErr: true},
+		{ip: "192.0.0.170", validator: dependencies.RestrictedURLs{}, wantErr: true},
+		{ip: "64:ff9b::a01:203", validator: dependencies.RestrictedURLs{}, wantErr: true},
+		{ip: "::ffff:127.0.0.1", validator: dependencies.RestrictedURLs{}, wantErr: true},
+		{ip: "10.1.2.3", validator: &dependencies.RestrictedURLs{}, wantErr: true},
+		{ip: "8.8.8.8", validator: dependencies.RestrictedURLs{}},
+		{ip: "10.1.2.3", validator: dependencies.RestrictedURLs{AllowPrivateIPs: true}},
+		{ip: "10.1.2.3", validator: dependencies.AllowAllURLs{}},
+		{ip: "8.8.8.8", validator: dependencies.Default().URLValidator, wantErr: true},
+	}
+	for _, tc := range testCases {
+		err := dependencies.CheckAddress(tc.validator, net.ParseIP(tc.ip))
+		if gotErr := err != nil; gotErr != tc.wantErr {
+			t.Errorf("unexpected error checking %s with %T: %v", tc.ip, tc.validator, err)
+		}
+	}
+}
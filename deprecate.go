@@ -0,0 +1,203 @@
+package flux
+
+import (
+	"fmt"
+	"path"
+
+	"github.com/influxdata/flux/ast"
+	"github.com/pkg/errors"
+)
+
+// deprecation describes a deprecated value of a builtin package.
+type deprecation struct {
+	// value is the message of the deprecation of the value itself,
+	// or empty if only some of its parameters are deprecated.
+	value string
+	// params maps the deprecated parameters of a function to their messages.
+	params map[string]string
+}
+
+// deprecations maps the import paths of the builtin packages and the names of their values
+// to their deprecations.
+var deprecations = make(map[string]map[string]*deprecation)
+
+// DeprecatePackageValue marks a value of a builtin package as deprecated.
+// Scripts that call the value as a function or set it as an option still compile,
+// but their Spec reports a warning with the message,
+// which should say what to use instead.
+func DeprecatePackageValue(pkgpath, name, message string) {
+	d := deprecationOf(pkgpath, name)
+	if d.value != "" {
+		panic(fmt.Errorf("duplicate deprecation of builtin package value %q %q", pkgpath, name))
+	}
+	d.value = message
+}
+
+// DeprecatePackageParameter marks a parameter of a function of a builtin package as deprecated.
+// Scripts that pass the parameter to the function still compile,
+// but their Spec reports a warning with the message.
+func DeprecatePackageParameter(pkgpath, name, param, message string) {
+	d := deprecationOf(pkgpath, name)
+	if _, ok := d.params[param]; ok {
+		panic(fmt.Errorf("duplicate deprecation of parameter %q of builtin package value %q %q", param, pkgpath, name))
+	}
+	d.params[param] = message
+}
+
+func deprecationOf(pkgpath, name string) *deprecation {
+	if finalized {
+		panic(errors.New("already finalized, cannot deprecate builtin package value"))
+	}
+	pkg, ok := deprecations[pkgpath]
+	if !ok {
+		pkg = make(map[string]*deprecation)
+		deprecations[pkgpath] = pkg
+	}
+	d, ok := pkg[name]
+	if !ok {
+		d = &deprecation{params: make(map[string]string)}
+		pkg[name] = d
+	}
+	return d
+}
+
+// validateDeprecations ensures that all deprecated values exist in their builtin packages.
+func validateDeprecations() error {
+	for pkgpath, pkg := range deprecations {
+		for name := range pkg {
+			p, ok := stdlib.pkgs[pkgpath]
+			if !ok {
+				return fmt.Errorf("deprecated builtin package value %q %q: missing package", pkgpath, name)
+			}
+			if _, ok := p.Get(name); !ok {
+				return fmt.Errorf("deprecated builtin package value %q %q: missing value", pkgpath, name)
+			}
+		}
+	}
+	return nil
+}
+
+// deprecationWarnings reports the uses of deprecated values and parameters in a package.
+// It finds the calls and the options that refer to builtin values directly,
+// either through an identifier of the prelude that the package does not shadow
+// or through a member of an imported package.
+// It does not follow the values through the variables they are assigned to.
+func deprecationWarnings(astPkg *ast.Package) []Warning {
+	if len(deprecations) == 0 {
+		return nil
+	}
+
+	// Any name that the package binds shadows the prelude.
+	shadowed := make(map[string]bool)
+	options := make(map[*ast.VariableAssignment]bool)
+	ast.Walk(ast.CreateVisitor(func(n ast.Node) {
+		switch n := n.(type) {
+		case *ast.ImportDeclaration:
+			shadowed[importName(n)] = true
+		case *ast.OptionStatement:
+			if a, ok := n.Assignment.(*ast.VariableAssignment); ok {
+				options[a] = true
+			}
+		case *ast.VariableAssignment:
+			if !options[n] {
+				shadowed[n.ID.Name] = true
+			}
+		case *ast.FunctionExpression:
+			for _, p := range n.Params {
+				shadowed[p.Key.Key()] = true
+			}
+		}
+	}), astPkg)
+
+	var warnings []Warning
+	warn := func(n ast.Node, format string, a ...interface{}) {
+		loc := n.Location()
+		warnings = append(warnings, Warning{
+			Message:  fmt.Sprintf(format, a...),
+			Location: &loc,
+		})
+	}
+	for _, file := range astPkg.Files {
+		imports := make(map[string]string, len(file.Imports))
+		for _, imp := range file.Imports {
+			imports[importName(imp)] = imp.Path.Value
+		}
+		lookup := func(e ast.Expression) (string, *deprecation) {
+			switch e := e.(type) {
+			case *ast.Identifier:
+				if shadowed[e.Name] {
+					return "", nil
+				}
+				for _, pkgpath := range prelude {
+					if _, ok := stdlib.pkgs[pkgpath].Get(e.Name); ok {
+						return e.Name, deprecations[pkgpath][e.Name]
+					}
+				}
+			case *ast.MemberExpression:
+				obj, ok := e.Object.(*ast.Identifier)
+				if !ok {
+					return "", nil
+				}
+				if pkgpath, ok := imports[obj.Name]; ok {
+					name := e.Property.Key()
+					return obj.Name + "." + name, deprecations[pkgpath][name]
+				}
+			}
+			return "", nil
+		}
+
+		ast.Walk(ast.CreateVisitor(func(n ast.Node) {
+			switch n := n.(type) {
+			case *ast.CallExpression:
+				name, d := lookup(n.Callee)
+				if d == nil {
+					return
+				}
+				if d.value != "" {
+					warn(n, "%s is deprecated: %s", name, d.value)
+				}
+				for _, arg := range n.Arguments {
+					obj, ok := arg.(*ast.ObjectExpression)
+					if !ok {
+						continue
+					}
+					for _, p := range obj.Properties {
+						if msg, ok := d.params[p.Key.Key()]; ok {
+							warn(p, "parameter %q of %s is deprecated: %s", p.Key.Key(), name, msg)
+						}
+					}
+				}
+			case *ast.OptionStatement:
+				var name string
+				var d *deprecation
+				switch a := n.Assignment.(type) {
+				case *ast.VariableAssignment:
+					// Options of the prelude are not shadowed by their own assignment.
+					for _, pkgpath := range prelude {
+						if _, ok := stdlib.pkgs[pkgpath].Get(a.ID.Name); ok {
+							name, d = a.ID.Name, deprecations[pkgpath][a.ID.Name]
+							break
+						}
+					}
+				case *ast.MemberAssignment:
+					name, d = lookup(a.Member)
+				}
+				if d != nil && d.value != "" {
+					warn(n, "option %s is deprecated: %s", name, d.value)
+				}
+			}
+		}), file)
+	}
+	return warnings
+}
+
+// importName returns the name that an import declaration binds.
+func importName(imp *ast.ImportDeclaration) string {
+	if imp.As != nil {
+		return imp.As.Name
+	}
+	if pkg, ok := stdlib.pkgs[imp.Path.Value]; ok {
+		return pkg.Name()
+	}
+	return path.Base(imp.Path.Value)
+}
@@ -0,0 +1,199 @@
+// Package docs extracts the documentation of the Flux standard library
+// from the registered builtin packages,
+// so that tools such as `flux doc` and editors can describe the builtins
+// without a separately maintained source of documentation.
+//
+// The signatures of the builtins come from the types of their registered values.
+// The doc comments come from the Flux source of the packages:
+// the builtin command records them when it generates the Go source of a package.
+package docs
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/flux/values"
+)
+
+// Comments are the doc comments of a package of the standard library.
+type Comments struct {
+	// Package is the doc comment of the package clause.
+	Package string
+	// Values maps the names of the values of the package to their doc comments.
+	Values map[string]string
+}
+
+var comments = make(map[string]Comments)
+
+// RegisterComments adds the doc comments of a package of the standard library.
+// It is called by the Go source that the builtin command generates.
+func RegisterComments(pkgpath string, c Comments) {
+	if _, ok := comments[pkgpath]; ok {
+		panic(fmt.Errorf("duplicate doc comments for package %q", pkgpath))
+	}
+	comments[pkgpath] = c
+}
+
+// Package documents a package of the standard library.
+type Package struct {
+	// Path is the import path of the package.
+	Path string `json:"path"`
+	// Name is the name that an import of the package binds.
+	Name   string  `json:"name"`
+	Doc    string  `json:"doc,omitempty"`
+	Values []Value `json:"values"`
+}
+
+// Value documents a value of a package of the standard library.
+type Value struct {
+	// Package is the import path of the package of the value.
+	Package string `json:"package"`
+	Name    string `json:"name"`
+	Doc     string `json:"doc,omitempty"`
+	// Type is the type of the value, as Flux writes it in error messages.
+	Type string `json:"type"`
+	// Parameters are the parameters of a function, sorted by name.
+	Parameters []Parameter `json:"parameters,omitempty"`
+	// Return is the type that a function returns.
+	Return string `json:"return,omitempty"`
+}
+
+// Parameter documents a parameter of a function.
+type Parameter struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Required bool   `json:"required"`
+	// Pipe reports whether the parameter receives the tables piped into the function.
+	Pipe bool `json:"pipe,omitempty"`
+}
+
+type functionType interface {
+	Signature() semantic.FunctionPolySignature
+}
+
+// Packages returns the documentation of all the packages of the standard library, sorted by import path.
+// The builtins must be finalized.
+func Packages() []Package {
+	paths := flux.StdLibPackagePaths()
+	pkgs := make([]Package, 0, len(paths))
+	for _, pkgpath := range paths {
+		if pkg, ok := LookupPackage(pkgpath); ok {
+			pkgs = append(pkgs, pkg)
+		}
+	}
+	return pkgs
+}
+
+// LookupPackage returns the documentation of the package of the standard library with the import path.
+// The builtins must be finalized.
+func LookupPackage(pkgpath string) (Package, bool) {
+	obj, ok := flux.StdLib().ImportPackageObject(pkgpath)
+	if !ok {
+		return Package{}, false
+	}
+	pkg := Package{
+		Path: pkgpath,
+		Name: obj.Name(),
+		Doc:  comments[pkgpath].Package,
+	}
+	obj.Range(func(name string, v values.Value) {
+		pkg.Values = append(pkg.Values, newValue(pkgpath, name, v))
+	})
+	sort.Slice(pkg.Values, func(i, j int) bool {
+		return pkg.Values[i].Name < pkg.Values[j].Name
+	})
+	return pkg, true
+}
+
+// Lookup returns the documentation of a value of the standard library.
+// The name is either the name of a value of the prelude, such as "range",
+// or qualified by the import path or the name of its package, such as "strings.title".
+// The builtins must be finalized.
+func Lookup(name string) (Value, error) {
+	i := strings.LastIndex(name, ".")
+	if i < 0 {
+		for _, pkgpath := range flux.PreludePackagePaths() {
+			if v, ok := lookupValue(pkgpath, name); ok {
+				return v, nil
+			}
+		}
+		return Value{}, fmt.Errorf("%q is not a value of the prelude", name)
+	}
+
+	qualifier, member := name[:i], name[i+1:]
+	if v, ok := lookupValue(qualifier, member); ok {
+		return v, nil
+	}
+	var found []Value
+	for _, pkgpath := range flux.StdLibPackagePaths() {
+		if path.Base(pkgpath) != qualifier {
+			continue
+		}
+		if v, ok := lookupValue(pkgpath, member); ok {
+			found = append(found, v)
+		}
+	}
+	switch len(found) {
+	case 0:
+		return Value{}, fmt.Errorf("%q is not a value of the standard library", name)
+	case 1:
+		return found[0], nil
+	default:
+		paths := make([]string, len(found))
+		for i, v := range found {
+			paths[i] = v.Package
+		}
+		return Value{}, fmt.Errorf("%q is ambiguous, qualify it with one of the import paths %v", name, paths)
+	}
+}
+
+func lookupValue(pkgpath, name string) (Value, bool) {
+	obj, ok := flux.StdLib().ImportPackageObject(pkgpath)
+	if !ok {
+		return Value{}, false
+	}
+	v, ok := obj.Get(name)
+	if !ok {
+		return Value{}, false
+	}
+	return newValue(pkgpath, name, v), true
+}
+
+func newValue(pkgpath, name string, v values.Value) Value {
+	typ := v.PolyType()
+	doc := Value{
+		Package: pkgpath,
+		Name:    name,
+		Doc:     comments[pkgpath].Values[name],
+		Type:    fmt.Sprint(typ),
+	}
+	ft, ok := typ.(functionType)
+	if !ok || typ.Nature() != semantic.Function {
+		return doc
+	}
+	sig := ft.Signature()
+	required := make(map[string]bool, len(sig.Required))
+	for _, l := range sig.Required {
+		required[l] = true
+	}
+	doc.Parameters = make([]Parameter, 0, len(sig.Parameters))
+	for l, t := range sig.Parameters {
+		doc.Parameters = append(doc.Parameters, Parameter{
+			Name:     l,
+			Type:     fmt.Sprint(t),
+			Required: required[l],
+			Pipe:     l == sig.PipeArgument,
+		})
+	}
+	sort.Slice(doc.Parameters, func(i, j int) bool {
+		return doc.Parameters[i].Name < doc.Parameters[j].Name
+	})
+	if sig.Return != nil {
+		doc.Return = fmt.Sprint(sig.Return)
+	}
+	return doc
+}
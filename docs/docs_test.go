@@ -0,0 +1,81 @@
+package docs_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	_ "github.com/influxdata/flux/builtin"
+	"github.com/influxdata/flux/docs"
+)
+
+func TestLookup(t *testing.T) {
+	got, err := docs.Lookup("strings.title")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := docs.Value{
+		Package: "strings",
+		Name:    "title",
+		Type:    "(^v: string) -> string",
+		Parameters: []docs.Parameter{
+			{Name: "v", Type: "string", Required: true},
+		},
+		Return: "string",
+	}
+	if !cmp.Equal(want, got) {
+		t.Errorf("unexpected documentation -want/+got:\n%s", cmp.Diff(want, got))
+	}
+}
+
+func TestLookup_Comments(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		pkg  string
+		doc  string
+	}{
+		{name: "top", pkg: "universe", doc: "top sorts a table"},
+		{name: "v1.fieldsAsCols", pkg: "influxdata/influxdb/v1", doc: "fieldsAsCols is"},
+		{name: "influxdata/influxdb/v1.fieldsAsCols", pkg: "influxdata/influxdb/v1", doc: "fieldsAsCols is"},
+	} {
+		v, err := docs.Lookup(tc.name)
+		if err != nil {
+			t.Errorf("unexpected error looking up %q: %v", tc.name, err)
+			continue
+		}
+		if v.Package != tc.pkg {
+			t.Errorf("unexpected package of %q: want %q, got %q", tc.name, tc.pkg, v.Package)
+		}
+		if !strings.HasPrefix(v.Doc, tc.doc) {
+			t.Errorf("unexpected documentation of %q: want prefix %q, got %q", tc.name, tc.doc, v.Doc)
+		}
+	}
+}
+
+func TestLookup_NotFound(t *testing.T) {
+	for _, name := range []string{"nope", "strings.nope", "nope.title"} {
+		if _, err := docs.Lookup(name); err == nil {
+			t.Errorf("expected an error looking up %q", name)
+		}
+	}
+}
+
+func TestPackages(t *testing.T) {
+	pkgs := docs.Packages()
+	for i, pkg := range pkgs {
+		if i > 0 && pkgs[i-1].Path >= pkg.Path {
+			t.Errorf("packages are not sorted: %q before %q", pkgs[i-1].Path, pkg.Path)
+		}
+	}
+	pkg, ok := docs.LookupPackage("strings")
+	if !ok {
+		t.Fatal("missing package strings")
+	}
+	var names []string
+	for _, v := range pkg.Values {
+		names = append(names, v.Name)
+	}
+	if want := []string{"equalFold", "levenshtein", "similarity", "title", "toLower", "toTitle", "toUpper", "trim", "trimSpace"}; !cmp.Equal(want, names) {
+		t.Errorf("unexpected values of package strings -want/+got:\n%s", cmp.Diff(want, names))
+	}
+}
@@ -0,0 +1,143 @@
+// Package errors defines the codes that classify the errors of Flux,
+// so that the layers that report errors to users, for example an HTTP API,
+// can map them to their own status codes.
+//
+// Errors are classified either by creating them with New, Newf, Wrap or Wrapf,
+// or by implementing Coder. CodeOf finds the code of an error
+// through the errors that wrap it.
+package errors
+
+import (
+	"fmt"
+)
+
+// Code classifies an error.
+type Code int
+
+const (
+	// Unknown is the code of errors that are not classified.
+	// An Error with the Unknown code inherits the code of the error it wraps.
+	Unknown Code = iota
+	// Invalid means the script or one of its arguments is not valid,
+	// for example it does not parse or type check.
+	Invalid
+	// NotFound means the script refers to something that does not exist,
+	// for example an identifier or a package.
+	NotFound
+	// ResourceExhausted means the query exceeded a limit on its resources,
+	// for example its memory or its time.
+	ResourceExhausted
+	// Internal means Flux failed in a way that is not caused by the script.
+	Internal
+	// Unimplemented means the script uses a feature that is not implemented.
+	Unimplemented
+	// Unavailable means the query could not be executed for now, but may be retried,
+	// for example because the server executing it is shutting down.
+	Unavailable
+	// Aborted means the script aborted the query on purpose with an error of its own,
+	// for example with die() or assert().
+	Aborted
+)
+
+func (c Code) String() string {
+	switch c {
+	case Unknown:
+		return "unknown"
+	case Invalid:
+		return "invalid"
+	case NotFound:
+		return "not found"
+	case ResourceExhausted:
+		return "resource exhausted"
+	case Internal:
+		return "internal"
+	case Unimplemented:
+		return "unimplemented"
+	case Unavailable:
+		return "unavailable"
+	case Aborted:
+		return "aborted"
+	default:
+		return fmt.Sprintf("code(%d)", int(c))
+	}
+}
+
+// Coder is implemented by errors that classify themselves.
+type Coder interface {
+	Code() Code
+}
+
+// Error is an error with a code.
+// It is not a causer, so errors.Cause of github.com/pkg/errors finds it when it has been wrapped.
+type Error struct {
+	Code Code
+	// Msg describes the error, or is empty if Err describes it on its own.
+	Msg string
+	// Err is the error that this error wraps, or nil.
+	Err error
+}
+
+func (e *Error) Error() string {
+	switch {
+	case e.Msg != "" && e.Err != nil:
+		return e.Msg + ": " + e.Err.Error()
+	case e.Msg != "":
+		return e.Msg
+	case e.Err != nil:
+		return e.Err.Error()
+	default:
+		return e.Code.String()
+	}
+}
+
+// New returns an error with the code and message.
+func New(code Code, msg string) error {
+	return &Error{Code: code, Msg: msg}
+}
+
+// Newf returns an error with the code and the formatted message.
+func Newf(code Code, format string, a ...interface{}) error {
+	return &Error{Code: code, Msg: fmt.Sprintf(format, a...)}
+}
+
+// Wrap returns an error with the code that wraps err.
+// The message may be empty, in which case the error reads as err.
+// Wrap returns nil if err is nil.
+func Wrap(err error, code Code, msg string) error {
+	if err == nil {
+		return nil
+	}
+	return &Error{Code: code, Msg: msg, Err: err}
+}
+
+// Wrapf returns an error with the code and the formatted message that wraps err.
+// Wrapf returns nil if err is nil.
+func Wrapf(err error, code Code, format string, a ...interface{}) error {
+	if err == nil {
+		return nil
+	}
+	return &Error{Code: code, Msg: fmt.Sprintf(format, a...), Err: err}
+}
+
+// CodeOf returns the code of err.
+// It follows the errors that err wraps, either as an Error with the Unknown code
+// or as a causer of github.com/pkg/errors, until it finds an error with a code.
+// It returns Unknown if err is nil or if none of the errors has a code.
+func CodeOf(err error) Code {
+	for err != nil {
+		switch e := err.(type) {
+		case *Error:
+			if e.Code != Unknown {
+				return e.Code
+			}
+			err = e.Err
+		case Coder:
+			return e.Code()
+		case interface{ Cause() error }:
+			err = e.Cause()
+		default:
+			return Unknown
+		}
+	}
+	return Unknown
+}
@@ -0,0 +1,71 @@
+package errors_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/influxdata/flux/errors"
+	pkgerrors "github.com/pkg/errors"
+)
+
+type coder struct{}
+
+func (coder) Error() string     { return "coder" }
+func (coder) Code() errors.Code { return errors.NotFound }
+
+func TestCodeOf(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		err  error
+		want errors.Code
+	}{
+		{name: "nil", want: errors.Unknown},
+		{name: "unclassified", err: context.Canceled, want: errors.Unknown},
+		{name: "new", err: errors.New(errors.Invalid, "bad"), want: errors.Invalid},
+		{name: "unavailable", err: errors.New(errors.Unavailable, "server shutting down"), want: errors.Unavailable},
+		{name: "aborted", err: errors.New(errors.Aborted, "no data"), want: errors.Aborted},
+		{name: "coder", err: coder{}, want: errors.NotFound},
+		{
+			name: "wrapped by a causer",
+			err:  pkgerrors.Wrap(errors.Newf(errors.Unimplemented, "%s is not implemented", "f"), "failed"),
+			want: errors.Unimplemented,
+		},
+		{
+			name: "inherited",
+			err:  errors.Wrap(coder{}, errors.Unknown, "failed"),
+			want: errors.NotFound,
+		},
+		{
+			name: "outermost",
+			err:  errors.Wrap(coder{}, errors.Internal, "failed"),
+			want: errors.Internal,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			if got := errors.CodeOf(tc.err); got != tc.want {
+				t.Errorf("unexpected code: want %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestError_Error(t *testing.T) {
+	for _, tc := range []struct {
+		err  error
+		want string
+	}{
+		{err: errors.New(errors.Invalid, "bad"), want: "bad"},
+		{err: errors.Wrap(context.Canceled, errors.Internal, "failed"), want: "failed: context canceled"},
+		{err: errors.Wrap(context.Canceled, errors.Internal, ""), want: "context canceled"},
+		{err: errors.Wrap(nil, errors.Internal, "failed"), want: ""},
+	} {
+		var got string
+		if tc.err != nil {
+			got = tc.err.Error()
+		}
+		if got != tc.want {
+			t.Errorf("unexpected message: want %q, got %q", tc.want, got)
+		}
+	}
+}
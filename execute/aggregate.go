@@ -23,6 +23,8 @@ type aggregateTransformation struct {
 type AggregateConfig struct {
 	plan.DefaultCost
 	Columns []string `json:"columns"`
+	// NaN is the policy for the NaN and infinite values of the float columns.
+	NaN NaNPolicy `json:"nan,omitempty"`
 }
 
 var DefaultAggregateConfig = AggregateConfig{
@@ -36,6 +38,7 @@ func AggregateSignature(args map[string]semantic.PolyType, required []string) se
 		args = make(map[string]semantic.PolyType)
 	}
 	args["columns"] = semantic.NewArrayPolyType(semantic.String)
+	args["nan"] = semantic.String
 	return flux.FunctionSignature(args, required)
 }
 
@@ -60,9 +63,29 @@ func (c *AggregateConfig) ReadArgs(args flux.Arguments) error {
 	} else {
 		c.Columns = DefaultAggregateConfig.Columns
 	}
+	return c.readNaNPolicy(args)
+}
+
+func (c *AggregateConfig) readNaNPolicy(args flux.Arguments) error {
+	if s, ok, err := args.GetString("nan"); err != nil {
+		return err
+	} else if ok {
+		p, err := ParseNaNPolicy(s)
+		if err != nil {
+			return err
+		}
+		c.NaN = p
+	}
 	return nil
 }
 
+// SetDefaultNaNPolicy implements NaNPolicySpec.
+func (c *AggregateConfig) SetDefaultNaNPolicy(p NaNPolicy) {
+	if c.NaN == "" {
+		c.NaN = p
+	}
+}
+
 func NewAggregateTransformation(d Dataset, c TableBuilderCache, agg Aggregate, config AggregateConfig) *aggregateTransformation {
 	return &aggregateTransformation{
 		d:      d,
@@ -156,7 +179,14 @@ func (t *aggregateTransformation) Process(id DatasetID, tbl flux.Table) error {
 			case flux.TUInt:
 				vf.(DoUIntAgg).DoUInt(cr.UInts(tj))
 			case flux.TFloat:
-				vf.(DoFloatAgg).DoFloat(cr.Floats(tj))
+				vs, err := t.config.NaN.RemoveFloats(c.Label, cr.Floats(tj))
+				if err != nil {
+					return err
+				}
+				vf.(DoFloatAgg).DoFloat(vs)
+				if vs != cr.Floats(tj) {
+					vs.Release()
+				}
 			case flux.TString:
 				vf.(DoStringAgg).DoString(cr.Strings(tj))
 			default:
@@ -167,6 +197,13 @@ func (t *aggregateTransformation) Process(id DatasetID, tbl flux.Table) error {
 	}); err != nil {
 		return err
 	}
+	for _, vf := range aggregates {
+		if ef, ok := vf.(ErrValueFunc); ok {
+			if err := ef.Err(); err != nil {
+				return err
+			}
+		}
+	}
 	for j, vf := range aggregates {
 		bj := builderColMap[j]
 
@@ -233,6 +270,13 @@ type ValueFunc interface {
 	Type() flux.ColType
 	IsNull() bool
 }
+
+// ErrValueFunc is implemented by the aggregates of values that can be invalid,
+// such as the partial aggregates combined by the final phase of an aggregate.
+// The aggregate transformation fails with the error instead of producing a value.
+type ErrValueFunc interface {
+	Err() error
+}
 type DoBoolAgg interface {
 	ValueFunc
 	DoBool(*array.Boolean)
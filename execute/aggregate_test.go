@@ -1,6 +1,7 @@
 package execute_test
 
 import (
+	"math"
 	"sort"
 	"testing"
 
@@ -546,6 +547,42 @@ func TestAggregate_Process(t *testing.T) {
 				},
 			}},
 		},
+		{
+			name: "drop NaN",
+			config: execute.AggregateConfig{
+				Columns: []string{"x", "y"},
+				NaN:     execute.NaNDrop,
+			},
+			agg: countAgg,
+			data: []*executetest.Table{{
+				KeyCols: []string{"_start", "_stop"},
+				ColMeta: []flux.ColMeta{
+					{Label: "_start", Type: flux.TTime},
+					{Label: "_stop", Type: flux.TTime},
+					{Label: "_time", Type: flux.TTime},
+					{Label: "x", Type: flux.TFloat},
+					{Label: "y", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{execute.Time(0), execute.Time(100), execute.Time(0), 0.0, math.NaN()},
+					{execute.Time(0), execute.Time(100), execute.Time(10), math.Inf(1), -1.0},
+					{execute.Time(0), execute.Time(100), execute.Time(20), 2.0, math.Inf(-1)},
+					{execute.Time(0), execute.Time(100), execute.Time(30), 3.0, -3.0},
+				},
+			}},
+			want: []*executetest.Table{{
+				KeyCols: []string{"_start", "_stop"},
+				ColMeta: []flux.ColMeta{
+					{Label: "_start", Type: flux.TTime},
+					{Label: "_stop", Type: flux.TTime},
+					{Label: "x", Type: flux.TInt},
+					{Label: "y", Type: flux.TInt},
+				},
+				Data: [][]interface{}{
+					{execute.Time(0), execute.Time(100), int64(3), int64(2)},
+				},
+			}},
+		},
 	}
 	for _, tc := range testCases {
 		tc := tc
@@ -574,7 +611,8 @@ func TestAggregate_Process(t *testing.T) {
 			sort.Sort(executetest.SortedTables(got))
 			sort.Sort(executetest.SortedTables(tc.want))
 
-			if !cmp.Equal(tc.want, got, cmpopts.EquateNaNs()) {
+			// The float sums of the vectorized kernels are not associated in row order.
+			if !cmp.Equal(tc.want, got, cmpopts.EquateNaNs(), cmpopts.EquateApprox(0, 1e-12)) {
 				t.Errorf("unexpected tables -want/+got\n%s", cmp.Diff(tc.want, got))
 			}
 		})
@@ -0,0 +1,77 @@
+package execute
+
+import (
+	"context"
+	"sync"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/memory"
+	"github.com/influxdata/flux/plan"
+)
+
+// MaxAllocatedMetadataPrefix prefixes the kind of a procedure in the key of the metadata
+// that reports the memory allocated by the operators of that kind.
+const MaxAllocatedMetadataPrefix = "max_allocated/"
+
+// KindAllocations records the memory that the operators of the executed queries allocate,
+// by the kind of their procedure.
+type KindAllocations struct {
+	mu     sync.Mutex
+	allocs map[plan.ProcedureKind][]*memory.Allocator
+}
+
+func NewKindAllocations() *KindAllocations {
+	return &KindAllocations{
+		allocs: make(map[plan.ProcedureKind][]*memory.Allocator),
+	}
+}
+
+// WithKindAllocations returns a context with which the memory of the operators of the executed queries
+// is recorded by a. A nil recorder disables the recording.
+func WithKindAllocations(ctx context.Context, a *KindAllocations) context.Context {
+	return context.WithValue(ctx, kindAllocationsKey, a)
+}
+
+func kindAllocationsFromContext(ctx context.Context) *KindAllocations {
+	a, _ := ctx.Value(kindAllocationsKey).(*KindAllocations)
+	return a
+}
+
+// add records the allocator with which the operators of a kind allocate their memory.
+func (a *KindAllocations) add(kind plan.ProcedureKind, alloc *memory.Allocator) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.allocs[kind] = append(a.allocs[kind], alloc)
+}
+
+// MaxAllocated returns the maximum number of bytes that the operators of every kind had allocated
+// at the same time. The operators of a kind in distinct executions, such as the pipelines of
+// experimental.tableMap(), are not added together, the kind reports the execution that allocated the most.
+func (a *KindAllocations) MaxAllocated() map[plan.ProcedureKind]int64 {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	max := make(map[plan.ProcedureKind]int64, len(a.allocs))
+	for kind, allocs := range a.allocs {
+		max[kind] = 0
+		for _, alloc := range allocs {
+			if n := alloc.MaxAllocated(); n > max[kind] {
+				max[kind] = n
+			}
+		}
+	}
+	return max
+}
+
+// Metadata reports the memory of every kind of operator under the key
+// MaxAllocatedMetadataPrefix followed by the kind.
+func (a *KindAllocations) Metadata() flux.Metadata {
+	max := a.MaxAllocated()
+	if len(max) == 0 {
+		return nil
+	}
+	md := make(flux.Metadata, len(max))
+	for kind, n := range max {
+		md.Add(MaxAllocatedMetadataPrefix+string(kind), n)
+	}
+	return md
+}
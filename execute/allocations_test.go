@@ -0,0 +1,79 @@
+package execute_test
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/flux/memory"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/plan/plantest"
+	"github.com/influxdata/flux/stdlib/universe"
+)
+
+func TestKindAllocations(t *testing.T) {
+	input := &executetest.Table{
+		ColMeta: []flux.ColMeta{
+			{Label: "_time", Type: flux.TTime},
+			{Label: "_value", Type: flux.TFloat},
+		},
+	}
+	for i := 0; i < 10; i++ {
+		input.Data = append(input.Data, []interface{}{execute.Time(i), float64(i)})
+	}
+	spec := &plantest.PlanSpec{
+		Nodes: []plan.PlanNode{
+			plan.CreatePhysicalNode("from-test", executetest.NewFromProcedureSpec([]*executetest.Table{input})),
+			plan.CreatePhysicalNode("limit0", &universe.LimitProcedureSpec{N: 5}),
+			plan.CreatePhysicalNode("limit1", &universe.LimitProcedureSpec{N: 2}),
+			plan.CreatePhysicalNode("yield", executetest.NewYieldProcedureSpec("_result")),
+		},
+		Edges: [][2]int{
+			{0, 1},
+			{1, 2},
+			{2, 3},
+		},
+		Resources: flux.ResourceManagement{
+			ConcurrencyQuota: 1,
+			MemoryBytesQuota: math.MaxInt64,
+		},
+		Now: time.Now(),
+	}
+
+	allocations := execute.NewKindAllocations()
+	alloc := &memory.Allocator{}
+	// The memory of profiled operators is accounted for in their kind too.
+	ctx := execute.WithProfiler(execute.WithKindAllocations(context.Background(), allocations), execute.NewProfiler())
+	results, err := execute.NewExecutor(nil, nil).Execute(ctx, plantest.CreatePlanSpec(spec), alloc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := results["_result"].Tables().Do(func(tbl flux.Table) error {
+		return tbl.Do(func(flux.ColReader) error { return nil })
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	got := allocations.MaxAllocated()
+	if len(got) != 2 {
+		t.Fatalf("expected the memory of 2 kinds of operators, got %v", got)
+	}
+	// The test source produces tables it already holds, the limits copy them.
+	if got[universe.LimitKind] == 0 {
+		t.Errorf("expected the memory of the limit operators to be recorded, got %v", got)
+	}
+	for kind, n := range got {
+		if n > alloc.MaxAllocated() {
+			t.Errorf("operators of kind %s allocated %d bytes, more than the %d bytes of the query", kind, n, alloc.MaxAllocated())
+		}
+	}
+
+	md := allocations.Metadata()
+	if values := md[execute.MaxAllocatedMetadataPrefix+string(universe.LimitKind)]; len(values) != 1 || values[0] != got[universe.LimitKind] {
+		t.Errorf("unexpected metadata of the limit operators: %v", values)
+	}
+}
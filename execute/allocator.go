@@ -1,6 +1,8 @@
 package execute
 
 import (
+	"unsafe"
+
 	"github.com/influxdata/flux/memory"
 )
 
@@ -16,6 +18,10 @@ const (
 // Allocator tracks the amount of memory being consumed by a query.
 // The allocator provides methods similar to make and append, to allocate large slices of data.
 // The allocator also provides a Free method to account for when memory will be freed.
+//
+// If the query has a pool, the slices of fixed-width values are allocated from it,
+// and the slices that are outgrown or released are returned to it.
+// Slices of strings hold pointers and are always allocated with make.
 type Allocator struct {
 	*memory.Allocator
 }
@@ -31,10 +37,28 @@ func (a *Allocator) account(n, size int) {
 	}
 }
 
+// buffer returns a zeroed buffer for at least c values of the given size from the pool of the query,
+// or nil if the query has no pool. The length of the buffer is its capacity.
+func (a *Allocator) buffer(c, size int) []byte {
+	if a.Pool == nil || c == 0 {
+		return nil
+	}
+	b := a.Pool.Get(c * size)
+	return b[:cap(b)]
+}
+
+// release returns the buffer of c values of the given size at p to the pool of the query.
+func (a *Allocator) release(p unsafe.Pointer, c, size int) {
+	if a.Pool != nil {
+		a.Pool.Put(unsafe.Slice((*byte)(p), c*size))
+	}
+}
+
 // Bools makes a slice of bool values.
 func (a *Allocator) Bools(l, c int) []bool {
-	a.account(c, boolSize)
-	return make([]bool, l, c)
+	s := a.bools(c)
+	a.account(cap(s), boolSize)
+	return s[:l]
 }
 
 // AppendBools appends bools to a slice
@@ -42,7 +66,15 @@ func (a *Allocator) AppendBools(slice []bool, vs ...bool) []bool {
 	if cap(slice)-len(slice) > len(vs) {
 		return append(slice, vs...)
 	}
-	s := append(slice, vs...)
+	var s []bool
+	if a.Pool == nil {
+		s = append(slice, vs...)
+	} else {
+		// grow capacity same way as GrowBools
+		s = append(a.bools((len(slice)+len(vs))*3/2+1), slice...)
+		s = append(s, vs...)
+		a.releaseBools(slice)
+	}
 	diff := cap(s) - cap(slice)
 	a.account(diff, boolSize)
 	return s
@@ -55,17 +87,35 @@ func (a *Allocator) GrowBools(slice []bool, n int) []bool {
 	}
 	// grow capacity same way as built-in append
 	newCap = newCap*3/2 + 1
-	s := make([]bool, len(slice)+n, newCap)
+	s := a.bools(newCap)[:len(slice)+n]
 	copy(s, slice)
+	a.releaseBools(slice)
 	diff := cap(s) - cap(slice)
 	a.account(diff, boolSize)
 	return s
 }
 
+// bools returns an empty slice with a capacity of at least c.
+func (a *Allocator) bools(c int) []bool {
+	if b := a.buffer(c, boolSize); b != nil {
+		return unsafe.Slice((*bool)(unsafe.Pointer(&b[0])), len(b)/boolSize)[:0]
+	}
+	return make([]bool, 0, c)
+}
+
+// releaseBools returns the memory of a slice to the pool of the query.
+// The slice must not be used afterwards.
+func (a *Allocator) releaseBools(s []bool) {
+	if cap(s) > 0 {
+		a.release(unsafe.Pointer(&s[:1][0]), cap(s), boolSize)
+	}
+}
+
 // Ints makes a slice of int64 values.
 func (a *Allocator) Ints(l, c int) []int64 {
-	a.account(c, int64Size)
-	return make([]int64, l, c)
+	s := a.ints(c)
+	a.account(cap(s), int64Size)
+	return s[:l]
 }
 
 // AppendInts appends int64s to a slice
@@ -73,7 +123,15 @@ func (a *Allocator) AppendInts(slice []int64, vs ...int64) []int64 {
 	if cap(slice)-len(slice) > len(vs) {
 		return append(slice, vs...)
 	}
-	s := append(slice, vs...)
+	var s []int64
+	if a.Pool == nil {
+		s = append(slice, vs...)
+	} else {
+		// grow capacity same way as GrowInts
+		s = append(a.ints((len(slice)+len(vs))*3/2+1), slice...)
+		s = append(s, vs...)
+		a.releaseInts(slice)
+	}
 	diff := cap(s) - cap(slice)
 	a.account(diff, int64Size)
 	return s
@@ -86,17 +144,35 @@ func (a *Allocator) GrowInts(slice []int64, n int) []int64 {
 	}
 	// grow capacity same way as built-in append
 	newCap = newCap*3/2 + 1
-	s := make([]int64, len(slice)+n, newCap)
+	s := a.ints(newCap)[:len(slice)+n]
 	copy(s, slice)
+	a.releaseInts(slice)
 	diff := cap(s) - cap(slice)
 	a.account(diff, int64Size)
 	return s
 }
 
+// ints returns an empty slice with a capacity of at least c.
+func (a *Allocator) ints(c int) []int64 {
+	if b := a.buffer(c, int64Size); b != nil {
+		return unsafe.Slice((*int64)(unsafe.Pointer(&b[0])), len(b)/int64Size)[:0]
+	}
+	return make([]int64, 0, c)
+}
+
+// releaseInts returns the memory of a slice to the pool of the query.
+// The slice must not be used afterwards.
+func (a *Allocator) releaseInts(s []int64) {
+	if cap(s) > 0 {
+		a.release(unsafe.Pointer(&s[:1][0]), cap(s), int64Size)
+	}
+}
+
 // UInts makes a slice of uint64 values.
 func (a *Allocator) UInts(l, c int) []uint64 {
-	a.account(c, uint64Size)
-	return make([]uint64, l, c)
+	s := a.uints(c)
+	a.account(cap(s), uint64Size)
+	return s[:l]
 }
 
 // AppendUInts appends uint64s to a slice
@@ -104,7 +180,15 @@ func (a *Allocator) AppendUInts(slice []uint64, vs ...uint64) []uint64 {
 	if cap(slice)-len(slice) > len(vs) {
 		return append(slice, vs...)
 	}
-	s := append(slice, vs...)
+	var s []uint64
+	if a.Pool == nil {
+		s = append(slice, vs...)
+	} else {
+		// grow capacity same way as GrowUInts
+		s = append(a.uints((len(slice)+len(vs))*3/2+1), slice...)
+		s = append(s, vs...)
+		a.releaseUInts(slice)
+	}
 	diff := cap(s) - cap(slice)
 	a.account(diff, uint64Size)
 	return s
@@ -117,17 +201,35 @@ func (a *Allocator) GrowUInts(slice []uint64, n int) []uint64 {
 	}
 	// grow capacity same way as built-in append
 	newCap = newCap*3/2 + 1
-	s := make([]uint64, len(slice)+n, newCap)
+	s := a.uints(newCap)[:len(slice)+n]
 	copy(s, slice)
+	a.releaseUInts(slice)
 	diff := cap(s) - cap(slice)
 	a.account(diff, uint64Size)
 	return s
 }
 
+// uints returns an empty slice with a capacity of at least c.
+func (a *Allocator) uints(c int) []uint64 {
+	if b := a.buffer(c, uint64Size); b != nil {
+		return unsafe.Slice((*uint64)(unsafe.Pointer(&b[0])), len(b)/uint64Size)[:0]
+	}
+	return make([]uint64, 0, c)
+}
+
+// releaseUInts returns the memory of a slice to the pool of the query.
+// The slice must not be used afterwards.
+func (a *Allocator) releaseUInts(s []uint64) {
+	if cap(s) > 0 {
+		a.release(unsafe.Pointer(&s[:1][0]), cap(s), uint64Size)
+	}
+}
+
 // Floats makes a slice of float64 values.
 func (a *Allocator) Floats(l, c int) []float64 {
-	a.account(c, float64Size)
-	return make([]float64, l, c)
+	s := a.floats(c)
+	a.account(cap(s), float64Size)
+	return s[:l]
 }
 
 // AppendFloats appends float64s to a slice
@@ -135,7 +237,15 @@ func (a *Allocator) AppendFloats(slice []float64, vs ...float64) []float64 {
 	if cap(slice)-len(slice) > len(vs) {
 		return append(slice, vs...)
 	}
-	s := append(slice, vs...)
+	var s []float64
+	if a.Pool == nil {
+		s = append(slice, vs...)
+	} else {
+		// grow capacity same way as GrowFloats
+		s = append(a.floats((len(slice)+len(vs))*3/2+1), slice...)
+		s = append(s, vs...)
+		a.releaseFloats(slice)
+	}
 	diff := cap(s) - cap(slice)
 	a.account(diff, float64Size)
 	return s
@@ -148,13 +258,30 @@ func (a *Allocator) GrowFloats(slice []float64, n int) []float64 {
 	}
 	// grow capacity same way as built-in append
 	newCap = newCap*3/2 + 1
-	s := make([]float64, len(slice)+n, newCap)
+	s := a.floats(newCap)[:len(slice)+n]
 	copy(s, slice)
+	a.releaseFloats(slice)
 	diff := cap(s) - cap(slice)
 	a.account(diff, float64Size)
 	return s
 }
 
+// floats returns an empty slice with a capacity of at least c.
+func (a *Allocator) floats(c int) []float64 {
+	if b := a.buffer(c, float64Size); b != nil {
+		return unsafe.Slice((*float64)(unsafe.Pointer(&b[0])), len(b)/float64Size)[:0]
+	}
+	return make([]float64, 0, c)
+}
+
+// releaseFloats returns the memory of a slice to the pool of the query.
+// The slice must not be used afterwards.
+func (a *Allocator) releaseFloats(s []float64) {
+	if cap(s) > 0 {
+		a.release(unsafe.Pointer(&s[:1][0]), cap(s), float64Size)
+	}
+}
+
 // Strings makes a slice of string values.
 // Only the string headers are accounted for.
 func (a *Allocator) Strings(l, c int) []string {
@@ -191,8 +318,9 @@ func (a *Allocator) GrowStrings(slice []string, n int) []string {
 
 // Times makes a slice of Time values.
 func (a *Allocator) Times(l, c int) []Time {
-	a.account(c, timeSize)
-	return make([]Time, l, c)
+	s := a.times(c)
+	a.account(cap(s), timeSize)
+	return s[:l]
 }
 
 // AppendTimes appends Times to a slice
@@ -200,7 +328,15 @@ func (a *Allocator) AppendTimes(slice []Time, vs ...Time) []Time {
 	if cap(slice)-len(slice) > len(vs) {
 		return append(slice, vs...)
 	}
-	s := append(slice, vs...)
+	var s []Time
+	if a.Pool == nil {
+		s = append(slice, vs...)
+	} else {
+		// grow capacity same way as GrowTimes
+		s = append(a.times((len(slice)+len(vs))*3/2+1), slice...)
+		s = append(s, vs...)
+		a.releaseTimes(slice)
+	}
 	diff := cap(s) - cap(slice)
 	a.account(diff, timeSize)
 	return s
@@ -213,9 +349,26 @@ func (a *Allocator) GrowTimes(slice []Time, n int) []Time {
 	}
 	// grow capacity same way as built-in append
 	newCap = newCap*3/2 + 1
-	s := make([]Time, len(slice)+n, newCap)
+	s := a.times(newCap)[:len(slice)+n]
 	copy(s, slice)
+	a.releaseTimes(slice)
 	diff := cap(s) - cap(slice)
 	a.account(diff, timeSize)
 	return s
 }
+
+// times returns an empty slice with a capacity of at least c.
+func (a *Allocator) times(c int) []Time {
+	if b := a.buffer(c, timeSize); b != nil {
+		return unsafe.Slice((*Time)(unsafe.Pointer(&b[0])), len(b)/timeSize)[:0]
+	}
+	return make([]Time, 0, c)
+}
+
+// releaseTimes returns the memory of a slice to the pool of the query.
+// The slice must not be used afterwards.
+func (a *Allocator) releaseTimes(s []Time) {
+	if cap(s) > 0 {
+		a.release(unsafe.Pointer(&s[:1][0]), cap(s), timeSize)
+	}
+}
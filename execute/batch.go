@@ -0,0 +1,167 @@
+package execute
+
+import (
+	"sync"
+	"time"
+
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/influxdata/flux"
+)
+
+const (
+	// targetBatchBytes is the size of the first batches of a transformation,
+	// from which the number of rows of the batches is derived given the width of the rows.
+	targetBatchBytes = 1 << 20
+	// targetBatchDuration is the time the downstream transformations should take to consume a batch.
+	// Larger batches amortize the cost of passing them, smaller batches reach the downstream transformations sooner.
+	targetBatchDuration = 10 * time.Millisecond
+)
+
+// ExecutorOption configures an Executor.
+type ExecutorOption func(*executor)
+
+// WithBatchSize bounds the number of rows of the batches in which transformations pass their tables
+// to the downstream transformations.
+// The number of rows is adapted for every transformation between the bounds, to the width of its rows
+// and to the time the downstream transformations take to consume its batches.
+// By default, the tables are passed in the batches they were built with.
+func WithBatchSize(min, max int) ExecutorOption {
+	return func(e *executor) {
+		if min < 1 {
+			min = 1
+		}
+		if max < min {
+			max = min
+		}
+		e.minBatchSize, e.maxBatchSize = min, max
+	}
+}
+
+// batchSizer adapts the number of rows of the batches of a transformation.
+type batchSizer struct {
+	min, max int
+
+	mu sync.Mutex
+	// rows is the number of rows of the next batch, zero until the first batch.
+	rows int
+}
+
+func newBatchSizer(min, max int) *batchSizer {
+	return &batchSizer{
+		min: min,
+		max: max,
+	}
+}
+
+func (s *batchSizer) clamp(rows int) int {
+	if rows < s.min {
+		return s.min
+	}
+	if rows > s.max {
+		return s.max
+	}
+	return rows
+}
+
+// size returns the number of rows of the next batch of the column reader.
+func (s *batchSizer) size(cr flux.ColReader) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.rows == 0 {
+		s.rows = s.clamp(targetBatchBytes / rowWidth(cr))
+	}
+	return s.rows
+}
+
+// observe adapts the number of rows of the batches to the time
+// the downstream transformation took to consume a batch of n rows.
+func (s *batchSizer) observe(n int, d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if n < s.rows {
+		// The last batch of a table is smaller, its duration says little about the rate.
+		return
+	}
+	switch {
+	case d > targetBatchDuration:
+		s.rows = s.clamp(s.rows / 2)
+	case d < targetBatchDuration/4:
+		s.rows = s.clamp(s.rows * 2)
+	}
+}
+
+// rowWidth returns the average number of bytes of the rows of the column reader.
+func rowWidth(cr flux.ColReader) int {
+	width := 0
+	for j, c := range cr.Cols() {
+		switch c.Type {
+		case flux.TBool:
+			width++
+		case flux.TString:
+			// The offset of the value and its average length.
+			width += 4
+			if vs := cr.Strings(j); vs.Len() > 0 {
+				width += len(vs.ValueBytes()) / vs.Len()
+			}
+		default:
+			width += 8
+		}
+	}
+	if width == 0 {
+		return 1
+	}
+	return width
+}
+
+// batchedTable passes the rows of a table in batches sized by a batchSizer.
+type batchedTable struct {
+	flux.Table
+	sizer *batchSizer
+}
+
+func (t *batchedTable) Do(f func(flux.ColReader) error) error {
+	return t.Table.Do(func(cr flux.ColReader) error {
+		l := cr.Len()
+		for start := 0; start < l; {
+			stop := start + t.sizer.size(cr)
+			if stop > l {
+				stop = l
+			}
+			if start == 0 && stop == l {
+				return t.consume(f, cr)
+			}
+			batch := sliceColReader(cr, start, stop)
+			err := t.consume(f, batch)
+			batch.Release()
+			if err != nil {
+				return err
+			}
+			start = stop
+		}
+		return nil
+	})
+}
+
+// consume passes a batch to f and observes the time f takes to consume it.
+func (t *batchedTable) consume(f func(flux.ColReader) error, cr flux.ColReader) error {
+	begin := time.Now()
+	if err := f(cr); err != nil {
+		return err
+	}
+	t.sizer.observe(cr.Len(), time.Since(begin))
+	return nil
+}
+
+// sliceColReader returns the rows [start, stop) of a ColReader, sharing its arrays.
+func sliceColReader(cr flux.ColReader, start, stop int) *arrayColReader {
+	arrs := make([]array.Interface, len(cr.Cols()))
+	for j := range cr.Cols() {
+		arrs[j] = sliceArray(ColReaderArray(cr, j), start, stop)
+	}
+	return &arrayColReader{
+		key:  cr.Key(),
+		cols: cr.Cols(),
+		l:    stop - start,
+		arrs: arrs,
+	}
+}
@@ -65,6 +65,14 @@ type dataset struct {
 	processingTime Time
 
 	cache DataCache
+
+	// sizer batches the tables passed to the transformations, unless it is nil.
+	sizer *batchSizer
+}
+
+// batchingDataset is implemented by datasets that pass their tables in batches.
+type batchingDataset interface {
+	setBatchSizer(s *batchSizer)
 }
 
 func NewDataset(id DatasetID, accMode AccumulationMode, cache DataCache) *dataset {
@@ -83,6 +91,10 @@ func (d *dataset) SetTriggerSpec(spec flux.TriggerSpec) {
 	d.cache.SetTriggerSpec(spec)
 }
 
+func (d *dataset) setBatchSizer(s *batchSizer) {
+	d.sizer = s
+}
+
 func (d *dataset) UpdateWatermark(mark Time) error {
 	d.watermark = mark
 	if err := d.evalTriggers(); err != nil {
@@ -137,6 +149,9 @@ func (d *dataset) triggerTable(key flux.GroupKey) error {
 		return err
 	}
 	b.RefCount(len(d.ts))
+	if d.sizer != nil {
+		b = &batchedTable{Table: b, sizer: d.sizer}
+	}
 	switch d.accMode {
 	case DiscardingMode:
 		for _, t := range d.ts {
@@ -0,0 +1,48 @@
+package execute
+
+import (
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/arrow"
+)
+
+// dictionaryMinRepetition is the minimum number of times the distinct values of a string column
+// are repeated on average for the column to be dictionary-encoded.
+const dictionaryMinRepetition = 2
+
+// DictionaryColReader is a ColReader whose string columns may be dictionary-encoded.
+//
+// The Strings method of the reader decodes a dictionary-encoded column.
+// Transformations that can operate on the indices of the dictionary,
+// for example to compare or hash the values, use Dictionary instead.
+type DictionaryColReader interface {
+	flux.ColReader
+	// Dictionary returns the values of string column j, or nil if the column is not dictionary-encoded.
+	Dictionary(j int) *arrow.Dictionary
+}
+
+// Dictionary returns the values of string column j of cr if it is dictionary-encoded, and nil otherwise.
+func Dictionary(cr flux.ColReader, j int) *arrow.Dictionary {
+	if dr, ok := cr.(DictionaryColReader); ok {
+		return dr.Dictionary(j)
+	}
+	return nil
+}
+
+// StringValues is the read access to the values of a string column,
+// which is common to plain and dictionary-encoded columns.
+type StringValues interface {
+	Len() int
+	IsNull(i int) bool
+	IsValid(i int) bool
+	Value(i int) []byte
+	ValueString(i int) string
+}
+
+// StringColumn returns the values of string column j of cr without decoding them
+// if the column is dictionary-encoded.
+func StringColumn(cr flux.ColReader, j int) StringValues {
+	if d := Dictionary(cr, j); d != nil {
+		return d
+	}
+	return cr.Strings(j)
+}
@@ -6,6 +6,7 @@ import (
 	"runtime/debug"
 	"sync"
 
+	fluxerrors "github.com/influxdata/flux/errors"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
@@ -70,7 +71,7 @@ func (d *poolDispatcher) Start(n int, ctx context.Context) {
 					default:
 						err = fmt.Errorf("%v", e)
 					}
-					d.setErr(fmt.Errorf("panic: %v\n%s", err, debug.Stack()))
+					d.setErr(fluxerrors.Newf(fluxerrors.Internal, "panic: %v\n%s", err, debug.Stack()))
 					if entry := d.logger.Check(zapcore.InfoLevel, "Dispatcher panic"); entry != nil {
 						entry.Stack = string(debug.Stack())
 						entry.Write(zap.Error(err))
@@ -0,0 +1,177 @@
+package execute
+
+import (
+	"fmt"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/memory"
+	"github.com/influxdata/flux/values"
+)
+
+// DuplicatePolicy determines how the rows of a table that have the same time are resolved.
+// Every table is a series, so those rows are duplicate points of the series.
+type DuplicatePolicy string
+
+const (
+	// KeepDuplicates keeps all the rows, it is the default.
+	KeepDuplicates DuplicatePolicy = "keep"
+	// KeepFirstDuplicate keeps the first row of the rows that have the same time.
+	KeepFirstDuplicate DuplicatePolicy = "first"
+	// KeepLastDuplicate keeps the last row of the rows that have the same time.
+	KeepLastDuplicate DuplicatePolicy = "last"
+	// ErrorOnDuplicates fails on the first rows that have the same time.
+	ErrorOnDuplicates DuplicatePolicy = "error"
+	// AggregateDuplicates replaces the rows that have the same time with a single row,
+	// whose numeric values are the mean of the non-null values of the rows,
+	// truncated for ints and uints, and whose other values are those of the last row.
+	AggregateDuplicates DuplicatePolicy = "aggregate"
+)
+
+// ParseDuplicatePolicy returns the DuplicatePolicy named s.
+// The empty string is KeepDuplicates.
+func ParseDuplicatePolicy(s string) (DuplicatePolicy, error) {
+	switch p := DuplicatePolicy(s); p {
+	case "":
+		return KeepDuplicates, nil
+	case KeepDuplicates, KeepFirstDuplicate, KeepLastDuplicate, ErrorOnDuplicates, AggregateDuplicates:
+		return p, nil
+	}
+	return "", fmt.Errorf("unknown duplicate policy %q, expected one of %q, %q, %q, %q or %q",
+		s, KeepDuplicates, KeepFirstDuplicate, KeepLastDuplicate, ErrorOnDuplicates, AggregateDuplicates)
+}
+
+// KeepsDuplicates reports whether the policy keeps all the rows, the empty policy does.
+func (p DuplicatePolicy) KeepsDuplicates() bool {
+	return p == "" || p == KeepDuplicates
+}
+
+// ResolveDuplicates returns a table with the rows of tbl that have the same value
+// in the time column resolved according to the policy.
+// The resolved rows take the place of the first of their duplicates, and the other rows keep their order.
+// Rows with a null time are never duplicates.
+// The table is returned unchanged if the policy keeps duplicates or it has no time column.
+func ResolveDuplicates(tbl flux.Table, timeCol string, policy DuplicatePolicy, a *memory.Allocator) (flux.Table, error) {
+	timeIdx := ColIdx(timeCol, tbl.Cols())
+	if policy.KeepsDuplicates() || timeIdx < 0 || tbl.Cols()[timeIdx].Type != flux.TTime {
+		return tbl, nil
+	}
+
+	copied, err := CopyTable(tbl, a)
+	if err != nil {
+		return nil, err
+	}
+	cr := copied.(flux.ColReader)
+	times := cr.Times(timeIdx)
+
+	// Group the indexes of the rows by time, in the order of the first row of every group.
+	var groups [][]int
+	groupIdxs := make(map[int64]int)
+	duplicates := false
+	for i := 0; i < cr.Len(); i++ {
+		if times.IsNull(i) {
+			groups = append(groups, []int{i})
+			continue
+		}
+		t := times.Value(i)
+		if g, ok := groupIdxs[t]; ok {
+			if policy == ErrorOnDuplicates {
+				return nil, fmt.Errorf("duplicate %s %v in table %v", timeCol, Time(t), tbl.Key())
+			}
+			groups[g] = append(groups[g], i)
+			duplicates = true
+			continue
+		}
+		groupIdxs[t] = len(groups)
+		groups = append(groups, []int{i})
+	}
+	if !duplicates {
+		return copied, nil
+	}
+
+	builder := NewColListTableBuilder(tbl.Key(), a)
+	if err := AddTableCols(copied, builder); err != nil {
+		return nil, err
+	}
+	for _, rows := range groups {
+		switch {
+		case len(rows) == 1 || policy == KeepFirstDuplicate:
+			err = AppendRecord(rows[0], cr, builder)
+		case policy == KeepLastDuplicate:
+			err = AppendRecord(rows[len(rows)-1], cr, builder)
+		default:
+			err = appendAggregatedRecord(rows, cr, builder)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return builder.Table()
+}
+
+// appendAggregatedRecord appends the record that aggregates the rows of cr to the builder,
+// as described by AggregateDuplicates.
+func appendAggregatedRecord(rows []int, cr flux.ColReader, builder TableBuilder) error {
+	last := rows[len(rows)-1]
+	for j, c := range cr.Cols() {
+		var err error
+		switch c.Type {
+		case flux.TFloat, flux.TInt, flux.TUInt:
+			if v, ok := meanValue(rows, cr, j); ok {
+				err = builder.AppendValue(j, v)
+			} else {
+				err = builder.AppendNil(j)
+			}
+		default:
+			err = builder.AppendValue(j, ValueForRow(cr, last, j))
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// meanValue returns the mean of the non-null values of the numeric column j in the rows,
+// with the type of the column, and false if they are all null.
+func meanValue(rows []int, cr flux.ColReader, j int) (values.Value, bool) {
+	n := 0
+	switch cr.Cols()[j].Type {
+	case flux.TFloat:
+		vs := cr.Floats(j)
+		var sum float64
+		for _, i := range rows {
+			if vs.IsValid(i) {
+				sum += vs.Value(i)
+				n++
+			}
+		}
+		if n > 0 {
+			return values.NewFloat(sum / float64(n)), true
+		}
+	case flux.TInt:
+		vs := cr.Ints(j)
+		var sum int64
+		for _, i := range rows {
+			if vs.IsValid(i) {
+				sum += vs.Value(i)
+				n++
+			}
+		}
+		if n > 0 {
+			return values.NewInt(sum / int64(n)), true
+		}
+	case flux.TUInt:
+		vs := cr.UInts(j)
+		var sum uint64
+		for _, i := range rows {
+			if vs.IsValid(i) {
+				sum += vs.Value(i)
+				n++
+			}
+		}
+		if n > 0 {
+			return values.NewUInt(sum / uint64(n)), true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,107 @@
+package execute_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+)
+
+func TestResolveDuplicates(t *testing.T) {
+	cols := []flux.ColMeta{
+		{Label: "_time", Type: flux.TTime},
+		{Label: "host", Type: flux.TString},
+		{Label: "f", Type: flux.TFloat},
+		{Label: "i", Type: flux.TInt},
+	}
+	data := [][]interface{}{
+		{execute.Time(1), "a", 1.0, int64(1)},
+		{execute.Time(2), "b", 2.0, int64(2)},
+		{execute.Time(3), "c", 3.0, int64(3)},
+		{execute.Time(2), "d", nil, int64(5)},
+		{nil, "e", 5.0, int64(5)},
+		{nil, "f", 6.0, int64(6)},
+	}
+
+	testCases := []struct {
+		policy  execute.DuplicatePolicy
+		want    [][]interface{}
+		wantErr string
+	}{
+		{
+			policy: execute.KeepDuplicates,
+			want:   data,
+		},
+		{
+			policy: execute.KeepFirstDuplicate,
+			want: [][]interface{}{
+				{execute.Time(1), "a", 1.0, int64(1)},
+				{execute.Time(2), "b", 2.0, int64(2)},
+				{execute.Time(3), "c", 3.0, int64(3)},
+				{nil, "e", 5.0, int64(5)},
+				{nil, "f", 6.0, int64(6)},
+			},
+		},
+		{
+			policy: execute.KeepLastDuplicate,
+			want: [][]interface{}{
+				{execute.Time(1), "a", 1.0, int64(1)},
+				{execute.Time(2), "d", nil, int64(5)},
+				{execute.Time(3), "c", 3.0, int64(3)},
+				{nil, "e", 5.0, int64(5)},
+				{nil, "f", 6.0, int64(6)},
+			},
+		},
+		{
+			policy: execute.AggregateDuplicates,
+			want: [][]interface{}{
+				{execute.Time(1), "a", 1.0, int64(1)},
+				{execute.Time(2), "d", 2.0, int64(3)},
+				{execute.Time(3), "c", 3.0, int64(3)},
+				{nil, "e", 5.0, int64(5)},
+				{nil, "f", 6.0, int64(6)},
+			},
+		},
+		{
+			policy:  execute.ErrorOnDuplicates,
+			wantErr: "duplicate _time 1970-01-01T00:00:00.000000002Z in table {}",
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(string(tc.policy), func(t *testing.T) {
+			tbl := &executetest.Table{ColMeta: cols, Data: data}
+			resolved, err := execute.ResolveDuplicates(tbl, execute.DefaultTimeColLabel, tc.policy, executetest.UnlimitedAllocator)
+			if tc.wantErr != "" {
+				if err == nil || err.Error() != tc.wantErr {
+					t.Fatalf("unexpected error: want %q, got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := executetest.ConvertTable(resolved)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !cmp.Equal(tc.want, got.Data) {
+				t.Errorf("unexpected rows -want/+got:\n%s", cmp.Diff(tc.want, got.Data))
+			}
+		})
+	}
+}
+
+func TestParseDuplicatePolicy(t *testing.T) {
+	if p, err := execute.ParseDuplicatePolicy(""); err != nil || p != execute.KeepDuplicates {
+		t.Errorf("unexpected default policy %q: %v", p, err)
+	}
+	if p, err := execute.ParseDuplicatePolicy("last"); err != nil || p != execute.KeepLastDuplicate {
+		t.Errorf("unexpected policy %q: %v", p, err)
+	}
+	if _, err := execute.ParseDuplicatePolicy("sum"); err == nil {
+		t.Error("expected an error for an unknown policy")
+	}
+}
@@ -50,6 +50,58 @@ func AggFuncTestHelper(t *testing.T, agg execute.Aggregate, data *array.Float64,
 	}
 }
 
+// SplitAggFuncTestHelper splits the data in half, runs the partial aggregate over each split,
+// then runs the final aggregate over the two partial aggregates and compares the Value to want.
+// The partial aggregates must be strings.
+func SplitAggFuncTestHelper(t *testing.T, partial, final execute.Aggregate, data *array.Float64, want interface{}) {
+	t.Helper()
+
+	h := data.Len() / 2
+	states := make([]string, 0, 2)
+	for _, d := range []*array.Float64{
+		arrow.FloatSlice(data, 0, h),
+		arrow.FloatSlice(data, h, data.Len()),
+	} {
+		vf := partial.NewFloatAgg()
+		vf.DoFloat(d)
+		d.Release()
+		if vf.Type() != flux.TString || vf.IsNull() {
+			t.Fatalf("unexpected partial aggregate of type %v, null %v", vf.Type(), vf.IsNull())
+		}
+		states = append(states, vf.(execute.StringValueFunc).ValueString())
+	}
+
+	vf := final.NewStringAgg()
+	vs := arrow.NewString(states, nil)
+	vf.DoString(vs)
+	vs.Release()
+	if ef, ok := vf.(execute.ErrValueFunc); ok {
+		if err := ef.Err(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var got interface{}
+	if !vf.IsNull() {
+		switch vf.Type() {
+		case flux.TBool:
+			got = vf.(execute.BoolValueFunc).ValueBool()
+		case flux.TInt:
+			got = vf.(execute.IntValueFunc).ValueInt()
+		case flux.TUInt:
+			got = vf.(execute.UIntValueFunc).ValueUInt()
+		case flux.TFloat:
+			got = vf.(execute.FloatValueFunc).ValueFloat()
+		case flux.TString:
+			got = vf.(execute.StringValueFunc).ValueString()
+		}
+	}
+
+	if !cmp.Equal(want, got, cmpopts.EquateNaNs()) {
+		t.Errorf("unexpected value -want/+got\n%s", cmp.Diff(want, got))
+	}
+}
+
 // AggFuncBenchmarkHelper benchmarks the aggregate function over data and compares to wantValue
 func AggFuncBenchmarkHelper(b *testing.B, agg execute.Aggregate, data *array.Float64, want interface{}) {
 	b.Helper()
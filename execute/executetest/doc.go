@@ -1,2 +1,9 @@
 // Package executetest contains utilities for testing the query execution phase.
+//
+// The package is meant to be used by the authors of transformations outside of
+// this repository as well. A transformation is registered as usual with
+// plan.RegisterProcedureSpec and execute.RegisterTransformation, and is then
+// tested with RunTransformationTestCase, which runs it on Table values the same way
+// the executor runs it within a query, or with ProcessTestHelper, which calls the
+// methods of the transformation directly.
 package executetest
@@ -0,0 +1,101 @@
+package executetest
+
+import (
+	"context"
+	"math"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/plan"
+)
+
+func init() {
+	execute.RegisterSource(FromTestKind, CreateFromSource)
+}
+
+// TransformationTestCase describes a test of a transformation.
+// The transformation must be registered with execute.RegisterTransformation
+// under the kind of Spec.
+type TransformationTestCase struct {
+	Name string
+	// Spec is the procedure spec of the transformation under test.
+	Spec plan.PhysicalProcedureSpec
+	// Data are the input tables of the transformation.
+	Data    []*Table
+	Want    []*Table
+	WantErr error
+}
+
+// RunTransformationTestCase runs the transformation of the test case on its data
+// the same way the executor runs it in a query and compares the produced tables
+// with the wanted tables, regardless of their order.
+func RunTransformationTestCase(t *testing.T, tc TransformationTestCase) {
+	t.Helper()
+
+	got, err := ExecuteTransformation(context.Background(), tc.Spec, tc.Data)
+	if tc.WantErr != nil {
+		if err == nil {
+			t.Fatalf("expected error %s, got none", tc.WantErr.Error())
+		} else if tc.WantErr.Error() != err.Error() {
+			t.Fatalf("unexpected error -want/+got\n%s", cmp.Diff(tc.WantErr.Error(), err.Error()))
+		}
+		return
+	} else if err != nil {
+		t.Fatalf("expected no error, got %s", err.Error())
+	}
+
+	want := make([]*Table, len(tc.Want))
+	copy(want, tc.Want)
+	NormalizeTables(want)
+	sort.Sort(SortedTables(got))
+	sort.Sort(SortedTables(want))
+
+	if !cmp.Equal(want, got, floatOptions) {
+		t.Errorf("unexpected tables -want/+got\n%s", cmp.Diff(want, got))
+	}
+}
+
+// ExecuteTransformation executes a plan that reads the data and passes it
+// to the transformation registered for the kind of spec.
+// It returns the normalized tables produced by the transformation.
+func ExecuteTransformation(ctx context.Context, spec plan.PhysicalProcedureSpec, data []*Table) ([]*Table, error) {
+	from := plan.CreatePhysicalNode(FromTestKind, NewFromProcedureSpec(data))
+	node := plan.CreatePhysicalNode(plan.NodeID(spec.Kind()), spec)
+	yield := plan.CreatePhysicalNode("yield", NewYieldProcedureSpec("_result"))
+	from.AddSuccessors(node)
+	node.AddPredecessors(from)
+	node.AddSuccessors(yield)
+	yield.AddPredecessors(node)
+
+	ps := plan.NewPlanSpec()
+	ps.Roots[yield] = struct{}{}
+	ps.Resources = flux.ResourceManagement{
+		ConcurrencyQuota: 1,
+		MemoryBytesQuota: math.MaxInt64,
+	}
+	ps.Now = time.Now()
+
+	results, err := execute.NewExecutor(nil, nil).Execute(ctx, ps, UnlimitedAllocator)
+	if err != nil {
+		return nil, err
+	}
+	var got []*Table
+	for _, r := range results {
+		if err := r.Tables().Do(func(tbl flux.Table) error {
+			t, err := ConvertTable(tbl)
+			if err != nil {
+				return err
+			}
+			got = append(got, t)
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+	}
+	NormalizeTables(got)
+	return got, nil
+}
@@ -0,0 +1,52 @@
+package executetest_test
+
+import (
+	"testing"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/flux/stdlib/universe"
+)
+
+func TestRunTransformationTestCase(t *testing.T) {
+	data := []*executetest.Table{{
+		KeyCols: []string{"t0"},
+		ColMeta: []flux.ColMeta{
+			{Label: "_time", Type: flux.TTime},
+			{Label: "t0", Type: flux.TString},
+			{Label: "_value", Type: flux.TFloat},
+		},
+		Data: [][]interface{}{
+			{execute.Time(1), "a", 1.0},
+			{execute.Time(2), "a", 2.0},
+			{execute.Time(3), "a", 3.0},
+		},
+	}}
+
+	testCases := []executetest.TransformationTestCase{
+		{
+			Name: "limit",
+			Spec: &universe.LimitProcedureSpec{N: 2},
+			Data: data,
+			Want: []*executetest.Table{{
+				KeyCols: []string{"t0"},
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "t0", Type: flux.TString},
+					{Label: "_value", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), "a", 1.0},
+					{execute.Time(2), "a", 2.0},
+				},
+			}},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			executetest.RunTransformationTestCase(t, tc)
+		})
+	}
+}
@@ -12,6 +12,7 @@ type Result struct {
 	Tbls  []*Table
 	Err   error
 	Stats flux.Statistics
+	Warns []flux.Warning
 }
 
 func NewResult(tables []*Table) *Result {
@@ -37,6 +38,10 @@ func (r *Result) Statistics() flux.Statistics {
 	return r.Stats
 }
 
+func (r *Result) Warnings() []flux.Warning {
+	return r.Warns
+}
+
 type TableIterator struct {
 	tables []*Table
 	err    error
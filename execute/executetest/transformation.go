@@ -55,7 +55,37 @@ func ProcessTestHelper(
 	c := execute.NewTableBuilderCache(UnlimitedAllocator)
 	c.SetTriggerSpec(execute.DefaultTriggerSpec)
 
-	tx := create(d, c)
+	processTestHelper(t, data, want, wantErr, d, c, create(d, c))
+}
+
+// SharedProcessTestHelper is like ProcessTestHelper for the transformations
+// that build their tables with an execute.SharedTableCache.
+func SharedProcessTestHelper(
+	t *testing.T,
+	data []flux.Table,
+	want []*Table,
+	wantErr error,
+	create func(d execute.Dataset, c *execute.SharedTableCache) execute.Transformation,
+) {
+	t.Helper()
+
+	d := NewDataset(RandomDatasetID())
+	c := execute.NewSharedTableCache(UnlimitedAllocator)
+	c.SetTriggerSpec(execute.DefaultTriggerSpec)
+
+	processTestHelper(t, data, want, wantErr, d, c, create(d, c))
+}
+
+func processTestHelper(
+	t *testing.T,
+	data []flux.Table,
+	want []*Table,
+	wantErr error,
+	d *Dataset,
+	c execute.DataCache,
+	tx execute.Transformation,
+) {
+	t.Helper()
 
 	parentID := RandomDatasetID()
 	var gotErr error
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/influxdata/flux"
+	fluxerrors "github.com/influxdata/flux/errors"
 	"github.com/influxdata/flux/memory"
 	"github.com/influxdata/flux/plan"
 	"github.com/pkg/errors"
@@ -22,9 +23,14 @@ type Executor interface {
 type executor struct {
 	deps   Dependencies
 	logger *zap.Logger
+
+	minBatchSize    int
+	maxBatchSize    int
+	nanPolicy       NaNPolicy
+	isolateFailures bool
 }
 
-func NewExecutor(deps Dependencies, logger *zap.Logger) Executor {
+func NewExecutor(deps Dependencies, logger *zap.Logger, opts ...ExecutorOption) Executor {
 	if logger == nil {
 		logger = zap.NewNop()
 	}
@@ -32,6 +38,9 @@ func NewExecutor(deps Dependencies, logger *zap.Logger) Executor {
 		deps:   deps,
 		logger: logger,
 	}
+	for _, opt := range opts {
+		opt(e)
+	}
 	return e
 }
 
@@ -54,10 +63,26 @@ type executionState struct {
 	results map[string]flux.Result
 	sources []Source
 
+	// resultNodes maps the plan nodes that produce a result to their result.
+	resultNodes map[plan.PlanNode]*result
+
 	transports []Transport
+	// progress is the progress of the operator of every node of the plan but the yields.
+	progress []*operatorProgress
+	// profiler profiles the operators, it is nil if the query is not profiled.
+	profiler *Profiler
+	// kindAllocs holds the allocator of every kind of operator, which kindAllocations records.
+	// It is nil if the memory of the operators is not recorded by kind.
+	kindAllocs      map[plan.ProcedureKind]*memory.Allocator
+	kindAllocations *KindAllocations
 
 	dispatcher *poolDispatcher
 	logger     *zap.Logger
+
+	minBatchSize    int
+	maxBatchSize    int
+	nanPolicy       NaNPolicy
+	isolateFailures bool
 }
 
 func (e *executor) Execute(ctx context.Context, p *plan.PlanSpec, a *memory.Allocator) (map[string]flux.Result, error) {
@@ -72,7 +97,7 @@ func (e *executor) Execute(ctx context.Context, p *plan.PlanSpec, a *memory.Allo
 
 func validatePlan(p *plan.PlanSpec) error {
 	if p.Resources.ConcurrencyQuota == 0 {
-		return errors.New("plan must have a non-zero concurrency quota")
+		return fluxerrors.New(fluxerrors.Internal, "plan must have a non-zero concurrency quota")
 	}
 	return nil
 }
@@ -89,8 +114,23 @@ func (e *executor) createExecutionState(ctx context.Context, p *plan.PlanSpec, a
 		alloc:     a,
 		resources: p.Resources,
 		results:   make(map[string]flux.Result),
+
+		resultNodes: make(map[plan.PlanNode]*result),
 		// TODO(nathanielc): Have the planner specify the dispatcher throughput
 		dispatcher: newPoolDispatcher(10, e.logger),
+
+		minBatchSize:    e.minBatchSize,
+		maxBatchSize:    e.maxBatchSize,
+		nanPolicy:       e.nanPolicy,
+		isolateFailures: e.isolateFailures,
+	}
+	if pr := profilerFromContext(ctx); pr != nil {
+		pr.start(p)
+		es.profiler = pr
+	}
+	if ka := kindAllocationsFromContext(ctx); ka != nil {
+		es.kindAllocs = make(map[plan.ProcedureKind]*memory.Allocator)
+		es.kindAllocations = ka
 	}
 	v := &createExecutionNodeVisitor{
 		ctx:   ctx,
@@ -150,8 +190,13 @@ func (v *createExecutionNodeVisitor) Visit(node plan.PlanNode) error {
 	id := DatasetIDFromNodeID(node.ID())
 
 	if yieldSpec, ok := spec.(plan.YieldProcedureSpec); ok {
+		if _, ok := v.es.results[yieldSpec.YieldName()]; ok {
+			return fluxerrors.Newf(fluxerrors.Invalid, "duplicate result name %q", yieldSpec.YieldName())
+		}
 		r := newResult(yieldSpec.YieldName())
+		r.diagnose = v.es.timeoutError
 		v.es.results[yieldSpec.YieldName()] = r
+		v.es.resultNodes[node] = r
 		v.nodes[skipYields(node)].AddTransformation(r)
 		return nil
 	}
@@ -169,20 +214,51 @@ func (v *createExecutionNodeVisitor) Visit(node plan.PlanNode) error {
 	ec := executionContext{
 		ctx:           v.ctx,
 		es:            v.es,
+		node:          node,
 		parents:       make([]DatasetID, len(node.Predecessors())),
 		streamContext: streamContext,
+		alloc:         v.es.alloc,
 	}
 
 	for i, pred := range nonYieldPredecessors(node) {
 		ec.parents[i] = DatasetIDFromNodeID(pred.ID())
 	}
 
+	progress := newOperatorProgress(node)
+	v.es.progress = append(v.es.progress, progress)
+
+	// The operators of a kind share an allocator when their memory is recorded by kind,
+	// which accounts for it in the allocator of the query too.
+	if v.es.kindAllocs != nil {
+		alloc, ok := v.es.kindAllocs[kind]
+		if !ok {
+			alloc = &memory.Allocator{
+				Pool:   v.es.alloc.Pool,
+				Parent: v.es.alloc,
+			}
+			v.es.kindAllocs[kind] = alloc
+			v.es.kindAllocations.add(kind, alloc)
+		}
+		ec.alloc = alloc
+	}
+
+	// A profiled operator allocates its memory with an allocator of its own,
+	// which accounts for it in the allocator of its kind or of the query too.
+	var profile *operatorProfile
+	if v.es.profiler != nil {
+		ec.alloc = &memory.Allocator{
+			Pool:   v.es.alloc.Pool,
+			Parent: ec.alloc,
+		}
+		profile = v.es.profiler.add(node, progress, ec.alloc)
+	}
+
 	// If node is a leaf, create a source
 	if len(node.Predecessors()) == 0 {
 		createSourceFn, ok := procedureToSource[kind]
 
 		if !ok {
-			return fmt.Errorf("unsupported source kind %v", kind)
+			return fluxerrors.Newf(fluxerrors.Unimplemented, "unsupported source kind %v", kind)
 		}
 
 		source, err := createSourceFn(spec, id, ec)
@@ -190,9 +266,23 @@ func (v *createExecutionNodeVisitor) Visit(node plan.PlanNode) error {
 		if err != nil {
 			return err
 		}
+		if v.es.isolateFailures {
+			source = &isolatedSource{Source: source, id: id}
+		}
 
-		v.es.sources = append(v.es.sources, source)
+		v.es.sources = append(v.es.sources, progressSource{Source: source, progress: progress})
 		v.nodes[node] = source
+		if r := sourceRecorderFromContext(v.ctx); r != nil {
+			r.RecordSource(node.ID())
+			rn := &recordingNode{node: node.ID(), recorder: r, alloc: v.es.alloc}
+			source.AddTransformation(rn)
+			v.nodes[node] = rn
+		}
+		if profile != nil {
+			pn := &profilingNode{profile: profile}
+			v.nodes[node].AddTransformation(pn)
+			v.nodes[node] = pn
+		}
 	} else {
 
 		// If node is internal, create a transformation.
@@ -200,9 +290,13 @@ func (v *createExecutionNodeVisitor) Visit(node plan.PlanNode) error {
 		createTransformationFn, ok := procedureToTransformation[kind]
 
 		if !ok {
-			return fmt.Errorf("unsupported procedure %v", kind)
+			return fluxerrors.Newf(fluxerrors.Unimplemented, "unsupported procedure %v", kind)
 		}
 
+		// The policy of the engine applies to the transformations without a policy of their own.
+		if s, ok := spec.(NaNPolicySpec); ok && v.es.nanPolicy != "" {
+			s.SetDefaultNaNPolicy(v.es.nanPolicy)
+		}
 		tr, ds, err := createTransformationFn(id, AccumulatingMode, spec, ec)
 
 		if err != nil {
@@ -215,11 +309,21 @@ func (v *createExecutionNodeVisitor) Visit(node plan.PlanNode) error {
 			ts = t.TriggerSpec()
 		}
 		ds.SetTriggerSpec(ts)
+		// Every transformation adapts the size of its batches on its own.
+		if bd, ok := ds.(batchingDataset); ok && v.es.maxBatchSize > 0 {
+			bd.setBatchSizer(newBatchSizer(v.es.minBatchSize, v.es.maxBatchSize))
+		}
 		v.nodes[node] = ds
+		if profile != nil {
+			pn := &profilingNode{profile: profile}
+			ds.AddTransformation(pn)
+			v.nodes[node] = pn
+		}
 
 		for _, p := range nonYieldPredecessors(node) {
 			executionNode := v.nodes[p]
-			transport := newConsecutiveTransport(v.es.dispatcher, tr)
+			transport := newConsecutiveTransport(v.es.dispatcher, tr, progress, node.Location())
+			transport.recoverPanics = v.es.isolateFailures
 			v.es.transports = append(v.es.transports, transport)
 			executionNode.AddTransformation(transport)
 		}
@@ -227,7 +331,9 @@ func (v *createExecutionNodeVisitor) Visit(node plan.PlanNode) error {
 		if plan.HasSideEffect(spec) && len(node.Successors()) == 0 {
 			name := string(node.ID())
 			r := newResult(name)
+			r.diagnose = v.es.timeoutError
 			v.es.results[name] = r
+			v.es.resultNodes[node] = r
 			v.nodes[skipYields(node)].AddTransformation(r)
 		}
 	}
@@ -235,6 +341,24 @@ func (v *createExecutionNodeVisitor) Visit(node plan.PlanNode) error {
 	return nil
 }
 
+// warn adds the warning to every result that is produced by the node or its successors.
+func (es *executionState) warn(node plan.PlanNode, w flux.Warning) {
+	visited := make(map[plan.PlanNode]bool)
+	nodes := []plan.PlanNode{node}
+	for len(nodes) > 0 {
+		n := nodes[len(nodes)-1]
+		nodes = nodes[:len(nodes)-1]
+		if visited[n] {
+			continue
+		}
+		visited[n] = true
+		if r, ok := es.resultNodes[n]; ok {
+			r.addWarning(w)
+		}
+		nodes = append(nodes, n.Successors()...)
+	}
+}
+
 func (es *executionState) abort(err error) {
 	for _, r := range es.results {
 		r.(*result).abort(err)
@@ -255,7 +379,7 @@ func (es *executionState) do(ctx context.Context) {
 					default:
 						err = fmt.Errorf("%v", e)
 					}
-					es.abort(fmt.Errorf("panic: %v\n%s", err, debug.Stack()))
+					es.abort(fluxerrors.Newf(fluxerrors.Internal, "panic: %v\n%s", err, debug.Stack()))
 					if entry := es.logger.Check(zapcore.InfoLevel, "Execute source panic"); entry != nil {
 						entry.Stack = string(debug.Stack())
 						entry.Write(zap.Error(err))
@@ -272,7 +396,11 @@ func (es *executionState) do(ctx context.Context) {
 			select {
 			case <-t.Finished():
 			case <-ctx.Done():
-				es.abort(errors.New("context done"))
+				if ctx.Err() == context.DeadlineExceeded {
+					es.abort(es.timeoutError(ctx.Err()))
+				} else {
+					es.abort(errors.New("context done"))
+				}
 			case err := <-es.dispatcher.Err():
 				if err != nil {
 					es.abort(err)
@@ -291,8 +419,10 @@ func (es *executionState) do(ctx context.Context) {
 type executionContext struct {
 	ctx           context.Context
 	es            *executionState
+	node          plan.PlanNode
 	parents       []DatasetID
 	streamContext streamContext
+	alloc         *memory.Allocator
 }
 
 func resolveTime(qt flux.Time, now time.Time) Time {
@@ -312,7 +442,7 @@ func (ec executionContext) StreamContext() StreamContext {
 }
 
 func (ec executionContext) Allocator() *memory.Allocator {
-	return ec.es.alloc
+	return ec.alloc
 }
 
 func (ec executionContext) Parents() []DatasetID {
@@ -322,3 +452,10 @@ func (ec executionContext) Parents() []DatasetID {
 func (ec executionContext) Dependencies() Dependencies {
 	return ec.es.deps
 }
+
+func (ec executionContext) Warnf(format string, a ...interface{}) {
+	ec.es.warn(ec.node, flux.Warning{
+		Node:    string(ec.node.ID()),
+		Message: fmt.Sprintf(format, a...),
+	})
+}
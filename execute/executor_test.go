@@ -3,10 +3,12 @@ package execute_test
 import (
 	"context"
 	"math"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/influxdata/flux"
 	"github.com/influxdata/flux/ast"
 	_ "github.com/influxdata/flux/builtin"
@@ -16,13 +18,83 @@ import (
 	"github.com/influxdata/flux/plan/plantest"
 	"github.com/influxdata/flux/semantic"
 	"github.com/influxdata/flux/stdlib/universe"
+	"github.com/pkg/errors"
 	"go.uber.org/zap/zaptest"
 )
 
 func init() {
-	execute.RegisterSource("from-test", executetest.CreateFromSource)
 	execute.RegisterTransformation(executetest.ToTestKind, executetest.CreateToTransformation)
 	plan.RegisterProcedureSpecWithSideEffect(executetest.ToTestKind, executetest.NewToProcedure, executetest.ToTestKind)
+	execute.RegisterTransformation(warnTestKind, createWarnTransformation)
+	execute.RegisterTransformation(blockTestKind, createBlockTransformation)
+	execute.RegisterTransformation(panicTestKind, createPanicTransformation)
+	execute.RegisterSource(panicTestKind, createPanicSource)
+}
+
+const warnTestKind = "warn-test"
+
+type warnProcedureSpec struct {
+	plan.DefaultCost
+}
+
+func (s *warnProcedureSpec) Kind() plan.ProcedureKind {
+	return warnTestKind
+}
+
+func (s *warnProcedureSpec) Copy() plan.ProcedureSpec {
+	return s
+}
+
+// warnTransformation passes its tables through and reports a warning for each of them.
+type warnTransformation struct {
+	execute.Transformation
+	a execute.Administration
+}
+
+func createWarnTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	t, d, err := executetest.CreateToTransformation(id, mode, spec, a)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &warnTransformation{Transformation: t, a: a}, d, nil
+}
+
+func (t *warnTransformation) Process(id execute.DatasetID, tbl flux.Table) error {
+	t.a.Warnf("table has %d columns", len(tbl.Cols()))
+	return t.Transformation.Process(id, tbl)
+}
+
+const blockTestKind = "block-test"
+
+type blockProcedureSpec struct {
+	plan.DefaultCost
+}
+
+func (s *blockProcedureSpec) Kind() plan.ProcedureKind {
+	return blockTestKind
+}
+
+func (s *blockProcedureSpec) Copy() plan.ProcedureSpec {
+	return s
+}
+
+// blockTransformation blocks on every table until the query is done.
+type blockTransformation struct {
+	execute.Transformation
+	a execute.Administration
+}
+
+func createBlockTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	t, d, err := executetest.CreateToTransformation(id, mode, spec, a)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &blockTransformation{Transformation: t, a: a}, d, nil
+}
+
+func (t *blockTransformation) Process(id execute.DatasetID, tbl flux.Table) error {
+	<-t.a.Context().Done()
+	return t.a.Context().Err()
 }
 
 func TestExecutor_Execute(t *testing.T) {
@@ -742,3 +814,400 @@ func TestExecutor_Execute(t *testing.T) {
 		})
 	}
 }
+
+const panicTestKind = "panic-test"
+
+type panicProcedureSpec struct {
+	plan.DefaultCost
+}
+
+func (s *panicProcedureSpec) Kind() plan.ProcedureKind {
+	return panicTestKind
+}
+
+func (s *panicProcedureSpec) Copy() plan.ProcedureSpec {
+	return s
+}
+
+// panicTransformation panics on every table.
+type panicTransformation struct {
+	execute.Transformation
+}
+
+func createPanicTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	t, d, err := executetest.CreateToTransformation(id, mode, spec, a)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &panicTransformation{Transformation: t}, d, nil
+}
+
+func (t *panicTransformation) Process(id execute.DatasetID, tbl flux.Table) error {
+	panic("expected panic")
+}
+
+// panicSource panics when it runs.
+type panicSource struct{}
+
+func createPanicSource(spec plan.ProcedureSpec, id execute.DatasetID, a execute.Administration) (execute.Source, error) {
+	return &panicSource{}, nil
+}
+
+func (s *panicSource) AddTransformation(t execute.Transformation) {}
+
+func (s *panicSource) Run(ctx context.Context) {
+	panic("expected panic")
+}
+
+func TestExecutor_IsolatedFailures(t *testing.T) {
+	input := &executetest.Table{
+		KeyCols: []string{"_start", "_stop"},
+		ColMeta: []flux.ColMeta{
+			{Label: "_start", Type: flux.TTime},
+			{Label: "_stop", Type: flux.TTime},
+			{Label: "_time", Type: flux.TTime},
+			{Label: "_value", Type: flux.TFloat},
+		},
+		Data: [][]interface{}{
+			{execute.Time(0), execute.Time(5), execute.Time(0), 1.0},
+		},
+	}
+	for _, tc := range []struct {
+		name   string
+		failed []plan.PlanNode
+	}{
+		{
+			name: "transformation",
+			failed: []plan.PlanNode{
+				plan.CreatePhysicalNode("from-test0", executetest.NewFromProcedureSpec([]*executetest.Table{input})),
+				plan.CreatePhysicalNode("panic", &panicProcedureSpec{}),
+			},
+		},
+		{
+			name: "source",
+			failed: []plan.PlanNode{
+				plan.CreatePhysicalNode("panic", &panicProcedureSpec{}),
+				plan.CreatePhysicalNode("to-test0", &executetest.ToProcedureSpec{}),
+			},
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			// Two independent pipelines, one of which panics.
+			spec := &plantest.PlanSpec{
+				Nodes: append(tc.failed,
+					plan.CreatePhysicalNode("yield0", executetest.NewYieldProcedureSpec("failed")),
+					plan.CreatePhysicalNode("from-test1", executetest.NewFromProcedureSpec([]*executetest.Table{input})),
+					plan.CreatePhysicalNode("to-test1", &executetest.ToProcedureSpec{}),
+					plan.CreatePhysicalNode("yield1", executetest.NewYieldProcedureSpec("succeeded")),
+				),
+				Edges: [][2]int{
+					{0, 1},
+					{1, 2},
+					{3, 4},
+					{4, 5},
+				},
+				Resources: flux.ResourceManagement{
+					ConcurrencyQuota: 2,
+					MemoryBytesQuota: math.MaxInt64,
+				},
+				Now: time.Now(),
+			}
+
+			exe := execute.NewExecutor(nil, zaptest.NewLogger(t), execute.WithIsolatedFailures())
+			results, err := exe.Execute(context.Background(), plantest.CreatePlanSpec(spec), executetest.UnlimitedAllocator)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var got []*executetest.Table
+			if err := results["succeeded"].Tables().Do(func(tbl flux.Table) error {
+				cb, err := executetest.ConvertTable(tbl)
+				if err != nil {
+					return err
+				}
+				got = append(got, cb)
+				return nil
+			}); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			want := []*executetest.Table{input}
+			executetest.NormalizeTables(want)
+			executetest.NormalizeTables(got)
+			if !cmp.Equal(want, got) {
+				t.Error("unexpected tables -want/+got", cmp.Diff(want, got))
+			}
+
+			err = results["failed"].Tables().Do(func(tbl flux.Table) error {
+				return tbl.Do(func(flux.ColReader) error { return nil })
+			})
+			if err == nil || !strings.Contains(err.Error(), "panic: expected panic") {
+				t.Fatalf("expected the panic to fail the result, got %v", err)
+			}
+		})
+	}
+}
+
+func TestExecutor_Warnings(t *testing.T) {
+	spec := &plantest.PlanSpec{
+		Nodes: []plan.PlanNode{
+			plan.CreatePhysicalNode("from-test", executetest.NewFromProcedureSpec(
+				[]*executetest.Table{{
+					KeyCols: []string{"_start", "_stop"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_start", Type: flux.TTime},
+						{Label: "_stop", Type: flux.TTime},
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_value", Type: flux.TFloat},
+					},
+					Data: [][]interface{}{
+						{execute.Time(0), execute.Time(5), execute.Time(0), 1.0},
+					},
+				}},
+			)),
+			plan.CreatePhysicalNode("warn", &warnProcedureSpec{}),
+			plan.CreatePhysicalNode("yield0", &universe.YieldProcedureSpec{Name: "warned"}),
+			plan.CreatePhysicalNode("yield1", &universe.YieldProcedureSpec{Name: "clean"}),
+		},
+		Edges: [][2]int{
+			{0, 1},
+			{1, 2},
+			{0, 3},
+		},
+		Resources: flux.ResourceManagement{
+			ConcurrencyQuota: 1,
+			MemoryBytesQuota: math.MaxInt64,
+		},
+		Now: time.Now(),
+	}
+
+	exe := execute.NewExecutor(nil, zaptest.NewLogger(t))
+	results, err := exe.Execute(context.Background(), plantest.CreatePlanSpec(spec), executetest.UnlimitedAllocator)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := make(map[string][]flux.Warning, len(results))
+	for name, r := range results {
+		if err := r.Tables().Do(func(tbl flux.Table) error {
+			return tbl.Do(func(flux.ColReader) error { return nil })
+		}); err != nil {
+			t.Fatal(err)
+		}
+		got[name] = r.(flux.Warner).Warnings()
+	}
+
+	want := map[string][]flux.Warning{
+		"warned": {{Node: "warn", Message: "table has 4 columns"}},
+		"clean":  {},
+	}
+	if !cmp.Equal(want, got, cmpopts.EquateEmpty()) {
+		t.Error("unexpected warnings -want/+got", cmp.Diff(want, got, cmpopts.EquateEmpty()))
+	}
+}
+
+func TestExecutor_Timeout(t *testing.T) {
+	spec := &plantest.PlanSpec{
+		Nodes: []plan.PlanNode{
+			plan.CreatePhysicalNode("from-test", executetest.NewFromProcedureSpec(
+				[]*executetest.Table{{
+					KeyCols: []string{"_start", "_stop"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_start", Type: flux.TTime},
+						{Label: "_stop", Type: flux.TTime},
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_value", Type: flux.TFloat},
+					},
+					Data: [][]interface{}{
+						{execute.Time(0), execute.Time(5), execute.Time(0), 1.0},
+					},
+				}},
+			)),
+			plan.CreatePhysicalNode("block", &blockProcedureSpec{}),
+			plan.CreatePhysicalNode("yield", executetest.NewYieldProcedureSpec("_result")),
+		},
+		Edges: [][2]int{
+			{0, 1},
+			{1, 2},
+		},
+		Resources: flux.ResourceManagement{
+			ConcurrencyQuota: 1,
+			MemoryBytesQuota: math.MaxInt64,
+		},
+		Now: time.Now(),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	exe := execute.NewExecutor(nil, zaptest.NewLogger(t))
+	results, err := exe.Execute(ctx, plantest.CreatePlanSpec(spec), executetest.UnlimitedAllocator)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = results["_result"].Tables().Do(func(tbl flux.Table) error {
+		return nil
+	})
+	te, ok := errors.Cause(err).(*execute.TimeoutError)
+	if !ok {
+		t.Fatalf("expected a timeout error, got %v", err)
+	}
+	if errors.Cause(te.Err) != context.DeadlineExceeded {
+		t.Errorf("unexpected cause of the timeout: %v", te.Err)
+	}
+	if len(te.Slowest) == 0 {
+		t.Fatal("expected the slowest operators")
+	}
+	if got := te.Slowest[0]; got.Node != "block" || got.Kind != blockTestKind || got.Tables != 1 || got.Duration <= 0 {
+		t.Errorf("unexpected slowest operator %v", got)
+	}
+}
+
+func TestExecutor_BatchSize(t *testing.T) {
+	input := &executetest.Table{
+		KeyCols: []string{"_start", "_stop"},
+		ColMeta: []flux.ColMeta{
+			{Label: "_start", Type: flux.TTime},
+			{Label: "_stop", Type: flux.TTime},
+			{Label: "_time", Type: flux.TTime},
+			{Label: "_value", Type: flux.TFloat},
+		},
+	}
+	for i := 0; i < 25; i++ {
+		input.Data = append(input.Data, []interface{}{execute.Time(0), execute.Time(25), execute.Time(i), float64(i)})
+	}
+	spec := &plantest.PlanSpec{
+		Nodes: []plan.PlanNode{
+			plan.CreatePhysicalNode("from-test", executetest.NewFromProcedureSpec([]*executetest.Table{input})),
+			plan.CreatePhysicalNode("to-test", &executetest.ToProcedureSpec{}),
+			plan.CreatePhysicalNode("yield", executetest.NewYieldProcedureSpec("_result")),
+		},
+		Edges: [][2]int{
+			{0, 1},
+			{1, 2},
+		},
+		Resources: flux.ResourceManagement{
+			ConcurrencyQuota: 1,
+			MemoryBytesQuota: math.MaxInt64,
+		},
+		Now: time.Now(),
+	}
+
+	// The rows are narrow enough for the batches to be as large as possible.
+	exe := execute.NewExecutor(nil, zaptest.NewLogger(t), execute.WithBatchSize(5, 10))
+	results, err := exe.Execute(context.Background(), plantest.CreatePlanSpec(spec), executetest.UnlimitedAllocator)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		lens []int
+		got  []*executetest.Table
+	)
+	if err := results["_result"].Tables().Do(func(tbl flux.Table) error {
+		if err := tbl.Do(func(cr flux.ColReader) error {
+			lens = append(lens, cr.Len())
+			return nil
+		}); err != nil {
+			return err
+		}
+		cb, err := executetest.ConvertTable(tbl)
+		if err != nil {
+			return err
+		}
+		got = append(got, cb)
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if want := []int{10, 10, 5}; !cmp.Equal(want, lens) {
+		t.Error("unexpected batch lengths -want/+got", cmp.Diff(want, lens))
+	}
+	want := []*executetest.Table{input}
+	executetest.NormalizeTables(want)
+	executetest.NormalizeTables(got)
+	if !cmp.Equal(want, got) {
+		t.Error("unexpected tables -want/+got", cmp.Diff(want, got))
+	}
+}
+
+func TestExecutor_NaNPolicy(t *testing.T) {
+	input := &executetest.Table{
+		KeyCols: []string{"_start", "_stop"},
+		ColMeta: []flux.ColMeta{
+			{Label: "_start", Type: flux.TTime},
+			{Label: "_stop", Type: flux.TTime},
+			{Label: "_time", Type: flux.TTime},
+			{Label: "_value", Type: flux.TFloat},
+		},
+		Data: [][]interface{}{
+			{execute.Time(0), execute.Time(3), execute.Time(0), 1.0},
+			{execute.Time(0), execute.Time(3), execute.Time(1), math.NaN()},
+			{execute.Time(0), execute.Time(3), execute.Time(2), 2.0},
+		},
+	}
+	run := func(policy, enginePolicy execute.NaNPolicy) ([]*executetest.Table, error) {
+		spec := &plantest.PlanSpec{
+			Nodes: []plan.PlanNode{
+				plan.CreatePhysicalNode("from-test", executetest.NewFromProcedureSpec([]*executetest.Table{input})),
+				plan.CreatePhysicalNode("sum", &universe.SumProcedureSpec{
+					AggregateConfig: execute.AggregateConfig{
+						Columns: []string{execute.DefaultValueColLabel},
+						NaN:     policy,
+					},
+				}),
+				plan.CreatePhysicalNode("yield", executetest.NewYieldProcedureSpec("_result")),
+			},
+			Edges: [][2]int{
+				{0, 1},
+				{1, 2},
+			},
+			Resources: flux.ResourceManagement{
+				ConcurrencyQuota: 1,
+				MemoryBytesQuota: math.MaxInt64,
+			},
+			Now: time.Now(),
+		}
+		exe := execute.NewExecutor(nil, zaptest.NewLogger(t), execute.WithNaNPolicy(enginePolicy))
+		results, err := exe.Execute(context.Background(), plantest.CreatePlanSpec(spec), executetest.UnlimitedAllocator)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got []*executetest.Table
+		err = results["_result"].Tables().Do(func(tbl flux.Table) error {
+			cb, err := executetest.ConvertTable(tbl)
+			if err != nil {
+				return err
+			}
+			got = append(got, cb)
+			return nil
+		})
+		return got, err
+	}
+
+	// The policy of the engine applies to the transformations without a policy of their own.
+	if _, err := run("", execute.NaNError); err == nil {
+		t.Error("expected an error with the error policy of the engine")
+	}
+	got, err := run(execute.NaNDrop, execute.NaNError)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []*executetest.Table{{
+		KeyCols: []string{"_start", "_stop"},
+		ColMeta: []flux.ColMeta{
+			{Label: "_start", Type: flux.TTime},
+			{Label: "_stop", Type: flux.TTime},
+			{Label: "_value", Type: flux.TFloat},
+		},
+		Data: [][]interface{}{
+			{execute.Time(0), execute.Time(3), 3.0},
+		},
+	}}
+	executetest.NormalizeTables(want)
+	executetest.NormalizeTables(got)
+	if !cmp.Equal(want, got) {
+		t.Error("unexpected tables -want/+got", cmp.Diff(want, got))
+	}
+}
@@ -20,6 +20,39 @@ func NewGroupKey(cols []flux.ColMeta, values []values.Value) flux.GroupKey {
 	}
 }
 
+// ScratchGroupKey is a group key that is rebuilt for each row of a table
+// to look up the table of the row, without allocating a new key for each row.
+// A TableBuilderCache retains a copy of the key when it creates a table for it,
+// so the key may be reset once the lookup returns.
+type ScratchGroupKey struct {
+	groupKey
+}
+
+// Reset removes the columns of the key and keeps their memory.
+func (k *ScratchGroupKey) Reset() {
+	k.cols = k.cols[:0]
+	k.values = k.values[:0]
+}
+
+// Append adds a column and its value to the key.
+func (k *ScratchGroupKey) Append(c flux.ColMeta, v values.Value) {
+	k.cols = append(k.cols, c)
+	k.values = append(k.values, v)
+}
+
+// retainGroupKey returns a key that can be retained after the given key is used for a lookup.
+func retainGroupKey(key flux.GroupKey) flux.GroupKey {
+	k, ok := key.(*ScratchGroupKey)
+	if !ok {
+		return key
+	}
+	cols := make([]flux.ColMeta, len(k.cols))
+	copy(cols, k.cols)
+	vs := make([]values.Value, len(k.values))
+	copy(vs, k.values)
+	return NewGroupKey(cols, vs)
+}
+
 func (k *groupKey) Cols() []flux.ColMeta {
 	return k.cols
 }
@@ -0,0 +1,55 @@
+package execute
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+
+	fluxerrors "github.com/influxdata/flux/errors"
+)
+
+// WithIsolatedFailures executes the independent pipelines of a script in isolated failure domains.
+// The pipelines share the resources of the query, but a source or a transformation that panics
+// only fails the results downstream of it, and the other results complete.
+// By default, a panic aborts every result of the query.
+//
+// The errors returned by sources and transformations always only fail the results downstream of them.
+func WithIsolatedFailures() ExecutorOption {
+	return func(e *executor) {
+		e.isolateFailures = true
+	}
+}
+
+// panicError returns the error of a recovered panic, along with the stack of the goroutine that panicked.
+func panicError(e interface{}) error {
+	err, ok := e.(error)
+	if !ok {
+		err = fmt.Errorf("%v", e)
+	}
+	return fluxerrors.Newf(fluxerrors.Internal, "panic: %v\n%s", err, debug.Stack())
+}
+
+// isolatedSource is a source that finishes its transformations with an error when it panics,
+// instead of aborting the whole execution.
+type isolatedSource struct {
+	Source
+	id DatasetID
+	ts []Transformation
+}
+
+func (s *isolatedSource) AddTransformation(t Transformation) {
+	s.Source.AddTransformation(t)
+	s.ts = append(s.ts, t)
+}
+
+func (s *isolatedSource) Run(ctx context.Context) {
+	defer func() {
+		if e := recover(); e != nil {
+			err := panicError(e)
+			for _, t := range s.ts {
+				t.Finish(s.id, err)
+			}
+		}
+	}()
+	s.Source.Run(ctx)
+}
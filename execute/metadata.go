@@ -0,0 +1,55 @@
+package execute
+
+import (
+	"context"
+	"sync"
+
+	"github.com/influxdata/flux"
+)
+
+// MetadataRecorder records the metadata that the operators of the executed queries report,
+// such as the cardinality of the data they processed.
+type MetadataRecorder struct {
+	mu sync.Mutex
+	md flux.Metadata
+}
+
+func NewMetadataRecorder() *MetadataRecorder {
+	return new(MetadataRecorder)
+}
+
+// Metadata returns the metadata reported so far.
+func (r *MetadataRecorder) Metadata() flux.Metadata {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.md) == 0 {
+		return nil
+	}
+	md := make(flux.Metadata, len(r.md))
+	md.AddAll(r.md)
+	return md
+}
+
+func (r *MetadataRecorder) add(md flux.Metadata) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.md == nil {
+		r.md = make(flux.Metadata, len(md))
+	}
+	r.md.AddAll(md)
+}
+
+// WithMetadataRecorder returns a context with which the metadata reported by the operators of the executed queries
+// is recorded by r. A nil recorder disables the recording.
+func WithMetadataRecorder(ctx context.Context, r *MetadataRecorder) context.Context {
+	return context.WithValue(ctx, metadataRecorderKey, r)
+}
+
+// ReportMetadata records metadata about the processing of an operator with the recorder of the context, if it has one.
+// The metadata ends up in the statistics of the query.
+// Operators call it with the context of their execution, usually once they have finished.
+func ReportMetadata(ctx context.Context, md flux.Metadata) {
+	if r, _ := ctx.Value(metadataRecorderKey).(*MetadataRecorder); r != nil {
+		r.add(md)
+	}
+}
@@ -0,0 +1,117 @@
+package execute
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/influxdata/flux/arrow"
+)
+
+// NaNPolicy decides what is done with the NaN and infinite float values
+// that aggregates and selectors meet.
+type NaNPolicy string
+
+const (
+	// NaNPropagate processes NaN and infinite values like any other value.
+	// It is the default policy.
+	NaNPropagate NaNPolicy = "propagate"
+	// NaNDrop ignores NaN and infinite values.
+	NaNDrop NaNPolicy = "drop"
+	// NaNError fails the query when a NaN or infinite value is met.
+	NaNError NaNPolicy = "error"
+)
+
+// ParseNaNPolicy parses the name of a policy, the empty string is the unset policy.
+func ParseNaNPolicy(s string) (NaNPolicy, error) {
+	switch p := NaNPolicy(s); p {
+	case "", NaNPropagate, NaNDrop, NaNError:
+		return p, nil
+	default:
+		return "", fmt.Errorf("invalid NaN policy %q, must be one of %q, %q or %q", s, NaNPropagate, NaNDrop, NaNError)
+	}
+}
+
+// WithNaNPolicy sets the policy of the engine, which applies to the aggregates and selectors
+// that are not given a policy of their own.
+func WithNaNPolicy(p NaNPolicy) ExecutorOption {
+	return func(e *executor) {
+		e.nanPolicy = p
+	}
+}
+
+// NaNPolicySpec is implemented by the procedure specs whose transformations apply a NaN policy.
+// The executor sets the policy of the engine on the specs that do not have a policy of their own.
+type NaNPolicySpec interface {
+	SetDefaultNaNPolicy(p NaNPolicy)
+}
+
+func isNaNOrInf(x float64) bool {
+	return math.IsNaN(x) || math.IsInf(x, 0)
+}
+
+// firstNaNOrInf returns the index of the first valid NaN or infinite value of an array, or -1.
+func firstNaNOrInf(vs *array.Float64) int {
+	checkNulls := vs.NullN() > 0
+	for i, x := range vs.Float64Values() {
+		if isNaNOrInf(x) && !(checkNulls && vs.IsNull(i)) {
+			return i
+		}
+	}
+	return -1
+}
+
+// check returns the error of the NaNError policy for the values of a column.
+func (p NaNPolicy) check(label string, vs *array.Float64) error {
+	if p != NaNError {
+		return nil
+	}
+	if i := firstNaNOrInf(vs); i >= 0 {
+		return fmt.Errorf("column %q has a %v value, which the NaN policy %q does not allow", label, vs.Value(i), p)
+	}
+	return nil
+}
+
+// RemoveFloats applies the policy to the values of a column of which only the values matter, as in an aggregate.
+// With NaNDrop, it returns the values without the NaN and infinite ones.
+// The returned array must be released if it is not vs.
+func (p NaNPolicy) RemoveFloats(label string, vs *array.Float64) (*array.Float64, error) {
+	if err := p.check(label, vs); err != nil {
+		return nil, err
+	}
+	if p != NaNDrop || firstNaNOrInf(vs) < 0 {
+		return vs, nil
+	}
+	b := arrow.NewFloatBuilder(nil)
+	b.Reserve(vs.Len())
+	for i, x := range vs.Float64Values() {
+		if vs.IsNull(i) {
+			b.AppendNull()
+		} else if !isNaNOrInf(x) {
+			b.Append(x)
+		}
+	}
+	return b.NewFloat64Array(), nil
+}
+
+// NullFloats applies the policy to the values of a column whose rows matter, as in a selector.
+// With NaNDrop, it returns the values with nulls in place of the NaN and infinite ones.
+// The returned array must be released if it is not vs.
+func (p NaNPolicy) NullFloats(label string, vs *array.Float64) (*array.Float64, error) {
+	if err := p.check(label, vs); err != nil {
+		return nil, err
+	}
+	if p != NaNDrop || firstNaNOrInf(vs) < 0 {
+		return vs, nil
+	}
+	b := arrow.NewFloatBuilder(nil)
+	b.Reserve(vs.Len())
+	for i, x := range vs.Float64Values() {
+		if vs.IsNull(i) || isNaNOrInf(x) {
+			b.AppendNull()
+		} else {
+			b.Append(x)
+		}
+	}
+	return b.NewFloat64Array(), nil
+}
@@ -0,0 +1,74 @@
+package execute_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/flux/arrow"
+	"github.com/influxdata/flux/execute"
+)
+
+func TestNaNPolicy(t *testing.T) {
+	b := arrow.NewFloatBuilder(nil)
+	b.Append(1)
+	b.Append(math.NaN())
+	b.AppendNull()
+	b.Append(math.Inf(1))
+	b.Append(2)
+	vs := b.NewFloat64Array()
+	defer vs.Release()
+
+	// floats returns the values of an array with nil for the nulls.
+	floats := func(vs interface {
+		Len() int
+		IsNull(i int) bool
+		Value(i int) float64
+	}) []interface{} {
+		fs := make([]interface{}, vs.Len())
+		for i := range fs {
+			if !vs.IsNull(i) {
+				fs[i] = vs.Value(i)
+			}
+		}
+		return fs
+	}
+
+	for _, p := range []execute.NaNPolicy{"", execute.NaNPropagate} {
+		if got, err := p.RemoveFloats("_value", vs); err != nil || got != vs {
+			t.Errorf("policy %q changed the values: %v", p, err)
+		}
+		if got, err := p.NullFloats("_value", vs); err != nil || got != vs {
+			t.Errorf("policy %q changed the values: %v", p, err)
+		}
+	}
+
+	removed, err := execute.NaNDrop.RemoveFloats("_value", vs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, got := []interface{}{1.0, nil, 2.0}, floats(removed); !cmp.Equal(want, got) {
+		t.Errorf("unexpected removed values -want/+got\n%s", cmp.Diff(want, got))
+	}
+	removed.Release()
+
+	nulled, err := execute.NaNDrop.NullFloats("_value", vs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, got := []interface{}{1.0, nil, nil, nil, 2.0}, floats(nulled); !cmp.Equal(want, got) {
+		t.Errorf("unexpected nulled values -want/+got\n%s", cmp.Diff(want, got))
+	}
+	nulled.Release()
+
+	if _, err := execute.NaNError.RemoveFloats("_value", vs); err == nil {
+		t.Error("expected an error removing NaN values")
+	}
+	if _, err := execute.NaNError.NullFloats("_value", vs); err == nil {
+		t.Error("expected an error nulling NaN values")
+	}
+
+	if _, err := execute.ParseNaNPolicy("ignore"); err == nil {
+		t.Error("expected an error parsing an invalid policy")
+	}
+}
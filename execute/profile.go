@@ -0,0 +1,265 @@
+package execute
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/memory"
+	"github.com/influxdata/flux/plan"
+)
+
+// OperatorProfile is the profile of the operator that executed a node of the physical plan of a query.
+type OperatorProfile struct {
+	// Node is the ID of the node in the physical plan.
+	Node plan.NodeID `json:"node"`
+	// Kind is the kind of the procedure of the node.
+	Kind plan.ProcedureKind `json:"kind"`
+	// Predecessors are the IDs of the nodes whose tables the operator consumed.
+	Predecessors []plan.NodeID `json:"predecessors,omitempty"`
+	// Tables and Rows count the tables the operator produced and the rows they held.
+	Tables int64 `json:"tables"`
+	Rows   int64 `json:"rows"`
+	// Duration is the time the operator was busy.
+	Duration time.Duration `json:"duration"`
+	// MaxAllocated is the maximum number of bytes the operator had allocated at any point.
+	MaxAllocated int64 `json:"max_allocated"`
+}
+
+func (p OperatorProfile) String() string {
+	return fmt.Sprintf("%d tables, %d rows in %v, %d bytes max allocated", p.Tables, p.Rows, p.Duration, p.MaxAllocated)
+}
+
+// Profiler profiles the operators of a query that is executed with a context returned by WithProfiler.
+// If several queries are executed with the context, it profiles the last one.
+type Profiler struct {
+	mu        sync.Mutex
+	plan      *plan.PlanSpec
+	operators []*operatorProfile
+}
+
+func NewProfiler() *Profiler {
+	return &Profiler{}
+}
+
+// WithProfiler returns a context with which the operators of the executed query are profiled by p.
+// A nil profiler disables the profiling.
+func WithProfiler(ctx context.Context, p *Profiler) context.Context {
+	return context.WithValue(ctx, profilerKey, p)
+}
+
+func profilerFromContext(ctx context.Context) *Profiler {
+	p, _ := ctx.Value(profilerKey).(*Profiler)
+	return p
+}
+
+// Plan returns the physical plan of the profiled query, or nil if no query has been executed.
+func (p *Profiler) Plan() *plan.PlanSpec {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.plan
+}
+
+// Profiles returns the profiles of the operators of the query, in the order of a bottom up walk of its plan.
+// The yields of the plan have no operator. The profiles are only complete once all the results
+// of the query have been read.
+func (p *Profiler) Profiles() []OperatorProfile {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	profiles := make([]OperatorProfile, len(p.operators))
+	for i, op := range p.operators {
+		profiles[i] = op.profile()
+	}
+	return profiles
+}
+
+// start resets the profiler for the execution of the plan.
+func (p *Profiler) start(plan *plan.PlanSpec) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.plan = plan
+	p.operators = nil
+}
+
+// add starts profiling the operator of a node, which allocates its memory with alloc.
+func (p *Profiler) add(node plan.PlanNode, progress *operatorProgress, alloc *memory.Allocator) *operatorProfile {
+	op := &operatorProfile{
+		node:     node.ID(),
+		kind:     node.Kind(),
+		progress: progress,
+		alloc:    alloc,
+	}
+	for _, pred := range nonYieldPredecessors(node) {
+		op.predecessors = append(op.predecessors, pred.ID())
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.operators = append(p.operators, op)
+	return op
+}
+
+// operatorProfile counts the output of an operator while it executes.
+// Its time is measured by the progress of the operator.
+type operatorProfile struct {
+	node         plan.NodeID
+	kind         plan.ProcedureKind
+	predecessors []plan.NodeID
+	progress     *operatorProgress
+	alloc        *memory.Allocator
+
+	tables int64
+	rows   int64
+}
+
+func (op *operatorProfile) profile() OperatorProfile {
+	return OperatorProfile{
+		Node:         op.node,
+		Kind:         op.kind,
+		Predecessors: op.predecessors,
+		Tables:       atomic.LoadInt64(&op.tables),
+		Rows:         atomic.LoadInt64(&op.rows),
+		Duration:     op.progress.progress().Duration,
+		MaxAllocated: op.alloc.MaxAllocated(),
+	}
+}
+
+// ExplainFormat is a format in which a Profiler writes the plan of a query.
+type ExplainFormat string
+
+const (
+	ExplainText     ExplainFormat = "text"
+	ExplainJSON     ExplainFormat = "json"
+	ExplainGraphviz ExplainFormat = "graphviz"
+)
+
+// Explain writes the physical plan of the profiled query, with every node annotated
+// with the tables and rows produced by its operator, the time it was busy and the memory it allocated.
+// The query must have been executed, and its results read, for the annotations to be complete.
+func (p *Profiler) Explain(w io.Writer, format ExplainFormat) error {
+	if p.Plan() == nil {
+		return fmt.Errorf("no query has been profiled")
+	}
+	profiles := p.Profiles()
+	switch format {
+	case ExplainText:
+		var b strings.Builder
+		for _, op := range profiles {
+			fmt.Fprintf(&b, "%v (%v)", op.Node, op.Kind)
+			for i, pred := range op.Predecessors {
+				if i == 0 {
+					b.WriteString(" <- ")
+				} else {
+					b.WriteString(", ")
+				}
+				b.WriteString(string(pred))
+			}
+			fmt.Fprintf(&b, ": %v\n", op)
+		}
+		_, err := io.WriteString(w, b.String())
+		return err
+	case ExplainJSON:
+		return json.NewEncoder(w).Encode(struct {
+			Nodes []OperatorProfile `json:"nodes"`
+		}{Nodes: profiles})
+	case ExplainGraphviz:
+		var b strings.Builder
+		b.WriteString("digraph {\n")
+		var edges []string
+		for _, op := range profiles {
+			label := fmt.Sprintf("%v\n%v\n%d tables, %d rows\n%v\n%d bytes", op.Node, op.Kind, op.Tables, op.Rows, op.Duration, op.MaxAllocated)
+			fmt.Fprintf(&b, "  %q [label=%q]\n", op.Node, label)
+			for _, pred := range op.Predecessors {
+				edges = append(edges, fmt.Sprintf("  %q -> %q", pred, op.Node))
+			}
+		}
+		b.WriteString("\n")
+		for _, e := range edges {
+			b.WriteString(e + "\n")
+		}
+		b.WriteString("}\n")
+		_, err := io.WriteString(w, b.String())
+		return err
+	default:
+		return fmt.Errorf("unknown explain format %q, expected one of text, json or graphviz", format)
+	}
+}
+
+// profilingNode sits between an operator and its successors,
+// and counts the tables and rows produced by the operator before passing them on.
+type profilingNode struct {
+	profile *operatorProfile
+	ts      []Transformation
+}
+
+func (n *profilingNode) AddTransformation(t Transformation) {
+	n.ts = append(n.ts, t)
+}
+
+func (n *profilingNode) RetractTable(id DatasetID, key flux.GroupKey) error {
+	for _, t := range n.ts {
+		if err := t.RetractTable(id, key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (n *profilingNode) Process(id DatasetID, tbl flux.Table) error {
+	atomic.AddInt64(&n.profile.tables, 1)
+	if t, ok := tbl.(interface{ NRows() int }); ok {
+		atomic.AddInt64(&n.profile.rows, int64(t.NRows()))
+	} else {
+		// The rows of the tables that do not report how many rows they hold
+		// are counted as they are read. Such tables may only be read once.
+		tbl = &countingTable{Table: tbl, rows: &n.profile.rows}
+	}
+	for _, t := range n.ts {
+		if err := t.Process(id, tbl); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (n *profilingNode) UpdateWatermark(id DatasetID, time Time) error {
+	for _, t := range n.ts {
+		if err := t.UpdateWatermark(id, time); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (n *profilingNode) UpdateProcessingTime(id DatasetID, time Time) error {
+	for _, t := range n.ts {
+		if err := t.UpdateProcessingTime(id, time); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (n *profilingNode) Finish(id DatasetID, err error) {
+	for _, t := range n.ts {
+		t.Finish(id, err)
+	}
+}
+
+// countingTable counts the rows of a table as they are read.
+type countingTable struct {
+	flux.Table
+	rows *int64
+}
+
+func (t *countingTable) Do(f func(flux.ColReader) error) error {
+	return t.Table.Do(func(cr flux.ColReader) error {
+		atomic.AddInt64(t.rows, int64(cr.Len()))
+		return f(cr)
+	})
+}
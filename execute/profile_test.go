@@ -0,0 +1,127 @@
+package execute_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/flux/memory"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/plan/plantest"
+	"github.com/influxdata/flux/stdlib/universe"
+)
+
+func TestProfiler(t *testing.T) {
+	var inputs []*executetest.Table
+	for _, host := range []string{"a", "b"} {
+		input := &executetest.Table{
+			KeyCols: []string{"host"},
+			ColMeta: []flux.ColMeta{
+				{Label: "host", Type: flux.TString},
+				{Label: "_time", Type: flux.TTime},
+				{Label: "_value", Type: flux.TFloat},
+			},
+		}
+		for i := 0; i < 5; i++ {
+			input.Data = append(input.Data, []interface{}{host, execute.Time(i), float64(i)})
+		}
+		inputs = append(inputs, input)
+	}
+	spec := &plantest.PlanSpec{
+		Nodes: []plan.PlanNode{
+			plan.CreatePhysicalNode("from-test", executetest.NewFromProcedureSpec(inputs)),
+			plan.CreatePhysicalNode("limit", &universe.LimitProcedureSpec{N: 2}),
+			plan.CreatePhysicalNode("yield", executetest.NewYieldProcedureSpec("_result")),
+		},
+		Edges: [][2]int{
+			{0, 1},
+			{1, 2},
+		},
+		Resources: flux.ResourceManagement{
+			ConcurrencyQuota: 1,
+			MemoryBytesQuota: math.MaxInt64,
+		},
+		Now: time.Now(),
+	}
+
+	profiler := execute.NewProfiler()
+	if err := profiler.Explain(&bytes.Buffer{}, execute.ExplainText); err == nil {
+		t.Error("expected an error explaining a query that has not been executed")
+	}
+
+	alloc := &memory.Allocator{}
+	ctx := execute.WithProfiler(context.Background(), profiler)
+	results, err := execute.NewExecutor(nil, nil).Execute(ctx, plantest.CreatePlanSpec(spec), alloc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := results["_result"].Tables().Do(func(tbl flux.Table) error {
+		return tbl.Do(func(flux.ColReader) error { return nil })
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	got := profiler.Profiles()
+	want := []execute.OperatorProfile{
+		{Node: "from-test", Kind: executetest.FromTestKind, Tables: 2, Rows: 10},
+		{Node: "limit", Kind: universe.LimitKind, Predecessors: []plan.NodeID{"from-test"}, Tables: 2, Rows: 4},
+	}
+	if !cmp.Equal(want, got, cmpopts.IgnoreFields(execute.OperatorProfile{}, "Duration", "MaxAllocated")) {
+		t.Errorf("unexpected profiles -want/+got:\n%s", cmp.Diff(want, got))
+	}
+	var allocated int64
+	for _, p := range got {
+		allocated += p.MaxAllocated
+	}
+	// The memory of the operators is accounted for in the allocator of the query too.
+	if allocated == 0 || allocated < alloc.MaxAllocated() {
+		t.Errorf("unexpected memory of the operators: %d bytes, the query allocated %d bytes", allocated, alloc.MaxAllocated())
+	}
+
+	var text bytes.Buffer
+	if err := profiler.Explain(&text, execute.ExplainText); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(text.String()), "\n")
+	if len(lines) != 2 ||
+		!strings.HasPrefix(lines[0], "from-test (from-test): 2 tables, 10 rows in ") ||
+		!strings.HasPrefix(lines[1], "limit (limit) <- from-test: 2 tables, 4 rows in ") {
+		t.Errorf("unexpected text:\n%s", text.String())
+	}
+
+	var js bytes.Buffer
+	if err := profiler.Explain(&js, execute.ExplainJSON); err != nil {
+		t.Fatal(err)
+	}
+	var decoded struct {
+		Nodes []execute.OperatorProfile `json:"nodes"`
+	}
+	if err := json.Unmarshal(js.Bytes(), &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if !cmp.Equal(got, decoded.Nodes) {
+		t.Errorf("unexpected JSON profiles -want/+got:\n%s", cmp.Diff(got, decoded.Nodes))
+	}
+
+	var dot bytes.Buffer
+	if err := profiler.Explain(&dot, execute.ExplainGraphviz); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(dot.String(), "digraph {\n  \"from-test\" [label=\"from-test\\nfrom-test\\n2 tables, 10 rows\\n") ||
+		!strings.HasSuffix(dot.String(), "\n  \"from-test\" -> \"limit\"\n}\n") {
+		t.Errorf("unexpected graphviz:\n%s", dot.String())
+	}
+
+	if err := profiler.Explain(&bytes.Buffer{}, "yaml"); err == nil {
+		t.Error("expected an error explaining in an unknown format")
+	}
+}
@@ -0,0 +1,184 @@
+package execute
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/influxdata/flux"
+	fluxerrors "github.com/influxdata/flux/errors"
+	"github.com/influxdata/flux/plan"
+	"github.com/pkg/errors"
+)
+
+// slowestOperators is the number of operators reported as the slowest by a TimeoutError.
+const slowestOperators = 3
+
+// OperatorProgress reports the progress of the operator that executes a node of the physical plan.
+type OperatorProgress struct {
+	// Node is the ID of the node in the physical plan.
+	Node plan.NodeID
+	// Kind is the kind of the procedure of the node.
+	Kind plan.ProcedureKind
+	// Running reports whether the operator was busy, either running a source
+	// or processing a message of a transformation.
+	Running bool
+	// Tables and Rows count the tables a transformation has processed
+	// and the rows they held, if their tables report how many rows they hold.
+	// They are not counted for sources.
+	Tables int64
+	Rows   int64
+	// Duration is the time the operator has been busy.
+	Duration time.Duration
+}
+
+func (p OperatorProgress) String() string {
+	state := "waiting"
+	if p.Running {
+		state = "running"
+	}
+	return fmt.Sprintf("%s (%s, %s): %d tables, %d rows in %v", p.Node, p.Kind, state, p.Tables, p.Rows, p.Duration)
+}
+
+// TimeoutError is the error of a query whose context exceeded its deadline while it was executing.
+// It is not a causer, so errors.Cause finds it when it has been wrapped.
+type TimeoutError struct {
+	// Err is the error that reported the deadline, which is caused by context.DeadlineExceeded.
+	Err error
+	// Running is the progress of the operators that were busy when the query timed out.
+	Running []OperatorProgress
+	// Slowest is the progress of the operators that were busy for the longest time, slowest first.
+	Slowest []OperatorProgress
+}
+
+func (e *TimeoutError) Error() string {
+	var b strings.Builder
+	b.WriteString("query timed out: ")
+	b.WriteString(e.Err.Error())
+	if len(e.Running) > 0 {
+		b.WriteString("; running: ")
+		writeProgress(&b, e.Running)
+	}
+	if len(e.Slowest) > 0 {
+		b.WriteString("; slowest: ")
+		writeProgress(&b, e.Slowest)
+	}
+	return b.String()
+}
+
+// Code implements errors.Coder, the time of a query is a resource.
+func (e *TimeoutError) Code() fluxerrors.Code {
+	return fluxerrors.ResourceExhausted
+}
+
+func writeProgress(b *strings.Builder, ps []OperatorProgress) {
+	for i, p := range ps {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString(p.String())
+	}
+}
+
+// operatorProgress counts the progress of an operator while it executes.
+// It is shared by the transports of a transformation with several parents,
+// so all of its counters are updated atomically.
+type operatorProgress struct {
+	node plan.NodeID
+	kind plan.ProcedureKind
+
+	// running counts the goroutines that are busy with the operator
+	// and since is the time, in nanoseconds, at which the first of them started.
+	running int32
+	since   int64
+
+	tables int64
+	rows   int64
+	busy   int64
+}
+
+func newOperatorProgress(node plan.PlanNode) *operatorProgress {
+	return &operatorProgress{
+		node: node.ID(),
+		kind: node.Kind(),
+	}
+}
+
+// start marks the operator as busy and returns the time it started.
+func (p *operatorProgress) start() time.Time {
+	now := time.Now()
+	if atomic.AddInt32(&p.running, 1) == 1 {
+		atomic.StoreInt64(&p.since, now.UnixNano())
+	}
+	return now
+}
+
+// stop marks the operator as no longer busy with the work that was started at the given time.
+func (p *operatorProgress) stop(start time.Time) {
+	atomic.AddInt64(&p.busy, int64(time.Since(start)))
+	atomic.AddInt32(&p.running, -1)
+}
+
+// count counts a table that the operator has processed.
+func (p *operatorProgress) count(tbl flux.Table) {
+	atomic.AddInt64(&p.tables, 1)
+	if t, ok := tbl.(interface{ NRows() int }); ok {
+		atomic.AddInt64(&p.rows, int64(t.NRows()))
+	}
+}
+
+// progress returns a snapshot of the progress of the operator.
+// The duration of the work in progress is included in the duration.
+func (p *operatorProgress) progress() OperatorProgress {
+	op := OperatorProgress{
+		Node:     p.node,
+		Kind:     p.kind,
+		Running:  atomic.LoadInt32(&p.running) > 0,
+		Tables:   atomic.LoadInt64(&p.tables),
+		Rows:     atomic.LoadInt64(&p.rows),
+		Duration: time.Duration(atomic.LoadInt64(&p.busy)),
+	}
+	if op.Running {
+		op.Duration += time.Since(time.Unix(0, atomic.LoadInt64(&p.since)))
+	}
+	return op
+}
+
+// timeoutError returns a TimeoutError with the progress of the operators if err was caused by
+// the deadline of the context of the query, and err otherwise.
+func (es *executionState) timeoutError(err error) error {
+	if errors.Cause(err) != context.DeadlineExceeded {
+		return err
+	}
+	te := &TimeoutError{Err: err}
+	all := make([]OperatorProgress, len(es.progress))
+	for i, p := range es.progress {
+		all[i] = p.progress()
+		if all[i].Running {
+			te.Running = append(te.Running, all[i])
+		}
+	}
+	sort.SliceStable(all, func(i, j int) bool {
+		return all[i].Duration > all[j].Duration
+	})
+	if len(all) > slowestOperators {
+		all = all[:slowestOperators]
+	}
+	te.Slowest = all
+	return te
+}
+
+// progressSource counts the progress of a source while it runs.
+type progressSource struct {
+	Source
+	progress *operatorProgress
+}
+
+func (s progressSource) Run(ctx context.Context) {
+	start := s.progress.start()
+	defer s.progress.stop(start)
+	s.Source.Run(ctx)
+}
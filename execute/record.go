@@ -0,0 +1,104 @@
+package execute
+
+import (
+	"context"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/memory"
+	"github.com/influxdata/flux/plan"
+)
+
+// SourceRecorder records the tables produced by the sources of a query,
+// so that the rest of the query can be executed again on the same input.
+type SourceRecorder interface {
+	// RecordSource is called when the source of a node is created, before any of its tables is recorded.
+	RecordSource(node plan.NodeID)
+	// RecordTable records a table produced by the source of a node.
+	// The table is only valid for the duration of the call.
+	RecordTable(node plan.NodeID, tbl flux.Table) error
+}
+
+type contextKey int
+
+const (
+	sourceRecorderKey contextKey = iota
+	profilerKey
+	kindAllocationsKey
+	usageRecorderKey
+	writeRecorderKey
+	metadataRecorderKey
+)
+
+// WithSourceRecorder returns a context with which the tables of the sources of the executed queries
+// are recorded by r. A nil recorder disables the recording.
+func WithSourceRecorder(ctx context.Context, r SourceRecorder) context.Context {
+	return context.WithValue(ctx, sourceRecorderKey, r)
+}
+
+func sourceRecorderFromContext(ctx context.Context) SourceRecorder {
+	r, _ := ctx.Value(sourceRecorderKey).(SourceRecorder)
+	return r
+}
+
+// recordingNode sits between a source and its transformations,
+// and records the tables of the source before passing them on.
+type recordingNode struct {
+	node     plan.NodeID
+	recorder SourceRecorder
+	alloc    *memory.Allocator
+	ts       []Transformation
+}
+
+func (n *recordingNode) AddTransformation(t Transformation) {
+	n.ts = append(n.ts, t)
+}
+
+func (n *recordingNode) RetractTable(id DatasetID, key flux.GroupKey) error {
+	for _, t := range n.ts {
+		if err := t.RetractTable(id, key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (n *recordingNode) Process(id DatasetID, tbl flux.Table) error {
+	// The table is copied since the tables of sources may only be read once.
+	cpy, err := CopyTable(tbl, n.alloc)
+	if err != nil {
+		return err
+	}
+	if err := n.recorder.RecordTable(n.node, cpy); err != nil {
+		return err
+	}
+	for _, t := range n.ts {
+		if err := t.Process(id, cpy); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (n *recordingNode) UpdateWatermark(id DatasetID, time Time) error {
+	for _, t := range n.ts {
+		if err := t.UpdateWatermark(id, time); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (n *recordingNode) UpdateProcessingTime(id DatasetID, time Time) error {
+	for _, t := range n.ts {
+		if err := t.UpdateProcessingTime(id, time); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (n *recordingNode) Finish(id DatasetID, err error) {
+	for _, t := range n.ts {
+		t.Finish(id, err)
+	}
+}
@@ -18,6 +18,12 @@ type result struct {
 	aborted  chan struct{}
 
 	stats flux.Statistics
+
+	// diagnose adds diagnostics to the error a result finishes with, or is nil.
+	diagnose func(error) error
+
+	warningsMu sync.Mutex
+	warnings   []flux.Warning
 }
 
 type resultMessage struct {
@@ -55,6 +61,19 @@ func (s *result) Process(id DatasetID, tbl flux.Table) error {
 
 func (s *result) Statistics() flux.Statistics { return s.stats }
 
+// Warnings implements flux.Warner.
+func (s *result) Warnings() []flux.Warning {
+	s.warningsMu.Lock()
+	defer s.warningsMu.Unlock()
+	return append([]flux.Warning(nil), s.warnings...)
+}
+
+func (s *result) addWarning(w flux.Warning) {
+	s.warningsMu.Lock()
+	s.warnings = append(s.warnings, w)
+	s.warningsMu.Unlock()
+}
+
 func (s *result) Tables() flux.TableIterator {
 	return s
 }
@@ -90,6 +109,9 @@ func (s *result) UpdateProcessingTime(id DatasetID, t Time) error {
 
 func (s *result) Finish(id DatasetID, err error) {
 	if err != nil {
+		if s.diagnose != nil {
+			err = s.diagnose(err)
+		}
 		select {
 		case s.tables <- resultMessage{
 			err: err,
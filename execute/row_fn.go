@@ -5,6 +5,7 @@ import (
 	"regexp"
 
 	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/ast"
 	"github.com/influxdata/flux/compiler"
 	"github.com/influxdata/flux/semantic"
 	"github.com/influxdata/flux/values"
@@ -12,6 +13,7 @@ import (
 )
 
 type rowFn struct {
+	fn               *semantic.FunctionExpression
 	compilationCache *compiler.CompilationCache
 	inRecord         values.Object
 
@@ -29,7 +31,9 @@ func newRowFn(fn *semantic.FunctionExpression) (rowFn, error) {
 		return rowFn{}, errors.New("function should only have a single parameter")
 	}
 	scope := flux.BuiltIns()
+	importPackages(fn, scope)
 	return rowFn{
+		fn:               fn,
 		compilationCache: compiler.NewCompilationCache(fn, scope),
 		inRecord:         values.NewObject(),
 		recordName:       fn.Block.Parameters.List[0].Key.Name,
@@ -199,6 +203,44 @@ func (f *RowPredicateFn) Eval(row int, cr flux.ColReader) (bool, error) {
 	return v.Bool(), nil
 }
 
+// EvalDictionary evaluates the predicate for the rows of cr and stores the results in pass,
+// when the only column the predicate references is a dictionary-encoded column of cr.
+// The predicate is then evaluated once per distinct value of the column instead of once per row.
+// The rows whose value is null, or whose value the predicate fails to evaluate, do not pass.
+// It reports whether the predicate has been evaluated.
+func (f *RowPredicateFn) EvalDictionary(cr flux.ColReader, pass []bool) bool {
+	if len(f.references) == 0 {
+		return false
+	}
+	ref := f.references[0]
+	for _, r := range f.references[1:] {
+		if r != ref {
+			return false
+		}
+	}
+	j := f.recordCols[ref]
+	if cr.Cols()[j].Type != flux.TString {
+		return false
+	}
+	d := Dictionary(cr, j)
+	if d == nil || d.Values().Len() >= d.Len() {
+		return false
+	}
+
+	dict := d.Values()
+	results := make([]bool, dict.Len())
+	for k := range results {
+		f.record.Set(ref, values.NewString(dict.ValueString(k)))
+		f.inRecord.Set(f.recordName, f.record)
+		v, err := f.preparedFn.Eval(f.inRecord)
+		results[k] = err == nil && v.Bool()
+	}
+	for i := 0; i < d.Len(); i++ {
+		pass[i] = d.IsValid(i) && results[d.Index(i)]
+	}
+	return true
+}
+
 type RowMapFn struct {
 	rowFn
 
@@ -250,6 +292,23 @@ func (f *RowMapFn) Eval(row int, cr flux.ColReader) (values.Object, error) {
 	return v.Object(), nil
 }
 
+// Location returns the location in the script of the expression that computes the column with the label,
+// if the function returns an object with the property, and the location of the body of the function otherwise.
+func (f *RowMapFn) Location(label string) ast.SourceLocation {
+	body := f.fn.Block.Body
+	if b, ok := body.(*semantic.Block); ok {
+		body = b.ReturnStatement().Argument
+	}
+	if obj, ok := body.(*semantic.ObjectExpression); ok {
+		for _, p := range obj.Properties {
+			if p.Key.Key() == label {
+				return p.Value.Location()
+			}
+		}
+	}
+	return body.Location()
+}
+
 func findColReferences(fn *semantic.FunctionExpression) []string {
 	v := &colReferenceVisitor{
 		recordName: fn.Block.Parameters.List[0].Key.Name,
@@ -274,6 +333,52 @@ func (c *colReferenceVisitor) Visit(node semantic.Node) semantic.Visitor {
 
 func (c *colReferenceVisitor) Done(semantic.Node) {}
 
+// importPackages adds to the scope the packages of the standard library that fn references by name
+// and that are not in the scope, so that the functions of the packages a script imports,
+// such as system.time, can be called by its row functions. Packages imported under another name
+// cannot be referenced.
+func importPackages(fn *semantic.FunctionExpression, scope map[string]values.Value) {
+	v := &packageReferenceVisitor{
+		recordName: fn.Block.Parameters.List[0].Key.Name,
+		scope:      scope,
+		names:      make(map[string]bool),
+	}
+	semantic.Walk(v, fn)
+	if len(v.names) == 0 {
+		return
+	}
+	stdlib := flux.StdLib()
+	for _, path := range flux.StdLibPackagePaths() {
+		pkg, ok := stdlib.ImportPackageObject(path)
+		if !ok || !v.names[pkg.Name()] {
+			continue
+		}
+		// The paths are sorted, so a name shared by several packages always refers to the same one.
+		if _, ok := scope[pkg.Name()]; !ok {
+			scope[pkg.Name()] = pkg
+		}
+	}
+}
+
+type packageReferenceVisitor struct {
+	recordName string
+	scope      map[string]values.Value
+	names      map[string]bool
+}
+
+func (p *packageReferenceVisitor) Visit(node semantic.Node) semantic.Visitor {
+	if me, ok := node.(*semantic.MemberExpression); ok {
+		if obj, ok := me.Object.(*semantic.IdentifierExpression); ok && obj.Name != p.recordName {
+			if _, ok := p.scope[obj.Name]; !ok {
+				p.names[obj.Name] = true
+			}
+		}
+	}
+	return p
+}
+
+func (p *packageReferenceVisitor) Done(semantic.Node) {}
+
 type Record struct {
 	t      semantic.Type
 	values map[string]values.Value
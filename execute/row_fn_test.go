@@ -153,6 +153,58 @@ func TestRowMapFn_Eval(t *testing.T) {
 				{},
 			},
 		},
+		{
+			name: "strings.toUpper(v: tag)",
+			f: func() (*execute.RowMapFn, error) {
+				return execute.NewRowMapFn(&semantic.FunctionExpression{
+					Block: &semantic.FunctionBlock{
+						Parameters: &semantic.FunctionParameters{
+							List: []*semantic.FunctionParameter{{Key: &semantic.Identifier{Name: "r"}}},
+						},
+						Body: &semantic.ObjectExpression{
+							Properties: []*semantic.Property{
+								{
+									Key: &semantic.StringLiteral{Value: "tag"},
+									Value: &semantic.CallExpression{
+										Callee: &semantic.MemberExpression{
+											Object: &semantic.IdentifierExpression{
+												Name: "strings",
+											},
+											Property: "toUpper",
+										},
+										Arguments: &semantic.ObjectExpression{
+											Properties: []*semantic.Property{{
+												Key: &semantic.Identifier{Name: "v"},
+												Value: &semantic.MemberExpression{
+													Object: &semantic.IdentifierExpression{
+														Name: "r",
+													},
+													Property: "tag",
+												},
+											}},
+										},
+									},
+								},
+							},
+						},
+					},
+				})
+			},
+			data: &executetest.Table{
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "tag", Type: flux.TString},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), "a"},
+					{execute.Time(2), "b"},
+				},
+			},
+			want: [][]interface{}{
+				{"tag", "A"},
+				{"tag", "B"},
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -0,0 +1,58 @@
+package execute
+
+import "math/bits"
+
+// Selection is a bitmap of the rows of a column reader that are selected,
+// such as the rows that pass the predicate of a filter.
+type Selection struct {
+	words []uint64
+	n     int
+}
+
+// NewSelection returns a selection of all the n rows.
+func NewSelection(n int) *Selection {
+	words := make([]uint64, (n+63)/64)
+	for k := range words {
+		words[k] = ^uint64(0)
+	}
+	if r := n % 64; r != 0 {
+		words[len(words)-1] = 1<<uint(r) - 1
+	}
+	return &Selection{words: words, n: n}
+}
+
+// Len returns the number of rows of the selection, whether they are selected or not.
+func (s *Selection) Len() int {
+	return s.n
+}
+
+// IsSelected reports whether row i is selected.
+func (s *Selection) IsSelected(i int) bool {
+	return s.words[i/64]&(1<<uint(i%64)) != 0
+}
+
+// Deselect removes row i from the selection.
+func (s *Selection) Deselect(i int) {
+	s.words[i/64] &^= 1 << uint(i%64)
+}
+
+// Count returns the number of selected rows.
+func (s *Selection) Count() int {
+	n := 0
+	for _, w := range s.words {
+		n += bits.OnesCount64(w)
+	}
+	return n
+}
+
+// Rows returns the indexes of the selected rows in increasing order.
+func (s *Selection) Rows() []int {
+	rows := make([]int, 0, s.Count())
+	for k, w := range s.words {
+		for w != 0 {
+			rows = append(rows, k*64+bits.TrailingZeros64(w))
+			w &= w - 1
+		}
+	}
+	return rows
+}
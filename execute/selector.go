@@ -21,6 +21,8 @@ type selectorTransformation struct {
 type SelectorConfig struct {
 	plan.DefaultCost
 	Column string `json:"column"`
+	// NaN is the policy for the NaN and infinite values of the column, when it is a float column.
+	NaN NaNPolicy `json:"nan,omitempty"`
 }
 
 func (c *SelectorConfig) ReadArgs(args flux.Arguments) error {
@@ -29,9 +31,25 @@ func (c *SelectorConfig) ReadArgs(args flux.Arguments) error {
 	} else if ok {
 		c.Column = col
 	}
+	if s, ok, err := args.GetString("nan"); err != nil {
+		return err
+	} else if ok {
+		p, err := ParseNaNPolicy(s)
+		if err != nil {
+			return err
+		}
+		c.NaN = p
+	}
 	return nil
 }
 
+// SetDefaultNaNPolicy implements NaNPolicySpec.
+func (c *SelectorConfig) SetDefaultNaNPolicy(p NaNPolicy) {
+	if c.NaN == "" {
+		c.NaN = p
+	}
+}
+
 // SelectorSignature returns a function signature common to all selector functions,
 // with any additional arguments.
 func SelectorSignature(args map[string]semantic.PolyType, required []string) semantic.FunctionPolySignature {
@@ -39,6 +57,7 @@ func SelectorSignature(args map[string]semantic.PolyType, required []string) sem
 		args = make(map[string]semantic.PolyType)
 	}
 	args["column"] = semantic.String
+	args["nan"] = semantic.String
 	return flux.FunctionSignature(args, required)
 }
 
@@ -152,7 +171,14 @@ func (t *indexSelectorTransformation) Process(id DatasetID, tbl flux.Table) erro
 			selected := s.(DoUIntIndexSelector).DoUInt(cr.UInts(valueIdx))
 			return t.appendSelected(selected, builder, cr)
 		case flux.TFloat:
-			selected := s.(DoFloatIndexSelector).DoFloat(cr.Floats(valueIdx))
+			vs, err := t.config.NaN.NullFloats(valueCol.Label, cr.Floats(valueIdx))
+			if err != nil {
+				return err
+			}
+			selected := s.(DoFloatIndexSelector).DoFloat(vs)
+			if vs != cr.Floats(valueIdx) {
+				vs.Release()
+			}
 			return t.appendSelected(selected, builder, cr)
 		case flux.TString:
 			selected := s.(DoStringIndexSelector).DoString(cr.Strings(valueIdx))
@@ -203,7 +229,14 @@ func (t *rowSelectorTransformation) Process(id DatasetID, tbl flux.Table) error
 		case flux.TUInt:
 			rower.(DoUIntRowSelector).DoUInt(cr.UInts(valueIdx), cr)
 		case flux.TFloat:
-			rower.(DoFloatRowSelector).DoFloat(cr.Floats(valueIdx), cr)
+			vs, err := t.config.NaN.NullFloats(valueCol.Label, cr.Floats(valueIdx))
+			if err != nil {
+				return err
+			}
+			rower.(DoFloatRowSelector).DoFloat(vs, cr)
+			if vs != cr.Floats(valueIdx) {
+				vs.Release()
+			}
 		case flux.TString:
 			rower.(DoStringRowSelector).DoString(cr.Strings(valueIdx), cr)
 		default:
@@ -0,0 +1,673 @@
+package execute
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/arrow"
+	"github.com/influxdata/flux/memory"
+)
+
+// minSharedRows is the minimum number of contiguous rows a SharedTableBuilder shares
+// instead of copying. Slicing a short run of rows costs more than copying it.
+const minSharedRows = 64
+
+// SharedTableBuilder builds a table out of the column readers of other tables,
+// sharing their Arrow arrays instead of copying their values.
+//
+// The shared arrays are retained for as long as the builder, or a table it has built, references them.
+// Their memory is accounted for once by the allocator that allocated it,
+// and freed when the last table that shares them is released.
+// Transformations that only filter rows, or add, drop or rename columns, can use it to pass
+// their input data downstream without copying it.
+type SharedTableBuilder struct {
+	key   flux.GroupKey
+	cols  []flux.ColMeta
+	alloc *memory.Allocator
+
+	chunks []sharedChunk
+	nrows  int
+}
+
+// sharedChunk holds rows of a table in one Arrow array per column.
+// If rows is not nil, only those rows of the arrays belong to the table,
+// and they are copied out of the arrays when a column is read.
+type sharedChunk struct {
+	arrs []array.Interface
+	rows []int
+}
+
+func (c sharedChunk) len() int {
+	if c.rows != nil {
+		return len(c.rows)
+	}
+	return c.arrs[0].Len()
+}
+
+func NewSharedTableBuilder(key flux.GroupKey, a *memory.Allocator) *SharedTableBuilder {
+	return &SharedTableBuilder{
+		key:   key,
+		alloc: a,
+	}
+}
+
+func (b *SharedTableBuilder) Key() flux.GroupKey {
+	return b.key
+}
+
+func (b *SharedTableBuilder) Cols() []flux.ColMeta {
+	return b.cols
+}
+
+func (b *SharedTableBuilder) NRows() int {
+	return b.nrows
+}
+
+// Allocator returns the allocator of the arrays the transformation creates for the table.
+func (b *SharedTableBuilder) Allocator() *memory.Allocator {
+	return b.alloc
+}
+
+// AddCol adds a column to the table. The columns must be added before any rows.
+func (b *SharedTableBuilder) AddCol(c flux.ColMeta) (int, error) {
+	if ColIdx(c.Label, b.cols) >= 0 {
+		return -1, fmt.Errorf("table builder already has column with label %s", c.Label)
+	}
+	if b.nrows > 0 {
+		return -1, fmt.Errorf("cannot add column %s to a table builder with rows", c.Label)
+	}
+	b.cols = append(b.cols, c)
+	return len(b.cols) - 1, nil
+}
+
+// AppendArrays appends rows made of one array per column of the table.
+// The arrays are retained, the caller keeps its own references.
+func (b *SharedTableBuilder) AppendArrays(arrs []array.Interface) error {
+	return b.appendChunk(arrs, nil)
+}
+
+// appendChunk appends the given rows of the arrays, or all of their rows if rows is nil.
+func (b *SharedTableBuilder) appendChunk(arrs []array.Interface, rows []int) error {
+	if len(arrs) != len(b.cols) {
+		return fmt.Errorf("cannot append %d arrays to a table with %d columns", len(arrs), len(b.cols))
+	}
+	if len(arrs) == 0 || arrs[0].Len() == 0 {
+		return nil
+	}
+	l := arrs[0].Len()
+	for j, arr := range arrs {
+		if err := checkArray(b.cols[j], arr); err != nil {
+			return err
+		}
+		if arr.Len() != l {
+			return fmt.Errorf("column %s has %d rows, expected %d", b.cols[j].Label, arr.Len(), l)
+		}
+	}
+	chunk := sharedChunk{
+		arrs: make([]array.Interface, len(arrs)),
+		rows: rows,
+	}
+	for j, arr := range arrs {
+		arr.Retain()
+		chunk.arrs[j] = arr
+	}
+	b.chunks = append(b.chunks, chunk)
+	b.nrows += chunk.len()
+	return nil
+}
+
+// AppendColReader appends the rows of a column reader, sharing its arrays.
+// colMap maps the columns of the table to the columns of the reader.
+// A nil colMap maps the columns to the columns of the reader with the same index,
+// and the columns mapped to -1 are appended nulls.
+func (b *SharedTableBuilder) AppendColReader(cr flux.ColReader, colMap []int) error {
+	arrs := make([]array.Interface, len(b.cols))
+	for j := range b.cols {
+		arrs[j] = b.readerArray(cr, colMap, j)
+	}
+	defer releaseArrays(arrs)
+	return b.AppendArrays(arrs)
+}
+
+// AppendRows appends the rows [start, stop) of a column reader, sharing its arrays.
+func (b *SharedTableBuilder) AppendRows(cr flux.ColReader, colMap []int, start, stop int) error {
+	if start == 0 && stop == cr.Len() {
+		return b.AppendColReader(cr, colMap)
+	}
+	arrs := make([]array.Interface, len(b.cols))
+	for j := range b.cols {
+		arr := b.readerArray(cr, colMap, j)
+		arrs[j] = sliceArray(arr, start, stop)
+		arr.Release()
+	}
+	defer releaseArrays(arrs)
+	return b.AppendArrays(arrs)
+}
+
+// AppendSelected appends the selected rows of a column reader.
+// The long runs of contiguous selected rows share the arrays of the reader, the other rows are copied.
+func (b *SharedTableBuilder) AppendSelected(cr flux.ColReader, colMap []int, selected []bool) error {
+	var copied []int
+	flush := func() error {
+		if len(copied) == 0 {
+			return nil
+		}
+		arrs := make([]array.Interface, len(b.cols))
+		for j := range b.cols {
+			arr := b.readerArray(cr, colMap, j)
+			arrs[j] = copyArrayRows(arr, copied, b.alloc)
+			arr.Release()
+		}
+		defer releaseArrays(arrs)
+		copied = copied[:0]
+		return b.AppendArrays(arrs)
+	}
+
+	l := cr.Len()
+	for i := 0; i < l; {
+		if !selected[i] {
+			i++
+			continue
+		}
+		start := i
+		for i < l && selected[i] {
+			i++
+		}
+		if i-start < minSharedRows {
+			for r := start; r < i; r++ {
+				copied = append(copied, r)
+			}
+			continue
+		}
+		if err := flush(); err != nil {
+			return err
+		}
+		if err := b.AppendRows(cr, colMap, start, i); err != nil {
+			return err
+		}
+	}
+	return flush()
+}
+
+// AppendSelection appends the selected rows of a column reader.
+// The arrays of the reader are shared along with the selection, which is only applied
+// when the columns of the table are read, so that the columns that are never read are never copied.
+func (b *SharedTableBuilder) AppendSelection(cr flux.ColReader, colMap []int, sel *Selection) error {
+	if sel.Len() != cr.Len() {
+		return fmt.Errorf("cannot append a selection of %d rows from a column reader with %d rows", sel.Len(), cr.Len())
+	}
+	switch sel.Count() {
+	case 0:
+		return nil
+	case cr.Len():
+		return b.AppendColReader(cr, colMap)
+	}
+	arrs := make([]array.Interface, len(b.cols))
+	for j := range b.cols {
+		arrs[j] = b.readerArray(cr, colMap, j)
+	}
+	defer releaseArrays(arrs)
+	return b.appendChunk(arrs, sel.Rows())
+}
+
+// readerArray returns a reference to the array of the column reader mapped to column j of the table,
+// or a new array of nulls if the column is not mapped.
+func (b *SharedTableBuilder) readerArray(cr flux.ColReader, colMap []int, j int) array.Interface {
+	idx := j
+	if colMap != nil {
+		idx = colMap[j]
+	}
+	if idx < 0 {
+		return nullArray(b.cols[j].Type, cr.Len(), b.alloc)
+	}
+	arr := ColReaderArray(cr, idx)
+	arr.Retain()
+	return arr
+}
+
+// ClearData releases the rows of the table, while preserving the columns.
+func (b *SharedTableBuilder) ClearData() {
+	for _, chunk := range b.chunks {
+		releaseArrays(chunk.arrs)
+	}
+	b.chunks = nil
+	b.nrows = 0
+}
+
+// Table returns the table that has been built.
+// The table shares the arrays of the builder and releases them when its reference count goes to zero.
+func (b *SharedTableBuilder) Table() (flux.Table, error) {
+	chunks := make([]sharedChunk, len(b.chunks))
+	for i, chunk := range b.chunks {
+		for _, arr := range chunk.arrs {
+			arr.Retain()
+		}
+		chunks[i] = chunk
+	}
+	return &sharedTable{
+		key:    b.key,
+		cols:   b.cols,
+		chunks: chunks,
+		nrows:  b.nrows,
+		alloc:  b.alloc,
+	}, nil
+}
+
+// sharedTable is a table whose rows are held in chunks of Arrow arrays shared with other tables.
+type sharedTable struct {
+	key    flux.GroupKey
+	cols   []flux.ColMeta
+	chunks []sharedChunk
+	nrows  int
+	alloc  *memory.Allocator
+
+	refCount int32
+}
+
+func (t *sharedTable) Key() flux.GroupKey {
+	return t.key
+}
+
+func (t *sharedTable) Cols() []flux.ColMeta {
+	return t.cols
+}
+
+func (t *sharedTable) Empty() bool {
+	return t.nrows == 0
+}
+
+func (t *sharedTable) NRows() int {
+	return t.nrows
+}
+
+func (t *sharedTable) Statistics() flux.Statistics {
+	return flux.Statistics{}
+}
+
+func (t *sharedTable) RefCount(n int) {
+	c := atomic.AddInt32(&t.refCount, int32(n))
+	if c == 0 {
+		for _, chunk := range t.chunks {
+			releaseArrays(chunk.arrs)
+		}
+		t.chunks = nil
+	}
+}
+
+func (t *sharedTable) Do(f func(flux.ColReader) error) error {
+	for _, chunk := range t.chunks {
+		cr := &arrayColReader{
+			key:   t.key,
+			cols:  t.cols,
+			l:     chunk.len(),
+			arrs:  chunk.arrs,
+			rows:  chunk.rows,
+			alloc: t.alloc,
+		}
+		err := f(cr)
+		cr.releaseDecoded()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SharedTableCache is a DataCache of SharedTableBuilders.
+type SharedTableCache struct {
+	tables *GroupLookup
+	alloc  *memory.Allocator
+
+	triggerSpec flux.TriggerSpec
+}
+
+type sharedTableState struct {
+	builder *SharedTableBuilder
+	trigger Trigger
+}
+
+func NewSharedTableCache(a *memory.Allocator) *SharedTableCache {
+	return &SharedTableCache{
+		tables: NewGroupLookup(),
+		alloc:  a,
+	}
+}
+
+func (c *SharedTableCache) SetTriggerSpec(ts flux.TriggerSpec) {
+	c.triggerSpec = ts
+}
+
+// TableBuilder returns the builder of the table with the given key.
+// If no builder exists, one is created. The boolean return value indicates if the builder is new.
+func (c *SharedTableCache) TableBuilder(key flux.GroupKey) (*SharedTableBuilder, bool) {
+	v, ok := c.tables.Lookup(key)
+	if !ok {
+		key = retainGroupKey(key)
+		s := sharedTableState{
+			builder: NewSharedTableBuilder(key, c.alloc),
+			trigger: NewTriggerFromSpec(c.triggerSpec),
+		}
+		c.tables.Set(key, s)
+		return s.builder, true
+	}
+	return v.(sharedTableState).builder, false
+}
+
+func (c *SharedTableCache) Table(key flux.GroupKey) (flux.Table, error) {
+	v, ok := c.tables.Lookup(key)
+	if !ok {
+		return nil, fmt.Errorf("table not found with key %v", key)
+	}
+	return v.(sharedTableState).builder.Table()
+}
+
+func (c *SharedTableCache) ForEach(f func(flux.GroupKey)) {
+	c.tables.Range(func(key flux.GroupKey, value interface{}) {
+		f(key)
+	})
+}
+
+func (c *SharedTableCache) ForEachWithContext(f func(flux.GroupKey, Trigger, TableContext)) {
+	c.tables.Range(func(key flux.GroupKey, value interface{}) {
+		s := value.(sharedTableState)
+		f(key, s.trigger, TableContext{
+			Key:   key,
+			Count: s.builder.NRows(),
+		})
+	})
+}
+
+func (c *SharedTableCache) DiscardTable(key flux.GroupKey) {
+	if v, ok := c.tables.Lookup(key); ok {
+		v.(sharedTableState).builder.ClearData()
+	}
+}
+
+func (c *SharedTableCache) ExpireTable(key flux.GroupKey) {
+	if v, ok := c.tables.Delete(key); ok {
+		v.(sharedTableState).builder.ClearData()
+	}
+}
+
+// arrayColReader reads rows held in one Arrow array per column.
+// The string columns may be held in dictionary-encoded arrays.
+// If rows is not nil, the reader only reads those rows of the arrays.
+type arrayColReader struct {
+	key  flux.GroupKey
+	cols []flux.ColMeta
+	l    int
+	arrs []array.Interface
+	rows []int
+
+	// taken holds the columns whose rows have been copied out of the arrays,
+	// and decoded the dictionary-encoded columns that have been read as plain strings.
+	alloc   *memory.Allocator
+	taken   []array.Interface
+	decoded []*array.Binary
+}
+
+func (r *arrayColReader) Key() flux.GroupKey {
+	return r.key
+}
+
+func (r *arrayColReader) Cols() []flux.ColMeta {
+	return r.cols
+}
+
+func (r *arrayColReader) Len() int {
+	return r.l
+}
+
+func (r *arrayColReader) Bools(j int) *array.Boolean {
+	CheckColType(r.cols[j], flux.TBool)
+	return r.array(j).(*array.Boolean)
+}
+
+func (r *arrayColReader) Ints(j int) *array.Int64 {
+	CheckColType(r.cols[j], flux.TInt)
+	return r.array(j).(*array.Int64)
+}
+
+func (r *arrayColReader) UInts(j int) *array.Uint64 {
+	CheckColType(r.cols[j], flux.TUInt)
+	return r.array(j).(*array.Uint64)
+}
+
+func (r *arrayColReader) Floats(j int) *array.Float64 {
+	CheckColType(r.cols[j], flux.TFloat)
+	return r.array(j).(*array.Float64)
+}
+
+func (r *arrayColReader) Strings(j int) *array.Binary {
+	CheckColType(r.cols[j], flux.TString)
+	d, ok := r.array(j).(*arrow.Dictionary)
+	if !ok {
+		return r.array(j).(*array.Binary)
+	}
+	if r.decoded == nil {
+		r.decoded = make([]*array.Binary, len(r.arrs))
+	}
+	if r.decoded[j] == nil {
+		r.decoded[j] = d.Decode(r.alloc)
+	}
+	return r.decoded[j]
+}
+
+func (r *arrayColReader) Dictionary(j int) *arrow.Dictionary {
+	CheckColType(r.cols[j], flux.TString)
+	d, _ := r.array(j).(*arrow.Dictionary)
+	return d
+}
+
+// array returns the array of column j, holding only the rows of the reader.
+func (r *arrayColReader) array(j int) array.Interface {
+	if r.rows == nil {
+		return r.arrs[j]
+	}
+	if r.taken == nil {
+		r.taken = make([]array.Interface, len(r.arrs))
+	}
+	if r.taken[j] == nil {
+		r.taken[j] = copyArrayRows(r.arrs[j], r.rows, r.alloc)
+	}
+	return r.taken[j]
+}
+
+func (r *arrayColReader) Times(j int) *array.Int64 {
+	CheckColType(r.cols[j], flux.TTime)
+	return r.array(j).(*array.Int64)
+}
+
+// Release releases the arrays of the reader.
+func (r *arrayColReader) Release() {
+	releaseArrays(r.arrs)
+	r.releaseDecoded()
+}
+
+func (r *arrayColReader) releaseDecoded() {
+	for _, arr := range r.decoded {
+		if arr != nil {
+			arr.Release()
+		}
+	}
+	r.decoded = nil
+	for _, arr := range r.taken {
+		if arr != nil {
+			arr.Release()
+		}
+	}
+	r.taken = nil
+}
+
+// ColReaderArray returns the array of column j of a column reader.
+// A dictionary-encoded string column is returned as an *arrow.Dictionary.
+// The array is not retained.
+func ColReaderArray(cr flux.ColReader, j int) array.Interface {
+	switch typ := cr.Cols()[j].Type; typ {
+	case flux.TBool:
+		return cr.Bools(j)
+	case flux.TInt:
+		return cr.Ints(j)
+	case flux.TUInt:
+		return cr.UInts(j)
+	case flux.TFloat:
+		return cr.Floats(j)
+	case flux.TString:
+		if d := Dictionary(cr, j); d != nil {
+			return d
+		}
+		return cr.Strings(j)
+	case flux.TTime:
+		return cr.Times(j)
+	default:
+		PanicUnknownType(typ)
+		return nil
+	}
+}
+
+// checkArray checks that an array holds values of the type of a column.
+func checkArray(c flux.ColMeta, arr array.Interface) error {
+	var ok bool
+	switch c.Type {
+	case flux.TBool:
+		_, ok = arr.(*array.Boolean)
+	case flux.TInt, flux.TTime:
+		_, ok = arr.(*array.Int64)
+	case flux.TUInt:
+		_, ok = arr.(*array.Uint64)
+	case flux.TFloat:
+		_, ok = arr.(*array.Float64)
+	case flux.TString:
+		switch arr.(type) {
+		case *array.Binary, *arrow.Dictionary:
+			ok = true
+		}
+	}
+	if !ok {
+		return fmt.Errorf("column %s of type %v cannot hold an array of type %v", c.Label, c.Type, arr.DataType())
+	}
+	return nil
+}
+
+// nullArray returns an array of l nulls for a column of the given type.
+func nullArray(typ flux.ColType, l int, a *memory.Allocator) array.Interface {
+	var b array.Builder
+	switch typ {
+	case flux.TBool:
+		b = arrow.NewBoolBuilder(a)
+	case flux.TInt, flux.TTime:
+		b = arrow.NewIntBuilder(a)
+	case flux.TUInt:
+		b = arrow.NewUintBuilder(a)
+	case flux.TFloat:
+		b = arrow.NewFloatBuilder(a)
+	case flux.TString:
+		b = arrow.NewStringBuilder(a)
+	default:
+		PanicUnknownType(typ)
+	}
+	defer b.Release()
+	b.Reserve(l)
+	for i := 0; i < l; i++ {
+		b.AppendNull()
+	}
+	return b.NewArray()
+}
+
+// sliceArray returns the values [start, stop) of an array, sharing its buffers.
+func sliceArray(arr array.Interface, start, stop int) array.Interface {
+	switch arr := arr.(type) {
+	case *array.Boolean:
+		return arrow.BoolSlice(arr, start, stop)
+	case *array.Int64:
+		return arrow.IntSlice(arr, start, stop)
+	case *array.Uint64:
+		return arrow.UintSlice(arr, start, stop)
+	case *array.Float64:
+		return arrow.FloatSlice(arr, start, stop)
+	case *array.Binary:
+		return arrow.StringSlice(arr, start, stop)
+	case *arrow.Dictionary:
+		return arrow.DictionarySlice(arr, start, stop)
+	default:
+		panic(fmt.Errorf("unexpected array type %v", arr.DataType()))
+	}
+}
+
+// copyArrayRows copies the values of the given rows of an array into a new array.
+func copyArrayRows(arr array.Interface, rows []int, a *memory.Allocator) array.Interface {
+	switch arr := arr.(type) {
+	case *array.Boolean:
+		b := arrow.NewBoolBuilder(a)
+		defer b.Release()
+		b.Reserve(len(rows))
+		for _, i := range rows {
+			if arr.IsNull(i) {
+				b.AppendNull()
+				continue
+			}
+			b.Append(arr.Value(i))
+		}
+		return b.NewBooleanArray()
+	case *array.Int64:
+		b := arrow.NewIntBuilder(a)
+		defer b.Release()
+		b.Reserve(len(rows))
+		for _, i := range rows {
+			if arr.IsNull(i) {
+				b.AppendNull()
+				continue
+			}
+			b.Append(arr.Value(i))
+		}
+		return b.NewInt64Array()
+	case *array.Uint64:
+		b := arrow.NewUintBuilder(a)
+		defer b.Release()
+		b.Reserve(len(rows))
+		for _, i := range rows {
+			if arr.IsNull(i) {
+				b.AppendNull()
+				continue
+			}
+			b.Append(arr.Value(i))
+		}
+		return b.NewUint64Array()
+	case *array.Float64:
+		b := arrow.NewFloatBuilder(a)
+		defer b.Release()
+		b.Reserve(len(rows))
+		for _, i := range rows {
+			if arr.IsNull(i) {
+				b.AppendNull()
+				continue
+			}
+			b.Append(arr.Value(i))
+		}
+		return b.NewFloat64Array()
+	case *array.Binary:
+		b := arrow.NewStringBuilder(a)
+		defer b.Release()
+		b.Reserve(len(rows))
+		for _, i := range rows {
+			if arr.IsNull(i) {
+				b.AppendNull()
+				continue
+			}
+			b.Append(arr.Value(i))
+		}
+		return b.NewBinaryArray()
+	case *arrow.Dictionary:
+		return arrow.DictionaryTake(arr, rows, a)
+	default:
+		panic(fmt.Errorf("unexpected array type %v", arr.DataType()))
+	}
+}
+
+func releaseArrays(arrs []array.Interface) {
+	for _, arr := range arrs {
+		arr.Release()
+	}
+}
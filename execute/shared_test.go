@@ -0,0 +1,191 @@
+package execute_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/flux/memory"
+)
+
+func TestSharedTableBuilder_AppendSelected(t *testing.T) {
+	in := &executetest.Table{
+		ColMeta: []flux.ColMeta{
+			{Label: "_time", Type: flux.TTime},
+			{Label: "_value", Type: flux.TFloat},
+			{Label: "host", Type: flux.TString},
+		},
+	}
+	for i := 0; i < 200; i++ {
+		var host interface{} = "a"
+		if i%7 == 0 {
+			host = nil
+		}
+		in.Data = append(in.Data, []interface{}{execute.Time(i), float64(i), host})
+	}
+
+	// A long run of rows is shared, the scattered rows are copied.
+	selected := make([]bool, len(in.Data))
+	want := &executetest.Table{ColMeta: in.ColMeta}
+	for i := range selected {
+		if i < 100 || i == 150 || i == 152 || i >= 180 {
+			selected[i] = true
+			want.Data = append(want.Data, in.Data[i])
+		}
+	}
+
+	b := execute.NewSharedTableBuilder(in.Key(), executetest.UnlimitedAllocator)
+	for _, c := range in.Cols() {
+		if _, err := b.AddCol(c); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := in.Do(func(cr flux.ColReader) error {
+		return b.AppendSelected(cr, nil, selected)
+	}); err != nil {
+		t.Fatal(err)
+	}
+	tbl, err := b.Table()
+	if err != nil {
+		t.Fatal(err)
+	}
+	b.ClearData()
+
+	var lens []int
+	if err := tbl.Do(func(cr flux.ColReader) error {
+		lens = append(lens, cr.Len())
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if want := []int{100, 22}; !cmp.Equal(want, lens) {
+		t.Errorf("unexpected chunk lengths -want/+got:\n%s", cmp.Diff(want, lens))
+	}
+
+	got, err := executetest.ConvertTable(tbl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want.Normalize()
+	if !cmp.Equal(want, got) {
+		t.Errorf("unexpected table -want/+got:\n%s", cmp.Diff(want, got))
+	}
+}
+
+func TestSharedTableBuilder_AppendSelection(t *testing.T) {
+	in := &executetest.Table{
+		ColMeta: []flux.ColMeta{
+			{Label: "_time", Type: flux.TTime},
+			{Label: "_value", Type: flux.TFloat},
+			{Label: "host", Type: flux.TString},
+		},
+	}
+	for i := 0; i < 100; i++ {
+		var host interface{} = "a"
+		if i%7 == 0 {
+			host = nil
+		}
+		in.Data = append(in.Data, []interface{}{execute.Time(i), float64(i), host})
+	}
+	// Copying the table dictionary-encodes the hosts.
+	tbl, err := execute.CopyTable(in, executetest.UnlimitedAllocator)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &executetest.Table{ColMeta: in.ColMeta}
+	b := execute.NewSharedTableBuilder(in.Key(), executetest.UnlimitedAllocator)
+	for _, c := range in.Cols() {
+		if _, err := b.AddCol(c); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tbl.Do(func(cr flux.ColReader) error {
+		sel := execute.NewSelection(cr.Len())
+		for i := 0; i < cr.Len(); i++ {
+			if i%3 == 0 {
+				want.Data = append(want.Data, in.Data[i])
+				continue
+			}
+			sel.Deselect(i)
+		}
+		// The selection must have as many rows as the reader.
+		if err := b.AppendSelection(cr, nil, execute.NewSelection(0)); err == nil {
+			t.Error("expected an error appending a selection of the wrong length")
+		}
+		return b.AppendSelection(cr, nil, sel)
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if want, got := len(want.Data), b.NRows(); want != got {
+		t.Errorf("unexpected number of rows: want %d, got %d", want, got)
+	}
+	out, err := b.Table()
+	if err != nil {
+		t.Fatal(err)
+	}
+	b.ClearData()
+
+	got, err := executetest.ConvertTable(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want.Normalize()
+	if !cmp.Equal(want, got) {
+		t.Errorf("unexpected table -want/+got:\n%s", cmp.Diff(want, got))
+	}
+}
+
+func TestSharedTableBuilder_Allocator(t *testing.T) {
+	alloc := &memory.Allocator{}
+	cols := []flux.ColMeta{{Label: "_value", Type: flux.TInt}}
+	key := execute.NewGroupKey(nil, nil)
+
+	src := execute.NewColListTableBuilder(key, alloc)
+	if _, err := src.AddCol(cols[0]); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 1000; i++ {
+		if err := src.AppendInt(0, int64(i)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	in, err := src.Table()
+	if err != nil {
+		t.Fatal(err)
+	}
+	src.ClearData()
+	in.RefCount(1)
+	allocated := alloc.Allocated()
+
+	b := execute.NewSharedTableBuilder(key, alloc)
+	if _, err := b.AddCol(cols[0]); err != nil {
+		t.Fatal(err)
+	}
+	if err := in.Do(func(cr flux.ColReader) error {
+		return b.AppendColReader(cr, nil)
+	}); err != nil {
+		t.Fatal(err)
+	}
+	out, err := b.Table()
+	if err != nil {
+		t.Fatal(err)
+	}
+	b.ClearData()
+	out.RefCount(1)
+
+	// The shared array is accounted for once, until both tables have released it.
+	if got := alloc.Allocated(); got != allocated {
+		t.Errorf("unexpected allocated memory after sharing: want %d, got %d", allocated, got)
+	}
+	in.RefCount(-1)
+	if got := alloc.Allocated(); got != allocated {
+		t.Errorf("unexpected allocated memory after releasing the input table: want %d, got %d", allocated, got)
+	}
+	out.RefCount(-1)
+	if got := alloc.Allocated(); got >= allocated {
+		t.Errorf("expected the shared array to be freed, allocated memory is still %d", got)
+	}
+}
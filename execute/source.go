@@ -3,6 +3,7 @@ package execute
 import (
 	"context"
 	"fmt"
+	"sort"
 
 	"github.com/influxdata/flux/plan"
 )
@@ -27,3 +28,15 @@ func RegisterSource(k plan.ProcedureKind, c CreateNewPlannerSource) {
 	}
 	procedureToSource[k] = c
 }
+
+// SourceKinds returns the sorted procedure kinds of the registered sources.
+func SourceKinds() []plan.ProcedureKind {
+	kinds := make([]plan.ProcedureKind, 0, len(procedureToSource))
+	for k := range procedureToSource {
+		kinds = append(kinds, k)
+	}
+	sort.Slice(kinds, func(i, j int) bool {
+		return kinds[i] < kinds[j]
+	})
+	return kinds
+}
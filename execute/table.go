@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"sort"
+	"sync"
 	"sync/atomic"
 
 	"github.com/apache/arrow/go/arrow/array"
@@ -20,6 +21,7 @@ const (
 	DefaultStopColLabel  = "_stop"
 	DefaultTimeColLabel  = "_time"
 	DefaultValueColLabel = "_value"
+	DefaultFieldColLabel = "_field"
 )
 
 func GroupKeyForRowOn(i int, cr flux.ColReader, on map[string]bool) flux.GroupKey {
@@ -208,6 +210,11 @@ func AppendCol(bj, cj int, cr flux.ColReader, builder TableBuilder) error {
 	case flux.TFloat:
 		return builder.AppendFloats(bj, cr.Floats(cj))
 	case flux.TString:
+		if d := Dictionary(cr, cj); d != nil {
+			if db, ok := builder.(dictionaryAppender); ok {
+				return db.AppendDictionary(bj, d)
+			}
+		}
 		return builder.AppendStrings(bj, cr.Strings(cj))
 	case flux.TTime:
 		return builder.AppendTimes(bj, cr.Times(cj))
@@ -402,10 +409,11 @@ func ValueForRow(cr flux.ColReader, i, j int) values.Value {
 	t := cr.Cols()[j].Type
 	switch t {
 	case flux.TString:
-		if cr.Strings(j).IsNull(i) {
+		vs := StringColumn(cr, j)
+		if vs.IsNull(i) {
 			return values.NewNull(semantic.String)
 		}
-		return values.NewString(cr.Strings(j).ValueString(i))
+		return values.NewString(vs.ValueString(i))
 	case flux.TInt:
 		if cr.Ints(j).IsNull(i) {
 			return values.NewNull(semantic.Int)
@@ -944,6 +952,30 @@ func (b *ColListTableBuilder) AppendStrings(j int, vs *array.Binary) error {
 	return nil
 }
 
+// AppendDictionary appends the values of a dictionary-encoded array to column j, without decoding it.
+func (b *ColListTableBuilder) AppendDictionary(j int, vs *arrow.Dictionary) error {
+	if err := b.checkCol(j, flux.TString); err != nil {
+		return err
+	}
+	col := b.cols[j].(*stringColumnBuilder)
+	for i := 0; i < vs.Len(); i++ {
+		if vs.IsNull(i) {
+			if err := b.AppendNil(j); err != nil {
+				return err
+			}
+		} else if err := b.AppendString(j, vs.ValueString(i)); err != nil {
+			return err
+		}
+	}
+	b.nrows = len(col.data)
+	return nil
+}
+
+// dictionaryAppender is a TableBuilder that can append dictionary-encoded arrays.
+type dictionaryAppender interface {
+	AppendDictionary(j int, vs *arrow.Dictionary) error
+}
+
 func (b *ColListTableBuilder) GrowStrings(j, n int) error {
 	if err := b.checkCol(j, flux.TString); err != nil {
 		return err
@@ -1244,6 +1276,14 @@ func (b *ColListTableBuilder) ClearData() {
 	b.nrows = 0
 }
 
+// release returns the memory of the columns to the pool of the query
+// once the builder is no longer used.
+func (b *ColListTableBuilder) release() {
+	for _, c := range b.cols {
+		c.release()
+	}
+}
+
 func (b *ColListTableBuilder) Sort(cols []string, desc bool) {
 	colIdxs := make([]int, len(cols))
 	for i, label := range cols {
@@ -1322,7 +1362,15 @@ func (t *ColListTable) Floats(j int) *array.Float64 {
 func (t *ColListTable) Strings(j int) *array.Binary {
 	meta := t.colMeta[j]
 	CheckColType(meta, flux.TString)
-	return t.cols[j].(*stringColumn).data
+	return t.cols[j].(*stringColumn).strings()
+}
+
+// Dictionary returns the values of string column j if they are dictionary-encoded.
+// The string columns whose values repeat enough are dictionary-encoded.
+func (t *ColListTable) Dictionary(j int) *arrow.Dictionary {
+	meta := t.colMeta[j]
+	CheckColType(meta, flux.TString)
+	return t.cols[j].(*stringColumn).dict
 }
 func (t *ColListTable) Times(j int) *array.Int64 {
 	CheckColType(t.colMeta[j], flux.TTime)
@@ -1380,9 +1428,10 @@ func (c colListTableSorter) Len() int {
 }
 
 func (c colListTableSorter) Less(x int, y int) (less bool) {
-	var hasNil bool
+	var hasNil, differ bool
 	for _, j := range c.cols {
 		if !c.b.cols[j].Equal(x, y) {
+			differ = true
 			less = c.b.cols[j].Less(x, y)
 			// The Less function for an individual column always
 			// considers nil to be a lesser value, but when we
@@ -1395,7 +1444,8 @@ func (c colListTableSorter) Less(x int, y int) (less bool) {
 			break
 		}
 	}
-	if c.desc && !hasNil {
+	// Rows with equal values are never less than each other, in either order.
+	if c.desc && differ && !hasNil {
 		less = !less
 	}
 	return
@@ -1416,6 +1466,9 @@ type column interface {
 type columnBuilder interface {
 	Meta() flux.ColMeta
 	Clear()
+	// release returns the memory of the column to the pool of the query.
+	// The column must not be used afterwards.
+	release()
 	Copy() column
 	Len() int
 	IsNil(i int) bool
@@ -1520,6 +1573,11 @@ func (c *boolColumnBuilder) Clear() {
 	c.data = c.data[0:0]
 }
 
+func (c *boolColumnBuilder) release() {
+	c.alloc.releaseBools(c.data)
+	c.data = nil
+}
+
 func (c *boolColumnBuilder) Copy() column {
 	var data *array.Boolean
 	if len(c.nils) > 0 {
@@ -1601,6 +1659,11 @@ func (c *intColumnBuilder) Clear() {
 	c.data = c.data[0:0]
 }
 
+func (c *intColumnBuilder) release() {
+	c.alloc.releaseInts(c.data)
+	c.data = nil
+}
+
 func (c *intColumnBuilder) Copy() column {
 	var data *array.Int64
 	if len(c.nils) > 0 {
@@ -1679,6 +1742,11 @@ func (c *uintColumnBuilder) Clear() {
 	c.data = c.data[0:0]
 }
 
+func (c *uintColumnBuilder) release() {
+	c.alloc.releaseUInts(c.data)
+	c.data = nil
+}
+
 func (c *uintColumnBuilder) Copy() column {
 	var data *array.Uint64
 	if len(c.nils) > 0 {
@@ -1758,6 +1826,11 @@ func (c *floatColumnBuilder) Clear() {
 	c.data = c.data[0:0]
 }
 
+func (c *floatColumnBuilder) release() {
+	c.alloc.releaseFloats(c.data)
+	c.data = nil
+}
+
 func (c *floatColumnBuilder) Copy() column {
 	var data *array.Float64
 	if len(c.nils) > 0 {
@@ -1803,27 +1876,55 @@ func (c *floatColumnBuilder) Swap(i, j int) {
 	c.data[i], c.data[j] = c.data[j], c.data[i]
 }
 
+// stringColumn holds either plain or dictionary-encoded values.
+// The dictionary-encoded values are decoded the first time they are read as plain values.
 type stringColumn struct {
 	flux.ColMeta
 	data *array.Binary
+	dict *arrow.Dictionary
+
+	alloc  *memory.Allocator
+	decode sync.Once
 }
 
 func (c *stringColumn) Meta() flux.ColMeta {
 	return c.ColMeta
 }
 
+func (c *stringColumn) strings() *array.Binary {
+	if c.dict != nil {
+		c.decode.Do(func() {
+			c.data = c.dict.Decode(c.alloc)
+		})
+	}
+	return c.data
+}
+
 func (c *stringColumn) Clear() {
 	if c.data != nil {
 		c.data.Release()
 		c.data = nil
 	}
+	if c.dict != nil {
+		c.dict.Release()
+		c.dict = nil
+	}
 }
 
 func (c *stringColumn) Copy() column {
+	if c.dict != nil {
+		c.dict.Retain()
+		return &stringColumn{
+			ColMeta: c.ColMeta,
+			dict:    c.dict,
+			alloc:   c.alloc,
+		}
+	}
 	c.data.Retain()
 	return &stringColumn{
 		ColMeta: c.ColMeta,
 		data:    c.data,
+		alloc:   c.alloc,
 	}
 }
 
@@ -1837,8 +1938,19 @@ func (c *stringColumnBuilder) Clear() {
 	c.data = c.data[0:0]
 }
 
+func (c *stringColumnBuilder) release() {
+	c.data = nil
+}
+
 func (c *stringColumnBuilder) Copy() column {
-	var data *array.Binary
+	col := &stringColumn{
+		ColMeta: c.ColMeta,
+		alloc:   c.alloc.Allocator,
+	}
+	if dict := c.dictionary(); dict != nil {
+		col.dict = dict
+		return col
+	}
 	if len(c.nils) > 0 {
 		b := arrow.NewStringBuilder(c.alloc.Allocator)
 		b.Reserve(len(c.data))
@@ -1849,18 +1961,37 @@ func (c *stringColumnBuilder) Copy() column {
 			}
 			b.AppendString(v)
 		}
-		data = b.NewBinaryArray()
+		col.data = b.NewBinaryArray()
 		b.Release()
 	} else {
-		data = arrow.NewString(c.data, c.alloc.Allocator)
-	}
-	col := &stringColumn{
-		ColMeta: c.ColMeta,
-		data:    data,
+		col.data = arrow.NewString(c.data, c.alloc.Allocator)
 	}
 	return col
 }
 
+// dictionary returns the dictionary-encoded values of the column,
+// or nil if the values do not repeat enough for the encoding to save memory.
+func (c *stringColumnBuilder) dictionary() *arrow.Dictionary {
+	maxCardinality := len(c.data) / dictionaryMinRepetition
+	if maxCardinality == 0 {
+		return nil
+	}
+	b := arrow.NewDictionaryBuilder(c.alloc.Allocator)
+	defer b.Release()
+	b.Reserve(len(c.data))
+	for i, v := range c.data {
+		if c.nils[i] {
+			b.AppendNull()
+			continue
+		}
+		b.AppendString(v)
+		if b.Cardinality() > maxCardinality {
+			return nil
+		}
+	}
+	return b.NewDictionary()
+}
+
 func (c *stringColumnBuilder) Len() int {
 	return len(c.data)
 }
@@ -1915,6 +2046,11 @@ func (c *timeColumnBuilder) Clear() {
 	c.data = c.data[0:0]
 }
 
+func (c *timeColumnBuilder) release() {
+	c.alloc.releaseTimes(c.data)
+	c.data = nil
+}
+
 func (c *timeColumnBuilder) Copy() column {
 	b := arrow.NewIntBuilder(c.alloc.Allocator)
 	b.Reserve(len(c.data))
@@ -1957,6 +2093,7 @@ func (c *timeColumnBuilder) Swap(i, j int) {
 type TableBuilderCache interface {
 	// TableBuilder returns an existing or new TableBuilder for the given meta data.
 	// The boolean return value indicates if TableBuilder is new.
+	// A new TableBuilder retains a copy of a ScratchGroupKey.
 	TableBuilder(key flux.GroupKey) (TableBuilder, bool)
 	ForEachBuilder(f func(flux.GroupKey, TableBuilder))
 }
@@ -2005,6 +2142,7 @@ func (d *tableBuilderCache) lookupState(key flux.GroupKey) (tableState, bool) {
 func (d *tableBuilderCache) TableBuilder(key flux.GroupKey) (TableBuilder, bool) {
 	b, ok := d.lookupState(key)
 	if !ok {
+		key = retainGroupKey(key)
 		builder := NewColListTableBuilder(key, d.alloc)
 		t := NewTriggerFromSpec(d.triggerSpec)
 		b = tableState{
@@ -2033,6 +2171,9 @@ func (d *tableBuilderCache) ExpireTable(key flux.GroupKey) {
 	b, ok := d.tables.Delete(key)
 	if ok {
 		b.(tableState).builder.ClearData()
+		if builder, ok := b.(tableState).builder.(*ColListTableBuilder); ok {
+			builder.release()
+		}
 	}
 }
 
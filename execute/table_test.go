@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/influxdata/flux"
 	"github.com/influxdata/flux/execute"
 	"github.com/influxdata/flux/execute/executetest"
@@ -253,3 +254,131 @@ func TestColListTable_SetNil(t *testing.T) {
 		t.Fatalf("unexpected error: %s", err)
 	}
 }
+
+func TestColListTable_Dictionary(t *testing.T) {
+	key := execute.NewGroupKey(nil, nil)
+	tb := execute.NewColListTableBuilder(key, &memory.Allocator{})
+
+	// The values of the tag are repeated, the values of the message are distinct.
+	tag, _ := tb.AddCol(flux.ColMeta{Label: "host", Type: flux.TString})
+	msg, _ := tb.AddCol(flux.ColMeta{Label: "message", Type: flux.TString})
+	hosts := []string{"a", "b", "a", "a", "b", "a"}
+	for i, host := range hosts {
+		_ = tb.AppendString(tag, host)
+		_ = tb.AppendString(msg, fmt.Sprintf("message %d", i))
+	}
+	_ = tb.AppendNil(tag)
+	_ = tb.AppendString(msg, "no host")
+
+	tbl, err := tb.Table()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if err := tbl.Do(func(cr flux.ColReader) error {
+		if execute.Dictionary(cr, msg) != nil {
+			t.Error("distinct values should not be dictionary-encoded")
+		}
+		d := execute.Dictionary(cr, tag)
+		if d == nil {
+			t.Fatal("repeated values should be dictionary-encoded")
+		}
+		if got, want := d.Values().Len(), 2; got != want {
+			t.Errorf("unexpected number of distinct values -want/+got\n\t- %d\n\t+ %d", want, got)
+		}
+
+		// The values are decoded when they are read as plain strings.
+		vs := cr.Strings(tag)
+		if got, want := vs.Len(), len(hosts)+1; got != want {
+			t.Fatalf("unexpected length -want/+got\n\t- %d\n\t+ %d", want, got)
+		}
+		for i, host := range hosts {
+			if got := vs.ValueString(i); got != host {
+				t.Errorf("unexpected value at row %d -want/+got\n\t- %s\n\t+ %s", i, host, got)
+			}
+			if got := execute.ValueForRow(cr, i, tag).Str(); got != host {
+				t.Errorf("unexpected value for row %d -want/+got\n\t- %s\n\t+ %s", i, host, got)
+			}
+		}
+		if !vs.IsNull(len(hosts)) || !d.IsNull(len(hosts)) {
+			t.Error("last value should be null")
+		}
+		return nil
+	}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
+
+func TestTableBuilderCache_Pool(t *testing.T) {
+	alloc := &memory.Allocator{Pool: memory.NewPool(memory.DefaultPoolLimit)}
+	cache := execute.NewTableBuilderCache(alloc)
+	cache.SetTriggerSpec(execute.DefaultTriggerSpec)
+	key := execute.NewGroupKey(nil, nil)
+
+	b, _ := cache.TableBuilder(key)
+	if _, err := b.AddCol(flux.ColMeta{Label: "_value", Type: flux.TInt}); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 1000; i++ {
+		if err := b.AppendInt(0, int64(i)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	tbl, err := cache.Table(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cache.ExpireTable(key)
+	if got := alloc.Pool.Retained(); got == 0 {
+		t.Fatal("expected the memory of the expired builder to be retained by the pool")
+	}
+
+	// The table does not share memory with the builder.
+	want := &executetest.Table{ColMeta: []flux.ColMeta{{Label: "_value", Type: flux.TInt}}}
+	for i := 0; i < 1000; i++ {
+		want.Data = append(want.Data, []interface{}{int64(i)})
+	}
+	b, _ = cache.TableBuilder(key)
+	if _, err := b.AddCol(flux.ColMeta{Label: "_value", Type: flux.TInt}); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 1000; i++ {
+		if err := b.AppendInt(0, -1); err != nil {
+			t.Fatal(err)
+		}
+	}
+	got, err := executetest.ConvertTable(tbl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want.Normalize()
+	if !cmp.Equal(want, got) {
+		t.Errorf("unexpected table -want/+got:\n%s", cmp.Diff(want, got))
+	}
+}
+
+func TestTableBuilderCache_ScratchGroupKey(t *testing.T) {
+	cache := execute.NewTableBuilderCache(executetest.UnlimitedAllocator)
+	cache.SetTriggerSpec(execute.DefaultTriggerSpec)
+	col := flux.ColMeta{Label: "t0", Type: flux.TString}
+
+	var key execute.ScratchGroupKey
+	for i, tt := range []struct {
+		value   string
+		created bool
+	}{
+		{value: "a", created: true},
+		{value: "b", created: true},
+		{value: "a", created: false},
+	} {
+		key.Reset()
+		key.Append(col, values.NewString(tt.value))
+		b, created := cache.TableBuilder(&key)
+		if created != tt.created {
+			t.Errorf("%d: unexpected created for %q: want %v, got %v", i, tt.value, tt.created, created)
+		}
+		if got := b.Key().ValueString(0); got != tt.value {
+			t.Errorf("%d: unexpected key value: want %q, got %q", i, tt.value, got)
+		}
+	}
+}
@@ -32,6 +32,9 @@ type Administration interface {
 	Parents() []DatasetID
 
 	Dependencies() Dependencies
+
+	// Warnf reports a non-fatal diagnostic on every result that depends on the transformation.
+	Warnf(format string, a ...interface{})
 }
 
 // Dependencies represents the provided dependencies to the execution environment.
@@ -5,6 +5,8 @@ import (
 	"sync/atomic"
 
 	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/ast"
+	"github.com/pkg/errors"
 )
 
 type Transport interface {
@@ -19,6 +21,11 @@ type consecutiveTransport struct {
 
 	t        Transformation
 	messages MessageQueue
+	progress *operatorProgress
+	// loc is the location in the script of the call that produced the transformation, or nil.
+	loc *ast.SourceLocation
+	// recoverPanics makes a panic of the transformation fail it like an error.
+	recoverPanics bool
 
 	finished chan struct{}
 	errMu    sync.Mutex
@@ -28,10 +35,12 @@ type consecutiveTransport struct {
 	inflight       int32
 }
 
-func newConsecutiveTransport(dispatcher Dispatcher, t Transformation) *consecutiveTransport {
+func newConsecutiveTransport(dispatcher Dispatcher, t Transformation, progress *operatorProgress, loc *ast.SourceLocation) *consecutiveTransport {
 	return &consecutiveTransport{
 		dispatcher: dispatcher,
 		t:          t,
+		progress:   progress,
+		loc:        loc,
 		// TODO(nathanielc): Have planner specify message queue initial buffer size.
 		messages: newMessageQueue(64),
 		finished: make(chan struct{}),
@@ -153,7 +162,16 @@ PROCESS:
 	i := 0
 	for m := t.messages.Pop(); m != nil; m = t.messages.Pop() {
 		atomic.AddInt32(&t.inflight, -1)
-		if f, err := processMessage(t.t, m); err != nil || f {
+		start := t.progress.start()
+		if pm, ok := m.(ProcessMsg); ok {
+			t.progress.count(pm.Table())
+		}
+		f, err := t.process(m)
+		t.progress.stop(start)
+		if err != nil {
+			err = t.locate(err)
+		}
+		if err != nil || f {
 			// Set the error if there was any
 			t.setErr(err)
 
@@ -188,6 +206,29 @@ PROCESS:
 	}
 }
 
+// locate adds the node and the location in the script of the call that produced it
+// to an error of the transformation, so that it can be traced back to the script.
+func (t *consecutiveTransport) locate(err error) error {
+	if t.loc == nil || !t.loc.IsValid() {
+		return errors.Wrapf(err, "%s", t.progress.node)
+	}
+	return errors.Wrapf(err, "%s at %v", t.progress.node, *t.loc)
+}
+
+// process processes the message with the transformation.
+// If the transport recovers panics, a panic of the transformation is returned as an error,
+// so that only the transformations downstream of it fail.
+func (t *consecutiveTransport) process(m Message) (finished bool, err error) {
+	if t.recoverPanics {
+		defer func() {
+			if e := recover(); e != nil {
+				finished, err = false, panicError(e)
+			}
+		}()
+	}
+	return processMessage(t.t, m)
+}
+
 // processMessage processes the message on t.
 // The return value is true if the message was a FinishMsg.
 func processMessage(t Transformation, m Message) (finished bool, err error) {
@@ -27,7 +27,7 @@ func NewTriggerFromSpec(spec flux.TriggerSpec) Trigger {
 	switch s := spec.(type) {
 	case flux.AfterWatermarkTriggerSpec:
 		return &afterWatermarkTrigger{
-			allowedLateness: Duration(s.AllowedLateness),
+			allowedLateness: s.AllowedLateness,
 		}
 	case flux.RepeatedTriggerSpec:
 		return &repeatedlyForever{
@@ -35,7 +35,7 @@ func NewTriggerFromSpec(spec flux.TriggerSpec) Trigger {
 		}
 	case flux.AfterProcessingTimeTriggerSpec:
 		return &afterProcessingTimeTrigger{
-			duration: Duration(s.Duration),
+			duration: s.Duration,
 		}
 	case flux.AfterAtLeastCountTriggerSpec:
 		return &afterAtLeastCount{
@@ -65,7 +65,7 @@ func (t *afterWatermarkTrigger) Triggered(c TriggerContext) bool {
 		return false
 	}
 	stop := c.Table.Key.ValueTime(timeIdx)
-	if c.Watermark >= stop+Time(t.allowedLateness) {
+	if c.Watermark >= stop.Add(t.allowedLateness) {
 		t.finished = true
 	}
 	return c.Watermark >= stop
@@ -104,7 +104,7 @@ type afterProcessingTimeTrigger struct {
 func (t *afterProcessingTimeTrigger) Triggered(c TriggerContext) bool {
 	if !t.triggerTimeSet {
 		t.triggerTimeSet = true
-		t.triggerTime = c.CurrentProcessingTime + Time(t.duration)
+		t.triggerTime = c.CurrentProcessingTime.Add(t.duration)
 	}
 	t.current = c.CurrentProcessingTime
 	return t.current >= t.triggerTime
@@ -0,0 +1,82 @@
+package execute
+
+import (
+	"context"
+	"sync"
+
+	"github.com/influxdata/flux"
+)
+
+// The keys of the metadata that reports the data scanned by the sources of a query.
+// They are stable, so that the usage of queries can be billed from their statistics.
+const (
+	BytesScannedMetadataKey  = "usage/bytes_scanned"
+	SeriesScannedMetadataKey = "usage/series_scanned"
+	PointsScannedMetadataKey = "usage/points_scanned"
+)
+
+// Usage is the data that sources scanned to produce their tables.
+// The bytes are the size of the data as the source read it, such as the bytes of a CSV document,
+// the series are the tables and the points are the rows of those tables.
+type Usage struct {
+	BytesScanned  int64
+	SeriesScanned int64
+	PointsScanned int64
+}
+
+// Add returns the sum of u and other.
+func (u Usage) Add(other Usage) Usage {
+	return Usage{
+		BytesScanned:  u.BytesScanned + other.BytesScanned,
+		SeriesScanned: u.SeriesScanned + other.SeriesScanned,
+		PointsScanned: u.PointsScanned + other.PointsScanned,
+	}
+}
+
+// Metadata reports the usage under the keys BytesScannedMetadataKey,
+// SeriesScannedMetadataKey and PointsScannedMetadataKey.
+func (u Usage) Metadata() flux.Metadata {
+	md := make(flux.Metadata, 3)
+	md.Add(BytesScannedMetadataKey, u.BytesScanned)
+	md.Add(SeriesScannedMetadataKey, u.SeriesScanned)
+	md.Add(PointsScannedMetadataKey, u.PointsScanned)
+	return md
+}
+
+// UsageRecorder adds up the usage that the sources of the executed queries report.
+type UsageRecorder struct {
+	mu    sync.Mutex
+	usage Usage
+}
+
+func NewUsageRecorder() *UsageRecorder {
+	return new(UsageRecorder)
+}
+
+// Usage returns the usage reported so far.
+func (r *UsageRecorder) Usage() Usage {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.usage
+}
+
+func (r *UsageRecorder) add(u Usage) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.usage = r.usage.Add(u)
+}
+
+// WithUsageRecorder returns a context with which the usage reported by the sources of the executed queries
+// is recorded by r. A nil recorder disables the recording.
+func WithUsageRecorder(ctx context.Context, r *UsageRecorder) context.Context {
+	return context.WithValue(ctx, usageRecorderKey, r)
+}
+
+// ReportUsage records the data that a source scanned with the recorder of the context, if it has one.
+// Sources call it with the context of their execution, as often as they like,
+// and readers that sources delegate to, such as a StorageReader, may call it too.
+func ReportUsage(ctx context.Context, u Usage) {
+	if r, _ := ctx.Value(usageRecorderKey).(*UsageRecorder); r != nil {
+		r.add(u)
+	}
+}
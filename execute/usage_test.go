@@ -0,0 +1,30 @@
+package execute_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+)
+
+func TestReportUsage(t *testing.T) {
+	// Usage reported without a recorder is ignored.
+	execute.ReportUsage(context.Background(), execute.Usage{BytesScanned: 1})
+
+	r := execute.NewUsageRecorder()
+	ctx := execute.WithUsageRecorder(context.Background(), r)
+	execute.ReportUsage(ctx, execute.Usage{BytesScanned: 100})
+	execute.ReportUsage(ctx, execute.Usage{SeriesScanned: 1, PointsScanned: 10})
+	execute.ReportUsage(ctx, execute.Usage{SeriesScanned: 1, PointsScanned: 5})
+
+	want := flux.Metadata{
+		execute.BytesScannedMetadataKey:  {int64(100)},
+		execute.SeriesScannedMetadataKey: {int64(2)},
+		execute.PointsScannedMetadataKey: {int64(15)},
+	}
+	if got := r.Usage().Metadata(); !cmp.Equal(want, got) {
+		t.Errorf("unexpected metadata -want/+got\n%s", cmp.Diff(want, got))
+	}
+}
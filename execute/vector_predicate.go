@@ -0,0 +1,311 @@
+package execute
+
+import (
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/ast"
+	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/flux/values"
+)
+
+// VectorPredicate is a row predicate that is evaluated on the Arrow arrays of the columns
+// of a column reader at once, instead of calling the predicate function once per row.
+//
+// It is compiled from predicates that are conjunctions of comparisons of a column
+// with a literal, such as (r) => r._measurement == "cpu" and r._value > 0.5.
+// Like a RowPredicateFn, the rows whose value is null for a referenced column do not pass.
+type VectorPredicate struct {
+	comparisons []vectorComparison
+}
+
+// vectorComparison compares the values of a column with a literal.
+type vectorComparison struct {
+	label string
+	op    ast.OperatorKind
+	value values.Value
+}
+
+// NewVectorPredicate compiles the predicate function into a VectorPredicate,
+// and reports false if the function is not a conjunction of comparisons of a column with a literal.
+func NewVectorPredicate(fn *semantic.FunctionExpression) (*VectorPredicate, bool) {
+	if fn == nil || fn.Block == nil || fn.Block.Parameters == nil || len(fn.Block.Parameters.List) != 1 {
+		return nil, false
+	}
+	p := &VectorPredicate{}
+	if !p.compile(fn.Block.Parameters.List[0].Key.Name, fn.Block.Body) {
+		return nil, false
+	}
+	return p, true
+}
+
+func (p *VectorPredicate) compile(record string, n semantic.Node) bool {
+	switch e := n.(type) {
+	case *semantic.LogicalExpression:
+		return e.Operator == ast.AndOperator && p.compile(record, e.Left) && p.compile(record, e.Right)
+	case *semantic.BinaryExpression:
+		op := e.Operator
+		label, ok := columnReference(record, e.Left)
+		lit := e.Right
+		if !ok {
+			if label, ok = columnReference(record, e.Right); !ok {
+				return false
+			}
+			lit = e.Left
+			if op, ok = flippedOperators[op]; !ok {
+				return false
+			}
+		}
+		v, ok := literalValue(lit)
+		if !ok || !comparableWith(op, v.Type()) {
+			return false
+		}
+		p.comparisons = append(p.comparisons, vectorComparison{label: label, op: op, value: v})
+		return true
+	}
+	return false
+}
+
+// flippedOperators maps the comparison operators to those that compare their operands the other way around.
+var flippedOperators = map[ast.OperatorKind]ast.OperatorKind{
+	ast.EqualOperator:            ast.EqualOperator,
+	ast.NotEqualOperator:         ast.NotEqualOperator,
+	ast.LessThanOperator:         ast.GreaterThanOperator,
+	ast.LessThanEqualOperator:    ast.GreaterThanEqualOperator,
+	ast.GreaterThanOperator:      ast.LessThanOperator,
+	ast.GreaterThanEqualOperator: ast.LessThanEqualOperator,
+}
+
+// comparableWith reports whether values of the type can be compared with the operator.
+func comparableWith(op ast.OperatorKind, typ semantic.Type) bool {
+	if _, ok := flippedOperators[op]; !ok {
+		return false
+	}
+	if typ == semantic.Bool {
+		return op == ast.EqualOperator || op == ast.NotEqualOperator
+	}
+	return true
+}
+
+// columnReference returns the label of the column that the expression references,
+// if it is a member of the record.
+func columnReference(record string, e semantic.Expression) (string, bool) {
+	m, ok := e.(*semantic.MemberExpression)
+	if !ok {
+		return "", false
+	}
+	id, ok := m.Object.(*semantic.IdentifierExpression)
+	if !ok || id.Name != record {
+		return "", false
+	}
+	return m.Property, true
+}
+
+func literalValue(e semantic.Expression) (values.Value, bool) {
+	switch l := e.(type) {
+	case *semantic.BooleanLiteral:
+		return values.NewBool(l.Value), true
+	case *semantic.IntegerLiteral:
+		return values.NewInt(l.Value), true
+	case *semantic.UnsignedIntegerLiteral:
+		return values.NewUInt(l.Value), true
+	case *semantic.FloatLiteral:
+		return values.NewFloat(l.Value), true
+	case *semantic.StringLiteral:
+		return values.NewString(l.Value), true
+	case *semantic.DateTimeLiteral:
+		return values.NewTime(values.ConvertTime(l.Value)), true
+	}
+	return nil, false
+}
+
+// Select returns the selection of the rows of cr that pass the predicate.
+// It reports false if a column the predicate references is missing,
+// or cannot be compared with its literal without converting its values,
+// in which case the predicate must be evaluated row by row.
+func (p *VectorPredicate) Select(cr flux.ColReader) (*Selection, bool) {
+	cols := cr.Cols()
+	idxs := make([]int, len(p.comparisons))
+	for k, c := range p.comparisons {
+		j := ColIdx(c.label, cols)
+		if j < 0 || !c.supports(cols[j].Type) {
+			return nil, false
+		}
+		idxs[k] = j
+	}
+	sel := NewSelection(cr.Len())
+	for k, c := range p.comparisons {
+		c.apply(cr, idxs[k], sel)
+	}
+	return sel, true
+}
+
+// supports reports whether the comparison can be applied to a column of the type.
+// Ints and floats are compared as floats, like the binary operators of Flux do.
+func (c vectorComparison) supports(typ flux.ColType) bool {
+	switch c.value.Type() {
+	case semantic.Int, semantic.Float:
+		return typ == flux.TInt || typ == flux.TFloat
+	case semantic.UInt:
+		return typ == flux.TUInt
+	case semantic.String:
+		return typ == flux.TString
+	case semantic.Bool:
+		return typ == flux.TBool
+	case semantic.Time:
+		return typ == flux.TTime
+	}
+	return false
+}
+
+// apply deselects the rows of cr whose value in column j is null or does not pass the comparison.
+func (c vectorComparison) apply(cr flux.ColReader, j int, sel *Selection) {
+	switch cr.Cols()[j].Type {
+	case flux.TInt:
+		vs := cr.Ints(j)
+		if c.value.Type() == semantic.Float {
+			lit := c.value.Float()
+			for i := 0; i < vs.Len(); i++ {
+				if vs.IsNull(i) || !compareFloats(c.op, float64(vs.Value(i)), lit) {
+					sel.Deselect(i)
+				}
+			}
+			return
+		}
+		lit := c.value.Int()
+		for i, v := range vs.Int64Values() {
+			if vs.IsNull(i) || !compareInts(c.op, v, lit) {
+				sel.Deselect(i)
+			}
+		}
+	case flux.TFloat:
+		vs := cr.Floats(j)
+		var lit float64
+		if c.value.Type() == semantic.Int {
+			lit = float64(c.value.Int())
+		} else {
+			lit = c.value.Float()
+		}
+		for i, v := range vs.Float64Values() {
+			if vs.IsNull(i) || !compareFloats(c.op, v, lit) {
+				sel.Deselect(i)
+			}
+		}
+	case flux.TUInt:
+		vs := cr.UInts(j)
+		lit := c.value.UInt()
+		for i, v := range vs.Uint64Values() {
+			if vs.IsNull(i) || !compareUInts(c.op, v, lit) {
+				sel.Deselect(i)
+			}
+		}
+	case flux.TTime:
+		vs := cr.Times(j)
+		lit := int64(c.value.Time())
+		for i, v := range vs.Int64Values() {
+			if vs.IsNull(i) || !compareInts(c.op, v, lit) {
+				sel.Deselect(i)
+			}
+		}
+	case flux.TBool:
+		vs := cr.Bools(j)
+		lit := c.value.Bool()
+		for i := 0; i < vs.Len(); i++ {
+			if vs.IsNull(i) || (vs.Value(i) == lit) != (c.op == ast.EqualOperator) {
+				sel.Deselect(i)
+			}
+		}
+	case flux.TString:
+		lit := c.value.Str()
+		// Compare the distinct values of a dictionary-encoded column only once.
+		if d := Dictionary(cr, j); d != nil {
+			dict := d.Values()
+			pass := make([]bool, dict.Len())
+			for k := range pass {
+				pass[k] = compareStrings(c.op, dict.ValueString(k), lit)
+			}
+			for i := 0; i < d.Len(); i++ {
+				if d.IsNull(i) || !pass[d.Index(i)] {
+					sel.Deselect(i)
+				}
+			}
+			return
+		}
+		vs := cr.Strings(j)
+		for i := 0; i < vs.Len(); i++ {
+			if vs.IsNull(i) || !compareStrings(c.op, vs.ValueString(i), lit) {
+				sel.Deselect(i)
+			}
+		}
+	}
+}
+
+func compareInts(op ast.OperatorKind, l, r int64) bool {
+	switch op {
+	case ast.EqualOperator:
+		return l == r
+	case ast.NotEqualOperator:
+		return l != r
+	case ast.LessThanOperator:
+		return l < r
+	case ast.LessThanEqualOperator:
+		return l <= r
+	case ast.GreaterThanOperator:
+		return l > r
+	case ast.GreaterThanEqualOperator:
+		return l >= r
+	}
+	return false
+}
+
+func compareUInts(op ast.OperatorKind, l, r uint64) bool {
+	switch op {
+	case ast.EqualOperator:
+		return l == r
+	case ast.NotEqualOperator:
+		return l != r
+	case ast.LessThanOperator:
+		return l < r
+	case ast.LessThanEqualOperator:
+		return l <= r
+	case ast.GreaterThanOperator:
+		return l > r
+	case ast.GreaterThanEqualOperator:
+		return l >= r
+	}
+	return false
+}
+
+func compareFloats(op ast.OperatorKind, l, r float64) bool {
+	switch op {
+	case ast.EqualOperator:
+		return l == r
+	case ast.NotEqualOperator:
+		return l != r
+	case ast.LessThanOperator:
+		return l < r
+	case ast.LessThanEqualOperator:
+		return l <= r
+	case ast.GreaterThanOperator:
+		return l > r
+	case ast.GreaterThanEqualOperator:
+		return l >= r
+	}
+	return false
+}
+
+func compareStrings(op ast.OperatorKind, l, r string) bool {
+	switch op {
+	case ast.EqualOperator:
+		return l == r
+	case ast.NotEqualOperator:
+		return l != r
+	case ast.LessThanOperator:
+		return l < r
+	case ast.LessThanEqualOperator:
+		return l <= r
+	case ast.GreaterThanOperator:
+		return l > r
+	case ast.GreaterThanEqualOperator:
+		return l >= r
+	}
+	return false
+}
@@ -0,0 +1,202 @@
+package execute_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/ast"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/flux/semantic"
+)
+
+func TestVectorPredicate_Select(t *testing.T) {
+	member := func(property string) *semantic.MemberExpression {
+		return &semantic.MemberExpression{
+			Object:   &semantic.IdentifierExpression{Name: "r"},
+			Property: property,
+		}
+	}
+	predicate := func(body semantic.Expression) *semantic.FunctionExpression {
+		return &semantic.FunctionExpression{
+			Block: &semantic.FunctionBlock{
+				Parameters: &semantic.FunctionParameters{
+					List: []*semantic.FunctionParameter{{Key: &semantic.Identifier{Name: "r"}}},
+				},
+				Body: body,
+			},
+		}
+	}
+	// Copying the table dictionary-encodes the repeated hosts.
+	tbl, err := execute.CopyTable(&executetest.Table{
+		ColMeta: []flux.ColMeta{
+			{Label: "_time", Type: flux.TTime},
+			{Label: "_value", Type: flux.TFloat},
+			{Label: "count", Type: flux.TInt},
+			{Label: "host", Type: flux.TString},
+			{Label: "ok", Type: flux.TBool},
+		},
+		Data: [][]interface{}{
+			{execute.Time(1), 1.0, int64(1), "a", true},
+			{execute.Time(2), 2.0, int64(2), "b", false},
+			{execute.Time(3), nil, int64(3), "a", true},
+			{execute.Time(4), 4.0, nil, nil, nil},
+			{execute.Time(5), 5.0, int64(5), "a", false},
+		},
+	}, executetest.UnlimitedAllocator)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		name         string
+		fn           *semantic.FunctionExpression
+		notCompiled  bool
+		notSupported bool
+		want         []int
+	}{
+		{
+			name: "float",
+			fn: predicate(&semantic.BinaryExpression{
+				Operator: ast.GreaterThanEqualOperator,
+				Left:     member("_value"),
+				Right:    &semantic.FloatLiteral{Value: 2},
+			}),
+			want: []int{1, 3, 4},
+		},
+		{
+			name: "int column with float literal",
+			fn: predicate(&semantic.BinaryExpression{
+				Operator: ast.LessThanOperator,
+				Left:     member("count"),
+				Right:    &semantic.FloatLiteral{Value: 2.5},
+			}),
+			want: []int{0, 1},
+		},
+		{
+			name: "flipped",
+			fn: predicate(&semantic.BinaryExpression{
+				Operator: ast.LessThanOperator,
+				Left:     &semantic.IntegerLiteral{Value: 2},
+				Right:    member("count"),
+			}),
+			want: []int{2, 4},
+		},
+		{
+			name: "time",
+			fn: predicate(&semantic.BinaryExpression{
+				Operator: ast.GreaterThanOperator,
+				Left:     member("_time"),
+				Right:    &semantic.DateTimeLiteral{Value: time.Unix(0, 3).UTC()},
+			}),
+			want: []int{3, 4},
+		},
+		{
+			name: "dictionary and bool",
+			fn: predicate(&semantic.LogicalExpression{
+				Operator: ast.AndOperator,
+				Left: &semantic.BinaryExpression{
+					Operator: ast.EqualOperator,
+					Left:     member("host"),
+					Right:    &semantic.StringLiteral{Value: "a"},
+				},
+				Right: &semantic.BinaryExpression{
+					Operator: ast.NotEqualOperator,
+					Left:     member("ok"),
+					Right:    &semantic.BooleanLiteral{Value: false},
+				},
+			}),
+			want: []int{0, 2},
+		},
+		{
+			name: "or",
+			fn: predicate(&semantic.LogicalExpression{
+				Operator: ast.OrOperator,
+				Left: &semantic.BinaryExpression{
+					Operator: ast.EqualOperator,
+					Left:     member("host"),
+					Right:    &semantic.StringLiteral{Value: "a"},
+				},
+				Right: &semantic.BinaryExpression{
+					Operator: ast.EqualOperator,
+					Left:     member("host"),
+					Right:    &semantic.StringLiteral{Value: "b"},
+				},
+			}),
+			notCompiled: true,
+		},
+		{
+			name: "bool ordering",
+			fn: predicate(&semantic.BinaryExpression{
+				Operator: ast.LessThanOperator,
+				Left:     member("ok"),
+				Right:    &semantic.BooleanLiteral{Value: true},
+			}),
+			notCompiled: true,
+		},
+		{
+			name: "mismatched type",
+			fn: predicate(&semantic.BinaryExpression{
+				Operator: ast.EqualOperator,
+				Left:     member("host"),
+				Right:    &semantic.IntegerLiteral{Value: 1},
+			}),
+			notSupported: true,
+		},
+		{
+			name: "missing column",
+			fn: predicate(&semantic.BinaryExpression{
+				Operator: ast.EqualOperator,
+				Left:     member("region"),
+				Right:    &semantic.StringLiteral{Value: "west"},
+			}),
+			notSupported: true,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			p, ok := execute.NewVectorPredicate(tc.fn)
+			if ok == tc.notCompiled {
+				t.Fatalf("unexpected compilation result: want %v, got %v", !tc.notCompiled, ok)
+			}
+			if !ok {
+				return
+			}
+			if err := tbl.Do(func(cr flux.ColReader) error {
+				sel, ok := p.Select(cr)
+				if ok == tc.notSupported {
+					t.Fatalf("unexpected selection result: want %v, got %v", !tc.notSupported, ok)
+				}
+				if !ok {
+					return nil
+				}
+				if got := sel.Rows(); !cmp.Equal(tc.want, got) {
+					t.Errorf("unexpected selected rows -want/+got:\n%s", cmp.Diff(tc.want, got))
+				}
+				return nil
+			}); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestSelection(t *testing.T) {
+	sel := execute.NewSelection(130)
+	if got := sel.Count(); got != 130 {
+		t.Fatalf("unexpected count: want 130, got %d", got)
+	}
+	for i := 0; i < 130; i++ {
+		if i%64 != 0 && i != 129 {
+			sel.Deselect(i)
+		}
+	}
+	if want, got := []int{0, 64, 128, 129}, sel.Rows(); !cmp.Equal(want, got) {
+		t.Errorf("unexpected rows -want/+got:\n%s", cmp.Diff(want, got))
+	}
+	if sel.IsSelected(1) || !sel.IsSelected(128) {
+		t.Error("unexpected selected rows")
+	}
+}
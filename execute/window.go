@@ -1,5 +1,7 @@
 package execute
 
+import "github.com/influxdata/flux/values"
+
 type Window struct {
 	Every  Duration
 	Period Duration
@@ -8,12 +10,15 @@ type Window struct {
 
 // NewWindow creates a window with the given parameters,
 // and normalizes the offset to a small positive duration.
+// The offset is only normalized when it has the same unit as every,
+// either months or nanoseconds.
 func NewWindow(every, period, offset Duration) Window {
 	// Normalize the offset to a small positive duration
-	if offset < 0 {
-		offset += every * ((offset / -every) + 1)
-	} else if offset > every {
-		offset -= every * (offset / every)
+	switch {
+	case every.Months() == 0 && offset.Months() == 0:
+		offset = normalizeOffset(offset.Nanoseconds(), every.Nanoseconds(), false)
+	case every.Nanoseconds() == 0 && offset.Nanoseconds() == 0:
+		offset = normalizeOffset(offset.Months(), every.Months(), true)
 	}
 
 	return Window{
@@ -23,23 +28,28 @@ func NewWindow(every, period, offset Duration) Window {
 	}
 }
 
+func normalizeOffset(offset, every int64, months bool) Duration {
+	if every != 0 {
+		if offset < 0 {
+			offset += every * ((offset / -every) + 1)
+		} else if offset > every {
+			offset -= every * (offset / every)
+		}
+	}
+	if months {
+		return values.MakeDuration(offset, 0)
+	}
+	return values.MakeDuration(0, offset)
+}
+
 // GetEarliestBounds returns the bounds for the earliest window bounds
 // that contains the given time t.  For underlapping windows that
 // do not contain time t, the window directly after time t will be returned.
 func (w Window) GetEarliestBounds(t Time) Bounds {
 	// translate to not-offset coordinate
-	t = t.Add(-w.Offset)
+	t = t.Add(w.Offset.Neg())
 
-	stop := t.Truncate(w.Every).Add(w.Every)
-
-	// translate to offset coordinate
-	stop = stop.Add(w.Offset)
-
-	start := stop.Add(-w.Period)
-	return Bounds{
-		Start: start,
-		Stop:  stop,
-	}
+	return w.bounds(t.Truncate(w.Every), 0)
 }
 
 // GetOverlappingBounds returns a slice of bounds for each window
@@ -49,15 +59,36 @@ func (w Window) GetOverlappingBounds(b Bounds) []Bounds {
 		return []Bounds{}
 	}
 
-	c := (b.Duration() / w.Every) + (w.Period / w.Every)
+	// The number of windows is estimated with the average length of a month.
+	every := w.Every.Duration()
+	c := (b.Duration().Duration() / every) + (w.Period.Duration() / every)
+	if c < 0 {
+		c = 0
+	}
 	bs := make([]Bounds, 0, c)
 
-	bi := w.GetEarliestBounds(b.Start)
-	for bi.Start < b.Stop {
+	start := b.Start.Add(w.Offset.Neg()).Truncate(w.Every)
+	for n := int64(0); ; n++ {
+		bi := w.bounds(start, n)
+		if bi.Start >= b.Stop {
+			break
+		}
 		bs = append(bs, bi)
-		bi.Start = bi.Start.Add(w.Every)
-		bi.Stop = bi.Stop.Add(w.Every)
 	}
 
 	return bs
 }
+
+// bounds returns the bounds of the nth window after the one that ends at start+every,
+// where start is a truncated time in the not-offset coordinate.
+// Both bounds are computed from start, rather than from each other or from the previous window,
+// so that the days lost at the end of short months do not add up.
+func (w Window) bounds(start Time, n int64) Bounds {
+	every := w.Every.Mul(n + 1)
+
+	// translate to offset coordinate
+	return Bounds{
+		Start: start.Add(every.Sub(w.Period)).Add(w.Offset),
+		Stop:  start.Add(every).Add(w.Offset),
+	}
+}
@@ -6,43 +6,44 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/values"
 )
 
 func TestNewWindow(t *testing.T) {
 	want := execute.Window{
-		Every:  execute.Duration(time.Minute),
-		Period: execute.Duration(time.Minute),
-		Offset: execute.Duration(time.Second),
+		Every:  values.ConvertDuration(time.Minute),
+		Period: values.ConvertDuration(time.Minute),
+		Offset: values.ConvertDuration(time.Second),
 	}
-	got := execute.NewWindow(execute.Duration(time.Minute), execute.Duration(time.Minute), execute.Duration(time.Second))
+	got := execute.NewWindow(values.ConvertDuration(time.Minute), values.ConvertDuration(time.Minute), values.ConvertDuration(time.Second))
 	if !cmp.Equal(want, got) {
 		t.Errorf("window different; -want/+got:\n%v\n", cmp.Diff(want, got))
 	}
 
 	// offset larger than "every" duration will be normalized
 	want = execute.Window{
-		Every:  execute.Duration(time.Minute),
-		Period: execute.Duration(time.Minute),
-		Offset: execute.Duration(30 * time.Second),
+		Every:  values.ConvertDuration(time.Minute),
+		Period: values.ConvertDuration(time.Minute),
+		Offset: values.ConvertDuration(30 * time.Second),
 	}
 	got = execute.NewWindow(
-		execute.Duration(time.Minute),
-		execute.Duration(time.Minute),
-		execute.Duration(2*time.Minute+30*time.Second))
+		values.ConvertDuration(time.Minute),
+		values.ConvertDuration(time.Minute),
+		values.ConvertDuration(2*time.Minute+30*time.Second))
 	if !cmp.Equal(want, got) {
 		t.Errorf("window different; -want/+got:\n%v\n", cmp.Diff(want, got))
 	}
 
 	// Negative offset will be normalized
 	want = execute.Window{
-		Every:  execute.Duration(time.Minute),
-		Period: execute.Duration(time.Minute),
-		Offset: execute.Duration(30 * time.Second),
+		Every:  values.ConvertDuration(time.Minute),
+		Period: values.ConvertDuration(time.Minute),
+		Offset: values.ConvertDuration(30 * time.Second),
 	}
 	got = execute.NewWindow(
-		execute.Duration(time.Minute),
-		execute.Duration(time.Minute),
-		-execute.Duration(2*time.Minute+30*time.Second))
+		values.ConvertDuration(time.Minute),
+		values.ConvertDuration(time.Minute),
+		values.ConvertDuration(-2*time.Minute-30*time.Second))
 	if !cmp.Equal(want, got) {
 		t.Errorf("window different; -want/+got:\n%v\n", cmp.Diff(want, got))
 	}
@@ -58,9 +59,9 @@ func TestWindow_GetEarliestBounds(t *testing.T) {
 		{
 			name: "simple",
 			w: execute.NewWindow(
-				execute.Duration(5*time.Minute),
-				execute.Duration(5*time.Minute),
-				0),
+				values.ConvertDuration(5*time.Minute),
+				values.ConvertDuration(5*time.Minute),
+				values.Duration{}),
 			t: execute.Time(6 * time.Minute),
 			want: execute.Bounds{
 				Start: execute.Time(5 * time.Minute),
@@ -70,9 +71,9 @@ func TestWindow_GetEarliestBounds(t *testing.T) {
 		{
 			name: "simple with offset",
 			w: execute.NewWindow(
-				execute.Duration(5*time.Minute),
-				execute.Duration(5*time.Minute),
-				execute.Duration(30*time.Second)),
+				values.ConvertDuration(5*time.Minute),
+				values.ConvertDuration(5*time.Minute),
+				values.ConvertDuration(30*time.Second)),
 			t: execute.Time(5 * time.Minute),
 			want: execute.Bounds{
 				Start: execute.Time(30 * time.Second),
@@ -82,9 +83,9 @@ func TestWindow_GetEarliestBounds(t *testing.T) {
 		{
 			name: "underlapping",
 			w: execute.NewWindow(
-				execute.Duration(2*time.Minute),
-				execute.Duration(1*time.Minute),
-				execute.Duration(30*time.Second)),
+				values.ConvertDuration(2*time.Minute),
+				values.ConvertDuration(1*time.Minute),
+				values.ConvertDuration(30*time.Second)),
 			t: execute.Time(3 * time.Minute),
 			want: execute.Bounds{
 				Start: execute.Time(3*time.Minute + 30*time.Second),
@@ -94,9 +95,9 @@ func TestWindow_GetEarliestBounds(t *testing.T) {
 		{
 			name: "underlapping not contained",
 			w: execute.NewWindow(
-				execute.Duration(2*time.Minute),
-				execute.Duration(1*time.Minute),
-				execute.Duration(30*time.Second)),
+				values.ConvertDuration(2*time.Minute),
+				values.ConvertDuration(1*time.Minute),
+				values.ConvertDuration(30*time.Second)),
 			t: execute.Time(2*time.Minute + 45*time.Second),
 			want: execute.Bounds{
 				Start: execute.Time(3*time.Minute + 30*time.Second),
@@ -106,9 +107,9 @@ func TestWindow_GetEarliestBounds(t *testing.T) {
 		{
 			name: "overlapping",
 			w: execute.NewWindow(
-				execute.Duration(1*time.Minute),
-				execute.Duration(2*time.Minute),
-				execute.Duration(30*time.Second)),
+				values.ConvertDuration(1*time.Minute),
+				values.ConvertDuration(2*time.Minute),
+				values.ConvertDuration(30*time.Second)),
 			t: execute.Time(30 * time.Second),
 			want: execute.Bounds{
 				Start: execute.Time(-30 * time.Second),
@@ -118,9 +119,9 @@ func TestWindow_GetEarliestBounds(t *testing.T) {
 		{
 			name: "partially overlapping",
 			w: execute.NewWindow(
-				execute.Duration(1*time.Minute),
-				execute.Duration(3*time.Minute+30*time.Second),
-				execute.Duration(30*time.Second)),
+				values.ConvertDuration(1*time.Minute),
+				values.ConvertDuration(3*time.Minute+30*time.Second),
+				values.ConvertDuration(30*time.Second)),
 			t: execute.Time(5*time.Minute + 45*time.Second),
 			want: execute.Bounds{
 				Start: execute.Time(3 * time.Minute),
@@ -130,9 +131,9 @@ func TestWindow_GetEarliestBounds(t *testing.T) {
 		{
 			name: "partially overlapping (t on boundary)",
 			w: execute.NewWindow(
-				execute.Duration(1*time.Minute),
-				execute.Duration(3*time.Minute+30*time.Second),
-				execute.Duration(30*time.Second)),
+				values.ConvertDuration(1*time.Minute),
+				values.ConvertDuration(3*time.Minute+30*time.Second),
+				values.ConvertDuration(30*time.Second)),
 			t: execute.Time(5 * time.Minute),
 			want: execute.Bounds{
 				Start: execute.Time(2 * time.Minute),
@@ -162,8 +163,8 @@ func TestWindow_GetOverlappingBounds(t *testing.T) {
 		{
 			name: "simple",
 			w: execute.Window{
-				Every:  execute.Duration(time.Minute),
-				Period: execute.Duration(time.Minute),
+				Every:  values.ConvertDuration(time.Minute),
+				Period: values.ConvertDuration(time.Minute),
 			},
 			b: execute.Bounds{
 				Start: execute.Time(5 * time.Minute),
@@ -178,9 +179,9 @@ func TestWindow_GetOverlappingBounds(t *testing.T) {
 		{
 			name: "simple with offset",
 			w: execute.Window{
-				Every:  execute.Duration(time.Minute),
-				Period: execute.Duration(time.Minute),
-				Offset: execute.Duration(15 * time.Second),
+				Every:  values.ConvertDuration(time.Minute),
+				Period: values.ConvertDuration(time.Minute),
+				Offset: values.ConvertDuration(15 * time.Second),
 			},
 			b: execute.Bounds{
 				Start: execute.Time(5 * time.Minute),
@@ -204,8 +205,8 @@ func TestWindow_GetOverlappingBounds(t *testing.T) {
 		{
 			name: "underlapping, bounds in gap",
 			w: execute.Window{
-				Every:  execute.Duration(2 * time.Minute),
-				Period: execute.Duration(time.Minute),
+				Every:  values.ConvertDuration(2 * time.Minute),
+				Period: values.ConvertDuration(time.Minute),
 			},
 			b: execute.Bounds{
 				Start: execute.Time(30 * time.Second),
@@ -216,9 +217,9 @@ func TestWindow_GetOverlappingBounds(t *testing.T) {
 		{
 			name: "underlapping",
 			w: execute.Window{
-				Every:  execute.Duration(2 * time.Minute),
-				Period: execute.Duration(time.Minute),
-				Offset: execute.Duration(30 * time.Second),
+				Every:  values.ConvertDuration(2 * time.Minute),
+				Period: values.ConvertDuration(time.Minute),
+				Offset: values.ConvertDuration(30 * time.Second),
 			},
 			b: execute.Bounds{
 				Start: execute.Time(time.Minute + 45*time.Second),
@@ -238,8 +239,8 @@ func TestWindow_GetOverlappingBounds(t *testing.T) {
 		{
 			name: "overlapping",
 			w: execute.Window{
-				Every:  execute.Duration(1 * time.Minute),
-				Period: execute.Duration(2*time.Minute + 15*time.Second),
+				Every:  values.ConvertDuration(1 * time.Minute),
+				Period: values.ConvertDuration(2*time.Minute + 15*time.Second),
 			},
 			b: execute.Bounds{
 				Start: execute.Time(10 * time.Minute),
@@ -264,6 +265,77 @@ func TestWindow_GetOverlappingBounds(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "calendar months",
+			w: execute.NewWindow(
+				values.MakeDuration(1, 0),
+				values.MakeDuration(1, 0),
+				values.Duration{}),
+			b: execute.Bounds{
+				Start: values.ConvertTime(time.Date(2019, 1, 15, 0, 0, 0, 0, time.UTC)),
+				Stop:  values.ConvertTime(time.Date(2019, 3, 15, 0, 0, 0, 0, time.UTC)),
+			},
+			want: []execute.Bounds{
+				{
+					Start: values.ConvertTime(time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)),
+					Stop:  values.ConvertTime(time.Date(2019, 2, 1, 0, 0, 0, 0, time.UTC)),
+				},
+				{
+					Start: values.ConvertTime(time.Date(2019, 2, 1, 0, 0, 0, 0, time.UTC)),
+					Stop:  values.ConvertTime(time.Date(2019, 3, 1, 0, 0, 0, 0, time.UTC)),
+				},
+				{
+					Start: values.ConvertTime(time.Date(2019, 3, 1, 0, 0, 0, 0, time.UTC)),
+					Stop:  values.ConvertTime(time.Date(2019, 4, 1, 0, 0, 0, 0, time.UTC)),
+				},
+			},
+		},
+		{
+			name: "calendar quarters with offset",
+			w: execute.NewWindow(
+				values.MakeDuration(3, 0),
+				values.MakeDuration(3, 0),
+				values.MakeDuration(-11, 0)),
+			b: execute.Bounds{
+				Start: values.ConvertTime(time.Date(2019, 1, 15, 0, 0, 0, 0, time.UTC)),
+				Stop:  values.ConvertTime(time.Date(2019, 4, 15, 0, 0, 0, 0, time.UTC)),
+			},
+			want: []execute.Bounds{
+				{
+					Start: values.ConvertTime(time.Date(2018, 11, 1, 0, 0, 0, 0, time.UTC)),
+					Stop:  values.ConvertTime(time.Date(2019, 2, 1, 0, 0, 0, 0, time.UTC)),
+				},
+				{
+					Start: values.ConvertTime(time.Date(2019, 2, 1, 0, 0, 0, 0, time.UTC)),
+					Stop:  values.ConvertTime(time.Date(2019, 5, 1, 0, 0, 0, 0, time.UTC)),
+				},
+			},
+		},
+		{
+			name: "months with days offset",
+			w: execute.NewWindow(
+				values.MakeDuration(1, 0),
+				values.MakeDuration(1, 0),
+				values.ConvertDuration(30*24*time.Hour)),
+			b: execute.Bounds{
+				Start: values.ConvertTime(time.Date(2019, 2, 1, 0, 0, 0, 0, time.UTC)),
+				Stop:  values.ConvertTime(time.Date(2019, 4, 1, 0, 0, 0, 0, time.UTC)),
+			},
+			want: []execute.Bounds{
+				{
+					Start: values.ConvertTime(time.Date(2019, 1, 31, 0, 0, 0, 0, time.UTC)),
+					Stop:  values.ConvertTime(time.Date(2019, 3, 3, 0, 0, 0, 0, time.UTC)),
+				},
+				{
+					Start: values.ConvertTime(time.Date(2019, 3, 3, 0, 0, 0, 0, time.UTC)),
+					Stop:  values.ConvertTime(time.Date(2019, 3, 31, 0, 0, 0, 0, time.UTC)),
+				},
+				{
+					Start: values.ConvertTime(time.Date(2019, 3, 31, 0, 0, 0, 0, time.UTC)),
+					Stop:  values.ConvertTime(time.Date(2019, 5, 1, 0, 0, 0, 0, time.UTC)),
+				},
+			},
+		},
 	}
 
 	for _, tc := range testcases {
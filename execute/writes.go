@@ -0,0 +1,67 @@
+package execute
+
+import (
+	"context"
+	"sync"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/dependencies"
+	"go.uber.org/zap"
+)
+
+// WriteRecorder records the writes to external systems that the functions of the executed queries perform,
+// so that the operators can tell what a query sent and where.
+type WriteRecorder struct {
+	mu     sync.Mutex
+	writes []flux.Write
+}
+
+func NewWriteRecorder() *WriteRecorder {
+	return new(WriteRecorder)
+}
+
+// Writes returns the writes recorded so far, in the order they were recorded.
+func (r *WriteRecorder) Writes() []flux.Write {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.writes) == 0 {
+		return nil
+	}
+	return append([]flux.Write(nil), r.writes...)
+}
+
+func (r *WriteRecorder) add(w flux.Write) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.writes = append(r.writes, w)
+}
+
+// WithWriteRecorder returns a context with which the writes of the executed queries are recorded by r.
+// A nil recorder disables the recording.
+func WithWriteRecorder(ctx context.Context, r *WriteRecorder) context.Context {
+	return context.WithValue(ctx, writeRecorderKey, r)
+}
+
+// RecordWrite records a write that a function performed with the recorder of the context, if it has one,
+// and logs it with the logger of the dependencies of the context.
+// Functions call it once a write has ended, whether it succeeded or not.
+func RecordWrite(ctx context.Context, w flux.Write) {
+	if r, _ := ctx.Value(writeRecorderKey).(*WriteRecorder); r != nil {
+		r.add(w)
+	}
+	fields := []zap.Field{
+		zap.String("function", w.Function),
+		zap.String("destination", w.Destination),
+		zap.Int64("rows", w.Rows),
+		zap.Int64("bytes", w.Bytes),
+		zap.Duration("duration", w.Duration),
+		zap.String("status", w.Status),
+	}
+	if w.Rejected != 0 {
+		fields = append(fields, zap.Int64("rejected", w.Rejected))
+	}
+	if w.Error != "" {
+		fields = append(fields, zap.String("error", w.Error))
+	}
+	dependencies.Get(ctx).Logger.Info("write performed", fields...)
+}
@@ -0,0 +1,45 @@
+package execute_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/dependencies"
+	"github.com/influxdata/flux/execute"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestRecordWrite(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+	deps := dependencies.Default()
+	deps.Logger = zap.New(core)
+	ctx := dependencies.Inject(context.Background(), deps)
+
+	// A write is logged even if it is not recorded.
+	execute.RecordWrite(ctx, flux.Write{Function: "to", Destination: "unrecorded", Status: "ok"})
+
+	r := execute.NewWriteRecorder()
+	ctx = execute.WithWriteRecorder(ctx, r)
+	want := []flux.Write{
+		{Function: "kafka.to", Destination: "localhost:9092/topic", Rows: 2, Bytes: 40, Duration: time.Millisecond, Status: "ok"},
+		{Function: "http.to", Destination: "http://localhost/write", Duration: time.Second, Status: "failed", Error: "connection refused"},
+	}
+	for _, w := range want {
+		execute.RecordWrite(ctx, w)
+	}
+	if got := r.Writes(); !cmp.Equal(want, got) {
+		t.Errorf("unexpected writes -want/+got\n%s", cmp.Diff(want, got))
+	}
+
+	entries := logs.FilterMessage("write performed").All()
+	if len(entries) != 3 {
+		t.Fatalf("expected every write to be logged, got %v", logs.All())
+	}
+	if got := entries[2].ContextMap()["error"]; got != "connection refused" {
+		t.Errorf("unexpected error logged: %v", got)
+	}
+}
@@ -10,4 +10,6 @@ const (
 	GroupModeBy GroupMode = 1 << iota
 	// GroupModeExcept produces a table for the unique values of all keys, except those specified by GroupKeys.
 	GroupModeExcept
+	// GroupModeExtend produces a table for the unique values of the group key of each table extended with the specified GroupKeys.
+	GroupModeExtend
 )
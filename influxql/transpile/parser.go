@@ -0,0 +1,347 @@
+package transpile
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/influxdata/flux/internal/querylang"
+)
+
+// dialect describes the lexical elements of the supported subset of InfluxQL.
+var dialect = &querylang.Dialect{
+	Keywords: map[string]bool{
+		"SELECT": true,
+		"FROM":   true,
+		"WHERE":  true,
+		"GROUP":  true,
+		"BY":     true,
+		"FILL":   true,
+		"ORDER":  true,
+		"LIMIT":  true,
+		"OFFSET": true,
+		"AND":    true,
+		"OR":     true,
+		"AS":     true,
+		"ASC":    true,
+		"DESC":   true,
+	},
+	Regexes:   true,
+	Durations: true,
+	TypeCasts: true,
+}
+
+// selectStatement is a parsed InfluxQL SELECT statement.
+type selectStatement struct {
+	fields []*querylang.Field
+	// sources are the measurements read by the statement.
+	// A statement reads either measurements or a subquery.
+	sources  []*measurement
+	subquery *selectStatement
+	// condition is the WHERE clause, nil if there is none.
+	condition querylang.Expr
+
+	// groupByTime is the interval of GROUP BY time(), zero if there is none.
+	groupByTime   *querylang.DurationLit
+	groupByOffset *querylang.DurationLit
+	// groupByTags are the tags of the GROUP BY clause.
+	groupByTags []string
+	// groupByAll is set by GROUP BY *.
+	groupByAll bool
+
+	fill      fillOption
+	fillValue querylang.Expr
+
+	descending bool
+	limit      int
+	offset     int
+}
+
+// measurement is a source of the FROM clause.
+type measurement struct {
+	database        string
+	retentionPolicy string
+	name            string
+	regex           *regexp.Regexp
+}
+
+type fillOption int
+
+const (
+	fillNull fillOption = iota
+	fillNone
+	fillNumber
+	fillPrevious
+	fillLinear
+)
+
+// parser parses InfluxQL SELECT statements.
+type parser struct {
+	*querylang.Parser
+}
+
+// parseQuery parses the SELECT statements of a query, separated by semicolons.
+func parseQuery(query string) ([]*selectStatement, error) {
+	p := &parser{Parser: querylang.NewParser(query, dialect)}
+	var stmts []*selectStatement
+	err := p.ParseStatements(func() error {
+		stmt, err := p.parseSelect()
+		if err != nil {
+			return err
+		}
+		stmts = append(stmts, stmt)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return stmts, nil
+}
+
+func (p *parser) parseSelect() (*selectStatement, error) {
+	if _, err := p.Expect(querylang.KEYWORD, "SELECT"); err != nil {
+		return nil, err
+	}
+	stmt := &selectStatement{}
+	var err error
+	if stmt.fields, err = p.ParseFields(); err != nil {
+		return nil, err
+	}
+	if _, err := p.Expect(querylang.KEYWORD, "FROM"); err != nil {
+		return nil, err
+	}
+	if err := p.parseSources(stmt); err != nil {
+		return nil, err
+	}
+	if ok, err := p.Accept("WHERE"); err != nil {
+		return nil, err
+	} else if ok {
+		if stmt.condition, err = p.ParseExpr(); err != nil {
+			return nil, err
+		}
+	}
+	if ok, err := p.Accept("GROUP"); err != nil {
+		return nil, err
+	} else if ok {
+		if err := p.parseGroupBy(stmt); err != nil {
+			return nil, err
+		}
+	}
+	if ok, err := p.Accept("FILL"); err != nil {
+		return nil, err
+	} else if ok {
+		if err := p.parseFill(stmt); err != nil {
+			return nil, err
+		}
+	}
+	if ok, err := p.Accept("ORDER"); err != nil {
+		return nil, err
+	} else if ok {
+		if err := p.parseOrderBy(stmt); err != nil {
+			return nil, err
+		}
+	}
+	if ok, err := p.Accept("LIMIT"); err != nil {
+		return nil, err
+	} else if ok {
+		if stmt.limit, err = p.ParseInt(); err != nil {
+			return nil, err
+		}
+	}
+	if ok, err := p.Accept("OFFSET"); err != nil {
+		return nil, err
+	} else if ok {
+		if stmt.offset, err = p.ParseInt(); err != nil {
+			return nil, err
+		}
+	}
+	return stmt, nil
+}
+
+func (p *parser) parseSources(stmt *selectStatement) error {
+	it, err := p.Next()
+	if err != nil {
+		return err
+	}
+	if it.Tok == querylang.LPAREN {
+		if stmt.subquery, err = p.parseSelect(); err != nil {
+			return err
+		}
+		_, err = p.Expect(querylang.RPAREN, "")
+		return err
+	}
+	p.Unscan()
+	for {
+		m, err := p.parseMeasurement()
+		if err != nil {
+			return err
+		}
+		stmt.sources = append(stmt.sources, m)
+		it, err := p.Next()
+		if err != nil {
+			return err
+		}
+		if it.Tok != querylang.COMMA {
+			p.Unscan()
+			return nil
+		}
+	}
+}
+
+// parseMeasurement parses a measurement name or regex, qualified by a database and a retention policy:
+// db.rp.measurement, db..measurement, rp.measurement or measurement.
+func (p *parser) parseMeasurement() (*measurement, error) {
+	var parts []string
+	for {
+		it, err := p.Next()
+		if err != nil {
+			return nil, err
+		}
+		switch it.Tok {
+		case querylang.IDENT:
+			parts = append(parts, it.Lit)
+		case querylang.REGEX:
+			re, err := regexp.Compile(it.Lit)
+			if err != nil {
+				return nil, err
+			}
+			return p.measurement(parts, &measurement{regex: re})
+		case querylang.DOT:
+			// An empty part, as in db..measurement.
+			parts = append(parts, "")
+			p.Unscan()
+		default:
+			return nil, p.Unexpected(it, "measurement")
+		}
+		it, err = p.Next()
+		if err != nil {
+			return nil, err
+		}
+		if it.Tok != querylang.DOT {
+			p.Unscan()
+			name := parts[len(parts)-1]
+			return p.measurement(parts[:len(parts)-1], &measurement{name: name})
+		}
+	}
+}
+
+// measurement sets the database and retention policy of m from the qualifiers that precede its name.
+func (p *parser) measurement(qualifiers []string, m *measurement) (*measurement, error) {
+	switch len(qualifiers) {
+	case 0:
+	case 1:
+		m.retentionPolicy = qualifiers[0]
+	case 2:
+		m.database, m.retentionPolicy = qualifiers[0], qualifiers[1]
+	default:
+		return nil, fmt.Errorf("invalid measurement %s", strings.Join(append(qualifiers, m.name), "."))
+	}
+	return m, nil
+}
+
+func (p *parser) parseGroupBy(stmt *selectStatement) error {
+	if _, err := p.Expect(querylang.KEYWORD, "BY"); err != nil {
+		return err
+	}
+	for {
+		it, err := p.Next()
+		if err != nil {
+			return err
+		}
+		switch {
+		case it.Tok == querylang.STAR:
+			stmt.groupByAll = true
+		case it.Tok == querylang.IDENT && strings.EqualFold(it.Lit, querylang.TimeColumn):
+			if _, err := p.Expect(querylang.LPAREN, ""); err != nil {
+				return err
+			}
+			every, err := p.Expect(querylang.DURATION, "")
+			if err != nil {
+				return err
+			}
+			stmt.groupByTime = &querylang.DurationLit{Lit: every.Lit}
+			it, err := p.Next()
+			if err != nil {
+				return err
+			}
+			if it.Tok == querylang.COMMA {
+				offset, err := p.Expect(querylang.DURATION, "")
+				if err != nil {
+					return err
+				}
+				stmt.groupByOffset = &querylang.DurationLit{Lit: offset.Lit}
+			} else {
+				p.Unscan()
+			}
+			if _, err := p.Expect(querylang.RPAREN, ""); err != nil {
+				return err
+			}
+		case it.Tok == querylang.IDENT:
+			stmt.groupByTags = append(stmt.groupByTags, it.Lit)
+		default:
+			return p.Unexpected(it, "time(), * or a tag")
+		}
+		it, err = p.Next()
+		if err != nil {
+			return err
+		}
+		if it.Tok != querylang.COMMA {
+			p.Unscan()
+			return nil
+		}
+	}
+}
+
+func (p *parser) parseFill(stmt *selectStatement) error {
+	if _, err := p.Expect(querylang.LPAREN, ""); err != nil {
+		return err
+	}
+	it, err := p.Next()
+	if err != nil {
+		return err
+	}
+	switch {
+	case it.Tok == querylang.IDENT && strings.EqualFold(it.Lit, "null"):
+		stmt.fill = fillNull
+	case it.Tok == querylang.IDENT && strings.EqualFold(it.Lit, "none"):
+		stmt.fill = fillNone
+	case it.Tok == querylang.IDENT && strings.EqualFold(it.Lit, "previous"):
+		stmt.fill = fillPrevious
+	case it.Tok == querylang.IDENT && strings.EqualFold(it.Lit, "linear"):
+		stmt.fill = fillLinear
+	default:
+		p.Unscan()
+		v, err := p.ParseUnary()
+		if err != nil {
+			return err
+		}
+		if _, ok := v.(*querylang.NumberLit); !ok {
+			return fmt.Errorf("invalid fill option %s", v)
+		}
+		stmt.fill = fillNumber
+		stmt.fillValue = v
+	}
+	_, err = p.Expect(querylang.RPAREN, "")
+	return err
+}
+
+func (p *parser) parseOrderBy(stmt *selectStatement) error {
+	if _, err := p.Expect(querylang.KEYWORD, "BY"); err != nil {
+		return err
+	}
+	it, err := p.Expect(querylang.IDENT, "")
+	if err != nil {
+		return err
+	}
+	if !strings.EqualFold(it.Lit, querylang.TimeColumn) {
+		return fmt.Errorf("only ORDER BY time is supported, found %q", it.Lit)
+	}
+	if ok, err := p.Accept("DESC"); err != nil {
+		return err
+	} else if ok {
+		stmt.descending = true
+		return nil
+	}
+	_, err = p.Accept("ASC")
+	return err
+}
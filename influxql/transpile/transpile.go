@@ -0,0 +1,447 @@
+// Package transpile converts InfluxQL queries into Flux, so that the queries of applications written
+// for InfluxDB 1.x can be executed by the Flux engine.
+//
+// A subset of InfluxQL SELECT statements is supported: fields that are raw field keys or calls to
+// aggregate and selector functions, measurements and subqueries as sources, WHERE clauses on the time
+// and on tags, GROUP BY time() and tags, fill(), ORDER BY time, LIMIT and OFFSET.
+// Conditions on field values and math between fields are not supported.
+//
+// The tables of every statement are pivoted so that each selected field is a column,
+// and are yielded with the index of the statement in the query as name.
+package transpile
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/influxdata/flux/ast"
+	"github.com/influxdata/flux/ast/astutil"
+	"github.com/influxdata/flux/internal/querylang"
+)
+
+// DefaultRetentionPolicy is the retention policy of the measurements whose retention policy is not given,
+// unless configured otherwise.
+const DefaultRetentionPolicy = "autogen"
+
+// Config configures the transpilation of queries.
+type Config struct {
+	// DefaultDatabase is the database of the measurements whose database is not given.
+	DefaultDatabase string
+	// DefaultRetentionPolicy is the retention policy of the measurements whose retention policy is not given.
+	// Defaults to DefaultRetentionPolicy.
+	DefaultRetentionPolicy string
+	// Bucket returns the bucket that stores the data of a database and a retention policy.
+	// Defaults to a bucket named "database/retentionPolicy".
+	Bucket func(database, retentionPolicy string) string
+}
+
+// Transpile converts an InfluxQL query, made of one or more SELECT statements, into a Flux package.
+func Transpile(query string, c Config) (*ast.Package, error) {
+	stmts, err := parseQuery(query)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse InfluxQL query: %v", err)
+	}
+	t := &transpiler{config: c}
+	file := &ast.File{}
+	for i, stmt := range stmts {
+		e, err := t.statement(stmt, querylang.TimeRange{})
+		if err != nil {
+			return nil, fmt.Errorf("statement %d: %v", i, err)
+		}
+		p := astutil.Split(t.output(stmt, e))
+		p.Stages = append(p.Stages, astutil.Call("yield", astutil.Property("name", &ast.StringLiteral{Value: strconv.Itoa(i)})))
+		file.Body = append(file.Body, &ast.ExpressionStatement{Expression: p.Expression()})
+	}
+	return &ast.Package{
+		Package: "main",
+		Files:   []*ast.File{file},
+	}, nil
+}
+
+type transpiler struct {
+	config Config
+}
+
+func (t *transpiler) bucket(m *measurement) string {
+	db, rp := m.database, m.retentionPolicy
+	if db == "" {
+		db = t.config.DefaultDatabase
+	}
+	if rp == "" {
+		rp = t.config.DefaultRetentionPolicy
+	}
+	if rp == "" {
+		rp = DefaultRetentionPolicy
+	}
+	if t.config.Bucket != nil {
+		return t.config.Bucket(db, rp)
+	}
+	return db + "/" + rp
+}
+
+// aggregate describes how an InfluxQL function is computed by Flux.
+type aggregate struct {
+	// fn is the name of the Flux function.
+	fn string
+	// selector is set for functions that return one of the records of their input.
+	selector bool
+	// integer is set for functions that return integers whatever the type of their input.
+	integer bool
+}
+
+var aggregates = map[string]aggregate{
+	"count":      {fn: "count", integer: true},
+	"sum":        {fn: "sum"},
+	"mean":       {fn: "mean"},
+	"median":     {fn: "median"},
+	"spread":     {fn: "spread"},
+	"stddev":     {fn: "stddev"},
+	"min":        {fn: "min", selector: true},
+	"max":        {fn: "max", selector: true},
+	"first":      {fn: "first", selector: true},
+	"last":       {fn: "last", selector: true},
+	"percentile": {fn: "percentile", selector: true},
+}
+
+// selection is a field of a statement, checked and named.
+type selection struct {
+	name string
+	// field is the field key that is selected, empty for all the fields.
+	field string
+	// call is the function applied to the field, nil for raw fields.
+	call *querylang.Call
+}
+
+// selections checks the fields of stmt and names them.
+func (t *transpiler) selections(stmt *selectStatement) ([]selection, error) {
+	var sels []selection
+	raw, aggregated := false, false
+	used := make(map[string]int)
+	for _, f := range stmt.fields {
+		var sel selection
+		switch e := f.Expr.(type) {
+		case *querylang.VarRef:
+			sel.field, sel.name = e.Name, e.Name
+			raw = true
+		case *querylang.Wildcard:
+			if f.Alias != "" {
+				return nil, fmt.Errorf("cannot alias *")
+			}
+			raw = true
+		case *querylang.Call:
+			if _, ok := aggregates[e.Name]; !ok {
+				return nil, fmt.Errorf("unsupported function %s()", e.Name)
+			}
+			wantArgs := 1
+			if e.Name == "percentile" {
+				wantArgs = 2
+			}
+			if len(e.Args) != wantArgs {
+				return nil, fmt.Errorf("invalid number of arguments for %s, expected %d, got %d", e.Name, wantArgs, len(e.Args))
+			}
+			ref, ok := e.Args[0].(*querylang.VarRef)
+			if !ok {
+				return nil, fmt.Errorf("unsupported argument %s of %s(), expected a field key", e.Args[0], e.Name)
+			}
+			if e.Name == "percentile" {
+				if _, ok := e.Args[1].(*querylang.NumberLit); !ok {
+					return nil, fmt.Errorf("invalid percentile %s, expected a number", e.Args[1])
+				}
+			}
+			sel.field, sel.name, sel.call = ref.Name, e.Name, e
+			aggregated = true
+		default:
+			return nil, fmt.Errorf("unsupported field expression %s", f.Expr)
+		}
+		if f.Alias != "" {
+			sel.name = f.Alias
+		}
+		// Repeated names are made unique with a suffix, as in InfluxQL.
+		if n := used[sel.name]; n > 0 && sel.name != "" {
+			used[sel.name]++
+			sel.name = fmt.Sprintf("%s_%d", sel.name, n)
+		} else {
+			used[sel.name]++
+		}
+		sels = append(sels, sel)
+	}
+	if raw && aggregated {
+		return nil, fmt.Errorf("mixing aggregate and non-aggregate fields is not supported")
+	}
+	if stmt.groupByTime != nil && !aggregated {
+		return nil, fmt.Errorf("GROUP BY time() requires an aggregate function")
+	}
+	return sels, nil
+}
+
+// statement returns the Flux expression of stmt, whose tables have a row per value of a selected field:
+// the name of the selection is in the _field column and its value in the _value column.
+// The time range applies when the statement has no time condition of its own, as for subqueries.
+func (t *transpiler) statement(stmt *selectStatement, inherited querylang.TimeRange) (ast.Expression, error) {
+	tr, cond, err := querylang.SplitCondition(stmt.condition)
+	if err != nil {
+		return nil, err
+	}
+	if tr.Start == nil && tr.Stop == nil {
+		tr = inherited
+	}
+	sels, err := t.selections(stmt)
+	if err != nil {
+		return nil, err
+	}
+
+	source, err := t.source(stmt, tr)
+	if err != nil {
+		return nil, err
+	}
+	p := astutil.Split(source)
+	if cond != nil {
+		fn, err := condition(cond)
+		if err != nil {
+			return nil, err
+		}
+		p.Stages = append(p.Stages, querylang.Filter(fn))
+	}
+
+	var fields []ast.Expression
+	for _, sel := range sels {
+		fp, err := t.selection(stmt, sel, &astutil.Pipeline{
+			Source: p.Expression(),
+		})
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, fp.Expression())
+	}
+
+	out := &astutil.Pipeline{Source: fields[0]}
+	if len(fields) > 1 {
+		out.Source = astutil.Call("union", astutil.Property("tables", &ast.ArrayExpression{Elements: fields}))
+	}
+	if stmt.descending {
+		out.Stages = append(out.Stages, astutil.Call("sort",
+			astutil.Property("columns", querylang.StringArray("_time")),
+			astutil.Property("desc", &ast.BooleanLiteral{Value: true}),
+		))
+	}
+	if stmt.limit > 0 || stmt.offset > 0 {
+		props := []*ast.Property{astutil.Property("n", &ast.IntegerLiteral{Value: int64(stmt.limit)})}
+		if stmt.limit == 0 {
+			// An OFFSET without a LIMIT keeps all the remaining rows.
+			props[0].Value = &ast.IntegerLiteral{Value: 1<<63 - 1}
+		}
+		if stmt.offset > 0 {
+			props = append(props, astutil.Property("offset", &ast.IntegerLiteral{Value: int64(stmt.offset)}))
+		}
+		out.Stages = append(out.Stages, astutil.Call("limit", props...))
+	}
+	return out.Expression(), nil
+}
+
+// source returns the expression of the data read by stmt.
+func (t *transpiler) source(stmt *selectStatement, tr querylang.TimeRange) (ast.Expression, error) {
+	if stmt.subquery != nil {
+		return t.statement(stmt.subquery, tr)
+	}
+
+	// The measurements are read with a single from for each bucket.
+	var buckets []string
+	measurements := make(map[string][]*measurement)
+	for _, m := range stmt.sources {
+		b := t.bucket(m)
+		if _, ok := measurements[b]; !ok {
+			buckets = append(buckets, b)
+		}
+		measurements[b] = append(measurements[b], m)
+	}
+	var reads []ast.Expression
+	for _, b := range buckets {
+		var cond ast.Expression
+		for _, m := range measurements[b] {
+			var c ast.Expression
+			if m.regex != nil {
+				c = querylang.Compare(ast.RegexpMatchOperator, querylang.Column("_measurement"), &ast.RegexpLiteral{Value: m.regex})
+			} else {
+				c = querylang.Compare(ast.EqualOperator, querylang.Column("_measurement"), &ast.StringLiteral{Value: m.name})
+			}
+			cond = or(cond, c)
+		}
+		p := &astutil.Pipeline{
+			Source: astutil.Call("from", astutil.Property("bucket", &ast.StringLiteral{Value: b})),
+			Stages: []*ast.CallExpression{
+				tr.Call(),
+				querylang.Filter(cond),
+			},
+		}
+		reads = append(reads, p.Expression())
+	}
+	if len(reads) == 1 {
+		return reads[0], nil
+	}
+	return astutil.Call("union", astutil.Property("tables", &ast.ArrayExpression{Elements: reads})), nil
+}
+
+// selection adds the stages that compute a selection to p.
+func (t *transpiler) selection(stmt *selectStatement, sel selection, p *astutil.Pipeline) (*astutil.Pipeline, error) {
+	if sel.field != "" {
+		p.Stages = append(p.Stages, querylang.Filter(querylang.Compare(ast.EqualOperator, querylang.Column("_field"), &ast.StringLiteral{Value: sel.field})))
+	}
+	if !stmt.groupByAll {
+		cols := append([]string{"_measurement", "_field"}, stmt.groupByTags...)
+		p.Stages = append(p.Stages,
+			astutil.Call("group", astutil.Property("columns", querylang.StringArray(cols...))),
+			astutil.Call("sort", astutil.Property("columns", querylang.StringArray("_time"))),
+		)
+	}
+
+	if sel.call != nil {
+		agg := aggregates[sel.call.Name]
+		fn := astutil.Call(agg.fn)
+		switch sel.call.Name {
+		case "median":
+			fn = astutil.Call(agg.fn, astutil.Property("method", &ast.StringLiteral{Value: "exact_mean"}))
+		case "percentile":
+			n, err := strconv.ParseFloat(sel.call.Args[1].(*querylang.NumberLit).Lit, 64)
+			if err != nil {
+				return nil, err
+			}
+			fn = astutil.Call(agg.fn,
+				astutil.Property("percentile", &ast.FloatLiteral{Value: n / 100}),
+				astutil.Property("method", &ast.StringLiteral{Value: "exact_selector"}),
+			)
+		}
+		startTime := astutil.Call("duplicate",
+			astutil.Property("column", &ast.StringLiteral{Value: "_start"}),
+			astutil.Property("as", &ast.StringLiteral{Value: "_time"}),
+		)
+
+		if stmt.groupByTime != nil {
+			// The time of a window is its start, as in InfluxQL, even for selectors.
+			every, err := querylang.DurationLiteral(stmt.groupByTime.Lit)
+			if err != nil {
+				return nil, err
+			}
+			props := []*ast.Property{astutil.Property("every", every)}
+			if stmt.groupByOffset != nil {
+				offset, err := querylang.DurationLiteral(stmt.groupByOffset.Lit)
+				if err != nil {
+					return nil, err
+				}
+				props = append(props, astutil.Property("offset", offset))
+			}
+			props = append(props, astutil.Property("createEmpty", &ast.BooleanLiteral{Value: stmt.fill != fillNone}))
+			p.Stages = append(p.Stages, astutil.Call("window", props...), fn)
+			if agg.selector {
+				p.Stages = append(p.Stages, astutil.Call("drop", astutil.Property("columns", querylang.StringArray("_time"))))
+			}
+			p.Stages = append(p.Stages,
+				startTime,
+				astutil.Call("window", astutil.Property("every", &ast.Identifier{Name: "inf"})),
+			)
+			fill, err := fillCall(stmt, agg)
+			if err != nil {
+				return nil, err
+			}
+			if fill != nil {
+				p.Stages = append(p.Stages, fill)
+			}
+		} else {
+			p.Stages = append(p.Stages, fn)
+			// Selectors keep the time of the selected record,
+			// the other functions report the start of the range.
+			if !agg.selector {
+				p.Stages = append(p.Stages, startTime)
+			}
+		}
+	}
+
+	if sel.name != "" && (sel.call != nil || sel.name != sel.field) {
+		p.Stages = append(p.Stages, astutil.Call("set",
+			astutil.Property("key", &ast.StringLiteral{Value: "_field"}),
+			astutil.Property("value", &ast.StringLiteral{Value: sel.name}),
+		))
+	}
+	return p, nil
+}
+
+// fillCall returns the call that fills the empty windows of a statement, nil if none is needed.
+func fillCall(stmt *selectStatement, agg aggregate) (*ast.CallExpression, error) {
+	switch stmt.fill {
+	case fillPrevious:
+		return astutil.Call("fill", astutil.Property("usePrevious", &ast.BooleanLiteral{Value: true})), nil
+	case fillLinear:
+		return nil, fmt.Errorf("fill(linear) is not supported")
+	case fillNumber:
+		// The type of the values is not known statically, so the values of fields are assumed to be floats,
+		// which they are most of the time.
+		f, err := strconv.ParseFloat(stmt.fillValue.(*querylang.NumberLit).Lit, 64)
+		if err != nil {
+			return nil, err
+		}
+		var value ast.Expression = &ast.FloatLiteral{Value: f}
+		if agg.integer {
+			value = &ast.IntegerLiteral{Value: int64(f)}
+		}
+		return astutil.Call("fill", astutil.Property("value", value)), nil
+	}
+	return nil, nil
+}
+
+// output pivots the tables of a statement so that each selection is a column.
+func (t *transpiler) output(stmt *selectStatement, e ast.Expression) ast.Expression {
+	var group *ast.CallExpression
+	if stmt.groupByAll {
+		group = astutil.Call("group",
+			astutil.Property("columns", querylang.StringArray("_time", "_value", "_field", "_start", "_stop")),
+			astutil.Property("mode", &ast.StringLiteral{Value: "except"}),
+		)
+	} else {
+		group = astutil.Call("group", astutil.Property("columns", querylang.StringArray(append([]string{"_measurement"}, stmt.groupByTags...)...)))
+	}
+	p := astutil.Split(e)
+	p.Stages = append(p.Stages,
+		group,
+		astutil.Call("pivot",
+			astutil.Property("rowKey", querylang.StringArray("_time")),
+			astutil.Property("columnKey", querylang.StringArray("_field")),
+			astutil.Property("valueColumn", &ast.StringLiteral{Value: "_value"}),
+		),
+	)
+	return p.Expression()
+}
+
+// condition converts a WHERE clause on tags into the body of a filter function.
+func condition(e querylang.Expr) (ast.Expression, error) {
+	return querylang.Condition(e, operand, false)
+}
+
+// operand converts an operand of a comparison in a WHERE clause.
+// Variables are tags, since the values of fields cannot be compared before they are pivoted.
+func operand(e querylang.Expr) (ast.Expression, error) {
+	switch n := e.(type) {
+	case *querylang.VarRef:
+		if n.Type == "field" {
+			return nil, fmt.Errorf("conditions on fields are not supported: %s", n)
+		}
+		return querylang.Column(n.Name), nil
+	case *querylang.StringLit:
+		return &ast.StringLiteral{Value: n.Value}, nil
+	case *querylang.RegexLit:
+		return &ast.RegexpLiteral{Value: n.Regexp}, nil
+	case *querylang.NumberLit:
+		if n.IsFloat {
+			f, err := strconv.ParseFloat(n.Lit, 64)
+			return &ast.FloatLiteral{Value: f}, err
+		}
+		i, err := strconv.ParseInt(n.Lit, 10, 64)
+		return &ast.IntegerLiteral{Value: i}, err
+	}
+	return nil, fmt.Errorf("unsupported operand %s", e)
+}
+
+func or(lhs, rhs ast.Expression) ast.Expression {
+	if lhs == nil {
+		return rhs
+	}
+	return &ast.LogicalExpression{Operator: ast.OrOperator, Left: lhs, Right: rhs}
+}
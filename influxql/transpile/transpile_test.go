@@ -0,0 +1,184 @@
+package transpile_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/ast"
+	_ "github.com/influxdata/flux/builtin"
+	"github.com/influxdata/flux/influxql/transpile"
+)
+
+func TestTranspile(t *testing.T) {
+	testCases := []struct {
+		name   string
+		query  string
+		config transpile.Config
+		want   string
+	}{
+		{
+			name:  "raw field",
+			query: `SELECT usage_user FROM cpu WHERE host = 'a' AND time > now() - 1h`,
+			want: `from(bucket: "telegraf/autogen")
+	|> range(start: -1h)
+	|> filter(fn: (r) =>
+		(r._measurement == "cpu"))
+	|> filter(fn: (r) =>
+		(r.host == "a"))
+	|> filter(fn: (r) =>
+		(r._field == "usage_user"))
+	|> group(columns: ["_measurement", "_field"])
+	|> sort(columns: ["_time"])
+	|> group(columns: ["_measurement"])
+	|> pivot(rowKey: ["_time"], columnKey: ["_field"], valueColumn: "_value")
+	|> yield(name: "0")`,
+		},
+		{
+			name:  "group by time",
+			query: `SELECT count(usage_user) AS n FROM db0.rp0.cpu WHERE time >= '2019-01-01T00:00:00Z' AND time <= '2019-01-02T00:00:00Z' GROUP BY time(1h, 15m), host fill(0)`,
+			want: `from(bucket: "db0/rp0")
+	|> range(start: 2019-01-01T00:00:00Z, stop: 2019-01-02T00:00:00.000000001Z)
+	|> filter(fn: (r) =>
+		(r._measurement == "cpu"))
+	|> filter(fn: (r) =>
+		(r._field == "usage_user"))
+	|> group(columns: ["_measurement", "_field", "host"])
+	|> sort(columns: ["_time"])
+	|> window(every: 1h, offset: 15m, createEmpty: true)
+	|> count()
+	|> duplicate(column: "_start", as: "_time")
+	|> window(every: inf)
+	|> fill(value: 0)
+	|> set(key: "_field", value: "n")
+	|> group(columns: ["_measurement", "host"])
+	|> pivot(rowKey: ["_time"], columnKey: ["_field"], valueColumn: "_value")
+	|> yield(name: "0")`,
+		},
+		{
+			name:  "selector without group by time",
+			query: `SELECT max(usage_user) FROM cpu WHERE time > now() - 1d`,
+			config: transpile.Config{
+				DefaultDatabase: "db0",
+				Bucket: func(db, rp string) string {
+					return db
+				},
+			},
+			want: `from(bucket: "db0")
+	|> range(start: -1d)
+	|> filter(fn: (r) =>
+		(r._measurement == "cpu"))
+	|> filter(fn: (r) =>
+		(r._field == "usage_user"))
+	|> group(columns: ["_measurement", "_field"])
+	|> sort(columns: ["_time"])
+	|> max()
+	|> set(key: "_field", value: "max")
+	|> group(columns: ["_measurement"])
+	|> pivot(rowKey: ["_time"], columnKey: ["_field"], valueColumn: "_value")
+	|> yield(name: "0")`,
+		},
+		{
+			name:  "subquery",
+			query: `SELECT max(mean) FROM (SELECT mean(usage_user) FROM cpu GROUP BY time(1m), host) WHERE time > now() - 1h GROUP BY time(10m) ORDER BY time DESC LIMIT 10`,
+			want: `from(bucket: "telegraf/autogen")
+	|> range(start: -1h)
+	|> filter(fn: (r) =>
+		(r._measurement == "cpu"))
+	|> filter(fn: (r) =>
+		(r._field == "usage_user"))
+	|> group(columns: ["_measurement", "_field", "host"])
+	|> sort(columns: ["_time"])
+	|> window(every: 1m, createEmpty: true)
+	|> mean()
+	|> duplicate(column: "_start", as: "_time")
+	|> window(every: inf)
+	|> set(key: "_field", value: "mean")
+	|> filter(fn: (r) =>
+		(r._field == "mean"))
+	|> group(columns: ["_measurement", "_field"])
+	|> sort(columns: ["_time"])
+	|> window(every: 10m, createEmpty: true)
+	|> max()
+	|> drop(columns: ["_time"])
+	|> duplicate(column: "_start", as: "_time")
+	|> window(every: inf)
+	|> set(key: "_field", value: "max")
+	|> sort(columns: ["_time"], desc: true)
+	|> limit(n: 10)
+	|> group(columns: ["_measurement"])
+	|> pivot(rowKey: ["_time"], columnKey: ["_field"], valueColumn: "_value")
+	|> yield(name: "0")`,
+		},
+		{
+			name:  "wildcard",
+			query: `SELECT * FROM /cpu.*/, mem WHERE (host =~ /^a/ OR region != 'eu') GROUP BY *; SELECT "used percent" FROM mem`,
+			want: `from(bucket: "telegraf/autogen")
+	|> range(start: 1677-09-21T00:12:43.145224194Z)
+	|> filter(fn: (r) =>
+		(r._measurement =~ /cpu.*/ or r._measurement == "mem"))
+	|> filter(fn: (r) =>
+		(r.host =~ /^a/ or r.region != "eu"))
+	|> group(columns: ["_time", "_value", "_field", "_start", "_stop"], mode: "except")
+	|> pivot(rowKey: ["_time"], columnKey: ["_field"], valueColumn: "_value")
+	|> yield(name: "0")
+from(bucket: "telegraf/autogen")
+	|> range(start: 1677-09-21T00:12:43.145224194Z)
+	|> filter(fn: (r) =>
+		(r._measurement == "mem"))
+	|> filter(fn: (r) =>
+		(r._field == "used percent"))
+	|> group(columns: ["_measurement", "_field"])
+	|> sort(columns: ["_time"])
+	|> group(columns: ["_measurement"])
+	|> pivot(rowKey: ["_time"], columnKey: ["_field"], valueColumn: "_value")
+	|> yield(name: "1")`,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			config := tc.config
+			if config.DefaultDatabase == "" {
+				config.DefaultDatabase = "telegraf"
+			}
+			pkg, err := transpile.Transpile(tc.query, config)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := ast.Format(pkg.Files[0])
+			if !cmp.Equal(tc.want, got) {
+				t.Errorf("unexpected Flux -want/+got\n%s", cmp.Diff(tc.want, got))
+			}
+			if _, err := flux.Compile(context.Background(), got, time.Now()); err != nil {
+				t.Errorf("cannot compile the Flux of the query: %v", err)
+			}
+		})
+	}
+}
+
+func TestTranspile_Errors(t *testing.T) {
+	testCases := []struct {
+		name  string
+		query string
+	}{
+		{name: "syntax", query: `SELECT FROM cpu`},
+		{name: "unterminated string", query: `SELECT a FROM cpu WHERE host = 'a`},
+		{name: "unsupported function", query: `SELECT holt_winters(a, 1, 1) FROM cpu`},
+		{name: "mixed fields", query: `SELECT a, mean(b) FROM cpu`},
+		{name: "group by time of raw fields", query: `SELECT a FROM cpu GROUP BY time(1m)`},
+		{name: "time in or", query: `SELECT a FROM cpu WHERE host = 'a' OR time > now() - 1h`},
+		{name: "field condition", query: `SELECT a FROM cpu WHERE a::field > 1`},
+		{name: "fill linear", query: `SELECT mean(a) FROM cpu GROUP BY time(1m) fill(linear)`},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := transpile.Transpile(tc.query, transpile.Config{DefaultDatabase: "db"}); err == nil {
+				t.Fatal("expected an error")
+			}
+		})
+	}
+}
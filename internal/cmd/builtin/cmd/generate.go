@@ -13,6 +13,7 @@ import (
 
 	"github.com/dave/jennifer/jen"
 	"github.com/influxdata/flux/ast"
+	"github.com/influxdata/flux/docs"
 	"github.com/influxdata/flux/internal/token"
 	"github.com/influxdata/flux/parser"
 	"github.com/pkg/errors"
@@ -111,8 +112,12 @@ func generate(cmd *cobra.Command, args []string) error {
 			if goPath != pkgName {
 				goPackages = append(goPackages, goPath)
 			}
+			comments, err := docComments(dir, fluxPkg)
+			if err != nil {
+				return err
+			}
 			// Write the ast file
-			if err := generateFluxASTFile(dir, fluxPkg); err != nil {
+			if err := generateFluxASTFile(dir, fluxPkg, comments); err != nil {
 				return err
 			}
 		}
@@ -148,22 +153,98 @@ func generate(cmd *cobra.Command, args []string) error {
 	return f.Save(filepath.Join(rootDir, importFile))
 }
 
-func generateFluxASTFile(dir string, pkg *ast.Package) error {
+func generateFluxASTFile(dir string, pkg *ast.Package, comments docs.Comments) error {
 	file := jen.NewFile(pkg.Package)
 	file.HeaderComment("// DO NOT EDIT: This file is autogenerated via the builtin command.")
-	file.Func().Id("init").Call().Block(
-		jen.Qual("github.com/influxdata/flux", "RegisterPackage").
-			Call(jen.Id("pkgAST")),
-	)
+	hasComments := comments.Package != "" || len(comments.Values) > 0
+	file.Func().Id("init").Call().BlockFunc(func(g *jen.Group) {
+		g.Qual("github.com/influxdata/flux", "RegisterPackage").
+			Call(jen.Id("pkgAST"))
+		if hasComments {
+			g.Qual("github.com/influxdata/flux/docs", "RegisterComments").
+				Call(jen.Lit(pkg.Path), jen.Id("pkgComments"))
+		}
+	})
 	// Construct a value using reflection for the pkg AST
 	v, err := constructValue(reflect.ValueOf(pkg))
 	if err != nil {
 		return err
 	}
 	file.Var().Id("pkgAST").Op("=").Add(v)
+	if hasComments {
+		c, err := constructValue(reflect.ValueOf(comments))
+		if err != nil {
+			return err
+		}
+		file.Var().Id("pkgComments").Op("=").Add(c)
+	}
 	return file.Save(filepath.Join(dir, "flux_gen.go"))
 }
 
+// docComments returns the doc comments of a package.
+// The doc comment of the package is the comment that directly precedes a package clause.
+// The doc comment of a value is the comment that directly precedes the builtin statement,
+// the variable assignment or the option that defines the value,
+// and it must start with the name of the value, in any case,
+// so that comments that head a group of statements are left out.
+func docComments(dir string, pkg *ast.Package) (docs.Comments, error) {
+	var comments docs.Comments
+	for _, file := range pkg.Files {
+		src, err := ioutil.ReadFile(filepath.Join(dir, file.Name))
+		if err != nil {
+			return comments, err
+		}
+		lines := strings.Split(string(src), "\n")
+		if file.Package != nil {
+			if c := commentAbove(lines, file.Package.Location().Start.Line); c != "" {
+				comments.Package = c
+			}
+		}
+		for _, stmt := range file.Body {
+			var name string
+			switch s := stmt.(type) {
+			case *ast.BuiltinStatement:
+				name = s.ID.Name
+			case *ast.VariableAssignment:
+				name = s.ID.Name
+			case *ast.OptionStatement:
+				if a, ok := s.Assignment.(*ast.VariableAssignment); ok {
+					name = a.ID.Name
+				}
+			}
+			if name == "" {
+				continue
+			}
+			c := commentAbove(lines, stmt.Location().Start.Line)
+			if len(c) <= len(name) || !strings.EqualFold(c[:len(name)], name) || c[len(name)] != ' ' {
+				continue
+			}
+			if comments.Values == nil {
+				comments.Values = make(map[string]string)
+			}
+			comments.Values[name] = c
+		}
+	}
+	return comments, nil
+}
+
+// commentAbove returns the text of the line comments that directly precede a line numbered from 1.
+func commentAbove(lines []string, line int) string {
+	var text []string
+	for i := line - 2; i >= 0 && i < len(lines); i-- {
+		l := strings.TrimSpace(lines[i])
+		if !strings.HasPrefix(l, "//") {
+			break
+		}
+		text = append(text, strings.TrimSpace(strings.TrimPrefix(l, "//")))
+	}
+	// The lines were collected bottom up.
+	for i, j := 0, len(text)-1; i < j; i, j = i+1, j-1 {
+		text[i], text[j] = text[j], text[i]
+	}
+	return strings.Join(text, "\n")
+}
+
 func generateTestPkgList(imports []string) error {
 	stmts := make([]jen.Code, len(imports)+2)
 	// var pkgs []*ast.Package
@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+)
+
+// fuzzCorpusCmd represents the fuzzcorpus command
+var fuzzCorpusCmd = &cobra.Command{
+	Use:   "fuzzcorpus /path/to/corpus [path...]",
+	Short: "Build a fuzzing corpus from Flux sources",
+	Long: `This utility copies every .flux file found in the given paths into the corpus directory
+of a go-fuzz workdir, naming each file after the SHA-1 of its contents so that
+rebuilding the corpus does not duplicate inputs.
+The current directory is searched when no path is given.
+`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: buildCorpus,
+}
+
+func Execute() {
+	if err := fuzzCorpusCmd.Execute(); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+}
+
+func buildCorpus(cmd *cobra.Command, args []string) error {
+	corpus, paths := args[0], args[1:]
+	if len(paths) == 0 {
+		paths = []string{"."}
+	}
+	if err := os.MkdirAll(corpus, 0755); err != nil {
+		return err
+	}
+
+	n := 0
+	for _, root := range paths {
+		err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if info.IsDir() || filepath.Ext(path) != ".flux" {
+				return nil
+			}
+			src, err := ioutil.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			sum := sha1.Sum(src)
+			if err := ioutil.WriteFile(filepath.Join(corpus, hex.EncodeToString(sum[:])), src, 0644); err != nil {
+				return err
+			}
+			n++
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	fmt.Printf("added %d files to %s\n", n, corpus)
+	return nil
+}
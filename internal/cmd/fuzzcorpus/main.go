@@ -0,0 +1,7 @@
+package main
+
+import "github.com/influxdata/flux/internal/cmd/fuzzcorpus/cmd"
+
+func main() {
+	cmd.Execute()
+}
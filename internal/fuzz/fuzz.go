@@ -0,0 +1,86 @@
+// Package fuzz contains the fuzz targets of the Flux front-end.
+//
+// The targets follow the conventions of go-fuzz (https://github.com/dvyukov/go-fuzz):
+// they return 1 when the input was valid and should be prioritized in the corpus,
+// 0 otherwise, and panic when they find a bug.
+// A target is built and run with
+//
+//	go-fuzz-build -func Format github.com/influxdata/flux/internal/fuzz
+//	go-fuzz -bin fuzz-fuzz.zip -workdir /tmp/fuzz/format
+//
+// The initial corpus of a workdir is built from the Flux sources of the repository with
+//
+//	go run ./internal/cmd/fuzzcorpus /tmp/fuzz/format/corpus
+//
+// Inputs found by go-fuzz in the crashers directory of a workdir are turned into
+// regression tests by copying them to testdata/<target>, where <target> is
+// the lowercase name of the target that crashed.
+package fuzz
+
+import (
+	"fmt"
+
+	"github.com/influxdata/flux/ast"
+	"github.com/influxdata/flux/parser"
+	"github.com/influxdata/flux/semantic"
+)
+
+// Targets maps the names of the testdata directories to the targets they exercise.
+var Targets = map[string]func(data []byte) int{
+	"parse":  Parse,
+	"format": Format,
+	"infer":  Infer,
+}
+
+// Parse runs the parser on the input.
+func Parse(data []byte) int {
+	if _, ok := parse(data); !ok {
+		return 0
+	}
+	return 1
+}
+
+// Format checks that formatting the parsed input produces a script
+// that parses and formats to the same script again.
+func Format(data []byte) int {
+	pkg, ok := parse(data)
+	if !ok {
+		return 0
+	}
+	formatted := ast.Format(pkg.Files[0])
+	reparsed, ok := parse([]byte(formatted))
+	if !ok {
+		panic(fmt.Sprintf("formatted script does not parse: %v\nscript:\n%s", ast.GetError(reparsed), formatted))
+	}
+	if again := ast.Format(reparsed.Files[0]); again != formatted {
+		panic(fmt.Sprintf("formatting is not stable\nfirst:\n%s\nsecond:\n%s", formatted, again))
+	}
+	return 1
+}
+
+// Infer runs type inference on the semantic graph of the input
+// and resolves the type of every node of the graph.
+func Infer(data []byte) int {
+	pkg, ok := parse(data)
+	if !ok {
+		return 0
+	}
+	semPkg, err := semantic.New(pkg)
+	if err != nil {
+		return 0
+	}
+	sol, err := semantic.InferTypes(semPkg, nil)
+	if err != nil {
+		return 0
+	}
+	semantic.Walk(semantic.CreateVisitor(func(n semantic.Node) {
+		_, _ = sol.TypeOf(n)
+		_, _ = sol.PolyTypeOf(n)
+	}), semPkg)
+	return 1
+}
+
+func parse(data []byte) (*ast.Package, bool) {
+	pkg := parser.ParseSource(string(data))
+	return pkg, ast.Check(pkg) == 0
+}
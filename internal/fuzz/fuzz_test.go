@@ -0,0 +1,36 @@
+package fuzz_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/influxdata/flux/internal/fuzz"
+)
+
+// TestRegressions runs every fuzz target on the inputs of its testdata directory.
+func TestRegressions(t *testing.T) {
+	for name, target := range fuzz.Targets {
+		name, target := name, target
+		t.Run(name, func(t *testing.T) {
+			files, err := filepath.Glob(filepath.Join("testdata", name, "*"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, file := range files {
+				data, err := ioutil.ReadFile(file)
+				if err != nil {
+					t.Fatal(err)
+				}
+				t.Run(filepath.Base(file), func(t *testing.T) {
+					defer func() {
+						if r := recover(); r != nil {
+							t.Fatalf("input crashed the target: %v\ninput:\n%s", r, data)
+						}
+					}()
+					target(data)
+				})
+			}
+		})
+	}
+}
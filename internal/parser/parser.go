@@ -581,7 +581,12 @@ func (p *parser) parsePipeExpressionSuffix(expr *ast.Expression) func() bool {
 		}
 		// todo(jsternberg): this is not correct.
 		rhs := p.parseUnaryExpression()
-		call, _ := rhs.(*ast.CallExpression)
+		call, ok := rhs.(*ast.CallExpression)
+		if !ok {
+			p.errs = append(p.errs, ast.Error{
+				Msg: "pipe destination must be a function call",
+			})
+		}
 		*expr = &ast.PipeExpression{
 			Argument: *expr,
 			Call:     call,
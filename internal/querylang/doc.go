@@ -0,0 +1,7 @@
+// Package querylang implements the lexer, the expression parser and the conversion of the conditions
+// of WHERE clauses shared by the InfluxQL and SQL front ends.
+//
+// The languages differ in their keywords, comments, quoting and literals, which are described by a Dialect.
+// The front ends parse their own statements with a Parser and build the Flux of their expressions
+// with the helpers of this package.
+package querylang
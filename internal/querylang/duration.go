@@ -0,0 +1,152 @@
+package querylang
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/influxdata/flux/ast"
+)
+
+// DurationUnits maps the units of duration literals to the units of Flux durations.
+var DurationUnits = map[string]string{
+	"ns": "ns",
+	"u":  "us",
+	"µ":  "us",
+	"ms": "ms",
+	"s":  "s",
+	"m":  "m",
+	"h":  "h",
+	"d":  "d",
+	"w":  "w",
+}
+
+// fluxDurationUnits are the units of Flux durations from the longest to the shortest.
+var fluxDurationUnits = []struct {
+	unit   string
+	length time.Duration
+}{
+	{"w", 7 * 24 * time.Hour},
+	{"d", 24 * time.Hour},
+	{"h", time.Hour},
+	{"m", time.Minute},
+	{"s", time.Second},
+	{"ms", time.Millisecond},
+	{"us", time.Microsecond},
+	{"ns", time.Nanosecond},
+}
+
+// fluxUnitLength returns the length of a unit of Flux durations, zero if there is no such unit.
+func fluxUnitLength(unit string) time.Duration {
+	for _, u := range fluxDurationUnits {
+		if u.unit == unit {
+			return u.length
+		}
+	}
+	return 0
+}
+
+// ParseDuration parses a duration literal, such as 10m or -1h.
+func ParseDuration(lit string) (time.Duration, error) {
+	neg := strings.HasPrefix(lit, "-")
+	lit = strings.TrimPrefix(lit, "-")
+	i := strings.IndexFunc(lit, func(r rune) bool { return r < '0' || r > '9' })
+	if i <= 0 {
+		return 0, fmt.Errorf("invalid duration %q", lit)
+	}
+	n, err := strconv.ParseInt(lit[:i], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration %q", lit)
+	}
+	length := fluxUnitLength(DurationUnits[lit[i:]])
+	if length == 0 {
+		return 0, fmt.Errorf("invalid duration unit in %q", lit)
+	}
+	d := time.Duration(n) * length
+	if neg {
+		d = -d
+	}
+	return d, nil
+}
+
+// intervalUnits maps the units of SQL intervals to the units of Flux durations.
+var intervalUnits = map[string]string{
+	"week":        "w",
+	"day":         "d",
+	"hour":        "h",
+	"minute":      "m",
+	"second":      "s",
+	"millisecond": "ms",
+	"microsecond": "us",
+	"nanosecond":  "ns",
+}
+
+// ParseInterval parses the value of an INTERVAL literal,
+// a sequence of magnitudes and units such as '1 hour 30 minutes' or '1h30m'.
+func ParseInterval(lit string) (time.Duration, error) {
+	var d time.Duration
+	rest := strings.TrimSpace(lit)
+	if rest == "" {
+		return 0, fmt.Errorf("invalid interval %q", lit)
+	}
+	for rest != "" {
+		i := strings.IndexFunc(rest, func(r rune) bool { return r < '0' || r > '9' })
+		if i == 0 {
+			return 0, fmt.Errorf("invalid interval %q", lit)
+		} else if i < 0 {
+			return 0, fmt.Errorf("invalid interval %q, missing unit", lit)
+		}
+		n, err := strconv.ParseInt(rest[:i], 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid interval %q", lit)
+		}
+		rest = strings.TrimLeft(rest[i:], " ")
+		j := strings.IndexFunc(rest, func(r rune) bool { return r == ' ' || r >= '0' && r <= '9' })
+		if j < 0 {
+			j = len(rest)
+		}
+		unit := strings.ToLower(rest[:j])
+		rest = strings.TrimLeft(rest[j:], " ")
+		if u, ok := intervalUnits[strings.TrimSuffix(unit, "s")]; ok && len(unit) > 2 {
+			unit = u
+		}
+		length := fluxUnitLength(unit)
+		if length == 0 {
+			return 0, fmt.Errorf("invalid unit %q in interval %q", unit, lit)
+		}
+		d += time.Duration(n) * length
+	}
+	return d, nil
+}
+
+// DurationExpression returns the Flux duration of d, negated with a unary expression when d is negative.
+func DurationExpression(d time.Duration) ast.Expression {
+	neg := d < 0
+	if neg {
+		d = -d
+	}
+	lit := &ast.DurationLiteral{}
+	for _, u := range fluxDurationUnits {
+		if n := d / u.length; n > 0 {
+			lit.Values = append(lit.Values, ast.Duration{Magnitude: int64(n), Unit: u.unit})
+			d -= n * u.length
+		}
+	}
+	if len(lit.Values) == 0 {
+		lit.Values = []ast.Duration{{Magnitude: 0, Unit: "s"}}
+	}
+	if neg {
+		return &ast.UnaryExpression{Operator: ast.SubtractionOperator, Argument: lit}
+	}
+	return lit
+}
+
+// DurationLiteral returns the Flux duration of a duration literal.
+func DurationLiteral(lit string) (ast.Expression, error) {
+	d, err := ParseDuration(lit)
+	if err != nil {
+		return nil, err
+	}
+	return DurationExpression(d), nil
+}
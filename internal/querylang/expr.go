@@ -0,0 +1,103 @@
+package querylang
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Expr is an expression of a SELECT or WHERE clause.
+type Expr interface {
+	String() string
+}
+
+// Field is a column of the SELECT clause.
+type Field struct {
+	Expr  Expr
+	Alias string
+}
+
+// VarRef is a reference to a column, a field or a tag.
+type VarRef struct {
+	Name string
+	// Type is the optional type of the variable given with ::, such as "field" or "tag".
+	Type string
+}
+
+type Wildcard struct{}
+
+type Call struct {
+	Name string
+	Args []Expr
+}
+
+type BinaryExpr struct {
+	Op       Token
+	OpLit    string
+	LHS, RHS Expr
+}
+
+type NotExpr struct {
+	Expr Expr
+}
+
+type ParenExpr struct {
+	Expr Expr
+}
+
+type StringLit struct {
+	Value string
+}
+
+type NumberLit struct {
+	Lit     string
+	IsFloat bool
+}
+
+type BooleanLit struct {
+	Value bool
+}
+
+// DurationLit is a duration literal such as 10m.
+type DurationLit struct {
+	Lit string
+}
+
+type RegexLit struct {
+	Regexp *regexp.Regexp
+}
+
+// IntervalLit is an INTERVAL '1 hour' literal.
+type IntervalLit struct {
+	Lit string
+}
+
+// TimestampLit is a TIMESTAMP '2019-01-01 00:00:00' literal.
+type TimestampLit struct {
+	Lit string
+}
+
+func (v *VarRef) String() string {
+	if v.Type != "" {
+		return v.Name + "::" + v.Type
+	}
+	return v.Name
+}
+func (*Wildcard) String() string { return "*" }
+func (c *Call) String() string {
+	args := make([]string, len(c.Args))
+	for i, a := range c.Args {
+		args[i] = a.String()
+	}
+	return c.Name + "(" + strings.Join(args, ", ") + ")"
+}
+func (b *BinaryExpr) String() string   { return b.LHS.String() + " " + b.OpLit + " " + b.RHS.String() }
+func (n *NotExpr) String() string      { return "NOT " + n.Expr.String() }
+func (p *ParenExpr) String() string    { return "(" + p.Expr.String() + ")" }
+func (s *StringLit) String() string    { return "'" + s.Value + "'" }
+func (n *NumberLit) String() string    { return n.Lit }
+func (b *BooleanLit) String() string   { return strings.ToUpper(strconv.FormatBool(b.Value)) }
+func (d *DurationLit) String() string  { return d.Lit }
+func (r *RegexLit) String() string     { return "/" + r.Regexp.String() + "/" }
+func (i *IntervalLit) String() string  { return "INTERVAL '" + i.Lit + "'" }
+func (t *TimestampLit) String() string { return "TIMESTAMP '" + t.Lit + "'" }
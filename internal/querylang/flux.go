@@ -0,0 +1,114 @@
+package querylang
+
+import (
+	"fmt"
+
+	"github.com/influxdata/flux/ast"
+	"github.com/influxdata/flux/ast/astutil"
+)
+
+// Condition converts a WHERE clause into the body of a filter function.
+// The operands of the comparisons are converted with operand.
+// When bare is set, the operands that are conditions on their own, such as boolean columns,
+// are converted with operand too.
+func Condition(e Expr, operand func(Expr) (ast.Expression, error), bare bool) (ast.Expression, error) {
+	switch n := e.(type) {
+	case *ParenExpr:
+		return Condition(n.Expr, operand, bare)
+	case *NotExpr:
+		arg, err := Condition(n.Expr, operand, bare)
+		if err != nil {
+			return nil, err
+		}
+		return &ast.UnaryExpression{Operator: ast.NotOperator, Argument: arg}, nil
+	case *BinaryExpr:
+		if n.Op == KEYWORD {
+			lhs, err := Condition(n.LHS, operand, bare)
+			if err != nil {
+				return nil, err
+			}
+			rhs, err := Condition(n.RHS, operand, bare)
+			if err != nil {
+				return nil, err
+			}
+			op := ast.AndOperator
+			if n.OpLit == "OR" {
+				op = ast.OrOperator
+			}
+			return &ast.LogicalExpression{Operator: op, Left: lhs, Right: rhs}, nil
+		}
+		op, ok := comparisons[n.Op]
+		if !ok {
+			return nil, fmt.Errorf("unsupported condition %s", n)
+		}
+		lhs, err := operand(n.LHS)
+		if err != nil {
+			return nil, err
+		}
+		rhs, err := operand(n.RHS)
+		if err != nil {
+			return nil, err
+		}
+		return Compare(op, lhs, rhs), nil
+	case *VarRef, *BooleanLit:
+		if bare {
+			return operand(n)
+		}
+	}
+	return nil, fmt.Errorf("unsupported condition %s", e)
+}
+
+var comparisons = map[Token]ast.OperatorKind{
+	EQ:       ast.EqualOperator,
+	NEQ:      ast.NotEqualOperator,
+	LT:       ast.LessThanOperator,
+	LTE:      ast.LessThanEqualOperator,
+	GT:       ast.GreaterThanOperator,
+	GTE:      ast.GreaterThanEqualOperator,
+	EQREGEX:  ast.RegexpMatchOperator,
+	NEQREGEX: ast.NotRegexpMatchOperator,
+}
+
+// Column returns the expression that reads a column of the record r of a function.
+func Column(name string) ast.Expression {
+	var property ast.PropertyKey = &ast.Identifier{Name: name}
+	if !IsIdentifier(name) {
+		property = &ast.StringLiteral{Value: name}
+	}
+	return &ast.MemberExpression{
+		Object:   &ast.Identifier{Name: "r"},
+		Property: property,
+	}
+}
+
+// IsIdentifier reports whether name can be written as a Flux identifier.
+func IsIdentifier(name string) bool {
+	for i, r := range name {
+		if !(r == '_' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || i > 0 && r >= '0' && r <= '9') {
+			return false
+		}
+	}
+	return name != ""
+}
+
+// Compare returns the comparison of lhs and rhs with op.
+func Compare(op ast.OperatorKind, lhs, rhs ast.Expression) ast.Expression {
+	return &ast.BinaryExpression{Operator: op, Left: lhs, Right: rhs}
+}
+
+// Filter returns the call to filter whose function has the given body.
+func Filter(body ast.Expression) *ast.CallExpression {
+	return astutil.Call("filter", astutil.Property("fn", &ast.FunctionExpression{
+		Params: []*ast.Property{{Key: &ast.Identifier{Name: "r"}}},
+		Body:   body,
+	}))
+}
+
+// StringArray returns the array of the strings strs.
+func StringArray(strs ...string) *ast.ArrayExpression {
+	array := &ast.ArrayExpression{Elements: make([]ast.Expression, len(strs))}
+	for i, s := range strs {
+		array.Elements[i] = &ast.StringLiteral{Value: s}
+	}
+	return array
+}
@@ -0,0 +1,364 @@
+package querylang
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Parser reads the tokens of a query and parses the expressions and lists its clauses are made of.
+// The front ends parse their statements with it.
+type Parser struct {
+	s   *Scanner
+	cur Item
+	// buf is a token that was read ahead and unscanned.
+	buf *Item
+}
+
+// NewParser returns a parser of the query in the language of the dialect.
+func NewParser(query string, dialect *Dialect) *Parser {
+	return &Parser{s: NewScanner(query, dialect)}
+}
+
+// ParseStatements parses the statements of a query, separated by semicolons, with parse.
+func (p *Parser) ParseStatements(parse func() error) error {
+	n := 0
+	for {
+		it, err := p.Next()
+		if err != nil {
+			return err
+		}
+		switch it.Tok {
+		case EOF:
+			if n == 0 {
+				return fmt.Errorf("query has no statement")
+			}
+			return nil
+		case SEMICOLON:
+			continue
+		}
+		p.Unscan()
+		if err := parse(); err != nil {
+			return err
+		}
+		n++
+		if it, err := p.Next(); err != nil {
+			return err
+		} else if it.Tok != SEMICOLON && it.Tok != EOF {
+			return p.Unexpected(it, "; or end of query")
+		} else if it.Tok == EOF {
+			p.Unscan()
+		}
+	}
+}
+
+// Next reads the next token.
+func (p *Parser) Next() (Item, error) {
+	if p.buf != nil {
+		p.cur, p.buf = *p.buf, nil
+		return p.cur, nil
+	}
+	it, err := p.s.Scan()
+	if err != nil {
+		return Item{}, err
+	}
+	p.cur = it
+	return it, nil
+}
+
+// Unscan pushes back the last token returned by Next.
+func (p *Parser) Unscan() {
+	cur := p.cur
+	p.buf = &cur
+}
+
+// Unexpected returns the error of an unexpected token.
+func (p *Parser) Unexpected(it Item, expected string) error {
+	return fmt.Errorf("found %v at position %d, expected %s", it, it.Pos, expected)
+}
+
+// Expect reads a token of the given kind, with the given literal for keywords.
+func (p *Parser) Expect(tok Token, lit string) (Item, error) {
+	it, err := p.Next()
+	if err != nil {
+		return Item{}, err
+	}
+	if it.Tok != tok || tok == KEYWORD && it.Lit != lit {
+		if lit == "" {
+			lit = tokenNames[tok]
+		}
+		return Item{}, p.Unexpected(it, lit)
+	}
+	return it, nil
+}
+
+// Accept reads the keyword kw if it is the next token.
+func (p *Parser) Accept(kw string) (bool, error) {
+	it, err := p.Next()
+	if err != nil {
+		return false, err
+	}
+	if it.Tok == KEYWORD && it.Lit == kw {
+		return true, nil
+	}
+	p.Unscan()
+	return false, nil
+}
+
+// acceptComma reads a comma if it is the next token.
+func (p *Parser) acceptComma() (bool, error) {
+	it, err := p.Next()
+	if err != nil {
+		return false, err
+	}
+	if it.Tok != COMMA {
+		p.Unscan()
+		return false, nil
+	}
+	return true, nil
+}
+
+// ParseFields parses the columns of a SELECT clause, with their optional aliases.
+func (p *Parser) ParseFields() ([]*Field, error) {
+	var fields []*Field
+	for {
+		e, err := p.ParseExpr()
+		if err != nil {
+			return nil, err
+		}
+		f := &Field{Expr: e}
+		if ok, err := p.Accept("AS"); err != nil {
+			return nil, err
+		} else if ok {
+			it, err := p.Expect(IDENT, "")
+			if err != nil {
+				return nil, err
+			}
+			f.Alias = it.Lit
+		}
+		fields = append(fields, f)
+
+		if ok, err := p.acceptComma(); err != nil {
+			return nil, err
+		} else if !ok {
+			return fields, nil
+		}
+	}
+}
+
+// ParseIdentList parses a list of identifiers separated by commas.
+func (p *Parser) ParseIdentList() ([]string, error) {
+	var idents []string
+	for {
+		it, err := p.Expect(IDENT, "")
+		if err != nil {
+			return nil, err
+		}
+		idents = append(idents, it.Lit)
+		if ok, err := p.acceptComma(); err != nil {
+			return nil, err
+		} else if !ok {
+			return idents, nil
+		}
+	}
+}
+
+// ParseInt parses a non-negative integer.
+func (p *Parser) ParseInt() (int, error) {
+	it, err := p.Expect(NUMBER, "")
+	if err != nil {
+		return 0, err
+	}
+	n, err := strconv.Atoi(it.Lit)
+	if err != nil {
+		return 0, fmt.Errorf("invalid integer %q", it.Lit)
+	}
+	return n, nil
+}
+
+// Precedences of the operators, NOT binds tighter than AND and looser than comparisons.
+const (
+	precedenceOr = iota + 1
+	precedenceAnd
+	precedenceNot
+	precedenceComparison
+	precedenceAdditive
+	precedenceMultiplicative
+)
+
+// binaryPrecedence returns the precedence of a binary operator, 0 if tok is not one.
+func binaryPrecedence(it Item) int {
+	switch it.Tok {
+	case KEYWORD:
+		switch it.Lit {
+		case "OR":
+			return precedenceOr
+		case "AND":
+			return precedenceAnd
+		}
+	case EQ, NEQ, LT, LTE, GT, GTE, EQREGEX, NEQREGEX:
+		return precedenceComparison
+	case PLUS, MINUS:
+		return precedenceAdditive
+	case STAR, DIV:
+		return precedenceMultiplicative
+	}
+	return 0
+}
+
+// ParseExpr parses an expression.
+func (p *Parser) ParseExpr() (Expr, error) {
+	return p.parseBinary(precedenceOr)
+}
+
+// parseBinary parses binary expressions whose operators have at least the given precedence.
+func (p *Parser) parseBinary(precedence int) (Expr, error) {
+	lhs, err := p.parseUnary(precedence)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		it, err := p.Next()
+		if err != nil {
+			return nil, err
+		}
+		prec := binaryPrecedence(it)
+		if prec == 0 || prec < precedence {
+			p.Unscan()
+			return lhs, nil
+		}
+		rhs, err := p.parseBinary(prec + 1)
+		if err != nil {
+			return nil, err
+		}
+		lhs = &BinaryExpr{Op: it.Tok, OpLit: it.Lit, LHS: lhs, RHS: rhs}
+	}
+}
+
+// ParseUnary parses an operand of a binary expression.
+func (p *Parser) ParseUnary() (Expr, error) {
+	return p.parseUnary(precedenceOr)
+}
+
+// parseUnary parses an operand of a binary expression whose operators have at least the given precedence.
+func (p *Parser) parseUnary(precedence int) (Expr, error) {
+	it, err := p.Next()
+	if err != nil {
+		return nil, err
+	}
+	switch it.Tok {
+	case LPAREN:
+		e, err := p.ParseExpr()
+		if err != nil {
+			return nil, err
+		}
+		if _, err := p.Expect(RPAREN, ""); err != nil {
+			return nil, err
+		}
+		return &ParenExpr{Expr: e}, nil
+	case STAR:
+		return &Wildcard{}, nil
+	case STRING:
+		return &StringLit{Value: it.Lit}, nil
+	case NUMBER:
+		return &NumberLit{Lit: it.Lit, IsFloat: strings.ContainsRune(it.Lit, '.')}, nil
+	case MINUS:
+		next, err := p.Next()
+		if err != nil {
+			return nil, err
+		}
+		switch next.Tok {
+		case NUMBER:
+			return &NumberLit{Lit: "-" + next.Lit, IsFloat: strings.ContainsRune(next.Lit, '.')}, nil
+		case DURATION:
+			return &DurationLit{Lit: "-" + next.Lit}, nil
+		}
+		if p.s.dialect.Durations {
+			return nil, p.Unexpected(next, "number or duration")
+		}
+		return nil, p.Unexpected(next, "number")
+	case DURATION:
+		return &DurationLit{Lit: it.Lit}, nil
+	case REGEX:
+		re, err := regexp.Compile(it.Lit)
+		if err != nil {
+			return nil, err
+		}
+		return &RegexLit{Regexp: re}, nil
+	case KEYWORD:
+		switch it.Lit {
+		case "NOT":
+			if precedence > precedenceNot {
+				break
+			}
+			e, err := p.parseBinary(precedenceNot)
+			if err != nil {
+				return nil, err
+			}
+			return &NotExpr{Expr: e}, nil
+		case "TRUE", "FALSE":
+			return &BooleanLit{Value: it.Lit == "TRUE"}, nil
+		case "INTERVAL":
+			lit, err := p.Expect(STRING, "")
+			if err != nil {
+				return nil, err
+			}
+			return &IntervalLit{Lit: lit.Lit}, nil
+		case "TIMESTAMP":
+			lit, err := p.Expect(STRING, "")
+			if err != nil {
+				return nil, err
+			}
+			return &TimestampLit{Lit: lit.Lit}, nil
+		}
+	case IDENT:
+		next, err := p.Next()
+		if err != nil {
+			return nil, err
+		}
+		switch next.Tok {
+		case LPAREN:
+			return p.parseCall(it.Lit)
+		case DOUBLECOLON:
+			typ, err := p.Expect(IDENT, "")
+			if err != nil {
+				return nil, err
+			}
+			return &VarRef{Name: it.Lit, Type: strings.ToLower(typ.Lit)}, nil
+		}
+		p.Unscan()
+		return &VarRef{Name: it.Lit}, nil
+	}
+	return nil, p.Unexpected(it, "expression")
+}
+
+func (p *Parser) parseCall(name string) (Expr, error) {
+	c := &Call{Name: strings.ToLower(name)}
+	it, err := p.Next()
+	if err != nil {
+		return nil, err
+	}
+	if it.Tok == RPAREN {
+		return c, nil
+	}
+	p.Unscan()
+	for {
+		arg, err := p.ParseExpr()
+		if err != nil {
+			return nil, err
+		}
+		c.Args = append(c.Args, arg)
+		it, err := p.Next()
+		if err != nil {
+			return nil, err
+		}
+		switch it.Tok {
+		case RPAREN:
+			return c, nil
+		case COMMA:
+		default:
+			return nil, p.Unexpected(it, ", or )")
+		}
+	}
+}
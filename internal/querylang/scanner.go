@@ -0,0 +1,220 @@
+package querylang
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Dialect describes the lexical differences between the languages of the front ends.
+type Dialect struct {
+	// Keywords are the reserved words of the language, in upper case.
+	Keywords map[string]bool
+	// LineComment starts the comments that run to the end of the line, none when empty.
+	LineComment string
+	// DoubledQuotes escapes the quotes of literals by doubling them rather than with a backslash.
+	DoubledQuotes bool
+	// Regexes enables the regex literals delimited by slashes.
+	Regexes bool
+	// Durations enables the duration literals, numbers followed by one of DurationUnits.
+	Durations bool
+	// TypeCasts enables the types given to variables with ::, as in usage::field.
+	TypeCasts bool
+}
+
+// Scanner splits a query into tokens.
+type Scanner struct {
+	dialect *Dialect
+	src     []rune
+	pos     int
+	// prev is the previous token, used to tell a regex from a division.
+	prev Token
+}
+
+// NewScanner returns a scanner of the query in the language of the dialect.
+func NewScanner(src string, dialect *Dialect) *Scanner {
+	return &Scanner{dialect: dialect, src: []rune(src), prev: ILLEGAL}
+}
+
+func (s *Scanner) peekRune(offset int) rune {
+	if s.pos+offset >= len(s.src) {
+		return 0
+	}
+	return s.src[s.pos+offset]
+}
+
+// Scan returns the next token, skipping spaces and comments.
+func (s *Scanner) Scan() (Item, error) {
+	for s.pos < len(s.src) {
+		if unicode.IsSpace(s.src[s.pos]) {
+			s.pos++
+		} else if s.atLineComment() {
+			for s.pos < len(s.src) && s.src[s.pos] != '\n' {
+				s.pos++
+			}
+		} else {
+			break
+		}
+	}
+	it, err := s.scanToken()
+	if err == nil {
+		s.prev = it.Tok
+	}
+	return it, err
+}
+
+func (s *Scanner) atLineComment() bool {
+	c := []rune(s.dialect.LineComment)
+	if len(c) == 0 || s.pos+len(c) > len(s.src) {
+		return false
+	}
+	return string(s.src[s.pos:s.pos+len(c)]) == string(c)
+}
+
+func (s *Scanner) scanToken() (Item, error) {
+	start := s.pos
+	if s.pos >= len(s.src) {
+		return Item{Tok: EOF, Pos: start}, nil
+	}
+	r := s.src[s.pos]
+	single := func(tok Token) (Item, error) {
+		s.pos++
+		return Item{Tok: tok, Lit: string(r), Pos: start}, nil
+	}
+	double := func(tok Token) (Item, error) {
+		s.pos += 2
+		return Item{Tok: tok, Lit: string(s.src[start:s.pos]), Pos: start}, nil
+	}
+	switch {
+	case r == '"':
+		lit, err := s.scanQuoted('"')
+		return Item{Tok: IDENT, Lit: lit, Pos: start}, err
+	case r == '\'':
+		lit, err := s.scanQuoted('\'')
+		return Item{Tok: STRING, Lit: lit, Pos: start}, err
+	case r == '/' && s.dialect.Regexes && s.regexAllowed():
+		lit, err := s.scanQuoted('/')
+		return Item{Tok: REGEX, Lit: lit, Pos: start}, err
+	case unicode.IsDigit(r) || r == '.' && unicode.IsDigit(s.peekRune(1)):
+		return s.scanNumber()
+	case unicode.IsLetter(r) || r == '_':
+		for s.pos < len(s.src) && (unicode.IsLetter(s.src[s.pos]) || unicode.IsDigit(s.src[s.pos]) || s.src[s.pos] == '_') {
+			s.pos++
+		}
+		lit := string(s.src[start:s.pos])
+		if s.dialect.Keywords[strings.ToUpper(lit)] {
+			return Item{Tok: KEYWORD, Lit: strings.ToUpper(lit), Pos: start}, nil
+		}
+		return Item{Tok: IDENT, Lit: lit, Pos: start}, nil
+	}
+	switch r {
+	case ',':
+		return single(COMMA)
+	case ';':
+		return single(SEMICOLON)
+	case '.':
+		return single(DOT)
+	case '(':
+		return single(LPAREN)
+	case ')':
+		return single(RPAREN)
+	case '*':
+		return single(STAR)
+	case '+':
+		return single(PLUS)
+	case '-':
+		return single(MINUS)
+	case '/':
+		return single(DIV)
+	case ':':
+		if s.dialect.TypeCasts && s.peekRune(1) == ':' {
+			return double(DOUBLECOLON)
+		}
+	case '=':
+		if s.dialect.Regexes && s.peekRune(1) == '~' {
+			return double(EQREGEX)
+		}
+		return single(EQ)
+	case '!':
+		switch {
+		case s.peekRune(1) == '=':
+			return double(NEQ)
+		case s.dialect.Regexes && s.peekRune(1) == '~':
+			return double(NEQREGEX)
+		}
+	case '<':
+		switch s.peekRune(1) {
+		case '=':
+			return double(LTE)
+		case '>':
+			return double(NEQ)
+		}
+		return single(LT)
+	case '>':
+		if s.peekRune(1) == '=' {
+			return double(GTE)
+		}
+		return single(GT)
+	}
+	return Item{}, fmt.Errorf("unexpected character %q at position %d", r, start)
+}
+
+// regexAllowed reports whether a slash starts a regex rather than being a division,
+// which is the case when it cannot follow an operand.
+func (s *Scanner) regexAllowed() bool {
+	switch s.prev {
+	case IDENT, STRING, NUMBER, DURATION, REGEX, RPAREN, STAR:
+		return false
+	}
+	return true
+}
+
+// scanQuoted scans a literal delimited by quote.
+// The quote is escaped by doubling it, or with a backslash, as is the backslash outside of regexes.
+func (s *Scanner) scanQuoted(quote rune) (string, error) {
+	start := s.pos
+	s.pos++
+	var b strings.Builder
+	for s.pos < len(s.src) {
+		r := s.src[s.pos]
+		switch {
+		case r == quote && s.dialect.DoubledQuotes && s.peekRune(1) == quote:
+			b.WriteRune(quote)
+			s.pos += 2
+		case r == quote:
+			s.pos++
+			return b.String(), nil
+		case !s.dialect.DoubledQuotes && r == '\\' && s.peekRune(1) == quote:
+			b.WriteRune(quote)
+			s.pos += 2
+		case !s.dialect.DoubledQuotes && r == '\\' && s.peekRune(1) == '\\' && quote != '/':
+			b.WriteRune('\\')
+			s.pos += 2
+		default:
+			b.WriteRune(r)
+			s.pos++
+		}
+	}
+	return "", fmt.Errorf("unterminated literal starting at position %d", start)
+}
+
+// scanNumber scans a number, or a duration when the number is followed by a unit.
+func (s *Scanner) scanNumber() (Item, error) {
+	start := s.pos
+	for s.pos < len(s.src) && (unicode.IsDigit(s.src[s.pos]) || s.src[s.pos] == '.') {
+		s.pos++
+	}
+	num := s.pos
+	if s.dialect.Durations {
+		for s.pos < len(s.src) && unicode.IsLetter(s.src[s.pos]) {
+			s.pos++
+		}
+	}
+	if s.pos == num {
+		return Item{Tok: NUMBER, Lit: string(s.src[start:s.pos]), Pos: start}, nil
+	}
+	if _, ok := DurationUnits[string(s.src[num:s.pos])]; !ok || strings.ContainsRune(string(s.src[start:num]), '.') {
+		return Item{}, fmt.Errorf("invalid duration %q at position %d", string(s.src[start:s.pos]), start)
+	}
+	return Item{Tok: DURATION, Lit: string(s.src[start:s.pos]), Pos: start}, nil
+}
@@ -0,0 +1,147 @@
+package querylang_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/influxdata/flux/internal/querylang"
+)
+
+var (
+	influxql = &querylang.Dialect{
+		Keywords:  map[string]bool{"SELECT": true, "FROM": true},
+		Regexes:   true,
+		Durations: true,
+		TypeCasts: true,
+	}
+	sql = &querylang.Dialect{
+		Keywords:      map[string]bool{"SELECT": true, "FROM": true},
+		LineComment:   "--",
+		DoubledQuotes: true,
+	}
+)
+
+func TestScanner(t *testing.T) {
+	testCases := []struct {
+		name    string
+		src     string
+		dialect *querylang.Dialect
+		want    []querylang.Item
+	}{
+		{
+			name:    "keywords and identifiers",
+			src:     `select "my field" from cpu`,
+			dialect: sql,
+			want: []querylang.Item{
+				{Tok: querylang.KEYWORD, Lit: "SELECT", Pos: 0},
+				{Tok: querylang.IDENT, Lit: "my field", Pos: 7},
+				{Tok: querylang.KEYWORD, Lit: "FROM", Pos: 18},
+				{Tok: querylang.IDENT, Lit: "cpu", Pos: 23},
+			},
+		},
+		{
+			name:    "doubled quotes",
+			src:     `'it''s'`,
+			dialect: sql,
+			want:    []querylang.Item{{Tok: querylang.STRING, Lit: "it's", Pos: 0}},
+		},
+		{
+			name:    "escaped quotes",
+			src:     `'it\'s'`,
+			dialect: influxql,
+			want:    []querylang.Item{{Tok: querylang.STRING, Lit: "it's", Pos: 0}},
+		},
+		{
+			name:    "line comment",
+			src:     "a -- b\n- c",
+			dialect: sql,
+			want: []querylang.Item{
+				{Tok: querylang.IDENT, Lit: "a", Pos: 0},
+				{Tok: querylang.MINUS, Lit: "-", Pos: 7},
+				{Tok: querylang.IDENT, Lit: "c", Pos: 9},
+			},
+		},
+		{
+			name:    "durations",
+			src:     "10m 1.5",
+			dialect: influxql,
+			want: []querylang.Item{
+				{Tok: querylang.DURATION, Lit: "10m", Pos: 0},
+				{Tok: querylang.NUMBER, Lit: "1.5", Pos: 4},
+			},
+		},
+		{
+			name:    "no durations",
+			src:     "10m",
+			dialect: sql,
+			want: []querylang.Item{
+				{Tok: querylang.NUMBER, Lit: "10", Pos: 0},
+				{Tok: querylang.IDENT, Lit: "m", Pos: 2},
+			},
+		},
+		{
+			name:    "regexes and type casts",
+			src:     "host =~ /a.*/ / usage::field",
+			dialect: influxql,
+			want: []querylang.Item{
+				{Tok: querylang.IDENT, Lit: "host", Pos: 0},
+				{Tok: querylang.EQREGEX, Lit: "=~", Pos: 5},
+				{Tok: querylang.REGEX, Lit: "a.*", Pos: 8},
+				{Tok: querylang.DIV, Lit: "/", Pos: 14},
+				{Tok: querylang.IDENT, Lit: "usage", Pos: 16},
+				{Tok: querylang.DOUBLECOLON, Lit: "::", Pos: 21},
+				{Tok: querylang.IDENT, Lit: "field", Pos: 23},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			s := querylang.NewScanner(tc.src, tc.dialect)
+			var got []querylang.Item
+			for {
+				it, err := s.Scan()
+				if err != nil {
+					t.Fatal(err)
+				}
+				if it.Tok == querylang.EOF {
+					break
+				}
+				got = append(got, it)
+			}
+			if !cmp.Equal(tc.want, got) {
+				t.Errorf("unexpected tokens -want/+got\n%s", cmp.Diff(tc.want, got))
+			}
+		})
+	}
+}
+
+func TestScanner_Errors(t *testing.T) {
+	testCases := []struct {
+		name    string
+		src     string
+		dialect *querylang.Dialect
+	}{
+		{name: "regex operator in SQL", src: "a !~ b", dialect: sql},
+		{name: "type cast in SQL", src: "a::field", dialect: sql},
+		{name: "invalid duration", src: "10x", dialect: influxql},
+		{name: "unterminated string", src: "'a", dialect: sql},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			s := querylang.NewScanner(tc.src, tc.dialect)
+			for {
+				it, err := s.Scan()
+				if err != nil {
+					return
+				}
+				if it.Tok == querylang.EOF {
+					break
+				}
+			}
+			t.Fatal("expected an error")
+		})
+	}
+}
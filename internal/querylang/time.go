@@ -0,0 +1,218 @@
+package querylang
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/influxdata/flux/ast"
+	"github.com/influxdata/flux/ast/astutil"
+)
+
+// TimeColumn is the name of the column of the time of the points in the queries.
+const TimeColumn = "time"
+
+// minTime is the start of the range of a statement without a lower time bound, as in InfluxQL.
+var minTime = time.Unix(0, -9223372036854775806).UTC()
+
+// TimeRange is the time range of a statement, given by the conditions on the time of its WHERE clause.
+type TimeRange struct {
+	// Start and Stop are nil when unbounded.
+	Start, Stop ast.Expression
+}
+
+// Call returns the call to range that reads the time range.
+func (tr TimeRange) Call() *ast.CallExpression {
+	start := tr.Start
+	if start == nil {
+		start = &ast.DateTimeLiteral{Value: minTime}
+	}
+	props := []*ast.Property{astutil.Property("start", start)}
+	if tr.Stop != nil {
+		props = append(props, astutil.Property("stop", tr.Stop))
+	}
+	return astutil.Call("range", props...)
+}
+
+// SplitCondition splits the conditions on the time from the rest of a WHERE clause.
+// The conditions on the time must be combined with the rest of the clause with AND.
+func SplitCondition(e Expr) (TimeRange, Expr, error) {
+	var tr TimeRange
+	var split func(e Expr) (Expr, error)
+	split = func(e Expr) (Expr, error) {
+		switch n := e.(type) {
+		case nil:
+			return nil, nil
+		case *ParenExpr:
+			if !refersToTime(n.Expr) {
+				return n, nil
+			}
+			return split(n.Expr)
+		case *BinaryExpr:
+			if n.Op == KEYWORD && n.OpLit == "AND" {
+				lhs, err := split(n.LHS)
+				if err != nil {
+					return nil, err
+				}
+				rhs, err := split(n.RHS)
+				if err != nil {
+					return nil, err
+				}
+				switch {
+				case lhs == nil:
+					return rhs, nil
+				case rhs == nil:
+					return lhs, nil
+				}
+				return &BinaryExpr{Op: n.Op, OpLit: n.OpLit, LHS: lhs, RHS: rhs}, nil
+			}
+			if IsTime(n.LHS) {
+				return nil, tr.add(n.Op, n.OpLit, n.RHS)
+			}
+			if IsTime(n.RHS) {
+				return nil, tr.add(flip(n.Op), n.OpLit, n.LHS)
+			}
+		}
+		if refersToTime(e) {
+			return nil, fmt.Errorf("invalid condition on the time %s, the conditions on the time must be combined with AND", e)
+		}
+		return e, nil
+	}
+	rest, err := split(e)
+	return tr, rest, err
+}
+
+// add restricts the time range with the condition `time op v`.
+func (tr *TimeRange) add(op Token, opLit string, v Expr) error {
+	tv, err := evalTime(v)
+	if err != nil {
+		return err
+	}
+	switch op {
+	case GT:
+		tr.Start = tv.expression(time.Nanosecond)
+	case GTE:
+		tr.Start = tv.expression(0)
+	case LT:
+		tr.Stop = tv.expression(0)
+	case LTE:
+		tr.Stop = tv.expression(time.Nanosecond)
+	case EQ:
+		tr.Start, tr.Stop = tv.expression(0), tv.expression(time.Nanosecond)
+	default:
+		return fmt.Errorf("unsupported operator %s in a condition on the time", opLit)
+	}
+	return nil
+}
+
+// flip returns the operator of a comparison whose operands are swapped.
+func flip(op Token) Token {
+	switch op {
+	case GT:
+		return LT
+	case GTE:
+		return LTE
+	case LT:
+		return GT
+	case LTE:
+		return GTE
+	}
+	return op
+}
+
+// IsTime reports whether e is a reference to the time column.
+func IsTime(e Expr) bool {
+	ref, ok := e.(*VarRef)
+	return ok && strings.EqualFold(ref.Name, TimeColumn)
+}
+
+func refersToTime(e Expr) bool {
+	switch n := e.(type) {
+	case *VarRef:
+		return IsTime(n)
+	case *ParenExpr:
+		return refersToTime(n.Expr)
+	case *NotExpr:
+		return refersToTime(n.Expr)
+	case *BinaryExpr:
+		return refersToTime(n.LHS) || refersToTime(n.RHS)
+	}
+	return false
+}
+
+// timeValue is a time of a condition, either absolute or relative to now.
+type timeValue struct {
+	now    bool
+	offset time.Duration
+	abs    time.Time
+}
+
+// expression returns the expression of the time moved by shift.
+// A shift of a time relative to now is ignored, since it would only matter for the current nanosecond.
+func (tv timeValue) expression(shift time.Duration) ast.Expression {
+	if !tv.now {
+		return &ast.DateTimeLiteral{Value: tv.abs.Add(shift).UTC()}
+	}
+	if tv.offset == 0 {
+		return astutil.Call("now")
+	}
+	return DurationExpression(tv.offset)
+}
+
+var timeLayouts = []string{time.RFC3339Nano, "2006-01-02 15:04:05.999999999", "2006-01-02"}
+
+// evalTime evaluates the time of a condition: now(), a time string, a timestamp, a number of nanoseconds
+// since the epoch, or one of them plus or minus durations or intervals.
+func evalTime(e Expr) (timeValue, error) {
+	var lit string
+	switch n := e.(type) {
+	case *ParenExpr:
+		return evalTime(n.Expr)
+	case *Call:
+		if n.Name == "now" && len(n.Args) == 0 {
+			return timeValue{now: true}, nil
+		}
+	case *StringLit:
+		lit = n.Value
+	case *TimestampLit:
+		lit = n.Lit
+	case *NumberLit:
+		if ns, err := strconv.ParseInt(n.Lit, 10, 64); err == nil {
+			return timeValue{abs: time.Unix(0, ns).UTC()}, nil
+		}
+	case *BinaryExpr:
+		if n.Op == PLUS || n.Op == MINUS {
+			tv, err := evalTime(n.LHS)
+			if err != nil {
+				return timeValue{}, err
+			}
+			var d time.Duration
+			switch rhs := n.RHS.(type) {
+			case *DurationLit:
+				d, err = ParseDuration(rhs.Lit)
+			case *IntervalLit:
+				d, err = ParseInterval(rhs.Lit)
+			default:
+				return timeValue{}, fmt.Errorf("invalid time %s, only durations can be added to times", n)
+			}
+			if err != nil {
+				return timeValue{}, err
+			}
+			if n.Op == MINUS {
+				d = -d
+			}
+			tv.offset += d
+			tv.abs = tv.abs.Add(d)
+			return tv, nil
+		}
+	}
+	if lit != "" {
+		for _, layout := range timeLayouts {
+			if t, err := time.Parse(layout, lit); err == nil {
+				return timeValue{abs: t}, nil
+			}
+		}
+	}
+	return timeValue{}, fmt.Errorf("invalid time %s", e)
+}
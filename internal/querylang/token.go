@@ -0,0 +1,65 @@
+package querylang
+
+import "fmt"
+
+// Token is the kind of a lexical token.
+type Token int
+
+const (
+	ILLEGAL Token = iota
+	EOF
+
+	IDENT    // cpu, "my field"
+	STRING   // 'value'
+	NUMBER   // 10, 1.5
+	DURATION // 10m
+	REGEX    // /cpu.*/
+
+	COMMA       // ,
+	SEMICOLON   // ;
+	DOT         // .
+	DOUBLECOLON // ::
+	LPAREN      // (
+	RPAREN      // )
+	STAR        // *
+	PLUS        // +
+	MINUS       // -
+	DIV         // /
+	EQ          // =
+	NEQ         // != or <>
+	LT          // <
+	LTE         // <=
+	GT          // >
+	GTE         // >=
+	EQREGEX     // =~
+	NEQREGEX    // !~
+
+	KEYWORD // SELECT, FROM, ...
+)
+
+// tokenNames are the names of the tokens in the errors of the parser.
+var tokenNames = map[Token]string{
+	IDENT:       "identifier",
+	STRING:      "string",
+	NUMBER:      "number",
+	DURATION:    "duration",
+	REGEX:       "regex",
+	COMMA:       ",",
+	LPAREN:      "(",
+	RPAREN:      ")",
+	DOUBLECOLON: "::",
+}
+
+// Item is a token with its literal text and its position in the query.
+type Item struct {
+	Tok Token
+	Lit string
+	Pos int
+}
+
+func (i Item) String() string {
+	if i.Tok == EOF {
+		return "end of query"
+	}
+	return fmt.Sprintf("%q", i.Lit)
+}
@@ -1,16 +1,20 @@
 package interpreter
 
 import (
+	"context"
 	"fmt"
 	"regexp"
+	"time"
 
 	"github.com/influxdata/flux/ast"
+	fluxerrors "github.com/influxdata/flux/errors"
 	"github.com/influxdata/flux/semantic"
 	"github.com/influxdata/flux/values"
 	"github.com/pkg/errors"
 )
 
 type Interpreter struct {
+	ctx         context.Context
 	types       map[semantic.Node]semantic.Type
 	polyTypes   map[semantic.Node]semantic.PolyType
 	sideEffects []values.Value
@@ -18,7 +22,14 @@ type Interpreter struct {
 }
 
 func NewInterpreter() *Interpreter {
+	return NewInterpreterWithContext(context.Background())
+}
+
+// NewInterpreterWithContext returns an interpreter that passes ctx to the
+// values.ContextFunction functions called by the scripts it evaluates.
+func NewInterpreterWithContext(ctx context.Context) *Interpreter {
 	return &Interpreter{
+		ctx:       ctx,
 		types:     make(map[semantic.Node]semantic.Type),
 		polyTypes: make(map[semantic.Node]semantic.PolyType),
 	}
@@ -132,7 +143,7 @@ func (itrp *Interpreter) doImport(dec *semantic.ImportDeclaration, scope Scope,
 	path := dec.Path.Value
 	pkg, ok := importer.ImportPackageObject(path)
 	if !ok {
-		return fmt.Errorf("invalid import path %s", path)
+		return fluxerrors.Newf(fluxerrors.NotFound, "invalid import path %s", path)
 	}
 	name := pkg.Name()
 	if dec.As != nil {
@@ -228,7 +239,7 @@ func (itrp *Interpreter) doExpression(expr semantic.Expression, scope Scope) (va
 	case *semantic.IdentifierExpression:
 		value, ok := scope.Lookup(e.Name)
 		if !ok {
-			return nil, fmt.Errorf("undefined identifier %q", e.Name)
+			return nil, fluxerrors.Newf(fluxerrors.NotFound, "undefined identifier %q", e.Name)
 		}
 		return value, nil
 	case *semantic.CallExpression:
@@ -285,7 +296,7 @@ func (itrp *Interpreter) doExpression(expr semantic.Expression, scope Scope) (va
 			case semantic.Float:
 				return values.NewFloat(-v.Float()), nil
 			case semantic.Duration:
-				return values.NewDuration(-v.Duration()), nil
+				return values.NewDuration(v.Duration().Neg()), nil
 			default:
 				return nil, fmt.Errorf("operand to unary expression is not a number value, got %v", v.Type())
 			}
@@ -360,11 +371,14 @@ func (itrp *Interpreter) doExpression(expr semantic.Expression, scope Scope) (va
 				polyTypes[node] = polyType
 			}
 		}), e)
+		// The function is bound to this interpreter so that it can also be called from Go,
+		// for example by a builtin; doCall rebinds it when it is called by another interpreter.
 		return function{
 			e:         e,
 			scope:     scope,
 			types:     types,
 			polyTypes: polyTypes,
+			itrp:      itrp,
 		}, nil
 	default:
 		return nil, fmt.Errorf("unsupported expression %T", expr)
@@ -408,7 +422,7 @@ func (itrp *Interpreter) doLiteral(lit semantic.Literal) (values.Value, error) {
 	case *semantic.DateTimeLiteral:
 		return values.NewTime(values.Time(l.Value.UnixNano())), nil
 	case *semantic.DurationLiteral:
-		return values.NewDuration(values.Duration(l.Value)), nil
+		return values.NewDuration(values.MakeDuration(l.Months, int64(l.Value))), nil
 	case *semantic.FloatLiteral:
 		return values.NewFloat(l.Value), nil
 	case *semantic.IntegerLiteral:
@@ -484,7 +498,12 @@ func (itrp *Interpreter) doCall(call *semantic.CallExpression, scope Scope) (val
 	}
 
 	// Call the function
-	value, err := f.Call(argObj)
+	var value values.Value
+	if cf, ok := f.(values.ContextFunction); ok {
+		value, err = cf.CallContext(itrp.ctx, argObj)
+	} else {
+		value, err = f.Call(argObj)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -493,9 +512,22 @@ func (itrp *Interpreter) doCall(call *semantic.CallExpression, scope Scope) (val
 		itrp.sideEffects = append(itrp.sideEffects, value)
 	}
 
+	if l, ok := value.(Locatable); ok {
+		l.SetLocation(call.Location())
+	}
+
 	return value, nil
 }
 
+// Locatable is implemented by values that record the location in the script
+// of the function call that produced them.
+type Locatable interface {
+	// SetLocation records the location of the call.
+	// It is called for every call that returns the value,
+	// so implementations should keep the first valid location.
+	SetLocation(loc ast.SourceLocation)
+}
+
 func (itrp *Interpreter) doArguments(args *semantic.ObjectExpression, scope Scope, pipeArgument string, pipe semantic.Expression) (values.Object, error) {
 	obj := values.NewObject()
 	if pipe == nil && (args == nil || len(args.Properties) == 0) {
@@ -733,7 +765,7 @@ func (f function) resolveIdentifiers(n semantic.Node) (semantic.Node, error) {
 		}
 		v, ok := f.scope.Lookup(n.Name)
 		if !ok {
-			return nil, fmt.Errorf("name %q does not exist in scope", n.Name)
+			return nil, fluxerrors.Newf(fluxerrors.NotFound, "name %q does not exist in scope", n.Name)
 		}
 		return resolveValue(v)
 	case *semantic.Block:
@@ -825,6 +857,24 @@ func (f function) resolveIdentifiers(n semantic.Node) (semantic.Node, error) {
 			}
 			n.Properties[i] = node.(*semantic.Property)
 		}
+	case *semantic.MemberExpression:
+		node, err := f.resolveIdentifiers(n.Object)
+		if err != nil {
+			return nil, err
+		}
+		n.Object = node.(semantic.Expression)
+	case *semantic.IndexExpression:
+		node, err := f.resolveIdentifiers(n.Array)
+		if err != nil {
+			return nil, err
+		}
+		n.Array = node.(semantic.Expression)
+
+		node, err = f.resolveIdentifiers(n.Index)
+		if err != nil {
+			return nil, err
+		}
+		n.Index = node.(semantic.Expression)
 	case *semantic.ConditionalExpression:
 		node, err := f.resolveIdentifiers(n.Test)
 		if err != nil {
@@ -884,8 +934,10 @@ func resolveValue(v values.Value) (semantic.Node, error) {
 			Value: v.Regexp(),
 		}, nil
 	case semantic.Duration:
+		d := v.Duration()
 		return &semantic.DurationLiteral{
-			Value: v.Duration().Duration(),
+			Value:  time.Duration(d.Nanoseconds()),
+			Months: d.Months(),
 		}, nil
 	case semantic.Function:
 		resolver, ok := v.Function().(Resolver)
@@ -5,6 +5,7 @@ import (
 	"regexp"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/influxdata/flux/ast"
@@ -166,6 +167,27 @@ func TestEval(t *testing.T) {
 				values.NewBool(true),
 			},
 		},
+		{
+			name: "duration arithmetic",
+			query: `
+            d = 1y2mo - 1mo + 1d
+            d == 1y1mo1d or fail()
+            nd = -d
+            nd == -1y1mo1d or fail()
+            d > 1y1mo or fail()
+            1mo < 32d or fail()
+            1mo > 27d or fail()
+            d
+			`,
+			want: []values.Value{
+				values.NewBool(true),
+				values.NewBool(true),
+				values.NewBool(true),
+				values.NewBool(true),
+				values.NewBool(true),
+				values.NewDuration(values.MakeDuration(13, int64(24*time.Hour))),
+			},
+		},
 		{
 			name: "function",
 			query: `
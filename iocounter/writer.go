@@ -20,3 +20,10 @@ func (c *Writer) Write(buf []byte) (int, error) {
 func (c *Writer) Count() int64 {
 	return c.count
 }
+
+// Flush flushes the underlying writer if it supports flushing.
+func (c *Writer) Flush() {
+	if f, ok := c.Writer.(interface{ Flush() }); ok {
+		f.Flush()
+	}
+}
@@ -0,0 +1,105 @@
+package lang
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"sync"
+
+	"github.com/influxdata/flux"
+)
+
+// DefaultCompilationCacheSize is the number of scripts kept by the DefaultCompilationCache.
+const DefaultCompilationCacheSize = 256
+
+// DefaultCompilationCache is the cache used by the FluxCompiler when it has none of its own.
+var DefaultCompilationCache = NewCompilationCache(DefaultCompilationCacheSize)
+
+// CompilationCache caches the parsing and semantic analysis of Flux scripts, keyed by the hash of their source,
+// and evicts the least recently used scripts once it holds more than its size.
+//
+// Only parsing and analysis are cached. Tasks and dashboards submit the same scripts over and over,
+// and parsing and analyzing them into their semantic graph is the part of compiling them
+// that does not depend on when they are compiled.
+// A cached script is still evaluated into its spec, which is then planned, every time it is compiled:
+// the spec depends on the now time of the script, on its options and on the data it may read
+// while it is evaluated, so neither it nor its plans are cached.
+type CompilationCache struct {
+	mu      sync.Mutex
+	size    int
+	entries map[[sha256.Size]byte]*list.Element
+	lru     *list.List
+
+	hits, misses int64
+}
+
+// analyze parses and analyzes the scripts missing from the cache.
+// It is replaced by the tests to count them.
+var analyze = flux.Analyze
+
+type cacheEntry struct {
+	key      [sha256.Size]byte
+	analyzed *flux.Analyzed
+}
+
+// NewCompilationCache returns a cache of the analysis of at most size scripts.
+// A cache of size zero caches nothing.
+func NewCompilationCache(size int) *CompilationCache {
+	return &CompilationCache{
+		size:    size,
+		entries: make(map[[sha256.Size]byte]*list.Element),
+		lru:     list.New(),
+	}
+}
+
+// Analyze returns the analysis of the script, from the cache if the script has been analyzed before.
+// Scripts that fail to analyze are not cached.
+func (c *CompilationCache) Analyze(q string) (*flux.Analyzed, error) {
+	key := sha256.Sum256([]byte(q))
+	c.mu.Lock()
+	if e, ok := c.entries[key]; ok {
+		c.lru.MoveToFront(e)
+		c.hits++
+		c.mu.Unlock()
+		return e.Value.(*cacheEntry).analyzed, nil
+	}
+	c.misses++
+	c.mu.Unlock()
+
+	// Scripts are analyzed outside of the lock,
+	// so the same script may be analyzed more than once by concurrent callers.
+	a, err := analyze(q)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.size <= 0 {
+		return a, nil
+	}
+	if e, ok := c.entries[key]; ok {
+		c.lru.MoveToFront(e)
+		return e.Value.(*cacheEntry).analyzed, nil
+	}
+	c.entries[key] = c.lru.PushFront(&cacheEntry{key: key, analyzed: a})
+	for c.lru.Len() > c.size {
+		e := c.lru.Back()
+		c.lru.Remove(e)
+		delete(c.entries, e.Value.(*cacheEntry).key)
+	}
+	return a, nil
+}
+
+// Len returns the number of scripts in the cache.
+func (c *CompilationCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lru.Len()
+}
+
+// Stats returns the number of times a script was found in the cache, and the number of times it was not.
+func (c *CompilationCache) Stats() (hits, misses int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.hits, c.misses
+}
@@ -0,0 +1,40 @@
+package lang
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/dependencies"
+)
+
+func TestFluxCompiler_CacheHit(t *testing.T) {
+	var analyzed int
+	defer func(f func(string) (*flux.Analyzed, error)) { analyze = f }(analyze)
+	analyze = func(q string) (*flux.Analyzed, error) {
+		analyzed++
+		return flux.Analyze(q)
+	}
+
+	c := FluxCompiler{
+		Query: `from(bucket: "telegraf") |> range(start: -5m)`,
+		Cache: NewCompilationCache(1),
+	}
+	for i, now := range []time.Time{time.Unix(10, 0).UTC(), time.Unix(20, 0).UTC(), time.Unix(30, 0).UTC()} {
+		now := now
+		deps := dependencies.NewBuilder().WithNow(func() time.Time { return now }).Build()
+		spec, err := c.Compile(dependencies.Inject(context.Background(), deps))
+		if err != nil {
+			t.Fatal(err)
+		}
+		// The script is only analyzed the first time it is compiled,
+		// but it is evaluated at the now time of every compilation.
+		if analyzed != 1 {
+			t.Fatalf("unexpected number of analyses after %d compilations: want 1, got %d", i+1, analyzed)
+		}
+		if !spec.Now.Equal(now) {
+			t.Errorf("unexpected now time: want %v, got %v", now, spec.Now)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package lang_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/dependencies"
+	"github.com/influxdata/flux/lang"
+)
+
+func TestCompilationCache(t *testing.T) {
+	cache := lang.NewCompilationCache(2)
+
+	a, err := cache.Analyze(`x = 1`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b, err := cache.Analyze(`x = 1`); err != nil {
+		t.Fatal(err)
+	} else if a != b {
+		t.Error("expected the analysis of the same script to be cached")
+	}
+	if _, err := cache.Analyze(`x = `); err == nil {
+		t.Error("expected an error for an invalid script")
+	}
+	if hits, misses := cache.Stats(); hits != 1 || misses != 2 {
+		t.Errorf("unexpected stats: want 1 hit and 2 misses, got %d hits and %d misses", hits, misses)
+	}
+	if want, got := 1, cache.Len(); want != got {
+		t.Errorf("unexpected length: want %d, got %d", want, got)
+	}
+
+	// The least recently used script is evicted.
+	for _, q := range []string{`y = 2`, `x = 1`, `z = 3`} {
+		if _, err := cache.Analyze(q); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if want, got := 2, cache.Len(); want != got {
+		t.Errorf("unexpected length: want %d, got %d", want, got)
+	}
+	if b, err := cache.Analyze(`x = 1`); err != nil {
+		t.Fatal(err)
+	} else if a != b {
+		t.Error("expected the recently used script to stay cached")
+	}
+	if hits, misses := cache.Stats(); hits != 3 || misses != 4 {
+		t.Errorf("unexpected stats: want 3 hits and 4 misses, got %d hits and %d misses", hits, misses)
+	}
+}
+
+func TestFluxCompiler_Cache(t *testing.T) {
+	cache := lang.NewCompilationCache(1)
+	c := lang.FluxCompiler{
+		Query: `from(bucket: "telegraf") |> range(start: -5m)`,
+		Cache: cache,
+	}
+
+	// The spec of a cached script is compiled at the now time of every query.
+	var specs []*flux.Spec
+	for _, now := range []time.Time{time.Unix(10, 0).UTC(), time.Unix(20, 0).UTC()} {
+		now := now
+		deps := dependencies.NewBuilder().WithNow(func() time.Time { return now }).Build()
+		spec, err := c.Compile(dependencies.Inject(context.Background(), deps))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !spec.Now.Equal(now) {
+			t.Errorf("unexpected now time: want %v, got %v", now, spec.Now)
+		}
+		specs = append(specs, spec)
+	}
+	if !cmp.Equal(specs[0].Operations, specs[1].Operations) {
+		t.Errorf("unexpected operations -first/+second:\n%s", cmp.Diff(specs[0].Operations, specs[1].Operations))
+	}
+	if hits, misses := cache.Stats(); hits != 1 || misses != 1 {
+		t.Errorf("unexpected stats: want 1 hit and 1 miss, got %d hits and %d misses", hits, misses)
+	}
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/influxdata/flux"
 	"github.com/influxdata/flux/ast"
+	"github.com/influxdata/flux/dependencies"
 )
 
 const (
@@ -28,12 +29,23 @@ func AddCompilerMappings(mappings flux.CompilerMappings) error {
 }
 
 // FluxCompiler compiles a Flux script into a spec.
+// The parsing and analysis of the script are cached by Cache, or by the DefaultCompilationCache if Cache is nil.
+// The script is evaluated into its spec every time it is compiled.
 type FluxCompiler struct {
-	Query string `json:"query"`
+	Query string            `json:"query"`
+	Cache *CompilationCache `json:"-"`
 }
 
 func (c FluxCompiler) Compile(ctx context.Context) (*flux.Spec, error) {
-	return flux.Compile(ctx, c.Query, time.Now())
+	cache := c.Cache
+	if cache == nil {
+		cache = DefaultCompilationCache
+	}
+	a, err := cache.Analyze(c.Query)
+	if err != nil {
+		return nil, err
+	}
+	return flux.CompileAnalyzed(ctx, a, dependencies.Get(ctx).Now())
 }
 
 func (c FluxCompiler) CompilerType() flux.CompilerType {
@@ -62,7 +74,7 @@ func (c ASTCompiler) Compile(ctx context.Context) (*flux.Spec, error) {
 	if c.Now != nil {
 		return flux.CompileAST(ctx, c.AST, c.Now())
 	}
-	return flux.CompileAST(ctx, c.AST, time.Now())
+	return flux.CompileAST(ctx, c.AST, dependencies.Get(ctx).Now())
 }
 
 func (ASTCompiler) CompilerType() flux.CompilerType {
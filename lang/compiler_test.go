@@ -19,7 +19,7 @@ func TestASTCompiler(t *testing.T) {
 	testcases := []struct {
 		name   string
 		now    func() time.Time
-		file *ast.File
+		file   *ast.File
 		script string
 		want   *flux.Spec
 	}{
@@ -134,7 +134,10 @@ csv.from(csv: "foo,bar") |> range(start: 2017-10-10T00:00:00Z)
 				t.Fatalf("failed to compile AST: %v", err)
 			}
 
-			cmpOpts := cmpopts.IgnoreUnexported(flux.Spec{})
+			cmpOpts := cmp.Options{
+				cmpopts.IgnoreUnexported(flux.Spec{}),
+				cmpopts.IgnoreFields(flux.Operation{}, "Location"),
+			}
 			if !cmp.Equal(tc.want, got, cmpOpts) {
 				t.Fatalf("compiler produced unexpected spec; -want/+got:\n%v\n", cmp.Diff(tc.want, got, cmpOpts))
 			}
@@ -0,0 +1,151 @@
+package lang
+
+import (
+	"context"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/ast"
+	"github.com/influxdata/flux/dependencies"
+	"github.com/influxdata/flux/errors"
+	"github.com/influxdata/flux/parser"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/flux/values"
+)
+
+// Phase is the phase of the validation of a script that reported a diagnostic.
+type Phase string
+
+const (
+	ParsePhase    Phase = "parse"
+	SemanticPhase Phase = "semantic"
+	TypePhase     Phase = "type"
+	CompilePhase  Phase = "compile"
+	PlanPhase     Phase = "plan"
+)
+
+// Diagnostic describes a problem found while validating a script.
+type Diagnostic struct {
+	Phase   Phase  `json:"phase"`
+	Message string `json:"message"`
+	// Location is the location of the problem in the script, if it is known.
+	Location *ast.SourceLocation `json:"location,omitempty"`
+}
+
+// Validation is the result of validating a script.
+type Validation struct {
+	Diagnostics []Diagnostic `json:"diagnostics"`
+	// Resources are the external resources the script would read or write.
+	Resources []flux.Resource `json:"resources"`
+	// Partial is set when the script reads tables while it is compiled, for example with tableFind.
+	// Those tables are not read during validation, so the script is only validated up to its type check,
+	// and its resources are unknown.
+	Partial bool `json:"partial,omitempty"`
+}
+
+// Valid reports whether the script has no diagnostics.
+func (v *Validation) Valid() bool {
+	return len(v.Diagnostics) == 0
+}
+
+func (v *Validation) report(phase Phase, err error) *Validation {
+	d := Diagnostic{Phase: phase, Message: err.Error()}
+	if verr, ok := err.(*plan.ValidationError); ok {
+		d.Location = verr.Location
+	}
+	v.Diagnostics = append(v.Diagnostics, d)
+	return v
+}
+
+// Validate parses, analyzes, type checks, compiles and plans a script without executing it.
+// Validation stops at the first phase that reports a problem.
+// The resources of a valid script are reported by flux.Resources.
+//
+// The script is compiled in a sandbox that denies access to all external resources,
+// and the tables it reads while it is compiled are never read.
+func Validate(ctx context.Context, source string) *Validation {
+	v := new(Validation)
+
+	deps := dependencies.Get(ctx)
+	deps.Sandbox = &dependencies.Sandbox{}
+	ctx = dependencies.Inject(ctx, deps)
+	tables := new(unreadTables)
+	ctx = flux.WithTablesEvaluator(ctx, tables)
+
+	pkg := parser.ParseSource(source)
+	if ast.Check(pkg) > 0 {
+		ast.Walk(ast.CreateVisitor(func(node ast.Node) {
+			for _, err := range node.Errs() {
+				loc := node.Location()
+				v.Diagnostics = append(v.Diagnostics, Diagnostic{
+					Phase:    ParsePhase,
+					Message:  err.Msg,
+					Location: &loc,
+				})
+			}
+		}), pkg)
+		return v
+	}
+
+	semPkg, err := semantic.New(pkg)
+	if err != nil {
+		return v.report(SemanticPhase, err)
+	}
+	if _, err := semantic.InferTypes(WithPrelude(semPkg), flux.StdLib()); err != nil {
+		return v.report(TypePhase, err)
+	}
+
+	spec, err := flux.CompileAST(ctx, pkg, deps.Now())
+	if err != nil {
+		if tables.read {
+			v.Partial = true
+			return v
+		}
+		return v.report(CompilePhase, err)
+	}
+	lp, err := plan.NewLogicalPlanner().CreateInitialPlan(spec)
+	if err != nil {
+		return v.report(PlanPhase, err)
+	}
+	lp.Flagger = deps.Flagger
+	if lp, err = plan.NewLogicalPlanner(plan.InferSchemas()).Plan(lp); err != nil {
+		return v.report(PlanPhase, err)
+	}
+	if _, err := plan.NewPhysicalPlanner().Plan(lp); err != nil {
+		return v.report(PlanPhase, err)
+	}
+
+	v.Resources = flux.Resources(spec)
+	return v
+}
+
+// unreadTables is the tables evaluator of a script being validated.
+// It records that the script reads tables instead of executing their pipelines.
+type unreadTables struct {
+	read bool
+}
+
+func (e *unreadTables) EvalTables(ctx context.Context, spec *flux.Spec, f func(flux.Table) error) error {
+	e.read = true
+	return errors.New(errors.Unimplemented, "tables are not read while a script is validated")
+}
+
+// WithPrelude declares the names of the prelude as external to a node,
+// so that a script is type checked the same way it is evaluated.
+func WithPrelude(n semantic.Node) semantic.Node {
+	for s := flux.Prelude(); s != nil; s = s.Pop() {
+		extern := &semantic.Extern{
+			Block: &semantic.ExternBlock{
+				Node: n,
+			},
+		}
+		s.LocalRange(func(k string, v values.Value) {
+			extern.Assignments = append(extern.Assignments, &semantic.ExternalVariableAssignment{
+				Identifier: &semantic.Identifier{Name: k},
+				ExternType: v.PolyType(),
+			})
+		})
+		n = extern
+	}
+	return n
+}
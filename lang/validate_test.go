@@ -0,0 +1,107 @@
+package lang_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/flux"
+	_ "github.com/influxdata/flux/builtin"
+	"github.com/influxdata/flux/lang"
+)
+
+func TestValidate(t *testing.T) {
+	testcases := []struct {
+		name          string
+		script        string
+		wantPhase     lang.Phase
+		wantResources []flux.Resource
+		wantPartial   bool
+	}{
+		{
+			name: "valid",
+			script: `
+from(bucket: "telegraf")
+	|> range(start: -5m)
+	|> to(bucket: "downsampled", org: "influxdata", host: "http://localhost:9999", token: "mytoken")
+`,
+			wantResources: []flux.Resource{
+				{Kind: flux.BucketResource, Name: "downsampled", Mode: flux.WriteAccess},
+				{Kind: flux.BucketResource, Name: "telegraf", Mode: flux.ReadAccess},
+				{Kind: flux.HostResource, Name: "http://localhost:9999", Mode: flux.WriteAccess},
+			},
+		},
+		{
+			name:      "parse error",
+			script:    `from(bucket: "telegraf") |> range(start: -5m) |>`,
+			wantPhase: lang.ParsePhase,
+		},
+		{
+			name:      "unbounded from",
+			script:    `from(bucket: "telegraf") |> yield()`,
+			wantPhase: lang.PlanPhase,
+		},
+		{
+			name:      "compile error",
+			script:    `from(bucket: "telegraf") |> range(start: -5m) |> to(bucket: "downsampled", org: "influxdata", batchSize: 0)`,
+			wantPhase: lang.CompilePhase,
+		},
+		{
+			name: "join column type mismatch",
+			script: `
+import "csv"
+
+a = csv.from(csv: "#datatype,string,long,dateTime:RFC3339,string\n#group,false,false,false,true\n#default,_result,,,\n,result,table,_time,host\n,,0,2018-05-22T19:53:26Z,a\n")
+b = csv.from(csv: "#datatype,string,long,dateTime:RFC3339,long\n#group,false,false,false,true\n#default,_result,,,\n,result,table,_time,host\n,,0,2018-05-22T19:53:26Z,1\n")
+join(tables: {a: a, b: b}, on: ["_time", "host"])
+`,
+			wantPhase: lang.PlanPhase,
+		},
+		{
+			name: "tables read while compiling",
+			script: `
+t = from(bucket: "telegraf")
+	|> range(start: -5m)
+	|> tableFind(fn: (key) => true)
+from(bucket: t._value[0])
+	|> range(start: -5m)
+`,
+			wantPartial: true,
+		},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := flux.WithTablesEvaluator(context.Background(), executingEvaluator{t: t})
+			v := lang.Validate(ctx, tc.script)
+			if tc.wantPhase != "" {
+				if v.Valid() {
+					t.Fatalf("expected %s diagnostics, got none", tc.wantPhase)
+				}
+				if got := v.Diagnostics[0].Phase; got != tc.wantPhase {
+					t.Fatalf("unexpected diagnostic phase -want/+got\n%s", cmp.Diff(tc.wantPhase, got))
+				}
+				return
+			}
+			if !v.Valid() {
+				t.Fatalf("unexpected diagnostics: %v", v.Diagnostics)
+			}
+			if v.Partial != tc.wantPartial {
+				t.Fatalf("unexpected partial validation: want %v, got %v", tc.wantPartial, v.Partial)
+			}
+			if !cmp.Equal(tc.wantResources, v.Resources) {
+				t.Errorf("unexpected resources -want/+got\n%s", cmp.Diff(tc.wantResources, v.Resources))
+			}
+		})
+	}
+}
+
+// executingEvaluator fails the test if the tables of the script being validated are read.
+type executingEvaluator struct {
+	t *testing.T
+}
+
+func (e executingEvaluator) EvalTables(ctx context.Context, spec *flux.Spec, f func(flux.Table) error) error {
+	e.t.Fatal("unexpected execution of a pipeline while validating")
+	return nil
+}
@@ -0,0 +1,371 @@
+package line
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/memory"
+	"github.com/influxdata/flux/values"
+	"github.com/pkg/errors"
+)
+
+const (
+	measurementColLabel = "_measurement"
+	fieldColLabel       = "_field"
+)
+
+// ProtocolDecoder decodes InfluxDB line protocol from a reader into a flux.Result.
+// Every field of a point becomes a row with schema `_time`, `_measurement`, `_field`, `_value`
+// plus one column per tag, and rows are grouped into tables by measurement, tag set and field.
+// Points without a timestamp are assigned the current time from the TimeProvider.
+// Like ResultDecoder, it emits tables whenever MaxBufferCount points have been read or
+// no more input is immediately available.
+type ProtocolDecoder struct {
+	reader *bufio.Reader
+	stats  flux.Statistics
+	config *ProtocolDecoderConfig
+}
+
+// ProtocolDecoderConfig is the configuration for a line protocol decoder.
+type ProtocolDecoderConfig struct {
+	TimeProvider TimeProvider
+	// MaxBufferCount is the maximum number of points that will be buffered
+	// before tables are emitted. Defaults to 1000.
+	MaxBufferCount int
+}
+
+// NewProtocolDecoder creates a new line protocol decoder from config.
+func NewProtocolDecoder(config *ProtocolDecoderConfig) *ProtocolDecoder {
+	return &ProtocolDecoder{config: config}
+}
+
+func (pd *ProtocolDecoder) Decode(r io.Reader) (flux.Result, error) {
+	pd.reader = bufio.NewReader(r)
+	return pd, nil
+}
+
+func (*ProtocolDecoder) Name() string {
+	return "_result"
+}
+
+func (pd *ProtocolDecoder) Tables() flux.TableIterator {
+	return pd
+}
+
+func (pd *ProtocolDecoder) Statistics() flux.Statistics {
+	return pd.stats
+}
+
+func (pd *ProtocolDecoder) Do(f func(flux.Table) error) error {
+	maxCount := maxBufferCount(pd.config.MaxBufferCount)
+	batch := newProtocolBatch()
+	flush := func() error {
+		tables, err := batch.tables()
+		if err != nil {
+			return err
+		}
+		batch = newProtocolBatch()
+		for _, tbl := range tables {
+			pd.stats = pd.stats.Add(tbl.Statistics())
+			if err := f(tbl); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	for n := 1; ; n++ {
+		s, err := pd.reader.ReadString('\n')
+		if err != nil && err != io.EOF {
+			return err
+		}
+		eof := err == io.EOF
+
+		p, err := parsePoint(s)
+		if err != nil {
+			return errors.Wrapf(err, "line %d", n)
+		}
+		if p != nil {
+			if !p.hasTime {
+				p.time = pd.config.TimeProvider.CurrentTime()
+			}
+			if err := batch.add(p); err != nil {
+				return errors.Wrapf(err, "line %d", n)
+			}
+		}
+		if eof {
+			break
+		}
+
+		// Emit what has been read so far if the buffer is full or if reading
+		// further would block waiting for more input.
+		if batch.count >= maxCount || pd.reader.Buffered() == 0 {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+	return flush()
+}
+
+type protocolTag struct {
+	key, value string
+}
+
+type protocolField struct {
+	key   string
+	value values.Value
+}
+
+type protocolPoint struct {
+	measurement string
+	tags        []protocolTag
+	fields      []protocolField
+	time        values.Time
+	hasTime     bool
+}
+
+// protocolBatch accumulates rows for a batch of points grouped by their table key.
+type protocolBatch struct {
+	count    int
+	builders *execute.GroupLookup
+}
+
+type protocolBuilder struct {
+	builder  *execute.ColListTableBuilder
+	timeIdx  int
+	valueIdx int
+}
+
+func newProtocolBatch() *protocolBatch {
+	return &protocolBatch{builders: execute.NewGroupLookup()}
+}
+
+func (b *protocolBatch) add(p *protocolPoint) error {
+	b.count++
+	for _, fv := range p.fields {
+		key := p.groupKey(fv.key)
+		var pb *protocolBuilder
+		if v, ok := b.builders.Lookup(key); ok {
+			pb = v.(*protocolBuilder)
+		} else {
+			builder := execute.NewColListTableBuilder(key, &memory.Allocator{})
+			timeIdx, err := builder.AddCol(flux.ColMeta{Label: execute.DefaultTimeColLabel, Type: flux.TTime})
+			if err != nil {
+				return err
+			}
+			if err := execute.AddTableKeyCols(key, builder); err != nil {
+				return err
+			}
+			valueIdx, err := builder.AddCol(flux.ColMeta{Label: execute.DefaultValueColLabel, Type: flux.ColumnType(fv.value.Type())})
+			if err != nil {
+				return err
+			}
+			pb = &protocolBuilder{
+				builder:  builder,
+				timeIdx:  timeIdx,
+				valueIdx: valueIdx,
+			}
+			b.builders.Set(key, pb)
+		}
+
+		if want, got := pb.builder.Cols()[pb.valueIdx].Type, flux.ColumnType(fv.value.Type()); want != got {
+			return fmt.Errorf("field type conflict for %q: %v != %v", fv.key, got, want)
+		}
+		if err := pb.builder.AppendTime(pb.timeIdx, p.time); err != nil {
+			return err
+		}
+		for j := range key.Cols() {
+			if err := pb.builder.AppendValue(j+1, key.Value(j)); err != nil {
+				return err
+			}
+		}
+		if err := pb.builder.AppendValue(pb.valueIdx, fv.value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *protocolBatch) tables() ([]flux.Table, error) {
+	var (
+		tables []flux.Table
+		err    error
+	)
+	b.builders.Range(func(key flux.GroupKey, v interface{}) {
+		if err != nil {
+			return
+		}
+		var tbl flux.Table
+		tbl, err = v.(*protocolBuilder).builder.Table()
+		tables = append(tables, tbl)
+	})
+	return tables, err
+}
+
+// groupKey returns the key of the table the given field of the point belongs to.
+// Key columns are sorted by label.
+func (p *protocolPoint) groupKey(field string) flux.GroupKey {
+	cols := make([]flux.ColMeta, 0, len(p.tags)+2)
+	vs := make([]values.Value, 0, len(p.tags)+2)
+	cols = append(cols,
+		flux.ColMeta{Label: fieldColLabel, Type: flux.TString},
+		flux.ColMeta{Label: measurementColLabel, Type: flux.TString},
+	)
+	vs = append(vs, values.NewString(field), values.NewString(p.measurement))
+	for _, t := range p.tags {
+		cols = append(cols, flux.ColMeta{Label: t.key, Type: flux.TString})
+		vs = append(vs, values.NewString(t.value))
+	}
+	sort.Sort(keySorter{cols: cols, vs: vs})
+	return execute.NewGroupKey(cols, vs)
+}
+
+type keySorter struct {
+	cols []flux.ColMeta
+	vs   []values.Value
+}
+
+func (s keySorter) Len() int           { return len(s.cols) }
+func (s keySorter) Less(i, j int) bool { return s.cols[i].Label < s.cols[j].Label }
+func (s keySorter) Swap(i, j int) {
+	s.cols[i], s.cols[j] = s.cols[j], s.cols[i]
+	s.vs[i], s.vs[j] = s.vs[j], s.vs[i]
+}
+
+// parsePoint parses a single line of line protocol.
+// It returns nil for blank lines and comments.
+func parsePoint(s string) (*protocolPoint, error) {
+	s = strings.TrimSpace(s)
+	if s == "" || s[0] == '#' {
+		return nil, nil
+	}
+
+	key, rest, ok := splitUnescaped(s, ' ', false)
+	if !ok {
+		return nil, errors.New("missing fields")
+	}
+	fields, ts, _ := splitUnescaped(strings.TrimLeft(rest, " "), ' ', true)
+
+	p := new(protocolPoint)
+	var tags string
+	p.measurement, tags, ok = splitUnescaped(key, ',', false)
+	p.measurement = unescape(p.measurement)
+	if p.measurement == "" {
+		return nil, errors.New("missing measurement")
+	}
+	for ok {
+		var tag string
+		tag, tags, ok = splitUnescaped(tags, ',', false)
+		k, v, found := splitUnescaped(tag, '=', false)
+		if !found || k == "" || v == "" {
+			return nil, fmt.Errorf("invalid tag %q", tag)
+		}
+		k = unescape(k)
+		if k == measurementColLabel || k == fieldColLabel || k == execute.DefaultTimeColLabel || k == execute.DefaultValueColLabel {
+			return nil, fmt.Errorf("invalid tag key %q", k)
+		}
+		p.tags = append(p.tags, protocolTag{key: k, value: unescape(v)})
+	}
+
+	for more := true; more; {
+		var field string
+		field, fields, more = splitUnescaped(fields, ',', true)
+		k, v, found := splitUnescaped(field, '=', false)
+		if !found || k == "" {
+			return nil, fmt.Errorf("invalid field %q", field)
+		}
+		value, err := parseFieldValue(v)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid field %q", field)
+		}
+		p.fields = append(p.fields, protocolField{key: unescape(k), value: value})
+	}
+
+	if ts = strings.TrimSpace(ts); ts != "" {
+		n, err := strconv.ParseInt(ts, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid timestamp %q", ts)
+		}
+		p.time = values.Time(n)
+		p.hasTime = true
+	}
+	return p, nil
+}
+
+func parseFieldValue(s string) (values.Value, error) {
+	if s == "" {
+		return nil, errors.New("missing value")
+	}
+	switch s {
+	case "t", "T", "true", "True", "TRUE":
+		return values.NewBool(true), nil
+	case "f", "F", "false", "False", "FALSE":
+		return values.NewBool(false), nil
+	}
+	switch last := s[len(s)-1]; {
+	case s[0] == '"':
+		if len(s) < 2 || last != '"' {
+			return nil, errors.New("unterminated string")
+		}
+		return values.NewString(unescapeString(s[1 : len(s)-1])), nil
+	case last == 'i':
+		n, err := strconv.ParseInt(s[:len(s)-1], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		return values.NewInt(n), nil
+	case last == 'u':
+		n, err := strconv.ParseUint(s[:len(s)-1], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		return values.NewUInt(n), nil
+	default:
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return nil, err
+		}
+		return values.NewFloat(f), nil
+	}
+}
+
+// splitUnescaped splits s at the first occurrence of sep that is not escaped
+// with a backslash and, if quoted is set, not within double quotes.
+func splitUnescaped(s string, sep byte, quoted bool) (head, tail string, found bool) {
+	inQuotes := false
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == '\\':
+			i++
+		case c == '"' && quoted:
+			inQuotes = !inQuotes
+		case c == sep && !inQuotes:
+			return s[:i], s[i+1:], true
+		}
+	}
+	return s, "", false
+}
+
+// unescape removes the escaping of commas, equal signs and spaces in
+// measurements, tag keys, tag values and field keys.
+func unescape(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+	return strings.NewReplacer(`\,`, ",", `\=`, "=", `\ `, " ").Replace(s)
+}
+
+// unescapeString removes the escaping of double quotes and backslashes in string field values.
+func unescapeString(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+	return strings.NewReplacer(`\"`, `"`, `\\`, `\`).Replace(s)
+}
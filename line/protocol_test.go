@@ -0,0 +1,214 @@
+package line_test
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/flux/line"
+	"github.com/influxdata/flux/mock"
+)
+
+func TestProtocolDecoder(t *testing.T) {
+	tcs := []struct {
+		name    string
+		input   string
+		want    []*executetest.Table
+		wantErr bool
+	}{
+		{
+			name: "fields and tags",
+			input: `# comment
+cpu,host=a,region=west usage=0.5,count=2i 10
+cpu,region=west,host=a usage=0.7,count=3i 20
+
+mem,host=a free=10u,ok=t,msg="disk \"full\"" 30
+`,
+			want: []*executetest.Table{
+				{
+					KeyCols: []string{"_field", "_measurement", "host", "region"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_field", Type: flux.TString},
+						{Label: "_measurement", Type: flux.TString},
+						{Label: "host", Type: flux.TString},
+						{Label: "region", Type: flux.TString},
+						{Label: "_value", Type: flux.TInt},
+					},
+					Data: [][]interface{}{
+						{execute.Time(10), "count", "cpu", "a", "west", int64(2)},
+						{execute.Time(20), "count", "cpu", "a", "west", int64(3)},
+					},
+				},
+				{
+					KeyCols: []string{"_field", "_measurement", "host"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_field", Type: flux.TString},
+						{Label: "_measurement", Type: flux.TString},
+						{Label: "host", Type: flux.TString},
+						{Label: "_value", Type: flux.TUInt},
+					},
+					Data: [][]interface{}{
+						{execute.Time(30), "free", "mem", "a", uint64(10)},
+					},
+				},
+				{
+					KeyCols: []string{"_field", "_measurement", "host"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_field", Type: flux.TString},
+						{Label: "_measurement", Type: flux.TString},
+						{Label: "host", Type: flux.TString},
+						{Label: "_value", Type: flux.TString},
+					},
+					Data: [][]interface{}{
+						{execute.Time(30), "msg", "mem", "a", `disk "full"`},
+					},
+				},
+				{
+					KeyCols: []string{"_field", "_measurement", "host"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_field", Type: flux.TString},
+						{Label: "_measurement", Type: flux.TString},
+						{Label: "host", Type: flux.TString},
+						{Label: "_value", Type: flux.TBool},
+					},
+					Data: [][]interface{}{
+						{execute.Time(30), "ok", "mem", "a", true},
+					},
+				},
+				{
+					KeyCols: []string{"_field", "_measurement", "host", "region"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_field", Type: flux.TString},
+						{Label: "_measurement", Type: flux.TString},
+						{Label: "host", Type: flux.TString},
+						{Label: "region", Type: flux.TString},
+						{Label: "_value", Type: flux.TFloat},
+					},
+					Data: [][]interface{}{
+						{execute.Time(10), "usage", "cpu", "a", "west", 0.5},
+						{execute.Time(20), "usage", "cpu", "a", "west", 0.7},
+					},
+				},
+			},
+		},
+		{
+			name:  "escaping and missing timestamp",
+			input: "disk\\ io,path=/var\\,log value=1\ndisk\\ io,path=/var\\,log value=2",
+			want: []*executetest.Table{{
+				KeyCols: []string{"_field", "_measurement", "path"},
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_field", Type: flux.TString},
+					{Label: "_measurement", Type: flux.TString},
+					{Label: "path", Type: flux.TString},
+					{Label: "_value", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{execute.Time(0), "value", "disk io", "/var,log", 1.0},
+					{execute.Time(1), "value", "disk io", "/var,log", 2.0},
+				},
+			}},
+		},
+		{
+			name:    "missing fields",
+			input:   "cpu,host=a\n",
+			wantErr: true,
+		},
+		{
+			name:    "field type conflict",
+			input:   "cpu value=1\ncpu value=1i\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			decoder := line.NewProtocolDecoder(&line.ProtocolDecoderConfig{
+				TimeProvider: &mock.AscendingTimeProvider{},
+			})
+			r, err := decoder.Decode(bytes.NewReader([]byte(tc.input)))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var got []*executetest.Table
+			err = r.Tables().Do(func(table flux.Table) error {
+				ct, err := executetest.ConvertTable(table)
+				if err != nil {
+					return err
+				}
+				got = append(got, ct)
+				return nil
+			})
+			if tc.wantErr {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			} else if err != nil {
+				t.Fatal(err)
+			}
+
+			executetest.NormalizeTables(got)
+			executetest.NormalizeTables(tc.want)
+
+			if !cmp.Equal(tc.want, got) {
+				t.Errorf("unexpected tables -want/+got\n%s", cmp.Diff(tc.want, got))
+			}
+		})
+	}
+}
+
+func TestProtocolDecoder_Streaming(t *testing.T) {
+	pr, pw := io.Pipe()
+	decoder := line.NewProtocolDecoder(&line.ProtocolDecoderConfig{
+		TimeProvider: &mock.AscendingTimeProvider{},
+	})
+	r, err := decoder.Decode(pr)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Each write is decoded into a table before the next one is made.
+	tables := make(chan *executetest.Table)
+	errC := make(chan error, 1)
+	go func() {
+		errC <- r.Tables().Do(func(table flux.Table) error {
+			ct, err := executetest.ConvertTable(table)
+			if err != nil {
+				return err
+			}
+			tables <- ct
+			return nil
+		})
+	}()
+
+	for i, input := range []string{"cpu value=1 1\n", "cpu value=2 2\n"} {
+		if _, err := io.WriteString(pw, input); err != nil {
+			t.Fatal(err)
+		}
+		tbl := <-tables
+		if len(tbl.Data) != 1 {
+			t.Fatalf("unexpected number of rows in table %d: %d", i, len(tbl.Data))
+		}
+		if got, want := tbl.Data[0][0], execute.Time(i+1); got != want {
+			t.Errorf("unexpected time in table %d: want %v, got %v", i, want, got)
+		}
+	}
+	pw.Close()
+	if err := <-errC; err != nil {
+		t.Fatal(err)
+	}
+}
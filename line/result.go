@@ -17,7 +17,10 @@ import (
 // The `_value` column contains tokens.
 // The `_time` column contains the timestamps for when each `_value` has been read.
 // Strings in `_value` are obtained from the io.Reader passed to the Decode function.
-// ResultDecoder outputs one table once the reader reaches EOF.
+// The final token does not need to be followed by the separator.
+// The result has a single table, whose rows are passed to its reader in a buffer whenever
+// MaxBufferCount tokens have been read or no more input is immediately available,
+// so that an unbounded reader produces data as it arrives.
 type ResultDecoder struct {
 	reader *bufio.Reader
 	stats  flux.Statistics
@@ -38,51 +41,107 @@ type TimeProvider interface {
 type ResultDecoderConfig struct {
 	Separator    byte
 	TimeProvider TimeProvider
+	// MaxBufferCount is the maximum number of rows that will be buffered
+	// before they are passed to the reader of the table. Defaults to 1000.
+	MaxBufferCount int
+}
+
+const defaultMaxBufferCount = 1000
+
+func maxBufferCount(n int) int {
+	if n <= 0 {
+		return defaultMaxBufferCount
+	}
+	return n
 }
 
 func (rd *ResultDecoder) Do(f func(flux.Table) error) error {
-	timeCol := flux.ColMeta{Label: "_time", Type: flux.TTime}
-	valueCol := flux.ColMeta{Label: "_value", Type: flux.TString}
-	key := execute.NewGroupKey(nil, nil)
-	builder := execute.NewColListTableBuilder(key, &memory.Allocator{})
-	timeIdx, err := builder.AddCol(timeCol)
-	if err != nil {
+	// An empty input has no table.
+	if _, err := rd.reader.Peek(1); err == io.EOF {
+		return nil
+	} else if err != nil {
 		return err
 	}
-	valueIdx, err := builder.AddCol(valueCol)
-	if err != nil {
-		return err
+	return f(&resultTable{rd: rd})
+}
+
+var (
+	resultKey  = execute.NewGroupKey(nil, nil)
+	resultCols = []flux.ColMeta{
+		{Label: "_time", Type: flux.TTime},
+		{Label: "_value", Type: flux.TString},
+	}
+)
+
+// resultTable is the table of the tokens of a ResultDecoder, read while the table is processed.
+type resultTable struct {
+	rd *ResultDecoder
+}
+
+func (t *resultTable) Key() flux.GroupKey          { return resultKey }
+func (t *resultTable) Cols() []flux.ColMeta        { return resultCols }
+func (t *resultTable) RefCount(n int)              {}
+func (t *resultTable) Empty() bool                 { return false }
+func (t *resultTable) Statistics() flux.Statistics { return t.rd.stats }
+
+func (t *resultTable) Do(f func(flux.ColReader) error) error {
+	rd := t.rd
+	maxCount := maxBufferCount(rd.config.MaxBufferCount)
+
+	var builder *execute.ColListTableBuilder
+	flush := func() error {
+		if builder == nil {
+			return nil
+		}
+		tbl, err := builder.Table()
+		if err != nil {
+			return err
+		}
+		builder = nil
+		rd.stats = rd.stats.Add(tbl.Statistics())
+		return tbl.Do(f)
 	}
 
-	var eof bool
-	for !eof {
+	for {
 		s, err := rd.reader.ReadString(rd.config.Separator)
-		if err != nil && err == io.EOF {
-			break
-		} else if err != nil {
+		eof := err == io.EOF
+		if err != nil && !eof {
 			return err
 		}
+		// The input may end with the separator or with a final token.
+		if eof && s == "" {
+			break
+		}
+
+		if builder == nil {
+			builder = execute.NewColListTableBuilder(resultKey, &memory.Allocator{})
+			for _, c := range resultCols {
+				if _, err := builder.AddCol(c); err != nil {
+					return err
+				}
+			}
+		}
 
 		v := strings.Trim(s, string(rd.config.Separator))
-		ts := rd.config.TimeProvider.CurrentTime()
-		err = builder.AppendTime(timeIdx, ts)
-		if err != nil {
+		if err := builder.AppendTime(0, rd.config.TimeProvider.CurrentTime()); err != nil {
 			return err
 		}
-		err = builder.AppendString(valueIdx, v)
-		if err != nil {
+		if err := builder.AppendString(1, v); err != nil {
 			return err
 		}
-	}
+		if eof {
+			break
+		}
 
-	tbl, err := builder.Table()
-	if err != nil {
-		return err
+		// Pass what has been read so far if the buffer is full or if reading
+		// further would block waiting for more input.
+		if builder.NRows() >= maxCount || rd.reader.Buffered() == 0 {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
 	}
-
-	rd.stats = tbl.Statistics()
-
-	return f(tbl)
+	return flush()
 }
 
 func (*ResultDecoder) Name() string {
@@ -16,6 +16,7 @@ func TestResultDecoder(t *testing.T) {
 	tcs := []struct {
 		name      string
 		separator byte
+		maxCount  int
 		input     string
 		want      *executetest.Result
 	}{
@@ -74,11 +75,59 @@ empty line above
 						{execute.Time(3), "an\nawesome\nline"},
 						{execute.Time(4), "decoder!\n\nempty"},
 						{execute.Time(5), "line"},
-						// "above" is not added because the reader doesn't find the separator at the end.
+						// The final token is added even though the separator does not follow it.
+						{execute.Time(6), "above\n"},
 					},
 				}},
 			},
 		},
+		{
+			name:      "no final separator",
+			separator: '\n',
+			input:     "first\nlast",
+			want: &executetest.Result{
+				Nm: "_result",
+				Tbls: []*executetest.Table{{
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_value", Type: flux.TString},
+					},
+					Data: [][]interface{}{
+						{execute.Time(0), "first"},
+						{execute.Time(1), "last"},
+					},
+				}},
+			},
+		},
+		{
+			name:      "several buffers",
+			separator: '\n',
+			maxCount:  2,
+			input:     "a\nb\nc\nd\ne\n",
+			want: &executetest.Result{
+				Nm: "_result",
+				// The buffers of the rows are in a single table, since they share its group key.
+				Tbls: []*executetest.Table{{
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_value", Type: flux.TString},
+					},
+					Data: [][]interface{}{
+						{execute.Time(0), "a"},
+						{execute.Time(1), "b"},
+						{execute.Time(2), "c"},
+						{execute.Time(3), "d"},
+						{execute.Time(4), "e"},
+					},
+				}},
+			},
+		},
+		{
+			name:      "empty input",
+			separator: '\n',
+			input:     "",
+			want:      &executetest.Result{Nm: "_result"},
+		},
 	}
 
 	for _, tc := range tcs {
@@ -87,8 +136,9 @@ empty line above
 			t.Parallel()
 
 			decoder := line.NewResultDecoder(&line.ResultDecoderConfig{
-				Separator:    tc.separator,
-				TimeProvider: &mock.AscendingTimeProvider{},
+				Separator:      tc.separator,
+				TimeProvider:   &mock.AscendingTimeProvider{},
+				MaxBufferCount: tc.maxCount,
 			})
 
 			r, err := decoder.Decode(bytes.NewReader([]byte(tc.input)))
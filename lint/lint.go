@@ -0,0 +1,231 @@
+// Package lint analyzes the semantic graph of Flux scripts for anti-patterns.
+//
+// The analysis is static: it looks at the pipelines of the script, the chains of calls joined by the pipe operator,
+// and reports the findings of a set of rules over them without evaluating the script.
+package lint
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/influxdata/flux/ast"
+	"github.com/influxdata/flux/parser"
+	"github.com/influxdata/flux/semantic"
+)
+
+// Finding is a problem found by a rule in a script.
+type Finding struct {
+	// Rule is the name of the rule that reported the finding.
+	Rule     string             `json:"rule"`
+	Message  string             `json:"message"`
+	Location ast.SourceLocation `json:"location"`
+}
+
+func (f Finding) String() string {
+	return fmt.Sprintf("%v: %s: %s", f.Location.Start, f.Rule, f.Message)
+}
+
+// Rule checks the pipelines of a script.
+type Rule interface {
+	// Name identifies the rule in findings and in configurations.
+	Name() string
+	// Check returns the findings of the rule for a single pipeline.
+	Check(p Pipeline) []Finding
+}
+
+// DefaultRules returns the rules applied by a linter unless they are disabled.
+func DefaultRules() []Rule {
+	return []Rule{
+		MissingRangeRule{},
+		FilterAfterPivotRule{},
+		UnusedMapColumnRule{},
+	}
+}
+
+// Config configures the rules of a Linter.
+type Config struct {
+	// Disabled are the names of the default rules that are not applied.
+	Disabled []string
+	// Rules are applied in addition to the default rules.
+	Rules []Rule
+}
+
+// Linter reports the findings of a set of rules.
+type Linter struct {
+	rules []Rule
+}
+
+// New creates a linter with the default rules, modified by c.
+// It is an error to disable a rule that does not exist.
+func New(c Config) (*Linter, error) {
+	disabled := make(map[string]bool, len(c.Disabled))
+	for _, name := range c.Disabled {
+		disabled[name] = true
+	}
+	l := new(Linter)
+	for _, r := range append(DefaultRules(), c.Rules...) {
+		if disabled[r.Name()] {
+			delete(disabled, r.Name())
+			continue
+		}
+		l.rules = append(l.rules, r)
+	}
+	for name := range disabled {
+		return nil, fmt.Errorf("cannot disable unknown lint rule %q", name)
+	}
+	return l, nil
+}
+
+// LintSource parses and analyzes a Flux script.
+func (l *Linter) LintSource(script string) ([]Finding, error) {
+	astPkg := parser.ParseSource(script)
+	if ast.Check(astPkg) > 0 {
+		return nil, ast.GetError(astPkg)
+	}
+	semPkg, err := semantic.New(astPkg)
+	if err != nil {
+		return nil, err
+	}
+	return l.Lint(semPkg), nil
+}
+
+// Lint returns the findings of the rules for the pipelines of pkg, ordered by location.
+func (l *Linter) Lint(pkg *semantic.Package) []Finding {
+	var findings []Finding
+	// A pipeline assigned to a variable is part of every pipeline that uses the variable,
+	// so the same finding may be reported more than once.
+	seen := make(map[Finding]bool)
+	for _, p := range Pipelines(pkg) {
+		for _, r := range l.rules {
+			for _, f := range r.Check(p) {
+				if !seen[f] {
+					seen[f] = true
+					findings = append(findings, f)
+				}
+			}
+		}
+	}
+	sort.SliceStable(findings, func(i, j int) bool {
+		return findings[i].Location.Start.Less(findings[j].Location.Start)
+	})
+	return findings
+}
+
+// Pipeline is a chain of calls joined by the pipe operator, ordered from the source to the sink.
+type Pipeline []*semantic.CallExpression
+
+// Pipelines returns the pipelines of pkg.
+// A pipe argument that is a variable is resolved to the expression assigned to the variable,
+// so a pipeline may span several statements.
+// A pipeline assigned to a variable that is used elsewhere is only returned as part of the pipelines that use it.
+func Pipelines(pkg *semantic.Package) []Pipeline {
+	assignments := make(map[string]semantic.Expression)
+	referenced := make(map[string]bool)
+	piped := make(map[*semantic.CallExpression]bool)
+	var calls []*semantic.CallExpression
+	semantic.Walk(semantic.CreateVisitor(func(n semantic.Node) {
+		switch n := n.(type) {
+		case *semantic.NativeVariableAssignment:
+			assignments[n.Identifier.Name] = n.Init
+		case *semantic.IdentifierExpression:
+			referenced[n.Name] = true
+		case *semantic.CallExpression:
+			calls = append(calls, n)
+			if pipe, ok := n.Pipe.(*semantic.CallExpression); ok {
+				piped[pipe] = true
+			}
+		}
+	}), pkg)
+
+	// The calls that are the value of a used variable.
+	assigned := make(map[*semantic.CallExpression]bool)
+	for name, init := range assignments {
+		if call, ok := init.(*semantic.CallExpression); ok && referenced[name] {
+			assigned[call] = true
+		}
+	}
+
+	var pipelines []Pipeline
+	for _, call := range calls {
+		if piped[call] || assigned[call] {
+			continue
+		}
+		pipelines = append(pipelines, pipeline(call, assignments))
+	}
+	return pipelines
+}
+
+// pipeline returns the pipeline ending with sink.
+func pipeline(sink *semantic.CallExpression, assignments map[string]semantic.Expression) Pipeline {
+	var p Pipeline
+	visited := make(map[string]bool)
+	var e semantic.Expression = sink
+	for e != nil {
+		switch n := e.(type) {
+		case *semantic.CallExpression:
+			p = append(p, n)
+			e = n.Pipe
+		case *semantic.IdentifierExpression:
+			// A variable that refers to itself through its pipeline cannot be resolved.
+			if visited[n.Name] {
+				e = nil
+				break
+			}
+			visited[n.Name] = true
+			e = assignments[n.Name]
+		default:
+			e = nil
+		}
+	}
+	for i, j := 0, len(p)-1; i < j; i, j = i+1, j-1 {
+		p[i], p[j] = p[j], p[i]
+	}
+	return p
+}
+
+// FunctionName returns the name of the function called by call,
+// which is the name of the member for a call to a function of a package.
+// It returns an empty string for calls to anonymous functions.
+func FunctionName(call *semantic.CallExpression) string {
+	switch callee := call.Callee.(type) {
+	case *semantic.IdentifierExpression:
+		return callee.Name
+	case *semantic.MemberExpression:
+		return callee.Property
+	}
+	return ""
+}
+
+// Argument returns the value of the argument of call with the given name.
+func Argument(call *semantic.CallExpression, name string) (semantic.Expression, bool) {
+	if call.Arguments == nil {
+		return nil, false
+	}
+	for _, p := range call.Arguments.Properties {
+		if p.Key.Key() == name {
+			return p.Value, true
+		}
+	}
+	return nil, false
+}
+
+// stringsArgument returns the values of an argument that is an array of string literals.
+func stringsArgument(call *semantic.CallExpression, name string) ([]string, bool) {
+	arg, ok := Argument(call, name)
+	if !ok {
+		return nil, false
+	}
+	array, ok := arg.(*semantic.ArrayExpression)
+	if !ok {
+		return nil, false
+	}
+	strs := make([]string, len(array.Elements))
+	for i, e := range array.Elements {
+		lit, ok := e.(*semantic.StringLiteral)
+		if !ok {
+			return nil, false
+		}
+		strs[i] = lit.Value
+	}
+	return strs, true
+}
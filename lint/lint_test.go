@@ -0,0 +1,133 @@
+package lint_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/flux/lint"
+)
+
+type finding struct {
+	Rule string
+	Line int
+}
+
+func TestLinter_LintSource(t *testing.T) {
+	testCases := []struct {
+		name   string
+		script string
+		config lint.Config
+		want   []finding
+	}{
+		{
+			name:   "bounded from",
+			script: `from(bucket: "telegraf") |> range(start: -1h) |> filter(fn: (r) => r._value > 0)`,
+		},
+		{
+			name:   "missing range",
+			script: `from(bucket: "telegraf") |> filter(fn: (r) => r._value > 0)`,
+			want:   []finding{{Rule: "missing-range", Line: 1}},
+		},
+		{
+			name: "range through a variable",
+			script: `
+data = from(bucket: "telegraf")
+data |> range(start: -1h) |> mean()
+data |> range(start: -2h) |> max()`,
+		},
+		{
+			name: "missing range through a variable",
+			script: `
+data = from(bucket: "telegraf")
+data |> range(start: -1h) |> mean()
+data |> max()`,
+			want: []finding{{Rule: "missing-range", Line: 2}},
+		},
+		{
+			name:   "disabled rule",
+			script: `from(bucket: "telegraf") |> filter(fn: (r) => r._value > 0)`,
+			config: lint.Config{Disabled: []string{"missing-range"}},
+		},
+		{
+			name: "filter on the row key after pivot",
+			script: `
+from(bucket: "telegraf")
+    |> range(start: -1h)
+    |> pivot(rowKey: ["_time"], columnKey: ["_field"], valueColumn: "_value")
+    |> filter(fn: (r) => r._time > 2019-01-01T00:00:00Z)`,
+			want: []finding{{Rule: "filter-after-pivot", Line: 5}},
+		},
+		{
+			name: "filter on a pivoted column",
+			script: `
+from(bucket: "telegraf")
+    |> range(start: -1h)
+    |> pivot(rowKey: ["_time"], columnKey: ["_field"], valueColumn: "_value")
+    |> filter(fn: (r) => r.usage_user > 10.0 and r._time > 2019-01-01T00:00:00Z)`,
+		},
+		{
+			name: "map column dropped",
+			script: `
+from(bucket: "telegraf")
+    |> range(start: -1h)
+    |> map(fn: (r) => ({_time: r._time, _value: r._value, doubled: r._value * 2.0}))
+    |> drop(columns: ["doubled"])`,
+			want: []finding{{Rule: "unused-map-column", Line: 4}},
+		},
+		{
+			name: "map column not kept",
+			script: `
+from(bucket: "telegraf")
+    |> range(start: -1h)
+    |> map(fn: (r) => ({_time: r._time, _value: r._value, doubled: r._value * 2.0}))
+    |> keep(columns: ["_time", "_value"])`,
+			want: []finding{{Rule: "unused-map-column", Line: 4}},
+		},
+		{
+			name: "map column used before drop",
+			script: `
+from(bucket: "telegraf")
+    |> range(start: -1h)
+    |> map(fn: (r) => ({_time: r._time, _value: r._value, doubled: r._value * 2.0}))
+    |> filter(fn: (r) => r.doubled > 1.0)
+    |> drop(columns: ["doubled"])`,
+		},
+		{
+			name: "findings ordered by location",
+			script: `
+from(bucket: "telegraf")
+    |> pivot(rowKey: ["_time"], columnKey: ["_field"], valueColumn: "_value")
+    |> filter(fn: (r) => r._time > 2019-01-01T00:00:00Z)`,
+			want: []finding{
+				{Rule: "missing-range", Line: 2},
+				{Rule: "filter-after-pivot", Line: 4},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			l, err := lint.New(tc.config)
+			if err != nil {
+				t.Fatal(err)
+			}
+			findings, err := l.LintSource(tc.script)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []finding
+			for _, f := range findings {
+				got = append(got, finding{Rule: f.Rule, Line: f.Location.Start.Line})
+			}
+			if !cmp.Equal(tc.want, got) {
+				t.Errorf("unexpected findings -want/+got\n%s", cmp.Diff(tc.want, got))
+			}
+		})
+	}
+}
+
+func TestNew_UnknownRule(t *testing.T) {
+	if _, err := lint.New(lint.Config{Disabled: []string{"no-such-rule"}}); err == nil {
+		t.Fatal("expected an error for an unknown rule")
+	}
+}
@@ -0,0 +1,221 @@
+package lint
+
+import (
+	"fmt"
+
+	"github.com/influxdata/flux/semantic"
+)
+
+// MissingRangeRule reports a from that is not followed by a range,
+// which reads all the data of the bucket.
+type MissingRangeRule struct{}
+
+func (MissingRangeRule) Name() string {
+	return "missing-range"
+}
+
+func (r MissingRangeRule) Check(p Pipeline) []Finding {
+	for i, call := range p {
+		if _, ok := call.Callee.(*semantic.IdentifierExpression); !ok || FunctionName(call) != "from" {
+			continue
+		}
+		bounded := false
+		for _, next := range p[i+1:] {
+			if FunctionName(next) == "range" {
+				bounded = true
+				break
+			}
+		}
+		if !bounded {
+			return []Finding{{
+				Rule:     r.Name(),
+				Message:  "from is not bounded by a range and reads all the data of the bucket",
+				Location: call.Location(),
+			}}
+		}
+	}
+	return nil
+}
+
+// FilterAfterPivotRule reports a filter directly after a pivot that only uses the row key of the pivot.
+// The filter can be applied before the pivot, where it may be pushed down to the source.
+type FilterAfterPivotRule struct{}
+
+func (FilterAfterPivotRule) Name() string {
+	return "filter-after-pivot"
+}
+
+func (r FilterAfterPivotRule) Check(p Pipeline) []Finding {
+	var findings []Finding
+	for i := 1; i < len(p); i++ {
+		if FunctionName(p[i-1]) != "pivot" || FunctionName(p[i]) != "filter" {
+			continue
+		}
+		rowKey, ok := stringsArgument(p[i-1], "rowKey")
+		if !ok {
+			continue
+		}
+		cols, ok := recordColumns(p[i], "fn")
+		if !ok || len(cols) == 0 {
+			continue
+		}
+		movable := true
+		for _, c := range cols {
+			if !contains(rowKey, c) {
+				movable = false
+				break
+			}
+		}
+		if movable {
+			findings = append(findings, Finding{
+				Rule:     r.Name(),
+				Message:  "filter only uses the row key of the preceding pivot and can be applied before it",
+				Location: p[i].Location(),
+			})
+		}
+	}
+	return findings
+}
+
+// UnusedMapColumnRule reports a column created by a map that is removed by a later drop or keep
+// before anything uses it.
+type UnusedMapColumnRule struct{}
+
+func (UnusedMapColumnRule) Name() string {
+	return "unused-map-column"
+}
+
+func (r UnusedMapColumnRule) Check(p Pipeline) []Finding {
+	var findings []Finding
+	for i, call := range p {
+		if FunctionName(call) != "map" {
+			continue
+		}
+		for _, col := range createdColumns(call) {
+		next:
+			for _, n := range p[i+1:] {
+				switch FunctionName(n) {
+				case "drop":
+					if cols, ok := stringsArgument(n, "columns"); ok && contains(cols, col) {
+						findings = append(findings, Finding{
+							Rule:     r.Name(),
+							Message:  fmt.Sprintf("column %q created by map is dropped before it is used", col),
+							Location: call.Location(),
+						})
+						break next
+					}
+				case "keep":
+					if cols, ok := stringsArgument(n, "columns"); ok && !contains(cols, col) {
+						findings = append(findings, Finding{
+							Rule:     r.Name(),
+							Message:  fmt.Sprintf("column %q created by map is not kept before it is used", col),
+							Location: call.Location(),
+						})
+						break next
+					}
+				}
+				if mentions(n.Arguments, col) {
+					break
+				}
+			}
+		}
+	}
+	return findings
+}
+
+// createdColumns returns the columns of the record returned by the fn argument of a map,
+// except for the columns that are copied unchanged from the input record.
+func createdColumns(call *semantic.CallExpression) []string {
+	arg, ok := Argument(call, "fn")
+	if !ok {
+		return nil
+	}
+	fn, ok := arg.(*semantic.FunctionExpression)
+	if !ok || fn.Block == nil {
+		return nil
+	}
+	var obj *semantic.ObjectExpression
+	switch body := fn.Block.Body.(type) {
+	case *semantic.ObjectExpression:
+		obj = body
+	case *semantic.Block:
+		if len(body.Body) == 0 {
+			return nil
+		}
+		if ret, ok := body.Body[len(body.Body)-1].(*semantic.ReturnStatement); ok {
+			obj, _ = ret.Argument.(*semantic.ObjectExpression)
+		}
+	}
+	if obj == nil {
+		return nil
+	}
+	var cols []string
+	for _, p := range obj.Properties {
+		key := p.Key.Key()
+		if m, ok := p.Value.(*semantic.MemberExpression); ok && m.Property == key {
+			if _, ok := m.Object.(*semantic.IdentifierExpression); ok {
+				continue
+			}
+		}
+		cols = append(cols, key)
+	}
+	return cols
+}
+
+// recordColumns returns the columns of the record that the function argument of call reads.
+// It returns false when the function uses the record other than by reading its columns.
+func recordColumns(call *semantic.CallExpression, name string) ([]string, bool) {
+	arg, ok := Argument(call, name)
+	if !ok {
+		return nil, false
+	}
+	fn, ok := arg.(*semantic.FunctionExpression)
+	if !ok || fn.Block == nil || fn.Block.Parameters == nil || len(fn.Block.Parameters.List) != 1 {
+		return nil, false
+	}
+	param := fn.Block.Parameters.List[0].Key.Name
+
+	var cols []string
+	uses, reads := 0, 0
+	semantic.Walk(semantic.CreateVisitor(func(n semantic.Node) {
+		switch n := n.(type) {
+		case *semantic.IdentifierExpression:
+			if n.Name == param {
+				uses++
+			}
+		case *semantic.MemberExpression:
+			if id, ok := n.Object.(*semantic.IdentifierExpression); ok && id.Name == param {
+				reads++
+				cols = append(cols, n.Property)
+			}
+		}
+	}), fn.Block.Body)
+	return cols, uses == reads
+}
+
+// mentions reports whether node reads col from a record or names it in a string literal.
+func mentions(node semantic.Node, col string) bool {
+	found := false
+	semantic.Walk(semantic.CreateVisitor(func(n semantic.Node) {
+		switch n := n.(type) {
+		case *semantic.MemberExpression:
+			if n.Property == col {
+				found = true
+			}
+		case *semantic.StringLiteral:
+			if n.Value == col {
+				found = true
+			}
+		}
+	}), node)
+	return found
+}
+
+func contains(strs []string, s string) bool {
+	for _, str := range strs {
+		if str == s {
+			return true
+		}
+	}
+	return false
+}
@@ -4,6 +4,8 @@ import (
 	"errors"
 	"fmt"
 	"sync/atomic"
+
+	fluxerrors "github.com/influxdata/flux/errors"
 )
 
 // Allocator tracks the amount of memory being consumed by a query.
@@ -12,6 +14,15 @@ type Allocator struct {
 	// can assign. If this is null, there is no limit.
 	Limit *int64
 
+	// Pool, if set, holds the buffers that were freed by the query
+	// to reuse them for its later allocations.
+	Pool *Pool
+
+	// Parent, if set, also accounts for the memory of this allocator,
+	// and the memory it can assign is limited by the limit of the parent too.
+	// It allows measuring the memory of a part of a query, such as one of its operators.
+	Parent *Allocator
+
 	bytesAllocated int64
 	maxAllocated   int64
 }
@@ -47,9 +58,17 @@ func (a *Allocator) Free(size int) {
 		panic(errors.New("cannot free negative memory"))
 	}
 	atomic.AddInt64(&a.bytesAllocated, int64(-size))
+	if a.Parent != nil {
+		a.Parent.Free(size)
+	}
 }
 
 func (a *Allocator) count(size int) error {
+	if a.Parent != nil {
+		if err := a.Parent.count(size); err != nil {
+			return err
+		}
+	}
 	var c int64
 	if a.Limit != nil {
 		// We need to load the current bytes allocated, add to it, and
@@ -58,6 +77,9 @@ func (a *Allocator) count(size int) error {
 		for {
 			allocated := atomic.LoadInt64(&a.bytesAllocated)
 			if want := allocated + int64(size); want > *a.Limit {
+				if a.Parent != nil {
+					a.Parent.Free(size)
+				}
 				return LimitExceededError{
 					Limit:     *a.Limit,
 					Allocated: allocated,
@@ -94,3 +116,8 @@ type LimitExceededError struct {
 func (a LimitExceededError) Error() string {
 	return fmt.Sprintf("allocation limit reached: limit %d, allocated: %d, wanted: %d", a.Limit, a.Allocated, a.Wanted)
 }
+
+// Code implements errors.Coder.
+func (a LimitExceededError) Code() fluxerrors.Code {
+	return fluxerrors.ResourceExhausted
+}
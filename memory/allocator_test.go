@@ -127,3 +127,35 @@ func TestAllocator_Free(t *testing.T) {
 		t.Fatalf("unexpected max allocated count -want/+got\n\t- %d\n\t+ %d", want, got)
 	}
 }
+
+func TestAllocator_Parent(t *testing.T) {
+	limit := int64(100)
+	parent := &memory.Allocator{Limit: &limit}
+	a := &memory.Allocator{Parent: parent}
+	b := &memory.Allocator{Parent: parent}
+
+	if err := a.Allocate(60); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	// The limit of the parent applies to all of its children.
+	if err := b.Allocate(50); err == nil {
+		t.Fatal("expected error when allocating more than the limit of the parent")
+	}
+	if err := b.Allocate(40); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	a.Free(60)
+
+	if want, got := int64(40), parent.Allocated(); want != got {
+		t.Fatalf("unexpected allocated count of the parent -want/+got\n\t- %d\n\t+ %d", want, got)
+	}
+	if want, got := int64(100), parent.MaxAllocated(); want != got {
+		t.Fatalf("unexpected max allocated count of the parent -want/+got\n\t- %d\n\t+ %d", want, got)
+	}
+	if want, got := int64(60), a.MaxAllocated(); want != got {
+		t.Fatalf("unexpected max allocated count -want/+got\n\t- %d\n\t+ %d", want, got)
+	}
+	if want, got := int64(40), b.Allocated(); want != got {
+		t.Fatalf("unexpected allocated count -want/+got\n\t- %d\n\t+ %d", want, got)
+	}
+}
@@ -0,0 +1,113 @@
+package memory
+
+import (
+	"math/bits"
+	"sync"
+	"unsafe"
+)
+
+const (
+	// DefaultPoolLimit is the default limit on the memory retained by the pool of a query.
+	DefaultPoolLimit = 32 << 20
+
+	minPoolClass = 6  // 64 bytes
+	maxPoolClass = 20 // 1 MiB
+
+	// poolAlignment is the alignment of the buffers, which is the alignment of the arrow buffers.
+	poolAlignment = 64
+)
+
+// Pool holds the buffers that were freed by a query so that its later allocations reuse them.
+// Queries that build many small tables otherwise allocate a new buffer for each of them,
+// which makes the garbage collector dominate their execution.
+//
+// The buffers are pooled by size class, the powers of two between 64 bytes and 1 MiB.
+// Larger buffers are not pooled. The buffers retained by the pool are not accounted
+// for as allocated by the Allocator of the query, since the query does not use them.
+// Only noscan memory is pooled, so a Pool may only be used for buffers
+// that do not hold pointers.
+type Pool struct {
+	mu       sync.Mutex
+	free     [maxPoolClass - minPoolClass + 1][][]byte
+	retained int64
+	limit    int64
+}
+
+// NewPool returns a Pool that retains at most limit bytes of free buffers.
+func NewPool(limit int64) *Pool {
+	return &Pool{limit: limit}
+}
+
+// poolClass returns the size class of buffers of the given size, or -1 if they are not pooled.
+func poolClass(size int) int {
+	if size > 1<<maxPoolClass {
+		return -1
+	}
+	c := minPoolClass
+	if size > 1<<minPoolClass {
+		c = bits.Len(uint(size - 1))
+	}
+	return c - minPoolClass
+}
+
+// Cap returns the capacity of the buffers returned by Get for the given size.
+func (p *Pool) Cap(size int) int {
+	if c := poolClass(size); c >= 0 {
+		return 1 << uint(c+minPoolClass)
+	}
+	return size
+}
+
+// Get returns a zeroed buffer of length size with a capacity of Cap(size).
+func (p *Pool) Get(size int) []byte {
+	n := p.Cap(size)
+	if c := poolClass(size); c >= 0 {
+		p.mu.Lock()
+		if free := p.free[c]; len(free) > 0 {
+			b := free[len(free)-1]
+			free[len(free)-1] = nil
+			p.free[c] = free[:len(free)-1]
+			p.retained -= int64(n)
+			p.mu.Unlock()
+			for i := range b {
+				b[i] = 0
+			}
+			return b[:size]
+		}
+		p.mu.Unlock()
+	}
+	b := make([]byte, n+poolAlignment)
+	shift := 0
+	if r := int(uintptr(unsafe.Pointer(&b[0])) % poolAlignment); r != 0 {
+		shift = poolAlignment - r
+	}
+	return b[shift : shift+size : shift+n]
+}
+
+// Put returns a buffer to the pool.
+// The buffer must not be used after it is returned.
+// Buffers whose capacity is not a size class, that are not aligned like the buffers of the pool,
+// or that would exceed the limit of the pool, are dropped.
+func (p *Pool) Put(b []byte) {
+	n := cap(b)
+	c := poolClass(n)
+	if c < 0 || 1<<uint(c+minPoolClass) != n {
+		return
+	}
+	if uintptr(unsafe.Pointer(&b[:1][0]))%poolAlignment != 0 {
+		return
+	}
+	p.mu.Lock()
+	if p.retained+int64(n) <= p.limit {
+		p.free[c] = append(p.free[c], b[:n])
+		p.retained += int64(n)
+	}
+	p.mu.Unlock()
+}
+
+// Retained returns the number of bytes of the free buffers retained by the pool.
+func (p *Pool) Retained() int64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.retained
+}
@@ -0,0 +1,75 @@
+package memory_test
+
+import (
+	"testing"
+	"unsafe"
+
+	"github.com/influxdata/flux/memory"
+)
+
+func TestPool_Reuse(t *testing.T) {
+	pool := memory.NewPool(1 << 20)
+	for _, tt := range []struct {
+		size, cap int
+	}{
+		{size: 1, cap: 64},
+		{size: 64, cap: 64},
+		{size: 65, cap: 128},
+		{size: 1000, cap: 1024},
+		{size: 1 << 20, cap: 1 << 20},
+		{size: 1<<20 + 1, cap: 1<<20 + 1},
+	} {
+		b := pool.Get(tt.size)
+		if len(b) != tt.size || cap(b) != tt.cap {
+			t.Fatalf("unexpected buffer for size %d: want len %d cap %d, got len %d cap %d", tt.size, tt.size, tt.cap, len(b), cap(b))
+		}
+		if got := pool.Cap(tt.size); got != tt.cap {
+			t.Errorf("unexpected capacity for size %d: want %d, got %d", tt.size, tt.cap, got)
+		}
+		if p := uintptr(unsafe.Pointer(&b[0])); p%64 != 0 {
+			t.Errorf("buffer for size %d is not aligned: %x", tt.size, p)
+		}
+	}
+
+	b := pool.Get(100)
+	for i := range b {
+		b[i] = 0xff
+	}
+	pool.Put(b)
+	if want, got := int64(128), pool.Retained(); want != got {
+		t.Fatalf("unexpected retained memory: want %d, got %d", want, got)
+	}
+
+	// The buffer is reused for the same size class, and zeroed.
+	r := pool.Get(120)
+	if &r[0] != &b[0] {
+		t.Fatal("expected the buffer to be reused")
+	}
+	for i, v := range r[:cap(r)] {
+		if v != 0 {
+			t.Fatalf("reused buffer is not zeroed at %d", i)
+		}
+	}
+	if want, got := int64(0), pool.Retained(); want != got {
+		t.Fatalf("unexpected retained memory: want %d, got %d", want, got)
+	}
+}
+
+func TestPool_Limit(t *testing.T) {
+	pool := memory.NewPool(256)
+	bufs := [][]byte{pool.Get(128), pool.Get(128), pool.Get(128), pool.Get(1 << 21)}
+	for _, b := range bufs {
+		pool.Put(b)
+	}
+	if want, got := int64(256), pool.Retained(); want != got {
+		t.Fatalf("unexpected retained memory: want %d, got %d", want, got)
+	}
+
+	// Buffers that are not a size class are not pooled.
+	pool = memory.NewPool(1 << 20)
+	pool.Put(make([]byte, 100))
+	pool.Put(pool.Get(256)[64:])
+	if want, got := int64(0), pool.Retained(); want != got {
+		t.Fatalf("unexpected retained memory: want %d, got %d", want, got)
+	}
+}
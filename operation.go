@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 
+	"github.com/influxdata/flux/ast"
 	"github.com/pkg/errors"
 )
 
@@ -11,6 +12,8 @@ import (
 type Operation struct {
 	ID   OperationID   `json:"id"`
 	Spec OperationSpec `json:"spec"`
+	// Location is the location in the script of the call that produced the operation, if it is known.
+	Location *ast.SourceLocation `json:"location,omitempty"`
 }
 
 func (o *Operation) UnmarshalJSON(data []byte) error {
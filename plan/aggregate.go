@@ -0,0 +1,58 @@
+package plan
+
+// SplittableAggregateSpec is implemented by the procedure specs of aggregates
+// that can be computed in two phases.
+//
+// The partial phase aggregates the rows of a table into partial aggregates with the same group key.
+// It may run on parts of the rows of a table, for example in a data source it has been pushed down into,
+// so it may output several rows for the same table.
+// The final phase combines all the partial aggregates of a table into the aggregate of the table,
+// with the same output as the aggregate computed in one phase.
+type SplittableAggregateSpec interface {
+	ProcedureSpec
+	// SplitAggregate returns the specs of the partial and final phases of the aggregate,
+	// or false if the aggregate cannot be split as it is configured.
+	SplitAggregate() (partial, final PhysicalProcedureSpec, ok bool)
+}
+
+// SplitAggregate splits the node of an aggregate into a node of its partial phase
+// followed by a node of its final phase, and returns the final node.
+//
+//	S1   S2        S1   S2
+//	  \ /
+//	 node             final
+//	   |     ==>        |
+//	   P             partial
+//	                    |
+//	                    P
+//
+// As is convention, the final node will not have any successors attached.
+// The planner will take care of this.
+// It returns false if the node is not a splittable aggregate with a single predecessor.
+func SplitAggregate(node PlanNode) (PlanNode, bool) {
+	spec, ok := node.ProcedureSpec().(SplittableAggregateSpec)
+	if !ok || len(node.Predecessors()) != 1 {
+		return node, false
+	}
+	partialSpec, finalSpec, ok := spec.SplitAggregate()
+	if !ok {
+		return node, false
+	}
+
+	partial := CreatePhysicalNode(node.ID()+"_partial", partialSpec)
+	final := CreatePhysicalNode(node.ID()+"_final", finalSpec)
+	partial.SetLocation(node.Location())
+	final.SetLocation(node.Location())
+
+	pred := node.Predecessors()[0]
+	for i, succ := range pred.Successors() {
+		if succ == node {
+			pred.Successors()[i] = partial
+		}
+	}
+	partial.AddPredecessors(pred)
+	partial.AddSuccessors(final)
+	final.AddPredecessors(partial)
+	node.ClearPredecessors()
+	return final, true
+}
@@ -0,0 +1,123 @@
+package plan_test
+
+import (
+	"testing"
+
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/plan/plantest"
+)
+
+const aggregateKind = "aggregate"
+
+type aggregateSpec struct {
+	plan.DefaultCost
+	Splittable bool
+}
+
+func (aggregateSpec) Kind() plan.ProcedureKind {
+	return aggregateKind
+}
+
+func (s aggregateSpec) Copy() plan.ProcedureSpec {
+	return s
+}
+
+func (s aggregateSpec) SplitAggregate() (partial, final plan.PhysicalProcedureSpec, ok bool) {
+	if !s.Splittable {
+		return nil, nil, false
+	}
+	return phaseSpec{Phase: "partial"}, phaseSpec{Phase: "final"}, true
+}
+
+type phaseSpec struct {
+	plan.DefaultCost
+	Phase string
+}
+
+func (s phaseSpec) Kind() plan.ProcedureKind {
+	return plan.ProcedureKind(s.Phase)
+}
+
+func (s phaseSpec) Copy() plan.ProcedureSpec {
+	return s
+}
+
+type splitAggregateRule struct{}
+
+func (splitAggregateRule) Name() string {
+	return "splitAggregateRule"
+}
+
+func (splitAggregateRule) Pattern() plan.Pattern {
+	return plan.Pat(aggregateKind, plan.Any())
+}
+
+func (splitAggregateRule) Rewrite(node plan.PlanNode) (plan.PlanNode, bool, error) {
+	final, ok := plan.SplitAggregate(node)
+	return final, ok, nil
+}
+
+func TestSplitAggregate(t *testing.T) {
+	tests := []plantest.RuleTestCase{
+		{
+			Name:  "split",
+			Rules: []plan.Rule{splitAggregateRule{}},
+			Before: &plantest.PlanSpec{
+				Nodes: []plan.PlanNode{
+					plantest.CreatePhysicalMockNode("0"),
+					plan.CreatePhysicalNode("aggregate", aggregateSpec{Splittable: true}),
+					plantest.CreatePhysicalMockNode("2"),
+				},
+				Edges: [][2]int{{0, 1}, {1, 2}},
+			},
+			After: &plantest.PlanSpec{
+				Nodes: []plan.PlanNode{
+					plantest.CreatePhysicalMockNode("0"),
+					plan.CreatePhysicalNode("aggregate_partial", phaseSpec{Phase: "partial"}),
+					plan.CreatePhysicalNode("aggregate_final", phaseSpec{Phase: "final"}),
+					plantest.CreatePhysicalMockNode("2"),
+				},
+				Edges: [][2]int{{0, 1}, {1, 2}, {2, 3}},
+			},
+		},
+		{
+			Name:  "split root",
+			Rules: []plan.Rule{splitAggregateRule{}},
+			Before: &plantest.PlanSpec{
+				Nodes: []plan.PlanNode{
+					plantest.CreatePhysicalMockNode("0"),
+					plan.CreatePhysicalNode("aggregate", aggregateSpec{Splittable: true}),
+				},
+				Edges: [][2]int{{0, 1}},
+			},
+			After: &plantest.PlanSpec{
+				Nodes: []plan.PlanNode{
+					plantest.CreatePhysicalMockNode("0"),
+					plan.CreatePhysicalNode("aggregate_partial", phaseSpec{Phase: "partial"}),
+					plan.CreatePhysicalNode("aggregate_final", phaseSpec{Phase: "final"}),
+				},
+				Edges: [][2]int{{0, 1}, {1, 2}},
+			},
+		},
+		{
+			Name:  "not splittable",
+			Rules: []plan.Rule{splitAggregateRule{}},
+			Before: &plantest.PlanSpec{
+				Nodes: []plan.PlanNode{
+					plantest.CreatePhysicalMockNode("0"),
+					plan.CreatePhysicalNode("aggregate", aggregateSpec{}),
+				},
+				Edges: [][2]int{{0, 1}},
+			},
+			NoChange: true,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			plantest.PhysicalRuleTestHelper(t, &tc)
+		})
+	}
+}
@@ -22,15 +22,20 @@ type BoundsAwareProcedureSpec interface {
 
 // ComputeBounds computes the time bounds for a
 // plan node from the bounds of its predecessors.
+// If any of the predecessors is not bounded in time, such as a source
+// that does not read data by time, the data of the node is not bounded
+// either, unless its procedure bounds it.
 func ComputeBounds(node PlanNode) error {
 	var bounds *Bounds
 
-	for _, pred := range node.Predecessors() {
-
-		if pred.Bounds() != nil && bounds == nil {
-			bounds = pred.Bounds()
+	for i, pred := range node.Predecessors() {
+		if pred.Bounds() == nil {
+			bounds = nil
+			break
 		}
-		if pred.Bounds() != nil && bounds != nil {
+		if i == 0 {
+			bounds = pred.Bounds()
+		} else {
 			bounds = bounds.Union(pred.Bounds())
 		}
 	}
@@ -390,7 +390,7 @@ func TestBounds_ComputePlanBounds(t *testing.T) {
 			spec: &plantest.PlanSpec{
 				Nodes: []plan.PlanNode{
 					plantest.CreatePhysicalMockNode("0"),
-					makeShiftNode("1", values.Duration(5)),
+					makeShiftNode("1", values.ConvertDuration(5)),
 					plantest.CreatePhysicalMockNode("2"),
 				},
 				Edges: [][2]int{
@@ -412,7 +412,7 @@ func TestBounds_ComputePlanBounds(t *testing.T) {
 					plantest.CreatePhysicalMockNode("0"),
 					makeBoundsNode("1", bounds(5, 10)),
 					plantest.CreatePhysicalMockNode("2"),
-					makeShiftNode("3", values.Duration(5)),
+					makeShiftNode("3", values.ConvertDuration(5)),
 					plantest.CreatePhysicalMockNode("4"),
 				},
 				Edges: [][2]int{
@@ -451,6 +451,55 @@ func TestBounds_ComputePlanBounds(t *testing.T) {
 				"2": bounds(5, 20),
 			},
 		},
+		{
+			name: "join with an unbounded source",
+			//   2
+			//  / \
+			// 0   1
+			spec: &plantest.PlanSpec{
+				Nodes: []plan.PlanNode{
+					makeBoundsNode("0", bounds(5, 10)),
+					plantest.CreatePhysicalMockNode("1"),
+					plantest.CreatePhysicalMockNode("2"),
+				},
+				Edges: [][2]int{
+					{0, 2},
+					{1, 2},
+				},
+			},
+			want: map[plan.NodeID]*plan.Bounds{
+				"0": bounds(5, 10),
+				"1": nil,
+				"2": nil,
+			},
+		},
+		{
+			name: "bounded join of an unbounded source",
+			//   3
+			//   |
+			//   2
+			//  / \
+			// 0   1
+			spec: &plantest.PlanSpec{
+				Nodes: []plan.PlanNode{
+					plantest.CreatePhysicalMockNode("0"),
+					makeBoundsNode("1", bounds(12, 20)),
+					plantest.CreatePhysicalMockNode("2"),
+					makeBoundsNode("3", bounds(5, 15)),
+				},
+				Edges: [][2]int{
+					{0, 2},
+					{1, 2},
+					{2, 3},
+				},
+			},
+			want: map[plan.NodeID]*plan.Bounds{
+				"0": nil,
+				"1": bounds(12, 20),
+				"2": nil,
+				"3": bounds(5, 15),
+			},
+		},
 		{
 			name: "yields",
 			// 3   4
@@ -15,15 +15,31 @@ func Formatted(p *PlanSpec, opts ...FormatOption) fmt.Formatter {
 	return f
 }
 
+// WithSchemas labels the nodes of the formatted plan with their inferred schemas, see InferSchema.
+func WithSchemas() FormatOption {
+	return func(f *formatter) {
+		f.schemas = true
+	}
+}
+
 type formatter struct {
-	p *PlanSpec
+	p       *PlanSpec
+	schemas bool
 }
 
 func (f formatter) Format(fs fmt.State, c rune) {
 	fmt.Fprintf(fs, "\ndigraph {\n")
 	var edges []string
 	f.p.BottomUpWalk(func(pn PlanNode) error {
-		fmt.Fprintf(fs, "  %v\n", pn.ID())
+		if f.schemas {
+			schema := "unknown"
+			if s := pn.Schema(); s != nil {
+				schema = s.String()
+			}
+			fmt.Fprintf(fs, "  %v [label=%q]\n", pn.ID(), fmt.Sprintf("%v\n%v", pn.ID(), schema))
+		} else {
+			fmt.Fprintf(fs, "  %v\n", pn.ID())
+		}
 		for _, pred := range pn.Predecessors() {
 			edges = append(edges, fmt.Sprintf("  %v -> %v", pred.ID(), pn.ID()))
 		}
@@ -1,6 +1,10 @@
 package plan
 
-import "sort"
+import (
+	"sort"
+
+	"github.com/influxdata/flux/dependencies"
+)
 
 // heuristicPlanner applies a set of rules to the nodes in a PlanSpec
 // until a fixed point is reached and no more rules can be applied.
@@ -27,11 +31,12 @@ func (p *heuristicPlanner) clearRules() {
 
 // matchRules applies any applicable rules to the given plan node,
 // and returns the rewritten plan node and whether or not any rewriting was done.
-func (p *heuristicPlanner) matchRules(node PlanNode) (PlanNode, bool, error) {
+// Flagged rules are only applied when the flagger enables them.
+func (p *heuristicPlanner) matchRules(node PlanNode, flagger dependencies.Flagger) (PlanNode, bool, error) {
 	anyChanged := false
 
 	for _, rule := range p.rules[AnyKind] {
-		if rule.Pattern().Match(node) {
+		if ruleEnabled(rule, flagger) && rule.Pattern().Match(node) {
 			newNode, changed, err := rule.Rewrite(node)
 			if err != nil {
 				return nil, false, err
@@ -42,7 +47,7 @@ func (p *heuristicPlanner) matchRules(node PlanNode) (PlanNode, bool, error) {
 	}
 
 	for _, rule := range p.rules[node.Kind()] {
-		if rule.Pattern().Match(node) {
+		if ruleEnabled(rule, flagger) && rule.Pattern().Match(node) {
 			newNode, changed, err := rule.Rewrite(node)
 			if err != nil {
 				return nil, false, err
@@ -84,7 +89,7 @@ func (p *heuristicPlanner) Plan(inputPlan *PlanSpec) (*PlanSpec, error) {
 			_, alreadyVisited := visited[node]
 
 			if !alreadyVisited {
-				newNode, changed, err := p.matchRules(node)
+				newNode, changed, err := p.matchRules(node, inputPlan.Flagger)
 				if err != nil {
 					return nil, err
 				}
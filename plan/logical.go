@@ -2,9 +2,11 @@ package plan
 
 import (
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/influxdata/flux"
+	fluxerrors "github.com/influxdata/flux/errors"
 )
 
 // LogicalPlanner translates a flux.Spec into a PlanSpec and applies any
@@ -58,6 +60,7 @@ func (opt logicalOption) apply(lp *logicalPlanner) {
 type logicalPlanner struct {
 	*heuristicPlanner
 	disableIntegrityChecks bool
+	inferSchemas           bool
 }
 
 // OnlyLogicalRules produces a logical plan option that forces only a set of particular rules to be
@@ -76,6 +79,16 @@ func DisableIntegrityChecks() LogicalOption {
 	})
 }
 
+// InferSchemas produces a logical plan option that infers the schema of the tables
+// produced by every node of the plan, see InferSchema.
+// The procedures that validate the schemas of their inputs fail the plan
+// before any physical planning.
+func InferSchemas() LogicalOption {
+	return logicalOption(func(lp *logicalPlanner) {
+		lp.inferSchemas = true
+	})
+}
+
 // CreateInitialPlan translates the flux.Spec into an unoptimized, naive plan.
 func (l *logicalPlanner) CreateInitialPlan(spec *flux.Spec) (*PlanSpec, error) {
 	return createLogicalPlan(spec)
@@ -96,6 +109,12 @@ func (l *logicalPlanner) Plan(logicalPlan *PlanSpec) (*PlanSpec, error) {
 		}
 	}
 
+	if l.inferSchemas {
+		if err := newLogicalPlan.BottomUpWalk(InferSchema); err != nil {
+			return nil, err
+		}
+	}
+
 	return newLogicalPlan, nil
 }
 
@@ -112,6 +131,8 @@ func (a administration) Now() time.Time {
 type LogicalPlanNode struct {
 	edges
 	bounds
+	schema
+	location
 	id   NodeID
 	Spec ProcedureSpec
 }
@@ -139,6 +160,7 @@ func (lpn *LogicalPlanNode) ReplaceSpec(newSpec ProcedureSpec) error {
 func (lpn *LogicalPlanNode) ShallowCopy() PlanNode {
 	newNode := new(LogicalPlanNode)
 	newNode.edges = lpn.edges.shallowCopy()
+	newNode.location = lpn.location
 	newNode.id = lpn.id + "_copy"
 	newNode.Spec = lpn.Spec.Copy()
 	return newNode
@@ -158,13 +180,26 @@ func createLogicalPlan(spec *flux.Spec) (*PlanSpec, error) {
 		spec:       spec,
 		plan:       plan,
 		nodes:      nodes,
-		yieldNames: make(map[string]struct{}),
+		yieldNames: make(map[string]int),
+		opIndex:    make(map[flux.OperationID]int, len(spec.Operations)),
+	}
+	for i, o := range spec.Operations {
+		v.opIndex[o.ID] = i
 	}
 
 	if err := spec.Walk(v.visitOperation); err != nil {
 		return nil, err
 	}
 
+	// The operations of the spec are in the order they were declared in the script,
+	// while the walk visits them in topological order.
+	sort.SliceStable(v.yields, func(i, j int) bool {
+		return v.yields[i].index < v.yields[j].index
+	})
+	for _, y := range v.yields {
+		v.plan.Results = append(v.plan.Results, y.name)
+	}
+
 	return v.plan, nil
 }
 
@@ -174,24 +209,45 @@ type fluxSpecVisitor struct {
 	spec       *flux.Spec
 	plan       *PlanSpec
 	nodes      map[flux.OperationID]PlanNode
-	yieldNames map[string]struct{}
+	yieldNames map[string]int
+	yields     []yieldDecl
+	opIndex    map[flux.OperationID]int
 }
 
-func (v *fluxSpecVisitor) addYieldName(pn PlanNode) error {
+// yieldDecl is a result of the plan, its yield node and the index of the operation that declared it.
+type yieldDecl struct {
+	name  string
+	node  PlanNode
+	index int
+}
+
+// addYieldName records the result produced by the yield node pn,
+// which was declared by the operation with the given ID.
+func (v *fluxSpecVisitor) addYieldName(pn PlanNode, decl flux.OperationID) error {
 	yieldSpec := pn.ProcedureSpec().(YieldProcedureSpec)
-	name := yieldSpec.YieldName()
-	_, isDup := v.yieldNames[name]
-	if isDup {
-		return fmt.Errorf("duplicate yield name \"%v\" found on plan node: %v", name, pn.ID())
+	y := yieldDecl{name: yieldSpec.YieldName(), node: pn, index: v.opIndex[decl]}
+	if i, isDup := v.yieldNames[y.name]; isDup {
+		// Report the yield that was declared last, whichever was visited first.
+		first, dup := v.yields[i], y
+		if dup.index < first.index {
+			first, dup = dup, first
+		}
+		return &ValidationError{
+			Node:     dup.node.ID(),
+			Location: dup.node.Location(),
+			Err:      fmt.Errorf("duplicate yield name %q, already used by plan node %q", y.name, first.node.ID()),
+		}
 	}
 
-	v.yieldNames[name] = struct{}{}
+	v.yieldNames[y.name] = len(v.yields)
+	v.yields = append(v.yields, y)
 	return nil
 }
 
 func generateYieldNode(pred PlanNode) PlanNode {
 	yieldSpec := &GeneratedYieldProcedureSpec{Name: DefaultYieldName}
 	yieldNode := CreateLogicalNode(NodeID("generated_yield"), yieldSpec)
+	yieldNode.SetLocation(pred.Location())
 	pred.AddSuccessors(yieldNode)
 	yieldNode.AddPredecessors(pred)
 	return yieldNode
@@ -204,7 +260,7 @@ func (v *fluxSpecVisitor) visitOperation(o *flux.Operation) error {
 	createFns, ok := createProcedureFnsFromKind(o.Spec.Kind())
 
 	if !ok {
-		return fmt.Errorf("no ProcedureSpec available for %s", o.Spec.Kind())
+		return fluxerrors.Newf(fluxerrors.Unimplemented, "no ProcedureSpec available for %s", o.Spec.Kind())
 	}
 
 	// TODO: differentiate between logical and physical procedures.
@@ -220,6 +276,7 @@ func (v *fluxSpecVisitor) visitOperation(o *flux.Operation) error {
 
 	// Create a LogicalPlanNode using the ProcedureSpec
 	logicalNode := CreateLogicalNode(NodeID(o.ID), procedureSpec)
+	logicalNode.SetLocation(o.Location)
 
 	v.nodes[o.ID] = logicalNode
 
@@ -232,7 +289,7 @@ func (v *fluxSpecVisitor) visitOperation(o *flux.Operation) error {
 
 	_, isYield := procedureSpec.(YieldProcedureSpec)
 	if isYield {
-		err = v.addYieldName(logicalNode)
+		err = v.addYieldName(logicalNode, o.ID)
 		if err != nil {
 			return err
 		}
@@ -245,7 +302,7 @@ func (v *fluxSpecVisitor) visitOperation(o *flux.Operation) error {
 		} else {
 			// Generate a yield node
 			generateYieldNode := generateYieldNode(logicalNode)
-			err = v.addYieldName(generateYieldNode)
+			err = v.addYieldName(generateYieldNode, o.ID)
 			if err != nil {
 				return err
 			}
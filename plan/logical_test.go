@@ -99,6 +99,7 @@ func TestPlan_LogicalPlanFromSpec(t *testing.T) {
 		joinSpec = &universe.MergeJoinProcedureSpec{
 			TableNames: []string{"a", "b"},
 			On:         []string{"_time"},
+			Missing:    "drop",
 		}
 		toHTTPSpec = &http.ToHTTPProcedureSpec{
 			Spec: &toHTTPOpSpec,
@@ -641,3 +642,51 @@ from(bucket: "telegraf")
 		t.Fatal("unexpected pass")
 	}
 }
+
+func TestLogicalPlanner_Results(t *testing.T) {
+	script := `
+data = from(bucket: "telegraf")
+data |> filter(fn: (r) => r._value > 0.0) |> yield(name: "zeta")
+data |> yield(name: "alpha")
+from(bucket: "other") |> yield(name: "mid")
+`
+
+	spec, err := compile(script, time.Unix(0, 0))
+	if err != nil {
+		t.Fatalf("could not compile flux query: %v", err)
+	}
+	initPlan, err := plan.NewLogicalPlanner().CreateInitialPlan(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"zeta", "alpha", "mid"}
+	if got := initPlan.Results; !cmp.Equal(want, got) {
+		t.Errorf("unexpected results -want/+got\n%s", cmp.Diff(want, got))
+	}
+}
+
+func TestLogicalPlanner_DuplicateYieldName(t *testing.T) {
+	script := `from(bucket: "telegraf") |> yield(name: "result")
+from(bucket: "other") |> yield(name: "result")
+`
+
+	spec, err := compile(script, time.Unix(0, 0))
+	if err != nil {
+		t.Fatalf("could not compile flux query: %v", err)
+	}
+	_, err = plan.NewLogicalPlanner().CreateInitialPlan(spec)
+	if err == nil {
+		t.Fatal("expected an error for the duplicate yield name")
+	}
+	verr, ok := err.(*plan.ValidationError)
+	if !ok {
+		t.Fatalf("expected a validation error, got %T: %v", err, err)
+	}
+	if verr.Location == nil {
+		t.Fatal("expected the location of the duplicate yield")
+	}
+	if want, got := 2, verr.Location.Start.Line; want != got {
+		t.Errorf("unexpected line of the duplicate yield: want %d, got %d", want, got)
+	}
+}
@@ -0,0 +1,49 @@
+package plan
+
+// OrderedProcedureSpec is any procedure that knows
+// the order of the rows of the tables it produces.
+type OrderedProcedureSpec interface {
+	// SortedBy returns the columns by which the rows of every table
+	// produced by the procedure are sorted in ascending order,
+	// given those of its predecessors, or nil if the order is not known.
+	SortedBy(predecessors [][]string) []string
+}
+
+// SortedBy returns the columns by which the rows of every table
+// produced by the node are sorted in ascending order,
+// or nil if the order is not known.
+func SortedBy(node PlanNode) []string {
+	s, ok := node.ProcedureSpec().(OrderedProcedureSpec)
+	if !ok {
+		return nil
+	}
+	preds := node.Predecessors()
+	sorted := make([][]string, len(preds))
+	for i, pred := range preds {
+		sorted[i] = SortedBy(pred)
+	}
+	return s.SortedBy(sorted)
+}
+
+// IsSortedBy reports whether rows sorted by the columns sorted
+// are also sorted by the columns cols, that is whether cols is a prefix of sorted.
+func IsSortedBy(sorted, cols []string) bool {
+	if len(cols) == 0 || len(cols) > len(sorted) {
+		return false
+	}
+	for i, c := range cols {
+		if sorted[i] != c {
+			return false
+		}
+	}
+	return true
+}
+
+// ComputeOutputAttrs computes the physical attributes
+// of the output of a physical plan node.
+func ComputeOutputAttrs(node PlanNode) error {
+	if ppn, ok := node.(*PhysicalPlanNode); ok {
+		ppn.OutputAttrs.SortedBy = SortedBy(node)
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package plan_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/plan/plantest"
+)
+
+// orderedSpec sorts the rows of its input by its columns,
+// or keeps their order if it has no columns.
+type orderedSpec struct {
+	plan.DefaultCost
+	columns []string
+}
+
+func (s *orderedSpec) Kind() plan.ProcedureKind {
+	return "ordered"
+}
+
+func (s *orderedSpec) Copy() plan.ProcedureSpec {
+	return &orderedSpec{columns: s.columns}
+}
+
+func (s *orderedSpec) SortedBy(predecessors [][]string) []string {
+	if s.columns == nil {
+		return predecessors[0]
+	}
+	return s.columns
+}
+
+func TestIsSortedBy(t *testing.T) {
+	for _, tc := range []struct {
+		sorted, cols []string
+		want         bool
+	}{
+		{sorted: []string{"_time"}, cols: []string{"_time"}, want: true},
+		{sorted: []string{"_time", "t0"}, cols: []string{"_time"}, want: true},
+		{sorted: []string{"_time"}, cols: []string{"_time", "t0"}},
+		{sorted: []string{"t0", "_time"}, cols: []string{"_time"}},
+		{sorted: nil, cols: []string{"_time"}},
+		{sorted: []string{"_time"}, cols: nil},
+	} {
+		if got := plan.IsSortedBy(tc.sorted, tc.cols); got != tc.want {
+			t.Errorf("IsSortedBy(%v, %v): want %v, got %v", tc.sorted, tc.cols, tc.want, got)
+		}
+	}
+}
+
+func TestPhysicalPlanner_OutputAttrs(t *testing.T) {
+	spec := plantest.CreatePlanSpec(&plantest.PlanSpec{
+		Nodes: []plan.PlanNode{
+			plantest.CreatePhysicalMockNode("0"),
+			plan.CreatePhysicalNode("1", &orderedSpec{columns: []string{"_time"}}),
+			plan.CreatePhysicalNode("2", &orderedSpec{}),
+		},
+		Edges: [][2]int{
+			{0, 1},
+			{1, 2},
+		},
+	})
+
+	pp, err := plan.NewPhysicalPlanner(plan.DisableValidation()).Plan(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[plan.NodeID][]string{
+		"0": nil,
+		"1": {"_time"},
+		"2": {"_time"},
+	}
+	got := make(map[plan.NodeID][]string)
+	if err := pp.BottomUpWalk(func(node plan.PlanNode) error {
+		got[node.ID()] = node.(*plan.PhysicalPlanNode).OutputAttrs.SortedBy
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if !cmp.Equal(want, got) {
+		t.Errorf("unexpected sort order -want/+got:\n%s", cmp.Diff(want, got))
+	}
+}
@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"math"
 
-	"github.com/pkg/errors"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/ast"
+	fluxerrors "github.com/influxdata/flux/errors"
 )
 
 // PhysicalPlanner performs transforms a logical plan to a physical plan,
@@ -47,11 +49,23 @@ func (pp *physicalPlanner) Plan(spec *PlanSpec) (*PlanSpec, error) {
 		return nil, err
 	}
 
+	// Bound any reads that are still unbounded
+	if pp.defaultRange != nil {
+		if err := applyDefaultRange(transformedSpec, *pp.defaultRange); err != nil {
+			return nil, err
+		}
+	}
+
 	// Compute time bounds for nodes in the plan
 	if err := transformedSpec.BottomUpWalk(ComputeBounds); err != nil {
 		return nil, err
 	}
 
+	// Compute the order of the rows produced by nodes in the plan
+	if err := transformedSpec.BottomUpWalk(ComputeOutputAttrs); err != nil {
+		return nil, err
+	}
+
 	// Ensure that the plan is valid
 	if !pp.disableValidation {
 		err := transformedSpec.CheckIntegrity()
@@ -80,12 +94,23 @@ func (pp *physicalPlanner) Plan(spec *PlanSpec) (*PlanSpec, error) {
 
 func validatePhysicalPlan(plan *PlanSpec) error {
 	err := plan.BottomUpWalk(func(pn PlanNode) error {
+		if err := validateSchema(pn); err != nil {
+			return err
+		}
+
 		if validator, ok := pn.ProcedureSpec().(PostPhysicalValidator); ok {
-			return validator.PostPhysicalValidate(pn.ID())
+			if err := validator.PostPhysicalValidate(pn.ID()); err != nil {
+				return &ValidationError{
+					Node:     pn.ID(),
+					Location: pn.Location(),
+					Err:      err,
+				}
+			}
+			return nil
 		}
 
 		if _, ok := pn.(*PhysicalPlanNode); !ok {
-			return errors.Errorf("logical node \"%v\" could not be converted to a physical node", pn.ID())
+			return fluxerrors.Newf(fluxerrors.Unimplemented, "logical node \"%v\" could not be converted to a physical node", pn.ID())
 		}
 
 		return nil
@@ -93,10 +118,45 @@ func validatePhysicalPlan(plan *PlanSpec) error {
 	return err
 }
 
+// applyDefaultRange bounds the procedures of the plan that would otherwise read an unbounded range of time.
+func applyDefaultRange(plan *PlanSpec, r flux.Bounds) error {
+	r.Now = plan.Now
+	return plan.BottomUpWalk(func(pn PlanNode) error {
+		if spec, ok := pn.ProcedureSpec().(UnboundedProcedureSpec); ok {
+			return pn.ReplaceSpec(spec.WithDefaultRange(r))
+		}
+		return nil
+	})
+}
+
+// ValidationError is returned by the planners when a node of the plan is not valid.
+// It is not a causer, so errors.Cause finds it when it has been wrapped.
+type ValidationError struct {
+	// Node is the ID of the offending node.
+	Node NodeID
+	// Location is the location in the script of the call that produced the node, or nil if it is not known.
+	Location *ast.SourceLocation
+	// Err describes why the node is not valid.
+	Err error
+}
+
+func (e *ValidationError) Error() string {
+	if e.Location != nil && e.Location.IsValid() {
+		return fmt.Sprintf("invalid plan node %q at %v: %v", e.Node, e.Location, e.Err)
+	}
+	return fmt.Sprintf("invalid plan node %q: %v", e.Node, e.Err)
+}
+
+// Code implements errors.Coder, a plan with an invalid node comes from an invalid script.
+func (e *ValidationError) Code() fluxerrors.Code {
+	return fluxerrors.Invalid
+}
+
 type physicalPlanner struct {
 	*heuristicPlanner
 	defaultMemoryLimit int64
 	disableValidation  bool
+	defaultRange       *flux.Bounds
 }
 
 // PhysicalOption is an option to configure the behavior of the physical plan.
@@ -126,6 +186,26 @@ func OnlyPhysicalRules(rules ...Rule) PhysicalOption {
 	})
 }
 
+// AddPhysicalRules produces a physical plan option that applies the given rules
+// in addition to the registered ones.
+func AddPhysicalRules(rules ...Rule) PhysicalOption {
+	return physicalOption(func(pp *physicalPlanner) {
+		pp.addRules(rules...)
+	})
+}
+
+// WithDefaultRange produces a physical plan option that bounds the reads that are not bounded by a range
+// with the given range, instead of failing validation.
+// Relative times are relative to the now time of the plan.
+func WithDefaultRange(start, stop flux.Time) PhysicalOption {
+	return physicalOption(func(p *physicalPlanner) {
+		p.defaultRange = &flux.Bounds{
+			Start: start,
+			Stop:  stop,
+		}
+	})
+}
+
 // Disables validation in the physical planner
 func DisableValidation() PhysicalOption {
 	return physicalOption(func(p *physicalPlanner) {
@@ -161,9 +241,11 @@ func (physicalConverterRule) Rewrite(pn PlanNode) (PlanNode, bool, error) {
 	}
 
 	newNode := PhysicalPlanNode{
-		bounds: ln.bounds,
-		id:     ln.id,
-		Spec:   pspec,
+		bounds:   ln.bounds,
+		schema:   ln.schema,
+		location: ln.location,
+		id:       ln.id,
+		Spec:     pspec,
 	}
 
 	ReplaceNode(pn, &newNode)
@@ -182,6 +264,8 @@ type PhysicalProcedureSpec interface {
 type PhysicalPlanNode struct {
 	edges
 	bounds
+	schema
+	location
 	id   NodeID
 	Spec PhysicalProcedureSpec
 
@@ -205,7 +289,7 @@ func (ppn *PhysicalPlanNode) ProcedureSpec() ProcedureSpec {
 func (ppn *PhysicalPlanNode) ReplaceSpec(newSpec ProcedureSpec) error {
 	physSpec, ok := newSpec.(PhysicalProcedureSpec)
 	if !ok {
-		return fmt.Errorf("couldn't replace ProcedureSpec for physical plan node \"%v\"", ppn.ID())
+		return fluxerrors.Newf(fluxerrors.Internal, "couldn't replace ProcedureSpec for physical plan node \"%v\"", ppn.ID())
 	}
 
 	ppn.Spec = physSpec
@@ -220,6 +304,7 @@ func (ppn *PhysicalPlanNode) Kind() ProcedureKind {
 func (ppn *PhysicalPlanNode) ShallowCopy() PlanNode {
 	newNode := new(PhysicalPlanNode)
 	newNode.edges = ppn.edges.shallowCopy()
+	newNode.location = ppn.location
 	newNode.id = ppn.id + "_copy"
 	// TODO: the type assertion below... is it needed?
 	newNode.Spec = ppn.Spec.Copy().(PhysicalProcedureSpec)
@@ -235,6 +320,9 @@ func (ppn *PhysicalPlanNode) Cost(inStats []Statistics) (cost Cost, outStats Sta
 // PhysicalAttributes encapsulates sny physical attributes of the result produced
 // by a physical plan node, such as collation, etc.
 type PhysicalAttributes struct {
+	// SortedBy lists the columns by which the rows of every table
+	// are sorted in ascending order, or is nil if the order is not known.
+	SortedBy []string
 }
 
 // CreatePhysicalNode creates a single physical plan node from a procedure spec.
@@ -246,6 +334,13 @@ func CreatePhysicalNode(id NodeID, spec PhysicalProcedureSpec) *PhysicalPlanNode
 	}
 }
 
+// UnboundedProcedureSpec is implemented by PhysicalProcedureSpecs that read an unbounded range of time,
+// such as a read of a data source that no range has been pushed down into.
+// The physical planner asks them for a bounded procedure when it is configured with WithDefaultRange.
+type UnboundedProcedureSpec interface {
+	WithDefaultRange(bounds flux.Bounds) PhysicalProcedureSpec
+}
+
 // PostPhysicalValidator provides an interface that can be implemented by PhysicalProcedureSpecs for any
 // validation checks to be performed post-physical planning.
 type PostPhysicalValidator interface {
@@ -0,0 +1,379 @@
+package plantest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/values"
+)
+
+// Specs names the procedure specs that the nodes of a textual plan refer to.
+// The name "mock" refers to a MockProcedureSpec unless Specs names another spec so.
+type Specs map[string]plan.ProcedureSpec
+
+func (s Specs) lookup(name string) (plan.ProcedureSpec, bool) {
+	if spec, ok := s[name]; ok {
+		return spec, true
+	}
+	if name == MockKind {
+		return MockProcedureSpec{}, true
+	}
+	return nil, false
+}
+
+// names returns the names of the specs in order, so that a spec named several times is printed consistently.
+func (s Specs) names() []string {
+	names := make([]string, 0, len(s))
+	for name := range s {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ParsePlan builds the nodes and edges of a plan from its textual form.
+//
+// Every line of the text is a statement, and so is every part of a line separated by a semicolon.
+// Everything after a # is a comment.
+// A plan made of physical nodes starts with the statement physical,
+// otherwise the nodes are logical nodes.
+// The other statements are chains of nodes, from a predecessor to its successor:
+//
+//	from = from -> range = range [2019-01-01T00:00:00Z, 2019-01-01T01:00:00Z)
+//	range -> sort = byTime {"Desc": true}
+//
+// A node is declared once, with its ID and its procedure spec, and is then referred to by its ID.
+// The spec is a copy of the spec with its name in specs, or of the spec decoded from the JSON object
+// that follows its name, starting from the named spec.
+// The declaration of a node may end with the bounds of the node,
+// from a start time to a stop time in RFC3339 format or in nanoseconds since the epoch.
+// The predecessors of a node are in the order in which their edges appear in the text.
+func ParsePlan(text string, specs Specs) (*PlanSpec, error) {
+	p := &parser{
+		specs: specs,
+		nodes: make(map[plan.NodeID]int),
+		ps:    new(PlanSpec),
+	}
+	for i, line := range strings.Split(text, "\n") {
+		if j := indexOutsideStrings(line, "#"); j >= 0 {
+			line = line[:j]
+		}
+		for _, stmt := range splitOutsideStrings(line, ";") {
+			if err := p.parseStatement(stmt); err != nil {
+				return nil, fmt.Errorf("line %d: %v", i+1, err)
+			}
+		}
+	}
+	return p.ps, nil
+}
+
+// MustParsePlan is like ParsePlan but panics if the text cannot be parsed.
+func MustParsePlan(text string, specs Specs) *PlanSpec {
+	ps, err := ParsePlan(text, specs)
+	if err != nil {
+		panic(err)
+	}
+	return ps
+}
+
+type parser struct {
+	specs    Specs
+	physical bool
+	nodes    map[plan.NodeID]int
+	ps       *PlanSpec
+}
+
+func (p *parser) parseStatement(stmt string) error {
+	switch stmt = strings.TrimSpace(stmt); stmt {
+	case "":
+		return nil
+	case "physical":
+		if len(p.ps.Nodes) > 0 {
+			return fmt.Errorf("physical must precede the nodes of the plan")
+		}
+		p.physical = true
+		return nil
+	}
+
+	prev := -1
+	for _, elem := range splitOutsideStrings(stmt, "->") {
+		elem = strings.TrimSpace(elem)
+		var (
+			idx int
+			err error
+		)
+		if i := strings.Index(elem, "="); i >= 0 {
+			idx, err = p.declare(plan.NodeID(strings.TrimSpace(elem[:i])), strings.TrimSpace(elem[i+1:]))
+		} else {
+			idx, err = p.node(plan.NodeID(elem))
+		}
+		if err != nil {
+			return err
+		}
+		if prev >= 0 {
+			p.ps.Edges = append(p.ps.Edges, [2]int{prev, idx})
+		}
+		prev = idx
+	}
+	return nil
+}
+
+func (p *parser) node(id plan.NodeID) (int, error) {
+	if id == "" {
+		return 0, fmt.Errorf("missing node ID")
+	}
+	idx, ok := p.nodes[id]
+	if !ok {
+		return 0, fmt.Errorf("node %q is not declared", id)
+	}
+	return idx, nil
+}
+
+func (p *parser) declare(id plan.NodeID, spec string) (int, error) {
+	if id == "" {
+		return 0, fmt.Errorf("missing node ID")
+	}
+	if _, ok := p.nodes[id]; ok {
+		return 0, fmt.Errorf("node %q is declared more than once", id)
+	}
+	var bounds *plan.Bounds
+	if strings.HasSuffix(spec, ")") {
+		i := strings.LastIndex(spec, "[")
+		if i < 0 {
+			return 0, fmt.Errorf("node %q: bounds must start with [", id)
+		}
+		var err error
+		if bounds, err = parseBounds(spec[i:]); err != nil {
+			return 0, fmt.Errorf("node %q: %v", id, err)
+		}
+		spec = strings.TrimSpace(spec[:i])
+	}
+	ps, err := p.spec(spec)
+	if err != nil {
+		return 0, fmt.Errorf("node %q: %v", id, err)
+	}
+	var node plan.PlanNode
+	if p.physical {
+		pps, ok := ps.(plan.PhysicalProcedureSpec)
+		if !ok {
+			return 0, fmt.Errorf("node %q: %T is not a physical procedure spec", id, ps)
+		}
+		node = plan.CreatePhysicalNode(id, pps)
+	} else {
+		node = plan.CreateLogicalNode(id, ps)
+	}
+	if bounds != nil {
+		node.SetBounds(bounds)
+	}
+	p.nodes[id] = len(p.ps.Nodes)
+	p.ps.Nodes = append(p.ps.Nodes, node)
+	return p.nodes[id], nil
+}
+
+// spec returns the spec of a declaration, which is the name of a spec optionally followed by a JSON object.
+func (p *parser) spec(text string) (plan.ProcedureSpec, error) {
+	name, literal := text, ""
+	if i := strings.IndexAny(text, " \t{"); i >= 0 {
+		name, literal = text[:i], strings.TrimSpace(text[i:])
+	}
+	if name == "" {
+		return nil, fmt.Errorf("missing procedure spec")
+	}
+	spec, ok := p.specs.lookup(name)
+	if !ok {
+		return nil, fmt.Errorf("unknown procedure spec %q", name)
+	}
+	spec = spec.Copy()
+	if literal == "" {
+		return spec, nil
+	}
+
+	// Decode the literal into a copy of the named spec,
+	// through a pointer to it if the spec is not a pointer itself.
+	v := reflect.ValueOf(spec)
+	ptr := v
+	if v.Kind() != reflect.Ptr {
+		ptr = reflect.New(v.Type())
+		ptr.Elem().Set(v)
+	}
+	dec := json.NewDecoder(strings.NewReader(literal))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(ptr.Interface()); err != nil {
+		return nil, fmt.Errorf("invalid %s spec %s: %v", name, literal, err)
+	}
+	if dec.More() {
+		return nil, fmt.Errorf("unexpected text after the %s spec %s", name, literal)
+	}
+	if v.Kind() != reflect.Ptr {
+		return ptr.Elem().Interface().(plan.ProcedureSpec), nil
+	}
+	return ptr.Interface().(plan.ProcedureSpec), nil
+}
+
+// FormatPlan returns the textual form of a plan, which ParsePlan parses back into the same plan.
+// The specs of the nodes are printed with their name in specs, or with the name of a spec of the same type
+// followed by their JSON encoding, and the nodes that have bounds are printed with them.
+//
+// The nodes are declared in chains, in the order of a bottom up walk of the plan.
+// A chain goes on as long as a node is the only predecessor of its only successor.
+func FormatPlan(p *plan.PlanSpec, specs Specs) (string, error) {
+	var (
+		b        strings.Builder
+		order    []plan.PlanNode
+		physical bool
+	)
+	if err := p.BottomUpWalk(func(node plan.PlanNode) error {
+		if _, ok := node.(*plan.PhysicalPlanNode); ok {
+			physical = true
+		}
+		order = append(order, node)
+		return nil
+	}); err != nil {
+		return "", err
+	}
+	if physical {
+		b.WriteString("physical\n")
+	}
+
+	declared := make(map[plan.PlanNode]bool)
+	var edges []string
+	for _, node := range order {
+		if declared[node] {
+			continue
+		}
+		// The edges into the first node of a chain are listed after the chains,
+		// once all the nodes have been declared.
+		for _, pred := range node.Predecessors() {
+			edges = append(edges, fmt.Sprintf("%s -> %s\n", pred.ID(), node.ID()))
+		}
+		for n := node; ; {
+			spec, err := formatSpec(n.ProcedureSpec(), specs)
+			if err != nil {
+				return "", fmt.Errorf("node %q: %v", n.ID(), err)
+			}
+			fmt.Fprintf(&b, "%s = %s", n.ID(), spec)
+			if bounds := n.Bounds(); bounds != nil {
+				fmt.Fprintf(&b, " [%v, %v)", bounds.Start, bounds.Stop)
+			}
+			declared[n] = true
+
+			succs := n.Successors()
+			if len(succs) != 1 || len(succs[0].Predecessors()) != 1 || declared[succs[0]] {
+				break
+			}
+			b.WriteString(" -> ")
+			n = succs[0]
+		}
+		b.WriteString("\n")
+	}
+	for _, e := range edges {
+		b.WriteString(e)
+	}
+	return b.String(), nil
+}
+
+func formatSpec(spec plan.ProcedureSpec, specs Specs) (string, error) {
+	literal, err := encodeSpec(spec)
+	if err != nil {
+		return "", fmt.Errorf("cannot encode %T spec: %v", spec, err)
+	}
+	names := specs.names()
+	for _, name := range names {
+		if reflect.TypeOf(specs[name]) != reflect.TypeOf(spec) {
+			continue
+		}
+		if l, err := encodeSpec(specs[name]); err == nil && l == literal {
+			return name, nil
+		}
+	}
+	if _, ok := spec.(MockProcedureSpec); ok {
+		if _, ok := specs[MockKind]; !ok {
+			return MockKind, nil
+		}
+	}
+	for _, name := range names {
+		if reflect.TypeOf(specs[name]) == reflect.TypeOf(spec) {
+			return fmt.Sprintf("%s %s", name, literal), nil
+		}
+	}
+	return "", fmt.Errorf("no spec of type %T is named", spec)
+}
+
+// encodeSpec returns the JSON encoding of a spec, without escaping the characters of HTML.
+func encodeSpec(spec plan.ProcedureSpec) (string, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(spec); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+// parseBounds parses bounds of the form [start, stop).
+func parseBounds(text string) (*plan.Bounds, error) {
+	times := strings.Split(strings.TrimSuffix(strings.TrimPrefix(text, "["), ")"), ",")
+	if len(times) != 2 {
+		return nil, fmt.Errorf("invalid bounds %s, expected [start, stop)", text)
+	}
+	start, err := parseTime(times[0])
+	if err != nil {
+		return nil, err
+	}
+	stop, err := parseTime(times[1])
+	if err != nil {
+		return nil, err
+	}
+	return &plan.Bounds{Start: start, Stop: stop}, nil
+}
+
+func parseTime(text string) (values.Time, error) {
+	text = strings.TrimSpace(text)
+	if ns, err := strconv.ParseInt(text, 10, 64); err == nil {
+		return values.Time(ns), nil
+	}
+	t, err := time.Parse(time.RFC3339Nano, text)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q", text)
+	}
+	return values.ConvertTime(t), nil
+}
+
+// indexOutsideStrings returns the index of the first occurrence of sep in s
+// that is not within a double quoted string, or -1 if there is none.
+func indexOutsideStrings(s, sep string) int {
+	quoted, escaped := false, false
+	for i := 0; i < len(s); i++ {
+		switch {
+		case escaped:
+			escaped = false
+		case quoted && s[i] == '\\':
+			escaped = true
+		case s[i] == '"':
+			quoted = !quoted
+		case !quoted && strings.HasPrefix(s[i:], sep):
+			return i
+		}
+	}
+	return -1
+}
+
+// splitOutsideStrings splits s around the occurrences of sep that are not within double quoted strings.
+func splitOutsideStrings(s, sep string) []string {
+	var parts []string
+	for {
+		i := indexOutsideStrings(s, sep)
+		if i < 0 {
+			return append(parts, s)
+		}
+		parts = append(parts, s[:i])
+		s = s[i+len(sep):]
+	}
+}
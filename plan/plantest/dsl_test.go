@@ -0,0 +1,131 @@
+package plantest_test
+
+import (
+	"testing"
+
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/plan/plantest"
+	"github.com/influxdata/flux/stdlib/universe"
+)
+
+func TestParsePlan(t *testing.T) {
+	specs := plantest.Specs{
+		"sort": &universe.SortProcedureSpec{Columns: []string{"_time"}},
+	}
+	testCases := []struct {
+		name string
+		text string
+		// want is the plan formatted by FormatPlan, or the empty string if the text is invalid.
+		want string
+	}{
+		{
+			name: "chain",
+			text: "a = mock -> b = mock -> c = mock",
+			want: "a = mock -> b = mock -> c = mock\n",
+		},
+		{
+			name: "edges",
+			text: `
+# The predecessors of c are in the order of their edges.
+a = mock
+b = mock
+c = mock  # A join.
+b -> c
+a -> c
+`,
+			want: "b = mock\na = mock\nc = mock\nb -> c\na -> c\n",
+		},
+		{
+			name: "physical",
+			text: "physical\na = mock -> b = sort",
+			want: "physical\na = mock -> b = sort\n",
+		},
+		{
+			name: "spec literal",
+			text: `a = mock -> b = sort {"Columns": ["_value"], "Desc": true}`,
+			want: `a = mock -> b = sort {"Columns":["_value"],"Desc":true}` + "\n",
+		},
+		{
+			name: "spec literal equal to a named spec",
+			text: `a = mock -> b = sort {"Columns": ["_time"]}`,
+			want: "a = mock -> b = sort\n",
+		},
+		{
+			name: "statements",
+			text: "physical; a = mock; b = mock; a -> b",
+			want: "physical\na = mock -> b = mock\n",
+		},
+		{
+			name: "bounds",
+			text: "a = mock [5, 10) -> b = mock [1970-01-01T00:00:00.000000005Z, 1970-01-01T00:00:01Z)",
+			want: "a = mock [1970-01-01T00:00:00.000000005Z, 1970-01-01T00:00:00.000000010Z) -> b = mock [1970-01-01T00:00:00.000000005Z, 1970-01-01T00:00:01.000000000Z)\n",
+		},
+		{
+			name: "separators in strings",
+			text: `a = mock -> b = sort {"Columns": ["#;->[)"]} # comment`,
+			want: `a = mock -> b = sort {"Columns":["#;->[)"],"Desc":false}` + "\n",
+		},
+		{
+			name: "undeclared node",
+			text: "a = mock -> b",
+		},
+		{
+			name: "node declared twice",
+			text: "a = mock -> b = mock\na = mock -> b",
+		},
+		{
+			name: "unknown spec",
+			text: "a = mock -> b = limit",
+		},
+		{
+			name: "unknown field",
+			text: `a = mock -> b = sort {"Column": ["_value"]}`,
+		},
+		{
+			name: "invalid bounds",
+			text: "a = mock [5)",
+		},
+		{
+			name: "physical after nodes",
+			text: "a = mock\nphysical",
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ps, err := plantest.ParsePlan(tc.text, specs)
+			if tc.want == "" {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := plantest.FormatPlan(plantest.CreatePlanSpec(ps), specs)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tc.want {
+				t.Errorf("unexpected plan, want:\n%s\ngot:\n%s", tc.want, got)
+			}
+
+			// The formatted plan parses back into the same plan.
+			ps, err = plantest.ParsePlan(got, specs)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := plantest.ComparePlans(plantest.CreatePlanSpec(ps), plantest.CreatePlanSpec(plantest.MustParsePlan(tc.text, specs)), cmpPlanNodes); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func cmpPlanNodes(p, q plan.PlanNode) error {
+	if _, ok := p.(*plan.PhysicalPlanNode); ok {
+		return plantest.ComparePhysicalPlanNodes(p, q)
+	}
+	return plantest.CompareLogicalPlanNodes(p, q)
+}
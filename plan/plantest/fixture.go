@@ -0,0 +1,103 @@
+package plantest
+
+import (
+	"flag"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/flux/plan"
+)
+
+var updateFixtures = flag.Bool("update-plans", false, "rewrite the expected plans of the planner rule fixtures with the plans the rules produce")
+
+// afterMarker separates the plan of a fixture from the plan expected once the rule has been applied to it.
+const afterMarker = "-- after --"
+
+// RuleFixturesTestHelper applies a rule to the example plans of its fixtures,
+// and checks that it produces the plans they expect.
+//
+// The fixtures of a rule are the files testdata/rules/<rule name>/*.plan of the package under test.
+// A fixture holds a plan in the form parsed by ParsePlan, followed by a line "-- after --"
+// and the plan expected once the rule has been applied, which is the same plan if the rule does not apply.
+// The nodes of both plans refer to specs, and the plans are planned by the logical planner,
+// or by the physical planner without validation if they are physical plans.
+//
+// Running the test with the flag -update-plans rewrites the expected plan of every fixture
+// with the plan produced by the rule, in the form printed by FormatPlan.
+func RuleFixturesTestHelper(t *testing.T, rule plan.Rule, specs Specs) {
+	t.Helper()
+
+	files, err := filepath.Glob(filepath.Join("testdata", "rules", rule.Name(), "*.plan"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) == 0 {
+		t.Fatalf("rule %s has no fixtures", rule.Name())
+	}
+	for _, file := range files {
+		file := file
+		t.Run(strings.TrimSuffix(filepath.Base(file), ".plan"), func(t *testing.T) {
+			runRuleFixture(t, rule, specs, file)
+		})
+	}
+}
+
+func runRuleFixture(t *testing.T, rule plan.Rule, specs Specs, file string) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	before, after := string(data), ""
+	if i := strings.Index(before, afterMarker); i >= 0 {
+		before, after = before[:i], before[i+len(afterMarker):]
+	} else if !*updateFixtures {
+		t.Fatalf("fixture has no %q plan, run the test with -update-plans to record it", afterMarker)
+	}
+
+	ps, err := ParsePlan(before, specs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := CreatePlanSpec(ps)
+	if len(ps.Nodes) > 0 && isPhysical(ps.Nodes[0]) {
+		p, err = plan.NewPhysicalPlanner(plan.OnlyPhysicalRules(rule), plan.DisableValidation()).Plan(p)
+	} else {
+		p, err = plan.NewLogicalPlanner(plan.OnlyLogicalRules(rule)).Plan(p)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := FormatPlan(p, specs)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if *updateFixtures {
+		if err := ioutil.WriteFile(file, []byte(before+afterMarker+"\n"+got), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	// The expected plan is formatted like the plan produced by the rule,
+	// so that it may be written in any layout.
+	wantPS, err := ParsePlan(after, specs)
+	if err != nil {
+		t.Fatalf("expected plan: %v", err)
+	}
+	want, err := FormatPlan(CreatePlanSpec(wantPS), specs)
+	if err != nil {
+		t.Fatalf("expected plan: %v", err)
+	}
+	if want != got {
+		t.Errorf("unexpected plan -want/+got:\n%s", cmp.Diff(strings.Split(want, "\n"), strings.Split(got, "\n")))
+	}
+}
+
+func isPhysical(node plan.PlanNode) bool {
+	_, ok := node.(*plan.PhysicalPlanNode)
+	return ok
+}
@@ -29,7 +29,7 @@ func (sr *SimpleRule) Name() string {
 	return "simple"
 }
 
-// MergeFromRangePhysicalRule merges a from and a subsequent range.
+// MergeFromRangePhysicalRule merges a from and a subsequent range into a bounded read.
 type MergeFromRangePhysicalRule struct{}
 
 func (sr *MergeFromRangePhysicalRule) Pattern() plan.Pattern {
@@ -37,7 +37,13 @@ func (sr *MergeFromRangePhysicalRule) Pattern() plan.Pattern {
 }
 
 func (sr *MergeFromRangePhysicalRule) Rewrite(node plan.PlanNode) (plan.PlanNode, bool, error) {
-	mergedSpec := node.Predecessors()[0].ProcedureSpec().Copy().(*influxdb.FromProcedureSpec)
+	fromSpec := node.Predecessors()[0].ProcedureSpec().(*influxdb.FromProcedureSpec)
+	rangeSpec := node.ProcedureSpec().(*universe.RangeProcedureSpec)
+	// An unbounded from does not pass physical validation, so the merged node must be a bounded read.
+	mergedSpec := &influxdb.ReadRangePhysSpec{
+		Bucket: fromSpec.Bucket,
+		Bounds: rangeSpec.Bounds,
+	}
 	mergedNode, err := plan.MergeToPhysicalPlanNode(node, node.Predecessors()[0], mergedSpec)
 	if err != nil {
 		return nil, false, err
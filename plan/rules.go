@@ -1,5 +1,7 @@
 package plan
 
+import "github.com/influxdata/flux/dependencies"
+
 // Rule is transformation rule for a query operation
 type Rule interface {
 	// The name of this rule (must be unique)
@@ -13,3 +15,22 @@ type Rule interface {
 	// The boolean return value should be true if anything changed during the rewrite.
 	Rewrite(PlanNode) (PlanNode, bool, error)
 }
+
+// FlaggedRule is a rule that is only applied when its feature flag
+// is enabled by the flagger of the plan.
+type FlaggedRule interface {
+	Rule
+
+	// Flag is the feature flag that enables the rule.
+	Flag() string
+}
+
+// ruleEnabled reports whether the rule may be applied to a plan with the flagger.
+// A nil flagger enables no flags.
+func ruleEnabled(rule Rule, flagger dependencies.Flagger) bool {
+	fr, ok := rule.(FlaggedRule)
+	if !ok {
+		return true
+	}
+	return flagger != nil && flagger.Enabled(fr.Flag())
+}
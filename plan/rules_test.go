@@ -7,6 +7,7 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/dependencies"
 	"github.com/influxdata/flux/plan"
 	"github.com/influxdata/flux/plan/plantest"
 )
@@ -57,3 +58,45 @@ func TestRuleRegistration(t *testing.T) {
 		t.Errorf("expected simpleRule to have been registered and have seen some nodes")
 	}
 }
+
+type flaggedRule struct {
+	plantest.SimpleRule
+}
+
+func (flaggedRule) Flag() string {
+	return "simpleRule"
+}
+
+func TestFlaggedRule(t *testing.T) {
+	fluxSpec, err := flux.Compile(context.Background(), `from(bucket: "telegraf") |> range(start: -5m)`, time.Now().UTC())
+	if err != nil {
+		t.Fatalf("could not compile very simple Flux query: %v", err)
+	}
+
+	for _, tc := range []struct {
+		name    string
+		flagger dependencies.Flagger
+		applied bool
+	}{
+		{name: "no flagger"},
+		{name: "disabled", flagger: dependencies.Flags{"otherRule": true}},
+		{name: "enabled", flagger: dependencies.Flags{"simpleRule": true}, applied: true},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			rule := &flaggedRule{}
+			logicalPlanner := plan.NewLogicalPlanner(plan.OnlyLogicalRules(rule))
+			initPlan, err := logicalPlanner.CreateInitialPlan(fluxSpec)
+			if err != nil {
+				t.Fatal(err)
+			}
+			initPlan.Flagger = tc.flagger
+			if _, err := logicalPlanner.Plan(initPlan); err != nil {
+				t.Fatalf("could not do logical planning: %v", err)
+			}
+			if applied := len(rule.SeenNodes) > 0; applied != tc.applied {
+				t.Errorf("unexpected rule application: want %v, got %v", tc.applied, applied)
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package plan
+
+import (
+	"strings"
+
+	"github.com/influxdata/flux"
+)
+
+// Schema describes the columns of the tables produced by a procedure
+// that are known before the query is executed.
+type Schema struct {
+	// Columns are the columns that every table has.
+	Columns []flux.ColMeta
+	// Open reports whether the tables may have other columns than Columns.
+	Open bool
+}
+
+// Column returns the column of the schema with the label,
+// and false if the schema has no such column.
+func (s *Schema) Column(label string) (flux.ColMeta, bool) {
+	for _, c := range s.Columns {
+		if c.Label == label {
+			return c, true
+		}
+	}
+	return flux.ColMeta{}, false
+}
+
+func (s *Schema) String() string {
+	cols := make([]string, 0, len(s.Columns)+1)
+	for _, c := range s.Columns {
+		cols = append(cols, c.Label+": "+c.Type.String())
+	}
+	if s.Open {
+		cols = append(cols, "...")
+	}
+	return "[" + strings.Join(cols, ", ") + "]"
+}
+
+// SchemaProcedureSpec is any procedure that knows
+// the schema of the tables it produces.
+type SchemaProcedureSpec interface {
+	// OutputSchema returns the schema of the tables produced by the procedure,
+	// given those of its predecessors, or nil if it is not known.
+	// The schemas of the predecessors are nil when they are not known.
+	OutputSchema(predecessors []*Schema) *Schema
+}
+
+// SchemaValidator is any procedure that validates
+// the schemas of the tables it consumes before the query is executed.
+type SchemaValidator interface {
+	// ValidateSchema returns an error if the procedure cannot consume tables
+	// with the schemas of its predecessors, which are nil when they are not known.
+	ValidateSchema(predecessors []*Schema) error
+}
+
+// OutputSchema returns the schema of the tables produced by the node,
+// or nil if it is not known.
+func OutputSchema(node PlanNode) *Schema {
+	s, ok := node.ProcedureSpec().(SchemaProcedureSpec)
+	if !ok {
+		return nil
+	}
+	return s.OutputSchema(predecessorSchemas(node))
+}
+
+func predecessorSchemas(node PlanNode) []*Schema {
+	preds := node.Predecessors()
+	schemas := make([]*Schema, len(preds))
+	for i, pred := range preds {
+		schemas[i] = OutputSchema(pred)
+	}
+	return schemas
+}
+
+// InferSchema infers the schema of the tables produced by a plan node
+// from the schemas inferred for its predecessors, and validates them
+// if the procedure of the node is a SchemaValidator.
+// Walking a plan bottom up with InferSchema infers the schemas of all of its nodes.
+func InferSchema(node PlanNode) error {
+	preds := node.Predecessors()
+	schemas := make([]*Schema, len(preds))
+	for i, pred := range preds {
+		schemas[i] = pred.Schema()
+	}
+	if err := validateSchemas(node, schemas); err != nil {
+		return err
+	}
+	var schema *Schema
+	if s, ok := node.ProcedureSpec().(SchemaProcedureSpec); ok {
+		schema = s.OutputSchema(schemas)
+	}
+	node.SetSchema(schema)
+	return nil
+}
+
+// validateSchema validates the schemas of the predecessors of the node
+// if its procedure is a SchemaValidator.
+func validateSchema(node PlanNode) error {
+	if _, ok := node.ProcedureSpec().(SchemaValidator); !ok {
+		return nil
+	}
+	return validateSchemas(node, predecessorSchemas(node))
+}
+
+// validateSchemas validates the given schemas of the predecessors of the node
+// if its procedure is a SchemaValidator.
+func validateSchemas(node PlanNode, predecessors []*Schema) error {
+	v, ok := node.ProcedureSpec().(SchemaValidator)
+	if !ok {
+		return nil
+	}
+	if err := v.ValidateSchema(predecessors); err != nil {
+		return &ValidationError{
+			Node:     node.ID(),
+			Location: node.Location(),
+			Err:      err,
+		}
+	}
+	return nil
+}
@@ -0,0 +1,189 @@
+package plan_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/plan/plantest"
+)
+
+// schemaSpec produces tables with its schema,
+// or with the schema of its input if it has none,
+// and validates that the schemas of its inputs have a time column.
+type schemaSpec struct {
+	plan.DefaultCost
+	schema *plan.Schema
+}
+
+func (s *schemaSpec) Kind() plan.ProcedureKind {
+	return "schema"
+}
+
+func (s *schemaSpec) Copy() plan.ProcedureSpec {
+	return &schemaSpec{schema: s.schema}
+}
+
+func (s *schemaSpec) OutputSchema(predecessors []*plan.Schema) *plan.Schema {
+	if s.schema == nil && len(predecessors) > 0 {
+		return predecessors[0]
+	}
+	return s.schema
+}
+
+func (s *schemaSpec) ValidateSchema(predecessors []*plan.Schema) error {
+	for _, schema := range predecessors {
+		if schema == nil {
+			continue
+		}
+		if _, ok := schema.Column("_time"); !ok && !schema.Open {
+			return errors.New("no time column in " + schema.String())
+		}
+	}
+	return nil
+}
+
+func TestSchema_String(t *testing.T) {
+	s := &plan.Schema{
+		Columns: []flux.ColMeta{
+			{Label: "_time", Type: flux.TTime},
+			{Label: "_value", Type: flux.TFloat},
+		},
+	}
+	if want, got := "[_time: time, _value: float]", s.String(); want != got {
+		t.Errorf("unexpected string: want %q, got %q", want, got)
+	}
+	s.Open = true
+	if want, got := "[_time: time, _value: float, ...]", s.String(); want != got {
+		t.Errorf("unexpected string: want %q, got %q", want, got)
+	}
+}
+
+func TestPhysicalPlanner_ValidateSchema(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		schema  *plan.Schema
+		wantErr string
+	}{
+		{
+			name: "valid",
+			schema: &plan.Schema{
+				Columns: []flux.ColMeta{{Label: "_time", Type: flux.TTime}},
+			},
+		},
+		{
+			name:   "unknown",
+			schema: nil,
+		},
+		{
+			name:   "open",
+			schema: &plan.Schema{Open: true},
+		},
+		{
+			name: "invalid",
+			schema: &plan.Schema{
+				Columns: []flux.ColMeta{{Label: "_value", Type: flux.TFloat}},
+			},
+			wantErr: `invalid plan node "1": no time column in [_value: float]`,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			spec := plantest.CreatePlanSpec(&plantest.PlanSpec{
+				Nodes: []plan.PlanNode{
+					plan.CreatePhysicalNode("0", &schemaSpec{schema: tc.schema}),
+					plan.CreatePhysicalNode("1", &schemaSpec{}),
+					plan.CreatePhysicalNode("2", &schemaSpec{}),
+				},
+				Edges: [][2]int{
+					{0, 1},
+					{1, 2},
+				},
+				Resources: flux.ResourceManagement{ConcurrencyQuota: 1, MemoryBytesQuota: 10000},
+			})
+
+			_, err := plan.NewPhysicalPlanner().Plan(spec)
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tc.wantErr {
+				t.Fatalf("unexpected error: want %q, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestLogicalPlanner_InferSchemas(t *testing.T) {
+	timeSchema := &plan.Schema{
+		Columns: []flux.ColMeta{{Label: "_time", Type: flux.TTime}},
+	}
+	spec := plantest.CreatePlanSpec(&plantest.PlanSpec{
+		Nodes: []plan.PlanNode{
+			plan.CreateLogicalNode("0", &schemaSpec{schema: timeSchema}),
+			plan.CreateLogicalNode("1", &schemaSpec{}),
+			plan.CreateLogicalNode("2", &plantest.MockProcedureSpec{}),
+		},
+		Edges: [][2]int{
+			{0, 1},
+			{1, 2},
+		},
+	})
+
+	pp, err := plan.NewLogicalPlanner(plan.InferSchemas()).Plan(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[plan.NodeID]*plan.Schema{
+		"0": timeSchema,
+		"1": timeSchema,
+		"2": nil,
+	}
+	got := make(map[plan.NodeID]*plan.Schema)
+	if err := pp.BottomUpWalk(func(node plan.PlanNode) error {
+		got[node.ID()] = node.Schema()
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if !cmp.Equal(want, got) {
+		t.Errorf("unexpected schemas -want/+got:\n%s", cmp.Diff(want, got))
+	}
+
+	wantFormatted := `
+digraph {
+  0 [label="0\n[_time: time]"]
+  1 [label="1\n[_time: time]"]
+  2 [label="2\nunknown"]
+
+  0 -> 1
+  1 -> 2
+}
+`
+	if gotFormatted := fmt.Sprintf("%v", plan.Formatted(pp, plan.WithSchemas())); gotFormatted != wantFormatted {
+		t.Errorf("unexpected formatted plan -want/+got:\n%s", cmp.Diff(wantFormatted, gotFormatted))
+	}
+}
+
+func TestLogicalPlanner_InferSchemasInvalid(t *testing.T) {
+	spec := plantest.CreatePlanSpec(&plantest.PlanSpec{
+		Nodes: []plan.PlanNode{
+			plan.CreateLogicalNode("0", &schemaSpec{schema: &plan.Schema{}}),
+			plan.CreateLogicalNode("1", &schemaSpec{}),
+		},
+		Edges: [][2]int{
+			{0, 1},
+		},
+	})
+
+	_, err := plan.NewLogicalPlanner(plan.InferSchemas()).Plan(spec)
+	if want := `invalid plan node "1": no time column in []`; err == nil || err.Error() != want {
+		t.Fatalf("unexpected error: want %q, got %v", want, err)
+	}
+}
@@ -0,0 +1,85 @@
+package plan
+
+import (
+	"fmt"
+	"strings"
+)
+
+// EngineSystem names the Flux engine in the summary of a plan.
+const EngineSystem = "flux"
+
+// ExternalProcedureSpec is any source procedure that reads data from a system outside of the Flux engine,
+// which executes the procedure, along with the operations that have been pushed down into it.
+type ExternalProcedureSpec interface {
+	// ExternalSystem names the system that executes the procedure, such as storage or a SQL database driver.
+	ExternalSystem() string
+}
+
+// Summary describes where the procedures of a plan execute.
+// A plan may read from any number of sources of different kinds,
+// each with the operations that could be pushed down into it.
+type Summary struct {
+	// Sources are the source nodes of the plan, in the order of a bottom up walk of the plan.
+	Sources []SourceSummary
+	// Engine are the other nodes of the plan, which the Flux engine executes,
+	// in the order of a bottom up walk of the plan.
+	Engine []NodeID
+}
+
+// SourceSummary describes a source node of a plan.
+type SourceSummary struct {
+	// Node is the ID of the source node.
+	Node NodeID
+	// Kind is the kind of the procedure of the node.
+	// It tells apart a source from the same source with operations pushed down into it.
+	Kind ProcedureKind
+	// System names the system that executes the source, it is EngineSystem
+	// if the procedure is not an ExternalProcedureSpec.
+	System string
+	// Bounds are the time bounds of the data the source reads, or nil if it is not bounded in time.
+	Bounds *Bounds
+}
+
+// Summarize returns the summary of where the procedures of a plan execute.
+// The bounds of the sources are those computed by the physical planner.
+func Summarize(plan *PlanSpec) *Summary {
+	s := new(Summary)
+	_ = plan.BottomUpWalk(func(node PlanNode) error {
+		if len(node.Predecessors()) > 0 {
+			s.Engine = append(s.Engine, node.ID())
+			return nil
+		}
+		system := EngineSystem
+		if spec, ok := node.ProcedureSpec().(ExternalProcedureSpec); ok {
+			system = spec.ExternalSystem()
+		}
+		s.Sources = append(s.Sources, SourceSummary{
+			Node:   node.ID(),
+			Kind:   node.Kind(),
+			System: system,
+			Bounds: node.Bounds(),
+		})
+		return nil
+	})
+	return s
+}
+
+func (s *Summary) String() string {
+	var b strings.Builder
+	for _, src := range s.Sources {
+		fmt.Fprintf(&b, "source %v (%v) executes in %v", src.Node, src.Kind, src.System)
+		if src.Bounds != nil {
+			fmt.Fprintf(&b, " over [%v, %v)\n", src.Bounds.Start, src.Bounds.Stop)
+		} else {
+			b.WriteString(" without time bounds\n")
+		}
+	}
+	if len(s.Engine) > 0 {
+		ids := make([]string, len(s.Engine))
+		for i, id := range s.Engine {
+			ids[i] = string(id)
+		}
+		fmt.Fprintf(&b, "%v executes %v\n", EngineSystem, strings.Join(ids, ", "))
+	}
+	return b.String()
+}
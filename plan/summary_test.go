@@ -0,0 +1,73 @@
+package plan_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/stdlib/influxdata/influxdb"
+)
+
+func TestSummarize_MultipleSources(t *testing.T) {
+	now := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	fluxSpec, err := compile(`
+import "csv"
+import "sql"
+
+hosts = "#datatype,string,long,string
+#group,false,false,true
+#default,_result,,
+,result,table,host
+,,0,server01
+"
+
+cpu = from(bucket: "telegraf")
+	|> range(start: 2019-01-01T00:00:00Z, stop: 2019-01-01T01:00:00Z)
+	|> filter(fn: (r) => r._measurement == "cpu")
+join(tables: {cpu: cpu, hosts: csv.from(csv: hosts)}, on: ["host"])
+	|> yield(name: "joined")
+
+sql.from(driverName: "postgres", dataSourceName: "postgres://localhost/metrics", query: "SELECT * FROM hosts")
+	|> limit(n: 10)
+	|> yield(name: "limited")
+`, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lp := plan.NewLogicalPlanner()
+	initPlan, err := lp.CreateInitialPlan(fluxSpec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	logicalPlan, err := lp.Plan(initPlan)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Storage only executes the operations it is capable of,
+	// the limit is pushed down into the SQL database by a registered rule.
+	pp := plan.NewPhysicalPlanner(plan.AddPhysicalRules(influxdb.PushDownRules(influxdb.StorageCapabilities{Filter: true})...))
+	physicalPlan, err := pp.Plan(logicalPlan)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `source merged_from0_range1_filter2 (ReadRangePhysKind) executes in storage over [2019-01-01T00:00:00.000000000Z, 2019-01-01T01:00:00.000000000Z)
+source fromCSV3 (fromCSV) executes in flux without time bounds
+source merged_fromSQL6_limit7 (fromSQL) executes in postgres without time bounds
+flux executes join4, yield5, yield8
+`
+	if got := plan.Summarize(physicalPlan).String(); want != got {
+		t.Errorf("unexpected summary -want/+got:\n%s", cmp.Diff(want, got))
+	}
+
+	// The join reads the CSV data, which is not bounded in time.
+	if err := physicalPlan.BottomUpWalk(func(node plan.PlanNode) error {
+		if node.ID() == "join4" && node.Bounds() != nil {
+			t.Errorf("unexpected bounds of the join: %v", *node.Bounds())
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -1,11 +1,13 @@
 package plan
 
 import (
-	"fmt"
 	"strings"
 	"time"
 
 	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/ast"
+	"github.com/influxdata/flux/dependencies"
+	fluxerrors "github.com/influxdata/flux/errors"
 )
 
 // PlanNode defines the common interface for interacting with
@@ -17,6 +19,10 @@ type PlanNode interface {
 	// Returns the time bounds for this plan node
 	Bounds() *Bounds
 
+	// Returns the schema of the tables produced by this plan node,
+	// or nil if it is not known or has not been inferred
+	Schema() *Schema
+
 	// Plan nodes executed immediately before this node
 	Predecessors() []PlanNode
 
@@ -32,9 +38,15 @@ type PlanNode interface {
 	// Type of procedure represented by this node
 	Kind() ProcedureKind
 
+	// Returns the location in the script of the call that produced this node,
+	// or nil if it is not known
+	Location() *ast.SourceLocation
+
 	// Helper methods for manipulating a plan
 	// These methods are used during planning
 	SetBounds(bounds *Bounds)
+	SetSchema(schema *Schema)
+	SetLocation(loc *ast.SourceLocation)
 	AddSuccessors(...PlanNode)
 	AddPredecessors(...PlanNode)
 	ClearSuccessors()
@@ -50,6 +62,12 @@ type PlanSpec struct {
 	Roots     map[PlanNode]struct{}
 	Resources flux.ResourceManagement
 	Now       time.Time
+	// Results are the names of the results produced by the plan,
+	// in the order their yields were declared in the script.
+	Results []string
+	// Flagger decides which flagged rules are applied to the plan.
+	// When it is nil, the flagged rules are not applied.
+	Flagger dependencies.Flagger
 }
 
 // NewPlanSpec initializes a new query plan
@@ -113,14 +131,14 @@ func (plan *PlanSpec) CheckIntegrity() error {
 func symmetryCheck(node PlanNode) error {
 	for _, pred := range node.Predecessors() {
 		if !isNodeInNodes(node, pred.Successors()) {
-			return fmt.Errorf("integrity violated: %s is predecessor of %s, "+
+			return fluxerrors.Newf(fluxerrors.Internal, "integrity violated: %s is predecessor of %s, "+
 				"but %s is not successor of %s", pred.ID(), node.ID(), node.ID(), pred.ID())
 		}
 	}
 
 	for _, succ := range node.Successors() {
 		if !isNodeInNodes(node, succ.Predecessors()) {
-			return fmt.Errorf("integrity violated: %s is successor of %s, "+
+			return fluxerrors.Newf(fluxerrors.Internal, "integrity violated: %s is successor of %s, "+
 				"but %s is not predecessor of %s`", succ.ID(), node.ID(), node.ID(), succ.ID())
 		}
 	}
@@ -159,6 +177,30 @@ func (b *bounds) Bounds() *Bounds {
 	return b.value
 }
 
+type schema struct {
+	value *Schema
+}
+
+func (s *schema) SetSchema(schema *Schema) {
+	s.value = schema
+}
+
+func (s *schema) Schema() *Schema {
+	return s.value
+}
+
+type location struct {
+	value *ast.SourceLocation
+}
+
+func (l *location) SetLocation(loc *ast.SourceLocation) {
+	l.value = loc
+}
+
+func (l *location) Location() *ast.SourceLocation {
+	return l.value
+}
+
 type edges struct {
 	predecessors []PlanNode
 	successors   []PlanNode
@@ -243,7 +285,14 @@ func mergePlanNodes(top, bottom, merged PlanNode) (PlanNode, error) {
 	if len(top.Predecessors()) != 1 ||
 		len(bottom.Successors()) != 1 ||
 		top.Predecessors()[0] != bottom {
-		return nil, fmt.Errorf("cannot merge %s and %s due to topological issues", top.ID(), bottom.ID())
+		return nil, fluxerrors.Newf(fluxerrors.Internal, "cannot merge %s and %s due to topological issues", top.ID(), bottom.ID())
+	}
+
+	// The merged node was produced by the call of the bottom node, unless it is not known.
+	if loc := bottom.Location(); loc != nil {
+		merged.SetLocation(loc)
+	} else {
+		merged.SetLocation(top.Location())
 	}
 
 	merged.AddPredecessors(bottom.Predecessors()...)
@@ -276,7 +325,7 @@ func SwapPlanNodes(top, bottom PlanNode) (PlanNode, error) {
 	if len(top.Predecessors()) != 1 ||
 		len(bottom.Successors()) != 1 ||
 		len(bottom.Predecessors()) != 1 {
-		return nil, fmt.Errorf("cannot swap nodes %v and %v due to topological issue", top.ID(), bottom.ID())
+		return nil, fluxerrors.Newf(fluxerrors.Internal, "cannot swap nodes %v and %v due to topological issue", top.ID(), bottom.ID())
 	}
 
 	newBottom := top.ShallowCopy()
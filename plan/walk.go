@@ -1,8 +1,9 @@
 package plan
 
 import (
-	"github.com/pkg/errors"
 	"sort"
+
+	fluxerrors "github.com/influxdata/flux/errors"
 )
 
 // TopDownWalk will execute f for each plan node in the PlanSpec.
@@ -169,7 +170,7 @@ func (tw *topologicalWalk) pushVisit(node PlanNode) {
 
 func (tw *topologicalWalk) walk(node PlanNode) error {
 	if tw.temporaryMarks[node] {
-		return errors.New("cycle detected")
+		return fluxerrors.New(fluxerrors.Internal, "cycle detected")
 	}
 
 	if !tw.permanentMarks[node] {
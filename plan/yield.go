@@ -30,3 +30,8 @@ func (y *GeneratedYieldProcedureSpec) Copy() ProcedureSpec {
 func (y *GeneratedYieldProcedureSpec) YieldName() string {
 	return y.Name
 }
+
+// OutputSchema implements SchemaProcedureSpec, a yield keeps the columns of its input.
+func (y *GeneratedYieldProcedureSpec) OutputSchema(predecessors []*Schema) *Schema {
+	return predecessors[0]
+}
@@ -0,0 +1,140 @@
+// Package plugin provides a registration API for builtins that are defined outside of the Flux standard library.
+//
+// A Plugin groups the registrations needed by a set of Flux functions:
+// the values of its packages, the operation and procedure specs,
+// the sources and transformations that execute them and the planner rules.
+// Plugins are registered with Register, typically from an init function,
+// or loaded from Go plugin files with Open.
+//
+// All plugins must be registered before flux.FinalizeBuiltIns is called.
+// Embedders that load plugin files at runtime must therefore import the
+// stdlib package instead of the builtin package and call flux.FinalizeBuiltIns
+// once the plugins are loaded.
+package plugin
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	goplugin "plugin"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/ast"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/values"
+	"github.com/pkg/errors"
+)
+
+// SymbolName is the name of the variable holding the Plugin in a Go plugin file.
+const SymbolName = "Plugin"
+
+// Plugin registers a set of builtins.
+type Plugin interface {
+	// Name identifies the plugin in errors.
+	Name() string
+	// Register registers the builtins of the plugin with the registry.
+	Register(r *Registry)
+}
+
+// Registry forwards the registrations of a plugin to Flux.
+// Its methods panic on invalid registrations, such as duplicates,
+// which Register reports as errors.
+type Registry struct{}
+
+// Package registers the Flux source of a builtin package.
+func (r *Registry) Package(pkg *ast.Package) {
+	flux.RegisterPackage(pkg)
+}
+
+// PackageValue registers a value, usually a function, within a builtin package.
+func (r *Registry) PackageValue(pkgpath, name string, value values.Value) {
+	flux.RegisterPackageValue(pkgpath, name, value)
+}
+
+// OpSpec registers the operation spec of a function.
+func (r *Registry) OpSpec(k flux.OperationKind, c flux.NewOperationSpec) {
+	flux.RegisterOpSpec(k, c)
+}
+
+// ProcedureSpec registers the procedure spec created from the given operation kinds.
+func (r *Registry) ProcedureSpec(k plan.ProcedureKind, c plan.CreateProcedureSpec, qks ...flux.OperationKind) {
+	plan.RegisterProcedureSpec(k, c, qks...)
+}
+
+// ProcedureSpecWithSideEffect registers the procedure spec of a function with side effects.
+func (r *Registry) ProcedureSpecWithSideEffect(k plan.ProcedureKind, c plan.CreateProcedureSpec, qks ...flux.OperationKind) {
+	plan.RegisterProcedureSpecWithSideEffect(k, c, qks...)
+}
+
+// Source registers the source executing procedures of the given kind.
+func (r *Registry) Source(k plan.ProcedureKind, c execute.CreateNewPlannerSource) {
+	execute.RegisterSource(k, c)
+}
+
+// Transformation registers the transformation executing procedures of the given kind.
+func (r *Registry) Transformation(k plan.ProcedureKind, c execute.CreateNewPlannerTransformation) {
+	execute.RegisterTransformation(k, c)
+}
+
+// LogicalRules registers rules with the logical planner.
+func (r *Registry) LogicalRules(rules ...plan.Rule) {
+	plan.RegisterLogicalRules(rules...)
+}
+
+// PhysicalRules registers rules with the physical planner.
+func (r *Registry) PhysicalRules(rules ...plan.Rule) {
+	plan.RegisterPhysicalRules(rules...)
+}
+
+// Register registers the builtins of the plugin.
+func Register(p Plugin) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("failed to register plugin %q: %v", p.Name(), r)
+		}
+	}()
+	p.Register(new(Registry))
+	return nil
+}
+
+// Open loads the Go plugin file at path and registers the Plugin it exports
+// in a variable named SymbolName.
+func Open(path string) error {
+	lib, err := goplugin.Open(path)
+	if err != nil {
+		return errors.Wrapf(err, "failed to open plugin %q", path)
+	}
+	sym, err := lib.Lookup(SymbolName)
+	if err != nil {
+		return errors.Wrapf(err, "failed to open plugin %q", path)
+	}
+	var p Plugin
+	switch s := sym.(type) {
+	case *Plugin:
+		p = *s
+	case Plugin:
+		p = s
+	}
+	if p == nil {
+		return fmt.Errorf("plugin %q does not export a %s variable of type plugin.Plugin, got %T", path, SymbolName, sym)
+	}
+	return Register(p)
+}
+
+// OpenDir loads every Go plugin file with the extension .so in the directory.
+func OpenDir(dir string) error {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	for _, f := range files {
+		if f.IsDir() || filepath.Ext(f.Name()) != ".so" {
+			continue
+		}
+		if err := Open(filepath.Join(dir, f.Name())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
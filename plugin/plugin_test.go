@@ -0,0 +1,52 @@
+package plugin_test
+
+import (
+	"testing"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/plugin"
+	"github.com/influxdata/flux/values"
+)
+
+type testPlugin struct {
+	name string
+}
+
+func (p testPlugin) Name() string {
+	return p.name
+}
+
+func (p testPlugin) Register(r *plugin.Registry) {
+	r.PackageValue("plugintest", p.name, values.NewInt(1))
+	r.OpSpec(flux.OperationKind("plugintest"), func() flux.OperationSpec { return nil })
+}
+
+func TestRegister(t *testing.T) {
+	if err := plugin.Register(testPlugin{name: "a"}); err != nil {
+		t.Fatal(err)
+	}
+	var found bool
+	for _, p := range flux.StdLibPackagePaths() {
+		if p == "plugintest" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("expected the plugin package to be registered")
+	}
+
+	// The second plugin registers the same operation kind.
+	err := plugin.Register(testPlugin{name: "b"})
+	if err == nil {
+		t.Fatal("expected error for a duplicate registration")
+	}
+	if want := `failed to register plugin "b": duplicate registration for operation kind plugintest`; err.Error() != want {
+		t.Errorf("unexpected error: got %q want %q", err.Error(), want)
+	}
+}
+
+func TestOpen_Missing(t *testing.T) {
+	if err := plugin.Open("testdata/missing.so"); err == nil {
+		t.Error("expected error opening a missing plugin file")
+	}
+}
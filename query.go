@@ -28,6 +28,13 @@ type Query interface {
 	Statisticser
 }
 
+// ResultNamer is implemented by queries that report the names of their results
+// in the order they were declared in the script.
+// Results read with NewResultIteratorFromQuery are returned in that order.
+type ResultNamer interface {
+	ResultNames() []string
+}
+
 // Statisticser reports statisitcs about query processing.
 type Statisticser interface {
 	// Statistics reports the statisitcs for the query.
@@ -59,6 +66,53 @@ type Statistics struct {
 	ScannedValues int `json:"scanned_values"`
 	// ScannedBytes number of uncompressed bytes scanned.
 	ScannedBytes int `json:"scanned_bytes"`
+
+	// Flags are the feature flags that were consulted while processing the query and their values.
+	Flags map[string]bool `json:"flags,omitempty"`
+
+	// Metadata is additional information about the processing of the query.
+	Metadata Metadata `json:"metadata,omitempty"`
+
+	// Writes are the writes to external systems that the query performed, in the order they ended.
+	Writes []Write `json:"writes,omitempty"`
+}
+
+// Write describes a write to an external system performed by a Flux function, such as a request of http.to.
+type Write struct {
+	// Function is the name of the Flux function that performed the write, such as "kafka.to".
+	Function string `json:"function"`
+	// Destination is where the data was written, such as a URL, a bucket or a topic.
+	Destination string `json:"destination"`
+	// Rows is the number of rows that were written.
+	Rows int64 `json:"rows"`
+	// Rejected is the number of rows that the destination rejected while it accepted the others.
+	Rejected int64 `json:"rejected,omitempty"`
+	// Bytes is the size of the data that was sent, or zero if the function does not know it.
+	Bytes int64 `json:"bytes"`
+	// Duration is the time the write took.
+	Duration time.Duration `json:"duration"`
+	// Status is the outcome of the write, such as the status of an HTTP response,
+	// "ok" for a write that does not have a status, "partial" if some of the rows were rejected,
+	// or "failed" if it failed before it had a status.
+	Status string `json:"status"`
+	// Error is the error of a failed or partial write, or empty.
+	Error string `json:"error,omitempty"`
+}
+
+// Metadata maps a key to the values that were reported for it.
+// A key may have several values, such as when the statistics of several results are added together.
+type Metadata map[string][]interface{}
+
+// Add records a value for a key.
+func (md Metadata) Add(key string, value interface{}) {
+	md[key] = append(md[key], value)
+}
+
+// AddAll records all the values of other.
+func (md Metadata) AddAll(other Metadata) {
+	for key, values := range other {
+		md[key] = append(md[key], values...)
+	}
 }
 
 // Add returns the sum of s and other.
@@ -74,5 +128,43 @@ func (s Statistics) Add(other Statistics) Statistics {
 		MaxAllocated:    s.MaxAllocated + other.MaxAllocated,
 		ScannedValues:   s.ScannedValues + other.ScannedValues,
 		ScannedBytes:    s.ScannedBytes + other.ScannedBytes,
+		Flags:           addFlags(s.Flags, other.Flags),
+		Metadata:        addMetadata(s.Metadata, other.Metadata),
+		Writes:          addWrites(s.Writes, other.Writes),
+	}
+}
+
+// addWrites returns the writes of a followed by those of b.
+func addWrites(a, b []Write) []Write {
+	if len(a) == 0 && len(b) == 0 {
+		return nil
+	}
+	writes := make([]Write, 0, len(a)+len(b))
+	writes = append(writes, a...)
+	return append(writes, b...)
+}
+
+// addFlags returns the union of the flags, a flag is enabled if it is enabled in either.
+func addFlags(a, b map[string]bool) map[string]bool {
+	if len(a) == 0 && len(b) == 0 {
+		return nil
+	}
+	flags := make(map[string]bool, len(a)+len(b))
+	for _, m := range []map[string]bool{a, b} {
+		for flag, v := range m {
+			flags[flag] = flags[flag] || v
+		}
+	}
+	return flags
+}
+
+// addMetadata returns the metadata with the values of both a and b.
+func addMetadata(a, b Metadata) Metadata {
+	if len(a) == 0 && len(b) == 0 {
+		return nil
 	}
+	md := make(Metadata, len(a)+len(b))
+	md.AddAll(a)
+	md.AddAll(b)
+	return md
 }
@@ -28,6 +28,7 @@ var opts = append(
 	cmp.AllowUnexported(universe.JoinOpSpec{}),
 	cmpopts.IgnoreUnexported(flux.Spec{}),
 	cmpopts.IgnoreUnexported(universe.JoinOpSpec{}),
+	cmpopts.IgnoreFields(flux.Operation{}, "Location"),
 )
 
 func NewQueryTestHelper(t *testing.T, tc NewQueryTestCase) {
@@ -0,0 +1,72 @@
+package repl
+
+import "strings"
+
+// needsContinuation reports whether src is an incomplete input that continues on the next line.
+// This is the case when it has unclosed brackets or string literals,
+// or when it ends with a pipe forward operator.
+func needsContinuation(src string) bool {
+	var (
+		depth int
+		// last is the last significant character outside of strings, regexes and comments.
+		last byte
+		end  int
+	)
+	for i := 0; i < len(src); i++ {
+		c := src[i]
+		switch {
+		case c == '"':
+			j, ok := skipLiteral(src, i, '"')
+			if !ok {
+				return true
+			}
+			i = j
+		case c == '/' && i+1 < len(src) && src[i+1] == '/':
+			for i < len(src) && src[i] != '\n' {
+				i++
+			}
+			continue
+		case c == '/' && startsRegex(last):
+			j, ok := skipLiteral(src, i, '/')
+			if !ok {
+				return false
+			}
+			i = j
+		case c == '(' || c == '[' || c == '{':
+			depth++
+		case c == ')' || c == ']' || c == '}':
+			depth--
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			continue
+		}
+		last = c
+		end = i
+	}
+	if depth > 0 {
+		return true
+	}
+	return end > 0 && strings.HasSuffix(src[:end+1], "|>")
+}
+
+// skipLiteral returns the index of the delimiter closing the literal that starts at index start.
+func skipLiteral(src string, start int, delim byte) (int, bool) {
+	for i := start + 1; i < len(src); i++ {
+		switch src[i] {
+		case '\\':
+			i++
+		case delim:
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// startsRegex reports whether a slash following the character last starts a regex literal
+// rather than being a division operator.
+func startsRegex(last byte) bool {
+	switch last {
+	case 0, '(', '[', '{', ',', ':', '=', '~':
+		return true
+	}
+	return false
+}
@@ -0,0 +1,40 @@
+package repl
+
+import "testing"
+
+func TestNeedsContinuation(t *testing.T) {
+	testCases := []struct {
+		name string
+		src  string
+		want bool
+	}{
+		{name: "complete", src: `1 + 1`, want: false},
+		{name: "empty", src: ``, want: false},
+		{name: "unclosed paren", src: `from(bucket: "a"`, want: true},
+		{name: "unclosed bracket", src: `x = [1, 2,`, want: true},
+		{name: "unclosed brace", src: `f = (r) => {`, want: true},
+		{name: "nested", src: "f = (r) => {\n\treturn [r, (1)]\n}", want: false},
+		{name: "nested unclosed", src: "f = (r) => {\n\treturn [r, (1)]", want: true},
+		{name: "too many closing", src: `x = (1))`, want: false},
+		{name: "pipe forward", src: `from(bucket: "a") |>`, want: true},
+		{name: "pipe forward and spaces", src: "from(bucket: \"a\")\n\t|> range(start: -1h) |> \n", want: true},
+		{name: "pipe forward and comment", src: `from(bucket: "a") |> // next`, want: true},
+		{name: "bracket in string", src: `x = "(["`, want: false},
+		{name: "closing bracket in string", src: `x = ("))"`, want: true},
+		{name: "escaped quote in string", src: `x = "a\"("`, want: false},
+		{name: "unterminated string", src: `x = "a`, want: true},
+		{name: "pipe forward in string", src: `x = "|>"`, want: false},
+		{name: "bracket in comment", src: "x = 1 // (\n", want: false},
+		{name: "closing bracket in comment", src: "x = (1 // )\n", want: true},
+		{name: "bracket in regex", src: `x = /\(/`, want: false},
+		{name: "division", src: `x = 4 / (2`, want: true},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			if got := needsContinuation(tc.src); got != tc.want {
+				t.Errorf("unexpected continuation of %q, want %v, got %v", tc.src, tc.want, got)
+			}
+		})
+	}
+}
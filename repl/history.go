@@ -0,0 +1,71 @@
+package repl
+
+import (
+	"bufio"
+	"os"
+	"os/user"
+	"path/filepath"
+)
+
+// maxHistory is the number of entries loaded from the history file.
+const maxHistory = 1000
+
+// defaultHistoryFile returns the path of the file the REPL history is persisted to.
+// The history is not persisted if the home directory of the user cannot be determined.
+func defaultHistoryFile() string {
+	u, err := user.Current()
+	if err != nil || u.HomeDir == "" {
+		return ""
+	}
+	return filepath.Join(u.HomeDir, ".flux_history")
+}
+
+// history persists the lines entered in the REPL across sessions, one line per entry.
+type history struct {
+	path string
+}
+
+func newHistory(path string) *history {
+	return &history{path: path}
+}
+
+// load returns the most recent entries of the history file.
+// A missing or unreadable history file results in an empty history.
+func (h *history) load() []string {
+	if h.path == "" {
+		return nil
+	}
+	f, err := os.Open(h.path)
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+
+	var entries []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if line := scanner.Text(); line != "" {
+			entries = append(entries, line)
+		}
+	}
+	if len(entries) > maxHistory {
+		entries = entries[len(entries)-maxHistory:]
+	}
+	return entries
+}
+
+// append adds an entry to the history file.
+func (h *history) append(entry string) error {
+	if h.path == "" || entry == "" {
+		return nil
+	}
+	f, err := os.OpenFile(h.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.WriteString(entry + "\n"); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,90 @@
+package repl
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestHistory(t *testing.T) {
+	dir, err := ioutil.TempDir("", "flux-history")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	testCases := []struct {
+		name    string
+		entries []string
+		want    []string
+	}{
+		{
+			name: "no entries",
+		},
+		{
+			name:    "entries",
+			entries: []string{`x = 1`, `from(bucket: "a") |>`, `range(start: -1h)`},
+			want:    []string{`x = 1`, `from(bucket: "a") |>`, `range(start: -1h)`},
+		},
+		{
+			name:    "empty entries",
+			entries: []string{`x = 1`, ``, `x`},
+			want:    []string{`x = 1`, `x`},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			h := newHistory(filepath.Join(dir, tc.name))
+			for _, e := range tc.entries {
+				if err := h.append(e); err != nil {
+					t.Fatal(err)
+				}
+			}
+			// A new history reads the entries saved by the previous one.
+			got := newHistory(h.path).load()
+			if !cmp.Equal(tc.want, got) {
+				t.Errorf("unexpected history -want/+got\n%s", cmp.Diff(tc.want, got))
+			}
+		})
+	}
+}
+
+func TestHistory_MaxEntries(t *testing.T) {
+	dir, err := ioutil.TempDir("", "flux-history")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	h := newHistory(filepath.Join(dir, "history"))
+	for i := 0; i < maxHistory+10; i++ {
+		if err := h.append(strconv.Itoa(i)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	got := h.load()
+	if len(got) != maxHistory {
+		t.Fatalf("unexpected number of entries, want %d, got %d", maxHistory, len(got))
+	}
+	if got[0] != "10" || got[len(got)-1] != strconv.Itoa(maxHistory+9) {
+		t.Errorf("expected the most recent entries, got %s to %s", got[0], got[len(got)-1])
+	}
+}
+
+func TestHistory_NoFile(t *testing.T) {
+	h := newHistory("")
+	if err := h.append("x"); err != nil {
+		t.Fatal(err)
+	}
+	if got := h.load(); got != nil {
+		t.Errorf("unexpected entries %v", got)
+	}
+	if got := newHistory(filepath.Join(os.TempDir(), "flux-history-missing", "history")).load(); got != nil {
+		t.Errorf("unexpected entries of a missing file %v", got)
+	}
+}
@@ -9,14 +9,15 @@ import (
 	"os/signal"
 	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"syscall"
 
-	"github.com/influxdata/flux/ast"
-
 	prompt "github.com/c-bata/go-prompt"
 	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/ast"
+	"github.com/influxdata/flux/complete"
 	"github.com/influxdata/flux/execute"
 	"github.com/influxdata/flux/interpreter"
 	"github.com/influxdata/flux/lang"
@@ -30,6 +31,10 @@ type REPL struct {
 	scope       interpreter.Scope
 	querier     Querier
 
+	// pending holds the lines of a multi-line input that is not yet complete.
+	pending []string
+	history *history
+
 	cancelMu   sync.Mutex
 	cancelFunc context.CancelFunc
 }
@@ -43,6 +48,7 @@ func New(q Querier) *REPL {
 		interpreter: interpreter.NewInterpreter(),
 		scope:       flux.Prelude(),
 		querier:     q,
+		history:     newHistory(defaultHistoryFile()),
 	}
 }
 
@@ -51,7 +57,10 @@ func (r *REPL) Run() {
 		r.input,
 		r.completer,
 		prompt.OptionPrefix("> "),
+		prompt.OptionLivePrefix(r.livePrefix),
 		prompt.OptionTitle("flux"),
+		prompt.OptionHistory(r.history.load()),
+		prompt.OptionCompletionWordSeparator(wordSeparators),
 	)
 	sigs := make(chan os.Signal, 1)
 	signal.Notify(sigs, syscall.SIGINT)
@@ -81,17 +90,52 @@ func (r *REPL) clearCancel() {
 	r.setCancel(nil)
 }
 
+// livePrefix switches the prompt to a continuation prefix while a multi-line input is pending.
+func (r *REPL) livePrefix() (string, bool) {
+	if len(r.pending) > 0 {
+		return "| ", true
+	}
+	return "", false
+}
+
+// wordSeparators are the characters that delimit the word being completed.
+const wordSeparators = " \t\n()[]{},:=<>+-*/%!|"
+
 func (r *REPL) completer(d prompt.Document) []prompt.Suggest {
-	names := make([]string, 0, r.scope.Size())
-	r.scope.Range(func(k string, v values.Value) {
-		names = append(names, k)
-	})
-	sort.Strings(names)
+	word := d.GetWordBeforeCursorUntilSeparator(wordSeparators)
+	c := complete.NewCompleter(r.scope)
+
+	// Complete the package path of an import statement.
+	if line := strings.TrimSpace(d.CurrentLineBeforeCursor()); strings.HasPrefix(line, "import") && strings.HasPrefix(word, `"`) {
+		paths := c.PackagePaths()
+		s := make([]prompt.Suggest, len(paths))
+		for i, p := range paths {
+			s[i] = prompt.Suggest{Text: strconv.Quote(p)}
+		}
+		return prompt.FilterHasPrefix(s, word, true)
+	}
+
+	// Complete the properties of a record or package.
+	if i := strings.LastIndex(word, "."); i > 0 {
+		name := word[:i]
+		props, err := c.Properties(name)
+		if err != nil {
+			return nil
+		}
+		s := make([]prompt.Suggest, 0, len(props))
+		for _, p := range props {
+			if isVisible(p) {
+				s = append(s, r.suggest(c, name+"."+p))
+			}
+		}
+		return prompt.FilterHasPrefix(s, word, true)
+	}
 
+	names := c.Names()
 	s := make([]prompt.Suggest, 0, len(names))
 	for _, n := range names {
-		if n == "_" || !strings.HasPrefix(n, "_") {
-			s = append(s, prompt.Suggest{Text: n})
+		if isVisible(n) {
+			s = append(s, r.suggest(c, n))
 		}
 	}
 	if d.Text == "" || strings.HasPrefix(d.Text, "@") {
@@ -108,9 +152,34 @@ func (r *REPL) completer(d prompt.Document) []prompt.Suggest {
 				s = append(s, prompt.Suggest{Text: "@" + fName + string(os.PathSeparator)})
 			}
 		}
+		word = d.GetWordBeforeCursor()
+	}
+
+	return prompt.FilterHasPrefix(s, word, true)
+}
+
+// suggest creates a suggestion for the named value.
+// Functions are described by their parameters.
+func (r *REPL) suggest(c complete.Completer, name string) prompt.Suggest {
+	fs, err := c.FunctionSuggestion(name)
+	if err != nil {
+		return prompt.Suggest{Text: name}
 	}
+	params := make([]string, 0, len(fs.Params))
+	for p := range fs.Params {
+		params = append(params, p)
+	}
+	sort.Strings(params)
+	return prompt.Suggest{
+		Text:        name,
+		Description: "(" + strings.Join(params, ", ") + ")",
+	}
+}
 
-	return prompt.FilterHasPrefix(s, d.GetWordBeforeCursor(), true)
+// isVisible reports whether a name should be suggested.
+// Names starting with an underscore are considered internal.
+func isVisible(name string) bool {
+	return name == "_" || !strings.HasPrefix(name, "_")
 }
 
 func (r *REPL) Input(t string) error {
@@ -119,8 +188,21 @@ func (r *REPL) Input(t string) error {
 }
 
 // input processes a line of input and prints the result.
+// Lines are accumulated until the brackets of the input are balanced
+// and the input does not end with a pipe forward operator.
 func (r *REPL) input(t string) {
-	v, err := r.executeLine(t)
+	if err := r.history.append(t); err != nil {
+		fmt.Println("Error: failed to save history:", err)
+	}
+
+	r.pending = append(r.pending, t)
+	src := strings.Join(r.pending, "\n")
+	if needsContinuation(src) {
+		return
+	}
+	r.pending = r.pending[:0]
+
+	v, err := r.executeLine(src)
 	if err != nil {
 		fmt.Println("Error:", err)
 	} else if v != nil {
@@ -0,0 +1,286 @@
+// Package replay records the tables that the sources of a query produce into an archive,
+// and executes the query again with the recorded tables in place of its sources.
+// It lets the bugs of the engine be reproduced without access to the data that was queried.
+//
+// An archive is a zip file with the JSON spec of the query in spec.json,
+// the sources of its plan in sources.json, and every recorded table as annotated CSV.
+package replay
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sort"
+	"sync"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/csv"
+	"github.com/influxdata/flux/dependencies"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/iocounter"
+	"github.com/influxdata/flux/memory"
+	"github.com/influxdata/flux/plan"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+const (
+	specFile    = "spec.json"
+	sourcesFile = "sources.json"
+)
+
+// Archive is a recorded query.
+type Archive struct {
+	Spec *flux.Spec
+	// Sources are the tables produced by the sources of the plan of the query, encoded as annotated CSV.
+	Sources map[plan.NodeID][][]byte
+}
+
+// source is an entry of sources.json.
+type source struct {
+	Node   plan.NodeID `json:"node"`
+	Tables []string    `json:"tables"`
+}
+
+// WriteTo writes the archive to w as a zip file.
+func (a *Archive) WriteTo(w io.Writer) (int64, error) {
+	cw := &iocounter.Writer{Writer: w}
+	zw := zip.NewWriter(cw)
+	write := func(name string, data []byte) error {
+		f, err := zw.Create(name)
+		if err != nil {
+			return err
+		}
+		_, err = f.Write(data)
+		return err
+	}
+
+	spec, err := json.MarshalIndent(a.Spec, "", "  ")
+	if err != nil {
+		return cw.Count(), errors.Wrap(err, "failed to encode the spec")
+	}
+	if err := write(specFile, spec); err != nil {
+		return cw.Count(), err
+	}
+
+	nodes := make([]string, 0, len(a.Sources))
+	for node := range a.Sources {
+		nodes = append(nodes, string(node))
+	}
+	sort.Strings(nodes)
+	sources := make([]source, len(nodes))
+	for i, node := range nodes {
+		sources[i].Node = plan.NodeID(node)
+		sources[i].Tables = []string{}
+		for j, tbl := range a.Sources[plan.NodeID(node)] {
+			name := fmt.Sprintf("sources/%d/%d.csv", i, j)
+			if err := write(name, tbl); err != nil {
+				return cw.Count(), err
+			}
+			sources[i].Tables = append(sources[i].Tables, name)
+		}
+	}
+	data, err := json.MarshalIndent(sources, "", "  ")
+	if err != nil {
+		return cw.Count(), err
+	}
+	if err := write(sourcesFile, data); err != nil {
+		return cw.Count(), err
+	}
+	err = zw.Close()
+	return cw.Count(), err
+}
+
+// ReadArchive reads an archive written by WriteTo.
+func ReadArchive(r io.ReaderAt, size int64) (*Archive, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid archive")
+	}
+	files := make(map[string]*zip.File, len(zr.File))
+	for _, f := range zr.File {
+		files[f.Name] = f
+	}
+	read := func(name string) ([]byte, error) {
+		f, ok := files[name]
+		if !ok {
+			return nil, fmt.Errorf("invalid archive: missing %s", name)
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, err
+		}
+		defer rc.Close()
+		return ioutil.ReadAll(rc)
+	}
+
+	a := &Archive{
+		Spec:    new(flux.Spec),
+		Sources: make(map[plan.NodeID][][]byte),
+	}
+	data, err := read(specFile)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, a.Spec); err != nil {
+		return nil, errors.Wrap(err, "failed to decode the spec")
+	}
+	if data, err = read(sourcesFile); err != nil {
+		return nil, err
+	}
+	var sources []source
+	if err := json.Unmarshal(data, &sources); err != nil {
+		return nil, errors.Wrap(err, "failed to decode the sources")
+	}
+	for _, s := range sources {
+		tables := [][]byte{}
+		for _, name := range s.Tables {
+			tbl, err := read(name)
+			if err != nil {
+				return nil, err
+			}
+			tables = append(tables, tbl)
+		}
+		a.Sources[s.Node] = tables
+	}
+	return a, nil
+}
+
+// Recorder records a query and the tables produced by its sources when it is executed.
+type Recorder struct {
+	mu      sync.Mutex
+	spec    *flux.Spec
+	sources map[plan.NodeID][][]byte
+}
+
+// NewRecorder creates a recorder of a single query.
+func NewRecorder() *Recorder {
+	return &Recorder{
+		sources: make(map[plan.NodeID][][]byte),
+	}
+}
+
+// Record returns the context and the compiler with which a query is recorded when it is executed,
+// as in controller.Query(recorder.Record(ctx, compiler)).
+func (r *Recorder) Record(ctx context.Context, c flux.Compiler) (context.Context, flux.Compiler) {
+	return execute.WithSourceRecorder(ctx, r), recordingCompiler{Compiler: c, r: r}
+}
+
+// RecordSource records that the plan has a source for the node.
+func (r *Recorder) RecordSource(node plan.NodeID) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.sources[node]; !ok {
+		r.sources[node] = [][]byte{}
+	}
+}
+
+// RecordTable records a table produced by the source of a node.
+func (r *Recorder) RecordTable(node plan.NodeID, tbl flux.Table) error {
+	var buf bytes.Buffer
+	enc := csv.NewResultEncoder(csv.DefaultEncoderConfig())
+	if _, err := enc.Encode(&buf, tableResult{tbl: tbl}); err != nil {
+		return errors.Wrapf(err, "failed to record a table of %s", node)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sources[node] = append(r.sources[node], buf.Bytes())
+	return nil
+}
+
+// Archive returns the archive of the recorded query.
+// It must be called once the query has been executed.
+func (r *Recorder) Archive() (*Archive, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.spec == nil {
+		return nil, errors.New("no query was recorded")
+	}
+	a := &Archive{
+		Spec:    r.spec,
+		Sources: make(map[plan.NodeID][][]byte, len(r.sources)),
+	}
+	for node, tables := range r.sources {
+		a.Sources[node] = append([][]byte{}, tables...)
+	}
+	return a, nil
+}
+
+// recordingCompiler records the spec of a query when it is compiled.
+type recordingCompiler struct {
+	flux.Compiler
+	r *Recorder
+}
+
+func (c recordingCompiler) Compile(ctx context.Context) (*flux.Spec, error) {
+	spec, err := c.Compiler.Compile(ctx)
+	if err != nil {
+		return nil, err
+	}
+	c.r.mu.Lock()
+	c.r.spec = spec
+	c.r.mu.Unlock()
+	return spec, nil
+}
+
+// tableResult is a result with a single table.
+type tableResult struct {
+	tbl flux.Table
+}
+
+func (r tableResult) Name() string                      { return "_result" }
+func (r tableResult) Tables() flux.TableIterator        { return r }
+func (r tableResult) Statistics() flux.Statistics       { return flux.Statistics{} }
+func (r tableResult) Do(f func(flux.Table) error) error { return f(r.tbl) }
+
+// Config configures the replay of a query.
+// The query must be planned as it was when it was recorded, so that the nodes of its plan match the recorded sources.
+type Config struct {
+	LPlannerOptions      []plan.LogicalOption
+	PPlannerOptions      []plan.PhysicalOption
+	ExecutorDependencies execute.Dependencies
+	Logger               *zap.Logger
+}
+
+// Replay executes the recorded query again, with the recorded tables in place of the sources of its plan.
+func (a *Archive) Replay(ctx context.Context, c Config, alloc *memory.Allocator) (map[string]flux.Result, error) {
+	lp := plan.NewLogicalPlanner(c.LPlannerOptions...)
+	ip, err := lp.CreateInitialPlan(a.Spec)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create initial logical plan")
+	}
+	ip.Flagger = dependencies.Get(ctx).Flagger
+	p, err := lp.Plan(ip)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create logical plan")
+	}
+	if p, err = plan.NewPhysicalPlanner(c.PPlannerOptions...).Plan(p); err != nil {
+		return nil, errors.Wrap(err, "failed to create physical plan")
+	}
+
+	replayed := 0
+	if err := p.BottomUpWalk(func(node plan.PlanNode) error {
+		if len(node.Predecessors()) > 0 {
+			return nil
+		}
+		tables, ok := a.Sources[node.ID()]
+		pn, isPhysical := node.(*plan.PhysicalPlanNode)
+		if !ok || !isPhysical {
+			return fmt.Errorf("no tables were recorded for the source %s, the query must be planned as it was recorded", node.ID())
+		}
+		pn.Spec = &SourceProcedureSpec{Tables: tables}
+		replayed++
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	if replayed != len(a.Sources) {
+		return nil, fmt.Errorf("the plan has %d sources but %d were recorded, the query must be planned as it was recorded", replayed, len(a.Sources))
+	}
+
+	return execute.NewExecutor(c.ExecutorDependencies, c.Logger).Execute(ctx, p, alloc)
+}
@@ -0,0 +1,91 @@
+package replay_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/flux"
+	_ "github.com/influxdata/flux/builtin"
+	"github.com/influxdata/flux/csv"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/lang"
+	"github.com/influxdata/flux/memory"
+	"github.com/influxdata/flux/querytest"
+	"github.com/influxdata/flux/replay"
+)
+
+const query = `
+import "csv"
+
+data = "
+#datatype,string,long,dateTime:RFC3339,dateTime:RFC3339,dateTime:RFC3339,string,string,double
+#group,false,false,true,true,false,true,true,false
+#default,_result,,,,,,,
+,result,table,_start,_stop,_time,_measurement,host,_value
+,,0,2018-05-22T19:53:00Z,2018-05-22T19:54:00Z,2018-05-22T19:53:10Z,cpu,a,1.5
+,,0,2018-05-22T19:53:00Z,2018-05-22T19:54:00Z,2018-05-22T19:53:20Z,cpu,a,2.5
+,,1,2018-05-22T19:53:00Z,2018-05-22T19:54:00Z,2018-05-22T19:53:10Z,cpu,b,4.0
+,,1,2018-05-22T19:53:00Z,2018-05-22T19:54:00Z,2018-05-22T19:53:20Z,cpu,b,5.0
+"
+csv.from(csv: data)
+	|> filter(fn: (r) => r._value > 2.0)
+	|> sum()
+`
+
+func encode(t *testing.T, results flux.ResultIterator) string {
+	t.Helper()
+	defer results.Release()
+	var buf bytes.Buffer
+	if _, err := csv.NewMultiResultEncoder(csv.DefaultEncoderConfig()).Encode(&buf, results); err != nil {
+		t.Fatal(err)
+	}
+	return buf.String()
+}
+
+func TestRecordReplay(t *testing.T) {
+	querier := querytest.NewQuerier()
+	rec := replay.NewRecorder()
+	q, err := querier.C.Query(rec.Record(context.Background(), lang.FluxCompiler{Query: query}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := encode(t, flux.NewResultIteratorFromQuery(q))
+
+	a, err := rec.Archive()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(a.Sources) != 1 {
+		t.Fatalf("unexpected number of recorded sources: %d", len(a.Sources))
+	}
+	var buf bytes.Buffer
+	if _, err := a.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	a, err = replay.ReadArchive(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	results, err := a.Replay(context.Background(), replay.Config{ExecutorDependencies: execute.Dependencies{}}, &memory.Allocator{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := encode(t, flux.NewMapResultIterator(results))
+	if !cmp.Equal(want, got) {
+		t.Errorf("unexpected replayed results -want/+got\n%s", cmp.Diff(want, got))
+	}
+}
+
+func TestReplay_NotRecorded(t *testing.T) {
+	spec, err := flux.Compile(context.Background(), query, execute.Now().Time())
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := &replay.Archive{Spec: spec}
+	if _, err := a.Replay(context.Background(), replay.Config{}, &memory.Allocator{}); err == nil {
+		t.Fatal("expected an error")
+	}
+}
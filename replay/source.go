@@ -0,0 +1,96 @@
+package replay
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/csv"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/plan"
+)
+
+const SourceKind = "replaySource"
+
+func init() {
+	execute.RegisterSource(SourceKind, createSource)
+}
+
+// SourceProcedureSpec replaces the source of a node of a replayed plan.
+type SourceProcedureSpec struct {
+	plan.DefaultCost
+	// Tables are the recorded tables of the source, encoded as annotated CSV.
+	Tables [][]byte
+}
+
+func (s *SourceProcedureSpec) Kind() plan.ProcedureKind {
+	return SourceKind
+}
+
+func (s *SourceProcedureSpec) Copy() plan.ProcedureSpec {
+	ns := new(SourceProcedureSpec)
+	ns.Tables = s.Tables
+	return ns
+}
+
+func createSource(prSpec plan.ProcedureSpec, dsid execute.DatasetID, a execute.Administration) (execute.Source, error) {
+	spec, ok := prSpec.(*SourceProcedureSpec)
+	if !ok {
+		return nil, fmt.Errorf("invalid spec type %T", prSpec)
+	}
+	return &replaySource{id: dsid, tables: spec.Tables}, nil
+}
+
+// replaySource produces the recorded tables of a source.
+type replaySource struct {
+	id     execute.DatasetID
+	tables [][]byte
+	ts     []execute.Transformation
+}
+
+func (s *replaySource) AddTransformation(t execute.Transformation) {
+	s.ts = append(s.ts, t)
+}
+
+func (s *replaySource) Run(ctx context.Context) {
+	var max execute.Time
+	maxSet := false
+	err := s.do(func(tbl flux.Table) error {
+		for _, t := range s.ts {
+			if err := t.Process(s.id, tbl); err != nil {
+				return err
+			}
+		}
+		if idx := execute.ColIdx(execute.DefaultStopColLabel, tbl.Key().Cols()); idx >= 0 {
+			if stop := tbl.Key().ValueTime(idx); !maxSet || stop > max {
+				max, maxSet = stop, true
+			}
+		}
+		return nil
+	})
+	if err == nil && maxSet {
+		for _, t := range s.ts {
+			if err = t.UpdateWatermark(s.id, max); err != nil {
+				break
+			}
+		}
+	}
+	for _, t := range s.ts {
+		t.Finish(s.id, err)
+	}
+}
+
+func (s *replaySource) do(f func(flux.Table) error) error {
+	dec := csv.NewResultDecoder(csv.ResultDecoderConfig{})
+	for _, data := range s.tables {
+		result, err := dec.Decode(bytes.NewReader(data))
+		if err != nil {
+			return err
+		}
+		if err := result.Tables().Do(f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
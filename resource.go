@@ -0,0 +1,72 @@
+package flux
+
+import "sort"
+
+// AccessMode describes how an operation accesses an external resource.
+type AccessMode string
+
+const (
+	ReadAccess  AccessMode = "read"
+	WriteAccess AccessMode = "write"
+)
+
+// Kinds of external resources.
+const (
+	BucketResource = "bucket"
+	HostResource   = "host"
+	URLResource    = "url"
+	FileResource   = "file"
+	// SQLResource is a database, named by its data source name.
+	SQLResource = "sql"
+	// ModelResource is a model scored by a query, named by its location.
+	ModelResource = "model"
+)
+
+// AllResources is the name of a resource that stands for every resource of its kind,
+// for example when an operation lists all of the buckets.
+const AllResources = "*"
+
+// Resource describes an external resource accessed by an operation.
+type Resource struct {
+	// Kind is the kind of the resource, such as BucketResource.
+	Kind string     `json:"kind"`
+	Name string     `json:"name"`
+	Mode AccessMode `json:"mode"`
+}
+
+// ResourceAccessor is implemented by the operation specs that access external resources.
+type ResourceAccessor interface {
+	// Resources returns the resources accessed by the operation.
+	Resources() []Resource
+}
+
+// Resources returns the external resources accessed by the operations of a spec,
+// so that access to them can be authorized before the spec is executed.
+// Operations that do not implement ResourceAccessor are assumed not to access any.
+// The resources are sorted by kind, name and access mode and contain no duplicates.
+func Resources(spec *Spec) []Resource {
+	seen := make(map[Resource]bool)
+	var resources []Resource
+	for _, op := range spec.Operations {
+		ra, ok := op.Spec.(ResourceAccessor)
+		if !ok {
+			continue
+		}
+		for _, r := range ra.Resources() {
+			if !seen[r] {
+				seen[r] = true
+				resources = append(resources, r)
+			}
+		}
+	}
+	sort.Slice(resources, func(i, j int) bool {
+		if resources[i].Kind != resources[j].Kind {
+			return resources[i].Kind < resources[j].Kind
+		}
+		if resources[i].Name != resources[j].Name {
+			return resources[i].Name < resources[j].Name
+		}
+		return resources[i].Mode < resources[j].Mode
+	})
+	return resources
+}
@@ -0,0 +1,35 @@
+package flux_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/stdlib/experimental/inference"
+	"github.com/influxdata/flux/stdlib/http"
+	"github.com/influxdata/flux/stdlib/influxdata/influxdb"
+	"github.com/influxdata/flux/stdlib/sql"
+	"github.com/influxdata/flux/stdlib/universe"
+)
+
+func TestResources(t *testing.T) {
+	spec := &flux.Spec{
+		Operations: []*flux.Operation{
+			{ID: "from0", Spec: &influxdb.FromOpSpec{Bucket: "telegraf"}},
+			{ID: "fromSQL1", Spec: &sql.FromSQLOpSpec{DriverName: "postgres", DataSourceName: "postgresql://localhost/db", Query: "SELECT * FROM t"}},
+			{ID: "from2", Spec: &influxdb.FromOpSpec{Bucket: "telegraf"}},
+			{ID: "union3", Spec: &universe.UnionOpSpec{}},
+			{ID: "toHTTP4", Spec: &http.ToHTTPOpSpec{URL: "http://localhost:8080/write"}},
+			{ID: "score5", Spec: &inference.ScoreOpSpec{Format: "onnx", Location: "/models/forecast.onnx"}},
+		},
+	}
+	want := []flux.Resource{
+		{Kind: flux.BucketResource, Name: "telegraf", Mode: flux.ReadAccess},
+		{Kind: flux.ModelResource, Name: "/models/forecast.onnx", Mode: flux.ReadAccess},
+		{Kind: flux.SQLResource, Name: "postgresql://localhost/db", Mode: flux.ReadAccess},
+		{Kind: flux.URLResource, Name: "http://localhost:8080/write", Mode: flux.WriteAccess},
+	}
+	if got := flux.Resources(spec); !cmp.Equal(want, got) {
+		t.Errorf("unexpected resources -want/+got\n%s", cmp.Diff(want, got))
+	}
+}
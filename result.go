@@ -4,6 +4,7 @@ import (
 	"io"
 
 	"github.com/apache/arrow/go/arrow/array"
+	"github.com/influxdata/flux/ast"
 	"github.com/influxdata/flux/iocounter"
 	"github.com/influxdata/flux/semantic"
 	"github.com/influxdata/flux/values"
@@ -18,6 +19,26 @@ type Result interface {
 	Statistics() Statistics
 }
 
+// Warning is a non-fatal diagnostic reported while compiling a script or computing a result,
+// for example rows that a transformation had to ignore.
+type Warning struct {
+	// Node is the ID of the plan node that reported the warning,
+	// or empty if the warning was reported while compiling the script.
+	Node string `json:"node"`
+	// Message describes the warning.
+	Message string `json:"message"`
+	// Location is the location in the script that the warning refers to, if it is known.
+	Location *ast.SourceLocation `json:"location,omitempty"`
+}
+
+// Warner is implemented by results that report warnings alongside their tables.
+// Encoders write the warnings as metadata of the result instead of failing the query.
+type Warner interface {
+	// Warnings returns the warnings reported for the result.
+	// The warnings are complete once the tables of the result have been consumed.
+	Warnings() []Warning
+}
+
 type TableIterator interface {
 	Do(f func(Table) error) error
 	Statistics() Statistics
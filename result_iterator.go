@@ -52,7 +52,11 @@ func (r *queryResultIterator) More() bool {
 		if !ok {
 			return false
 		}
-		r.results = NewMapResultIterator(results)
+		if rn, ok := r.query.(ResultNamer); ok {
+			r.results = NewOrderedMapResultIterator(results, rn.ResultNames())
+		} else {
+			r.results = NewMapResultIterator(results)
+		}
 	}
 	return r.results.More()
 }
@@ -98,6 +102,30 @@ func NewMapResultIterator(results map[string]Result) ResultIterator {
 	}
 }
 
+// NewOrderedMapResultIterator returns an iterator over the results in the given order.
+// Results that are missing from the order follow in the order of their names.
+func NewOrderedMapResultIterator(results map[string]Result, order []string) ResultIterator {
+	seen := make(map[string]bool, len(order))
+	o := make([]string, 0, len(results))
+	for _, name := range order {
+		if _, ok := results[name]; ok && !seen[name] {
+			o = append(o, name)
+			seen[name] = true
+		}
+	}
+	rest := make([]string, 0, len(results)-len(o))
+	for k := range results {
+		if !seen[k] {
+			rest = append(rest, k)
+		}
+	}
+	sort.Strings(rest)
+	return &mapResultIterator{
+		results: results,
+		order:   append(o, rest...),
+	}
+}
+
 func (r *mapResultIterator) More() bool {
 	return len(r.order) > 0
 }
@@ -0,0 +1,29 @@
+package flux_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute/executetest"
+)
+
+func TestOrderedMapResultIterator(t *testing.T) {
+	results := map[string]flux.Result{
+		"zeta":  &executetest.Result{Nm: "zeta"},
+		"alpha": &executetest.Result{Nm: "alpha"},
+		"mid":   &executetest.Result{Nm: "mid"},
+		"extra": &executetest.Result{Nm: "extra"},
+	}
+	ri := flux.NewOrderedMapResultIterator(results, []string{"zeta", "missing", "alpha", "mid", "zeta"})
+	defer ri.Release()
+
+	var got []string
+	for ri.More() {
+		got = append(got, ri.Next().Name())
+	}
+	want := []string{"zeta", "alpha", "mid", "extra"}
+	if !cmp.Equal(want, got) {
+		t.Errorf("unexpected result order -want/+got\n%s", cmp.Diff(want, got))
+	}
+}
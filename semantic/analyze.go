@@ -3,7 +3,6 @@ package semantic
 import (
 	"errors"
 	"fmt"
-	"time"
 
 	"github.com/influxdata/flux/ast"
 )
@@ -599,13 +598,14 @@ func analyzeDateTimeLiteral(lit *ast.DateTimeLiteral) (*DateTimeLiteral, error)
 	}, nil
 }
 func analyzeDurationLiteral(lit *ast.DurationLiteral) (*DurationLiteral, error) {
-	duration, err := ast.DurationFrom(lit, time.Time{})
+	months, nsecs, err := ast.DurationParts(lit)
 	if err != nil {
 		return nil, err
 	}
 	return &DurationLiteral{
-		loc:   loc(lit.Location()),
-		Value: duration,
+		loc:    loc(lit.Location()),
+		Value:  nsecs,
+		Months: months,
 	}, nil
 }
 func analyzeFloatLiteral(lit *ast.FloatLiteral) (*FloatLiteral, error) {
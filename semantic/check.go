@@ -1,6 +1,9 @@
 package semantic
 
-import "fmt"
+import (
+	"fmt"
+	"path"
+)
 
 func runChecks(n Node, vars, opts map[string]bool) error {
 	// Check for options declared below package block.
@@ -9,6 +12,10 @@ func runChecks(n Node, vars, opts map[string]bool) error {
 	if err != nil {
 		return err
 	}
+	// Check that options of other packages are qualified by an import.
+	if err := optionQualifiers(n); err != nil {
+		return err
+	}
 	// Check for option reassignments in package block.
 	if err := optionReAssignments(stmts, vars, opts); err != nil {
 		return err
@@ -91,6 +98,48 @@ func (v optionStmtVisitor) Nest() NestingVisitor {
 
 func (v optionStmtVisitor) Done(node Node) {}
 
+// optionQualifiers checks that the options a file sets in other packages
+// are qualified by the name of a package that the file imports.
+// Options are scoped to the package that declares them,
+// so a qualified option is the only way to set an option of another package.
+func optionQualifiers(n Node) error {
+	var files []*File
+	switch n := n.(type) {
+	case *Package:
+		files = n.Files
+	case *File:
+		files = []*File{n}
+	}
+	for _, file := range files {
+		imports := make(map[string]bool, len(file.Imports))
+		for _, dec := range file.Imports {
+			if dec.As != nil {
+				imports[dec.As.Name] = true
+			} else {
+				imports[path.Base(dec.Path.Value)] = true
+			}
+		}
+		for _, stmt := range file.Body {
+			opt, ok := stmt.(*OptionStatement)
+			if !ok {
+				continue
+			}
+			a, ok := opt.Assignment.(*MemberAssignment)
+			if !ok {
+				continue
+			}
+			name, err := optionName(opt)
+			if err != nil {
+				return err
+			}
+			if id := a.Member.Object.(*IdentifierExpression); !imports[id.Name] {
+				return fmt.Errorf("option %q at %v is not qualified by an imported package", name, opt.Location())
+			}
+		}
+	}
+	return nil
+}
+
 func optionReAssignments(stmts []*OptionStatement, vars, options map[string]bool) error {
 	for _, stmt := range stmts {
 		name, err := optionName(stmt)
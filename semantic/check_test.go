@@ -406,6 +406,179 @@ func TestOptionDeclarations(t *testing.T) {
 	}
 }
 
+func TestOptionQualifiers(t *testing.T) {
+	testcases := []struct {
+		name string
+		pkg  *Package
+		err  error
+	}{
+		{
+			// package foo
+			// import "bar"
+			// import b "baz"
+			// option bar.x = 0
+			// option b.y = 0
+			//
+			name: "imported",
+			pkg: &Package{
+				Package: "foo",
+				Files: []*File{
+					{
+						Package: &PackageClause{
+							Name: &Identifier{Name: "foo"},
+						},
+						Imports: []*ImportDeclaration{
+							{
+								Path: &StringLiteral{Value: "bar"},
+							},
+							{
+								As:   &Identifier{Name: "b"},
+								Path: &StringLiteral{Value: "baz"},
+							},
+						},
+						Body: []Statement{
+							&OptionStatement{
+								Assignment: &MemberAssignment{
+									Member: &MemberExpression{
+										Object:   &IdentifierExpression{Name: "bar"},
+										Property: "x",
+									},
+									Init: &IntegerLiteral{Value: 0},
+								},
+							},
+							&OptionStatement{
+								Assignment: &MemberAssignment{
+									Member: &MemberExpression{
+										Object:   &IdentifierExpression{Name: "b"},
+										Property: "y",
+									},
+									Init: &IntegerLiteral{Value: 0},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			// package foo
+			// import b "bar"
+			// option bar.x = 0
+			//
+			name: "not imported",
+			pkg: &Package{
+				Package: "foo",
+				Files: []*File{
+					{
+						Package: &PackageClause{
+							Name: &Identifier{Name: "foo"},
+						},
+						Imports: []*ImportDeclaration{
+							{
+								As:   &Identifier{Name: "b"},
+								Path: &StringLiteral{Value: "bar"},
+							},
+						},
+						Body: []Statement{
+							&OptionStatement{
+								loc: loc{
+									Start: ast.Position{
+										Line:   3,
+										Column: 1,
+									},
+									End: ast.Position{
+										Line:   3,
+										Column: 17,
+									},
+								},
+								Assignment: &MemberAssignment{
+									Member: &MemberExpression{
+										Object:   &IdentifierExpression{Name: "bar"},
+										Property: "x",
+									},
+									Init: &IntegerLiteral{Value: 0},
+								},
+							},
+						},
+					},
+				},
+			},
+			err: fmt.Errorf(`option "bar.x" at 3:1-3:17 is not qualified by an imported package`),
+		},
+		{
+			// package foo
+			// import "bar"
+			//
+			// package foo
+			// option bar.x = 0
+			//
+			name: "imported by another file",
+			pkg: &Package{
+				Package: "foo",
+				Files: []*File{
+					{
+						Package: &PackageClause{
+							Name: &Identifier{Name: "foo"},
+						},
+						Imports: []*ImportDeclaration{
+							{
+								Path: &StringLiteral{Value: "bar"},
+							},
+						},
+					},
+					{
+						Package: &PackageClause{
+							Name: &Identifier{Name: "foo"},
+						},
+						Body: []Statement{
+							&OptionStatement{
+								loc: loc{
+									File: "b.flux",
+									Start: ast.Position{
+										Line:   2,
+										Column: 1,
+									},
+									End: ast.Position{
+										Line:   2,
+										Column: 17,
+									},
+								},
+								Assignment: &MemberAssignment{
+									Member: &MemberExpression{
+										Object:   &IdentifierExpression{Name: "bar"},
+										Property: "x",
+									},
+									Init: &IntegerLiteral{Value: 0},
+								},
+							},
+						},
+					},
+				},
+			},
+			err: fmt.Errorf(`option "bar.x" at b.flux|2:1-2:17 is not qualified by an imported package`),
+		},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			err := optionQualifiers(tc.pkg)
+			switch {
+			case err == nil && tc.err == nil:
+				// Test passes
+			case err == nil && tc.err != nil:
+				t.Errorf("expected error: %v", tc.err)
+			case err != nil && tc.err == nil:
+				t.Errorf("unexpected error: %v", err)
+			case err != nil && tc.err != nil:
+				if err.Error() != tc.err.Error() {
+					t.Errorf("unexpected result; want err=%v, got err=%v", tc.err, err)
+				}
+				// else test passes
+			}
+		})
+	}
+}
+
 func TestOptionReAssignments(t *testing.T) {
 	testcases := []struct {
 		name string
@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/influxdata/flux/ast"
+	fluxerrors "github.com/influxdata/flux/errors"
 	"github.com/pkg/errors"
 )
 
@@ -110,7 +111,7 @@ func (v ConstraintGenerator) typeof(n Node) (PolyType, error) {
 	case *ImportDeclaration:
 		pkg, ok := v.importer.Import(n.Path.Value)
 		if !ok {
-			return nil, fmt.Errorf("unknown import path: %q", n.Path.Value)
+			return nil, fluxerrors.Newf(fluxerrors.NotFound, "unknown import path: %q", n.Path.Value)
 		}
 		// Do not trust imported type variables,
 		// substitute them with fresh vars.
@@ -161,7 +162,7 @@ func (v ConstraintGenerator) typeof(n Node) (PolyType, error) {
 	case *IdentifierExpression:
 		scheme, ok := v.env.Lookup(n.Name)
 		if !ok {
-			return nil, fmt.Errorf("undefined identifier %q", n.Name)
+			return nil, fluxerrors.Newf(fluxerrors.NotFound, "undefined identifier %q", n.Name)
 		}
 		t := v.cs.Instantiate(scheme, n.Location())
 		return t, nil
@@ -891,7 +891,10 @@ func (l *DateTimeLiteral) Copy() Node {
 type DurationLiteral struct {
 	loc `json:"-"`
 
+	// Value is the part of the duration that has a fixed length.
 	Value time.Duration `json:"value"`
+	// Months is the part of the duration in months, which have no fixed length.
+	Months int64 `json:"months,omitempty"`
 }
 
 func (*DurationLiteral) NodeType() string { return "DurationLiteral" }
@@ -357,6 +357,11 @@ func TestNew(t *testing.T) {
 		{
 			name: "qualified option statement",
 			pkg: &ast.Package{Files: []*ast.File{&ast.File{
+				Imports: []*ast.ImportDeclaration{
+					{
+						Path: &ast.StringLiteral{Value: "alert"},
+					},
+				},
 				Body: []ast.Statement{
 					&ast.OptionStatement{
 						Assignment: &ast.MemberAssignment{
@@ -376,6 +381,11 @@ func TestNew(t *testing.T) {
 				}}},
 			},
 			want: &semantic.Package{Files: []*semantic.File{&semantic.File{
+				Imports: []*semantic.ImportDeclaration{
+					{
+						Path: &semantic.StringLiteral{Value: "alert"},
+					},
+				},
 				Body: []semantic.Statement{
 					&semantic.OptionStatement{
 						Assignment: &semantic.MemberAssignment{
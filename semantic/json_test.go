@@ -324,6 +324,14 @@ func TestJSONMarshal(t *testing.T) {
 			},
 			want: `{"type":"DurationLiteral","value":"1h1m0s"}`,
 		},
+		{
+			name: "duration literal with months",
+			node: &semantic.DurationLiteral{
+				Value:  time.Hour,
+				Months: 14,
+			},
+			want: `{"type":"DurationLiteral","months":14,"value":"1h0m0s"}`,
+		},
 		{
 			name: "datetime literal",
 			node: &semantic.DateTimeLiteral{
@@ -0,0 +1,144 @@
+package semantic
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// SignatureBuilder builds a FunctionPolySignature one parameter at a time.
+// Mistakes in the declaration, such as a parameter declared twice or a type
+// variable of the return type that no parameter binds, are reported by Build.
+type SignatureBuilder struct {
+	sig  FunctionPolySignature
+	errs []string
+}
+
+// NewSignatureBuilder returns a builder of a function signature without parameters.
+func NewSignatureBuilder() *SignatureBuilder {
+	return &SignatureBuilder{
+		sig: FunctionPolySignature{
+			Parameters: make(map[string]PolyType),
+		},
+	}
+}
+
+// Pipe declares the pipe parameter of the function.
+// The pipe parameter is always required.
+func (b *SignatureBuilder) Pipe(name string, t PolyType) *SignatureBuilder {
+	if b.sig.PipeArgument != "" {
+		b.errorf("pipe parameter declared twice, %q and %q", b.sig.PipeArgument, name)
+		return b
+	}
+	b.sig.PipeArgument = name
+	return b.Required(name, t)
+}
+
+// Required declares a parameter that must be passed to the function.
+func (b *SignatureBuilder) Required(name string, t PolyType) *SignatureBuilder {
+	if b.param(name, t) {
+		b.sig.Required = append(b.sig.Required, name)
+	}
+	return b
+}
+
+// Optional declares a parameter that may be omitted when calling the function.
+func (b *SignatureBuilder) Optional(name string, t PolyType) *SignatureBuilder {
+	b.param(name, t)
+	return b
+}
+
+// Return declares the return type of the function.
+func (b *SignatureBuilder) Return(t PolyType) *SignatureBuilder {
+	if t == nil {
+		b.errorf("return type is nil")
+		return b
+	}
+	b.sig.Return = t
+	return b
+}
+
+func (b *SignatureBuilder) param(name string, t PolyType) bool {
+	switch {
+	case name == "":
+		b.errorf("parameter without a name")
+		return false
+	case t == nil:
+		b.errorf("parameter %q has no type", name)
+		return false
+	}
+	if _, ok := b.sig.Parameters[name]; ok {
+		b.errorf("parameter %q declared twice", name)
+		return false
+	}
+	b.sig.Parameters[name] = t
+	return true
+}
+
+func (b *SignatureBuilder) errorf(format string, args ...interface{}) {
+	b.errs = append(b.errs, fmt.Sprintf(format, args...))
+}
+
+// Build returns the declared signature or an error describing every mistake in its declaration.
+func (b *SignatureBuilder) Build() (FunctionPolySignature, error) {
+	errs := b.errs
+	if b.sig.Return == nil {
+		errs = append(errs, "missing return type")
+	} else {
+		var bound TvarSet
+		for _, t := range b.sig.Parameters {
+			bound = bound.union(t.freeVars(nil))
+		}
+		if free := b.sig.Return.freeVars(nil).diff(bound); len(free) > 0 {
+			errs = append(errs, fmt.Sprintf("return type %v has type variables %v that no parameter binds", b.sig.Return, free))
+		}
+	}
+	if len(errs) > 0 {
+		return FunctionPolySignature{}, fmt.Errorf("invalid function signature: %s", strings.Join(errs, "; "))
+	}
+	return b.sig, nil
+}
+
+// MustBuild is like Build but panics if the declaration is invalid.
+// It is meant to be used when registering builtins in an init function,
+// so that a mistake fails the program at startup.
+func (b *SignatureBuilder) MustBuild() FunctionPolySignature {
+	sig, err := b.Build()
+	if err != nil {
+		panic(err)
+	}
+	return sig
+}
+
+// ValidateFunctionPolyType reports whether the signature of a function type is consistent,
+// that is whether its required and pipe parameters are declared and every parameter has a type.
+func ValidateFunctionPolyType(t PolyType) error {
+	f, ok := t.(function)
+	if !ok {
+		return fmt.Errorf("type %v is not a function", t)
+	}
+	var errs []string
+	for _, l := range f.required {
+		if _, ok := f.parameters[l]; !ok {
+			errs = append(errs, fmt.Sprintf("required parameter %q is not declared", l))
+		}
+	}
+	if f.pipeArgument != "" {
+		if _, ok := f.parameters[f.pipeArgument]; !ok {
+			errs = append(errs, fmt.Sprintf("pipe parameter %q is not declared", f.pipeArgument))
+		}
+	}
+	for l, pt := range f.parameters {
+		if pt == nil {
+			errs = append(errs, fmt.Sprintf("parameter %q has no type", l))
+		}
+	}
+	if f.ret == nil {
+		errs = append(errs, "missing return type")
+	}
+	if len(errs) > 0 {
+		sort.Strings(errs)
+		return fmt.Errorf("invalid function signature: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
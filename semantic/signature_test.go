@@ -0,0 +1,144 @@
+package semantic_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/flux/semantic"
+)
+
+func TestSignatureBuilder(t *testing.T) {
+	testCases := []struct {
+		name    string
+		builder *semantic.SignatureBuilder
+		want    semantic.FunctionPolySignature
+		wantErr string
+	}{
+		{
+			name: "pipe, required and optional",
+			builder: semantic.NewSignatureBuilder().
+				Pipe("tables", semantic.Object).
+				Required("column", semantic.String).
+				Optional("n", semantic.Int).
+				Return(semantic.Object),
+			want: semantic.FunctionPolySignature{
+				Parameters: map[string]semantic.PolyType{
+					"tables": semantic.Object,
+					"column": semantic.String,
+					"n":      semantic.Int,
+				},
+				Required:     semantic.LabelSet{"tables", "column"},
+				Return:       semantic.Object,
+				PipeArgument: "tables",
+			},
+		},
+		{
+			name: "return type bound by a parameter",
+			builder: semantic.NewSignatureBuilder().
+				Required("v", semantic.Tvar(1)).
+				Return(semantic.Tvar(1)),
+			want: semantic.FunctionPolySignature{
+				Parameters: map[string]semantic.PolyType{
+					"v": semantic.Tvar(1),
+				},
+				Required: semantic.LabelSet{"v"},
+				Return:   semantic.Tvar(1),
+			},
+		},
+		{
+			name: "duplicate parameter",
+			builder: semantic.NewSignatureBuilder().
+				Required("v", semantic.String).
+				Optional("v", semantic.Int).
+				Return(semantic.String),
+			wantErr: `parameter "v" declared twice`,
+		},
+		{
+			name: "two pipe parameters",
+			builder: semantic.NewSignatureBuilder().
+				Pipe("a", semantic.Object).
+				Pipe("b", semantic.Object).
+				Return(semantic.Object),
+			wantErr: `pipe parameter declared twice, "a" and "b"`,
+		},
+		{
+			name: "parameter without type",
+			builder: semantic.NewSignatureBuilder().
+				Required("v", nil).
+				Return(semantic.String),
+			wantErr: `parameter "v" has no type`,
+		},
+		{
+			name:    "missing return",
+			builder: semantic.NewSignatureBuilder().Required("v", semantic.String),
+			wantErr: "missing return type",
+		},
+		{
+			name: "unbound return type variable",
+			builder: semantic.NewSignatureBuilder().
+				Required("v", semantic.Tvar(1)).
+				Return(semantic.Tvar(2)),
+			wantErr: "return type t2 has type variables [t2] that no parameter binds",
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tc.builder.Build()
+			if tc.wantErr != "" {
+				if err == nil {
+					t.Fatalf("expected error %q", tc.wantErr)
+				}
+				if !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("unexpected error: want %q in %q", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !cmp.Equal(tc.want, got) {
+				t.Errorf("unexpected signature -want/+got\n%s", cmp.Diff(tc.want, got))
+			}
+		})
+	}
+}
+
+func TestSignatureBuilder_MustBuildPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected panic")
+		}
+	}()
+	semantic.NewSignatureBuilder().MustBuild()
+}
+
+func TestValidateFunctionPolyType(t *testing.T) {
+	valid := semantic.NewFunctionPolyType(semantic.FunctionPolySignature{
+		Parameters: map[string]semantic.PolyType{
+			"tables": semantic.Object,
+		},
+		Required:     semantic.LabelSet{"tables"},
+		Return:       semantic.Object,
+		PipeArgument: "tables",
+	})
+	if err := semantic.ValidateFunctionPolyType(valid); err != nil {
+		t.Fatal(err)
+	}
+
+	invalid := semantic.NewFunctionPolyType(semantic.FunctionPolySignature{
+		Parameters:   map[string]semantic.PolyType{},
+		Required:     semantic.LabelSet{"column"},
+		Return:       semantic.Object,
+		PipeArgument: "tables",
+	})
+	err := semantic.ValidateFunctionPolyType(invalid)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	want := `invalid function signature: pipe parameter "tables" is not declared; required parameter "column" is not declared`
+	if err.Error() != want {
+		t.Errorf("unexpected error: want %q got %q", want, err)
+	}
+}
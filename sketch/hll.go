@@ -0,0 +1,243 @@
+package sketch
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+	"sort"
+
+	"github.com/cespare/xxhash"
+)
+
+const (
+	// MinPrecision and MaxPrecision bound the precision of a HyperLogLog sketch.
+	MinPrecision = 4
+	MaxPrecision = 18
+	// DefaultPrecision is the default precision of a HyperLogLog sketch,
+	// its standard error is about 0.8%.
+	DefaultPrecision = 14
+)
+
+// linearCountingThresholds are the cardinalities below which linear counting
+// is more accurate than the raw HyperLogLog estimate, indexed by precision - MinPrecision.
+// They come from the HyperLogLog++ paper by Heule, Nunkesser and Hall.
+var linearCountingThresholds = [...]float64{
+	10, 20, 40, 80, 220, 400, 900, 1800, 3100, 6500, 11500, 20000, 50000, 120000, 350000,
+}
+
+// The formats of the registers of a serialized HyperLogLog sketch.
+const (
+	sparseFormat byte = 0
+	denseFormat  byte = 1
+)
+
+// HyperLogLog estimates the number of distinct values it has seen.
+//
+// It follows HyperLogLog++: values are hashed to 64 bits, small cardinalities are
+// estimated with linear counting and the registers are kept in a sparse representation
+// until enough of them are set. The empirical bias correction of HyperLogLog++ is not applied.
+type HyperLogLog struct {
+	precision uint8
+	// sparse holds the non zero registers while there are few of them, dense is nil until then.
+	sparse map[uint32]uint8
+	dense  []uint8
+}
+
+// NewHyperLogLog creates an empty HyperLogLog sketch with 2^precision registers.
+func NewHyperLogLog(precision int) (*HyperLogLog, error) {
+	if precision < MinPrecision || precision > MaxPrecision {
+		return nil, fmt.Errorf("HyperLogLog precision must be between %d and %d, got %d", MinPrecision, MaxPrecision, precision)
+	}
+	return &HyperLogLog{
+		precision: uint8(precision),
+		sparse:    make(map[uint32]uint8),
+	}, nil
+}
+
+func (h *HyperLogLog) Kind() Kind {
+	return HyperLogLogKind
+}
+
+// Precision reports the precision of the sketch.
+func (h *HyperLogLog) Precision() int {
+	return int(h.precision)
+}
+
+func (h *HyperLogLog) m() int {
+	return 1 << h.precision
+}
+
+// Add adds a value to the sketch.
+func (h *HyperLogLog) Add(v []byte) {
+	h.AddHash(xxhash.Sum64(v))
+}
+
+// AddHash adds a value to the sketch by its 64 bit hash.
+func (h *HyperLogLog) AddHash(x uint64) {
+	idx := uint32(x >> (64 - h.precision))
+	// The guard bit bounds the rank when the remaining bits are all zero.
+	w := x<<h.precision | 1<<(h.precision-1)
+	h.set(idx, uint8(bits.LeadingZeros64(w)+1))
+}
+
+// set raises the register idx to rho.
+func (h *HyperLogLog) set(idx uint32, rho uint8) {
+	if h.dense != nil {
+		if rho > h.dense[idx] {
+			h.dense[idx] = rho
+		}
+		return
+	}
+	if rho > h.sparse[idx] {
+		h.sparse[idx] = rho
+		// A map entry takes several times the size of a dense register.
+		if len(h.sparse) > h.m()/16 {
+			h.toDense()
+		}
+	}
+}
+
+func (h *HyperLogLog) toDense() {
+	h.dense = make([]uint8, h.m())
+	for idx, rho := range h.sparse {
+		h.dense[idx] = rho
+	}
+	h.sparse = nil
+}
+
+// Merge adds the values of another HyperLogLog sketch with the same precision.
+func (h *HyperLogLog) Merge(o Sketch) error {
+	other, ok := o.(*HyperLogLog)
+	if !ok {
+		return mergeError(h.Kind(), o)
+	}
+	if other.precision != h.precision {
+		return fmt.Errorf("cannot merge HyperLogLog sketches of precision %d and %d", other.precision, h.precision)
+	}
+	if other.dense != nil {
+		for idx, rho := range other.dense {
+			if rho > 0 {
+				h.set(uint32(idx), rho)
+			}
+		}
+		return nil
+	}
+	for idx, rho := range other.sparse {
+		h.set(idx, rho)
+	}
+	return nil
+}
+
+// Estimate returns the estimated number of distinct values added to the sketch.
+func (h *HyperLogLog) Estimate() uint64 {
+	m := float64(h.m())
+	var sum float64
+	zeros := 0
+	if h.dense != nil {
+		for _, rho := range h.dense {
+			if rho == 0 {
+				zeros++
+			}
+			sum += 1 / float64(uint64(1)<<rho)
+		}
+	} else {
+		zeros = h.m() - len(h.sparse)
+		sum = float64(zeros)
+		for _, rho := range h.sparse {
+			sum += 1 / float64(uint64(1)<<rho)
+		}
+	}
+
+	e := alpha(h.m()) * m * m / sum
+	if zeros > 0 {
+		lc := m * math.Log(m/float64(zeros))
+		if lc <= linearCountingThresholds[h.precision-MinPrecision] {
+			return uint64(math.Round(lc))
+		}
+	}
+	return uint64(math.Round(e))
+}
+
+// alpha is the bias correction constant of the raw estimate for m registers.
+func alpha(m int) float64 {
+	switch m {
+	case 16:
+		return 0.673
+	case 32:
+		return 0.697
+	case 64:
+		return 0.709
+	default:
+		return 0.7213 / (1 + 1.079/float64(m))
+	}
+}
+
+// MarshalBinary serializes the sketch.
+func (h *HyperLogLog) MarshalBinary() ([]byte, error) {
+	if h.dense != nil {
+		data := make([]byte, 0, 3+len(h.dense))
+		data = append(data, hyperLogLogTag, h.precision, denseFormat)
+		return append(data, h.dense...), nil
+	}
+
+	idxs := make([]uint32, 0, len(h.sparse))
+	for idx := range h.sparse {
+		idxs = append(idxs, idx)
+	}
+	sort.Slice(idxs, func(i, j int) bool { return idxs[i] < idxs[j] })
+
+	data := make([]byte, 7, 7+5*len(idxs))
+	data[0], data[1], data[2] = hyperLogLogTag, h.precision, sparseFormat
+	binary.LittleEndian.PutUint32(data[3:], uint32(len(idxs)))
+	var b [4]byte
+	for _, idx := range idxs {
+		binary.LittleEndian.PutUint32(b[:], idx)
+		data = append(data, b[:]...)
+		data = append(data, h.sparse[idx])
+	}
+	return data, nil
+}
+
+// UnmarshalBinary deserializes a sketch serialized with MarshalBinary.
+func (h *HyperLogLog) UnmarshalBinary(data []byte) error {
+	if len(data) < 3 || data[0] != hyperLogLogTag {
+		return errors.New("invalid HyperLogLog sketch")
+	}
+	precision := int(data[1])
+	if precision < MinPrecision || precision > MaxPrecision {
+		return fmt.Errorf("invalid HyperLogLog sketch precision %d", precision)
+	}
+	format := data[2]
+	*h = HyperLogLog{precision: uint8(precision)}
+	data = data[3:]
+
+	switch format {
+	case denseFormat:
+		if len(data) != h.m() {
+			return errors.New("invalid HyperLogLog sketch: wrong number of registers")
+		}
+		h.dense = append([]uint8(nil), data...)
+	case sparseFormat:
+		if len(data) < 4 {
+			return errors.New("invalid HyperLogLog sketch: missing register count")
+		}
+		n := int(binary.LittleEndian.Uint32(data))
+		data = data[4:]
+		if len(data) != 5*n {
+			return errors.New("invalid HyperLogLog sketch: wrong number of registers")
+		}
+		h.sparse = make(map[uint32]uint8, n)
+		for i := 0; i < n; i++ {
+			idx := binary.LittleEndian.Uint32(data[5*i:])
+			if int(idx) >= h.m() {
+				return fmt.Errorf("invalid HyperLogLog sketch register %d", idx)
+			}
+			h.sparse[idx] = data[5*i+4]
+		}
+	default:
+		return fmt.Errorf("invalid HyperLogLog sketch format %d", format)
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package sketch_test
+
+import (
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/influxdata/flux/sketch"
+)
+
+func TestHyperLogLog_Estimate(t *testing.T) {
+	for _, n := range []int{0, 1, 10, 100, 1000, 10000, 100000, 1000000} {
+		h, err := sketch.NewHyperLogLog(sketch.DefaultPrecision)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := 0; i < n; i++ {
+			// Every value is added twice.
+			h.Add([]byte(strconv.Itoa(i)))
+			h.Add([]byte(strconv.Itoa(i)))
+		}
+		got := float64(h.Estimate())
+		// Allow four standard errors of 1.04/sqrt(m).
+		tolerance := 4 * 1.04 / math.Sqrt(1<<sketch.DefaultPrecision)
+		if n < 1000 {
+			// Linear counting is almost exact for small cardinalities.
+			tolerance = 0.01
+		}
+		if math.Abs(got-float64(n)) > tolerance*float64(n) {
+			t.Errorf("unexpected estimate of %d distinct values: got %v", n, got)
+		}
+	}
+}
+
+func TestHyperLogLog_Precision(t *testing.T) {
+	for _, p := range []int{sketch.MinPrecision - 1, sketch.MaxPrecision + 1} {
+		if _, err := sketch.NewHyperLogLog(p); err == nil {
+			t.Errorf("expected an error for precision %d", p)
+		}
+	}
+}
+
+func TestHyperLogLog_Merge(t *testing.T) {
+	for _, n := range []int{100, 100000} {
+		a, _ := sketch.NewHyperLogLog(12)
+		b, _ := sketch.NewHyperLogLog(12)
+		all, _ := sketch.NewHyperLogLog(12)
+		for i := 0; i < n; i++ {
+			v := []byte(strconv.Itoa(i))
+			// The sketches overlap by half.
+			if i < 3*n/4 {
+				a.Add(v)
+			}
+			if i >= n/4 {
+				b.Add(v)
+			}
+			all.Add(v)
+		}
+		if err := a.Merge(b); err != nil {
+			t.Fatal(err)
+		}
+		if got, want := a.Estimate(), all.Estimate(); got != want {
+			t.Errorf("unexpected estimate of merged sketches of %d values: got %d want %d", n, got, want)
+		}
+	}
+
+	a, _ := sketch.NewHyperLogLog(12)
+	b, _ := sketch.NewHyperLogLog(13)
+	if err := a.Merge(b); err == nil {
+		t.Error("expected an error merging sketches of different precisions")
+	}
+	if err := a.Merge(sketch.NewTDigest(100)); err == nil {
+		t.Error("expected an error merging a t-digest")
+	}
+}
+
+func TestHyperLogLog_Marshal(t *testing.T) {
+	for _, n := range []int{0, 10, 100000} {
+		h, _ := sketch.NewHyperLogLog(10)
+		for i := 0; i < n; i++ {
+			h.Add([]byte(strconv.Itoa(i)))
+		}
+		data, err := h.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		s, err := sketch.Unmarshal(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, ok := s.(*sketch.HyperLogLog)
+		if !ok {
+			t.Fatalf("unexpected sketch type %T", s)
+		}
+		if got.Precision() != 10 || got.Estimate() != h.Estimate() {
+			t.Errorf("unexpected unmarshaled sketch of %d values: precision %d estimate %d, want estimate %d", n, got.Precision(), got.Estimate(), h.Estimate())
+		}
+	}
+
+	if _, err := sketch.Unmarshal([]byte{1, 10, 1, 0}); err == nil {
+		t.Error("expected an error unmarshaling a truncated sketch")
+	}
+}
@@ -0,0 +1,66 @@
+// Package sketch implements mergeable summaries of the values of a column.
+//
+// A sketch summarizes values in a bounded amount of memory so that it can be computed
+// in parts, for example by a storage engine for every shard of a read,
+// and then merged into the summary of all the values.
+// Sketches are serialized with MarshalBinary and deserialized with Unmarshal.
+package sketch
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+)
+
+// Kind identifies the type of a sketch.
+type Kind string
+
+const (
+	// HyperLogLogKind is the kind of the HyperLogLog sketch, which estimates the number of distinct values.
+	HyperLogLogKind Kind = "hll"
+	// TDigestKind is the kind of the t-digest sketch, which estimates the quantiles of values.
+	TDigestKind Kind = "tdigest"
+)
+
+// The first byte of a serialized sketch identifies its kind.
+const (
+	hyperLogLogTag byte = 1
+	tdigestTag     byte = 2
+)
+
+// Sketch is a mergeable summary of values.
+type Sketch interface {
+	encoding.BinaryMarshaler
+	// Kind reports the kind of the sketch.
+	Kind() Kind
+	// Merge adds the values summarized by another sketch of the same kind to the sketch.
+	Merge(o Sketch) error
+}
+
+// Unmarshal deserializes a sketch serialized with MarshalBinary.
+func Unmarshal(data []byte) (Sketch, error) {
+	if len(data) == 0 {
+		return nil, errors.New("empty sketch")
+	}
+	var s interface {
+		Sketch
+		encoding.BinaryUnmarshaler
+	}
+	switch data[0] {
+	case hyperLogLogTag:
+		s = new(HyperLogLog)
+	case tdigestTag:
+		s = new(TDigest)
+	default:
+		return nil, fmt.Errorf("unknown sketch tag %d", data[0])
+	}
+	if err := s.UnmarshalBinary(data); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// mergeError returns the error of merging a sketch of the wrong kind into a sketch of the given kind.
+func mergeError(k Kind, o Sketch) error {
+	return fmt.Errorf("cannot merge a %s sketch into a %s sketch", o.Kind(), k)
+}
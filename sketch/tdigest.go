@@ -0,0 +1,318 @@
+package sketch
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"sort"
+
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/influxdata/tdigest"
+)
+
+// DefaultCompression is the default compression of a t-digest sketch.
+const DefaultCompression = 1000
+
+// TDigest estimates the quantiles of the values it has seen.
+//
+// It implements the merging t-digest of github.com/influxdata/tdigest and produces the same
+// estimates, but it exposes its centroids so that it can be merged and serialized.
+type TDigest struct {
+	compression float64
+
+	maxProcessed      int
+	maxUnprocessed    int
+	processed         tdigest.CentroidList
+	unprocessed       tdigest.CentroidList
+	cumulative        []float64
+	processedWeight   float64
+	unprocessedWeight float64
+	min               float64
+	max               float64
+}
+
+// NewTDigest creates an empty t-digest sketch with the given compression.
+func NewTDigest(compression float64) *TDigest {
+	t := &TDigest{
+		compression:    compression,
+		maxProcessed:   int(2 * math.Ceil(compression)),
+		maxUnprocessed: int(8 * math.Ceil(compression)),
+		min:            math.MaxFloat64,
+		max:            -math.MaxFloat64,
+	}
+	t.processed = make(tdigest.CentroidList, 0, t.maxProcessed)
+	t.unprocessed = make(tdigest.CentroidList, 0, t.maxUnprocessed+1)
+	return t
+}
+
+func (t *TDigest) Kind() Kind {
+	return TDigestKind
+}
+
+// Compression reports the compression of the sketch.
+func (t *TDigest) Compression() float64 {
+	return t.compression
+}
+
+// Add adds a value with a weight to the sketch, NaN values are ignored.
+func (t *TDigest) Add(x, w float64) {
+	if math.IsNaN(x) {
+		return
+	}
+	t.addCentroid(tdigest.Centroid{Mean: x, Weight: w})
+}
+
+// AddFloats adds the valid values of an array to the sketch with a weight of 1, NaN values are ignored.
+// It reads the values directly from the buffer of the array, and is equivalent to
+// adding them one by one with Add.
+func (t *TDigest) AddFloats(vs *array.Float64) {
+	values := vs.Float64Values()
+	checkNulls := vs.NullN() > 0
+	for i, x := range values {
+		if checkNulls && vs.IsNull(i) || math.IsNaN(x) {
+			continue
+		}
+		t.unprocessed = append(t.unprocessed, tdigest.Centroid{Mean: x, Weight: 1})
+		t.unprocessedWeight++
+		if t.processed.Len() > t.maxProcessed ||
+			t.unprocessed.Len() > t.maxUnprocessed {
+			t.process()
+		}
+	}
+}
+
+func (t *TDigest) addCentroid(c tdigest.Centroid) {
+	t.unprocessed = append(t.unprocessed, c)
+	t.unprocessedWeight += c.Weight
+
+	if t.processed.Len() > t.maxProcessed ||
+		t.unprocessed.Len() > t.maxUnprocessed {
+		t.process()
+	}
+}
+
+// Centroids returns the centroids of the sketch, sorted by mean.
+func (t *TDigest) Centroids() tdigest.CentroidList {
+	t.process()
+	return append(tdigest.CentroidList(nil), t.processed...)
+}
+
+// Count returns the total weight of the values of the sketch.
+func (t *TDigest) Count() float64 {
+	return t.processedWeight + t.unprocessedWeight
+}
+
+// MergeBinary adds the values of a t-digest sketch serialized with MarshalBinary to the sketch.
+// It reads the centroids directly from the serialized sketch, and is equivalent to
+// merging the sketch returned by Unmarshal.
+func (t *TDigest) MergeBinary(data []byte) error {
+	_, min, max, centroids, err := readTDigest(data)
+	if err != nil {
+		return err
+	}
+	n := len(centroids) / 16
+	for i := 0; i < n; i++ {
+		t.addCentroid(tdigest.Centroid{
+			Mean:   readFloat64(centroids[16*i:]),
+			Weight: readFloat64(centroids[16*i+8:]),
+		})
+	}
+	if n > 0 {
+		t.process()
+		t.min = math.Min(t.min, min)
+		t.max = math.Max(t.max, max)
+	}
+	return nil
+}
+
+// Merge adds the values of another t-digest sketch to the sketch.
+func (t *TDigest) Merge(o Sketch) error {
+	other, ok := o.(*TDigest)
+	if !ok {
+		return mergeError(t.Kind(), o)
+	}
+	for _, c := range other.Centroids() {
+		t.addCentroid(c)
+	}
+	// The centroids of the other sketch may not reach its extreme values.
+	if other.Count() > 0 {
+		t.process()
+		t.min = math.Min(t.min, other.min)
+		t.max = math.Max(t.max, other.max)
+	}
+	return nil
+}
+
+func (t *TDigest) process() {
+	if t.unprocessed.Len() > 0 ||
+		t.processed.Len() > t.maxProcessed {
+
+		// Append all processed centroids to the unprocessed list and sort
+		t.unprocessed = append(t.unprocessed, t.processed...)
+		sort.Sort(&t.unprocessed)
+
+		// Reset processed list with first centroid
+		t.processed.Clear()
+		t.processed = append(t.processed, t.unprocessed[0])
+
+		t.processedWeight += t.unprocessedWeight
+		t.unprocessedWeight = 0
+		soFar := t.unprocessed[0].Weight
+		limit := t.processedWeight * t.integratedQ(1.0)
+		for _, centroid := range t.unprocessed[1:] {
+			projected := soFar + centroid.Weight
+			if projected <= limit {
+				soFar = projected
+				(&t.processed[t.processed.Len()-1]).Add(centroid)
+			} else {
+				k1 := t.integratedLocation(soFar / t.processedWeight)
+				limit = t.processedWeight * t.integratedQ(k1+1.0)
+				soFar += centroid.Weight
+				t.processed = append(t.processed, centroid)
+			}
+		}
+		t.min = math.Min(t.min, t.processed[0].Mean)
+		t.max = math.Max(t.max, t.processed[t.processed.Len()-1].Mean)
+		t.updateCumulative()
+		t.unprocessed.Clear()
+	}
+}
+
+func (t *TDigest) updateCumulative() {
+	t.cumulative = make([]float64, t.processed.Len()+1)
+	prev := 0.0
+	for i, centroid := range t.processed {
+		cur := centroid.Weight
+		t.cumulative[i] = prev + cur/2.0
+		prev = prev + cur
+	}
+	t.cumulative[t.processed.Len()] = prev
+}
+
+// Quantile returns the estimated value at quantile q, or NaN if the sketch is empty.
+func (t *TDigest) Quantile(q float64) float64 {
+	t.process()
+	if q < 0 || q > 1 || t.processed.Len() == 0 {
+		return math.NaN()
+	}
+	if t.processed.Len() == 1 {
+		return t.processed[0].Mean
+	}
+	index := q * t.processedWeight
+	if index <= t.processed[0].Weight/2.0 {
+		return t.min + 2.0*index/t.processed[0].Weight*(t.processed[0].Mean-t.min)
+	}
+
+	lower := sort.Search(len(t.cumulative), func(i int) bool {
+		return t.cumulative[i] >= index
+	})
+
+	if lower+1 != len(t.cumulative) {
+		z1 := index - t.cumulative[lower-1]
+		z2 := t.cumulative[lower] - index
+		return weightedAverage(t.processed[lower-1].Mean, z2, t.processed[lower].Mean, z1)
+	}
+
+	z1 := index - t.processedWeight - t.processed[lower-1].Weight/2.0
+	z2 := (t.processed[lower-1].Weight / 2.0) - z1
+	return weightedAverage(t.processed[t.processed.Len()-1].Mean, z1, t.max, z2)
+}
+
+func (t *TDigest) integratedQ(k float64) float64 {
+	return (math.Sin(math.Min(k, t.compression)*math.Pi/t.compression-math.Pi/2.0) + 1.0) / 2.0
+}
+
+func (t *TDigest) integratedLocation(q float64) float64 {
+	return t.compression * (math.Asin(2.0*q-1.0) + math.Pi/2.0) / math.Pi
+}
+
+func weightedAverage(x1, w1, x2, w2 float64) float64 {
+	if x1 <= x2 {
+		return weightedAverageSorted(x1, w1, x2, w2)
+	}
+	return weightedAverageSorted(x2, w2, x1, w1)
+}
+
+func weightedAverageSorted(x1, w1, x2, w2 float64) float64 {
+	x := (x1*w1 + x2*w2) / (w1 + w2)
+	return math.Max(x1, math.Min(x, x2))
+}
+
+// tdigestHeaderLen is the length of the tag, compression, min, max and number of centroids of a serialized t-digest.
+const tdigestHeaderLen = 1 + 8 + 8 + 8 + 4
+
+// MarshalBinary serializes the sketch.
+func (t *TDigest) MarshalBinary() ([]byte, error) {
+	centroids := t.Centroids()
+	data := make([]byte, 1, tdigestHeaderLen+16*len(centroids))
+	data[0] = tdigestTag
+	data = appendFloat64(data, t.compression)
+	data = appendFloat64(data, t.min)
+	data = appendFloat64(data, t.max)
+	var n [4]byte
+	binary.LittleEndian.PutUint32(n[:], uint32(len(centroids)))
+	data = append(data, n[:]...)
+	for _, c := range centroids {
+		data = appendFloat64(data, c.Mean)
+		data = appendFloat64(data, c.Weight)
+	}
+	return data, nil
+}
+
+// UnmarshalBinary deserializes a sketch serialized with MarshalBinary.
+func (t *TDigest) UnmarshalBinary(data []byte) error {
+	compression, min, max, data, err := readTDigest(data)
+	if err != nil {
+		return err
+	}
+	n := len(data) / 16
+
+	*t = *NewTDigest(compression)
+	// The centroids were serialized in order once processed, they are restored as they were.
+	for i := 0; i < n; i++ {
+		c := tdigest.Centroid{
+			Mean:   readFloat64(data[16*i:]),
+			Weight: readFloat64(data[16*i+8:]),
+		}
+		if i > 0 && c.Mean < t.processed[i-1].Mean {
+			return errors.New("invalid t-digest sketch: centroids are not sorted")
+		}
+		t.processed = append(t.processed, c)
+		t.processedWeight += c.Weight
+	}
+	if n > 0 {
+		t.min, t.max = min, max
+		t.updateCumulative()
+	}
+	return nil
+}
+
+// readTDigest reads the header of a serialized t-digest sketch,
+// and returns the serialized centroids that follow it.
+func readTDigest(data []byte) (compression, min, max float64, centroids []byte, err error) {
+	if len(data) < tdigestHeaderLen || data[0] != tdigestTag {
+		return 0, 0, 0, nil, errors.New("invalid t-digest sketch")
+	}
+	data = data[1:]
+	compression = readFloat64(data[0:])
+	if !(compression > 0) {
+		return 0, 0, 0, nil, errors.New("invalid t-digest sketch compression")
+	}
+	min, max = readFloat64(data[8:]), readFloat64(data[16:])
+	n := int(binary.LittleEndian.Uint32(data[24:]))
+	centroids = data[28:]
+	if len(centroids) != 16*n {
+		return 0, 0, 0, nil, errors.New("invalid t-digest sketch: wrong number of centroids")
+	}
+	return compression, min, max, centroids, nil
+}
+
+func appendFloat64(data []byte, v float64) []byte {
+	var b [8]byte
+	binary.LittleEndian.PutUint64(b[:], math.Float64bits(v))
+	return append(data, b[:]...)
+}
+
+func readFloat64(data []byte) float64 {
+	return math.Float64frombits(binary.LittleEndian.Uint64(data))
+}
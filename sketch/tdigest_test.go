@@ -0,0 +1,159 @@
+package sketch_test
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/influxdata/flux/arrow"
+	"github.com/influxdata/flux/sketch"
+	"github.com/influxdata/tdigest"
+)
+
+var quantiles = []float64{0, 0.01, 0.1, 0.25, 0.5, 0.75, 0.9, 0.99, 1}
+
+func TestTDigest_Quantile(t *testing.T) {
+	// The sketch produces the same estimates as the digest it is based on.
+	r := rand.New(rand.NewSource(1))
+	for _, compression := range []float64{10, 100, 1000} {
+		s := sketch.NewTDigest(compression)
+		d := tdigest.NewWithCompression(compression)
+		for i := 0; i < 100000; i++ {
+			v := r.NormFloat64()
+			s.Add(v, 1)
+			d.Add(v, 1)
+		}
+		for _, q := range quantiles {
+			if got, want := s.Quantile(q), d.Quantile(q); got != want {
+				t.Errorf("unexpected quantile %v with compression %v: got %v want %v", q, compression, got, want)
+			}
+		}
+	}
+
+	if q := sketch.NewTDigest(100).Quantile(0.5); !math.IsNaN(q) {
+		t.Errorf("unexpected quantile of an empty sketch: %v", q)
+	}
+}
+
+func TestTDigest_AddFloats(t *testing.T) {
+	// Adding an array is equivalent to adding its valid values one by one.
+	r := rand.New(rand.NewSource(1))
+	b := arrow.NewFloatBuilder(nil)
+	s := sketch.NewTDigest(100)
+	want := sketch.NewTDigest(100)
+	for i := 0; i < 10; i++ {
+		for j := 0; j < 10000; j++ {
+			switch v := r.NormFloat64(); {
+			case j%100 == 0:
+				b.AppendNull()
+			case j%101 == 0:
+				b.Append(math.NaN())
+			default:
+				b.Append(v)
+				want.Add(v, 1)
+			}
+		}
+		vs := b.NewFloat64Array()
+		s.AddFloats(vs)
+		vs.Release()
+	}
+	if got, want := s.Count(), want.Count(); got != want {
+		t.Errorf("unexpected count: got %v want %v", got, want)
+	}
+	for _, q := range quantiles {
+		if got, want := s.Quantile(q), want.Quantile(q); got != want {
+			t.Errorf("unexpected quantile %v: got %v want %v", q, got, want)
+		}
+	}
+}
+
+func TestTDigest_Merge(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	parts := make([]*sketch.TDigest, 4)
+	for i := range parts {
+		parts[i] = sketch.NewTDigest(sketch.DefaultCompression)
+	}
+	all := sketch.NewTDigest(sketch.DefaultCompression)
+	for i := 0; i < 100000; i++ {
+		v := r.Float64()
+		// The parts have different ranges of values.
+		parts[int(v*4)].Add(v, 1)
+		all.Add(v, 1)
+	}
+
+	merged := sketch.NewTDigest(sketch.DefaultCompression)
+	for _, p := range parts {
+		if err := merged.Merge(p); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got, want := merged.Count(), all.Count(); got != want {
+		t.Errorf("unexpected count: got %v want %v", got, want)
+	}
+	for _, q := range quantiles {
+		// The values are uniform between 0 and 1.
+		if got, want := merged.Quantile(q), all.Quantile(q); math.Abs(got-want) > 0.001 {
+			t.Errorf("unexpected quantile %v of merged sketches: got %v want %v", q, got, want)
+		}
+	}
+
+	// Merging serialized sketches is equivalent to merging the sketches.
+	mergedBinary := sketch.NewTDigest(sketch.DefaultCompression)
+	for _, p := range parts {
+		data, err := p.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := mergedBinary.MergeBinary(data); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, q := range quantiles {
+		if got, want := mergedBinary.Quantile(q), merged.Quantile(q); got != want {
+			t.Errorf("unexpected quantile %v of merged serialized sketches: got %v want %v", q, got, want)
+		}
+	}
+	if err := mergedBinary.MergeBinary([]byte{2, 0}); err == nil {
+		t.Error("expected an error merging a truncated sketch")
+	}
+
+	h, _ := sketch.NewHyperLogLog(sketch.DefaultPrecision)
+	if err := merged.Merge(h); err == nil {
+		t.Error("expected an error merging a HyperLogLog sketch")
+	}
+}
+
+func TestTDigest_Marshal(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for _, n := range []int{0, 1, 10, 100000} {
+		s := sketch.NewTDigest(100)
+		for i := 0; i < n; i++ {
+			s.Add(r.ExpFloat64(), 1)
+		}
+		data, err := s.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		u, err := sketch.Unmarshal(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, ok := u.(*sketch.TDigest)
+		if !ok {
+			t.Fatalf("unexpected sketch type %T", u)
+		}
+		if got.Compression() != 100 || got.Count() != s.Count() {
+			t.Errorf("unexpected unmarshaled sketch of %d values: compression %v count %v", n, got.Compression(), got.Count())
+		}
+		for _, q := range quantiles {
+			want, got := s.Quantile(q), got.Quantile(q)
+			if got != want && !(math.IsNaN(got) && math.IsNaN(want)) {
+				t.Errorf("unexpected quantile %v of unmarshaled sketch of %d values: got %v want %v", q, n, got, want)
+			}
+		}
+	}
+
+	if _, err := sketch.Unmarshal([]byte{2, 0}); err == nil {
+		t.Error("expected an error unmarshaling a truncated sketch")
+	}
+}
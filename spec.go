@@ -13,6 +13,8 @@ type Spec struct {
 	Edges      []Edge             `json:"edges"`
 	Resources  ResourceManagement `json:"resources"`
 	Now        time.Time          `json:"now"`
+	// Warnings report the uses of deprecated builtins in the script.
+	Warnings []Warning `json:"warnings,omitempty"`
 
 	sorted   []*Operation
 	children map[OperationID][]*Operation
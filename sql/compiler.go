@@ -0,0 +1,36 @@
+package sql
+
+import (
+	"context"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/dependencies"
+)
+
+const CompilerType = "sql"
+
+// AddCompilerMappings adds the SQL compiler mapping.
+func AddCompilerMappings(mappings flux.CompilerMappings) error {
+	return mappings.Add(CompilerType, func() flux.Compiler {
+		return new(Compiler)
+	})
+}
+
+// Compiler compiles a SQL query into a spec.
+type Compiler struct {
+	Query string `json:"query"`
+	// Bucket is the bucket of the measurements that are not qualified with a bucket.
+	Bucket string `json:"bucket"`
+}
+
+func (c Compiler) Compile(ctx context.Context) (*flux.Spec, error) {
+	pkg, err := Transpile(c.Query, Config{DefaultBucket: c.Bucket})
+	if err != nil {
+		return nil, err
+	}
+	return flux.CompileAST(ctx, pkg, dependencies.Get(ctx).Now())
+}
+
+func (Compiler) CompilerType() flux.CompilerType {
+	return CompilerType
+}
@@ -0,0 +1,180 @@
+package sql
+
+import (
+	"github.com/influxdata/flux/internal/querylang"
+)
+
+// dialect describes the lexical elements of the supported subset of SQL.
+var dialect = &querylang.Dialect{
+	Keywords: map[string]bool{
+		"SELECT":    true,
+		"FROM":      true,
+		"WHERE":     true,
+		"GROUP":     true,
+		"ORDER":     true,
+		"BY":        true,
+		"ASC":       true,
+		"DESC":      true,
+		"LIMIT":     true,
+		"OFFSET":    true,
+		"AS":        true,
+		"AND":       true,
+		"OR":        true,
+		"NOT":       true,
+		"TRUE":      true,
+		"FALSE":     true,
+		"INTERVAL":  true,
+		"TIMESTAMP": true,
+	},
+	LineComment:   "--",
+	DoubledQuotes: true,
+}
+
+// selectStatement is a parsed SQL SELECT statement.
+type selectStatement struct {
+	fields []*querylang.Field
+	// bucket is the qualifier of the measurement in the FROM clause, empty if there is none.
+	bucket      string
+	measurement string
+	// condition is the WHERE clause, nil if there is none.
+	condition querylang.Expr
+	groupBy   []string
+	orderBy   []orderTerm
+	limit     int
+	offset    int
+}
+
+// orderTerm is a column of the ORDER BY clause.
+type orderTerm struct {
+	column string
+	desc   bool
+}
+
+// parser parses SQL SELECT statements.
+type parser struct {
+	*querylang.Parser
+}
+
+// parseQuery parses the SELECT statements of a query, separated by semicolons.
+func parseQuery(query string) ([]*selectStatement, error) {
+	p := &parser{Parser: querylang.NewParser(query, dialect)}
+	var stmts []*selectStatement
+	err := p.ParseStatements(func() error {
+		stmt, err := p.parseSelect()
+		if err != nil {
+			return err
+		}
+		stmts = append(stmts, stmt)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return stmts, nil
+}
+
+func (p *parser) parseSelect() (*selectStatement, error) {
+	if _, err := p.Expect(querylang.KEYWORD, "SELECT"); err != nil {
+		return nil, err
+	}
+	stmt := &selectStatement{}
+	var err error
+	if stmt.fields, err = p.ParseFields(); err != nil {
+		return nil, err
+	}
+	if _, err := p.Expect(querylang.KEYWORD, "FROM"); err != nil {
+		return nil, err
+	}
+	if err := p.parseFrom(stmt); err != nil {
+		return nil, err
+	}
+	if ok, err := p.Accept("WHERE"); err != nil {
+		return nil, err
+	} else if ok {
+		if stmt.condition, err = p.ParseExpr(); err != nil {
+			return nil, err
+		}
+	}
+	if ok, err := p.Accept("GROUP"); err != nil {
+		return nil, err
+	} else if ok {
+		if _, err := p.Expect(querylang.KEYWORD, "BY"); err != nil {
+			return nil, err
+		}
+		if stmt.groupBy, err = p.ParseIdentList(); err != nil {
+			return nil, err
+		}
+	}
+	if ok, err := p.Accept("ORDER"); err != nil {
+		return nil, err
+	} else if ok {
+		if err := p.parseOrderBy(stmt); err != nil {
+			return nil, err
+		}
+	}
+	if ok, err := p.Accept("LIMIT"); err != nil {
+		return nil, err
+	} else if ok {
+		if stmt.limit, err = p.ParseInt(); err != nil {
+			return nil, err
+		}
+	}
+	if ok, err := p.Accept("OFFSET"); err != nil {
+		return nil, err
+	} else if ok {
+		if stmt.offset, err = p.ParseInt(); err != nil {
+			return nil, err
+		}
+	}
+	return stmt, nil
+}
+
+// parseFrom parses the measurement of the FROM clause, optionally qualified with its bucket.
+func (p *parser) parseFrom(stmt *selectStatement) error {
+	it, err := p.Expect(querylang.IDENT, "")
+	if err != nil {
+		return err
+	}
+	stmt.measurement = it.Lit
+	dot, err := p.Next()
+	if err != nil {
+		return err
+	}
+	if dot.Tok != querylang.DOT {
+		p.Unscan()
+		return nil
+	}
+	it, err = p.Expect(querylang.IDENT, "")
+	if err != nil {
+		return err
+	}
+	stmt.bucket, stmt.measurement = stmt.measurement, it.Lit
+	return nil
+}
+
+func (p *parser) parseOrderBy(stmt *selectStatement) error {
+	if _, err := p.Expect(querylang.KEYWORD, "BY"); err != nil {
+		return err
+	}
+	for {
+		it, err := p.Expect(querylang.IDENT, "")
+		if err != nil {
+			return err
+		}
+		term := orderTerm{column: it.Lit}
+		if term.desc, err = p.Accept("DESC"); err != nil {
+			return err
+		} else if !term.desc {
+			if _, err := p.Accept("ASC"); err != nil {
+				return err
+			}
+		}
+		stmt.orderBy = append(stmt.orderBy, term)
+		if it, err := p.Next(); err != nil {
+			return err
+		} else if it.Tok != querylang.COMMA {
+			p.Unscan()
+			return nil
+		}
+	}
+}
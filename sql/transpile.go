@@ -0,0 +1,477 @@
+// Package sql is an experimental front-end that converts SQL queries into Flux,
+// so that the data of measurements can be queried with a language that most users already know.
+//
+// A pragmatic subset of SQL SELECT statements is supported: the columns of a SELECT clause are
+// columns of the measurement or calls to aggregate functions, the FROM clause is a single measurement
+// optionally qualified with its bucket, as in bucket.measurement, and the WHERE, GROUP BY, ORDER BY,
+// LIMIT and OFFSET clauses have their usual meaning.
+//
+// The rows of a measurement are its points: the columns are time, the tags and the fields of the points.
+// The conditions on the time in the WHERE clause must be combined with the rest of the clause with AND,
+// since they select the time range that is read.
+package sql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/influxdata/flux/ast"
+	"github.com/influxdata/flux/ast/astutil"
+	"github.com/influxdata/flux/internal/querylang"
+)
+
+// Config configures the transpilation of queries.
+type Config struct {
+	// DefaultBucket is the bucket of the measurements that are not qualified with a bucket.
+	DefaultBucket string
+}
+
+// Transpile converts a SQL query, made of one or more SELECT statements, into a Flux package.
+// The result of each statement is a single table, yielded with the index of the statement in the query as name.
+func Transpile(query string, c Config) (*ast.Package, error) {
+	stmts, err := parseQuery(query)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse SQL query: %v", err)
+	}
+	t := &transpiler{config: c}
+	file := &ast.File{}
+	for i, stmt := range stmts {
+		body, err := t.statement(stmt, i)
+		if err != nil {
+			return nil, fmt.Errorf("statement %d: %v", i, err)
+		}
+		file.Body = append(file.Body, body...)
+	}
+	return &ast.Package{
+		Package: "main",
+		Files:   []*ast.File{file},
+	}, nil
+}
+
+type transpiler struct {
+	config Config
+}
+
+// aggregate describes how a SQL aggregate function is computed by Flux.
+type aggregate struct {
+	// fn is the name of the Flux function.
+	fn string
+	// selector is set for functions that return one of the records of their input,
+	// which aggregate a single column.
+	selector bool
+}
+
+var aggregates = map[string]aggregate{
+	"count":  {fn: "count"},
+	"sum":    {fn: "sum"},
+	"avg":    {fn: "mean"},
+	"mean":   {fn: "mean"},
+	"stddev": {fn: "stddev"},
+	"spread": {fn: "spread"},
+	"min":    {fn: "min", selector: true},
+	"max":    {fn: "max", selector: true},
+	"first":  {fn: "first", selector: true},
+	"last":   {fn: "last", selector: true},
+}
+
+// selection is a column of the SELECT clause, checked and named.
+type selection struct {
+	// name is the name of the column in the result.
+	name string
+	// column is the column of the measurement that is selected, empty for all the columns.
+	column string
+	// fn is the name of the aggregate function applied to the column, empty for a column of the measurement.
+	fn string
+}
+
+// selections checks the columns of stmt and names them.
+// It reports whether the columns are aggregated.
+func (t *transpiler) selections(stmt *selectStatement) ([]selection, bool, error) {
+	var sels []selection
+	raw, aggregated := false, false
+	used := make(map[string]int)
+	for _, f := range stmt.fields {
+		var sel selection
+		switch e := f.Expr.(type) {
+		case *querylang.VarRef:
+			sel.column, sel.name = fluxColumn(e.Name), e.Name
+			raw = true
+		case *querylang.Wildcard:
+			if len(stmt.fields) > 1 {
+				return nil, false, fmt.Errorf("* cannot be selected with other columns")
+			}
+			if f.Alias != "" {
+				return nil, false, fmt.Errorf("cannot alias *")
+			}
+			if len(stmt.groupBy) > 0 {
+				return nil, false, fmt.Errorf("* cannot be selected with GROUP BY")
+			}
+			sels = append(sels, sel)
+			continue
+		case *querylang.Call:
+			agg, ok := aggregates[e.Name]
+			if !ok {
+				return nil, false, fmt.Errorf("unsupported function %s()", e.Name)
+			}
+			if len(e.Args) != 1 {
+				return nil, false, fmt.Errorf("invalid number of arguments for %s(), expected 1, got %d", e.Name, len(e.Args))
+			}
+			switch arg := e.Args[0].(type) {
+			case *querylang.VarRef:
+				sel.column = fluxColumn(arg.Name)
+			case *querylang.Wildcard:
+				if agg.fn != "count" {
+					return nil, false, fmt.Errorf("* can only be counted, found %s", e)
+				}
+				// Every point has a measurement, so counting the measurements counts the points.
+				sel.column = "_measurement"
+			default:
+				return nil, false, fmt.Errorf("unsupported argument %s of %s(), expected a column", e.Args[0], e.Name)
+			}
+			if sel.column == "_time" {
+				return nil, false, fmt.Errorf("cannot compute %s", e)
+			}
+			sel.name, sel.fn = e.Name, agg.fn
+			aggregated = true
+		default:
+			return nil, false, fmt.Errorf("unsupported column expression %s", f.Expr)
+		}
+		if f.Alias != "" {
+			sel.name = f.Alias
+		}
+		// Repeated names are made unique with a suffix.
+		if n := used[sel.name]; n > 0 {
+			used[sel.name]++
+			sel.name = fmt.Sprintf("%s_%d", sel.name, n)
+		} else {
+			used[sel.name]++
+		}
+		sels = append(sels, sel)
+	}
+
+	for _, col := range stmt.groupBy {
+		if fluxColumn(col) == "_time" {
+			return nil, false, fmt.Errorf("cannot group by %s", col)
+		}
+	}
+	if len(stmt.groupBy) > 0 && !aggregated {
+		return nil, false, fmt.Errorf("GROUP BY requires an aggregate function")
+	}
+	if raw && aggregated {
+		for _, sel := range sels {
+			if sel.fn == "" && !contains(stmt.groupBy, sel.column) {
+				return nil, false, fmt.Errorf("column %s must be in the GROUP BY clause or be aggregated", sel.column)
+			}
+		}
+	}
+	return sels, aggregated, nil
+}
+
+// statement returns the Flux statements of stmt, the last of which yields its result.
+func (t *transpiler) statement(stmt *selectStatement, index int) ([]ast.Statement, error) {
+	sels, aggregated, err := t.selections(stmt)
+	if err != nil {
+		return nil, err
+	}
+	tr, cond, err := querylang.SplitCondition(stmt.condition)
+	if err != nil {
+		return nil, err
+	}
+	bucket := stmt.bucket
+	if bucket == "" {
+		bucket = t.config.DefaultBucket
+	}
+	if bucket == "" {
+		return nil, fmt.Errorf("no bucket for measurement %s", stmt.measurement)
+	}
+
+	// The fields are pivoted into columns so that the points are rows, as in SQL.
+	p := &astutil.Pipeline{
+		Source: astutil.Call("from", astutil.Property("bucket", &ast.StringLiteral{Value: bucket})),
+		Stages: []*ast.CallExpression{
+			tr.Call(),
+			querylang.Filter(querylang.Compare(ast.EqualOperator, querylang.Column("_measurement"), &ast.StringLiteral{Value: stmt.measurement})),
+			astutil.Call("pivot",
+				astutil.Property("rowKey", querylang.StringArray("_time")),
+				astutil.Property("columnKey", querylang.StringArray("_field")),
+				astutil.Property("valueColumn", &ast.StringLiteral{Value: "_value"}),
+			),
+		},
+	}
+	if cond != nil {
+		fn, err := condition(cond)
+		if err != nil {
+			return nil, err
+		}
+		p.Stages = append(p.Stages, querylang.Filter(fn))
+	}
+
+	var body []ast.Statement
+	if aggregated {
+		group := astutil.Call("group")
+		if len(stmt.groupBy) > 0 {
+			group = astutil.Call("group", astutil.Property("columns", querylang.StringArray(stmt.groupBy...)))
+		}
+		p.Stages = append(p.Stages, group)
+		var assignment *ast.VariableAssignment
+		if p, assignment, err = aggregateSelections(stmt, sels, p, index); err != nil {
+			return nil, err
+		}
+		if assignment != nil {
+			body = append(body, assignment)
+		}
+	} else {
+		p.Stages = append(p.Stages, astutil.Call("group"))
+		if sels[0].column == "" {
+			p.Stages = append(p.Stages,
+				astutil.Call("drop", astutil.Property("columns", querylang.StringArray("_start", "_stop", "_measurement"))),
+				rename(map[string]string{"_time": querylang.TimeColumn}, []string{"_time"}),
+			)
+		} else {
+			var cols []string
+			for _, sel := range sels {
+				if !contains(cols, sel.column) {
+					cols = append(cols, sel.column)
+				}
+			}
+			p.Stages = append(p.Stages, astutil.Call("keep", astutil.Property("columns", querylang.StringArray(cols...))))
+			p.Stages = append(p.Stages, outputColumns(sels)...)
+		}
+	}
+
+	if len(stmt.orderBy) > 0 {
+		call, err := sortCall(stmt, sels)
+		if err != nil {
+			return nil, err
+		}
+		p.Stages = append(p.Stages, call)
+	}
+	if stmt.limit > 0 || stmt.offset > 0 {
+		props := []*ast.Property{astutil.Property("n", &ast.IntegerLiteral{Value: int64(stmt.limit)})}
+		if stmt.limit == 0 {
+			// An OFFSET without a LIMIT keeps all the remaining rows.
+			props[0].Value = &ast.IntegerLiteral{Value: 1<<63 - 1}
+		}
+		if stmt.offset > 0 {
+			props = append(props, astutil.Property("offset", &ast.IntegerLiteral{Value: int64(stmt.offset)}))
+		}
+		p.Stages = append(p.Stages, astutil.Call("limit", props...))
+	}
+	p.Stages = append(p.Stages, astutil.Call("yield", astutil.Property("name", &ast.StringLiteral{Value: strconv.Itoa(index)})))
+	return append(body, &ast.ExpressionStatement{Expression: p.Expression()}), nil
+}
+
+// aggregateCall is a call to a Flux function that computes some of the aggregated selections.
+type aggregateCall struct {
+	agg     aggregate
+	columns []string
+	sels    []selection
+}
+
+// aggregateSelections adds the stages that aggregate the grouped points of p.
+// Aggregate functions compute all of their columns in a single call, selectors need a call per column.
+// When several calls are needed, their results are joined on the grouped columns,
+// and the points are assigned to a variable that is returned.
+func aggregateSelections(stmt *selectStatement, sels []selection, p *astutil.Pipeline, index int) (*astutil.Pipeline, *ast.VariableAssignment, error) {
+	var calls []*aggregateCall
+	for _, sel := range sels {
+		if sel.fn == "" {
+			continue
+		}
+		agg := aggregates[sel.fn]
+		var c *aggregateCall
+		for _, prev := range calls {
+			if prev.agg.fn == sel.fn && (!agg.selector || prev.columns[0] == sel.column) {
+				c = prev
+				break
+			}
+		}
+		if c == nil {
+			c = &aggregateCall{agg: agg}
+			calls = append(calls, c)
+		}
+		if !contains(c.columns, sel.column) {
+			c.columns = append(c.columns, sel.column)
+		}
+		c.sels = append(c.sels, sel)
+	}
+
+	branch := func(source ast.Expression, c *aggregateCall) *astutil.Pipeline {
+		bp := astutil.Split(source)
+		if c.agg.selector {
+			bp.Stages = append(bp.Stages,
+				astutil.Call(c.agg.fn, astutil.Property("column", &ast.StringLiteral{Value: c.columns[0]})),
+				astutil.Call("keep", astutil.Property("columns", querylang.StringArray(append(append([]string{}, stmt.groupBy...), c.columns[0])...))),
+			)
+		} else {
+			bp.Stages = append(bp.Stages, astutil.Call(c.agg.fn, astutil.Property("columns", querylang.StringArray(c.columns...))))
+		}
+		bp.Stages = append(bp.Stages, outputColumns(c.sels)...)
+		return bp
+	}
+
+	var out *astutil.Pipeline
+	var assignment *ast.VariableAssignment
+	if len(calls) == 1 {
+		out = branch(p.Expression(), calls[0])
+	} else {
+		id := &ast.Identifier{Name: "data" + strconv.Itoa(index)}
+		assignment = &ast.VariableAssignment{ID: id, Init: p.Expression()}
+		joined := branch(id, calls[0]).Expression()
+		for _, c := range calls[1:] {
+			joined = astutil.Call("join",
+				astutil.Property("tables", &ast.ObjectExpression{Properties: []*ast.Property{
+					astutil.Property("left", joined),
+					astutil.Property("right", branch(id, c).Expression()),
+				}}),
+				astutil.Property("on", querylang.StringArray(stmt.groupBy...)),
+			)
+		}
+		out = &astutil.Pipeline{Source: joined}
+	}
+
+	out.Stages = append(out.Stages, astutil.Call("group"))
+	// The grouped columns that are not selected are not part of the result.
+	var names []string
+	for _, sel := range sels {
+		names = append(names, sel.name)
+	}
+	for _, col := range stmt.groupBy {
+		if !contains(names, col) {
+			out.Stages = append(out.Stages, astutil.Call("keep", astutil.Property("columns", querylang.StringArray(names...))))
+			break
+		}
+	}
+	return out, assignment, nil
+}
+
+// outputColumns returns the stages that give the selected columns their names.
+// The first selection of a column renames it and the others duplicate it.
+func outputColumns(sels []selection) []*ast.CallExpression {
+	names := make(map[string]string)
+	var renamed []string
+	var duplicates []*ast.CallExpression
+	for _, sel := range sels {
+		name, ok := names[sel.column]
+		if !ok {
+			names[sel.column] = sel.name
+			if sel.column != sel.name {
+				renamed = append(renamed, sel.column)
+			}
+			continue
+		}
+		duplicates = append(duplicates, astutil.Call("duplicate",
+			astutil.Property("column", &ast.StringLiteral{Value: name}),
+			astutil.Property("as", &ast.StringLiteral{Value: sel.name}),
+		))
+	}
+	var stages []*ast.CallExpression
+	if len(renamed) > 0 {
+		stages = append(stages, rename(names, renamed))
+	}
+	return append(stages, duplicates...)
+}
+
+// rename returns the call that renames the given columns with the names of the mapping.
+func rename(names map[string]string, columns []string) *ast.CallExpression {
+	obj := &ast.ObjectExpression{}
+	for _, col := range columns {
+		var key ast.PropertyKey = &ast.Identifier{Name: col}
+		if !querylang.IsIdentifier(col) {
+			key = &ast.StringLiteral{Value: col}
+		}
+		obj.Properties = append(obj.Properties, &ast.Property{Key: key, Value: &ast.StringLiteral{Value: names[col]}})
+	}
+	return astutil.Call("rename", astutil.Property("columns", obj))
+}
+
+// sortCall returns the call that sorts the result of stmt by the columns of its ORDER BY clause.
+func sortCall(stmt *selectStatement, sels []selection) (*ast.CallExpression, error) {
+	var cols []string
+	desc := stmt.orderBy[0].desc
+	for _, term := range stmt.orderBy {
+		if term.desc != desc {
+			return nil, fmt.Errorf("cannot order by columns in different directions")
+		}
+		found := false
+		for _, sel := range sels {
+			if sel.name == term.column || sel.column == "" {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("cannot order by %s, which is not selected", term.column)
+		}
+		cols = append(cols, term.column)
+	}
+	props := []*ast.Property{astutil.Property("columns", querylang.StringArray(cols...))}
+	if desc {
+		props = append(props, astutil.Property("desc", &ast.BooleanLiteral{Value: true}))
+	}
+	return astutil.Call("sort", props...), nil
+}
+
+// fluxColumn returns the Flux column of a SQL column.
+func fluxColumn(name string) string {
+	if strings.EqualFold(name, querylang.TimeColumn) {
+		return "_time"
+	}
+	return name
+}
+
+func contains(strs []string, str string) bool {
+	for _, s := range strs {
+		if s == str {
+			return true
+		}
+	}
+	return false
+}
+
+// condition converts a WHERE clause into the body of a filter function.
+func condition(e querylang.Expr) (ast.Expression, error) {
+	return querylang.Condition(e, operand, true)
+}
+
+// operand converts an operand of a comparison in a WHERE clause.
+func operand(e querylang.Expr) (ast.Expression, error) {
+	switch n := e.(type) {
+	case *querylang.ParenExpr:
+		return operand(n.Expr)
+	case *querylang.VarRef:
+		return querylang.Column(n.Name), nil
+	case *querylang.StringLit:
+		return &ast.StringLiteral{Value: n.Value}, nil
+	case *querylang.BooleanLit:
+		return &ast.Identifier{Name: strconv.FormatBool(n.Value)}, nil
+	case *querylang.NumberLit:
+		if n.IsFloat {
+			f, err := strconv.ParseFloat(n.Lit, 64)
+			return &ast.FloatLiteral{Value: f}, err
+		}
+		i, err := strconv.ParseInt(n.Lit, 10, 64)
+		return &ast.IntegerLiteral{Value: i}, err
+	case *querylang.BinaryExpr:
+		var op ast.OperatorKind
+		switch n.Op {
+		case querylang.PLUS:
+			op = ast.AdditionOperator
+		case querylang.MINUS:
+			op = ast.SubtractionOperator
+		default:
+			return nil, fmt.Errorf("unsupported operand %s", e)
+		}
+		lhs, err := operand(n.LHS)
+		if err != nil {
+			return nil, err
+		}
+		rhs, err := operand(n.RHS)
+		if err != nil {
+			return nil, err
+		}
+		return &ast.BinaryExpression{Operator: op, Left: lhs, Right: rhs}, nil
+	}
+	return nil, fmt.Errorf("unsupported operand %s", e)
+}
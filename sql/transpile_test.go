@@ -0,0 +1,169 @@
+package sql_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/ast"
+	_ "github.com/influxdata/flux/builtin"
+	"github.com/influxdata/flux/sql"
+)
+
+func TestTranspile(t *testing.T) {
+	testCases := []struct {
+		name  string
+		query string
+		want  string
+	}{
+		{
+			name:  "columns",
+			query: `SELECT time, usage_user AS user, host FROM cpu WHERE host = 'a' AND time > now() - INTERVAL '1 hour'`,
+			want: `from(bucket: "db")
+	|> range(start: -1h)
+	|> filter(fn: (r) =>
+		(r._measurement == "cpu"))
+	|> pivot(rowKey: ["_time"], columnKey: ["_field"], valueColumn: "_value")
+	|> filter(fn: (r) =>
+		(r.host == "a"))
+	|> group()
+	|> keep(columns: ["_time", "usage_user", "host"])
+	|> rename(columns: {_time: "time", usage_user: "user"})
+	|> yield(name: "0")`,
+		},
+		{
+			name:  "wildcard",
+			query: `SELECT * FROM telegraf.cpu WHERE time >= '2019-01-01T00:00:00Z' AND time < TIMESTAMP '2019-01-02 00:00:00' ORDER BY time DESC LIMIT 10`,
+			want: `from(bucket: "telegraf")
+	|> range(start: 2019-01-01T00:00:00Z, stop: 2019-01-02T00:00:00Z)
+	|> filter(fn: (r) =>
+		(r._measurement == "cpu"))
+	|> pivot(rowKey: ["_time"], columnKey: ["_field"], valueColumn: "_value")
+	|> group()
+	|> drop(columns: ["_start", "_stop", "_measurement"])
+	|> rename(columns: {_time: "time"})
+	|> sort(columns: ["time"], desc: true)
+	|> limit(n: 10)
+	|> yield(name: "0")`,
+		},
+		{
+			name:  "aggregates",
+			query: `SELECT host, avg(usage_user), avg(usage_system) AS system, count(*) FROM cpu WHERE NOT (region = 'eu' OR usage_idle < 10.5) GROUP BY host`,
+			want: `data0 = from(bucket: "db")
+	|> range(start: 1677-09-21T00:12:43.145224194Z)
+	|> filter(fn: (r) =>
+		(r._measurement == "cpu"))
+	|> pivot(rowKey: ["_time"], columnKey: ["_field"], valueColumn: "_value")
+	|> filter(fn: (r) =>
+		(not (r.region == "eu" or r.usage_idle < 10.5)))
+	|> group(columns: ["host"])
+
+join(tables: {left: data0
+	|> mean(columns: ["usage_user", "usage_system"])
+	|> rename(columns: {usage_user: "avg", usage_system: "system"}), right: data0
+	|> count(columns: ["_measurement"])
+	|> rename(columns: {_measurement: "count"})}, on: ["host"])
+	|> group()
+	|> yield(name: "0")`,
+		},
+		{
+			name:  "selectors",
+			query: `SELECT max(usage_user), min(usage_user), sum("usage system") FROM cpu WHERE time > now() - INTERVAL '1h30m'; SELECT last(free) FROM mem`,
+			want: `data0 = from(bucket: "db")
+	|> range(start: -1h30m)
+	|> filter(fn: (r) =>
+		(r._measurement == "cpu"))
+	|> pivot(rowKey: ["_time"], columnKey: ["_field"], valueColumn: "_value")
+	|> group()
+
+join(tables: {left: join(tables: {left: data0
+	|> max(column: "usage_user")
+	|> keep(columns: ["usage_user"])
+	|> rename(columns: {usage_user: "max"}), right: data0
+	|> min(column: "usage_user")
+	|> keep(columns: ["usage_user"])
+	|> rename(columns: {usage_user: "min"})}, on: []), right: data0
+	|> sum(columns: ["usage system"])
+	|> rename(columns: {"usage system": "sum"})}, on: [])
+	|> group()
+	|> yield(name: "0")
+from(bucket: "db")
+	|> range(start: 1677-09-21T00:12:43.145224194Z)
+	|> filter(fn: (r) =>
+		(r._measurement == "mem"))
+	|> pivot(rowKey: ["_time"], columnKey: ["_field"], valueColumn: "_value")
+	|> group()
+	|> last(column: "free")
+	|> keep(columns: ["free"])
+	|> rename(columns: {free: "last"})
+	|> group()
+	|> yield(name: "1")`,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			pkg, err := sql.Transpile(tc.query, sql.Config{DefaultBucket: "db"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := ast.Format(pkg.Files[0])
+			if !cmp.Equal(tc.want, got) {
+				t.Errorf("unexpected Flux -want/+got\n%s", cmp.Diff(tc.want, got))
+			}
+			if _, err := flux.Compile(context.Background(), got, time.Now()); err != nil {
+				t.Errorf("cannot compile the Flux of the query: %v", err)
+			}
+		})
+	}
+}
+
+func TestTranspile_Errors(t *testing.T) {
+	testCases := []struct {
+		name  string
+		query string
+	}{
+		{name: "syntax", query: `SELECT FROM cpu`},
+		{name: "unterminated string", query: `SELECT a FROM cpu WHERE host = 'a`},
+		{name: "unsupported function", query: `SELECT median(a) FROM cpu`},
+		{name: "column not grouped", query: `SELECT host, mean(a) FROM cpu`},
+		{name: "group by without aggregate", query: `SELECT a FROM cpu GROUP BY host`},
+		{name: "time in or", query: `SELECT a FROM cpu WHERE host = 'a' OR time > now() - INTERVAL '1h'`},
+		{name: "invalid interval", query: `SELECT a FROM cpu WHERE time > now() - INTERVAL '1 fortnight'`},
+		{name: "mixed order", query: `SELECT a, b FROM cpu ORDER BY a, b DESC`},
+		{name: "no bucket", query: `SELECT a FROM cpu`},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			config := sql.Config{DefaultBucket: "db"}
+			if tc.name == "no bucket" {
+				config.DefaultBucket = ""
+			}
+			if _, err := sql.Transpile(tc.query, config); err == nil {
+				t.Fatal("expected an error")
+			}
+		})
+	}
+}
+
+func TestCompiler(t *testing.T) {
+	c := sql.Compiler{
+		Query:  `SELECT mean(usage_user) FROM cpu WHERE time > now() - INTERVAL '1h'`,
+		Bucket: "db",
+	}
+	spec, err := c.Compile(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var kinds []flux.OperationKind
+	for _, op := range spec.Operations {
+		kinds = append(kinds, op.Spec.Kind())
+	}
+	want := []flux.OperationKind{"from", "range", "filter", "pivot", "group", "mean", "rename", "group", "yield"}
+	if !cmp.Equal(want, kinds) {
+		t.Errorf("unexpected operations -want/+got\n%s", cmp.Diff(want, kinds))
+	}
+}
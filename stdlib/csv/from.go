@@ -3,14 +3,15 @@ package csv
 import (
 	"fmt"
 	"io/ioutil"
-	"os"
 
 	"context"
 	"strings"
 
 	"github.com/influxdata/flux"
 	"github.com/influxdata/flux/csv"
+	"github.com/influxdata/flux/dependencies"
 	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/memory"
 	"github.com/influxdata/flux/plan"
 	"github.com/influxdata/flux/semantic"
 	"github.com/pkg/errors"
@@ -19,15 +20,17 @@ import (
 const FromCSVKind = "fromCSV"
 
 type FromCSVOpSpec struct {
-	CSV  string `json:"csv"`
-	File string `json:"file"`
+	CSV        string `json:"csv"`
+	File       string `json:"file"`
+	Duplicates string `json:"duplicates,omitempty"`
 }
 
 func init() {
 	fromCSVSignature := semantic.FunctionPolySignature{
 		Parameters: map[string]semantic.PolyType{
-			"csv":  semantic.String,
-			"file": semantic.String,
+			"csv":        semantic.String,
+			"file":       semantic.String,
+			"duplicates": semantic.String,
 		},
 		Required: nil,
 		Return:   flux.TableObjectType,
@@ -53,6 +56,15 @@ func createFromCSVOpSpec(args flux.Arguments, a *flux.Administration) (flux.Oper
 		spec.File = file
 	}
 
+	if duplicates, ok, err := args.GetString("duplicates"); err != nil {
+		return nil, err
+	} else if ok {
+		if _, err := execute.ParseDuplicatePolicy(duplicates); err != nil {
+			return nil, err
+		}
+		spec.Duplicates = duplicates
+	}
+
 	if spec.CSV == "" && spec.File == "" {
 		return nil, errors.New("must provide csv raw text or filename")
 	}
@@ -61,12 +73,6 @@ func createFromCSVOpSpec(args flux.Arguments, a *flux.Administration) (flux.Oper
 		return nil, errors.New("must provide exactly one of the parameters csv or file")
 	}
 
-	if spec.File != "" {
-		if _, err := os.Stat(spec.File); err != nil {
-			return nil, errors.Wrap(err, "failed to stat csv file: ")
-		}
-	}
-
 	return spec, nil
 }
 
@@ -78,10 +84,19 @@ func (s *FromCSVOpSpec) Kind() flux.OperationKind {
 	return FromCSVKind
 }
 
+func (s *FromCSVOpSpec) Resources() []flux.Resource {
+	if s.File == "" {
+		return nil
+	}
+	return []flux.Resource{{Kind: flux.FileResource, Name: s.File, Mode: flux.ReadAccess}}
+}
+
 type FromCSVProcedureSpec struct {
 	plan.DefaultCost
 	CSV  string
 	File string
+	// Duplicates is the policy for the rows of the decoded tables that have the same time.
+	Duplicates execute.DuplicatePolicy
 }
 
 func newFromCSVProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
@@ -90,9 +105,15 @@ func newFromCSVProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.Pr
 		return nil, fmt.Errorf("invalid spec type %T", qs)
 	}
 
+	duplicates, err := execute.ParseDuplicatePolicy(spec.Duplicates)
+	if err != nil {
+		return nil, err
+	}
+
 	return &FromCSVProcedureSpec{
-		CSV:  spec.CSV,
-		File: spec.File,
+		CSV:        spec.CSV,
+		File:       spec.File,
+		Duplicates: duplicates,
 	}, nil
 }
 
@@ -104,9 +125,45 @@ func (s *FromCSVProcedureSpec) Copy() plan.ProcedureSpec {
 	ns := new(FromCSVProcedureSpec)
 	ns.CSV = s.CSV
 	ns.File = s.File
+	ns.Duplicates = s.Duplicates
 	return ns
 }
 
+// OutputSchema implements plan.SchemaProcedureSpec.
+// The annotations of raw csv text declare the columns of its tables,
+// the schema has the columns that all the tables have with the same type.
+// The schema of a file is not known until it is read.
+func (s *FromCSVProcedureSpec) OutputSchema(predecessors []*plan.Schema) *plan.Schema {
+	if s.CSV == "" {
+		return nil
+	}
+	decoder := csv.NewResultDecoder(csv.ResultDecoderConfig{})
+	result, err := decoder.Decode(strings.NewReader(s.CSV))
+	if err != nil {
+		return nil
+	}
+	var schema *plan.Schema
+	if err := result.Tables().Do(func(tbl flux.Table) error {
+		cols := tbl.Cols()
+		if schema == nil {
+			schema = &plan.Schema{Columns: append([]flux.ColMeta(nil), cols...)}
+			return nil
+		}
+		common := schema.Columns[:0]
+		for _, c := range schema.Columns {
+			if j := execute.ColIdx(c.Label, cols); j >= 0 && cols[j].Type == c.Type {
+				common = append(common, c)
+			}
+		}
+		schema.Open = schema.Open || len(common) < len(schema.Columns) || len(common) < len(cols)
+		schema.Columns = common
+		return nil
+	}); err != nil {
+		return nil
+	}
+	return schema
+}
+
 func createFromCSVSource(prSpec plan.ProcedureSpec, dsid execute.DatasetID, a execute.Administration) (execute.Source, error) {
 	spec, ok := prSpec.(*FromCSVProcedureSpec)
 	if !ok {
@@ -116,7 +173,12 @@ func createFromCSVSource(prSpec plan.ProcedureSpec, dsid execute.DatasetID, a ex
 	csvText := spec.CSV
 	// if spec.File non-empty then spec.CSV is empty
 	if spec.File != "" {
-		csvBytes, err := ioutil.ReadFile(spec.File)
+		f, err := dependencies.Get(a.Context()).Filesystem.Open(spec.File)
+		if err != nil {
+			return nil, err
+		}
+		csvBytes, err := ioutil.ReadAll(f)
+		f.Close()
 		if err != nil {
 			return nil, err
 		}
@@ -128,7 +190,13 @@ func createFromCSVSource(prSpec plan.ProcedureSpec, dsid execute.DatasetID, a ex
 	if err != nil {
 		return nil, err
 	}
-	csvSource := CSVSource{id: dsid, data: result}
+	csvSource := CSVSource{
+		id:         dsid,
+		data:       result,
+		duplicates: spec.Duplicates,
+		alloc:      a.Allocator(),
+		size:       int64(len(csvText)),
+	}
 
 	return &csvSource, nil
 }
@@ -137,6 +205,11 @@ type CSVSource struct {
 	id   execute.DatasetID
 	data flux.Result
 	ts   []execute.Transformation
+
+	duplicates execute.DuplicatePolicy
+	alloc      *memory.Allocator
+	// size is the number of bytes of the csv text, which are reported as scanned.
+	size int64
 }
 
 func (c *CSVSource) AddTransformation(t execute.Transformation) {
@@ -147,7 +220,13 @@ func (c *CSVSource) Run(ctx context.Context) {
 	var err error
 	var max execute.Time
 	maxSet := false
+	execute.ReportUsage(ctx, execute.Usage{BytesScanned: c.size})
 	err = c.data.Tables().Do(func(tbl flux.Table) error {
+		tbl, err := execute.ResolveDuplicates(tbl, execute.DefaultTimeColLabel, c.duplicates, c.alloc)
+		if err != nil {
+			return err
+		}
+		tbl = newScannedTable(ctx, tbl)
 		for _, t := range c.ts {
 			err := t.Process(c.id, tbl)
 			if err != nil {
@@ -179,3 +258,21 @@ FINISH:
 		t.Finish(c.id, err)
 	}
 }
+
+// scannedTable reports the table as a scanned series, and its rows as scanned points as they are read.
+type scannedTable struct {
+	flux.Table
+	ctx context.Context
+}
+
+func newScannedTable(ctx context.Context, tbl flux.Table) *scannedTable {
+	execute.ReportUsage(ctx, execute.Usage{SeriesScanned: 1})
+	return &scannedTable{Table: tbl, ctx: ctx}
+}
+
+func (t *scannedTable) Do(f func(flux.ColReader) error) error {
+	return t.Table.Do(func(cr flux.ColReader) error {
+		execute.ReportUsage(t.ctx, execute.Usage{PointsScanned: int64(cr.Len())})
+		return f(cr)
+	})
+}
@@ -4,9 +4,11 @@ import (
 	"testing"
 	"time"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/influxdata/flux"
 	_ "github.com/influxdata/flux/builtin" // We need to import the builtins for the tests to work.
 	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/plan"
 	"github.com/influxdata/flux/querytest"
 	"github.com/influxdata/flux/stdlib/csv"
 	"github.com/influxdata/flux/stdlib/universe"
@@ -75,8 +77,38 @@ func TestFromCSV_NewQuery(t *testing.T) {
 			},
 		},
 		{
-			Name:    "fromCSV File",
-			Raw:     `import "csv" csv.from(file: "f.txt") |> range(start:-4h, stop:-2h) |> sum()`,
+			// The file is read through the filesystem of the dependencies of the query when it executes.
+			Name: "fromCSV File",
+			Raw:  `import "csv" csv.from(file: "f.txt")`,
+			Want: &flux.Spec{
+				Operations: []*flux.Operation{
+					{
+						ID: "fromCSV0",
+						Spec: &csv.FromCSVOpSpec{
+							File: "f.txt",
+						},
+					},
+				},
+			},
+		},
+		{
+			Name: "fromCSV duplicates",
+			Raw:  `import "csv" csv.from(csv: "1,2", duplicates: "first")`,
+			Want: &flux.Spec{
+				Operations: []*flux.Operation{
+					{
+						ID: "fromCSV0",
+						Spec: &csv.FromCSVOpSpec{
+							CSV:        "1,2",
+							Duplicates: "first",
+						},
+					},
+				},
+			},
+		},
+		{
+			Name:    "fromCSV unknown duplicates",
+			Raw:     `import "csv" csv.from(csv: "1,2", duplicates: "drop")`,
 			WantErr: true,
 		},
 	}
@@ -99,3 +131,63 @@ func TestFromCSVOperation_Marshaling(t *testing.T) {
 	}
 	querytest.OperationMarshalingTestHelper(t, data, op)
 }
+
+func TestFromCSVProcedureSpec_OutputSchema(t *testing.T) {
+	testCases := []struct {
+		name string
+		spec *csv.FromCSVProcedureSpec
+		want *plan.Schema
+	}{
+		{
+			name: "file",
+			spec: &csv.FromCSVProcedureSpec{File: "data.csv"},
+		},
+		{
+			name: "same columns",
+			spec: &csv.FromCSVProcedureSpec{CSV: `#datatype,string,long,dateTime:RFC3339,double,string
+#group,false,false,false,false,true
+#default,_result,,,,
+,result,table,_time,_value,host
+,,0,2018-05-22T19:53:26Z,1.0,a
+,,1,2018-05-22T19:53:26Z,2.0,b
+`},
+			want: &plan.Schema{
+				Columns: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_value", Type: flux.TFloat},
+					{Label: "host", Type: flux.TString},
+				},
+			},
+		},
+		{
+			name: "different columns",
+			spec: &csv.FromCSVProcedureSpec{CSV: `#datatype,string,long,dateTime:RFC3339,double,string
+#group,false,false,false,false,true
+#default,_result,,,,
+,result,table,_time,_value,host
+,,0,2018-05-22T19:53:26Z,1.0,a
+
+#datatype,string,long,dateTime:RFC3339,long,string
+#group,false,false,false,false,true
+#default,_result,,,,
+,result,table,_time,_value,region
+,,1,2018-05-22T19:53:26Z,2,west
+`},
+			want: &plan.Schema{
+				Columns: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+				},
+				Open: true,
+			},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.spec.OutputSchema(nil)
+			if !cmp.Equal(tc.want, got) {
+				t.Errorf("unexpected schema -want/+got:\n%s", cmp.Diff(tc.want, got))
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+// DO NOT EDIT: This file is autogenerated via the builtin command.
+
+package aggregate
+
+import (
+	flux "github.com/influxdata/flux"
+	ast "github.com/influxdata/flux/ast"
+	docs "github.com/influxdata/flux/docs"
+)
+
+func init() {
+	flux.RegisterPackage(pkgAST)
+	docs.RegisterComments("experimental/aggregate", pkgComments)
+}
+
+var pkgAST = &ast.Package{
+	BaseNode: ast.BaseNode{
+		Errors: nil,
+		Loc:    nil,
+	},
+	Files: []*ast.File{&ast.File{
+		BaseNode: ast.BaseNode{
+			Errors: nil,
+			Loc: &ast.SourceLocation{
+				End: ast.Position{
+					Column: 15,
+					Line:   4,
+				},
+				File:   "aggregate.flux",
+				Source: "package aggregate\n\n// Window computes several aggregates of every window of a table in a single pass.\nbuiltin window",
+				Start: ast.Position{
+					Column: 1,
+					Line:   1,
+				},
+			},
+		},
+		Body: []ast.Statement{&ast.BuiltinStatement{
+			BaseNode: ast.BaseNode{
+				Errors: nil,
+				Loc: &ast.SourceLocation{
+					End: ast.Position{
+						Column: 15,
+						Line:   4,
+					},
+					File:   "aggregate.flux",
+					Source: "builtin window",
+					Start: ast.Position{
+						Column: 1,
+						Line:   4,
+					},
+				},
+			},
+			ID: &ast.Identifier{
+				BaseNode: ast.BaseNode{
+					Errors: nil,
+					Loc: &ast.SourceLocation{
+						End: ast.Position{
+							Column: 15,
+							Line:   4,
+						},
+						File:   "aggregate.flux",
+						Source: "window",
+						Start: ast.Position{
+							Column: 9,
+							Line:   4,
+						},
+					},
+				},
+				Name: "window",
+			},
+		}},
+		Imports: nil,
+		Name:    "aggregate.flux",
+		Package: &ast.PackageClause{
+			BaseNode: ast.BaseNode{
+				Errors: nil,
+				Loc: &ast.SourceLocation{
+					End: ast.Position{
+						Column: 18,
+						Line:   1,
+					},
+					File:   "aggregate.flux",
+					Source: "package aggregate",
+					Start: ast.Position{
+						Column: 1,
+						Line:   1,
+					},
+				},
+			},
+			Name: &ast.Identifier{
+				BaseNode: ast.BaseNode{
+					Errors: nil,
+					Loc: &ast.SourceLocation{
+						End: ast.Position{
+							Column: 18,
+							Line:   1,
+						},
+						File:   "aggregate.flux",
+						Source: "aggregate",
+						Start: ast.Position{
+							Column: 9,
+							Line:   1,
+						},
+					},
+				},
+				Name: "aggregate",
+			},
+		},
+	}},
+	Package: "aggregate",
+	Path:    "experimental/aggregate",
+}
+var pkgComments = docs.Comments{
+	Package: "",
+	Values:  map[string]string{"window": "Window computes several aggregates of every window of a table in a single pass."},
+}
@@ -0,0 +1,495 @@
+// Package aggregate contains transformations that compute several aggregates at once.
+package aggregate
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/interpreter"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/flux/values"
+	"github.com/pkg/errors"
+)
+
+const WindowKind = "aggregateWindowMulti"
+
+// The aggregates that window can compute.
+const (
+	CountAggregate = "count"
+	SumAggregate   = "sum"
+	MeanAggregate  = "mean"
+	MinAggregate   = "min"
+	MaxAggregate   = "max"
+)
+
+var DefaultAggregates = []string{MinAggregate, MaxAggregate, MeanAggregate, CountAggregate}
+
+type WindowOpSpec struct {
+	Every       flux.Duration `json:"every"`
+	Period      flux.Duration `json:"period"`
+	Offset      flux.Duration `json:"offset"`
+	Aggregates  []string      `json:"aggregates"`
+	Column      string        `json:"column"`
+	TimeColumn  string        `json:"timeColumn"`
+	TimeSrc     string        `json:"timeSrc"`
+	TimeDst     string        `json:"timeDst"`
+	CreateEmpty bool          `json:"createEmpty"`
+}
+
+func init() {
+	windowSignature := flux.FunctionSignature(
+		map[string]semantic.PolyType{
+			"every":       semantic.Duration,
+			"period":      semantic.Duration,
+			"offset":      semantic.Duration,
+			"aggregates":  semantic.NewArrayPolyType(semantic.String),
+			"column":      semantic.String,
+			"timeColumn":  semantic.String,
+			"timeSrc":     semantic.String,
+			"timeDst":     semantic.String,
+			"createEmpty": semantic.Bool,
+		},
+		[]string{"every"},
+	)
+
+	flux.RegisterPackageValue("experimental/aggregate", "window", flux.FunctionValue(WindowKind, createWindowOpSpec, windowSignature))
+	flux.RegisterOpSpec(WindowKind, newWindowOp)
+	plan.RegisterProcedureSpec(WindowKind, newWindowProcedure, WindowKind)
+	execute.RegisterTransformation(WindowKind, createWindowTransformation)
+}
+
+func createWindowOpSpec(args flux.Arguments, a *flux.Administration) (flux.OperationSpec, error) {
+	if err := a.AddParentFromArgs(args); err != nil {
+		return nil, err
+	}
+
+	spec := new(WindowOpSpec)
+	every, err := args.GetRequiredDuration("every")
+	if err != nil {
+		return nil, err
+	}
+	if !every.IsPositive() {
+		return nil, errors.New("every must be greater than zero")
+	}
+	spec.Every = every
+
+	if period, ok, err := args.GetDuration("period"); err != nil {
+		return nil, err
+	} else if ok {
+		if !period.IsPositive() {
+			return nil, errors.New("period must be greater than zero")
+		}
+		spec.Period = period
+	} else {
+		spec.Period = spec.Every
+	}
+
+	if offset, ok, err := args.GetDuration("offset"); err != nil {
+		return nil, err
+	} else if ok {
+		spec.Offset = offset
+	}
+
+	if aggs, ok, err := args.GetArray("aggregates", semantic.String); err != nil {
+		return nil, err
+	} else if ok {
+		aggregates, err := interpreter.ToStringArray(aggs)
+		if err != nil {
+			return nil, err
+		}
+		if len(aggregates) == 0 {
+			return nil, errors.New("at least one aggregate is required")
+		}
+		seen := make(map[string]bool, len(aggregates))
+		for _, agg := range aggregates {
+			switch agg {
+			case CountAggregate, SumAggregate, MeanAggregate, MinAggregate, MaxAggregate:
+			default:
+				return nil, fmt.Errorf("unknown aggregate %q", agg)
+			}
+			if seen[agg] {
+				return nil, fmt.Errorf("duplicate aggregate %q", agg)
+			}
+			seen[agg] = true
+		}
+		spec.Aggregates = aggregates
+	} else {
+		spec.Aggregates = DefaultAggregates
+	}
+
+	if label, ok, err := args.GetString("column"); err != nil {
+		return nil, err
+	} else if ok {
+		spec.Column = label
+	} else {
+		spec.Column = execute.DefaultValueColLabel
+	}
+	if label, ok, err := args.GetString("timeColumn"); err != nil {
+		return nil, err
+	} else if ok {
+		spec.TimeColumn = label
+	} else {
+		spec.TimeColumn = execute.DefaultTimeColLabel
+	}
+	if label, ok, err := args.GetString("timeSrc"); err != nil {
+		return nil, err
+	} else if ok {
+		if label != execute.DefaultStartColLabel && label != execute.DefaultStopColLabel {
+			return nil, fmt.Errorf("timeSrc must be %q or %q", execute.DefaultStartColLabel, execute.DefaultStopColLabel)
+		}
+		spec.TimeSrc = label
+	} else {
+		spec.TimeSrc = execute.DefaultStopColLabel
+	}
+	if label, ok, err := args.GetString("timeDst"); err != nil {
+		return nil, err
+	} else if ok {
+		spec.TimeDst = label
+	} else {
+		spec.TimeDst = execute.DefaultTimeColLabel
+	}
+	if createEmpty, ok, err := args.GetBool("createEmpty"); err != nil {
+		return nil, err
+	} else if ok {
+		spec.CreateEmpty = createEmpty
+	} else {
+		spec.CreateEmpty = true
+	}
+	return spec, nil
+}
+
+func newWindowOp() flux.OperationSpec {
+	return new(WindowOpSpec)
+}
+
+func (s *WindowOpSpec) Kind() flux.OperationKind {
+	return WindowKind
+}
+
+type WindowProcedureSpec struct {
+	plan.DefaultCost
+	Window      plan.WindowSpec
+	Aggregates  []string
+	Column      string
+	TimeColumn  string
+	TimeSrc     string
+	TimeDst     string
+	CreateEmpty bool
+}
+
+func newWindowProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	s, ok := qs.(*WindowOpSpec)
+	if !ok {
+		return nil, fmt.Errorf("invalid spec type %T", qs)
+	}
+	return &WindowProcedureSpec{
+		Window: plan.WindowSpec{
+			Every:  s.Every,
+			Period: s.Period,
+			Offset: s.Offset,
+		},
+		Aggregates:  s.Aggregates,
+		Column:      s.Column,
+		TimeColumn:  s.TimeColumn,
+		TimeSrc:     s.TimeSrc,
+		TimeDst:     s.TimeDst,
+		CreateEmpty: s.CreateEmpty,
+	}, nil
+}
+
+func (s *WindowProcedureSpec) Kind() plan.ProcedureKind {
+	return WindowKind
+}
+func (s *WindowProcedureSpec) Copy() plan.ProcedureSpec {
+	ns := new(WindowProcedureSpec)
+	*ns = *s
+	ns.Aggregates = make([]string, len(s.Aggregates))
+	copy(ns.Aggregates, s.Aggregates)
+	return ns
+}
+
+func createWindowTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	s, ok := spec.(*WindowProcedureSpec)
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid spec type %T", spec)
+	}
+	cache := execute.NewTableBuilderCache(a.Allocator())
+	d := execute.NewDataset(id, mode, cache)
+
+	bounds := a.StreamContext().Bounds()
+	if bounds == nil {
+		return nil, nil, errors.New("nil bounds passed to window")
+	}
+	t := NewWindowTransformation(d, cache, *bounds, s)
+	return t, d, nil
+}
+
+type windowTransformation struct {
+	d      execute.Dataset
+	cache  execute.TableBuilderCache
+	w      execute.Window
+	bounds execute.Bounds
+
+	spec WindowProcedureSpec
+}
+
+// NewWindowTransformation creates a transformation that computes the aggregates of the spec
+// over the windows of every table in a single pass.
+// Every input table produces a table with the same group key that holds a row per window,
+// with the time of the window and a column per aggregate named after the aggregate.
+func NewWindowTransformation(d execute.Dataset, cache execute.TableBuilderCache, bounds execute.Bounds, spec *WindowProcedureSpec) *windowTransformation {
+	return &windowTransformation{
+		d:     d,
+		cache: cache,
+		w: execute.NewWindow(
+			spec.Window.Every,
+			spec.Window.Period,
+			spec.Window.Offset,
+		),
+		bounds: bounds,
+		spec:   *spec,
+	}
+}
+
+func (t *windowTransformation) RetractTable(id execute.DatasetID, key flux.GroupKey) error {
+	return t.d.RetractTable(key)
+}
+
+func (t *windowTransformation) Process(id execute.DatasetID, tbl flux.Table) error {
+	builder, created := t.cache.TableBuilder(tbl.Key())
+	if !created {
+		return fmt.Errorf("aggregate window found duplicate table with key: %v", tbl.Key())
+	}
+
+	cols := tbl.Cols()
+	timeIdx := execute.ColIdx(t.spec.TimeColumn, cols)
+	if timeIdx < 0 {
+		return fmt.Errorf("missing time column %q", t.spec.TimeColumn)
+	}
+	valueIdx := execute.ColIdx(t.spec.Column, cols)
+	if valueIdx < 0 {
+		return fmt.Errorf("no column %q exists", t.spec.Column)
+	}
+	typ := cols[valueIdx].Type
+	if typ != flux.TFloat && typ != flux.TInt && typ != flux.TUInt {
+		return fmt.Errorf("cannot aggregate column %q of type %v", t.spec.Column, typ)
+	}
+
+	if err := execute.AddTableKeyCols(tbl.Key(), builder); err != nil {
+		return err
+	}
+	timeDstIdx, err := t.addCol(builder, t.spec.TimeDst, flux.TTime)
+	if err != nil {
+		return err
+	}
+	aggIdxs := make([]int, len(t.spec.Aggregates))
+	for i, agg := range t.spec.Aggregates {
+		aggType := typ
+		switch agg {
+		case CountAggregate:
+			aggType = flux.TInt
+		case MeanAggregate:
+			aggType = flux.TFloat
+		}
+		if aggIdxs[i], err = t.addCol(builder, agg, aggType); err != nil {
+			return err
+		}
+	}
+
+	// Abort processing if no data will match bounds
+	if t.bounds.IsEmpty() {
+		return nil
+	}
+
+	states := make(map[execute.Time]*windowState)
+	if t.spec.CreateEmpty {
+		for _, bnds := range t.overlappingBounds(t.bounds) {
+			states[bnds.Start] = &windowState{bounds: bnds}
+		}
+	}
+	if err := tbl.Do(func(cr flux.ColReader) error {
+		times := cr.Times(timeIdx)
+		for i := 0; i < cr.Len(); i++ {
+			if times.IsNull(i) {
+				continue
+			}
+			var v values.Value
+			switch typ {
+			case flux.TFloat:
+				if vs := cr.Floats(valueIdx); vs.IsValid(i) {
+					v = values.NewFloat(vs.Value(i))
+				}
+			case flux.TInt:
+				if vs := cr.Ints(valueIdx); vs.IsValid(i) {
+					v = values.NewInt(vs.Value(i))
+				}
+			case flux.TUInt:
+				if vs := cr.UInts(valueIdx); vs.IsValid(i) {
+					v = values.NewUInt(vs.Value(i))
+				}
+			}
+			if v == nil {
+				continue
+			}
+
+			tm := execute.Time(times.Value(i))
+			for _, bnds := range t.overlappingBounds(execute.Bounds{Start: tm, Stop: tm + 1}) {
+				state, ok := states[bnds.Start]
+				if !ok {
+					state = &windowState{bounds: bnds}
+					states[bnds.Start] = state
+				}
+				state.add(v)
+			}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	windows := make([]*windowState, 0, len(states))
+	for _, state := range states {
+		windows = append(windows, state)
+	}
+	sort.Slice(windows, func(i, j int) bool {
+		return windows[i].bounds.Start < windows[j].bounds.Start
+	})
+	for _, state := range windows {
+		if err := execute.AppendKeyValues(tbl.Key(), builder); err != nil {
+			return err
+		}
+		tm := state.bounds.Stop
+		if t.spec.TimeSrc == execute.DefaultStartColLabel {
+			tm = state.bounds.Start
+		}
+		if err := builder.AppendTime(timeDstIdx, tm); err != nil {
+			return err
+		}
+		for i, agg := range t.spec.Aggregates {
+			if err := builder.AppendValue(aggIdxs[i], state.value(agg, typ)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (t *windowTransformation) addCol(builder execute.TableBuilder, label string, typ flux.ColType) (int, error) {
+	if execute.HasCol(label, builder.Cols()) {
+		return -1, fmt.Errorf("column %q already exists", label)
+	}
+	return builder.AddCol(flux.ColMeta{Label: label, Type: typ})
+}
+
+// overlappingBounds returns the bounds of the windows that overlap b, clipped to the query bounds.
+func (t *windowTransformation) overlappingBounds(b execute.Bounds) []execute.Bounds {
+	bs := t.w.GetOverlappingBounds(b)
+	for i := range bs {
+		bs[i] = t.bounds.Intersect(bs[i])
+	}
+	return bs
+}
+
+func (t *windowTransformation) UpdateWatermark(id execute.DatasetID, mark execute.Time) error {
+	return t.d.UpdateWatermark(mark)
+}
+func (t *windowTransformation) UpdateProcessingTime(id execute.DatasetID, pt execute.Time) error {
+	return t.d.UpdateProcessingTime(pt)
+}
+func (t *windowTransformation) Finish(id execute.DatasetID, err error) {
+	t.d.Finish(err)
+}
+
+// windowState accumulates the values of a window for all aggregates at once.
+type windowState struct {
+	bounds execute.Bounds
+	count  int64
+
+	// The sum, min and max are kept for the type of the column,
+	// fsum is the sum of the values as floats to compute the mean.
+	fsum, fmin, fmax float64
+	isum, imin, imax int64
+	usum, umin, umax uint64
+}
+
+func (s *windowState) add(v values.Value) {
+	first := s.count == 0
+	s.count++
+	switch v.Type() {
+	case semantic.Float:
+		f := v.Float()
+		s.fsum += f
+		if first || f < s.fmin {
+			s.fmin = f
+		}
+		if first || f > s.fmax {
+			s.fmax = f
+		}
+	case semantic.Int:
+		i := v.Int()
+		s.isum += i
+		s.fsum += float64(i)
+		if first || i < s.imin {
+			s.imin = i
+		}
+		if first || i > s.imax {
+			s.imax = i
+		}
+	case semantic.UInt:
+		u := v.UInt()
+		s.usum += u
+		s.fsum += float64(u)
+		if first || u < s.umin {
+			s.umin = u
+		}
+		if first || u > s.umax {
+			s.umax = u
+		}
+	}
+}
+
+// value returns the aggregate of the window, which is null if the window is empty,
+// except for the count.
+func (s *windowState) value(agg string, typ flux.ColType) values.Value {
+	if agg == CountAggregate {
+		return values.NewInt(s.count)
+	}
+	if s.count == 0 {
+		return values.NewNull(semantic.Nil)
+	}
+	if agg == MeanAggregate {
+		return values.NewFloat(s.fsum / float64(s.count))
+	}
+	switch typ {
+	case flux.TFloat:
+		switch agg {
+		case SumAggregate:
+			return values.NewFloat(s.fsum)
+		case MinAggregate:
+			return values.NewFloat(s.fmin)
+		default:
+			return values.NewFloat(s.fmax)
+		}
+	case flux.TInt:
+		switch agg {
+		case SumAggregate:
+			return values.NewInt(s.isum)
+		case MinAggregate:
+			return values.NewInt(s.imin)
+		default:
+			return values.NewInt(s.imax)
+		}
+	default:
+		switch agg {
+		case SumAggregate:
+			return values.NewUInt(s.usum)
+		case MinAggregate:
+			return values.NewUInt(s.umin)
+		default:
+			return values.NewUInt(s.umax)
+		}
+	}
+}
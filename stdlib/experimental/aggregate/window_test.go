@@ -0,0 +1,147 @@
+package aggregate_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/querytest"
+	"github.com/influxdata/flux/stdlib/experimental/aggregate"
+	"github.com/influxdata/flux/values"
+)
+
+func TestWindowOperation_Marshaling(t *testing.T) {
+	data := []byte(`{"id":"aggregateWindowMulti","kind":"aggregateWindowMulti","spec":{"every":"1m","period":"1m","aggregates":["min","max"],"column":"_value","timeColumn":"_time","timeSrc":"_stop","timeDst":"_time","createEmpty":true}}`)
+	op := &flux.Operation{
+		ID: "aggregateWindowMulti",
+		Spec: &aggregate.WindowOpSpec{
+			Every:       values.ConvertDuration(time.Minute),
+			Period:      values.ConvertDuration(time.Minute),
+			Aggregates:  []string{"min", "max"},
+			Column:      "_value",
+			TimeColumn:  "_time",
+			TimeSrc:     "_stop",
+			TimeDst:     "_time",
+			CreateEmpty: true,
+		},
+	}
+	querytest.OperationMarshalingTestHelper(t, data, op)
+}
+
+func TestWindow_Process(t *testing.T) {
+	spec := func(aggregates []string, createEmpty bool) *aggregate.WindowProcedureSpec {
+		return &aggregate.WindowProcedureSpec{
+			Window:      plan.WindowSpec{Every: values.ConvertDuration(10), Period: values.ConvertDuration(10)},
+			Aggregates:  aggregates,
+			Column:      execute.DefaultValueColLabel,
+			TimeColumn:  execute.DefaultTimeColLabel,
+			TimeSrc:     execute.DefaultStopColLabel,
+			TimeDst:     execute.DefaultTimeColLabel,
+			CreateEmpty: createEmpty,
+		}
+	}
+	testCases := []struct {
+		name    string
+		spec    *aggregate.WindowProcedureSpec
+		data    []flux.Table
+		want    []*executetest.Table
+		wantErr error
+	}{
+		{
+			name: "float",
+			spec: spec(aggregate.DefaultAggregates, true),
+			data: []flux.Table{&executetest.Table{
+				KeyCols: []string{"t1"},
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_value", Type: flux.TFloat},
+					{Label: "t1", Type: flux.TString},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), 2.0, "a"},
+					{execute.Time(5), 4.0, "a"},
+					{execute.Time(8), nil, "a"},
+					{execute.Time(12), 1.0, "a"},
+					{execute.Time(25), 3.0, "a"},
+					{execute.Time(28), 6.0, "a"},
+				},
+			}},
+			want: []*executetest.Table{{
+				KeyCols: []string{"t1"},
+				ColMeta: []flux.ColMeta{
+					{Label: "t1", Type: flux.TString},
+					{Label: "_time", Type: flux.TTime},
+					{Label: "min", Type: flux.TFloat},
+					{Label: "max", Type: flux.TFloat},
+					{Label: "mean", Type: flux.TFloat},
+					{Label: "count", Type: flux.TInt},
+				},
+				Data: [][]interface{}{
+					{"a", execute.Time(10), 2.0, 4.0, 3.0, int64(2)},
+					{"a", execute.Time(20), 1.0, 1.0, 1.0, int64(1)},
+					{"a", execute.Time(30), 3.0, 6.0, 4.5, int64(2)},
+					{"a", execute.Time(40), nil, nil, nil, int64(0)},
+				},
+			}},
+		},
+		{
+			name: "int without empty windows",
+			spec: spec([]string{"sum", "max"}, false),
+			data: []flux.Table{&executetest.Table{
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_value", Type: flux.TInt},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), int64(2)},
+					{execute.Time(5), int64(-4)},
+					{execute.Time(31), int64(7)},
+				},
+			}},
+			want: []*executetest.Table{{
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "sum", Type: flux.TInt},
+					{Label: "max", Type: flux.TInt},
+				},
+				Data: [][]interface{}{
+					{execute.Time(10), int64(-2), int64(2)},
+					{execute.Time(40), int64(7), int64(7)},
+				},
+			}},
+		},
+		{
+			name: "string",
+			spec: spec(aggregate.DefaultAggregates, true),
+			data: []flux.Table{&executetest.Table{
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_value", Type: flux.TString},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), "a"},
+				},
+			}},
+			wantErr: errors.New(`cannot aggregate column "_value" of type string`),
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			executetest.ProcessTestHelper(
+				t,
+				tc.data,
+				tc.want,
+				tc.wantErr,
+				func(d execute.Dataset, c execute.TableBuilderCache) execute.Transformation {
+					bounds := execute.Bounds{Start: 0, Stop: 40}
+					return aggregate.NewWindowTransformation(d, c, bounds, tc.spec)
+				},
+			)
+		})
+	}
+}
@@ -0,0 +1,219 @@
+// Package anomaly contains transformations that detect the anomalous values of a table.
+//
+// Every detector flags the records of a table whose value is an outlier with respect
+// to the other values of the table, so that alerting tasks can detect anomalies in place.
+package anomaly
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/semantic"
+)
+
+const (
+	// DefaultFlagColLabel is the default name of the column that holds the flags.
+	DefaultFlagColLabel = "_anomaly"
+
+	// madScale scales the median absolute deviation of normally distributed values
+	// into an estimate of their standard deviation.
+	madScale = 1.4826
+	// meanADScale scales the mean absolute deviation of normally distributed values
+	// into an estimate of their standard deviation.
+	meanADScale = 1.2533
+)
+
+// Detector flags the anomalous values of a table.
+type Detector interface {
+	// Detect returns whether each of the values is anomalous.
+	Detect(vs []float64) []bool
+}
+
+// DetectorConfig holds the options common to all detectors.
+type DetectorConfig struct {
+	// Column is the column of the values to inspect.
+	Column string `json:"column"`
+	// FlagColumn is the boolean column added to the table to flag the anomalous records.
+	FlagColumn string `json:"flagColumn"`
+}
+
+var DefaultDetectorConfig = DetectorConfig{
+	Column:     execute.DefaultValueColLabel,
+	FlagColumn: DefaultFlagColLabel,
+}
+
+// DetectorSignature returns a function signature common to all detectors,
+// with any additional arguments.
+func DetectorSignature(args map[string]semantic.PolyType, required []string) semantic.FunctionPolySignature {
+	if args == nil {
+		args = make(map[string]semantic.PolyType)
+	}
+	args["column"] = semantic.String
+	args["flagColumn"] = semantic.String
+	return flux.FunctionSignature(args, required)
+}
+
+func (c *DetectorConfig) ReadArgs(args flux.Arguments) error {
+	if col, ok, err := args.GetString("column"); err != nil {
+		return err
+	} else if ok {
+		c.Column = col
+	} else {
+		c.Column = DefaultDetectorConfig.Column
+	}
+
+	if col, ok, err := args.GetString("flagColumn"); err != nil {
+		return err
+	} else if ok {
+		c.FlagColumn = col
+	} else {
+		c.FlagColumn = DefaultDetectorConfig.FlagColumn
+	}
+	return nil
+}
+
+type detectorTransformation struct {
+	d     execute.Dataset
+	cache execute.TableBuilderCache
+
+	detector Detector
+	config   DetectorConfig
+}
+
+// NewDetectorTransformation creates a transformation that copies every table and
+// adds a column that flags the records the detector considers anomalous.
+// Records with a null value are never flagged and are ignored by the detector.
+func NewDetectorTransformation(d execute.Dataset, cache execute.TableBuilderCache, detector Detector, config DetectorConfig) *detectorTransformation {
+	return &detectorTransformation{
+		d:        d,
+		cache:    cache,
+		detector: detector,
+		config:   config,
+	}
+}
+
+func (t *detectorTransformation) RetractTable(id execute.DatasetID, key flux.GroupKey) error {
+	return t.d.RetractTable(key)
+}
+
+func (t *detectorTransformation) Process(id execute.DatasetID, tbl flux.Table) error {
+	builder, created := t.cache.TableBuilder(tbl.Key())
+	if !created {
+		return fmt.Errorf("anomaly detector found duplicate table with key: %v", tbl.Key())
+	}
+
+	cols := tbl.Cols()
+	valueIdx := execute.ColIdx(t.config.Column, cols)
+	if valueIdx < 0 {
+		return fmt.Errorf("no column %q exists", t.config.Column)
+	}
+	typ := cols[valueIdx].Type
+	if typ != flux.TFloat && typ != flux.TInt && typ != flux.TUInt {
+		return fmt.Errorf("cannot detect anomalies over %v", typ)
+	}
+	if execute.HasCol(t.config.FlagColumn, cols) {
+		return fmt.Errorf("column %q already exists", t.config.FlagColumn)
+	}
+
+	if err := execute.AddTableCols(tbl, builder); err != nil {
+		return err
+	}
+	flagIdx, err := builder.AddCol(flux.ColMeta{Label: t.config.FlagColumn, Type: flux.TBool})
+	if err != nil {
+		return err
+	}
+
+	// The values of the table are gathered while the records are copied,
+	// the flags are appended once the detector has seen all of them.
+	var (
+		vs   []float64
+		rows []int
+		n    int
+	)
+	if err := tbl.Do(func(cr flux.ColReader) error {
+		for j := range cols {
+			if err := execute.AppendCol(j, j, cr, builder); err != nil {
+				return err
+			}
+		}
+		for i := 0; i < cr.Len(); i++ {
+			switch typ {
+			case flux.TFloat:
+				if vals := cr.Floats(valueIdx); vals.IsValid(i) {
+					vs = append(vs, vals.Value(i))
+					rows = append(rows, n+i)
+				}
+			case flux.TInt:
+				if vals := cr.Ints(valueIdx); vals.IsValid(i) {
+					vs = append(vs, float64(vals.Value(i)))
+					rows = append(rows, n+i)
+				}
+			case flux.TUInt:
+				if vals := cr.UInts(valueIdx); vals.IsValid(i) {
+					vs = append(vs, float64(vals.Value(i)))
+					rows = append(rows, n+i)
+				}
+			}
+		}
+		n += cr.Len()
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	flags := make([]bool, n)
+	for k, anomalous := range t.detector.Detect(vs) {
+		flags[rows[k]] = anomalous
+	}
+	for _, f := range flags {
+		if err := builder.AppendBool(flagIdx, f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *detectorTransformation) UpdateWatermark(id execute.DatasetID, mark execute.Time) error {
+	return t.d.UpdateWatermark(mark)
+}
+func (t *detectorTransformation) UpdateProcessingTime(id execute.DatasetID, pt execute.Time) error {
+	return t.d.UpdateProcessingTime(pt)
+}
+func (t *detectorTransformation) Finish(id execute.DatasetID, err error) {
+	t.d.Finish(err)
+}
+
+// median returns the median of vs without modifying it.
+func median(vs []float64) float64 {
+	if len(vs) == 0 {
+		return math.NaN()
+	}
+	sorted := make([]float64, len(vs))
+	copy(sorted, vs)
+	sort.Float64s(sorted)
+	n := len(sorted)
+	if n%2 == 1 {
+		return sorted[n/2]
+	}
+	return (sorted[n/2-1] + sorted[n/2]) / 2
+}
+
+// robustScale returns the median of vs and a robust estimate of their standard deviation.
+// The estimate is based on the median absolute deviation and falls back on the
+// mean absolute deviation when more than half of the values are equal.
+func robustScale(vs []float64) (med, scale float64) {
+	med = median(vs)
+	devs := make([]float64, len(vs))
+	sum := 0.0
+	for i, v := range vs {
+		devs[i] = math.Abs(v - med)
+		sum += devs[i]
+	}
+	if mad := median(devs); mad > 0 {
+		return med, madScale * mad
+	}
+	return med, meanADScale * sum / float64(len(vs))
+}
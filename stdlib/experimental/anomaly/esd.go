@@ -0,0 +1,228 @@
+package anomaly
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/semantic"
+	"gonum.org/v1/gonum/stat/distuv"
+)
+
+const ESDKind = "esd"
+
+const (
+	// DefaultESDMaxAnomalies is the default upper bound of the fraction of anomalous values.
+	DefaultESDMaxAnomalies = 0.1
+	// DefaultESDAlpha is the default significance level of the test.
+	DefaultESDAlpha = 0.05
+)
+
+type ESDOpSpec struct {
+	Seasonality  int64   `json:"seasonality"`
+	MaxAnomalies float64 `json:"maxAnomalies"`
+	Alpha        float64 `json:"alpha"`
+	DetectorConfig
+}
+
+func init() {
+	esdSignature := DetectorSignature(map[string]semantic.PolyType{
+		"seasonality":  semantic.Int,
+		"maxAnomalies": semantic.Float,
+		"alpha":        semantic.Float,
+	}, nil)
+
+	flux.RegisterPackageValue("experimental/anomaly", ESDKind, flux.FunctionValue(ESDKind, createESDOpSpec, esdSignature))
+	flux.RegisterOpSpec(ESDKind, newESDOp)
+	plan.RegisterProcedureSpec(ESDKind, newESDProcedure, ESDKind)
+	execute.RegisterTransformation(ESDKind, createESDTransformation)
+}
+
+func createESDOpSpec(args flux.Arguments, a *flux.Administration) (flux.OperationSpec, error) {
+	if err := a.AddParentFromArgs(args); err != nil {
+		return nil, err
+	}
+
+	spec := new(ESDOpSpec)
+	if seasonality, ok, err := args.GetInt("seasonality"); err != nil {
+		return nil, err
+	} else if ok {
+		if seasonality < 0 {
+			return nil, errors.New("seasonality must not be negative")
+		}
+		spec.Seasonality = seasonality
+	}
+
+	if maxAnomalies, ok, err := args.GetFloat("maxAnomalies"); err != nil {
+		return nil, err
+	} else if ok {
+		if maxAnomalies <= 0 || maxAnomalies > 0.5 {
+			return nil, errors.New("maxAnomalies must be greater than zero and at most 0.5")
+		}
+		spec.MaxAnomalies = maxAnomalies
+	} else {
+		spec.MaxAnomalies = DefaultESDMaxAnomalies
+	}
+
+	if alpha, ok, err := args.GetFloat("alpha"); err != nil {
+		return nil, err
+	} else if ok {
+		if alpha <= 0 || alpha >= 1 {
+			return nil, errors.New("alpha must be between zero and one")
+		}
+		spec.Alpha = alpha
+	} else {
+		spec.Alpha = DefaultESDAlpha
+	}
+
+	if err := spec.DetectorConfig.ReadArgs(args); err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
+
+func newESDOp() flux.OperationSpec {
+	return new(ESDOpSpec)
+}
+
+func (s *ESDOpSpec) Kind() flux.OperationKind {
+	return ESDKind
+}
+
+type ESDProcedureSpec struct {
+	plan.DefaultCost
+	Seasonality  int64   `json:"seasonality"`
+	MaxAnomalies float64 `json:"maxAnomalies"`
+	Alpha        float64 `json:"alpha"`
+	DetectorConfig
+}
+
+func newESDProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	spec, ok := qs.(*ESDOpSpec)
+	if !ok {
+		return nil, fmt.Errorf("invalid spec type %T", qs)
+	}
+	return &ESDProcedureSpec{
+		Seasonality:    spec.Seasonality,
+		MaxAnomalies:   spec.MaxAnomalies,
+		Alpha:          spec.Alpha,
+		DetectorConfig: spec.DetectorConfig,
+	}, nil
+}
+
+func (s *ESDProcedureSpec) Kind() plan.ProcedureKind {
+	return ESDKind
+}
+func (s *ESDProcedureSpec) Copy() plan.ProcedureSpec {
+	ns := new(ESDProcedureSpec)
+	*ns = *s
+	return ns
+}
+
+func createESDTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	s, ok := spec.(*ESDProcedureSpec)
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid spec type %T", spec)
+	}
+	cache := execute.NewTableBuilderCache(a.Allocator())
+	d := execute.NewDataset(id, mode, cache)
+	detector := &ESDDetector{
+		Seasonality:  int(s.Seasonality),
+		MaxAnomalies: s.MaxAnomalies,
+		Alpha:        s.Alpha,
+	}
+	t := NewDetectorTransformation(d, cache, detector, s.DetectorConfig)
+	return t, d, nil
+}
+
+// ESDDetector flags anomalous values with the seasonal hybrid extreme studentized deviate test.
+//
+// When Seasonality is set, the seasonal component of the values is removed first,
+// which is estimated as the median of the values at each position of the season.
+// The generalized ESD test is then run on the residuals using the median and the
+// median absolute deviation in place of the mean and the standard deviation,
+// so that the anomalies themselves do not mask each other.
+type ESDDetector struct {
+	// Seasonality is the number of values in a season, zero means the values are not seasonal.
+	Seasonality int
+	// MaxAnomalies is the upper bound of the fraction of values that can be flagged.
+	MaxAnomalies float64
+	// Alpha is the significance level of the test.
+	Alpha float64
+}
+
+func (d *ESDDetector) Detect(vs []float64) []bool {
+	flags := make([]bool, len(vs))
+	residuals := d.residuals(vs)
+
+	n := len(residuals)
+	k := int(d.MaxAnomalies * float64(n))
+	remaining := make([]int, n)
+	for i := range remaining {
+		remaining[i] = i
+	}
+	candidates := make([]int, 0, k)
+	anomalies := 0
+	rs := make([]float64, 0, n)
+	for i := 1; i <= k; i++ {
+		// nr is the number of values that remain before removing the i-th candidate.
+		nr := n - i + 1
+		if nr < 3 {
+			break
+		}
+		rs = rs[:0]
+		for _, idx := range remaining {
+			rs = append(rs, residuals[idx])
+		}
+		med, scale := robustScale(rs)
+		if scale == 0 {
+			break
+		}
+
+		maxDev, maxPos := -1.0, 0
+		for pos, r := range rs {
+			if dev := math.Abs(r - med); dev > maxDev {
+				maxDev, maxPos = dev, pos
+			}
+		}
+		candidates = append(candidates, remaining[maxPos])
+		remaining = append(remaining[:maxPos], remaining[maxPos+1:]...)
+
+		p := 1 - d.Alpha/(2*float64(nr))
+		t := distuv.StudentsT{Mu: 0, Sigma: 1, Nu: float64(nr - 2)}.Quantile(p)
+		critical := float64(nr-1) * t / math.Sqrt((float64(nr-2)+t*t)*float64(nr))
+		if maxDev/scale > critical {
+			anomalies = i
+		}
+	}
+	for _, idx := range candidates[:anomalies] {
+		flags[idx] = true
+	}
+	return flags
+}
+
+// residuals returns the values without their seasonal component and median.
+func (d *ESDDetector) residuals(vs []float64) []float64 {
+	m := d.Seasonality
+	if m <= 1 || len(vs) < 2*m {
+		return vs
+	}
+	med := median(vs)
+	seasonal := make([]float64, m)
+	season := make([]float64, 0, len(vs)/m+1)
+	for p := range seasonal {
+		season = season[:0]
+		for i := p; i < len(vs); i += m {
+			season = append(season, vs[i])
+		}
+		seasonal[p] = median(season) - med
+	}
+	residuals := make([]float64, len(vs))
+	for i, v := range vs {
+		residuals[i] = v - seasonal[i%m] - med
+	}
+	return residuals
+}
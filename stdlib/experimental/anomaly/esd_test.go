@@ -0,0 +1,80 @@
+package anomaly_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/querytest"
+	"github.com/influxdata/flux/stdlib/experimental/anomaly"
+)
+
+func TestESDOperation_Marshaling(t *testing.T) {
+	data := []byte(`{"id":"esd","kind":"esd","spec":{"seasonality":24,"maxAnomalies":0.05,"alpha":0.01,"column":"_value","flagColumn":"_anomaly"}}`)
+	op := &flux.Operation{
+		ID: "esd",
+		Spec: &anomaly.ESDOpSpec{
+			Seasonality:    24,
+			MaxAnomalies:   0.05,
+			Alpha:          0.01,
+			DetectorConfig: anomaly.DefaultDetectorConfig,
+		},
+	}
+	querytest.OperationMarshalingTestHelper(t, data, op)
+}
+
+func TestESDDetector(t *testing.T) {
+	testCases := []struct {
+		name     string
+		detector *anomaly.ESDDetector
+		values   []float64
+		want     []bool
+	}{
+		{
+			name: "outlier",
+			detector: &anomaly.ESDDetector{
+				MaxAnomalies: 0.2,
+				Alpha:        0.05,
+			},
+			values: []float64{1, 2, 1, 2, 1, 20, 1, 2, 1, 2},
+			want:   []bool{false, false, false, false, false, true, false, false, false, false},
+		},
+		{
+			name: "not seasonal",
+			detector: &anomaly.ESDDetector{
+				MaxAnomalies: 0.2,
+				Alpha:        0.05,
+			},
+			values: []float64{10, 20, 30, 10, 20, 30, 30, 20, 30, 10, 20, 30, 10, 20, 30},
+			want:   make([]bool, 15),
+		},
+		{
+			name: "seasonal",
+			detector: &anomaly.ESDDetector{
+				Seasonality:  3,
+				MaxAnomalies: 0.2,
+				Alpha:        0.05,
+			},
+			values: []float64{10, 20, 30, 10, 20, 30, 30, 20, 30, 10, 20, 30, 10, 20, 30},
+			want:   []bool{false, false, false, false, false, false, true, false, false, false, false, false, false, false, false},
+		},
+		{
+			name: "constant",
+			detector: &anomaly.ESDDetector{
+				MaxAnomalies: 0.5,
+				Alpha:        0.05,
+			},
+			values: []float64{3, 3, 3, 3},
+			want:   make([]bool, 4),
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.detector.Detect(tc.values)
+			if !cmp.Equal(tc.want, got) {
+				t.Errorf("unexpected flags -want/+got\n%s", cmp.Diff(tc.want, got))
+			}
+		})
+	}
+}
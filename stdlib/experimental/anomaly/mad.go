@@ -0,0 +1,124 @@
+package anomaly
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/semantic"
+)
+
+const MADKind = "mad"
+
+// DefaultMADThreshold is the default number of estimated standard deviations
+// past which a value is considered anomalous.
+const DefaultMADThreshold = 3.0
+
+type MADOpSpec struct {
+	Threshold float64 `json:"threshold"`
+	DetectorConfig
+}
+
+func init() {
+	madSignature := DetectorSignature(map[string]semantic.PolyType{
+		"threshold": semantic.Float,
+	}, nil)
+
+	flux.RegisterPackageValue("experimental/anomaly", MADKind, flux.FunctionValue(MADKind, createMADOpSpec, madSignature))
+	flux.RegisterOpSpec(MADKind, newMADOp)
+	plan.RegisterProcedureSpec(MADKind, newMADProcedure, MADKind)
+	execute.RegisterTransformation(MADKind, createMADTransformation)
+}
+
+func createMADOpSpec(args flux.Arguments, a *flux.Administration) (flux.OperationSpec, error) {
+	if err := a.AddParentFromArgs(args); err != nil {
+		return nil, err
+	}
+
+	spec := new(MADOpSpec)
+	if threshold, ok, err := args.GetFloat("threshold"); err != nil {
+		return nil, err
+	} else if ok {
+		if threshold <= 0 {
+			return nil, errors.New("threshold must be greater than zero")
+		}
+		spec.Threshold = threshold
+	} else {
+		spec.Threshold = DefaultMADThreshold
+	}
+
+	if err := spec.DetectorConfig.ReadArgs(args); err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
+
+func newMADOp() flux.OperationSpec {
+	return new(MADOpSpec)
+}
+
+func (s *MADOpSpec) Kind() flux.OperationKind {
+	return MADKind
+}
+
+type MADProcedureSpec struct {
+	plan.DefaultCost
+	Threshold float64 `json:"threshold"`
+	DetectorConfig
+}
+
+func newMADProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	spec, ok := qs.(*MADOpSpec)
+	if !ok {
+		return nil, fmt.Errorf("invalid spec type %T", qs)
+	}
+	return &MADProcedureSpec{
+		Threshold:      spec.Threshold,
+		DetectorConfig: spec.DetectorConfig,
+	}, nil
+}
+
+func (s *MADProcedureSpec) Kind() plan.ProcedureKind {
+	return MADKind
+}
+func (s *MADProcedureSpec) Copy() plan.ProcedureSpec {
+	ns := new(MADProcedureSpec)
+	*ns = *s
+	return ns
+}
+
+func createMADTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	s, ok := spec.(*MADProcedureSpec)
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid spec type %T", spec)
+	}
+	cache := execute.NewTableBuilderCache(a.Allocator())
+	d := execute.NewDataset(id, mode, cache)
+	t := NewDetectorTransformation(d, cache, &MADDetector{Threshold: s.Threshold}, s.DetectorConfig)
+	return t, d, nil
+}
+
+// MADDetector flags the values that deviate from the median by more than Threshold
+// standard deviations, where the standard deviation is estimated from the
+// median absolute deviation of the values.
+type MADDetector struct {
+	Threshold float64
+}
+
+func (d *MADDetector) Detect(vs []float64) []bool {
+	flags := make([]bool, len(vs))
+	if len(vs) == 0 {
+		return flags
+	}
+	med, scale := robustScale(vs)
+	if scale == 0 {
+		return flags
+	}
+	for i, v := range vs {
+		flags[i] = math.Abs(v-med)/scale > d.Threshold
+	}
+	return flags
+}
@@ -0,0 +1,162 @@
+package anomaly_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/flux/querytest"
+	"github.com/influxdata/flux/stdlib/experimental/anomaly"
+)
+
+func TestMADOperation_Marshaling(t *testing.T) {
+	data := []byte(`{"id":"mad","kind":"mad","spec":{"threshold":3.5,"column":"_value","flagColumn":"_anomaly"}}`)
+	op := &flux.Operation{
+		ID: "mad",
+		Spec: &anomaly.MADOpSpec{
+			Threshold:      3.5,
+			DetectorConfig: anomaly.DefaultDetectorConfig,
+		},
+	}
+	querytest.OperationMarshalingTestHelper(t, data, op)
+}
+
+func TestMAD_PassThrough(t *testing.T) {
+	executetest.TransformationPassThroughTestHelper(t, func(d execute.Dataset, c execute.TableBuilderCache) execute.Transformation {
+		s := anomaly.NewDetectorTransformation(
+			d,
+			c,
+			&anomaly.MADDetector{Threshold: anomaly.DefaultMADThreshold},
+			anomaly.DefaultDetectorConfig,
+		)
+		return s
+	})
+}
+
+func TestMAD_Process(t *testing.T) {
+	testCases := []struct {
+		name    string
+		config  anomaly.DetectorConfig
+		data    []flux.Table
+		want    []*executetest.Table
+		wantErr error
+	}{
+		{
+			name:   "float",
+			config: anomaly.DefaultDetectorConfig,
+			data: []flux.Table{&executetest.Table{
+				KeyCols: []string{"t1"},
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_value", Type: flux.TFloat},
+					{Label: "t1", Type: flux.TString},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), 1.0, "a"},
+					{execute.Time(2), 2.0, "a"},
+					{execute.Time(3), nil, "a"},
+					{execute.Time(4), 1.0, "a"},
+					{execute.Time(5), 20.0, "a"},
+					{execute.Time(6), 2.0, "a"},
+					{execute.Time(7), 1.0, "a"},
+				},
+			}},
+			want: []*executetest.Table{{
+				KeyCols: []string{"t1"},
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_value", Type: flux.TFloat},
+					{Label: "t1", Type: flux.TString},
+					{Label: "_anomaly", Type: flux.TBool},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), 1.0, "a", false},
+					{execute.Time(2), 2.0, "a", false},
+					{execute.Time(3), nil, "a", false},
+					{execute.Time(4), 1.0, "a", false},
+					{execute.Time(5), 20.0, "a", true},
+					{execute.Time(6), 2.0, "a", false},
+					{execute.Time(7), 1.0, "a", false},
+				},
+			}},
+		},
+		{
+			name: "int with more than half equal values",
+			config: anomaly.DetectorConfig{
+				Column:     "x",
+				FlagColumn: "outlier",
+			},
+			data: []flux.Table{&executetest.Table{
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "x", Type: flux.TInt},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), int64(5)},
+					{execute.Time(2), int64(5)},
+					{execute.Time(3), int64(5)},
+					{execute.Time(4), int64(5)},
+					{execute.Time(5), int64(6)},
+				},
+			}},
+			want: []*executetest.Table{{
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "x", Type: flux.TInt},
+					{Label: "outlier", Type: flux.TBool},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), int64(5), false},
+					{execute.Time(2), int64(5), false},
+					{execute.Time(3), int64(5), false},
+					{execute.Time(4), int64(5), false},
+					{execute.Time(5), int64(6), true},
+				},
+			}},
+		},
+		{
+			name:   "string",
+			config: anomaly.DefaultDetectorConfig,
+			data: []flux.Table{&executetest.Table{
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_value", Type: flux.TString},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), "a"},
+				},
+			}},
+			wantErr: errors.New("cannot detect anomalies over string"),
+		},
+		{
+			name:   "existing flag column",
+			config: anomaly.DefaultDetectorConfig,
+			data: []flux.Table{&executetest.Table{
+				ColMeta: []flux.ColMeta{
+					{Label: "_value", Type: flux.TFloat},
+					{Label: "_anomaly", Type: flux.TBool},
+				},
+				Data: [][]interface{}{
+					{1.0, false},
+				},
+			}},
+			wantErr: errors.New(`column "_anomaly" already exists`),
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			executetest.ProcessTestHelper(
+				t,
+				tc.data,
+				tc.want,
+				tc.wantErr,
+				func(d execute.Dataset, c execute.TableBuilderCache) execute.Transformation {
+					return anomaly.NewDetectorTransformation(d, c, &anomaly.MADDetector{Threshold: anomaly.DefaultMADThreshold}, tc.config)
+				},
+			)
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package anomaly
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/semantic"
+	"gonum.org/v1/gonum/stat"
+)
+
+const ZScoreKind = "zscore"
+
+// DefaultZScoreThreshold is the default z-score past which a value is considered anomalous.
+const DefaultZScoreThreshold = 3.0
+
+type ZScoreOpSpec struct {
+	Threshold float64 `json:"threshold"`
+	DetectorConfig
+}
+
+func init() {
+	zscoreSignature := DetectorSignature(map[string]semantic.PolyType{
+		"threshold": semantic.Float,
+	}, nil)
+
+	flux.RegisterPackageValue("experimental/anomaly", ZScoreKind, flux.FunctionValue(ZScoreKind, createZScoreOpSpec, zscoreSignature))
+	flux.RegisterOpSpec(ZScoreKind, newZScoreOp)
+	plan.RegisterProcedureSpec(ZScoreKind, newZScoreProcedure, ZScoreKind)
+	execute.RegisterTransformation(ZScoreKind, createZScoreTransformation)
+}
+
+func createZScoreOpSpec(args flux.Arguments, a *flux.Administration) (flux.OperationSpec, error) {
+	if err := a.AddParentFromArgs(args); err != nil {
+		return nil, err
+	}
+
+	spec := new(ZScoreOpSpec)
+	if threshold, ok, err := args.GetFloat("threshold"); err != nil {
+		return nil, err
+	} else if ok {
+		if threshold <= 0 {
+			return nil, errors.New("threshold must be greater than zero")
+		}
+		spec.Threshold = threshold
+	} else {
+		spec.Threshold = DefaultZScoreThreshold
+	}
+
+	if err := spec.DetectorConfig.ReadArgs(args); err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
+
+func newZScoreOp() flux.OperationSpec {
+	return new(ZScoreOpSpec)
+}
+
+func (s *ZScoreOpSpec) Kind() flux.OperationKind {
+	return ZScoreKind
+}
+
+type ZScoreProcedureSpec struct {
+	plan.DefaultCost
+	Threshold float64 `json:"threshold"`
+	DetectorConfig
+}
+
+func newZScoreProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	spec, ok := qs.(*ZScoreOpSpec)
+	if !ok {
+		return nil, fmt.Errorf("invalid spec type %T", qs)
+	}
+	return &ZScoreProcedureSpec{
+		Threshold:      spec.Threshold,
+		DetectorConfig: spec.DetectorConfig,
+	}, nil
+}
+
+func (s *ZScoreProcedureSpec) Kind() plan.ProcedureKind {
+	return ZScoreKind
+}
+func (s *ZScoreProcedureSpec) Copy() plan.ProcedureSpec {
+	ns := new(ZScoreProcedureSpec)
+	*ns = *s
+	return ns
+}
+
+func createZScoreTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	s, ok := spec.(*ZScoreProcedureSpec)
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid spec type %T", spec)
+	}
+	cache := execute.NewTableBuilderCache(a.Allocator())
+	d := execute.NewDataset(id, mode, cache)
+	t := NewDetectorTransformation(d, cache, &ZScoreDetector{Threshold: s.Threshold}, s.DetectorConfig)
+	return t, d, nil
+}
+
+// ZScoreDetector flags the values that deviate from the mean by more than Threshold standard deviations.
+type ZScoreDetector struct {
+	Threshold float64
+}
+
+func (d *ZScoreDetector) Detect(vs []float64) []bool {
+	flags := make([]bool, len(vs))
+	if len(vs) < 2 {
+		return flags
+	}
+	mean, stddev := stat.MeanStdDev(vs, nil)
+	if stddev == 0 {
+		return flags
+	}
+	for i, v := range vs {
+		flags[i] = math.Abs(v-mean)/stddev > d.Threshold
+	}
+	return flags
+}
@@ -0,0 +1,72 @@
+package anomaly_test
+
+import (
+	"testing"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/flux/querytest"
+	"github.com/influxdata/flux/stdlib/experimental/anomaly"
+)
+
+func TestZScoreOperation_Marshaling(t *testing.T) {
+	data := []byte(`{"id":"zscore","kind":"zscore","spec":{"threshold":2,"column":"_value","flagColumn":"_anomaly"}}`)
+	op := &flux.Operation{
+		ID: "zscore",
+		Spec: &anomaly.ZScoreOpSpec{
+			Threshold:      2,
+			DetectorConfig: anomaly.DefaultDetectorConfig,
+		},
+	}
+	querytest.OperationMarshalingTestHelper(t, data, op)
+}
+
+func TestZScore_Process(t *testing.T) {
+	data := []flux.Table{&executetest.Table{
+		ColMeta: []flux.ColMeta{
+			{Label: "_time", Type: flux.TTime},
+			{Label: "_value", Type: flux.TUInt},
+		},
+		Data: [][]interface{}{
+			{execute.Time(1), uint64(1)},
+			{execute.Time(2), uint64(2)},
+			{execute.Time(3), uint64(1)},
+			{execute.Time(4), uint64(2)},
+			{execute.Time(5), uint64(1)},
+			{execute.Time(6), uint64(20)},
+			{execute.Time(7), uint64(1)},
+			{execute.Time(8), uint64(2)},
+			{execute.Time(9), uint64(1)},
+			{execute.Time(10), uint64(2)},
+		},
+	}}
+	want := []*executetest.Table{{
+		ColMeta: []flux.ColMeta{
+			{Label: "_time", Type: flux.TTime},
+			{Label: "_value", Type: flux.TUInt},
+			{Label: "_anomaly", Type: flux.TBool},
+		},
+		Data: [][]interface{}{
+			{execute.Time(1), uint64(1), false},
+			{execute.Time(2), uint64(2), false},
+			{execute.Time(3), uint64(1), false},
+			{execute.Time(4), uint64(2), false},
+			{execute.Time(5), uint64(1), false},
+			{execute.Time(6), uint64(20), true},
+			{execute.Time(7), uint64(1), false},
+			{execute.Time(8), uint64(2), false},
+			{execute.Time(9), uint64(1), false},
+			{execute.Time(10), uint64(2), false},
+		},
+	}}
+	executetest.ProcessTestHelper(
+		t,
+		data,
+		want,
+		nil,
+		func(d execute.Dataset, c execute.TableBuilderCache) execute.Transformation {
+			return anomaly.NewDetectorTransformation(d, c, &anomaly.ZScoreDetector{Threshold: 2}, anomaly.DefaultDetectorConfig)
+		},
+	)
+}
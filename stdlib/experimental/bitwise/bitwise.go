@@ -0,0 +1,118 @@
+// Package bitwise contains the bitwise operations on int and uint values.
+//
+// The functions that operate on uint values are prefixed with u, and those that operate
+// on int values, in two's complement, with s.
+package bitwise
+
+import (
+	"fmt"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/flux/values"
+)
+
+func init() {
+	for name, fn := range map[string]func(a, b uint64) uint64{
+		"uand":    func(a, b uint64) uint64 { return a & b },
+		"uor":     func(a, b uint64) uint64 { return a | b },
+		"uxor":    func(a, b uint64) uint64 { return a ^ b },
+		"uclear":  func(a, b uint64) uint64 { return a &^ b },
+		"ulshift": func(a, b uint64) uint64 { return a << b },
+		"urshift": func(a, b uint64) uint64 { return a >> b },
+	} {
+		fn := fn
+		flux.RegisterPackageValue("experimental/bitwise", name, makeBinaryFunction(name, semantic.UInt, func(a, b values.Value) (values.Value, error) {
+			return values.NewUInt(fn(a.UInt(), b.UInt())), nil
+		}))
+	}
+	flux.RegisterPackageValue("experimental/bitwise", "unot", makeUnaryFunction("unot", semantic.UInt, func(a values.Value) values.Value {
+		return values.NewUInt(^a.UInt())
+	}))
+
+	for name, fn := range map[string]func(a, b int64) (int64, error){
+		"sand":    func(a, b int64) (int64, error) { return a & b, nil },
+		"sor":     func(a, b int64) (int64, error) { return a | b, nil },
+		"sxor":    func(a, b int64) (int64, error) { return a ^ b, nil },
+		"sclear":  func(a, b int64) (int64, error) { return a &^ b, nil },
+		"slshift": shiftFunction(func(a int64, b uint64) int64 { return a << b }),
+		"srshift": shiftFunction(func(a int64, b uint64) int64 { return a >> b }),
+	} {
+		fn := fn
+		flux.RegisterPackageValue("experimental/bitwise", name, makeBinaryFunction(name, semantic.Int, func(a, b values.Value) (values.Value, error) {
+			v, err := fn(a.Int(), b.Int())
+			if err != nil {
+				return nil, err
+			}
+			return values.NewInt(v), nil
+		}))
+	}
+	flux.RegisterPackageValue("experimental/bitwise", "snot", makeUnaryFunction("snot", semantic.Int, func(a values.Value) values.Value {
+		return values.NewInt(^a.Int())
+	}))
+}
+
+// shiftFunction returns a shift of int values whose shift count cannot be negative.
+// The right shift of an int is arithmetic, it keeps the sign of the value.
+func shiftFunction(shift func(a int64, b uint64) int64) func(a, b int64) (int64, error) {
+	return func(a, b int64) (int64, error) {
+		if b < 0 {
+			return 0, fmt.Errorf("cannot shift by a negative count %d", b)
+		}
+		return shift(a, uint64(b)), nil
+	}
+}
+
+// makeBinaryFunction returns a function of the arguments a and b of type t.
+func makeBinaryFunction(name string, t semantic.Nature, fn func(a, b values.Value) (values.Value, error)) values.Function {
+	return values.NewFunction(
+		name,
+		semantic.NewFunctionPolyType(semantic.NewSignatureBuilder().
+			Required("a", t).
+			Required("b", t).
+			Return(t).
+			MustBuild()),
+		func(args values.Object) (values.Value, error) {
+			a, err := getArg(args, "a", t)
+			if err != nil {
+				return nil, err
+			}
+			b, err := getArg(args, "b", t)
+			if err != nil {
+				return nil, err
+			}
+			return fn(a, b)
+		},
+		false,
+	)
+}
+
+// makeUnaryFunction returns a function of the argument a of type t.
+func makeUnaryFunction(name string, t semantic.Nature, fn func(a values.Value) values.Value) values.Function {
+	return values.NewFunction(
+		name,
+		semantic.NewFunctionPolyType(semantic.NewSignatureBuilder().
+			Required("a", t).
+			Return(t).
+			MustBuild()),
+		func(args values.Object) (values.Value, error) {
+			a, err := getArg(args, "a", t)
+			if err != nil {
+				return nil, err
+			}
+			return fn(a), nil
+		},
+		false,
+	)
+}
+
+func getArg(args values.Object, name string, t semantic.Nature) (values.Value, error) {
+	v, ok := args.Get(name)
+	if !ok {
+		return nil, fmt.Errorf("missing argument %q", name)
+	}
+	if v.Type().Nature() != t {
+		return nil, fmt.Errorf("argument %q must be of type %v, got %v", name, t, v.Type().Nature())
+	}
+	return v, nil
+}
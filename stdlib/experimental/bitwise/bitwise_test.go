@@ -0,0 +1,60 @@
+package bitwise_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/influxdata/flux"
+	_ "github.com/influxdata/flux/builtin"
+	"github.com/influxdata/flux/values"
+)
+
+func TestBitwise(t *testing.T) {
+	testCases := []struct {
+		expr string
+		want values.Value
+	}{
+		{expr: `bitwise.uand(a: uint(v: 12), b: uint(v: 10))`, want: values.NewUInt(8)},
+		{expr: `bitwise.uor(a: uint(v: 12), b: uint(v: 10))`, want: values.NewUInt(14)},
+		{expr: `bitwise.uxor(a: uint(v: 12), b: uint(v: 10))`, want: values.NewUInt(6)},
+		{expr: `bitwise.uclear(a: uint(v: 12), b: uint(v: 10))`, want: values.NewUInt(4)},
+		{expr: `bitwise.unot(a: uint(v: 0))`, want: values.NewUInt(1<<64 - 1)},
+		{expr: `bitwise.ulshift(a: uint(v: 3), b: uint(v: 2))`, want: values.NewUInt(12)},
+		{expr: `bitwise.urshift(a: uint(v: 12), b: uint(v: 2))`, want: values.NewUInt(3)},
+		{expr: `bitwise.ulshift(a: uint(v: 1), b: uint(v: 64))`, want: values.NewUInt(0)},
+		{expr: `bitwise.sand(a: 12, b: 10)`, want: values.NewInt(8)},
+		{expr: `bitwise.sor(a: 12, b: 10)`, want: values.NewInt(14)},
+		{expr: `bitwise.sxor(a: 12, b: -1)`, want: values.NewInt(-13)},
+		{expr: `bitwise.sclear(a: 12, b: 10)`, want: values.NewInt(4)},
+		{expr: `bitwise.snot(a: 0)`, want: values.NewInt(-1)},
+		{expr: `bitwise.slshift(a: 3, b: 2)`, want: values.NewInt(12)},
+		// The right shift of an int keeps its sign.
+		{expr: `bitwise.srshift(a: -12, b: 2)`, want: values.NewInt(-3)},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.expr, func(t *testing.T) {
+			_, scope, err := flux.Eval("import \"experimental/bitwise\"\nx = " + tc.expr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, ok := scope.Lookup("x")
+			if !ok {
+				t.Fatal("x is not defined")
+			}
+			if !got.Equal(tc.want) {
+				t.Errorf("unexpected value: want %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestBitwise_NegativeShift(t *testing.T) {
+	_, _, err := flux.Eval(`
+import "experimental/bitwise"
+
+x = bitwise.slshift(a: 1, b: -1)`)
+	if err == nil || !strings.Contains(err.Error(), "cannot shift by a negative count") {
+		t.Errorf("expected a negative shift count error, got %v", err)
+	}
+}
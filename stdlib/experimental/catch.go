@@ -0,0 +1,208 @@
+package experimental
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/semantic"
+)
+
+const CatchKind = "experimentalCatch"
+
+// CatchOpSpec outputs the tables of its input, or the tables of a fallback if its input fails.
+// The tables of the fallback are the second parent of the operation, if there is a fallback.
+type CatchOpSpec struct{}
+
+func init() {
+	catchSignature := semantic.FunctionPolySignature{
+		Parameters: map[string]semantic.PolyType{
+			"tables":   flux.TableObjectType,
+			"fallback": flux.TableObjectType,
+		},
+		Return:       flux.TableObjectType,
+		PipeArgument: "tables",
+	}
+
+	flux.RegisterPackageValue("experimental", "catch", flux.FunctionValue(CatchKind, createCatchOpSpec, catchSignature))
+	flux.RegisterOpSpec(CatchKind, newCatchOp)
+	plan.RegisterProcedureSpec(CatchKind, newCatchProcedure, CatchKind)
+	execute.RegisterTransformation(CatchKind, createCatchTransformation)
+}
+
+func createCatchOpSpec(args flux.Arguments, a *flux.Administration) (flux.OperationSpec, error) {
+	if err := a.AddParentFromArgs(args); err != nil {
+		return nil, err
+	}
+	fallback, ok := args.Get("fallback")
+	if ok {
+		p, ok := fallback.(*flux.TableObject)
+		if !ok {
+			return nil, fmt.Errorf("fallback of %s is not a stream of tables", CatchKind)
+		}
+		a.AddParent(p)
+	}
+	return &CatchOpSpec{}, nil
+}
+
+func newCatchOp() flux.OperationSpec {
+	return new(CatchOpSpec)
+}
+
+func (s *CatchOpSpec) Kind() flux.OperationKind {
+	return CatchKind
+}
+
+type CatchProcedureSpec struct {
+	plan.DefaultCost
+}
+
+func newCatchProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	if _, ok := qs.(*CatchOpSpec); !ok {
+		return nil, fmt.Errorf("invalid spec type %T", qs)
+	}
+	return &CatchProcedureSpec{}, nil
+}
+
+func (s *CatchProcedureSpec) Kind() plan.ProcedureKind {
+	return CatchKind
+}
+
+func (s *CatchProcedureSpec) Copy() plan.ProcedureSpec {
+	return &CatchProcedureSpec{}
+}
+
+func createCatchTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	if _, ok := spec.(*CatchProcedureSpec); !ok {
+		return nil, nil, fmt.Errorf("invalid spec type %T", spec)
+	}
+	cache := execute.NewTableBuilderCache(a.Allocator())
+	d := execute.NewDataset(id, mode, cache)
+	t := NewCatchTransformation(d, cache, a)
+	return t, d, nil
+}
+
+// catchTransformation holds back the tables of its parents until they have all finished.
+// It then outputs the tables of its input or, if the input has failed, the tables of the fallback.
+// The fallback is executed even if its tables are not used.
+type catchTransformation struct {
+	mu sync.Mutex
+
+	d     execute.Dataset
+	cache execute.TableBuilderCache
+	a     execute.Administration
+
+	input       execute.DatasetID
+	parentState map[execute.DatasetID]*catchParentState
+}
+
+type catchParentState struct {
+	// tables holds the copies of the tables of the parent.
+	tables   []flux.Table
+	finished bool
+	err      error
+}
+
+func NewCatchTransformation(d execute.Dataset, cache execute.TableBuilderCache, a execute.Administration) *catchTransformation {
+	parents := a.Parents()
+	parentState := make(map[execute.DatasetID]*catchParentState, len(parents))
+	for _, id := range parents {
+		parentState[id] = new(catchParentState)
+	}
+	return &catchTransformation{
+		d:           d,
+		cache:       cache,
+		a:           a,
+		input:       parents[0],
+		parentState: parentState,
+	}
+}
+
+func (t *catchTransformation) RetractTable(id execute.DatasetID, key flux.GroupKey) error {
+	panic("not implemented")
+}
+
+func (t *catchTransformation) Process(id execute.DatasetID, tbl flux.Table) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if input := t.parentState[t.input]; id != t.input && input.finished && input.err == nil {
+		// The fallback is not used.
+		return nil
+	}
+	// The table is only valid during this call.
+	buffered, err := execute.CopyTable(tbl, t.a.Allocator())
+	if err != nil {
+		return err
+	}
+	state := t.parentState[id]
+	state.tables = append(state.tables, buffered)
+	return nil
+}
+
+// UpdateWatermark does not forward the watermark, so that no table is output before the parents have finished.
+func (t *catchTransformation) UpdateWatermark(id execute.DatasetID, mark execute.Time) error {
+	return nil
+}
+
+// UpdateProcessingTime does not forward the processing time, so that no table is output before the parents have finished.
+func (t *catchTransformation) UpdateProcessingTime(id execute.DatasetID, pt execute.Time) error {
+	return nil
+}
+
+func (t *catchTransformation) Finish(id execute.DatasetID, err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	state := t.parentState[id]
+	state.finished, state.err = true, err
+	if id == t.input {
+		if err != nil {
+			// The error of the input is reported as a warning and its tables are discarded.
+			t.a.Warnf("%s caught an error: %v", CatchKind, err)
+			state.tables = nil
+		} else {
+			for pid, state := range t.parentState {
+				if pid != t.input {
+					state.tables, state.err = nil, nil
+				}
+			}
+		}
+	}
+	for _, state := range t.parentState {
+		if !state.finished {
+			return
+		}
+	}
+
+	out := t.parentState[t.input]
+	if out.err != nil {
+		out = &catchParentState{}
+		for pid, state := range t.parentState {
+			if pid != t.input {
+				out = state
+			}
+		}
+	}
+	if out.err != nil {
+		t.d.Finish(out.err)
+		return
+	}
+	for _, tbl := range out.tables {
+		builder, created := t.cache.TableBuilder(tbl.Key())
+		if created {
+			if err := execute.AddTableCols(tbl, builder); err != nil {
+				t.d.Finish(err)
+				return
+			}
+		}
+		if err := execute.AppendTable(tbl, builder); err != nil {
+			t.d.Finish(err)
+			return
+		}
+	}
+	for _, state := range t.parentState {
+		state.tables = nil
+	}
+	t.d.Finish(nil)
+}
@@ -0,0 +1,120 @@
+package experimental_test
+
+import (
+	"context"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/flux"
+	_ "github.com/influxdata/flux/builtin"
+	"github.com/influxdata/flux/control"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/flux/lang"
+)
+
+func TestCatch(t *testing.T) {
+	values := func(vs ...int64) []*executetest.Table {
+		tbl := &executetest.Table{
+			ColMeta: []flux.ColMeta{{Label: "_value", Type: flux.TInt}},
+		}
+		for _, v := range vs {
+			tbl.Data = append(tbl.Data, []interface{}{v})
+		}
+		return []*executetest.Table{tbl}
+	}
+	const (
+		input  = `generate.from(count: 3, fn: (n) => n)`
+		failed = `generate.from(count: 3, fn: (n) => n) |> filter(fn: (r) => r._value < 2 or die(msg: "value is too large"))`
+	)
+	testCases := []struct {
+		name        string
+		script      string
+		want        []*executetest.Table
+		wantWarning bool
+		wantErr     string
+	}{
+		{
+			name:   "input",
+			script: input + ` |> experimental.catch(fallback: generate.from(count: 1, fn: (n) => -1))`,
+			want:   values(0, 1, 2),
+		},
+		{
+			name:        "fallback",
+			script:      failed + ` |> experimental.catch(fallback: generate.from(count: 1, fn: (n) => -1))`,
+			want:        values(-1),
+			wantWarning: true,
+		},
+		{
+			name:        "no fallback",
+			script:      failed + ` |> experimental.catch()`,
+			wantWarning: true,
+		},
+		{
+			name:   "failed fallback of a successful input",
+			script: input + ` |> experimental.catch(fallback: ` + failed + `)`,
+			want:   values(0, 1, 2),
+		},
+		{
+			name:        "failed fallback",
+			script:      failed + ` |> experimental.catch(fallback: ` + failed + `)`,
+			wantWarning: true,
+			wantErr:     "value is too large",
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			c := control.New(control.Config{
+				ConcurrencyQuota: 1,
+				MemoryBytesQuota: math.MaxInt64,
+			})
+			defer func() {
+				if err := c.Shutdown(context.Background()); err != nil {
+					t.Fatal(err)
+				}
+			}()
+			script := "import \"experimental\"\nimport \"generate\"\n" + tc.script
+			q, err := c.Query(context.Background(), lang.FluxCompiler{Query: script})
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer q.Done()
+
+			var (
+				got      []*executetest.Table
+				warnings []flux.Warning
+			)
+			for _, r := range <-q.Ready() {
+				err = r.Tables().Do(func(tbl flux.Table) error {
+					ct, err := executetest.ConvertTable(tbl)
+					if err != nil {
+						return err
+					}
+					got = append(got, ct)
+					return nil
+				})
+				warnings = append(warnings, r.(flux.Warner).Warnings()...)
+			}
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("expected an error containing %q, got %v", tc.wantErr, err)
+				}
+			} else if err != nil {
+				t.Fatal(err)
+			}
+			if got, want := len(warnings) > 0, tc.wantWarning; got != want {
+				t.Errorf("unexpected warnings, want some: %v, got %v", want, warnings)
+			}
+			if tc.wantErr != "" {
+				return
+			}
+			executetest.NormalizeTables(tc.want)
+			executetest.NormalizeTables(got)
+			if !cmp.Equal(tc.want, got) {
+				t.Errorf("unexpected tables -want/+got\n%s", cmp.Diff(tc.want, got))
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+// Package experimental contains functions that compose pipelines in ways that
+// cannot be expressed by a single graph of transformations, such as running a
+// pipeline to completion before another one, or applying a pipeline to every
+// table of a stream.
+package experimental
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/flux/values"
+)
+
+const ChainKind = "chain"
+
+func init() {
+	chainSignature := semantic.FunctionPolySignature{
+		Parameters: map[string]semantic.PolyType{
+			"first":  flux.TableObjectType,
+			"second": flux.TableObjectType,
+		},
+		Required: semantic.LabelSet{"first", "second"},
+		Return:   flux.TableObjectType,
+	}
+	flux.RegisterPackageValue("experimental", "chain", values.NewContextFunction(
+		ChainKind,
+		semantic.NewFunctionPolyType(chainSignature),
+		chain,
+		false,
+	))
+}
+
+// chain executes the pipeline of first to completion and returns second,
+// so that the side effects of first, such as writes, are seen by second.
+func chain(ctx context.Context, args values.Object) (values.Value, error) {
+	first, err := tableObjectArg(args, "first")
+	if err != nil {
+		return nil, err
+	}
+	second, err := tableObjectArg(args, "second")
+	if err != nil {
+		return nil, err
+	}
+	if err := flux.EvalTables(ctx, first, func(tbl flux.Table) error {
+		// Read the table so that the pipeline runs to completion.
+		return tbl.Do(func(flux.ColReader) error { return nil })
+	}); err != nil {
+		return nil, err
+	}
+	return second, nil
+}
+
+func tableObjectArg(args values.Object, name string) (*flux.TableObject, error) {
+	v, ok := args.Get(name)
+	if !ok {
+		return nil, fmt.Errorf("missing argument %s", name)
+	}
+	t, ok := v.(*flux.TableObject)
+	if !ok {
+		return nil, fmt.Errorf("argument %s must be a stream of tables", name)
+	}
+	return t, nil
+}
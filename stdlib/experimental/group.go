@@ -0,0 +1,143 @@
+package experimental
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/interpreter"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/flux/stdlib/universe"
+	"github.com/influxdata/flux/values"
+)
+
+const (
+	GroupKind        = "experimentalGroup"
+	GroupColumnsKind = "groupColumns"
+
+	groupModeExtend = "extend"
+)
+
+// GroupOpSpec regroups the tables of its input with the group keys of the tables
+// extended with the columns.
+type GroupOpSpec struct {
+	Mode    string   `json:"mode"`
+	Columns []string `json:"columns"`
+}
+
+func init() {
+	groupSignature := flux.FunctionSignature(
+		map[string]semantic.PolyType{
+			"mode":    semantic.String,
+			"columns": semantic.NewArrayPolyType(semantic.String),
+		},
+		[]string{"mode", "columns"},
+	)
+
+	flux.RegisterPackageValue("experimental", "group", flux.FunctionValue(GroupKind, createGroupOpSpec, groupSignature))
+	flux.RegisterOpSpec(GroupKind, newGroupOp)
+	plan.RegisterProcedureSpec(GroupKind, newGroupProcedure, GroupKind)
+
+	groupColumnsSignature := semantic.FunctionPolySignature{
+		Parameters: map[string]semantic.PolyType{
+			"tables": flux.TableObjectType,
+		},
+		Return:       semantic.NewArrayPolyType(semantic.String),
+		PipeArgument: "tables",
+	}
+	flux.RegisterPackageValue("experimental", GroupColumnsKind, values.NewContextFunction(
+		GroupColumnsKind,
+		semantic.NewFunctionPolyType(groupColumnsSignature),
+		groupColumns,
+		false,
+	))
+}
+
+func createGroupOpSpec(args flux.Arguments, a *flux.Administration) (flux.OperationSpec, error) {
+	if err := a.AddParentFromArgs(args); err != nil {
+		return nil, err
+	}
+
+	mode, err := args.GetRequiredString("mode")
+	if err != nil {
+		return nil, err
+	}
+	if mode != groupModeExtend {
+		return nil, fmt.Errorf(`invalid group mode %q: must be "extend"`, mode)
+	}
+
+	columns, err := args.GetRequiredArray("columns", semantic.String)
+	if err != nil {
+		return nil, err
+	}
+	spec := &GroupOpSpec{Mode: mode}
+	if spec.Columns, err = interpreter.ToStringArray(columns); err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
+
+func newGroupOp() flux.OperationSpec {
+	return new(GroupOpSpec)
+}
+
+func (s *GroupOpSpec) Kind() flux.OperationKind {
+	return GroupKind
+}
+
+// newGroupProcedure plans experimental.group as a universe group in the extend mode,
+// so that it is executed by the group transformation.
+func newGroupProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	spec, ok := qs.(*GroupOpSpec)
+	if !ok {
+		return nil, fmt.Errorf("invalid spec type %T", qs)
+	}
+	if spec.Mode != groupModeExtend {
+		return nil, fmt.Errorf(`invalid group mode %q: must be "extend"`, spec.Mode)
+	}
+
+	keys := make([]string, len(spec.Columns))
+	copy(keys, spec.Columns)
+	return &universe.GroupProcedureSpec{
+		GroupMode: flux.GroupModeExtend,
+		GroupKeys: keys,
+	}, nil
+}
+
+// groupColumns executes the pipeline of its tables and returns the sorted labels
+// of the columns of their group keys, so that the grouping can be restored with group(columns:).
+func groupColumns(ctx context.Context, args values.Object) (values.Value, error) {
+	v, ok := args.Get("tables")
+	if !ok {
+		return nil, errors.New("missing argument tables")
+	}
+	to, ok := v.(*flux.TableObject)
+	if !ok {
+		return nil, fmt.Errorf("argument tables must be a stream of tables, got %v", v.Type().Nature())
+	}
+
+	seen := make(map[string]bool)
+	if err := flux.EvalTables(ctx, to, func(tbl flux.Table) error {
+		for _, c := range tbl.Key().Cols() {
+			seen[c.Label] = true
+		}
+		// Read the table so that the pipeline runs to completion.
+		return tbl.Do(func(flux.ColReader) error { return nil })
+	}); err != nil {
+		return nil, err
+	}
+
+	labels := make([]string, 0, len(seen))
+	for label := range seen {
+		labels = append(labels, label)
+	}
+	sort.Strings(labels)
+	vs := make([]values.Value, len(labels))
+	for i, label := range labels {
+		vs[i] = values.NewString(label)
+	}
+	return values.NewArrayWithBacking(semantic.String, vs), nil
+}
@@ -0,0 +1,116 @@
+// DO NOT EDIT: This file is autogenerated via the builtin command.
+
+package inference
+
+import (
+	flux "github.com/influxdata/flux"
+	ast "github.com/influxdata/flux/ast"
+	docs "github.com/influxdata/flux/docs"
+)
+
+func init() {
+	flux.RegisterPackage(pkgAST)
+	docs.RegisterComments("experimental/inference", pkgComments)
+}
+
+var pkgAST = &ast.Package{
+	BaseNode: ast.BaseNode{
+		Errors: nil,
+		Loc:    nil,
+	},
+	Files: []*ast.File{&ast.File{
+		BaseNode: ast.BaseNode{
+			Errors: nil,
+			Loc: &ast.SourceLocation{
+				End: ast.Position{
+					Column: 14,
+					Line:   5,
+				},
+				File:   "inference.flux",
+				Source: "package inference\n\n// score adds the outputs of the model of the format found at the location to every record,\n// computed from the columns named after the inputs of the model.\nbuiltin score",
+				Start: ast.Position{
+					Column: 1,
+					Line:   1,
+				},
+			},
+		},
+		Body: []ast.Statement{&ast.BuiltinStatement{
+			BaseNode: ast.BaseNode{
+				Errors: nil,
+				Loc: &ast.SourceLocation{
+					End: ast.Position{
+						Column: 14,
+						Line:   5,
+					},
+					File:   "inference.flux",
+					Source: "builtin score",
+					Start: ast.Position{
+						Column: 1,
+						Line:   5,
+					},
+				},
+			},
+			ID: &ast.Identifier{
+				BaseNode: ast.BaseNode{
+					Errors: nil,
+					Loc: &ast.SourceLocation{
+						End: ast.Position{
+							Column: 14,
+							Line:   5,
+						},
+						File:   "inference.flux",
+						Source: "score",
+						Start: ast.Position{
+							Column: 9,
+							Line:   5,
+						},
+					},
+				},
+				Name: "score",
+			},
+		}},
+		Imports: nil,
+		Name:    "inference.flux",
+		Package: &ast.PackageClause{
+			BaseNode: ast.BaseNode{
+				Errors: nil,
+				Loc: &ast.SourceLocation{
+					End: ast.Position{
+						Column: 18,
+						Line:   1,
+					},
+					File:   "inference.flux",
+					Source: "package inference",
+					Start: ast.Position{
+						Column: 1,
+						Line:   1,
+					},
+				},
+			},
+			Name: &ast.Identifier{
+				BaseNode: ast.BaseNode{
+					Errors: nil,
+					Loc: &ast.SourceLocation{
+						End: ast.Position{
+							Column: 18,
+							Line:   1,
+						},
+						File:   "inference.flux",
+						Source: "inference",
+						Start: ast.Position{
+							Column: 9,
+							Line:   1,
+						},
+					},
+				},
+				Name: "inference",
+			},
+		},
+	}},
+	Package: "inference",
+	Path:    "experimental/inference",
+}
+var pkgComments = docs.Comments{
+	Package: "",
+	Values:  map[string]string{"score": "score adds the outputs of the model of the format found at the location to every record,\ncomputed from the columns named after the inputs of the model."},
+}
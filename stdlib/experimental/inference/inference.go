@@ -0,0 +1,245 @@
+// Package inference scores the records of tables with models trained offline.
+//
+// The models are loaded by the ModelRuntime of the dependencies of the query,
+// so that embedders choose the formats they support, such as ONNX or PMML,
+// and where the models are stored.
+package inference
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/dependencies"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/semantic"
+)
+
+const ScoreKind = "inferenceScore"
+
+type ScoreOpSpec struct {
+	Format   string `json:"format"`
+	Location string `json:"location"`
+}
+
+func init() {
+	scoreSignature := flux.FunctionSignature(
+		map[string]semantic.PolyType{
+			"format":   semantic.String,
+			"location": semantic.String,
+		},
+		[]string{"format", "location"},
+	)
+
+	flux.RegisterPackageValue("experimental/inference", "score", flux.FunctionValue(ScoreKind, createScoreOpSpec, scoreSignature))
+	flux.RegisterOpSpec(ScoreKind, newScoreOp)
+	plan.RegisterProcedureSpec(ScoreKind, newScoreProcedure, ScoreKind)
+	execute.RegisterTransformation(ScoreKind, createScoreTransformation)
+}
+
+func createScoreOpSpec(args flux.Arguments, a *flux.Administration) (flux.OperationSpec, error) {
+	if err := a.AddParentFromArgs(args); err != nil {
+		return nil, err
+	}
+
+	spec := new(ScoreOpSpec)
+	var err error
+	if spec.Format, err = args.GetRequiredString("format"); err != nil {
+		return nil, err
+	}
+	if spec.Location, err = args.GetRequiredString("location"); err != nil {
+		return nil, err
+	}
+	if spec.Location == "" {
+		return nil, errors.New("location cannot be empty")
+	}
+	return spec, nil
+}
+
+func newScoreOp() flux.OperationSpec {
+	return new(ScoreOpSpec)
+}
+
+func (s *ScoreOpSpec) Kind() flux.OperationKind {
+	return ScoreKind
+}
+
+func (s *ScoreOpSpec) Resources() []flux.Resource {
+	return []flux.Resource{{Kind: flux.ModelResource, Name: s.Location, Mode: flux.ReadAccess}}
+}
+
+type ScoreProcedureSpec struct {
+	plan.DefaultCost
+	Format   string
+	Location string
+}
+
+func newScoreProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	s, ok := qs.(*ScoreOpSpec)
+	if !ok {
+		return nil, fmt.Errorf("invalid spec type %T", qs)
+	}
+	return &ScoreProcedureSpec{
+		Format:   s.Format,
+		Location: s.Location,
+	}, nil
+}
+
+func (s *ScoreProcedureSpec) Kind() plan.ProcedureKind {
+	return ScoreKind
+}
+func (s *ScoreProcedureSpec) Copy() plan.ProcedureSpec {
+	ns := new(ScoreProcedureSpec)
+	*ns = *s
+	return ns
+}
+
+func createScoreTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	s, ok := spec.(*ScoreProcedureSpec)
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid spec type %T", spec)
+	}
+	ctx := a.Context()
+	model, err := dependencies.Get(ctx).Models.LoadModel(ctx, s.Format, s.Location)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load %s model %q: %v", s.Format, s.Location, err)
+	}
+	cache := execute.NewTableBuilderCache(a.Allocator())
+	d := execute.NewDataset(id, mode, cache)
+	t := NewScoreTransformation(ctx, d, cache, model)
+	return t, d, nil
+}
+
+type scoreTransformation struct {
+	ctx   context.Context
+	d     execute.Dataset
+	cache execute.TableBuilderCache
+
+	model dependencies.Model
+}
+
+// NewScoreTransformation creates a transformation that scores every record with the model.
+// Every table is copied with an additional float column per output of the model.
+// The inputs of the model are read from the columns with the same names,
+// and the outputs of the records that have a null input are null.
+func NewScoreTransformation(ctx context.Context, d execute.Dataset, cache execute.TableBuilderCache, model dependencies.Model) *scoreTransformation {
+	return &scoreTransformation{
+		ctx:   ctx,
+		d:     d,
+		cache: cache,
+		model: model,
+	}
+}
+
+func (t *scoreTransformation) RetractTable(id execute.DatasetID, key flux.GroupKey) error {
+	return t.d.RetractTable(key)
+}
+
+func (t *scoreTransformation) Process(id execute.DatasetID, tbl flux.Table) error {
+	builder, created := t.cache.TableBuilder(tbl.Key())
+	if !created {
+		return fmt.Errorf("score found duplicate table with key: %v", tbl.Key())
+	}
+
+	cols := tbl.Cols()
+	inputs := t.model.Inputs()
+	inputIdxs := make([]int, len(inputs))
+	for k, label := range inputs {
+		j := execute.ColIdx(label, cols)
+		if j < 0 {
+			return fmt.Errorf("no column %q exists for the input of the model", label)
+		}
+		switch cols[j].Type {
+		case flux.TFloat, flux.TInt, flux.TUInt:
+		default:
+			return fmt.Errorf("input column %q must be numeric, got %v", label, cols[j].Type)
+		}
+		inputIdxs[k] = j
+	}
+
+	if err := execute.AddTableCols(tbl, builder); err != nil {
+		return err
+	}
+	outputs := t.model.Outputs()
+	outputIdxs := make([]int, len(outputs))
+	for k, label := range outputs {
+		if execute.HasCol(label, cols) {
+			return fmt.Errorf("column %q already exists for the output of the model", label)
+		}
+		j, err := builder.AddCol(flux.ColMeta{Label: label, Type: flux.TFloat})
+		if err != nil {
+			return err
+		}
+		outputIdxs[k] = j
+	}
+
+	features := make([]float64, len(inputs))
+	return tbl.Do(func(cr flux.ColReader) error {
+		for j := range cols {
+			if err := execute.AppendCol(j, j, cr, builder); err != nil {
+				return err
+			}
+		}
+		for i := 0; i < cr.Len(); i++ {
+			valid := true
+			for k, j := range inputIdxs {
+				var ok bool
+				if features[k], ok = floatValue(cr, i, j); !ok {
+					valid = false
+					break
+				}
+			}
+			if !valid {
+				for _, j := range outputIdxs {
+					if err := builder.AppendNil(j); err != nil {
+						return err
+					}
+				}
+				continue
+			}
+
+			scores, err := t.model.Score(t.ctx, features)
+			if err != nil {
+				return err
+			}
+			if len(scores) != len(outputs) {
+				return fmt.Errorf("model returned %d outputs, expected %d", len(scores), len(outputs))
+			}
+			for k, j := range outputIdxs {
+				if err := builder.AppendFloat(j, scores[k]); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// floatValue returns the value of the numeric column j of the row i as a float,
+// and false when it is null.
+func floatValue(cr flux.ColReader, i, j int) (float64, bool) {
+	switch cr.Cols()[j].Type {
+	case flux.TFloat:
+		vs := cr.Floats(j)
+		return vs.Value(i), vs.IsValid(i)
+	case flux.TInt:
+		vs := cr.Ints(j)
+		return float64(vs.Value(i)), vs.IsValid(i)
+	case flux.TUInt:
+		vs := cr.UInts(j)
+		return float64(vs.Value(i)), vs.IsValid(i)
+	}
+	return 0, false
+}
+
+func (t *scoreTransformation) UpdateWatermark(id execute.DatasetID, mark execute.Time) error {
+	return t.d.UpdateWatermark(mark)
+}
+func (t *scoreTransformation) UpdateProcessingTime(id execute.DatasetID, pt execute.Time) error {
+	return t.d.UpdateProcessingTime(pt)
+}
+func (t *scoreTransformation) Finish(id execute.DatasetID, err error) {
+	t.d.Finish(err)
+}
@@ -0,0 +1,162 @@
+package inference_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/flux/querytest"
+	"github.com/influxdata/flux/stdlib/experimental/inference"
+)
+
+// linearModel computes y = a*x0 + b*x1 + c.
+type linearModel struct {
+	a, b, c float64
+}
+
+func (m linearModel) Inputs() []string  { return []string{"x0", "x1"} }
+func (m linearModel) Outputs() []string { return []string{"y"} }
+func (m linearModel) Score(ctx context.Context, inputs []float64) ([]float64, error) {
+	return []float64{m.a*inputs[0] + m.b*inputs[1] + m.c}, nil
+}
+
+func TestScoreOperation_Marshaling(t *testing.T) {
+	data := []byte(`{"id":"score","kind":"inferenceScore","spec":{"format":"onnx","location":"models/linear.onnx"}}`)
+	op := &flux.Operation{
+		ID: "score",
+		Spec: &inference.ScoreOpSpec{
+			Format:   "onnx",
+			Location: "models/linear.onnx",
+		},
+	}
+	querytest.OperationMarshalingTestHelper(t, data, op)
+}
+
+func TestScore_Process(t *testing.T) {
+	model := linearModel{a: 2, b: -1, c: 0.5}
+	testCases := []struct {
+		name    string
+		data    []flux.Table
+		want    []*executetest.Table
+		wantErr error
+	}{
+		{
+			name: "numeric inputs",
+			data: []flux.Table{&executetest.Table{
+				KeyCols: []string{"t0"},
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "t0", Type: flux.TString},
+					{Label: "x0", Type: flux.TFloat},
+					{Label: "x1", Type: flux.TInt},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), "a", 1.0, int64(1)},
+					{execute.Time(2), "a", 2.5, int64(3)},
+					{execute.Time(3), "a", -1.0, int64(0)},
+				},
+			}},
+			want: []*executetest.Table{{
+				KeyCols: []string{"t0"},
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "t0", Type: flux.TString},
+					{Label: "x0", Type: flux.TFloat},
+					{Label: "x1", Type: flux.TInt},
+					{Label: "y", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), "a", 1.0, int64(1), 1.5},
+					{execute.Time(2), "a", 2.5, int64(3), 2.5},
+					{execute.Time(3), "a", -1.0, int64(0), -1.5},
+				},
+			}},
+		},
+		{
+			name: "null inputs",
+			data: []flux.Table{&executetest.Table{
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "x0", Type: flux.TFloat},
+					{Label: "x1", Type: flux.TUInt},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), 1.0, nil},
+					{execute.Time(2), nil, uint64(3)},
+					{execute.Time(3), 2.0, uint64(1)},
+				},
+			}},
+			want: []*executetest.Table{{
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "x0", Type: flux.TFloat},
+					{Label: "x1", Type: flux.TUInt},
+					{Label: "y", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), 1.0, nil, nil},
+					{execute.Time(2), nil, uint64(3), nil},
+					{execute.Time(3), 2.0, uint64(1), 3.5},
+				},
+			}},
+		},
+		{
+			name: "missing input",
+			data: []flux.Table{&executetest.Table{
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "x0", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), 1.0},
+				},
+			}},
+			wantErr: errors.New(`no column "x1" exists for the input of the model`),
+		},
+		{
+			name: "string input",
+			data: []flux.Table{&executetest.Table{
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "x0", Type: flux.TFloat},
+					{Label: "x1", Type: flux.TString},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), 1.0, "a"},
+				},
+			}},
+			wantErr: errors.New(`input column "x1" must be numeric, got string`),
+		},
+		{
+			name: "existing output",
+			data: []flux.Table{&executetest.Table{
+				ColMeta: []flux.ColMeta{
+					{Label: "x0", Type: flux.TFloat},
+					{Label: "x1", Type: flux.TFloat},
+					{Label: "y", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{1.0, 1.0, 1.0},
+				},
+			}},
+			wantErr: errors.New(`column "y" already exists for the output of the model`),
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			executetest.ProcessTestHelper(
+				t,
+				tc.data,
+				tc.want,
+				tc.wantErr,
+				func(d execute.Dataset, c execute.TableBuilderCache) execute.Transformation {
+					return inference.NewScoreTransformation(context.Background(), d, c, model)
+				},
+			)
+		})
+	}
+}
@@ -0,0 +1,153 @@
+// DO NOT EDIT: This file is autogenerated via the builtin command.
+
+package interpolate
+
+import (
+	flux "github.com/influxdata/flux"
+	ast "github.com/influxdata/flux/ast"
+	docs "github.com/influxdata/flux/docs"
+)
+
+func init() {
+	flux.RegisterPackage(pkgAST)
+	docs.RegisterComments("experimental/interpolate", pkgComments)
+}
+
+var pkgAST = &ast.Package{
+	BaseNode: ast.BaseNode{
+		Errors: nil,
+		Loc:    nil,
+	},
+	Files: []*ast.File{&ast.File{
+		BaseNode: ast.BaseNode{
+			Errors: nil,
+			Loc: &ast.SourceLocation{
+				End: ast.Position{
+					Column: 12,
+					Line:   9,
+				},
+				File:   "interpolate.flux",
+				Source: "package interpolate\n\n// linear resamples every table onto the times that are multiples of every,\n// interpolating linearly between the points of the series around each time.\nbuiltin linear\n\n// lag resamples every table onto the times that are multiples of every,\n// carrying the value of the last point at or before each time forward.\nbuiltin lag",
+				Start: ast.Position{
+					Column: 1,
+					Line:   1,
+				},
+			},
+		},
+		Body: []ast.Statement{&ast.BuiltinStatement{
+			BaseNode: ast.BaseNode{
+				Errors: nil,
+				Loc: &ast.SourceLocation{
+					End: ast.Position{
+						Column: 15,
+						Line:   5,
+					},
+					File:   "interpolate.flux",
+					Source: "builtin linear",
+					Start: ast.Position{
+						Column: 1,
+						Line:   5,
+					},
+				},
+			},
+			ID: &ast.Identifier{
+				BaseNode: ast.BaseNode{
+					Errors: nil,
+					Loc: &ast.SourceLocation{
+						End: ast.Position{
+							Column: 15,
+							Line:   5,
+						},
+						File:   "interpolate.flux",
+						Source: "linear",
+						Start: ast.Position{
+							Column: 9,
+							Line:   5,
+						},
+					},
+				},
+				Name: "linear",
+			},
+		}, &ast.BuiltinStatement{
+			BaseNode: ast.BaseNode{
+				Errors: nil,
+				Loc: &ast.SourceLocation{
+					End: ast.Position{
+						Column: 12,
+						Line:   9,
+					},
+					File:   "interpolate.flux",
+					Source: "builtin lag",
+					Start: ast.Position{
+						Column: 1,
+						Line:   9,
+					},
+				},
+			},
+			ID: &ast.Identifier{
+				BaseNode: ast.BaseNode{
+					Errors: nil,
+					Loc: &ast.SourceLocation{
+						End: ast.Position{
+							Column: 12,
+							Line:   9,
+						},
+						File:   "interpolate.flux",
+						Source: "lag",
+						Start: ast.Position{
+							Column: 9,
+							Line:   9,
+						},
+					},
+				},
+				Name: "lag",
+			},
+		}},
+		Imports: nil,
+		Name:    "interpolate.flux",
+		Package: &ast.PackageClause{
+			BaseNode: ast.BaseNode{
+				Errors: nil,
+				Loc: &ast.SourceLocation{
+					End: ast.Position{
+						Column: 20,
+						Line:   1,
+					},
+					File:   "interpolate.flux",
+					Source: "package interpolate",
+					Start: ast.Position{
+						Column: 1,
+						Line:   1,
+					},
+				},
+			},
+			Name: &ast.Identifier{
+				BaseNode: ast.BaseNode{
+					Errors: nil,
+					Loc: &ast.SourceLocation{
+						End: ast.Position{
+							Column: 20,
+							Line:   1,
+						},
+						File:   "interpolate.flux",
+						Source: "interpolate",
+						Start: ast.Position{
+							Column: 9,
+							Line:   1,
+						},
+					},
+				},
+				Name: "interpolate",
+			},
+		},
+	}},
+	Package: "interpolate",
+	Path:    "experimental/interpolate",
+}
+var pkgComments = docs.Comments{
+	Package: "",
+	Values: map[string]string{
+		"lag":    "lag resamples every table onto the times that are multiples of every,\ncarrying the value of the last point at or before each time forward.",
+		"linear": "linear resamples every table onto the times that are multiples of every,\ninterpolating linearly between the points of the series around each time.",
+	},
+}
@@ -0,0 +1,297 @@
+// Package interpolate resamples the series of tables onto a regular grid of times.
+//
+// Series that are sampled at different or irregular times cannot be joined or combined
+// point by point; resampling them onto the same grid first aligns their points.
+package interpolate
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/flux/values"
+)
+
+const InterpolateKind = "interpolate"
+
+// Method determines how the value of a series at a time of the grid is computed
+// from the points of the series around it.
+type Method string
+
+const (
+	// Linear interpolates linearly between the points before and after the time.
+	Linear Method = "linear"
+	// Lag carries the value of the last point at or before the time forward.
+	Lag Method = "lag"
+)
+
+type InterpolateOpSpec struct {
+	Method Method        `json:"method"`
+	Every  flux.Duration `json:"every"`
+}
+
+func init() {
+	signature := flux.FunctionSignature(
+		map[string]semantic.PolyType{
+			"every": semantic.Duration,
+		},
+		[]string{"every"},
+	)
+
+	flux.RegisterPackageValue("experimental/interpolate", string(Linear), flux.FunctionValue(string(Linear), createOpSpec(Linear), signature))
+	flux.RegisterPackageValue("experimental/interpolate", string(Lag), flux.FunctionValue(string(Lag), createOpSpec(Lag), signature))
+	flux.RegisterOpSpec(InterpolateKind, newInterpolateOp)
+	plan.RegisterProcedureSpec(InterpolateKind, newInterpolateProcedure, InterpolateKind)
+	execute.RegisterTransformation(InterpolateKind, createInterpolateTransformation)
+}
+
+func createOpSpec(method Method) flux.CreateOperationSpec {
+	return func(args flux.Arguments, a *flux.Administration) (flux.OperationSpec, error) {
+		if err := a.AddParentFromArgs(args); err != nil {
+			return nil, err
+		}
+
+		spec := &InterpolateOpSpec{Method: method}
+		var err error
+		if spec.Every, err = args.GetRequiredDuration("every"); err != nil {
+			return nil, err
+		}
+		if !spec.Every.IsPositive() {
+			return nil, errors.New("every must be greater than zero")
+		}
+		if spec.Every.Months() != 0 {
+			return nil, errors.New("every must be a duration without months")
+		}
+		return spec, nil
+	}
+}
+
+func newInterpolateOp() flux.OperationSpec {
+	return new(InterpolateOpSpec)
+}
+
+func (s *InterpolateOpSpec) Kind() flux.OperationKind {
+	return InterpolateKind
+}
+
+type InterpolateProcedureSpec struct {
+	plan.DefaultCost
+	Method Method
+	Every  flux.Duration
+}
+
+func newInterpolateProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	s, ok := qs.(*InterpolateOpSpec)
+	if !ok {
+		return nil, fmt.Errorf("invalid spec type %T", qs)
+	}
+	return &InterpolateProcedureSpec{
+		Method: s.Method,
+		Every:  s.Every,
+	}, nil
+}
+
+func (s *InterpolateProcedureSpec) Kind() plan.ProcedureKind {
+	return InterpolateKind
+}
+func (s *InterpolateProcedureSpec) Copy() plan.ProcedureSpec {
+	ns := new(InterpolateProcedureSpec)
+	*ns = *s
+	return ns
+}
+
+// SortedBy returns the time column, the rows of the grid are produced in time order.
+func (s *InterpolateProcedureSpec) SortedBy(predecessors [][]string) []string {
+	return []string{execute.DefaultTimeColLabel}
+}
+
+func createInterpolateTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	s, ok := spec.(*InterpolateProcedureSpec)
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid spec type %T", spec)
+	}
+	cache := execute.NewTableBuilderCache(a.Allocator())
+	d := execute.NewDataset(id, mode, cache)
+	t := NewInterpolateTransformation(d, cache, s)
+	return t, d, nil
+}
+
+type interpolateTransformation struct {
+	d     execute.Dataset
+	cache execute.TableBuilderCache
+
+	method Method
+	every  int64
+}
+
+// NewInterpolateTransformation creates a transformation that resamples every table
+// onto the times that are multiples of every, from the first to the last time of the table.
+// The output tables have the group key columns, the time column and the value column of their input,
+// and the value at every time of the grid is computed with the method of the spec.
+func NewInterpolateTransformation(d execute.Dataset, cache execute.TableBuilderCache, spec *InterpolateProcedureSpec) *interpolateTransformation {
+	return &interpolateTransformation{
+		d:      d,
+		cache:  cache,
+		method: spec.Method,
+		every:  spec.Every.Duration().Nanoseconds(),
+	}
+}
+
+func (t *interpolateTransformation) RetractTable(id execute.DatasetID, key flux.GroupKey) error {
+	return t.d.RetractTable(key)
+}
+
+// point is a point of a series, the value is a float for Linear.
+type point struct {
+	time  int64
+	value values.Value
+}
+
+func (t *interpolateTransformation) Process(id execute.DatasetID, tbl flux.Table) error {
+	builder, created := t.cache.TableBuilder(tbl.Key())
+	if !created {
+		return fmt.Errorf("interpolate found duplicate table with key: %v", tbl.Key())
+	}
+
+	cols := tbl.Cols()
+	timeIdx := execute.ColIdx(execute.DefaultTimeColLabel, cols)
+	if timeIdx < 0 {
+		return fmt.Errorf("no column %q exists", execute.DefaultTimeColLabel)
+	}
+	if cols[timeIdx].Type != flux.TTime {
+		return fmt.Errorf("column %q must be a time, got %v", execute.DefaultTimeColLabel, cols[timeIdx].Type)
+	}
+	valueIdx := execute.ColIdx(execute.DefaultValueColLabel, cols)
+	if valueIdx < 0 {
+		return fmt.Errorf("no column %q exists", execute.DefaultValueColLabel)
+	}
+	valueType := cols[valueIdx].Type
+	if t.method == Linear {
+		switch valueType {
+		case flux.TFloat, flux.TInt, flux.TUInt:
+		default:
+			return fmt.Errorf("column %q must be numeric to interpolate linearly, got %v", execute.DefaultValueColLabel, valueType)
+		}
+		valueType = flux.TFloat
+	}
+
+	// The output has the columns of the group key, the time column and the value column,
+	// in the order of the input.
+	key := tbl.Key()
+	outIdxs := make(map[int]int)
+	for j, c := range cols {
+		var err error
+		switch {
+		case j == valueIdx:
+			outIdxs[j], err = builder.AddCol(flux.ColMeta{Label: c.Label, Type: valueType})
+		case j == timeIdx || key.HasCol(c.Label):
+			outIdxs[j], err = builder.AddCol(c)
+		default:
+			continue
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	var points []point
+	if err := tbl.Do(func(cr flux.ColReader) error {
+		times := cr.Times(timeIdx)
+		for i := 0; i < cr.Len(); i++ {
+			if times.IsNull(i) {
+				continue
+			}
+			v := execute.ValueForRow(cr, i, valueIdx)
+			if v.IsNull() {
+				continue
+			}
+			if t.method == Linear {
+				v = values.NewFloat(floatValue(v))
+			}
+			points = append(points, point{time: times.Value(i), value: v})
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	if len(points) == 0 {
+		return nil
+	}
+	sort.SliceStable(points, func(i, j int) bool {
+		return points[i].time < points[j].time
+	})
+
+	first, last := points[0].time, points[len(points)-1].time
+	p := 0
+	for g := t.firstGridTime(first); g <= last; g += t.every {
+		// Move p to the last point at or before g.
+		for p+1 < len(points) && points[p+1].time <= g {
+			p++
+		}
+		v := points[p].value
+		if t.method == Linear && points[p].time != g {
+			v = interpolate(points[p], points[p+1], g)
+		}
+		for j, c := range cols {
+			oj, ok := outIdxs[j]
+			if !ok {
+				continue
+			}
+			var err error
+			switch j {
+			case timeIdx:
+				err = builder.AppendTime(oj, values.Time(g))
+			case valueIdx:
+				err = builder.AppendValue(oj, v)
+			default:
+				err = builder.AppendValue(oj, key.LabelValue(c.Label))
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// firstGridTime returns the first multiple of every at or after the time.
+func (t *interpolateTransformation) firstGridTime(time int64) int64 {
+	g := time - time%t.every
+	if g < time {
+		g += t.every
+	}
+	return g
+}
+
+// interpolate returns the value at the time between the points p0 and p1
+// on the line through them.
+func interpolate(p0, p1 point, time int64) values.Value {
+	v0, v1 := p0.value.Float(), p1.value.Float()
+	frac := float64(time-p0.time) / float64(p1.time-p0.time)
+	return values.NewFloat(v0 + (v1-v0)*frac)
+}
+
+// floatValue converts a numeric value to a float.
+func floatValue(v values.Value) float64 {
+	switch v.Type() {
+	case semantic.Int:
+		return float64(v.Int())
+	case semantic.UInt:
+		return float64(v.UInt())
+	}
+	return v.Float()
+}
+
+func (t *interpolateTransformation) UpdateWatermark(id execute.DatasetID, mark execute.Time) error {
+	return t.d.UpdateWatermark(mark)
+}
+func (t *interpolateTransformation) UpdateProcessingTime(id execute.DatasetID, pt execute.Time) error {
+	return t.d.UpdateProcessingTime(pt)
+}
+func (t *interpolateTransformation) Finish(id execute.DatasetID, err error) {
+	t.d.Finish(err)
+}
@@ -0,0 +1,157 @@
+package interpolate_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/flux/querytest"
+	"github.com/influxdata/flux/stdlib/experimental/interpolate"
+	"github.com/influxdata/flux/values"
+)
+
+func TestInterpolateOperation_Marshaling(t *testing.T) {
+	data := []byte(`{"id":"interpolate","kind":"interpolate","spec":{"method":"linear","every":"10s"}}`)
+	op := &flux.Operation{
+		ID: "interpolate",
+		Spec: &interpolate.InterpolateOpSpec{
+			Method: interpolate.Linear,
+			Every:  values.ConvertDuration(10 * time.Second),
+		},
+	}
+	querytest.OperationMarshalingTestHelper(t, data, op)
+}
+
+func TestInterpolate_Process(t *testing.T) {
+	testCases := []struct {
+		name    string
+		spec    *interpolate.InterpolateProcedureSpec
+		data    []flux.Table
+		want    []*executetest.Table
+		wantErr error
+	}{
+		{
+			name: "linear",
+			spec: &interpolate.InterpolateProcedureSpec{
+				Method: interpolate.Linear,
+				Every:  values.ConvertDuration(10),
+			},
+			data: []flux.Table{&executetest.Table{
+				KeyCols: []string{"t0"},
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "t0", Type: flux.TString},
+					{Label: "host", Type: flux.TString},
+					{Label: "_value", Type: flux.TInt},
+				},
+				Data: [][]interface{}{
+					{execute.Time(5), "a", "h1", int64(0)},
+					{execute.Time(15), "a", "h1", int64(10)},
+					{execute.Time(20), "a", "h2", nil},
+					{execute.Time(40), "a", "h2", int64(30)},
+					{execute.Time(48), "a", "h1", int64(50)},
+				},
+			}},
+			want: []*executetest.Table{{
+				KeyCols: []string{"t0"},
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "t0", Type: flux.TString},
+					{Label: "_value", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{execute.Time(10), "a", 5.0},
+					{execute.Time(20), "a", 14.0},
+					{execute.Time(30), "a", 22.0},
+					{execute.Time(40), "a", 30.0},
+				},
+			}},
+		},
+		{
+			name: "lag",
+			spec: &interpolate.InterpolateProcedureSpec{
+				Method: interpolate.Lag,
+				Every:  values.ConvertDuration(10),
+			},
+			data: []flux.Table{&executetest.Table{
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_value", Type: flux.TString},
+				},
+				Data: [][]interface{}{
+					{execute.Time(30), "c"},
+					{execute.Time(10), "a"},
+					{execute.Time(12), "b"},
+					{nil, "d"},
+				},
+			}},
+			want: []*executetest.Table{{
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_value", Type: flux.TString},
+				},
+				Data: [][]interface{}{
+					{execute.Time(10), "a"},
+					{execute.Time(20), "b"},
+					{execute.Time(30), "c"},
+				},
+			}},
+		},
+		{
+			name: "no points",
+			spec: &interpolate.InterpolateProcedureSpec{
+				Method: interpolate.Linear,
+				Every:  values.ConvertDuration(10),
+			},
+			data: []flux.Table{&executetest.Table{
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_value", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), nil},
+				},
+			}},
+			want: []*executetest.Table{{
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_value", Type: flux.TFloat},
+				},
+			}},
+		},
+		{
+			name: "linear string",
+			spec: &interpolate.InterpolateProcedureSpec{
+				Method: interpolate.Linear,
+				Every:  values.ConvertDuration(10),
+			},
+			data: []flux.Table{&executetest.Table{
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_value", Type: flux.TString},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), "a"},
+				},
+			}},
+			wantErr: errors.New(`column "_value" must be numeric to interpolate linearly, got string`),
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			executetest.ProcessTestHelper(
+				t,
+				tc.data,
+				tc.want,
+				tc.wantErr,
+				func(d execute.Dataset, c execute.TableBuilderCache) execute.Transformation {
+					return interpolate.NewInterpolateTransformation(d, c, tc.spec)
+				},
+			)
+		})
+	}
+}
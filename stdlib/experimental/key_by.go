@@ -0,0 +1,234 @@
+package experimental
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/interpreter"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/semantic"
+)
+
+const KeyByKind = "keyBy"
+
+// KeyByOpSpec regroups the rows of its input by the record that a function computes for every row.
+type KeyByOpSpec struct {
+	Fn *semantic.FunctionExpression `json:"fn"`
+}
+
+func init() {
+	keyBySignature := flux.FunctionSignature(
+		map[string]semantic.PolyType{
+			"fn": semantic.NewFunctionPolyType(semantic.FunctionPolySignature{
+				Parameters: map[string]semantic.PolyType{
+					"r": semantic.Tvar(1),
+				},
+				Required: semantic.LabelSet{"r"},
+				Return:   semantic.Tvar(2),
+			}),
+		},
+		[]string{"fn"},
+	)
+
+	flux.RegisterPackageValue("experimental", KeyByKind, flux.FunctionValue(KeyByKind, createKeyByOpSpec, keyBySignature))
+	flux.RegisterOpSpec(KeyByKind, newKeyByOp)
+	plan.RegisterProcedureSpec(KeyByKind, newKeyByProcedure, KeyByKind)
+	execute.RegisterTransformation(KeyByKind, createKeyByTransformation)
+}
+
+func createKeyByOpSpec(args flux.Arguments, a *flux.Administration) (flux.OperationSpec, error) {
+	if err := a.AddParentFromArgs(args); err != nil {
+		return nil, err
+	}
+	f, err := args.GetRequiredFunction("fn")
+	if err != nil {
+		return nil, err
+	}
+	fn, err := interpreter.ResolveFunction(f)
+	if err != nil {
+		return nil, err
+	}
+	return &KeyByOpSpec{Fn: fn}, nil
+}
+
+func newKeyByOp() flux.OperationSpec {
+	return new(KeyByOpSpec)
+}
+
+func (s *KeyByOpSpec) Kind() flux.OperationKind {
+	return KeyByKind
+}
+
+type KeyByProcedureSpec struct {
+	plan.DefaultCost
+	Fn *semantic.FunctionExpression
+}
+
+func newKeyByProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	spec, ok := qs.(*KeyByOpSpec)
+	if !ok {
+		return nil, fmt.Errorf("invalid spec type %T", qs)
+	}
+	return &KeyByProcedureSpec{Fn: spec.Fn}, nil
+}
+
+func (s *KeyByProcedureSpec) Kind() plan.ProcedureKind {
+	return KeyByKind
+}
+
+func (s *KeyByProcedureSpec) Copy() plan.ProcedureSpec {
+	ns := new(KeyByProcedureSpec)
+	*ns = *s
+	ns.Fn = s.Fn.Copy().(*semantic.FunctionExpression)
+	return ns
+}
+
+func createKeyByTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	s, ok := spec.(*KeyByProcedureSpec)
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid spec type %T", spec)
+	}
+	cache := execute.NewTableBuilderCache(a.Allocator())
+	d := execute.NewDataset(id, mode, cache)
+	t, err := NewKeyByTransformation(d, cache, s)
+	if err != nil {
+		return nil, nil, err
+	}
+	return t, d, nil
+}
+
+// keyByTransformation moves every row to the table whose group key is the record
+// that the function returns for the row. The properties of the record are set
+// as the columns of the row, in place of the columns with the same label.
+type keyByTransformation struct {
+	d     execute.Dataset
+	cache execute.TableBuilderCache
+
+	fn *execute.RowMapFn
+
+	// key is the group key of the current row.
+	key execute.ScratchGroupKey
+}
+
+func NewKeyByTransformation(d execute.Dataset, cache execute.TableBuilderCache, spec *KeyByProcedureSpec) (*keyByTransformation, error) {
+	fn, err := execute.NewRowMapFn(spec.Fn)
+	if err != nil {
+		return nil, err
+	}
+	return &keyByTransformation{
+		d:     d,
+		cache: cache,
+		fn:    fn,
+	}, nil
+}
+
+func (t *keyByTransformation) RetractTable(id execute.DatasetID, key flux.GroupKey) error {
+	return t.d.RetractTable(key)
+}
+
+func (t *keyByTransformation) Process(id execute.DatasetID, tbl flux.Table) error {
+	cols := tbl.Cols()
+	if err := t.fn.Prepare(cols); err != nil {
+		return err
+	}
+
+	// The key columns are the properties of the record, in sorted order.
+	properties := t.fn.Type().Properties()
+	keyCols := make([]flux.ColMeta, 0, len(properties))
+	for label, typ := range properties {
+		keyCols = append(keyCols, flux.ColMeta{
+			Label: label,
+			Type:  execute.ConvertFromKind(typ.Nature()),
+		})
+	}
+	sort.Slice(keyCols, func(i, j int) bool {
+		return keyCols[i].Label < keyCols[j].Label
+	})
+	for _, c := range keyCols {
+		if c.Type == flux.TInvalid {
+			return fmt.Errorf("keyBy: the property %q of the record has an invalid column type", c.Label)
+		}
+	}
+
+	return tbl.Do(func(cr flux.ColReader) error {
+		for i := 0; i < cr.Len(); i++ {
+			m, err := t.fn.Eval(i, cr)
+			if err != nil {
+				return err
+			}
+			t.key.Reset()
+			for _, c := range keyCols {
+				v, _ := m.Get(c.Label)
+				t.key.Append(c, v)
+			}
+
+			builder, _ := t.cache.TableBuilder(&t.key)
+			if err := addKeyByCols(builder, cols, keyCols); err != nil {
+				return err
+			}
+			for j, c := range builder.Cols() {
+				if v, ok := m.Get(c.Label); ok {
+					if err := builder.AppendValue(j, v); err != nil {
+						return err
+					}
+					continue
+				}
+				idx := execute.ColIdx(c.Label, cols)
+				if idx < 0 {
+					if err := builder.AppendNil(j); err != nil {
+						return err
+					}
+					continue
+				}
+				if cols[idx].Type != c.Type {
+					return fmt.Errorf("keyBy: column %q is of type %v in a table and %v in another", c.Label, c.Type, cols[idx].Type)
+				}
+				if err := builder.AppendValue(j, execute.ValueForRow(cr, i, idx)); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// addKeyByCols adds the columns of a table and the key columns that the builder does not have yet.
+// The columns of the table keep their order, with the type of the key column of the same label,
+// and the other key columns follow them. The rows that are already in the builder are null in the new columns.
+func addKeyByCols(builder execute.TableBuilder, cols, keyCols []flux.ColMeta) error {
+	add := func(c flux.ColMeta) error {
+		if execute.ColIdx(c.Label, builder.Cols()) >= 0 {
+			return nil
+		}
+		_, err := builder.AddCol(c)
+		return err
+	}
+	for _, c := range cols {
+		if idx := execute.ColIdx(c.Label, keyCols); idx >= 0 {
+			c = keyCols[idx]
+		}
+		if err := add(c); err != nil {
+			return err
+		}
+	}
+	for _, c := range keyCols {
+		if err := add(c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *keyByTransformation) UpdateWatermark(id execute.DatasetID, mark execute.Time) error {
+	return t.d.UpdateWatermark(mark)
+}
+
+func (t *keyByTransformation) UpdateProcessingTime(id execute.DatasetID, pt execute.Time) error {
+	return t.d.UpdateProcessingTime(pt)
+}
+
+func (t *keyByTransformation) Finish(id execute.DatasetID, err error) {
+	t.d.Finish(err)
+}
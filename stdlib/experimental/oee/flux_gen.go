@@ -0,0 +1,116 @@
+// DO NOT EDIT: This file is autogenerated via the builtin command.
+
+package oee
+
+import (
+	flux "github.com/influxdata/flux"
+	ast "github.com/influxdata/flux/ast"
+	docs "github.com/influxdata/flux/docs"
+)
+
+func init() {
+	flux.RegisterPackage(pkgAST)
+	docs.RegisterComments("experimental/oee", pkgComments)
+}
+
+var pkgAST = &ast.Package{
+	BaseNode: ast.BaseNode{
+		Errors: nil,
+		Loc:    nil,
+	},
+	Files: []*ast.File{&ast.File{
+		BaseNode: ast.BaseNode{
+			Errors: nil,
+			Loc: &ast.SourceLocation{
+				End: ast.Position{
+					Column: 12,
+					Line:   5,
+				},
+				File:   "oee.flux",
+				Source: "package oee\n\n// APQ computes the availability, performance, quality and overall equipment effectiveness\n// of the machine of every table from its state and part count events.\nbuiltin APQ",
+				Start: ast.Position{
+					Column: 1,
+					Line:   1,
+				},
+			},
+		},
+		Body: []ast.Statement{&ast.BuiltinStatement{
+			BaseNode: ast.BaseNode{
+				Errors: nil,
+				Loc: &ast.SourceLocation{
+					End: ast.Position{
+						Column: 12,
+						Line:   5,
+					},
+					File:   "oee.flux",
+					Source: "builtin APQ",
+					Start: ast.Position{
+						Column: 1,
+						Line:   5,
+					},
+				},
+			},
+			ID: &ast.Identifier{
+				BaseNode: ast.BaseNode{
+					Errors: nil,
+					Loc: &ast.SourceLocation{
+						End: ast.Position{
+							Column: 12,
+							Line:   5,
+						},
+						File:   "oee.flux",
+						Source: "APQ",
+						Start: ast.Position{
+							Column: 9,
+							Line:   5,
+						},
+					},
+				},
+				Name: "APQ",
+			},
+		}},
+		Imports: nil,
+		Name:    "oee.flux",
+		Package: &ast.PackageClause{
+			BaseNode: ast.BaseNode{
+				Errors: nil,
+				Loc: &ast.SourceLocation{
+					End: ast.Position{
+						Column: 12,
+						Line:   1,
+					},
+					File:   "oee.flux",
+					Source: "package oee",
+					Start: ast.Position{
+						Column: 1,
+						Line:   1,
+					},
+				},
+			},
+			Name: &ast.Identifier{
+				BaseNode: ast.BaseNode{
+					Errors: nil,
+					Loc: &ast.SourceLocation{
+						End: ast.Position{
+							Column: 12,
+							Line:   1,
+						},
+						File:   "oee.flux",
+						Source: "oee",
+						Start: ast.Position{
+							Column: 9,
+							Line:   1,
+						},
+					},
+				},
+				Name: "oee",
+			},
+		},
+	}},
+	Package: "oee",
+	Path:    "experimental/oee",
+}
+var pkgComments = docs.Comments{
+	Package: "",
+	Values:  map[string]string{"APQ": "APQ computes the availability, performance, quality and overall equipment effectiveness\nof the machine of every table from its state and part count events."},
+}
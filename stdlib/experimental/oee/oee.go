@@ -0,0 +1,384 @@
+// Package oee computes the overall equipment effectiveness of machines.
+//
+// The overall equipment effectiveness (OEE) of a machine over a period is the product of
+// its availability, the fraction of the planned production time it spends running,
+// its performance, the fraction of its ideal production rate it achieves while running,
+// and its quality, the fraction of the parts it produces that are good.
+package oee
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/flux/values"
+)
+
+const APQKind = "APQ"
+
+// The columns of the output of APQ.
+const (
+	AvailabilityColLabel = "availability"
+	PerformanceColLabel  = "performance"
+	QualityColLabel      = "quality"
+	OEEColLabel          = "oee"
+)
+
+// The default columns of the input of APQ.
+const (
+	DefaultStateColLabel     = "state"
+	DefaultPartCountColLabel = "partCount"
+	DefaultBadCountColLabel  = "badCount"
+)
+
+type APQOpSpec struct {
+	RunningState    string        `json:"runningState"`
+	PlannedTime     flux.Duration `json:"plannedTime"`
+	IdealCycleTime  flux.Duration `json:"idealCycleTime"`
+	StateColumn     string        `json:"stateColumn"`
+	PartCountColumn string        `json:"partCountColumn"`
+	BadCountColumn  string        `json:"badCountColumn"`
+	TimeColumn      string        `json:"timeColumn"`
+}
+
+func init() {
+	apqSignature := flux.FunctionSignature(
+		map[string]semantic.PolyType{
+			"runningState":    semantic.String,
+			"plannedTime":     semantic.Duration,
+			"idealCycleTime":  semantic.Duration,
+			"stateColumn":     semantic.String,
+			"partCountColumn": semantic.String,
+			"badCountColumn":  semantic.String,
+			"timeColumn":      semantic.String,
+		},
+		[]string{"runningState", "plannedTime", "idealCycleTime"},
+	)
+
+	flux.RegisterPackageValue("experimental/oee", APQKind, flux.FunctionValue(APQKind, createAPQOpSpec, apqSignature))
+	flux.RegisterOpSpec(APQKind, newAPQOp)
+	plan.RegisterProcedureSpec(APQKind, newAPQProcedure, APQKind)
+	execute.RegisterTransformation(APQKind, createAPQTransformation)
+}
+
+func createAPQOpSpec(args flux.Arguments, a *flux.Administration) (flux.OperationSpec, error) {
+	if err := a.AddParentFromArgs(args); err != nil {
+		return nil, err
+	}
+
+	spec := new(APQOpSpec)
+	var err error
+	if spec.RunningState, err = args.GetRequiredString("runningState"); err != nil {
+		return nil, err
+	}
+	if spec.PlannedTime, err = args.GetRequiredDuration("plannedTime"); err != nil {
+		return nil, err
+	}
+	if !spec.PlannedTime.IsPositive() {
+		return nil, errors.New("plannedTime must be greater than zero")
+	}
+	if spec.IdealCycleTime, err = args.GetRequiredDuration("idealCycleTime"); err != nil {
+		return nil, err
+	}
+	if !spec.IdealCycleTime.IsPositive() {
+		return nil, errors.New("idealCycleTime must be greater than zero")
+	}
+
+	for _, col := range []struct {
+		name  string
+		label *string
+		def   string
+	}{
+		{name: "stateColumn", label: &spec.StateColumn, def: DefaultStateColLabel},
+		{name: "partCountColumn", label: &spec.PartCountColumn, def: DefaultPartCountColLabel},
+		{name: "badCountColumn", label: &spec.BadCountColumn, def: DefaultBadCountColLabel},
+		{name: "timeColumn", label: &spec.TimeColumn, def: execute.DefaultTimeColLabel},
+	} {
+		if label, ok, err := args.GetString(col.name); err != nil {
+			return nil, err
+		} else if ok {
+			*col.label = label
+		} else {
+			*col.label = col.def
+		}
+	}
+	return spec, nil
+}
+
+func newAPQOp() flux.OperationSpec {
+	return new(APQOpSpec)
+}
+
+func (s *APQOpSpec) Kind() flux.OperationKind {
+	return APQKind
+}
+
+type APQProcedureSpec struct {
+	plan.DefaultCost
+	RunningState    string
+	PlannedTime     flux.Duration
+	IdealCycleTime  flux.Duration
+	StateColumn     string
+	PartCountColumn string
+	BadCountColumn  string
+	TimeColumn      string
+}
+
+func newAPQProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	s, ok := qs.(*APQOpSpec)
+	if !ok {
+		return nil, fmt.Errorf("invalid spec type %T", qs)
+	}
+	return &APQProcedureSpec{
+		RunningState:    s.RunningState,
+		PlannedTime:     s.PlannedTime,
+		IdealCycleTime:  s.IdealCycleTime,
+		StateColumn:     s.StateColumn,
+		PartCountColumn: s.PartCountColumn,
+		BadCountColumn:  s.BadCountColumn,
+		TimeColumn:      s.TimeColumn,
+	}, nil
+}
+
+func (s *APQProcedureSpec) Kind() plan.ProcedureKind {
+	return APQKind
+}
+func (s *APQProcedureSpec) Copy() plan.ProcedureSpec {
+	ns := new(APQProcedureSpec)
+	*ns = *s
+	return ns
+}
+
+func createAPQTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	s, ok := spec.(*APQProcedureSpec)
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid spec type %T", spec)
+	}
+	cache := execute.NewTableBuilderCache(a.Allocator())
+	d := execute.NewDataset(id, mode, cache)
+	t := NewAPQTransformation(d, cache, s)
+	return t, d, nil
+}
+
+type apqTransformation struct {
+	d     execute.Dataset
+	cache execute.TableBuilderCache
+
+	spec APQProcedureSpec
+}
+
+// NewAPQTransformation creates a transformation that computes the availability, the performance,
+// the quality and the overall equipment effectiveness of the machine of every table.
+// Every input table produces a table with the same group key and a single row,
+// whose time is the end of the period of the table.
+func NewAPQTransformation(d execute.Dataset, cache execute.TableBuilderCache, spec *APQProcedureSpec) *apqTransformation {
+	return &apqTransformation{
+		d:     d,
+		cache: cache,
+		spec:  *spec,
+	}
+}
+
+func (t *apqTransformation) RetractTable(id execute.DatasetID, key flux.GroupKey) error {
+	return t.d.RetractTable(key)
+}
+
+// event is a record of a table, where the state and the counts are null
+// when the record does not report them.
+type event struct {
+	time      values.Time
+	state     values.Value
+	partCount values.Value
+	badCount  values.Value
+}
+
+func (t *apqTransformation) Process(id execute.DatasetID, tbl flux.Table) error {
+	builder, created := t.cache.TableBuilder(tbl.Key())
+	if !created {
+		return fmt.Errorf("APQ found duplicate table with key: %v", tbl.Key())
+	}
+
+	cols := tbl.Cols()
+	timeIdx := execute.ColIdx(t.spec.TimeColumn, cols)
+	if timeIdx < 0 {
+		return fmt.Errorf("no column %q exists", t.spec.TimeColumn)
+	} else if cols[timeIdx].Type != flux.TTime {
+		return fmt.Errorf("column %q must be a time, got %v", t.spec.TimeColumn, cols[timeIdx].Type)
+	}
+	stateIdx := execute.ColIdx(t.spec.StateColumn, cols)
+	if stateIdx < 0 {
+		return fmt.Errorf("no column %q exists", t.spec.StateColumn)
+	} else if cols[stateIdx].Type != flux.TString {
+		return fmt.Errorf("column %q must be a string, got %v", t.spec.StateColumn, cols[stateIdx].Type)
+	}
+	countIdxs := make([]int, 2)
+	for k, label := range []string{t.spec.PartCountColumn, t.spec.BadCountColumn} {
+		j := execute.ColIdx(label, cols)
+		if j < 0 {
+			return fmt.Errorf("no column %q exists", label)
+		}
+		switch cols[j].Type {
+		case flux.TInt, flux.TUInt, flux.TFloat:
+		default:
+			return fmt.Errorf("column %q must be numeric, got %v", label, cols[j].Type)
+		}
+		countIdxs[k] = j
+	}
+
+	var events []event
+	if err := tbl.Do(func(cr flux.ColReader) error {
+		times := cr.Times(timeIdx)
+		for i := 0; i < cr.Len(); i++ {
+			if !times.IsValid(i) {
+				continue
+			}
+			events = append(events, event{
+				time:      values.Time(times.Value(i)),
+				state:     execute.ValueForRow(cr, i, stateIdx),
+				partCount: execute.ValueForRow(cr, i, countIdxs[0]),
+				badCount:  execute.ValueForRow(cr, i, countIdxs[1]),
+			})
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	// The events of the machine are not necessarily reported in order,
+	// as when the states and the counts come from different series.
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].time < events[j].time
+	})
+
+	var end values.Time
+	if v := tbl.Key().LabelValue(execute.DefaultStopColLabel); v != nil && v.Type() == semantic.Time {
+		end = v.Time()
+	} else if len(events) > 0 {
+		end = events[len(events)-1].time
+	}
+
+	if err := execute.AddTableKeyCols(tbl.Key(), builder); err != nil {
+		return err
+	}
+	outIdx := execute.ColIdx(t.spec.TimeColumn, builder.Cols())
+	if outIdx < 0 {
+		var err error
+		if outIdx, err = builder.AddCol(flux.ColMeta{Label: t.spec.TimeColumn, Type: flux.TTime}); err != nil {
+			return err
+		}
+	}
+	valueIdxs := make([]int, 4)
+	for k, label := range []string{AvailabilityColLabel, PerformanceColLabel, QualityColLabel, OEEColLabel} {
+		j, err := builder.AddCol(flux.ColMeta{Label: label, Type: flux.TFloat})
+		if err != nil {
+			return err
+		}
+		valueIdxs[k] = j
+	}
+
+	if err := execute.AppendKeyValues(tbl.Key(), builder); err != nil {
+		return err
+	}
+	if !tbl.Key().HasCol(t.spec.TimeColumn) {
+		if err := builder.AppendTime(outIdx, end); err != nil {
+			return err
+		}
+	}
+	for k, v := range t.apq(events, end) {
+		if err := builder.AppendValue(valueIdxs[k], v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// apq returns the availability, the performance, the quality and the overall equipment effectiveness
+// of the events of a period that ends at end. The ratios that cannot be computed are null.
+func (t *apqTransformation) apq(events []event, end values.Time) []values.Value {
+	// Every state lasts until the next state, and the last one until the end of the period.
+	var (
+		runTime values.Time
+		state   values.Value
+		since   values.Time
+	)
+	for _, e := range events {
+		if e.state.IsNull() {
+			continue
+		}
+		if state != nil && state.Str() == t.spec.RunningState {
+			runTime += e.time - since
+		}
+		state, since = e.state, e.time
+	}
+	if state != nil && state.Str() == t.spec.RunningState && end > since {
+		runTime += end - since
+	}
+
+	parts, partsOK := increase(events, func(e event) values.Value { return e.partCount })
+	bad, badOK := increase(events, func(e event) values.Value { return e.badCount })
+
+	availability := float64(runTime) / float64(t.spec.PlannedTime.Duration())
+	apq := []values.Value{
+		values.NewFloat(availability),
+		values.NewNull(semantic.Float),
+		values.NewNull(semantic.Float),
+		values.NewNull(semantic.Float),
+	}
+	if partsOK && runTime > 0 {
+		performance := float64(t.spec.IdealCycleTime.Duration()) * parts / float64(runTime)
+		apq[1] = values.NewFloat(performance)
+		if badOK && parts > 0 {
+			quality := (parts - bad) / parts
+			apq[2] = values.NewFloat(quality)
+			apq[3] = values.NewFloat(availability * performance * quality)
+		}
+	}
+	return apq
+}
+
+// increase returns the increase of a counter over the events, where a counter that decreases has been reset.
+// It reports false when the counter has no value.
+func increase(events []event, counter func(e event) values.Value) (float64, bool) {
+	var (
+		total float64
+		prev  float64
+		ok    bool
+	)
+	for _, e := range events {
+		v := counter(e)
+		if v.IsNull() {
+			continue
+		}
+		var f float64
+		switch v.Type() {
+		case semantic.Int:
+			f = float64(v.Int())
+		case semantic.UInt:
+			f = float64(v.UInt())
+		case semantic.Float:
+			f = v.Float()
+		}
+		if ok {
+			if f >= prev {
+				total += f - prev
+			} else {
+				total += f
+			}
+		}
+		prev, ok = f, true
+	}
+	return total, ok
+}
+
+func (t *apqTransformation) UpdateWatermark(id execute.DatasetID, mark execute.Time) error {
+	return t.d.UpdateWatermark(mark)
+}
+func (t *apqTransformation) UpdateProcessingTime(id execute.DatasetID, pt execute.Time) error {
+	return t.d.UpdateProcessingTime(pt)
+}
+func (t *apqTransformation) Finish(id execute.DatasetID, err error) {
+	t.d.Finish(err)
+}
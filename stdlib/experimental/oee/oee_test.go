@@ -0,0 +1,171 @@
+package oee_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/flux/querytest"
+	"github.com/influxdata/flux/stdlib/experimental/oee"
+	"github.com/influxdata/flux/values"
+)
+
+func TestAPQOperation_Marshaling(t *testing.T) {
+	data := []byte(`{"id":"APQ","kind":"APQ","spec":{"runningState":"running","plannedTime":"8h","idealCycleTime":"30s","stateColumn":"state","partCountColumn":"partCount","badCountColumn":"badCount","timeColumn":"_time"}}`)
+	op := &flux.Operation{
+		ID: "APQ",
+		Spec: &oee.APQOpSpec{
+			RunningState:    "running",
+			PlannedTime:     values.ConvertDuration(8 * time.Hour),
+			IdealCycleTime:  values.ConvertDuration(30 * time.Second),
+			StateColumn:     "state",
+			PartCountColumn: "partCount",
+			BadCountColumn:  "badCount",
+			TimeColumn:      "_time",
+		},
+	}
+	querytest.OperationMarshalingTestHelper(t, data, op)
+}
+
+func TestAPQ_Process(t *testing.T) {
+	spec := &oee.APQProcedureSpec{
+		RunningState:    "running",
+		PlannedTime:     values.ConvertDuration(80),
+		IdealCycleTime:  values.ConvertDuration(5),
+		StateColumn:     oee.DefaultStateColLabel,
+		PartCountColumn: oee.DefaultPartCountColLabel,
+		BadCountColumn:  oee.DefaultBadCountColLabel,
+		TimeColumn:      execute.DefaultTimeColLabel,
+	}
+	inCols := []flux.ColMeta{
+		{Label: "_start", Type: flux.TTime},
+		{Label: "_stop", Type: flux.TTime},
+		{Label: "_time", Type: flux.TTime},
+		{Label: "host", Type: flux.TString},
+		{Label: "state", Type: flux.TString},
+		{Label: "partCount", Type: flux.TInt},
+		{Label: "badCount", Type: flux.TInt},
+	}
+	outCols := []flux.ColMeta{
+		{Label: "_start", Type: flux.TTime},
+		{Label: "_stop", Type: flux.TTime},
+		{Label: "host", Type: flux.TString},
+		{Label: "_time", Type: flux.TTime},
+		{Label: "availability", Type: flux.TFloat},
+		{Label: "performance", Type: flux.TFloat},
+		{Label: "quality", Type: flux.TFloat},
+		{Label: "oee", Type: flux.TFloat},
+	}
+
+	testCases := []struct {
+		name    string
+		data    []flux.Table
+		want    []*executetest.Table
+		wantErr error
+	}{
+		{
+			name: "states and counts",
+			data: []flux.Table{&executetest.Table{
+				KeyCols: []string{"_start", "_stop", "host"},
+				ColMeta: inCols,
+				// The counts are reset at 70.
+				Data: [][]interface{}{
+					{execute.Time(0), execute.Time(100), execute.Time(0), "a", "stopped", nil, nil},
+					{execute.Time(0), execute.Time(100), execute.Time(10), "a", nil, int64(1), int64(0)},
+					{execute.Time(0), execute.Time(100), execute.Time(40), "a", "stopped", nil, nil},
+					{execute.Time(0), execute.Time(100), execute.Time(20), "a", "running", nil, nil},
+					{execute.Time(0), execute.Time(100), execute.Time(50), "a", nil, int64(3), int64(1)},
+					{execute.Time(0), execute.Time(100), execute.Time(70), "a", nil, int64(2), int64(0)},
+					{execute.Time(0), execute.Time(100), execute.Time(80), "a", "running", nil, nil},
+				},
+			}},
+			want: []*executetest.Table{{
+				KeyCols: []string{"_start", "_stop", "host"},
+				ColMeta: outCols,
+				Data: [][]interface{}{
+					{execute.Time(0), execute.Time(100), "a", execute.Time(100), 0.5, 0.5, 0.75, 0.1875},
+				},
+			}},
+		},
+		{
+			name: "no parts",
+			data: []flux.Table{&executetest.Table{
+				KeyCols: []string{"_start", "_stop", "host"},
+				ColMeta: inCols,
+				Data: [][]interface{}{
+					{execute.Time(0), execute.Time(100), execute.Time(60), "a", "running", nil, nil},
+				},
+			}},
+			want: []*executetest.Table{{
+				KeyCols: []string{"_start", "_stop", "host"},
+				ColMeta: outCols,
+				Data: [][]interface{}{
+					{execute.Time(0), execute.Time(100), "a", execute.Time(100), 0.5, nil, nil, nil},
+				},
+			}},
+		},
+		{
+			name: "no stop",
+			data: []flux.Table{&executetest.Table{
+				KeyCols: []string{"host"},
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "host", Type: flux.TString},
+					{Label: "state", Type: flux.TString},
+					{Label: "partCount", Type: flux.TFloat},
+					{Label: "badCount", Type: flux.TUInt},
+				},
+				// The last state lasts until the last record.
+				Data: [][]interface{}{
+					{execute.Time(0), "a", "running", 0.0, uint64(0)},
+					{execute.Time(40), "a", nil, 8.0, uint64(0)},
+				},
+			}},
+			want: []*executetest.Table{{
+				KeyCols: []string{"host"},
+				ColMeta: []flux.ColMeta{
+					{Label: "host", Type: flux.TString},
+					{Label: "_time", Type: flux.TTime},
+					{Label: "availability", Type: flux.TFloat},
+					{Label: "performance", Type: flux.TFloat},
+					{Label: "quality", Type: flux.TFloat},
+					{Label: "oee", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{"a", execute.Time(40), 0.5, 1.0, 1.0, 0.5},
+				},
+			}},
+		},
+		{
+			name: "no state column",
+			data: []flux.Table{&executetest.Table{
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "partCount", Type: flux.TInt},
+					{Label: "badCount", Type: flux.TInt},
+				},
+				Data: [][]interface{}{
+					{execute.Time(0), int64(0), int64(0)},
+				},
+			}},
+			wantErr: errors.New(`no column "state" exists`),
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			executetest.ProcessTestHelper(
+				t,
+				tc.data,
+				tc.want,
+				tc.wantErr,
+				func(d execute.Dataset, c execute.TableBuilderCache) execute.Transformation {
+					return oee.NewAPQTransformation(d, c, spec)
+				},
+			)
+		})
+	}
+}
@@ -0,0 +1,460 @@
+// Package sets contains transformations that combine streams of tables as sets of records.
+//
+// The records of the streams are compared on their group key and the values of a chosen
+// set of columns, so that questions such as "what is in A but not in B" can be answered
+// without joining the streams.
+package sets
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/interpreter"
+	"github.com/influxdata/flux/memory"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/flux/values"
+)
+
+const (
+	IntersectKind = "setsIntersect"
+	ExceptKind    = "setsExcept"
+)
+
+// IntersectOpSpec keeps the records of the first stream that are found in all the other streams.
+type IntersectOpSpec struct {
+	Columns []string `json:"columns"`
+}
+
+// ExceptOpSpec keeps the records of the first stream that are found in none of the other streams.
+type ExceptOpSpec struct {
+	Columns []string `json:"columns"`
+}
+
+func init() {
+	setSignature := semantic.FunctionPolySignature{
+		Parameters: map[string]semantic.PolyType{
+			"tables":  semantic.NewArrayPolyType(flux.TableObjectType),
+			"columns": semantic.NewArrayPolyType(semantic.String),
+		},
+		Required: semantic.LabelSet{"tables"},
+		Return:   flux.TableObjectType,
+	}
+
+	flux.RegisterPackageValue("experimental/sets", "intersect", flux.FunctionValue(IntersectKind, createIntersectOpSpec, setSignature))
+	flux.RegisterOpSpec(IntersectKind, newIntersectOp)
+	plan.RegisterProcedureSpec(IntersectKind, newIntersectProcedure, IntersectKind)
+	execute.RegisterTransformation(IntersectKind, createSetTransformation)
+
+	flux.RegisterPackageValue("experimental/sets", "except", flux.FunctionValue(ExceptKind, createExceptOpSpec, setSignature))
+	flux.RegisterOpSpec(ExceptKind, newExceptOp)
+	plan.RegisterProcedureSpec(ExceptKind, newExceptProcedure, ExceptKind)
+	execute.RegisterTransformation(ExceptKind, createSetTransformation)
+}
+
+func createIntersectOpSpec(args flux.Arguments, a *flux.Administration) (flux.OperationSpec, error) {
+	columns, err := readSetArgs("intersect", args, a)
+	if err != nil {
+		return nil, err
+	}
+	return &IntersectOpSpec{Columns: columns}, nil
+}
+
+func createExceptOpSpec(args flux.Arguments, a *flux.Administration) (flux.OperationSpec, error) {
+	columns, err := readSetArgs("except", args, a)
+	if err != nil {
+		return nil, err
+	}
+	return &ExceptOpSpec{Columns: columns}, nil
+}
+
+// readSetArgs adds the streams of the tables argument as parents, in order,
+// and returns the columns to compare the records on.
+func readSetArgs(name string, args flux.Arguments, a *flux.Administration) ([]string, error) {
+	tables, err := args.GetRequiredArray("tables", semantic.Object)
+	if err != nil {
+		return nil, err
+	}
+	if tables.Len() < 2 {
+		return nil, fmt.Errorf("%s must have at least two streams as input", name)
+	}
+
+	tables.Range(func(i int, parent values.Value) {
+		p, ok := parent.(*flux.TableObject)
+		if !ok {
+			err = fmt.Errorf("input to %s is not a table object", name)
+			return
+		}
+		a.AddParent(p)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	columns := []string{}
+	if cols, ok, err := args.GetArray("columns", semantic.String); err != nil {
+		return nil, err
+	} else if ok {
+		columns, err = interpreter.ToStringArray(cols)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return columns, nil
+}
+
+func newIntersectOp() flux.OperationSpec {
+	return new(IntersectOpSpec)
+}
+
+func (s *IntersectOpSpec) Kind() flux.OperationKind {
+	return IntersectKind
+}
+
+func newExceptOp() flux.OperationSpec {
+	return new(ExceptOpSpec)
+}
+
+func (s *ExceptOpSpec) Kind() flux.OperationKind {
+	return ExceptKind
+}
+
+// SetProcedureSpec is the procedure of both intersect and except, Op is the kind of the operation.
+type SetProcedureSpec struct {
+	plan.DefaultCost
+	Op      plan.ProcedureKind
+	Columns []string
+}
+
+func newIntersectProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	spec, ok := qs.(*IntersectOpSpec)
+	if !ok {
+		return nil, fmt.Errorf("invalid spec type %T", qs)
+	}
+	return &SetProcedureSpec{
+		Op:      IntersectKind,
+		Columns: spec.Columns,
+	}, nil
+}
+
+func newExceptProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	spec, ok := qs.(*ExceptOpSpec)
+	if !ok {
+		return nil, fmt.Errorf("invalid spec type %T", qs)
+	}
+	return &SetProcedureSpec{
+		Op:      ExceptKind,
+		Columns: spec.Columns,
+	}, nil
+}
+
+func (s *SetProcedureSpec) Kind() plan.ProcedureKind {
+	return s.Op
+}
+
+func (s *SetProcedureSpec) Copy() plan.ProcedureSpec {
+	ns := new(SetProcedureSpec)
+	*ns = *s
+	ns.Columns = make([]string, len(s.Columns))
+	copy(ns.Columns, s.Columns)
+	return ns
+}
+
+func createSetTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	s, ok := spec.(*SetProcedureSpec)
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid spec type %T", spec)
+	}
+
+	cache := execute.NewTableBuilderCache(a.Allocator())
+	d := execute.NewDataset(id, mode, cache)
+	t, err := NewSetTransformation(d, cache, s, a.Parents(), a.Allocator())
+	if err != nil {
+		return nil, nil, err
+	}
+	return t, d, nil
+}
+
+// setTransformation buffers the tables of the first parent and indexes the records of the
+// other parents. Once all the parents have finished, it emits the records of the first
+// parent according to the number of other parents they were found in.
+//
+// The records of the first parent are not deduplicated.
+type setTransformation struct {
+	mu sync.Mutex
+
+	d     execute.Dataset
+	cache execute.TableBuilderCache
+	alloc *memory.Allocator
+
+	op      plan.ProcedureKind
+	columns []string
+
+	parents     []execute.DatasetID
+	parentState map[execute.DatasetID]*setParentState
+	done        bool
+
+	// tables are the buffered tables of the first parent.
+	tables []flux.Table
+	buf    []byte
+}
+
+type setParentState struct {
+	mark       execute.Time
+	processing execute.Time
+	finished   bool
+
+	// records holds the encoded records of the parent, indexed by group key.
+	records *execute.GroupLookup
+}
+
+func NewSetTransformation(d execute.Dataset, cache execute.TableBuilderCache, spec *SetProcedureSpec, parents []execute.DatasetID, a *memory.Allocator) (*setTransformation, error) {
+	if len(parents) < 2 {
+		return nil, errors.New("set operations must have at least two streams as input")
+	}
+	parentState := make(map[execute.DatasetID]*setParentState, len(parents))
+	for _, id := range parents {
+		parentState[id] = &setParentState{
+			records: execute.NewGroupLookup(),
+		}
+	}
+	return &setTransformation{
+		d:           d,
+		cache:       cache,
+		alloc:       a,
+		op:          spec.Op,
+		columns:     spec.Columns,
+		parents:     parents,
+		parentState: parentState,
+	}, nil
+}
+
+func (t *setTransformation) RetractTable(id execute.DatasetID, key flux.GroupKey) error {
+	panic("not implemented")
+}
+
+func (t *setTransformation) Process(id execute.DatasetID, tbl flux.Table) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if id == t.parents[0] {
+		cpy, err := execute.CopyTable(tbl, t.alloc)
+		if err != nil {
+			return err
+		}
+		t.tables = append(t.tables, cpy)
+		return nil
+	}
+
+	state := t.parentState[id]
+	var records map[string]bool
+	if v, ok := state.records.Lookup(tbl.Key()); ok {
+		records = v.(map[string]bool)
+	} else {
+		records = make(map[string]bool)
+		state.records.Set(tbl.Key(), records)
+	}
+
+	idxs := t.columnIdxs(tbl.Cols())
+	return tbl.Do(func(cr flux.ColReader) error {
+		for i := 0; i < cr.Len(); i++ {
+			records[string(t.encodeRecord(cr, i, idxs))] = true
+		}
+		return nil
+	})
+}
+
+// columnIdxs returns the indexes of the compared columns in cols, or -1 for the missing ones.
+func (t *setTransformation) columnIdxs(cols []flux.ColMeta) []int {
+	idxs := make([]int, len(t.columns))
+	for k, label := range t.columns {
+		idxs[k] = execute.ColIdx(label, cols)
+	}
+	return idxs
+}
+
+// encodeRecord encodes the types and values of the compared columns of row i.
+// A missing column is encoded the same way as a null value,
+// just like union fills missing columns with nulls.
+func (t *setTransformation) encodeRecord(cr flux.ColReader, i int, idxs []int) []byte {
+	t.buf = t.buf[:0]
+	for _, j := range idxs {
+		if j < 0 {
+			t.buf = append(t.buf, 0)
+			continue
+		}
+		typ := cr.Cols()[j].Type
+		switch typ {
+		case flux.TBool:
+			if vs := cr.Bools(j); vs.IsValid(i) {
+				if vs.Value(i) {
+					t.buf = append(t.buf, 1, byte(typ), 1)
+				} else {
+					t.buf = append(t.buf, 1, byte(typ), 0)
+				}
+			} else {
+				t.buf = append(t.buf, 0)
+			}
+		case flux.TInt:
+			if vs := cr.Ints(j); vs.IsValid(i) {
+				t.buf = appendUint64(append(t.buf, 1, byte(typ)), uint64(vs.Value(i)))
+			} else {
+				t.buf = append(t.buf, 0)
+			}
+		case flux.TUInt:
+			if vs := cr.UInts(j); vs.IsValid(i) {
+				t.buf = appendUint64(append(t.buf, 1, byte(typ)), vs.Value(i))
+			} else {
+				t.buf = append(t.buf, 0)
+			}
+		case flux.TFloat:
+			if vs := cr.Floats(j); vs.IsValid(i) {
+				t.buf = appendUint64(append(t.buf, 1, byte(typ)), math.Float64bits(vs.Value(i)))
+			} else {
+				t.buf = append(t.buf, 0)
+			}
+		case flux.TString:
+			if vs := cr.Strings(j); vs.IsValid(i) {
+				// Prefix the string with its length so that consecutive columns cannot collide.
+				t.buf = appendUint64(append(t.buf, 1, byte(typ)), uint64(len(vs.Value(i))))
+				t.buf = append(t.buf, vs.Value(i)...)
+			} else {
+				t.buf = append(t.buf, 0)
+			}
+		case flux.TTime:
+			if vs := cr.Times(j); vs.IsValid(i) {
+				t.buf = appendUint64(append(t.buf, 1, byte(typ)), uint64(vs.Value(i)))
+			} else {
+				t.buf = append(t.buf, 0)
+			}
+		default:
+			execute.PanicUnknownType(typ)
+		}
+	}
+	return t.buf
+}
+
+func appendUint64(buf []byte, v uint64) []byte {
+	var b [8]byte
+	binary.LittleEndian.PutUint64(b[:], v)
+	return append(buf, b[:]...)
+}
+
+// keep reports whether a record of the first parent that was found in n of the other parents is emitted.
+func (t *setTransformation) keep(n int) bool {
+	if t.op == IntersectKind {
+		return n == len(t.parents)-1
+	}
+	return n == 0
+}
+
+// emit appends the kept records of the buffered tables to their builders.
+func (t *setTransformation) emit() error {
+	others := make([]map[string]bool, len(t.parents)-1)
+	for _, tbl := range t.tables {
+		for k, id := range t.parents[1:] {
+			others[k] = nil
+			if v, ok := t.parentState[id].records.Lookup(tbl.Key()); ok {
+				others[k] = v.(map[string]bool)
+			}
+		}
+
+		// The builder is only created once a record is kept,
+		// so that no empty tables are produced.
+		var builder execute.TableBuilder
+		idxs := t.columnIdxs(tbl.Cols())
+		if err := tbl.Do(func(cr flux.ColReader) error {
+			for i := 0; i < cr.Len(); i++ {
+				record := string(t.encodeRecord(cr, i, idxs))
+				n := 0
+				for _, records := range others {
+					if records[record] {
+						n++
+					}
+				}
+				if !t.keep(n) {
+					continue
+				}
+				if builder == nil {
+					var created bool
+					builder, created = t.cache.TableBuilder(tbl.Key())
+					if created {
+						if err := execute.AddTableCols(tbl, builder); err != nil {
+							return err
+						}
+					}
+				}
+				if err := execute.AppendRecord(i, cr, builder); err != nil {
+					return err
+				}
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+	t.tables = nil
+	return nil
+}
+
+func (t *setTransformation) UpdateWatermark(id execute.DatasetID, mark execute.Time) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.parentState[id].mark = mark
+
+	min := execute.Time(math.MaxInt64)
+	for _, state := range t.parentState {
+		if state.mark < min {
+			min = state.mark
+		}
+	}
+
+	return t.d.UpdateWatermark(min)
+}
+
+func (t *setTransformation) UpdateProcessingTime(id execute.DatasetID, pt execute.Time) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.parentState[id].processing = pt
+
+	min := execute.Time(math.MaxInt64)
+	for _, state := range t.parentState {
+		if state.processing < min {
+			min = state.processing
+		}
+	}
+
+	return t.d.UpdateProcessingTime(min)
+}
+
+func (t *setTransformation) Finish(id execute.DatasetID, err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.parentState[id].finished = true
+	if t.done {
+		return
+	}
+
+	if err != nil {
+		t.done = true
+		t.d.Finish(err)
+		return
+	}
+
+	for _, state := range t.parentState {
+		if !state.finished {
+			return
+		}
+	}
+
+	t.done = true
+	t.d.Finish(t.emit())
+}
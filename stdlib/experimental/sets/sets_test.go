@@ -0,0 +1,222 @@
+package sets_test
+
+import (
+	"errors"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/flux/querytest"
+	"github.com/influxdata/flux/stdlib/experimental/sets"
+)
+
+func TestIntersectOperation_Marshaling(t *testing.T) {
+	data := []byte(`{"id":"setsIntersect","kind":"setsIntersect","spec":{"columns":["_time"]}}`)
+	op := &flux.Operation{
+		ID: "setsIntersect",
+		Spec: &sets.IntersectOpSpec{
+			Columns: []string{"_time"},
+		},
+	}
+	querytest.OperationMarshalingTestHelper(t, data, op)
+}
+
+func TestExceptOperation_Marshaling(t *testing.T) {
+	data := []byte(`{"id":"setsExcept","kind":"setsExcept","spec":{"columns":["_time"]}}`)
+	op := &flux.Operation{
+		ID: "setsExcept",
+		Spec: &sets.ExceptOpSpec{
+			Columns: []string{"_time"},
+		},
+	}
+	querytest.OperationMarshalingTestHelper(t, data, op)
+}
+
+func TestSet_Process(t *testing.T) {
+	table := func(host string, rows ...[]interface{}) *executetest.Table {
+		data := make([][]interface{}, len(rows))
+		for i, r := range rows {
+			data[i] = append([]interface{}{host}, r...)
+		}
+		return &executetest.Table{
+			KeyCols: []string{"host"},
+			ColMeta: []flux.ColMeta{
+				{Label: "host", Type: flux.TString},
+				{Label: "_time", Type: flux.TTime},
+				{Label: "_value", Type: flux.TFloat},
+			},
+			Data: data,
+		}
+	}
+	testCases := []struct {
+		name string
+		spec *sets.SetProcedureSpec
+		data [][]flux.Table
+		want []*executetest.Table
+	}{
+		{
+			name: "intersect on time",
+			spec: &sets.SetProcedureSpec{Op: sets.IntersectKind, Columns: []string{"_time"}},
+			data: [][]flux.Table{
+				{
+					table("a", []interface{}{execute.Time(1), 1.0}, []interface{}{execute.Time(2), 2.0}, []interface{}{execute.Time(3), 3.0}),
+					table("b", []interface{}{execute.Time(1), 4.0}),
+				},
+				{
+					table("a", []interface{}{execute.Time(2), 20.0}, []interface{}{execute.Time(3), 30.0}),
+					table("c", []interface{}{execute.Time(1), 5.0}),
+				},
+			},
+			want: []*executetest.Table{
+				table("a", []interface{}{execute.Time(2), 2.0}, []interface{}{execute.Time(3), 3.0}),
+			},
+		},
+		{
+			name: "except on time",
+			spec: &sets.SetProcedureSpec{Op: sets.ExceptKind, Columns: []string{"_time"}},
+			data: [][]flux.Table{
+				{
+					table("a", []interface{}{execute.Time(1), 1.0}, []interface{}{execute.Time(2), 2.0}, []interface{}{execute.Time(3), 3.0}),
+					table("b", []interface{}{execute.Time(1), 4.0}),
+				},
+				{
+					table("a", []interface{}{execute.Time(2), 20.0}, []interface{}{execute.Time(3), 30.0}),
+					table("c", []interface{}{execute.Time(1), 5.0}),
+				},
+			},
+			want: []*executetest.Table{
+				table("a", []interface{}{execute.Time(1), 1.0}),
+				table("b", []interface{}{execute.Time(1), 4.0}),
+			},
+		},
+		{
+			name: "except on group key",
+			spec: &sets.SetProcedureSpec{Op: sets.ExceptKind, Columns: []string{}},
+			data: [][]flux.Table{
+				{
+					table("a", []interface{}{execute.Time(1), 1.0}),
+					table("b", []interface{}{execute.Time(1), 2.0}, []interface{}{execute.Time(2), 3.0}),
+				},
+				{
+					table("a", []interface{}{execute.Time(5), 10.0}),
+				},
+			},
+			want: []*executetest.Table{
+				table("b", []interface{}{execute.Time(1), 2.0}, []interface{}{execute.Time(2), 3.0}),
+			},
+		},
+		{
+			name: "intersect three streams",
+			spec: &sets.SetProcedureSpec{Op: sets.IntersectKind, Columns: []string{"_time", "_value"}},
+			data: [][]flux.Table{
+				{
+					table("a", []interface{}{execute.Time(1), 1.0}, []interface{}{execute.Time(2), 2.0}, []interface{}{execute.Time(3), nil}),
+				},
+				{
+					table("a", []interface{}{execute.Time(1), 1.0}, []interface{}{execute.Time(2), 2.5}, []interface{}{execute.Time(3), nil}),
+				},
+				{
+					table("a", []interface{}{execute.Time(1), 1.0}, []interface{}{execute.Time(3), nil}),
+				},
+			},
+			want: []*executetest.Table{
+				table("a", []interface{}{execute.Time(1), 1.0}, []interface{}{execute.Time(3), nil}),
+			},
+		},
+		{
+			name: "missing column",
+			spec: &sets.SetProcedureSpec{Op: sets.IntersectKind, Columns: []string{"region"}},
+			data: [][]flux.Table{
+				{
+					table("a", []interface{}{execute.Time(1), 1.0}),
+				},
+				{
+					&executetest.Table{
+						KeyCols: []string{"host"},
+						ColMeta: []flux.ColMeta{
+							{Label: "host", Type: flux.TString},
+							{Label: "region", Type: flux.TString},
+						},
+						Data: [][]interface{}{
+							{"a", nil},
+							{"a", "west"},
+						},
+					},
+				},
+			},
+			want: []*executetest.Table{
+				table("a", []interface{}{execute.Time(1), 1.0}),
+			},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			parentIds := make([]execute.DatasetID, len(tc.data))
+			for i := 0; i < len(parentIds); i++ {
+				parentIds[i] = executetest.RandomDatasetID()
+			}
+
+			d := executetest.NewDataset(executetest.RandomDatasetID())
+			c := execute.NewTableBuilderCache(executetest.UnlimitedAllocator)
+			c.SetTriggerSpec(execute.DefaultTriggerSpec)
+			st, err := sets.NewSetTransformation(d, c, tc.spec, parentIds, executetest.UnlimitedAllocator)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// Process the streams in reverse order so that the
+			// other streams are indexed before the first one is seen.
+			for i := len(tc.data) - 1; i >= 0; i-- {
+				for _, tbl := range tc.data[i] {
+					if err := st.Process(parentIds[i], tbl); err != nil {
+						t.Fatal(err)
+					}
+				}
+			}
+			for _, id := range parentIds {
+				st.Finish(id, nil)
+			}
+			if d.FinishedErr != nil {
+				t.Fatal(d.FinishedErr)
+			}
+
+			got, err := executetest.TablesFromCache(c)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			executetest.NormalizeTables(got)
+			executetest.NormalizeTables(tc.want)
+
+			sort.Sort(executetest.SortedTables(got))
+			sort.Sort(executetest.SortedTables(tc.want))
+
+			if !cmp.Equal(tc.want, got) {
+				t.Errorf("unexpected tables -want/+got\n%s", cmp.Diff(tc.want, got))
+			}
+		})
+	}
+}
+
+func TestSet_FinishError(t *testing.T) {
+	parentIds := []execute.DatasetID{executetest.RandomDatasetID(), executetest.RandomDatasetID()}
+	d := executetest.NewDataset(executetest.RandomDatasetID())
+	c := execute.NewTableBuilderCache(executetest.UnlimitedAllocator)
+	c.SetTriggerSpec(execute.DefaultTriggerSpec)
+	spec := &sets.SetProcedureSpec{Op: sets.ExceptKind}
+	st, err := sets.NewSetTransformation(d, c, spec, parentIds, executetest.UnlimitedAllocator)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := errors.New("expected")
+	st.Finish(parentIds[1], want)
+	st.Finish(parentIds[0], nil)
+	if d.FinishedErr != want {
+		t.Errorf("unexpected finish error: want %v, got %v", want, d.FinishedErr)
+	}
+}
@@ -0,0 +1,251 @@
+package experimental
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/flux/values"
+)
+
+const (
+	TableMapKind    = "experimentalTableMap"
+	TableSourceKind = "experimentalTableSource"
+)
+
+// TableMapOpSpec applies the pipeline returned by a function to every table of its input.
+type TableMapOpSpec struct {
+	// Fn is called with the stream made of one table and returns the pipeline to apply to it.
+	// It is evaluated while the query is executed and cannot be serialized.
+	Fn values.Function `json:"-"`
+}
+
+// TableSourceOpSpec produces a single table that is already in memory.
+// It is the source of the pipelines applied to the tables of a tableMap.
+type TableSourceOpSpec struct {
+	Table flux.Table `json:"-"`
+}
+
+func init() {
+	tableMapSignature := semantic.FunctionPolySignature{
+		Parameters: map[string]semantic.PolyType{
+			"tables": flux.TableObjectType,
+			"fn": semantic.NewFunctionPolyType(semantic.FunctionPolySignature{
+				Parameters: map[string]semantic.PolyType{
+					"table": flux.TableObjectType,
+				},
+				Required: semantic.LabelSet{"table"},
+				Return:   flux.TableObjectType,
+			}),
+		},
+		Required:     semantic.LabelSet{"fn"},
+		Return:       flux.TableObjectType,
+		PipeArgument: "tables",
+	}
+
+	flux.RegisterPackageValue("experimental", "tableMap", flux.FunctionValue(TableMapKind, createTableMapOpSpec, tableMapSignature))
+	flux.RegisterOpSpec(TableMapKind, newTableMapOp)
+	plan.RegisterProcedureSpec(TableMapKind, newTableMapProcedure, TableMapKind)
+	execute.RegisterTransformation(TableMapKind, createTableMapTransformation)
+
+	flux.RegisterOpSpec(TableSourceKind, newTableSourceOp)
+	plan.RegisterProcedureSpec(TableSourceKind, newTableSourceProcedure, TableSourceKind)
+	execute.RegisterSource(TableSourceKind, createTableSource)
+}
+
+func createTableMapOpSpec(args flux.Arguments, a *flux.Administration) (flux.OperationSpec, error) {
+	if err := a.AddParentFromArgs(args); err != nil {
+		return nil, err
+	}
+	fn, err := args.GetRequiredFunction("fn")
+	if err != nil {
+		return nil, err
+	}
+	return &TableMapOpSpec{Fn: fn}, nil
+}
+
+func newTableMapOp() flux.OperationSpec {
+	return new(TableMapOpSpec)
+}
+
+func (s *TableMapOpSpec) Kind() flux.OperationKind {
+	return TableMapKind
+}
+
+type TableMapProcedureSpec struct {
+	plan.DefaultCost
+	Fn  values.Function
+	Now time.Time
+}
+
+func newTableMapProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	spec, ok := qs.(*TableMapOpSpec)
+	if !ok {
+		return nil, fmt.Errorf("invalid spec type %T", qs)
+	}
+	if spec.Fn == nil {
+		return nil, fmt.Errorf("%s requires a function, which cannot be read from a serialized query", TableMapKind)
+	}
+	return &TableMapProcedureSpec{
+		Fn:  spec.Fn,
+		Now: pa.Now(),
+	}, nil
+}
+
+func (s *TableMapProcedureSpec) Kind() plan.ProcedureKind {
+	return TableMapKind
+}
+
+func (s *TableMapProcedureSpec) Copy() plan.ProcedureSpec {
+	ns := new(TableMapProcedureSpec)
+	*ns = *s
+	return ns
+}
+
+func createTableMapTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	s, ok := spec.(*TableMapProcedureSpec)
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid spec type %T", spec)
+	}
+	cache := execute.NewTableBuilderCache(a.Allocator())
+	d := execute.NewDataset(id, mode, cache)
+	t := NewTableMapTransformation(d, cache, s, a)
+	return t, d, nil
+}
+
+type tableMapTransformation struct {
+	d     execute.Dataset
+	cache execute.TableBuilderCache
+	a     execute.Administration
+
+	fn  values.Function
+	now time.Time
+}
+
+func NewTableMapTransformation(d execute.Dataset, cache execute.TableBuilderCache, spec *TableMapProcedureSpec, a execute.Administration) *tableMapTransformation {
+	return &tableMapTransformation{
+		d:     d,
+		cache: cache,
+		a:     a,
+		fn:    spec.Fn,
+		now:   spec.Now,
+	}
+}
+
+func (t *tableMapTransformation) RetractTable(id execute.DatasetID, key flux.GroupKey) error {
+	return t.d.RetractTable(key)
+}
+
+// Process executes the pipeline returned by fn on tbl, and adds the tables it produces to the output.
+// Tables with the same group key produced for different input tables are appended to each other.
+func (t *tableMapTransformation) Process(id execute.DatasetID, tbl flux.Table) error {
+	// The table is read by another query, after this call returns.
+	buffered, err := execute.CopyTable(tbl, t.a.Allocator())
+	if err != nil {
+		return err
+	}
+	source := &flux.TableObject{
+		Kind:    TableSourceKind,
+		Spec:    &TableSourceOpSpec{Table: buffered},
+		Parents: values.NewArray(semantic.EmptyObject),
+	}
+	v, err := t.fn.Call(values.NewObjectWithValues(map[string]values.Value{
+		"table": source,
+	}))
+	if err != nil {
+		return err
+	}
+	pipeline, ok := v.(*flux.TableObject)
+	if !ok {
+		return fmt.Errorf("%s: fn must return a stream of tables, got %v", TableMapKind, v.Type())
+	}
+	return flux.EvalTablesAt(t.a.Context(), pipeline, t.now, func(out flux.Table) error {
+		builder, created := t.cache.TableBuilder(out.Key())
+		if created {
+			if err := execute.AddTableCols(out, builder); err != nil {
+				return err
+			}
+		}
+		return execute.AppendTable(out, builder)
+	})
+}
+
+func (t *tableMapTransformation) UpdateWatermark(id execute.DatasetID, mark execute.Time) error {
+	return t.d.UpdateWatermark(mark)
+}
+
+func (t *tableMapTransformation) UpdateProcessingTime(id execute.DatasetID, pt execute.Time) error {
+	return t.d.UpdateProcessingTime(pt)
+}
+
+func (t *tableMapTransformation) Finish(id execute.DatasetID, err error) {
+	t.d.Finish(err)
+}
+
+func newTableSourceOp() flux.OperationSpec {
+	return new(TableSourceOpSpec)
+}
+
+func (s *TableSourceOpSpec) Kind() flux.OperationKind {
+	return TableSourceKind
+}
+
+type TableSourceProcedureSpec struct {
+	plan.DefaultCost
+	Table flux.Table
+}
+
+func newTableSourceProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	spec, ok := qs.(*TableSourceOpSpec)
+	if !ok {
+		return nil, fmt.Errorf("invalid spec type %T", qs)
+	}
+	return &TableSourceProcedureSpec{Table: spec.Table}, nil
+}
+
+func (s *TableSourceProcedureSpec) Kind() plan.ProcedureKind {
+	return TableSourceKind
+}
+
+func (s *TableSourceProcedureSpec) Copy() plan.ProcedureSpec {
+	ns := new(TableSourceProcedureSpec)
+	*ns = *s
+	return ns
+}
+
+func createTableSource(prSpec plan.ProcedureSpec, dsid execute.DatasetID, a execute.Administration) (execute.Source, error) {
+	spec, ok := prSpec.(*TableSourceProcedureSpec)
+	if !ok {
+		return nil, fmt.Errorf("invalid spec type %T", prSpec)
+	}
+	if spec.Table == nil {
+		return nil, fmt.Errorf("%s has no table, it cannot be read from a serialized query", TableSourceKind)
+	}
+	return execute.CreateSourceFromDecoder(&tableDecoder{table: spec.Table}, dsid, a)
+}
+
+// tableDecoder decodes a single table that is already in memory.
+type tableDecoder struct {
+	table flux.Table
+	done  bool
+}
+
+func (d *tableDecoder) Connect() error {
+	return nil
+}
+
+func (d *tableDecoder) Fetch() (bool, error) {
+	return !d.done, nil
+}
+
+func (d *tableDecoder) Decode() (flux.Table, error) {
+	d.done = true
+	return d.table, nil
+}
+
+func (d *tableDecoder) Close() error {
+	return nil
+}
@@ -21,11 +21,11 @@ var pkgAST = &ast.Package{
 			Errors: nil,
 			Loc: &ast.SourceLocation{
 				End: ast.Position{
-					Column: 13,
-					Line:   3,
+					Column: 15,
+					Line:   4,
 				},
 				File:   "generate.flux",
-				Source: "package generate\n\nbuiltin from",
+				Source: "package generate\n\nbuiltin from\nbuiltin series",
 				Start: ast.Position{
 					Column: 1,
 					Line:   1,
@@ -66,6 +66,40 @@ var pkgAST = &ast.Package{
 				},
 				Name: "from",
 			},
+		}, &ast.BuiltinStatement{
+			BaseNode: ast.BaseNode{
+				Errors: nil,
+				Loc: &ast.SourceLocation{
+					End: ast.Position{
+						Column: 15,
+						Line:   4,
+					},
+					File:   "generate.flux",
+					Source: "builtin series",
+					Start: ast.Position{
+						Column: 1,
+						Line:   4,
+					},
+				},
+			},
+			ID: &ast.Identifier{
+				BaseNode: ast.BaseNode{
+					Errors: nil,
+					Loc: &ast.SourceLocation{
+						End: ast.Position{
+							Column: 15,
+							Line:   4,
+						},
+						File:   "generate.flux",
+						Source: "series",
+						Start: ast.Position{
+							Column: 9,
+							Line:   4,
+						},
+					},
+				},
+				Name: "series",
+			},
 		}},
 		Imports: nil,
 		Name:    "generate.flux",
@@ -1,6 +1,7 @@
 package generate
 
 import (
+	"errors"
 	"fmt"
 	"time"
 
@@ -34,10 +35,10 @@ func init() {
 					"n": semantic.Int,
 				},
 				Required: semantic.LabelSet{"n"},
-				Return:   semantic.Int,
+				Return:   semantic.Tvar(1),
 			}),
 		},
-		Required: semantic.LabelSet{"start", "stop", "count", "fn"},
+		Required: semantic.LabelSet{"count", "fn"},
 		Return:   flux.TableObjectType,
 	}
 	flux.RegisterPackageValue("generate", "from", flux.FunctionValue(FromGeneratorKind, createFromGeneratorOpSpec, fromGeneratorSignature))
@@ -49,20 +50,26 @@ func init() {
 func createFromGeneratorOpSpec(args flux.Arguments, a *flux.Administration) (flux.OperationSpec, error) {
 	spec := new(FromGeneratorOpSpec)
 
-	if t, err := args.GetRequiredTime("start"); err != nil {
+	start, hasStart, err := args.GetTime("start")
+	if err != nil {
 		return nil, err
-	} else {
-		spec.Start = t.Time(time.Now())
 	}
-
-	if t, err := args.GetRequiredTime("stop"); err != nil {
+	stop, hasStop, err := args.GetTime("stop")
+	if err != nil {
 		return nil, err
-	} else {
-		spec.Stop = t.Time(time.Now())
+	}
+	if hasStart != hasStop {
+		return nil, errors.New("start and stop must be given together")
+	}
+	if hasStart {
+		spec.Start = start.Time(time.Now())
+		spec.Stop = stop.Time(time.Now())
 	}
 
 	if i, err := args.GetRequiredInt("count"); err != nil {
 		return nil, err
+	} else if i < 0 {
+		return nil, fmt.Errorf("count must not be negative, got %d", i)
 	} else {
 		spec.Count = i
 	}
@@ -97,13 +104,12 @@ type FromGeneratorProcedureSpec struct {
 }
 
 func newFromGeneratorProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
-	// TODO: copy over data from the OpSpec to the ProcedureSpec
 	spec, ok := qs.(*FromGeneratorOpSpec)
 	if !ok {
 		return nil, fmt.Errorf("invalid spec type %T", qs)
 	}
 
-	fn, _, err := compiler.CompileFnParam(spec.Fn, semantic.Int, semantic.Int)
+	fn, err := compileFn(spec.Fn, map[string]semantic.Type{"n": semantic.Int})
 	if err != nil {
 		return nil, err
 	}
@@ -121,10 +127,40 @@ func (s *FromGeneratorProcedureSpec) Kind() plan.ProcedureKind {
 
 func (s *FromGeneratorProcedureSpec) Copy() plan.ProcedureSpec {
 	ns := new(FromGeneratorProcedureSpec)
-
+	*ns = *s
 	return ns
 }
 
+// hasTime reports whether the generated rows are spread over a time range.
+func (s *FromGeneratorProcedureSpec) hasTime() bool {
+	return !s.Start.IsZero() || !s.Stop.IsZero()
+}
+
+// SortedBy implements plan.OrderedProcedureSpec, the rows are generated in time order.
+func (s *FromGeneratorProcedureSpec) SortedBy(predecessors [][]string) []string {
+	if !s.hasTime() {
+		return nil
+	}
+	return []string{execute.DefaultTimeColLabel}
+}
+
+// OutputSchema implements plan.SchemaProcedureSpec, the generated table always has the same columns.
+// Without a time range it only has a value column.
+func (s *FromGeneratorProcedureSpec) OutputSchema(predecessors []*plan.Schema) *plan.Schema {
+	value := flux.ColMeta{Label: execute.DefaultValueColLabel, Type: flux.ColumnType(s.Fn.Type())}
+	if !s.hasTime() {
+		return &plan.Schema{Columns: []flux.ColMeta{value}}
+	}
+	return &plan.Schema{
+		Columns: []flux.ColMeta{
+			{Label: execute.DefaultStartColLabel, Type: flux.TTime},
+			{Label: execute.DefaultStopColLabel, Type: flux.TTime},
+			{Label: execute.DefaultTimeColLabel, Type: flux.TTime},
+			value,
+		},
+	}
+}
+
 func createFromGeneratorSource(prSpec plan.ProcedureSpec, dsid execute.DatasetID, a execute.Administration) (execute.Source, error) {
 	spec, ok := prSpec.(*FromGeneratorProcedureSpec)
 	if !ok {
@@ -140,6 +176,8 @@ func createFromGeneratorSource(prSpec plan.ProcedureSpec, dsid execute.DatasetID
 	return execute.CreateSourceFromDecoder(s, dsid, a)
 }
 
+// GeneratorSource decodes a single table of Count rows whose values are returned by Fn for the row numbers.
+// If the source has a time range, the rows are spread evenly over it.
 type GeneratorSource struct {
 	done  bool
 	Start time.Time
@@ -165,55 +203,41 @@ func (s *GeneratorSource) Decode() (flux.Table, error) {
 	defer func() {
 		s.done = true
 	}()
-	ks := []flux.ColMeta{
-		flux.ColMeta{
-			Label: "_start",
-			Type:  flux.TTime,
-		},
-		flux.ColMeta{
-			Label: "_stop",
-			Type:  flux.TTime,
-		},
+	hasTime := !s.Start.IsZero() || !s.Stop.IsZero()
+	b := execute.NewColListTableBuilder(execute.NewGroupKey(nil, nil), s.alloc)
+	if hasTime {
+		var err error
+		if b, err = newTimeRangeBuilder(values.ConvertTime(s.Start), values.ConvertTime(s.Stop), s.alloc); err != nil {
+			return nil, err
+		}
 	}
-	vs := []values.Value{
-		values.NewTime(values.ConvertTime(s.Start)),
-		values.NewTime(values.ConvertTime(s.Stop)),
+	if _, err := b.AddCol(flux.ColMeta{Label: execute.DefaultValueColLabel, Type: flux.ColumnType(s.Fn.Type())}); err != nil {
+		return nil, err
 	}
-	groupKey := execute.NewGroupKey(ks, vs)
-	b := execute.NewColListTableBuilder(groupKey, s.alloc)
 
-	cols := []flux.ColMeta{
-		flux.ColMeta{
-			Label: "_time",
-			Type:  flux.TTime,
-		},
-		flux.ColMeta{
-			Label: "_value",
-			Type:  flux.TInt,
-		},
+	cols := b.Cols()
+	timeIdx := execute.ColIdx(execute.DefaultTimeColLabel, cols)
+	valueIdx := execute.ColIdx(execute.DefaultValueColLabel, cols)
+	var deltaT time.Duration
+	if s.Count > 0 {
+		deltaT = s.Stop.Sub(s.Start) / time.Duration(s.Count)
 	}
-
-	for _, col := range cols {
-		_, err := b.AddCol(col)
-		if err != nil {
-			return nil, err
+	for i := int64(0); i < s.Count; i++ {
+		if hasTime {
+			if err := execute.AppendKeyValues(b.Key(), b); err != nil {
+				return nil, err
+			}
+			if err := b.AppendTime(timeIdx, values.ConvertTime(s.Start.Add(time.Duration(i)*deltaT))); err != nil {
+				return nil, err
+			}
 		}
-	}
-
-	cols = b.Cols()
-
-	deltaT := s.Stop.Sub(s.Start) / time.Duration(s.Count)
-	timeIdx := execute.ColIdx("_time", cols)
-	valueIdx := execute.ColIdx("_value", cols)
-	for i := 0; i < int(s.Count); i++ {
-		b.AppendTime(timeIdx, values.ConvertTime(s.Start.Add(time.Duration(i)*deltaT)))
 		in := values.NewObject()
-		in.Set("n", values.NewInt(int64(i)))
-		v, err := s.Fn.EvalInt(in)
+		in.Set("n", values.NewInt(i))
+		v, err := s.Fn.Eval(in)
 		if err != nil {
 			return nil, err
 		}
-		if err := b.AppendInt(valueIdx, v); err != nil {
+		if err := b.AppendValue(valueIdx, v); err != nil {
 			return nil, err
 		}
 	}
@@ -224,3 +248,49 @@ func (s *GeneratorSource) Decode() (flux.Table, error) {
 func (s *GeneratorSource) Close() error {
 	return nil
 }
+
+// compileFn compiles the fn parameter of a generator for the parameters it declares among params.
+// The values it returns are the values of the _value column, so they must be of a column type.
+func compileFn(fn *semantic.FunctionExpression, params map[string]semantic.Type) (compiler.Func, error) {
+	in := make(map[string]semantic.Type, len(params))
+	if fn.Block.Parameters != nil {
+		for _, p := range fn.Block.Parameters.List {
+			typ, ok := params[p.Key.Name]
+			if !ok {
+				return nil, fmt.Errorf("function does not take a parameter %q", p.Key.Name)
+			}
+			in[p.Key.Name] = typ
+		}
+	}
+	compiled, err := compiler.Compile(fn, semantic.NewObjectType(in), flux.BuiltIns())
+	if err != nil {
+		return nil, err
+	}
+	if flux.ColumnType(compiled.Type()) == flux.TInvalid {
+		return nil, fmt.Errorf("provided function does not evaluate to a column type, got %s", compiled.Type().Nature())
+	}
+	return compiled, nil
+}
+
+// newTimeRangeBuilder returns a builder for a table of the time range from start to stop,
+// with the _start and _stop columns of its group key and a _time column.
+func newTimeRangeBuilder(start, stop values.Time, alloc *memory.Allocator) (*execute.ColListTableBuilder, error) {
+	key := execute.NewGroupKey(
+		[]flux.ColMeta{
+			{Label: execute.DefaultStartColLabel, Type: flux.TTime},
+			{Label: execute.DefaultStopColLabel, Type: flux.TTime},
+		},
+		[]values.Value{
+			values.NewTime(start),
+			values.NewTime(stop),
+		},
+	)
+	b := execute.NewColListTableBuilder(key, alloc)
+	if err := execute.AddTableKeyCols(key, b); err != nil {
+		return nil, err
+	}
+	if _, err := b.AddCol(flux.ColMeta{Label: execute.DefaultTimeColLabel, Type: flux.TTime}); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
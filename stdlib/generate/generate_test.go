@@ -0,0 +1,209 @@
+package generate_test
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/flux"
+	_ "github.com/influxdata/flux/builtin"
+	"github.com/influxdata/flux/control"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/flux/lang"
+	"github.com/influxdata/flux/querytest"
+)
+
+// query executes a script and returns the tables of its results.
+func query(t *testing.T, script string) ([]*executetest.Table, error) {
+	t.Helper()
+	c := control.New(control.Config{
+		ConcurrencyQuota: 1,
+		MemoryBytesQuota: math.MaxInt64,
+	})
+	defer func() {
+		if err := c.Shutdown(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}()
+	q, err := c.Query(context.Background(), lang.FluxCompiler{Query: script})
+	if err != nil {
+		return nil, err
+	}
+	defer q.Done()
+
+	var got []*executetest.Table
+	for _, r := range <-q.Ready() {
+		if err := r.Tables().Do(func(tbl flux.Table) error {
+			ct, err := executetest.ConvertTable(tbl)
+			if err != nil {
+				return err
+			}
+			got = append(got, ct)
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+	}
+	if err := q.Err(); err != nil {
+		return nil, err
+	}
+	executetest.NormalizeTables(got)
+	return got, nil
+}
+
+func TestGenerate_NewQuery(t *testing.T) {
+	tests := []querytest.NewQueryTestCase{
+		{
+			Name:    "from without count",
+			Raw:     `import "generate" generate.from(fn: (n) => n)`,
+			WantErr: true,
+		},
+		{
+			Name:    "from with start only",
+			Raw:     `import "generate" generate.from(start: 2019-01-01T00:00:00Z, count: 3, fn: (n) => n)`,
+			WantErr: true,
+		},
+		{
+			Name:    "from negative count",
+			Raw:     `import "generate" generate.from(count: -1, fn: (n) => n)`,
+			WantErr: true,
+		},
+		{
+			Name:    "series zero every",
+			Raw:     `import "generate" generate.series(start: 2019-01-01T00:00:00Z, stop: 2019-01-02T00:00:00Z, every: 0s, fn: (n) => n)`,
+			WantErr: true,
+		},
+		{
+			Name:    "series unknown parameter",
+			Raw:     `import "generate" generate.series(start: 2019-01-01T00:00:00Z, stop: 2019-01-02T00:00:00Z, every: 1h, fn: (r) => 1)`,
+			WantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			querytest.NewQueryTestHelper(t, tc)
+		})
+	}
+}
+
+func TestGenerate_Query(t *testing.T) {
+	start, stop := execute.Time(0), execute.Time(60e9)
+	timeCols := func(typ flux.ColType) []flux.ColMeta {
+		return []flux.ColMeta{
+			{Label: "_start", Type: flux.TTime},
+			{Label: "_stop", Type: flux.TTime},
+			{Label: "_time", Type: flux.TTime},
+			{Label: "_value", Type: typ},
+		}
+	}
+	keyCols := []string{"_start", "_stop"}
+	testCases := []struct {
+		name    string
+		script  string
+		want    []*executetest.Table
+		wantErr bool
+	}{
+		{
+			name:   "from",
+			script: `import "generate" generate.from(count: 3, fn: (n) => n * n)`,
+			want: []*executetest.Table{{
+				ColMeta: []flux.ColMeta{{Label: "_value", Type: flux.TInt}},
+				Data: [][]interface{}{
+					{int64(0)},
+					{int64(1)},
+					{int64(4)},
+				},
+			}},
+		},
+		{
+			name:   "from time range",
+			script: `import "generate" generate.from(start: 1970-01-01T00:00:00Z, stop: 1970-01-01T00:01:00Z, count: 3, fn: (n) => float(v: n) / 2.0)`,
+			want: []*executetest.Table{{
+				KeyCols: keyCols,
+				ColMeta: timeCols(flux.TFloat),
+				Data: [][]interface{}{
+					{start, stop, execute.Time(0), 0.0},
+					{start, stop, execute.Time(20e9), 0.5},
+					{start, stop, execute.Time(40e9), 1.0},
+				},
+			}},
+		},
+		{
+			name:   "from no rows",
+			script: `import "generate" generate.from(start: 1970-01-01T00:00:00Z, stop: 1970-01-01T00:01:00Z, count: 0, fn: (n) => n)`,
+			want: []*executetest.Table{{
+				KeyCols:   keyCols,
+				KeyValues: []interface{}{start, stop},
+				ColMeta:   timeCols(flux.TInt),
+			}},
+		},
+		{
+			name:   "series",
+			script: `import "generate" generate.series(start: 1970-01-01T00:00:00Z, stop: 1970-01-01T00:01:00Z, every: 25s, fn: (n, t) => "row " + string(v: n) + " at " + string(v: t))`,
+			want: []*executetest.Table{{
+				KeyCols: keyCols,
+				ColMeta: timeCols(flux.TString),
+				Data: [][]interface{}{
+					{start, stop, execute.Time(0), "row 0 at 1970-01-01T00:00:00.000000000Z"},
+					{start, stop, execute.Time(25e9), "row 1 at 1970-01-01T00:00:25.000000000Z"},
+					{start, stop, execute.Time(50e9), "row 2 at 1970-01-01T00:00:50.000000000Z"},
+				},
+			}},
+		},
+		{
+			name:   "series of times",
+			script: `import "generate" generate.series(start: 1970-01-01T00:00:00Z, stop: 1970-01-01T00:01:00Z, every: 30s, fn: (t) => t)`,
+			want: []*executetest.Table{{
+				KeyCols: keyCols,
+				ColMeta: timeCols(flux.TTime),
+				Data: [][]interface{}{
+					{start, stop, execute.Time(0), execute.Time(0)},
+					{start, stop, execute.Time(30e9), execute.Time(30e9)},
+				},
+			}},
+		},
+		{
+			name:   "series transformed",
+			script: `import "generate" generate.series(start: 1970-01-01T00:00:00Z, stop: 1970-01-01T00:01:00Z, every: 10s, fn: (n) => n) |> sum()`,
+			want: []*executetest.Table{{
+				KeyCols: keyCols,
+				ColMeta: []flux.ColMeta{
+					{Label: "_start", Type: flux.TTime},
+					{Label: "_stop", Type: flux.TTime},
+					{Label: "_value", Type: flux.TInt},
+				},
+				Data: [][]interface{}{
+					{start, stop, int64(15)},
+				},
+			}},
+		},
+		{
+			name:    "from values of no column type",
+			script:  `import "generate" generate.from(count: 3, fn: (n) => [n])`,
+			wantErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := query(t, tc.script)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			executetest.NormalizeTables(tc.want)
+			if !cmp.Equal(tc.want, got) {
+				t.Errorf("unexpected tables -want/+got\n%s", cmp.Diff(tc.want, got))
+			}
+		})
+	}
+}
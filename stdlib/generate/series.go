@@ -0,0 +1,245 @@
+package generate
+
+import (
+	"fmt"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/compiler"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/interpreter"
+	"github.com/influxdata/flux/memory"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/flux/values"
+)
+
+const SeriesGeneratorKind = "seriesGenerator"
+
+type SeriesGeneratorOpSpec struct {
+	Start flux.Time                    `json:"start"`
+	Stop  flux.Time                    `json:"stop"`
+	Every flux.Duration                `json:"every"`
+	Fn    *semantic.FunctionExpression `json:"fn"`
+}
+
+func init() {
+	seriesGeneratorSignature := semantic.FunctionPolySignature{
+		Parameters: map[string]semantic.PolyType{
+			"start": semantic.Time,
+			"stop":  semantic.Time,
+			"every": semantic.Duration,
+			"fn": semantic.NewFunctionPolyType(semantic.FunctionPolySignature{
+				Parameters: map[string]semantic.PolyType{
+					"n": semantic.Int,
+					"t": semantic.Time,
+				},
+				Return: semantic.Tvar(1),
+			}),
+		},
+		Required: semantic.LabelSet{"start", "stop", "every", "fn"},
+		Return:   flux.TableObjectType,
+	}
+	flux.RegisterPackageValue("generate", "series", flux.FunctionValue(SeriesGeneratorKind, createSeriesGeneratorOpSpec, seriesGeneratorSignature))
+	flux.RegisterOpSpec(SeriesGeneratorKind, newSeriesGeneratorOp)
+	plan.RegisterProcedureSpec(SeriesGeneratorKind, newSeriesGeneratorProcedure, SeriesGeneratorKind)
+	execute.RegisterSource(SeriesGeneratorKind, createSeriesGeneratorSource)
+}
+
+func createSeriesGeneratorOpSpec(args flux.Arguments, a *flux.Administration) (flux.OperationSpec, error) {
+	spec := new(SeriesGeneratorOpSpec)
+
+	if t, err := args.GetRequiredTime("start"); err != nil {
+		return nil, err
+	} else {
+		spec.Start = t
+	}
+
+	if t, err := args.GetRequiredTime("stop"); err != nil {
+		return nil, err
+	} else {
+		spec.Stop = t
+	}
+
+	if d, err := args.GetRequiredDuration("every"); err != nil {
+		return nil, err
+	} else if !d.IsPositive() {
+		return nil, fmt.Errorf("every must be positive, got %v", d)
+	} else {
+		spec.Every = d
+	}
+
+	if f, err := args.GetRequiredFunction("fn"); err != nil {
+		return nil, err
+	} else {
+		fn, err := interpreter.ResolveFunction(f)
+		if err != nil {
+			return nil, err
+		}
+		spec.Fn = fn
+	}
+
+	return spec, nil
+}
+
+func newSeriesGeneratorOp() flux.OperationSpec {
+	return new(SeriesGeneratorOpSpec)
+}
+
+func (s *SeriesGeneratorOpSpec) Kind() flux.OperationKind {
+	return SeriesGeneratorKind
+}
+
+type SeriesGeneratorProcedureSpec struct {
+	plan.DefaultCost
+	Start values.Time
+	Stop  values.Time
+	Every values.Duration
+	Fn    compiler.Func
+	// Params are the names of the parameters that Fn takes, out of n and t.
+	Params []string
+}
+
+func newSeriesGeneratorProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	spec, ok := qs.(*SeriesGeneratorOpSpec)
+	if !ok {
+		return nil, fmt.Errorf("invalid spec type %T", qs)
+	}
+
+	fn, err := compileFn(spec.Fn, map[string]semantic.Type{
+		"n": semantic.Int,
+		"t": semantic.Time,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var params []string
+	if spec.Fn.Block.Parameters != nil {
+		for _, p := range spec.Fn.Block.Parameters.List {
+			params = append(params, p.Key.Name)
+		}
+	}
+	return &SeriesGeneratorProcedureSpec{
+		Start:  values.ConvertTime(spec.Start.Time(pa.Now())),
+		Stop:   values.ConvertTime(spec.Stop.Time(pa.Now())),
+		Every:  spec.Every,
+		Fn:     fn,
+		Params: params,
+	}, nil
+}
+
+func (s *SeriesGeneratorProcedureSpec) Kind() plan.ProcedureKind {
+	return SeriesGeneratorKind
+}
+
+func (s *SeriesGeneratorProcedureSpec) Copy() plan.ProcedureSpec {
+	ns := new(SeriesGeneratorProcedureSpec)
+	*ns = *s
+	ns.Params = append([]string(nil), s.Params...)
+	return ns
+}
+
+// SortedBy implements plan.OrderedProcedureSpec, the rows are generated in time order.
+func (s *SeriesGeneratorProcedureSpec) SortedBy(predecessors [][]string) []string {
+	return []string{execute.DefaultTimeColLabel}
+}
+
+// OutputSchema implements plan.SchemaProcedureSpec, the generated table always has the same columns.
+func (s *SeriesGeneratorProcedureSpec) OutputSchema(predecessors []*plan.Schema) *plan.Schema {
+	return &plan.Schema{
+		Columns: []flux.ColMeta{
+			{Label: execute.DefaultStartColLabel, Type: flux.TTime},
+			{Label: execute.DefaultStopColLabel, Type: flux.TTime},
+			{Label: execute.DefaultTimeColLabel, Type: flux.TTime},
+			{Label: execute.DefaultValueColLabel, Type: flux.ColumnType(s.Fn.Type())},
+		},
+	}
+}
+
+func createSeriesGeneratorSource(prSpec plan.ProcedureSpec, dsid execute.DatasetID, a execute.Administration) (execute.Source, error) {
+	spec, ok := prSpec.(*SeriesGeneratorProcedureSpec)
+	if !ok {
+		return nil, fmt.Errorf("invalid spec type %T", prSpec)
+	}
+
+	s := NewSeriesSource(a.Allocator())
+	s.Start = spec.Start
+	s.Stop = spec.Stop
+	s.Every = spec.Every
+	s.Fn = spec.Fn
+	s.Params = spec.Params
+
+	return execute.CreateSourceFromDecoder(s, dsid, a)
+}
+
+// SeriesSource decodes a single table with a row at every interval of Every from Start until Stop,
+// whose values are returned by Fn for the row numbers and times.
+type SeriesSource struct {
+	done   bool
+	Start  values.Time
+	Stop   values.Time
+	Every  values.Duration
+	alloc  *memory.Allocator
+	Fn     compiler.Func
+	Params []string
+}
+
+func NewSeriesSource(a *memory.Allocator) *SeriesSource {
+	return &SeriesSource{alloc: a}
+}
+
+func (s *SeriesSource) Connect() error {
+	return nil
+}
+
+func (s *SeriesSource) Fetch() (bool, error) {
+	return !s.done, nil
+}
+
+func (s *SeriesSource) Decode() (flux.Table, error) {
+	defer func() {
+		s.done = true
+	}()
+	b, err := newTimeRangeBuilder(s.Start, s.Stop, s.alloc)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := b.AddCol(flux.ColMeta{Label: execute.DefaultValueColLabel, Type: flux.ColumnType(s.Fn.Type())}); err != nil {
+		return nil, err
+	}
+
+	cols := b.Cols()
+	timeIdx := execute.ColIdx(execute.DefaultTimeColLabel, cols)
+	valueIdx := execute.ColIdx(execute.DefaultValueColLabel, cols)
+	// The times are offsets from the start, rather than increments of the previous time,
+	// so that durations of months always land on the same day of the month.
+	for n, t := int64(0), s.Start; t < s.Stop; n, t = n+1, s.Start.Add(s.Every.Mul(n+1)) {
+		if err := execute.AppendKeyValues(b.Key(), b); err != nil {
+			return nil, err
+		}
+		if err := b.AppendTime(timeIdx, t); err != nil {
+			return nil, err
+		}
+		in := values.NewObject()
+		for _, p := range s.Params {
+			switch p {
+			case "n":
+				in.Set("n", values.NewInt(n))
+			case "t":
+				in.Set("t", values.NewTime(t))
+			}
+		}
+		v, err := s.Fn.Eval(in)
+		if err != nil {
+			return nil, err
+		}
+		if err := b.AppendValue(valueIdx, v); err != nil {
+			return nil, err
+		}
+	}
+
+	return b.Table()
+}
+
+func (s *SeriesSource) Close() error {
+	return nil
+}
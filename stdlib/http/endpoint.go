@@ -0,0 +1,511 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/dependencies"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/interpreter"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/flux/values"
+	"go.uber.org/zap"
+)
+
+// Endpoints send a notification for every row of their input. The notification of a row is
+// the record that the mapping function given to the endpoint returns for the row.
+// The rows are output with two more columns that tell whether their notification was sent.
+const (
+	// SentColLabel is the label of the boolean column that tells whether the notification of a row was sent.
+	SentColLabel = "_sent"
+	// ErrorColLabel is the label of the column with the error that the notification of a row failed with,
+	// which is empty when it was sent.
+	ErrorColLabel = "_error"
+)
+
+const (
+	EndpointKind = "httpEndpoint"
+
+	// DefaultEndpointTimeout bounds the time a notification takes to be sent.
+	DefaultEndpointTimeout = 30 * time.Second
+)
+
+func init() {
+	endpointSignature := semantic.FunctionPolySignature{
+		Parameters: map[string]semantic.PolyType{
+			"url":       semantic.String,
+			"batchSize": semantic.Int,
+		},
+		Required: semantic.LabelSet{"url"},
+	}
+	flux.RegisterPackageValue("http", "endpoint", NewEndpointFunction(EndpointKind, endpointSignature, createEndpointOpSpec))
+	flux.RegisterOpSpec(EndpointKind, func() flux.OperationSpec { return &HTTPEndpointOpSpec{} })
+	plan.RegisterProcedureSpecWithSideEffect(EndpointKind, newEndpointProcedure, EndpointKind)
+	execute.RegisterTransformation(EndpointKind, createEndpointTransformation)
+}
+
+// EndpointSpec is the part of the operation spec of an endpoint that is common to all endpoints.
+type EndpointSpec struct {
+	// Fn maps the rows to their notifications.
+	Fn *semantic.FunctionExpression `json:"fn"`
+	// BatchSize is the largest number of notifications that are sent at once.
+	BatchSize int `json:"batchSize"`
+}
+
+// Endpoint returns the spec, so that the operation specs that embed it implement EndpointOpSpec.
+func (s *EndpointSpec) Endpoint() *EndpointSpec {
+	return s
+}
+
+// Copy returns a deep copy of the spec.
+func (s EndpointSpec) Copy() EndpointSpec {
+	if s.Fn != nil {
+		s.Fn = s.Fn.Copy().(*semantic.FunctionExpression)
+	}
+	return s
+}
+
+// ReadBatchSize reads the batchSize argument of an endpoint, which defaults to 1.
+func (s *EndpointSpec) ReadBatchSize(args flux.Arguments) error {
+	batchSize, ok, err := args.GetInt("batchSize")
+	if err != nil {
+		return err
+	}
+	if !ok {
+		batchSize = 1
+	}
+	if batchSize < 1 {
+		return fmt.Errorf("batchSize must be positive, got %d", batchSize)
+	}
+	s.BatchSize = int(batchSize)
+	return nil
+}
+
+// EndpointOpSpec is the operation spec of an endpoint, which embeds an EndpointSpec.
+type EndpointOpSpec interface {
+	flux.OperationSpec
+	Endpoint() *EndpointSpec
+}
+
+// NewEndpointFunction returns the function of an endpoint, which takes the configuration of the endpoint
+// and returns a function that takes the mapping function of the notifications. That function returns
+// the function that sends the notifications of its input tables, as in
+//
+//	http.endpoint(url: u)(mapFn: (r) => ({text: r._value}))
+//
+// The configuration is read by createOpSpec, which is called once when the endpoint is configured,
+// to report invalid arguments early, and once for every mapping function.
+func NewEndpointFunction(kind flux.OperationKind, config semantic.FunctionPolySignature, createOpSpec func(args flux.Arguments) (EndpointOpSpec, error)) values.Function {
+	mapSignature := semantic.FunctionPolySignature{
+		Parameters: map[string]semantic.PolyType{
+			"mapFn": semantic.NewFunctionPolyType(semantic.FunctionPolySignature{
+				Parameters: map[string]semantic.PolyType{
+					"r": semantic.Tvar(1),
+				},
+				Required: semantic.LabelSet{"r"},
+				Return:   semantic.Tvar(2),
+			}),
+		},
+		Required: semantic.LabelSet{"mapFn"},
+	}
+	tablesSignature := flux.FunctionSignature(nil, nil)
+	mapSignature.Return = semantic.NewFunctionPolyType(tablesSignature)
+	config.Return = semantic.NewFunctionPolyType(mapSignature)
+
+	name := string(kind)
+	return values.NewFunction(name, semantic.NewFunctionPolyType(config), func(configArgs values.Object) (values.Value, error) {
+		return interpreter.DoFunctionCall(func(args interpreter.Arguments) (values.Value, error) {
+			if _, err := createOpSpec(flux.Arguments{Arguments: args}); err != nil {
+				return nil, err
+			}
+			return values.NewFunction(name, semantic.NewFunctionPolyType(mapSignature), func(mapArgs values.Object) (values.Value, error) {
+				return interpreter.DoFunctionCall(func(args interpreter.Arguments) (values.Value, error) {
+					f, err := args.GetRequiredFunction("mapFn")
+					if err != nil {
+						return nil, err
+					}
+					fn, err := interpreter.ResolveFunction(f)
+					if err != nil {
+						return nil, err
+					}
+					return flux.FunctionValueWithSideEffect(name, func(args flux.Arguments, a *flux.Administration) (flux.OperationSpec, error) {
+						if err := a.AddParentFromArgs(args); err != nil {
+							return nil, err
+						}
+						spec, err := createOpSpec(flux.Arguments{Arguments: interpreter.NewArguments(configArgs)})
+						if err != nil {
+							return nil, err
+						}
+						spec.Endpoint().Fn = fn
+						return spec, nil
+					}, tablesSignature), nil
+				}, mapArgs)
+			}, false), nil
+		}, configArgs)
+	}, false)
+}
+
+// HTTPEndpointOpSpec is the operation spec of http.endpoint, which posts the notifications
+// as JSON to a URL.
+type HTTPEndpointOpSpec struct {
+	EndpointSpec
+	URL string `json:"url"`
+}
+
+func createEndpointOpSpec(args flux.Arguments) (EndpointOpSpec, error) {
+	spec := new(HTTPEndpointOpSpec)
+	var err error
+	if spec.URL, err = args.GetRequiredString("url"); err != nil {
+		return nil, err
+	}
+	if err := spec.ReadBatchSize(args); err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
+
+func (s *HTTPEndpointOpSpec) Kind() flux.OperationKind {
+	return EndpointKind
+}
+
+func (s *HTTPEndpointOpSpec) Resources() []flux.Resource {
+	return []flux.Resource{{Kind: flux.URLResource, Name: s.URL, Mode: flux.WriteAccess}}
+}
+
+type EndpointProcedureSpec struct {
+	plan.DefaultCost
+	Spec *HTTPEndpointOpSpec
+}
+
+func newEndpointProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	spec, ok := qs.(*HTTPEndpointOpSpec)
+	if !ok {
+		return nil, fmt.Errorf("invalid spec type %T", qs)
+	}
+	return &EndpointProcedureSpec{Spec: spec}, nil
+}
+
+func (s *EndpointProcedureSpec) Kind() plan.ProcedureKind {
+	return EndpointKind
+}
+
+func (s *EndpointProcedureSpec) Copy() plan.ProcedureSpec {
+	spec := *s.Spec
+	spec.EndpointSpec = s.Spec.EndpointSpec.Copy()
+	return &EndpointProcedureSpec{Spec: &spec}
+}
+
+func createEndpointTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	s, ok := spec.(*EndpointProcedureSpec)
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid spec type %T", spec)
+	}
+	cache := execute.NewTableBuilderCache(a.Allocator())
+	d := execute.NewDataset(id, mode, cache)
+	t, err := NewEndpointTransformation(a.Context(), d, cache, s.Spec.EndpointSpec, s.Spec.Send)
+	if err != nil {
+		return nil, nil, err
+	}
+	return t, d, nil
+}
+
+// Send posts a notification as a JSON object, and a batch of notifications as a JSON array.
+func (s *HTTPEndpointOpSpec) Send(ctx context.Context, notifications []values.Object) error {
+	var v interface{}
+	if s.BatchSize == 1 {
+		v = ToJSON(notifications[0])
+	} else {
+		vs := make([]interface{}, len(notifications))
+		for i, n := range notifications {
+			vs[i] = ToJSON(n)
+		}
+		v = vs
+	}
+	body, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	header := http.Header{}
+	header.Set("Content-Type", "application/json")
+	status, _, err := Post(ctx, s.URL, header, body)
+	if err != nil {
+		return err
+	}
+	return CheckStatus(status)
+}
+
+// EndpointSender sends a batch of notifications.
+type EndpointSender func(ctx context.Context, notifications []values.Object) error
+
+// EndpointTransformation sends the notifications of its input rows in batches,
+// and outputs the rows with the columns that tell whether their notification was sent.
+// A batch that cannot be sent does not fail the query, its error is set in the rows of the batch.
+type EndpointTransformation struct {
+	ctx   context.Context
+	d     execute.Dataset
+	cache execute.TableBuilderCache
+
+	fn        *execute.RowMapFn
+	batchSize int
+	send      EndpointSender
+}
+
+// NewEndpointTransformation creates a transformation that sends the notifications of its input with send.
+func NewEndpointTransformation(ctx context.Context, d execute.Dataset, cache execute.TableBuilderCache, spec EndpointSpec, send EndpointSender) (*EndpointTransformation, error) {
+	fn, err := execute.NewRowMapFn(spec.Fn)
+	if err != nil {
+		return nil, err
+	}
+	batchSize := spec.BatchSize
+	if batchSize < 1 {
+		batchSize = 1
+	}
+	return &EndpointTransformation{
+		ctx:       ctx,
+		d:         d,
+		cache:     cache,
+		fn:        fn,
+		batchSize: batchSize,
+		send:      send,
+	}, nil
+}
+
+func (t *EndpointTransformation) RetractTable(id execute.DatasetID, key flux.GroupKey) error {
+	return t.d.RetractTable(key)
+}
+
+func (t *EndpointTransformation) Process(id execute.DatasetID, tbl flux.Table) error {
+	for _, label := range []string{SentColLabel, ErrorColLabel} {
+		if execute.ColIdx(label, tbl.Cols()) >= 0 {
+			return fmt.Errorf("the input of an endpoint cannot have a column %q", label)
+		}
+	}
+	if err := t.fn.Prepare(tbl.Cols()); err != nil {
+		return err
+	}
+
+	builder, created := t.cache.TableBuilder(tbl.Key())
+	if !created {
+		return fmt.Errorf("endpoint found duplicate table with key: %v", tbl.Key())
+	}
+	if err := execute.AddTableCols(tbl, builder); err != nil {
+		return err
+	}
+	sentIdx, err := builder.AddCol(flux.ColMeta{Label: SentColLabel, Type: flux.TBool})
+	if err != nil {
+		return err
+	}
+	errorIdx, err := builder.AddCol(flux.ColMeta{Label: ErrorColLabel, Type: flux.TString})
+	if err != nil {
+		return err
+	}
+
+	batch := make([]values.Object, 0, t.batchSize)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		msg := ""
+		if err := t.send(withNotifications(t.ctx, len(batch)), batch); err != nil {
+			// The query fails when it is cancelled, rather than every notification that is left.
+			if ctxErr := t.ctx.Err(); ctxErr != nil {
+				return ctxErr
+			}
+			msg = err.Error()
+		}
+		for range batch {
+			if err := builder.AppendBool(sentIdx, msg == ""); err != nil {
+				return err
+			}
+			if err := builder.AppendString(errorIdx, msg); err != nil {
+				return err
+			}
+		}
+		batch = batch[:0]
+		return nil
+	}
+
+	if err := tbl.Do(func(cr flux.ColReader) error {
+		// The columns of the input are the first columns of the builder.
+		for j := range cr.Cols() {
+			if err := execute.AppendCol(j, j, cr, builder); err != nil {
+				return err
+			}
+		}
+		for i := 0; i < cr.Len(); i++ {
+			n, err := t.fn.Eval(i, cr)
+			if err != nil {
+				return err
+			}
+			// The function may reuse the record it returns, so the batch holds a copy of it.
+			properties := make(map[string]values.Value, n.Len())
+			n.Range(func(k string, v values.Value) {
+				properties[k] = v
+			})
+			batch = append(batch, values.NewObjectWithValues(properties))
+			if len(batch) == t.batchSize {
+				if err := flush(); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	return flush()
+}
+
+func (t *EndpointTransformation) UpdateWatermark(id execute.DatasetID, pt execute.Time) error {
+	return t.d.UpdateWatermark(pt)
+}
+
+func (t *EndpointTransformation) UpdateProcessingTime(id execute.DatasetID, pt execute.Time) error {
+	return t.d.UpdateProcessingTime(pt)
+}
+
+func (t *EndpointTransformation) Finish(id execute.DatasetID, err error) {
+	t.d.Finish(err)
+}
+
+// NotificationString returns the string property of a notification, and whether the notification has it.
+// A null property is missing.
+func NotificationString(n values.Object, property string) (string, bool, error) {
+	v, ok := n.Get(property)
+	if !ok || v.IsNull() {
+		return "", false, nil
+	}
+	if v.Type().Nature() != semantic.String {
+		return "", true, fmt.Errorf("property %q of the notification must be a string, got %v", property, v.Type().Nature())
+	}
+	return v.Str(), true, nil
+}
+
+// Post sends a POST request with the body to the URL, with the HTTP client of the dependencies in ctx,
+// and returns the status code and the body of the response.
+// The request is recorded as a write of the query, with the number of notifications the endpoint sends with it.
+func Post(ctx context.Context, url string, header http.Header, body []byte) (int, []byte, error) {
+	deps := dependencies.Get(ctx)
+	req, err := http.NewRequest("POST", url, bytes.NewReader(body))
+	if err != nil {
+		return 0, nil, err
+	}
+	if err := deps.URLValidator.Validate(req.URL); err != nil {
+		return 0, nil, err
+	}
+	for k, vs := range header {
+		req.Header[k] = vs
+	}
+
+	write := flux.Write{
+		Function:    "http.post",
+		Destination: writeDestination(req.URL),
+		Rows:        notificationsFromContext(ctx),
+		Bytes:       int64(len(body)),
+	}
+	start := time.Now()
+	rctx, cancel := context.WithTimeout(ctx, DefaultEndpointTimeout)
+	defer cancel()
+	resp, err := deps.HTTPClient.Do(req.WithContext(rctx))
+	if err != nil {
+		deps.Logger.Warn("notification request failed", zap.String("host", req.URL.Host), zap.Error(err))
+		write.Duration, write.Status, write.Error = time.Since(start), "failed", err.Error()
+		execute.RecordWrite(ctx, write)
+		return 0, nil, err
+	}
+	defer resp.Body.Close()
+	respBody, err := ioutil.ReadAll(resp.Body)
+	write.Duration, write.Status = time.Since(start), resp.Status
+	execute.RecordWrite(ctx, write)
+	if err != nil {
+		return 0, nil, err
+	}
+	if CheckStatus(resp.StatusCode) != nil {
+		deps.Logger.Warn("notification request was rejected", zap.String("host", req.URL.Host), zap.Int("status", resp.StatusCode))
+	}
+	return resp.StatusCode, respBody, nil
+}
+
+type notificationsKey struct{}
+
+// withNotifications returns a context with which Post records that its request sends n notifications.
+func withNotifications(ctx context.Context, n int) context.Context {
+	return context.WithValue(ctx, notificationsKey{}, n)
+}
+
+// notificationsFromContext returns the number of notifications that a request sends, which is one by default.
+func notificationsFromContext(ctx context.Context) int64 {
+	if n, ok := ctx.Value(notificationsKey{}).(int); ok {
+		return int64(n)
+	}
+	return 1
+}
+
+// writeDestination returns the URL of a request without its user info and its query,
+// which may hold credentials, to record it as the destination of a write.
+func writeDestination(u *url.URL) string {
+	dest := *u
+	dest.User = nil
+	dest.RawQuery = ""
+	dest.ForceQuery = false
+	dest.Fragment = ""
+	return dest.String()
+}
+
+// CheckStatus returns an error unless the status code is a success.
+func CheckStatus(status int) error {
+	if status/100 != 2 {
+		return fmt.Errorf("unexpected status code %d %s", status, http.StatusText(status))
+	}
+	return nil
+}
+
+// ToJSON converts a value to the Go value that is encoded as its JSON representation.
+// Times are encoded as RFC3339 strings and durations as Flux duration literals.
+func ToJSON(v values.Value) interface{} {
+	if v.IsNull() {
+		return nil
+	}
+	switch v.Type().Nature() {
+	case semantic.String:
+		return v.Str()
+	case semantic.Int:
+		return v.Int()
+	case semantic.UInt:
+		return v.UInt()
+	case semantic.Float:
+		return v.Float()
+	case semantic.Bool:
+		return v.Bool()
+	case semantic.Time:
+		return v.Time().Time().Format(time.RFC3339Nano)
+	case semantic.Duration:
+		return v.Duration().String()
+	case semantic.Regexp:
+		return v.Regexp().String()
+	case semantic.Array:
+		arr := v.Array()
+		vs := make([]interface{}, arr.Len())
+		arr.Range(func(i int, v values.Value) {
+			vs[i] = ToJSON(v)
+		})
+		return vs
+	case semantic.Object:
+		obj := v.Object()
+		m := make(map[string]interface{}, obj.Len())
+		obj.Range(func(k string, v values.Value) {
+			m[k] = ToJSON(v)
+		})
+		return m
+	default:
+		return fmt.Sprint(v)
+	}
+}
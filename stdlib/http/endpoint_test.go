@@ -0,0 +1,162 @@
+package http_test
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/dependencies"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+	fhttp "github.com/influxdata/flux/stdlib/http"
+)
+
+// compileEndpoint compiles the script and returns the spec of its endpoint operation.
+func compileEndpoint(t *testing.T, script string) *fhttp.HTTPEndpointOpSpec {
+	t.Helper()
+	spec, err := flux.Compile(context.Background(), script, execute.Now().Time())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, op := range spec.Operations {
+		if s, ok := op.Spec.(*fhttp.HTTPEndpointOpSpec); ok {
+			return s
+		}
+	}
+	t.Fatal("the script has no endpoint operation")
+	return nil
+}
+
+func TestEndpoint_NewQuery(t *testing.T) {
+	spec := compileEndpoint(t, `
+import "http"
+
+e = http.endpoint(url: "http://localhost:8081", batchSize: 2)
+from(bucket: "mybucket")
+	|> range(start: -1h)
+	|> e(mapFn: (r) => ({text: r._value}))()`)
+	if spec.URL != "http://localhost:8081" {
+		t.Errorf("unexpected url %q", spec.URL)
+	}
+	if spec.BatchSize != 2 {
+		t.Errorf("unexpected batch size %d", spec.BatchSize)
+	}
+	if spec.Fn == nil {
+		t.Error("expected a mapping function")
+	}
+
+	if _, err := flux.Compile(context.Background(), `
+import "http"
+
+e = http.endpoint(url: "http://localhost:8081", batchSize: 0)`, execute.Now().Time()); err == nil {
+		t.Error("expected an error for a batch size of 0")
+	}
+}
+
+func TestEndpoint_Process(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		bodies []string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		mu.Lock()
+		bodies = append(bodies, string(body))
+		mu.Unlock()
+		if strings.Contains(string(body), "fail") {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	data := []flux.Table{&executetest.Table{
+		KeyCols: []string{"host"},
+		ColMeta: []flux.ColMeta{
+			{Label: "_time", Type: flux.TTime},
+			{Label: "host", Type: flux.TString},
+			{Label: "_value", Type: flux.TString},
+		},
+		Data: [][]interface{}{
+			{execute.Time(1), "a", "up"},
+			{execute.Time(2), "a", "fail"},
+			{execute.Time(3), "a", "down"},
+		},
+	}}
+	wantCols := []flux.ColMeta{
+		{Label: "_time", Type: flux.TTime},
+		{Label: "host", Type: flux.TString},
+		{Label: "_value", Type: flux.TString},
+		{Label: "_sent", Type: flux.TBool},
+		{Label: "_error", Type: flux.TString},
+	}
+	failed := "unexpected status code 500 Internal Server Error"
+
+	testCases := []struct {
+		name       string
+		batchSize  int
+		want       [][]interface{}
+		wantBodies []string
+	}{
+		{
+			name:      "one by one",
+			batchSize: 1,
+			want: [][]interface{}{
+				{execute.Time(1), "a", "up", true, ""},
+				{execute.Time(2), "a", "fail", false, failed},
+				{execute.Time(3), "a", "down", true, ""},
+			},
+			wantBodies: []string{`{"text":"up"}`, `{"text":"fail"}`, `{"text":"down"}`},
+		},
+		{
+			name:      "batches",
+			batchSize: 2,
+			want: [][]interface{}{
+				{execute.Time(1), "a", "up", false, failed},
+				{execute.Time(2), "a", "fail", false, failed},
+				{execute.Time(3), "a", "down", true, ""},
+			},
+			wantBodies: []string{`[{"text":"up"},{"text":"fail"}]`, `[{"text":"down"}]`},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			bodies = bodies[:0]
+			spec := compileEndpoint(t, `
+import "http"
+
+from(bucket: "mybucket")
+	|> range(start: -1h)
+	|> http.endpoint(url: "`+server.URL+`")(mapFn: (r) => ({text: r._value}))()`)
+			spec.BatchSize = tc.batchSize
+
+			ctx := dependencies.Inject(context.Background(), dependencies.Unrestricted())
+			executetest.ProcessTestHelper(
+				t,
+				data,
+				[]*executetest.Table{{
+					KeyCols: []string{"host"},
+					ColMeta: wantCols,
+					Data:    tc.want,
+				}},
+				nil,
+				func(d execute.Dataset, c execute.TableBuilderCache) execute.Transformation {
+					tr, err := fhttp.NewEndpointTransformation(ctx, d, c, spec.EndpointSpec, spec.Send)
+					if err != nil {
+						t.Fatal(err)
+					}
+					return tr
+				},
+			)
+			if !cmp.Equal(tc.wantBodies, bodies) {
+				t.Errorf("unexpected bodies -want/+got\n%s", cmp.Diff(tc.wantBodies, bodies))
+			}
+		})
+	}
+}
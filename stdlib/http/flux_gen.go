@@ -21,11 +21,11 @@ var pkgAST = &ast.Package{
 			Errors: nil,
 			Loc: &ast.SourceLocation{
 				End: ast.Position{
-					Column: 11,
-					Line:   3,
+					Column: 17,
+					Line:   4,
 				},
 				File:   "http.flux",
-				Source: "package http\n\nbuiltin to",
+				Source: "package http\n\nbuiltin to\nbuiltin endpoint",
 				Start: ast.Position{
 					Column: 1,
 					Line:   1,
@@ -66,6 +66,40 @@ var pkgAST = &ast.Package{
 				},
 				Name: "to",
 			},
+		}, &ast.BuiltinStatement{
+			BaseNode: ast.BaseNode{
+				Errors: nil,
+				Loc: &ast.SourceLocation{
+					End: ast.Position{
+						Column: 17,
+						Line:   4,
+					},
+					File:   "http.flux",
+					Source: "builtin endpoint",
+					Start: ast.Position{
+						Column: 1,
+						Line:   4,
+					},
+				},
+			},
+			ID: &ast.Identifier{
+				BaseNode: ast.BaseNode{
+					Errors: nil,
+					Loc: &ast.SourceLocation{
+						End: ast.Position{
+							Column: 17,
+							Line:   4,
+						},
+						File:   "http.flux",
+						Source: "endpoint",
+						Start: ast.Position{
+							Column: 9,
+							Line:   4,
+						},
+					},
+				},
+				Name: "endpoint",
+			},
 		}},
 		Imports: nil,
 		Name:    "http.flux",
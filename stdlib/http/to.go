@@ -5,22 +5,23 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
-	"net"
 	"net/http"
 	"net/url"
-	"runtime"
 	"sort"
 	"strings"
 	"time"
 
 	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/dependencies"
 	"github.com/influxdata/flux/execute"
 	"github.com/influxdata/flux/internal/pkg/syncutil"
+	"github.com/influxdata/flux/iocounter"
 	"github.com/influxdata/flux/plan"
 	"github.com/influxdata/flux/semantic"
 	"github.com/influxdata/flux/values"
 	protocol "github.com/influxdata/line-protocol"
 	"github.com/pkg/errors"
+	"go.uber.org/zap"
 )
 
 const (
@@ -51,26 +52,6 @@ func init() {
 // DefaultToHTTPUserAgent is the default user agent used by ToHttp
 var DefaultToHTTPUserAgent = "fluxd/dev"
 
-func newToHTTPClient() *http.Client {
-	return &http.Client{
-		Transport: &http.Transport{
-			Proxy: http.ProxyFromEnvironment,
-			DialContext: (&net.Dialer{
-				Timeout:   30 * time.Second,
-				KeepAlive: 30 * time.Second,
-				DualStack: true,
-			}).DialContext,
-			MaxIdleConns:          100,
-			IdleConnTimeout:       90 * time.Second,
-			TLSHandshakeTimeout:   10 * time.Second,
-			ExpectContinueTimeout: 1 * time.Second,
-			MaxIdleConnsPerHost:   runtime.GOMAXPROCS(0) + 1,
-		},
-	}
-}
-
-var toHTTPKeepAliveClient = newToHTTPClient()
-
 // this is used so we can get better validation on marshaling, innerToHTTPOpSpec and ToHTTPOpSpec
 // need to have identical fields
 type innerToHTTPOpSpec ToHTTPOpSpec
@@ -131,7 +112,7 @@ func (o *ToHTTPOpSpec) ReadArgs(args flux.Arguments) error {
 	if !ok {
 		o.Timeout = DefaultToHTTPTimeout
 	} else {
-		o.Timeout = time.Duration(timeout)
+		o.Timeout = timeout.Duration()
 	}
 
 	o.TimeColumn, ok, err = args.GetString("timeColumn")
@@ -208,6 +189,10 @@ func (ToHTTPOpSpec) Kind() flux.OperationKind {
 	return ToHTTPKind
 }
 
+func (o *ToHTTPOpSpec) Resources() []flux.Resource {
+	return []flux.Resource{{Kind: flux.URLResource, Name: o.URL, Mode: flux.WriteAccess}}
+}
+
 type ToHTTPProcedureSpec struct {
 	plan.DefaultCost
 	Spec *ToHTTPOpSpec
@@ -258,11 +243,12 @@ func createToHTTPTransformation(id execute.DatasetID, mode execute.AccumulationM
 	}
 	cache := execute.NewTableBuilderCache(a.Allocator())
 	d := execute.NewDataset(id, mode, cache)
-	t := NewToHTTPTransformation(d, cache, s)
+	t := NewToHTTPTransformation(a.Context(), d, cache, s)
 	return t, d, nil
 }
 
 type ToHTTPTransformation struct {
+	ctx   context.Context
 	d     execute.Dataset
 	cache execute.TableBuilderCache
 	spec  *ToHTTPProcedureSpec
@@ -272,8 +258,11 @@ func (t *ToHTTPTransformation) RetractTable(id execute.DatasetID, key flux.Group
 	return t.d.RetractTable(key)
 }
 
-func NewToHTTPTransformation(d execute.Dataset, cache execute.TableBuilderCache, spec *ToHTTPProcedureSpec) *ToHTTPTransformation {
+// NewToHTTPTransformation creates a transformation that sends the tables it processes
+// to the URL of the spec using the HTTP client of the dependencies in ctx.
+func NewToHTTPTransformation(ctx context.Context, d execute.Dataset, cache execute.TableBuilderCache, spec *ToHTTPProcedureSpec) *ToHTTPTransformation {
 	return &ToHTTPTransformation{
+		ctx:   ctx,
 		d:     d,
 		cache: cache,
 		spec:  spec,
@@ -315,7 +304,10 @@ type idxType struct {
 func (t *ToHTTPTransformation) Process(id execute.DatasetID, tbl flux.Table) error {
 	pr, pw := io.Pipe() // TODO: replce the pipe with something faster
 	m := &toHttpMetric{}
-	e := protocol.NewEncoder(pw)
+	// The bytes and the rows that are sent are counted for the record of the write.
+	cw := &iocounter.Writer{Writer: pw}
+	var rows int64
+	e := protocol.NewEncoder(cw)
 	e.FailOnFieldErr(true)
 	e.SetFieldSortOrder(protocol.SortFields)
 	cols := tbl.Cols()
@@ -404,6 +396,7 @@ func (t *ToHTTPTransformation) Process(id execute.DatasetID, tbl flux.Table) err
 				if err != nil {
 					return err
 				}
+				rows++
 
 				if err := execute.AppendRecord(i, er, builder); err != nil {
 					return err
@@ -417,28 +410,44 @@ func (t *ToHTTPTransformation) Process(id execute.DatasetID, tbl flux.Table) err
 		return err
 	})
 
+	deps := dependencies.Get(t.ctx)
 	req, err := http.NewRequest(t.spec.Spec.Method, t.spec.Spec.URL, pr)
 	if err != nil {
 		return err
 	}
+	if err := deps.URLValidator.Validate(req.URL); err != nil {
+		return err
+	}
+	req.Close = t.spec.Spec.NoKeepAlive
 
+	ctx := t.ctx
 	if t.spec.Spec.Timeout > 0 {
-		ctx, cancel := context.WithTimeout(context.Background(), t.spec.Spec.Timeout)
-		req = req.WithContext(ctx)
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, t.spec.Spec.Timeout)
 		defer cancel()
 	}
-	var resp *http.Response
-	if t.spec.Spec.NoKeepAlive {
-		resp, err = newToHTTPClient().Do(req)
-	} else {
-		resp, err = toHTTPKeepAliveClient.Do(req)
+	write := flux.Write{
+		Function:    "http.to",
+		Destination: writeDestination(req.URL),
 	}
+	start := time.Now()
+	resp, err := deps.HTTPClient.Do(req.WithContext(ctx))
 	if err != nil {
+		deps.Logger.Warn("http.to request failed", zap.String("method", req.Method), zap.String("host", req.URL.Host), zap.Error(err))
+		write.Duration, write.Status, write.Error = time.Since(start), "failed", err.Error()
+		execute.RecordWrite(t.ctx, write)
 		return err
 	}
 	if err := wg.Wait(); err != nil {
 		return err
 	}
+	write.Rows, write.Bytes = rows, cw.Count()
+	write.Duration, write.Status = time.Since(start), resp.Status
+	execute.RecordWrite(t.ctx, write)
+	// The status of the response does not fail the query, but it is logged.
+	if resp.StatusCode/100 != 2 {
+		deps.Logger.Warn("http.to request was rejected", zap.String("method", req.Method), zap.String("host", req.URL.Host), zap.Int("status", resp.StatusCode))
+	}
 	if err := resp.Body.Close(); err != nil {
 		return err
 	}
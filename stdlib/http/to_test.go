@@ -1,6 +1,7 @@
 package http_test
 
 import (
+	"context"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
@@ -10,11 +11,14 @@ import (
 
 	"github.com/influxdata/flux"
 	_ "github.com/influxdata/flux/builtin" // We need to import the builtins for the tests to work.
+	"github.com/influxdata/flux/dependencies"
 	"github.com/influxdata/flux/execute"
 	"github.com/influxdata/flux/execute/executetest"
 	"github.com/influxdata/flux/querytest"
 	fhttp "github.com/influxdata/flux/stdlib/http"
 	"github.com/influxdata/flux/stdlib/influxdata/influxdb"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
 )
 
 func TestToHTTP_NewQuery(t *testing.T) {
@@ -560,7 +564,8 @@ one_table_w_tag,fred=elevendyone _value=4 41
 				tc.want.Table,
 				nil,
 				func(d execute.Dataset, c execute.TableBuilderCache) execute.Transformation {
-					return fhttp.NewToHTTPTransformation(d, c, tc.spec)
+					ctx := dependencies.Inject(context.Background(), dependencies.Unrestricted())
+					return fhttp.NewToHTTPTransformation(ctx, d, c, tc.spec)
 				},
 			)
 			wg.Wait() // wait till we are done getting the data back
@@ -572,3 +577,57 @@ one_table_w_tag,fred=elevendyone _value=4 41
 		})
 	}
 }
+
+func TestToHTTP_LogRejectedRequest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	core, logs := observer.New(zap.WarnLevel)
+	spec := &fhttp.ToHTTPProcedureSpec{
+		Spec: &fhttp.ToHTTPOpSpec{
+			URL:          server.URL,
+			Method:       "POST",
+			Timeout:      time.Second,
+			TimeColumn:   execute.DefaultTimeColLabel,
+			ValueColumns: []string{"_value"},
+			NameColumn:   "_measurement",
+		},
+	}
+	input := &executetest.Table{
+		ColMeta: []flux.ColMeta{
+			{Label: "_time", Type: flux.TTime},
+			{Label: "_measurement", Type: flux.TString},
+			{Label: "_value", Type: flux.TFloat},
+		},
+		Data: [][]interface{}{
+			{execute.Time(11), "a", 2.0},
+		},
+	}
+	writes := execute.NewWriteRecorder()
+	// The status of the response does not fail the query.
+	executetest.ProcessTestHelper(t, []flux.Table{input}, []*executetest.Table{input}, nil, func(d execute.Dataset, c execute.TableBuilderCache) execute.Transformation {
+		deps := dependencies.Unrestricted()
+		deps.Logger = zap.New(core)
+		ctx := execute.WithWriteRecorder(dependencies.Inject(context.Background(), deps), writes)
+		return fhttp.NewToHTTPTransformation(ctx, d, c, spec)
+	})
+
+	entries := logs.FilterMessage("http.to request was rejected").All()
+	if len(entries) != 1 {
+		t.Fatalf("expected the rejected request to be logged once, got %v", logs.All())
+	}
+	if got := entries[0].ContextMap()["status"]; got != int64(http.StatusServiceUnavailable) {
+		t.Errorf("unexpected status logged: %v", got)
+	}
+
+	// The request is recorded as a write, whatever its status.
+	got := writes.Writes()
+	if len(got) != 1 {
+		t.Fatalf("expected one write to be recorded, got %v", got)
+	}
+	if w := got[0]; w.Function != "http.to" || w.Destination != server.URL || w.Rows != 1 || w.Bytes == 0 || w.Status != "503 Service Unavailable" {
+		t.Errorf("unexpected write: %+v", w)
+	}
+}
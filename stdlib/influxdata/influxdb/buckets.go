@@ -1,11 +1,14 @@
 package influxdb
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
 	"github.com/influxdata/flux/plan"
 	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/flux/values"
 )
 
 const BucketsKind = "buckets"
@@ -21,6 +24,7 @@ func init() {
 	flux.RegisterPackageValue("influxdata/influxdb", BucketsKind, flux.FunctionValue(BucketsKind, createBucketsOpSpec, bucketsSignature))
 	flux.RegisterOpSpec(BucketsKind, newBucketsOp)
 	plan.RegisterProcedureSpec(BucketsKind, newBucketsProcedure, BucketsKind)
+	execute.RegisterSource(BucketsKind, createBucketsSource)
 }
 
 func createBucketsOpSpec(args flux.Arguments, a *flux.Administration) (flux.OperationSpec, error) {
@@ -36,6 +40,10 @@ func (s *BucketsOpSpec) Kind() flux.OperationKind {
 	return BucketsKind
 }
 
+func (s *BucketsOpSpec) Resources() []flux.Resource {
+	return []flux.Resource{{Kind: flux.BucketResource, Name: flux.AllResources, Mode: flux.ReadAccess}}
+}
+
 type BucketsProcedureSpec struct {
 	plan.DefaultCost
 }
@@ -57,3 +65,40 @@ func (s *BucketsProcedureSpec) Copy() plan.ProcedureSpec {
 	ns := new(BucketsProcedureSpec)
 	return ns
 }
+
+var bucketsCols = []flux.ColMeta{
+	{Label: "name", Type: flux.TString},
+	{Label: "id", Type: flux.TString},
+	{Label: "organization", Type: flux.TString},
+	{Label: "organizationID", Type: flux.TString},
+	{Label: "retentionPolicy", Type: flux.TString},
+	{Label: "retentionPeriod", Type: flux.TInt},
+}
+
+func createBucketsSource(s plan.ProcedureSpec, id execute.DatasetID, a execute.Administration) (execute.Source, error) {
+	if _, ok := s.(*BucketsProcedureSpec); !ok {
+		return nil, fmt.Errorf("invalid spec type %T", s)
+	}
+	client, err := GetMetaClient(a)
+	if err != nil {
+		return nil, err
+	}
+	return NewMetaSource(id, a.Allocator(), bucketsCols, func(ctx context.Context) ([][]values.Value, error) {
+		buckets, err := client.Buckets(ctx)
+		if err != nil {
+			return nil, err
+		}
+		rows := make([][]values.Value, len(buckets))
+		for i, b := range buckets {
+			rows[i] = []values.Value{
+				values.NewString(b.Name),
+				values.NewString(b.ID),
+				values.NewString(b.Organization),
+				values.NewString(b.OrganizationID),
+				values.NewString(b.RetentionPolicy),
+				values.NewInt(int64(b.RetentionPeriod)),
+			}
+		}
+		return rows, nil
+	}), nil
+}
@@ -1,9 +1,14 @@
 package influxdb_test
 
 import (
+	"context"
 	"testing"
+	"time"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
 	"github.com/influxdata/flux/querytest"
 	"github.com/influxdata/flux/stdlib/influxdata/influxdb"
 )
@@ -31,3 +36,53 @@ func TestBuckets_NewQuery(t *testing.T) {
 		})
 	}
 }
+
+type mockMetaClient struct {
+	buckets []influxdb.Bucket
+}
+
+func (c *mockMetaClient) Buckets(ctx context.Context) ([]influxdb.Bucket, error) {
+	return c.buckets, nil
+}
+
+func (c *mockMetaClient) Databases(ctx context.Context) ([]influxdb.Database, error) {
+	return nil, nil
+}
+
+func (c *mockMetaClient) TagKeys(ctx context.Context, spec influxdb.ReadFilterSpec) ([]string, error) {
+	return nil, nil
+}
+
+func (c *mockMetaClient) TagValues(ctx context.Context, spec influxdb.TagValuesSpec) ([]string, error) {
+	return nil, nil
+}
+
+func TestBuckets_Run(t *testing.T) {
+	client := &mockMetaClient{
+		buckets: []influxdb.Bucket{
+			{Name: "telegraf", ID: "0000000000000001", Organization: "my-org", OrganizationID: "0000000000000002", RetentionPeriod: time.Hour},
+			{Name: "_monitoring", ID: "0000000000000003", Organization: "my-org", OrganizationID: "0000000000000002"},
+		},
+	}
+	got := executeQuery(t, `buckets() |> filter(fn: (r) => r.retentionPeriod > 0)`, time.Now(), execute.Dependencies{
+		influxdb.MetaDependencyKey: client,
+	})
+
+	want := []*executetest.Table{{
+		ColMeta: []flux.ColMeta{
+			{Label: "name", Type: flux.TString},
+			{Label: "id", Type: flux.TString},
+			{Label: "organization", Type: flux.TString},
+			{Label: "organizationID", Type: flux.TString},
+			{Label: "retentionPolicy", Type: flux.TString},
+			{Label: "retentionPeriod", Type: flux.TInt},
+		},
+		Data: [][]interface{}{
+			{"telegraf", "0000000000000001", "my-org", "0000000000000002", "", int64(time.Hour)},
+		},
+	}}
+	executetest.NormalizeTables(want)
+	if !cmp.Equal(want, got) {
+		t.Errorf("unexpected tables -want/+got\n%s", cmp.Diff(want, got))
+	}
+}
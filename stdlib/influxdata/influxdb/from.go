@@ -1,6 +1,8 @@
-// From is an operation that mocks the real implementation of InfluxDB's from.
-// It is used in Flux to compile queries that resemble real queries issued against InfluxDB.
-// Implementors of the real from are expected to replace its implementation via flux.ReplacePackageValue.
+// From is an operation that reads series data from a storage engine.
+// A from bounded by range is planned as a read against the StorageReader that embedders provide
+// in the execute.Dependencies under StorageDependencyKey; see storage.go and rules.go.
+// A from that is not bounded by range fails planning, unless the planner is configured with plan.WithDefaultRange.
+// Implementors may still replace its implementation entirely via flux.ReplacePackageValue.
 package influxdb
 
 import (
@@ -48,6 +50,10 @@ func (s *FromOpSpec) Kind() flux.OperationKind {
 	return FromKind
 }
 
+func (s *FromOpSpec) Resources() []flux.Resource {
+	return []flux.Resource{{Kind: flux.BucketResource, Name: s.Bucket, Mode: flux.ReadAccess}}
+}
+
 type FromProcedureSpec struct {
 	plan.DefaultCost
 	Bucket string
@@ -73,3 +79,22 @@ func (s *FromProcedureSpec) Copy() plan.ProcedureSpec {
 	*ns = *s
 	return ns
 }
+
+// ExternalSystem implements plan.ExternalProcedureSpec.
+func (s *FromProcedureSpec) ExternalSystem() string {
+	return StorageSystem
+}
+
+// PostPhysicalValidate implements plan.PostPhysicalValidator.
+// A from that remains in the physical plan has not been bounded by a range and cannot be read.
+func (s *FromProcedureSpec) PostPhysicalValidate(id plan.NodeID) error {
+	return fmt.Errorf("cannot submit unbounded read to %q; try bounding 'from' with a call to 'range'", s.Bucket)
+}
+
+// WithDefaultRange implements plan.UnboundedProcedureSpec.
+func (s *FromProcedureSpec) WithDefaultRange(bounds flux.Bounds) plan.PhysicalProcedureSpec {
+	return &ReadRangePhysSpec{
+		Bucket: s.Bucket,
+		Bounds: bounds,
+	}
+}
@@ -1,11 +1,15 @@
 package influxdb_test
 
 import (
+	"context"
 	"testing"
 	"time"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/ast"
 	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/plan"
 	"github.com/influxdata/flux/querytest"
 	"github.com/influxdata/flux/stdlib/influxdata/influxdb"
 	"github.com/influxdata/flux/stdlib/universe"
@@ -82,3 +86,72 @@ func TestFrom_NewQuery(t *testing.T) {
 		})
 	}
 }
+
+func TestFrom_Unbounded(t *testing.T) {
+	now := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	query := `from(bucket: "telegraf")
+	|> sum()`
+
+	planQuery := func(opts ...plan.PhysicalOption) (*plan.PlanSpec, error) {
+		spec, err := flux.Compile(context.Background(), query, now)
+		if err != nil {
+			t.Fatal(err)
+		}
+		lp := plan.NewLogicalPlanner()
+		initPlan, err := lp.CreateInitialPlan(spec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		logicalPlan, err := lp.Plan(initPlan)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return plan.NewPhysicalPlanner(opts...).Plan(logicalPlan)
+	}
+
+	t.Run("validation error", func(t *testing.T) {
+		_, err := planQuery()
+		if err == nil {
+			t.Fatal("expected an error planning an unbounded from")
+		}
+		verr, ok := err.(*plan.ValidationError)
+		if !ok {
+			t.Fatalf("expected a validation error, got %T: %v", err, err)
+		}
+		if want, got := plan.NodeID("from0"), verr.Node; want != got {
+			t.Errorf("unexpected node -want/+got\n%s", cmp.Diff(want, got))
+		}
+		if verr.Location == nil {
+			t.Fatal("expected the location of the from call")
+		}
+		if want, got := (ast.Position{Line: 1, Column: 1}), verr.Location.Start; want != got {
+			t.Errorf("unexpected location -want/+got\n%s", cmp.Diff(want, got))
+		}
+	})
+
+	t.Run("default range", func(t *testing.T) {
+		start := flux.Time{IsRelative: true, Relative: -time.Hour}
+		stop := flux.Time{IsRelative: true}
+		pp, err := planQuery(plan.WithDefaultRange(start, stop))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var got plan.ProcedureSpec
+		if err := pp.BottomUpWalk(func(pn plan.PlanNode) error {
+			if pn.ID() == "from0" {
+				got = pn.ProcedureSpec()
+			}
+			return nil
+		}); err != nil {
+			t.Fatal(err)
+		}
+		want := &influxdb.ReadRangePhysSpec{
+			Bucket: "telegraf",
+			Bounds: flux.Bounds{Start: start, Stop: stop, Now: now},
+		}
+		if !cmp.Equal(want, got) {
+			t.Errorf("unexpected procedure spec -want/+got\n%s", cmp.Diff(want, got))
+		}
+	})
+}
@@ -0,0 +1,415 @@
+// Package memstore implements a lightweight storage engine that keeps series in memory,
+// so that scripts can read and write buckets with from(), buckets() and to()
+// without a running InfluxDB, for example to learn Flux or to test scripts from the command line.
+//
+// A Store is provided to the executor with its Dependencies, and to the queries that write to it
+// as the PointsWriter of their dependencies.Dependencies.
+// It is filled with points loaded from line protocol or written by to().
+package memstore
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/dependencies"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/line"
+	"github.com/influxdata/flux/memory"
+	"github.com/influxdata/flux/stdlib/influxdata/influxdb"
+	"github.com/influxdata/flux/values"
+	"github.com/pkg/errors"
+)
+
+// Store keeps the series of its buckets in memory.
+// It is an influxdb.StorageReader, an influxdb.MetaClient and a dependencies.PointsWriter.
+//
+// Every series of a bucket is identified by its measurement, tag set and field,
+// and holds one value per time, in time order. Writing a value at a time that
+// the series already holds replaces it, and all the values of a series must have the same type.
+type Store struct {
+	mu      sync.RWMutex
+	buckets map[string]map[string]*series
+}
+
+// New returns an empty store.
+func New() *Store {
+	return &Store{
+		buckets: make(map[string]map[string]*series),
+	}
+}
+
+// Dependencies returns the dependencies with which the executor reads the store.
+func (s *Store) Dependencies() execute.Dependencies {
+	return execute.Dependencies{
+		influxdb.StorageDependencyKey: s,
+		influxdb.MetaDependencyKey:    s,
+	}
+}
+
+// CreateBucket creates an empty bucket, if it does not exist yet.
+// Buckets are also created when points are written to them.
+func (s *Store) CreateBucket(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.bucket(name)
+}
+
+// bucket returns the series of the bucket, creating it if needed.
+// It must be called with the lock held for writing.
+func (s *Store) bucket(name string) map[string]*series {
+	b, ok := s.buckets[name]
+	if !ok {
+		b = make(map[string]*series)
+		s.buckets[name] = b
+	}
+	return b
+}
+
+// series is the values of a field of a measurement with a tag set.
+type series struct {
+	measurement string
+	field       string
+	// tags are sorted by key.
+	tags   []dependencies.Tag
+	typ    flux.ColType
+	times  []values.Time
+	values []values.Value
+}
+
+func seriesKey(measurement string, tags []dependencies.Tag, field string) string {
+	var b strings.Builder
+	b.WriteString(measurement)
+	for _, t := range tags {
+		b.WriteString("," + t.Key + "=" + t.Value)
+	}
+	b.WriteString(" " + field)
+	return b.String()
+}
+
+// write writes the value at time t into the series, keeping the values in time order.
+func (ser *series) write(t values.Time, v values.Value) error {
+	if typ := flux.ColumnType(v.Type()); typ != ser.typ {
+		return fmt.Errorf("field %q of measurement %q has type %v, cannot write a value of type %v", ser.field, ser.measurement, ser.typ, typ)
+	}
+	n := len(ser.times)
+	if n == 0 || ser.times[n-1] < t {
+		ser.times = append(ser.times, t)
+		ser.values = append(ser.values, v)
+		return nil
+	}
+	i := sort.Search(n, func(i int) bool { return ser.times[i] >= t })
+	if ser.times[i] == t {
+		ser.values[i] = v
+		return nil
+	}
+	ser.times = append(ser.times, 0)
+	copy(ser.times[i+1:], ser.times[i:])
+	ser.times[i] = t
+	ser.values = append(ser.values, nil)
+	copy(ser.values[i+1:], ser.values[i:])
+	ser.values[i] = v
+	return nil
+}
+
+// writeValue writes a value into the series of a bucket, creating the series if needed.
+// It must be called with the lock held for writing.
+func (s *Store) writeValue(bucket, measurement string, tags []dependencies.Tag, field string, t values.Time, v values.Value) error {
+	b := s.bucket(bucket)
+	key := seriesKey(measurement, tags, field)
+	ser, ok := b[key]
+	if !ok {
+		typ := flux.ColumnType(v.Type())
+		if typ == flux.TInvalid {
+			return fmt.Errorf("field %q of measurement %q has an unsupported type %v", field, measurement, v.Type())
+		}
+		ser = &series{
+			measurement: measurement,
+			field:       field,
+			tags:        tags,
+			typ:         typ,
+		}
+		b[key] = ser
+	}
+	return ser.write(t, v)
+}
+
+// Load writes the points read from line protocol into a bucket.
+// Points without a timestamp are written at the current time.
+func (s *Store) Load(bucket string, r io.Reader) error {
+	dec := line.NewProtocolDecoder(&line.ProtocolDecoderConfig{TimeProvider: currentTime{}})
+	res, err := dec.Decode(r)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.bucket(bucket)
+	return res.Tables().Do(func(tbl flux.Table) error {
+		var (
+			measurement string
+			field       string
+			tags        []dependencies.Tag
+		)
+		for j, c := range tbl.Key().Cols() {
+			v := tbl.Key().ValueString(j)
+			switch c.Label {
+			case "_measurement":
+				measurement = v
+			case "_field":
+				field = v
+			default:
+				tags = append(tags, dependencies.Tag{Key: c.Label, Value: v})
+			}
+		}
+		sort.Slice(tags, func(i, j int) bool { return tags[i].Key < tags[j].Key })
+		return tbl.Do(func(cr flux.ColReader) error {
+			timeIdx := execute.ColIdx(execute.DefaultTimeColLabel, cr.Cols())
+			valueIdx := execute.ColIdx(execute.DefaultValueColLabel, cr.Cols())
+			if timeIdx < 0 || valueIdx < 0 {
+				return errors.New("line protocol table without time or value column")
+			}
+			for i := 0; i < cr.Len(); i++ {
+				t := execute.ValueForRow(cr, i, timeIdx).Time()
+				v := execute.ValueForRow(cr, i, valueIdx)
+				if err := s.writeValue(bucket, measurement, tags, field, t, v); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	})
+}
+
+type currentTime struct{}
+
+func (currentTime) CurrentTime() values.Time {
+	return values.ConvertTime(time.Now())
+}
+
+// WritePoints writes the points produced by to() into the bucket of the destination.
+// The points that cannot be written are reported with an *dependencies.PartialWriteError.
+func (s *Store) WritePoints(ctx context.Context, dest dependencies.WriteDestination, points []dependencies.Point) error {
+	bucket := dest.Bucket
+	if bucket == "" {
+		bucket = dest.BucketID
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var perr dependencies.PartialWriteError
+	for i, p := range points {
+		tags := make([]dependencies.Tag, len(p.Tags))
+		copy(tags, p.Tags)
+		t := values.ConvertTime(p.Time)
+		for _, f := range p.Fields {
+			if err := s.writeValue(bucket, p.Measurement, tags, f.Key, t, values.New(f.Value)); err != nil {
+				perr.Errors = append(perr.Errors, dependencies.PointError{Index: i, Err: err})
+				break
+			}
+		}
+	}
+	if len(perr.Errors) > 0 {
+		return &perr
+	}
+	return nil
+}
+
+// Capabilities reports that only time ranges are read from the store,
+// the other operations are executed by Flux.
+func (s *Store) Capabilities() influxdb.StorageCapabilities {
+	return influxdb.StorageCapabilities{}
+}
+
+// ReadFilter returns a table for every series of the bucket that has values within the bounds.
+// The tables are grouped by `_start`, `_stop`, `_measurement`, `_field` and the tags of the series.
+func (s *Store) ReadFilter(ctx context.Context, spec influxdb.ReadFilterSpec, alloc *memory.Allocator) (flux.TableIterator, error) {
+	if spec.Predicate != nil {
+		return nil, errors.New("predicates are not supported")
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	b, ok := s.buckets[spec.Bucket]
+	if !ok {
+		return nil, fmt.Errorf("bucket %q not found", spec.Bucket)
+	}
+	var tables tableIterator
+	for _, key := range sortedKeys(b) {
+		tbl, err := b[key].table(spec.Bounds, alloc)
+		if err != nil {
+			return nil, err
+		}
+		if tbl != nil {
+			tables = append(tables, tbl)
+		}
+	}
+	return tables, nil
+}
+
+func sortedKeys(b map[string]*series) []string {
+	keys := make([]string, 0, len(b))
+	for k := range b {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// table returns the values of the series within the bounds, or nil if there are none.
+func (ser *series) table(bounds execute.Bounds, alloc *memory.Allocator) (flux.Table, error) {
+	lo := sort.Search(len(ser.times), func(i int) bool { return ser.times[i] >= bounds.Start })
+	hi := sort.Search(len(ser.times), func(i int) bool { return ser.times[i] >= bounds.Stop })
+	if lo >= hi {
+		return nil, nil
+	}
+
+	cols := []flux.ColMeta{
+		{Label: execute.DefaultStartColLabel, Type: flux.TTime},
+		{Label: execute.DefaultStopColLabel, Type: flux.TTime},
+		{Label: execute.DefaultTimeColLabel, Type: flux.TTime},
+		{Label: execute.DefaultValueColLabel, Type: ser.typ},
+		{Label: "_field", Type: flux.TString},
+		{Label: "_measurement", Type: flux.TString},
+	}
+	keyValues := []values.Value{
+		values.NewTime(bounds.Start),
+		values.NewTime(bounds.Stop),
+		values.NewString(ser.field),
+		values.NewString(ser.measurement),
+	}
+	for _, t := range ser.tags {
+		cols = append(cols, flux.ColMeta{Label: t.Key, Type: flux.TString})
+		keyValues = append(keyValues, values.NewString(t.Value))
+	}
+	keyCols := append([]flux.ColMeta{cols[0], cols[1]}, cols[4:]...)
+	key := execute.NewGroupKey(keyCols, keyValues)
+
+	builder := execute.NewColListTableBuilder(key, alloc)
+	for _, c := range cols {
+		if _, err := builder.AddCol(c); err != nil {
+			return nil, err
+		}
+	}
+	for i := lo; i < hi; i++ {
+		if err := builder.AppendTime(2, ser.times[i]); err != nil {
+			return nil, err
+		}
+		if err := builder.AppendValue(3, ser.values[i]); err != nil {
+			return nil, err
+		}
+		if err := execute.AppendKeyValues(key, builder); err != nil {
+			return nil, err
+		}
+	}
+	return builder.Table()
+}
+
+func (s *Store) ReadGroup(ctx context.Context, spec influxdb.ReadGroupSpec, alloc *memory.Allocator) (flux.TableIterator, error) {
+	return nil, errors.New("group is not supported")
+}
+
+func (s *Store) ReadWindowAggregate(ctx context.Context, spec influxdb.ReadWindowAggregateSpec, alloc *memory.Allocator) (flux.TableIterator, error) {
+	return nil, errors.New("window aggregate is not supported")
+}
+
+// Buckets returns the buckets of the store, sorted by name.
+func (s *Store) Buckets(ctx context.Context) ([]influxdb.Bucket, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	buckets := make([]influxdb.Bucket, 0, len(s.buckets))
+	for name := range s.buckets {
+		buckets = append(buckets, influxdb.Bucket{Name: name})
+	}
+	sort.Slice(buckets, func(i, j int) bool { return buckets[i].Name < buckets[j].Name })
+	return buckets, nil
+}
+
+// Databases returns no databases, the store has no InfluxDB 1.x databases.
+func (s *Store) Databases(ctx context.Context) ([]influxdb.Database, error) {
+	return nil, nil
+}
+
+// TagKeys returns the sorted tag keys of the series that have values within the bounds,
+// including `_measurement` and `_field`.
+func (s *Store) TagKeys(ctx context.Context, spec influxdb.ReadFilterSpec) ([]string, error) {
+	set := make(map[string]bool)
+	if err := s.matching(spec, func(ser *series) {
+		set["_measurement"], set["_field"] = true, true
+		for _, t := range ser.tags {
+			set[t.Key] = true
+		}
+	}); err != nil {
+		return nil, err
+	}
+	return sortedSet(set), nil
+}
+
+// TagValues returns the sorted values of a tag of the series that have values within the bounds.
+func (s *Store) TagValues(ctx context.Context, spec influxdb.TagValuesSpec) ([]string, error) {
+	set := make(map[string]bool)
+	if err := s.matching(spec.ReadFilterSpec, func(ser *series) {
+		switch spec.Tag {
+		case "_measurement":
+			set[ser.measurement] = true
+		case "_field":
+			set[ser.field] = true
+		default:
+			for _, t := range ser.tags {
+				if t.Key == spec.Tag {
+					set[t.Value] = true
+				}
+			}
+		}
+	}); err != nil {
+		return nil, err
+	}
+	return sortedSet(set), nil
+}
+
+// matching calls f for every series of the bucket that has values within the bounds.
+func (s *Store) matching(spec influxdb.ReadFilterSpec, f func(ser *series)) error {
+	if spec.Predicate != nil {
+		return errors.New("predicates are not supported")
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	b, ok := s.buckets[spec.Bucket]
+	if !ok {
+		return fmt.Errorf("bucket %q not found", spec.Bucket)
+	}
+	for _, ser := range b {
+		i := sort.Search(len(ser.times), func(i int) bool { return ser.times[i] >= spec.Bounds.Start })
+		if i < len(ser.times) && ser.times[i] < spec.Bounds.Stop {
+			f(ser)
+		}
+	}
+	return nil
+}
+
+func sortedSet(set map[string]bool) []string {
+	vs := make([]string, 0, len(set))
+	for v := range set {
+		vs = append(vs, v)
+	}
+	sort.Strings(vs)
+	return vs
+}
+
+type tableIterator []flux.Table
+
+func (ti tableIterator) Do(f func(flux.Table) error) error {
+	for _, tbl := range ti {
+		if err := f(tbl); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (ti tableIterator) Statistics() flux.Statistics {
+	return flux.Statistics{}
+}
@@ -0,0 +1,190 @@
+package memstore_test
+
+import (
+	"context"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/flux"
+	_ "github.com/influxdata/flux/builtin"
+	"github.com/influxdata/flux/control"
+	"github.com/influxdata/flux/dependencies"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/flux/lang"
+	"github.com/influxdata/flux/stdlib/influxdata/influxdb"
+	"github.com/influxdata/flux/stdlib/influxdata/influxdb/memstore"
+)
+
+const points = `cpu,host=b usage=3 15
+cpu,host=a usage=1 10
+mem,host=a free=10i 10
+`
+
+// query executes a script against the store and returns the tables of its results.
+func query(t *testing.T, store *memstore.Store, script string) []*executetest.Table {
+	t.Helper()
+	c := control.New(control.Config{
+		ConcurrencyQuota:     1,
+		MemoryBytesQuota:     math.MaxInt64,
+		ExecutorDependencies: store.Dependencies(),
+	})
+	defer func() {
+		if err := c.Shutdown(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}()
+	ctx := dependencies.Inject(context.Background(), dependencies.NewBuilder().WithPointsWriter(store).Build())
+	q, err := c.Query(ctx, lang.FluxCompiler{Query: script})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer q.Done()
+
+	var got []*executetest.Table
+	for _, r := range <-q.Ready() {
+		if err := r.Tables().Do(func(tbl flux.Table) error {
+			ct, err := executetest.ConvertTable(tbl)
+			if err != nil {
+				return err
+			}
+			got = append(got, ct)
+			return nil
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := q.Err(); err != nil {
+		t.Fatal(err)
+	}
+	executetest.NormalizeTables(got)
+	return got
+}
+
+func TestStore_Load(t *testing.T) {
+	store := memstore.New()
+	if err := store.Load("telegraf", strings.NewReader(points)); err != nil {
+		t.Fatal(err)
+	}
+
+	got := query(t, store, `from(bucket: "telegraf") |> range(start: 1970-01-01T00:00:00.000000010Z, stop: 1970-01-01T00:00:00.000000020Z)`)
+	start, stop := execute.Time(10), execute.Time(20)
+	cols := func(typ flux.ColType) []flux.ColMeta {
+		return []flux.ColMeta{
+			{Label: "_start", Type: flux.TTime},
+			{Label: "_stop", Type: flux.TTime},
+			{Label: "_time", Type: flux.TTime},
+			{Label: "_value", Type: typ},
+			{Label: "_field", Type: flux.TString},
+			{Label: "_measurement", Type: flux.TString},
+			{Label: "host", Type: flux.TString},
+		}
+	}
+	keyCols := []string{"_start", "_stop", "_field", "_measurement", "host"}
+	want := []*executetest.Table{
+		{
+			KeyCols: keyCols,
+			ColMeta: cols(flux.TFloat),
+			Data: [][]interface{}{
+				{start, stop, execute.Time(10), 1.0, "usage", "cpu", "a"},
+			},
+		},
+		{
+			KeyCols: keyCols,
+			ColMeta: cols(flux.TFloat),
+			Data: [][]interface{}{
+				{start, stop, execute.Time(15), 3.0, "usage", "cpu", "b"},
+			},
+		},
+		{
+			KeyCols: keyCols,
+			ColMeta: cols(flux.TInt),
+			Data: [][]interface{}{
+				{start, stop, execute.Time(10), int64(10), "free", "mem", "a"},
+			},
+		},
+	}
+	executetest.NormalizeTables(want)
+	if !cmp.Equal(want, got) {
+		t.Errorf("unexpected tables -want/+got\n%s", cmp.Diff(want, got))
+	}
+
+	// The last value written at a time replaces the others.
+	if err := store.Load("dups", strings.NewReader("cpu usage=2 20\ncpu usage=1 10\ncpu usage=4 20\n")); err != nil {
+		t.Fatal(err)
+	}
+	got = query(t, store, `from(bucket: "dups") |> range(start: 0) |> keep(columns: ["_time", "_value"])`)
+	want = []*executetest.Table{{
+		ColMeta: []flux.ColMeta{
+			{Label: "_time", Type: flux.TTime},
+			{Label: "_value", Type: flux.TFloat},
+		},
+		Data: [][]interface{}{
+			{execute.Time(10), 1.0},
+			{execute.Time(20), 4.0},
+		},
+	}}
+	executetest.NormalizeTables(want)
+	if !cmp.Equal(want, got) {
+		t.Errorf("unexpected tables -want/+got\n%s", cmp.Diff(want, got))
+	}
+
+	if err := store.Load("telegraf", strings.NewReader(`mem,host=a free=1.5 30`)); err == nil {
+		t.Error("expected an error when writing a value of another type into a series")
+	}
+}
+
+func TestStore_WriteAndMeta(t *testing.T) {
+	store := memstore.New()
+	if err := store.Load("telegraf", strings.NewReader(points)); err != nil {
+		t.Fatal(err)
+	}
+
+	query(t, store, `from(bucket: "telegraf") |> range(start: 0) |> filter(fn: (r) => r._measurement == "mem") |> to(bucket: "copy")`)
+
+	got := query(t, store, `from(bucket: "copy") |> range(start: 0) |> keep(columns: ["_time", "_value", "host"])`)
+	want := []*executetest.Table{{
+		KeyCols: []string{"host"},
+		ColMeta: []flux.ColMeta{
+			{Label: "_time", Type: flux.TTime},
+			{Label: "_value", Type: flux.TInt},
+			{Label: "host", Type: flux.TString},
+		},
+		Data: [][]interface{}{
+			{execute.Time(10), int64(10), "a"},
+		},
+	}}
+	executetest.NormalizeTables(want)
+	if !cmp.Equal(want, got) {
+		t.Errorf("unexpected tables -want/+got\n%s", cmp.Diff(want, got))
+	}
+
+	buckets, err := store.Buckets(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []influxdb.Bucket{{Name: "copy"}, {Name: "telegraf"}}; !cmp.Equal(want, buckets) {
+		t.Errorf("unexpected buckets -want/+got\n%s", cmp.Diff(want, buckets))
+	}
+
+	spec := influxdb.ReadFilterSpec{
+		Bucket: "telegraf",
+		Bounds: execute.Bounds{Start: 15, Stop: 16},
+	}
+	keys, err := store.TagKeys(context.Background(), spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"_field", "_measurement", "host"}; !cmp.Equal(want, keys) {
+		t.Errorf("unexpected tag keys -want/+got\n%s", cmp.Diff(want, keys))
+	}
+	hosts, err := store.TagValues(context.Background(), influxdb.TagValuesSpec{ReadFilterSpec: spec, Tag: "host"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"b"}; !cmp.Equal(want, hosts) {
+		t.Errorf("unexpected tag values -want/+got\n%s", cmp.Diff(want, hosts))
+	}
+}
@@ -0,0 +1,127 @@
+package influxdb
+
+import (
+	"context"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/memory"
+	"github.com/influxdata/flux/values"
+	"github.com/pkg/errors"
+)
+
+// MetaDependencyKey is the key under which embedders provide the MetaClient
+// used by buckets() and the schema functions in the execute.Dependencies of the executor.
+// Like the StorageReader, it is not part of dependencies.Dependencies.
+const MetaDependencyKey = "influxdata/influxdb.MetaClient"
+
+// MetaClient exposes the catalog of a storage engine to Flux scripts.
+type MetaClient interface {
+	// Buckets returns the buckets the caller is authorized to access.
+	Buckets(ctx context.Context) ([]Bucket, error)
+	// Databases returns the InfluxDB 1.x databases and retention policies
+	// the caller is authorized to access.
+	Databases(ctx context.Context) ([]Database, error)
+	// TagKeys returns the tag keys of the series matched by the spec.
+	TagKeys(ctx context.Context, spec ReadFilterSpec) ([]string, error)
+	// TagValues returns the values of a tag for the series matched by the spec.
+	TagValues(ctx context.Context, spec TagValuesSpec) ([]string, error)
+}
+
+// Bucket describes a bucket returned by a MetaClient.
+type Bucket struct {
+	Name            string
+	ID              string
+	Organization    string
+	OrganizationID  string
+	RetentionPolicy string
+	RetentionPeriod time.Duration
+}
+
+// Database describes an InfluxDB 1.x database and retention policy returned by a MetaClient.
+type Database struct {
+	Name            string
+	RetentionPolicy string
+	RetentionPeriod time.Duration
+	Default         bool
+	BucketID        string
+	OrganizationID  string
+}
+
+// TagValuesSpec describes a lookup of the values of a tag.
+type TagValuesSpec struct {
+	ReadFilterSpec
+	Tag string
+}
+
+// GetMetaClient returns the MetaClient provided in the dependencies of the execution.
+func GetMetaClient(a execute.Administration) (MetaClient, error) {
+	client, ok := a.Dependencies()[MetaDependencyKey].(MetaClient)
+	if !ok {
+		return nil, errors.New("no meta client has been provided")
+	}
+	return client, nil
+}
+
+// MetaReadFunc returns the rows of a metadata table.
+// Each row holds one value per column of the table.
+type MetaReadFunc func(ctx context.Context) ([][]values.Value, error)
+
+// NewMetaSource creates a source that produces a single table with the given columns
+// and an empty group key from the rows returned by read.
+func NewMetaSource(id execute.DatasetID, alloc *memory.Allocator, cols []flux.ColMeta, read MetaReadFunc) execute.Source {
+	return &metaSource{
+		id:    id,
+		alloc: alloc,
+		cols:  cols,
+		read:  read,
+	}
+}
+
+type metaSource struct {
+	id    execute.DatasetID
+	ts    []execute.Transformation
+	alloc *memory.Allocator
+	cols  []flux.ColMeta
+	read  MetaReadFunc
+}
+
+func (s *metaSource) AddTransformation(t execute.Transformation) {
+	s.ts = append(s.ts, t)
+}
+
+func (s *metaSource) Run(ctx context.Context) {
+	tbl, err := s.table(ctx)
+	if err == nil {
+		for _, t := range s.ts {
+			if err = t.Process(s.id, tbl); err != nil {
+				break
+			}
+		}
+	}
+	for _, t := range s.ts {
+		t.Finish(s.id, err)
+	}
+}
+
+func (s *metaSource) table(ctx context.Context) (flux.Table, error) {
+	rows, err := s.read(ctx)
+	if err != nil {
+		return nil, err
+	}
+	builder := execute.NewColListTableBuilder(execute.NewGroupKey(nil, nil), s.alloc)
+	for _, c := range s.cols {
+		if _, err := builder.AddCol(c); err != nil {
+			return nil, err
+		}
+	}
+	for _, row := range rows {
+		for j, v := range row {
+			if err := builder.AppendValue(j, v); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return builder.Table()
+}
@@ -0,0 +1,120 @@
+package influxdb
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/influxdata/flux/dependencies"
+	protocol "github.com/influxdata/line-protocol"
+	"go.uber.org/zap"
+)
+
+// httpPointsWriter writes points to the HTTP API of a remote InfluxDB.
+// Every batch is encoded as line protocol and sent in a single gzipped request.
+// It counts the bytes of the requests it sends.
+type httpPointsWriter struct {
+	host  string
+	url   string
+	token string
+	sent  int64
+}
+
+func newHTTPPointsWriter(spec *ToOpSpec) (*httpPointsWriter, error) {
+	u, err := spec.WriteURL()
+	if err != nil {
+		return nil, err
+	}
+	return &httpPointsWriter{
+		host:  spec.Host,
+		url:   u,
+		token: spec.Token,
+	}, nil
+}
+
+func (w *httpPointsWriter) WritePoints(ctx context.Context, _ dependencies.WriteDestination, points []dependencies.Point) error {
+	var body bytes.Buffer
+	zw := gzip.NewWriter(&body)
+	enc := protocol.NewEncoder(zw)
+	enc.FailOnFieldErr(true)
+	enc.SetFieldSortOrder(protocol.SortFields)
+	for i := range points {
+		if _, err := enc.Encode(pointMetric{&points[i]}); err != nil {
+			return err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return err
+	}
+
+	w.sent += int64(body.Len())
+	deps := dependencies.Get(ctx)
+	req, err := http.NewRequest("POST", w.url, &body)
+	if err != nil {
+		return err
+	}
+	if err := deps.URLValidator.Validate(req.URL); err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "text/plain; charset=utf-8")
+	req.Header.Set("Content-Encoding", "gzip")
+	req.Header.Set("User-Agent", DefaultToUserAgent)
+	req.Header.Set("Authorization", "Token "+w.token)
+
+	ctx, cancel := context.WithTimeout(ctx, DefaultToTimeout)
+	defer cancel()
+	resp, err := deps.HTTPClient.Do(req.WithContext(ctx))
+	if err != nil {
+		deps.Logger.Warn("write request failed", zap.String("host", w.host), zap.Error(err))
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		msg, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+		deps.Logger.Warn("write request was rejected", zap.String("host", w.host), zap.Int("status", resp.StatusCode))
+		return fmt.Errorf("failed to write to %s: %s: %s", w.host, resp.Status, strings.TrimSpace(string(msg)))
+	}
+	_, err = io.Copy(ioutil.Discard, resp.Body)
+	return err
+}
+
+// Count returns the number of bytes of the requests that the writer has sent, which are gzipped.
+// It implements iocounter.Counter.
+func (w *httpPointsWriter) Count() int64 {
+	return w.sent
+}
+
+// pointMetric adapts a Point to the metrics of the line protocol encoder.
+type pointMetric struct {
+	p *dependencies.Point
+}
+
+func (m pointMetric) Name() string {
+	return m.p.Measurement
+}
+
+func (m pointMetric) TagList() []*protocol.Tag {
+	tags := make([]*protocol.Tag, len(m.p.Tags))
+	for i, t := range m.p.Tags {
+		tags[i] = &protocol.Tag{Key: t.Key, Value: t.Value}
+	}
+	return tags
+}
+
+func (m pointMetric) FieldList() []*protocol.Field {
+	fields := make([]*protocol.Field, len(m.p.Fields))
+	for i, f := range m.p.Fields {
+		fields[i] = &protocol.Field{Key: f.Key, Value: f.Value}
+	}
+	return fields
+}
+
+func (m pointMetric) Time() time.Time {
+	return m.p.Time
+}
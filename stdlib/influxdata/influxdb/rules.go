@@ -0,0 +1,582 @@
+package influxdb
+
+import (
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/ast"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/flux/sketch"
+	"github.com/influxdata/flux/stdlib/universe"
+)
+
+func init() {
+	plan.RegisterPhysicalRules(PushDownRangeRule{})
+}
+
+// PushDownRules returns the physical planner rules that push operations down
+// into a StorageReader with the given capabilities.
+// Embedders add them to the physical planner with plan.AddPhysicalRules,
+// with the capabilities returned by ReaderCapabilities.
+func PushDownRules(caps StorageCapabilities) []plan.Rule {
+	var rules []plan.Rule
+	if caps.Filter {
+		rules = append(rules, PushDownFilterRule{})
+	}
+	if caps.Group {
+		rules = append(rules, PushDownGroupRule{})
+	}
+	if caps.WindowAggregate {
+		for _, kind := range windowAggregateKinds {
+			rules = append(rules, PushDownWindowAggregateRule{AggregateKind: kind})
+		}
+	}
+	if caps.KeyValues {
+		rules = append(rules, PushDownTagKeysRule{})
+		if caps.Group {
+			rules = append(rules, PushDownKeyValuesRule{}, PushDownTagValuesRule{})
+		}
+	}
+	if caps.OrderedReads {
+		for _, kind := range selectorKinds {
+			rules = append(rules, PushDownSelectorRule{SelectorKind: kind})
+		}
+	}
+	if caps.Sketches {
+		for _, kind := range sketchAggregateKinds {
+			rules = append(rules, PushDownSketchRule{AggregateKind: kind, ReadKind: ReadRangePhysKind})
+			if caps.Group {
+				rules = append(rules, PushDownSketchRule{AggregateKind: kind, ReadKind: ReadGroupPhysKind})
+			}
+		}
+	}
+	if caps.Distinct {
+		rules = append(rules, PushDownDistinctRule{ReadKind: ReadRangePhysKind})
+		if caps.Group {
+			rules = append(rules, PushDownDistinctRule{ReadKind: ReadGroupPhysKind})
+		}
+	}
+	return rules
+}
+
+// PushDownRangeRule merges from() and a subsequent range() into a read of a time range.
+type PushDownRangeRule struct{}
+
+func (PushDownRangeRule) Name() string {
+	return "PushDownRangeRule"
+}
+
+// Pattern matches `from |> range`
+func (PushDownRangeRule) Pattern() plan.Pattern {
+	return plan.Pat(universe.RangeKind, plan.Pat(FromKind))
+}
+
+func (PushDownRangeRule) Rewrite(node plan.PlanNode) (plan.PlanNode, bool, error) {
+	fromNode := node.Predecessors()[0]
+	fromSpec := fromNode.ProcedureSpec().(*FromProcedureSpec)
+	rangeSpec := node.ProcedureSpec().(*universe.RangeProcedureSpec)
+
+	// Storage only knows about the default time columns.
+	if rangeSpec.TimeColumn != execute.DefaultTimeColLabel ||
+		rangeSpec.StartColumn != execute.DefaultStartColLabel ||
+		rangeSpec.StopColumn != execute.DefaultStopColLabel ||
+		len(fromNode.Successors()) != 1 {
+		return node, false, nil
+	}
+
+	merged, err := plan.MergeToPhysicalPlanNode(node, fromNode, &ReadRangePhysSpec{
+		Bucket: fromSpec.Bucket,
+		Bounds: rangeSpec.Bounds,
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	return merged, true, nil
+}
+
+// PushDownFilterRule merges a filter() into a preceding read of a time range.
+type PushDownFilterRule struct{}
+
+func (PushDownFilterRule) Name() string {
+	return "PushDownFilterRule"
+}
+
+// Pattern matches `ReadRange |> filter`
+func (PushDownFilterRule) Pattern() plan.Pattern {
+	return plan.Pat(universe.FilterKind, plan.Pat(ReadRangePhysKind))
+}
+
+func (PushDownFilterRule) Rewrite(node plan.PlanNode) (plan.PlanNode, bool, error) {
+	readNode := node.Predecessors()[0]
+	readSpec := readNode.ProcedureSpec().(*ReadRangePhysSpec)
+	filterSpec := node.ProcedureSpec().(*universe.FilterProcedureSpec)
+	if len(readNode.Successors()) != 1 {
+		return node, false, nil
+	}
+
+	predicate, ok := mergePredicates(readSpec.Filter, filterSpec.Fn)
+	if !ok {
+		return node, false, nil
+	}
+
+	newSpec := readSpec.Copy().(*ReadRangePhysSpec)
+	newSpec.Filter = predicate
+	merged, err := plan.MergeToPhysicalPlanNode(node, readNode, newSpec)
+	if err != nil {
+		return nil, false, err
+	}
+	return merged, true, nil
+}
+
+// mergePredicates combines two filter functions with a logical and.
+// The functions can only be combined if they name their row parameter the same.
+func mergePredicates(a, b *semantic.FunctionExpression) (*semantic.FunctionExpression, bool) {
+	if a == nil {
+		return b.Copy().(*semantic.FunctionExpression), true
+	}
+	aParams, bParams := a.Block.Parameters, b.Block.Parameters
+	if aParams == nil || bParams == nil ||
+		len(aParams.List) != 1 || len(bParams.List) != 1 ||
+		aParams.List[0].Key.Name != bParams.List[0].Key.Name {
+		return nil, false
+	}
+	aBody, ok := a.Block.Body.(semantic.Expression)
+	if !ok {
+		return nil, false
+	}
+	bBody, ok := b.Block.Body.(semantic.Expression)
+	if !ok {
+		return nil, false
+	}
+
+	merged := a.Copy().(*semantic.FunctionExpression)
+	merged.Block.Body = &semantic.LogicalExpression{
+		Operator: ast.AndOperator,
+		Left:     aBody.Copy().(semantic.Expression),
+		Right:    bBody.Copy().(semantic.Expression),
+	}
+	return merged, true
+}
+
+// PushDownGroupRule merges a group() into a preceding read of a time range.
+type PushDownGroupRule struct{}
+
+func (PushDownGroupRule) Name() string {
+	return "PushDownGroupRule"
+}
+
+// Pattern matches `ReadRange |> group`
+func (PushDownGroupRule) Pattern() plan.Pattern {
+	return plan.Pat(universe.GroupKind, plan.Pat(ReadRangePhysKind))
+}
+
+func (PushDownGroupRule) Rewrite(node plan.PlanNode) (plan.PlanNode, bool, error) {
+	readNode := node.Predecessors()[0]
+	readSpec := readNode.ProcedureSpec().(*ReadRangePhysSpec)
+	groupSpec := node.ProcedureSpec().(*universe.GroupProcedureSpec)
+	if len(readNode.Successors()) != 1 ||
+		(groupSpec.GroupMode != flux.GroupModeBy && groupSpec.GroupMode != flux.GroupModeExcept) {
+		return node, false, nil
+	}
+
+	merged, err := plan.MergeToPhysicalPlanNode(node, readNode, &ReadGroupPhysSpec{
+		ReadRangePhysSpec: *readSpec.Copy().(*ReadRangePhysSpec),
+		GroupMode:         groupSpec.GroupMode,
+		GroupKeys:         append([]string(nil), groupSpec.GroupKeys...),
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	return merged, true, nil
+}
+
+// windowAggregateKinds are the aggregates and selectors that can be pushed down
+// together with a window into a read of a time range.
+var windowAggregateKinds = []plan.ProcedureKind{
+	universe.CountKind,
+	universe.SumKind,
+	universe.MeanKind,
+	universe.MinKind,
+	universe.MaxKind,
+	universe.FirstKind,
+	universe.LastKind,
+}
+
+// PushDownWindowAggregateRule merges a window() and a subsequent aggregate
+// into a preceding read of a time range.
+type PushDownWindowAggregateRule struct {
+	AggregateKind plan.ProcedureKind
+}
+
+func (r PushDownWindowAggregateRule) Name() string {
+	return "PushDownWindowAggregateRule_" + string(r.AggregateKind)
+}
+
+// Pattern matches `ReadRange |> window |> <aggregate>`
+func (r PushDownWindowAggregateRule) Pattern() plan.Pattern {
+	return plan.Pat(r.AggregateKind, plan.Pat(universe.WindowKind, plan.Pat(ReadRangePhysKind)))
+}
+
+func (r PushDownWindowAggregateRule) Rewrite(node plan.PlanNode) (plan.PlanNode, bool, error) {
+	windowNode := node.Predecessors()[0]
+	windowSpec := windowNode.ProcedureSpec().(*universe.WindowProcedureSpec)
+	readNode := windowNode.Predecessors()[0]
+	readSpec := readNode.ProcedureSpec().(*ReadRangePhysSpec)
+
+	if len(windowNode.Successors()) != 1 ||
+		len(readNode.Successors()) != 1 ||
+		windowSpec.TimeColumn != execute.DefaultTimeColLabel ||
+		windowSpec.StartColumn != execute.DefaultStartColLabel ||
+		windowSpec.StopColumn != execute.DefaultStopColLabel ||
+		!windowSpec.Duplicates.KeepsDuplicates() ||
+		!isValueAggregate(node.ProcedureSpec()) {
+		return node, false, nil
+	}
+
+	spec := &ReadWindowAggregatePhysSpec{
+		ReadRangePhysSpec: *readSpec.Copy().(*ReadRangePhysSpec),
+		Window:            windowSpec.Window,
+		CreateEmpty:       windowSpec.CreateEmpty,
+		Aggregate:         r.AggregateKind,
+	}
+	merged, err := plan.MergeToPhysicalPlanNode(node, windowNode, spec)
+	if err != nil {
+		return nil, false, err
+	}
+	merged, err = plan.MergeToPhysicalPlanNode(merged, readNode, spec)
+	if err != nil {
+		return nil, false, err
+	}
+	return merged, true, nil
+}
+
+// isValueAggregate reports whether an aggregate or selector only operates on the `_value` column.
+func isValueAggregate(spec plan.ProcedureSpec) bool {
+	switch spec := spec.(type) {
+	case *universe.CountProcedureSpec:
+		return isValueColumns(spec.Columns)
+	case *universe.SumProcedureSpec:
+		return isValueColumns(spec.Columns)
+	case *universe.MeanProcedureSpec:
+		return isValueColumns(spec.Columns)
+	case *universe.MinProcedureSpec:
+		return isValueColumn(spec.Column)
+	case *universe.MaxProcedureSpec:
+		return isValueColumn(spec.Column)
+	case *universe.FirstProcedureSpec:
+		return isValueColumn(spec.Column)
+	case *universe.LastProcedureSpec:
+		return isValueColumn(spec.Column)
+	default:
+		return false
+	}
+}
+
+// isValueColumn reports whether the column of a selector is `_value`, which is the default when it is empty.
+func isValueColumn(column string) bool {
+	return column == "" || column == execute.DefaultValueColLabel
+}
+
+func isValueColumns(columns []string) bool {
+	return len(columns) == 1 && columns[0] == execute.DefaultValueColLabel
+}
+
+// selectorKinds are the selectors that can limit a read of a time range
+// to the rows they may pick.
+var selectorKinds = []plan.ProcedureKind{
+	universe.FirstKind,
+	universe.LastKind,
+	universe.MaxKind,
+}
+
+// PushDownSelectorRule marks a read of a time range that is followed by a selector as selector-limited,
+// so that the StorageReader streams only the rows of each series the selector may pick
+// instead of materializing every series.
+// The selector is kept in the plan, since a read returns one table per series.
+type PushDownSelectorRule struct {
+	SelectorKind plan.ProcedureKind
+}
+
+func (r PushDownSelectorRule) Name() string {
+	return "PushDownSelectorRule_" + string(r.SelectorKind)
+}
+
+// Pattern matches `ReadRange |> <selector>`
+func (r PushDownSelectorRule) Pattern() plan.Pattern {
+	return plan.Pat(r.SelectorKind, plan.Pat(ReadRangePhysKind))
+}
+
+func (r PushDownSelectorRule) Rewrite(node plan.PlanNode) (plan.PlanNode, bool, error) {
+	readNode := node.Predecessors()[0]
+	readSpec := readNode.ProcedureSpec().(*ReadRangePhysSpec)
+	if readSpec.Selector != "" ||
+		len(readNode.Successors()) != 1 ||
+		!isValueAggregate(node.ProcedureSpec()) {
+		return node, false, nil
+	}
+
+	newSpec := readSpec.Copy().(*ReadRangePhysSpec)
+	newSpec.Selector = r.SelectorKind
+	if err := readNode.ReplaceSpec(newSpec); err != nil {
+		return nil, false, err
+	}
+	return node, true, nil
+}
+
+// PushDownKeyValuesRule replaces a read grouped into a single table and a subsequent keyValues()
+// with a lookup of the distinct tag values in the index of the storage engine.
+// Unlike keyValues(), the lookup does not report a null value for series that lack a tag.
+type PushDownKeyValuesRule struct{}
+
+func (PushDownKeyValuesRule) Name() string {
+	return "PushDownKeyValuesRule"
+}
+
+// Pattern matches `ReadGroup |> keyValues`
+func (PushDownKeyValuesRule) Pattern() plan.Pattern {
+	return plan.Pat(universe.KeyValuesKind, plan.Pat(ReadGroupPhysKind))
+}
+
+func (PushDownKeyValuesRule) Rewrite(node plan.PlanNode) (plan.PlanNode, bool, error) {
+	readNode := node.Predecessors()[0]
+	readSpec := readNode.ProcedureSpec().(*ReadGroupPhysSpec)
+	keyValuesSpec := node.ProcedureSpec().(*universe.KeyValuesProcedureSpec)
+	if len(readNode.Successors()) != 1 ||
+		readSpec.GroupMode != flux.GroupModeBy || len(readSpec.GroupKeys) != 0 ||
+		keyValuesSpec.Predicate != nil || len(keyValuesSpec.KeyColumns) == 0 {
+		return node, false, nil
+	}
+	for _, col := range keyValuesSpec.KeyColumns {
+		if !isTagColumn(col) {
+			return node, false, nil
+		}
+	}
+
+	merged, err := plan.MergeToPhysicalPlanNode(node, readNode, &ReadKeyValuesPhysSpec{
+		ReadRangePhysSpec: *readSpec.ReadRangePhysSpec.Copy().(*ReadRangePhysSpec),
+		KeyColumns:        append([]string(nil), keyValuesSpec.KeyColumns...),
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	return merged, true, nil
+}
+
+// PushDownTagKeysRule replaces a read of a time range followed by keys() and keep(columns: ["_value"]),
+// which is how v1.tagKeys() is written, with a lookup of the tag keys in the index of the storage engine.
+// Unlike the pipeline, the lookup reports every tag key once.
+type PushDownTagKeysRule struct{}
+
+func (PushDownTagKeysRule) Name() string {
+	return "PushDownTagKeysRule"
+}
+
+// Pattern matches `ReadRange |> keys |> keep`
+func (PushDownTagKeysRule) Pattern() plan.Pattern {
+	return plan.Pat(universe.SchemaMutationKind, plan.Pat(universe.KeysKind, plan.Pat(ReadRangePhysKind)))
+}
+
+func (PushDownTagKeysRule) Rewrite(node plan.PlanNode) (plan.PlanNode, bool, error) {
+	keysNode := node.Predecessors()[0]
+	keysSpec := keysNode.ProcedureSpec().(*universe.KeysProcedureSpec)
+	readNode := keysNode.Predecessors()[0]
+	readSpec := readNode.ProcedureSpec().(*ReadRangePhysSpec)
+	if len(keysNode.Successors()) != 1 ||
+		len(readNode.Successors()) != 1 ||
+		keysSpec.Column != execute.DefaultValueColLabel ||
+		!keepsValueColumn(node.ProcedureSpec()) {
+		return node, false, nil
+	}
+
+	spec := &ReadTagKeysPhysSpec{
+		ReadRangePhysSpec: *readSpec.Copy().(*ReadRangePhysSpec),
+	}
+	merged, err := plan.MergeToPhysicalPlanNode(node, keysNode, spec)
+	if err != nil {
+		return nil, false, err
+	}
+	merged, err = plan.MergeToPhysicalPlanNode(merged, readNode, spec)
+	if err != nil {
+		return nil, false, err
+	}
+	return merged, true, nil
+}
+
+// PushDownTagValuesRule replaces a read grouped by a tag followed by distinct() of the tag
+// and keep(columns: ["_value"]), which is how v1.tagValues() is written,
+// with a lookup of the values of the tag in the index of the storage engine.
+type PushDownTagValuesRule struct{}
+
+func (PushDownTagValuesRule) Name() string {
+	return "PushDownTagValuesRule"
+}
+
+// Pattern matches `ReadGroup |> distinct |> keep`
+func (PushDownTagValuesRule) Pattern() plan.Pattern {
+	return plan.Pat(universe.SchemaMutationKind, plan.Pat(universe.DistinctKind, plan.Pat(ReadGroupPhysKind)))
+}
+
+func (PushDownTagValuesRule) Rewrite(node plan.PlanNode) (plan.PlanNode, bool, error) {
+	distinctNode := node.Predecessors()[0]
+	distinctSpec := distinctNode.ProcedureSpec().(*universe.DistinctProcedureSpec)
+	readNode := distinctNode.Predecessors()[0]
+	readSpec := readNode.ProcedureSpec().(*ReadGroupPhysSpec)
+	if len(distinctNode.Successors()) != 1 ||
+		len(readNode.Successors()) != 1 ||
+		len(distinctSpec.Columns) != 0 || !isTagColumn(distinctSpec.Column) ||
+		readSpec.GroupMode != flux.GroupModeBy ||
+		len(readSpec.GroupKeys) != 1 || readSpec.GroupKeys[0] != distinctSpec.Column ||
+		!keepsValueColumn(node.ProcedureSpec()) {
+		return node, false, nil
+	}
+
+	spec := &ReadTagValuesPhysSpec{
+		ReadRangePhysSpec: *readSpec.ReadRangePhysSpec.Copy().(*ReadRangePhysSpec),
+		Tag:               distinctSpec.Column,
+	}
+	merged, err := plan.MergeToPhysicalPlanNode(node, distinctNode, spec)
+	if err != nil {
+		return nil, false, err
+	}
+	merged, err = plan.MergeToPhysicalPlanNode(merged, readNode, spec)
+	if err != nil {
+		return nil, false, err
+	}
+	return merged, true, nil
+}
+
+// keepsValueColumn reports whether a schema mutation is a single keep(columns: ["_value"]).
+func keepsValueColumn(spec plan.ProcedureSpec) bool {
+	s := spec.(*universe.SchemaMutationProcedureSpec)
+	if len(s.Mutations) != 1 {
+		return false
+	}
+	keep, ok := s.Mutations[0].(*universe.KeepOpSpec)
+	return ok && keep.Predicate == nil && isValueColumns(keep.Columns)
+}
+
+// isTagColumn reports whether a column of a read holds a tag, which includes the measurement and field.
+func isTagColumn(label string) bool {
+	switch label {
+	case execute.DefaultStartColLabel, execute.DefaultStopColLabel,
+		execute.DefaultTimeColLabel, execute.DefaultValueColLabel:
+		return false
+	default:
+		return true
+	}
+}
+
+// sketchAggregateKinds are the approximate aggregates that can be computed
+// from sketches returned by a StorageReader.
+var sketchAggregateKinds = []plan.ProcedureKind{
+	universe.ApproxDistinctKind,
+	universe.PercentileKind,
+}
+
+// PushDownSketchRule splits an approximate aggregate of a read of a time range, optionally grouped,
+// and pushes its partial phase down into the read as a read of sketches.
+// The sketches are merged into the estimate of the aggregate by its final phase.
+type PushDownSketchRule struct {
+	AggregateKind plan.ProcedureKind
+	ReadKind      plan.ProcedureKind
+}
+
+func (r PushDownSketchRule) Name() string {
+	return "PushDownSketchRule_" + string(r.ReadKind) + "_" + string(r.AggregateKind)
+}
+
+// Pattern matches `ReadRange |> <aggregate>` or `ReadGroup |> <aggregate>`
+func (r PushDownSketchRule) Pattern() plan.Pattern {
+	return plan.Pat(r.AggregateKind, plan.Pat(r.ReadKind))
+}
+
+func (r PushDownSketchRule) Rewrite(node plan.PlanNode) (plan.PlanNode, bool, error) {
+	readNode := node.Predecessors()[0]
+	if len(readNode.Successors()) != 1 {
+		return node, false, nil
+	}
+
+	spec := new(ReadSketchPhysSpec)
+	switch readSpec := readNode.ProcedureSpec().(type) {
+	case *ReadRangePhysSpec:
+		spec.ReadRangePhysSpec = *readSpec.Copy().(*ReadRangePhysSpec)
+	case *ReadGroupPhysSpec:
+		spec.ReadGroupPhysSpec = *readSpec.Copy().(*ReadGroupPhysSpec)
+	default:
+		return node, false, nil
+	}
+
+	switch aggSpec := node.ProcedureSpec().(type) {
+	case *universe.ApproxDistinctProcedureSpec:
+		if !isValueColumns(aggSpec.Columns) {
+			return node, false, nil
+		}
+		spec.Sketch = sketch.HyperLogLogKind
+		spec.Precision = int(aggSpec.Precision)
+	case *universe.TDigestPercentileProcedureSpec:
+		if !isValueColumns(aggSpec.Columns) {
+			return node, false, nil
+		}
+		spec.Sketch = sketch.TDigestKind
+		spec.Compression = aggSpec.Compression
+	default:
+		return node, false, nil
+	}
+
+	final, ok := plan.SplitAggregate(node)
+	if !ok {
+		return node, false, nil
+	}
+	partial := final.Predecessors()[0]
+	merged, err := plan.MergeToPhysicalPlanNode(partial, readNode, spec)
+	if err != nil {
+		return nil, false, err
+	}
+	final.ClearPredecessors()
+	final.AddPredecessors(merged)
+	merged.AddSuccessors(final)
+	return final, true, nil
+}
+
+// PushDownDistinctRule merges a distinct() of a set of columns into a preceding read of a time range,
+// optionally grouped, so that the StorageReader returns the distinct combinations of values,
+// for example from its index, instead of every row.
+// A distinct() of a single column is not pushed down, since it renames the column to `_value`.
+type PushDownDistinctRule struct {
+	ReadKind plan.ProcedureKind
+}
+
+func (r PushDownDistinctRule) Name() string {
+	return "PushDownDistinctRule_" + string(r.ReadKind)
+}
+
+// Pattern matches `ReadRange |> distinct` or `ReadGroup |> distinct`
+func (r PushDownDistinctRule) Pattern() plan.Pattern {
+	return plan.Pat(universe.DistinctKind, plan.Pat(r.ReadKind))
+}
+
+func (r PushDownDistinctRule) Rewrite(node plan.PlanNode) (plan.PlanNode, bool, error) {
+	readNode := node.Predecessors()[0]
+	distinctSpec := node.ProcedureSpec().(*universe.DistinctProcedureSpec)
+	if len(readNode.Successors()) != 1 || len(distinctSpec.Columns) == 0 {
+		return node, false, nil
+	}
+
+	spec := &ReadDistinctPhysSpec{
+		Columns: append([]string(nil), distinctSpec.Columns...),
+	}
+	switch readSpec := readNode.ProcedureSpec().(type) {
+	case *ReadRangePhysSpec:
+		spec.ReadRangePhysSpec = *readSpec.Copy().(*ReadRangePhysSpec)
+	case *ReadGroupPhysSpec:
+		spec.ReadGroupPhysSpec = *readSpec.Copy().(*ReadGroupPhysSpec)
+	default:
+		return node, false, nil
+	}
+
+	merged, err := plan.MergeToPhysicalPlanNode(node, readNode, spec)
+	if err != nil {
+		return nil, false, err
+	}
+	return merged, true, nil
+}
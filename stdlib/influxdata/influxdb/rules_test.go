@@ -0,0 +1,368 @@
+package influxdb_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/ast"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/plan/plantest"
+	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/flux/sketch"
+	"github.com/influxdata/flux/stdlib/influxdata/influxdb"
+	"github.com/influxdata/flux/stdlib/universe"
+	"github.com/influxdata/flux/values"
+)
+
+func TestPushDownRules(t *testing.T) {
+	now := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	bounds := flux.Bounds{
+		Start: flux.Time{IsRelative: true, Relative: -time.Hour},
+		Stop:  flux.Time{IsRelative: true},
+		Now:   now,
+	}
+	predicate := func(measurement string) *semantic.FunctionExpression {
+		return &semantic.FunctionExpression{
+			Block: &semantic.FunctionBlock{
+				Parameters: &semantic.FunctionParameters{
+					List: []*semantic.FunctionParameter{{Key: &semantic.Identifier{Name: "r"}}},
+				},
+				Body: &semantic.BinaryExpression{
+					Operator: ast.EqualOperator,
+					Left: &semantic.MemberExpression{
+						Object:   &semantic.IdentifierExpression{Name: "r"},
+						Property: "_measurement",
+					},
+					Right: &semantic.StringLiteral{Value: measurement},
+				},
+			},
+		}
+	}
+	from := &influxdb.FromProcedureSpec{Bucket: "my_bucket"}
+	rangeSpec := &universe.RangeProcedureSpec{
+		Bounds:      bounds,
+		TimeColumn:  execute.DefaultTimeColLabel,
+		StartColumn: execute.DefaultStartColLabel,
+		StopColumn:  execute.DefaultStopColLabel,
+	}
+	readRange := &influxdb.ReadRangePhysSpec{
+		Bucket: "my_bucket",
+		Bounds: bounds,
+	}
+	readRangeFiltered := &influxdb.ReadRangePhysSpec{
+		Bucket: "my_bucket",
+		Bounds: bounds,
+		Filter: predicate("cpu"),
+	}
+	window := &universe.WindowProcedureSpec{
+		Window: plan.WindowSpec{
+			Every:  values.ConvertDuration(time.Minute),
+			Period: values.ConvertDuration(time.Minute),
+		},
+		TimeColumn:  execute.DefaultTimeColLabel,
+		StartColumn: execute.DefaultStartColLabel,
+		StopColumn:  execute.DefaultStopColLabel,
+	}
+	readGroup := func(rr *influxdb.ReadRangePhysSpec, keys ...string) *influxdb.ReadGroupPhysSpec {
+		return &influxdb.ReadGroupPhysSpec{
+			ReadRangePhysSpec: *rr,
+			GroupMode:         flux.GroupModeBy,
+			GroupKeys:         keys,
+		}
+	}
+	allCapabilities := influxdb.PushDownRules(influxdb.StorageCapabilities{
+		Filter:          true,
+		Group:           true,
+		WindowAggregate: true,
+		KeyValues:       true,
+		Sketches:        true,
+		OrderedReads:    true,
+		Distinct:        true,
+	})
+	specs := plantest.Specs{
+		"from":  from,
+		"range": rangeSpec,
+		"rangeOnTime": &universe.RangeProcedureSpec{
+			Bounds:      bounds,
+			TimeColumn:  "time",
+			StartColumn: execute.DefaultStartColLabel,
+			StopColumn:  execute.DefaultStopColLabel,
+		},
+		"filterCPU": &universe.FilterProcedureSpec{Fn: predicate("cpu")},
+		"filterMem": &universe.FilterProcedureSpec{Fn: predicate("mem")},
+		"group":     &universe.GroupProcedureSpec{GroupMode: flux.GroupModeBy},
+		"window":    window,
+		"count":     &universe.CountProcedureSpec{AggregateConfig: execute.DefaultAggregateConfig},
+		"first":     &universe.FirstProcedureSpec{SelectorConfig: execute.SelectorConfig{Column: execute.DefaultValueColLabel}},
+		"last":      &universe.LastProcedureSpec{SelectorConfig: execute.SelectorConfig{Column: execute.DefaultValueColLabel}},
+		"max":       &universe.MaxProcedureSpec{SelectorConfig: execute.SelectorConfig{Column: execute.DefaultValueColLabel}},
+		"keyValues": &universe.KeyValuesProcedureSpec{},
+		"distinct":  &universe.DistinctProcedureSpec{Column: execute.DefaultValueColLabel},
+		"keys":      &universe.KeysProcedureSpec{Column: execute.DefaultValueColLabel},
+		"keepValue": &universe.SchemaMutationProcedureSpec{Mutations: []universe.SchemaMutation{
+			&universe.KeepOpSpec{Columns: []string{execute.DefaultValueColLabel}},
+		}},
+		"keepTime": &universe.SchemaMutationProcedureSpec{Mutations: []universe.SchemaMutation{
+			&universe.KeepOpSpec{Columns: []string{execute.DefaultTimeColLabel}},
+		}},
+		"approxDistinct": &universe.ApproxDistinctProcedureSpec{
+			Precision:       12,
+			AggregateConfig: execute.DefaultAggregateConfig,
+		},
+		"approxDistinctFinal": &universe.ApproxDistinctFinalProcedureSpec{
+			Precision:       12,
+			AggregateConfig: execute.DefaultAggregateConfig,
+		},
+		"percentile": &universe.TDigestPercentileProcedureSpec{
+			Percentile:      0.99,
+			Compression:     1000,
+			AggregateConfig: execute.DefaultAggregateConfig,
+		},
+		"percentileFinal": &universe.TDigestPercentileFinalProcedureSpec{
+			Percentile:      0.99,
+			Compression:     1000,
+			AggregateConfig: execute.DefaultAggregateConfig,
+		},
+
+		"readRange":    readRange,
+		"readRangeCPU": readRangeFiltered,
+		"readRangeCPUAndMem": &influxdb.ReadRangePhysSpec{
+			Bucket: "my_bucket",
+			Bounds: bounds,
+			Filter: &semantic.FunctionExpression{
+				Block: &semantic.FunctionBlock{
+					Parameters: predicate("cpu").Block.Parameters,
+					Body: &semantic.LogicalExpression{
+						Operator: ast.AndOperator,
+						Left:     predicate("cpu").Block.Body.(semantic.Expression),
+						Right:    predicate("mem").Block.Body.(semantic.Expression),
+					},
+				},
+			},
+		},
+		"readRangeLast": &influxdb.ReadRangePhysSpec{
+			Bucket:   readRange.Bucket,
+			Bounds:   readRange.Bounds,
+			Selector: universe.LastKind,
+		},
+		"readGroup":     readGroup(readRange),
+		"readGroupHost": readGroup(readRange, "host"),
+		"readGroupCPU":  readGroup(readRangeFiltered, "host"),
+		"readWindowCount": &influxdb.ReadWindowAggregatePhysSpec{
+			ReadRangePhysSpec: *readRange,
+			Window:            window.Window,
+			Aggregate:         universe.CountKind,
+		},
+		"readKeyValues": &influxdb.ReadKeyValuesPhysSpec{
+			ReadRangePhysSpec: *readRangeFiltered,
+			KeyColumns:        []string{"host", "region"},
+		},
+		"readTagKeys": &influxdb.ReadTagKeysPhysSpec{
+			ReadRangePhysSpec: *readRangeFiltered,
+		},
+		"readTagValues": &influxdb.ReadTagValuesPhysSpec{
+			ReadRangePhysSpec: *readRange,
+			Tag:               "host",
+		},
+		"readHyperLogLog": &influxdb.ReadSketchPhysSpec{
+			ReadGroupPhysSpec: influxdb.ReadGroupPhysSpec{
+				ReadRangePhysSpec: *readRangeFiltered,
+			},
+			Sketch:    sketch.HyperLogLogKind,
+			Precision: 12,
+		},
+		"readTDigest": &influxdb.ReadSketchPhysSpec{
+			ReadGroupPhysSpec: *readGroup(readRange, "host"),
+			Sketch:            sketch.TDigestKind,
+			Compression:       1000,
+		},
+		"readDistinct": &influxdb.ReadDistinctPhysSpec{
+			ReadGroupPhysSpec: influxdb.ReadGroupPhysSpec{
+				ReadRangePhysSpec: *readRangeFiltered,
+			},
+			Columns: []string{"host", "region"},
+		},
+		"readGroupDistinct": &influxdb.ReadDistinctPhysSpec{
+			ReadGroupPhysSpec: *readGroup(readRange, "region"),
+			Columns:           []string{"host"},
+		},
+	}
+
+	tests := []plantest.RuleTestCase{
+		{
+			Name:   "range",
+			Rules:  []plan.Rule{influxdb.PushDownRangeRule{}},
+			Before: plantest.MustParsePlan("physical; from = from -> range = range", specs),
+			After:  plantest.MustParsePlan("physical; merged_from_range = readRange", specs),
+		},
+		{
+			Name:     "range with custom time column",
+			Rules:    []plan.Rule{influxdb.PushDownRangeRule{}},
+			Before:   plantest.MustParsePlan("physical; from = from -> range = rangeOnTime", specs),
+			NoChange: true,
+		},
+		{
+			Name:   "filter",
+			Rules:  allCapabilities,
+			Before: plantest.MustParsePlan("physical; ReadRange = readRange -> filter = filterCPU", specs),
+			After:  plantest.MustParsePlan("physical; merged_ReadRange_filter = readRangeCPU", specs),
+		},
+		{
+			Name:   "two filters",
+			Rules:  allCapabilities,
+			Before: plantest.MustParsePlan("physical; ReadRange = readRangeCPU -> filter = filterMem", specs),
+			After:  plantest.MustParsePlan("physical; merged_ReadRange_filter = readRangeCPUAndMem", specs),
+		},
+		{
+			Name: "filter without capability",
+			Rules: influxdb.PushDownRules(influxdb.StorageCapabilities{
+				Group:           true,
+				WindowAggregate: true,
+			}),
+			Before:   plantest.MustParsePlan("physical; ReadRange = readRange -> filter = filterCPU", specs),
+			NoChange: true,
+		},
+		{
+			Name:   "group",
+			Rules:  allCapabilities,
+			Before: plantest.MustParsePlan(`physical; ReadRange = readRangeCPU -> group = group {"GroupKeys": ["host"]}`, specs),
+			After:  plantest.MustParsePlan("physical; merged_ReadRange_group = readGroupCPU", specs),
+		},
+		{
+			Name:   "window aggregate",
+			Rules:  allCapabilities,
+			Before: plantest.MustParsePlan("physical; ReadRange = readRange -> window = window -> count = count", specs),
+			After:  plantest.MustParsePlan("physical; merged_ReadRange_window_count = readWindowCount", specs),
+		},
+		{
+			Name:     "window aggregate on other column",
+			Rules:    allCapabilities,
+			Before:   plantest.MustParsePlan(`physical; ReadRange = readRange -> window = window -> max = max {"column": "other"}`, specs),
+			NoChange: true,
+		},
+		{
+			Name:   "selector-limited read",
+			Rules:  allCapabilities,
+			Before: plantest.MustParsePlan("physical; ReadRange = readRange -> last = last", specs),
+			After:  plantest.MustParsePlan("physical; ReadRange = readRangeLast -> last = last", specs),
+		},
+		{
+			Name:     "selector on other column",
+			Rules:    allCapabilities,
+			Before:   plantest.MustParsePlan(`physical; ReadRange = readRange -> first = first {"column": "other"}`, specs),
+			NoChange: true,
+		},
+		{
+			Name:     "selector without capability",
+			Rules:    influxdb.PushDownRules(influxdb.StorageCapabilities{Filter: true}),
+			Before:   plantest.MustParsePlan("physical; ReadRange = readRange -> max = max", specs),
+			NoChange: true,
+		},
+		{
+			Name:   "key values",
+			Rules:  allCapabilities,
+			Before: plantest.MustParsePlan(`physical; ReadRange = readRangeCPU -> group = group -> keyValues = keyValues {"keyColumns": ["host", "region"]}`, specs),
+			After:  plantest.MustParsePlan("physical; merged_ReadRange_group_keyValues = readKeyValues", specs),
+		},
+		{
+			Name:     "key values of a field value",
+			Rules:    allCapabilities,
+			Before:   plantest.MustParsePlan(`physical; ReadGroup = readGroup -> keyValues = keyValues {"keyColumns": ["host", "_value"]}`, specs),
+			NoChange: true,
+		},
+		{
+			Name:     "key values per group",
+			Rules:    allCapabilities,
+			Before:   plantest.MustParsePlan(`physical; ReadGroup = readGroup {"GroupKeys": ["region"]} -> keyValues = keyValues {"keyColumns": ["host"]}`, specs),
+			NoChange: true,
+		},
+		{
+			Name:   "tag keys",
+			Rules:  allCapabilities,
+			Before: plantest.MustParsePlan("physical; ReadRange = readRangeCPU -> keys = keys -> keep = keepValue", specs),
+			After:  plantest.MustParsePlan("physical; merged_ReadRange_keys_keep = readTagKeys", specs),
+		},
+		{
+			Name:     "keys without keeping the value",
+			Rules:    allCapabilities,
+			Before:   plantest.MustParsePlan("physical; ReadRange = readRangeCPU -> keys = keys -> keep = keepTime", specs),
+			NoChange: true,
+		},
+		{
+			Name:     "tag keys without capability",
+			Rules:    influxdb.PushDownRules(influxdb.StorageCapabilities{Filter: true, Group: true}),
+			Before:   plantest.MustParsePlan("physical; ReadRange = readRangeCPU -> keys = keys -> keep = keepValue", specs),
+			NoChange: true,
+		},
+		{
+			Name:   "tag values",
+			Rules:  allCapabilities,
+			Before: plantest.MustParsePlan(`physical; ReadGroup = readGroupHost -> distinct = distinct {"Column": "host"} -> keep = keepValue`, specs),
+			After:  plantest.MustParsePlan("physical; merged_ReadGroup_distinct_keep = readTagValues", specs),
+		},
+		{
+			Name:     "distinct of a column other than the group",
+			Rules:    allCapabilities,
+			Before:   plantest.MustParsePlan(`physical; ReadGroup = readGroupHost -> distinct = distinct {"Column": "region"} -> keep = keepValue`, specs),
+			NoChange: true,
+		},
+		{
+			Name:   "approximate distinct",
+			Rules:  allCapabilities,
+			Before: plantest.MustParsePlan("physical; ReadRange = readRangeCPU -> approxDistinct = approxDistinct", specs),
+			After:  plantest.MustParsePlan("physical; merged_ReadRange_approxDistinct_partial = readHyperLogLog -> approxDistinct_final = approxDistinctFinal", specs),
+		},
+		{
+			Name:   "grouped percentile",
+			Rules:  allCapabilities,
+			Before: plantest.MustParsePlan("physical; ReadGroup = readGroupHost -> percentile = percentile", specs),
+			After:  plantest.MustParsePlan("physical; merged_ReadGroup_percentile_partial = readTDigest -> percentile_final = percentileFinal", specs),
+		},
+		{
+			Name:     "percentile without compression",
+			Rules:    allCapabilities,
+			Before:   plantest.MustParsePlan(`physical; ReadRange = readRange -> percentile = percentile {"compression": 0}`, specs),
+			NoChange: true,
+		},
+		{
+			Name:     "approximate distinct without capability",
+			Rules:    influxdb.PushDownRules(influxdb.StorageCapabilities{Group: true}),
+			Before:   plantest.MustParsePlan("physical; ReadRange = readRange -> approxDistinct = approxDistinct", specs),
+			NoChange: true,
+		},
+		{
+			Name:   "distinct",
+			Rules:  allCapabilities,
+			Before: plantest.MustParsePlan(`physical; ReadRange = readRangeCPU -> distinct = distinct {"Columns": ["host", "region"]}`, specs),
+			After:  plantest.MustParsePlan("physical; merged_ReadRange_distinct = readDistinct", specs),
+		},
+		{
+			Name:   "grouped distinct",
+			Rules:  allCapabilities,
+			Before: plantest.MustParsePlan(`physical; ReadGroup = readGroup {"GroupKeys": ["region"]} -> distinct = distinct {"Columns": ["host"]}`, specs),
+			After:  plantest.MustParsePlan("physical; merged_ReadGroup_distinct = readGroupDistinct", specs),
+		},
+		{
+			Name:     "distinct of a single column",
+			Rules:    allCapabilities,
+			Before:   plantest.MustParsePlan(`physical; ReadRange = readRange -> distinct = distinct {"Column": "host"}`, specs),
+			NoChange: true,
+		},
+		{
+			Name:     "distinct without capability",
+			Rules:    influxdb.PushDownRules(influxdb.StorageCapabilities{Group: true}),
+			Before:   plantest.MustParsePlan(`physical; ReadRange = readRange -> distinct = distinct {"Columns": ["host"]}`, specs),
+			NoChange: true,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			plantest.PhysicalRuleTestHelper(t, &tc)
+		})
+	}
+}
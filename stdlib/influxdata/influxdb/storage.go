@@ -0,0 +1,645 @@
+package influxdb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/memory"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/flux/sketch"
+	"github.com/influxdata/flux/values"
+	"github.com/pkg/errors"
+)
+
+// StorageDependencyKey is the key under which embedders provide the StorageReader
+// used by from() in the execute.Dependencies of the executor.
+// It is not part of dependencies.Dependencies, which cannot refer to the types of the execution engine.
+const StorageDependencyKey = "influxdata/influxdb.StorageReader"
+
+// StorageReader reads series data on behalf of from().
+// Each method returns the tables for the given spec as a stream of tables
+// with the `_start`, `_stop`, `_time`, `_measurement`, `_field` and `_value` columns
+// and one column per tag, in the same shape InfluxDB produces them.
+// ReadFilter returns the rows of every series in time order.
+// Readers report the data they scan with execute.ReportUsage and the context of the read.
+type StorageReader interface {
+	// Capabilities reports which operations can be pushed down into the reader.
+	Capabilities() StorageCapabilities
+
+	// ReadFilter reads the series in a bucket for a time range,
+	// optionally filtered by a predicate.
+	ReadFilter(ctx context.Context, spec ReadFilterSpec, alloc *memory.Allocator) (flux.TableIterator, error)
+	// ReadGroup reads the series in a bucket for a time range and
+	// groups them by a set of columns.
+	ReadGroup(ctx context.Context, spec ReadGroupSpec, alloc *memory.Allocator) (flux.TableIterator, error)
+	// ReadWindowAggregate reads the series in a bucket for a time range and
+	// aggregates them over windows of time.
+	ReadWindowAggregate(ctx context.Context, spec ReadWindowAggregateSpec, alloc *memory.Allocator) (flux.TableIterator, error)
+}
+
+// SketchReader is implemented by the StorageReaders that can summarize series in sketches.
+// It is only used when the reader also declares the Sketches capability.
+type SketchReader interface {
+	// ReadSketch reads the series in a bucket for a time range and
+	// summarizes the values of every table in a sketch.
+	ReadSketch(ctx context.Context, spec ReadSketchSpec, alloc *memory.Allocator) (flux.TableIterator, error)
+}
+
+// DistinctReader is implemented by the StorageReaders that can read the distinct values of series.
+// It is only used when the reader also declares the Distinct capability.
+type DistinctReader interface {
+	// ReadDistinct reads the series in a bucket for a time range and
+	// returns the distinct combinations of values of a set of columns of every table.
+	ReadDistinct(ctx context.Context, spec ReadDistinctSpec, alloc *memory.Allocator) (flux.TableIterator, error)
+}
+
+// ReaderCapabilities returns the capabilities of a reader, without the ones
+// whose optional interface the reader does not implement.
+// Embedders pass them to PushDownRules.
+func ReaderCapabilities(r StorageReader) StorageCapabilities {
+	caps := r.Capabilities()
+	if _, ok := r.(SketchReader); !ok {
+		caps.Sketches = false
+	}
+	if _, ok := r.(DistinctReader); !ok {
+		caps.Distinct = false
+	}
+	return caps
+}
+
+// StorageCapabilities declares the operations a StorageReader can perform
+// beyond reading a time range, which every reader must support.
+// The planner only pushes an operation down into the reader if the capability is set.
+type StorageCapabilities struct {
+	// Filter reports whether ReadFilter supports a predicate.
+	Filter bool
+	// Group reports whether ReadGroup is supported.
+	Group bool
+	// WindowAggregate reports whether ReadWindowAggregate is supported.
+	WindowAggregate bool
+	// KeyValues reports whether the distinct values of tags can be answered
+	// from the index of the MetaClient instead of reading the series.
+	KeyValues bool
+	// Sketches reports whether ReadSketch is supported, the reader must implement SketchReader.
+	Sketches bool
+	// Distinct reports whether ReadDistinct is supported, the reader must implement DistinctReader.
+	Distinct bool
+	// OrderedReads reports whether ReadFilter supports a Selector,
+	// reading each series in time order only as far as the selector needs.
+	OrderedReads bool
+}
+
+// ReadFilterSpec describes a read of a time range from a bucket.
+type ReadFilterSpec struct {
+	Bucket string
+	Bounds execute.Bounds
+	// Predicate is the filter function pushed down into the read, or nil.
+	Predicate *semantic.FunctionExpression
+	// Selector is the kind of the selector that follows the read, or empty.
+	// A selector-limited read only needs to return the rows of each series
+	// that the selector may pick: the first row of the series for "first",
+	// the last row for "last", and the row with the largest value for "max".
+	// Returning more rows is correct, since the selector is still applied to the result.
+	Selector plan.ProcedureKind
+}
+
+// ReadGroupSpec describes a read that groups the series by a set of columns.
+type ReadGroupSpec struct {
+	ReadFilterSpec
+	GroupMode flux.GroupMode
+	GroupKeys []string
+}
+
+// ReadWindowAggregateSpec describes a read that aggregates the series over windows of time.
+type ReadWindowAggregateSpec struct {
+	ReadFilterSpec
+	Window      plan.WindowSpec
+	CreateEmpty bool
+	// Aggregate is the kind of the aggregate or selector applied to each window,
+	// for example "count" or "max".
+	Aggregate plan.ProcedureKind
+}
+
+// ReadSketchSpec describes a read that summarizes the values of every table in a sketch.
+// The series are grouped as for ReadGroup, and with flux.GroupModeNone they are
+// returned one table per series as for ReadFilter.
+//
+// Every table has the columns of its group key and a string `_value` column,
+// which holds sketches serialized with MarshalBinary.
+// The sketches of a table are merged, so a reader may return one per row,
+// for example one for every shard it reads.
+type ReadSketchSpec struct {
+	ReadGroupSpec
+	Sketch sketch.Kind
+	// Precision is the precision of HyperLogLog sketches.
+	Precision int
+	// Compression is the compression of t-digest sketches.
+	Compression float64
+}
+
+// ReadDistinctSpec describes a read that returns the distinct combinations of values of a set of columns
+// of every table, in the shape distinct() produces them with its columns parameter.
+// The series are grouped as for ReadGroup, and with flux.GroupModeNone they are
+// returned one table per series as for ReadFilter.
+//
+// Every table has the columns of its group key and the listed columns that are not part of it,
+// and one row for every combination of values of the columns, null values included.
+// The order of the rows is not specified.
+type ReadDistinctSpec struct {
+	ReadGroupSpec
+	Columns []string
+}
+
+// StorageSystem names the storage that executes reads in the summary of a plan.
+const StorageSystem = "storage"
+
+const (
+	ReadRangePhysKind           = "ReadRangePhysKind"
+	ReadGroupPhysKind           = "ReadGroupPhysKind"
+	ReadWindowAggregatePhysKind = "ReadWindowAggregatePhysKind"
+	ReadKeyValuesPhysKind       = "ReadKeyValuesPhysKind"
+	ReadSketchPhysKind          = "ReadSketchPhysKind"
+	ReadDistinctPhysKind        = "ReadDistinctPhysKind"
+	ReadTagKeysPhysKind         = "ReadTagKeysPhysKind"
+	ReadTagValuesPhysKind       = "ReadTagValuesPhysKind"
+)
+
+func init() {
+	execute.RegisterSource(ReadRangePhysKind, createReadFilterSource)
+	execute.RegisterSource(ReadGroupPhysKind, createReadGroupSource)
+	execute.RegisterSource(ReadWindowAggregatePhysKind, createReadWindowAggregateSource)
+	execute.RegisterSource(ReadKeyValuesPhysKind, createReadKeyValuesSource)
+	execute.RegisterSource(ReadSketchPhysKind, createReadSketchSource)
+	execute.RegisterSource(ReadDistinctPhysKind, createReadDistinctSource)
+	execute.RegisterSource(ReadTagKeysPhysKind, createReadTagKeysSource)
+	execute.RegisterSource(ReadTagValuesPhysKind, createReadTagValuesSource)
+}
+
+// ReadRangePhysSpec is the physical procedure for a from() bounded by range(),
+// optionally with a filter pushed down into it.
+type ReadRangePhysSpec struct {
+	plan.DefaultCost
+	Bucket string
+	Bounds flux.Bounds
+	Filter *semantic.FunctionExpression
+	// Selector marks the read as selector-limited, see ReadFilterSpec.
+	Selector plan.ProcedureKind
+}
+
+func (s *ReadRangePhysSpec) Kind() plan.ProcedureKind {
+	return ReadRangePhysKind
+}
+
+func (s *ReadRangePhysSpec) Copy() plan.ProcedureSpec {
+	ns := new(ReadRangePhysSpec)
+	*ns = *s
+	if s.Filter != nil {
+		ns.Filter = s.Filter.Copy().(*semantic.FunctionExpression)
+	}
+	return ns
+}
+
+// TimeBounds implements plan.BoundsAwareProcedureSpec
+func (s *ReadRangePhysSpec) TimeBounds(predecessorBounds *plan.Bounds) *plan.Bounds {
+	return &plan.Bounds{
+		Start: values.ConvertTime(s.Bounds.Start.Time(s.Bounds.Now)),
+		Stop:  values.ConvertTime(s.Bounds.Stop.Time(s.Bounds.Now)),
+	}
+}
+
+// ExternalSystem implements plan.ExternalProcedureSpec, the reads execute in storage
+// along with the operations pushed down into them.
+func (s *ReadRangePhysSpec) ExternalSystem() string {
+	return StorageSystem
+}
+
+// SortedBy implements plan.OrderedProcedureSpec, a StorageReader reads every series in time order.
+func (s *ReadRangePhysSpec) SortedBy(predecessors [][]string) []string {
+	return []string{execute.DefaultTimeColLabel}
+}
+
+func (s *ReadRangePhysSpec) readFilterSpec(bounds execute.Bounds) ReadFilterSpec {
+	return ReadFilterSpec{
+		Bucket:    s.Bucket,
+		Bounds:    bounds,
+		Predicate: s.Filter,
+		Selector:  s.Selector,
+	}
+}
+
+// ReadGroupPhysSpec is the physical procedure for a ranged from() followed by group().
+type ReadGroupPhysSpec struct {
+	ReadRangePhysSpec
+	GroupMode flux.GroupMode
+	GroupKeys []string
+}
+
+func (s *ReadGroupPhysSpec) Kind() plan.ProcedureKind {
+	return ReadGroupPhysKind
+}
+
+// SortedBy implements plan.OrderedProcedureSpec, the rows of the series of a group are not sorted.
+func (s *ReadGroupPhysSpec) SortedBy(predecessors [][]string) []string {
+	return nil
+}
+
+func (s *ReadGroupPhysSpec) Copy() plan.ProcedureSpec {
+	ns := new(ReadGroupPhysSpec)
+	ns.ReadRangePhysSpec = *s.ReadRangePhysSpec.Copy().(*ReadRangePhysSpec)
+	ns.GroupMode = s.GroupMode
+	ns.GroupKeys = append([]string(nil), s.GroupKeys...)
+	return ns
+}
+
+// ReadWindowAggregatePhysSpec is the physical procedure for a ranged from()
+// followed by window() and an aggregate or selector.
+type ReadWindowAggregatePhysSpec struct {
+	ReadRangePhysSpec
+	Window      plan.WindowSpec
+	CreateEmpty bool
+	Aggregate   plan.ProcedureKind
+}
+
+func (s *ReadWindowAggregatePhysSpec) Kind() plan.ProcedureKind {
+	return ReadWindowAggregatePhysKind
+}
+
+// SortedBy implements plan.OrderedProcedureSpec, the order of the aggregates is not known.
+func (s *ReadWindowAggregatePhysSpec) SortedBy(predecessors [][]string) []string {
+	return nil
+}
+
+func (s *ReadWindowAggregatePhysSpec) Copy() plan.ProcedureSpec {
+	ns := new(ReadWindowAggregatePhysSpec)
+	ns.ReadRangePhysSpec = *s.ReadRangePhysSpec.Copy().(*ReadRangePhysSpec)
+	ns.Window = s.Window
+	ns.CreateEmpty = s.CreateEmpty
+	ns.Aggregate = s.Aggregate
+	return ns
+}
+
+// ReadKeyValuesPhysSpec is the physical procedure for a ranged from() followed by
+// group() into a single table and keyValues().
+// The distinct values of the key columns are looked up with the MetaClient.
+type ReadKeyValuesPhysSpec struct {
+	ReadRangePhysSpec
+	KeyColumns []string
+}
+
+func (s *ReadKeyValuesPhysSpec) Kind() plan.ProcedureKind {
+	return ReadKeyValuesPhysKind
+}
+
+// SortedBy implements plan.OrderedProcedureSpec, the order of the key values is not known.
+func (s *ReadKeyValuesPhysSpec) SortedBy(predecessors [][]string) []string {
+	return nil
+}
+
+func (s *ReadKeyValuesPhysSpec) Copy() plan.ProcedureSpec {
+	ns := new(ReadKeyValuesPhysSpec)
+	ns.ReadRangePhysSpec = *s.ReadRangePhysSpec.Copy().(*ReadRangePhysSpec)
+	ns.KeyColumns = append([]string(nil), s.KeyColumns...)
+	return ns
+}
+
+// ReadTagKeysPhysSpec is the physical procedure for a ranged from() followed by
+// keys() and keep(columns: ["_value"]), as in v1.tagKeys().
+// The tag keys are looked up with the MetaClient.
+type ReadTagKeysPhysSpec struct {
+	ReadRangePhysSpec
+}
+
+func (s *ReadTagKeysPhysSpec) Kind() plan.ProcedureKind {
+	return ReadTagKeysPhysKind
+}
+
+// SortedBy implements plan.OrderedProcedureSpec, the order of the tag keys is not known.
+func (s *ReadTagKeysPhysSpec) SortedBy(predecessors [][]string) []string {
+	return nil
+}
+
+func (s *ReadTagKeysPhysSpec) Copy() plan.ProcedureSpec {
+	ns := new(ReadTagKeysPhysSpec)
+	ns.ReadRangePhysSpec = *s.ReadRangePhysSpec.Copy().(*ReadRangePhysSpec)
+	return ns
+}
+
+// ReadTagValuesPhysSpec is the physical procedure for a ranged from() grouped by a tag,
+// followed by distinct() of the tag and keep(columns: ["_value"]), as in v1.tagValues().
+// The values of the tag are looked up with the MetaClient.
+type ReadTagValuesPhysSpec struct {
+	ReadRangePhysSpec
+	Tag string
+}
+
+func (s *ReadTagValuesPhysSpec) Kind() plan.ProcedureKind {
+	return ReadTagValuesPhysKind
+}
+
+// SortedBy implements plan.OrderedProcedureSpec, the order of the tag values is not known.
+func (s *ReadTagValuesPhysSpec) SortedBy(predecessors [][]string) []string {
+	return nil
+}
+
+func (s *ReadTagValuesPhysSpec) Copy() plan.ProcedureSpec {
+	ns := new(ReadTagValuesPhysSpec)
+	ns.ReadRangePhysSpec = *s.ReadRangePhysSpec.Copy().(*ReadRangePhysSpec)
+	ns.Tag = s.Tag
+	return ns
+}
+
+// ReadSketchPhysSpec is the physical procedure for a ranged from(), optionally followed by group(),
+// and the partial phase of an approximate aggregate, which is computed as sketches by the StorageReader.
+type ReadSketchPhysSpec struct {
+	ReadGroupPhysSpec
+	Sketch      sketch.Kind
+	Precision   int
+	Compression float64
+}
+
+func (s *ReadSketchPhysSpec) Kind() plan.ProcedureKind {
+	return ReadSketchPhysKind
+}
+
+func (s *ReadSketchPhysSpec) Copy() plan.ProcedureSpec {
+	ns := new(ReadSketchPhysSpec)
+	ns.ReadGroupPhysSpec = *s.ReadGroupPhysSpec.Copy().(*ReadGroupPhysSpec)
+	ns.Sketch = s.Sketch
+	ns.Precision = s.Precision
+	ns.Compression = s.Compression
+	return ns
+}
+
+// ReadDistinctPhysSpec is the physical procedure for a ranged from(), optionally followed by group(),
+// and distinct() of a set of columns, which is computed by the StorageReader.
+type ReadDistinctPhysSpec struct {
+	ReadGroupPhysSpec
+	Columns []string
+}
+
+func (s *ReadDistinctPhysSpec) Kind() plan.ProcedureKind {
+	return ReadDistinctPhysKind
+}
+
+func (s *ReadDistinctPhysSpec) Copy() plan.ProcedureSpec {
+	ns := new(ReadDistinctPhysSpec)
+	ns.ReadGroupPhysSpec = *s.ReadGroupPhysSpec.Copy().(*ReadGroupPhysSpec)
+	ns.Columns = append([]string(nil), s.Columns...)
+	return ns
+}
+
+func storageReader(a execute.Administration) (StorageReader, error) {
+	reader, ok := a.Dependencies()[StorageDependencyKey].(StorageReader)
+	if !ok {
+		return nil, errors.New("no storage reader has been provided for from()")
+	}
+	return reader, nil
+}
+
+func createReadFilterSource(s plan.ProcedureSpec, id execute.DatasetID, a execute.Administration) (execute.Source, error) {
+	spec, ok := s.(*ReadRangePhysSpec)
+	if !ok {
+		return nil, fmt.Errorf("invalid spec type %T", s)
+	}
+	reader, err := storageReader(a)
+	if err != nil {
+		return nil, err
+	}
+	bounds := *a.StreamContext().Bounds()
+	return &storageSource{
+		id:     id,
+		bounds: bounds,
+		read: func(ctx context.Context) (flux.TableIterator, error) {
+			return reader.ReadFilter(ctx, spec.readFilterSpec(bounds), a.Allocator())
+		},
+	}, nil
+}
+
+func createReadGroupSource(s plan.ProcedureSpec, id execute.DatasetID, a execute.Administration) (execute.Source, error) {
+	spec, ok := s.(*ReadGroupPhysSpec)
+	if !ok {
+		return nil, fmt.Errorf("invalid spec type %T", s)
+	}
+	reader, err := storageReader(a)
+	if err != nil {
+		return nil, err
+	}
+	bounds := *a.StreamContext().Bounds()
+	return &storageSource{
+		id:     id,
+		bounds: bounds,
+		read: func(ctx context.Context) (flux.TableIterator, error) {
+			return reader.ReadGroup(ctx, ReadGroupSpec{
+				ReadFilterSpec: spec.readFilterSpec(bounds),
+				GroupMode:      spec.GroupMode,
+				GroupKeys:      spec.GroupKeys,
+			}, a.Allocator())
+		},
+	}, nil
+}
+
+func createReadWindowAggregateSource(s plan.ProcedureSpec, id execute.DatasetID, a execute.Administration) (execute.Source, error) {
+	spec, ok := s.(*ReadWindowAggregatePhysSpec)
+	if !ok {
+		return nil, fmt.Errorf("invalid spec type %T", s)
+	}
+	reader, err := storageReader(a)
+	if err != nil {
+		return nil, err
+	}
+	bounds := *a.StreamContext().Bounds()
+	return &storageSource{
+		id:     id,
+		bounds: bounds,
+		read: func(ctx context.Context) (flux.TableIterator, error) {
+			return reader.ReadWindowAggregate(ctx, ReadWindowAggregateSpec{
+				ReadFilterSpec: spec.readFilterSpec(bounds),
+				Window:         spec.Window,
+				CreateEmpty:    spec.CreateEmpty,
+				Aggregate:      spec.Aggregate,
+			}, a.Allocator())
+		},
+	}, nil
+}
+
+func createReadSketchSource(s plan.ProcedureSpec, id execute.DatasetID, a execute.Administration) (execute.Source, error) {
+	spec, ok := s.(*ReadSketchPhysSpec)
+	if !ok {
+		return nil, fmt.Errorf("invalid spec type %T", s)
+	}
+	r, err := storageReader(a)
+	if err != nil {
+		return nil, err
+	}
+	reader, ok := r.(SketchReader)
+	if !ok {
+		return nil, fmt.Errorf("storage reader %T does not read sketches", r)
+	}
+	bounds := *a.StreamContext().Bounds()
+	return &storageSource{
+		id:     id,
+		bounds: bounds,
+		read: func(ctx context.Context) (flux.TableIterator, error) {
+			return reader.ReadSketch(ctx, ReadSketchSpec{
+				ReadGroupSpec: ReadGroupSpec{
+					ReadFilterSpec: spec.readFilterSpec(bounds),
+					GroupMode:      spec.GroupMode,
+					GroupKeys:      spec.GroupKeys,
+				},
+				Sketch:      spec.Sketch,
+				Precision:   spec.Precision,
+				Compression: spec.Compression,
+			}, a.Allocator())
+		},
+	}, nil
+}
+
+func createReadDistinctSource(s plan.ProcedureSpec, id execute.DatasetID, a execute.Administration) (execute.Source, error) {
+	spec, ok := s.(*ReadDistinctPhysSpec)
+	if !ok {
+		return nil, fmt.Errorf("invalid spec type %T", s)
+	}
+	r, err := storageReader(a)
+	if err != nil {
+		return nil, err
+	}
+	reader, ok := r.(DistinctReader)
+	if !ok {
+		return nil, fmt.Errorf("storage reader %T does not read distinct values", r)
+	}
+	bounds := *a.StreamContext().Bounds()
+	return &storageSource{
+		id:     id,
+		bounds: bounds,
+		read: func(ctx context.Context) (flux.TableIterator, error) {
+			return reader.ReadDistinct(ctx, ReadDistinctSpec{
+				ReadGroupSpec: ReadGroupSpec{
+					ReadFilterSpec: spec.readFilterSpec(bounds),
+					GroupMode:      spec.GroupMode,
+					GroupKeys:      spec.GroupKeys,
+				},
+				Columns: spec.Columns,
+			}, a.Allocator())
+		},
+	}, nil
+}
+
+func createReadKeyValuesSource(s plan.ProcedureSpec, id execute.DatasetID, a execute.Administration) (execute.Source, error) {
+	spec, ok := s.(*ReadKeyValuesPhysSpec)
+	if !ok {
+		return nil, fmt.Errorf("invalid spec type %T", s)
+	}
+	client, err := GetMetaClient(a)
+	if err != nil {
+		return nil, err
+	}
+	bounds := *a.StreamContext().Bounds()
+	cols := []flux.ColMeta{
+		{Label: "_key", Type: flux.TString},
+		{Label: execute.DefaultValueColLabel, Type: flux.TString},
+	}
+	return NewMetaSource(id, a.Allocator(), cols, func(ctx context.Context) ([][]values.Value, error) {
+		var rows [][]values.Value
+		for _, key := range spec.KeyColumns {
+			vs, err := client.TagValues(ctx, TagValuesSpec{
+				ReadFilterSpec: spec.readFilterSpec(bounds),
+				Tag:            key,
+			})
+			if err != nil {
+				return nil, err
+			}
+			for _, v := range vs {
+				rows = append(rows, []values.Value{values.NewString(key), values.NewString(v)})
+			}
+		}
+		return rows, nil
+	}), nil
+}
+
+func createReadTagKeysSource(s plan.ProcedureSpec, id execute.DatasetID, a execute.Administration) (execute.Source, error) {
+	spec, ok := s.(*ReadTagKeysPhysSpec)
+	if !ok {
+		return nil, fmt.Errorf("invalid spec type %T", s)
+	}
+	client, err := GetMetaClient(a)
+	if err != nil {
+		return nil, err
+	}
+	bounds := *a.StreamContext().Bounds()
+	return NewMetaSource(id, a.Allocator(), tagCols, func(ctx context.Context) ([][]values.Value, error) {
+		return stringRows(client.TagKeys(ctx, spec.readFilterSpec(bounds)))
+	}), nil
+}
+
+func createReadTagValuesSource(s plan.ProcedureSpec, id execute.DatasetID, a execute.Administration) (execute.Source, error) {
+	spec, ok := s.(*ReadTagValuesPhysSpec)
+	if !ok {
+		return nil, fmt.Errorf("invalid spec type %T", s)
+	}
+	client, err := GetMetaClient(a)
+	if err != nil {
+		return nil, err
+	}
+	bounds := *a.StreamContext().Bounds()
+	return NewMetaSource(id, a.Allocator(), tagCols, func(ctx context.Context) ([][]values.Value, error) {
+		return stringRows(client.TagValues(ctx, TagValuesSpec{
+			ReadFilterSpec: spec.readFilterSpec(bounds),
+			Tag:            spec.Tag,
+		}))
+	}), nil
+}
+
+var tagCols = []flux.ColMeta{
+	{Label: execute.DefaultValueColLabel, Type: flux.TString},
+}
+
+func stringRows(vs []string, err error) ([][]values.Value, error) {
+	if err != nil {
+		return nil, err
+	}
+	rows := make([][]values.Value, len(vs))
+	for i, v := range vs {
+		rows[i] = []values.Value{values.NewString(v)}
+	}
+	return rows, nil
+}
+
+// storageSource is the source for every read from a StorageReader.
+// It passes the tables returned by the read to its transformations.
+type storageSource struct {
+	id     execute.DatasetID
+	ts     []execute.Transformation
+	bounds execute.Bounds
+	read   func(ctx context.Context) (flux.TableIterator, error)
+}
+
+func (s *storageSource) AddTransformation(t execute.Transformation) {
+	s.ts = append(s.ts, t)
+}
+
+func (s *storageSource) Run(ctx context.Context) {
+	tables, err := s.read(ctx)
+	if err == nil {
+		err = tables.Do(func(tbl flux.Table) error {
+			for _, t := range s.ts {
+				if err := t.Process(s.id, tbl); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	}
+	if err == nil {
+		for _, t := range s.ts {
+			if err = t.UpdateWatermark(s.id, s.bounds.Stop); err != nil {
+				break
+			}
+		}
+	}
+	for _, t := range s.ts {
+		t.Finish(s.id, err)
+	}
+}
@@ -0,0 +1,422 @@
+package influxdb_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/flux/memory"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/sketch"
+	"github.com/influxdata/flux/stdlib/influxdata/influxdb"
+	"github.com/influxdata/flux/stdlib/universe"
+	"github.com/influxdata/flux/values"
+)
+
+type mockStorageReader struct {
+	caps   influxdb.StorageCapabilities
+	tables []*executetest.Table
+	reads  []interface{}
+}
+
+func (r *mockStorageReader) Capabilities() influxdb.StorageCapabilities {
+	return r.caps
+}
+
+func (r *mockStorageReader) ReadFilter(ctx context.Context, spec influxdb.ReadFilterSpec, alloc *memory.Allocator) (flux.TableIterator, error) {
+	r.reads = append(r.reads, spec)
+	return (&executetest.Result{Tbls: r.tables}).Tables(), nil
+}
+
+func (r *mockStorageReader) ReadGroup(ctx context.Context, spec influxdb.ReadGroupSpec, alloc *memory.Allocator) (flux.TableIterator, error) {
+	r.reads = append(r.reads, spec)
+	return (&executetest.Result{Tbls: r.tables}).Tables(), nil
+}
+
+func (r *mockStorageReader) ReadWindowAggregate(ctx context.Context, spec influxdb.ReadWindowAggregateSpec, alloc *memory.Allocator) (flux.TableIterator, error) {
+	r.reads = append(r.reads, spec)
+	return (&executetest.Result{Tbls: r.tables}).Tables(), nil
+}
+
+func (r *mockStorageReader) ReadSketch(ctx context.Context, spec influxdb.ReadSketchSpec, alloc *memory.Allocator) (flux.TableIterator, error) {
+	r.reads = append(r.reads, spec)
+	return (&executetest.Result{Tbls: r.tables}).Tables(), nil
+}
+
+func (r *mockStorageReader) ReadDistinct(ctx context.Context, spec influxdb.ReadDistinctSpec, alloc *memory.Allocator) (flux.TableIterator, error) {
+	r.reads = append(r.reads, spec)
+	return (&executetest.Result{Tbls: r.tables}).Tables(), nil
+}
+
+// basicStorageReader only implements StorageReader, without the optional interfaces.
+type basicStorageReader struct {
+	caps influxdb.StorageCapabilities
+}
+
+func (r basicStorageReader) Capabilities() influxdb.StorageCapabilities {
+	return r.caps
+}
+
+func (basicStorageReader) ReadFilter(ctx context.Context, spec influxdb.ReadFilterSpec, alloc *memory.Allocator) (flux.TableIterator, error) {
+	return (&executetest.Result{}).Tables(), nil
+}
+
+func (basicStorageReader) ReadGroup(ctx context.Context, spec influxdb.ReadGroupSpec, alloc *memory.Allocator) (flux.TableIterator, error) {
+	return (&executetest.Result{}).Tables(), nil
+}
+
+func (basicStorageReader) ReadWindowAggregate(ctx context.Context, spec influxdb.ReadWindowAggregateSpec, alloc *memory.Allocator) (flux.TableIterator, error) {
+	return (&executetest.Result{}).Tables(), nil
+}
+
+func TestReaderCapabilities(t *testing.T) {
+	caps := influxdb.StorageCapabilities{Group: true, Sketches: true, Distinct: true}
+	if got := influxdb.ReaderCapabilities(&mockStorageReader{caps: caps}); got != caps {
+		t.Errorf("unexpected capabilities of a reader with the optional interfaces: %+v", got)
+	}
+	want := influxdb.StorageCapabilities{Group: true}
+	if got := influxdb.ReaderCapabilities(basicStorageReader{caps: caps}); got != want {
+		t.Errorf("unexpected capabilities of a reader without the optional interfaces: %+v", got)
+	}
+}
+
+func TestStorageReader(t *testing.T) {
+	now := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	bounds := execute.Bounds{
+		Start: execute.Time(now.Add(-time.Hour).UnixNano()),
+		Stop:  execute.Time(now.UnixNano()),
+	}
+	table := &executetest.Table{
+		KeyCols: []string{"_start", "_stop", "_measurement", "_field"},
+		ColMeta: []flux.ColMeta{
+			{Label: "_start", Type: flux.TTime},
+			{Label: "_stop", Type: flux.TTime},
+			{Label: "_time", Type: flux.TTime},
+			{Label: "_measurement", Type: flux.TString},
+			{Label: "_field", Type: flux.TString},
+			{Label: "_value", Type: flux.TFloat},
+		},
+		Data: [][]interface{}{
+			{bounds.Start, bounds.Stop, bounds.Start + 1, "cpu", "usage", 1.0},
+		},
+	}
+
+	testCases := []struct {
+		name  string
+		query string
+		caps  influxdb.StorageCapabilities
+		want  []interface{}
+	}{
+		{
+			name:  "read filter",
+			query: `from(bucket: "my_bucket") |> range(start: -1h)`,
+			want: []interface{}{
+				influxdb.ReadFilterSpec{
+					Bucket: "my_bucket",
+					Bounds: bounds,
+				},
+			},
+		},
+		{
+			name:  "read selector-limited",
+			query: `from(bucket: "my_bucket") |> range(start: -1h) |> last()`,
+			caps:  influxdb.StorageCapabilities{OrderedReads: true},
+			want: []interface{}{
+				influxdb.ReadFilterSpec{
+					Bucket:   "my_bucket",
+					Bounds:   bounds,
+					Selector: universe.LastKind,
+				},
+			},
+		},
+		{
+			name:  "read group",
+			query: `from(bucket: "my_bucket") |> range(start: -1h) |> group(columns: ["_measurement"])`,
+			caps:  influxdb.StorageCapabilities{Group: true},
+			want: []interface{}{
+				influxdb.ReadGroupSpec{
+					ReadFilterSpec: influxdb.ReadFilterSpec{
+						Bucket: "my_bucket",
+						Bounds: bounds,
+					},
+					GroupMode: flux.GroupModeBy,
+					GroupKeys: []string{"_measurement"},
+				},
+			},
+		},
+		{
+			name:  "read window aggregate",
+			query: `from(bucket: "my_bucket") |> range(start: -1h) |> window(every: 1m) |> max()`,
+			caps:  influxdb.StorageCapabilities{WindowAggregate: true},
+			want: []interface{}{
+				influxdb.ReadWindowAggregateSpec{
+					ReadFilterSpec: influxdb.ReadFilterSpec{
+						Bucket: "my_bucket",
+						Bounds: bounds,
+					},
+					Window: plan.WindowSpec{
+						Every:  values.ConvertDuration(time.Minute),
+						Period: values.ConvertDuration(time.Minute),
+					},
+					Aggregate: universe.MaxKind,
+				},
+			},
+		},
+		{
+			name:  "read distinct",
+			query: `from(bucket: "my_bucket") |> range(start: -1h) |> distinct(columns: ["_measurement", "_field"])`,
+			caps:  influxdb.StorageCapabilities{Distinct: true},
+			want: []interface{}{
+				influxdb.ReadDistinctSpec{
+					ReadGroupSpec: influxdb.ReadGroupSpec{
+						ReadFilterSpec: influxdb.ReadFilterSpec{
+							Bucket: "my_bucket",
+							Bounds: bounds,
+						},
+					},
+					Columns: []string{"_measurement", "_field"},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			reader := &mockStorageReader{caps: tc.caps, tables: []*executetest.Table{table}}
+
+			got := executeQuery(t, tc.query, now, execute.Dependencies{
+				influxdb.StorageDependencyKey: reader,
+			}, plan.AddPhysicalRules(influxdb.PushDownRules(influxdb.ReaderCapabilities(reader))...))
+
+			if !cmp.Equal(tc.want, reader.reads) {
+				t.Errorf("unexpected reads -want/+got\n%s", cmp.Diff(tc.want, reader.reads))
+			}
+			want := []*executetest.Table{table}
+			executetest.NormalizeTables(want)
+			if !cmp.Equal(want, got) {
+				t.Errorf("unexpected tables -want/+got\n%s", cmp.Diff(want, got))
+			}
+		})
+	}
+}
+
+func TestStorageReader_ReadSketch(t *testing.T) {
+	now := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	bounds := execute.Bounds{
+		Start: execute.Time(now.Add(-time.Hour).UnixNano()),
+		Stop:  execute.Time(now.UnixNano()),
+	}
+	// Each shard returns a sketch of its values.
+	hll := func(vs ...string) string {
+		h, err := sketch.NewHyperLogLog(sketch.DefaultPrecision)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, v := range vs {
+			h.Add([]byte(v))
+		}
+		data, err := h.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(data)
+	}
+	tdigest := func(vs ...float64) string {
+		td := sketch.NewTDigest(1000)
+		for _, v := range vs {
+			td.Add(v, 1)
+		}
+		data, err := td.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(data)
+	}
+	sketches := func(host string, shards ...string) *executetest.Table {
+		// The key values are set explicitly, since a shard may have no sketches.
+		table := &executetest.Table{
+			KeyCols:   []string{"_start", "_stop", "host"},
+			KeyValues: []interface{}{bounds.Start, bounds.Stop, host},
+			ColMeta: []flux.ColMeta{
+				{Label: "_start", Type: flux.TTime},
+				{Label: "_stop", Type: flux.TTime},
+				{Label: "host", Type: flux.TString},
+				{Label: "_value", Type: flux.TString},
+			},
+		}
+		for _, s := range shards {
+			table.Data = append(table.Data, []interface{}{bounds.Start, bounds.Stop, host, s})
+		}
+		return table
+	}
+	readSpec := influxdb.ReadGroupSpec{
+		ReadFilterSpec: influxdb.ReadFilterSpec{
+			Bucket: "my_bucket",
+			Bounds: bounds,
+		},
+		GroupMode: flux.GroupModeBy,
+		GroupKeys: []string{"host"},
+	}
+
+	testCases := []struct {
+		name   string
+		query  string
+		tables []*executetest.Table
+		want   []interface{}
+		result []*executetest.Table
+	}{
+		{
+			name:  "approximate distinct",
+			query: `from(bucket: "my_bucket") |> range(start: -1h) |> group(columns: ["host"]) |> approxDistinct()`,
+			tables: []*executetest.Table{
+				sketches("a", hll("x", "y"), hll("y", "z")),
+				sketches("b"),
+			},
+			want: []interface{}{
+				influxdb.ReadSketchSpec{
+					ReadGroupSpec: readSpec,
+					Sketch:        sketch.HyperLogLogKind,
+					Precision:     sketch.DefaultPrecision,
+				},
+			},
+			result: []*executetest.Table{
+				{
+					KeyCols: []string{"_start", "_stop", "host"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_start", Type: flux.TTime},
+						{Label: "_stop", Type: flux.TTime},
+						{Label: "host", Type: flux.TString},
+						{Label: "_value", Type: flux.TInt},
+					},
+					Data: [][]interface{}{
+						{bounds.Start, bounds.Stop, "a", int64(3)},
+					},
+				},
+				{
+					KeyCols: []string{"_start", "_stop", "host"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_start", Type: flux.TTime},
+						{Label: "_stop", Type: flux.TTime},
+						{Label: "host", Type: flux.TString},
+						{Label: "_value", Type: flux.TInt},
+					},
+					Data: [][]interface{}{
+						{bounds.Start, bounds.Stop, "b", int64(0)},
+					},
+				},
+			},
+		},
+		{
+			name:  "percentile",
+			query: `from(bucket: "my_bucket") |> range(start: -1h) |> group(columns: ["host"]) |> percentile(percentile: 0.5, method: "estimate_tdigest")`,
+			tables: []*executetest.Table{
+				sketches("a", tdigest(1, 2), tdigest(3)),
+				sketches("b", tdigest()),
+			},
+			want: []interface{}{
+				influxdb.ReadSketchSpec{
+					ReadGroupSpec: readSpec,
+					Sketch:        sketch.TDigestKind,
+					Compression:   1000,
+				},
+			},
+			result: []*executetest.Table{
+				{
+					KeyCols: []string{"_start", "_stop", "host"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_start", Type: flux.TTime},
+						{Label: "_stop", Type: flux.TTime},
+						{Label: "host", Type: flux.TString},
+						{Label: "_value", Type: flux.TFloat},
+					},
+					Data: [][]interface{}{
+						{bounds.Start, bounds.Stop, "a", 2.0},
+					},
+				},
+				{
+					KeyCols: []string{"_start", "_stop", "host"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_start", Type: flux.TTime},
+						{Label: "_stop", Type: flux.TTime},
+						{Label: "host", Type: flux.TString},
+						{Label: "_value", Type: flux.TFloat},
+					},
+					Data: [][]interface{}{
+						{bounds.Start, bounds.Stop, "b", nil},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			reader := &mockStorageReader{
+				caps:   influxdb.StorageCapabilities{Group: true, Sketches: true},
+				tables: tc.tables,
+			}
+
+			got := executeQuery(t, tc.query, now, execute.Dependencies{
+				influxdb.StorageDependencyKey: reader,
+			}, plan.AddPhysicalRules(influxdb.PushDownRules(influxdb.ReaderCapabilities(reader))...))
+
+			if !cmp.Equal(tc.want, reader.reads) {
+				t.Errorf("unexpected reads -want/+got\n%s", cmp.Diff(tc.want, reader.reads))
+			}
+			executetest.NormalizeTables(tc.result)
+			if !cmp.Equal(tc.result, got) {
+				t.Errorf("unexpected tables -want/+got\n%s", cmp.Diff(tc.result, got))
+			}
+		})
+	}
+}
+
+// executeQuery compiles, plans and executes a query with the given dependencies
+// and returns the tables of all of its results.
+func executeQuery(t *testing.T, query string, now time.Time, deps execute.Dependencies, opts ...plan.PhysicalOption) []*executetest.Table {
+	t.Helper()
+
+	spec, err := flux.Compile(context.Background(), query, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lp := plan.NewLogicalPlanner()
+	initPlan, err := lp.CreateInitialPlan(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	logicalPlan, err := lp.Plan(initPlan)
+	if err != nil {
+		t.Fatal(err)
+	}
+	physicalPlan, err := plan.NewPhysicalPlanner(opts...).Plan(logicalPlan)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	results, err := execute.NewExecutor(deps, nil).Execute(context.Background(), physicalPlan, executetest.UnlimitedAllocator)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []*executetest.Table
+	for _, r := range results {
+		if err := r.Tables().Do(func(tbl flux.Table) error {
+			ct, err := executetest.ConvertTable(tbl)
+			if err != nil {
+				return err
+			}
+			got = append(got, ct)
+			return nil
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	executetest.NormalizeTables(got)
+	return got
+}
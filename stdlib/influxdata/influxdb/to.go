@@ -1,13 +1,37 @@
 package influxdb
 
 import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
 	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/dependencies"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/interpreter"
+	"github.com/influxdata/flux/iocounter"
+	"github.com/influxdata/flux/plan"
 	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/flux/values"
+	"github.com/pkg/errors"
 )
 
 // ToKind is the kind for the `to` flux function
 const ToKind = "to"
 
+const (
+	// DefaultToBatchSize is the number of points buffered before they are written.
+	DefaultToBatchSize = 5000
+	// DefaultToTimeout is the timeout for a single write request.
+	DefaultToTimeout = 10 * time.Second
+
+	defaultFieldColLabel       = "_field"
+	defaultMeasurementColLabel = "_measurement"
+)
+
 var ToSignature = flux.FunctionSignature(
 	map[string]semantic.PolyType{
 		"bucket":            semantic.String,
@@ -18,7 +42,7 @@ var ToSignature = flux.FunctionSignature(
 		"token":             semantic.String,
 		"timeColumn":        semantic.String,
 		"measurementColumn": semantic.String,
-		"tagColumns":        semantic.Array,
+		"tagColumns":        semantic.NewArrayPolyType(semantic.String),
 		"fieldFn": semantic.NewFunctionPolyType(semantic.FunctionPolySignature{
 			Parameters: map[string]semantic.PolyType{
 				"r": semantic.Tvar(1),
@@ -26,10 +50,516 @@ var ToSignature = flux.FunctionSignature(
 			Required: semantic.LabelSet{"r"},
 			Return:   semantic.Tvar(2),
 		}),
+		"batchSize": semantic.Int,
 	},
 	[]string{},
 )
 
 func init() {
-	flux.RegisterPackageValue("influxdata/influxdb", ToKind, flux.FunctionValueWithSideEffect(ToKind, nil, ToSignature))
+	flux.RegisterPackageValue("influxdata/influxdb", ToKind, flux.FunctionValueWithSideEffect(ToKind, createToOpSpec, ToSignature))
+	flux.RegisterOpSpec(ToKind, func() flux.OperationSpec { return &ToOpSpec{} })
+	plan.RegisterProcedureSpecWithSideEffect(ToKind, newToProcedure, ToKind)
+	execute.RegisterTransformation(ToKind, createToTransformation)
+}
+
+// DefaultToUserAgent is the user agent used by to when writing to a remote InfluxDB.
+var DefaultToUserAgent = "fluxd/dev"
+
+// ToOpSpec is the operation spec for the `to` function.
+// It converts the rows of tables into points and writes them to the InfluxDB v2 HTTP API located at Host,
+// or with the PointsWriter of the dependencies when no Host is given.
+type ToOpSpec struct {
+	Bucket            string                       `json:"bucket"`
+	BucketID          string                       `json:"bucketID"`
+	Org               string                       `json:"org"`
+	OrgID             string                       `json:"orgID"`
+	Host              string                       `json:"host"`
+	Token             string                       `json:"token"`
+	TimeColumn        string                       `json:"timeColumn"`
+	MeasurementColumn string                       `json:"measurementColumn"`
+	TagColumns        []string                     `json:"tagColumns"`
+	FieldFn           *semantic.FunctionExpression `json:"fieldFn"`
+	BatchSize         int                          `json:"batchSize"`
+}
+
+// ReadArgs loads a flux.Arguments into ToOpSpec.
+// If the timeColumn isn't set, it defaults to execute.DefaultTimeColLabel.
+// If the measurementColumn isn't set, it defaults to "_measurement".
+// If tagColumns isn't set, every string column that is not otherwise used is a tag.
+// If fieldFn isn't set, the field key is read from "_field" and the field value from "_value".
+func (o *ToOpSpec) ReadArgs(args flux.Arguments) error {
+	var err error
+	var ok bool
+
+	if o.Bucket, ok, err = args.GetString("bucket"); err != nil {
+		return err
+	}
+	var okID bool
+	if o.BucketID, okID, err = args.GetString("bucketID"); err != nil {
+		return err
+	}
+	if ok == okID {
+		return errors.New("exactly one of bucket or bucketID must be specified")
+	}
+
+	if o.Org, ok, err = args.GetString("org"); err != nil {
+		return err
+	}
+	if o.OrgID, okID, err = args.GetString("orgID"); err != nil {
+		return err
+	}
+	if ok && okID {
+		return errors.New("at most one of org or orgID may be specified")
+	}
+
+	if o.Host, ok, err = args.GetString("host"); err != nil {
+		return err
+	} else if ok {
+		if o.Token, err = args.GetRequiredString("token"); err != nil {
+			return err
+		}
+	}
+
+	if o.TimeColumn, ok, err = args.GetString("timeColumn"); err != nil {
+		return err
+	} else if !ok {
+		o.TimeColumn = execute.DefaultTimeColLabel
+	}
+
+	if o.MeasurementColumn, ok, err = args.GetString("measurementColumn"); err != nil {
+		return err
+	} else if !ok {
+		o.MeasurementColumn = defaultMeasurementColLabel
+	}
+
+	tagColumns, ok, err := args.GetArray("tagColumns", semantic.String)
+	if err != nil {
+		return err
+	}
+	o.TagColumns = o.TagColumns[:0]
+	if ok {
+		for i := 0; i < tagColumns.Len(); i++ {
+			o.TagColumns = append(o.TagColumns, tagColumns.Get(i).Str())
+		}
+		sort.Strings(o.TagColumns)
+	}
+
+	if f, ok, err := args.GetFunction("fieldFn"); err != nil {
+		return err
+	} else if ok {
+		fn, err := interpreter.ResolveFunction(f)
+		if err != nil {
+			return err
+		}
+		o.FieldFn = fn
+	}
+
+	if batchSize, ok, err := args.GetInt("batchSize"); err != nil {
+		return err
+	} else if ok {
+		if batchSize <= 0 {
+			return errors.New("batchSize must be greater than zero")
+		}
+		o.BatchSize = int(batchSize)
+	} else {
+		o.BatchSize = DefaultToBatchSize
+	}
+	return nil
+}
+
+func createToOpSpec(args flux.Arguments, a *flux.Administration) (flux.OperationSpec, error) {
+	if err := a.AddParentFromArgs(args); err != nil {
+		return nil, err
+	}
+	s := new(ToOpSpec)
+	if err := s.ReadArgs(args); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (ToOpSpec) Kind() flux.OperationKind {
+	return ToKind
+}
+
+func (o *ToOpSpec) Resources() []flux.Resource {
+	bucket := o.Bucket
+	if bucket == "" {
+		bucket = o.BucketID
+	}
+	resources := []flux.Resource{{Kind: flux.BucketResource, Name: bucket, Mode: flux.WriteAccess}}
+	if o.Host != "" {
+		resources = append(resources, flux.Resource{Kind: flux.HostResource, Name: o.Host, Mode: flux.WriteAccess})
+	}
+	return resources
+}
+
+// WriteURL returns the URL of the write endpoint for the spec.
+func (o *ToOpSpec) WriteURL() (string, error) {
+	u, err := url.Parse(o.Host)
+	if err != nil {
+		return "", err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return "", fmt.Errorf("scheme must be http or https but was %q", u.Scheme)
+	}
+	u.Path = strings.TrimSuffix(u.Path, "/") + "/api/v2/write"
+
+	params := url.Values{}
+	if o.Bucket != "" {
+		params.Set("bucket", o.Bucket)
+	} else {
+		params.Set("bucket", o.BucketID)
+	}
+	if o.Org != "" {
+		params.Set("org", o.Org)
+	} else if o.OrgID != "" {
+		params.Set("orgID", o.OrgID)
+	}
+	params.Set("precision", "ns")
+	u.RawQuery = params.Encode()
+	return u.String(), nil
+}
+
+type ToProcedureSpec struct {
+	plan.DefaultCost
+	Spec *ToOpSpec
+}
+
+func (o *ToProcedureSpec) Kind() plan.ProcedureKind {
+	return ToKind
+}
+
+func (o *ToProcedureSpec) Copy() plan.ProcedureSpec {
+	s := o.Spec
+	res := &ToProcedureSpec{
+		Spec: &ToOpSpec{
+			Bucket:            s.Bucket,
+			BucketID:          s.BucketID,
+			Org:               s.Org,
+			OrgID:             s.OrgID,
+			Host:              s.Host,
+			Token:             s.Token,
+			TimeColumn:        s.TimeColumn,
+			MeasurementColumn: s.MeasurementColumn,
+			TagColumns:        append([]string(nil), s.TagColumns...),
+			BatchSize:         s.BatchSize,
+		},
+	}
+	if s.FieldFn != nil {
+		res.Spec.FieldFn = s.FieldFn.Copy().(*semantic.FunctionExpression)
+	}
+	return res
+}
+
+func newToProcedure(qs flux.OperationSpec, a plan.Administration) (plan.ProcedureSpec, error) {
+	spec, ok := qs.(*ToOpSpec)
+	if !ok {
+		return nil, fmt.Errorf("invalid spec type %T", qs)
+	}
+	return &ToProcedureSpec{Spec: spec}, nil
+}
+
+func createToTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	s, ok := spec.(*ToProcedureSpec)
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid spec type %T", spec)
+	}
+	cache := execute.NewTableBuilderCache(a.Allocator())
+	d := execute.NewDataset(id, mode, cache)
+	t, err := NewToTransformation(a.Context(), d, cache, s)
+	if err != nil {
+		return nil, nil, err
+	}
+	return t, d, nil
+}
+
+// ToTransformation writes the tables it processes as points and passes them through unchanged.
+// Points are buffered and written in batches.
+type ToTransformation struct {
+	ctx   context.Context
+	d     execute.Dataset
+	cache execute.TableBuilderCache
+	spec  *ToProcedureSpec
+
+	writer dependencies.PointsWriter
+	dest   dependencies.WriteDestination
+	fn     *execute.RowMapFn
+	points []dependencies.Point
+}
+
+// NewToTransformation creates a transformation that writes the tables it processes.
+// When the spec has a host, the points are written to its HTTP API
+// using the HTTP client of the dependencies in ctx.
+// Otherwise they are written with the PointsWriter of the dependencies in ctx.
+func NewToTransformation(ctx context.Context, d execute.Dataset, cache execute.TableBuilderCache, spec *ToProcedureSpec) (*ToTransformation, error) {
+	var writer dependencies.PointsWriter
+	if spec.Spec.Host != "" {
+		w, err := newHTTPPointsWriter(spec.Spec)
+		if err != nil {
+			return nil, err
+		}
+		writer = w
+	} else {
+		writer = dependencies.Get(ctx).PointsWriter
+	}
+	t := &ToTransformation{
+		ctx:    ctx,
+		d:      d,
+		cache:  cache,
+		spec:   spec,
+		writer: writer,
+		dest: dependencies.WriteDestination{
+			Org:      spec.Spec.Org,
+			OrgID:    spec.Spec.OrgID,
+			Bucket:   spec.Spec.Bucket,
+			BucketID: spec.Spec.BucketID,
+		},
+	}
+	if spec.Spec.FieldFn != nil {
+		fn, err := execute.NewRowMapFn(spec.Spec.FieldFn)
+		if err != nil {
+			return nil, err
+		}
+		t.fn = fn
+	}
+	return t, nil
+}
+
+func (t *ToTransformation) RetractTable(id execute.DatasetID, key flux.GroupKey) error {
+	return t.d.RetractTable(key)
+}
+
+// toColumns records the role each column of a table plays when it is encoded.
+type toColumns struct {
+	time        int
+	measurement int
+	field       int
+	value       int
+	tags        []int
+}
+
+func (t *ToTransformation) columns(tbl flux.Table) (toColumns, error) {
+	spec := t.spec.Spec
+	cols := tbl.Cols()
+	tc := toColumns{
+		time:        execute.ColIdx(spec.TimeColumn, cols),
+		measurement: execute.ColIdx(spec.MeasurementColumn, cols),
+		field:       -1,
+		value:       -1,
+	}
+	if tc.time < 0 {
+		return tc, fmt.Errorf("no time column %q", spec.TimeColumn)
+	} else if cols[tc.time].Type != flux.TTime {
+		return tc, fmt.Errorf("column %s is not of type %s", spec.TimeColumn, flux.TTime)
+	}
+	if tc.measurement < 0 {
+		return tc, fmt.Errorf("no measurement column %q", spec.MeasurementColumn)
+	} else if cols[tc.measurement].Type != flux.TString {
+		return tc, fmt.Errorf("column %s is not of type %s", spec.MeasurementColumn, flux.TString)
+	}
+	if t.fn == nil {
+		tc.field = execute.ColIdx(defaultFieldColLabel, cols)
+		if tc.field < 0 {
+			return tc, fmt.Errorf("no field column %q", defaultFieldColLabel)
+		} else if cols[tc.field].Type != flux.TString {
+			return tc, fmt.Errorf("column %s is not of type %s", defaultFieldColLabel, flux.TString)
+		}
+		tc.value = execute.ColIdx(execute.DefaultValueColLabel, cols)
+		if tc.value < 0 {
+			return tc, fmt.Errorf("no value column %q", execute.DefaultValueColLabel)
+		}
+	}
+
+	if len(spec.TagColumns) > 0 {
+		for _, label := range spec.TagColumns {
+			j := execute.ColIdx(label, cols)
+			if j < 0 {
+				return tc, fmt.Errorf("no tag column %q", label)
+			}
+			if cols[j].Type != flux.TString {
+				return tc, fmt.Errorf("invalid type for tag column %s", label)
+			}
+			tc.tags = append(tc.tags, j)
+		}
+		return tc, nil
+	}
+	for j, c := range cols {
+		if c.Type != flux.TString || j == tc.measurement || j == tc.field || j == tc.value {
+			continue
+		}
+		tc.tags = append(tc.tags, j)
+	}
+	return tc, nil
+}
+
+func (t *ToTransformation) Process(id execute.DatasetID, tbl flux.Table) error {
+	tc, err := t.columns(tbl)
+	if err != nil {
+		return err
+	}
+	if t.fn != nil {
+		if err := t.fn.Prepare(tbl.Cols()); err != nil {
+			return err
+		}
+	}
+
+	builder, created := t.cache.TableBuilder(tbl.Key())
+	if created {
+		if err := execute.AddTableCols(tbl, builder); err != nil {
+			return err
+		}
+	}
+
+	return tbl.Do(func(cr flux.ColReader) error {
+		for i := 0; i < cr.Len(); i++ {
+			if err := t.writeRecord(tc, i, cr); err != nil {
+				return err
+			}
+			if err := execute.AppendRecord(i, cr, builder); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// writeRecord converts a single row into a point and buffers it.
+// Rows without a time, a measurement or any non-null field are skipped.
+func (t *ToTransformation) writeRecord(tc toColumns, i int, cr flux.ColReader) error {
+	if cr.Times(tc.time).IsNull(i) || cr.Strings(tc.measurement).IsNull(i) {
+		return nil
+	}
+	p := dependencies.Point{
+		Measurement: cr.Strings(tc.measurement).ValueString(i),
+		Time:        values.Time(cr.Times(tc.time).Value(i)).Time(),
+	}
+
+	for _, j := range tc.tags {
+		if cr.Strings(j).IsNull(i) {
+			continue
+		}
+		p.Tags = append(p.Tags, dependencies.Tag{Key: cr.Cols()[j].Label, Value: cr.Strings(j).ValueString(i)})
+	}
+	sort.Slice(p.Tags, func(i, j int) bool {
+		return p.Tags[i].Key < p.Tags[j].Key
+	})
+
+	if t.fn == nil {
+		if cr.Strings(tc.field).IsNull(i) {
+			return nil
+		}
+		v, err := fieldValue(execute.ValueForRow(cr, i, tc.value))
+		if err != nil {
+			return err
+		}
+		if v != nil {
+			p.Fields = append(p.Fields, dependencies.Field{Key: cr.Strings(tc.field).ValueString(i), Value: v})
+		}
+	} else {
+		obj, err := t.fn.Eval(i, cr)
+		if err != nil {
+			return err
+		}
+		obj.Range(func(k string, v values.Value) {
+			if err != nil {
+				return
+			}
+			var fv interface{}
+			if fv, err = fieldValue(v); err == nil && fv != nil {
+				p.Fields = append(p.Fields, dependencies.Field{Key: k, Value: fv})
+			}
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if len(p.Fields) == 0 {
+		return nil
+	}
+
+	t.points = append(t.points, p)
+	if len(t.points) >= t.spec.Spec.BatchSize {
+		return t.flush()
+	}
+	return nil
+}
+
+// fieldValue converts a value into the value of a field.
+// Null values produce a nil result.
+func fieldValue(v values.Value) (interface{}, error) {
+	if v.IsNull() {
+		return nil, nil
+	}
+	switch v.Type().Nature() {
+	case semantic.Int:
+		return v.Int(), nil
+	case semantic.UInt:
+		return v.UInt(), nil
+	case semantic.Float:
+		return v.Float(), nil
+	case semantic.String:
+		return v.Str(), nil
+	case semantic.Bool:
+		return v.Bool(), nil
+	case semantic.Time:
+		return int64(v.Time()), nil
+	default:
+		return nil, fmt.Errorf("unsupported field type %v", v.Type())
+	}
+}
+
+// flush writes all buffered points in a single batch, and records the write.
+// The bytes of the write are only known if the writer counts the bytes it sends.
+func (t *ToTransformation) flush() error {
+	if len(t.points) == 0 {
+		return nil
+	}
+	write := flux.Write{
+		Function:    "to",
+		Destination: t.dest.String(),
+		Rows:        int64(len(t.points)),
+	}
+	if t.spec.Spec.Host != "" {
+		write.Destination = strings.TrimSuffix(t.spec.Spec.Host, "/") + "/" + write.Destination
+	}
+	counter, counts := t.writer.(iocounter.Counter)
+	var sent int64
+	if counts {
+		sent = counter.Count()
+	}
+	start := time.Now()
+	err := t.writer.WritePoints(t.ctx, t.dest, t.points)
+	write.Duration, write.Status = time.Since(start), "ok"
+	if counts {
+		write.Bytes = counter.Count() - sent
+	}
+	if perr, ok := err.(*dependencies.PartialWriteError); ok {
+		// The points that were accepted are written, the rejected ones are
+		// reported with the write and the query goes on.
+		write.Rows -= int64(len(perr.Errors))
+		write.Rejected = int64(len(perr.Errors))
+		write.Status, write.Error = "partial", err.Error()
+		err = nil
+	} else if err != nil {
+		write.Status, write.Error = "failed", err.Error()
+	}
+	execute.RecordWrite(t.ctx, write)
+	t.points = t.points[:0]
+	return err
+}
+
+func (t *ToTransformation) UpdateWatermark(id execute.DatasetID, pt execute.Time) error {
+	return t.d.UpdateWatermark(pt)
+}
+
+func (t *ToTransformation) UpdateProcessingTime(id execute.DatasetID, pt execute.Time) error {
+	return t.d.UpdateProcessingTime(pt)
+}
+
+func (t *ToTransformation) Finish(id execute.DatasetID, err error) {
+	if err == nil {
+		err = t.flush()
+	}
+	t.d.Finish(err)
 }
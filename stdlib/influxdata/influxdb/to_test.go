@@ -0,0 +1,404 @@
+package influxdb_test
+
+import (
+	"compress/gzip"
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/dependencies"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/flux/querytest"
+	"github.com/influxdata/flux/stdlib/influxdata/influxdb"
+)
+
+func TestTo_NewQuery(t *testing.T) {
+	tests := []querytest.NewQueryTestCase{
+		{
+			Name: "from with to",
+			Raw:  `from(bucket:"mydb") |> to(bucket:"series1", org:"myorg", host:"http://localhost:9999", token:"mytoken")`,
+			Want: &flux.Spec{
+				Operations: []*flux.Operation{
+					{
+						ID: "from0",
+						Spec: &influxdb.FromOpSpec{
+							Bucket: "mydb",
+						},
+					},
+					{
+						ID: "to1",
+						Spec: &influxdb.ToOpSpec{
+							Bucket:            "series1",
+							Org:               "myorg",
+							Host:              "http://localhost:9999",
+							Token:             "mytoken",
+							TimeColumn:        execute.DefaultTimeColLabel,
+							MeasurementColumn: "_measurement",
+							BatchSize:         influxdb.DefaultToBatchSize,
+						},
+					},
+				},
+				Edges: []flux.Edge{
+					{Parent: "from0", Child: "to1"},
+				},
+			},
+		},
+		{
+			Name: "to without host",
+			Raw:  `from(bucket:"mydb") |> to(bucket:"series1")`,
+			Want: &flux.Spec{
+				Operations: []*flux.Operation{
+					{
+						ID: "from0",
+						Spec: &influxdb.FromOpSpec{
+							Bucket: "mydb",
+						},
+					},
+					{
+						ID: "to1",
+						Spec: &influxdb.ToOpSpec{
+							Bucket:            "series1",
+							TimeColumn:        execute.DefaultTimeColLabel,
+							MeasurementColumn: "_measurement",
+							BatchSize:         influxdb.DefaultToBatchSize,
+						},
+					},
+				},
+				Edges: []flux.Edge{
+					{Parent: "from0", Child: "to1"},
+				},
+			},
+		},
+		{
+			Name:    "to without token",
+			Raw:     `from(bucket:"mydb") |> to(bucket:"series1", host:"http://localhost:9999")`,
+			WantErr: true,
+		},
+		{
+			Name:    "to with bucket and bucketID",
+			Raw:     `from(bucket:"mydb") |> to(bucket:"series1", bucketID:"0000000000000001", host:"http://localhost:9999", token:"mytoken")`,
+			WantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			querytest.NewQueryTestHelper(t, tc)
+		})
+	}
+}
+
+func TestTo_Process(t *testing.T) {
+	type request struct {
+		query string
+		auth  string
+		body  string
+	}
+	var requests []request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		zr, err := gzip.NewReader(r.Body)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		body, err := ioutil.ReadAll(zr)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		requests = append(requests, request{
+			query: r.URL.RawQuery,
+			auth:  r.Header.Get("Authorization"),
+			body:  string(body),
+		})
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	testCases := []struct {
+		name string
+		spec *influxdb.ToOpSpec
+		data []*executetest.Table
+		want []request
+	}{
+		{
+			name: "default field and tag columns",
+			spec: &influxdb.ToOpSpec{
+				Bucket:            "my_bucket",
+				Org:               "my_org",
+				Host:              server.URL,
+				Token:             "my_token",
+				TimeColumn:        "_time",
+				MeasurementColumn: "_measurement",
+				BatchSize:         influxdb.DefaultToBatchSize,
+			},
+			data: []*executetest.Table{{
+				KeyCols: []string{"_measurement", "_field", "host"},
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_measurement", Type: flux.TString},
+					{Label: "_field", Type: flux.TString},
+					{Label: "host", Type: flux.TString},
+					{Label: "_value", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{execute.Time(11), "cpu", "usage", "a", 2.0},
+					{execute.Time(21), "cpu", "usage", "a", nil},
+					{execute.Time(31), "cpu", "usage", "a", 3.5},
+				},
+			}},
+			want: []request{{
+				query: "bucket=my_bucket&org=my_org&precision=ns",
+				auth:  "Token my_token",
+				body:  "cpu,host=a usage=2 11\ncpu,host=a usage=3.5 31\n",
+			}},
+		},
+		{
+			name: "batches",
+			spec: &influxdb.ToOpSpec{
+				BucketID:          "0000000000000001",
+				OrgID:             "0000000000000002",
+				Host:              server.URL,
+				TimeColumn:        "_time",
+				MeasurementColumn: "_measurement",
+				TagColumns:        []string{"host"},
+				Token:             "my_token",
+				BatchSize:         2,
+			},
+			data: []*executetest.Table{{
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_measurement", Type: flux.TString},
+					{Label: "_field", Type: flux.TString},
+					{Label: "host", Type: flux.TString},
+					{Label: "_value", Type: flux.TInt},
+				},
+				Data: [][]interface{}{
+					{execute.Time(11), "cpu", "n", "a", int64(1)},
+					{execute.Time(21), "cpu", "n", "b", int64(2)},
+					{execute.Time(31), "cpu", "n", "c", int64(3)},
+				},
+			}},
+			want: []request{
+				{
+					query: "bucket=0000000000000001&orgID=0000000000000002&precision=ns",
+					auth:  "Token my_token",
+					body:  "cpu,host=a n=1i 11\ncpu,host=b n=2i 21\n",
+				},
+				{
+					query: "bucket=0000000000000001&orgID=0000000000000002&precision=ns",
+					auth:  "Token my_token",
+					body:  "cpu,host=c n=3i 31\n",
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			requests = requests[:0]
+			data := make([]flux.Table, len(tc.data))
+			for i, tbl := range tc.data {
+				data[i] = tbl
+			}
+			ctx := dependencies.Inject(context.Background(), dependencies.Unrestricted())
+			// The transformation passes its input through unchanged.
+			executetest.ProcessTestHelper(
+				t,
+				data,
+				tc.data,
+				nil,
+				func(d execute.Dataset, c execute.TableBuilderCache) execute.Transformation {
+					tx, err := influxdb.NewToTransformation(ctx, d, c, &influxdb.ToProcedureSpec{Spec: tc.spec})
+					if err != nil {
+						t.Fatal(err)
+					}
+					return tx
+				},
+			)
+			if len(requests) != len(tc.want) {
+				t.Fatalf("unexpected number of requests: want %d, got %d", len(tc.want), len(requests))
+			}
+			for i := range tc.want {
+				if got, want := requests[i], tc.want[i]; got != want {
+					t.Errorf("unexpected request %d: want %+v, got %+v", i, want, got)
+				}
+			}
+		})
+	}
+}
+
+type mockPointsWriter struct {
+	dest    dependencies.WriteDestination
+	batches [][]dependencies.Point
+	err     error
+}
+
+func (w *mockPointsWriter) WritePoints(ctx context.Context, dest dependencies.WriteDestination, points []dependencies.Point) error {
+	w.dest = dest
+	w.batches = append(w.batches, append([]dependencies.Point(nil), points...))
+	// The error is only returned for the first batch.
+	err := w.err
+	w.err = nil
+	return err
+}
+
+func TestTo_PointsWriter(t *testing.T) {
+	spec := &influxdb.ToOpSpec{
+		Bucket:            "my_bucket",
+		OrgID:             "0000000000000001",
+		TimeColumn:        "_time",
+		MeasurementColumn: "_measurement",
+		BatchSize:         2,
+	}
+	data := []*executetest.Table{{
+		KeyCols: []string{"_measurement", "_field", "host"},
+		ColMeta: []flux.ColMeta{
+			{Label: "_time", Type: flux.TTime},
+			{Label: "_measurement", Type: flux.TString},
+			{Label: "_field", Type: flux.TString},
+			{Label: "host", Type: flux.TString},
+			{Label: "_value", Type: flux.TInt},
+		},
+		Data: [][]interface{}{
+			{execute.Time(11), "cpu", "n", "a", int64(1)},
+			{execute.Time(21), "cpu", "n", "a", int64(2)},
+			{execute.Time(31), "cpu", "n", "a", int64(3)},
+		},
+	}}
+	point := func(ts int64, v int64) dependencies.Point {
+		return dependencies.Point{
+			Measurement: "cpu",
+			Tags:        []dependencies.Tag{{Key: "host", Value: "a"}},
+			Fields:      []dependencies.Field{{Key: "n", Value: v}},
+			Time:        execute.Time(ts).Time(),
+		}
+	}
+
+	testCases := []struct {
+		name    string
+		err     error
+		want    [][]dependencies.Point
+		writes  []flux.Write
+		wantErr error
+	}{
+		{
+			name: "batches",
+			want: [][]dependencies.Point{
+				{point(11, 1), point(21, 2)},
+				{point(31, 3)},
+			},
+			writes: []flux.Write{
+				{Function: "to", Destination: "0000000000000001/my_bucket", Rows: 2, Status: "ok"},
+				{Function: "to", Destination: "0000000000000001/my_bucket", Rows: 1, Status: "ok"},
+			},
+		},
+		{
+			// The rejected points are reported with the writes and the query goes on.
+			name: "partial write",
+			err: &dependencies.PartialWriteError{Errors: []dependencies.PointError{
+				{Index: 1, Err: errors.New("field type conflict")},
+			}},
+			want: [][]dependencies.Point{
+				{point(11, 1), point(21, 2)},
+				{point(31, 3)},
+			},
+			writes: []flux.Write{
+				{Function: "to", Destination: "0000000000000001/my_bucket", Rows: 1, Rejected: 1, Status: "partial", Error: "failed to write point 1: field type conflict"},
+				{Function: "to", Destination: "0000000000000001/my_bucket", Rows: 1, Status: "ok"},
+			},
+		},
+		{
+			name: "failed write",
+			err:  errors.New("bucket not found"),
+			want: [][]dependencies.Point{
+				{point(11, 1), point(21, 2)},
+			},
+			writes: []flux.Write{
+				{Function: "to", Destination: "0000000000000001/my_bucket", Rows: 2, Status: "failed", Error: "bucket not found"},
+			},
+			wantErr: errors.New("bucket not found"),
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			w := &mockPointsWriter{err: tc.err}
+			writes := execute.NewWriteRecorder()
+			ctx := dependencies.Inject(context.Background(), dependencies.NewBuilder().WithPointsWriter(w).Build())
+			ctx = execute.WithWriteRecorder(ctx, writes)
+			var want []*executetest.Table
+			if tc.wantErr == nil {
+				want = data
+			}
+			tables := make([]flux.Table, len(data))
+			for i, tbl := range data {
+				tables[i] = tbl
+			}
+			executetest.ProcessTestHelper(
+				t,
+				tables,
+				want,
+				tc.wantErr,
+				func(d execute.Dataset, c execute.TableBuilderCache) execute.Transformation {
+					tx, err := influxdb.NewToTransformation(ctx, d, c, &influxdb.ToProcedureSpec{Spec: spec})
+					if err != nil {
+						t.Fatal(err)
+					}
+					return tx
+				},
+			)
+			if want := (dependencies.WriteDestination{Bucket: "my_bucket", OrgID: "0000000000000001"}); w.dest != want {
+				t.Errorf("unexpected destination: want %+v, got %+v", want, w.dest)
+			}
+			if !cmp.Equal(tc.want, w.batches) {
+				t.Errorf("unexpected batches -want/+got\n%s", cmp.Diff(tc.want, w.batches))
+			}
+			got := writes.Writes()
+			for i := range got {
+				got[i].Duration = 0
+			}
+			if !cmp.Equal(tc.writes, got) {
+				t.Errorf("unexpected writes -want/+got\n%s", cmp.Diff(tc.writes, got))
+			}
+		})
+	}
+}
+
+func TestTo_NoPointsWriter(t *testing.T) {
+	spec := &influxdb.ToOpSpec{Bucket: "my_bucket", TimeColumn: "_time", MeasurementColumn: "_measurement", BatchSize: influxdb.DefaultToBatchSize}
+	data := &executetest.Table{
+		KeyCols: []string{"_measurement", "_field"},
+		ColMeta: []flux.ColMeta{
+			{Label: "_time", Type: flux.TTime},
+			{Label: "_measurement", Type: flux.TString},
+			{Label: "_field", Type: flux.TString},
+			{Label: "_value", Type: flux.TInt},
+		},
+		Data: [][]interface{}{
+			{execute.Time(11), "cpu", "n", int64(1)},
+		},
+	}
+	// Without a points writer in the dependencies, the writes are denied.
+	executetest.ProcessTestHelper(
+		t,
+		[]flux.Table{data},
+		nil,
+		dependencies.ErrPointsWriterDenied,
+		func(d execute.Dataset, c execute.TableBuilderCache) execute.Transformation {
+			tx, err := influxdb.NewToTransformation(context.Background(), d, c, &influxdb.ToProcedureSpec{Spec: spec})
+			if err != nil {
+				t.Fatal(err)
+			}
+			return tx
+		},
+	)
+}
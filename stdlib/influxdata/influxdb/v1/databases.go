@@ -1,8 +1,15 @@
 package v1
 
 import (
+	"context"
+	"fmt"
+
 	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/plan"
 	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/flux/stdlib/influxdata/influxdb"
+	"github.com/influxdata/flux/values"
 )
 
 const DatabasesKind = "databases"
@@ -12,5 +19,83 @@ var DatabasesSignature = semantic.FunctionPolySignature{
 }
 
 func init() {
-	flux.RegisterPackageValue("influxdata/influxdb/v1", DatabasesKind, flux.FunctionValue(DatabasesKind, nil, DatabasesSignature))
+	flux.RegisterPackageValue("influxdata/influxdb/v1", DatabasesKind, flux.FunctionValue(DatabasesKind, createDatabasesOpSpec, DatabasesSignature))
+	flux.RegisterOpSpec(DatabasesKind, newDatabasesOp)
+	plan.RegisterProcedureSpec(DatabasesKind, newDatabasesProcedure, DatabasesKind)
+	execute.RegisterSource(DatabasesKind, createDatabasesSource)
+}
+
+type DatabasesOpSpec struct {
+}
+
+func createDatabasesOpSpec(args flux.Arguments, a *flux.Administration) (flux.OperationSpec, error) {
+	return new(DatabasesOpSpec), nil
+}
+
+func newDatabasesOp() flux.OperationSpec {
+	return new(DatabasesOpSpec)
+}
+
+func (s *DatabasesOpSpec) Kind() flux.OperationKind {
+	return DatabasesKind
+}
+
+func (s *DatabasesOpSpec) Resources() []flux.Resource {
+	return []flux.Resource{{Kind: flux.BucketResource, Name: flux.AllResources, Mode: flux.ReadAccess}}
+}
+
+type DatabasesProcedureSpec struct {
+	plan.DefaultCost
+}
+
+func newDatabasesProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	if _, ok := qs.(*DatabasesOpSpec); !ok {
+		return nil, fmt.Errorf("invalid spec type %T", qs)
+	}
+	return new(DatabasesProcedureSpec), nil
+}
+
+func (s *DatabasesProcedureSpec) Kind() plan.ProcedureKind {
+	return DatabasesKind
+}
+
+func (s *DatabasesProcedureSpec) Copy() plan.ProcedureSpec {
+	return new(DatabasesProcedureSpec)
+}
+
+var databasesCols = []flux.ColMeta{
+	{Label: "organizationID", Type: flux.TString},
+	{Label: "databaseName", Type: flux.TString},
+	{Label: "retentionPolicy", Type: flux.TString},
+	{Label: "retentionPeriod", Type: flux.TInt},
+	{Label: "default", Type: flux.TBool},
+	{Label: "bucketID", Type: flux.TString},
+}
+
+func createDatabasesSource(s plan.ProcedureSpec, id execute.DatasetID, a execute.Administration) (execute.Source, error) {
+	if _, ok := s.(*DatabasesProcedureSpec); !ok {
+		return nil, fmt.Errorf("invalid spec type %T", s)
+	}
+	client, err := influxdb.GetMetaClient(a)
+	if err != nil {
+		return nil, err
+	}
+	return influxdb.NewMetaSource(id, a.Allocator(), databasesCols, func(ctx context.Context) ([][]values.Value, error) {
+		databases, err := client.Databases(ctx)
+		if err != nil {
+			return nil, err
+		}
+		rows := make([][]values.Value, len(databases))
+		for i, db := range databases {
+			rows[i] = []values.Value{
+				values.NewString(db.OrganizationID),
+				values.NewString(db.Name),
+				values.NewString(db.RetentionPolicy),
+				values.NewInt(int64(db.RetentionPeriod)),
+				values.NewBool(db.Default),
+				values.NewString(db.BucketID),
+			}
+		}
+		return rows, nil
+	}), nil
 }
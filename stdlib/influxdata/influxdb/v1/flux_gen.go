@@ -5,10 +5,12 @@ package v1
 import (
 	flux "github.com/influxdata/flux"
 	ast "github.com/influxdata/flux/ast"
+	docs "github.com/influxdata/flux/docs"
 )
 
 func init() {
 	flux.RegisterPackage(pkgAST)
+	docs.RegisterComments("influxdata/influxdb/v1", pkgComments)
 }
 
 var pkgAST = &ast.Package{
@@ -22,10 +24,10 @@ var pkgAST = &ast.Package{
 			Loc: &ast.SourceLocation{
 				End: ast.Position{
 					Column: 51,
-					Line:   44,
+					Line:   46,
 				},
 				File:   "v1.flux",
-				Source: "package v1\n\n// Json parses an InfluxDB 1.x json result into a table stream.\nbuiltin json\n\n// Databases returns the list of available databases, it has no parameters.\nbuiltin databases\n\n// fieldsAsCols is a special application of pivot that will automatically align fields within each measurement that have the same timestamp.\nfieldsAsCols = (tables=<-) =>\n    tables\n        |> pivot(rowKey:[\"_time\"], columnKey: [\"_field\"], valueColumn: \"_value\")\n\n// TagValues returns the unique values for a given tag.\n// The return value is always a single table with a single column \"_value\".\ntagValues = (bucket, tag, predicate=(r) => true, start=-30d) =>\n    from(bucket: bucket)\n      |> range(start: start)\n      |> filter(fn: predicate)\n      |> group(columns: [tag])\n      |> distinct(column: tag)\n      |> keep(columns: [\"_value\"])\n\n// MeasurementTagValues returns a single table with a single column \"_value\" that contains the\n// The return value is always a single table with a single column \"_value\".\nmeasurementTagValues = (bucket, measurement, tag) =>\n    tagValues(bucket: bucket, tag: tag, predicate: (r) => r._measurement == measurement)\n\n// TagKeys returns the list of tag keys for all series that match the predicate.\n// The return value is always a single table with a single column \"_value\".\ntagKeys = (bucket, predicate=(r) => true, start=-30d) =>\n    from(bucket: bucket)\n        |> range(start: start)\n        |> filter(fn: predicate)\n        |> keys()\n        |> keep(columns: [\"_value\"])\n\n// MeasurementTagKeys returns the list of tag keys for a specific measurement.\nmeasurementTagKeys = (bucket, measurement) =>\n    tagKeys(bucket: bucket, predicate: (r) => r._measurement == measurement)\n\n// Measurements returns the list of measurements in a specific bucket.\nmeasurements = (bucket) =>\n    tagValues(bucket: bucket, tag: \"_measurement\")",
+				Source: "package v1\n\n// Json parses an InfluxDB 1.x json result into a table stream.\nbuiltin json\n\n// Databases returns the list of available databases, it has no parameters.\nbuiltin databases\n\n// fieldsAsCols is a special application of pivot that will automatically align fields within each measurement that have the same timestamp.\nfieldsAsCols = (tables=<-) =>\n    tables\n        |> pivot(rowKey:[\"_time\"], columnKey: [\"_field\"], valueColumn: \"_value\")\n\n// TagValues returns the unique values for a given tag.\n// The return value is always a single table with a single column \"_value\".\n// When the storage engine can look up tag values in its index, the lookup replaces reading the series.\ntagValues = (bucket, tag, predicate=(r) => true, start=-30d) =>\n    from(bucket: bucket)\n      |> range(start: start)\n      |> filter(fn: predicate)\n      |> group(columns: [tag])\n      |> distinct(column: tag)\n      |> keep(columns: [\"_value\"])\n\n// MeasurementTagValues returns a single table with a single column \"_value\" that contains the\n// The return value is always a single table with a single column \"_value\".\nmeasurementTagValues = (bucket, measurement, tag) =>\n    tagValues(bucket: bucket, tag: tag, predicate: (r) => r._measurement == measurement)\n\n// TagKeys returns the list of tag keys for all series that match the predicate.\n// The return value is always a single table with a single column \"_value\".\n// When the storage engine can look up tag keys in its index, the lookup replaces reading the series.\ntagKeys = (bucket, predicate=(r) => true, start=-30d) =>\n    from(bucket: bucket)\n        |> range(start: start)\n        |> filter(fn: predicate)\n        |> keys()\n        |> keep(columns: [\"_value\"])\n\n// MeasurementTagKeys returns the list of tag keys for a specific measurement.\nmeasurementTagKeys = (bucket, measurement) =>\n    tagKeys(bucket: bucket, predicate: (r) => r._measurement == measurement)\n\n// Measurements returns the list of measurements in a specific bucket.\nmeasurements = (bucket) =>\n    tagValues(bucket: bucket, tag: \"_measurement\")",
 				Start: ast.Position{
 					Column: 1,
 					Line:   1,
@@ -485,13 +487,13 @@ var pkgAST = &ast.Package{
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
 						Column: 35,
-						Line:   22,
+						Line:   23,
 					},
 					File:   "v1.flux",
 					Source: "tagValues = (bucket, tag, predicate=(r) => true, start=-30d) =>\n    from(bucket: bucket)\n      |> range(start: start)\n      |> filter(fn: predicate)\n      |> group(columns: [tag])\n      |> distinct(column: tag)\n      |> keep(columns: [\"_value\"])",
 					Start: ast.Position{
 						Column: 1,
-						Line:   16,
+						Line:   17,
 					},
 				},
 			},
@@ -501,13 +503,13 @@ var pkgAST = &ast.Package{
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
 							Column: 10,
-							Line:   16,
+							Line:   17,
 						},
 						File:   "v1.flux",
 						Source: "tagValues",
 						Start: ast.Position{
 							Column: 1,
-							Line:   16,
+							Line:   17,
 						},
 					},
 				},
@@ -519,13 +521,13 @@ var pkgAST = &ast.Package{
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
 							Column: 35,
-							Line:   22,
+							Line:   23,
 						},
 						File:   "v1.flux",
 						Source: "(bucket, tag, predicate=(r) => true, start=-30d) =>\n    from(bucket: bucket)\n      |> range(start: start)\n      |> filter(fn: predicate)\n      |> group(columns: [tag])\n      |> distinct(column: tag)\n      |> keep(columns: [\"_value\"])",
 						Start: ast.Position{
 							Column: 13,
-							Line:   16,
+							Line:   17,
 						},
 					},
 				},
@@ -541,13 +543,13 @@ var pkgAST = &ast.Package{
 												Loc: &ast.SourceLocation{
 													End: ast.Position{
 														Column: 24,
-														Line:   17,
+														Line:   18,
 													},
 													File:   "v1.flux",
 													Source: "bucket: bucket",
 													Start: ast.Position{
 														Column: 10,
-														Line:   17,
+														Line:   18,
 													},
 												},
 											},
@@ -557,13 +559,13 @@ var pkgAST = &ast.Package{
 													Loc: &ast.SourceLocation{
 														End: ast.Position{
 															Column: 24,
-															Line:   17,
+															Line:   18,
 														},
 														File:   "v1.flux",
 														Source: "bucket: bucket",
 														Start: ast.Position{
 															Column: 10,
-															Line:   17,
+															Line:   18,
 														},
 													},
 												},
@@ -573,13 +575,13 @@ var pkgAST = &ast.Package{
 														Loc: &ast.SourceLocation{
 															End: ast.Position{
 																Column: 16,
-																Line:   17,
+																Line:   18,
 															},
 															File:   "v1.flux",
 															Source: "bucket",
 															Start: ast.Position{
 																Column: 10,
-																Line:   17,
+																Line:   18,
 															},
 														},
 													},
@@ -591,13 +593,13 @@ var pkgAST = &ast.Package{
 														Loc: &ast.SourceLocation{
 															End: ast.Position{
 																Column: 24,
-																Line:   17,
+																Line:   18,
 															},
 															File:   "v1.flux",
 															Source: "bucket",
 															Start: ast.Position{
 																Column: 18,
-																Line:   17,
+																Line:   18,
 															},
 														},
 													},
@@ -610,13 +612,13 @@ var pkgAST = &ast.Package{
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 25,
-													Line:   17,
+													Line:   18,
 												},
 												File:   "v1.flux",
 												Source: "from(bucket: bucket)",
 												Start: ast.Position{
 													Column: 5,
-													Line:   17,
+													Line:   18,
 												},
 											},
 										},
@@ -626,13 +628,13 @@ var pkgAST = &ast.Package{
 												Loc: &ast.SourceLocation{
 													End: ast.Position{
 														Column: 9,
-														Line:   17,
+														Line:   18,
 													},
 													File:   "v1.flux",
 													Source: "from",
 													Start: ast.Position{
 														Column: 5,
-														Line:   17,
+														Line:   18,
 													},
 												},
 											},
@@ -644,13 +646,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 29,
-												Line:   18,
+												Line:   19,
 											},
 											File:   "v1.flux",
 											Source: "from(bucket: bucket)\n      |> range(start: start)",
 											Start: ast.Position{
 												Column: 5,
-												Line:   17,
+												Line:   18,
 											},
 										},
 									},
@@ -661,13 +663,13 @@ var pkgAST = &ast.Package{
 												Loc: &ast.SourceLocation{
 													End: ast.Position{
 														Column: 28,
-														Line:   18,
+														Line:   19,
 													},
 													File:   "v1.flux",
 													Source: "start: start",
 													Start: ast.Position{
 														Column: 16,
-														Line:   18,
+														Line:   19,
 													},
 												},
 											},
@@ -677,13 +679,13 @@ var pkgAST = &ast.Package{
 													Loc: &ast.SourceLocation{
 														End: ast.Position{
 															Column: 28,
-															Line:   18,
+															Line:   19,
 														},
 														File:   "v1.flux",
 														Source: "start: start",
 														Start: ast.Position{
 															Column: 16,
-															Line:   18,
+															Line:   19,
 														},
 													},
 												},
@@ -693,13 +695,13 @@ var pkgAST = &ast.Package{
 														Loc: &ast.SourceLocation{
 															End: ast.Position{
 																Column: 21,
-																Line:   18,
+																Line:   19,
 															},
 															File:   "v1.flux",
 															Source: "start",
 															Start: ast.Position{
 																Column: 16,
-																Line:   18,
+																Line:   19,
 															},
 														},
 													},
@@ -711,13 +713,13 @@ var pkgAST = &ast.Package{
 														Loc: &ast.SourceLocation{
 															End: ast.Position{
 																Column: 28,
-																Line:   18,
+																Line:   19,
 															},
 															File:   "v1.flux",
 															Source: "start",
 															Start: ast.Position{
 																Column: 23,
-																Line:   18,
+																Line:   19,
 															},
 														},
 													},
@@ -730,13 +732,13 @@ var pkgAST = &ast.Package{
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 29,
-													Line:   18,
+													Line:   19,
 												},
 												File:   "v1.flux",
 												Source: "range(start: start)",
 												Start: ast.Position{
 													Column: 10,
-													Line:   18,
+													Line:   19,
 												},
 											},
 										},
@@ -746,13 +748,13 @@ var pkgAST = &ast.Package{
 												Loc: &ast.SourceLocation{
 													End: ast.Position{
 														Column: 15,
-														Line:   18,
+														Line:   19,
 													},
 													File:   "v1.flux",
 													Source: "range",
 													Start: ast.Position{
 														Column: 10,
-														Line:   18,
+														Line:   19,
 													},
 												},
 											},
@@ -765,13 +767,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 31,
-											Line:   19,
+											Line:   20,
 										},
 										File:   "v1.flux",
 										Source: "from(bucket: bucket)\n      |> range(start: start)\n      |> filter(fn: predicate)",
 										Start: ast.Position{
 											Column: 5,
-											Line:   17,
+											Line:   18,
 										},
 									},
 								},
@@ -782,13 +784,13 @@ var pkgAST = &ast.Package{
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 30,
-													Line:   19,
+													Line:   20,
 												},
 												File:   "v1.flux",
 												Source: "fn: predicate",
 												Start: ast.Position{
 													Column: 17,
-													Line:   19,
+													Line:   20,
 												},
 											},
 										},
@@ -798,13 +800,13 @@ var pkgAST = &ast.Package{
 												Loc: &ast.SourceLocation{
 													End: ast.Position{
 														Column: 30,
-														Line:   19,
+														Line:   20,
 													},
 													File:   "v1.flux",
 													Source: "fn: predicate",
 													Start: ast.Position{
 														Column: 17,
-														Line:   19,
+														Line:   20,
 													},
 												},
 											},
@@ -814,13 +816,13 @@ var pkgAST = &ast.Package{
 													Loc: &ast.SourceLocation{
 														End: ast.Position{
 															Column: 19,
-															Line:   19,
+															Line:   20,
 														},
 														File:   "v1.flux",
 														Source: "fn",
 														Start: ast.Position{
 															Column: 17,
-															Line:   19,
+															Line:   20,
 														},
 													},
 												},
@@ -832,13 +834,13 @@ var pkgAST = &ast.Package{
 													Loc: &ast.SourceLocation{
 														End: ast.Position{
 															Column: 30,
-															Line:   19,
+															Line:   20,
 														},
 														File:   "v1.flux",
 														Source: "predicate",
 														Start: ast.Position{
 															Column: 21,
-															Line:   19,
+															Line:   20,
 														},
 													},
 												},
@@ -851,13 +853,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 31,
-												Line:   19,
+												Line:   20,
 											},
 											File:   "v1.flux",
 											Source: "filter(fn: predicate)",
 											Start: ast.Position{
 												Column: 10,
-												Line:   19,
+												Line:   20,
 											},
 										},
 									},
@@ -867,13 +869,13 @@ var pkgAST = &ast.Package{
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 16,
-													Line:   19,
+													Line:   20,
 												},
 												File:   "v1.flux",
 												Source: "filter",
 												Start: ast.Position{
 													Column: 10,
-													Line:   19,
+													Line:   20,
 												},
 											},
 										},
@@ -886,13 +888,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 31,
-										Line:   20,
+										Line:   21,
 									},
 									File:   "v1.flux",
 									Source: "from(bucket: bucket)\n      |> range(start: start)\n      |> filter(fn: predicate)\n      |> group(columns: [tag])",
 									Start: ast.Position{
 										Column: 5,
-										Line:   17,
+										Line:   18,
 									},
 								},
 							},
@@ -903,13 +905,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 30,
-												Line:   20,
+												Line:   21,
 											},
 											File:   "v1.flux",
 											Source: "columns: [tag]",
 											Start: ast.Position{
 												Column: 16,
-												Line:   20,
+												Line:   21,
 											},
 										},
 									},
@@ -919,13 +921,13 @@ var pkgAST = &ast.Package{
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 30,
-													Line:   20,
+													Line:   21,
 												},
 												File:   "v1.flux",
 												Source: "columns: [tag]",
 												Start: ast.Position{
 													Column: 16,
-													Line:   20,
+													Line:   21,
 												},
 											},
 										},
@@ -935,13 +937,13 @@ var pkgAST = &ast.Package{
 												Loc: &ast.SourceLocation{
 													End: ast.Position{
 														Column: 23,
-														Line:   20,
+														Line:   21,
 													},
 													File:   "v1.flux",
 													Source: "columns",
 													Start: ast.Position{
 														Column: 16,
-														Line:   20,
+														Line:   21,
 													},
 												},
 											},
@@ -953,13 +955,13 @@ var pkgAST = &ast.Package{
 												Loc: &ast.SourceLocation{
 													End: ast.Position{
 														Column: 30,
-														Line:   20,
+														Line:   21,
 													},
 													File:   "v1.flux",
 													Source: "[tag]",
 													Start: ast.Position{
 														Column: 25,
-														Line:   20,
+														Line:   21,
 													},
 												},
 											},
@@ -969,13 +971,13 @@ var pkgAST = &ast.Package{
 													Loc: &ast.SourceLocation{
 														End: ast.Position{
 															Column: 29,
-															Line:   20,
+															Line:   21,
 														},
 														File:   "v1.flux",
 														Source: "tag",
 														Start: ast.Position{
 															Column: 26,
-															Line:   20,
+															Line:   21,
 														},
 													},
 												},
@@ -989,13 +991,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 31,
-											Line:   20,
+											Line:   21,
 										},
 										File:   "v1.flux",
 										Source: "group(columns: [tag])",
 										Start: ast.Position{
 											Column: 10,
-											Line:   20,
+											Line:   21,
 										},
 									},
 								},
@@ -1005,13 +1007,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 15,
-												Line:   20,
+												Line:   21,
 											},
 											File:   "v1.flux",
 											Source: "group",
 											Start: ast.Position{
 												Column: 10,
-												Line:   20,
+												Line:   21,
 											},
 										},
 									},
@@ -1024,13 +1026,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 31,
-									Line:   21,
+									Line:   22,
 								},
 								File:   "v1.flux",
 								Source: "from(bucket: bucket)\n      |> range(start: start)\n      |> filter(fn: predicate)\n      |> group(columns: [tag])\n      |> distinct(column: tag)",
 								Start: ast.Position{
 									Column: 5,
-									Line:   17,
+									Line:   18,
 								},
 							},
 						},
@@ -1041,13 +1043,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 30,
-											Line:   21,
+											Line:   22,
 										},
 										File:   "v1.flux",
 										Source: "column: tag",
 										Start: ast.Position{
 											Column: 19,
-											Line:   21,
+											Line:   22,
 										},
 									},
 								},
@@ -1057,13 +1059,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 30,
-												Line:   21,
+												Line:   22,
 											},
 											File:   "v1.flux",
 											Source: "column: tag",
 											Start: ast.Position{
 												Column: 19,
-												Line:   21,
+												Line:   22,
 											},
 										},
 									},
@@ -1073,13 +1075,13 @@ var pkgAST = &ast.Package{
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 25,
-													Line:   21,
+													Line:   22,
 												},
 												File:   "v1.flux",
 												Source: "column",
 												Start: ast.Position{
 													Column: 19,
-													Line:   21,
+													Line:   22,
 												},
 											},
 										},
@@ -1091,13 +1093,13 @@ var pkgAST = &ast.Package{
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 30,
-													Line:   21,
+													Line:   22,
 												},
 												File:   "v1.flux",
 												Source: "tag",
 												Start: ast.Position{
 													Column: 27,
-													Line:   21,
+													Line:   22,
 												},
 											},
 										},
@@ -1110,13 +1112,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 31,
-										Line:   21,
+										Line:   22,
 									},
 									File:   "v1.flux",
 									Source: "distinct(column: tag)",
 									Start: ast.Position{
 										Column: 10,
-										Line:   21,
+										Line:   22,
 									},
 								},
 							},
@@ -1126,13 +1128,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 18,
-											Line:   21,
+											Line:   22,
 										},
 										File:   "v1.flux",
 										Source: "distinct",
 										Start: ast.Position{
 											Column: 10,
-											Line:   21,
+											Line:   22,
 										},
 									},
 								},
@@ -1145,13 +1147,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 35,
-								Line:   22,
+								Line:   23,
 							},
 							File:   "v1.flux",
 							Source: "from(bucket: bucket)\n      |> range(start: start)\n      |> filter(fn: predicate)\n      |> group(columns: [tag])\n      |> distinct(column: tag)\n      |> keep(columns: [\"_value\"])",
 							Start: ast.Position{
 								Column: 5,
-								Line:   17,
+								Line:   18,
 							},
 						},
 					},
@@ -1162,13 +1164,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 34,
-										Line:   22,
+										Line:   23,
 									},
 									File:   "v1.flux",
 									Source: "columns: [\"_value\"]",
 									Start: ast.Position{
 										Column: 15,
-										Line:   22,
+										Line:   23,
 									},
 								},
 							},
@@ -1178,13 +1180,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 34,
-											Line:   22,
+											Line:   23,
 										},
 										File:   "v1.flux",
 										Source: "columns: [\"_value\"]",
 										Start: ast.Position{
 											Column: 15,
-											Line:   22,
+											Line:   23,
 										},
 									},
 								},
@@ -1194,13 +1196,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 22,
-												Line:   22,
+												Line:   23,
 											},
 											File:   "v1.flux",
 											Source: "columns",
 											Start: ast.Position{
 												Column: 15,
-												Line:   22,
+												Line:   23,
 											},
 										},
 									},
@@ -1212,13 +1214,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 34,
-												Line:   22,
+												Line:   23,
 											},
 											File:   "v1.flux",
 											Source: "[\"_value\"]",
 											Start: ast.Position{
 												Column: 24,
-												Line:   22,
+												Line:   23,
 											},
 										},
 									},
@@ -1228,13 +1230,13 @@ var pkgAST = &ast.Package{
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 33,
-													Line:   22,
+													Line:   23,
 												},
 												File:   "v1.flux",
 												Source: "\"_value\"",
 												Start: ast.Position{
 													Column: 25,
-													Line:   22,
+													Line:   23,
 												},
 											},
 										},
@@ -1248,13 +1250,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 35,
-									Line:   22,
+									Line:   23,
 								},
 								File:   "v1.flux",
 								Source: "keep(columns: [\"_value\"])",
 								Start: ast.Position{
 									Column: 10,
-									Line:   22,
+									Line:   23,
 								},
 							},
 						},
@@ -1264,13 +1266,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 14,
-										Line:   22,
+										Line:   23,
 									},
 									File:   "v1.flux",
 									Source: "keep",
 									Start: ast.Position{
 										Column: 10,
-										Line:   22,
+										Line:   23,
 									},
 								},
 							},
@@ -1284,13 +1286,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 20,
-								Line:   16,
+								Line:   17,
 							},
 							File:   "v1.flux",
 							Source: "bucket",
 							Start: ast.Position{
 								Column: 14,
-								Line:   16,
+								Line:   17,
 							},
 						},
 					},
@@ -1300,13 +1302,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 20,
-									Line:   16,
+									Line:   17,
 								},
 								File:   "v1.flux",
 								Source: "bucket",
 								Start: ast.Position{
 									Column: 14,
-									Line:   16,
+									Line:   17,
 								},
 							},
 						},
@@ -1319,13 +1321,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 25,
-								Line:   16,
+								Line:   17,
 							},
 							File:   "v1.flux",
 							Source: "tag",
 							Start: ast.Position{
 								Column: 22,
-								Line:   16,
+								Line:   17,
 							},
 						},
 					},
@@ -1335,13 +1337,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 25,
-									Line:   16,
+									Line:   17,
 								},
 								File:   "v1.flux",
 								Source: "tag",
 								Start: ast.Position{
 									Column: 22,
-									Line:   16,
+									Line:   17,
 								},
 							},
 						},
@@ -1354,13 +1356,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 48,
-								Line:   16,
+								Line:   17,
 							},
 							File:   "v1.flux",
 							Source: "predicate=(r) => true",
 							Start: ast.Position{
 								Column: 27,
-								Line:   16,
+								Line:   17,
 							},
 						},
 					},
@@ -1370,13 +1372,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 36,
-									Line:   16,
+									Line:   17,
 								},
 								File:   "v1.flux",
 								Source: "predicate",
 								Start: ast.Position{
 									Column: 27,
-									Line:   16,
+									Line:   17,
 								},
 							},
 						},
@@ -1388,13 +1390,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 48,
-									Line:   16,
+									Line:   17,
 								},
 								File:   "v1.flux",
 								Source: "(r) => true",
 								Start: ast.Position{
 									Column: 37,
-									Line:   16,
+									Line:   17,
 								},
 							},
 						},
@@ -1404,13 +1406,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 48,
-										Line:   16,
+										Line:   17,
 									},
 									File:   "v1.flux",
 									Source: "true",
 									Start: ast.Position{
 										Column: 44,
-										Line:   16,
+										Line:   17,
 									},
 								},
 							},
@@ -1422,13 +1424,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 39,
-										Line:   16,
+										Line:   17,
 									},
 									File:   "v1.flux",
 									Source: "r",
 									Start: ast.Position{
 										Column: 38,
-										Line:   16,
+										Line:   17,
 									},
 								},
 							},
@@ -1438,13 +1440,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 39,
-											Line:   16,
+											Line:   17,
 										},
 										File:   "v1.flux",
 										Source: "r",
 										Start: ast.Position{
 											Column: 38,
-											Line:   16,
+											Line:   17,
 										},
 									},
 								},
@@ -1459,13 +1461,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 60,
-								Line:   16,
+								Line:   17,
 							},
 							File:   "v1.flux",
 							Source: "start=-30d",
 							Start: ast.Position{
 								Column: 50,
-								Line:   16,
+								Line:   17,
 							},
 						},
 					},
@@ -1475,13 +1477,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 55,
-									Line:   16,
+									Line:   17,
 								},
 								File:   "v1.flux",
 								Source: "start",
 								Start: ast.Position{
 									Column: 50,
-									Line:   16,
+									Line:   17,
 								},
 							},
 						},
@@ -1494,13 +1496,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 60,
-										Line:   16,
+										Line:   17,
 									},
 									File:   "v1.flux",
 									Source: "30d",
 									Start: ast.Position{
 										Column: 57,
-										Line:   16,
+										Line:   17,
 									},
 								},
 							},
@@ -1514,13 +1516,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 60,
-									Line:   16,
+									Line:   17,
 								},
 								File:   "v1.flux",
 								Source: "-30d",
 								Start: ast.Position{
 									Column: 56,
-									Line:   16,
+									Line:   17,
 								},
 							},
 						},
@@ -1534,13 +1536,13 @@ var pkgAST = &ast.Package{
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
 						Column: 89,
-						Line:   27,
+						Line:   28,
 					},
 					File:   "v1.flux",
 					Source: "measurementTagValues = (bucket, measurement, tag) =>\n    tagValues(bucket: bucket, tag: tag, predicate: (r) => r._measurement == measurement)",
 					Start: ast.Position{
 						Column: 1,
-						Line:   26,
+						Line:   27,
 					},
 				},
 			},
@@ -1550,13 +1552,13 @@ var pkgAST = &ast.Package{
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
 							Column: 21,
-							Line:   26,
+							Line:   27,
 						},
 						File:   "v1.flux",
 						Source: "measurementTagValues",
 						Start: ast.Position{
 							Column: 1,
-							Line:   26,
+							Line:   27,
 						},
 					},
 				},
@@ -1568,13 +1570,13 @@ var pkgAST = &ast.Package{
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
 							Column: 89,
-							Line:   27,
+							Line:   28,
 						},
 						File:   "v1.flux",
 						Source: "(bucket, measurement, tag) =>\n    tagValues(bucket: bucket, tag: tag, predicate: (r) => r._measurement == measurement)",
 						Start: ast.Position{
 							Column: 24,
-							Line:   26,
+							Line:   27,
 						},
 					},
 				},
@@ -1585,13 +1587,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 88,
-									Line:   27,
+									Line:   28,
 								},
 								File:   "v1.flux",
 								Source: "bucket: bucket, tag: tag, predicate: (r) => r._measurement == measurement",
 								Start: ast.Position{
 									Column: 15,
-									Line:   27,
+									Line:   28,
 								},
 							},
 						},
@@ -1601,13 +1603,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 29,
-										Line:   27,
+										Line:   28,
 									},
 									File:   "v1.flux",
 									Source: "bucket: bucket",
 									Start: ast.Position{
 										Column: 15,
-										Line:   27,
+										Line:   28,
 									},
 								},
 							},
@@ -1617,13 +1619,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 21,
-											Line:   27,
+											Line:   28,
 										},
 										File:   "v1.flux",
 										Source: "bucket",
 										Start: ast.Position{
 											Column: 15,
-											Line:   27,
+											Line:   28,
 										},
 									},
 								},
@@ -1635,13 +1637,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 29,
-											Line:   27,
+											Line:   28,
 										},
 										File:   "v1.flux",
 										Source: "bucket",
 										Start: ast.Position{
 											Column: 23,
-											Line:   27,
+											Line:   28,
 										},
 									},
 								},
@@ -1653,13 +1655,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 39,
-										Line:   27,
+										Line:   28,
 									},
 									File:   "v1.flux",
 									Source: "tag: tag",
 									Start: ast.Position{
 										Column: 31,
-										Line:   27,
+										Line:   28,
 									},
 								},
 							},
@@ -1669,13 +1671,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 34,
-											Line:   27,
+											Line:   28,
 										},
 										File:   "v1.flux",
 										Source: "tag",
 										Start: ast.Position{
 											Column: 31,
-											Line:   27,
+											Line:   28,
 										},
 									},
 								},
@@ -1687,13 +1689,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 39,
-											Line:   27,
+											Line:   28,
 										},
 										File:   "v1.flux",
 										Source: "tag",
 										Start: ast.Position{
 											Column: 36,
-											Line:   27,
+											Line:   28,
 										},
 									},
 								},
@@ -1705,13 +1707,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 88,
-										Line:   27,
+										Line:   28,
 									},
 									File:   "v1.flux",
 									Source: "predicate: (r) => r._measurement == measurement",
 									Start: ast.Position{
 										Column: 41,
-										Line:   27,
+										Line:   28,
 									},
 								},
 							},
@@ -1721,13 +1723,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 50,
-											Line:   27,
+											Line:   28,
 										},
 										File:   "v1.flux",
 										Source: "predicate",
 										Start: ast.Position{
 											Column: 41,
-											Line:   27,
+											Line:   28,
 										},
 									},
 								},
@@ -1739,13 +1741,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 88,
-											Line:   27,
+											Line:   28,
 										},
 										File:   "v1.flux",
 										Source: "(r) => r._measurement == measurement",
 										Start: ast.Position{
 											Column: 52,
-											Line:   27,
+											Line:   28,
 										},
 									},
 								},
@@ -1755,13 +1757,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 88,
-												Line:   27,
+												Line:   28,
 											},
 											File:   "v1.flux",
 											Source: "r._measurement == measurement",
 											Start: ast.Position{
 												Column: 59,
-												Line:   27,
+												Line:   28,
 											},
 										},
 									},
@@ -1771,13 +1773,13 @@ var pkgAST = &ast.Package{
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 73,
-													Line:   27,
+													Line:   28,
 												},
 												File:   "v1.flux",
 												Source: "r._measurement",
 												Start: ast.Position{
 													Column: 59,
-													Line:   27,
+													Line:   28,
 												},
 											},
 										},
@@ -1787,13 +1789,13 @@ var pkgAST = &ast.Package{
 												Loc: &ast.SourceLocation{
 													End: ast.Position{
 														Column: 60,
-														Line:   27,
+														Line:   28,
 													},
 													File:   "v1.flux",
 													Source: "r",
 													Start: ast.Position{
 														Column: 59,
-														Line:   27,
+														Line:   28,
 													},
 												},
 											},
@@ -1805,13 +1807,13 @@ var pkgAST = &ast.Package{
 												Loc: &ast.SourceLocation{
 													End: ast.Position{
 														Column: 73,
-														Line:   27,
+														Line:   28,
 													},
 													File:   "v1.flux",
 													Source: "_measurement",
 													Start: ast.Position{
 														Column: 61,
-														Line:   27,
+														Line:   28,
 													},
 												},
 											},
@@ -1825,13 +1827,13 @@ var pkgAST = &ast.Package{
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 88,
-													Line:   27,
+													Line:   28,
 												},
 												File:   "v1.flux",
 												Source: "measurement",
 												Start: ast.Position{
 													Column: 77,
-													Line:   27,
+													Line:   28,
 												},
 											},
 										},
@@ -1844,13 +1846,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 54,
-												Line:   27,
+												Line:   28,
 											},
 											File:   "v1.flux",
 											Source: "r",
 											Start: ast.Position{
 												Column: 53,
-												Line:   27,
+												Line:   28,
 											},
 										},
 									},
@@ -1860,13 +1862,13 @@ var pkgAST = &ast.Package{
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 54,
-													Line:   27,
+													Line:   28,
 												},
 												File:   "v1.flux",
 												Source: "r",
 												Start: ast.Position{
 													Column: 53,
-													Line:   27,
+													Line:   28,
 												},
 											},
 										},
@@ -1882,13 +1884,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 89,
-								Line:   27,
+								Line:   28,
 							},
 							File:   "v1.flux",
 							Source: "tagValues(bucket: bucket, tag: tag, predicate: (r) => r._measurement == measurement)",
 							Start: ast.Position{
 								Column: 5,
-								Line:   27,
+								Line:   28,
 							},
 						},
 					},
@@ -1898,13 +1900,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 14,
-									Line:   27,
+									Line:   28,
 								},
 								File:   "v1.flux",
 								Source: "tagValues",
 								Start: ast.Position{
 									Column: 5,
-									Line:   27,
+									Line:   28,
 								},
 							},
 						},
@@ -1917,13 +1919,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 31,
-								Line:   26,
+								Line:   27,
 							},
 							File:   "v1.flux",
 							Source: "bucket",
 							Start: ast.Position{
 								Column: 25,
-								Line:   26,
+								Line:   27,
 							},
 						},
 					},
@@ -1933,13 +1935,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 31,
-									Line:   26,
+									Line:   27,
 								},
 								File:   "v1.flux",
 								Source: "bucket",
 								Start: ast.Position{
 									Column: 25,
-									Line:   26,
+									Line:   27,
 								},
 							},
 						},
@@ -1952,13 +1954,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 44,
-								Line:   26,
+								Line:   27,
 							},
 							File:   "v1.flux",
 							Source: "measurement",
 							Start: ast.Position{
 								Column: 33,
-								Line:   26,
+								Line:   27,
 							},
 						},
 					},
@@ -1968,13 +1970,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 44,
-									Line:   26,
+									Line:   27,
 								},
 								File:   "v1.flux",
 								Source: "measurement",
 								Start: ast.Position{
 									Column: 33,
-									Line:   26,
+									Line:   27,
 								},
 							},
 						},
@@ -1987,13 +1989,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 49,
-								Line:   26,
+								Line:   27,
 							},
 							File:   "v1.flux",
 							Source: "tag",
 							Start: ast.Position{
 								Column: 46,
-								Line:   26,
+								Line:   27,
 							},
 						},
 					},
@@ -2003,13 +2005,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 49,
-									Line:   26,
+									Line:   27,
 								},
 								File:   "v1.flux",
 								Source: "tag",
 								Start: ast.Position{
 									Column: 46,
-									Line:   26,
+									Line:   27,
 								},
 							},
 						},
@@ -2024,13 +2026,13 @@ var pkgAST = &ast.Package{
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
 						Column: 37,
-						Line:   36,
+						Line:   38,
 					},
 					File:   "v1.flux",
 					Source: "tagKeys = (bucket, predicate=(r) => true, start=-30d) =>\n    from(bucket: bucket)\n        |> range(start: start)\n        |> filter(fn: predicate)\n        |> keys()\n        |> keep(columns: [\"_value\"])",
 					Start: ast.Position{
 						Column: 1,
-						Line:   31,
+						Line:   33,
 					},
 				},
 			},
@@ -2040,13 +2042,13 @@ var pkgAST = &ast.Package{
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
 							Column: 8,
-							Line:   31,
+							Line:   33,
 						},
 						File:   "v1.flux",
 						Source: "tagKeys",
 						Start: ast.Position{
 							Column: 1,
-							Line:   31,
+							Line:   33,
 						},
 					},
 				},
@@ -2058,13 +2060,13 @@ var pkgAST = &ast.Package{
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
 							Column: 37,
-							Line:   36,
+							Line:   38,
 						},
 						File:   "v1.flux",
 						Source: "(bucket, predicate=(r) => true, start=-30d) =>\n    from(bucket: bucket)\n        |> range(start: start)\n        |> filter(fn: predicate)\n        |> keys()\n        |> keep(columns: [\"_value\"])",
 						Start: ast.Position{
 							Column: 11,
-							Line:   31,
+							Line:   33,
 						},
 					},
 				},
@@ -2079,13 +2081,13 @@ var pkgAST = &ast.Package{
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 24,
-													Line:   32,
+													Line:   34,
 												},
 												File:   "v1.flux",
 												Source: "bucket: bucket",
 												Start: ast.Position{
 													Column: 10,
-													Line:   32,
+													Line:   34,
 												},
 											},
 										},
@@ -2095,13 +2097,13 @@ var pkgAST = &ast.Package{
 												Loc: &ast.SourceLocation{
 													End: ast.Position{
 														Column: 24,
-														Line:   32,
+														Line:   34,
 													},
 													File:   "v1.flux",
 													Source: "bucket: bucket",
 													Start: ast.Position{
 														Column: 10,
-														Line:   32,
+														Line:   34,
 													},
 												},
 											},
@@ -2111,13 +2113,13 @@ var pkgAST = &ast.Package{
 													Loc: &ast.SourceLocation{
 														End: ast.Position{
 															Column: 16,
-															Line:   32,
+															Line:   34,
 														},
 														File:   "v1.flux",
 														Source: "bucket",
 														Start: ast.Position{
 															Column: 10,
-															Line:   32,
+															Line:   34,
 														},
 													},
 												},
@@ -2129,13 +2131,13 @@ var pkgAST = &ast.Package{
 													Loc: &ast.SourceLocation{
 														End: ast.Position{
 															Column: 24,
-															Line:   32,
+															Line:   34,
 														},
 														File:   "v1.flux",
 														Source: "bucket",
 														Start: ast.Position{
 															Column: 18,
-															Line:   32,
+															Line:   34,
 														},
 													},
 												},
@@ -2148,13 +2150,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 25,
-												Line:   32,
+												Line:   34,
 											},
 											File:   "v1.flux",
 											Source: "from(bucket: bucket)",
 											Start: ast.Position{
 												Column: 5,
-												Line:   32,
+												Line:   34,
 											},
 										},
 									},
@@ -2164,13 +2166,13 @@ var pkgAST = &ast.Package{
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 9,
-													Line:   32,
+													Line:   34,
 												},
 												File:   "v1.flux",
 												Source: "from",
 												Start: ast.Position{
 													Column: 5,
-													Line:   32,
+													Line:   34,
 												},
 											},
 										},
@@ -2182,13 +2184,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 31,
-											Line:   33,
+											Line:   35,
 										},
 										File:   "v1.flux",
 										Source: "from(bucket: bucket)\n        |> range(start: start)",
 										Start: ast.Position{
 											Column: 5,
-											Line:   32,
+											Line:   34,
 										},
 									},
 								},
@@ -2199,13 +2201,13 @@ var pkgAST = &ast.Package{
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 30,
-													Line:   33,
+													Line:   35,
 												},
 												File:   "v1.flux",
 												Source: "start: start",
 												Start: ast.Position{
 													Column: 18,
-													Line:   33,
+													Line:   35,
 												},
 											},
 										},
@@ -2215,13 +2217,13 @@ var pkgAST = &ast.Package{
 												Loc: &ast.SourceLocation{
 													End: ast.Position{
 														Column: 30,
-														Line:   33,
+														Line:   35,
 													},
 													File:   "v1.flux",
 													Source: "start: start",
 													Start: ast.Position{
 														Column: 18,
-														Line:   33,
+														Line:   35,
 													},
 												},
 											},
@@ -2231,13 +2233,13 @@ var pkgAST = &ast.Package{
 													Loc: &ast.SourceLocation{
 														End: ast.Position{
 															Column: 23,
-															Line:   33,
+															Line:   35,
 														},
 														File:   "v1.flux",
 														Source: "start",
 														Start: ast.Position{
 															Column: 18,
-															Line:   33,
+															Line:   35,
 														},
 													},
 												},
@@ -2249,13 +2251,13 @@ var pkgAST = &ast.Package{
 													Loc: &ast.SourceLocation{
 														End: ast.Position{
 															Column: 30,
-															Line:   33,
+															Line:   35,
 														},
 														File:   "v1.flux",
 														Source: "start",
 														Start: ast.Position{
 															Column: 25,
-															Line:   33,
+															Line:   35,
 														},
 													},
 												},
@@ -2268,13 +2270,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 31,
-												Line:   33,
+												Line:   35,
 											},
 											File:   "v1.flux",
 											Source: "range(start: start)",
 											Start: ast.Position{
 												Column: 12,
-												Line:   33,
+												Line:   35,
 											},
 										},
 									},
@@ -2284,13 +2286,13 @@ var pkgAST = &ast.Package{
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 17,
-													Line:   33,
+													Line:   35,
 												},
 												File:   "v1.flux",
 												Source: "range",
 												Start: ast.Position{
 													Column: 12,
-													Line:   33,
+													Line:   35,
 												},
 											},
 										},
@@ -2303,13 +2305,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 33,
-										Line:   34,
+										Line:   36,
 									},
 									File:   "v1.flux",
 									Source: "from(bucket: bucket)\n        |> range(start: start)\n        |> filter(fn: predicate)",
 									Start: ast.Position{
 										Column: 5,
-										Line:   32,
+										Line:   34,
 									},
 								},
 							},
@@ -2320,13 +2322,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 32,
-												Line:   34,
+												Line:   36,
 											},
 											File:   "v1.flux",
 											Source: "fn: predicate",
 											Start: ast.Position{
 												Column: 19,
-												Line:   34,
+												Line:   36,
 											},
 										},
 									},
@@ -2336,13 +2338,13 @@ var pkgAST = &ast.Package{
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 32,
-													Line:   34,
+													Line:   36,
 												},
 												File:   "v1.flux",
 												Source: "fn: predicate",
 												Start: ast.Position{
 													Column: 19,
-													Line:   34,
+													Line:   36,
 												},
 											},
 										},
@@ -2352,13 +2354,13 @@ var pkgAST = &ast.Package{
 												Loc: &ast.SourceLocation{
 													End: ast.Position{
 														Column: 21,
-														Line:   34,
+														Line:   36,
 													},
 													File:   "v1.flux",
 													Source: "fn",
 													Start: ast.Position{
 														Column: 19,
-														Line:   34,
+														Line:   36,
 													},
 												},
 											},
@@ -2370,13 +2372,13 @@ var pkgAST = &ast.Package{
 												Loc: &ast.SourceLocation{
 													End: ast.Position{
 														Column: 32,
-														Line:   34,
+														Line:   36,
 													},
 													File:   "v1.flux",
 													Source: "predicate",
 													Start: ast.Position{
 														Column: 23,
-														Line:   34,
+														Line:   36,
 													},
 												},
 											},
@@ -2389,13 +2391,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 33,
-											Line:   34,
+											Line:   36,
 										},
 										File:   "v1.flux",
 										Source: "filter(fn: predicate)",
 										Start: ast.Position{
 											Column: 12,
-											Line:   34,
+											Line:   36,
 										},
 									},
 								},
@@ -2405,13 +2407,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 18,
-												Line:   34,
+												Line:   36,
 											},
 											File:   "v1.flux",
 											Source: "filter",
 											Start: ast.Position{
 												Column: 12,
-												Line:   34,
+												Line:   36,
 											},
 										},
 									},
@@ -2424,13 +2426,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 18,
-									Line:   35,
+									Line:   37,
 								},
 								File:   "v1.flux",
 								Source: "from(bucket: bucket)\n        |> range(start: start)\n        |> filter(fn: predicate)\n        |> keys()",
 								Start: ast.Position{
 									Column: 5,
-									Line:   32,
+									Line:   34,
 								},
 							},
 						},
@@ -2441,13 +2443,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 18,
-										Line:   35,
+										Line:   37,
 									},
 									File:   "v1.flux",
 									Source: "keys()",
 									Start: ast.Position{
 										Column: 12,
-										Line:   35,
+										Line:   37,
 									},
 								},
 							},
@@ -2457,13 +2459,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 16,
-											Line:   35,
+											Line:   37,
 										},
 										File:   "v1.flux",
 										Source: "keys",
 										Start: ast.Position{
 											Column: 12,
-											Line:   35,
+											Line:   37,
 										},
 									},
 								},
@@ -2476,13 +2478,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 37,
-								Line:   36,
+								Line:   38,
 							},
 							File:   "v1.flux",
 							Source: "from(bucket: bucket)\n        |> range(start: start)\n        |> filter(fn: predicate)\n        |> keys()\n        |> keep(columns: [\"_value\"])",
 							Start: ast.Position{
 								Column: 5,
-								Line:   32,
+								Line:   34,
 							},
 						},
 					},
@@ -2493,13 +2495,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 36,
-										Line:   36,
+										Line:   38,
 									},
 									File:   "v1.flux",
 									Source: "columns: [\"_value\"]",
 									Start: ast.Position{
 										Column: 17,
-										Line:   36,
+										Line:   38,
 									},
 								},
 							},
@@ -2509,13 +2511,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 36,
-											Line:   36,
+											Line:   38,
 										},
 										File:   "v1.flux",
 										Source: "columns: [\"_value\"]",
 										Start: ast.Position{
 											Column: 17,
-											Line:   36,
+											Line:   38,
 										},
 									},
 								},
@@ -2525,13 +2527,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 24,
-												Line:   36,
+												Line:   38,
 											},
 											File:   "v1.flux",
 											Source: "columns",
 											Start: ast.Position{
 												Column: 17,
-												Line:   36,
+												Line:   38,
 											},
 										},
 									},
@@ -2543,13 +2545,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 36,
-												Line:   36,
+												Line:   38,
 											},
 											File:   "v1.flux",
 											Source: "[\"_value\"]",
 											Start: ast.Position{
 												Column: 26,
-												Line:   36,
+												Line:   38,
 											},
 										},
 									},
@@ -2559,13 +2561,13 @@ var pkgAST = &ast.Package{
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 35,
-													Line:   36,
+													Line:   38,
 												},
 												File:   "v1.flux",
 												Source: "\"_value\"",
 												Start: ast.Position{
 													Column: 27,
-													Line:   36,
+													Line:   38,
 												},
 											},
 										},
@@ -2579,13 +2581,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 37,
-									Line:   36,
+									Line:   38,
 								},
 								File:   "v1.flux",
 								Source: "keep(columns: [\"_value\"])",
 								Start: ast.Position{
 									Column: 12,
-									Line:   36,
+									Line:   38,
 								},
 							},
 						},
@@ -2595,13 +2597,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 16,
-										Line:   36,
+										Line:   38,
 									},
 									File:   "v1.flux",
 									Source: "keep",
 									Start: ast.Position{
 										Column: 12,
-										Line:   36,
+										Line:   38,
 									},
 								},
 							},
@@ -2615,13 +2617,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 18,
-								Line:   31,
+								Line:   33,
 							},
 							File:   "v1.flux",
 							Source: "bucket",
 							Start: ast.Position{
 								Column: 12,
-								Line:   31,
+								Line:   33,
 							},
 						},
 					},
@@ -2631,13 +2633,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 18,
-									Line:   31,
+									Line:   33,
 								},
 								File:   "v1.flux",
 								Source: "bucket",
 								Start: ast.Position{
 									Column: 12,
-									Line:   31,
+									Line:   33,
 								},
 							},
 						},
@@ -2650,13 +2652,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 41,
-								Line:   31,
+								Line:   33,
 							},
 							File:   "v1.flux",
 							Source: "predicate=(r) => true",
 							Start: ast.Position{
 								Column: 20,
-								Line:   31,
+								Line:   33,
 							},
 						},
 					},
@@ -2666,13 +2668,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 29,
-									Line:   31,
+									Line:   33,
 								},
 								File:   "v1.flux",
 								Source: "predicate",
 								Start: ast.Position{
 									Column: 20,
-									Line:   31,
+									Line:   33,
 								},
 							},
 						},
@@ -2684,13 +2686,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 41,
-									Line:   31,
+									Line:   33,
 								},
 								File:   "v1.flux",
 								Source: "(r) => true",
 								Start: ast.Position{
 									Column: 30,
-									Line:   31,
+									Line:   33,
 								},
 							},
 						},
@@ -2700,13 +2702,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 41,
-										Line:   31,
+										Line:   33,
 									},
 									File:   "v1.flux",
 									Source: "true",
 									Start: ast.Position{
 										Column: 37,
-										Line:   31,
+										Line:   33,
 									},
 								},
 							},
@@ -2718,13 +2720,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 32,
-										Line:   31,
+										Line:   33,
 									},
 									File:   "v1.flux",
 									Source: "r",
 									Start: ast.Position{
 										Column: 31,
-										Line:   31,
+										Line:   33,
 									},
 								},
 							},
@@ -2734,13 +2736,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 32,
-											Line:   31,
+											Line:   33,
 										},
 										File:   "v1.flux",
 										Source: "r",
 										Start: ast.Position{
 											Column: 31,
-											Line:   31,
+											Line:   33,
 										},
 									},
 								},
@@ -2755,13 +2757,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 53,
-								Line:   31,
+								Line:   33,
 							},
 							File:   "v1.flux",
 							Source: "start=-30d",
 							Start: ast.Position{
 								Column: 43,
-								Line:   31,
+								Line:   33,
 							},
 						},
 					},
@@ -2771,13 +2773,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 48,
-									Line:   31,
+									Line:   33,
 								},
 								File:   "v1.flux",
 								Source: "start",
 								Start: ast.Position{
 									Column: 43,
-									Line:   31,
+									Line:   33,
 								},
 							},
 						},
@@ -2790,13 +2792,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 53,
-										Line:   31,
+										Line:   33,
 									},
 									File:   "v1.flux",
 									Source: "30d",
 									Start: ast.Position{
 										Column: 50,
-										Line:   31,
+										Line:   33,
 									},
 								},
 							},
@@ -2810,13 +2812,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 53,
-									Line:   31,
+									Line:   33,
 								},
 								File:   "v1.flux",
 								Source: "-30d",
 								Start: ast.Position{
 									Column: 49,
-									Line:   31,
+									Line:   33,
 								},
 							},
 						},
@@ -2830,13 +2832,13 @@ var pkgAST = &ast.Package{
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
 						Column: 77,
-						Line:   40,
+						Line:   42,
 					},
 					File:   "v1.flux",
 					Source: "measurementTagKeys = (bucket, measurement) =>\n    tagKeys(bucket: bucket, predicate: (r) => r._measurement == measurement)",
 					Start: ast.Position{
 						Column: 1,
-						Line:   39,
+						Line:   41,
 					},
 				},
 			},
@@ -2846,13 +2848,13 @@ var pkgAST = &ast.Package{
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
 							Column: 19,
-							Line:   39,
+							Line:   41,
 						},
 						File:   "v1.flux",
 						Source: "measurementTagKeys",
 						Start: ast.Position{
 							Column: 1,
-							Line:   39,
+							Line:   41,
 						},
 					},
 				},
@@ -2864,13 +2866,13 @@ var pkgAST = &ast.Package{
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
 							Column: 77,
-							Line:   40,
+							Line:   42,
 						},
 						File:   "v1.flux",
 						Source: "(bucket, measurement) =>\n    tagKeys(bucket: bucket, predicate: (r) => r._measurement == measurement)",
 						Start: ast.Position{
 							Column: 22,
-							Line:   39,
+							Line:   41,
 						},
 					},
 				},
@@ -2881,13 +2883,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 76,
-									Line:   40,
+									Line:   42,
 								},
 								File:   "v1.flux",
 								Source: "bucket: bucket, predicate: (r) => r._measurement == measurement",
 								Start: ast.Position{
 									Column: 13,
-									Line:   40,
+									Line:   42,
 								},
 							},
 						},
@@ -2897,13 +2899,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 27,
-										Line:   40,
+										Line:   42,
 									},
 									File:   "v1.flux",
 									Source: "bucket: bucket",
 									Start: ast.Position{
 										Column: 13,
-										Line:   40,
+										Line:   42,
 									},
 								},
 							},
@@ -2913,13 +2915,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 19,
-											Line:   40,
+											Line:   42,
 										},
 										File:   "v1.flux",
 										Source: "bucket",
 										Start: ast.Position{
 											Column: 13,
-											Line:   40,
+											Line:   42,
 										},
 									},
 								},
@@ -2931,13 +2933,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 27,
-											Line:   40,
+											Line:   42,
 										},
 										File:   "v1.flux",
 										Source: "bucket",
 										Start: ast.Position{
 											Column: 21,
-											Line:   40,
+											Line:   42,
 										},
 									},
 								},
@@ -2949,13 +2951,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 76,
-										Line:   40,
+										Line:   42,
 									},
 									File:   "v1.flux",
 									Source: "predicate: (r) => r._measurement == measurement",
 									Start: ast.Position{
 										Column: 29,
-										Line:   40,
+										Line:   42,
 									},
 								},
 							},
@@ -2965,13 +2967,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 38,
-											Line:   40,
+											Line:   42,
 										},
 										File:   "v1.flux",
 										Source: "predicate",
 										Start: ast.Position{
 											Column: 29,
-											Line:   40,
+											Line:   42,
 										},
 									},
 								},
@@ -2983,13 +2985,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 76,
-											Line:   40,
+											Line:   42,
 										},
 										File:   "v1.flux",
 										Source: "(r) => r._measurement == measurement",
 										Start: ast.Position{
 											Column: 40,
-											Line:   40,
+											Line:   42,
 										},
 									},
 								},
@@ -2999,13 +3001,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 76,
-												Line:   40,
+												Line:   42,
 											},
 											File:   "v1.flux",
 											Source: "r._measurement == measurement",
 											Start: ast.Position{
 												Column: 47,
-												Line:   40,
+												Line:   42,
 											},
 										},
 									},
@@ -3015,13 +3017,13 @@ var pkgAST = &ast.Package{
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 61,
-													Line:   40,
+													Line:   42,
 												},
 												File:   "v1.flux",
 												Source: "r._measurement",
 												Start: ast.Position{
 													Column: 47,
-													Line:   40,
+													Line:   42,
 												},
 											},
 										},
@@ -3031,13 +3033,13 @@ var pkgAST = &ast.Package{
 												Loc: &ast.SourceLocation{
 													End: ast.Position{
 														Column: 48,
-														Line:   40,
+														Line:   42,
 													},
 													File:   "v1.flux",
 													Source: "r",
 													Start: ast.Position{
 														Column: 47,
-														Line:   40,
+														Line:   42,
 													},
 												},
 											},
@@ -3049,13 +3051,13 @@ var pkgAST = &ast.Package{
 												Loc: &ast.SourceLocation{
 													End: ast.Position{
 														Column: 61,
-														Line:   40,
+														Line:   42,
 													},
 													File:   "v1.flux",
 													Source: "_measurement",
 													Start: ast.Position{
 														Column: 49,
-														Line:   40,
+														Line:   42,
 													},
 												},
 											},
@@ -3069,13 +3071,13 @@ var pkgAST = &ast.Package{
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 76,
-													Line:   40,
+													Line:   42,
 												},
 												File:   "v1.flux",
 												Source: "measurement",
 												Start: ast.Position{
 													Column: 65,
-													Line:   40,
+													Line:   42,
 												},
 											},
 										},
@@ -3088,13 +3090,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 42,
-												Line:   40,
+												Line:   42,
 											},
 											File:   "v1.flux",
 											Source: "r",
 											Start: ast.Position{
 												Column: 41,
-												Line:   40,
+												Line:   42,
 											},
 										},
 									},
@@ -3104,13 +3106,13 @@ var pkgAST = &ast.Package{
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 42,
-													Line:   40,
+													Line:   42,
 												},
 												File:   "v1.flux",
 												Source: "r",
 												Start: ast.Position{
 													Column: 41,
-													Line:   40,
+													Line:   42,
 												},
 											},
 										},
@@ -3126,13 +3128,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 77,
-								Line:   40,
+								Line:   42,
 							},
 							File:   "v1.flux",
 							Source: "tagKeys(bucket: bucket, predicate: (r) => r._measurement == measurement)",
 							Start: ast.Position{
 								Column: 5,
-								Line:   40,
+								Line:   42,
 							},
 						},
 					},
@@ -3142,13 +3144,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 12,
-									Line:   40,
+									Line:   42,
 								},
 								File:   "v1.flux",
 								Source: "tagKeys",
 								Start: ast.Position{
 									Column: 5,
-									Line:   40,
+									Line:   42,
 								},
 							},
 						},
@@ -3161,13 +3163,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 29,
-								Line:   39,
+								Line:   41,
 							},
 							File:   "v1.flux",
 							Source: "bucket",
 							Start: ast.Position{
 								Column: 23,
-								Line:   39,
+								Line:   41,
 							},
 						},
 					},
@@ -3177,13 +3179,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 29,
-									Line:   39,
+									Line:   41,
 								},
 								File:   "v1.flux",
 								Source: "bucket",
 								Start: ast.Position{
 									Column: 23,
-									Line:   39,
+									Line:   41,
 								},
 							},
 						},
@@ -3196,13 +3198,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 42,
-								Line:   39,
+								Line:   41,
 							},
 							File:   "v1.flux",
 							Source: "measurement",
 							Start: ast.Position{
 								Column: 31,
-								Line:   39,
+								Line:   41,
 							},
 						},
 					},
@@ -3212,13 +3214,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 42,
-									Line:   39,
+									Line:   41,
 								},
 								File:   "v1.flux",
 								Source: "measurement",
 								Start: ast.Position{
 									Column: 31,
-									Line:   39,
+									Line:   41,
 								},
 							},
 						},
@@ -3233,13 +3235,13 @@ var pkgAST = &ast.Package{
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
 						Column: 51,
-						Line:   44,
+						Line:   46,
 					},
 					File:   "v1.flux",
 					Source: "measurements = (bucket) =>\n    tagValues(bucket: bucket, tag: \"_measurement\")",
 					Start: ast.Position{
 						Column: 1,
-						Line:   43,
+						Line:   45,
 					},
 				},
 			},
@@ -3249,13 +3251,13 @@ var pkgAST = &ast.Package{
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
 							Column: 13,
-							Line:   43,
+							Line:   45,
 						},
 						File:   "v1.flux",
 						Source: "measurements",
 						Start: ast.Position{
 							Column: 1,
-							Line:   43,
+							Line:   45,
 						},
 					},
 				},
@@ -3267,13 +3269,13 @@ var pkgAST = &ast.Package{
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
 							Column: 51,
-							Line:   44,
+							Line:   46,
 						},
 						File:   "v1.flux",
 						Source: "(bucket) =>\n    tagValues(bucket: bucket, tag: \"_measurement\")",
 						Start: ast.Position{
 							Column: 16,
-							Line:   43,
+							Line:   45,
 						},
 					},
 				},
@@ -3284,13 +3286,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 50,
-									Line:   44,
+									Line:   46,
 								},
 								File:   "v1.flux",
 								Source: "bucket: bucket, tag: \"_measurement\"",
 								Start: ast.Position{
 									Column: 15,
-									Line:   44,
+									Line:   46,
 								},
 							},
 						},
@@ -3300,13 +3302,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 29,
-										Line:   44,
+										Line:   46,
 									},
 									File:   "v1.flux",
 									Source: "bucket: bucket",
 									Start: ast.Position{
 										Column: 15,
-										Line:   44,
+										Line:   46,
 									},
 								},
 							},
@@ -3316,13 +3318,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 21,
-											Line:   44,
+											Line:   46,
 										},
 										File:   "v1.flux",
 										Source: "bucket",
 										Start: ast.Position{
 											Column: 15,
-											Line:   44,
+											Line:   46,
 										},
 									},
 								},
@@ -3334,13 +3336,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 29,
-											Line:   44,
+											Line:   46,
 										},
 										File:   "v1.flux",
 										Source: "bucket",
 										Start: ast.Position{
 											Column: 23,
-											Line:   44,
+											Line:   46,
 										},
 									},
 								},
@@ -3352,13 +3354,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 50,
-										Line:   44,
+										Line:   46,
 									},
 									File:   "v1.flux",
 									Source: "tag: \"_measurement\"",
 									Start: ast.Position{
 										Column: 31,
-										Line:   44,
+										Line:   46,
 									},
 								},
 							},
@@ -3368,13 +3370,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 34,
-											Line:   44,
+											Line:   46,
 										},
 										File:   "v1.flux",
 										Source: "tag",
 										Start: ast.Position{
 											Column: 31,
-											Line:   44,
+											Line:   46,
 										},
 									},
 								},
@@ -3386,13 +3388,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 50,
-											Line:   44,
+											Line:   46,
 										},
 										File:   "v1.flux",
 										Source: "\"_measurement\"",
 										Start: ast.Position{
 											Column: 36,
-											Line:   44,
+											Line:   46,
 										},
 									},
 								},
@@ -3405,13 +3407,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 51,
-								Line:   44,
+								Line:   46,
 							},
 							File:   "v1.flux",
 							Source: "tagValues(bucket: bucket, tag: \"_measurement\")",
 							Start: ast.Position{
 								Column: 5,
-								Line:   44,
+								Line:   46,
 							},
 						},
 					},
@@ -3421,13 +3423,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 14,
-									Line:   44,
+									Line:   46,
 								},
 								File:   "v1.flux",
 								Source: "tagValues",
 								Start: ast.Position{
 									Column: 5,
-									Line:   44,
+									Line:   46,
 								},
 							},
 						},
@@ -3440,13 +3442,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 23,
-								Line:   43,
+								Line:   45,
 							},
 							File:   "v1.flux",
 							Source: "bucket",
 							Start: ast.Position{
 								Column: 17,
-								Line:   43,
+								Line:   45,
 							},
 						},
 					},
@@ -3456,13 +3458,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 23,
-									Line:   43,
+									Line:   45,
 								},
 								File:   "v1.flux",
 								Source: "bucket",
 								Start: ast.Position{
 									Column: 17,
-									Line:   43,
+									Line:   45,
 								},
 							},
 						},
@@ -3513,3 +3515,16 @@ var pkgAST = &ast.Package{
 	Package: "v1",
 	Path:    "influxdata/influxdb/v1",
 }
+var pkgComments = docs.Comments{
+	Package: "",
+	Values: map[string]string{
+		"databases":            "Databases returns the list of available databases, it has no parameters.",
+		"fieldsAsCols":         "fieldsAsCols is a special application of pivot that will automatically align fields within each measurement that have the same timestamp.",
+		"json":                 "Json parses an InfluxDB 1.x json result into a table stream.",
+		"measurementTagKeys":   "MeasurementTagKeys returns the list of tag keys for a specific measurement.",
+		"measurementTagValues": "MeasurementTagValues returns a single table with a single column \"_value\" that contains the\nThe return value is always a single table with a single column \"_value\".",
+		"measurements":         "Measurements returns the list of measurements in a specific bucket.",
+		"tagKeys":              "TagKeys returns the list of tag keys for all series that match the predicate.\nThe return value is always a single table with a single column \"_value\".\nWhen the storage engine can look up tag keys in its index, the lookup replaces reading the series.",
+		"tagValues":            "TagValues returns the unique values for a given tag.\nThe return value is always a single table with a single column \"_value\".\nWhen the storage engine can look up tag values in its index, the lookup replaces reading the series.",
+	},
+}
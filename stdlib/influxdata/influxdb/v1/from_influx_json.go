@@ -6,10 +6,10 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
-	"os"
 	"strings"
 
 	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/dependencies"
 	"github.com/influxdata/flux/execute"
 	"github.com/influxdata/flux/influxql"
 	"github.com/influxdata/flux/plan"
@@ -58,12 +58,6 @@ func createFromInfluxJSONOpSpec(args flux.Arguments, a *flux.Administration) (fl
 		return nil, errors.New("must provide exactly one of the parameters json or file")
 	}
 
-	if spec.File != "" {
-		if _, err := os.Stat(spec.File); err != nil {
-			return nil, errors.Wrapf(err, "failed to stat json file: %s", spec.File)
-		}
-	}
-
 	return spec, nil
 }
 
@@ -81,6 +75,13 @@ func (s *FromInfluxJSONOpSpec) Kind() flux.OperationKind {
 	return FromInfluxJSONKind
 }
 
+func (s *FromInfluxJSONOpSpec) Resources() []flux.Resource {
+	if s.File == "" {
+		return nil
+	}
+	return []flux.Resource{{Kind: flux.FileResource, Name: s.File, Mode: flux.ReadAccess}}
+}
+
 // FromInfluxJSONProcedureSpec describes the `fromInfluxJSON` prodecure
 type FromInfluxJSONProcedureSpec struct {
 	plan.DefaultCost
@@ -119,7 +120,7 @@ func createFromInfluxJSONSource(prSpec plan.ProcedureSpec, dsid execute.DatasetI
 	var jsonReader io.Reader
 
 	if spec.File != "" {
-		f, err := os.Open(spec.File)
+		f, err := dependencies.Get(a.Context()).Filesystem.Open(spec.File)
 		if err != nil {
 			return nil, err
 		}
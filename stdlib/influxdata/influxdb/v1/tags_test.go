@@ -0,0 +1,268 @@
+package v1_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/stdlib/influxdata/influxdb"
+	"github.com/influxdata/flux/stdlib/influxdata/influxdb/memstore"
+)
+
+type mockMetaClient struct {
+	values    []string
+	databases []influxdb.Database
+	specs     []interface{}
+}
+
+func (c *mockMetaClient) Buckets(ctx context.Context) ([]influxdb.Bucket, error) {
+	return nil, nil
+}
+
+func (c *mockMetaClient) Databases(ctx context.Context) ([]influxdb.Database, error) {
+	return c.databases, nil
+}
+
+func (c *mockMetaClient) TagKeys(ctx context.Context, spec influxdb.ReadFilterSpec) ([]string, error) {
+	c.specs = append(c.specs, spec)
+	return c.values, nil
+}
+
+func (c *mockMetaClient) TagValues(ctx context.Context, spec influxdb.TagValuesSpec) ([]string, error) {
+	c.specs = append(c.specs, spec)
+	return c.values, nil
+}
+
+func TestMetaClient_Run(t *testing.T) {
+	now := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	bounds := execute.Bounds{
+		Start: execute.Time(now.Add(-30 * 24 * time.Hour).UnixNano()),
+		Stop:  execute.Time(now.UnixNano()),
+	}
+	valueCols := []flux.ColMeta{{Label: "_value", Type: flux.TString}}
+
+	testCases := []struct {
+		name          string
+		query         string
+		client        *mockMetaClient
+		want          []*executetest.Table
+		wantSpec      interface{}
+		wantPredicate bool
+	}{
+		{
+			name: "tagKeys",
+			query: `import "influxdata/influxdb/v1"
+v1.tagKeys(bucket: "telegraf")`,
+			client: &mockMetaClient{values: []string{"_field", "_measurement", "host"}},
+			want: []*executetest.Table{{
+				ColMeta: valueCols,
+				Data:    [][]interface{}{{"_field"}, {"_measurement"}, {"host"}},
+			}},
+			wantSpec: influxdb.ReadFilterSpec{
+				Bucket: "telegraf",
+				Bounds: bounds,
+			},
+		},
+		{
+			name: "measurements",
+			query: `import "influxdata/influxdb/v1"
+v1.measurements(bucket: "telegraf")`,
+			client: &mockMetaClient{values: []string{"cpu", "mem"}},
+			want: []*executetest.Table{{
+				ColMeta: valueCols,
+				Data:    [][]interface{}{{"cpu"}, {"mem"}},
+			}},
+			wantSpec: influxdb.TagValuesSpec{
+				ReadFilterSpec: influxdb.ReadFilterSpec{
+					Bucket: "telegraf",
+					Bounds: bounds,
+				},
+				Tag: "_measurement",
+			},
+		},
+		{
+			name: "measurementTagValues",
+			query: `import "influxdata/influxdb/v1"
+v1.measurementTagValues(bucket: "telegraf", measurement: "cpu", tag: "host")`,
+			client: &mockMetaClient{values: []string{"a", "b"}},
+			want: []*executetest.Table{{
+				ColMeta: valueCols,
+				Data:    [][]interface{}{{"a"}, {"b"}},
+			}},
+			wantPredicate: true,
+		},
+		{
+			name: "databases",
+			query: `import "influxdata/influxdb/v1"
+v1.databases()`,
+			client: &mockMetaClient{databases: []influxdb.Database{{
+				Name:            "telegraf",
+				RetentionPolicy: "autogen",
+				Default:         true,
+				BucketID:        "0000000000000001",
+				OrganizationID:  "0000000000000002",
+			}}},
+			want: []*executetest.Table{{
+				ColMeta: []flux.ColMeta{
+					{Label: "organizationID", Type: flux.TString},
+					{Label: "databaseName", Type: flux.TString},
+					{Label: "retentionPolicy", Type: flux.TString},
+					{Label: "retentionPeriod", Type: flux.TInt},
+					{Label: "default", Type: flux.TBool},
+					{Label: "bucketID", Type: flux.TString},
+				},
+				Data: [][]interface{}{
+					{"0000000000000002", "telegraf", "autogen", int64(0), true, "0000000000000001"},
+				},
+			}},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			// The tag functions are only looked up with the MetaClient
+			// when the storage engine declares that it can do so.
+			caps := influxdb.StorageCapabilities{Filter: true, Group: true, KeyValues: true}
+			got := executeQuery(t, tc.query, now, execute.Dependencies{
+				influxdb.MetaDependencyKey: tc.client,
+			}, plan.AddPhysicalRules(influxdb.PushDownRules(caps)...))
+
+			executetest.NormalizeTables(tc.want)
+			if !cmp.Equal(tc.want, got) {
+				t.Errorf("unexpected tables -want/+got\n%s", cmp.Diff(tc.want, got))
+			}
+			if tc.wantSpec != nil {
+				// The predicate of the filter is always pushed down, even if it is the default one.
+				opt := cmpopts.IgnoreFields(influxdb.ReadFilterSpec{}, "Predicate")
+				if !cmp.Equal([]interface{}{tc.wantSpec}, tc.client.specs, opt) {
+					t.Errorf("unexpected specs -want/+got\n%s", cmp.Diff([]interface{}{tc.wantSpec}, tc.client.specs, opt))
+				}
+			}
+			if tc.wantPredicate {
+				if len(tc.client.specs) != 1 || tc.client.specs[0].(influxdb.TagValuesSpec).Predicate == nil {
+					t.Errorf("expected a predicate, got specs %v", tc.client.specs)
+				}
+			}
+		})
+	}
+}
+
+func TestTags_WithoutMetaClient(t *testing.T) {
+	store := memstore.New()
+	if err := store.Load("telegraf", strings.NewReader(`cpu,host=b usage=3 15
+cpu,host=a usage=1 10
+mem,host=a free=10 10
+`)); err != nil {
+		t.Fatal(err)
+	}
+	// The points are written at the epoch, the tag functions search back from now.
+	now := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	valueCols := []flux.ColMeta{{Label: "_value", Type: flux.TString}}
+
+	testCases := []struct {
+		name  string
+		query string
+		want  []*executetest.Table
+	}{
+		{
+			name: "tagValues",
+			query: `import "influxdata/influxdb/v1"
+v1.tagValues(bucket: "telegraf", tag: "host", start: -50y)`,
+			want: []*executetest.Table{{
+				ColMeta: valueCols,
+				Data:    [][]interface{}{{"a"}, {"b"}},
+			}},
+		},
+		{
+			name: "tagValues with predicate",
+			query: `import "influxdata/influxdb/v1"
+v1.tagValues(bucket: "telegraf", tag: "_measurement", predicate: (r) => r.host == "b", start: -50y)`,
+			want: []*executetest.Table{{
+				ColMeta: valueCols,
+				Data:    [][]interface{}{{"cpu"}},
+			}},
+		},
+		{
+			name: "tagKeys",
+			query: `import "influxdata/influxdb/v1"
+v1.tagKeys(bucket: "telegraf", predicate: (r) => r._measurement == "mem", start: -50y)`,
+			// keys() reports the group key of every series that was read,
+			// including the series that the predicate emptied.
+			want: []*executetest.Table{{
+				ColMeta: valueCols,
+				Data: [][]interface{}{
+					{"_start"}, {"_stop"}, {"_field"}, {"_measurement"}, {"host"},
+					{"_start"}, {"_stop"}, {"_field"}, {"_measurement"}, {"host"},
+					{"_start"}, {"_stop"}, {"_field"}, {"_measurement"}, {"host"},
+				},
+			}},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			// Only the StorageReader is provided, so the tag functions read the series.
+			got := executeQuery(t, tc.query, now, execute.Dependencies{
+				influxdb.StorageDependencyKey: store,
+			}, plan.AddPhysicalRules(influxdb.PushDownRules(influxdb.ReaderCapabilities(store))...))
+
+			executetest.NormalizeTables(tc.want)
+			if !cmp.Equal(tc.want, got) {
+				t.Errorf("unexpected tables -want/+got\n%s", cmp.Diff(tc.want, got))
+			}
+		})
+	}
+}
+
+// executeQuery compiles, plans and executes a query with the given dependencies
+// and returns the tables of all of its results.
+func executeQuery(t *testing.T, query string, now time.Time, deps execute.Dependencies, opts ...plan.PhysicalOption) []*executetest.Table {
+	t.Helper()
+
+	spec, err := flux.Compile(context.Background(), query, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lp := plan.NewLogicalPlanner()
+	initPlan, err := lp.CreateInitialPlan(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	logicalPlan, err := lp.Plan(initPlan)
+	if err != nil {
+		t.Fatal(err)
+	}
+	physicalPlan, err := plan.NewPhysicalPlanner(opts...).Plan(logicalPlan)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	results, err := execute.NewExecutor(deps, nil).Execute(context.Background(), physicalPlan, executetest.UnlimitedAllocator)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []*executetest.Table
+	for _, r := range results {
+		if err := r.Tables().Do(func(tbl flux.Table) error {
+			ct, err := executetest.ConvertTable(tbl)
+			if err != nil {
+				return err
+			}
+			got = append(got, ct)
+			return nil
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	executetest.NormalizeTables(got)
+	return got
+}
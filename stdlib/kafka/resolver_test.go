@@ -0,0 +1,25 @@
+package kafka
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/flux/dependencies"
+)
+
+func TestBrokerResolver(t *testing.T) {
+	r := brokerResolver{validator: dependencies.RestrictedURLs{}}
+	if _, err := r.LookupHost(context.Background(), "127.0.0.1"); err == nil {
+		t.Fatal("expected error resolving a private address, got none")
+	}
+
+	r = brokerResolver{validator: dependencies.AllowAllURLs{}}
+	addrs, err := r.LookupHost(context.Background(), "127.0.0.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"127.0.0.1"}; !cmp.Equal(want, addrs) {
+		t.Fatalf("unexpected addresses -want/+got\n%s", cmp.Diff(want, addrs))
+	}
+}
@@ -6,11 +6,15 @@ import (
 	"encoding/binary"
 	"fmt"
 	"io"
+	"net"
+	"net/url"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/cespare/xxhash"
 	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/dependencies"
 	"github.com/influxdata/flux/execute"
 	"github.com/influxdata/flux/internal/pkg/syncutil"
 	"github.com/influxdata/flux/plan"
@@ -174,6 +178,14 @@ func (ToKafkaOpSpec) Kind() flux.OperationKind {
 	return ToKafkaKind
 }
 
+func (o *ToKafkaOpSpec) Resources() []flux.Resource {
+	resources := make([]flux.Resource, len(o.Brokers))
+	for i, broker := range o.Brokers {
+		resources[i] = flux.Resource{Kind: flux.HostResource, Name: broker, Mode: flux.WriteAccess}
+	}
+	return resources
+}
+
 type ToKafkaProcedureSpec struct {
 	plan.DefaultCost
 	Spec     *ToKafkaOpSpec
@@ -221,13 +233,21 @@ func createToKafkaTransformation(id execute.DatasetID, mode execute.Accumulation
 	if !ok {
 		return nil, nil, fmt.Errorf("invalid spec type %T", spec)
 	}
+	validator := dependencies.Get(a.Context()).URLValidator
+	for _, broker := range s.Spec.Brokers {
+		if err := validator.Validate(&url.URL{Scheme: "tcp", Host: broker}); err != nil {
+			return nil, nil, err
+		}
+	}
 	cache := execute.NewTableBuilderCache(a.Allocator())
 	d := execute.NewDataset(id, mode, cache)
 	t := NewToKafkaTransformation(d, cache, s)
+	t.ctx = a.Context()
 	return t, d, nil
 }
 
 type ToKafkaTransformation struct {
+	ctx   context.Context
 	d     execute.Dataset
 	cache execute.TableBuilderCache
 	spec  *ToKafkaProcedureSpec
@@ -238,6 +258,7 @@ func (t *ToKafkaTransformation) RetractTable(id execute.DatasetID, key flux.Grou
 }
 func NewToKafkaTransformation(d execute.Dataset, cache execute.TableBuilderCache, spec *ToKafkaProcedureSpec) *ToKafkaTransformation {
 	return &ToKafkaTransformation{
+		ctx:   context.Background(),
 		d:     d,
 		cache: cache,
 		spec:  spec,
@@ -268,6 +289,31 @@ func (m *toKafkaMetric) Time() time.Time {
 	return m.t
 }
 
+// brokerResolver resolves the hosts of the brokers, and rejects the addresses
+// that the dialer of the dependencies does not connect to.
+// kafka-go connects with a dialer of its own, but to the addresses returned by its resolver,
+// including those of the brokers it discovers from the cluster.
+type brokerResolver struct {
+	validator dependencies.URLValidator
+}
+
+func (r brokerResolver) LookupHost(ctx context.Context, host string) ([]string, error) {
+	addrs, err := net.DefaultResolver.LookupHost(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+	for _, addr := range addrs {
+		// Link local addresses may have a zone.
+		if i := strings.IndexByte(addr, '%'); i >= 0 {
+			addr = addr[:i]
+		}
+		if err := dependencies.CheckAddress(r.validator, net.ParseIP(addr)); err != nil {
+			return nil, err
+		}
+	}
+	return addrs, nil
+}
+
 type idxType struct {
 	Idx  int
 	Type flux.ColType
@@ -280,8 +326,26 @@ func (t *ToKafkaTransformation) Process(id execute.DatasetID, tbl flux.Table) (e
 		Balancer:      t.spec.balancer,
 		BatchSize:     t.spec.Spec.MsgBufSize,
 		QueueCapacity: t.spec.Spec.MsgBufSize,
+		Dialer: &kafka.Dialer{
+			Timeout:   10 * time.Second,
+			DualStack: true,
+			Resolver:  brokerResolver{validator: dependencies.Get(t.ctx).URLValidator},
+		},
 	})
 
+	// The write is recorded once the writer is closed, with the messages that were sent to it.
+	write := flux.Write{
+		Function:    "kafka.to",
+		Destination: strings.Join(t.spec.Spec.Brokers, ",") + "/" + t.spec.Spec.Topic,
+	}
+	start := time.Now()
+	defer func() {
+		write.Duration, write.Status = time.Since(start), "ok"
+		if err != nil {
+			write.Status, write.Error = "failed", err.Error()
+		}
+		execute.RecordWrite(t.ctx, write)
+	}()
 	defer func() {
 		err2 := w.Close()
 		// don't overwrite current error
@@ -402,6 +466,8 @@ func (t *ToKafkaTransformation) Process(id execute.DatasetID, tbl flux.Table) (e
 			}
 			binary.LittleEndian.PutUint64(msgBuf[i].Key, xxhash.Sum64(v))
 			msgBuf[i].Value = v
+			write.Rows++
+			write.Bytes += int64(len(v))
 			if i == t.spec.Spec.MsgBufSize-1 {
 				if err = w.WriteMessages(context.Background(), msgBuf...); err != nil {
 					return err
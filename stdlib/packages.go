@@ -4,11 +4,22 @@ package stdlib
 
 import (
 	_ "github.com/influxdata/flux/stdlib/csv"
+	_ "github.com/influxdata/flux/stdlib/experimental"
+	_ "github.com/influxdata/flux/stdlib/experimental/aggregate"
+	_ "github.com/influxdata/flux/stdlib/experimental/anomaly"
+	_ "github.com/influxdata/flux/stdlib/experimental/bitwise"
+	_ "github.com/influxdata/flux/stdlib/experimental/inference"
+	_ "github.com/influxdata/flux/stdlib/experimental/interpolate"
+	_ "github.com/influxdata/flux/stdlib/experimental/oee"
+	_ "github.com/influxdata/flux/stdlib/experimental/sets"
 	_ "github.com/influxdata/flux/stdlib/generate"
 	_ "github.com/influxdata/flux/stdlib/http"
 	_ "github.com/influxdata/flux/stdlib/influxdata/influxdb"
 	_ "github.com/influxdata/flux/stdlib/influxdata/influxdb/v1"
 	_ "github.com/influxdata/flux/stdlib/kafka"
+	_ "github.com/influxdata/flux/stdlib/pagerduty"
+	_ "github.com/influxdata/flux/stdlib/runtime"
+	_ "github.com/influxdata/flux/stdlib/slack"
 	_ "github.com/influxdata/flux/stdlib/socket"
 	_ "github.com/influxdata/flux/stdlib/sql"
 	_ "github.com/influxdata/flux/stdlib/strings"
@@ -0,0 +1,153 @@
+// DO NOT EDIT: This file is autogenerated via the builtin command.
+
+package pagerduty
+
+import (
+	flux "github.com/influxdata/flux"
+	ast "github.com/influxdata/flux/ast"
+	docs "github.com/influxdata/flux/docs"
+)
+
+func init() {
+	flux.RegisterPackage(pkgAST)
+	docs.RegisterComments("pagerduty", pkgComments)
+}
+
+var pkgAST = &ast.Package{
+	BaseNode: ast.BaseNode{
+		Errors: nil,
+		Loc:    nil,
+	},
+	Files: []*ast.File{&ast.File{
+		BaseNode: ast.BaseNode{
+			Errors: nil,
+			Loc: &ast.SourceLocation{
+				End: ast.Position{
+					Column: 17,
+					Line:   8,
+				},
+				File:   "pagerduty.flux",
+				Source: "package pagerduty\n\n// sendEvent sends an event to PagerDuty and returns the HTTP status code of the response.\nbuiltin sendEvent\n\n// endpoint sends an event for every row of its input.\n// The mapping function returns the arguments of sendEvent for the event of a row.\nbuiltin endpoint",
+				Start: ast.Position{
+					Column: 1,
+					Line:   1,
+				},
+			},
+		},
+		Body: []ast.Statement{&ast.BuiltinStatement{
+			BaseNode: ast.BaseNode{
+				Errors: nil,
+				Loc: &ast.SourceLocation{
+					End: ast.Position{
+						Column: 18,
+						Line:   4,
+					},
+					File:   "pagerduty.flux",
+					Source: "builtin sendEvent",
+					Start: ast.Position{
+						Column: 1,
+						Line:   4,
+					},
+				},
+			},
+			ID: &ast.Identifier{
+				BaseNode: ast.BaseNode{
+					Errors: nil,
+					Loc: &ast.SourceLocation{
+						End: ast.Position{
+							Column: 18,
+							Line:   4,
+						},
+						File:   "pagerduty.flux",
+						Source: "sendEvent",
+						Start: ast.Position{
+							Column: 9,
+							Line:   4,
+						},
+					},
+				},
+				Name: "sendEvent",
+			},
+		}, &ast.BuiltinStatement{
+			BaseNode: ast.BaseNode{
+				Errors: nil,
+				Loc: &ast.SourceLocation{
+					End: ast.Position{
+						Column: 17,
+						Line:   8,
+					},
+					File:   "pagerduty.flux",
+					Source: "builtin endpoint",
+					Start: ast.Position{
+						Column: 1,
+						Line:   8,
+					},
+				},
+			},
+			ID: &ast.Identifier{
+				BaseNode: ast.BaseNode{
+					Errors: nil,
+					Loc: &ast.SourceLocation{
+						End: ast.Position{
+							Column: 17,
+							Line:   8,
+						},
+						File:   "pagerduty.flux",
+						Source: "endpoint",
+						Start: ast.Position{
+							Column: 9,
+							Line:   8,
+						},
+					},
+				},
+				Name: "endpoint",
+			},
+		}},
+		Imports: nil,
+		Name:    "pagerduty.flux",
+		Package: &ast.PackageClause{
+			BaseNode: ast.BaseNode{
+				Errors: nil,
+				Loc: &ast.SourceLocation{
+					End: ast.Position{
+						Column: 18,
+						Line:   1,
+					},
+					File:   "pagerduty.flux",
+					Source: "package pagerduty",
+					Start: ast.Position{
+						Column: 1,
+						Line:   1,
+					},
+				},
+			},
+			Name: &ast.Identifier{
+				BaseNode: ast.BaseNode{
+					Errors: nil,
+					Loc: &ast.SourceLocation{
+						End: ast.Position{
+							Column: 18,
+							Line:   1,
+						},
+						File:   "pagerduty.flux",
+						Source: "pagerduty",
+						Start: ast.Position{
+							Column: 9,
+							Line:   1,
+						},
+					},
+				},
+				Name: "pagerduty",
+			},
+		},
+	}},
+	Package: "pagerduty",
+	Path:    "pagerduty",
+}
+var pkgComments = docs.Comments{
+	Package: "",
+	Values: map[string]string{
+		"endpoint":  "endpoint sends an event for every row of its input.\nThe mapping function returns the arguments of sendEvent for the event of a row.",
+		"sendEvent": "sendEvent sends an event to PagerDuty and returns the HTTP status code of the response.",
+	},
+}
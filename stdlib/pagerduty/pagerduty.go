@@ -0,0 +1,317 @@
+// Package pagerduty sends events to the PagerDuty Events API v2.
+package pagerduty
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/interpreter"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/semantic"
+	fhttp "github.com/influxdata/flux/stdlib/http"
+	"github.com/influxdata/flux/values"
+)
+
+const (
+	// DefaultURL is the URL of the PagerDuty Events API v2.
+	DefaultURL = "https://events.pagerduty.com/v2/enqueue"
+
+	EndpointKind = "pagerdutyEndpoint"
+)
+
+// The actions of events.
+const (
+	ActionTrigger     = "trigger"
+	ActionAcknowledge = "acknowledge"
+	ActionResolve     = "resolve"
+)
+
+var severities = map[string]bool{
+	"critical": true,
+	"error":    true,
+	"warning":  true,
+	"info":     true,
+}
+
+func init() {
+	sendEventSignature := semantic.FunctionPolySignature{
+		Parameters: map[string]semantic.PolyType{
+			"pagerdutyURL": semantic.String,
+			"routingKey":   semantic.String,
+			"eventAction":  semantic.String,
+			"dedupKey":     semantic.String,
+			"client":       semantic.String,
+			"clientURL":    semantic.String,
+			"summary":      semantic.String,
+			"source":       semantic.String,
+			"severity":     semantic.String,
+			"timestamp":    semantic.String,
+			"component":    semantic.String,
+			"group":        semantic.String,
+			"class":        semantic.String,
+		},
+		Required: semantic.LabelSet{"routingKey"},
+		Return:   semantic.Int,
+	}
+	flux.RegisterPackageValue("pagerduty", "sendEvent", values.NewContextFunction(
+		"sendEvent",
+		semantic.NewFunctionPolyType(sendEventSignature),
+		sendEvent,
+		true,
+	))
+
+	endpointSignature := semantic.FunctionPolySignature{
+		Parameters: map[string]semantic.PolyType{
+			"url": semantic.String,
+		},
+	}
+	flux.RegisterPackageValue("pagerduty", "endpoint", fhttp.NewEndpointFunction(EndpointKind, endpointSignature, createEndpointOpSpec))
+	flux.RegisterOpSpec(EndpointKind, func() flux.OperationSpec { return &EndpointOpSpec{} })
+	plan.RegisterProcedureSpecWithSideEffect(EndpointKind, newEndpointProcedure, EndpointKind)
+	execute.RegisterTransformation(EndpointKind, createEndpointTransformation)
+}
+
+// Event is an event of the Events API v2.
+type Event struct {
+	RoutingKey  string   `json:"routing_key"`
+	EventAction string   `json:"event_action"`
+	DedupKey    string   `json:"dedup_key,omitempty"`
+	Client      string   `json:"client,omitempty"`
+	ClientURL   string   `json:"client_url,omitempty"`
+	Payload     *Payload `json:"payload,omitempty"`
+}
+
+// Payload describes the incident of an event that triggers it.
+type Payload struct {
+	Summary   string `json:"summary"`
+	Source    string `json:"source"`
+	Severity  string `json:"severity"`
+	Timestamp string `json:"timestamp,omitempty"`
+	Component string `json:"component,omitempty"`
+	Group     string `json:"group,omitempty"`
+	Class     string `json:"class,omitempty"`
+}
+
+func newEvent() *Event {
+	return &Event{Payload: new(Payload)}
+}
+
+// stringFields returns the string fields of the event but its timestamp,
+// by the names of the arguments of sendEvent and of the properties of notifications that set them.
+func (e *Event) stringFields() map[string]*string {
+	return map[string]*string{
+		"routingKey":  &e.RoutingKey,
+		"eventAction": &e.EventAction,
+		"dedupKey":    &e.DedupKey,
+		"client":      &e.Client,
+		"clientURL":   &e.ClientURL,
+		"summary":     &e.Payload.Summary,
+		"source":      &e.Payload.Source,
+		"severity":    &e.Payload.Severity,
+		"component":   &e.Payload.Component,
+		"group":       &e.Payload.Group,
+		"class":       &e.Payload.Class,
+	}
+}
+
+// validate checks the event and sets its default action.
+// Events that trigger an incident must describe it, and the others must have the key of the incident.
+func (e *Event) validate() error {
+	if e.RoutingKey == "" {
+		return fmt.Errorf("the routing key of an event cannot be empty")
+	}
+	if e.EventAction == "" {
+		e.EventAction = ActionTrigger
+	}
+	switch e.EventAction {
+	case ActionTrigger:
+		p := e.Payload
+		if p == nil || p.Summary == "" || p.Source == "" || p.Severity == "" {
+			return fmt.Errorf("an event that triggers an incident must have a summary, a source and a severity")
+		}
+		if !severities[p.Severity] {
+			return fmt.Errorf(`invalid severity %q, must be "critical", "error", "warning" or "info"`, p.Severity)
+		}
+	case ActionAcknowledge, ActionResolve:
+		if e.DedupKey == "" {
+			return fmt.Errorf("an event that will %s an incident must have a dedup key", e.EventAction)
+		}
+		// The payload is only used by the events that trigger an incident.
+		e.Payload = nil
+	default:
+		return fmt.Errorf("invalid event action %q, must be %q, %q or %q", e.EventAction, ActionTrigger, ActionAcknowledge, ActionResolve)
+	}
+	return nil
+}
+
+// post sends the event and returns the status code and the body of the response.
+func post(ctx context.Context, url string, e *Event) (int, []byte, error) {
+	body, err := json.Marshal(e)
+	if err != nil {
+		return 0, nil, err
+	}
+	header := http.Header{}
+	header.Set("Content-Type", "application/json")
+	return fhttp.Post(ctx, url, header, body)
+}
+
+// checkResponse returns the error of a response of the Events API.
+func checkResponse(status int, body []byte) error {
+	if err := fhttp.CheckStatus(status); err == nil {
+		return nil
+	}
+	var resp struct {
+		Message string   `json:"message"`
+		Errors  []string `json:"errors"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil || resp.Message == "" {
+		return fhttp.CheckStatus(status)
+	}
+	return fmt.Errorf("pagerduty error: %s %v", resp.Message, resp.Errors)
+}
+
+// sendEvent sends an event and returns the status code of the response.
+func sendEvent(ctx context.Context, args values.Object) (values.Value, error) {
+	return interpreter.DoFunctionCall(func(args interpreter.Arguments) (values.Value, error) {
+		url, ok, err := args.GetString("pagerdutyURL")
+		if err != nil {
+			return nil, err
+		} else if !ok {
+			url = DefaultURL
+		}
+
+		e := newEvent()
+		for name, dst := range e.stringFields() {
+			if *dst, _, err = args.GetString(name); err != nil {
+				return nil, err
+			}
+		}
+		if e.Payload.Timestamp, _, err = args.GetString("timestamp"); err != nil {
+			return nil, err
+		}
+		if err := e.validate(); err != nil {
+			return nil, err
+		}
+
+		status, _, err := post(ctx, url, e)
+		if err != nil {
+			return nil, err
+		}
+		return values.NewInt(int64(status)), nil
+	}, args)
+}
+
+// EndpointOpSpec is the operation spec of pagerduty.endpoint, which sends the notifications as events.
+// The properties of the notifications are named as the arguments of sendEvent,
+// and their timestamp may be a time.
+type EndpointOpSpec struct {
+	fhttp.EndpointSpec
+	URL string `json:"url"`
+}
+
+func createEndpointOpSpec(args flux.Arguments) (fhttp.EndpointOpSpec, error) {
+	spec := &EndpointOpSpec{
+		EndpointSpec: fhttp.EndpointSpec{BatchSize: 1},
+	}
+	url, ok, err := args.GetString("url")
+	if err != nil {
+		return nil, err
+	} else if !ok {
+		url = DefaultURL
+	}
+	spec.URL = url
+	return spec, nil
+}
+
+func (s *EndpointOpSpec) Kind() flux.OperationKind {
+	return EndpointKind
+}
+
+func (s *EndpointOpSpec) Resources() []flux.Resource {
+	return []flux.Resource{{Kind: flux.URLResource, Name: s.URL, Mode: flux.WriteAccess}}
+}
+
+// notificationEvent returns the event of a notification.
+func notificationEvent(n values.Object) (*Event, error) {
+	e := newEvent()
+	for name, dst := range e.stringFields() {
+		var err error
+		if *dst, _, err = fhttp.NotificationString(n, name); err != nil {
+			return nil, err
+		}
+	}
+	if v, ok := n.Get("timestamp"); ok && !v.IsNull() {
+		switch v.Type().Nature() {
+		case semantic.Time:
+			e.Payload.Timestamp = v.Time().Time().Format(time.RFC3339Nano)
+		case semantic.String:
+			e.Payload.Timestamp = v.Str()
+		default:
+			return nil, fmt.Errorf("property %q of the notification must be a time or a string, got %v", "timestamp", v.Type().Nature())
+		}
+	}
+	if err := e.validate(); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// Send sends the event of every notification of a batch.
+func (s *EndpointOpSpec) Send(ctx context.Context, notifications []values.Object) error {
+	for _, n := range notifications {
+		e, err := notificationEvent(n)
+		if err != nil {
+			return err
+		}
+		status, body, err := post(ctx, s.URL, e)
+		if err != nil {
+			return err
+		}
+		if err := checkResponse(status, body); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type EndpointProcedureSpec struct {
+	plan.DefaultCost
+	Spec *EndpointOpSpec
+}
+
+func newEndpointProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	spec, ok := qs.(*EndpointOpSpec)
+	if !ok {
+		return nil, fmt.Errorf("invalid spec type %T", qs)
+	}
+	return &EndpointProcedureSpec{Spec: spec}, nil
+}
+
+func (s *EndpointProcedureSpec) Kind() plan.ProcedureKind {
+	return EndpointKind
+}
+
+func (s *EndpointProcedureSpec) Copy() plan.ProcedureSpec {
+	spec := *s.Spec
+	spec.EndpointSpec = s.Spec.EndpointSpec.Copy()
+	return &EndpointProcedureSpec{Spec: &spec}
+}
+
+func createEndpointTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	s, ok := spec.(*EndpointProcedureSpec)
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid spec type %T", spec)
+	}
+	cache := execute.NewTableBuilderCache(a.Allocator())
+	d := execute.NewDataset(id, mode, cache)
+	t, err := fhttp.NewEndpointTransformation(a.Context(), d, cache, s.Spec.EndpointSpec, s.Spec.Send)
+	if err != nil {
+		return nil, nil, err
+	}
+	return t, d, nil
+}
@@ -0,0 +1,171 @@
+package pagerduty_test
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/flux"
+	_ "github.com/influxdata/flux/builtin"
+	"github.com/influxdata/flux/dependencies"
+	"github.com/influxdata/flux/stdlib/pagerduty"
+	"github.com/influxdata/flux/values"
+)
+
+// newServer returns a server that records the bodies of its requests and responds with the status and the body.
+func newServer(status int, respBody string, bodies *[]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		*bodies = append(*bodies, string(body))
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(respBody))
+	}))
+}
+
+func TestSendEvent(t *testing.T) {
+	var bodies []string
+	server := newServer(http.StatusAccepted, `{"status": "success"}`, &bodies)
+	defer server.Close()
+
+	ctx := dependencies.Inject(context.Background(), dependencies.Unrestricted())
+	script := `
+import "pagerduty"
+
+pagerduty.sendEvent(
+	pagerdutyURL: "` + server.URL + `",
+	routingKey: "key",
+	summary: "cpu is high",
+	source: "host1",
+	severity: "critical",
+	timestamp: "2019-01-01T00:00:00Z",
+)`
+	if _, err := flux.Compile(ctx, script, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{`{"routing_key":"key","event_action":"trigger","payload":{"summary":"cpu is high","source":"host1","severity":"critical","timestamp":"2019-01-01T00:00:00Z"}}`}
+	if !cmp.Equal(want, bodies) {
+		t.Errorf("unexpected bodies -want/+got\n%s", cmp.Diff(want, bodies))
+	}
+}
+
+func TestEndpoint_Send(t *testing.T) {
+	var bodies []string
+	server := newServer(http.StatusAccepted, `{"status": "success"}`, &bodies)
+	defer server.Close()
+
+	ctx := dependencies.Inject(context.Background(), dependencies.Unrestricted())
+	spec := &pagerduty.EndpointOpSpec{URL: server.URL}
+	if err := spec.Send(ctx, []values.Object{
+		values.NewObjectWithValues(map[string]values.Value{
+			"routingKey": values.NewString("key"),
+			"summary":    values.NewString("cpu is high"),
+			"source":     values.NewString("host1"),
+			"severity":   values.NewString("warning"),
+			"timestamp":  values.NewTime(values.ConvertTime(time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC))),
+		}),
+		values.NewObjectWithValues(map[string]values.Value{
+			"routingKey":  values.NewString("key"),
+			"eventAction": values.NewString("resolve"),
+			"dedupKey":    values.NewString("host1"),
+			"summary":     values.NewString("cpu is fine"),
+		}),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		`{"routing_key":"key","event_action":"trigger","payload":{"summary":"cpu is high","source":"host1","severity":"warning","timestamp":"2019-01-01T00:00:00Z"}}`,
+		// The payload is only sent to trigger an incident.
+		`{"routing_key":"key","event_action":"resolve","dedup_key":"host1"}`,
+	}
+	if !cmp.Equal(want, bodies) {
+		t.Errorf("unexpected bodies -want/+got\n%s", cmp.Diff(want, bodies))
+	}
+}
+
+func TestEndpoint_SendError(t *testing.T) {
+	var bodies []string
+	server := newServer(http.StatusBadRequest, `{"status": "invalid event", "message": "Event object is invalid", "errors": ["Length of 'routing_key' is incorrect"]}`, &bodies)
+	defer server.Close()
+
+	ctx := dependencies.Inject(context.Background(), dependencies.Unrestricted())
+	spec := &pagerduty.EndpointOpSpec{URL: server.URL}
+	err := spec.Send(ctx, []values.Object{
+		values.NewObjectWithValues(map[string]values.Value{
+			"routingKey": values.NewString("k"),
+			"summary":    values.NewString("cpu is high"),
+			"source":     values.NewString("host1"),
+			"severity":   values.NewString("info"),
+		}),
+	})
+	if want := "pagerduty error: Event object is invalid [Length of 'routing_key' is incorrect]"; err == nil || err.Error() != want {
+		t.Errorf("unexpected error: want %q, got %v", want, err)
+	}
+}
+
+func TestEndpoint_InvalidEvent(t *testing.T) {
+	testCases := []struct {
+		name       string
+		properties map[string]values.Value
+		wantErr    string
+	}{
+		{
+			name: "missing routing key",
+			properties: map[string]values.Value{
+				"summary": values.NewString("cpu is high"),
+			},
+			wantErr: "the routing key of an event cannot be empty",
+		},
+		{
+			name: "missing source",
+			properties: map[string]values.Value{
+				"routingKey": values.NewString("key"),
+				"summary":    values.NewString("cpu is high"),
+				"severity":   values.NewString("info"),
+			},
+			wantErr: "must have a summary, a source and a severity",
+		},
+		{
+			name: "invalid severity",
+			properties: map[string]values.Value{
+				"routingKey": values.NewString("key"),
+				"summary":    values.NewString("cpu is high"),
+				"source":     values.NewString("host1"),
+				"severity":   values.NewString("crit"),
+			},
+			wantErr: `invalid severity "crit"`,
+		},
+		{
+			name: "resolve without dedup key",
+			properties: map[string]values.Value{
+				"routingKey":  values.NewString("key"),
+				"eventAction": values.NewString("resolve"),
+			},
+			wantErr: "must have a dedup key",
+		},
+		{
+			name: "invalid timestamp",
+			properties: map[string]values.Value{
+				"routingKey": values.NewString("key"),
+				"timestamp":  values.NewInt(0),
+			},
+			wantErr: `property "timestamp" of the notification must be a time or a string`,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			spec := &pagerduty.EndpointOpSpec{URL: pagerduty.DefaultURL}
+			err := spec.Send(context.Background(), []values.Object{values.NewObjectWithValues(tc.properties)})
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("expected an error containing %q, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,223 @@
+// DO NOT EDIT: This file is autogenerated via the builtin command.
+
+package runtime
+
+import (
+	flux "github.com/influxdata/flux"
+	ast "github.com/influxdata/flux/ast"
+	docs "github.com/influxdata/flux/docs"
+)
+
+func init() {
+	flux.RegisterPackage(pkgAST)
+	docs.RegisterComments("runtime", pkgComments)
+}
+
+var pkgAST = &ast.Package{
+	BaseNode: ast.BaseNode{
+		Errors: nil,
+		Loc:    nil,
+	},
+	Files: []*ast.File{&ast.File{
+		BaseNode: ast.BaseNode{
+			Errors: nil,
+			Loc: &ast.SourceLocation{
+				End: ast.Position{
+					Column: 23,
+					Line:   13,
+				},
+				File:   "runtime.flux",
+				Source: "package runtime\n\n// version returns the version of the Flux engine.\nbuiltin version\n\n// buildInfo returns the version of the Flux engine and how it was built.\nbuiltin buildInfo\n\n// capabilities returns the kinds of the sources the engine can read from.\nbuiltin capabilities\n\n// featureEnabled reports whether a feature flag is enabled for the query.\nbuiltin featureEnabled",
+				Start: ast.Position{
+					Column: 1,
+					Line:   1,
+				},
+			},
+		},
+		Body: []ast.Statement{&ast.BuiltinStatement{
+			BaseNode: ast.BaseNode{
+				Errors: nil,
+				Loc: &ast.SourceLocation{
+					End: ast.Position{
+						Column: 16,
+						Line:   4,
+					},
+					File:   "runtime.flux",
+					Source: "builtin version",
+					Start: ast.Position{
+						Column: 1,
+						Line:   4,
+					},
+				},
+			},
+			ID: &ast.Identifier{
+				BaseNode: ast.BaseNode{
+					Errors: nil,
+					Loc: &ast.SourceLocation{
+						End: ast.Position{
+							Column: 16,
+							Line:   4,
+						},
+						File:   "runtime.flux",
+						Source: "version",
+						Start: ast.Position{
+							Column: 9,
+							Line:   4,
+						},
+					},
+				},
+				Name: "version",
+			},
+		}, &ast.BuiltinStatement{
+			BaseNode: ast.BaseNode{
+				Errors: nil,
+				Loc: &ast.SourceLocation{
+					End: ast.Position{
+						Column: 18,
+						Line:   7,
+					},
+					File:   "runtime.flux",
+					Source: "builtin buildInfo",
+					Start: ast.Position{
+						Column: 1,
+						Line:   7,
+					},
+				},
+			},
+			ID: &ast.Identifier{
+				BaseNode: ast.BaseNode{
+					Errors: nil,
+					Loc: &ast.SourceLocation{
+						End: ast.Position{
+							Column: 18,
+							Line:   7,
+						},
+						File:   "runtime.flux",
+						Source: "buildInfo",
+						Start: ast.Position{
+							Column: 9,
+							Line:   7,
+						},
+					},
+				},
+				Name: "buildInfo",
+			},
+		}, &ast.BuiltinStatement{
+			BaseNode: ast.BaseNode{
+				Errors: nil,
+				Loc: &ast.SourceLocation{
+					End: ast.Position{
+						Column: 21,
+						Line:   10,
+					},
+					File:   "runtime.flux",
+					Source: "builtin capabilities",
+					Start: ast.Position{
+						Column: 1,
+						Line:   10,
+					},
+				},
+			},
+			ID: &ast.Identifier{
+				BaseNode: ast.BaseNode{
+					Errors: nil,
+					Loc: &ast.SourceLocation{
+						End: ast.Position{
+							Column: 21,
+							Line:   10,
+						},
+						File:   "runtime.flux",
+						Source: "capabilities",
+						Start: ast.Position{
+							Column: 9,
+							Line:   10,
+						},
+					},
+				},
+				Name: "capabilities",
+			},
+		}, &ast.BuiltinStatement{
+			BaseNode: ast.BaseNode{
+				Errors: nil,
+				Loc: &ast.SourceLocation{
+					End: ast.Position{
+						Column: 23,
+						Line:   13,
+					},
+					File:   "runtime.flux",
+					Source: "builtin featureEnabled",
+					Start: ast.Position{
+						Column: 1,
+						Line:   13,
+					},
+				},
+			},
+			ID: &ast.Identifier{
+				BaseNode: ast.BaseNode{
+					Errors: nil,
+					Loc: &ast.SourceLocation{
+						End: ast.Position{
+							Column: 23,
+							Line:   13,
+						},
+						File:   "runtime.flux",
+						Source: "featureEnabled",
+						Start: ast.Position{
+							Column: 9,
+							Line:   13,
+						},
+					},
+				},
+				Name: "featureEnabled",
+			},
+		}},
+		Imports: nil,
+		Name:    "runtime.flux",
+		Package: &ast.PackageClause{
+			BaseNode: ast.BaseNode{
+				Errors: nil,
+				Loc: &ast.SourceLocation{
+					End: ast.Position{
+						Column: 16,
+						Line:   1,
+					},
+					File:   "runtime.flux",
+					Source: "package runtime",
+					Start: ast.Position{
+						Column: 1,
+						Line:   1,
+					},
+				},
+			},
+			Name: &ast.Identifier{
+				BaseNode: ast.BaseNode{
+					Errors: nil,
+					Loc: &ast.SourceLocation{
+						End: ast.Position{
+							Column: 16,
+							Line:   1,
+						},
+						File:   "runtime.flux",
+						Source: "runtime",
+						Start: ast.Position{
+							Column: 9,
+							Line:   1,
+						},
+					},
+				},
+				Name: "runtime",
+			},
+		},
+	}},
+	Package: "runtime",
+	Path:    "runtime",
+}
+var pkgComments = docs.Comments{
+	Package: "",
+	Values: map[string]string{
+		"buildInfo":      "buildInfo returns the version of the Flux engine and how it was built.",
+		"capabilities":   "capabilities returns the kinds of the sources the engine can read from.",
+		"featureEnabled": "featureEnabled reports whether a feature flag is enabled for the query.",
+		"version":        "version returns the version of the Flux engine.",
+	},
+}
@@ -0,0 +1,141 @@
+// Package runtime describes the Flux engine that runs a script,
+// so that shared libraries can branch on the version and the capabilities of the engine.
+package runtime
+
+import (
+	"context"
+	goruntime "runtime"
+	"runtime/debug"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/dependencies"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/interpreter"
+	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/flux/values"
+)
+
+const (
+	modulePath = "github.com/influxdata/flux"
+
+	// DevelVersion is the version of an engine that was not built from a released module.
+	DevelVersion = "(devel)"
+)
+
+func init() {
+	flux.RegisterPackageValue("runtime", "version", values.NewFunction(
+		"version",
+		semantic.NewFunctionPolyType(semantic.FunctionPolySignature{
+			Return: semantic.String,
+		}),
+		func(args values.Object) (values.Value, error) {
+			return values.NewString(Version()), nil
+		},
+		false,
+	))
+
+	buildInfoType := objectPolyType(map[string]semantic.PolyType{
+		"version":   semantic.String,
+		"goVersion": semantic.String,
+		"os":        semantic.String,
+		"arch":      semantic.String,
+	})
+	flux.RegisterPackageValue("runtime", "buildInfo", values.NewFunction(
+		"buildInfo",
+		semantic.NewFunctionPolyType(semantic.FunctionPolySignature{
+			Return: buildInfoType,
+		}),
+		func(args values.Object) (values.Value, error) {
+			return values.NewObjectWithValues(map[string]values.Value{
+				"version":   values.NewString(Version()),
+				"goVersion": values.NewString(goruntime.Version()),
+				"os":        values.NewString(goruntime.GOOS),
+				"arch":      values.NewString(goruntime.GOARCH),
+			}), nil
+		},
+		false,
+	))
+
+	capabilitiesType := objectPolyType(map[string]semantic.PolyType{
+		"sources": semantic.NewArrayPolyType(semantic.String),
+	})
+	flux.RegisterPackageValue("runtime", "capabilities", values.NewFunction(
+		"capabilities",
+		semantic.NewFunctionPolyType(semantic.FunctionPolySignature{
+			Return: capabilitiesType,
+		}),
+		func(args values.Object) (values.Value, error) {
+			kinds := execute.SourceKinds()
+			sources := make([]values.Value, len(kinds))
+			for i, k := range kinds {
+				sources[i] = values.NewString(string(k))
+			}
+			return values.NewObjectWithValues(map[string]values.Value{
+				"sources": values.NewArrayWithBacking(semantic.String, sources),
+			}), nil
+		},
+		false,
+	))
+
+	flux.RegisterPackageValue("runtime", "featureEnabled", values.NewContextFunction(
+		"featureEnabled",
+		semantic.NewFunctionPolyType(semantic.FunctionPolySignature{
+			Parameters: map[string]semantic.PolyType{
+				"flag": semantic.String,
+			},
+			Required: semantic.LabelSet{"flag"},
+			Return:   semantic.Bool,
+		}),
+		featureEnabled,
+		false,
+	))
+}
+
+// Version returns the version of the Flux module the engine was built with,
+// either as the main module or as a dependency, or DevelVersion when it is unknown.
+func Version() string {
+	bi, ok := debug.ReadBuildInfo()
+	if !ok {
+		return DevelVersion
+	}
+	if bi.Main.Path == modulePath {
+		return moduleVersion(&bi.Main)
+	}
+	for _, m := range bi.Deps {
+		if m.Path == modulePath {
+			return moduleVersion(m)
+		}
+	}
+	return DevelVersion
+}
+
+func moduleVersion(m *debug.Module) string {
+	if m.Replace != nil {
+		m = m.Replace
+	}
+	if m.Version == "" {
+		return DevelVersion
+	}
+	return m.Version
+}
+
+// featureEnabled reports whether a feature flag is enabled for the query,
+// as decided by the flagger of its dependencies.
+func featureEnabled(ctx context.Context, args values.Object) (values.Value, error) {
+	return interpreter.DoFunctionCall(func(args interpreter.Arguments) (values.Value, error) {
+		flag, err := args.GetRequiredString("flag")
+		if err != nil {
+			return nil, err
+		}
+		return values.NewBool(dependencies.Get(ctx).Flagger.Enabled(flag)), nil
+	}, args)
+}
+
+// objectPolyType returns the type of an object that has exactly the properties.
+func objectPolyType(properties map[string]semantic.PolyType) semantic.PolyType {
+	labels := make(semantic.LabelSet, 0, len(properties))
+	for k := range properties {
+		labels = append(labels, k)
+	}
+	return semantic.NewObjectPolyType(properties, labels, labels)
+}
@@ -0,0 +1,91 @@
+package runtime_test
+
+import (
+	"context"
+	goruntime "runtime"
+	"testing"
+
+	"github.com/influxdata/flux"
+	_ "github.com/influxdata/flux/builtin"
+	"github.com/influxdata/flux/dependencies"
+	"github.com/influxdata/flux/stdlib/runtime"
+	"github.com/influxdata/flux/values"
+)
+
+// eval evaluates the script and returns the value of x.
+func eval(t *testing.T, script string) values.Value {
+	t.Helper()
+	_, scope, err := flux.Eval(script)
+	if err != nil {
+		t.Fatal(err)
+	}
+	x, ok := scope.Lookup("x")
+	if !ok {
+		t.Fatal("x is not defined")
+	}
+	return x
+}
+
+func TestVersion(t *testing.T) {
+	x := eval(t, `
+import "runtime"
+
+x = runtime.version()`)
+	// Tests are not built from a released module.
+	if want := runtime.DevelVersion; x.Str() != want {
+		t.Errorf("unexpected version: want %q, got %q", want, x.Str())
+	}
+}
+
+func TestBuildInfo(t *testing.T) {
+	x := eval(t, `
+import "runtime"
+
+info = runtime.buildInfo()
+x = info.goVersion + " " + info.os + "/" + info.arch`)
+	if want := goruntime.Version() + " " + goruntime.GOOS + "/" + goruntime.GOARCH; x.Str() != want {
+		t.Errorf("unexpected build info: want %q, got %q", want, x.Str())
+	}
+}
+
+func TestCapabilities(t *testing.T) {
+	x := eval(t, `
+import "runtime"
+
+x = runtime.capabilities().sources`)
+	var sources []string
+	x.Array().Range(func(i int, v values.Value) {
+		sources = append(sources, v.Str())
+	})
+	for _, want := range []string{"fromCSV", "fromGenerator"} {
+		found := false
+		for _, s := range sources {
+			found = found || s == want
+		}
+		if !found {
+			t.Errorf("expected source %q in %v", want, sources)
+		}
+	}
+}
+
+func TestFeatureEnabled(t *testing.T) {
+	x := eval(t, `
+import "runtime"
+
+x = runtime.featureEnabled`)
+	fn := x.Function().(values.ContextFunction)
+
+	deps := dependencies.NewBuilder().WithFlagger(dependencies.Flags{"a": true}).Build()
+	ctx := dependencies.Inject(context.Background(), deps)
+	for flag, want := range map[string]bool{"a": true, "b": false} {
+		got, err := fn.CallContext(ctx, values.NewObjectWithValues(map[string]values.Value{
+			"flag": values.NewString(flag),
+		}))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Bool() != want {
+			t.Errorf("unexpected value of flag %q: want %v, got %v", flag, want, got.Bool())
+		}
+	}
+}
@@ -0,0 +1,153 @@
+// DO NOT EDIT: This file is autogenerated via the builtin command.
+
+package slack
+
+import (
+	flux "github.com/influxdata/flux"
+	ast "github.com/influxdata/flux/ast"
+	docs "github.com/influxdata/flux/docs"
+)
+
+func init() {
+	flux.RegisterPackage(pkgAST)
+	docs.RegisterComments("slack", pkgComments)
+}
+
+var pkgAST = &ast.Package{
+	BaseNode: ast.BaseNode{
+		Errors: nil,
+		Loc:    nil,
+	},
+	Files: []*ast.File{&ast.File{
+		BaseNode: ast.BaseNode{
+			Errors: nil,
+			Loc: &ast.SourceLocation{
+				End: ast.Position{
+					Column: 17,
+					Line:   8,
+				},
+				File:   "slack.flux",
+				Source: "package slack\n\n// message sends a message to a Slack channel and returns the HTTP status code of the response.\nbuiltin message\n\n// endpoint sends a message for every row of its input.\n// The mapping function returns the channel, the text and the color of the message of a row.\nbuiltin endpoint",
+				Start: ast.Position{
+					Column: 1,
+					Line:   1,
+				},
+			},
+		},
+		Body: []ast.Statement{&ast.BuiltinStatement{
+			BaseNode: ast.BaseNode{
+				Errors: nil,
+				Loc: &ast.SourceLocation{
+					End: ast.Position{
+						Column: 16,
+						Line:   4,
+					},
+					File:   "slack.flux",
+					Source: "builtin message",
+					Start: ast.Position{
+						Column: 1,
+						Line:   4,
+					},
+				},
+			},
+			ID: &ast.Identifier{
+				BaseNode: ast.BaseNode{
+					Errors: nil,
+					Loc: &ast.SourceLocation{
+						End: ast.Position{
+							Column: 16,
+							Line:   4,
+						},
+						File:   "slack.flux",
+						Source: "message",
+						Start: ast.Position{
+							Column: 9,
+							Line:   4,
+						},
+					},
+				},
+				Name: "message",
+			},
+		}, &ast.BuiltinStatement{
+			BaseNode: ast.BaseNode{
+				Errors: nil,
+				Loc: &ast.SourceLocation{
+					End: ast.Position{
+						Column: 17,
+						Line:   8,
+					},
+					File:   "slack.flux",
+					Source: "builtin endpoint",
+					Start: ast.Position{
+						Column: 1,
+						Line:   8,
+					},
+				},
+			},
+			ID: &ast.Identifier{
+				BaseNode: ast.BaseNode{
+					Errors: nil,
+					Loc: &ast.SourceLocation{
+						End: ast.Position{
+							Column: 17,
+							Line:   8,
+						},
+						File:   "slack.flux",
+						Source: "endpoint",
+						Start: ast.Position{
+							Column: 9,
+							Line:   8,
+						},
+					},
+				},
+				Name: "endpoint",
+			},
+		}},
+		Imports: nil,
+		Name:    "slack.flux",
+		Package: &ast.PackageClause{
+			BaseNode: ast.BaseNode{
+				Errors: nil,
+				Loc: &ast.SourceLocation{
+					End: ast.Position{
+						Column: 14,
+						Line:   1,
+					},
+					File:   "slack.flux",
+					Source: "package slack",
+					Start: ast.Position{
+						Column: 1,
+						Line:   1,
+					},
+				},
+			},
+			Name: &ast.Identifier{
+				BaseNode: ast.BaseNode{
+					Errors: nil,
+					Loc: &ast.SourceLocation{
+						End: ast.Position{
+							Column: 14,
+							Line:   1,
+						},
+						File:   "slack.flux",
+						Source: "slack",
+						Start: ast.Position{
+							Column: 9,
+							Line:   1,
+						},
+					},
+				},
+				Name: "slack",
+			},
+		},
+	}},
+	Package: "slack",
+	Path:    "slack",
+}
+var pkgComments = docs.Comments{
+	Package: "",
+	Values: map[string]string{
+		"endpoint": "endpoint sends a message for every row of its input.\nThe mapping function returns the channel, the text and the color of the message of a row.",
+		"message":  "message sends a message to a Slack channel and returns the HTTP status code of the response.",
+	},
+}
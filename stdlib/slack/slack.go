@@ -0,0 +1,277 @@
+// Package slack sends messages to Slack channels.
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/interpreter"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/semantic"
+	fhttp "github.com/influxdata/flux/stdlib/http"
+	"github.com/influxdata/flux/values"
+)
+
+const (
+	// DefaultURL is the URL of the chat.postMessage method of the Slack API.
+	DefaultURL = "https://slack.com/api/chat.postMessage"
+
+	EndpointKind = "slackEndpoint"
+)
+
+// colorRe matches the colors of the messages, either a color named by Slack or a hex color.
+var colorRe = regexp.MustCompile(`^(good|warning|danger|#?[0-9a-fA-F]{6})$`)
+
+func init() {
+	messageSignature := semantic.FunctionPolySignature{
+		Parameters: map[string]semantic.PolyType{
+			"url":     semantic.String,
+			"token":   semantic.String,
+			"channel": semantic.String,
+			"text":    semantic.String,
+			"color":   semantic.String,
+		},
+		Required: semantic.LabelSet{"channel", "text"},
+		Return:   semantic.Int,
+	}
+	flux.RegisterPackageValue("slack", "message", values.NewContextFunction(
+		"message",
+		semantic.NewFunctionPolyType(messageSignature),
+		message,
+		true,
+	))
+
+	endpointSignature := semantic.FunctionPolySignature{
+		Parameters: map[string]semantic.PolyType{
+			"url":       semantic.String,
+			"token":     semantic.String,
+			"batchSize": semantic.Int,
+		},
+	}
+	flux.RegisterPackageValue("slack", "endpoint", fhttp.NewEndpointFunction(EndpointKind, endpointSignature, createEndpointOpSpec))
+	flux.RegisterOpSpec(EndpointKind, func() flux.OperationSpec { return &EndpointOpSpec{} })
+	plan.RegisterProcedureSpecWithSideEffect(EndpointKind, newEndpointProcedure, EndpointKind)
+	execute.RegisterTransformation(EndpointKind, createEndpointTransformation)
+}
+
+// Message is a message sent to a channel.
+type Message struct {
+	Channel string
+	Text    string
+	Color   string
+}
+
+func (m Message) validate() error {
+	if m.Channel == "" {
+		return fmt.Errorf("the channel of a message cannot be empty")
+	}
+	if m.Color != "" && !colorRe.MatchString(m.Color) {
+		return fmt.Errorf(`invalid color %q, must be "good", "warning", "danger" or a hex color`, m.Color)
+	}
+	return nil
+}
+
+type attachment struct {
+	Color    string   `json:"color,omitempty"`
+	Text     string   `json:"text"`
+	MrkdwnIn []string `json:"mrkdwn_in"`
+}
+
+type payload struct {
+	Channel     string       `json:"channel"`
+	Attachments []attachment `json:"attachments"`
+}
+
+// post posts the messages, which must have the same channel, as one message with an attachment per message,
+// and returns the status code and the body of the response.
+func post(ctx context.Context, url, token string, messages []Message) (int, []byte, error) {
+	p := payload{
+		Channel:     messages[0].Channel,
+		Attachments: make([]attachment, len(messages)),
+	}
+	for i, m := range messages {
+		p.Attachments[i] = attachment{
+			Color:    m.Color,
+			Text:     m.Text,
+			MrkdwnIn: []string{"text"},
+		}
+	}
+	body, err := json.Marshal(p)
+	if err != nil {
+		return 0, nil, err
+	}
+	header := http.Header{}
+	header.Set("Content-Type", "application/json; charset=utf-8")
+	if token != "" {
+		header.Set("Authorization", "Bearer "+token)
+	}
+	return fhttp.Post(ctx, url, header, body)
+}
+
+// checkResponse returns the error of a response of the Slack API.
+// The API reports most errors with a successful status code and a body whose ok property is false.
+func checkResponse(status int, body []byte) error {
+	if err := fhttp.CheckStatus(status); err != nil {
+		return err
+	}
+	var resp struct {
+		OK    *bool  `json:"ok"`
+		Error string `json:"error"`
+	}
+	// Incoming webhooks respond with a plain text body.
+	if err := json.Unmarshal(body, &resp); err != nil || resp.OK == nil || *resp.OK {
+		return nil
+	}
+	return fmt.Errorf("slack error: %s", resp.Error)
+}
+
+// message sends a message and returns the status code of the response.
+func message(ctx context.Context, args values.Object) (values.Value, error) {
+	return interpreter.DoFunctionCall(func(args interpreter.Arguments) (values.Value, error) {
+		url, ok, err := args.GetString("url")
+		if err != nil {
+			return nil, err
+		} else if !ok {
+			url = DefaultURL
+		}
+		token, _, err := args.GetString("token")
+		if err != nil {
+			return nil, err
+		}
+		var m Message
+		if m.Channel, err = args.GetRequiredString("channel"); err != nil {
+			return nil, err
+		}
+		if m.Text, err = args.GetRequiredString("text"); err != nil {
+			return nil, err
+		}
+		if m.Color, _, err = args.GetString("color"); err != nil {
+			return nil, err
+		}
+		if err := m.validate(); err != nil {
+			return nil, err
+		}
+
+		status, _, err := post(ctx, url, token, []Message{m})
+		if err != nil {
+			return nil, err
+		}
+		return values.NewInt(int64(status)), nil
+	}, args)
+}
+
+// EndpointOpSpec is the operation spec of slack.endpoint, which sends the notifications as messages.
+// The notifications have the channel, the text and the optional color of the messages.
+type EndpointOpSpec struct {
+	fhttp.EndpointSpec
+	URL   string `json:"url"`
+	Token string `json:"token"`
+}
+
+func createEndpointOpSpec(args flux.Arguments) (fhttp.EndpointOpSpec, error) {
+	spec := new(EndpointOpSpec)
+	url, ok, err := args.GetString("url")
+	if err != nil {
+		return nil, err
+	} else if !ok {
+		url = DefaultURL
+	}
+	spec.URL = url
+	if spec.Token, _, err = args.GetString("token"); err != nil {
+		return nil, err
+	}
+	if err := spec.ReadBatchSize(args); err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
+
+func (s *EndpointOpSpec) Kind() flux.OperationKind {
+	return EndpointKind
+}
+
+func (s *EndpointOpSpec) Resources() []flux.Resource {
+	return []flux.Resource{{Kind: flux.URLResource, Name: s.URL, Mode: flux.WriteAccess}}
+}
+
+// Send sends the messages of a batch of notifications, with one message per channel
+// that has an attachment for every notification to the channel.
+func (s *EndpointOpSpec) Send(ctx context.Context, notifications []values.Object) error {
+	var channels []string
+	messages := make(map[string][]Message)
+	for _, n := range notifications {
+		var (
+			m   Message
+			err error
+		)
+		if m.Channel, _, err = fhttp.NotificationString(n, "channel"); err != nil {
+			return err
+		}
+		if m.Text, _, err = fhttp.NotificationString(n, "text"); err != nil {
+			return err
+		}
+		if m.Color, _, err = fhttp.NotificationString(n, "color"); err != nil {
+			return err
+		}
+		if err := m.validate(); err != nil {
+			return err
+		}
+		if _, ok := messages[m.Channel]; !ok {
+			channels = append(channels, m.Channel)
+		}
+		messages[m.Channel] = append(messages[m.Channel], m)
+	}
+
+	for _, channel := range channels {
+		status, body, err := post(ctx, s.URL, s.Token, messages[channel])
+		if err != nil {
+			return err
+		}
+		if err := checkResponse(status, body); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type EndpointProcedureSpec struct {
+	plan.DefaultCost
+	Spec *EndpointOpSpec
+}
+
+func newEndpointProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	spec, ok := qs.(*EndpointOpSpec)
+	if !ok {
+		return nil, fmt.Errorf("invalid spec type %T", qs)
+	}
+	return &EndpointProcedureSpec{Spec: spec}, nil
+}
+
+func (s *EndpointProcedureSpec) Kind() plan.ProcedureKind {
+	return EndpointKind
+}
+
+func (s *EndpointProcedureSpec) Copy() plan.ProcedureSpec {
+	spec := *s.Spec
+	spec.EndpointSpec = s.Spec.EndpointSpec.Copy()
+	return &EndpointProcedureSpec{Spec: &spec}
+}
+
+func createEndpointTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	s, ok := spec.(*EndpointProcedureSpec)
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid spec type %T", spec)
+	}
+	cache := execute.NewTableBuilderCache(a.Allocator())
+	d := execute.NewDataset(id, mode, cache)
+	t, err := fhttp.NewEndpointTransformation(a.Context(), d, cache, s.Spec.EndpointSpec, s.Spec.Send)
+	if err != nil {
+		return nil, nil, err
+	}
+	return t, d, nil
+}
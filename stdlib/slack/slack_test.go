@@ -0,0 +1,122 @@
+package slack_test
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/flux"
+	_ "github.com/influxdata/flux/builtin"
+	"github.com/influxdata/flux/dependencies"
+	"github.com/influxdata/flux/stdlib/slack"
+	"github.com/influxdata/flux/values"
+)
+
+type request struct {
+	Authorization string
+	Body          string
+}
+
+// newServer returns a server that records its requests and responds with the body.
+func newServer(respBody string, requests *[]request) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		*requests = append(*requests, request{
+			Authorization: r.Header.Get("Authorization"),
+			Body:          string(body),
+		})
+		_, _ = w.Write([]byte(respBody))
+	}))
+}
+
+func TestMessage(t *testing.T) {
+	var requests []request
+	server := newServer(`{"ok": true}`, &requests)
+	defer server.Close()
+
+	ctx := dependencies.Inject(context.Background(), dependencies.Unrestricted())
+	script := `
+import "slack"
+
+slack.message(url: "` + server.URL + `", token: "xoxb", channel: "#alerts", text: "disk is *full*", color: "danger")`
+	if _, err := flux.Compile(ctx, script, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []request{{
+		Authorization: "Bearer xoxb",
+		Body:          `{"channel":"#alerts","attachments":[{"color":"danger","text":"disk is *full*","mrkdwn_in":["text"]}]}`,
+	}}
+	if !cmp.Equal(want, requests) {
+		t.Errorf("unexpected requests -want/+got\n%s", cmp.Diff(want, requests))
+	}
+}
+
+func TestMessage_InvalidColor(t *testing.T) {
+	ctx := dependencies.Inject(context.Background(), dependencies.Unrestricted())
+	script := `
+import "slack"
+
+slack.message(url: "http://localhost", channel: "#alerts", text: "hi", color: "blue")`
+	if _, err := flux.Compile(ctx, script, time.Now()); err == nil || !strings.Contains(err.Error(), "invalid color") {
+		t.Errorf("expected an invalid color error, got %v", err)
+	}
+}
+
+func notification(channel, text string) values.Object {
+	return values.NewObjectWithValues(map[string]values.Value{
+		"channel": values.NewString(channel),
+		"text":    values.NewString(text),
+	})
+}
+
+func TestEndpoint_Send(t *testing.T) {
+	var requests []request
+	server := newServer(`{"ok": true}`, &requests)
+	defer server.Close()
+
+	ctx := dependencies.Inject(context.Background(), dependencies.Unrestricted())
+	spec := &slack.EndpointOpSpec{URL: server.URL}
+	if err := spec.Send(ctx, []values.Object{
+		notification("#a", "one"),
+		notification("#b", "two"),
+		notification("#a", "three"),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	// The messages to the same channel are sent together.
+	want := []request{
+		{Body: `{"channel":"#a","attachments":[{"text":"one","mrkdwn_in":["text"]},{"text":"three","mrkdwn_in":["text"]}]}`},
+		{Body: `{"channel":"#b","attachments":[{"text":"two","mrkdwn_in":["text"]}]}`},
+	}
+	if !cmp.Equal(want, requests) {
+		t.Errorf("unexpected requests -want/+got\n%s", cmp.Diff(want, requests))
+	}
+}
+
+func TestEndpoint_SendError(t *testing.T) {
+	var requests []request
+	server := newServer(`{"ok": false, "error": "channel_not_found"}`, &requests)
+	defer server.Close()
+
+	ctx := dependencies.Inject(context.Background(), dependencies.Unrestricted())
+	spec := &slack.EndpointOpSpec{URL: server.URL}
+	err := spec.Send(ctx, []values.Object{notification("#nowhere", "hi")})
+	if want := "slack error: channel_not_found"; err == nil || err.Error() != want {
+		t.Errorf("unexpected error: want %q, got %v", want, err)
+	}
+}
+
+func TestEndpoint_Denied(t *testing.T) {
+	spec := &slack.EndpointOpSpec{URL: slack.DefaultURL}
+	err := spec.Send(context.Background(), []values.Object{notification("#a", "hi")})
+	if err != dependencies.ErrURLDenied {
+		t.Errorf("unexpected error: want %v, got %v", dependencies.ErrURLDenied, err)
+	}
+}
@@ -1,19 +1,20 @@
 // This source gets input from a socket connection and produces tables given a decoder.
-// This is a good candidate for streaming use cases. For now, it produces a single table for everything
-// that it receives from the start to the end of the connection.
+// Tables are produced incrementally as records arrive, so the connection can be treated as an unbounded
+// stream that feeds downstream transformations until it is closed or the query is cancelled.
 package socket
 
 import (
 	"context"
 	"fmt"
 	"io"
-	"net"
 	neturl "net/url"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/influxdata/flux"
 	"github.com/influxdata/flux/csv"
+	"github.com/influxdata/flux/dependencies"
 	"github.com/influxdata/flux/execute"
 	"github.com/influxdata/flux/line"
 	"github.com/influxdata/flux/plan"
@@ -45,15 +46,17 @@ func init() {
 	execute.RegisterSource(FromSocketKind, createFromSocketSource)
 }
 
-// nowTimeProvider provides wall clock time.
-type nowTimeProvider struct{}
+// nowTimeProvider provides the current time of the dependencies.
+type nowTimeProvider struct {
+	now func() time.Time
+}
 
 func (a *nowTimeProvider) CurrentTime() values.Time {
-	return values.ConvertTime(time.Now())
+	return values.ConvertTime(a.now())
 }
 
 var (
-	decoders = []string{"csv", "line"}
+	decoders = []string{"csv", "line", "lineprotocol"}
 	schemes  = []string{"tcp", "unix"}
 )
 
@@ -98,6 +101,10 @@ func (s *FromSocketOpSpec) Kind() flux.OperationKind {
 	return FromSocketKind
 }
 
+func (s *FromSocketOpSpec) Resources() []flux.Resource {
+	return []flux.Resource{{Kind: flux.URLResource, Name: s.URL, Mode: flux.ReadAccess}}
+}
+
 type FromSocketProcedureSpec struct {
 	plan.DefaultCost
 	URL     string
@@ -152,12 +159,20 @@ func createFromSocketSource(s plan.ProcedureSpec, dsid execute.DatasetID, a exec
 		}
 	}
 
-	conn, err := net.Dial(scheme, address)
+	u := &neturl.URL{Scheme: scheme, Host: address}
+	if scheme == "unix" {
+		u = &neturl.URL{Scheme: scheme, Path: address}
+	}
+	deps := dependencies.Get(a.Context())
+	if err := deps.URLValidator.Validate(u); err != nil {
+		return nil, err
+	}
+	conn, err := dependencies.NewDialer(deps.URLValidator).DialContext(a.Context(), scheme, address)
 	if err != nil {
 		return nil, errors.Wrap(err, "error in creating socket source")
 	}
 
-	return NewSocketSource(spec, conn, &nowTimeProvider{}, dsid)
+	return NewSocketSource(spec, conn, &nowTimeProvider{now: deps.Now}, dsid)
 }
 
 func NewSocketSource(spec *FromSocketProcedureSpec, rc io.ReadCloser, tp line.TimeProvider, dsid execute.DatasetID) (execute.Source, error) {
@@ -170,6 +185,10 @@ func NewSocketSource(spec *FromSocketProcedureSpec, rc io.ReadCloser, tp line.Ti
 			Separator:    '\n',
 			TimeProvider: tp,
 		})
+	case "lineprotocol":
+		decoder = line.NewProtocolDecoder(&line.ProtocolDecoderConfig{
+			TimeProvider: tp,
+		})
 	}
 
 	if decoder == nil {
@@ -195,7 +214,25 @@ func (ss *socketSource) AddTransformation(t execute.Transformation) {
 }
 
 func (ss *socketSource) Run(ctx context.Context) {
-	defer ss.rc.Close()
+	// The connection is closed once, when the source is done or earlier if the query is cancelled,
+	// which unblocks the decoder.
+	var closeOnce sync.Once
+	closeConn := func() {
+		closeOnce.Do(func() {
+			ss.rc.Close()
+		})
+	}
+	defer closeConn()
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			closeConn()
+		case <-done:
+		}
+	}()
+
 	result, err := ss.decoder.Decode(ss.rc)
 	if err != nil {
 		err = errors.Wrap(err, "decode error")
@@ -205,9 +242,15 @@ func (ss *socketSource) Run(ctx context.Context) {
 				if err := t.Process(ss.d, tbl); err != nil {
 					return err
 				}
+				if err := t.UpdateProcessingTime(ss.d, execute.Now()); err != nil {
+					return err
+				}
 			}
 			return nil
 		})
+		if ctx.Err() != nil {
+			err = ctx.Err()
+		}
 	}
 
 	for _, t := range ss.ts {
@@ -3,7 +3,9 @@ package socket_test
 import (
 	"bytes"
 	"context"
+	"io"
 	"io/ioutil"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -127,6 +129,27 @@ source
 				},
 			}},
 		},
+		{
+			name: "line protocol",
+			spec: &socket.FromSocketProcedureSpec{Decoder: "lineprotocol"},
+			input: `cpu,host=a usage=0.5 10
+cpu,host=a usage=0.7
+`,
+			want: []*executetest.Table{{
+				KeyCols: []string{"_field", "_measurement", "host"},
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_field", Type: flux.TString},
+					{Label: "_measurement", Type: flux.TString},
+					{Label: "host", Type: flux.TString},
+					{Label: "_value", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{execute.Time(10), "usage", "cpu", "a", 0.5},
+					{execute.Time(0), "usage", "cpu", "a", 0.7},
+				},
+			}},
+		},
 		{
 			name: "csv",
 			spec: &socket.FromSocketProcedureSpec{Decoder: "csv"},
@@ -242,3 +265,64 @@ source
 		})
 	}
 }
+
+// countingCloser counts the calls to the Close method of a reader.
+type countingCloser struct {
+	io.ReadCloser
+	closes int32
+}
+
+func (c *countingCloser) Close() error {
+	atomic.AddInt32(&c.closes, 1)
+	return c.ReadCloser.Close()
+}
+
+func TestFromSocketSource_Close(t *testing.T) {
+	testCases := []struct {
+		name   string
+		reader func() io.ReadCloser
+		cancel bool
+	}{
+		{
+			name: "end of input",
+			reader: func() io.ReadCloser {
+				return ioutil.NopCloser(bytes.NewReader([]byte("a\nb\n")))
+			},
+		},
+		{
+			name: "cancelled",
+			reader: func() io.ReadCloser {
+				// The reader blocks until it is closed.
+				r, _ := io.Pipe()
+				return r
+			},
+			cancel: true,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			id := executetest.RandomDatasetID()
+			d := executetest.NewDataset(id)
+			c := execute.NewTableBuilderCache(executetest.UnlimitedAllocator)
+			c.SetTriggerSpec(flux.DefaultTrigger)
+			r := &countingCloser{ReadCloser: tc.reader()}
+			ss, err := socket.NewSocketSource(&socket.FromSocketProcedureSpec{Decoder: "line"}, r, &mock.AscendingTimeProvider{}, id)
+			if err != nil {
+				t.Fatal(err)
+			}
+			ss.AddTransformation(executetest.NewYieldTransformation(d, c))
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if tc.cancel {
+				time.AfterFunc(10*time.Millisecond, cancel)
+			}
+			ss.Run(ctx)
+
+			if got := atomic.LoadInt32(&r.closes); got != 1 {
+				t.Errorf("expected the connection to be closed once, got %d closes", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/influxdata/flux/dependencies"
+	"github.com/lib/pq"
+)
+
+// mysqlValidatedNet is the network through which the mysql driver connects with the dialer
+// of the URLValidator of a query.
+// The driver only lets dialers be registered per network, and does not pass them a context,
+// so the connections opened for a query register their dialer in mysqlDials under a token
+// that is appended to the address of the data source name, as in db.example.com:3306#42.
+const mysqlValidatedNet = "flux-validated-tcp"
+
+var (
+	mysqlDials   sync.Map
+	mysqlDialSeq uint64
+)
+
+// mysqlDial is the dialer of a connection that is being opened through mysqlValidatedNet.
+type mysqlDial struct {
+	ctx    context.Context
+	dialer *net.Dialer
+}
+
+func init() {
+	mysql.RegisterDial(mysqlValidatedNet, func(addr string) (net.Conn, error) {
+		i := strings.LastIndexByte(addr, '#')
+		if i < 0 {
+			return nil, fmt.Errorf("invalid address %q", addr)
+		}
+		d, ok := mysqlDials.Load(addr[i+1:])
+		if !ok {
+			return nil, fmt.Errorf("no dialer for the address %q", addr)
+		}
+		dial := d.(mysqlDial)
+		return dial.dialer.DialContext(dial.ctx, "tcp", addr[:i])
+	})
+}
+
+// openDB opens the database of a data source name.
+// Every connection of the database is opened with the dialer of the URLValidator
+// in the dependencies of the context it is opened with, so that the driver cannot connect
+// to the addresses the validator rejects.
+func openDB(driverName, dataSourceName string) (*sql.DB, error) {
+	switch driverName {
+	case "mysql":
+		cfg, err := mysql.ParseDSN(dataSourceName)
+		if err != nil {
+			return nil, err
+		}
+		return sql.OpenDB(mysqlConnector{cfg: cfg}), nil
+	case "postgres":
+		return sql.OpenDB(pqConnector{dataSourceName: dataSourceName}), nil
+	default:
+		return nil, fmt.Errorf("sql driver %s not supported", driverName)
+	}
+}
+
+// mysqlConnector opens the connections of a mysql database.
+// When the URLValidator of the context checks the addresses connections are opened to,
+// only the tcp network is supported, since the addresses of other networks cannot be checked.
+type mysqlConnector struct {
+	cfg *mysql.Config
+}
+
+func (c mysqlConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	v := dependencies.Get(ctx).URLValidator
+	if _, ok := v.(dependencies.AddressChecker); !ok {
+		return mysql.MySQLDriver{}.Open(c.cfg.FormatDSN())
+	}
+	if c.cfg.Net != "tcp" {
+		return nil, fmt.Errorf("connections through the mysql network %q are not allowed", c.cfg.Net)
+	}
+
+	dialer := dependencies.NewDialer(v)
+	if c.cfg.Timeout > 0 {
+		dialer.Timeout = c.cfg.Timeout
+	}
+	token := strconv.FormatUint(atomic.AddUint64(&mysqlDialSeq, 1), 10)
+	mysqlDials.Store(token, mysqlDial{ctx: ctx, dialer: dialer})
+	defer mysqlDials.Delete(token)
+
+	cfg := *c.cfg
+	cfg.Net = mysqlValidatedNet
+	cfg.Addr += "#" + token
+	return mysql.MySQLDriver{}.Open(cfg.FormatDSN())
+}
+
+func (c mysqlConnector) Driver() driver.Driver {
+	return mysql.MySQLDriver{}
+}
+
+// pqConnector opens the connections of a postgres database.
+type pqConnector struct {
+	dataSourceName string
+}
+
+func (c pqConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	dialer := dependencies.NewDialer(dependencies.Get(ctx).URLValidator)
+	return pq.DialOpen(pqDialer{ctx: ctx, dialer: dialer}, c.dataSourceName)
+}
+
+func (c pqConnector) Driver() driver.Driver {
+	return &pq.Driver{}
+}
+
+// pqDialer adapts a net.Dialer to the dialer of lib/pq.
+type pqDialer struct {
+	ctx    context.Context
+	dialer *net.Dialer
+}
+
+func (d pqDialer) Dial(network, address string) (net.Conn, error) {
+	return d.dialer.DialContext(d.ctx, network, address)
+}
+
+func (d pqDialer) DialTimeout(network, address string, timeout time.Duration) (net.Conn, error) {
+	dialer := *d.dialer
+	dialer.Timeout = timeout
+	return dialer.DialContext(d.ctx, network, address)
+}
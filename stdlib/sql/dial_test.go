@@ -0,0 +1,86 @@
+package sql
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/influxdata/flux/dependencies"
+)
+
+func TestOpenDB_PrivateAddress(t *testing.T) {
+	// The server closes every connection, so that the drivers fail after connecting.
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			conn.Close()
+		}
+	}()
+	host, port, err := net.SplitHostPort(l.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		driverName     string
+		dataSourceName string
+	}{
+		{driverName: "mysql", dataSourceName: "user:pass@tcp(" + l.Addr().String() + ")/db"},
+		{driverName: "postgres", dataSourceName: "postgres://user:pass@" + l.Addr().String() + "/db?sslmode=disable"},
+		{driverName: "postgres", dataSourceName: "host=" + host + " port=" + port + " sslmode=disable"},
+	}
+	validators := []dependencies.URLValidator{
+		dependencies.RestrictedURLs{},
+		&dependencies.RestrictedURLs{},
+		dependencies.AllowAllURLs{},
+	}
+	for _, tc := range testCases {
+		for _, v := range validators {
+			db, err := openDB(tc.driverName, tc.dataSourceName)
+			if err != nil {
+				t.Fatal(err)
+			}
+			ctx := dependencies.Inject(context.Background(), dependencies.Dependencies{URLValidator: v})
+			err = db.PingContext(ctx)
+			db.Close()
+			if err == nil {
+				t.Fatalf("%s: expected the connection to fail", tc.dataSourceName)
+			}
+			_, restricted := v.(dependencies.AddressChecker)
+			if got := strings.Contains(err.Error(), "private address"); got != restricted {
+				t.Errorf("%s: unexpected error with %T: %v", tc.dataSourceName, v, err)
+			}
+		}
+	}
+}
+
+func TestOpenDB_UnixSocket(t *testing.T) {
+	testCases := []struct {
+		driverName     string
+		dataSourceName string
+	}{
+		{driverName: "mysql", dataSourceName: "user:pass@unix(/tmp/mysql.sock)/db"},
+		{driverName: "postgres", dataSourceName: "host=/tmp sslmode=disable"},
+	}
+	for _, tc := range testCases {
+		db, err := openDB(tc.driverName, tc.dataSourceName)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ctx := dependencies.Inject(context.Background(), dependencies.Dependencies{URLValidator: dependencies.RestrictedURLs{}})
+		err = db.PingContext(ctx)
+		db.Close()
+		if err == nil || !strings.Contains(err.Error(), "not allowed") {
+			t.Errorf("%s: expected the connection to be rejected, got: %v", tc.dataSourceName, err)
+		}
+	}
+}
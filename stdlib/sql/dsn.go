@@ -0,0 +1,114 @@
+package sql
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// dataSourceURL returns the URL of the database server that a data source name connects to,
+// so that it can be validated before connecting.
+func dataSourceURL(driverName, dataSourceName string) (*url.URL, error) {
+	switch driverName {
+	case "mysql":
+		cfg, err := mysql.ParseDSN(dataSourceName)
+		if err != nil {
+			return nil, err
+		}
+		if cfg.Net == "unix" {
+			return &url.URL{Scheme: cfg.Net, Path: cfg.Addr}, nil
+		}
+		return &url.URL{Scheme: cfg.Net, Host: cfg.Addr}, nil
+	case "postgres":
+		return postgresURL(dataSourceName)
+	default:
+		return nil, fmt.Errorf("sql driver %s not supported", driverName)
+	}
+}
+
+// postgresURL returns the URL of the server of a postgres connection string,
+// which is either a URL or a list of key=value settings.
+// The defaults of the settings are those of lib/pq.
+func postgresURL(dataSourceName string) (*url.URL, error) {
+	host, port := "localhost", "5432"
+	if strings.HasPrefix(dataSourceName, "postgres://") || strings.HasPrefix(dataSourceName, "postgresql://") {
+		u, err := url.Parse(dataSourceName)
+		if err != nil {
+			return nil, err
+		}
+		if h := u.Hostname(); h != "" {
+			host = h
+		}
+		if p := u.Port(); p != "" {
+			port = p
+		}
+	} else {
+		settings, err := parsePostgresSettings(dataSourceName)
+		if err != nil {
+			return nil, err
+		}
+		if h, ok := settings["host"]; ok && h != "" {
+			host = h
+		}
+		if p, ok := settings["port"]; ok && p != "" {
+			port = p
+		}
+	}
+	if strings.HasPrefix(host, "/") {
+		// The host is the directory of a unix socket.
+		return &url.URL{Scheme: "unix", Path: host}, nil
+	}
+	return &url.URL{Scheme: "tcp", Host: net.JoinHostPort(host, port)}, nil
+}
+
+// parsePostgresSettings parses the key=value settings of a postgres connection string.
+// Values may be quoted with single quotes, in which case backslashes escape the following character.
+func parsePostgresSettings(s string) (map[string]string, error) {
+	settings := make(map[string]string)
+	i := 0
+	skipSpaces := func() {
+		for i < len(s) && s[i] == ' ' {
+			i++
+		}
+	}
+	for {
+		skipSpaces()
+		if i == len(s) {
+			return settings, nil
+		}
+		start := i
+		for i < len(s) && s[i] != '=' && s[i] != ' ' {
+			i++
+		}
+		key := s[start:i]
+		skipSpaces()
+		if key == "" || i == len(s) || s[i] != '=' {
+			return nil, errors.New("invalid postgres connection string")
+		}
+		i++
+		skipSpaces()
+
+		var value []byte
+		if i < len(s) && s[i] == '\'' {
+			for i++; i < len(s) && s[i] != '\''; i++ {
+				if s[i] == '\\' && i+1 < len(s) {
+					i++
+				}
+				value = append(value, s[i])
+			}
+			if i == len(s) {
+				return nil, errors.New("unterminated quoted value in postgres connection string")
+			}
+			i++
+		} else {
+			for ; i < len(s) && s[i] != ' '; i++ {
+				value = append(value, s[i])
+			}
+		}
+		settings[key] = string(value)
+	}
+}
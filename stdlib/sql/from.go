@@ -5,15 +5,16 @@ import (
 	"fmt"
 
 	"reflect"
+	"strings"
 	"time"
 
-	_ "github.com/go-sql-driver/mysql"
 	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/dependencies"
 	"github.com/influxdata/flux/execute"
 	"github.com/influxdata/flux/plan"
 	"github.com/influxdata/flux/semantic"
 	"github.com/influxdata/flux/values"
-	_ "github.com/lib/pq"
+	"go.uber.org/zap"
 )
 
 const FromSQLKind = "fromSQL"
@@ -72,11 +73,19 @@ func (s *FromSQLOpSpec) Kind() flux.OperationKind {
 	return FromSQLKind
 }
 
+func (s *FromSQLOpSpec) Resources() []flux.Resource {
+	return []flux.Resource{{Kind: flux.SQLResource, Name: s.DataSourceName, Mode: flux.ReadAccess}}
+}
+
 type FromSQLProcedureSpec struct {
 	plan.DefaultCost
 	DriverName     string
 	DataSourceName string
 	Query          string
+	// Limit is the number of rows of the result of the query that are read, after skipping Offset rows.
+	// The rows are not limited if it is zero. The limit is pushed down by PushDownLimitRule.
+	Limit  int64
+	Offset int64
 }
 
 func newFromSQLProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
@@ -101,17 +110,38 @@ func (s *FromSQLProcedureSpec) Copy() plan.ProcedureSpec {
 	ns.DriverName = s.DriverName
 	ns.DataSourceName = s.DataSourceName
 	ns.Query = s.Query
+	ns.Limit = s.Limit
+	ns.Offset = s.Offset
 	return ns
 }
 
+// ExternalSystem implements plan.ExternalProcedureSpec, the query is executed by the database.
+func (s *FromSQLProcedureSpec) ExternalSystem() string {
+	return s.DriverName
+}
+
+// query returns the query that is sent to the database,
+// which wraps the query of the script to apply a limit that has been pushed down.
+func (s *FromSQLProcedureSpec) query() string {
+	if s.Limit == 0 {
+		return s.Query
+	}
+	q := strings.TrimRight(s.Query, "; \t\r\n")
+	return fmt.Sprintf("SELECT * FROM (%s) AS limited LIMIT %d OFFSET %d", q, s.Limit, s.Offset)
+}
+
 func createFromSQLSource(prSpec plan.ProcedureSpec, dsid execute.DatasetID, a execute.Administration) (execute.Source, error) {
 	spec, ok := prSpec.(*FromSQLProcedureSpec)
 	if !ok {
 		return nil, fmt.Errorf("invalid spec type %T", prSpec)
 	}
 
-	if spec.DriverName != "postgres" && spec.DriverName != "mysql" {
-		return nil, fmt.Errorf("sql driver %s not supported", spec.DriverName)
+	u, err := dataSourceURL(spec.DriverName, spec.DataSourceName)
+	if err != nil {
+		return nil, err
+	}
+	if err := dependencies.Get(a.Context()).URLValidator.Validate(u); err != nil {
+		return nil, err
 	}
 
 	SQLIterator := SQLIterator{id: dsid, spec: spec, administration: a}
@@ -127,12 +157,18 @@ type SQLIterator struct {
 	rows           *sql.Rows
 }
 
+// logger returns the logger of the dependencies of the query.
+func (c *SQLIterator) logger() *zap.Logger {
+	return dependencies.Get(c.administration.Context()).Logger
+}
+
 func (c *SQLIterator) Connect() error {
-	db, err := sql.Open(c.spec.DriverName, c.spec.DataSourceName)
+	db, err := openDB(c.spec.DriverName, c.spec.DataSourceName)
 	if err != nil {
 		return err
 	}
-	if err = db.Ping(); err != nil {
+	if err = db.PingContext(c.administration.Context()); err != nil {
+		c.logger().Warn("failed to connect to the database", zap.String("driver", c.spec.DriverName), zap.Error(err))
 		return err
 	}
 	c.db = db
@@ -141,8 +177,9 @@ func (c *SQLIterator) Connect() error {
 }
 
 func (c *SQLIterator) Fetch() (bool, error) {
-	rows, err := c.db.Query(c.spec.Query)
+	rows, err := c.db.QueryContext(c.administration.Context(), c.spec.query())
 	if err != nil {
+		c.logger().Warn("failed to query the database", zap.String("driver", c.spec.DriverName), zap.Error(err))
 		return false, err
 	}
 	c.rows = rows
@@ -238,6 +275,10 @@ func (c *SQLIterator) Decode() (flux.Table, error) {
 		}
 	}
 
+	execute.ReportUsage(c.administration.Context(), execute.Usage{
+		SeriesScanned: 1,
+		PointsScanned: int64(builder.NRows()),
+	})
 	return builder.Table()
 }
 
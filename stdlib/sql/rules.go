@@ -0,0 +1,42 @@
+package sql
+
+import (
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/stdlib/universe"
+)
+
+func init() {
+	plan.RegisterPhysicalRules(PushDownLimitRule{})
+}
+
+// PushDownLimitRule merges a limit() into a preceding sql.from(),
+// so that the database only returns the rows that the limit keeps.
+// sql.from() produces a single table, so the limit applies to all the rows of the query.
+type PushDownLimitRule struct{}
+
+func (PushDownLimitRule) Name() string {
+	return "PushDownSQLLimitRule"
+}
+
+// Pattern matches `sql.from |> limit`
+func (PushDownLimitRule) Pattern() plan.Pattern {
+	return plan.Pat(universe.LimitKind, plan.Pat(FromSQLKind))
+}
+
+func (PushDownLimitRule) Rewrite(node plan.PlanNode) (plan.PlanNode, bool, error) {
+	fromNode := node.Predecessors()[0]
+	fromSpec := fromNode.ProcedureSpec().(*FromSQLProcedureSpec)
+	limitSpec := node.ProcedureSpec().(*universe.LimitProcedureSpec)
+	if fromSpec.Limit != 0 || limitSpec.N <= 0 || len(fromNode.Successors()) != 1 {
+		return node, false, nil
+	}
+
+	newSpec := fromSpec.Copy().(*FromSQLProcedureSpec)
+	newSpec.Limit = limitSpec.N
+	newSpec.Offset = limitSpec.Offset
+	merged, err := plan.MergeToPhysicalPlanNode(node, fromNode, newSpec)
+	if err != nil {
+		return nil, false, err
+	}
+	return merged, true, nil
+}
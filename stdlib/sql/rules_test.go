@@ -0,0 +1,66 @@
+package sql
+
+import (
+	"testing"
+
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/plan/plantest"
+	"github.com/influxdata/flux/stdlib/universe"
+)
+
+func TestPushDownLimitRule(t *testing.T) {
+	from := &FromSQLProcedureSpec{
+		DriverName:     "postgres",
+		DataSourceName: "postgres://localhost/metrics",
+		Query:          "SELECT * FROM cpu",
+	}
+	limited := from.Copy().(*FromSQLProcedureSpec)
+	limited.Limit = 10
+	limited.Offset = 5
+
+	specs := plantest.Specs{
+		"from":    from,
+		"limited": limited,
+		"limit":   &universe.LimitProcedureSpec{},
+		"count":   &universe.CountProcedureSpec{},
+	}
+
+	tests := []plantest.RuleTestCase{
+		{
+			Name:   "limit",
+			Rules:  []plan.Rule{PushDownLimitRule{}},
+			Before: plantest.MustParsePlan(`physical; from = from -> limit = limit {"n": 10, "offset": 5}`, specs),
+			After:  plantest.MustParsePlan("physical; merged_from_limit = limited", specs),
+		},
+		{
+			Name:     "already limited",
+			Rules:    []plan.Rule{PushDownLimitRule{}},
+			Before:   plantest.MustParsePlan(`physical; from = limited -> limit = limit {"n": 3}`, specs),
+			NoChange: true,
+		},
+		{
+			Name:     "multiple successors",
+			Rules:    []plan.Rule{PushDownLimitRule{}},
+			Before:   plantest.MustParsePlan(`physical; from = from -> limit = limit {"n": 10}; from -> count = count`, specs),
+			NoChange: true,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			plantest.PhysicalRuleTestHelper(t, &tc)
+		})
+	}
+}
+
+func TestFromSQLProcedureSpec_Query(t *testing.T) {
+	spec := &FromSQLProcedureSpec{Query: "SELECT * FROM cpu;\n"}
+	if want, got := spec.Query, spec.query(); want != got {
+		t.Errorf("unexpected query: want %q, got %q", want, got)
+	}
+	spec.Limit, spec.Offset = 10, 5
+	if want, got := "SELECT * FROM (SELECT * FROM cpu) AS limited LIMIT 10 OFFSET 5", spec.query(); want != got {
+		t.Errorf("unexpected query: want %q, got %q", want, got)
+	}
+}
@@ -0,0 +1,114 @@
+package strings
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/flux/values"
+)
+
+const (
+	otherArg = "t"
+)
+
+var errMissingT = fmt.Errorf("missing argument %q", otherArg)
+
+// generateCompareFunction returns a function that compares the strings v and t into a value of type ret.
+func generateCompareFunction(name string, ret semantic.Nature, compareFn func(v, t string) values.Value) values.Function {
+	return values.NewFunction(
+		name,
+		semantic.NewFunctionPolyType(semantic.NewSignatureBuilder().
+			Required(stringArg, semantic.String).
+			Required(otherArg, semantic.String).
+			Return(ret).
+			MustBuild()),
+		func(args values.Object) (values.Value, error) {
+			v, ok := args.Get(stringArg)
+			if !ok {
+				return nil, errMissingV
+			}
+			t, ok := args.Get(otherArg)
+			if !ok {
+				return nil, errMissingT
+			}
+
+			if v.Type().Nature() == semantic.String && t.Type().Nature() == semantic.String {
+				return compareFn(v.Str(), t.Str()), nil
+			}
+
+			return nil, fmt.Errorf("cannot compare arguments of type %v and %v", v.Type().Nature(), t.Type().Nature())
+		},
+		false,
+	)
+}
+
+func init() {
+	flux.RegisterPackageValue("strings", "equalFold", generateCompareFunction("equalFold", semantic.Bool, equalFold))
+	flux.RegisterPackageValue("strings", "levenshtein", generateCompareFunction("levenshtein", semantic.Int, levenshteinDistance))
+	flux.RegisterPackageValue("strings", "similarity", generateCompareFunction("similarity", semantic.Float, similarityScore))
+}
+
+// equalFold reports whether v and t are equal under Unicode case folding.
+func equalFold(v, t string) values.Value {
+	return values.NewBool(strings.EqualFold(v, t))
+}
+
+func levenshteinDistance(v, t string) values.Value {
+	return values.NewInt(int64(levenshtein([]rune(v), []rune(t))))
+}
+
+func similarityScore(v, t string) values.Value {
+	return values.NewFloat(similarity(v, t))
+}
+
+// levenshtein returns the smallest number of insertions, deletions and substitutions of runes
+// that turns v into t.
+func levenshtein(v, t []rune) int {
+	if len(v) < len(t) {
+		v, t = t, v
+	}
+	// Only the previous row of the distances between the prefixes of v and t is kept.
+	row := make([]int, len(t)+1)
+	for j := range row {
+		row[j] = j
+	}
+	for i := 1; i <= len(v); i++ {
+		prev := row[0]
+		row[0] = i
+		for j := 1; j <= len(t); j++ {
+			cost := 1
+			if v[i-1] == t[j-1] {
+				cost = 0
+			}
+			d := minInt(row[j]+1, row[j-1]+1, prev+cost)
+			prev, row[j] = row[j], d
+		}
+	}
+	return row[len(t)]
+}
+
+// similarity returns a score between 0 and 1 of how similar v and t are,
+// 1 minus their Levenshtein distance divided by the length of the longest of them.
+// Two empty strings are identical.
+func similarity(v, t string) float64 {
+	vr, tr := []rune(v), []rune(t)
+	n := len(vr)
+	if len(tr) > n {
+		n = len(tr)
+	}
+	if n == 0 {
+		return 1
+	}
+	return 1 - float64(levenshtein(vr, tr))/float64(n)
+}
+
+func minInt(x int, ys ...int) int {
+	for _, y := range ys {
+		if y < x {
+			x = y
+		}
+	}
+	return x
+}
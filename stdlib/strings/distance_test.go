@@ -0,0 +1,84 @@
+package strings
+
+import (
+	"testing"
+
+	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/flux/values"
+)
+
+func TestEqualFold(t *testing.T) {
+	testCases := []struct {
+		name string
+		v    string
+		t    string
+		want bool
+	}{
+		{
+			name: "different case",
+			v:    "Server-01",
+			t:    "SERVER-01",
+			want: true,
+		},
+		{
+			name: "different strings",
+			v:    "server-01",
+			t:    "server-02",
+			want: false,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			equalFold := generateCompareFunction("equalFold", semantic.Bool, equalFold)
+			result, err := equalFold.Call(values.NewObjectWithValues(map[string]values.Value{"v": values.NewString(tc.v), "t": values.NewString(tc.t)}))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result.Bool() != tc.want {
+				t.Errorf("unexpected result of equalFold(%q, %q): want %v, got %v", tc.v, tc.t, tc.want, result)
+			}
+		})
+	}
+}
+
+func TestLevenshtein(t *testing.T) {
+	testCases := []struct {
+		v    string
+		t    string
+		want int
+	}{
+		{v: "", t: "", want: 0},
+		{v: "", t: "abc", want: 3},
+		{v: "kitten", t: "sitting", want: 3},
+		{v: "sitting", t: "kitten", want: 3},
+		{v: "flaw", t: "lawn", want: 2},
+		{v: "host-a", t: "host-a", want: 0},
+		// Runes are compared rather than bytes.
+		{v: "café", t: "cafe", want: 1},
+	}
+	for _, tc := range testCases {
+		if got := levenshtein([]rune(tc.v), []rune(tc.t)); got != tc.want {
+			t.Errorf("unexpected distance between %q and %q: want %d, got %d", tc.v, tc.t, tc.want, got)
+		}
+	}
+}
+
+func TestSimilarity(t *testing.T) {
+	testCases := []struct {
+		v    string
+		t    string
+		want float64
+	}{
+		{v: "", t: "", want: 1},
+		{v: "abcd", t: "abcd", want: 1},
+		{v: "abcd", t: "abce", want: 0.75},
+		{v: "abcd", t: "", want: 0},
+		{v: "ab", t: "abcd", want: 0.5},
+	}
+	for _, tc := range testCases {
+		if got := similarity(tc.v, tc.t); got != tc.want {
+			t.Errorf("unexpected similarity of %q and %q: want %v, got %v", tc.v, tc.t, tc.want, got)
+		}
+	}
+}
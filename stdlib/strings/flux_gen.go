@@ -21,11 +21,11 @@ var pkgAST = &ast.Package{
 			Errors: nil,
 			Loc: &ast.SourceLocation{
 				End: ast.Position{
-					Column: 18,
-					Line:   8,
+					Column: 19,
+					Line:   14,
 				},
 				File:   "strings.flux",
-				Source: "package strings\n\n// Transformation functions\nbuiltin trim\nbuiltin toUpper\nbuiltin toLower\nbuiltin title\nbuiltin trimSpace",
+				Source: "package strings\n\n// Transformation functions\nbuiltin trim\nbuiltin toUpper\nbuiltin toLower\nbuiltin title\nbuiltin trimSpace\nbuiltin toTitle\n\n// Comparison functions\nbuiltin equalFold\nbuiltin levenshtein\nbuiltin similarity",
 				Start: ast.Position{
 					Column: 1,
 					Line:   1,
@@ -202,6 +202,142 @@ var pkgAST = &ast.Package{
 				},
 				Name: "trimSpace",
 			},
+		}, &ast.BuiltinStatement{
+			BaseNode: ast.BaseNode{
+				Errors: nil,
+				Loc: &ast.SourceLocation{
+					End: ast.Position{
+						Column: 16,
+						Line:   9,
+					},
+					File:   "strings.flux",
+					Source: "builtin toTitle",
+					Start: ast.Position{
+						Column: 1,
+						Line:   9,
+					},
+				},
+			},
+			ID: &ast.Identifier{
+				BaseNode: ast.BaseNode{
+					Errors: nil,
+					Loc: &ast.SourceLocation{
+						End: ast.Position{
+							Column: 16,
+							Line:   9,
+						},
+						File:   "strings.flux",
+						Source: "toTitle",
+						Start: ast.Position{
+							Column: 9,
+							Line:   9,
+						},
+					},
+				},
+				Name: "toTitle",
+			},
+		}, &ast.BuiltinStatement{
+			BaseNode: ast.BaseNode{
+				Errors: nil,
+				Loc: &ast.SourceLocation{
+					End: ast.Position{
+						Column: 18,
+						Line:   12,
+					},
+					File:   "strings.flux",
+					Source: "builtin equalFold",
+					Start: ast.Position{
+						Column: 1,
+						Line:   12,
+					},
+				},
+			},
+			ID: &ast.Identifier{
+				BaseNode: ast.BaseNode{
+					Errors: nil,
+					Loc: &ast.SourceLocation{
+						End: ast.Position{
+							Column: 18,
+							Line:   12,
+						},
+						File:   "strings.flux",
+						Source: "equalFold",
+						Start: ast.Position{
+							Column: 9,
+							Line:   12,
+						},
+					},
+				},
+				Name: "equalFold",
+			},
+		}, &ast.BuiltinStatement{
+			BaseNode: ast.BaseNode{
+				Errors: nil,
+				Loc: &ast.SourceLocation{
+					End: ast.Position{
+						Column: 20,
+						Line:   13,
+					},
+					File:   "strings.flux",
+					Source: "builtin levenshtein",
+					Start: ast.Position{
+						Column: 1,
+						Line:   13,
+					},
+				},
+			},
+			ID: &ast.Identifier{
+				BaseNode: ast.BaseNode{
+					Errors: nil,
+					Loc: &ast.SourceLocation{
+						End: ast.Position{
+							Column: 20,
+							Line:   13,
+						},
+						File:   "strings.flux",
+						Source: "levenshtein",
+						Start: ast.Position{
+							Column: 9,
+							Line:   13,
+						},
+					},
+				},
+				Name: "levenshtein",
+			},
+		}, &ast.BuiltinStatement{
+			BaseNode: ast.BaseNode{
+				Errors: nil,
+				Loc: &ast.SourceLocation{
+					End: ast.Position{
+						Column: 19,
+						Line:   14,
+					},
+					File:   "strings.flux",
+					Source: "builtin similarity",
+					Start: ast.Position{
+						Column: 1,
+						Line:   14,
+					},
+				},
+			},
+			ID: &ast.Identifier{
+				BaseNode: ast.BaseNode{
+					Errors: nil,
+					Loc: &ast.SourceLocation{
+						End: ast.Position{
+							Column: 19,
+							Line:   14,
+						},
+						File:   "strings.flux",
+						Source: "similarity",
+						Start: ast.Position{
+							Column: 9,
+							Line:   14,
+						},
+					},
+				},
+				Name: "similarity",
+			},
 		}},
 		Imports: nil,
 		Name:    "strings.flux",
@@ -12,11 +12,10 @@ import (
 func generateStringFunction(name string, stringFn func(string) string) values.Function {
 	return values.NewFunction(
 		name,
-		semantic.NewFunctionPolyType(semantic.FunctionPolySignature{
-			Parameters: map[string]semantic.PolyType{stringArg: semantic.String},
-			Required:   semantic.LabelSet{stringArg},
-			Return:     semantic.String,
-		}),
+		semantic.NewFunctionPolyType(semantic.NewSignatureBuilder().
+			Required(stringArg, semantic.String).
+			Return(semantic.String).
+			MustBuild()),
 		func(args values.Object) (values.Value, error) {
 			var str string
 
@@ -43,4 +42,5 @@ func init() {
 	flux.RegisterPackageValue("strings", "title", generateStringFunction("title", strings.Title))
 	flux.RegisterPackageValue("strings", "toUpper", generateStringFunction("toUpper", strings.ToUpper))
 	flux.RegisterPackageValue("strings", "toLower", generateStringFunction("toLower", strings.ToLower))
+	flux.RegisterPackageValue("strings", "toTitle", generateStringFunction("toTitle", strings.ToTitle))
 }
@@ -168,3 +168,40 @@ func TestToLower(t *testing.T) {
 
 	}
 }
+
+func TestToTitle(t *testing.T) {
+	testCases := []struct {
+		name string
+		v    string
+		want string
+	}{
+		{
+			name: "lower case string",
+			v:    "a giraffe",
+			want: "A GIRAFFE",
+		},
+		{
+			name: "digraph",
+			v:    "ǆ",
+			want: "ǅ",
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			toTitle := generateStringFunction("toTitle", strings.ToTitle)
+			testCase := values.NewObjectWithValues(map[string]values.Value{"v": values.NewString(tc.v)})
+			result, err := toTitle.Call(testCase)
+			res := result.Str()
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if res != tc.want {
+				t.Errorf("string function result %s expected: %s, got: %s", tc.name, tc.want, result)
+			}
+		})
+
+	}
+}
@@ -10,14 +10,11 @@ import (
 func generateMultiArgStringFunction(name string, stringFn func(string, string) string) values.Function {
 	return values.NewFunction(
 		name,
-		semantic.NewFunctionPolyType(semantic.FunctionPolySignature{
-			Parameters: map[string]semantic.PolyType{
-				stringArg: semantic.String,
-				cutset:    semantic.String,
-			},
-			Required: semantic.LabelSet{stringArg, cutset},
-			Return:   semantic.String,
-		}),
+		semantic.NewFunctionPolyType(semantic.NewSignatureBuilder().
+			Required(stringArg, semantic.String).
+			Required(cutset, semantic.String).
+			Return(semantic.String).
+			MustBuild()),
 		func(args values.Object) (values.Value, error) {
 			var str string
 			var cutsetStr string
@@ -1,25 +1,23 @@
 package testing
 
 import (
-	"bytes"
 	"errors"
 	"fmt"
-	"sort"
 	"sync"
 
-	"github.com/apache/arrow/go/arrow/array"
 	"github.com/influxdata/flux"
-	"github.com/influxdata/flux/arrow"
 	"github.com/influxdata/flux/execute"
 	"github.com/influxdata/flux/memory"
 	"github.com/influxdata/flux/plan"
 	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/flux/tableutil"
 )
 
 const DiffKind = "diff"
 
 type DiffOpSpec struct {
-	Verbose bool `json:"verbose,omitempty"`
+	Verbose bool    `json:"verbose,omitempty"`
+	Epsilon float64 `json:"epsilon,omitempty"`
 }
 
 func (s *DiffOpSpec) Kind() flux.OperationKind {
@@ -27,16 +25,13 @@ func (s *DiffOpSpec) Kind() flux.OperationKind {
 }
 
 func init() {
-	diffSignature := semantic.FunctionPolySignature{
-		Parameters: map[string]semantic.PolyType{
-			"verbose": semantic.Bool,
-			"got":     flux.TableObjectType,
-			"want":    flux.TableObjectType,
-		},
-		Required:     semantic.LabelSet{"got", "want"},
-		Return:       flux.TableObjectType,
-		PipeArgument: "got",
-	}
+	diffSignature := semantic.NewSignatureBuilder().
+		Pipe("got", flux.TableObjectType).
+		Required("want", flux.TableObjectType).
+		Optional("verbose", semantic.Bool).
+		Optional("epsilon", semantic.Float).
+		Return(flux.TableObjectType).
+		MustBuild()
 
 	flux.RegisterPackageValue("testing", "diff", flux.FunctionValue(DiffKind, createDiffOpSpec, diffSignature))
 	flux.RegisterOpSpec(DiffKind, newDiffOp)
@@ -72,7 +67,16 @@ func createDiffOpSpec(args flux.Arguments, a *flux.Administration) (flux.Operati
 		verbose = false
 	}
 
-	return &DiffOpSpec{Verbose: verbose}, nil
+	epsilon, ok, err := args.GetFloat("epsilon")
+	if err != nil {
+		return nil, err
+	} else if !ok {
+		epsilon = 0
+	} else if epsilon < 0 {
+		return nil, errors.New("epsilon of diff must not be negative")
+	}
+
+	return &DiffOpSpec{Verbose: verbose, Epsilon: epsilon}, nil
 }
 
 func newDiffOp() flux.OperationSpec {
@@ -82,6 +86,7 @@ func newDiffOp() flux.OperationSpec {
 type DiffProcedureSpec struct {
 	plan.DefaultCost
 	Verbose bool
+	Epsilon float64
 }
 
 func (s *DiffProcedureSpec) Kind() plan.ProcedureKind {
@@ -98,7 +103,7 @@ func newDiffProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.Proce
 	if !ok {
 		return nil, fmt.Errorf("invalid spec type %T", qs)
 	}
-	return &DiffProcedureSpec{Verbose: spec.Verbose}, nil
+	return &DiffProcedureSpec{Verbose: spec.Verbose, Epsilon: spec.Epsilon}, nil
 }
 
 type DiffTransformation struct {
@@ -109,164 +114,16 @@ type DiffTransformation struct {
 
 	d     execute.Dataset
 	cache execute.TableBuilderCache
+	alloc *memory.Allocator
+	opts  tableutil.Options
 
 	inputCache *execute.GroupLookup
 }
 
-type tableBuffer struct {
-	id      execute.DatasetID
-	columns map[string]*tableColumn
-	sz      int
-}
-
-func (tb *tableBuffer) Release() {
-	for _, col := range tb.columns {
-		col.Values.Release()
-	}
-}
-
-type tableColumn struct {
-	Type   flux.ColType
-	Values array.Interface
-}
-
-func copyTable(id execute.DatasetID, tbl flux.Table) (*tableBuffer, error) {
-	// Find the value columns for the table and save them.
-	// We do not care about the group key.
-	type tableBuilderColumn struct {
-		Type    flux.ColType
-		Builder array.Builder
-	}
-	builders := make(map[string]tableBuilderColumn)
-	for _, col := range tbl.Cols() {
-		if tbl.Key().HasCol(col.Label) {
-			continue
-		}
-
-		bc := tableBuilderColumn{Type: col.Type}
-		switch col.Type {
-		case flux.TFloat:
-			bc.Builder = arrow.NewFloatBuilder(nil)
-		case flux.TInt:
-			bc.Builder = arrow.NewIntBuilder(nil)
-		case flux.TUInt:
-			bc.Builder = arrow.NewUintBuilder(nil)
-		case flux.TString:
-			bc.Builder = arrow.NewStringBuilder(nil)
-		case flux.TBool:
-			bc.Builder = arrow.NewBoolBuilder(nil)
-		case flux.TTime:
-			bc.Builder = arrow.NewIntBuilder(nil)
-		default:
-			return nil, errors.New("implement me")
-		}
-		builders[col.Label] = bc
-	}
-
-	sz := 0
-	if err := tbl.Do(func(cr flux.ColReader) error {
-		sz += cr.Len()
-		for j, col := range cr.Cols() {
-			if tbl.Key().HasCol(col.Label) {
-				continue
-			}
-
-			switch col.Type {
-			case flux.TFloat:
-				b := builders[col.Label].Builder.(*array.Float64Builder)
-				b.Reserve(cr.Len())
-
-				vs := cr.Floats(j)
-				for i := 0; i < vs.Len(); i++ {
-					if vs.IsValid(i) {
-						b.Append(vs.Value(i))
-					} else {
-						b.AppendNull()
-					}
-				}
-			case flux.TInt:
-				b := builders[col.Label].Builder.(*array.Int64Builder)
-				b.Reserve(cr.Len())
-
-				vs := cr.Ints(j)
-				for i := 0; i < vs.Len(); i++ {
-					if vs.IsValid(i) {
-						b.Append(vs.Value(i))
-					} else {
-						b.AppendNull()
-					}
-				}
-			case flux.TUInt:
-				b := builders[col.Label].Builder.(*array.Uint64Builder)
-				b.Reserve(cr.Len())
-
-				vs := cr.UInts(j)
-				for i := 0; i < vs.Len(); i++ {
-					if vs.IsValid(i) {
-						b.Append(vs.Value(i))
-					} else {
-						b.AppendNull()
-					}
-				}
-			case flux.TString:
-				b := builders[col.Label].Builder.(*array.BinaryBuilder)
-				b.Reserve(cr.Len())
-
-				vs := cr.Strings(j)
-				for i := 0; i < vs.Len(); i++ {
-					if vs.IsValid(i) {
-						b.Append(vs.Value(i))
-					} else {
-						b.AppendNull()
-					}
-				}
-			case flux.TBool:
-				b := builders[col.Label].Builder.(*array.BooleanBuilder)
-				b.Reserve(cr.Len())
-
-				vs := cr.Bools(j)
-				for i := 0; i < vs.Len(); i++ {
-					if vs.IsValid(i) {
-						b.Append(vs.Value(i))
-					} else {
-						b.AppendNull()
-					}
-				}
-			case flux.TTime:
-				b := builders[col.Label].Builder.(*array.Int64Builder)
-				b.Reserve(cr.Len())
-
-				vs := cr.Times(j)
-				for i := 0; i < vs.Len(); i++ {
-					if vs.IsValid(i) {
-						b.Append(vs.Value(i))
-					} else {
-						b.AppendNull()
-					}
-				}
-			default:
-				return errors.New("implement me")
-			}
-		}
-		return nil
-	}); err != nil {
-		return nil, err
-	}
-
-	// Construct each of the columns and then store the table buffer.
-	columns := make(map[string]*tableColumn, len(builders))
-	for label, bc := range builders {
-		columns[label] = &tableColumn{
-			Type:   bc.Type,
-			Values: bc.Builder.NewArray(),
-		}
-		bc.Builder.Release()
-	}
-	return &tableBuffer{
-		id:      id,
-		columns: columns,
-		sz:      sz,
-	}, nil
+// bufferedTable is a table that waits for the table with the same key in the other stream.
+type bufferedTable struct {
+	id  execute.DatasetID
+	tbl flux.Table
 }
 
 func createDiffTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
@@ -292,6 +149,8 @@ func NewDiffTransformation(d execute.Dataset, cache execute.TableBuilderCache, s
 		gotID:      gotID,
 		d:          d,
 		cache:      cache,
+		alloc:      a,
+		opts:       tableutil.Options{Epsilon: spec.Epsilon},
 		inputCache: execute.NewGroupLookup(),
 		finished:   make(map[execute.DatasetID]bool, 2),
 	}
@@ -312,260 +171,79 @@ func (t *DiffTransformation) Process(id execute.DatasetID, tbl flux.Table) error
 		return nil
 	}
 
-	// Copy the table we are processing into a buffer.
-	// This may or may not be the want table. We fix that later.
-	want, err := copyTable(id, tbl)
-	if err != nil {
-		return err
-	}
-
-	// Look in the input cache for a table buffer.
-	var got *tableBuffer
-	if obj, ok := t.inputCache.Delete(tbl.Key()); !ok {
+	// Look in the input cache for the table of the other stream.
+	obj, ok := t.inputCache.Delete(tbl.Key())
+	if !ok {
 		// We did not find an entry. If the other table has
-		// not been finished, we need to store this table
+		// not been finished, we need to store a copy of this table
 		// for later usage.
-		if len(t.finished) != 1 || !t.finished[id] {
-			t.inputCache.Set(tbl.Key(), want)
+		if len(t.finished) == 0 {
+			cpy, err := execute.CopyTable(tbl, t.alloc)
+			if err != nil {
+				return err
+			}
+			t.inputCache.Set(tbl.Key(), &bufferedTable{id: id, tbl: cpy})
 			return nil
 		}
-
-		// The other table has been finished so we can construct
-		// this table immediately. Generate an empty table buffer.
-		got = &tableBuffer{}
-	} else {
-		// Otherwise, we assign the stored table buffer to got
-		// so we can generate the diff.
-		got = obj.(*tableBuffer)
+		// The other table has been finished so this table is missing from it.
+		return t.diff(id, tbl, nil)
 	}
+	return t.diff(id, tbl, obj.(*bufferedTable).tbl)
+}
 
-	// If the want table does not match the want id, we need to swap
-	// the tables. We use want here instead of got because goot
-	// may be a pseudo-table we created above and we only need to
-	// test one of them.
-	if want.id != t.wantID {
-		got, want = want, got
+// diff produces the diff of a table of the stream id and the table with the same key
+// in the other stream, which is nil if it is missing.
+func (t *DiffTransformation) diff(id execute.DatasetID, tbl, other flux.Table) error {
+	want, got := tbl, other
+	if id != t.wantID {
+		want, got = got, want
+	}
+	td, err := tableutil.DiffTables(want, got, t.opts)
+	if err != nil {
+		return err
+	} else if td == nil || len(td.Rows) == 0 {
+		return nil
 	}
-	return t.diff(tbl.Key(), want, got)
-}
 
-func (t *DiffTransformation) createSchema(builder execute.TableBuilder, want, got *tableBuffer) (diffIdx int, colMap map[string]int, err error) {
 	// Construct the table schema by adding columns for the table key
 	// (which, by definition, cannot be different at this point),
-	// a _diff column for the marker, and then the columns  for each
+	// a _diff column for the marker, and then the columns for each
 	// of the value types in alphabetical order.
-	if err := execute.AddTableKeyCols(builder.Key(), builder); err != nil {
-		return 0, nil, err
+	builder, created := t.cache.TableBuilder(td.Key)
+	if !created {
+		return errors.New("duplicate table key")
 	}
-	diffIdx, err = builder.AddCol(flux.ColMeta{
+	if err := execute.AddTableKeyCols(td.Key, builder); err != nil {
+		return err
+	}
+	diffIdx, err := builder.AddCol(flux.ColMeta{
 		Label: "_diff",
 		Type:  flux.TString,
 	})
-	if err != nil {
-		return 0, nil, err
-	}
-
-	// Determine all of the column names and their types.
-	colTypes := make(map[string]flux.ColType)
-	for label, col := range want.columns {
-		colTypes[label] = col.Type
-	}
-	for label, col := range got.columns {
-		if typ, ok := colTypes[label]; ok && typ != col.Type {
-			return 0, nil, fmt.Errorf("column types differ: want=%s got=%s", typ, col.Type)
-		} else if !ok {
-			colTypes[label] = col.Type
-		}
-	}
-
-	labels := make([]string, 0, len(colTypes))
-	for label := range colTypes {
-		labels = append(labels, label)
-	}
-	sort.Strings(labels)
-
-	// Now construct the schema and mark the column ids.
-	colMap = make(map[string]int)
-	for _, label := range labels {
-		idx, err := builder.AddCol(flux.ColMeta{
-			Label: label,
-			Type:  colTypes[label],
-		})
-		if err != nil {
-			return 0, nil, err
-		}
-		colMap[label] = idx
-	}
-	return diffIdx, colMap, nil
-}
-
-func (t *DiffTransformation) diff(key flux.GroupKey, want, got *tableBuffer) error {
-	// Find the smallest size for the tables. We will only iterate
-	// over these rows.
-	sz := want.sz
-	if got.sz < sz {
-		sz = got.sz
-	}
-
-	// Look for the first row that is unequal. This is only needed
-	// if the sizes are the same.
-	i := 0
-	if want.sz == got.sz {
-		for ; i < sz; i++ {
-			if eq := t.rowEqual(want, got, i); !eq {
-				break
-			}
-		}
-
-		// The tables are equal.
-		if i == sz {
-			return nil
-		}
-	}
-
-	// This diff algorithm is not really a smart diff. We may want to
-	// fix that in the future and we reserve the right to do that, but
-	// this will just check the first row of one table with the first
-	// row of the other.
-	// First, construct an output table.
-	builder, created := t.cache.TableBuilder(key)
-	if !created {
-		return errors.New("duplicate table key")
-	}
-
-	diffIdx, columnIdxs, err := t.createSchema(builder, want, got)
 	if err != nil {
 		return err
 	}
-
-	for ; i < sz; i++ {
-		if eq := t.rowEqual(want, got, i); !eq {
-			if err := t.appendRow(builder, i, diffIdx, "-", want, columnIdxs); err != nil {
-				return err
-			}
-			if err := t.appendRow(builder, i, diffIdx, "+", got, columnIdxs); err != nil {
-				return err
-			}
+	colIdxs := make([]int, len(td.Columns))
+	for j, c := range td.Columns {
+		if colIdxs[j], err = builder.AddCol(c); err != nil {
+			return err
 		}
 	}
 
-	// Append the remainder of the rows.
-	for i := sz; i < want.sz; i++ {
-		if err := t.appendRow(builder, i, diffIdx, "-", want, columnIdxs); err != nil {
+	for _, row := range td.Rows {
+		if err := execute.AppendKeyValues(td.Key, builder); err != nil {
 			return err
 		}
-	}
-	for i := sz; i < got.sz; i++ {
-		if err := t.appendRow(builder, i, diffIdx, "+", got, columnIdxs); err != nil {
+		if err := builder.AppendString(diffIdx, string(row.Op)); err != nil {
 			return err
 		}
-	}
-	return nil
-}
-
-func (t *DiffTransformation) rowEqual(want, got *tableBuffer, i int) bool {
-	if len(want.columns) != len(got.columns) {
-		return false
-	}
-
-	for label, wantCol := range want.columns {
-		gotCol, ok := got.columns[label]
-		if !ok {
-			return false
-		}
-
-		if wantCol.Values.IsValid(i) != gotCol.Values.IsValid(i) {
-			return false
-		} else if wantCol.Values.IsNull(i) {
-			continue
-		}
-
-		switch wantCol.Type {
-		case flux.TFloat:
-			want, got := wantCol.Values.(*array.Float64), gotCol.Values.(*array.Float64)
-			if want.Value(i) != got.Value(i) {
-				return false
-			}
-		case flux.TInt:
-			want, got := wantCol.Values.(*array.Int64), gotCol.Values.(*array.Int64)
-			if want.Value(i) != got.Value(i) {
-				return false
-			}
-		case flux.TUInt:
-			want, got := wantCol.Values.(*array.Uint64), gotCol.Values.(*array.Uint64)
-			if want.Value(i) != got.Value(i) {
-				return false
-			}
-		case flux.TString:
-			want, got := wantCol.Values.(*array.Binary), gotCol.Values.(*array.Binary)
-			if !bytes.Equal(want.Value(i), got.Value(i)) {
-				return false
+		for j, v := range row.Values {
+			if v == nil || v.IsNull() {
+				err = builder.AppendNil(colIdxs[j])
+			} else {
+				err = builder.AppendValue(colIdxs[j], v)
 			}
-		case flux.TBool:
-			want, got := wantCol.Values.(*array.Boolean), gotCol.Values.(*array.Boolean)
-			if want.Value(i) != got.Value(i) {
-				return false
-			}
-		case flux.TTime:
-			want, got := wantCol.Values.(*array.Int64), gotCol.Values.(*array.Int64)
-			if want.Value(i) != got.Value(i) {
-				return false
-			}
-		default:
-			return false
-		}
-	}
-	return true
-}
-
-func (t *DiffTransformation) appendRow(builder execute.TableBuilder, i, diffIdx int, diff string, tbl *tableBuffer, colMap map[string]int) error {
-	// Add the want column first.
-	if err := execute.AppendKeyValues(builder.Key(), builder); err != nil {
-		return err
-	}
-	// Add the diff column.
-	if err := builder.AppendString(diffIdx, diff); err != nil {
-		return err
-	}
-	// Add all of the values.
-	for label, j := range colMap {
-		col, ok := tbl.columns[label]
-		if !ok || col.Values.IsNull(i) {
-			if err := builder.AppendNil(j); err != nil {
-				return err
-			}
-			continue
-		}
-
-		switch col.Type {
-		case flux.TFloat:
-			vs := col.Values.(*array.Float64)
-			if err := builder.AppendFloat(j, vs.Value(i)); err != nil {
-				return err
-			}
-		case flux.TInt:
-			vs := col.Values.(*array.Int64)
-			if err := builder.AppendInt(j, vs.Value(i)); err != nil {
-				return err
-			}
-		case flux.TUInt:
-			vs := col.Values.(*array.Uint64)
-			if err := builder.AppendUInt(j, vs.Value(i)); err != nil {
-				return err
-			}
-		case flux.TString:
-			vs := col.Values.(*array.Binary)
-			if err := builder.AppendString(j, vs.ValueString(i)); err != nil {
-				return err
-			}
-		case flux.TBool:
-			vs := col.Values.(*array.Boolean)
-			if err := builder.AppendBool(j, vs.Value(i)); err != nil {
-				return err
-			}
-		case flux.TTime:
-			vs := col.Values.(*array.Int64)
-			if err := builder.AppendTime(j, execute.Time(vs.Value(i))); err != nil {
+			if err != nil {
 				return err
 			}
 		}
@@ -614,13 +292,8 @@ func (t *DiffTransformation) Finish(id execute.DatasetID, err error) {
 			return
 		}
 
-		var got, want *tableBuffer
-		if obj := value.(*tableBuffer); obj.id == t.wantID {
-			want, got = obj, &tableBuffer{}
-		} else {
-			want, got = &tableBuffer{}, obj
-		}
-		err = t.diff(key, want, got)
+		obj := value.(*bufferedTable)
+		err = t.diff(obj.id, obj.tbl, nil)
 	})
 	t.d.Finish(err)
 }
@@ -147,6 +147,50 @@ func TestDiff_Process(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "float tolerance",
+			spec: &fluxtesting.DiffProcedureSpec{
+				DefaultCost: plan.DefaultCost{},
+				Epsilon:     0.01,
+			},
+			data0: []*executetest.Table{
+				{
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_value", Type: flux.TFloat},
+					},
+					Data: [][]interface{}{
+						{execute.Time(1), 1.0},
+						{execute.Time(2), 2.0},
+					},
+				},
+			},
+			data1: []*executetest.Table{
+				{
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_value", Type: flux.TFloat},
+					},
+					Data: [][]interface{}{
+						{execute.Time(1), 1.001},
+						{execute.Time(2), 2.1},
+					},
+				},
+			},
+			want: []*executetest.Table{
+				{
+					ColMeta: []flux.ColMeta{
+						{Label: "_diff", Type: flux.TString},
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_value", Type: flux.TFloat},
+					},
+					Data: [][]interface{}{
+						{"-", execute.Time(2), 2.0},
+						{"+", execute.Time(2), 2.1},
+					},
+				},
+			},
+		},
 		{
 			name: "missing from got",
 			spec: &fluxtesting.DiffProcedureSpec{
@@ -0,0 +1,350 @@
+package universe
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/flux/sketch"
+)
+
+const (
+	ApproxDistinctKind        = "approxDistinct"
+	ApproxDistinctPartialKind = "approxDistinctPartial"
+	ApproxDistinctFinalKind   = "approxDistinctFinal"
+)
+
+type ApproxDistinctOpSpec struct {
+	Precision int64 `json:"precision"`
+	execute.AggregateConfig
+}
+
+func init() {
+	approxDistinctSignature := execute.AggregateSignature(
+		map[string]semantic.PolyType{
+			"precision": semantic.Int,
+		},
+		nil,
+	)
+
+	flux.RegisterPackageValue("universe", ApproxDistinctKind, flux.FunctionValue(ApproxDistinctKind, createApproxDistinctOpSpec, approxDistinctSignature))
+	flux.RegisterOpSpec(ApproxDistinctKind, newApproxDistinctOp)
+	plan.RegisterProcedureSpec(ApproxDistinctKind, newApproxDistinctProcedure, ApproxDistinctKind)
+	execute.RegisterTransformation(ApproxDistinctKind, createApproxDistinctTransformation)
+	execute.RegisterTransformation(ApproxDistinctPartialKind, createApproxDistinctPartialTransformation)
+	execute.RegisterTransformation(ApproxDistinctFinalKind, createApproxDistinctFinalTransformation)
+}
+
+func createApproxDistinctOpSpec(args flux.Arguments, a *flux.Administration) (flux.OperationSpec, error) {
+	if err := a.AddParentFromArgs(args); err != nil {
+		return nil, err
+	}
+
+	spec := new(ApproxDistinctOpSpec)
+	if p, ok, err := args.GetInt("precision"); err != nil {
+		return nil, err
+	} else if ok {
+		if p < sketch.MinPrecision || p > sketch.MaxPrecision {
+			return nil, fmt.Errorf("precision must be between %d and %d, got %d", sketch.MinPrecision, sketch.MaxPrecision, p)
+		}
+		spec.Precision = p
+	} else {
+		spec.Precision = sketch.DefaultPrecision
+	}
+
+	if err := spec.AggregateConfig.ReadArgs(args); err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
+
+func newApproxDistinctOp() flux.OperationSpec {
+	return new(ApproxDistinctOpSpec)
+}
+
+func (s *ApproxDistinctOpSpec) Kind() flux.OperationKind {
+	return ApproxDistinctKind
+}
+
+type ApproxDistinctProcedureSpec struct {
+	Precision int64 `json:"precision"`
+	execute.AggregateConfig
+}
+
+func newApproxDistinctProcedure(qs flux.OperationSpec, a plan.Administration) (plan.ProcedureSpec, error) {
+	spec, ok := qs.(*ApproxDistinctOpSpec)
+	if !ok {
+		return nil, fmt.Errorf("invalid spec type %T", qs)
+	}
+	return &ApproxDistinctProcedureSpec{
+		Precision:       spec.Precision,
+		AggregateConfig: spec.AggregateConfig,
+	}, nil
+}
+
+func (s *ApproxDistinctProcedureSpec) Kind() plan.ProcedureKind {
+	return ApproxDistinctKind
+}
+
+func (s *ApproxDistinctProcedureSpec) Copy() plan.ProcedureSpec {
+	return &ApproxDistinctProcedureSpec{
+		Precision:       s.Precision,
+		AggregateConfig: s.AggregateConfig.Copy(),
+	}
+}
+
+// SplitAggregate implements plan.SplittableAggregateSpec.
+// The partial phase outputs HyperLogLog sketches, which the final phase merges.
+func (s *ApproxDistinctProcedureSpec) SplitAggregate() (partial, final plan.PhysicalProcedureSpec, ok bool) {
+	return &ApproxDistinctPartialProcedureSpec{Precision: s.Precision, AggregateConfig: s.AggregateConfig.Copy()},
+		&ApproxDistinctFinalProcedureSpec{Precision: s.Precision, AggregateConfig: s.AggregateConfig.Copy()}, true
+}
+
+type ApproxDistinctPartialProcedureSpec struct {
+	Precision int64 `json:"precision"`
+	execute.AggregateConfig
+}
+
+func (s *ApproxDistinctPartialProcedureSpec) Kind() plan.ProcedureKind {
+	return ApproxDistinctPartialKind
+}
+
+func (s *ApproxDistinctPartialProcedureSpec) Copy() plan.ProcedureSpec {
+	return &ApproxDistinctPartialProcedureSpec{
+		Precision:       s.Precision,
+		AggregateConfig: s.AggregateConfig.Copy(),
+	}
+}
+
+type ApproxDistinctFinalProcedureSpec struct {
+	Precision int64 `json:"precision"`
+	execute.AggregateConfig
+}
+
+func (s *ApproxDistinctFinalProcedureSpec) Kind() plan.ProcedureKind {
+	return ApproxDistinctFinalKind
+}
+
+func (s *ApproxDistinctFinalProcedureSpec) Copy() plan.ProcedureSpec {
+	return &ApproxDistinctFinalProcedureSpec{
+		Precision:       s.Precision,
+		AggregateConfig: s.AggregateConfig.Copy(),
+	}
+}
+
+func createApproxDistinctTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	s, ok := spec.(*ApproxDistinctProcedureSpec)
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid spec type %T", spec)
+	}
+	if _, err := sketch.NewHyperLogLog(int(s.Precision)); err != nil {
+		return nil, nil, err
+	}
+
+	agg := &ApproxDistinctAgg{Precision: int(s.Precision)}
+	t, d := execute.NewAggregateTransformationAndDataset(id, mode, agg, s.AggregateConfig, a.Allocator())
+	return t, d, nil
+}
+
+// ApproxDistinctAgg estimates the number of distinct non null values with a HyperLogLog sketch.
+type ApproxDistinctAgg struct {
+	Precision int
+
+	hll *sketch.HyperLogLog
+	buf [8]byte
+}
+
+func (a *ApproxDistinctAgg) new() *ApproxDistinctAgg {
+	// The precision is validated when the transformation is created.
+	hll, err := sketch.NewHyperLogLog(a.Precision)
+	if err != nil {
+		panic(err)
+	}
+	return &ApproxDistinctAgg{
+		Precision: a.Precision,
+		hll:       hll,
+	}
+}
+
+func (a *ApproxDistinctAgg) NewBoolAgg() execute.DoBoolAgg {
+	return a.new()
+}
+func (a *ApproxDistinctAgg) NewIntAgg() execute.DoIntAgg {
+	return a.new()
+}
+func (a *ApproxDistinctAgg) NewUIntAgg() execute.DoUIntAgg {
+	return a.new()
+}
+func (a *ApproxDistinctAgg) NewFloatAgg() execute.DoFloatAgg {
+	return a.new()
+}
+func (a *ApproxDistinctAgg) NewStringAgg() execute.DoStringAgg {
+	return a.new()
+}
+
+func (a *ApproxDistinctAgg) addUint64(v uint64) {
+	binary.LittleEndian.PutUint64(a.buf[:], v)
+	a.hll.Add(a.buf[:])
+}
+
+func (a *ApproxDistinctAgg) DoBool(vs *array.Boolean) {
+	for i := 0; i < vs.Len(); i++ {
+		if vs.IsNull(i) {
+			continue
+		}
+		v := byte(0)
+		if vs.Value(i) {
+			v = 1
+		}
+		a.hll.Add([]byte{v})
+	}
+}
+func (a *ApproxDistinctAgg) DoInt(vs *array.Int64) {
+	for i := 0; i < vs.Len(); i++ {
+		if vs.IsValid(i) {
+			a.addUint64(uint64(vs.Value(i)))
+		}
+	}
+}
+func (a *ApproxDistinctAgg) DoUInt(vs *array.Uint64) {
+	for i := 0; i < vs.Len(); i++ {
+		if vs.IsValid(i) {
+			a.addUint64(vs.Value(i))
+		}
+	}
+}
+func (a *ApproxDistinctAgg) DoFloat(vs *array.Float64) {
+	for i := 0; i < vs.Len(); i++ {
+		if vs.IsValid(i) {
+			a.addUint64(math.Float64bits(vs.Value(i)))
+		}
+	}
+}
+func (a *ApproxDistinctAgg) DoString(vs *array.Binary) {
+	for i := 0; i < vs.Len(); i++ {
+		if vs.IsValid(i) {
+			a.hll.Add(vs.Value(i))
+		}
+	}
+}
+
+func (a *ApproxDistinctAgg) Type() flux.ColType {
+	return flux.TInt
+}
+func (a *ApproxDistinctAgg) ValueInt() int64 {
+	return int64(a.hll.Estimate())
+}
+func (a *ApproxDistinctAgg) IsNull() bool {
+	return false
+}
+
+func createApproxDistinctPartialTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	s, ok := spec.(*ApproxDistinctPartialProcedureSpec)
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid spec type %T", spec)
+	}
+	if _, err := sketch.NewHyperLogLog(int(s.Precision)); err != nil {
+		return nil, nil, err
+	}
+
+	agg := &ApproxDistinctPartialAgg{ApproxDistinctAgg{Precision: int(s.Precision)}}
+	t, d := execute.NewAggregateTransformationAndDataset(id, mode, agg, s.AggregateConfig, a.Allocator())
+	return t, d, nil
+}
+
+// ApproxDistinctPartialAgg outputs the serialized HyperLogLog sketch of the values instead of its estimate.
+type ApproxDistinctPartialAgg struct {
+	ApproxDistinctAgg
+}
+
+func (a *ApproxDistinctPartialAgg) NewBoolAgg() execute.DoBoolAgg {
+	return &ApproxDistinctPartialAgg{*a.new()}
+}
+func (a *ApproxDistinctPartialAgg) NewIntAgg() execute.DoIntAgg {
+	return &ApproxDistinctPartialAgg{*a.new()}
+}
+func (a *ApproxDistinctPartialAgg) NewUIntAgg() execute.DoUIntAgg {
+	return &ApproxDistinctPartialAgg{*a.new()}
+}
+func (a *ApproxDistinctPartialAgg) NewFloatAgg() execute.DoFloatAgg {
+	return &ApproxDistinctPartialAgg{*a.new()}
+}
+func (a *ApproxDistinctPartialAgg) NewStringAgg() execute.DoStringAgg {
+	return &ApproxDistinctPartialAgg{*a.new()}
+}
+
+func (a *ApproxDistinctPartialAgg) Type() flux.ColType {
+	return flux.TString
+}
+func (a *ApproxDistinctPartialAgg) ValueString() string {
+	data, _ := a.hll.MarshalBinary()
+	return string(data)
+}
+
+func createApproxDistinctFinalTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	s, ok := spec.(*ApproxDistinctFinalProcedureSpec)
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid spec type %T", spec)
+	}
+	if _, err := sketch.NewHyperLogLog(int(s.Precision)); err != nil {
+		return nil, nil, err
+	}
+
+	agg := &ApproxDistinctFinalAgg{ApproxDistinctAgg: ApproxDistinctAgg{Precision: int(s.Precision)}}
+	t, d := execute.NewAggregateTransformationAndDataset(id, mode, agg, s.AggregateConfig, a.Allocator())
+	return t, d, nil
+}
+
+// ApproxDistinctFinalAgg merges the HyperLogLog sketches output by ApproxDistinctPartialAgg
+// and estimates the number of distinct values they have seen.
+type ApproxDistinctFinalAgg struct {
+	ApproxDistinctAgg
+	err error
+}
+
+func (a *ApproxDistinctFinalAgg) NewBoolAgg() execute.DoBoolAgg {
+	return nil
+}
+func (a *ApproxDistinctFinalAgg) NewIntAgg() execute.DoIntAgg {
+	return nil
+}
+func (a *ApproxDistinctFinalAgg) NewUIntAgg() execute.DoUIntAgg {
+	return nil
+}
+func (a *ApproxDistinctFinalAgg) NewFloatAgg() execute.DoFloatAgg {
+	return nil
+}
+func (a *ApproxDistinctFinalAgg) NewStringAgg() execute.DoStringAgg {
+	return &ApproxDistinctFinalAgg{ApproxDistinctAgg: *a.new()}
+}
+
+func (a *ApproxDistinctFinalAgg) DoString(vs *array.Binary) {
+	if a.err == nil {
+		a.err = mergeSketches(a.hll, vs)
+	}
+}
+func (a *ApproxDistinctFinalAgg) Err() error {
+	return a.err
+}
+
+// mergeSketches merges the serialized sketches of an array into a sketch, null values are skipped.
+func mergeSketches(s sketch.Sketch, vs *array.Binary) error {
+	for i := 0; i < vs.Len(); i++ {
+		if vs.IsNull(i) {
+			continue
+		}
+		other, err := sketch.Unmarshal(vs.Value(i))
+		if err != nil {
+			return err
+		}
+		if err := s.Merge(other); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,177 @@
+package universe_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/arrow"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/flux/querytest"
+	"github.com/influxdata/flux/stdlib/influxdata/influxdb"
+	"github.com/influxdata/flux/stdlib/universe"
+)
+
+func TestApproxDistinct_NewQuery(t *testing.T) {
+	tests := []querytest.NewQueryTestCase{
+		{
+			Name: "from with range and approxDistinct",
+			Raw:  `from(bucket:"mydb") |> range(start:-1h) |> approxDistinct(precision: 10)`,
+			Want: &flux.Spec{
+				Operations: []*flux.Operation{
+					{
+						ID: "from0",
+						Spec: &influxdb.FromOpSpec{
+							Bucket: "mydb",
+						},
+					},
+					{
+						ID: "range1",
+						Spec: &universe.RangeOpSpec{
+							Start: flux.Time{
+								Relative:   -1 * time.Hour,
+								IsRelative: true,
+							},
+							Stop:        flux.Now,
+							TimeColumn:  "_time",
+							StartColumn: "_start",
+							StopColumn:  "_stop",
+						},
+					},
+					{
+						ID: "approxDistinct2",
+						Spec: &universe.ApproxDistinctOpSpec{
+							Precision:       10,
+							AggregateConfig: execute.DefaultAggregateConfig,
+						},
+					},
+				},
+				Edges: []flux.Edge{
+					{Parent: "from0", Child: "range1"},
+					{Parent: "range1", Child: "approxDistinct2"},
+				},
+			},
+		},
+		{
+			Name:    "precision too large",
+			Raw:     `from(bucket:"mydb") |> range(start:-1h) |> approxDistinct(precision: 19)`,
+			WantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			querytest.NewQueryTestHelper(t, tc)
+		})
+	}
+}
+
+func TestApproxDistinctOperation_Marshaling(t *testing.T) {
+	data := []byte(`{"id":"approxDistinct","kind":"approxDistinct","spec":{"precision":12}}`)
+	op := &flux.Operation{
+		ID: "approxDistinct",
+		Spec: &universe.ApproxDistinctOpSpec{
+			Precision: 12,
+		},
+	}
+
+	querytest.OperationMarshalingTestHelper(t, data, op)
+}
+
+func TestApproxDistinct_Process(t *testing.T) {
+	testCases := []struct {
+		name string
+		data func() *array.Float64
+		want int64
+	}{
+		{
+			name: "distinct",
+			data: func() *array.Float64 {
+				return arrow.NewFloat([]float64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, nil)
+			},
+			want: 10,
+		},
+		{
+			name: "repeated",
+			data: func() *array.Float64 {
+				return arrow.NewFloat([]float64{1, 1, 2, 2, 1, 3, 3, 1}, nil)
+			},
+			want: 3,
+		},
+		{
+			name: "empty",
+			data: func() *array.Float64 {
+				return arrow.NewFloat(nil, nil)
+			},
+			want: 0,
+		},
+		{
+			name: "with nulls",
+			data: func() *array.Float64 {
+				b := arrow.NewFloatBuilder(nil)
+				defer b.Release()
+				b.AppendValues([]float64{0, 1, 2}, nil)
+				b.AppendNull()
+				b.AppendValues([]float64{2, 1}, nil)
+				b.AppendNull()
+				return b.NewFloat64Array()
+			},
+			want: 3,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			data := tc.data()
+			defer data.Release()
+
+			executetest.AggFuncTestHelper(
+				t,
+				&universe.ApproxDistinctAgg{Precision: 14},
+				data,
+				tc.want,
+			)
+		})
+	}
+}
+
+func TestApproxDistinct_SplitAggregate(t *testing.T) {
+	testCases := []struct {
+		name string
+		data func() *array.Float64
+		want int64
+	}{
+		{
+			name: "overlapping",
+			data: func() *array.Float64 {
+				return arrow.NewFloat([]float64{1, 2, 3, 1, 2, 4}, nil)
+			},
+			want: 4,
+		},
+		{
+			name: "empty",
+			data: func() *array.Float64 {
+				return arrow.NewFloat(nil, nil)
+			},
+			want: 0,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			data := tc.data()
+			defer data.Release()
+
+			executetest.SplitAggFuncTestHelper(
+				t,
+				&universe.ApproxDistinctPartialAgg{ApproxDistinctAgg: universe.ApproxDistinctAgg{Precision: 14}},
+				&universe.ApproxDistinctFinalAgg{ApproxDistinctAgg: universe.ApproxDistinctAgg{Precision: 14}},
+				data,
+				tc.want,
+			)
+		})
+	}
+}
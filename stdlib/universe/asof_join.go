@@ -0,0 +1,533 @@
+package universe
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/interpreter"
+	"github.com/influxdata/flux/memory"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/flux/values"
+)
+
+const AsOfJoinKind = "asofJoin"
+
+// The directions in which asofJoin looks for the row of the right stream that matches a row of the left stream.
+const (
+	AsOfBackward = "backward"
+	AsOfForward  = "forward"
+	AsOfNearest  = "nearest"
+)
+
+// AsOfJoinOpSpec joins every row of the left stream with the row of the right stream
+// that has the same values in the on columns and the closest time.
+type AsOfJoinOpSpec struct {
+	On         []string      `json:"on"`
+	TimeColumn string        `json:"timeColumn"`
+	Direction  string        `json:"direction"`
+	Tolerance  flux.Duration `json:"tolerance"`
+}
+
+func init() {
+	asofJoinSignature := semantic.FunctionPolySignature{
+		Parameters: map[string]semantic.PolyType{
+			"left":       flux.TableObjectType,
+			"right":      flux.TableObjectType,
+			"on":         semantic.NewArrayPolyType(semantic.String),
+			"timeColumn": semantic.String,
+			"direction":  semantic.String,
+			"tolerance":  semantic.Duration,
+		},
+		Required: semantic.LabelSet{"left", "right"},
+		Return:   flux.TableObjectType,
+	}
+
+	flux.RegisterPackageValue("universe", AsOfJoinKind, flux.FunctionValue(AsOfJoinKind, createAsOfJoinOpSpec, asofJoinSignature))
+	flux.RegisterOpSpec(AsOfJoinKind, newAsOfJoinOp)
+	plan.RegisterProcedureSpec(AsOfJoinKind, newAsOfJoinProcedure, AsOfJoinKind)
+	execute.RegisterTransformation(AsOfJoinKind, createAsOfJoinTransformation)
+}
+
+func createAsOfJoinOpSpec(args flux.Arguments, a *flux.Administration) (flux.OperationSpec, error) {
+	for _, name := range []string{"left", "right"} {
+		v, err := args.GetRequiredObject(name)
+		if err != nil {
+			return nil, err
+		}
+		p, ok := v.(*flux.TableObject)
+		if !ok {
+			return nil, fmt.Errorf("argument %s is not a table object: got %T", name, v)
+		}
+		a.AddParent(p)
+	}
+
+	spec := &AsOfJoinOpSpec{
+		On:         []string{},
+		TimeColumn: execute.DefaultTimeColLabel,
+		Direction:  AsOfBackward,
+	}
+	if array, ok, err := args.GetArray("on", semantic.String); err != nil {
+		return nil, err
+	} else if ok {
+		spec.On, err = interpreter.ToStringArray(array)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if col, ok, err := args.GetString("timeColumn"); err != nil {
+		return nil, err
+	} else if ok {
+		spec.TimeColumn = col
+	}
+	if direction, ok, err := args.GetString("direction"); err != nil {
+		return nil, err
+	} else if ok {
+		switch direction {
+		case AsOfBackward, AsOfForward, AsOfNearest:
+			spec.Direction = direction
+		default:
+			return nil, fmt.Errorf("%s is not a valid direction, it must be one of %q, %q or %q", direction, AsOfBackward, AsOfForward, AsOfNearest)
+		}
+	}
+	if tolerance, ok, err := args.GetDuration("tolerance"); err != nil {
+		return nil, err
+	} else if ok {
+		if tolerance.IsNegative() {
+			return nil, errors.New("tolerance must not be negative")
+		}
+		spec.Tolerance = tolerance
+	}
+	return spec, nil
+}
+
+func newAsOfJoinOp() flux.OperationSpec {
+	return new(AsOfJoinOpSpec)
+}
+
+func (s *AsOfJoinOpSpec) Kind() flux.OperationKind {
+	return AsOfJoinKind
+}
+
+type AsOfJoinProcedureSpec struct {
+	plan.DefaultCost
+	On         []string
+	TimeColumn string
+	Direction  string
+	Tolerance  flux.Duration
+}
+
+func newAsOfJoinProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	spec, ok := qs.(*AsOfJoinOpSpec)
+	if !ok {
+		return nil, fmt.Errorf("invalid spec type %T", qs)
+	}
+	return &AsOfJoinProcedureSpec{
+		On:         spec.On,
+		TimeColumn: spec.TimeColumn,
+		Direction:  spec.Direction,
+		Tolerance:  spec.Tolerance,
+	}, nil
+}
+
+func (s *AsOfJoinProcedureSpec) Kind() plan.ProcedureKind {
+	return AsOfJoinKind
+}
+
+func (s *AsOfJoinProcedureSpec) Copy() plan.ProcedureSpec {
+	ns := new(AsOfJoinProcedureSpec)
+	*ns = *s
+	ns.On = make([]string, len(s.On))
+	copy(ns.On, s.On)
+	return ns
+}
+
+func createAsOfJoinTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	s, ok := spec.(*AsOfJoinProcedureSpec)
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid spec type %T", spec)
+	}
+	cache := execute.NewTableBuilderCache(a.Allocator())
+	d := execute.NewDataset(id, mode, cache)
+	t, err := NewAsOfJoinTransformation(d, cache, s, a.Parents(), a.Allocator())
+	if err != nil {
+		return nil, nil, err
+	}
+	return t, d, nil
+}
+
+// asofJoinTransformation buffers the tables of the left parent and indexes the rows of the
+// right parent by the values of their on columns. Once both parents have finished, it emits
+// the rows of the left tables that have a match, extended with the columns of their match.
+type asofJoinTransformation struct {
+	mu sync.Mutex
+
+	d     execute.Dataset
+	cache execute.TableBuilderCache
+	alloc *memory.Allocator
+
+	on         []string
+	timeColumn string
+	direction  string
+	tolerance  int64
+
+	left, right execute.DatasetID
+	parentState map[execute.DatasetID]*joinParentState
+	done        bool
+
+	// tables are the buffered tables of the left parent.
+	tables []flux.Table
+	// rightCols are the columns of the right parent, other than the on columns.
+	rightCols []flux.ColMeta
+	// rows are the rows of the right parent indexed by the values of their on columns.
+	rows map[string][]asofRow
+}
+
+type joinParentState struct {
+	mark       execute.Time
+	processing execute.Time
+	finished   bool
+}
+
+// asofRow is a row of the right parent. Its values are indexed like rightCols.
+type asofRow struct {
+	time   int64
+	values []values.Value
+}
+
+func NewAsOfJoinTransformation(d execute.Dataset, cache execute.TableBuilderCache, spec *AsOfJoinProcedureSpec, parents []execute.DatasetID, a *memory.Allocator) (*asofJoinTransformation, error) {
+	if len(parents) != 2 {
+		return nil, errors.New("asofJoin must have two different streams as input")
+	}
+	tolerance := int64(spec.Tolerance.Duration())
+	if tolerance == 0 {
+		tolerance = math.MaxInt64
+	}
+	return &asofJoinTransformation{
+		d:          d,
+		cache:      cache,
+		alloc:      a,
+		on:         spec.On,
+		timeColumn: spec.TimeColumn,
+		direction:  spec.Direction,
+		tolerance:  tolerance,
+		left:       parents[0],
+		right:      parents[1],
+		parentState: map[execute.DatasetID]*joinParentState{
+			parents[0]: {},
+			parents[1]: {},
+		},
+		rows: make(map[string][]asofRow),
+	}, nil
+}
+
+func (t *asofJoinTransformation) RetractTable(id execute.DatasetID, key flux.GroupKey) error {
+	panic("not implemented")
+}
+
+func (t *asofJoinTransformation) Process(id execute.DatasetID, tbl flux.Table) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if id == t.left {
+		cpy, err := execute.CopyTable(tbl, t.alloc)
+		if err != nil {
+			return err
+		}
+		t.tables = append(t.tables, cpy)
+		return nil
+	}
+
+	cols := tbl.Cols()
+	timeIdx := execute.ColIdx(t.timeColumn, cols)
+	if timeIdx < 0 {
+		return fmt.Errorf("asofJoin: right table has no column %q", t.timeColumn)
+	}
+	if typ := cols[timeIdx].Type; typ != flux.TTime {
+		return fmt.Errorf("asofJoin: column %q of the right table is of type %v, not time", t.timeColumn, typ)
+	}
+	onIdxs, err := t.onIdxs(cols, "right")
+	if err != nil {
+		return err
+	}
+
+	// Map the columns of the table to the columns of the right parent.
+	colMap := make([]int, len(cols))
+	for j, c := range cols {
+		colMap[j] = -1
+		if j == timeIdx || execute.ContainsStr(t.on, c.Label) {
+			continue
+		}
+		k := execute.ColIdx(c.Label, t.rightCols)
+		if k < 0 {
+			k = len(t.rightCols)
+			t.rightCols = append(t.rightCols, c)
+		} else if t.rightCols[k].Type != c.Type {
+			return fmt.Errorf("asofJoin: column %q of the right stream has both types %v and %v", c.Label, t.rightCols[k].Type, c.Type)
+		}
+		colMap[j] = k
+	}
+	// The time column of the right stream is kept, after its other columns.
+	timeCol := len(t.rightCols)
+
+	return tbl.Do(func(cr flux.ColReader) error {
+		times := cr.Times(timeIdx)
+		for i := 0; i < cr.Len(); i++ {
+			if times.IsNull(i) {
+				continue
+			}
+			key, ok := joinKey(cr, i, onIdxs)
+			if !ok {
+				continue
+			}
+			row := asofRow{
+				time:   times.Value(i),
+				values: make([]values.Value, timeCol),
+			}
+			for j, k := range colMap {
+				if k >= 0 {
+					row.values[k] = execute.ValueForRow(cr, i, j)
+				}
+			}
+			t.rows[key] = append(t.rows[key], row)
+		}
+		return nil
+	})
+}
+
+// onIdxs returns the indexes of the on columns in cols.
+func (t *asofJoinTransformation) onIdxs(cols []flux.ColMeta, side string) ([]int, error) {
+	idxs := make([]int, len(t.on))
+	for k, label := range t.on {
+		idxs[k] = execute.ColIdx(label, cols)
+		if idxs[k] < 0 {
+			return nil, fmt.Errorf("asofJoin: %s table has no column %q", side, label)
+		}
+	}
+	return idxs, nil
+}
+
+// joinKey encodes the values of the on columns of row i.
+// A row with a null value in one of them matches no other row.
+func joinKey(cr flux.ColReader, i int, idxs []int) (string, bool) {
+	var b strings.Builder
+	for _, j := range idxs {
+		v := execute.ValueForRow(cr, i, j)
+		if v.IsNull() {
+			return "", false
+		}
+		b.WriteString(v.Type().Nature().String())
+		b.WriteByte(':')
+		switch v.Type() {
+		case semantic.String:
+			b.WriteString(strconv.Quote(v.Str()))
+		case semantic.Int:
+			b.WriteString(strconv.FormatInt(v.Int(), 10))
+		case semantic.UInt:
+			b.WriteString(strconv.FormatUint(v.UInt(), 10))
+		case semantic.Float:
+			b.WriteString(strconv.FormatFloat(v.Float(), 'g', -1, 64))
+		case semantic.Bool:
+			b.WriteString(strconv.FormatBool(v.Bool()))
+		case semantic.Time:
+			b.WriteString(strconv.FormatInt(int64(v.Time()), 10))
+		}
+		b.WriteByte(',')
+	}
+	return b.String(), true
+}
+
+// match returns the index of the row of rows, sorted by time, that matches time ts, or -1.
+func (t *asofJoinTransformation) match(rows []asofRow, ts int64) int {
+	// The first row that is not before ts.
+	next := sort.Search(len(rows), func(i int) bool { return rows[i].time >= ts })
+	before, after := -1, -1
+	if next < len(rows) && rows[next].time == ts {
+		return next
+	}
+	if next > 0 && ts-rows[next-1].time <= t.tolerance {
+		before = next - 1
+	}
+	if next < len(rows) && rows[next].time-ts <= t.tolerance {
+		after = next
+	}
+	switch t.direction {
+	case AsOfBackward:
+		return before
+	case AsOfForward:
+		return after
+	default:
+		if before < 0 {
+			return after
+		}
+		if after < 0 || ts-rows[before].time <= rows[after].time-ts {
+			return before
+		}
+		return after
+	}
+}
+
+// emit appends the matched rows of the buffered tables to their builders.
+func (t *asofJoinTransformation) emit() error {
+	for _, rows := range t.rows {
+		sort.SliceStable(rows, func(i, j int) bool { return rows[i].time < rows[j].time })
+	}
+
+	for _, tbl := range t.tables {
+		cols := tbl.Cols()
+		timeIdx := execute.ColIdx(t.timeColumn, cols)
+		if timeIdx < 0 {
+			return fmt.Errorf("asofJoin: left table has no column %q", t.timeColumn)
+		}
+		if typ := cols[timeIdx].Type; typ != flux.TTime {
+			return fmt.Errorf("asofJoin: column %q of the left table is of type %v, not time", t.timeColumn, typ)
+		}
+		onIdxs, err := t.onIdxs(cols, "left")
+		if err != nil {
+			return err
+		}
+
+		// The builder is only created once a row is matched,
+		// so that no empty tables are produced.
+		var (
+			builder   execute.TableBuilder
+			rightIdxs []int
+		)
+		if err := tbl.Do(func(cr flux.ColReader) error {
+			times := cr.Times(timeIdx)
+			for i := 0; i < cr.Len(); i++ {
+				if times.IsNull(i) {
+					continue
+				}
+				key, ok := joinKey(cr, i, onIdxs)
+				if !ok {
+					continue
+				}
+				rows := t.rows[key]
+				m := t.match(rows, times.Value(i))
+				if m < 0 {
+					continue
+				}
+				if builder == nil {
+					if builder, rightIdxs, err = t.builder(tbl); err != nil {
+						return err
+					}
+				}
+				for j := range cols {
+					if err := builder.AppendValue(j, execute.ValueForRow(cr, i, j)); err != nil {
+						return err
+					}
+				}
+				row := rows[m]
+				for k, j := range rightIdxs[:len(rightIdxs)-1] {
+					v := values.Value(values.NewNull(execute.ConvertToKind(t.rightCols[k].Type)))
+					if k < len(row.values) && row.values[k] != nil {
+						v = row.values[k]
+					}
+					if err := builder.AppendValue(j, v); err != nil {
+						return err
+					}
+				}
+				if err := builder.AppendTime(rightIdxs[len(rightIdxs)-1], execute.Time(row.time)); err != nil {
+					return err
+				}
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+	t.tables = nil
+	t.rows = nil
+	return nil
+}
+
+// builder returns the builder of the output table of tbl, and the indexes of the columns of the
+// right stream in it, followed by the index of its time column.
+// The columns of the right stream that are also columns of tbl are suffixed with _right.
+func (t *asofJoinTransformation) builder(tbl flux.Table) (execute.TableBuilder, []int, error) {
+	builder, created := t.cache.TableBuilder(tbl.Key())
+	if !created {
+		return nil, nil, fmt.Errorf("asofJoin found duplicate table with key: %v", tbl.Key())
+	}
+	if err := execute.AddTableCols(tbl, builder); err != nil {
+		return nil, nil, err
+	}
+	cols := append(t.rightCols[:len(t.rightCols):len(t.rightCols)], flux.ColMeta{Label: t.timeColumn, Type: flux.TTime})
+	idxs := make([]int, len(cols))
+	for k, c := range cols {
+		if execute.ColIdx(c.Label, tbl.Cols()) >= 0 {
+			c.Label += "_right"
+		}
+		j, err := builder.AddCol(c)
+		if err != nil {
+			return nil, nil, err
+		}
+		idxs[k] = j
+	}
+	return builder, idxs, nil
+}
+
+func (t *asofJoinTransformation) UpdateWatermark(id execute.DatasetID, mark execute.Time) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.parentState[id].mark = mark
+
+	min := execute.Time(math.MaxInt64)
+	for _, state := range t.parentState {
+		if state.mark < min {
+			min = state.mark
+		}
+	}
+
+	return t.d.UpdateWatermark(min)
+}
+
+func (t *asofJoinTransformation) UpdateProcessingTime(id execute.DatasetID, pt execute.Time) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.parentState[id].processing = pt
+
+	min := execute.Time(math.MaxInt64)
+	for _, state := range t.parentState {
+		if state.processing < min {
+			min = state.processing
+		}
+	}
+
+	return t.d.UpdateProcessingTime(min)
+}
+
+func (t *asofJoinTransformation) Finish(id execute.DatasetID, err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.parentState[id].finished = true
+	if t.done {
+		return
+	}
+
+	if err != nil {
+		t.done = true
+		t.d.Finish(err)
+		return
+	}
+
+	for _, state := range t.parentState {
+		if !state.finished {
+			return
+		}
+	}
+
+	t.done = true
+	t.d.Finish(t.emit())
+}
@@ -0,0 +1,195 @@
+package universe_test
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/flux/querytest"
+	"github.com/influxdata/flux/stdlib/universe"
+	"github.com/influxdata/flux/values"
+)
+
+func TestAsOfJoinOperation_Marshaling(t *testing.T) {
+	data := []byte(`{"id":"asofJoin","kind":"asofJoin","spec":{"on":["host"],"timeColumn":"_time","direction":"nearest","tolerance":"1m"}}`)
+	op := &flux.Operation{
+		ID: "asofJoin",
+		Spec: &universe.AsOfJoinOpSpec{
+			On:         []string{"host"},
+			TimeColumn: "_time",
+			Direction:  "nearest",
+			Tolerance:  values.ConvertDuration(time.Minute),
+		},
+	}
+	querytest.OperationMarshalingTestHelper(t, data, op)
+}
+
+func TestAsOfJoin_Process(t *testing.T) {
+	left := func(host string, rows ...[]interface{}) *executetest.Table {
+		data := make([][]interface{}, len(rows))
+		for i, r := range rows {
+			data[i] = append([]interface{}{host}, r...)
+		}
+		return &executetest.Table{
+			KeyCols: []string{"host"},
+			ColMeta: []flux.ColMeta{
+				{Label: "host", Type: flux.TString},
+				{Label: "_time", Type: flux.TTime},
+				{Label: "_value", Type: flux.TFloat},
+			},
+			Data: data,
+		}
+	}
+	right := func(host string, rows ...[]interface{}) *executetest.Table {
+		data := make([][]interface{}, len(rows))
+		for i, r := range rows {
+			data[i] = append([]interface{}{host}, r...)
+		}
+		return &executetest.Table{
+			KeyCols: []string{"host"},
+			ColMeta: []flux.ColMeta{
+				{Label: "host", Type: flux.TString},
+				{Label: "_time", Type: flux.TTime},
+				{Label: "temp", Type: flux.TFloat},
+			},
+			Data: data,
+		}
+	}
+	joined := func(host string, rows ...[]interface{}) *executetest.Table {
+		data := make([][]interface{}, len(rows))
+		for i, r := range rows {
+			data[i] = append([]interface{}{host}, r...)
+		}
+		return &executetest.Table{
+			KeyCols: []string{"host"},
+			ColMeta: []flux.ColMeta{
+				{Label: "host", Type: flux.TString},
+				{Label: "_time", Type: flux.TTime},
+				{Label: "_value", Type: flux.TFloat},
+				{Label: "temp", Type: flux.TFloat},
+				{Label: "_time_right", Type: flux.TTime},
+			},
+			Data: data,
+		}
+	}
+	leftData := []flux.Table{
+		left("a", []interface{}{execute.Time(10), 1.0}, []interface{}{execute.Time(20), 2.0}, []interface{}{execute.Time(30), 3.0}),
+		left("b", []interface{}{execute.Time(10), 4.0}),
+	}
+	rightData := []flux.Table{
+		right("a", []interface{}{execute.Time(19), 20.0}, []interface{}{execute.Time(8), 10.0}, []interface{}{execute.Time(33), 30.0}),
+		right("b", []interface{}{execute.Time(50), 40.0}),
+	}
+	testCases := []struct {
+		name string
+		spec *universe.AsOfJoinProcedureSpec
+		want []*executetest.Table
+	}{
+		{
+			name: "backward",
+			spec: &universe.AsOfJoinProcedureSpec{On: []string{"host"}, TimeColumn: "_time", Direction: universe.AsOfBackward},
+			want: []*executetest.Table{
+				joined("a",
+					[]interface{}{execute.Time(10), 1.0, 10.0, execute.Time(8)},
+					[]interface{}{execute.Time(20), 2.0, 20.0, execute.Time(19)},
+					[]interface{}{execute.Time(30), 3.0, 20.0, execute.Time(19)},
+				),
+			},
+		},
+		{
+			name: "forward",
+			spec: &universe.AsOfJoinProcedureSpec{On: []string{"host"}, TimeColumn: "_time", Direction: universe.AsOfForward},
+			want: []*executetest.Table{
+				joined("a",
+					[]interface{}{execute.Time(10), 1.0, 20.0, execute.Time(19)},
+					[]interface{}{execute.Time(20), 2.0, 30.0, execute.Time(33)},
+					[]interface{}{execute.Time(30), 3.0, 30.0, execute.Time(33)},
+				),
+				joined("b",
+					[]interface{}{execute.Time(10), 4.0, 40.0, execute.Time(50)},
+				),
+			},
+		},
+		{
+			name: "nearest with tolerance",
+			spec: &universe.AsOfJoinProcedureSpec{On: []string{"host"}, TimeColumn: "_time", Direction: universe.AsOfNearest, Tolerance: values.ConvertDuration(3)},
+			want: []*executetest.Table{
+				joined("a",
+					[]interface{}{execute.Time(10), 1.0, 10.0, execute.Time(8)},
+					[]interface{}{execute.Time(20), 2.0, 20.0, execute.Time(19)},
+					[]interface{}{execute.Time(30), 3.0, 30.0, execute.Time(33)},
+				),
+			},
+		},
+		{
+			name: "without on columns",
+			spec: &universe.AsOfJoinProcedureSpec{On: []string{}, TimeColumn: "_time", Direction: universe.AsOfNearest, Tolerance: values.ConvertDuration(1)},
+			want: []*executetest.Table{
+				&executetest.Table{
+					KeyCols: []string{"host"},
+					ColMeta: []flux.ColMeta{
+						{Label: "host", Type: flux.TString},
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_value", Type: flux.TFloat},
+						{Label: "host_right", Type: flux.TString},
+						{Label: "temp", Type: flux.TFloat},
+						{Label: "_time_right", Type: flux.TTime},
+					},
+					Data: [][]interface{}{
+						{"a", execute.Time(20), 2.0, "a", 20.0, execute.Time(19)},
+					},
+				},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			leftID := executetest.RandomDatasetID()
+			rightID := executetest.RandomDatasetID()
+
+			d := executetest.NewDataset(executetest.RandomDatasetID())
+			c := execute.NewTableBuilderCache(executetest.UnlimitedAllocator)
+			c.SetTriggerSpec(execute.DefaultTriggerSpec)
+			jt, err := universe.NewAsOfJoinTransformation(d, c, tc.spec, []execute.DatasetID{leftID, rightID}, executetest.UnlimitedAllocator)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, tbl := range leftData {
+				if err := jt.Process(leftID, tbl); err != nil {
+					t.Fatal(err)
+				}
+			}
+			for _, tbl := range rightData {
+				if err := jt.Process(rightID, tbl); err != nil {
+					t.Fatal(err)
+				}
+			}
+			jt.Finish(rightID, nil)
+			jt.Finish(leftID, nil)
+			if d.FinishedErr != nil {
+				t.Fatal(d.FinishedErr)
+			}
+
+			got, err := executetest.TablesFromCache(c)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			executetest.NormalizeTables(got)
+			executetest.NormalizeTables(tc.want)
+
+			sort.Sort(executetest.SortedTables(got))
+			sort.Sort(executetest.SortedTables(tc.want))
+
+			if !cmp.Equal(tc.want, got) {
+				t.Errorf("unexpected tables -want/+got\n%s", cmp.Diff(tc.want, got))
+			}
+		})
+	}
+}
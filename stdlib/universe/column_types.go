@@ -0,0 +1,191 @@
+package universe
+
+import (
+	"fmt"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/arrow"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/semantic"
+)
+
+const ColumnTypesKind = "columnTypes"
+
+// DefaultTypeColLabel is the default label of the column holding the column types.
+const DefaultTypeColLabel = "_type"
+
+type ColumnTypesOpSpec struct {
+	Column     string `json:"column"`
+	TypeColumn string `json:"typeColumn"`
+}
+
+func init() {
+	columnTypesSignature := flux.FunctionSignature(map[string]semantic.PolyType{
+		"column":     semantic.String,
+		"typeColumn": semantic.String,
+	}, nil)
+
+	flux.RegisterPackageValue("universe", ColumnTypesKind, flux.FunctionValue(ColumnTypesKind, createColumnTypesOpSpec, columnTypesSignature))
+	flux.RegisterOpSpec(ColumnTypesKind, newColumnTypesOp)
+	plan.RegisterProcedureSpec(ColumnTypesKind, newColumnTypesProcedure, ColumnTypesKind)
+	execute.RegisterTransformation(ColumnTypesKind, createColumnTypesTransformation)
+}
+
+func createColumnTypesOpSpec(args flux.Arguments, a *flux.Administration) (flux.OperationSpec, error) {
+	if err := a.AddParentFromArgs(args); err != nil {
+		return nil, err
+	}
+
+	spec := new(ColumnTypesOpSpec)
+
+	if col, found, err := args.GetString("column"); err != nil {
+		return nil, err
+	} else if found {
+		spec.Column = col
+	} else {
+		spec.Column = execute.DefaultValueColLabel
+	}
+
+	if col, found, err := args.GetString("typeColumn"); err != nil {
+		return nil, err
+	} else if found {
+		spec.TypeColumn = col
+	} else {
+		spec.TypeColumn = DefaultTypeColLabel
+	}
+
+	if spec.Column == spec.TypeColumn {
+		return nil, fmt.Errorf("columnTypes error: column and typeColumn must differ, both are %q", spec.Column)
+	}
+	return spec, nil
+}
+
+func newColumnTypesOp() flux.OperationSpec {
+	return new(ColumnTypesOpSpec)
+}
+
+func (s *ColumnTypesOpSpec) Kind() flux.OperationKind {
+	return ColumnTypesKind
+}
+
+type ColumnTypesProcedureSpec struct {
+	plan.DefaultCost
+	Column     string
+	TypeColumn string
+}
+
+func newColumnTypesProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	spec, ok := qs.(*ColumnTypesOpSpec)
+	if !ok {
+		return nil, fmt.Errorf("invalid spec type %T", qs)
+	}
+
+	return &ColumnTypesProcedureSpec{
+		Column:     spec.Column,
+		TypeColumn: spec.TypeColumn,
+	}, nil
+}
+
+func (s *ColumnTypesProcedureSpec) Kind() plan.ProcedureKind {
+	return ColumnTypesKind
+}
+
+func (s *ColumnTypesProcedureSpec) Copy() plan.ProcedureSpec {
+	ns := new(ColumnTypesProcedureSpec)
+	*ns = *s
+	return ns
+}
+
+func createColumnTypesTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	s, ok := spec.(*ColumnTypesProcedureSpec)
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid spec type %T", spec)
+	}
+	cache := execute.NewTableBuilderCache(a.Allocator())
+	d := execute.NewDataset(id, mode, cache)
+	t := NewColumnTypesTransformation(d, cache, s)
+	return t, d, nil
+}
+
+type columnTypesTransformation struct {
+	d     execute.Dataset
+	cache execute.TableBuilderCache
+
+	column     string
+	typeColumn string
+}
+
+func NewColumnTypesTransformation(d execute.Dataset, cache execute.TableBuilderCache, spec *ColumnTypesProcedureSpec) *columnTypesTransformation {
+	return &columnTypesTransformation{
+		d:          d,
+		cache:      cache,
+		column:     spec.Column,
+		typeColumn: spec.TypeColumn,
+	}
+}
+
+func (t *columnTypesTransformation) RetractTable(id execute.DatasetID, key flux.GroupKey) error {
+	return t.d.RetractTable(key)
+}
+
+func (t *columnTypesTransformation) Process(id execute.DatasetID, tbl flux.Table) error {
+	builder, created := t.cache.TableBuilder(tbl.Key())
+	if !created {
+		return fmt.Errorf("columnTypes found duplicate table with key: %v", tbl.Key())
+	}
+
+	labels := make([]string, len(tbl.Cols()))
+	types := make([]string, len(tbl.Cols()))
+	for i, c := range tbl.Cols() {
+		labels[i] = c.Label
+		types[i] = c.Type.String()
+	}
+
+	// Add the group key columns to this table.
+	if err := execute.AddTableKeyCols(tbl.Key(), builder); err != nil {
+		return err
+	}
+
+	// Create new columns for the column names and types.
+	colIdx, err := builder.AddCol(flux.ColMeta{Label: t.column, Type: flux.TString})
+	if err != nil {
+		return err
+	}
+	typeIdx, err := builder.AddCol(flux.ColMeta{Label: t.typeColumn, Type: flux.TString})
+	if err != nil {
+		return err
+	}
+
+	// Append the key values repeatedly to the table.
+	for i := 0; i < len(labels); i++ {
+		if err := execute.AppendKeyValues(tbl.Key(), builder); err != nil {
+			return err
+		}
+	}
+
+	labelsArrow := arrow.NewString(labels, nil)
+	defer labelsArrow.Release()
+	if err := builder.AppendStrings(colIdx, labelsArrow); err != nil {
+		return err
+	}
+	typesArrow := arrow.NewString(types, nil)
+	defer typesArrow.Release()
+	if err := builder.AppendStrings(typeIdx, typesArrow); err != nil {
+		return err
+	}
+
+	return tbl.Do(func(flux.ColReader) error {
+		return nil
+	})
+}
+
+func (t *columnTypesTransformation) UpdateWatermark(id execute.DatasetID, mark execute.Time) error {
+	return t.d.UpdateWatermark(mark)
+}
+func (t *columnTypesTransformation) UpdateProcessingTime(id execute.DatasetID, pt execute.Time) error {
+	return t.d.UpdateProcessingTime(pt)
+}
+func (t *columnTypesTransformation) Finish(id execute.DatasetID, err error) {
+	t.d.Finish(err)
+}
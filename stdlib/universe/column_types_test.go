@@ -0,0 +1,116 @@
+package universe_test
+
+import (
+	"testing"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/flux/querytest"
+	"github.com/influxdata/flux/stdlib/influxdata/influxdb"
+	"github.com/influxdata/flux/stdlib/universe"
+)
+
+func TestColumnTypes_NewQuery(t *testing.T) {
+	tests := []querytest.NewQueryTestCase{
+		{
+			Name: "from columnTypes",
+			Raw:  `from(bucket:"mydb") |> columnTypes(typeColumn: "type")`,
+			Want: &flux.Spec{
+				Operations: []*flux.Operation{
+					{
+						ID: "from0",
+						Spec: &influxdb.FromOpSpec{
+							Bucket: "mydb",
+						},
+					},
+					{
+						ID: "columnTypes1",
+						Spec: &universe.ColumnTypesOpSpec{
+							Column:     "_value",
+							TypeColumn: "type",
+						},
+					},
+				},
+				Edges: []flux.Edge{
+					{Parent: "from0", Child: "columnTypes1"},
+				},
+			},
+		},
+		{
+			Name:    "same output columns",
+			Raw:     `from(bucket:"mydb") |> columnTypes(column: "x", typeColumn: "x")`,
+			WantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			querytest.NewQueryTestHelper(t, tc)
+		})
+	}
+}
+
+func TestColumnTypes_Process(t *testing.T) {
+	testCases := []struct {
+		name string
+		spec *universe.ColumnTypesProcedureSpec
+		data []flux.Table
+		want []*executetest.Table
+	}{
+		{
+			name: "one table",
+			spec: &universe.ColumnTypesProcedureSpec{
+				Column:     "_value",
+				TypeColumn: "_type",
+			},
+			data: []flux.Table{
+				&executetest.Table{
+					KeyCols: []string{"tag0"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_value", Type: flux.TFloat},
+						{Label: "count", Type: flux.TInt},
+						{Label: "total", Type: flux.TUInt},
+						{Label: "tag0", Type: flux.TString},
+						{Label: "valid", Type: flux.TBool},
+					},
+					Data: [][]interface{}{
+						{execute.Time(1), 2.0, int64(1), uint64(2), "a", true},
+					},
+				},
+			},
+			want: []*executetest.Table{{
+				KeyCols: []string{"tag0"},
+				ColMeta: []flux.ColMeta{
+					{Label: "tag0", Type: flux.TString},
+					{Label: "_value", Type: flux.TString},
+					{Label: "_type", Type: flux.TString},
+				},
+				Data: [][]interface{}{
+					{"a", "_time", "time"},
+					{"a", "_value", "float"},
+					{"a", "count", "int"},
+					{"a", "total", "uint"},
+					{"a", "tag0", "string"},
+					{"a", "valid", "bool"},
+				},
+			}},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			executetest.ProcessTestHelper(
+				t,
+				tc.data,
+				tc.want,
+				nil,
+				func(d execute.Dataset, c execute.TableBuilderCache) execute.Transformation {
+					return universe.NewColumnTypesTransformation(d, c, tc.spec)
+				},
+			)
+		})
+	}
+}
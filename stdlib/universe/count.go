@@ -73,6 +73,11 @@ func (s *CountProcedureSpec) ReAggregateSpec() plan.ProcedureSpec {
 	return new(SumProcedureSpec)
 }
 
+// SplitAggregate implements plan.SplittableAggregateSpec, the partial counts are summed.
+func (s *CountProcedureSpec) SplitAggregate() (partial, final plan.PhysicalProcedureSpec, ok bool) {
+	return s.Copy().(*CountProcedureSpec), &SumProcedureSpec{AggregateConfig: s.AggregateConfig}, true
+}
+
 type CountAgg struct {
 	count int64
 }
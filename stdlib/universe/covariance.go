@@ -62,6 +62,9 @@ func createCovarianceOpSpec(args flux.Arguments, a *flux.Administration) (flux.O
 	if len(spec.Columns) != 2 {
 		return nil, errors.New("must provide exactly two columns")
 	}
+	if spec.NaN != "" {
+		return nil, errors.New("covariance does not support a NaN policy")
+	}
 	return spec, nil
 }
 
@@ -171,14 +174,16 @@ func (t *CovarianceTransformation) Process(id execute.DatasetID, tbl flux.Table)
 		return errors.New("cannot compute the covariance between different types")
 	}
 
+	switch typ := cols[xIdx].Type; typ {
+	case flux.TFloat, flux.TInt, flux.TUInt:
+	default:
+		return fmt.Errorf("covariance does not support %v", typ)
+	}
+
 	t.reset()
 	err = tbl.Do(func(cr flux.ColReader) error {
-		switch typ := cols[xIdx].Type; typ {
-		case flux.TFloat:
-			t.DoFloat(cr.Floats(xIdx), cr.Floats(yIdx))
-		default:
-			return fmt.Errorf("covariance does not support %v", typ)
-		}
+		typ := cols[xIdx].Type
+		t.do(floatValues(cr, xIdx, typ), floatValues(cr, yIdx, typ))
 		return nil
 	})
 	if err != nil {
@@ -200,6 +205,10 @@ func (t *CovarianceTransformation) reset() {
 	t.xym2 = 0
 }
 func (t *CovarianceTransformation) DoFloat(xs, ys *array.Float64) {
+	t.do(xs, ys)
+}
+
+func (t *CovarianceTransformation) do(xs, ys numericValues) {
 	var xdelta, ydelta, xdelta2, ydelta2 float64
 	for i := 0; i < xs.Len(); i++ {
 		if xs.IsNull(i) || ys.IsNull(i) {
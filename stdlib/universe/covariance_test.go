@@ -96,7 +96,8 @@ func TestCovariance_NewQuery(t *testing.T) {
 								"from0": "x",
 								"from1": "y",
 							},
-							Method: "inner",
+							Method:  "inner",
+							Missing: "drop",
 						},
 					},
 					{
@@ -188,6 +189,43 @@ func TestCovariance_Process(t *testing.T) {
 				},
 			}},
 		},
+		{
+			name: "uint variance",
+			spec: &universe.CovarianceProcedureSpec{
+				ValueLabel: execute.DefaultValueColLabel,
+				AggregateConfig: execute.AggregateConfig{
+					Columns: []string{"x", "y"},
+				},
+			},
+			data: []flux.Table{&executetest.Table{
+				KeyCols: []string{"_start", "_stop"},
+				ColMeta: []flux.ColMeta{
+					{Label: "_start", Type: flux.TTime},
+					{Label: "_stop", Type: flux.TTime},
+					{Label: "_time", Type: flux.TTime},
+					{Label: "x", Type: flux.TUInt},
+					{Label: "y", Type: flux.TUInt},
+				},
+				Data: [][]interface{}{
+					{execute.Time(0), execute.Time(5), execute.Time(0), uint64(1), uint64(1)},
+					{execute.Time(0), execute.Time(5), execute.Time(1), uint64(2), uint64(2)},
+					{execute.Time(0), execute.Time(5), execute.Time(2), uint64(3), uint64(3)},
+					{execute.Time(0), execute.Time(5), execute.Time(3), uint64(4), uint64(4)},
+					{execute.Time(0), execute.Time(5), execute.Time(4), uint64(5), uint64(5)},
+				},
+			}},
+			want: []*executetest.Table{{
+				KeyCols: []string{"_start", "_stop"},
+				ColMeta: []flux.ColMeta{
+					{Label: "_start", Type: flux.TTime},
+					{Label: "_stop", Type: flux.TTime},
+					{Label: "_value", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{execute.Time(0), execute.Time(5), 2.5},
+				},
+			}},
+		},
 		{
 			name: "negative covariance",
 			spec: &universe.CovarianceProcedureSpec{
@@ -13,13 +13,15 @@ import (
 const CumulativeSumKind = "cumulativeSum"
 
 type CumulativeSumOpSpec struct {
-	Columns []string `json:"columns"`
+	Columns        []string `json:"columns"`
+	PropagateNulls bool     `json:"propagateNulls"`
 }
 
 func init() {
 	cumulativeSumSignature := flux.FunctionSignature(
 		map[string]semantic.PolyType{
-			"columns": semantic.NewArrayPolyType(semantic.String),
+			"columns":        semantic.NewArrayPolyType(semantic.String),
+			"propagateNulls": semantic.Bool,
 		},
 		nil,
 	)
@@ -47,6 +49,12 @@ func createCumulativeSumOpSpec(args flux.Arguments, a *flux.Administration) (flu
 	} else {
 		spec.Columns = []string{execute.DefaultValueColLabel}
 	}
+
+	if propagateNulls, ok, err := args.GetBool("propagateNulls"); err != nil {
+		return nil, err
+	} else if ok {
+		spec.PropagateNulls = propagateNulls
+	}
 	return spec, nil
 }
 
@@ -60,7 +68,8 @@ func (s *CumulativeSumOpSpec) Kind() flux.OperationKind {
 
 type CumulativeSumProcedureSpec struct {
 	plan.DefaultCost
-	Columns []string
+	Columns        []string
+	PropagateNulls bool
 }
 
 func newCumulativeSumProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
@@ -70,7 +79,8 @@ func newCumulativeSumProcedure(qs flux.OperationSpec, pa plan.Administration) (p
 	}
 
 	return &CumulativeSumProcedureSpec{
-		Columns: spec.Columns,
+		Columns:        spec.Columns,
+		PropagateNulls: spec.PropagateNulls,
 	}, nil
 }
 
@@ -140,16 +150,19 @@ func (t *cumulativeSumTransformation) Process(id execute.DatasetID, tbl flux.Tab
 		for j, c := range cols {
 			switch c.Type {
 			case flux.TBool:
-				for i := 0; i < l; i++ {
-					if err := builder.AppendBool(j, cr.Bools(j).Value(i)); err != nil {
-						return err
-					}
+				if err := builder.AppendBools(j, cr.Bools(j)); err != nil {
+					return err
 				}
 			case flux.TInt:
 				if sumers[j] != nil {
 					for i := 0; i < l; i++ {
 						if vs := cr.Ints(j); vs.IsValid(i) {
 							sumers[j].sumInt(vs.Value(i))
+						} else if t.spec.PropagateNulls {
+							if err := builder.AppendNil(j); err != nil {
+								return err
+							}
+							continue
 						}
 
 						if err := builder.AppendInt(j, sumers[j].intVal); err != nil {
@@ -166,6 +179,11 @@ func (t *cumulativeSumTransformation) Process(id execute.DatasetID, tbl flux.Tab
 					for i := 0; i < l; i++ {
 						if vs := cr.UInts(j); vs.IsValid(i) {
 							sumers[j].sumUInt(vs.Value(i))
+						} else if t.spec.PropagateNulls {
+							if err := builder.AppendNil(j); err != nil {
+								return err
+							}
+							continue
 						}
 
 						if err := builder.AppendUInt(j, sumers[j].uintVal); err != nil {
@@ -182,6 +200,11 @@ func (t *cumulativeSumTransformation) Process(id execute.DatasetID, tbl flux.Tab
 					for i := 0; i < l; i++ {
 						if vs := cr.Floats(j); vs.IsValid(i) {
 							sumers[j].sumFloat(vs.Value(i))
+						} else if t.spec.PropagateNulls {
+							if err := builder.AppendNil(j); err != nil {
+								return err
+							}
+							continue
 						}
 
 						if err := builder.AppendFloat(j, sumers[j].floatVal); err != nil {
@@ -194,16 +217,12 @@ func (t *cumulativeSumTransformation) Process(id execute.DatasetID, tbl flux.Tab
 					}
 				}
 			case flux.TString:
-				for i := 0; i < l; i++ {
-					if err := builder.AppendString(j, cr.Strings(j).ValueString(i)); err != nil {
-						return err
-					}
+				if err := builder.AppendStrings(j, cr.Strings(j)); err != nil {
+					return err
 				}
 			case flux.TTime:
-				for i := 0; i < l; i++ {
-					if err := builder.AppendTime(j, execute.Time(cr.Times(j).Value(i))); err != nil {
-						return err
-					}
+				if err := builder.AppendTimes(j, cr.Times(j)); err != nil {
+					return err
 				}
 			}
 		}
@@ -10,25 +10,32 @@ import (
 	"github.com/influxdata/flux/interpreter"
 	"github.com/influxdata/flux/plan"
 	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/flux/values"
 	"github.com/pkg/errors"
 )
 
 const DerivativeKind = "derivative"
 
 type DerivativeOpSpec struct {
-	Unit        flux.Duration `json:"unit"`
-	NonNegative bool          `json:"nonNegative"`
-	Columns     []string      `json:"columns"`
-	TimeColumn  string        `json:"timeColumn"`
+	Unit           flux.Duration `json:"unit"`
+	NonNegative    bool          `json:"nonNegative"`
+	Columns        []string      `json:"columns"`
+	TimeColumn     string        `json:"timeColumn"`
+	KeepFirst      bool          `json:"keepFirst"`
+	InitialZero    bool          `json:"initialZero"`
+	PropagateNulls bool          `json:"propagateNulls"`
 }
 
 func init() {
 	derivativeSignature := flux.FunctionSignature(
 		map[string]semantic.PolyType{
-			"unit":        semantic.Duration,
-			"nonNegative": semantic.Bool,
-			"columns":     semantic.NewArrayPolyType(semantic.String),
-			"timeColumn":  semantic.String,
+			"unit":           semantic.Duration,
+			"nonNegative":    semantic.Bool,
+			"columns":        semantic.NewArrayPolyType(semantic.String),
+			"timeColumn":     semantic.String,
+			"keepFirst":      semantic.Bool,
+			"initialZero":    semantic.Bool,
+			"propagateNulls": semantic.Bool,
 		},
 		nil,
 	)
@@ -52,7 +59,7 @@ func createDerivativeOpSpec(args flux.Arguments, a *flux.Administration) (flux.O
 		spec.Unit = unit
 	} else {
 		//Default is 1s
-		spec.Unit = flux.Duration(time.Second)
+		spec.Unit = values.ConvertDuration(time.Second)
 	}
 
 	if nn, ok, err := args.GetBool("nonNegative"); err != nil {
@@ -60,6 +67,21 @@ func createDerivativeOpSpec(args flux.Arguments, a *flux.Administration) (flux.O
 	} else if ok {
 		spec.NonNegative = nn
 	}
+	if keepFirst, ok, err := args.GetBool("keepFirst"); err != nil {
+		return nil, err
+	} else if ok {
+		spec.KeepFirst = keepFirst
+	}
+	if initialZero, ok, err := args.GetBool("initialZero"); err != nil {
+		return nil, err
+	} else if ok {
+		spec.InitialZero = initialZero
+	}
+	if propagateNulls, ok, err := args.GetBool("propagateNulls"); err != nil {
+		return nil, err
+	} else if ok {
+		spec.PropagateNulls = propagateNulls
+	}
 	if timeCol, ok, err := args.GetString("timeColumn"); err != nil {
 		return nil, err
 	} else if ok {
@@ -92,10 +114,13 @@ func (s *DerivativeOpSpec) Kind() flux.OperationKind {
 
 type DerivativeProcedureSpec struct {
 	plan.DefaultCost
-	Unit        flux.Duration `json:"unit"`
-	NonNegative bool          `json:"non_negative"`
-	Columns     []string      `json:"columns"`
-	TimeColumn  string        `json:"timeColumn"`
+	Unit           flux.Duration `json:"unit"`
+	NonNegative    bool          `json:"non_negative"`
+	Columns        []string      `json:"columns"`
+	TimeColumn     string        `json:"timeColumn"`
+	KeepFirst      bool          `json:"keep_first"`
+	InitialZero    bool          `json:"initial_zero"`
+	PropagateNulls bool          `json:"propagate_nulls"`
 }
 
 func newDerivativeProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
@@ -105,10 +130,13 @@ func newDerivativeProcedure(qs flux.OperationSpec, pa plan.Administration) (plan
 	}
 
 	return &DerivativeProcedureSpec{
-		Unit:        spec.Unit,
-		NonNegative: spec.NonNegative,
-		Columns:     spec.Columns,
-		TimeColumn:  spec.TimeColumn,
+		Unit:           spec.Unit,
+		NonNegative:    spec.NonNegative,
+		Columns:        spec.Columns,
+		TimeColumn:     spec.TimeColumn,
+		KeepFirst:      spec.KeepFirst,
+		InitialZero:    spec.InitialZero,
+		PropagateNulls: spec.PropagateNulls,
 	}, nil
 }
 
@@ -125,6 +153,16 @@ func (s *DerivativeProcedureSpec) Copy() plan.ProcedureSpec {
 	return ns
 }
 
+// SortedBy implements plan.OrderedProcedureSpec.
+// The rows of the output are in the order of the rows of the input,
+// but only the time column is known to be kept.
+func (s *DerivativeProcedureSpec) SortedBy(predecessors [][]string) []string {
+	if plan.IsSortedBy(predecessors[0], []string{s.TimeColumn}) {
+		return []string{s.TimeColumn}
+	}
+	return nil
+}
+
 func createDerivativeTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
 	s, ok := spec.(*DerivativeProcedureSpec)
 	if !ok {
@@ -140,20 +178,26 @@ type derivativeTransformation struct {
 	d     execute.Dataset
 	cache execute.TableBuilderCache
 
-	unit        float64
-	nonNegative bool
-	columns     []string
-	timeCol     string
+	unit           float64
+	nonNegative    bool
+	columns        []string
+	timeCol        string
+	keepFirst      bool
+	initialZero    bool
+	propagateNulls bool
 }
 
 func NewDerivativeTransformation(d execute.Dataset, cache execute.TableBuilderCache, spec *DerivativeProcedureSpec) *derivativeTransformation {
 	return &derivativeTransformation{
-		d:           d,
-		cache:       cache,
-		unit:        float64(spec.Unit),
-		nonNegative: spec.NonNegative,
-		columns:     spec.Columns,
-		timeCol:     spec.TimeColumn,
+		d:              d,
+		cache:          cache,
+		unit:           float64(spec.Unit.Duration()),
+		nonNegative:    spec.NonNegative,
+		columns:        spec.Columns,
+		timeCol:        spec.TimeColumn,
+		keepFirst:      spec.KeepFirst,
+		initialZero:    spec.InitialZero,
+		propagateNulls: spec.PropagateNulls,
 	}
 }
 
@@ -167,7 +211,7 @@ func (t *derivativeTransformation) Process(id execute.DatasetID, tbl flux.Table)
 		return fmt.Errorf("derivative found duplicate table with key: %v", tbl.Key())
 	}
 	cols := tbl.Cols()
-	doDerivative := make([]bool, len(cols))
+	derivatives := make([]*derivative, len(cols))
 	timeIdx := -1
 	for j, c := range cols {
 		found := false
@@ -182,6 +226,11 @@ func (t *derivativeTransformation) Process(id execute.DatasetID, tbl flux.Table)
 		}
 
 		if found {
+			switch c.Type {
+			case flux.TInt, flux.TUInt, flux.TFloat:
+			default:
+				return fmt.Errorf("cannot compute the derivative of column %q of type %v", c.Label, c.Type)
+			}
 			dc := c
 			// Derivative always results in a float
 			dc.Type = flux.TFloat
@@ -189,7 +238,7 @@ func (t *derivativeTransformation) Process(id execute.DatasetID, tbl flux.Table)
 			if err != nil {
 				return err
 			}
-			doDerivative[j] = true
+			derivatives[j] = &derivative{t: t, col: j}
 		} else {
 			_, err := builder.AddCol(c)
 			if err != nil {
@@ -201,34 +250,55 @@ func (t *derivativeTransformation) Process(id execute.DatasetID, tbl flux.Table)
 		return fmt.Errorf("no column %q exists", t.timeCol)
 	}
 
+	var (
+		// pTime is the time of the last row that was processed.
+		pTime execute.Time
+		first = true
+		rows  []int
+	)
 	return tbl.Do(func(cr flux.ColReader) error {
 		if cr.Len() == 0 {
 			return nil
 		}
 
-		if cr.Times(timeIdx).NullN() > 0 {
+		ts := cr.Times(timeIdx)
+		if ts.NullN() > 0 {
 			return fmt.Errorf("derivative found null time in time column")
 		}
 
-		for j, c := range cr.Cols() {
-			var err error
-			switch c.Type {
-			case flux.TBool:
-				err = t.passThroughBool(cr.Times(timeIdx), cr.Bools(j), builder, j)
-			case flux.TInt:
-				err = t.doInt(cr.Times(timeIdx), cr.Ints(j), builder, j, doDerivative[j])
-			case flux.TUInt:
-				err = t.doUInt(cr.Times(timeIdx), cr.UInts(j), builder, j, doDerivative[j])
-			case flux.TFloat:
-				err = t.doFloat(cr.Times(timeIdx), cr.Floats(j), builder, j, doDerivative[j])
-			case flux.TString:
-				err = t.passThroughString(cr.Times(timeIdx), cr.Strings(j), builder, j)
-			case flux.TTime:
-				err = t.passThroughTime(cr.Times(timeIdx), cr.Times(j), builder, j)
+		// Select the rows of the chunk with an increasing time,
+		// only the first row found for a time value is used.
+		rows = rows[:0]
+		from := 0
+		for i := 0; i < cr.Len(); i++ {
+			cTime := execute.Time(ts.Value(i))
+			if !first {
+				if cTime < pTime {
+					return errors.New(derivativeUnsortedTimeErr)
+				}
+				if cTime == pTime {
+					continue
+				}
+			} else if !t.keepFirst {
+				// The first row only provides the previous value of the next row.
+				from = 1
 			}
+			rows = append(rows, i)
+			pTime = cTime
+			first = false
+		}
 
-			if err != nil {
-				return err
+		for j := range cols {
+			if d := derivatives[j]; d != nil {
+				if err := d.appendDerivatives(cr, ts, rows, from, builder); err != nil {
+					return err
+				}
+				continue
+			}
+			for _, i := range rows[from:] {
+				if err := builder.AppendValue(j, execute.ValueForRow(cr, i, j)); err != nil {
+					return err
+				}
 			}
 		}
 		return nil
@@ -247,378 +317,88 @@ func (t *derivativeTransformation) Finish(id execute.DatasetID, err error) {
 
 const derivativeUnsortedTimeErr = "derivative found out-of-order times in time column"
 
-func (t *derivativeTransformation) passThroughBool(ts *array.Int64, vs *array.Boolean, b execute.TableBuilder, bj int) error {
-	i := 0
-
-	pTime := execute.Time(ts.Value(i))
-	i++
-
-	// Process the rest of the rows
-	l := vs.Len()
-	for ; i < l; i++ {
-		cTime := execute.Time(ts.Value(i))
-		if cTime < pTime {
-			return errors.New(derivativeUnsortedTimeErr)
-		}
-
-		if cTime == pTime {
-			// Only use the first value found if a time value is the same as
-			// the previous row.
-			continue
-		}
-
-		// We have a valid time for this row.  Code below should not exit
-		// the loop early so pTime can be set for the next iteration.
-
-		if vs.IsValid(i) {
-			if err := b.AppendBool(bj, vs.Value(i)); err != nil {
-				return err
-			}
-		} else {
-			if err := b.AppendNil(bj); err != nil {
-				return err
-			}
-		}
-
-		pTime = cTime
-	}
-
-	return nil
+// derivative holds the previous value of a column across the chunks of a table.
+type derivative struct {
+	t   *derivativeTransformation
+	col int
+
+	// valid reports whether there is a previous value to compute the derivative from.
+	valid       bool
+	pTime       execute.Time
+	pIntValue   int64
+	pUIntValue  uint64
+	pFloatValue float64
 }
 
-func (t *derivativeTransformation) doInt(ts, vs *array.Int64, b execute.TableBuilder, bj int, doDerivative bool) error {
-	i := 0
-	var pValue int64
-	var pValueTime execute.Time
-	validPValue := false
-
-	// Now consume the first input value, which doesn't produce an output value
-	pTime := execute.Time(ts.Value(i))
-	if vs.IsValid(i) {
-		pValue = vs.Value(i)
-		pValueTime = pTime
-		validPValue = true
-	}
-	i++
-
-	// Process the rest of the rows
-	l := vs.Len()
-	for ; i < l; i++ {
+// appendDerivatives appends the derivatives of the given rows of the column of cr onto the builder,
+// starting with the row at index from. The rows before from only update the previous value.
+func (d *derivative) appendDerivatives(cr flux.ColReader, ts *array.Int64, rows []int, from int, b execute.TableBuilder) error {
+	for k, i := range rows {
 		cTime := execute.Time(ts.Value(i))
-		if cTime < pTime {
-			return errors.New(derivativeUnsortedTimeErr)
+		pTime := d.pTime
+		diff, reset, negative, hasPrevious, valid := d.update(cr, i)
+		if valid {
+			d.pTime = cTime
 		}
-
-		if cTime == pTime {
-			// if time did not increase with this row, ignore it.
+		if k < from {
 			continue
 		}
 
-		// We have a valid time for this row.  Code below should not exit
-		// the loop early so pTime can be set for the next iteration.
-
-		if !doDerivative {
-			// Just write the value to the builder
-			if vs.IsValid(i) {
-				if err := b.AppendInt(bj, vs.Value(i)); err != nil {
-					return err
-				}
-			} else {
-				if err := b.AppendNil(bj); err != nil {
-					return err
-				}
-			}
-		} else if !validPValue {
-			// We have not yet seen a valid value.
-			if err := b.AppendNil(bj); err != nil {
-				return err
-			}
-
-			if vs.IsValid(i) {
-				pValue = vs.Value(i)
-				pValueTime = cTime
-				validPValue = true
-			}
-		} else if vs.IsNull(i) {
-			// If current value is null, then produce null value
-			if err := b.AppendNil(bj); err != nil {
+		if !valid || !hasPrevious || (negative && d.t.nonNegative && !d.t.initialZero) {
+			if err := b.AppendNil(d.col); err != nil {
 				return err
 			}
-		} else {
-			// We have a valid previous value and current value.
-			cValue := vs.Value(i)
-			if t.nonNegative && pValue > cValue {
-				if err := b.AppendNil(bj); err != nil {
-					return err
-				}
-			} else {
-				// Finally, do the derivative.
-				elapsed := float64(cTime-pValueTime) / t.unit
-				diff := float64(cValue - pValue)
-				if err := b.AppendFloat(bj, diff/elapsed); err != nil {
-					return err
-				}
-			}
-
-			pValue = cValue
-			pValueTime = cTime
-		}
-
-		pTime = cTime
-	}
-
-	return nil
-}
-
-func (t *derivativeTransformation) doUInt(ts *array.Int64, vs *array.Uint64, b execute.TableBuilder, bj int, doDerivative bool) error {
-	i := 0
-	var pValue uint64
-	var pValueTime execute.Time
-	validPValue := false
-
-	// Now consume the first input value, which doesn't produce an output value
-	pTime := execute.Time(ts.Value(i))
-	if vs.IsValid(i) {
-		pValue = vs.Value(i)
-		pValueTime = pTime
-		validPValue = true
-	}
-	i++
-
-	// Process the rest of the rows
-	l := vs.Len()
-	for ; i < l; i++ {
-		cTime := execute.Time(ts.Value(i))
-		if cTime < pTime {
-			return errors.New(derivativeUnsortedTimeErr)
-		}
-
-		if cTime == pTime {
-			// if time did not increase with this row, ignore it.
 			continue
 		}
-
-		// We have a valid time for this row.  Code below should not exit
-		// the loop early so pTime can be set for the next iteration.
-
-		if !doDerivative {
-			// Just write the value to the builder
-			if vs.IsValid(i) {
-				if err := b.AppendUInt(bj, vs.Value(i)); err != nil {
-					return err
-				}
-			} else {
-				if err := b.AppendNil(bj); err != nil {
-					return err
-				}
-			}
-		} else if !validPValue {
-			// We have not yet seen a valid value.
-			if err := b.AppendNil(bj); err != nil {
-				return err
-			}
-
-			if vs.IsValid(i) {
-				pValue = vs.Value(i)
-				pValueTime = cTime
-				validPValue = true
-			}
-		} else if vs.IsNull(i) {
-			// If current value is null, then produce null value
-			if err := b.AppendNil(bj); err != nil {
-				return err
-			}
-		} else {
-			// We have a valid previous value and current value.
-			cValue := vs.Value(i)
-			isNeg := pValue > cValue
-			if t.nonNegative && isNeg {
-				if err := b.AppendNil(bj); err != nil {
-					return err
-				}
-			} else {
-				// Finally, do the derivative.
-				elapsed := float64(cTime-pValueTime) / t.unit
-
-				var diff float64
-				if isNeg {
-					// Avoid wrapping on unsigned subtraction
-					diff = -float64(pValue - cValue)
-				} else {
-					diff = float64(cValue - pValue)
-				}
-
-				if err := b.AppendFloat(bj, diff/elapsed); err != nil {
-					return err
-				}
-			}
-
-			pValue = cValue
-			pValueTime = cTime
+		if negative && d.t.nonNegative {
+			// The value is a reset, its derivative is computed from zero.
+			diff = reset
+		}
+		elapsed := float64(cTime-pTime) / d.t.unit
+		if err := b.AppendFloat(d.col, diff/elapsed); err != nil {
+			return err
 		}
-
-		pTime = cTime
 	}
-
 	return nil
 }
 
-func (t *derivativeTransformation) doFloat(ts *array.Int64, vs *array.Float64, b execute.TableBuilder, bj int, doDerivative bool) error {
-	i := 0
-	var pValue float64
-	var pValueTime execute.Time
-	validPValue := false
-
-	// Now consume the first input value, which doesn't produce an output value
-	pTime := execute.Time(ts.Value(i))
-	if vs.IsValid(i) {
-		pValue = vs.Value(i)
-		pValueTime = pTime
-		validPValue = true
-	}
-	i++
-
-	// Process the rest of the rows
-	l := vs.Len()
-	for ; i < l; i++ {
-		cTime := execute.Time(ts.Value(i))
-		if cTime < pTime {
-			return errors.New(derivativeUnsortedTimeErr)
-		}
-
-		if cTime == pTime {
-			// if time did not increase with this row, ignore it.
-			continue
+// update records the value of row i and returns its difference with the previous value,
+// the difference from zero used when the value is a reset, whether the difference is negative,
+// whether there was a previous value and whether the value of the row is valid.
+func (d *derivative) update(cr flux.ColReader, i int) (diff, reset float64, negative, hasPrevious, valid bool) {
+	hasPrevious = d.valid
+	switch cr.Cols()[d.col].Type {
+	case flux.TInt:
+		if vs := cr.Ints(d.col); vs.IsValid(i) {
+			v := vs.Value(i)
+			diff, reset, negative = float64(v-d.pIntValue), float64(v), v < d.pIntValue
+			d.pIntValue, valid = v, true
 		}
-
-		// We have a valid time for this row.  Code below should not exit
-		// the loop early so pTime can be set for the next iteration.
-
-		if !doDerivative {
-			// Just write the value to the builder
-			if vs.IsValid(i) {
-				if err := b.AppendFloat(bj, vs.Value(i)); err != nil {
-					return err
-				}
-			} else {
-				if err := b.AppendNil(bj); err != nil {
-					return err
-				}
-			}
-		} else if !validPValue {
-			// We have not yet seen a valid value.
-			if err := b.AppendNil(bj); err != nil {
-				return err
-			}
-
-			if vs.IsValid(i) {
-				pValue = vs.Value(i)
-				pValueTime = cTime
-				validPValue = true
-			}
-		} else if vs.IsNull(i) {
-			// If current value is null, then produce null value
-			if err := b.AppendNil(bj); err != nil {
-				return err
-			}
-		} else {
-			// We have a valid previous value and current value.
-			cValue := vs.Value(i)
-			if t.nonNegative && pValue > cValue {
-				if err := b.AppendNil(bj); err != nil {
-					return err
-				}
+	case flux.TUInt:
+		if vs := cr.UInts(d.col); vs.IsValid(i) {
+			v := vs.Value(i)
+			if v < d.pUIntValue {
+				// Avoid wrapping on unsigned subtraction
+				diff, negative = -float64(d.pUIntValue-v), true
 			} else {
-				// Finally, do the derivative.
-				elapsed := float64(cTime-pValueTime) / t.unit
-				diff := float64(cValue - pValue)
-				if err := b.AppendFloat(bj, diff/elapsed); err != nil {
-					return err
-				}
+				diff = float64(v - d.pUIntValue)
 			}
-
-			pValue = cValue
-			pValueTime = cTime
-		}
-
-		pTime = cTime
-	}
-
-	return nil
-}
-
-func (t *derivativeTransformation) passThroughString(ts *array.Int64, vs *array.Binary, b execute.TableBuilder, bj int) error {
-	i := 0
-	pTime := execute.Time(ts.Value(i))
-	i++
-
-	// Process the rest of the rows
-	l := vs.Len()
-	for ; i < l; i++ {
-		cTime := execute.Time(ts.Value(i))
-		if cTime < pTime {
-			return errors.New(derivativeUnsortedTimeErr)
-		}
-
-		if cTime == pTime {
-			// Only use the first value found if a time value is the same as
-			// the previous row.
-			continue
+			reset = float64(v)
+			d.pUIntValue, valid = v, true
 		}
-
-		// We have a valid time for this row.  Code below should not exit
-		// the loop early so pTime can be set for the next iteration.
-
-		if vs.IsValid(i) {
-			if err := b.AppendString(bj, string(vs.Value(i))); err != nil {
-				return err
-			}
-		} else {
-			if err := b.AppendNil(bj); err != nil {
-				return err
-			}
+	case flux.TFloat:
+		if vs := cr.Floats(d.col); vs.IsValid(i) {
+			v := vs.Value(i)
+			diff, reset, negative = v-d.pFloatValue, v, v < d.pFloatValue
+			d.pFloatValue, valid = v, true
 		}
-
-		pTime = cTime
 	}
 
-	return nil
-}
-
-func (t *derivativeTransformation) passThroughTime(ts *array.Int64, vs *array.Int64, b execute.TableBuilder, bj int) error {
-	i := 0
-	pTime := execute.Time(ts.Value(i))
-	i++
-
-	// Process the rest of the rows
-	l := vs.Len()
-	for ; i < l; i++ {
-		cTime := execute.Time(ts.Value(i))
-		if cTime < pTime {
-			return errors.New(derivativeUnsortedTimeErr)
-		}
-
-		if cTime == pTime {
-			// Only use the first value found if a time value is the same as
-			// the previous row.
-			continue
-		}
-
-		// We have a valid time for this row.  Code below should not exit
-		// the loop early so pTime can be set for the next iteration.
-
-		if vs.IsValid(i) {
-			if err := b.AppendTime(bj, execute.Time(vs.Value(i))); err != nil {
-				return err
-			}
-		} else {
-			if err := b.AppendNil(bj); err != nil {
-				return err
-			}
-		}
-
-		pTime = cTime
+	if valid {
+		d.valid = true
+	} else if d.t.propagateNulls {
+		// The null value discards the previous value.
+		d.valid = false
 	}
-
-	return nil
+	return
 }
@@ -10,6 +10,7 @@ import (
 	"github.com/influxdata/flux/execute/executetest"
 	"github.com/influxdata/flux/querytest"
 	"github.com/influxdata/flux/stdlib/universe"
+	"github.com/influxdata/flux/values"
 )
 
 func TestDerivativeOperation_Marshaling(t *testing.T) {
@@ -17,7 +18,7 @@ func TestDerivativeOperation_Marshaling(t *testing.T) {
 	op := &flux.Operation{
 		ID: "derivative",
 		Spec: &universe.DerivativeOpSpec{
-			Unit:        flux.Duration(time.Minute),
+			Unit:        values.ConvertDuration(time.Minute),
 			NonNegative: true,
 		},
 	}
@@ -48,7 +49,7 @@ func TestDerivative_Process(t *testing.T) {
 			spec: &universe.DerivativeProcedureSpec{
 				Columns:    []string{execute.DefaultValueColLabel},
 				TimeColumn: execute.DefaultTimeColLabel,
-				Unit:       1,
+				Unit:       values.ConvertDuration(1),
 			},
 			data: []flux.Table{&executetest.Table{
 				ColMeta: []flux.ColMeta{
@@ -75,7 +76,7 @@ func TestDerivative_Process(t *testing.T) {
 			spec: &universe.DerivativeProcedureSpec{
 				Columns:    []string{execute.DefaultValueColLabel},
 				TimeColumn: execute.DefaultTimeColLabel,
-				Unit:       flux.Duration(time.Second),
+				Unit:       values.ConvertDuration(time.Second),
 			},
 			data: []flux.Table{&executetest.Table{
 				ColMeta: []flux.ColMeta{
@@ -102,7 +103,7 @@ func TestDerivative_Process(t *testing.T) {
 			spec: &universe.DerivativeProcedureSpec{
 				Columns:    []string{execute.DefaultValueColLabel},
 				TimeColumn: execute.DefaultTimeColLabel,
-				Unit:       1,
+				Unit:       values.ConvertDuration(1),
 			},
 			data: []flux.Table{&executetest.Table{
 				ColMeta: []flux.ColMeta{
@@ -129,7 +130,7 @@ func TestDerivative_Process(t *testing.T) {
 			spec: &universe.DerivativeProcedureSpec{
 				Columns:    []string{execute.DefaultValueColLabel},
 				TimeColumn: execute.DefaultTimeColLabel,
-				Unit:       flux.Duration(time.Second),
+				Unit:       values.ConvertDuration(time.Second),
 			},
 			data: []flux.Table{&executetest.Table{
 				ColMeta: []flux.ColMeta{
@@ -156,7 +157,7 @@ func TestDerivative_Process(t *testing.T) {
 			spec: &universe.DerivativeProcedureSpec{
 				Columns:     []string{execute.DefaultValueColLabel},
 				TimeColumn:  execute.DefaultTimeColLabel,
-				Unit:        1,
+				Unit:        values.ConvertDuration(1),
 				NonNegative: true,
 			},
 			data: []flux.Table{&executetest.Table{
@@ -186,7 +187,7 @@ func TestDerivative_Process(t *testing.T) {
 			spec: &universe.DerivativeProcedureSpec{
 				Columns:    []string{execute.DefaultValueColLabel},
 				TimeColumn: execute.DefaultTimeColLabel,
-				Unit:       1,
+				Unit:       values.ConvertDuration(1),
 			},
 			data: []flux.Table{&executetest.Table{
 				ColMeta: []flux.ColMeta{
@@ -213,7 +214,7 @@ func TestDerivative_Process(t *testing.T) {
 			spec: &universe.DerivativeProcedureSpec{
 				Columns:    []string{execute.DefaultValueColLabel},
 				TimeColumn: execute.DefaultTimeColLabel,
-				Unit:       1,
+				Unit:       values.ConvertDuration(1),
 			},
 			data: []flux.Table{&executetest.Table{
 				ColMeta: []flux.ColMeta{
@@ -240,7 +241,7 @@ func TestDerivative_Process(t *testing.T) {
 			spec: &universe.DerivativeProcedureSpec{
 				Columns:     []string{execute.DefaultValueColLabel},
 				TimeColumn:  execute.DefaultTimeColLabel,
-				Unit:        1,
+				Unit:        values.ConvertDuration(1),
 				NonNegative: true,
 			},
 			data: []flux.Table{&executetest.Table{
@@ -270,7 +271,7 @@ func TestDerivative_Process(t *testing.T) {
 			spec: &universe.DerivativeProcedureSpec{
 				Columns:    []string{execute.DefaultValueColLabel},
 				TimeColumn: execute.DefaultTimeColLabel,
-				Unit:       flux.Duration(time.Second),
+				Unit:       values.ConvertDuration(time.Second),
 			},
 			data: []flux.Table{&executetest.Table{
 				ColMeta: []flux.ColMeta{
@@ -297,7 +298,7 @@ func TestDerivative_Process(t *testing.T) {
 			spec: &universe.DerivativeProcedureSpec{
 				Columns:     []string{execute.DefaultValueColLabel},
 				TimeColumn:  execute.DefaultTimeColLabel,
-				Unit:        1,
+				Unit:        values.ConvertDuration(1),
 				NonNegative: true,
 			},
 			data: []flux.Table{&executetest.Table{
@@ -327,7 +328,7 @@ func TestDerivative_Process(t *testing.T) {
 			spec: &universe.DerivativeProcedureSpec{
 				Columns:    []string{execute.DefaultValueColLabel},
 				TimeColumn: execute.DefaultTimeColLabel,
-				Unit:       1,
+				Unit:       values.ConvertDuration(1),
 			},
 			data: []flux.Table{&executetest.Table{
 				ColMeta: []flux.ColMeta{
@@ -356,7 +357,7 @@ func TestDerivative_Process(t *testing.T) {
 			spec: &universe.DerivativeProcedureSpec{
 				Columns:    []string{"x", "y"},
 				TimeColumn: execute.DefaultTimeColLabel,
-				Unit:       1,
+				Unit:       values.ConvertDuration(1),
 			},
 			data: []flux.Table{&executetest.Table{
 				ColMeta: []flux.ColMeta{
@@ -385,7 +386,7 @@ func TestDerivative_Process(t *testing.T) {
 			spec: &universe.DerivativeProcedureSpec{
 				Columns:     []string{"x", "y"},
 				TimeColumn:  execute.DefaultTimeColLabel,
-				Unit:        1,
+				Unit:        values.ConvertDuration(1),
 				NonNegative: true,
 			},
 			data: []flux.Table{&executetest.Table{
@@ -417,7 +418,7 @@ func TestDerivative_Process(t *testing.T) {
 			spec: &universe.DerivativeProcedureSpec{
 				Columns:    []string{"x", "y"},
 				TimeColumn: execute.DefaultTimeColLabel,
-				Unit:       1,
+				Unit:       values.ConvertDuration(1),
 			},
 			data: []flux.Table{&executetest.Table{
 				ColMeta: []flux.ColMeta{
@@ -448,7 +449,7 @@ func TestDerivative_Process(t *testing.T) {
 			spec: &universe.DerivativeProcedureSpec{
 				Columns:    []string{"x", "y"},
 				TimeColumn: execute.DefaultTimeColLabel,
-				Unit:       1,
+				Unit:       values.ConvertDuration(1),
 			},
 			data: []flux.Table{&executetest.Table{
 				ColMeta: []flux.ColMeta{
@@ -473,7 +474,7 @@ func TestDerivative_Process(t *testing.T) {
 			spec: &universe.DerivativeProcedureSpec{
 				Columns:    []string{"x", "y"},
 				TimeColumn: execute.DefaultTimeColLabel,
-				Unit:       1,
+				Unit:       values.ConvertDuration(1),
 			},
 			data: []flux.Table{&executetest.Table{
 				ColMeta: []flux.ColMeta{
@@ -498,7 +499,7 @@ func TestDerivative_Process(t *testing.T) {
 			spec: &universe.DerivativeProcedureSpec{
 				Columns:    []string{"x"},
 				TimeColumn: execute.DefaultTimeColLabel,
-				Unit:       1,
+				Unit:       values.ConvertDuration(1),
 			},
 			data: []flux.Table{&executetest.Table{
 				ColMeta: []flux.ColMeta{
@@ -528,6 +529,131 @@ func TestDerivative_Process(t *testing.T) {
 				},
 			}},
 		},
+		{
+			name: "keep first",
+			spec: &universe.DerivativeProcedureSpec{
+				Columns:    []string{execute.DefaultValueColLabel},
+				TimeColumn: execute.DefaultTimeColLabel,
+				Unit:       values.ConvertDuration(1),
+				KeepFirst:  true,
+			},
+			data: []flux.Table{&executetest.Table{
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_value", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), 2.0},
+					{execute.Time(3), 8.0},
+				},
+			}},
+			want: []*executetest.Table{{
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_value", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), nil},
+					{execute.Time(3), 3.0},
+				},
+			}},
+		},
+		{
+			name: "non negative initial zero",
+			spec: &universe.DerivativeProcedureSpec{
+				Columns:     []string{execute.DefaultValueColLabel},
+				TimeColumn:  execute.DefaultTimeColLabel,
+				Unit:        values.ConvertDuration(1),
+				NonNegative: true,
+				InitialZero: true,
+			},
+			data: []flux.Table{&executetest.Table{
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_value", Type: flux.TInt},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), int64(10)},
+					{execute.Time(3), int64(20)},
+					{execute.Time(5), int64(4)},
+				},
+			}},
+			want: []*executetest.Table{{
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_value", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{execute.Time(3), 5.0},
+					{execute.Time(5), 2.0},
+				},
+			}},
+		},
+		{
+			name: "float with null values propagate nulls",
+			spec: &universe.DerivativeProcedureSpec{
+				Columns:        []string{"x", "y"},
+				TimeColumn:     execute.DefaultTimeColLabel,
+				Unit:           values.ConvertDuration(1),
+				PropagateNulls: true,
+			},
+			data: []flux.Table{&executetest.Table{
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "x", Type: flux.TFloat},
+					{Label: "y", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), 2.0, nil},
+					{execute.Time(2), nil, 10.0},
+					{execute.Time(3), 8.0, 20.0},
+					{execute.Time(4), 10.0, 25.0},
+				},
+			}},
+			want: []*executetest.Table{{
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "x", Type: flux.TFloat},
+					{Label: "y", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{execute.Time(2), nil, nil},
+					{execute.Time(3), nil, 10.0},
+					{execute.Time(4), 2.0, 5.0},
+				},
+			}},
+		},
+		{
+			name: "multiple chunks",
+			spec: &universe.DerivativeProcedureSpec{
+				Columns:    []string{execute.DefaultValueColLabel},
+				TimeColumn: execute.DefaultTimeColLabel,
+				Unit:       values.ConvertDuration(1),
+			},
+			data: []flux.Table{&executetest.RowWiseTable{
+				Table: &executetest.Table{
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_value", Type: flux.TFloat},
+					},
+					Data: [][]interface{}{
+						{execute.Time(1), 2.0},
+						{execute.Time(2), 4.0},
+						{execute.Time(4), 10.0},
+					},
+				},
+			}},
+			want: []*executetest.Table{{
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_value", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{execute.Time(2), 2.0},
+					{execute.Time(4), 3.0},
+				},
+			}},
+		},
 	}
 	for _, tc := range testCases {
 		tc := tc
@@ -0,0 +1,80 @@
+package universe
+
+import (
+	"github.com/influxdata/flux"
+	fluxerrors "github.com/influxdata/flux/errors"
+	"github.com/influxdata/flux/interpreter"
+	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/flux/values"
+)
+
+// DefaultAssertMessage is the message of the error of a failed assertion that has no message of its own.
+const DefaultAssertMessage = "assertion failed"
+
+// MakeDieFunc returns die(), which aborts the query with an error with the message msg.
+// Its return type is free, so that it may be called where a value of any type is expected,
+// such as in a branch of a conditional expression.
+func MakeDieFunc() values.Function {
+	return values.NewFunction(
+		"die",
+		semantic.NewFunctionPolyType(semantic.FunctionPolySignature{
+			Parameters: map[string]semantic.PolyType{
+				"msg": semantic.String,
+			},
+			Required: semantic.LabelSet{"msg"},
+			Return:   semantic.Tvar(1),
+		}),
+		func(args values.Object) (values.Value, error) {
+			return interpreter.DoFunctionCall(die, args)
+		}, false,
+	)
+}
+
+// MakeAssertFunc returns assert(), which aborts the query with an error with the message msg
+// unless condition is true, and returns true otherwise.
+func MakeAssertFunc() values.Function {
+	return values.NewFunction(
+		"assert",
+		semantic.NewFunctionPolyType(semantic.FunctionPolySignature{
+			Parameters: map[string]semantic.PolyType{
+				"condition": semantic.Bool,
+				"msg":       semantic.String,
+			},
+			Required: semantic.LabelSet{"condition"},
+			Return:   semantic.Bool,
+		}),
+		func(args values.Object) (values.Value, error) {
+			return interpreter.DoFunctionCall(assert, args)
+		}, false,
+	)
+}
+
+func die(args interpreter.Arguments) (values.Value, error) {
+	msg, err := args.GetRequiredString("msg")
+	if err != nil {
+		return nil, err
+	}
+	return nil, fluxerrors.New(fluxerrors.Aborted, msg)
+}
+
+func assert(args interpreter.Arguments) (values.Value, error) {
+	condition, err := args.GetRequiredBool("condition")
+	if err != nil {
+		return nil, err
+	}
+	msg, ok, err := args.GetString("msg")
+	if err != nil {
+		return nil, err
+	} else if !ok {
+		msg = DefaultAssertMessage
+	}
+	if !condition {
+		return nil, fluxerrors.New(fluxerrors.Aborted, msg)
+	}
+	return values.NewBool(true), nil
+}
+
+func init() {
+	flux.RegisterPackageValue("universe", "die", MakeDieFunc())
+	flux.RegisterPackageValue("universe", "assert", MakeAssertFunc())
+}
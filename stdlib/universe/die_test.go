@@ -0,0 +1,108 @@
+package universe_test
+
+import (
+	"context"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/control"
+	fluxerrors "github.com/influxdata/flux/errors"
+	"github.com/influxdata/flux/lang"
+)
+
+func TestDie_Compile(t *testing.T) {
+	testCases := []struct {
+		name    string
+		script  string
+		wantErr string
+	}{
+		{
+			name:   "assert holds",
+			script: `assert(condition: 1 < 2, msg: "1 is not less than 2")`,
+		},
+		{
+			name:    "assert fails",
+			script:  `assert(condition: 2 < 1, msg: "2 is not less than 1")`,
+			wantErr: "2 is not less than 1",
+		},
+		{
+			name:    "assert fails without message",
+			script:  `assert(condition: false)`,
+			wantErr: "assertion failed",
+		},
+		{
+			name:    "die",
+			script:  `die(msg: "stop here")`,
+			wantErr: "stop here",
+		},
+		{
+			name:    "die as an operand",
+			script:  `x = 1 > 2 or die(msg: "1 is not greater than 2")`,
+			wantErr: "1 is not greater than 2",
+		},
+		{
+			name:   "die as an operand that is not evaluated",
+			script: `x = 1 < 2 or die(msg: "1 is not less than 2")`,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := flux.Compile(context.Background(), tc.script, time.Now())
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("expected error %q", tc.wantErr)
+			}
+			if got := err.Error(); !strings.Contains(got, tc.wantErr) {
+				t.Errorf("unexpected error: want %q, got %q", tc.wantErr, got)
+			}
+			if code := fluxerrors.CodeOf(err); code != fluxerrors.Aborted {
+				t.Errorf("unexpected code: want %v, got %v", fluxerrors.Aborted, code)
+			}
+		})
+	}
+}
+
+// TestDie_Execute checks that die() aborts the query when it is called by the function of a transformation,
+// rather than being ignored like the other errors of the function.
+func TestDie_Execute(t *testing.T) {
+	c := control.New(control.Config{
+		ConcurrencyQuota: 1,
+		MemoryBytesQuota: math.MaxInt64,
+	})
+	defer func() {
+		if err := c.Shutdown(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}()
+
+	script := `
+import "generate"
+
+generate.from(count: 5, fn: (n) => n)
+    |> filter(fn: (r) => r._value < 3 or die(msg: "value too large"))`
+	q, err := c.Query(context.Background(), lang.FluxCompiler{Query: script})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer q.Done()
+	for _, r := range <-q.Ready() {
+		err = r.Tables().Do(func(tbl flux.Table) error {
+			return tbl.Do(func(flux.ColReader) error { return nil })
+		})
+	}
+	if err == nil {
+		t.Fatal("expected the query to be aborted")
+	}
+	if code := fluxerrors.CodeOf(err); code != fluxerrors.Aborted {
+		t.Errorf("unexpected code of error %q: want %v, got %v", err, fluxerrors.Aborted, code)
+	}
+}
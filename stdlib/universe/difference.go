@@ -15,15 +15,21 @@ import (
 const DifferenceKind = "difference"
 
 type DifferenceOpSpec struct {
-	NonNegative bool     `json:"nonNegative"`
-	Columns     []string `json:"columns"`
+	NonNegative    bool     `json:"nonNegative"`
+	Columns        []string `json:"columns"`
+	KeepFirst      bool     `json:"keepFirst"`
+	InitialZero    bool     `json:"initialZero"`
+	PropagateNulls bool     `json:"propagateNulls"`
 }
 
 func init() {
 	differenceSignature := flux.FunctionSignature(
 		map[string]semantic.PolyType{
-			"nonNegative": semantic.Bool,
-			"columns":     semantic.NewArrayPolyType(semantic.String),
+			"nonNegative":    semantic.Bool,
+			"columns":        semantic.NewArrayPolyType(semantic.String),
+			"keepFirst":      semantic.Bool,
+			"initialZero":    semantic.Bool,
+			"propagateNulls": semantic.Bool,
 		},
 		nil,
 	)
@@ -51,6 +57,21 @@ func createDifferenceOpSpec(args flux.Arguments, a *flux.Administration) (flux.O
 	} else if ok {
 		spec.NonNegative = nn
 	}
+	if keepFirst, ok, err := args.GetBool("keepFirst"); err != nil {
+		return nil, err
+	} else if ok {
+		spec.KeepFirst = keepFirst
+	}
+	if initialZero, ok, err := args.GetBool("initialZero"); err != nil {
+		return nil, err
+	} else if ok {
+		spec.InitialZero = initialZero
+	}
+	if propagateNulls, ok, err := args.GetBool("propagateNulls"); err != nil {
+		return nil, err
+	} else if ok {
+		spec.PropagateNulls = propagateNulls
+	}
 
 	if cols, ok, err := args.GetArray("columns", semantic.String); err != nil {
 		return nil, err
@@ -77,8 +98,11 @@ func (s *DifferenceOpSpec) Kind() flux.OperationKind {
 
 type DifferenceProcedureSpec struct {
 	plan.DefaultCost
-	NonNegative bool     `json:"non_negative"`
-	Columns     []string `json:"columns"`
+	NonNegative    bool     `json:"non_negative"`
+	Columns        []string `json:"columns"`
+	KeepFirst      bool     `json:"keep_first"`
+	InitialZero    bool     `json:"initial_zero"`
+	PropagateNulls bool     `json:"propagate_nulls"`
 }
 
 func newDifferenceProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
@@ -88,8 +112,11 @@ func newDifferenceProcedure(qs flux.OperationSpec, pa plan.Administration) (plan
 	}
 
 	return &DifferenceProcedureSpec{
-		NonNegative: spec.NonNegative,
-		Columns:     spec.Columns,
+		NonNegative:    spec.NonNegative,
+		Columns:        spec.Columns,
+		KeepFirst:      spec.KeepFirst,
+		InitialZero:    spec.InitialZero,
+		PropagateNulls: spec.PropagateNulls,
 	}, nil
 }
 
@@ -121,16 +148,22 @@ type differenceTransformation struct {
 	d     execute.Dataset
 	cache execute.TableBuilderCache
 
-	nonNegative bool
-	columns     []string
+	nonNegative    bool
+	columns        []string
+	keepFirst      bool
+	initialZero    bool
+	propagateNulls bool
 }
 
 func NewDifferenceTransformation(d execute.Dataset, cache execute.TableBuilderCache, spec *DifferenceProcedureSpec) *differenceTransformation {
 	return &differenceTransformation{
-		d:           d,
-		cache:       cache,
-		nonNegative: spec.NonNegative,
-		columns:     spec.Columns,
+		d:              d,
+		cache:          cache,
+		nonNegative:    spec.NonNegative,
+		columns:        spec.Columns,
+		keepFirst:      spec.KeepFirst,
+		initialZero:    spec.InitialZero,
+		propagateNulls: spec.PropagateNulls,
 	}
 }
 
@@ -161,6 +194,8 @@ func (t *differenceTransformation) Process(id execute.DatasetID, tbl flux.Table)
 				typ = flux.TInt
 			case flux.TFloat:
 				typ = flux.TFloat
+			default:
+				return fmt.Errorf("cannot compute the difference of column %q of type %v", c.Label, c.Type)
 			}
 			if _, err := builder.AddCol(flux.ColMeta{
 				Label: c.Label,
@@ -168,7 +203,7 @@ func (t *differenceTransformation) Process(id execute.DatasetID, tbl flux.Table)
 			}); err != nil {
 				return err
 			}
-			differences[j] = newDifference(j, t.nonNegative)
+			differences[j] = newDifference(j, t.nonNegative, t.initialZero, t.propagateNulls)
 		} else {
 			_, err := builder.AddCol(c)
 			if err != nil {
@@ -177,118 +212,68 @@ func (t *differenceTransformation) Process(id execute.DatasetID, tbl flux.Table)
 		}
 	}
 
-	// We need to drop the first row since its difference is undefined
+	// The first row is dropped unless it is kept, since its difference is undefined
 	firstIdx := 1
+	if t.keepFirst {
+		firstIdx = 0
+	}
 	return tbl.Do(func(cr flux.ColReader) error {
 		l := cr.Len()
+		if l == 0 {
+			return nil
+		}
+
+		for j, c := range cols {
+			if d := differences[j]; d != nil {
+				if err := d.appendDifferences(cr, builder, firstIdx); err != nil {
+					return err
+				}
+				continue
+			}
 
-		if l != 0 {
-			for j, c := range cols {
-				d := differences[j]
-				switch c.Type {
-				case flux.TBool:
-					s := arrow.BoolSlice(cr.Bools(j), firstIdx, l)
-					if err := builder.AppendBools(j, s); err != nil {
-						s.Release()
-						return err
-					}
+			switch c.Type {
+			case flux.TBool:
+				s := arrow.BoolSlice(cr.Bools(j), firstIdx, l)
+				if err := builder.AppendBools(j, s); err != nil {
 					s.Release()
-				case flux.TInt:
-					if d != nil {
-						for i := 0; i < l; i++ {
-							if vs := cr.Ints(j); vs.IsValid(i) {
-								if v, first := d.updateInt(vs.Value(i)); !first {
-									if d.nonNegative && v < 0 {
-										if err := builder.AppendNil(j); err != nil {
-											return err
-										}
-									} else {
-										if err := builder.AppendInt(j, v); err != nil {
-											return err
-										}
-									}
-								}
-							} else if err := builder.AppendNil(j); err != nil {
-								return err
-							}
-						}
-					} else {
-						s := arrow.IntSlice(cr.Ints(j), firstIdx, l)
-						if err := builder.AppendInts(j, s); err != nil {
-							s.Release()
-							return err
-						}
-						s.Release()
-					}
-				case flux.TUInt:
-					if d != nil {
-						for i := 0; i < l; i++ {
-							if vs := cr.UInts(j); vs.IsValid(i) {
-								if v, first := d.updateUInt(vs.Value(i)); !first {
-									if d.nonNegative && v < 0 {
-										if err := builder.AppendNil(j); err != nil {
-											return err
-										}
-									} else {
-										if err := builder.AppendInt(j, v); err != nil {
-											return err
-										}
-									}
-								}
-							} else if err := builder.AppendNil(j); err != nil {
-								return err
-							}
-						}
-					} else {
-						s := arrow.UintSlice(cr.UInts(j), firstIdx, l)
-						if err := builder.AppendUInts(j, s); err != nil {
-							s.Release()
-							return err
-						}
-						s.Release()
-					}
-				case flux.TFloat:
-					if d != nil {
-						for i := 0; i < l; i++ {
-							if vs := cr.Floats(j); vs.IsValid(i) {
-								if v, first := d.updateFloat(vs.Value(i)); !first {
-									if d.nonNegative && v < 0 {
-										if err := builder.AppendNil(j); err != nil {
-											return err
-										}
-									} else {
-										if err := builder.AppendFloat(j, v); err != nil {
-											return err
-										}
-									}
-								}
-							} else if err := builder.AppendNil(j); err != nil {
-								return err
-							}
-						}
-					} else {
-						s := arrow.FloatSlice(cr.Floats(j), firstIdx, l)
-						if err := builder.AppendFloats(j, s); err != nil {
-							s.Release()
-							return err
-						}
-						s.Release()
-					}
-				case flux.TString:
-					s := arrow.StringSlice(cr.Strings(j), firstIdx, l)
-					if err := builder.AppendStrings(j, s); err != nil {
-						s.Release()
-						return err
-					}
+					return err
+				}
+				s.Release()
+			case flux.TInt:
+				s := arrow.IntSlice(cr.Ints(j), firstIdx, l)
+				if err := builder.AppendInts(j, s); err != nil {
 					s.Release()
-				case flux.TTime:
-					s := arrow.IntSlice(cr.Times(j), firstIdx, l)
-					if err := builder.AppendTimes(j, s); err != nil {
-						s.Release()
-						return err
-					}
+					return err
+				}
+				s.Release()
+			case flux.TUInt:
+				s := arrow.UintSlice(cr.UInts(j), firstIdx, l)
+				if err := builder.AppendUInts(j, s); err != nil {
+					s.Release()
+					return err
+				}
+				s.Release()
+			case flux.TFloat:
+				s := arrow.FloatSlice(cr.Floats(j), firstIdx, l)
+				if err := builder.AppendFloats(j, s); err != nil {
+					s.Release()
+					return err
+				}
+				s.Release()
+			case flux.TString:
+				s := arrow.StringSlice(cr.Strings(j), firstIdx, l)
+				if err := builder.AppendStrings(j, s); err != nil {
+					s.Release()
+					return err
+				}
+				s.Release()
+			case flux.TTime:
+				s := arrow.IntSlice(cr.Times(j), firstIdx, l)
+				if err := builder.AppendTimes(j, s); err != nil {
 					s.Release()
+					return err
 				}
+				s.Release()
 			}
 		}
 
@@ -308,41 +293,158 @@ func (t *differenceTransformation) Finish(id execute.DatasetID, err error) {
 	t.d.Finish(err)
 }
 
-func newDifference(col int, nonNegative bool) *difference {
+func newDifference(col int, nonNegative, initialZero, propagateNulls bool) *difference {
 	return &difference{
-		col:         col,
-		first:       true,
-		nonNegative: nonNegative,
+		col:            col,
+		nonNegative:    nonNegative,
+		initialZero:    initialZero,
+		propagateNulls: propagateNulls,
 	}
 }
 
 type difference struct {
-	col         int
-	first       bool
-	nonNegative bool
+	col            int
+	nonNegative    bool
+	initialZero    bool
+	propagateNulls bool
 
+	// valid reports whether there is a previous value to compute the difference from.
+	valid       bool
 	pIntValue   int64
 	pUIntValue  uint64
 	pFloatValue float64
 }
 
+// appendDifferences appends the differences of the column of cr onto the builder,
+// starting with the row at index from. The rows before from only update the previous value.
+func (d *difference) appendDifferences(cr flux.ColReader, builder execute.TableBuilder, from int) error {
+	j := d.col
+	l := cr.Len()
+	switch cr.Cols()[j].Type {
+	case flux.TInt:
+		vs := cr.Ints(j)
+		for i := 0; i < l; i++ {
+			if vs.IsNull(i) {
+				d.null()
+				if err := d.appendNil(builder, i >= from); err != nil {
+					return err
+				}
+				continue
+			}
+			diff, ok := d.updateInt(vs.Value(i))
+			if i < from {
+				continue
+			}
+			if !ok || d.nonNegativeNull(diff < 0) {
+				if err := builder.AppendNil(j); err != nil {
+					return err
+				}
+				continue
+			}
+			if diff < 0 && d.nonNegative {
+				// The value is a reset, its difference is computed from zero.
+				diff = vs.Value(i)
+			}
+			if err := builder.AppendInt(j, diff); err != nil {
+				return err
+			}
+		}
+	case flux.TUInt:
+		vs := cr.UInts(j)
+		for i := 0; i < l; i++ {
+			if vs.IsNull(i) {
+				d.null()
+				if err := d.appendNil(builder, i >= from); err != nil {
+					return err
+				}
+				continue
+			}
+			diff, ok := d.updateUInt(vs.Value(i))
+			if i < from {
+				continue
+			}
+			if !ok || d.nonNegativeNull(diff < 0) {
+				if err := builder.AppendNil(j); err != nil {
+					return err
+				}
+				continue
+			}
+			if diff < 0 && d.nonNegative {
+				// The value is a reset, its difference is computed from zero.
+				diff = int64(vs.Value(i))
+			}
+			if err := builder.AppendInt(j, diff); err != nil {
+				return err
+			}
+		}
+	case flux.TFloat:
+		vs := cr.Floats(j)
+		for i := 0; i < l; i++ {
+			if vs.IsNull(i) {
+				d.null()
+				if err := d.appendNil(builder, i >= from); err != nil {
+					return err
+				}
+				continue
+			}
+			diff, ok := d.updateFloat(vs.Value(i))
+			if i < from {
+				continue
+			}
+			if !ok || d.nonNegativeNull(diff < 0) {
+				if err := builder.AppendNil(j); err != nil {
+					return err
+				}
+				continue
+			}
+			if diff < 0 && d.nonNegative {
+				// The value is a reset, its difference is computed from zero.
+				diff = vs.Value(i)
+			}
+			if err := builder.AppendFloat(j, diff); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (d *difference) appendNil(builder execute.TableBuilder, ok bool) error {
+	if !ok {
+		return nil
+	}
+	return builder.AppendNil(d.col)
+}
+
+// nonNegativeNull reports whether a negative difference results in a null value.
+func (d *difference) nonNegativeNull(negative bool) bool {
+	return negative && d.nonNegative && !d.initialZero
+}
+
+// null records a null value, which discards the previous value if nulls are propagated.
+func (d *difference) null() {
+	if d.propagateNulls {
+		d.valid = false
+	}
+}
+
 func (d *difference) updateInt(v int64) (int64, bool) {
-	if d.first {
+	if !d.valid {
 		d.pIntValue = v
-		d.first = false
-		return 0, true
+		d.valid = true
+		return 0, false
 	}
 
 	diff := v - d.pIntValue
 	d.pIntValue = v
 
-	return diff, false
+	return diff, true
 }
 func (d *difference) updateUInt(v uint64) (int64, bool) {
-	if d.first {
+	if !d.valid {
 		d.pUIntValue = v
-		d.first = false
-		return 0, true
+		d.valid = true
+		return 0, false
 	}
 
 	var diff int64
@@ -355,17 +457,17 @@ func (d *difference) updateUInt(v uint64) (int64, bool) {
 
 	d.pUIntValue = v
 
-	return diff, false
+	return diff, true
 }
 func (d *difference) updateFloat(v float64) (float64, bool) {
-	if d.first {
+	if !d.valid {
 		d.pFloatValue = v
-		d.first = false
-		return math.NaN(), true
+		d.valid = true
+		return math.NaN(), false
 	}
 
 	diff := v - d.pFloatValue
 	d.pFloatValue = v
 
-	return diff, false
+	return diff, true
 }
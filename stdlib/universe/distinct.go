@@ -1,8 +1,11 @@
 package universe
 
 import (
+	"encoding/binary"
 	"fmt"
+	"math"
 
+	"github.com/influxdata/flux/interpreter"
 	"github.com/influxdata/flux/values"
 
 	"github.com/influxdata/flux"
@@ -14,13 +17,15 @@ import (
 const DistinctKind = "distinct"
 
 type DistinctOpSpec struct {
-	Column string `json:"column"`
+	Column  string   `json:"column"`
+	Columns []string `json:"columns,omitempty"`
 }
 
 func init() {
 	distinctSignature := flux.FunctionSignature(
 		map[string]semantic.PolyType{
-			"column": semantic.String,
+			"column":  semantic.String,
+			"columns": semantic.NewArrayPolyType(semantic.String),
 		},
 		nil,
 	)
@@ -38,6 +43,15 @@ func createDistinctOpSpec(args flux.Arguments, a *flux.Administration) (flux.Ope
 
 	spec := new(DistinctOpSpec)
 
+	columns, err := getDistinctColumns(args, DistinctKind)
+	if err != nil {
+		return nil, err
+	}
+	if columns != nil {
+		spec.Columns = columns
+		return spec, nil
+	}
+
 	if col, ok, err := args.GetString("column"); err != nil {
 		return nil, err
 	} else if ok {
@@ -49,6 +63,33 @@ func createDistinctOpSpec(args flux.Arguments, a *flux.Administration) (flux.Ope
 	return spec, nil
 }
 
+// getDistinctColumns reads the columns parameter of distinct() and unique(), which is exclusive with column.
+// It returns nil if the parameter is not given.
+func getDistinctColumns(args flux.Arguments, kind string) ([]string, error) {
+	array, ok, err := args.GetArray("columns", semantic.String)
+	if err != nil || !ok {
+		return nil, err
+	}
+	if _, ok := args.Get("column"); ok {
+		return nil, fmt.Errorf("%s requires at most one of column or columns", kind)
+	}
+	columns, err := interpreter.ToStringArray(array)
+	if err != nil {
+		return nil, err
+	}
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("%s requires at least one column", kind)
+	}
+	seen := make(map[string]bool, len(columns))
+	for _, label := range columns {
+		if seen[label] {
+			return nil, fmt.Errorf("%s column %q is listed more than once", kind, label)
+		}
+		seen[label] = true
+	}
+	return columns, nil
+}
+
 func newDistinctOp() flux.OperationSpec {
 	return new(DistinctOpSpec)
 }
@@ -60,6 +101,9 @@ func (s *DistinctOpSpec) Kind() flux.OperationKind {
 type DistinctProcedureSpec struct {
 	plan.DefaultCost
 	Column string
+	// Columns are the columns of which the distinct combinations of values are output
+	// under their own labels, instead of the distinct values of Column under `_value`.
+	Columns []string
 }
 
 func newDistinctProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
@@ -69,7 +113,8 @@ func newDistinctProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.P
 	}
 
 	return &DistinctProcedureSpec{
-		Column: spec.Column,
+		Column:  spec.Column,
+		Columns: spec.Columns,
 	}, nil
 }
 
@@ -80,6 +125,9 @@ func (s *DistinctProcedureSpec) Copy() plan.ProcedureSpec {
 	ns := new(DistinctProcedureSpec)
 
 	*ns = *s
+	if s.Columns != nil {
+		ns.Columns = append([]string(nil), s.Columns...)
+	}
 
 	return ns
 }
@@ -99,14 +147,16 @@ type distinctTransformation struct {
 	d     execute.Dataset
 	cache execute.TableBuilderCache
 
-	column string
+	column  string
+	columns []string
 }
 
 func NewDistinctTransformation(d execute.Dataset, cache execute.TableBuilderCache, spec *DistinctProcedureSpec) *distinctTransformation {
 	return &distinctTransformation{
-		d:      d,
-		cache:  cache,
-		column: spec.Column,
+		d:       d,
+		cache:   cache,
+		column:  spec.Column,
+		columns: spec.Columns,
 	}
 }
 
@@ -120,6 +170,10 @@ func (t *distinctTransformation) Process(id execute.DatasetID, tbl flux.Table) e
 		return fmt.Errorf("distinct found duplicate table with key: %v", tbl.Key())
 	}
 
+	if t.columns != nil {
+		return t.processColumns(tbl, builder)
+	}
+
 	colIdx := execute.ColIdx(t.column, tbl.Cols())
 	if colIdx < 0 {
 		// doesn't exist in this table, so add an empty value
@@ -346,6 +400,140 @@ func (t *distinctTransformation) Process(id execute.DatasetID, tbl flux.Table) e
 	})
 }
 
+// processColumns outputs the distinct combinations of the values of the columns, in the order in which they
+// first appear in the table. The output has the columns of the group key and the other columns that are listed.
+func (t *distinctTransformation) processColumns(tbl flux.Table, builder execute.TableBuilder) error {
+	cols, err := distinctColumnIndexes(t.columns, tbl.Cols(), DistinctKind)
+	if err != nil {
+		return err
+	}
+	if err := execute.AddTableKeyCols(tbl.Key(), builder); err != nil {
+		return err
+	}
+	// colMap maps the columns of the output that are not part of the group key to the columns of the table.
+	colMap := make(map[int]int, len(cols))
+	for _, j := range cols {
+		col := tbl.Cols()[j]
+		if tbl.Key().HasCol(col.Label) {
+			continue
+		}
+		k, err := builder.AddCol(col)
+		if err != nil {
+			return err
+		}
+		colMap[k] = j
+	}
+
+	set := newRowSet(cols)
+	return tbl.Do(func(cr flux.ColReader) error {
+		for i := 0; i < cr.Len(); i++ {
+			if !set.add(cr, i) {
+				continue
+			}
+			for k, j := range colMap {
+				if err := builder.AppendValue(k, execute.ValueForRow(cr, i, j)); err != nil {
+					return err
+				}
+			}
+			if err := execute.AppendKeyValues(tbl.Key(), builder); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// distinctColumnIndexes returns the indexes of the columns that distinct() or unique() compare in a table.
+func distinctColumnIndexes(columns []string, cols []flux.ColMeta, kind string) ([]int, error) {
+	idxs := make([]int, len(columns))
+	for i, label := range columns {
+		j := execute.ColIdx(label, cols)
+		if j < 0 {
+			return nil, fmt.Errorf("%s column %q does not exist", kind, label)
+		}
+		idxs[i] = j
+	}
+	return idxs, nil
+}
+
+// rowSet is a hash set of the combinations of values that rows have in a set of columns.
+// Rows are added as they are read, so the rows of a table do not need to be sorted or buffered.
+// Null values are equal to one another, and so are the values of a float column that compare equal.
+type rowSet struct {
+	cols []int
+	seen map[string]bool
+	buf  []byte
+}
+
+func newRowSet(cols []int) *rowSet {
+	return &rowSet{
+		cols: cols,
+		seen: make(map[string]bool),
+	}
+}
+
+// add adds the combination of values of a row to the set, and reports whether the combination is new.
+func (s *rowSet) add(cr flux.ColReader, i int) bool {
+	s.buf = s.buf[:0]
+	for _, j := range s.cols {
+		s.buf = appendRowSetValue(s.buf, cr, i, j)
+	}
+	if s.seen[string(s.buf)] {
+		return false
+	}
+	s.seen[string(s.buf)] = true
+	return true
+}
+
+// appendRowSetValue appends the encoding of a value to the key of a combination.
+// The type of a column is the same for every row, so values are only tagged as null or not.
+func appendRowSetValue(buf []byte, cr flux.ColReader, i, j int) []byte {
+	var scratch [binary.MaxVarintLen64]byte
+	switch typ := cr.Cols()[j].Type; typ {
+	case flux.TBool:
+		if vs := cr.Bools(j); vs.IsValid(i) {
+			if vs.Value(i) {
+				return append(buf, 1, 1)
+			}
+			return append(buf, 1, 0)
+		}
+	case flux.TInt:
+		if vs := cr.Ints(j); vs.IsValid(i) {
+			binary.LittleEndian.PutUint64(scratch[:8], uint64(vs.Value(i)))
+			return append(append(buf, 1), scratch[:8]...)
+		}
+	case flux.TUInt:
+		if vs := cr.UInts(j); vs.IsValid(i) {
+			binary.LittleEndian.PutUint64(scratch[:8], vs.Value(i))
+			return append(append(buf, 1), scratch[:8]...)
+		}
+	case flux.TFloat:
+		if vs := cr.Floats(j); vs.IsValid(i) {
+			v := vs.Value(i)
+			if v == 0 {
+				// -0 equals 0.
+				v = 0
+			}
+			binary.LittleEndian.PutUint64(scratch[:8], math.Float64bits(v))
+			return append(append(buf, 1), scratch[:8]...)
+		}
+	case flux.TString:
+		if vs := cr.Strings(j); vs.IsValid(i) {
+			v := vs.Value(i)
+			n := binary.PutUvarint(scratch[:], uint64(len(v)))
+			return append(append(append(buf, 1), scratch[:n]...), v...)
+		}
+	case flux.TTime:
+		if vs := cr.Times(j); vs.IsValid(i) {
+			binary.LittleEndian.PutUint64(scratch[:8], uint64(vs.Value(i)))
+			return append(append(buf, 1), scratch[:8]...)
+		}
+	default:
+		execute.PanicUnknownType(typ)
+	}
+	return append(buf, 0)
+}
+
 func (t *distinctTransformation) UpdateWatermark(id execute.DatasetID, mark execute.Time) error {
 	return t.d.UpdateWatermark(mark)
 }
@@ -1,6 +1,7 @@
 package universe_test
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/influxdata/flux"
@@ -11,10 +12,11 @@ import (
 
 func TestDistinct_Process(t *testing.T) {
 	testCases := []struct {
-		name string
-		spec *universe.DistinctProcedureSpec
-		data []flux.Table
-		want []*executetest.Table
+		name    string
+		spec    *universe.DistinctProcedureSpec
+		data    []flux.Table
+		want    []*executetest.Table
+		wantErr error
 	}{
 		{
 			name: "no group key",
@@ -204,6 +206,104 @@ func TestDistinct_Process(t *testing.T) {
 				},
 			}},
 		},
+		{
+			name: "columns outside group key",
+			spec: &universe.DistinctProcedureSpec{Columns: []string{"tag1", "tag2"}},
+			data: []flux.Table{
+				&executetest.Table{
+					KeyCols: []string{"tag0"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_value", Type: flux.TFloat},
+						{Label: "tag0", Type: flux.TString},
+						{Label: "tag1", Type: flux.TString},
+						{Label: "tag2", Type: flux.TString},
+					},
+					Data: [][]interface{}{
+						{execute.Time(1), 2.0, "a", "b", "x"},
+						{execute.Time(2), 2.0, "a", "c", "x"},
+						{execute.Time(3), 2.0, "a", "b", "x"},
+						{execute.Time(4), 2.0, "a", "b", "y"},
+						{execute.Time(5), 2.0, "a", nil, "y"},
+						{execute.Time(6), 2.0, "a", nil, "y"},
+					},
+				},
+			},
+			want: []*executetest.Table{{
+				KeyCols: []string{"tag0"},
+				ColMeta: []flux.ColMeta{
+					{Label: "tag0", Type: flux.TString},
+					{Label: "tag1", Type: flux.TString},
+					{Label: "tag2", Type: flux.TString},
+				},
+				Data: [][]interface{}{
+					{"a", "b", "x"},
+					{"a", "c", "x"},
+					{"a", "b", "y"},
+					{"a", nil, "y"},
+				},
+			}},
+		},
+		{
+			name: "columns inside group key",
+			spec: &universe.DistinctProcedureSpec{Columns: []string{"tag2", "tag0"}},
+			data: []flux.Table{
+				&executetest.Table{
+					KeyCols: []string{"tag0"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_value", Type: flux.TFloat},
+						{Label: "tag0", Type: flux.TString},
+						{Label: "tag1", Type: flux.TString},
+						{Label: "tag2", Type: flux.TString},
+					},
+					Data: [][]interface{}{
+						{execute.Time(1), 2.0, "a", "b", "x"},
+						{execute.Time(2), 2.0, "a", "c", "x"},
+						{execute.Time(3), 2.0, "a", "b", "x"},
+						{execute.Time(4), 2.0, "a", "b", "y"},
+						{execute.Time(5), 2.0, "a", nil, "y"},
+						{execute.Time(6), 2.0, "a", nil, "y"},
+					},
+				},
+			},
+			want: []*executetest.Table{{
+				KeyCols: []string{"tag0"},
+				ColMeta: []flux.ColMeta{
+					{Label: "tag0", Type: flux.TString},
+					{Label: "tag2", Type: flux.TString},
+				},
+				Data: [][]interface{}{
+					{"a", "x"},
+					{"a", "y"},
+				},
+			}},
+		},
+		{
+			name: "missing column",
+			spec: &universe.DistinctProcedureSpec{Columns: []string{"tag1", "tag3"}},
+			data: []flux.Table{
+				&executetest.Table{
+					KeyCols: []string{"tag0"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_value", Type: flux.TFloat},
+						{Label: "tag0", Type: flux.TString},
+						{Label: "tag1", Type: flux.TString},
+						{Label: "tag2", Type: flux.TString},
+					},
+					Data: [][]interface{}{
+						{execute.Time(1), 2.0, "a", "b", "x"},
+						{execute.Time(2), 2.0, "a", "c", "x"},
+						{execute.Time(3), 2.0, "a", "b", "x"},
+						{execute.Time(4), 2.0, "a", "b", "y"},
+						{execute.Time(5), 2.0, "a", nil, "y"},
+						{execute.Time(6), 2.0, "a", nil, "y"},
+					},
+				},
+			},
+			wantErr: errors.New(`distinct column "tag3" does not exist`),
+		},
 	}
 	for _, tc := range testCases {
 		tc := tc
@@ -212,7 +312,7 @@ func TestDistinct_Process(t *testing.T) {
 				t,
 				tc.data,
 				tc.want,
-				nil,
+				tc.wantErr,
 				func(d execute.Dataset, c execute.TableBuilderCache) execute.Transformation {
 					return universe.NewDistinctTransformation(d, c, tc.spec)
 				},
@@ -2,13 +2,15 @@ package universe
 
 import (
 	"fmt"
-	"log"
 
 	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/dependencies"
+	fluxerrors "github.com/influxdata/flux/errors"
 	"github.com/influxdata/flux/execute"
 	"github.com/influxdata/flux/interpreter"
 	"github.com/influxdata/flux/plan"
 	"github.com/influxdata/flux/semantic"
+	"go.uber.org/zap"
 )
 
 const FilterKind = "filter"
@@ -91,37 +93,57 @@ func (s *FilterProcedureSpec) Copy() plan.ProcedureSpec {
 	return ns
 }
 
+// SortedBy implements plan.OrderedProcedureSpec, filter keeps the order of the rows of its input.
+func (s *FilterProcedureSpec) SortedBy(predecessors [][]string) []string {
+	return predecessors[0]
+}
+
+// OutputSchema implements plan.SchemaProcedureSpec, filter keeps the columns of its input.
+func (s *FilterProcedureSpec) OutputSchema(predecessors []*plan.Schema) *plan.Schema {
+	return predecessors[0]
+}
+
 func createFilterTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
 	s, ok := spec.(*FilterProcedureSpec)
 	if !ok {
 		return nil, nil, fmt.Errorf("invalid spec type %T", spec)
 	}
-	cache := execute.NewTableBuilderCache(a.Allocator())
+	cache := execute.NewSharedTableCache(a.Allocator())
 	d := execute.NewDataset(id, mode, cache)
 	t, err := NewFilterTransformation(d, cache, s)
 	if err != nil {
 		return nil, nil, err
 	}
+	t.logger = dependencies.Get(a.Context()).Logger
 	return t, d, nil
 }
 
+// filterTransformation shares the arrays of the rows that pass the predicate with its input tables.
 type filterTransformation struct {
 	d     execute.Dataset
-	cache execute.TableBuilderCache
+	cache *execute.SharedTableCache
 
 	fn *execute.RowPredicateFn
+	// vp evaluates the predicate on whole columns, it is nil if the predicate cannot be vectorized.
+	vp *execute.VectorPredicate
+
+	// logger logs the rows the function fails to evaluate.
+	logger *zap.Logger
 }
 
-func NewFilterTransformation(d execute.Dataset, cache execute.TableBuilderCache, spec *FilterProcedureSpec) (*filterTransformation, error) {
+func NewFilterTransformation(d execute.Dataset, cache *execute.SharedTableCache, spec *FilterProcedureSpec) (*filterTransformation, error) {
 	fn, err := execute.NewRowPredicateFn(spec.Fn)
 	if err != nil {
 		return nil, err
 	}
+	vp, _ := execute.NewVectorPredicate(spec.Fn)
 
 	return &filterTransformation{
-		d:     d,
-		cache: cache,
-		fn:    fn,
+		d:      d,
+		cache:  cache,
+		fn:     fn,
+		vp:     vp,
+		logger: zap.NewNop(),
 	}, nil
 }
 
@@ -134,8 +156,10 @@ func (t *filterTransformation) Process(id execute.DatasetID, tbl flux.Table) err
 	if !created {
 		return fmt.Errorf("filter found duplicate table with key: %v", tbl.Key())
 	}
-	if err := execute.AddTableCols(tbl, builder); err != nil {
-		return err
+	for _, c := range tbl.Cols() {
+		if _, err := builder.AddCol(c); err != nil {
+			return err
+		}
 	}
 
 	// Prepare the function for the column types.
@@ -146,21 +170,33 @@ func (t *filterTransformation) Process(id execute.DatasetID, tbl flux.Table) err
 	}
 
 	// Append only matching rows to table
+	var selected []bool
 	return tbl.Do(func(cr flux.ColReader) error {
+		// Compute the selection on the arrays of the columns, and let the downstream
+		// operators copy the selected rows out of the columns they read.
+		if t.vp != nil {
+			if sel, ok := t.vp.Select(cr); ok {
+				return builder.AppendSelection(cr, nil, sel)
+			}
+		}
 		l := cr.Len()
+		if cap(selected) < l {
+			selected = make([]bool, l)
+		}
+		selected = selected[:l]
+		if t.fn.EvalDictionary(cr, selected) {
+			return builder.AppendSelected(cr, nil, selected)
+		}
 		for i := 0; i < l; i++ {
-			if pass, err := t.fn.Eval(i, cr); err != nil {
-				log.Printf("failed to evaluate filter expression: %v", err)
-				continue
-			} else if !pass {
-				// No match, skipping
-				continue
-			}
-			if err := execute.AppendRecord(i, cr, builder); err != nil {
+			pass, err := t.fn.Eval(i, cr)
+			if fluxerrors.CodeOf(err) == fluxerrors.Aborted {
 				return err
+			} else if err != nil {
+				t.logger.Debug("failed to evaluate filter expression", zap.Error(err))
 			}
+			selected[i] = err == nil && pass
 		}
-		return nil
+		return builder.AppendSelected(cr, nil, selected)
 	})
 }
 
@@ -614,87 +614,44 @@ func TestMergeFilterAnyRule(t *testing.T) {
 			},
 		}
 	)
+	specs := plantest.Specs{
+		"from":        from,
+		"count":       count,
+		"filterOther": filterOther,
+		"filterTrue":  filterTrue,
+		"filterFalse": filterFalse,
+	}
 
 	tests := []plantest.RuleTestCase{
 		{
-			Name: "filterOther",
-			// from -> filter => from -> filter
-			Rules: []plan.Rule{universe.RemoveTrivialFilterRule{}},
-			Before: &plantest.PlanSpec{
-				Nodes: []plan.PlanNode{
-					plan.CreatePhysicalNode("from", from),
-					plan.CreatePhysicalNode("filter", filterOther),
-				},
-				Edges: [][2]int{{0, 1}},
-			},
+			Name:     "filterOther",
+			Rules:    []plan.Rule{universe.RemoveTrivialFilterRule{}},
+			Before:   plantest.MustParsePlan("physical; from = from -> filter = filterOther", specs),
 			NoChange: true,
 		},
 		{
-			Name: "filterFalse",
-			// from -> filter => from -> filter
-			Rules: []plan.Rule{universe.RemoveTrivialFilterRule{}},
-			Before: &plantest.PlanSpec{
-				Nodes: []plan.PlanNode{
-					plan.CreatePhysicalNode("from", from),
-					plan.CreatePhysicalNode("filter", filterFalse),
-				},
-				Edges: [][2]int{{0, 1}},
-			},
+			Name:     "filterFalse",
+			Rules:    []plan.Rule{universe.RemoveTrivialFilterRule{}},
+			Before:   plantest.MustParsePlan("physical; from = from -> filter = filterFalse", specs),
 			NoChange: true,
 		},
 		{
-			Name: "filterTrue",
-			// from -> filter => from
-			Rules: []plan.Rule{universe.RemoveTrivialFilterRule{}},
-			Before: &plantest.PlanSpec{
-				Nodes: []plan.PlanNode{
-					plan.CreatePhysicalNode("from", from),
-					plan.CreatePhysicalNode("filter", filterTrue),
-				},
-				Edges: [][2]int{{0, 1}},
-			},
-			After: &plantest.PlanSpec{
-				Nodes: []plan.PlanNode{
-					plan.CreatePhysicalNode("from", from),
-				},
-			},
+			Name:   "filterTrue",
+			Rules:  []plan.Rule{universe.RemoveTrivialFilterRule{}},
+			Before: plantest.MustParsePlan("physical; from = from -> filter = filterTrue", specs),
+			After:  plantest.MustParsePlan("physical; from = from", specs),
 		},
 		{
-			Name: "count filterTrue",
-			// count -> filter => count
-			Rules: []plan.Rule{universe.RemoveTrivialFilterRule{}},
-			Before: &plantest.PlanSpec{
-				Nodes: []plan.PlanNode{
-					plan.CreatePhysicalNode("count", count),
-					plan.CreatePhysicalNode("filter", filterTrue),
-				},
-				Edges: [][2]int{{0, 1}},
-			},
-			After: &plantest.PlanSpec{
-				Nodes: []plan.PlanNode{
-					plan.CreatePhysicalNode("count", count),
-				},
-			},
+			Name:   "count filterTrue",
+			Rules:  []plan.Rule{universe.RemoveTrivialFilterRule{}},
+			Before: plantest.MustParsePlan("physical; count = count -> filter = filterTrue", specs),
+			After:  plantest.MustParsePlan("physical; count = count", specs),
 		},
 		{
-			Name: "from filterTrue count",
-			// from -> filter -> count => from -> count
-			Rules: []plan.Rule{universe.RemoveTrivialFilterRule{}},
-			Before: &plantest.PlanSpec{
-				Nodes: []plan.PlanNode{
-					plan.CreatePhysicalNode("from", from),
-					plan.CreatePhysicalNode("filter", filterTrue),
-					plan.CreatePhysicalNode("count", count),
-				},
-				Edges: [][2]int{{0, 1}, {1, 2}},
-			},
-			After: &plantest.PlanSpec{
-				Nodes: []plan.PlanNode{
-					plan.CreatePhysicalNode("from", from),
-					plan.CreatePhysicalNode("count", count),
-				},
-				Edges: [][2]int{{0, 1}},
-			},
+			Name:   "from filterTrue count",
+			Rules:  []plan.Rule{universe.RemoveTrivialFilterRule{}},
+			Before: plantest.MustParsePlan("physical; from = from -> filter = filterTrue -> count = count", specs),
+			After:  plantest.MustParsePlan("physical; from = from -> count = count", specs),
 		},
 	}
 
@@ -943,16 +900,58 @@ func TestFilter_Process(t *testing.T) {
 				},
 			}},
 		},
+		{
+			name: `5.5<_value int`,
+			spec: &universe.FilterProcedureSpec{
+				Fn: &semantic.FunctionExpression{
+					Block: &semantic.FunctionBlock{
+						Parameters: &semantic.FunctionParameters{
+							List: []*semantic.FunctionParameter{{Key: &semantic.Identifier{Name: "r"}}},
+						},
+						Body: &semantic.BinaryExpression{
+							Operator: ast.LessThanOperator,
+							Left:     &semantic.FloatLiteral{Value: 5.5},
+							Right: &semantic.MemberExpression{
+								Object:   &semantic.IdentifierExpression{Name: "r"},
+								Property: "_value",
+							},
+						},
+					},
+				},
+			},
+			data: []flux.Table{&executetest.Table{
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_value", Type: flux.TInt},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), int64(5)},
+					{execute.Time(2), int64(6)},
+					{execute.Time(3), nil},
+					{execute.Time(4), int64(7)},
+				},
+			}},
+			want: []*executetest.Table{{
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_value", Type: flux.TInt},
+				},
+				Data: [][]interface{}{
+					{execute.Time(2), int64(6)},
+					{execute.Time(4), int64(7)},
+				},
+			}},
+		},
 	}
 	for _, tc := range testCases {
 		tc := tc
 		t.Run(tc.name, func(t *testing.T) {
-			executetest.ProcessTestHelper(
+			executetest.SharedProcessTestHelper(
 				t,
 				tc.data,
 				tc.want,
 				nil,
-				func(d execute.Dataset, c execute.TableBuilderCache) execute.Transformation {
+				func(d execute.Dataset, c *execute.SharedTableCache) execute.Transformation {
 					f, err := universe.NewFilterTransformation(d, c, tc.spec)
 					if err != nil {
 						t.Fatal(err)
@@ -963,3 +962,65 @@ func TestFilter_Process(t *testing.T) {
 		})
 	}
 }
+
+func TestFilter_Dictionary(t *testing.T) {
+	// Copying the table dictionary-encodes its repeated tags,
+	// so the predicate is evaluated once per distinct host.
+	tbl, err := execute.CopyTable(&executetest.Table{
+		ColMeta: []flux.ColMeta{
+			{Label: "_time", Type: flux.TTime},
+			{Label: "host", Type: flux.TString},
+		},
+		Data: [][]interface{}{
+			{execute.Time(1), "server01"},
+			{execute.Time(2), "server02"},
+			{execute.Time(3), nil},
+			{execute.Time(4), "server01"},
+			{execute.Time(5), "server02"},
+			{execute.Time(6), "server01"},
+		},
+	}, executetest.UnlimitedAllocator)
+	if err != nil {
+		t.Fatal(err)
+	}
+	spec := &universe.FilterProcedureSpec{
+		Fn: &semantic.FunctionExpression{
+			Block: &semantic.FunctionBlock{
+				Parameters: &semantic.FunctionParameters{
+					List: []*semantic.FunctionParameter{{Key: &semantic.Identifier{Name: "r"}}},
+				},
+				Body: &semantic.BinaryExpression{
+					Operator: ast.EqualOperator,
+					Left: &semantic.MemberExpression{
+						Object:   &semantic.IdentifierExpression{Name: "r"},
+						Property: "host",
+					},
+					Right: &semantic.StringLiteral{Value: "server01"},
+				},
+			},
+		},
+	}
+	executetest.SharedProcessTestHelper(
+		t,
+		[]flux.Table{tbl},
+		[]*executetest.Table{{
+			ColMeta: []flux.ColMeta{
+				{Label: "_time", Type: flux.TTime},
+				{Label: "host", Type: flux.TString},
+			},
+			Data: [][]interface{}{
+				{execute.Time(1), "server01"},
+				{execute.Time(4), "server01"},
+				{execute.Time(6), "server01"},
+			},
+		}},
+		nil,
+		func(d execute.Dataset, c *execute.SharedTableCache) execute.Transformation {
+			f, err := universe.NewFilterTransformation(d, c, spec)
+			if err != nil {
+				t.Fatal(err)
+			}
+			return f
+		},
+	)
+}
@@ -70,6 +70,11 @@ func (s *FirstProcedureSpec) Copy() plan.ProcedureSpec {
 	return ns
 }
 
+// SortedBy implements plan.OrderedProcedureSpec, first selects rows in the order of its input.
+func (s *FirstProcedureSpec) SortedBy(predecessors [][]string) []string {
+	return predecessors[0]
+}
+
 type FirstSelector struct {
 	selected bool
 }
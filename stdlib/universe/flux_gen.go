@@ -5,10 +5,12 @@ package universe
 import (
 	flux "github.com/influxdata/flux"
 	ast "github.com/influxdata/flux/ast"
+	docs "github.com/influxdata/flux/docs"
 )
 
 func init() {
 	flux.RegisterPackage(pkgAST)
+	docs.RegisterComments("universe", pkgComments)
 }
 
 var pkgAST = &ast.Package{
@@ -22,10 +24,10 @@ var pkgAST = &ast.Package{
 			Loc: &ast.SourceLocation{
 				End: ast.Position{
 					Column: 74,
-					Line:   243,
+					Line:   270,
 				},
 				File:   "universe.flux",
-				Source: "package universe\n\nimport \"system\"\n\n// now is a function option whose default behaviour is to return the current system time\noption now = system.time\n\n// Booleans\nbuiltin true\nbuiltin false\n\n// Transformation functions\nbuiltin columns\nbuiltin count\nbuiltin covariance\nbuiltin cumulativeSum\nbuiltin derivative\nbuiltin difference\nbuiltin distinct\nbuiltin drop\nbuiltin duplicate\nbuiltin fill\nbuiltin filter\nbuiltin first\nbuiltin group\nbuiltin histogram\nbuiltin histogramQuantile\nbuiltin integral\nbuiltin join\nbuiltin keep\nbuiltin keyValues\nbuiltin keys\nbuiltin last\nbuiltin limit\nbuiltin map\nbuiltin max\nbuiltin mean\nbuiltin min\nbuiltin percentile\nbuiltin pivot\nbuiltin range\nbuiltin rename\nbuiltin sample\nbuiltin set\nbuiltin shift\nbuiltin skew\nbuiltin spread\nbuiltin sort\nbuiltin stateTracking\nbuiltin stddev\nbuiltin sum\nbuiltin union\nbuiltin unique\nbuiltin window\nbuiltin yield\n\n\n// type conversion functions\nbuiltin bool\nbuiltin duration\nbuiltin float\nbuiltin int\nbuiltin string\nbuiltin time\nbuiltin uint\n\n// contains function\nbuiltin contains\n\n// other builtins\nbuiltin inf\nbuiltin linearBins\nbuiltin logarithmicBins\n\n// covariance function with automatic join\ncov = (x,y,on,pearsonr=false) =>\n    join(\n        tables:{x:x, y:y},\n        on:on,\n    )\n    |> covariance(pearsonr:pearsonr, columns:[\"_value_x\",\"_value_y\"])\n\npearsonr = (x,y,on) => cov(x:x, y:y, on:on, pearsonr:true)\n\n// AggregateWindow applies an aggregate function to fixed windows of time.\n// The procedure is to window the data, perform an aggregate operation,\n// and then undo the windowing to produce an output table for every input table.\naggregateWindow = (every, fn, columns=[\"_value\"], timeSrc=\"_stop\",timeDst=\"_time\", createEmpty=true, tables=<-) =>\n    tables\n        |> window(every:every, createEmpty: createEmpty)\n        |> fn(columns:columns)\n        |> duplicate(column:timeSrc,as:timeDst)\n        |> window(every:inf, timeColumn:timeDst)\n\n// Increase returns the total non-negative difference between values in a table.\n// A main usage case is tracking changes in counter values which may wrap over time when they hit\n// a threshold or are reset. In the case of a wrap/reset,\n// we can assume that the absolute delta between two points will be at least their non-negative difference.\nincrease = (tables=<-, columns=[\"_value\"]) =>\n    tables\n        |> difference(nonNegative: true, columns:columns)\n        |> cumulativeSum(columns: columns)\n\n// median returns the 50th percentile.\n// By default an approximate percentile is computed, this can be disabled by passing exact:true.\n// Using the exact method requires that the entire data set can fit in memory.\nmedian = (method=\"estimate_tdigest\", compression=0.0, tables=<-) =>\n    tables\n        |> percentile(percentile:0.5, method:method, compression:compression)\n\n// stateCount computes the number of consecutive records in a given state.\n// The state is defined via the function fn. For each consecutive point for\n// which the expression evaluates as true, the state count will be incremented\n// When a point evaluates as false, the state count is reset.\n//\n// The state count will be added as an additional column to each record. If the\n// expression evaluates as false, the value will be -1. If the expression\n// generates an error during evaluation, the point is discarded, and does not\n// affect the state count.\nstateCount = (fn, column=\"stateCount\", tables=<-) =>\n    tables\n        |> stateTracking(countColumn:column, fn:fn)\n\n// stateDuration computes the duration of a given state.\n// The state is defined via the function fn. For each consecutive point for\n// which the expression evaluates as true, the state duration will be\n// incremented by the duration between points. When a point evaluates as false,\n// the state duration is reset.\n//\n// The state duration will be added as an additional column to each record. If the\n// expression evaluates as false, the value will be -1. If the expression\n// generates an error during evaluation, the point is discarded, and does not\n// affect the state duration.\n//\n// Note that as the first point in the given state has no previous point, its\n// state duration will be 0.\n//\n// The duration is represented as an integer in the units specified.\nstateDuration = (fn, column=\"stateDuration\", timeColumn=\"_time\", unit=1s, tables=<-) =>\n    tables\n        |> stateTracking(durationColumn:column, timeColumn:timeColumn, fn:fn, durationUnit:unit)\n\n// _sortLimit is a helper function, which sorts and limits a table.\n_sortLimit = (n, desc, columns=[\"_value\"], tables=<-) =>\n    tables\n        |> sort(columns:columns, desc:desc)\n        |> limit(n:n)\n\n// top sorts a table by columns and keeps only the top n records.\ntop = (n, columns=[\"_value\"], tables=<-) =>\n    tables\n        |> _sortLimit(n:n, columns:columns, desc:true)\n\n// top sorts a table by columns and keeps only the bottom n records.\nbottom = (n, columns=[\"_value\"], tables=<-) =>\n    tables\n        |> _sortLimit(n:n, columns:columns, desc:false)\n\n// _highestOrLowest is a helper function, which reduces all groups into a single group by specific tags and a reducer function,\n// then it selects the highest or lowest records based on the columns and the _sortLimit function.\n// The default reducer assumes no reducing needs to be performed.\n_highestOrLowest = (n, _sortLimit, reducer, columns=[\"_value\"], groupColumns=[], tables=<-) =>\n    tables\n        |> group(columns:groupColumns)\n        |> reducer()\n        |> group(columns:[])\n        |> _sortLimit(n:n, columns:columns)\n\n// highestMax returns the top N records from all groups using the maximum of each group.\nhighestMax = (n, columns=[\"_value\"], groupColumns=[], tables=<-) =>\n    tables\n        |> _highestOrLowest(\n                n:n,\n                columns:columns,\n                groupColumns:groupColumns,\n                // TODO(nathanielc): Once max/min support selecting based on multiple columns change this to pass all columns.\n                reducer: (tables=<-) => tables |> max(column:columns[0]),\n                _sortLimit: top,\n            )\n\n// highestAverage returns the top N records from all groups using the average of each group.\nhighestAverage = (n, columns=[\"_value\"], groupColumns=[], tables=<-) =>\n    tables\n        |> _highestOrLowest(\n                n:n,\n                columns:columns,\n                groupColumns:groupColumns,\n                reducer: (tables=<-) => tables |> mean(columns:[columns[0]]),\n                _sortLimit: top,\n            )\n\n// highestCurrent returns the top N records from all groups using the last value of each group.\nhighestCurrent = (n, columns=[\"_value\"], groupColumns=[], tables=<-) =>\n    tables\n        |> _highestOrLowest(\n                n:n,\n                columns:columns,\n                groupColumns:groupColumns,\n                reducer: (tables=<-) => tables |> last(column:columns[0]),\n                _sortLimit: top,\n            )\n\n// lowestMin returns the bottom N records from all groups using the minimum of each group.\nlowestMin = (n, columns=[\"_value\"], groupColumns=[], tables=<-) =>\n    tables\n        |> _highestOrLowest(\n                n:n,\n                columns:columns,\n                groupColumns:groupColumns,\n                // TODO(nathanielc): Once max/min support selecting based on multiple columns change this to pass all columns.\n                reducer: (tables=<-) => tables |> min(column:columns[0]),\n                _sortLimit: bottom,\n            )\n\n// lowestAverage returns the bottom N records from all groups using the average of each group.\nlowestAverage = (n, columns=[\"_value\"], groupColumns=[], tables=<-) =>\n    tables\n        |> _highestOrLowest(\n                n:n,\n                columns:columns,\n                groupColumns:groupColumns,\n                reducer: (tables=<-) => tables |> mean(columns:[columns[0]]),\n                _sortLimit: bottom,\n            )\n\n// lowestCurrent returns the bottom N records from all groups using the last value of each group.\nlowestCurrent = (n, columns=[\"_value\"], groupColumns=[], tables=<-) =>\n    tables\n        |> _highestOrLowest(\n                n:n,\n                columns:columns,\n                groupColumns:groupColumns,\n                reducer: (tables=<-) => tables |> last(column:columns[0]),\n                _sortLimit: bottom,\n            )\n\ntoString = (tables=<-) => tables |> map(fn:(r) => string(v:r._value))\ntoInt = (tables=<-) => tables |> map(fn:(r) => int(v:r._value))\ntoUInt = (tables=<-) => tables |> map(fn:(r) => uint(v:r._value))\ntoFloat = (tables=<-) => tables |> map(fn:(r) => float(v:r._value))\ntoBool = (tables=<-) => tables |> map(fn:(r) => bool(v:r._value))\ntoTime = (tables=<-) => tables |> map(fn:(r) => time(v:r._value))\ntoDuration = (tables=<-) => tables |> map(fn:(r) => duration(v:r._value))",
+				Source: "package universe\n\nimport \"system\"\n\n// now is a function option whose default behaviour is to return the current system time\noption now = system.time\n\n// Booleans\nbuiltin true\nbuiltin false\n\n// Transformation functions\nbuiltin approxDistinct\nbuiltin asofJoin\nbuiltin columns\nbuiltin columnTypes\nbuiltin count\nbuiltin covariance\nbuiltin cumulativeSum\nbuiltin derivative\nbuiltin difference\nbuiltin distinct\nbuiltin drop\nbuiltin duplicate\nbuiltin fill\nbuiltin filter\nbuiltin first\nbuiltin group\nbuiltin histogram\nbuiltin histogramQuantile\nbuiltin holtWinters\nbuiltin integral\nbuiltin intervalJoin\nbuiltin join\nbuiltin keep\nbuiltin keyValues\nbuiltin keys\nbuiltin last\nbuiltin limit\nbuiltin map\nbuiltin mapColumns\nbuiltin max\nbuiltin mean\nbuiltin melt\nbuiltin min\nbuiltin movingAverage\nbuiltin over\nbuiltin percentile\nbuiltin pivot\nbuiltin randomSample\nbuiltin range\nbuiltin rename\nbuiltin renameAll\nbuiltin sample\nbuiltin set\nbuiltin shift\nbuiltin skew\nbuiltin spread\nbuiltin sort\nbuiltin stateTracking\nbuiltin stddev\nbuiltin sum\nbuiltin tableCount\nbuiltin union\nbuiltin unique\nbuiltin unpivot\nbuiltin window\nbuiltin yield\n\n\n// type conversion functions\nbuiltin bool\nbuiltin duration\nbuiltin float\nbuiltin int\nbuiltin string\nbuiltin time\nbuiltin uint\n\n// contains function\nbuiltin contains\n\n// error control functions\nbuiltin die\nbuiltin assert\n\n// table extraction functions\nbuiltin tableFind\nbuiltin getColumn\nbuiltin getRecord\n\n// other builtins\nbuiltin inf\nbuiltin linearBins\nbuiltin logarithmicBins\n\n// covariance function with automatic join\ncov = (x,y,on,pearsonr=false) =>\n    join(\n        tables:{x:x, y:y},\n        on:on,\n    )\n    |> covariance(pearsonr:pearsonr, columns:[\"_value_x\",\"_value_y\"])\n\npearsonr = (x,y,on) => cov(x:x, y:y, on:on, pearsonr:true)\n\n// AggregateWindow applies an aggregate function to fixed windows of time.\n// The procedure is to window the data, perform an aggregate operation,\n// and then undo the windowing to produce an output table for every input table.\naggregateWindow = (every, fn, columns=[\"_value\"], timeSrc=\"_stop\",timeDst=\"_time\", createEmpty=true, tables=<-) =>\n    tables\n        |> window(every:every, createEmpty: createEmpty)\n        |> fn(columns:columns)\n        |> duplicate(column:timeSrc,as:timeDst)\n        |> window(every:inf, timeColumn:timeDst)\n\n// Increase returns the total non-negative difference between values in a table.\n// A main usage case is tracking changes in counter values which may wrap over time when they hit\n// a threshold or are reset. In the case of a wrap/reset,\n// we can assume that the absolute delta between two points will be at least their non-negative difference.\nincrease = (tables=<-, columns=[\"_value\"]) =>\n    tables\n        |> difference(nonNegative: true, columns:columns)\n        |> cumulativeSum(columns: columns)\n\n// exponentialMovingAverage computes the exponential moving average of the last n values of a column.\nexponentialMovingAverage = (n, column=\"_value\", tables=<-) =>\n    tables\n        |> movingAverage(n:n, kernel:\"exponential\", column:column)\n\n// median returns the 50th percentile.\n// By default an approximate percentile is computed, this can be disabled by passing exact:true.\n// Using the exact method requires that the entire data set can fit in memory.\nmedian = (method=\"estimate_tdigest\", compression=0.0, tables=<-) =>\n    tables\n        |> percentile(percentile:0.5, method:method, compression:compression)\n\n// stateCount computes the number of consecutive records in a given state.\n// The state is defined via the function fn. For each consecutive point for\n// which the expression evaluates as true, the state count will be incremented\n// When a point evaluates as false, the state count is reset.\n//\n// The state count will be added as an additional column to each record. If the\n// expression evaluates as false, the value will be -1. If the expression\n// generates an error during evaluation, the point is discarded, and does not\n// affect the state count.\nstateCount = (fn, column=\"stateCount\", tables=<-) =>\n    tables\n        |> stateTracking(countColumn:column, fn:fn)\n\n// stateDuration computes the duration of a given state.\n// The state is defined via the function fn. For each consecutive point for\n// which the expression evaluates as true, the state duration will be\n// incremented by the duration between points. When a point evaluates as false,\n// the state duration is reset.\n//\n// The state duration will be added as an additional column to each record. If the\n// expression evaluates as false, the value will be -1. If the expression\n// generates an error during evaluation, the point is discarded, and does not\n// affect the state duration.\n//\n// Note that as the first point in the given state has no previous point, its\n// state duration will be 0.\n//\n// The duration is represented as an integer in the units specified.\nstateDuration = (fn, column=\"stateDuration\", timeColumn=\"_time\", unit=1s, tables=<-) =>\n    tables\n        |> stateTracking(durationColumn:column, timeColumn:timeColumn, fn:fn, durationUnit:unit)\n\n// _sortLimit is a helper function, which sorts and limits a table.\n_sortLimit = (n, desc, columns=[\"_value\"], tables=<-) =>\n    tables\n        |> sort(columns:columns, desc:desc)\n        |> limit(n:n)\n\n// top sorts a table by columns and keeps only the top n records.\ntop = (n, columns=[\"_value\"], tables=<-) =>\n    tables\n        |> _sortLimit(n:n, columns:columns, desc:true)\n\n// top sorts a table by columns and keeps only the bottom n records.\nbottom = (n, columns=[\"_value\"], tables=<-) =>\n    tables\n        |> _sortLimit(n:n, columns:columns, desc:false)\n\n// _highestOrLowest is a helper function, which reduces all groups into a single group by specific tags and a reducer function,\n// then it selects the highest or lowest records based on the columns and the _sortLimit function.\n// The default reducer assumes no reducing needs to be performed.\n_highestOrLowest = (n, _sortLimit, reducer, columns=[\"_value\"], groupColumns=[], tables=<-) =>\n    tables\n        |> group(columns:groupColumns)\n        |> reducer()\n        |> group(columns:[])\n        |> _sortLimit(n:n, columns:columns)\n\n// highestMax returns the top N records from all groups using the maximum of each group.\nhighestMax = (n, columns=[\"_value\"], groupColumns=[], tables=<-) =>\n    tables\n        |> _highestOrLowest(\n                n:n,\n                columns:columns,\n                groupColumns:groupColumns,\n                // TODO(nathanielc): Once max/min support selecting based on multiple columns change this to pass all columns.\n                reducer: (tables=<-) => tables |> max(column:columns[0]),\n                _sortLimit: top,\n            )\n\n// highestAverage returns the top N records from all groups using the average of each group.\nhighestAverage = (n, columns=[\"_value\"], groupColumns=[], tables=<-) =>\n    tables\n        |> _highestOrLowest(\n                n:n,\n                columns:columns,\n                groupColumns:groupColumns,\n                reducer: (tables=<-) => tables |> mean(columns:[columns[0]]),\n                _sortLimit: top,\n            )\n\n// highestCurrent returns the top N records from all groups using the last value of each group.\nhighestCurrent = (n, columns=[\"_value\"], groupColumns=[], tables=<-) =>\n    tables\n        |> _highestOrLowest(\n                n:n,\n                columns:columns,\n                groupColumns:groupColumns,\n                reducer: (tables=<-) => tables |> last(column:columns[0]),\n                _sortLimit: top,\n            )\n\n// lowestMin returns the bottom N records from all groups using the minimum of each group.\nlowestMin = (n, columns=[\"_value\"], groupColumns=[], tables=<-) =>\n    tables\n        |> _highestOrLowest(\n                n:n,\n                columns:columns,\n                groupColumns:groupColumns,\n                // TODO(nathanielc): Once max/min support selecting based on multiple columns change this to pass all columns.\n                reducer: (tables=<-) => tables |> min(column:columns[0]),\n                _sortLimit: bottom,\n            )\n\n// lowestAverage returns the bottom N records from all groups using the average of each group.\nlowestAverage = (n, columns=[\"_value\"], groupColumns=[], tables=<-) =>\n    tables\n        |> _highestOrLowest(\n                n:n,\n                columns:columns,\n                groupColumns:groupColumns,\n                reducer: (tables=<-) => tables |> mean(columns:[columns[0]]),\n                _sortLimit: bottom,\n            )\n\n// lowestCurrent returns the bottom N records from all groups using the last value of each group.\nlowestCurrent = (n, columns=[\"_value\"], groupColumns=[], tables=<-) =>\n    tables\n        |> _highestOrLowest(\n                n:n,\n                columns:columns,\n                groupColumns:groupColumns,\n                reducer: (tables=<-) => tables |> last(column:columns[0]),\n                _sortLimit: bottom,\n            )\n\ntoString = (tables=<-) => tables |> map(fn:(r) => string(v:r._value))\ntoInt = (tables=<-) => tables |> map(fn:(r) => int(v:r._value))\ntoUInt = (tables=<-) => tables |> map(fn:(r) => uint(v:r._value))\ntoFloat = (tables=<-) => tables |> map(fn:(r) => float(v:r._value))\ntoBool = (tables=<-) => tables |> map(fn:(r) => bool(v:r._value))\ntoTime = (tables=<-) => tables |> map(fn:(r) => time(v:r._value))\ntoDuration = (tables=<-) => tables |> map(fn:(r) => duration(v:r._value))",
 				Start: ast.Position{
 					Column: 1,
 					Line:   1,
@@ -209,11 +211,11 @@ var pkgAST = &ast.Package{
 				Errors: nil,
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
-						Column: 16,
+						Column: 23,
 						Line:   13,
 					},
 					File:   "universe.flux",
-					Source: "builtin columns",
+					Source: "builtin approxDistinct",
 					Start: ast.Position{
 						Column: 1,
 						Line:   13,
@@ -225,29 +227,29 @@ var pkgAST = &ast.Package{
 					Errors: nil,
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
-							Column: 16,
+							Column: 23,
 							Line:   13,
 						},
 						File:   "universe.flux",
-						Source: "columns",
+						Source: "approxDistinct",
 						Start: ast.Position{
 							Column: 9,
 							Line:   13,
 						},
 					},
 				},
-				Name: "columns",
+				Name: "approxDistinct",
 			},
 		}, &ast.BuiltinStatement{
 			BaseNode: ast.BaseNode{
 				Errors: nil,
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
-						Column: 14,
+						Column: 17,
 						Line:   14,
 					},
 					File:   "universe.flux",
-					Source: "builtin count",
+					Source: "builtin asofJoin",
 					Start: ast.Position{
 						Column: 1,
 						Line:   14,
@@ -259,29 +261,29 @@ var pkgAST = &ast.Package{
 					Errors: nil,
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
-							Column: 14,
+							Column: 17,
 							Line:   14,
 						},
 						File:   "universe.flux",
-						Source: "count",
+						Source: "asofJoin",
 						Start: ast.Position{
 							Column: 9,
 							Line:   14,
 						},
 					},
 				},
-				Name: "count",
+				Name: "asofJoin",
 			},
 		}, &ast.BuiltinStatement{
 			BaseNode: ast.BaseNode{
 				Errors: nil,
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
-						Column: 19,
+						Column: 16,
 						Line:   15,
 					},
 					File:   "universe.flux",
-					Source: "builtin covariance",
+					Source: "builtin columns",
 					Start: ast.Position{
 						Column: 1,
 						Line:   15,
@@ -293,29 +295,29 @@ var pkgAST = &ast.Package{
 					Errors: nil,
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
-							Column: 19,
+							Column: 16,
 							Line:   15,
 						},
 						File:   "universe.flux",
-						Source: "covariance",
+						Source: "columns",
 						Start: ast.Position{
 							Column: 9,
 							Line:   15,
 						},
 					},
 				},
-				Name: "covariance",
+				Name: "columns",
 			},
 		}, &ast.BuiltinStatement{
 			BaseNode: ast.BaseNode{
 				Errors: nil,
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
-						Column: 22,
+						Column: 20,
 						Line:   16,
 					},
 					File:   "universe.flux",
-					Source: "builtin cumulativeSum",
+					Source: "builtin columnTypes",
 					Start: ast.Position{
 						Column: 1,
 						Line:   16,
@@ -327,29 +329,29 @@ var pkgAST = &ast.Package{
 					Errors: nil,
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
-							Column: 22,
+							Column: 20,
 							Line:   16,
 						},
 						File:   "universe.flux",
-						Source: "cumulativeSum",
+						Source: "columnTypes",
 						Start: ast.Position{
 							Column: 9,
 							Line:   16,
 						},
 					},
 				},
-				Name: "cumulativeSum",
+				Name: "columnTypes",
 			},
 		}, &ast.BuiltinStatement{
 			BaseNode: ast.BaseNode{
 				Errors: nil,
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
-						Column: 19,
+						Column: 14,
 						Line:   17,
 					},
 					File:   "universe.flux",
-					Source: "builtin derivative",
+					Source: "builtin count",
 					Start: ast.Position{
 						Column: 1,
 						Line:   17,
@@ -361,18 +363,18 @@ var pkgAST = &ast.Package{
 					Errors: nil,
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
-							Column: 19,
+							Column: 14,
 							Line:   17,
 						},
 						File:   "universe.flux",
-						Source: "derivative",
+						Source: "count",
 						Start: ast.Position{
 							Column: 9,
 							Line:   17,
 						},
 					},
 				},
-				Name: "derivative",
+				Name: "count",
 			},
 		}, &ast.BuiltinStatement{
 			BaseNode: ast.BaseNode{
@@ -383,7 +385,7 @@ var pkgAST = &ast.Package{
 						Line:   18,
 					},
 					File:   "universe.flux",
-					Source: "builtin difference",
+					Source: "builtin covariance",
 					Start: ast.Position{
 						Column: 1,
 						Line:   18,
@@ -399,25 +401,25 @@ var pkgAST = &ast.Package{
 							Line:   18,
 						},
 						File:   "universe.flux",
-						Source: "difference",
+						Source: "covariance",
 						Start: ast.Position{
 							Column: 9,
 							Line:   18,
 						},
 					},
 				},
-				Name: "difference",
+				Name: "covariance",
 			},
 		}, &ast.BuiltinStatement{
 			BaseNode: ast.BaseNode{
 				Errors: nil,
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
-						Column: 17,
+						Column: 22,
 						Line:   19,
 					},
 					File:   "universe.flux",
-					Source: "builtin distinct",
+					Source: "builtin cumulativeSum",
 					Start: ast.Position{
 						Column: 1,
 						Line:   19,
@@ -429,29 +431,29 @@ var pkgAST = &ast.Package{
 					Errors: nil,
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
-							Column: 17,
+							Column: 22,
 							Line:   19,
 						},
 						File:   "universe.flux",
-						Source: "distinct",
+						Source: "cumulativeSum",
 						Start: ast.Position{
 							Column: 9,
 							Line:   19,
 						},
 					},
 				},
-				Name: "distinct",
+				Name: "cumulativeSum",
 			},
 		}, &ast.BuiltinStatement{
 			BaseNode: ast.BaseNode{
 				Errors: nil,
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
-						Column: 13,
+						Column: 19,
 						Line:   20,
 					},
 					File:   "universe.flux",
-					Source: "builtin drop",
+					Source: "builtin derivative",
 					Start: ast.Position{
 						Column: 1,
 						Line:   20,
@@ -463,29 +465,29 @@ var pkgAST = &ast.Package{
 					Errors: nil,
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
-							Column: 13,
+							Column: 19,
 							Line:   20,
 						},
 						File:   "universe.flux",
-						Source: "drop",
+						Source: "derivative",
 						Start: ast.Position{
 							Column: 9,
 							Line:   20,
 						},
 					},
 				},
-				Name: "drop",
+				Name: "derivative",
 			},
 		}, &ast.BuiltinStatement{
 			BaseNode: ast.BaseNode{
 				Errors: nil,
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
-						Column: 18,
+						Column: 19,
 						Line:   21,
 					},
 					File:   "universe.flux",
-					Source: "builtin duplicate",
+					Source: "builtin difference",
 					Start: ast.Position{
 						Column: 1,
 						Line:   21,
@@ -497,29 +499,29 @@ var pkgAST = &ast.Package{
 					Errors: nil,
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
-							Column: 18,
+							Column: 19,
 							Line:   21,
 						},
 						File:   "universe.flux",
-						Source: "duplicate",
+						Source: "difference",
 						Start: ast.Position{
 							Column: 9,
 							Line:   21,
 						},
 					},
 				},
-				Name: "duplicate",
+				Name: "difference",
 			},
 		}, &ast.BuiltinStatement{
 			BaseNode: ast.BaseNode{
 				Errors: nil,
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
-						Column: 13,
+						Column: 17,
 						Line:   22,
 					},
 					File:   "universe.flux",
-					Source: "builtin fill",
+					Source: "builtin distinct",
 					Start: ast.Position{
 						Column: 1,
 						Line:   22,
@@ -531,29 +533,29 @@ var pkgAST = &ast.Package{
 					Errors: nil,
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
-							Column: 13,
+							Column: 17,
 							Line:   22,
 						},
 						File:   "universe.flux",
-						Source: "fill",
+						Source: "distinct",
 						Start: ast.Position{
 							Column: 9,
 							Line:   22,
 						},
 					},
 				},
-				Name: "fill",
+				Name: "distinct",
 			},
 		}, &ast.BuiltinStatement{
 			BaseNode: ast.BaseNode{
 				Errors: nil,
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
-						Column: 15,
+						Column: 13,
 						Line:   23,
 					},
 					File:   "universe.flux",
-					Source: "builtin filter",
+					Source: "builtin drop",
 					Start: ast.Position{
 						Column: 1,
 						Line:   23,
@@ -565,29 +567,29 @@ var pkgAST = &ast.Package{
 					Errors: nil,
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
-							Column: 15,
+							Column: 13,
 							Line:   23,
 						},
 						File:   "universe.flux",
-						Source: "filter",
+						Source: "drop",
 						Start: ast.Position{
 							Column: 9,
 							Line:   23,
 						},
 					},
 				},
-				Name: "filter",
+				Name: "drop",
 			},
 		}, &ast.BuiltinStatement{
 			BaseNode: ast.BaseNode{
 				Errors: nil,
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
-						Column: 14,
+						Column: 18,
 						Line:   24,
 					},
 					File:   "universe.flux",
-					Source: "builtin first",
+					Source: "builtin duplicate",
 					Start: ast.Position{
 						Column: 1,
 						Line:   24,
@@ -599,29 +601,29 @@ var pkgAST = &ast.Package{
 					Errors: nil,
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
-							Column: 14,
+							Column: 18,
 							Line:   24,
 						},
 						File:   "universe.flux",
-						Source: "first",
+						Source: "duplicate",
 						Start: ast.Position{
 							Column: 9,
 							Line:   24,
 						},
 					},
 				},
-				Name: "first",
+				Name: "duplicate",
 			},
 		}, &ast.BuiltinStatement{
 			BaseNode: ast.BaseNode{
 				Errors: nil,
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
-						Column: 14,
+						Column: 13,
 						Line:   25,
 					},
 					File:   "universe.flux",
-					Source: "builtin group",
+					Source: "builtin fill",
 					Start: ast.Position{
 						Column: 1,
 						Line:   25,
@@ -633,29 +635,29 @@ var pkgAST = &ast.Package{
 					Errors: nil,
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
-							Column: 14,
+							Column: 13,
 							Line:   25,
 						},
 						File:   "universe.flux",
-						Source: "group",
+						Source: "fill",
 						Start: ast.Position{
 							Column: 9,
 							Line:   25,
 						},
 					},
 				},
-				Name: "group",
+				Name: "fill",
 			},
 		}, &ast.BuiltinStatement{
 			BaseNode: ast.BaseNode{
 				Errors: nil,
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
-						Column: 18,
+						Column: 15,
 						Line:   26,
 					},
 					File:   "universe.flux",
-					Source: "builtin histogram",
+					Source: "builtin filter",
 					Start: ast.Position{
 						Column: 1,
 						Line:   26,
@@ -667,29 +669,29 @@ var pkgAST = &ast.Package{
 					Errors: nil,
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
-							Column: 18,
+							Column: 15,
 							Line:   26,
 						},
 						File:   "universe.flux",
-						Source: "histogram",
+						Source: "filter",
 						Start: ast.Position{
 							Column: 9,
 							Line:   26,
 						},
 					},
 				},
-				Name: "histogram",
+				Name: "filter",
 			},
 		}, &ast.BuiltinStatement{
 			BaseNode: ast.BaseNode{
 				Errors: nil,
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
-						Column: 26,
+						Column: 14,
 						Line:   27,
 					},
 					File:   "universe.flux",
-					Source: "builtin histogramQuantile",
+					Source: "builtin first",
 					Start: ast.Position{
 						Column: 1,
 						Line:   27,
@@ -701,29 +703,29 @@ var pkgAST = &ast.Package{
 					Errors: nil,
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
-							Column: 26,
+							Column: 14,
 							Line:   27,
 						},
 						File:   "universe.flux",
-						Source: "histogramQuantile",
+						Source: "first",
 						Start: ast.Position{
 							Column: 9,
 							Line:   27,
 						},
 					},
 				},
-				Name: "histogramQuantile",
+				Name: "first",
 			},
 		}, &ast.BuiltinStatement{
 			BaseNode: ast.BaseNode{
 				Errors: nil,
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
-						Column: 17,
+						Column: 14,
 						Line:   28,
 					},
 					File:   "universe.flux",
-					Source: "builtin integral",
+					Source: "builtin group",
 					Start: ast.Position{
 						Column: 1,
 						Line:   28,
@@ -735,29 +737,29 @@ var pkgAST = &ast.Package{
 					Errors: nil,
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
-							Column: 17,
+							Column: 14,
 							Line:   28,
 						},
 						File:   "universe.flux",
-						Source: "integral",
+						Source: "group",
 						Start: ast.Position{
 							Column: 9,
 							Line:   28,
 						},
 					},
 				},
-				Name: "integral",
+				Name: "group",
 			},
 		}, &ast.BuiltinStatement{
 			BaseNode: ast.BaseNode{
 				Errors: nil,
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
-						Column: 13,
+						Column: 18,
 						Line:   29,
 					},
 					File:   "universe.flux",
-					Source: "builtin join",
+					Source: "builtin histogram",
 					Start: ast.Position{
 						Column: 1,
 						Line:   29,
@@ -769,29 +771,29 @@ var pkgAST = &ast.Package{
 					Errors: nil,
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
-							Column: 13,
+							Column: 18,
 							Line:   29,
 						},
 						File:   "universe.flux",
-						Source: "join",
+						Source: "histogram",
 						Start: ast.Position{
 							Column: 9,
 							Line:   29,
 						},
 					},
 				},
-				Name: "join",
+				Name: "histogram",
 			},
 		}, &ast.BuiltinStatement{
 			BaseNode: ast.BaseNode{
 				Errors: nil,
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
-						Column: 13,
+						Column: 26,
 						Line:   30,
 					},
 					File:   "universe.flux",
-					Source: "builtin keep",
+					Source: "builtin histogramQuantile",
 					Start: ast.Position{
 						Column: 1,
 						Line:   30,
@@ -803,29 +805,29 @@ var pkgAST = &ast.Package{
 					Errors: nil,
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
-							Column: 13,
+							Column: 26,
 							Line:   30,
 						},
 						File:   "universe.flux",
-						Source: "keep",
+						Source: "histogramQuantile",
 						Start: ast.Position{
 							Column: 9,
 							Line:   30,
 						},
 					},
 				},
-				Name: "keep",
+				Name: "histogramQuantile",
 			},
 		}, &ast.BuiltinStatement{
 			BaseNode: ast.BaseNode{
 				Errors: nil,
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
-						Column: 18,
+						Column: 20,
 						Line:   31,
 					},
 					File:   "universe.flux",
-					Source: "builtin keyValues",
+					Source: "builtin holtWinters",
 					Start: ast.Position{
 						Column: 1,
 						Line:   31,
@@ -837,29 +839,29 @@ var pkgAST = &ast.Package{
 					Errors: nil,
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
-							Column: 18,
+							Column: 20,
 							Line:   31,
 						},
 						File:   "universe.flux",
-						Source: "keyValues",
+						Source: "holtWinters",
 						Start: ast.Position{
 							Column: 9,
 							Line:   31,
 						},
 					},
 				},
-				Name: "keyValues",
+				Name: "holtWinters",
 			},
 		}, &ast.BuiltinStatement{
 			BaseNode: ast.BaseNode{
 				Errors: nil,
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
-						Column: 13,
+						Column: 17,
 						Line:   32,
 					},
 					File:   "universe.flux",
-					Source: "builtin keys",
+					Source: "builtin integral",
 					Start: ast.Position{
 						Column: 1,
 						Line:   32,
@@ -871,29 +873,29 @@ var pkgAST = &ast.Package{
 					Errors: nil,
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
-							Column: 13,
+							Column: 17,
 							Line:   32,
 						},
 						File:   "universe.flux",
-						Source: "keys",
+						Source: "integral",
 						Start: ast.Position{
 							Column: 9,
 							Line:   32,
 						},
 					},
 				},
-				Name: "keys",
+				Name: "integral",
 			},
 		}, &ast.BuiltinStatement{
 			BaseNode: ast.BaseNode{
 				Errors: nil,
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
-						Column: 13,
+						Column: 21,
 						Line:   33,
 					},
 					File:   "universe.flux",
-					Source: "builtin last",
+					Source: "builtin intervalJoin",
 					Start: ast.Position{
 						Column: 1,
 						Line:   33,
@@ -905,29 +907,29 @@ var pkgAST = &ast.Package{
 					Errors: nil,
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
-							Column: 13,
+							Column: 21,
 							Line:   33,
 						},
 						File:   "universe.flux",
-						Source: "last",
+						Source: "intervalJoin",
 						Start: ast.Position{
 							Column: 9,
 							Line:   33,
 						},
 					},
 				},
-				Name: "last",
+				Name: "intervalJoin",
 			},
 		}, &ast.BuiltinStatement{
 			BaseNode: ast.BaseNode{
 				Errors: nil,
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
-						Column: 14,
+						Column: 13,
 						Line:   34,
 					},
 					File:   "universe.flux",
-					Source: "builtin limit",
+					Source: "builtin join",
 					Start: ast.Position{
 						Column: 1,
 						Line:   34,
@@ -939,29 +941,29 @@ var pkgAST = &ast.Package{
 					Errors: nil,
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
-							Column: 14,
+							Column: 13,
 							Line:   34,
 						},
 						File:   "universe.flux",
-						Source: "limit",
+						Source: "join",
 						Start: ast.Position{
 							Column: 9,
 							Line:   34,
 						},
 					},
 				},
-				Name: "limit",
+				Name: "join",
 			},
 		}, &ast.BuiltinStatement{
 			BaseNode: ast.BaseNode{
 				Errors: nil,
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
-						Column: 12,
+						Column: 13,
 						Line:   35,
 					},
 					File:   "universe.flux",
-					Source: "builtin map",
+					Source: "builtin keep",
 					Start: ast.Position{
 						Column: 1,
 						Line:   35,
@@ -973,29 +975,29 @@ var pkgAST = &ast.Package{
 					Errors: nil,
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
-							Column: 12,
+							Column: 13,
 							Line:   35,
 						},
 						File:   "universe.flux",
-						Source: "map",
+						Source: "keep",
 						Start: ast.Position{
 							Column: 9,
 							Line:   35,
 						},
 					},
 				},
-				Name: "map",
+				Name: "keep",
 			},
 		}, &ast.BuiltinStatement{
 			BaseNode: ast.BaseNode{
 				Errors: nil,
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
-						Column: 12,
+						Column: 18,
 						Line:   36,
 					},
 					File:   "universe.flux",
-					Source: "builtin max",
+					Source: "builtin keyValues",
 					Start: ast.Position{
 						Column: 1,
 						Line:   36,
@@ -1007,18 +1009,18 @@ var pkgAST = &ast.Package{
 					Errors: nil,
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
-							Column: 12,
+							Column: 18,
 							Line:   36,
 						},
 						File:   "universe.flux",
-						Source: "max",
+						Source: "keyValues",
 						Start: ast.Position{
 							Column: 9,
 							Line:   36,
 						},
 					},
 				},
-				Name: "max",
+				Name: "keyValues",
 			},
 		}, &ast.BuiltinStatement{
 			BaseNode: ast.BaseNode{
@@ -1029,7 +1031,7 @@ var pkgAST = &ast.Package{
 						Line:   37,
 					},
 					File:   "universe.flux",
-					Source: "builtin mean",
+					Source: "builtin keys",
 					Start: ast.Position{
 						Column: 1,
 						Line:   37,
@@ -1045,25 +1047,25 @@ var pkgAST = &ast.Package{
 							Line:   37,
 						},
 						File:   "universe.flux",
-						Source: "mean",
+						Source: "keys",
 						Start: ast.Position{
 							Column: 9,
 							Line:   37,
 						},
 					},
 				},
-				Name: "mean",
+				Name: "keys",
 			},
 		}, &ast.BuiltinStatement{
 			BaseNode: ast.BaseNode{
 				Errors: nil,
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
-						Column: 12,
+						Column: 13,
 						Line:   38,
 					},
 					File:   "universe.flux",
-					Source: "builtin min",
+					Source: "builtin last",
 					Start: ast.Position{
 						Column: 1,
 						Line:   38,
@@ -1075,29 +1077,29 @@ var pkgAST = &ast.Package{
 					Errors: nil,
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
-							Column: 12,
+							Column: 13,
 							Line:   38,
 						},
 						File:   "universe.flux",
-						Source: "min",
+						Source: "last",
 						Start: ast.Position{
 							Column: 9,
 							Line:   38,
 						},
 					},
 				},
-				Name: "min",
+				Name: "last",
 			},
 		}, &ast.BuiltinStatement{
 			BaseNode: ast.BaseNode{
 				Errors: nil,
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
-						Column: 19,
+						Column: 14,
 						Line:   39,
 					},
 					File:   "universe.flux",
-					Source: "builtin percentile",
+					Source: "builtin limit",
 					Start: ast.Position{
 						Column: 1,
 						Line:   39,
@@ -1109,29 +1111,29 @@ var pkgAST = &ast.Package{
 					Errors: nil,
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
-							Column: 19,
+							Column: 14,
 							Line:   39,
 						},
 						File:   "universe.flux",
-						Source: "percentile",
+						Source: "limit",
 						Start: ast.Position{
 							Column: 9,
 							Line:   39,
 						},
 					},
 				},
-				Name: "percentile",
+				Name: "limit",
 			},
 		}, &ast.BuiltinStatement{
 			BaseNode: ast.BaseNode{
 				Errors: nil,
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
-						Column: 14,
+						Column: 12,
 						Line:   40,
 					},
 					File:   "universe.flux",
-					Source: "builtin pivot",
+					Source: "builtin map",
 					Start: ast.Position{
 						Column: 1,
 						Line:   40,
@@ -1143,29 +1145,29 @@ var pkgAST = &ast.Package{
 					Errors: nil,
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
-							Column: 14,
+							Column: 12,
 							Line:   40,
 						},
 						File:   "universe.flux",
-						Source: "pivot",
+						Source: "map",
 						Start: ast.Position{
 							Column: 9,
 							Line:   40,
 						},
 					},
 				},
-				Name: "pivot",
+				Name: "map",
 			},
 		}, &ast.BuiltinStatement{
 			BaseNode: ast.BaseNode{
 				Errors: nil,
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
-						Column: 14,
+						Column: 19,
 						Line:   41,
 					},
 					File:   "universe.flux",
-					Source: "builtin range",
+					Source: "builtin mapColumns",
 					Start: ast.Position{
 						Column: 1,
 						Line:   41,
@@ -1177,29 +1179,29 @@ var pkgAST = &ast.Package{
 					Errors: nil,
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
-							Column: 14,
+							Column: 19,
 							Line:   41,
 						},
 						File:   "universe.flux",
-						Source: "range",
+						Source: "mapColumns",
 						Start: ast.Position{
 							Column: 9,
 							Line:   41,
 						},
 					},
 				},
-				Name: "range",
+				Name: "mapColumns",
 			},
 		}, &ast.BuiltinStatement{
 			BaseNode: ast.BaseNode{
 				Errors: nil,
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
-						Column: 15,
+						Column: 12,
 						Line:   42,
 					},
 					File:   "universe.flux",
-					Source: "builtin rename",
+					Source: "builtin max",
 					Start: ast.Position{
 						Column: 1,
 						Line:   42,
@@ -1211,29 +1213,29 @@ var pkgAST = &ast.Package{
 					Errors: nil,
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
-							Column: 15,
+							Column: 12,
 							Line:   42,
 						},
 						File:   "universe.flux",
-						Source: "rename",
+						Source: "max",
 						Start: ast.Position{
 							Column: 9,
 							Line:   42,
 						},
 					},
 				},
-				Name: "rename",
+				Name: "max",
 			},
 		}, &ast.BuiltinStatement{
 			BaseNode: ast.BaseNode{
 				Errors: nil,
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
-						Column: 15,
+						Column: 13,
 						Line:   43,
 					},
 					File:   "universe.flux",
-					Source: "builtin sample",
+					Source: "builtin mean",
 					Start: ast.Position{
 						Column: 1,
 						Line:   43,
@@ -1245,29 +1247,29 @@ var pkgAST = &ast.Package{
 					Errors: nil,
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
-							Column: 15,
+							Column: 13,
 							Line:   43,
 						},
 						File:   "universe.flux",
-						Source: "sample",
+						Source: "mean",
 						Start: ast.Position{
 							Column: 9,
 							Line:   43,
 						},
 					},
 				},
-				Name: "sample",
+				Name: "mean",
 			},
 		}, &ast.BuiltinStatement{
 			BaseNode: ast.BaseNode{
 				Errors: nil,
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
-						Column: 12,
+						Column: 13,
 						Line:   44,
 					},
 					File:   "universe.flux",
-					Source: "builtin set",
+					Source: "builtin melt",
 					Start: ast.Position{
 						Column: 1,
 						Line:   44,
@@ -1279,29 +1281,29 @@ var pkgAST = &ast.Package{
 					Errors: nil,
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
-							Column: 12,
+							Column: 13,
 							Line:   44,
 						},
 						File:   "universe.flux",
-						Source: "set",
+						Source: "melt",
 						Start: ast.Position{
 							Column: 9,
 							Line:   44,
 						},
 					},
 				},
-				Name: "set",
+				Name: "melt",
 			},
 		}, &ast.BuiltinStatement{
 			BaseNode: ast.BaseNode{
 				Errors: nil,
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
-						Column: 14,
+						Column: 12,
 						Line:   45,
 					},
 					File:   "universe.flux",
-					Source: "builtin shift",
+					Source: "builtin min",
 					Start: ast.Position{
 						Column: 1,
 						Line:   45,
@@ -1313,29 +1315,29 @@ var pkgAST = &ast.Package{
 					Errors: nil,
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
-							Column: 14,
+							Column: 12,
 							Line:   45,
 						},
 						File:   "universe.flux",
-						Source: "shift",
+						Source: "min",
 						Start: ast.Position{
 							Column: 9,
 							Line:   45,
 						},
 					},
 				},
-				Name: "shift",
+				Name: "min",
 			},
 		}, &ast.BuiltinStatement{
 			BaseNode: ast.BaseNode{
 				Errors: nil,
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
-						Column: 13,
+						Column: 22,
 						Line:   46,
 					},
 					File:   "universe.flux",
-					Source: "builtin skew",
+					Source: "builtin movingAverage",
 					Start: ast.Position{
 						Column: 1,
 						Line:   46,
@@ -1347,29 +1349,29 @@ var pkgAST = &ast.Package{
 					Errors: nil,
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
-							Column: 13,
+							Column: 22,
 							Line:   46,
 						},
 						File:   "universe.flux",
-						Source: "skew",
+						Source: "movingAverage",
 						Start: ast.Position{
 							Column: 9,
 							Line:   46,
 						},
 					},
 				},
-				Name: "skew",
+				Name: "movingAverage",
 			},
 		}, &ast.BuiltinStatement{
 			BaseNode: ast.BaseNode{
 				Errors: nil,
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
-						Column: 15,
+						Column: 13,
 						Line:   47,
 					},
 					File:   "universe.flux",
-					Source: "builtin spread",
+					Source: "builtin over",
 					Start: ast.Position{
 						Column: 1,
 						Line:   47,
@@ -1381,29 +1383,29 @@ var pkgAST = &ast.Package{
 					Errors: nil,
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
-							Column: 15,
+							Column: 13,
 							Line:   47,
 						},
 						File:   "universe.flux",
-						Source: "spread",
+						Source: "over",
 						Start: ast.Position{
 							Column: 9,
 							Line:   47,
 						},
 					},
 				},
-				Name: "spread",
+				Name: "over",
 			},
 		}, &ast.BuiltinStatement{
 			BaseNode: ast.BaseNode{
 				Errors: nil,
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
-						Column: 13,
+						Column: 19,
 						Line:   48,
 					},
 					File:   "universe.flux",
-					Source: "builtin sort",
+					Source: "builtin percentile",
 					Start: ast.Position{
 						Column: 1,
 						Line:   48,
@@ -1415,29 +1417,29 @@ var pkgAST = &ast.Package{
 					Errors: nil,
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
-							Column: 13,
+							Column: 19,
 							Line:   48,
 						},
 						File:   "universe.flux",
-						Source: "sort",
+						Source: "percentile",
 						Start: ast.Position{
 							Column: 9,
 							Line:   48,
 						},
 					},
 				},
-				Name: "sort",
+				Name: "percentile",
 			},
 		}, &ast.BuiltinStatement{
 			BaseNode: ast.BaseNode{
 				Errors: nil,
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
-						Column: 22,
+						Column: 14,
 						Line:   49,
 					},
 					File:   "universe.flux",
-					Source: "builtin stateTracking",
+					Source: "builtin pivot",
 					Start: ast.Position{
 						Column: 1,
 						Line:   49,
@@ -1449,29 +1451,29 @@ var pkgAST = &ast.Package{
 					Errors: nil,
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
-							Column: 22,
+							Column: 14,
 							Line:   49,
 						},
 						File:   "universe.flux",
-						Source: "stateTracking",
+						Source: "pivot",
 						Start: ast.Position{
 							Column: 9,
 							Line:   49,
 						},
 					},
 				},
-				Name: "stateTracking",
+				Name: "pivot",
 			},
 		}, &ast.BuiltinStatement{
 			BaseNode: ast.BaseNode{
 				Errors: nil,
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
-						Column: 15,
+						Column: 21,
 						Line:   50,
 					},
 					File:   "universe.flux",
-					Source: "builtin stddev",
+					Source: "builtin randomSample",
 					Start: ast.Position{
 						Column: 1,
 						Line:   50,
@@ -1483,29 +1485,29 @@ var pkgAST = &ast.Package{
 					Errors: nil,
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
-							Column: 15,
+							Column: 21,
 							Line:   50,
 						},
 						File:   "universe.flux",
-						Source: "stddev",
+						Source: "randomSample",
 						Start: ast.Position{
 							Column: 9,
 							Line:   50,
 						},
 					},
 				},
-				Name: "stddev",
+				Name: "randomSample",
 			},
 		}, &ast.BuiltinStatement{
 			BaseNode: ast.BaseNode{
 				Errors: nil,
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
-						Column: 12,
+						Column: 14,
 						Line:   51,
 					},
 					File:   "universe.flux",
-					Source: "builtin sum",
+					Source: "builtin range",
 					Start: ast.Position{
 						Column: 1,
 						Line:   51,
@@ -1517,29 +1519,29 @@ var pkgAST = &ast.Package{
 					Errors: nil,
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
-							Column: 12,
+							Column: 14,
 							Line:   51,
 						},
 						File:   "universe.flux",
-						Source: "sum",
+						Source: "range",
 						Start: ast.Position{
 							Column: 9,
 							Line:   51,
 						},
 					},
 				},
-				Name: "sum",
+				Name: "range",
 			},
 		}, &ast.BuiltinStatement{
 			BaseNode: ast.BaseNode{
 				Errors: nil,
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
-						Column: 14,
+						Column: 15,
 						Line:   52,
 					},
 					File:   "universe.flux",
-					Source: "builtin union",
+					Source: "builtin rename",
 					Start: ast.Position{
 						Column: 1,
 						Line:   52,
@@ -1551,29 +1553,29 @@ var pkgAST = &ast.Package{
 					Errors: nil,
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
-							Column: 14,
+							Column: 15,
 							Line:   52,
 						},
 						File:   "universe.flux",
-						Source: "union",
+						Source: "rename",
 						Start: ast.Position{
 							Column: 9,
 							Line:   52,
 						},
 					},
 				},
-				Name: "union",
+				Name: "rename",
 			},
 		}, &ast.BuiltinStatement{
 			BaseNode: ast.BaseNode{
 				Errors: nil,
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
-						Column: 15,
+						Column: 18,
 						Line:   53,
 					},
 					File:   "universe.flux",
-					Source: "builtin unique",
+					Source: "builtin renameAll",
 					Start: ast.Position{
 						Column: 1,
 						Line:   53,
@@ -1585,18 +1587,18 @@ var pkgAST = &ast.Package{
 					Errors: nil,
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
-							Column: 15,
+							Column: 18,
 							Line:   53,
 						},
 						File:   "universe.flux",
-						Source: "unique",
+						Source: "renameAll",
 						Start: ast.Position{
 							Column: 9,
 							Line:   53,
 						},
 					},
 				},
-				Name: "unique",
+				Name: "renameAll",
 			},
 		}, &ast.BuiltinStatement{
 			BaseNode: ast.BaseNode{
@@ -1607,7 +1609,7 @@ var pkgAST = &ast.Package{
 						Line:   54,
 					},
 					File:   "universe.flux",
-					Source: "builtin window",
+					Source: "builtin sample",
 					Start: ast.Position{
 						Column: 1,
 						Line:   54,
@@ -1623,25 +1625,25 @@ var pkgAST = &ast.Package{
 							Line:   54,
 						},
 						File:   "universe.flux",
-						Source: "window",
+						Source: "sample",
 						Start: ast.Position{
 							Column: 9,
 							Line:   54,
 						},
 					},
 				},
-				Name: "window",
+				Name: "sample",
 			},
 		}, &ast.BuiltinStatement{
 			BaseNode: ast.BaseNode{
 				Errors: nil,
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
-						Column: 14,
+						Column: 12,
 						Line:   55,
 					},
 					File:   "universe.flux",
-					Source: "builtin yield",
+					Source: "builtin set",
 					Start: ast.Position{
 						Column: 1,
 						Line:   55,
@@ -1653,32 +1655,32 @@ var pkgAST = &ast.Package{
 					Errors: nil,
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
-							Column: 14,
+							Column: 12,
 							Line:   55,
 						},
 						File:   "universe.flux",
-						Source: "yield",
+						Source: "set",
 						Start: ast.Position{
 							Column: 9,
 							Line:   55,
 						},
 					},
 				},
-				Name: "yield",
+				Name: "set",
 			},
 		}, &ast.BuiltinStatement{
 			BaseNode: ast.BaseNode{
 				Errors: nil,
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
-						Column: 13,
-						Line:   59,
+						Column: 14,
+						Line:   56,
 					},
 					File:   "universe.flux",
-					Source: "builtin bool",
+					Source: "builtin shift",
 					Start: ast.Position{
 						Column: 1,
-						Line:   59,
+						Line:   56,
 					},
 				},
 			},
@@ -1687,32 +1689,32 @@ var pkgAST = &ast.Package{
 					Errors: nil,
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
-							Column: 13,
-							Line:   59,
+							Column: 14,
+							Line:   56,
 						},
 						File:   "universe.flux",
-						Source: "bool",
+						Source: "shift",
 						Start: ast.Position{
 							Column: 9,
-							Line:   59,
+							Line:   56,
 						},
 					},
 				},
-				Name: "bool",
+				Name: "shift",
 			},
 		}, &ast.BuiltinStatement{
 			BaseNode: ast.BaseNode{
 				Errors: nil,
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
-						Column: 17,
-						Line:   60,
+						Column: 13,
+						Line:   57,
 					},
 					File:   "universe.flux",
-					Source: "builtin duration",
+					Source: "builtin skew",
 					Start: ast.Position{
 						Column: 1,
-						Line:   60,
+						Line:   57,
 					},
 				},
 			},
@@ -1721,32 +1723,32 @@ var pkgAST = &ast.Package{
 					Errors: nil,
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
-							Column: 17,
-							Line:   60,
+							Column: 13,
+							Line:   57,
 						},
 						File:   "universe.flux",
-						Source: "duration",
+						Source: "skew",
 						Start: ast.Position{
 							Column: 9,
-							Line:   60,
+							Line:   57,
 						},
 					},
 				},
-				Name: "duration",
+				Name: "skew",
 			},
 		}, &ast.BuiltinStatement{
 			BaseNode: ast.BaseNode{
 				Errors: nil,
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
-						Column: 14,
-						Line:   61,
+						Column: 15,
+						Line:   58,
 					},
 					File:   "universe.flux",
-					Source: "builtin float",
+					Source: "builtin spread",
 					Start: ast.Position{
 						Column: 1,
-						Line:   61,
+						Line:   58,
 					},
 				},
 			},
@@ -1755,32 +1757,32 @@ var pkgAST = &ast.Package{
 					Errors: nil,
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
-							Column: 14,
-							Line:   61,
+							Column: 15,
+							Line:   58,
 						},
 						File:   "universe.flux",
-						Source: "float",
+						Source: "spread",
 						Start: ast.Position{
 							Column: 9,
-							Line:   61,
+							Line:   58,
 						},
 					},
 				},
-				Name: "float",
+				Name: "spread",
 			},
 		}, &ast.BuiltinStatement{
 			BaseNode: ast.BaseNode{
 				Errors: nil,
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
-						Column: 12,
-						Line:   62,
+						Column: 13,
+						Line:   59,
 					},
 					File:   "universe.flux",
-					Source: "builtin int",
+					Source: "builtin sort",
 					Start: ast.Position{
 						Column: 1,
-						Line:   62,
+						Line:   59,
 					},
 				},
 			},
@@ -1789,32 +1791,32 @@ var pkgAST = &ast.Package{
 					Errors: nil,
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
-							Column: 12,
-							Line:   62,
+							Column: 13,
+							Line:   59,
 						},
 						File:   "universe.flux",
-						Source: "int",
+						Source: "sort",
 						Start: ast.Position{
 							Column: 9,
-							Line:   62,
+							Line:   59,
 						},
 					},
 				},
-				Name: "int",
+				Name: "sort",
 			},
 		}, &ast.BuiltinStatement{
 			BaseNode: ast.BaseNode{
 				Errors: nil,
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
-						Column: 15,
-						Line:   63,
+						Column: 22,
+						Line:   60,
 					},
 					File:   "universe.flux",
-					Source: "builtin string",
+					Source: "builtin stateTracking",
 					Start: ast.Position{
 						Column: 1,
-						Line:   63,
+						Line:   60,
 					},
 				},
 			},
@@ -1823,32 +1825,32 @@ var pkgAST = &ast.Package{
 					Errors: nil,
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
-							Column: 15,
-							Line:   63,
+							Column: 22,
+							Line:   60,
 						},
 						File:   "universe.flux",
-						Source: "string",
+						Source: "stateTracking",
 						Start: ast.Position{
 							Column: 9,
-							Line:   63,
+							Line:   60,
 						},
 					},
 				},
-				Name: "string",
+				Name: "stateTracking",
 			},
 		}, &ast.BuiltinStatement{
 			BaseNode: ast.BaseNode{
 				Errors: nil,
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
-						Column: 13,
-						Line:   64,
+						Column: 15,
+						Line:   61,
 					},
 					File:   "universe.flux",
-					Source: "builtin time",
+					Source: "builtin stddev",
 					Start: ast.Position{
 						Column: 1,
-						Line:   64,
+						Line:   61,
 					},
 				},
 			},
@@ -1857,32 +1859,32 @@ var pkgAST = &ast.Package{
 					Errors: nil,
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
-							Column: 13,
-							Line:   64,
+							Column: 15,
+							Line:   61,
 						},
 						File:   "universe.flux",
-						Source: "time",
+						Source: "stddev",
 						Start: ast.Position{
 							Column: 9,
-							Line:   64,
+							Line:   61,
 						},
 					},
 				},
-				Name: "time",
+				Name: "stddev",
 			},
 		}, &ast.BuiltinStatement{
 			BaseNode: ast.BaseNode{
 				Errors: nil,
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
-						Column: 13,
-						Line:   65,
+						Column: 12,
+						Line:   62,
 					},
 					File:   "universe.flux",
-					Source: "builtin uint",
+					Source: "builtin sum",
 					Start: ast.Position{
 						Column: 1,
-						Line:   65,
+						Line:   62,
 					},
 				},
 			},
@@ -1891,32 +1893,32 @@ var pkgAST = &ast.Package{
 					Errors: nil,
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
-							Column: 13,
-							Line:   65,
+							Column: 12,
+							Line:   62,
 						},
 						File:   "universe.flux",
-						Source: "uint",
+						Source: "sum",
 						Start: ast.Position{
 							Column: 9,
-							Line:   65,
+							Line:   62,
 						},
 					},
 				},
-				Name: "uint",
+				Name: "sum",
 			},
 		}, &ast.BuiltinStatement{
 			BaseNode: ast.BaseNode{
 				Errors: nil,
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
-						Column: 17,
-						Line:   68,
+						Column: 19,
+						Line:   63,
 					},
 					File:   "universe.flux",
-					Source: "builtin contains",
+					Source: "builtin tableCount",
 					Start: ast.Position{
 						Column: 1,
-						Line:   68,
+						Line:   63,
 					},
 				},
 			},
@@ -1925,32 +1927,32 @@ var pkgAST = &ast.Package{
 					Errors: nil,
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
-							Column: 17,
-							Line:   68,
+							Column: 19,
+							Line:   63,
 						},
 						File:   "universe.flux",
-						Source: "contains",
+						Source: "tableCount",
 						Start: ast.Position{
 							Column: 9,
-							Line:   68,
+							Line:   63,
 						},
 					},
 				},
-				Name: "contains",
+				Name: "tableCount",
 			},
 		}, &ast.BuiltinStatement{
 			BaseNode: ast.BaseNode{
 				Errors: nil,
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
-						Column: 12,
-						Line:   71,
+						Column: 14,
+						Line:   64,
 					},
 					File:   "universe.flux",
-					Source: "builtin inf",
+					Source: "builtin union",
 					Start: ast.Position{
 						Column: 1,
-						Line:   71,
+						Line:   64,
 					},
 				},
 			},
@@ -1959,29 +1961,165 @@ var pkgAST = &ast.Package{
 					Errors: nil,
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
-							Column: 12,
-							Line:   71,
+							Column: 14,
+							Line:   64,
 						},
 						File:   "universe.flux",
-						Source: "inf",
+						Source: "union",
 						Start: ast.Position{
 							Column: 9,
-							Line:   71,
+							Line:   64,
 						},
 					},
 				},
-				Name: "inf",
+				Name: "union",
 			},
 		}, &ast.BuiltinStatement{
 			BaseNode: ast.BaseNode{
 				Errors: nil,
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
-						Column: 19,
+						Column: 15,
+						Line:   65,
+					},
+					File:   "universe.flux",
+					Source: "builtin unique",
+					Start: ast.Position{
+						Column: 1,
+						Line:   65,
+					},
+				},
+			},
+			ID: &ast.Identifier{
+				BaseNode: ast.BaseNode{
+					Errors: nil,
+					Loc: &ast.SourceLocation{
+						End: ast.Position{
+							Column: 15,
+							Line:   65,
+						},
+						File:   "universe.flux",
+						Source: "unique",
+						Start: ast.Position{
+							Column: 9,
+							Line:   65,
+						},
+					},
+				},
+				Name: "unique",
+			},
+		}, &ast.BuiltinStatement{
+			BaseNode: ast.BaseNode{
+				Errors: nil,
+				Loc: &ast.SourceLocation{
+					End: ast.Position{
+						Column: 16,
+						Line:   66,
+					},
+					File:   "universe.flux",
+					Source: "builtin unpivot",
+					Start: ast.Position{
+						Column: 1,
+						Line:   66,
+					},
+				},
+			},
+			ID: &ast.Identifier{
+				BaseNode: ast.BaseNode{
+					Errors: nil,
+					Loc: &ast.SourceLocation{
+						End: ast.Position{
+							Column: 16,
+							Line:   66,
+						},
+						File:   "universe.flux",
+						Source: "unpivot",
+						Start: ast.Position{
+							Column: 9,
+							Line:   66,
+						},
+					},
+				},
+				Name: "unpivot",
+			},
+		}, &ast.BuiltinStatement{
+			BaseNode: ast.BaseNode{
+				Errors: nil,
+				Loc: &ast.SourceLocation{
+					End: ast.Position{
+						Column: 15,
+						Line:   67,
+					},
+					File:   "universe.flux",
+					Source: "builtin window",
+					Start: ast.Position{
+						Column: 1,
+						Line:   67,
+					},
+				},
+			},
+			ID: &ast.Identifier{
+				BaseNode: ast.BaseNode{
+					Errors: nil,
+					Loc: &ast.SourceLocation{
+						End: ast.Position{
+							Column: 15,
+							Line:   67,
+						},
+						File:   "universe.flux",
+						Source: "window",
+						Start: ast.Position{
+							Column: 9,
+							Line:   67,
+						},
+					},
+				},
+				Name: "window",
+			},
+		}, &ast.BuiltinStatement{
+			BaseNode: ast.BaseNode{
+				Errors: nil,
+				Loc: &ast.SourceLocation{
+					End: ast.Position{
+						Column: 14,
+						Line:   68,
+					},
+					File:   "universe.flux",
+					Source: "builtin yield",
+					Start: ast.Position{
+						Column: 1,
+						Line:   68,
+					},
+				},
+			},
+			ID: &ast.Identifier{
+				BaseNode: ast.BaseNode{
+					Errors: nil,
+					Loc: &ast.SourceLocation{
+						End: ast.Position{
+							Column: 14,
+							Line:   68,
+						},
+						File:   "universe.flux",
+						Source: "yield",
+						Start: ast.Position{
+							Column: 9,
+							Line:   68,
+						},
+					},
+				},
+				Name: "yield",
+			},
+		}, &ast.BuiltinStatement{
+			BaseNode: ast.BaseNode{
+				Errors: nil,
+				Loc: &ast.SourceLocation{
+					End: ast.Position{
+						Column: 13,
 						Line:   72,
 					},
 					File:   "universe.flux",
-					Source: "builtin linearBins",
+					Source: "builtin bool",
 					Start: ast.Position{
 						Column: 1,
 						Line:   72,
@@ -1993,29 +2131,29 @@ var pkgAST = &ast.Package{
 					Errors: nil,
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
-							Column: 19,
+							Column: 13,
 							Line:   72,
 						},
 						File:   "universe.flux",
-						Source: "linearBins",
+						Source: "bool",
 						Start: ast.Position{
 							Column: 9,
 							Line:   72,
 						},
 					},
 				},
-				Name: "linearBins",
+				Name: "bool",
 			},
 		}, &ast.BuiltinStatement{
 			BaseNode: ast.BaseNode{
 				Errors: nil,
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
-						Column: 24,
+						Column: 17,
 						Line:   73,
 					},
 					File:   "universe.flux",
-					Source: "builtin logarithmicBins",
+					Source: "builtin duration",
 					Start: ast.Position{
 						Column: 1,
 						Line:   73,
@@ -2027,29 +2165,97 @@ var pkgAST = &ast.Package{
 					Errors: nil,
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
-							Column: 24,
+							Column: 17,
 							Line:   73,
 						},
 						File:   "universe.flux",
-						Source: "logarithmicBins",
+						Source: "duration",
 						Start: ast.Position{
 							Column: 9,
 							Line:   73,
 						},
 					},
 				},
-				Name: "logarithmicBins",
+				Name: "duration",
 			},
-		}, &ast.VariableAssignment{
+		}, &ast.BuiltinStatement{
 			BaseNode: ast.BaseNode{
 				Errors: nil,
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
-						Column: 70,
-						Line:   81,
+						Column: 14,
+						Line:   74,
 					},
 					File:   "universe.flux",
-					Source: "cov = (x,y,on,pearsonr=false) =>\n    join(\n        tables:{x:x, y:y},\n        on:on,\n    )\n    |> covariance(pearsonr:pearsonr, columns:[\"_value_x\",\"_value_y\"])",
+					Source: "builtin float",
+					Start: ast.Position{
+						Column: 1,
+						Line:   74,
+					},
+				},
+			},
+			ID: &ast.Identifier{
+				BaseNode: ast.BaseNode{
+					Errors: nil,
+					Loc: &ast.SourceLocation{
+						End: ast.Position{
+							Column: 14,
+							Line:   74,
+						},
+						File:   "universe.flux",
+						Source: "float",
+						Start: ast.Position{
+							Column: 9,
+							Line:   74,
+						},
+					},
+				},
+				Name: "float",
+			},
+		}, &ast.BuiltinStatement{
+			BaseNode: ast.BaseNode{
+				Errors: nil,
+				Loc: &ast.SourceLocation{
+					End: ast.Position{
+						Column: 12,
+						Line:   75,
+					},
+					File:   "universe.flux",
+					Source: "builtin int",
+					Start: ast.Position{
+						Column: 1,
+						Line:   75,
+					},
+				},
+			},
+			ID: &ast.Identifier{
+				BaseNode: ast.BaseNode{
+					Errors: nil,
+					Loc: &ast.SourceLocation{
+						End: ast.Position{
+							Column: 12,
+							Line:   75,
+						},
+						File:   "universe.flux",
+						Source: "int",
+						Start: ast.Position{
+							Column: 9,
+							Line:   75,
+						},
+					},
+				},
+				Name: "int",
+			},
+		}, &ast.BuiltinStatement{
+			BaseNode: ast.BaseNode{
+				Errors: nil,
+				Loc: &ast.SourceLocation{
+					End: ast.Position{
+						Column: 15,
+						Line:   76,
+					},
+					File:   "universe.flux",
+					Source: "builtin string",
 					Start: ast.Position{
 						Column: 1,
 						Line:   76,
@@ -2061,987 +2267,2284 @@ var pkgAST = &ast.Package{
 					Errors: nil,
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
-							Column: 4,
+							Column: 15,
 							Line:   76,
 						},
 						File:   "universe.flux",
-						Source: "cov",
+						Source: "string",
 						Start: ast.Position{
-							Column: 1,
+							Column: 9,
 							Line:   76,
 						},
 					},
 				},
-				Name: "cov",
+				Name: "string",
 			},
-			Init: &ast.FunctionExpression{
+		}, &ast.BuiltinStatement{
+			BaseNode: ast.BaseNode{
+				Errors: nil,
+				Loc: &ast.SourceLocation{
+					End: ast.Position{
+						Column: 13,
+						Line:   77,
+					},
+					File:   "universe.flux",
+					Source: "builtin time",
+					Start: ast.Position{
+						Column: 1,
+						Line:   77,
+					},
+				},
+			},
+			ID: &ast.Identifier{
 				BaseNode: ast.BaseNode{
 					Errors: nil,
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
-							Column: 70,
-							Line:   81,
+							Column: 13,
+							Line:   77,
 						},
 						File:   "universe.flux",
-						Source: "(x,y,on,pearsonr=false) =>\n    join(\n        tables:{x:x, y:y},\n        on:on,\n    )\n    |> covariance(pearsonr:pearsonr, columns:[\"_value_x\",\"_value_y\"])",
+						Source: "time",
 						Start: ast.Position{
-							Column: 7,
-							Line:   76,
+							Column: 9,
+							Line:   77,
 						},
 					},
 				},
-				Body: &ast.PipeExpression{
-					Argument: &ast.CallExpression{
-						Arguments: []ast.Expression{&ast.ObjectExpression{
+				Name: "time",
+			},
+		}, &ast.BuiltinStatement{
+			BaseNode: ast.BaseNode{
+				Errors: nil,
+				Loc: &ast.SourceLocation{
+					End: ast.Position{
+						Column: 13,
+						Line:   78,
+					},
+					File:   "universe.flux",
+					Source: "builtin uint",
+					Start: ast.Position{
+						Column: 1,
+						Line:   78,
+					},
+				},
+			},
+			ID: &ast.Identifier{
+				BaseNode: ast.BaseNode{
+					Errors: nil,
+					Loc: &ast.SourceLocation{
+						End: ast.Position{
+							Column: 13,
+							Line:   78,
+						},
+						File:   "universe.flux",
+						Source: "uint",
+						Start: ast.Position{
+							Column: 9,
+							Line:   78,
+						},
+					},
+				},
+				Name: "uint",
+			},
+		}, &ast.BuiltinStatement{
+			BaseNode: ast.BaseNode{
+				Errors: nil,
+				Loc: &ast.SourceLocation{
+					End: ast.Position{
+						Column: 17,
+						Line:   81,
+					},
+					File:   "universe.flux",
+					Source: "builtin contains",
+					Start: ast.Position{
+						Column: 1,
+						Line:   81,
+					},
+				},
+			},
+			ID: &ast.Identifier{
+				BaseNode: ast.BaseNode{
+					Errors: nil,
+					Loc: &ast.SourceLocation{
+						End: ast.Position{
+							Column: 17,
+							Line:   81,
+						},
+						File:   "universe.flux",
+						Source: "contains",
+						Start: ast.Position{
+							Column: 9,
+							Line:   81,
+						},
+					},
+				},
+				Name: "contains",
+			},
+		}, &ast.BuiltinStatement{
+			BaseNode: ast.BaseNode{
+				Errors: nil,
+				Loc: &ast.SourceLocation{
+					End: ast.Position{
+						Column: 12,
+						Line:   84,
+					},
+					File:   "universe.flux",
+					Source: "builtin die",
+					Start: ast.Position{
+						Column: 1,
+						Line:   84,
+					},
+				},
+			},
+			ID: &ast.Identifier{
+				BaseNode: ast.BaseNode{
+					Errors: nil,
+					Loc: &ast.SourceLocation{
+						End: ast.Position{
+							Column: 12,
+							Line:   84,
+						},
+						File:   "universe.flux",
+						Source: "die",
+						Start: ast.Position{
+							Column: 9,
+							Line:   84,
+						},
+					},
+				},
+				Name: "die",
+			},
+		}, &ast.BuiltinStatement{
+			BaseNode: ast.BaseNode{
+				Errors: nil,
+				Loc: &ast.SourceLocation{
+					End: ast.Position{
+						Column: 15,
+						Line:   85,
+					},
+					File:   "universe.flux",
+					Source: "builtin assert",
+					Start: ast.Position{
+						Column: 1,
+						Line:   85,
+					},
+				},
+			},
+			ID: &ast.Identifier{
+				BaseNode: ast.BaseNode{
+					Errors: nil,
+					Loc: &ast.SourceLocation{
+						End: ast.Position{
+							Column: 15,
+							Line:   85,
+						},
+						File:   "universe.flux",
+						Source: "assert",
+						Start: ast.Position{
+							Column: 9,
+							Line:   85,
+						},
+					},
+				},
+				Name: "assert",
+			},
+		}, &ast.BuiltinStatement{
+			BaseNode: ast.BaseNode{
+				Errors: nil,
+				Loc: &ast.SourceLocation{
+					End: ast.Position{
+						Column: 18,
+						Line:   88,
+					},
+					File:   "universe.flux",
+					Source: "builtin tableFind",
+					Start: ast.Position{
+						Column: 1,
+						Line:   88,
+					},
+				},
+			},
+			ID: &ast.Identifier{
+				BaseNode: ast.BaseNode{
+					Errors: nil,
+					Loc: &ast.SourceLocation{
+						End: ast.Position{
+							Column: 18,
+							Line:   88,
+						},
+						File:   "universe.flux",
+						Source: "tableFind",
+						Start: ast.Position{
+							Column: 9,
+							Line:   88,
+						},
+					},
+				},
+				Name: "tableFind",
+			},
+		}, &ast.BuiltinStatement{
+			BaseNode: ast.BaseNode{
+				Errors: nil,
+				Loc: &ast.SourceLocation{
+					End: ast.Position{
+						Column: 18,
+						Line:   89,
+					},
+					File:   "universe.flux",
+					Source: "builtin getColumn",
+					Start: ast.Position{
+						Column: 1,
+						Line:   89,
+					},
+				},
+			},
+			ID: &ast.Identifier{
+				BaseNode: ast.BaseNode{
+					Errors: nil,
+					Loc: &ast.SourceLocation{
+						End: ast.Position{
+							Column: 18,
+							Line:   89,
+						},
+						File:   "universe.flux",
+						Source: "getColumn",
+						Start: ast.Position{
+							Column: 9,
+							Line:   89,
+						},
+					},
+				},
+				Name: "getColumn",
+			},
+		}, &ast.BuiltinStatement{
+			BaseNode: ast.BaseNode{
+				Errors: nil,
+				Loc: &ast.SourceLocation{
+					End: ast.Position{
+						Column: 18,
+						Line:   90,
+					},
+					File:   "universe.flux",
+					Source: "builtin getRecord",
+					Start: ast.Position{
+						Column: 1,
+						Line:   90,
+					},
+				},
+			},
+			ID: &ast.Identifier{
+				BaseNode: ast.BaseNode{
+					Errors: nil,
+					Loc: &ast.SourceLocation{
+						End: ast.Position{
+							Column: 18,
+							Line:   90,
+						},
+						File:   "universe.flux",
+						Source: "getRecord",
+						Start: ast.Position{
+							Column: 9,
+							Line:   90,
+						},
+					},
+				},
+				Name: "getRecord",
+			},
+		}, &ast.BuiltinStatement{
+			BaseNode: ast.BaseNode{
+				Errors: nil,
+				Loc: &ast.SourceLocation{
+					End: ast.Position{
+						Column: 12,
+						Line:   93,
+					},
+					File:   "universe.flux",
+					Source: "builtin inf",
+					Start: ast.Position{
+						Column: 1,
+						Line:   93,
+					},
+				},
+			},
+			ID: &ast.Identifier{
+				BaseNode: ast.BaseNode{
+					Errors: nil,
+					Loc: &ast.SourceLocation{
+						End: ast.Position{
+							Column: 12,
+							Line:   93,
+						},
+						File:   "universe.flux",
+						Source: "inf",
+						Start: ast.Position{
+							Column: 9,
+							Line:   93,
+						},
+					},
+				},
+				Name: "inf",
+			},
+		}, &ast.BuiltinStatement{
+			BaseNode: ast.BaseNode{
+				Errors: nil,
+				Loc: &ast.SourceLocation{
+					End: ast.Position{
+						Column: 19,
+						Line:   94,
+					},
+					File:   "universe.flux",
+					Source: "builtin linearBins",
+					Start: ast.Position{
+						Column: 1,
+						Line:   94,
+					},
+				},
+			},
+			ID: &ast.Identifier{
+				BaseNode: ast.BaseNode{
+					Errors: nil,
+					Loc: &ast.SourceLocation{
+						End: ast.Position{
+							Column: 19,
+							Line:   94,
+						},
+						File:   "universe.flux",
+						Source: "linearBins",
+						Start: ast.Position{
+							Column: 9,
+							Line:   94,
+						},
+					},
+				},
+				Name: "linearBins",
+			},
+		}, &ast.BuiltinStatement{
+			BaseNode: ast.BaseNode{
+				Errors: nil,
+				Loc: &ast.SourceLocation{
+					End: ast.Position{
+						Column: 24,
+						Line:   95,
+					},
+					File:   "universe.flux",
+					Source: "builtin logarithmicBins",
+					Start: ast.Position{
+						Column: 1,
+						Line:   95,
+					},
+				},
+			},
+			ID: &ast.Identifier{
+				BaseNode: ast.BaseNode{
+					Errors: nil,
+					Loc: &ast.SourceLocation{
+						End: ast.Position{
+							Column: 24,
+							Line:   95,
+						},
+						File:   "universe.flux",
+						Source: "logarithmicBins",
+						Start: ast.Position{
+							Column: 9,
+							Line:   95,
+						},
+					},
+				},
+				Name: "logarithmicBins",
+			},
+		}, &ast.VariableAssignment{
+			BaseNode: ast.BaseNode{
+				Errors: nil,
+				Loc: &ast.SourceLocation{
+					End: ast.Position{
+						Column: 70,
+						Line:   103,
+					},
+					File:   "universe.flux",
+					Source: "cov = (x,y,on,pearsonr=false) =>\n    join(\n        tables:{x:x, y:y},\n        on:on,\n    )\n    |> covariance(pearsonr:pearsonr, columns:[\"_value_x\",\"_value_y\"])",
+					Start: ast.Position{
+						Column: 1,
+						Line:   98,
+					},
+				},
+			},
+			ID: &ast.Identifier{
+				BaseNode: ast.BaseNode{
+					Errors: nil,
+					Loc: &ast.SourceLocation{
+						End: ast.Position{
+							Column: 4,
+							Line:   98,
+						},
+						File:   "universe.flux",
+						Source: "cov",
+						Start: ast.Position{
+							Column: 1,
+							Line:   98,
+						},
+					},
+				},
+				Name: "cov",
+			},
+			Init: &ast.FunctionExpression{
+				BaseNode: ast.BaseNode{
+					Errors: nil,
+					Loc: &ast.SourceLocation{
+						End: ast.Position{
+							Column: 70,
+							Line:   103,
+						},
+						File:   "universe.flux",
+						Source: "(x,y,on,pearsonr=false) =>\n    join(\n        tables:{x:x, y:y},\n        on:on,\n    )\n    |> covariance(pearsonr:pearsonr, columns:[\"_value_x\",\"_value_y\"])",
+						Start: ast.Position{
+							Column: 7,
+							Line:   98,
+						},
+					},
+				},
+				Body: &ast.PipeExpression{
+					Argument: &ast.CallExpression{
+						Arguments: []ast.Expression{&ast.ObjectExpression{
+							BaseNode: ast.BaseNode{
+								Errors: nil,
+								Loc: &ast.SourceLocation{
+									End: ast.Position{
+										Column: 14,
+										Line:   101,
+									},
+									File:   "universe.flux",
+									Source: "tables:{x:x, y:y},\n        on:on",
+									Start: ast.Position{
+										Column: 9,
+										Line:   100,
+									},
+								},
+							},
+							Properties: []*ast.Property{&ast.Property{
+								BaseNode: ast.BaseNode{
+									Errors: nil,
+									Loc: &ast.SourceLocation{
+										End: ast.Position{
+											Column: 26,
+											Line:   100,
+										},
+										File:   "universe.flux",
+										Source: "tables:{x:x, y:y}",
+										Start: ast.Position{
+											Column: 9,
+											Line:   100,
+										},
+									},
+								},
+								Key: &ast.Identifier{
+									BaseNode: ast.BaseNode{
+										Errors: nil,
+										Loc: &ast.SourceLocation{
+											End: ast.Position{
+												Column: 15,
+												Line:   100,
+											},
+											File:   "universe.flux",
+											Source: "tables",
+											Start: ast.Position{
+												Column: 9,
+												Line:   100,
+											},
+										},
+									},
+									Name: "tables",
+								},
+								Value: &ast.ObjectExpression{
+									BaseNode: ast.BaseNode{
+										Errors: nil,
+										Loc: &ast.SourceLocation{
+											End: ast.Position{
+												Column: 26,
+												Line:   100,
+											},
+											File:   "universe.flux",
+											Source: "{x:x, y:y}",
+											Start: ast.Position{
+												Column: 16,
+												Line:   100,
+											},
+										},
+									},
+									Properties: []*ast.Property{&ast.Property{
+										BaseNode: ast.BaseNode{
+											Errors: nil,
+											Loc: &ast.SourceLocation{
+												End: ast.Position{
+													Column: 20,
+													Line:   100,
+												},
+												File:   "universe.flux",
+												Source: "x:x",
+												Start: ast.Position{
+													Column: 17,
+													Line:   100,
+												},
+											},
+										},
+										Key: &ast.Identifier{
+											BaseNode: ast.BaseNode{
+												Errors: nil,
+												Loc: &ast.SourceLocation{
+													End: ast.Position{
+														Column: 18,
+														Line:   100,
+													},
+													File:   "universe.flux",
+													Source: "x",
+													Start: ast.Position{
+														Column: 17,
+														Line:   100,
+													},
+												},
+											},
+											Name: "x",
+										},
+										Value: &ast.Identifier{
+											BaseNode: ast.BaseNode{
+												Errors: nil,
+												Loc: &ast.SourceLocation{
+													End: ast.Position{
+														Column: 20,
+														Line:   100,
+													},
+													File:   "universe.flux",
+													Source: "x",
+													Start: ast.Position{
+														Column: 19,
+														Line:   100,
+													},
+												},
+											},
+											Name: "x",
+										},
+									}, &ast.Property{
+										BaseNode: ast.BaseNode{
+											Errors: nil,
+											Loc: &ast.SourceLocation{
+												End: ast.Position{
+													Column: 25,
+													Line:   100,
+												},
+												File:   "universe.flux",
+												Source: "y:y",
+												Start: ast.Position{
+													Column: 22,
+													Line:   100,
+												},
+											},
+										},
+										Key: &ast.Identifier{
+											BaseNode: ast.BaseNode{
+												Errors: nil,
+												Loc: &ast.SourceLocation{
+													End: ast.Position{
+														Column: 23,
+														Line:   100,
+													},
+													File:   "universe.flux",
+													Source: "y",
+													Start: ast.Position{
+														Column: 22,
+														Line:   100,
+													},
+												},
+											},
+											Name: "y",
+										},
+										Value: &ast.Identifier{
+											BaseNode: ast.BaseNode{
+												Errors: nil,
+												Loc: &ast.SourceLocation{
+													End: ast.Position{
+														Column: 25,
+														Line:   100,
+													},
+													File:   "universe.flux",
+													Source: "y",
+													Start: ast.Position{
+														Column: 24,
+														Line:   100,
+													},
+												},
+											},
+											Name: "y",
+										},
+									}},
+								},
+							}, &ast.Property{
+								BaseNode: ast.BaseNode{
+									Errors: nil,
+									Loc: &ast.SourceLocation{
+										End: ast.Position{
+											Column: 14,
+											Line:   101,
+										},
+										File:   "universe.flux",
+										Source: "on:on",
+										Start: ast.Position{
+											Column: 9,
+											Line:   101,
+										},
+									},
+								},
+								Key: &ast.Identifier{
+									BaseNode: ast.BaseNode{
+										Errors: nil,
+										Loc: &ast.SourceLocation{
+											End: ast.Position{
+												Column: 11,
+												Line:   101,
+											},
+											File:   "universe.flux",
+											Source: "on",
+											Start: ast.Position{
+												Column: 9,
+												Line:   101,
+											},
+										},
+									},
+									Name: "on",
+								},
+								Value: &ast.Identifier{
+									BaseNode: ast.BaseNode{
+										Errors: nil,
+										Loc: &ast.SourceLocation{
+											End: ast.Position{
+												Column: 14,
+												Line:   101,
+											},
+											File:   "universe.flux",
+											Source: "on",
+											Start: ast.Position{
+												Column: 12,
+												Line:   101,
+											},
+										},
+									},
+									Name: "on",
+								},
+							}},
+						}},
+						BaseNode: ast.BaseNode{
+							Errors: nil,
+							Loc: &ast.SourceLocation{
+								End: ast.Position{
+									Column: 6,
+									Line:   102,
+								},
+								File:   "universe.flux",
+								Source: "join(\n        tables:{x:x, y:y},\n        on:on,\n    )",
+								Start: ast.Position{
+									Column: 5,
+									Line:   99,
+								},
+							},
+						},
+						Callee: &ast.Identifier{
+							BaseNode: ast.BaseNode{
+								Errors: nil,
+								Loc: &ast.SourceLocation{
+									End: ast.Position{
+										Column: 9,
+										Line:   99,
+									},
+									File:   "universe.flux",
+									Source: "join",
+									Start: ast.Position{
+										Column: 5,
+										Line:   99,
+									},
+								},
+							},
+							Name: "join",
+						},
+					},
+					BaseNode: ast.BaseNode{
+						Errors: nil,
+						Loc: &ast.SourceLocation{
+							End: ast.Position{
+								Column: 70,
+								Line:   103,
+							},
+							File:   "universe.flux",
+							Source: "join(\n        tables:{x:x, y:y},\n        on:on,\n    )\n    |> covariance(pearsonr:pearsonr, columns:[\"_value_x\",\"_value_y\"])",
+							Start: ast.Position{
+								Column: 5,
+								Line:   99,
+							},
+						},
+					},
+					Call: &ast.CallExpression{
+						Arguments: []ast.Expression{&ast.ObjectExpression{
+							BaseNode: ast.BaseNode{
+								Errors: nil,
+								Loc: &ast.SourceLocation{
+									End: ast.Position{
+										Column: 69,
+										Line:   103,
+									},
+									File:   "universe.flux",
+									Source: "pearsonr:pearsonr, columns:[\"_value_x\",\"_value_y\"]",
+									Start: ast.Position{
+										Column: 19,
+										Line:   103,
+									},
+								},
+							},
+							Properties: []*ast.Property{&ast.Property{
+								BaseNode: ast.BaseNode{
+									Errors: nil,
+									Loc: &ast.SourceLocation{
+										End: ast.Position{
+											Column: 36,
+											Line:   103,
+										},
+										File:   "universe.flux",
+										Source: "pearsonr:pearsonr",
+										Start: ast.Position{
+											Column: 19,
+											Line:   103,
+										},
+									},
+								},
+								Key: &ast.Identifier{
+									BaseNode: ast.BaseNode{
+										Errors: nil,
+										Loc: &ast.SourceLocation{
+											End: ast.Position{
+												Column: 27,
+												Line:   103,
+											},
+											File:   "universe.flux",
+											Source: "pearsonr",
+											Start: ast.Position{
+												Column: 19,
+												Line:   103,
+											},
+										},
+									},
+									Name: "pearsonr",
+								},
+								Value: &ast.Identifier{
+									BaseNode: ast.BaseNode{
+										Errors: nil,
+										Loc: &ast.SourceLocation{
+											End: ast.Position{
+												Column: 36,
+												Line:   103,
+											},
+											File:   "universe.flux",
+											Source: "pearsonr",
+											Start: ast.Position{
+												Column: 28,
+												Line:   103,
+											},
+										},
+									},
+									Name: "pearsonr",
+								},
+							}, &ast.Property{
+								BaseNode: ast.BaseNode{
+									Errors: nil,
+									Loc: &ast.SourceLocation{
+										End: ast.Position{
+											Column: 69,
+											Line:   103,
+										},
+										File:   "universe.flux",
+										Source: "columns:[\"_value_x\",\"_value_y\"]",
+										Start: ast.Position{
+											Column: 38,
+											Line:   103,
+										},
+									},
+								},
+								Key: &ast.Identifier{
+									BaseNode: ast.BaseNode{
+										Errors: nil,
+										Loc: &ast.SourceLocation{
+											End: ast.Position{
+												Column: 45,
+												Line:   103,
+											},
+											File:   "universe.flux",
+											Source: "columns",
+											Start: ast.Position{
+												Column: 38,
+												Line:   103,
+											},
+										},
+									},
+									Name: "columns",
+								},
+								Value: &ast.ArrayExpression{
+									BaseNode: ast.BaseNode{
+										Errors: nil,
+										Loc: &ast.SourceLocation{
+											End: ast.Position{
+												Column: 69,
+												Line:   103,
+											},
+											File:   "universe.flux",
+											Source: "[\"_value_x\",\"_value_y\"]",
+											Start: ast.Position{
+												Column: 46,
+												Line:   103,
+											},
+										},
+									},
+									Elements: []ast.Expression{&ast.StringLiteral{
+										BaseNode: ast.BaseNode{
+											Errors: nil,
+											Loc: &ast.SourceLocation{
+												End: ast.Position{
+													Column: 57,
+													Line:   103,
+												},
+												File:   "universe.flux",
+												Source: "\"_value_x\"",
+												Start: ast.Position{
+													Column: 47,
+													Line:   103,
+												},
+											},
+										},
+										Value: "_value_x",
+									}, &ast.StringLiteral{
+										BaseNode: ast.BaseNode{
+											Errors: nil,
+											Loc: &ast.SourceLocation{
+												End: ast.Position{
+													Column: 68,
+													Line:   103,
+												},
+												File:   "universe.flux",
+												Source: "\"_value_y\"",
+												Start: ast.Position{
+													Column: 58,
+													Line:   103,
+												},
+											},
+										},
+										Value: "_value_y",
+									}},
+								},
+							}},
+						}},
+						BaseNode: ast.BaseNode{
+							Errors: nil,
+							Loc: &ast.SourceLocation{
+								End: ast.Position{
+									Column: 70,
+									Line:   103,
+								},
+								File:   "universe.flux",
+								Source: "covariance(pearsonr:pearsonr, columns:[\"_value_x\",\"_value_y\"])",
+								Start: ast.Position{
+									Column: 8,
+									Line:   103,
+								},
+							},
+						},
+						Callee: &ast.Identifier{
+							BaseNode: ast.BaseNode{
+								Errors: nil,
+								Loc: &ast.SourceLocation{
+									End: ast.Position{
+										Column: 18,
+										Line:   103,
+									},
+									File:   "universe.flux",
+									Source: "covariance",
+									Start: ast.Position{
+										Column: 8,
+										Line:   103,
+									},
+								},
+							},
+							Name: "covariance",
+						},
+					},
+				},
+				Params: []*ast.Property{&ast.Property{
+					BaseNode: ast.BaseNode{
+						Errors: nil,
+						Loc: &ast.SourceLocation{
+							End: ast.Position{
+								Column: 9,
+								Line:   98,
+							},
+							File:   "universe.flux",
+							Source: "x",
+							Start: ast.Position{
+								Column: 8,
+								Line:   98,
+							},
+						},
+					},
+					Key: &ast.Identifier{
+						BaseNode: ast.BaseNode{
+							Errors: nil,
+							Loc: &ast.SourceLocation{
+								End: ast.Position{
+									Column: 9,
+									Line:   98,
+								},
+								File:   "universe.flux",
+								Source: "x",
+								Start: ast.Position{
+									Column: 8,
+									Line:   98,
+								},
+							},
+						},
+						Name: "x",
+					},
+					Value: nil,
+				}, &ast.Property{
+					BaseNode: ast.BaseNode{
+						Errors: nil,
+						Loc: &ast.SourceLocation{
+							End: ast.Position{
+								Column: 11,
+								Line:   98,
+							},
+							File:   "universe.flux",
+							Source: "y",
+							Start: ast.Position{
+								Column: 10,
+								Line:   98,
+							},
+						},
+					},
+					Key: &ast.Identifier{
+						BaseNode: ast.BaseNode{
+							Errors: nil,
+							Loc: &ast.SourceLocation{
+								End: ast.Position{
+									Column: 11,
+									Line:   98,
+								},
+								File:   "universe.flux",
+								Source: "y",
+								Start: ast.Position{
+									Column: 10,
+									Line:   98,
+								},
+							},
+						},
+						Name: "y",
+					},
+					Value: nil,
+				}, &ast.Property{
+					BaseNode: ast.BaseNode{
+						Errors: nil,
+						Loc: &ast.SourceLocation{
+							End: ast.Position{
+								Column: 14,
+								Line:   98,
+							},
+							File:   "universe.flux",
+							Source: "on",
+							Start: ast.Position{
+								Column: 12,
+								Line:   98,
+							},
+						},
+					},
+					Key: &ast.Identifier{
+						BaseNode: ast.BaseNode{
+							Errors: nil,
+							Loc: &ast.SourceLocation{
+								End: ast.Position{
+									Column: 14,
+									Line:   98,
+								},
+								File:   "universe.flux",
+								Source: "on",
+								Start: ast.Position{
+									Column: 12,
+									Line:   98,
+								},
+							},
+						},
+						Name: "on",
+					},
+					Value: nil,
+				}, &ast.Property{
+					BaseNode: ast.BaseNode{
+						Errors: nil,
+						Loc: &ast.SourceLocation{
+							End: ast.Position{
+								Column: 29,
+								Line:   98,
+							},
+							File:   "universe.flux",
+							Source: "pearsonr=false",
+							Start: ast.Position{
+								Column: 15,
+								Line:   98,
+							},
+						},
+					},
+					Key: &ast.Identifier{
+						BaseNode: ast.BaseNode{
+							Errors: nil,
+							Loc: &ast.SourceLocation{
+								End: ast.Position{
+									Column: 23,
+									Line:   98,
+								},
+								File:   "universe.flux",
+								Source: "pearsonr",
+								Start: ast.Position{
+									Column: 15,
+									Line:   98,
+								},
+							},
+						},
+						Name: "pearsonr",
+					},
+					Value: &ast.Identifier{
+						BaseNode: ast.BaseNode{
+							Errors: nil,
+							Loc: &ast.SourceLocation{
+								End: ast.Position{
+									Column: 29,
+									Line:   98,
+								},
+								File:   "universe.flux",
+								Source: "false",
+								Start: ast.Position{
+									Column: 24,
+									Line:   98,
+								},
+							},
+						},
+						Name: "false",
+					},
+				}},
+			},
+		}, &ast.VariableAssignment{
+			BaseNode: ast.BaseNode{
+				Errors: nil,
+				Loc: &ast.SourceLocation{
+					End: ast.Position{
+						Column: 59,
+						Line:   105,
+					},
+					File:   "universe.flux",
+					Source: "pearsonr = (x,y,on) => cov(x:x, y:y, on:on, pearsonr:true)",
+					Start: ast.Position{
+						Column: 1,
+						Line:   105,
+					},
+				},
+			},
+			ID: &ast.Identifier{
+				BaseNode: ast.BaseNode{
+					Errors: nil,
+					Loc: &ast.SourceLocation{
+						End: ast.Position{
+							Column: 9,
+							Line:   105,
+						},
+						File:   "universe.flux",
+						Source: "pearsonr",
+						Start: ast.Position{
+							Column: 1,
+							Line:   105,
+						},
+					},
+				},
+				Name: "pearsonr",
+			},
+			Init: &ast.FunctionExpression{
+				BaseNode: ast.BaseNode{
+					Errors: nil,
+					Loc: &ast.SourceLocation{
+						End: ast.Position{
+							Column: 59,
+							Line:   105,
+						},
+						File:   "universe.flux",
+						Source: "(x,y,on) => cov(x:x, y:y, on:on, pearsonr:true)",
+						Start: ast.Position{
+							Column: 12,
+							Line:   105,
+						},
+					},
+				},
+				Body: &ast.CallExpression{
+					Arguments: []ast.Expression{&ast.ObjectExpression{
+						BaseNode: ast.BaseNode{
+							Errors: nil,
+							Loc: &ast.SourceLocation{
+								End: ast.Position{
+									Column: 58,
+									Line:   105,
+								},
+								File:   "universe.flux",
+								Source: "x:x, y:y, on:on, pearsonr:true",
+								Start: ast.Position{
+									Column: 28,
+									Line:   105,
+								},
+							},
+						},
+						Properties: []*ast.Property{&ast.Property{
 							BaseNode: ast.BaseNode{
 								Errors: nil,
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
-										Column: 14,
-										Line:   79,
+										Column: 31,
+										Line:   105,
 									},
 									File:   "universe.flux",
-									Source: "tables:{x:x, y:y},\n        on:on",
+									Source: "x:x",
 									Start: ast.Position{
-										Column: 9,
-										Line:   78,
+										Column: 28,
+										Line:   105,
 									},
 								},
 							},
-							Properties: []*ast.Property{&ast.Property{
+							Key: &ast.Identifier{
 								BaseNode: ast.BaseNode{
 									Errors: nil,
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
-											Column: 26,
-											Line:   78,
+											Column: 29,
+											Line:   105,
 										},
 										File:   "universe.flux",
-										Source: "tables:{x:x, y:y}",
+										Source: "x",
 										Start: ast.Position{
-											Column: 9,
-											Line:   78,
+											Column: 28,
+											Line:   105,
 										},
 									},
 								},
-								Key: &ast.Identifier{
-									BaseNode: ast.BaseNode{
-										Errors: nil,
-										Loc: &ast.SourceLocation{
-											End: ast.Position{
-												Column: 15,
-												Line:   78,
-											},
-											File:   "universe.flux",
-											Source: "tables",
-											Start: ast.Position{
-												Column: 9,
-												Line:   78,
-											},
+								Name: "x",
+							},
+							Value: &ast.Identifier{
+								BaseNode: ast.BaseNode{
+									Errors: nil,
+									Loc: &ast.SourceLocation{
+										End: ast.Position{
+											Column: 31,
+											Line:   105,
+										},
+										File:   "universe.flux",
+										Source: "x",
+										Start: ast.Position{
+											Column: 30,
+											Line:   105,
 										},
 									},
-									Name: "tables",
 								},
-								Value: &ast.ObjectExpression{
-									BaseNode: ast.BaseNode{
-										Errors: nil,
-										Loc: &ast.SourceLocation{
-											End: ast.Position{
-												Column: 26,
-												Line:   78,
-											},
-											File:   "universe.flux",
-											Source: "{x:x, y:y}",
-											Start: ast.Position{
-												Column: 16,
-												Line:   78,
-											},
-										},
+								Name: "x",
+							},
+						}, &ast.Property{
+							BaseNode: ast.BaseNode{
+								Errors: nil,
+								Loc: &ast.SourceLocation{
+									End: ast.Position{
+										Column: 36,
+										Line:   105,
 									},
-									Properties: []*ast.Property{&ast.Property{
-										BaseNode: ast.BaseNode{
-											Errors: nil,
-											Loc: &ast.SourceLocation{
-												End: ast.Position{
-													Column: 20,
-													Line:   78,
-												},
-												File:   "universe.flux",
-												Source: "x:x",
-												Start: ast.Position{
-													Column: 17,
-													Line:   78,
-												},
-											},
+									File:   "universe.flux",
+									Source: "y:y",
+									Start: ast.Position{
+										Column: 33,
+										Line:   105,
+									},
+								},
+							},
+							Key: &ast.Identifier{
+								BaseNode: ast.BaseNode{
+									Errors: nil,
+									Loc: &ast.SourceLocation{
+										End: ast.Position{
+											Column: 34,
+											Line:   105,
 										},
-										Key: &ast.Identifier{
-											BaseNode: ast.BaseNode{
-												Errors: nil,
-												Loc: &ast.SourceLocation{
-													End: ast.Position{
-														Column: 18,
-														Line:   78,
-													},
-													File:   "universe.flux",
-													Source: "x",
-													Start: ast.Position{
-														Column: 17,
-														Line:   78,
-													},
-												},
-											},
-											Name: "x",
+										File:   "universe.flux",
+										Source: "y",
+										Start: ast.Position{
+											Column: 33,
+											Line:   105,
 										},
-										Value: &ast.Identifier{
-											BaseNode: ast.BaseNode{
-												Errors: nil,
-												Loc: &ast.SourceLocation{
-													End: ast.Position{
-														Column: 20,
-														Line:   78,
-													},
-													File:   "universe.flux",
-													Source: "x",
-													Start: ast.Position{
-														Column: 19,
-														Line:   78,
-													},
-												},
-											},
-											Name: "x",
+									},
+								},
+								Name: "y",
+							},
+							Value: &ast.Identifier{
+								BaseNode: ast.BaseNode{
+									Errors: nil,
+									Loc: &ast.SourceLocation{
+										End: ast.Position{
+											Column: 36,
+											Line:   105,
 										},
-									}, &ast.Property{
-										BaseNode: ast.BaseNode{
-											Errors: nil,
-											Loc: &ast.SourceLocation{
-												End: ast.Position{
-													Column: 25,
-													Line:   78,
-												},
-												File:   "universe.flux",
-												Source: "y:y",
-												Start: ast.Position{
-													Column: 22,
-													Line:   78,
-												},
-											},
+										File:   "universe.flux",
+										Source: "y",
+										Start: ast.Position{
+											Column: 35,
+											Line:   105,
 										},
-										Key: &ast.Identifier{
-											BaseNode: ast.BaseNode{
-												Errors: nil,
-												Loc: &ast.SourceLocation{
-													End: ast.Position{
-														Column: 23,
-														Line:   78,
-													},
-													File:   "universe.flux",
-													Source: "y",
-													Start: ast.Position{
-														Column: 22,
-														Line:   78,
-													},
-												},
-											},
-											Name: "y",
+									},
+								},
+								Name: "y",
+							},
+						}, &ast.Property{
+							BaseNode: ast.BaseNode{
+								Errors: nil,
+								Loc: &ast.SourceLocation{
+									End: ast.Position{
+										Column: 43,
+										Line:   105,
+									},
+									File:   "universe.flux",
+									Source: "on:on",
+									Start: ast.Position{
+										Column: 38,
+										Line:   105,
+									},
+								},
+							},
+							Key: &ast.Identifier{
+								BaseNode: ast.BaseNode{
+									Errors: nil,
+									Loc: &ast.SourceLocation{
+										End: ast.Position{
+											Column: 40,
+											Line:   105,
 										},
-										Value: &ast.Identifier{
-											BaseNode: ast.BaseNode{
-												Errors: nil,
-												Loc: &ast.SourceLocation{
-													End: ast.Position{
-														Column: 25,
-														Line:   78,
-													},
-													File:   "universe.flux",
-													Source: "y",
-													Start: ast.Position{
-														Column: 24,
-														Line:   78,
-													},
-												},
-											},
-											Name: "y",
+										File:   "universe.flux",
+										Source: "on",
+										Start: ast.Position{
+											Column: 38,
+											Line:   105,
 										},
-									}},
+									},
 								},
-							}, &ast.Property{
+								Name: "on",
+							},
+							Value: &ast.Identifier{
 								BaseNode: ast.BaseNode{
 									Errors: nil,
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
-											Column: 14,
-											Line:   79,
+											Column: 43,
+											Line:   105,
 										},
 										File:   "universe.flux",
-										Source: "on:on",
+										Source: "on",
 										Start: ast.Position{
-											Column: 9,
-											Line:   79,
+											Column: 41,
+											Line:   105,
 										},
 									},
 								},
-								Key: &ast.Identifier{
-									BaseNode: ast.BaseNode{
-										Errors: nil,
-										Loc: &ast.SourceLocation{
-											End: ast.Position{
-												Column: 11,
-												Line:   79,
-											},
-											File:   "universe.flux",
-											Source: "on",
-											Start: ast.Position{
-												Column: 9,
-												Line:   79,
-											},
+								Name: "on",
+							},
+						}, &ast.Property{
+							BaseNode: ast.BaseNode{
+								Errors: nil,
+								Loc: &ast.SourceLocation{
+									End: ast.Position{
+										Column: 58,
+										Line:   105,
+									},
+									File:   "universe.flux",
+									Source: "pearsonr:true",
+									Start: ast.Position{
+										Column: 45,
+										Line:   105,
+									},
+								},
+							},
+							Key: &ast.Identifier{
+								BaseNode: ast.BaseNode{
+									Errors: nil,
+									Loc: &ast.SourceLocation{
+										End: ast.Position{
+											Column: 53,
+											Line:   105,
+										},
+										File:   "universe.flux",
+										Source: "pearsonr",
+										Start: ast.Position{
+											Column: 45,
+											Line:   105,
 										},
 									},
-									Name: "on",
 								},
-								Value: &ast.Identifier{
-									BaseNode: ast.BaseNode{
-										Errors: nil,
-										Loc: &ast.SourceLocation{
-											End: ast.Position{
-												Column: 14,
-												Line:   79,
-											},
-											File:   "universe.flux",
-											Source: "on",
-											Start: ast.Position{
-												Column: 12,
-												Line:   79,
-											},
+								Name: "pearsonr",
+							},
+							Value: &ast.Identifier{
+								BaseNode: ast.BaseNode{
+									Errors: nil,
+									Loc: &ast.SourceLocation{
+										End: ast.Position{
+											Column: 58,
+											Line:   105,
+										},
+										File:   "universe.flux",
+										Source: "true",
+										Start: ast.Position{
+											Column: 54,
+											Line:   105,
 										},
 									},
-									Name: "on",
 								},
-							}},
+								Name: "true",
+							},
 						}},
+					}},
+					BaseNode: ast.BaseNode{
+						Errors: nil,
+						Loc: &ast.SourceLocation{
+							End: ast.Position{
+								Column: 59,
+								Line:   105,
+							},
+							File:   "universe.flux",
+							Source: "cov(x:x, y:y, on:on, pearsonr:true)",
+							Start: ast.Position{
+								Column: 24,
+								Line:   105,
+							},
+						},
+					},
+					Callee: &ast.Identifier{
 						BaseNode: ast.BaseNode{
 							Errors: nil,
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
-									Column: 6,
-									Line:   80,
+									Column: 27,
+									Line:   105,
 								},
 								File:   "universe.flux",
-								Source: "join(\n        tables:{x:x, y:y},\n        on:on,\n    )",
+								Source: "cov",
 								Start: ast.Position{
-									Column: 5,
-									Line:   77,
+									Column: 24,
+									Line:   105,
 								},
 							},
 						},
-						Callee: &ast.Identifier{
-							BaseNode: ast.BaseNode{
-								Errors: nil,
-								Loc: &ast.SourceLocation{
-									End: ast.Position{
-										Column: 9,
-										Line:   77,
-									},
-									File:   "universe.flux",
-									Source: "join",
-									Start: ast.Position{
-										Column: 5,
-										Line:   77,
-									},
+						Name: "cov",
+					},
+				},
+				Params: []*ast.Property{&ast.Property{
+					BaseNode: ast.BaseNode{
+						Errors: nil,
+						Loc: &ast.SourceLocation{
+							End: ast.Position{
+								Column: 14,
+								Line:   105,
+							},
+							File:   "universe.flux",
+							Source: "x",
+							Start: ast.Position{
+								Column: 13,
+								Line:   105,
+							},
+						},
+					},
+					Key: &ast.Identifier{
+						BaseNode: ast.BaseNode{
+							Errors: nil,
+							Loc: &ast.SourceLocation{
+								End: ast.Position{
+									Column: 14,
+									Line:   105,
+								},
+								File:   "universe.flux",
+								Source: "x",
+								Start: ast.Position{
+									Column: 13,
+									Line:   105,
 								},
 							},
-							Name: "join",
 						},
+						Name: "x",
 					},
+					Value: nil,
+				}, &ast.Property{
 					BaseNode: ast.BaseNode{
 						Errors: nil,
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
-								Column: 70,
-								Line:   81,
+								Column: 16,
+								Line:   105,
 							},
 							File:   "universe.flux",
-							Source: "join(\n        tables:{x:x, y:y},\n        on:on,\n    )\n    |> covariance(pearsonr:pearsonr, columns:[\"_value_x\",\"_value_y\"])",
+							Source: "y",
+							Start: ast.Position{
+								Column: 15,
+								Line:   105,
+							},
+						},
+					},
+					Key: &ast.Identifier{
+						BaseNode: ast.BaseNode{
+							Errors: nil,
+							Loc: &ast.SourceLocation{
+								End: ast.Position{
+									Column: 16,
+									Line:   105,
+								},
+								File:   "universe.flux",
+								Source: "y",
+								Start: ast.Position{
+									Column: 15,
+									Line:   105,
+								},
+							},
+						},
+						Name: "y",
+					},
+					Value: nil,
+				}, &ast.Property{
+					BaseNode: ast.BaseNode{
+						Errors: nil,
+						Loc: &ast.SourceLocation{
+							End: ast.Position{
+								Column: 19,
+								Line:   105,
+							},
+							File:   "universe.flux",
+							Source: "on",
 							Start: ast.Position{
-								Column: 5,
-								Line:   77,
+								Column: 17,
+								Line:   105,
 							},
 						},
 					},
-					Call: &ast.CallExpression{
-						Arguments: []ast.Expression{&ast.ObjectExpression{
-							BaseNode: ast.BaseNode{
-								Errors: nil,
-								Loc: &ast.SourceLocation{
-									End: ast.Position{
-										Column: 69,
-										Line:   81,
-									},
-									File:   "universe.flux",
-									Source: "pearsonr:pearsonr, columns:[\"_value_x\",\"_value_y\"]",
-									Start: ast.Position{
-										Column: 19,
-										Line:   81,
-									},
-								},
-							},
-							Properties: []*ast.Property{&ast.Property{
-								BaseNode: ast.BaseNode{
-									Errors: nil,
-									Loc: &ast.SourceLocation{
-										End: ast.Position{
-											Column: 36,
-											Line:   81,
-										},
-										File:   "universe.flux",
-										Source: "pearsonr:pearsonr",
-										Start: ast.Position{
-											Column: 19,
-											Line:   81,
-										},
-									},
+					Key: &ast.Identifier{
+						BaseNode: ast.BaseNode{
+							Errors: nil,
+							Loc: &ast.SourceLocation{
+								End: ast.Position{
+									Column: 19,
+									Line:   105,
 								},
-								Key: &ast.Identifier{
-									BaseNode: ast.BaseNode{
-										Errors: nil,
-										Loc: &ast.SourceLocation{
-											End: ast.Position{
-												Column: 27,
-												Line:   81,
-											},
-											File:   "universe.flux",
-											Source: "pearsonr",
-											Start: ast.Position{
-												Column: 19,
-												Line:   81,
-											},
-										},
-									},
-									Name: "pearsonr",
+								File:   "universe.flux",
+								Source: "on",
+								Start: ast.Position{
+									Column: 17,
+									Line:   105,
 								},
-								Value: &ast.Identifier{
+							},
+						},
+						Name: "on",
+					},
+					Value: nil,
+				}},
+			},
+		}, &ast.VariableAssignment{
+			BaseNode: ast.BaseNode{
+				Errors: nil,
+				Loc: &ast.SourceLocation{
+					End: ast.Position{
+						Column: 49,
+						Line:   115,
+					},
+					File:   "universe.flux",
+					Source: "aggregateWindow = (every, fn, columns=[\"_value\"], timeSrc=\"_stop\",timeDst=\"_time\", createEmpty=true, tables=<-) =>\n    tables\n        |> window(every:every, createEmpty: createEmpty)\n        |> fn(columns:columns)\n        |> duplicate(column:timeSrc,as:timeDst)\n        |> window(every:inf, timeColumn:timeDst)",
+					Start: ast.Position{
+						Column: 1,
+						Line:   110,
+					},
+				},
+			},
+			ID: &ast.Identifier{
+				BaseNode: ast.BaseNode{
+					Errors: nil,
+					Loc: &ast.SourceLocation{
+						End: ast.Position{
+							Column: 16,
+							Line:   110,
+						},
+						File:   "universe.flux",
+						Source: "aggregateWindow",
+						Start: ast.Position{
+							Column: 1,
+							Line:   110,
+						},
+					},
+				},
+				Name: "aggregateWindow",
+			},
+			Init: &ast.FunctionExpression{
+				BaseNode: ast.BaseNode{
+					Errors: nil,
+					Loc: &ast.SourceLocation{
+						End: ast.Position{
+							Column: 49,
+							Line:   115,
+						},
+						File:   "universe.flux",
+						Source: "(every, fn, columns=[\"_value\"], timeSrc=\"_stop\",timeDst=\"_time\", createEmpty=true, tables=<-) =>\n    tables\n        |> window(every:every, createEmpty: createEmpty)\n        |> fn(columns:columns)\n        |> duplicate(column:timeSrc,as:timeDst)\n        |> window(every:inf, timeColumn:timeDst)",
+						Start: ast.Position{
+							Column: 19,
+							Line:   110,
+						},
+					},
+				},
+				Body: &ast.PipeExpression{
+					Argument: &ast.PipeExpression{
+						Argument: &ast.PipeExpression{
+							Argument: &ast.PipeExpression{
+								Argument: &ast.Identifier{
 									BaseNode: ast.BaseNode{
 										Errors: nil,
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
-												Column: 36,
-												Line:   81,
+												Column: 11,
+												Line:   111,
 											},
 											File:   "universe.flux",
-											Source: "pearsonr",
+											Source: "tables",
 											Start: ast.Position{
-												Column: 28,
-												Line:   81,
+												Column: 5,
+												Line:   111,
 											},
 										},
 									},
-									Name: "pearsonr",
+									Name: "tables",
 								},
-							}, &ast.Property{
 								BaseNode: ast.BaseNode{
 									Errors: nil,
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
-											Column: 69,
-											Line:   81,
+											Column: 57,
+											Line:   112,
 										},
 										File:   "universe.flux",
-										Source: "columns:[\"_value_x\",\"_value_y\"]",
+										Source: "tables\n        |> window(every:every, createEmpty: createEmpty)",
 										Start: ast.Position{
-											Column: 38,
-											Line:   81,
+											Column: 5,
+											Line:   111,
 										},
 									},
 								},
-								Key: &ast.Identifier{
+								Call: &ast.CallExpression{
+									Arguments: []ast.Expression{&ast.ObjectExpression{
+										BaseNode: ast.BaseNode{
+											Errors: nil,
+											Loc: &ast.SourceLocation{
+												End: ast.Position{
+													Column: 56,
+													Line:   112,
+												},
+												File:   "universe.flux",
+												Source: "every:every, createEmpty: createEmpty",
+												Start: ast.Position{
+													Column: 19,
+													Line:   112,
+												},
+											},
+										},
+										Properties: []*ast.Property{&ast.Property{
+											BaseNode: ast.BaseNode{
+												Errors: nil,
+												Loc: &ast.SourceLocation{
+													End: ast.Position{
+														Column: 30,
+														Line:   112,
+													},
+													File:   "universe.flux",
+													Source: "every:every",
+													Start: ast.Position{
+														Column: 19,
+														Line:   112,
+													},
+												},
+											},
+											Key: &ast.Identifier{
+												BaseNode: ast.BaseNode{
+													Errors: nil,
+													Loc: &ast.SourceLocation{
+														End: ast.Position{
+															Column: 24,
+															Line:   112,
+														},
+														File:   "universe.flux",
+														Source: "every",
+														Start: ast.Position{
+															Column: 19,
+															Line:   112,
+														},
+													},
+												},
+												Name: "every",
+											},
+											Value: &ast.Identifier{
+												BaseNode: ast.BaseNode{
+													Errors: nil,
+													Loc: &ast.SourceLocation{
+														End: ast.Position{
+															Column: 30,
+															Line:   112,
+														},
+														File:   "universe.flux",
+														Source: "every",
+														Start: ast.Position{
+															Column: 25,
+															Line:   112,
+														},
+													},
+												},
+												Name: "every",
+											},
+										}, &ast.Property{
+											BaseNode: ast.BaseNode{
+												Errors: nil,
+												Loc: &ast.SourceLocation{
+													End: ast.Position{
+														Column: 56,
+														Line:   112,
+													},
+													File:   "universe.flux",
+													Source: "createEmpty: createEmpty",
+													Start: ast.Position{
+														Column: 32,
+														Line:   112,
+													},
+												},
+											},
+											Key: &ast.Identifier{
+												BaseNode: ast.BaseNode{
+													Errors: nil,
+													Loc: &ast.SourceLocation{
+														End: ast.Position{
+															Column: 43,
+															Line:   112,
+														},
+														File:   "universe.flux",
+														Source: "createEmpty",
+														Start: ast.Position{
+															Column: 32,
+															Line:   112,
+														},
+													},
+												},
+												Name: "createEmpty",
+											},
+											Value: &ast.Identifier{
+												BaseNode: ast.BaseNode{
+													Errors: nil,
+													Loc: &ast.SourceLocation{
+														End: ast.Position{
+															Column: 56,
+															Line:   112,
+														},
+														File:   "universe.flux",
+														Source: "createEmpty",
+														Start: ast.Position{
+															Column: 45,
+															Line:   112,
+														},
+													},
+												},
+												Name: "createEmpty",
+											},
+										}},
+									}},
 									BaseNode: ast.BaseNode{
 										Errors: nil,
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
-												Column: 45,
-												Line:   81,
+												Column: 57,
+												Line:   112,
 											},
 											File:   "universe.flux",
-											Source: "columns",
+											Source: "window(every:every, createEmpty: createEmpty)",
 											Start: ast.Position{
-												Column: 38,
-												Line:   81,
+												Column: 12,
+												Line:   112,
+											},
+										},
+									},
+									Callee: &ast.Identifier{
+										BaseNode: ast.BaseNode{
+											Errors: nil,
+											Loc: &ast.SourceLocation{
+												End: ast.Position{
+													Column: 18,
+													Line:   112,
+												},
+												File:   "universe.flux",
+												Source: "window",
+												Start: ast.Position{
+													Column: 12,
+													Line:   112,
+												},
 											},
 										},
+										Name: "window",
 									},
-									Name: "columns",
 								},
-								Value: &ast.ArrayExpression{
+							},
+							BaseNode: ast.BaseNode{
+								Errors: nil,
+								Loc: &ast.SourceLocation{
+									End: ast.Position{
+										Column: 31,
+										Line:   113,
+									},
+									File:   "universe.flux",
+									Source: "tables\n        |> window(every:every, createEmpty: createEmpty)\n        |> fn(columns:columns)",
+									Start: ast.Position{
+										Column: 5,
+										Line:   111,
+									},
+								},
+							},
+							Call: &ast.CallExpression{
+								Arguments: []ast.Expression{&ast.ObjectExpression{
 									BaseNode: ast.BaseNode{
 										Errors: nil,
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
-												Column: 69,
-												Line:   81,
+												Column: 30,
+												Line:   113,
 											},
 											File:   "universe.flux",
-											Source: "[\"_value_x\",\"_value_y\"]",
+											Source: "columns:columns",
 											Start: ast.Position{
-												Column: 46,
-												Line:   81,
+												Column: 15,
+												Line:   113,
 											},
 										},
 									},
-									Elements: []ast.Expression{&ast.StringLiteral{
+									Properties: []*ast.Property{&ast.Property{
 										BaseNode: ast.BaseNode{
 											Errors: nil,
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
-													Column: 57,
-													Line:   81,
+													Column: 30,
+													Line:   113,
 												},
 												File:   "universe.flux",
-												Source: "\"_value_x\"",
+												Source: "columns:columns",
 												Start: ast.Position{
-													Column: 47,
-													Line:   81,
+													Column: 15,
+													Line:   113,
 												},
 											},
 										},
-										Value: "_value_x",
-									}, &ast.StringLiteral{
-										BaseNode: ast.BaseNode{
-											Errors: nil,
-											Loc: &ast.SourceLocation{
-												End: ast.Position{
-													Column: 68,
-													Line:   81,
+										Key: &ast.Identifier{
+											BaseNode: ast.BaseNode{
+												Errors: nil,
+												Loc: &ast.SourceLocation{
+													End: ast.Position{
+														Column: 22,
+														Line:   113,
+													},
+													File:   "universe.flux",
+													Source: "columns",
+													Start: ast.Position{
+														Column: 15,
+														Line:   113,
+													},
 												},
-												File:   "universe.flux",
-												Source: "\"_value_y\"",
-												Start: ast.Position{
-													Column: 58,
-													Line:   81,
+											},
+											Name: "columns",
+										},
+										Value: &ast.Identifier{
+											BaseNode: ast.BaseNode{
+												Errors: nil,
+												Loc: &ast.SourceLocation{
+													End: ast.Position{
+														Column: 30,
+														Line:   113,
+													},
+													File:   "universe.flux",
+													Source: "columns",
+													Start: ast.Position{
+														Column: 23,
+														Line:   113,
+													},
 												},
 											},
+											Name: "columns",
 										},
-										Value: "_value_y",
 									}},
-								},
-							}},
-						}},
-						BaseNode: ast.BaseNode{
-							Errors: nil,
-							Loc: &ast.SourceLocation{
-								End: ast.Position{
-									Column: 70,
-									Line:   81,
-								},
-								File:   "universe.flux",
-								Source: "covariance(pearsonr:pearsonr, columns:[\"_value_x\",\"_value_y\"])",
-								Start: ast.Position{
-									Column: 8,
-									Line:   81,
-								},
-							},
-						},
-						Callee: &ast.Identifier{
-							BaseNode: ast.BaseNode{
-								Errors: nil,
-								Loc: &ast.SourceLocation{
-									End: ast.Position{
-										Column: 18,
-										Line:   81,
-									},
-									File:   "universe.flux",
-									Source: "covariance",
-									Start: ast.Position{
-										Column: 8,
-										Line:   81,
-									},
-								},
-							},
-							Name: "covariance",
-						},
-					},
-				},
-				Params: []*ast.Property{&ast.Property{
-					BaseNode: ast.BaseNode{
-						Errors: nil,
-						Loc: &ast.SourceLocation{
-							End: ast.Position{
-								Column: 9,
-								Line:   76,
-							},
-							File:   "universe.flux",
-							Source: "x",
-							Start: ast.Position{
-								Column: 8,
-								Line:   76,
-							},
-						},
-					},
-					Key: &ast.Identifier{
-						BaseNode: ast.BaseNode{
-							Errors: nil,
-							Loc: &ast.SourceLocation{
-								End: ast.Position{
-									Column: 9,
-									Line:   76,
-								},
-								File:   "universe.flux",
-								Source: "x",
-								Start: ast.Position{
-									Column: 8,
-									Line:   76,
-								},
-							},
-						},
-						Name: "x",
-					},
-					Value: nil,
-				}, &ast.Property{
-					BaseNode: ast.BaseNode{
-						Errors: nil,
-						Loc: &ast.SourceLocation{
-							End: ast.Position{
-								Column: 11,
-								Line:   76,
-							},
-							File:   "universe.flux",
-							Source: "y",
-							Start: ast.Position{
-								Column: 10,
-								Line:   76,
-							},
-						},
-					},
-					Key: &ast.Identifier{
-						BaseNode: ast.BaseNode{
-							Errors: nil,
-							Loc: &ast.SourceLocation{
-								End: ast.Position{
-									Column: 11,
-									Line:   76,
-								},
-								File:   "universe.flux",
-								Source: "y",
-								Start: ast.Position{
-									Column: 10,
-									Line:   76,
-								},
-							},
-						},
-						Name: "y",
-					},
-					Value: nil,
-				}, &ast.Property{
-					BaseNode: ast.BaseNode{
-						Errors: nil,
-						Loc: &ast.SourceLocation{
-							End: ast.Position{
-								Column: 14,
-								Line:   76,
-							},
-							File:   "universe.flux",
-							Source: "on",
-							Start: ast.Position{
-								Column: 12,
-								Line:   76,
-							},
-						},
-					},
-					Key: &ast.Identifier{
-						BaseNode: ast.BaseNode{
-							Errors: nil,
-							Loc: &ast.SourceLocation{
-								End: ast.Position{
-									Column: 14,
-									Line:   76,
-								},
-								File:   "universe.flux",
-								Source: "on",
-								Start: ast.Position{
-									Column: 12,
-									Line:   76,
-								},
-							},
-						},
-						Name: "on",
-					},
-					Value: nil,
-				}, &ast.Property{
-					BaseNode: ast.BaseNode{
-						Errors: nil,
-						Loc: &ast.SourceLocation{
-							End: ast.Position{
-								Column: 29,
-								Line:   76,
-							},
-							File:   "universe.flux",
-							Source: "pearsonr=false",
-							Start: ast.Position{
-								Column: 15,
-								Line:   76,
-							},
-						},
-					},
-					Key: &ast.Identifier{
-						BaseNode: ast.BaseNode{
-							Errors: nil,
-							Loc: &ast.SourceLocation{
-								End: ast.Position{
-									Column: 23,
-									Line:   76,
-								},
-								File:   "universe.flux",
-								Source: "pearsonr",
-								Start: ast.Position{
-									Column: 15,
-									Line:   76,
-								},
-							},
-						},
-						Name: "pearsonr",
-					},
-					Value: &ast.Identifier{
-						BaseNode: ast.BaseNode{
-							Errors: nil,
-							Loc: &ast.SourceLocation{
-								End: ast.Position{
-									Column: 29,
-									Line:   76,
-								},
-								File:   "universe.flux",
-								Source: "false",
-								Start: ast.Position{
-									Column: 24,
-									Line:   76,
+								}},
+								BaseNode: ast.BaseNode{
+									Errors: nil,
+									Loc: &ast.SourceLocation{
+										End: ast.Position{
+											Column: 31,
+											Line:   113,
+										},
+										File:   "universe.flux",
+										Source: "fn(columns:columns)",
+										Start: ast.Position{
+											Column: 12,
+											Line:   113,
+										},
+									},
+								},
+								Callee: &ast.Identifier{
+									BaseNode: ast.BaseNode{
+										Errors: nil,
+										Loc: &ast.SourceLocation{
+											End: ast.Position{
+												Column: 14,
+												Line:   113,
+											},
+											File:   "universe.flux",
+											Source: "fn",
+											Start: ast.Position{
+												Column: 12,
+												Line:   113,
+											},
+										},
+									},
+									Name: "fn",
 								},
 							},
 						},
-						Name: "false",
-					},
-				}},
-			},
-		}, &ast.VariableAssignment{
-			BaseNode: ast.BaseNode{
-				Errors: nil,
-				Loc: &ast.SourceLocation{
-					End: ast.Position{
-						Column: 59,
-						Line:   83,
-					},
-					File:   "universe.flux",
-					Source: "pearsonr = (x,y,on) => cov(x:x, y:y, on:on, pearsonr:true)",
-					Start: ast.Position{
-						Column: 1,
-						Line:   83,
-					},
-				},
-			},
-			ID: &ast.Identifier{
-				BaseNode: ast.BaseNode{
-					Errors: nil,
-					Loc: &ast.SourceLocation{
-						End: ast.Position{
-							Column: 9,
-							Line:   83,
-						},
-						File:   "universe.flux",
-						Source: "pearsonr",
-						Start: ast.Position{
-							Column: 1,
-							Line:   83,
-						},
-					},
-				},
-				Name: "pearsonr",
-			},
-			Init: &ast.FunctionExpression{
-				BaseNode: ast.BaseNode{
-					Errors: nil,
-					Loc: &ast.SourceLocation{
-						End: ast.Position{
-							Column: 59,
-							Line:   83,
-						},
-						File:   "universe.flux",
-						Source: "(x,y,on) => cov(x:x, y:y, on:on, pearsonr:true)",
-						Start: ast.Position{
-							Column: 12,
-							Line:   83,
-						},
-					},
-				},
-				Body: &ast.CallExpression{
-					Arguments: []ast.Expression{&ast.ObjectExpression{
 						BaseNode: ast.BaseNode{
 							Errors: nil,
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
-									Column: 58,
-									Line:   83,
+									Column: 48,
+									Line:   114,
 								},
 								File:   "universe.flux",
-								Source: "x:x, y:y, on:on, pearsonr:true",
+								Source: "tables\n        |> window(every:every, createEmpty: createEmpty)\n        |> fn(columns:columns)\n        |> duplicate(column:timeSrc,as:timeDst)",
 								Start: ast.Position{
-									Column: 28,
-									Line:   83,
+									Column: 5,
+									Line:   111,
 								},
 							},
 						},
-						Properties: []*ast.Property{&ast.Property{
-							BaseNode: ast.BaseNode{
-								Errors: nil,
-								Loc: &ast.SourceLocation{
-									End: ast.Position{
-										Column: 31,
-										Line:   83,
-									},
-									File:   "universe.flux",
-									Source: "x:x",
-									Start: ast.Position{
-										Column: 28,
-										Line:   83,
-									},
-								},
-							},
-							Key: &ast.Identifier{
+						Call: &ast.CallExpression{
+							Arguments: []ast.Expression{&ast.ObjectExpression{
 								BaseNode: ast.BaseNode{
 									Errors: nil,
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
-											Column: 29,
-											Line:   83,
+											Column: 47,
+											Line:   114,
 										},
 										File:   "universe.flux",
-										Source: "x",
+										Source: "column:timeSrc,as:timeDst",
 										Start: ast.Position{
-											Column: 28,
-											Line:   83,
+											Column: 22,
+											Line:   114,
 										},
 									},
 								},
-								Name: "x",
-							},
-							Value: &ast.Identifier{
-								BaseNode: ast.BaseNode{
-									Errors: nil,
-									Loc: &ast.SourceLocation{
-										End: ast.Position{
-											Column: 31,
-											Line:   83,
+								Properties: []*ast.Property{&ast.Property{
+									BaseNode: ast.BaseNode{
+										Errors: nil,
+										Loc: &ast.SourceLocation{
+											End: ast.Position{
+												Column: 36,
+												Line:   114,
+											},
+											File:   "universe.flux",
+											Source: "column:timeSrc",
+											Start: ast.Position{
+												Column: 22,
+												Line:   114,
+											},
 										},
-										File:   "universe.flux",
-										Source: "x",
-										Start: ast.Position{
-											Column: 30,
-											Line:   83,
+									},
+									Key: &ast.Identifier{
+										BaseNode: ast.BaseNode{
+											Errors: nil,
+											Loc: &ast.SourceLocation{
+												End: ast.Position{
+													Column: 28,
+													Line:   114,
+												},
+												File:   "universe.flux",
+												Source: "column",
+												Start: ast.Position{
+													Column: 22,
+													Line:   114,
+												},
+											},
 										},
+										Name: "column",
 									},
-								},
-								Name: "x",
-							},
-						}, &ast.Property{
+									Value: &ast.Identifier{
+										BaseNode: ast.BaseNode{
+											Errors: nil,
+											Loc: &ast.SourceLocation{
+												End: ast.Position{
+													Column: 36,
+													Line:   114,
+												},
+												File:   "universe.flux",
+												Source: "timeSrc",
+												Start: ast.Position{
+													Column: 29,
+													Line:   114,
+												},
+											},
+										},
+										Name: "timeSrc",
+									},
+								}, &ast.Property{
+									BaseNode: ast.BaseNode{
+										Errors: nil,
+										Loc: &ast.SourceLocation{
+											End: ast.Position{
+												Column: 47,
+												Line:   114,
+											},
+											File:   "universe.flux",
+											Source: "as:timeDst",
+											Start: ast.Position{
+												Column: 37,
+												Line:   114,
+											},
+										},
+									},
+									Key: &ast.Identifier{
+										BaseNode: ast.BaseNode{
+											Errors: nil,
+											Loc: &ast.SourceLocation{
+												End: ast.Position{
+													Column: 39,
+													Line:   114,
+												},
+												File:   "universe.flux",
+												Source: "as",
+												Start: ast.Position{
+													Column: 37,
+													Line:   114,
+												},
+											},
+										},
+										Name: "as",
+									},
+									Value: &ast.Identifier{
+										BaseNode: ast.BaseNode{
+											Errors: nil,
+											Loc: &ast.SourceLocation{
+												End: ast.Position{
+													Column: 47,
+													Line:   114,
+												},
+												File:   "universe.flux",
+												Source: "timeDst",
+												Start: ast.Position{
+													Column: 40,
+													Line:   114,
+												},
+											},
+										},
+										Name: "timeDst",
+									},
+								}},
+							}},
 							BaseNode: ast.BaseNode{
 								Errors: nil,
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
-										Column: 36,
-										Line:   83,
+										Column: 48,
+										Line:   114,
 									},
 									File:   "universe.flux",
-									Source: "y:y",
+									Source: "duplicate(column:timeSrc,as:timeDst)",
 									Start: ast.Position{
-										Column: 33,
-										Line:   83,
+										Column: 12,
+										Line:   114,
 									},
 								},
 							},
-							Key: &ast.Identifier{
+							Callee: &ast.Identifier{
 								BaseNode: ast.BaseNode{
 									Errors: nil,
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
-											Column: 34,
-											Line:   83,
+											Column: 21,
+											Line:   114,
 										},
 										File:   "universe.flux",
-										Source: "y",
+										Source: "duplicate",
 										Start: ast.Position{
-											Column: 33,
-											Line:   83,
+											Column: 12,
+											Line:   114,
 										},
 									},
 								},
-								Name: "y",
+								Name: "duplicate",
 							},
-							Value: &ast.Identifier{
-								BaseNode: ast.BaseNode{
-									Errors: nil,
-									Loc: &ast.SourceLocation{
-										End: ast.Position{
-											Column: 36,
-											Line:   83,
-										},
-										File:   "universe.flux",
-										Source: "y",
-										Start: ast.Position{
-											Column: 35,
-											Line:   83,
-										},
-									},
-								},
-								Name: "y",
+						},
+					},
+					BaseNode: ast.BaseNode{
+						Errors: nil,
+						Loc: &ast.SourceLocation{
+							End: ast.Position{
+								Column: 49,
+								Line:   115,
 							},
-						}, &ast.Property{
+							File:   "universe.flux",
+							Source: "tables\n        |> window(every:every, createEmpty: createEmpty)\n        |> fn(columns:columns)\n        |> duplicate(column:timeSrc,as:timeDst)\n        |> window(every:inf, timeColumn:timeDst)",
+							Start: ast.Position{
+								Column: 5,
+								Line:   111,
+							},
+						},
+					},
+					Call: &ast.CallExpression{
+						Arguments: []ast.Expression{&ast.ObjectExpression{
 							BaseNode: ast.BaseNode{
 								Errors: nil,
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
-										Column: 43,
-										Line:   83,
+										Column: 48,
+										Line:   115,
 									},
 									File:   "universe.flux",
-									Source: "on:on",
+									Source: "every:inf, timeColumn:timeDst",
 									Start: ast.Position{
-										Column: 38,
-										Line:   83,
+										Column: 19,
+										Line:   115,
 									},
 								},
 							},
-							Key: &ast.Identifier{
+							Properties: []*ast.Property{&ast.Property{
 								BaseNode: ast.BaseNode{
 									Errors: nil,
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
-											Column: 40,
-											Line:   83,
+											Column: 28,
+											Line:   115,
 										},
 										File:   "universe.flux",
-										Source: "on",
+										Source: "every:inf",
 										Start: ast.Position{
-											Column: 38,
-											Line:   83,
+											Column: 19,
+											Line:   115,
 										},
 									},
 								},
-								Name: "on",
-							},
-							Value: &ast.Identifier{
+								Key: &ast.Identifier{
+									BaseNode: ast.BaseNode{
+										Errors: nil,
+										Loc: &ast.SourceLocation{
+											End: ast.Position{
+												Column: 24,
+												Line:   115,
+											},
+											File:   "universe.flux",
+											Source: "every",
+											Start: ast.Position{
+												Column: 19,
+												Line:   115,
+											},
+										},
+									},
+									Name: "every",
+								},
+								Value: &ast.Identifier{
+									BaseNode: ast.BaseNode{
+										Errors: nil,
+										Loc: &ast.SourceLocation{
+											End: ast.Position{
+												Column: 28,
+												Line:   115,
+											},
+											File:   "universe.flux",
+											Source: "inf",
+											Start: ast.Position{
+												Column: 25,
+												Line:   115,
+											},
+										},
+									},
+									Name: "inf",
+								},
+							}, &ast.Property{
 								BaseNode: ast.BaseNode{
 									Errors: nil,
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
-											Column: 43,
-											Line:   83,
+											Column: 48,
+											Line:   115,
 										},
 										File:   "universe.flux",
-										Source: "on",
+										Source: "timeColumn:timeDst",
 										Start: ast.Position{
-											Column: 41,
-											Line:   83,
+											Column: 30,
+											Line:   115,
 										},
 									},
 								},
-								Name: "on",
+								Key: &ast.Identifier{
+									BaseNode: ast.BaseNode{
+										Errors: nil,
+										Loc: &ast.SourceLocation{
+											End: ast.Position{
+												Column: 40,
+												Line:   115,
+											},
+											File:   "universe.flux",
+											Source: "timeColumn",
+											Start: ast.Position{
+												Column: 30,
+												Line:   115,
+											},
+										},
+									},
+									Name: "timeColumn",
+								},
+								Value: &ast.Identifier{
+									BaseNode: ast.BaseNode{
+										Errors: nil,
+										Loc: &ast.SourceLocation{
+											End: ast.Position{
+												Column: 48,
+												Line:   115,
+											},
+											File:   "universe.flux",
+											Source: "timeDst",
+											Start: ast.Position{
+												Column: 41,
+												Line:   115,
+											},
+										},
+									},
+									Name: "timeDst",
+								},
+							}},
+						}},
+						BaseNode: ast.BaseNode{
+							Errors: nil,
+							Loc: &ast.SourceLocation{
+								End: ast.Position{
+									Column: 49,
+									Line:   115,
+								},
+								File:   "universe.flux",
+								Source: "window(every:inf, timeColumn:timeDst)",
+								Start: ast.Position{
+									Column: 12,
+									Line:   115,
+								},
 							},
-						}, &ast.Property{
+						},
+						Callee: &ast.Identifier{
 							BaseNode: ast.BaseNode{
 								Errors: nil,
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
-										Column: 58,
-										Line:   83,
+										Column: 18,
+										Line:   115,
 									},
 									File:   "universe.flux",
-									Source: "pearsonr:true",
+									Source: "window",
 									Start: ast.Position{
-										Column: 45,
-										Line:   83,
+										Column: 12,
+										Line:   115,
 									},
 								},
 							},
-							Key: &ast.Identifier{
-								BaseNode: ast.BaseNode{
-									Errors: nil,
-									Loc: &ast.SourceLocation{
-										End: ast.Position{
-											Column: 53,
-											Line:   83,
-										},
-										File:   "universe.flux",
-										Source: "pearsonr",
-										Start: ast.Position{
-											Column: 45,
-											Line:   83,
-										},
-									},
-								},
-								Name: "pearsonr",
+							Name: "window",
+						},
+					},
+				},
+				Params: []*ast.Property{&ast.Property{
+					BaseNode: ast.BaseNode{
+						Errors: nil,
+						Loc: &ast.SourceLocation{
+							End: ast.Position{
+								Column: 25,
+								Line:   110,
 							},
-							Value: &ast.Identifier{
-								BaseNode: ast.BaseNode{
-									Errors: nil,
-									Loc: &ast.SourceLocation{
-										End: ast.Position{
-											Column: 58,
-											Line:   83,
-										},
-										File:   "universe.flux",
-										Source: "true",
-										Start: ast.Position{
-											Column: 54,
-											Line:   83,
-										},
-									},
+							File:   "universe.flux",
+							Source: "every",
+							Start: ast.Position{
+								Column: 20,
+								Line:   110,
+							},
+						},
+					},
+					Key: &ast.Identifier{
+						BaseNode: ast.BaseNode{
+							Errors: nil,
+							Loc: &ast.SourceLocation{
+								End: ast.Position{
+									Column: 25,
+									Line:   110,
+								},
+								File:   "universe.flux",
+								Source: "every",
+								Start: ast.Position{
+									Column: 20,
+									Line:   110,
 								},
-								Name: "true",
 							},
-						}},
-					}},
+						},
+						Name: "every",
+					},
+					Value: nil,
+				}, &ast.Property{
 					BaseNode: ast.BaseNode{
 						Errors: nil,
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
-								Column: 59,
-								Line:   83,
+								Column: 29,
+								Line:   110,
 							},
 							File:   "universe.flux",
-							Source: "cov(x:x, y:y, on:on, pearsonr:true)",
+							Source: "fn",
 							Start: ast.Position{
-								Column: 24,
-								Line:   83,
+								Column: 27,
+								Line:   110,
 							},
 						},
 					},
-					Callee: &ast.Identifier{
+					Key: &ast.Identifier{
 						BaseNode: ast.BaseNode{
 							Errors: nil,
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
-									Column: 27,
-									Line:   83,
+									Column: 29,
+									Line:   110,
 								},
 								File:   "universe.flux",
-								Source: "cov",
+								Source: "fn",
 								Start: ast.Position{
-									Column: 24,
-									Line:   83,
+									Column: 27,
+									Line:   110,
 								},
 							},
 						},
-						Name: "cov",
+						Name: "fn",
 					},
-				},
-				Params: []*ast.Property{&ast.Property{
+					Value: nil,
+				}, &ast.Property{
 					BaseNode: ast.BaseNode{
 						Errors: nil,
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
-								Column: 14,
-								Line:   83,
+								Column: 49,
+								Line:   110,
 							},
 							File:   "universe.flux",
-							Source: "x",
+							Source: "columns=[\"_value\"]",
 							Start: ast.Position{
-								Column: 13,
-								Line:   83,
+								Column: 31,
+								Line:   110,
 							},
 						},
 					},
@@ -3050,68 +4553,119 @@ var pkgAST = &ast.Package{
 							Errors: nil,
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
-									Column: 14,
-									Line:   83,
+									Column: 38,
+									Line:   110,
 								},
 								File:   "universe.flux",
-								Source: "x",
+								Source: "columns",
 								Start: ast.Position{
-									Column: 13,
-									Line:   83,
+									Column: 31,
+									Line:   110,
 								},
 							},
 						},
-						Name: "x",
+						Name: "columns",
+					},
+					Value: &ast.ArrayExpression{
+						BaseNode: ast.BaseNode{
+							Errors: nil,
+							Loc: &ast.SourceLocation{
+								End: ast.Position{
+									Column: 49,
+									Line:   110,
+								},
+								File:   "universe.flux",
+								Source: "[\"_value\"]",
+								Start: ast.Position{
+									Column: 39,
+									Line:   110,
+								},
+							},
+						},
+						Elements: []ast.Expression{&ast.StringLiteral{
+							BaseNode: ast.BaseNode{
+								Errors: nil,
+								Loc: &ast.SourceLocation{
+									End: ast.Position{
+										Column: 48,
+										Line:   110,
+									},
+									File:   "universe.flux",
+									Source: "\"_value\"",
+									Start: ast.Position{
+										Column: 40,
+										Line:   110,
+									},
+								},
+							},
+							Value: "_value",
+						}},
 					},
-					Value: nil,
 				}, &ast.Property{
 					BaseNode: ast.BaseNode{
 						Errors: nil,
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
-								Column: 16,
-								Line:   83,
+								Column: 66,
+								Line:   110,
 							},
 							File:   "universe.flux",
-							Source: "y",
+							Source: "timeSrc=\"_stop\"",
 							Start: ast.Position{
-								Column: 15,
-								Line:   83,
+								Column: 51,
+								Line:   110,
+							},
+						},
+					},
+					Key: &ast.Identifier{
+						BaseNode: ast.BaseNode{
+							Errors: nil,
+							Loc: &ast.SourceLocation{
+								End: ast.Position{
+									Column: 58,
+									Line:   110,
+								},
+								File:   "universe.flux",
+								Source: "timeSrc",
+								Start: ast.Position{
+									Column: 51,
+									Line:   110,
+								},
 							},
 						},
+						Name: "timeSrc",
 					},
-					Key: &ast.Identifier{
+					Value: &ast.StringLiteral{
 						BaseNode: ast.BaseNode{
 							Errors: nil,
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
-									Column: 16,
-									Line:   83,
+									Column: 66,
+									Line:   110,
 								},
 								File:   "universe.flux",
-								Source: "y",
+								Source: "\"_stop\"",
 								Start: ast.Position{
-									Column: 15,
-									Line:   83,
+									Column: 59,
+									Line:   110,
 								},
 							},
 						},
-						Name: "y",
+						Value: "_stop",
 					},
-					Value: nil,
 				}, &ast.Property{
 					BaseNode: ast.BaseNode{
 						Errors: nil,
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
-								Column: 19,
-								Line:   83,
+								Column: 82,
+								Line:   110,
 							},
 							File:   "universe.flux",
-							Source: "on",
+							Source: "timeDst=\"_time\"",
 							Start: ast.Position{
-								Column: 17,
-								Line:   83,
+								Column: 67,
+								Line:   110,
 							},
 						},
 					},
@@ -3120,400 +4674,222 @@ var pkgAST = &ast.Package{
 							Errors: nil,
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
-									Column: 19,
-									Line:   83,
+									Column: 74,
+									Line:   110,
 								},
 								File:   "universe.flux",
-								Source: "on",
+								Source: "timeDst",
 								Start: ast.Position{
-									Column: 17,
-									Line:   83,
+									Column: 67,
+									Line:   110,
 								},
 							},
 						},
-						Name: "on",
-					},
-					Value: nil,
-				}},
-			},
-		}, &ast.VariableAssignment{
-			BaseNode: ast.BaseNode{
-				Errors: nil,
-				Loc: &ast.SourceLocation{
-					End: ast.Position{
-						Column: 49,
-						Line:   93,
-					},
-					File:   "universe.flux",
-					Source: "aggregateWindow = (every, fn, columns=[\"_value\"], timeSrc=\"_stop\",timeDst=\"_time\", createEmpty=true, tables=<-) =>\n    tables\n        |> window(every:every, createEmpty: createEmpty)\n        |> fn(columns:columns)\n        |> duplicate(column:timeSrc,as:timeDst)\n        |> window(every:inf, timeColumn:timeDst)",
-					Start: ast.Position{
-						Column: 1,
-						Line:   88,
-					},
-				},
-			},
-			ID: &ast.Identifier{
-				BaseNode: ast.BaseNode{
-					Errors: nil,
-					Loc: &ast.SourceLocation{
-						End: ast.Position{
-							Column: 16,
-							Line:   88,
-						},
-						File:   "universe.flux",
-						Source: "aggregateWindow",
-						Start: ast.Position{
-							Column: 1,
-							Line:   88,
-						},
-					},
-				},
-				Name: "aggregateWindow",
-			},
-			Init: &ast.FunctionExpression{
-				BaseNode: ast.BaseNode{
-					Errors: nil,
-					Loc: &ast.SourceLocation{
-						End: ast.Position{
-							Column: 49,
-							Line:   93,
-						},
-						File:   "universe.flux",
-						Source: "(every, fn, columns=[\"_value\"], timeSrc=\"_stop\",timeDst=\"_time\", createEmpty=true, tables=<-) =>\n    tables\n        |> window(every:every, createEmpty: createEmpty)\n        |> fn(columns:columns)\n        |> duplicate(column:timeSrc,as:timeDst)\n        |> window(every:inf, timeColumn:timeDst)",
-						Start: ast.Position{
-							Column: 19,
-							Line:   88,
-						},
+						Name: "timeDst",
 					},
-				},
-				Body: &ast.PipeExpression{
-					Argument: &ast.PipeExpression{
-						Argument: &ast.PipeExpression{
-							Argument: &ast.PipeExpression{
-								Argument: &ast.Identifier{
-									BaseNode: ast.BaseNode{
-										Errors: nil,
-										Loc: &ast.SourceLocation{
-											End: ast.Position{
-												Column: 11,
-												Line:   89,
-											},
-											File:   "universe.flux",
-											Source: "tables",
-											Start: ast.Position{
-												Column: 5,
-												Line:   89,
-											},
-										},
-									},
-									Name: "tables",
-								},
-								BaseNode: ast.BaseNode{
-									Errors: nil,
-									Loc: &ast.SourceLocation{
-										End: ast.Position{
-											Column: 57,
-											Line:   90,
-										},
-										File:   "universe.flux",
-										Source: "tables\n        |> window(every:every, createEmpty: createEmpty)",
-										Start: ast.Position{
-											Column: 5,
-											Line:   89,
-										},
-									},
+					Value: &ast.StringLiteral{
+						BaseNode: ast.BaseNode{
+							Errors: nil,
+							Loc: &ast.SourceLocation{
+								End: ast.Position{
+									Column: 82,
+									Line:   110,
 								},
-								Call: &ast.CallExpression{
-									Arguments: []ast.Expression{&ast.ObjectExpression{
-										BaseNode: ast.BaseNode{
-											Errors: nil,
-											Loc: &ast.SourceLocation{
-												End: ast.Position{
-													Column: 56,
-													Line:   90,
-												},
-												File:   "universe.flux",
-												Source: "every:every, createEmpty: createEmpty",
-												Start: ast.Position{
-													Column: 19,
-													Line:   90,
-												},
-											},
-										},
-										Properties: []*ast.Property{&ast.Property{
-											BaseNode: ast.BaseNode{
-												Errors: nil,
-												Loc: &ast.SourceLocation{
-													End: ast.Position{
-														Column: 30,
-														Line:   90,
-													},
-													File:   "universe.flux",
-													Source: "every:every",
-													Start: ast.Position{
-														Column: 19,
-														Line:   90,
-													},
-												},
-											},
-											Key: &ast.Identifier{
-												BaseNode: ast.BaseNode{
-													Errors: nil,
-													Loc: &ast.SourceLocation{
-														End: ast.Position{
-															Column: 24,
-															Line:   90,
-														},
-														File:   "universe.flux",
-														Source: "every",
-														Start: ast.Position{
-															Column: 19,
-															Line:   90,
-														},
-													},
-												},
-												Name: "every",
-											},
-											Value: &ast.Identifier{
-												BaseNode: ast.BaseNode{
-													Errors: nil,
-													Loc: &ast.SourceLocation{
-														End: ast.Position{
-															Column: 30,
-															Line:   90,
-														},
-														File:   "universe.flux",
-														Source: "every",
-														Start: ast.Position{
-															Column: 25,
-															Line:   90,
-														},
-													},
-												},
-												Name: "every",
-											},
-										}, &ast.Property{
-											BaseNode: ast.BaseNode{
-												Errors: nil,
-												Loc: &ast.SourceLocation{
-													End: ast.Position{
-														Column: 56,
-														Line:   90,
-													},
-													File:   "universe.flux",
-													Source: "createEmpty: createEmpty",
-													Start: ast.Position{
-														Column: 32,
-														Line:   90,
-													},
-												},
-											},
-											Key: &ast.Identifier{
-												BaseNode: ast.BaseNode{
-													Errors: nil,
-													Loc: &ast.SourceLocation{
-														End: ast.Position{
-															Column: 43,
-															Line:   90,
-														},
-														File:   "universe.flux",
-														Source: "createEmpty",
-														Start: ast.Position{
-															Column: 32,
-															Line:   90,
-														},
-													},
-												},
-												Name: "createEmpty",
-											},
-											Value: &ast.Identifier{
-												BaseNode: ast.BaseNode{
-													Errors: nil,
-													Loc: &ast.SourceLocation{
-														End: ast.Position{
-															Column: 56,
-															Line:   90,
-														},
-														File:   "universe.flux",
-														Source: "createEmpty",
-														Start: ast.Position{
-															Column: 45,
-															Line:   90,
-														},
-													},
-												},
-												Name: "createEmpty",
-											},
-										}},
-									}},
-									BaseNode: ast.BaseNode{
-										Errors: nil,
-										Loc: &ast.SourceLocation{
-											End: ast.Position{
-												Column: 57,
-												Line:   90,
-											},
-											File:   "universe.flux",
-											Source: "window(every:every, createEmpty: createEmpty)",
-											Start: ast.Position{
-												Column: 12,
-												Line:   90,
-											},
-										},
-									},
-									Callee: &ast.Identifier{
-										BaseNode: ast.BaseNode{
-											Errors: nil,
-											Loc: &ast.SourceLocation{
-												End: ast.Position{
-													Column: 18,
-													Line:   90,
-												},
-												File:   "universe.flux",
-												Source: "window",
-												Start: ast.Position{
-													Column: 12,
-													Line:   90,
-												},
-											},
-										},
-										Name: "window",
-									},
+								File:   "universe.flux",
+								Source: "\"_time\"",
+								Start: ast.Position{
+									Column: 75,
+									Line:   110,
+								},
+							},
+						},
+						Value: "_time",
+					},
+				}, &ast.Property{
+					BaseNode: ast.BaseNode{
+						Errors: nil,
+						Loc: &ast.SourceLocation{
+							End: ast.Position{
+								Column: 100,
+								Line:   110,
+							},
+							File:   "universe.flux",
+							Source: "createEmpty=true",
+							Start: ast.Position{
+								Column: 84,
+								Line:   110,
+							},
+						},
+					},
+					Key: &ast.Identifier{
+						BaseNode: ast.BaseNode{
+							Errors: nil,
+							Loc: &ast.SourceLocation{
+								End: ast.Position{
+									Column: 95,
+									Line:   110,
+								},
+								File:   "universe.flux",
+								Source: "createEmpty",
+								Start: ast.Position{
+									Column: 84,
+									Line:   110,
+								},
+							},
+						},
+						Name: "createEmpty",
+					},
+					Value: &ast.Identifier{
+						BaseNode: ast.BaseNode{
+							Errors: nil,
+							Loc: &ast.SourceLocation{
+								End: ast.Position{
+									Column: 100,
+									Line:   110,
+								},
+								File:   "universe.flux",
+								Source: "true",
+								Start: ast.Position{
+									Column: 96,
+									Line:   110,
+								},
+							},
+						},
+						Name: "true",
+					},
+				}, &ast.Property{
+					BaseNode: ast.BaseNode{
+						Errors: nil,
+						Loc: &ast.SourceLocation{
+							End: ast.Position{
+								Column: 111,
+								Line:   110,
+							},
+							File:   "universe.flux",
+							Source: "tables=<-",
+							Start: ast.Position{
+								Column: 102,
+								Line:   110,
+							},
+						},
+					},
+					Key: &ast.Identifier{
+						BaseNode: ast.BaseNode{
+							Errors: nil,
+							Loc: &ast.SourceLocation{
+								End: ast.Position{
+									Column: 108,
+									Line:   110,
+								},
+								File:   "universe.flux",
+								Source: "tables",
+								Start: ast.Position{
+									Column: 102,
+									Line:   110,
 								},
 							},
+						},
+						Name: "tables",
+					},
+					Value: &ast.PipeLiteral{BaseNode: ast.BaseNode{
+						Errors: nil,
+						Loc: &ast.SourceLocation{
+							End: ast.Position{
+								Column: 111,
+								Line:   110,
+							},
+							File:   "universe.flux",
+							Source: "<-",
+							Start: ast.Position{
+								Column: 109,
+								Line:   110,
+							},
+						},
+					}},
+				}},
+			},
+		}, &ast.VariableAssignment{
+			BaseNode: ast.BaseNode{
+				Errors: nil,
+				Loc: &ast.SourceLocation{
+					End: ast.Position{
+						Column: 43,
+						Line:   124,
+					},
+					File:   "universe.flux",
+					Source: "increase = (tables=<-, columns=[\"_value\"]) =>\n    tables\n        |> difference(nonNegative: true, columns:columns)\n        |> cumulativeSum(columns: columns)",
+					Start: ast.Position{
+						Column: 1,
+						Line:   121,
+					},
+				},
+			},
+			ID: &ast.Identifier{
+				BaseNode: ast.BaseNode{
+					Errors: nil,
+					Loc: &ast.SourceLocation{
+						End: ast.Position{
+							Column: 9,
+							Line:   121,
+						},
+						File:   "universe.flux",
+						Source: "increase",
+						Start: ast.Position{
+							Column: 1,
+							Line:   121,
+						},
+					},
+				},
+				Name: "increase",
+			},
+			Init: &ast.FunctionExpression{
+				BaseNode: ast.BaseNode{
+					Errors: nil,
+					Loc: &ast.SourceLocation{
+						End: ast.Position{
+							Column: 43,
+							Line:   124,
+						},
+						File:   "universe.flux",
+						Source: "(tables=<-, columns=[\"_value\"]) =>\n    tables\n        |> difference(nonNegative: true, columns:columns)\n        |> cumulativeSum(columns: columns)",
+						Start: ast.Position{
+							Column: 12,
+							Line:   121,
+						},
+					},
+				},
+				Body: &ast.PipeExpression{
+					Argument: &ast.PipeExpression{
+						Argument: &ast.Identifier{
 							BaseNode: ast.BaseNode{
 								Errors: nil,
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
-										Column: 31,
-										Line:   91,
+										Column: 11,
+										Line:   122,
 									},
 									File:   "universe.flux",
-									Source: "tables\n        |> window(every:every, createEmpty: createEmpty)\n        |> fn(columns:columns)",
+									Source: "tables",
 									Start: ast.Position{
 										Column: 5,
-										Line:   89,
-									},
-								},
-							},
-							Call: &ast.CallExpression{
-								Arguments: []ast.Expression{&ast.ObjectExpression{
-									BaseNode: ast.BaseNode{
-										Errors: nil,
-										Loc: &ast.SourceLocation{
-											End: ast.Position{
-												Column: 30,
-												Line:   91,
-											},
-											File:   "universe.flux",
-											Source: "columns:columns",
-											Start: ast.Position{
-												Column: 15,
-												Line:   91,
-											},
-										},
-									},
-									Properties: []*ast.Property{&ast.Property{
-										BaseNode: ast.BaseNode{
-											Errors: nil,
-											Loc: &ast.SourceLocation{
-												End: ast.Position{
-													Column: 30,
-													Line:   91,
-												},
-												File:   "universe.flux",
-												Source: "columns:columns",
-												Start: ast.Position{
-													Column: 15,
-													Line:   91,
-												},
-											},
-										},
-										Key: &ast.Identifier{
-											BaseNode: ast.BaseNode{
-												Errors: nil,
-												Loc: &ast.SourceLocation{
-													End: ast.Position{
-														Column: 22,
-														Line:   91,
-													},
-													File:   "universe.flux",
-													Source: "columns",
-													Start: ast.Position{
-														Column: 15,
-														Line:   91,
-													},
-												},
-											},
-											Name: "columns",
-										},
-										Value: &ast.Identifier{
-											BaseNode: ast.BaseNode{
-												Errors: nil,
-												Loc: &ast.SourceLocation{
-													End: ast.Position{
-														Column: 30,
-														Line:   91,
-													},
-													File:   "universe.flux",
-													Source: "columns",
-													Start: ast.Position{
-														Column: 23,
-														Line:   91,
-													},
-												},
-											},
-											Name: "columns",
-										},
-									}},
-								}},
-								BaseNode: ast.BaseNode{
-									Errors: nil,
-									Loc: &ast.SourceLocation{
-										End: ast.Position{
-											Column: 31,
-											Line:   91,
-										},
-										File:   "universe.flux",
-										Source: "fn(columns:columns)",
-										Start: ast.Position{
-											Column: 12,
-											Line:   91,
-										},
-									},
-								},
-								Callee: &ast.Identifier{
-									BaseNode: ast.BaseNode{
-										Errors: nil,
-										Loc: &ast.SourceLocation{
-											End: ast.Position{
-												Column: 14,
-												Line:   91,
-											},
-											File:   "universe.flux",
-											Source: "fn",
-											Start: ast.Position{
-												Column: 12,
-												Line:   91,
-											},
-										},
+										Line:   122,
 									},
-									Name: "fn",
 								},
 							},
+							Name: "tables",
 						},
 						BaseNode: ast.BaseNode{
 							Errors: nil,
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
-									Column: 48,
-									Line:   92,
+									Column: 58,
+									Line:   123,
 								},
 								File:   "universe.flux",
-								Source: "tables\n        |> window(every:every, createEmpty: createEmpty)\n        |> fn(columns:columns)\n        |> duplicate(column:timeSrc,as:timeDst)",
+								Source: "tables\n        |> difference(nonNegative: true, columns:columns)",
 								Start: ast.Position{
 									Column: 5,
-									Line:   89,
+									Line:   122,
 								},
 							},
 						},
@@ -3523,14 +4899,14 @@ var pkgAST = &ast.Package{
 									Errors: nil,
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
-											Column: 47,
-											Line:   92,
+											Column: 57,
+											Line:   123,
 										},
 										File:   "universe.flux",
-										Source: "column:timeSrc,as:timeDst",
+										Source: "nonNegative: true, columns:columns",
 										Start: ast.Position{
-											Column: 22,
-											Line:   92,
+											Column: 23,
+											Line:   123,
 										},
 									},
 								},
@@ -3539,14 +4915,14 @@ var pkgAST = &ast.Package{
 										Errors: nil,
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
-												Column: 36,
-												Line:   92,
+												Column: 40,
+												Line:   123,
 											},
 											File:   "universe.flux",
-											Source: "column:timeSrc",
+											Source: "nonNegative: true",
 											Start: ast.Position{
-												Column: 22,
-												Line:   92,
+												Column: 23,
+												Line:   123,
 											},
 										},
 									},
@@ -3555,50 +4931,50 @@ var pkgAST = &ast.Package{
 											Errors: nil,
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
-													Column: 28,
-													Line:   92,
+													Column: 34,
+													Line:   123,
 												},
 												File:   "universe.flux",
-												Source: "column",
+												Source: "nonNegative",
 												Start: ast.Position{
-													Column: 22,
-													Line:   92,
+													Column: 23,
+													Line:   123,
 												},
 											},
 										},
-										Name: "column",
+										Name: "nonNegative",
 									},
 									Value: &ast.Identifier{
 										BaseNode: ast.BaseNode{
 											Errors: nil,
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
-													Column: 36,
-													Line:   92,
+													Column: 40,
+													Line:   123,
 												},
 												File:   "universe.flux",
-												Source: "timeSrc",
+												Source: "true",
 												Start: ast.Position{
-													Column: 29,
-													Line:   92,
+													Column: 36,
+													Line:   123,
 												},
 											},
 										},
-										Name: "timeSrc",
+										Name: "true",
 									},
 								}, &ast.Property{
 									BaseNode: ast.BaseNode{
 										Errors: nil,
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
-												Column: 47,
-												Line:   92,
+												Column: 57,
+												Line:   123,
 											},
 											File:   "universe.flux",
-											Source: "as:timeDst",
+											Source: "columns:columns",
 											Start: ast.Position{
-												Column: 37,
-												Line:   92,
+												Column: 42,
+												Line:   123,
 											},
 										},
 									},
@@ -3607,36 +4983,36 @@ var pkgAST = &ast.Package{
 											Errors: nil,
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
-													Column: 39,
-													Line:   92,
+													Column: 49,
+													Line:   123,
 												},
 												File:   "universe.flux",
-												Source: "as",
+												Source: "columns",
 												Start: ast.Position{
-													Column: 37,
-													Line:   92,
+													Column: 42,
+													Line:   123,
 												},
 											},
 										},
-										Name: "as",
+										Name: "columns",
 									},
 									Value: &ast.Identifier{
 										BaseNode: ast.BaseNode{
 											Errors: nil,
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
-													Column: 47,
-													Line:   92,
+													Column: 57,
+													Line:   123,
 												},
 												File:   "universe.flux",
-												Source: "timeDst",
+												Source: "columns",
 												Start: ast.Position{
-													Column: 40,
-													Line:   92,
+													Column: 50,
+													Line:   123,
 												},
 											},
 										},
-										Name: "timeDst",
+										Name: "columns",
 									},
 								}},
 							}},
@@ -3644,14 +5020,14 @@ var pkgAST = &ast.Package{
 								Errors: nil,
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
-										Column: 48,
-										Line:   92,
+										Column: 58,
+										Line:   123,
 									},
 									File:   "universe.flux",
-									Source: "duplicate(column:timeSrc,as:timeDst)",
+									Source: "difference(nonNegative: true, columns:columns)",
 									Start: ast.Position{
 										Column: 12,
-										Line:   92,
+										Line:   123,
 									},
 								},
 							},
@@ -3660,18 +5036,18 @@ var pkgAST = &ast.Package{
 									Errors: nil,
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
-											Column: 21,
-											Line:   92,
+											Column: 22,
+											Line:   123,
 										},
 										File:   "universe.flux",
-										Source: "duplicate",
+										Source: "difference",
 										Start: ast.Position{
 											Column: 12,
-											Line:   92,
+											Line:   123,
 										},
 									},
 								},
-								Name: "duplicate",
+								Name: "difference",
 							},
 						},
 					},
@@ -3679,14 +5055,14 @@ var pkgAST = &ast.Package{
 						Errors: nil,
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
-								Column: 49,
-								Line:   93,
+								Column: 43,
+								Line:   124,
 							},
 							File:   "universe.flux",
-							Source: "tables\n        |> window(every:every, createEmpty: createEmpty)\n        |> fn(columns:columns)\n        |> duplicate(column:timeSrc,as:timeDst)\n        |> window(every:inf, timeColumn:timeDst)",
+							Source: "tables\n        |> difference(nonNegative: true, columns:columns)\n        |> cumulativeSum(columns: columns)",
 							Start: ast.Position{
 								Column: 5,
-								Line:   89,
+								Line:   122,
 							},
 						},
 					},
@@ -3696,14 +5072,14 @@ var pkgAST = &ast.Package{
 								Errors: nil,
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
-										Column: 48,
-										Line:   93,
+										Column: 42,
+										Line:   124,
 									},
 									File:   "universe.flux",
-									Source: "every:inf, timeColumn:timeDst",
+									Source: "columns: columns",
 									Start: ast.Position{
-										Column: 19,
-										Line:   93,
+										Column: 26,
+										Line:   124,
 									},
 								},
 							},
@@ -3712,66 +5088,14 @@ var pkgAST = &ast.Package{
 									Errors: nil,
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
-											Column: 28,
-											Line:   93,
-										},
-										File:   "universe.flux",
-										Source: "every:inf",
-										Start: ast.Position{
-											Column: 19,
-											Line:   93,
-										},
-									},
-								},
-								Key: &ast.Identifier{
-									BaseNode: ast.BaseNode{
-										Errors: nil,
-										Loc: &ast.SourceLocation{
-											End: ast.Position{
-												Column: 24,
-												Line:   93,
-											},
-											File:   "universe.flux",
-											Source: "every",
-											Start: ast.Position{
-												Column: 19,
-												Line:   93,
-											},
-										},
-									},
-									Name: "every",
-								},
-								Value: &ast.Identifier{
-									BaseNode: ast.BaseNode{
-										Errors: nil,
-										Loc: &ast.SourceLocation{
-											End: ast.Position{
-												Column: 28,
-												Line:   93,
-											},
-											File:   "universe.flux",
-											Source: "inf",
-											Start: ast.Position{
-												Column: 25,
-												Line:   93,
-											},
-										},
-									},
-									Name: "inf",
-								},
-							}, &ast.Property{
-								BaseNode: ast.BaseNode{
-									Errors: nil,
-									Loc: &ast.SourceLocation{
-										End: ast.Position{
-											Column: 48,
-											Line:   93,
+											Column: 42,
+											Line:   124,
 										},
 										File:   "universe.flux",
-										Source: "timeColumn:timeDst",
+										Source: "columns: columns",
 										Start: ast.Position{
-											Column: 30,
-											Line:   93,
+											Column: 26,
+											Line:   124,
 										},
 									},
 								},
@@ -3780,226 +5104,87 @@ var pkgAST = &ast.Package{
 										Errors: nil,
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
-												Column: 40,
-												Line:   93,
+												Column: 33,
+												Line:   124,
 											},
 											File:   "universe.flux",
-											Source: "timeColumn",
+											Source: "columns",
 											Start: ast.Position{
-												Column: 30,
-												Line:   93,
+												Column: 26,
+												Line:   124,
 											},
 										},
 									},
-									Name: "timeColumn",
+									Name: "columns",
 								},
 								Value: &ast.Identifier{
 									BaseNode: ast.BaseNode{
 										Errors: nil,
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
-												Column: 48,
-												Line:   93,
-											},
-											File:   "universe.flux",
-											Source: "timeDst",
-											Start: ast.Position{
-												Column: 41,
-												Line:   93,
+												Column: 42,
+												Line:   124,
 											},
-										},
-									},
-									Name: "timeDst",
-								},
-							}},
-						}},
-						BaseNode: ast.BaseNode{
-							Errors: nil,
-							Loc: &ast.SourceLocation{
-								End: ast.Position{
-									Column: 49,
-									Line:   93,
-								},
-								File:   "universe.flux",
-								Source: "window(every:inf, timeColumn:timeDst)",
-								Start: ast.Position{
-									Column: 12,
-									Line:   93,
-								},
-							},
-						},
-						Callee: &ast.Identifier{
-							BaseNode: ast.BaseNode{
-								Errors: nil,
-								Loc: &ast.SourceLocation{
-									End: ast.Position{
-										Column: 18,
-										Line:   93,
-									},
-									File:   "universe.flux",
-									Source: "window",
-									Start: ast.Position{
-										Column: 12,
-										Line:   93,
-									},
-								},
-							},
-							Name: "window",
-						},
-					},
-				},
-				Params: []*ast.Property{&ast.Property{
-					BaseNode: ast.BaseNode{
-						Errors: nil,
-						Loc: &ast.SourceLocation{
-							End: ast.Position{
-								Column: 25,
-								Line:   88,
-							},
-							File:   "universe.flux",
-							Source: "every",
-							Start: ast.Position{
-								Column: 20,
-								Line:   88,
-							},
-						},
-					},
-					Key: &ast.Identifier{
-						BaseNode: ast.BaseNode{
-							Errors: nil,
-							Loc: &ast.SourceLocation{
-								End: ast.Position{
-									Column: 25,
-									Line:   88,
-								},
-								File:   "universe.flux",
-								Source: "every",
-								Start: ast.Position{
-									Column: 20,
-									Line:   88,
-								},
-							},
-						},
-						Name: "every",
-					},
-					Value: nil,
-				}, &ast.Property{
-					BaseNode: ast.BaseNode{
-						Errors: nil,
-						Loc: &ast.SourceLocation{
-							End: ast.Position{
-								Column: 29,
-								Line:   88,
-							},
-							File:   "universe.flux",
-							Source: "fn",
-							Start: ast.Position{
-								Column: 27,
-								Line:   88,
-							},
-						},
-					},
-					Key: &ast.Identifier{
-						BaseNode: ast.BaseNode{
-							Errors: nil,
-							Loc: &ast.SourceLocation{
-								End: ast.Position{
-									Column: 29,
-									Line:   88,
-								},
-								File:   "universe.flux",
-								Source: "fn",
-								Start: ast.Position{
-									Column: 27,
-									Line:   88,
-								},
-							},
-						},
-						Name: "fn",
-					},
-					Value: nil,
-				}, &ast.Property{
-					BaseNode: ast.BaseNode{
-						Errors: nil,
-						Loc: &ast.SourceLocation{
-							End: ast.Position{
-								Column: 49,
-								Line:   88,
-							},
-							File:   "universe.flux",
-							Source: "columns=[\"_value\"]",
-							Start: ast.Position{
-								Column: 31,
-								Line:   88,
-							},
-						},
-					},
-					Key: &ast.Identifier{
-						BaseNode: ast.BaseNode{
-							Errors: nil,
-							Loc: &ast.SourceLocation{
-								End: ast.Position{
-									Column: 38,
-									Line:   88,
-								},
-								File:   "universe.flux",
-								Source: "columns",
-								Start: ast.Position{
-									Column: 31,
-									Line:   88,
+											File:   "universe.flux",
+											Source: "columns",
+											Start: ast.Position{
+												Column: 35,
+												Line:   124,
+											},
+										},
+									},
+									Name: "columns",
 								},
-							},
-						},
-						Name: "columns",
-					},
-					Value: &ast.ArrayExpression{
+							}},
+						}},
 						BaseNode: ast.BaseNode{
 							Errors: nil,
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
-									Column: 49,
-									Line:   88,
+									Column: 43,
+									Line:   124,
 								},
 								File:   "universe.flux",
-								Source: "[\"_value\"]",
+								Source: "cumulativeSum(columns: columns)",
 								Start: ast.Position{
-									Column: 39,
-									Line:   88,
+									Column: 12,
+									Line:   124,
 								},
 							},
 						},
-						Elements: []ast.Expression{&ast.StringLiteral{
+						Callee: &ast.Identifier{
 							BaseNode: ast.BaseNode{
 								Errors: nil,
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
-										Column: 48,
-										Line:   88,
+										Column: 25,
+										Line:   124,
 									},
 									File:   "universe.flux",
-									Source: "\"_value\"",
+									Source: "cumulativeSum",
 									Start: ast.Position{
-										Column: 40,
-										Line:   88,
+										Column: 12,
+										Line:   124,
 									},
 								},
 							},
-							Value: "_value",
-						}},
+							Name: "cumulativeSum",
+						},
 					},
-				}, &ast.Property{
+				},
+				Params: []*ast.Property{&ast.Property{
 					BaseNode: ast.BaseNode{
 						Errors: nil,
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
-								Column: 66,
-								Line:   88,
+								Column: 22,
+								Line:   121,
 							},
 							File:   "universe.flux",
-							Source: "timeSrc=\"_stop\"",
+							Source: "tables=<-",
 							Start: ast.Position{
-								Column: 51,
-								Line:   88,
+								Column: 13,
+								Line:   121,
 							},
 						},
 					},
@@ -4008,102 +5193,47 @@ var pkgAST = &ast.Package{
 							Errors: nil,
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
-									Column: 58,
-									Line:   88,
-								},
-								File:   "universe.flux",
-								Source: "timeSrc",
-								Start: ast.Position{
-									Column: 51,
-									Line:   88,
-								},
-							},
-						},
-						Name: "timeSrc",
-					},
-					Value: &ast.StringLiteral{
-						BaseNode: ast.BaseNode{
-							Errors: nil,
-							Loc: &ast.SourceLocation{
-								End: ast.Position{
-									Column: 66,
-									Line:   88,
+									Column: 19,
+									Line:   121,
 								},
 								File:   "universe.flux",
-								Source: "\"_stop\"",
+								Source: "tables",
 								Start: ast.Position{
-									Column: 59,
-									Line:   88,
+									Column: 13,
+									Line:   121,
 								},
 							},
 						},
-						Value: "_stop",
+						Name: "tables",
 					},
-				}, &ast.Property{
-					BaseNode: ast.BaseNode{
+					Value: &ast.PipeLiteral{BaseNode: ast.BaseNode{
 						Errors: nil,
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
-								Column: 82,
-								Line:   88,
+								Column: 22,
+								Line:   121,
 							},
 							File:   "universe.flux",
-							Source: "timeDst=\"_time\"",
+							Source: "<-",
 							Start: ast.Position{
-								Column: 67,
-								Line:   88,
-							},
-						},
-					},
-					Key: &ast.Identifier{
-						BaseNode: ast.BaseNode{
-							Errors: nil,
-							Loc: &ast.SourceLocation{
-								End: ast.Position{
-									Column: 74,
-									Line:   88,
-								},
-								File:   "universe.flux",
-								Source: "timeDst",
-								Start: ast.Position{
-									Column: 67,
-									Line:   88,
-								},
-							},
-						},
-						Name: "timeDst",
-					},
-					Value: &ast.StringLiteral{
-						BaseNode: ast.BaseNode{
-							Errors: nil,
-							Loc: &ast.SourceLocation{
-								End: ast.Position{
-									Column: 82,
-									Line:   88,
-								},
-								File:   "universe.flux",
-								Source: "\"_time\"",
-								Start: ast.Position{
-									Column: 75,
-									Line:   88,
-								},
+								Column: 20,
+								Line:   121,
 							},
 						},
-						Value: "_time",
-					},
+					}},
 				}, &ast.Property{
 					BaseNode: ast.BaseNode{
 						Errors: nil,
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
-								Column: 100,
-								Line:   88,
+								Column: 42,
+								Line:   121,
 							},
 							File:   "universe.flux",
-							Source: "createEmpty=true",
+							Source: "columns=[\"_value\"]",
 							Start: ast.Position{
-								Column: 84,
-								Line:   88,
+								Column: 24,
+								Line:   121,
 							},
 						},
 					},
@@ -4112,86 +5242,54 @@ var pkgAST = &ast.Package{
 							Errors: nil,
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
-									Column: 95,
-									Line:   88,
+									Column: 31,
+									Line:   121,
 								},
 								File:   "universe.flux",
-								Source: "createEmpty",
+								Source: "columns",
 								Start: ast.Position{
-									Column: 84,
-									Line:   88,
+									Column: 24,
+									Line:   121,
 								},
 							},
 						},
-						Name: "createEmpty",
+						Name: "columns",
 					},
-					Value: &ast.Identifier{
+					Value: &ast.ArrayExpression{
 						BaseNode: ast.BaseNode{
 							Errors: nil,
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
-									Column: 100,
-									Line:   88,
+									Column: 42,
+									Line:   121,
 								},
 								File:   "universe.flux",
-								Source: "true",
+								Source: "[\"_value\"]",
 								Start: ast.Position{
-									Column: 96,
-									Line:   88,
+									Column: 32,
+									Line:   121,
 								},
 							},
 						},
-						Name: "true",
-					},
-				}, &ast.Property{
-					BaseNode: ast.BaseNode{
-						Errors: nil,
-						Loc: &ast.SourceLocation{
-							End: ast.Position{
-								Column: 111,
-								Line:   88,
-							},
-							File:   "universe.flux",
-							Source: "tables=<-",
-							Start: ast.Position{
-								Column: 102,
-								Line:   88,
-							},
-						},
-					},
-					Key: &ast.Identifier{
-						BaseNode: ast.BaseNode{
-							Errors: nil,
-							Loc: &ast.SourceLocation{
-								End: ast.Position{
-									Column: 108,
-									Line:   88,
-								},
-								File:   "universe.flux",
-								Source: "tables",
-								Start: ast.Position{
-									Column: 102,
-									Line:   88,
+						Elements: []ast.Expression{&ast.StringLiteral{
+							BaseNode: ast.BaseNode{
+								Errors: nil,
+								Loc: &ast.SourceLocation{
+									End: ast.Position{
+										Column: 41,
+										Line:   121,
+									},
+									File:   "universe.flux",
+									Source: "\"_value\"",
+									Start: ast.Position{
+										Column: 33,
+										Line:   121,
+									},
 								},
 							},
-						},
-						Name: "tables",
+							Value: "_value",
+						}},
 					},
-					Value: &ast.PipeLiteral{BaseNode: ast.BaseNode{
-						Errors: nil,
-						Loc: &ast.SourceLocation{
-							End: ast.Position{
-								Column: 111,
-								Line:   88,
-							},
-							File:   "universe.flux",
-							Source: "<-",
-							Start: ast.Position{
-								Column: 109,
-								Line:   88,
-							},
-						},
-					}},
 				}},
 			},
 		}, &ast.VariableAssignment{
@@ -4199,14 +5297,14 @@ var pkgAST = &ast.Package{
 				Errors: nil,
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
-						Column: 43,
-						Line:   102,
+						Column: 67,
+						Line:   129,
 					},
 					File:   "universe.flux",
-					Source: "increase = (tables=<-, columns=[\"_value\"]) =>\n    tables\n        |> difference(nonNegative: true, columns:columns)\n        |> cumulativeSum(columns: columns)",
+					Source: "exponentialMovingAverage = (n, column=\"_value\", tables=<-) =>\n    tables\n        |> movingAverage(n:n, kernel:\"exponential\", column:column)",
 					Start: ast.Position{
 						Column: 1,
-						Line:   99,
+						Line:   127,
 					},
 				},
 			},
@@ -4215,273 +5313,203 @@ var pkgAST = &ast.Package{
 					Errors: nil,
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
-							Column: 9,
-							Line:   99,
+							Column: 25,
+							Line:   127,
 						},
 						File:   "universe.flux",
-						Source: "increase",
+						Source: "exponentialMovingAverage",
 						Start: ast.Position{
 							Column: 1,
-							Line:   99,
+							Line:   127,
 						},
 					},
 				},
-				Name: "increase",
+				Name: "exponentialMovingAverage",
 			},
 			Init: &ast.FunctionExpression{
 				BaseNode: ast.BaseNode{
 					Errors: nil,
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
-							Column: 43,
-							Line:   102,
+							Column: 67,
+							Line:   129,
 						},
 						File:   "universe.flux",
-						Source: "(tables=<-, columns=[\"_value\"]) =>\n    tables\n        |> difference(nonNegative: true, columns:columns)\n        |> cumulativeSum(columns: columns)",
+						Source: "(n, column=\"_value\", tables=<-) =>\n    tables\n        |> movingAverage(n:n, kernel:\"exponential\", column:column)",
 						Start: ast.Position{
-							Column: 12,
-							Line:   99,
+							Column: 28,
+							Line:   127,
 						},
 					},
 				},
 				Body: &ast.PipeExpression{
-					Argument: &ast.PipeExpression{
-						Argument: &ast.Identifier{
-							BaseNode: ast.BaseNode{
-								Errors: nil,
-								Loc: &ast.SourceLocation{
-									End: ast.Position{
-										Column: 11,
-										Line:   100,
-									},
-									File:   "universe.flux",
-									Source: "tables",
-									Start: ast.Position{
-										Column: 5,
-										Line:   100,
-									},
-								},
-							},
-							Name: "tables",
-						},
+					Argument: &ast.Identifier{
 						BaseNode: ast.BaseNode{
 							Errors: nil,
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
-									Column: 58,
-									Line:   101,
+									Column: 11,
+									Line:   128,
 								},
 								File:   "universe.flux",
-								Source: "tables\n        |> difference(nonNegative: true, columns:columns)",
+								Source: "tables",
 								Start: ast.Position{
 									Column: 5,
-									Line:   100,
+									Line:   128,
+								},
+							},
+						},
+						Name: "tables",
+					},
+					BaseNode: ast.BaseNode{
+						Errors: nil,
+						Loc: &ast.SourceLocation{
+							End: ast.Position{
+								Column: 67,
+								Line:   129,
+							},
+							File:   "universe.flux",
+							Source: "tables\n        |> movingAverage(n:n, kernel:\"exponential\", column:column)",
+							Start: ast.Position{
+								Column: 5,
+								Line:   128,
+							},
+						},
+					},
+					Call: &ast.CallExpression{
+						Arguments: []ast.Expression{&ast.ObjectExpression{
+							BaseNode: ast.BaseNode{
+								Errors: nil,
+								Loc: &ast.SourceLocation{
+									End: ast.Position{
+										Column: 66,
+										Line:   129,
+									},
+									File:   "universe.flux",
+									Source: "n:n, kernel:\"exponential\", column:column",
+									Start: ast.Position{
+										Column: 26,
+										Line:   129,
+									},
 								},
 							},
-						},
-						Call: &ast.CallExpression{
-							Arguments: []ast.Expression{&ast.ObjectExpression{
+							Properties: []*ast.Property{&ast.Property{
 								BaseNode: ast.BaseNode{
 									Errors: nil,
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
-											Column: 57,
-											Line:   101,
+											Column: 29,
+											Line:   129,
 										},
 										File:   "universe.flux",
-										Source: "nonNegative: true, columns:columns",
+										Source: "n:n",
 										Start: ast.Position{
-											Column: 23,
-											Line:   101,
+											Column: 26,
+											Line:   129,
 										},
 									},
 								},
-								Properties: []*ast.Property{&ast.Property{
+								Key: &ast.Identifier{
 									BaseNode: ast.BaseNode{
 										Errors: nil,
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
-												Column: 40,
-												Line:   101,
+												Column: 27,
+												Line:   129,
 											},
 											File:   "universe.flux",
-											Source: "nonNegative: true",
+											Source: "n",
 											Start: ast.Position{
-												Column: 23,
-												Line:   101,
-											},
-										},
-									},
-									Key: &ast.Identifier{
-										BaseNode: ast.BaseNode{
-											Errors: nil,
-											Loc: &ast.SourceLocation{
-												End: ast.Position{
-													Column: 34,
-													Line:   101,
-												},
-												File:   "universe.flux",
-												Source: "nonNegative",
-												Start: ast.Position{
-													Column: 23,
-													Line:   101,
-												},
-											},
-										},
-										Name: "nonNegative",
-									},
-									Value: &ast.Identifier{
-										BaseNode: ast.BaseNode{
-											Errors: nil,
-											Loc: &ast.SourceLocation{
-												End: ast.Position{
-													Column: 40,
-													Line:   101,
-												},
-												File:   "universe.flux",
-												Source: "true",
-												Start: ast.Position{
-													Column: 36,
-													Line:   101,
-												},
+												Column: 26,
+												Line:   129,
 											},
 										},
-										Name: "true",
 									},
-								}, &ast.Property{
+									Name: "n",
+								},
+								Value: &ast.Identifier{
 									BaseNode: ast.BaseNode{
 										Errors: nil,
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
-												Column: 57,
-												Line:   101,
+												Column: 29,
+												Line:   129,
 											},
 											File:   "universe.flux",
-											Source: "columns:columns",
+											Source: "n",
 											Start: ast.Position{
-												Column: 42,
-												Line:   101,
-											},
-										},
-									},
-									Key: &ast.Identifier{
-										BaseNode: ast.BaseNode{
-											Errors: nil,
-											Loc: &ast.SourceLocation{
-												End: ast.Position{
-													Column: 49,
-													Line:   101,
-												},
-												File:   "universe.flux",
-												Source: "columns",
-												Start: ast.Position{
-													Column: 42,
-													Line:   101,
-												},
-											},
-										},
-										Name: "columns",
-									},
-									Value: &ast.Identifier{
-										BaseNode: ast.BaseNode{
-											Errors: nil,
-											Loc: &ast.SourceLocation{
-												End: ast.Position{
-													Column: 57,
-													Line:   101,
-												},
-												File:   "universe.flux",
-												Source: "columns",
-												Start: ast.Position{
-													Column: 50,
-													Line:   101,
-												},
+												Column: 28,
+												Line:   129,
 											},
 										},
-										Name: "columns",
-									},
-								}},
-							}},
-							BaseNode: ast.BaseNode{
-								Errors: nil,
-								Loc: &ast.SourceLocation{
-									End: ast.Position{
-										Column: 58,
-										Line:   101,
-									},
-									File:   "universe.flux",
-									Source: "difference(nonNegative: true, columns:columns)",
-									Start: ast.Position{
-										Column: 12,
-										Line:   101,
 									},
+									Name: "n",
 								},
-							},
-							Callee: &ast.Identifier{
+							}, &ast.Property{
 								BaseNode: ast.BaseNode{
 									Errors: nil,
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
-											Column: 22,
-											Line:   101,
+											Column: 51,
+											Line:   129,
 										},
 										File:   "universe.flux",
-										Source: "difference",
+										Source: "kernel:\"exponential\"",
 										Start: ast.Position{
-											Column: 12,
-											Line:   101,
+											Column: 31,
+											Line:   129,
 										},
 									},
 								},
-								Name: "difference",
-							},
-						},
-					},
-					BaseNode: ast.BaseNode{
-						Errors: nil,
-						Loc: &ast.SourceLocation{
-							End: ast.Position{
-								Column: 43,
-								Line:   102,
-							},
-							File:   "universe.flux",
-							Source: "tables\n        |> difference(nonNegative: true, columns:columns)\n        |> cumulativeSum(columns: columns)",
-							Start: ast.Position{
-								Column: 5,
-								Line:   100,
-							},
-						},
-					},
-					Call: &ast.CallExpression{
-						Arguments: []ast.Expression{&ast.ObjectExpression{
-							BaseNode: ast.BaseNode{
-								Errors: nil,
-								Loc: &ast.SourceLocation{
-									End: ast.Position{
-										Column: 42,
-										Line:   102,
+								Key: &ast.Identifier{
+									BaseNode: ast.BaseNode{
+										Errors: nil,
+										Loc: &ast.SourceLocation{
+											End: ast.Position{
+												Column: 37,
+												Line:   129,
+											},
+											File:   "universe.flux",
+											Source: "kernel",
+											Start: ast.Position{
+												Column: 31,
+												Line:   129,
+											},
+										},
 									},
-									File:   "universe.flux",
-									Source: "columns: columns",
-									Start: ast.Position{
-										Column: 26,
-										Line:   102,
+									Name: "kernel",
+								},
+								Value: &ast.StringLiteral{
+									BaseNode: ast.BaseNode{
+										Errors: nil,
+										Loc: &ast.SourceLocation{
+											End: ast.Position{
+												Column: 51,
+												Line:   129,
+											},
+											File:   "universe.flux",
+											Source: "\"exponential\"",
+											Start: ast.Position{
+												Column: 38,
+												Line:   129,
+											},
+										},
 									},
+									Value: "exponential",
 								},
-							},
-							Properties: []*ast.Property{&ast.Property{
+							}, &ast.Property{
 								BaseNode: ast.BaseNode{
 									Errors: nil,
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
-											Column: 42,
-											Line:   102,
+											Column: 66,
+											Line:   129,
 										},
 										File:   "universe.flux",
-										Source: "columns: columns",
+										Source: "column:column",
 										Start: ast.Position{
-											Column: 26,
-											Line:   102,
+											Column: 53,
+											Line:   129,
 										},
 									},
 								},
@@ -4490,36 +5518,36 @@ var pkgAST = &ast.Package{
 										Errors: nil,
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
-												Column: 33,
-												Line:   102,
+												Column: 59,
+												Line:   129,
 											},
 											File:   "universe.flux",
-											Source: "columns",
+											Source: "column",
 											Start: ast.Position{
-												Column: 26,
-												Line:   102,
+												Column: 53,
+												Line:   129,
 											},
 										},
 									},
-									Name: "columns",
+									Name: "column",
 								},
 								Value: &ast.Identifier{
 									BaseNode: ast.BaseNode{
 										Errors: nil,
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
-												Column: 42,
-												Line:   102,
+												Column: 66,
+												Line:   129,
 											},
 											File:   "universe.flux",
-											Source: "columns",
+											Source: "column",
 											Start: ast.Position{
-												Column: 35,
-												Line:   102,
+												Column: 60,
+												Line:   129,
 											},
 										},
 									},
-									Name: "columns",
+									Name: "column",
 								},
 							}},
 						}},
@@ -4527,14 +5555,14 @@ var pkgAST = &ast.Package{
 							Errors: nil,
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
-									Column: 43,
-									Line:   102,
+									Column: 67,
+									Line:   129,
 								},
 								File:   "universe.flux",
-								Source: "cumulativeSum(columns: columns)",
+								Source: "movingAverage(n:n, kernel:\"exponential\", column:column)",
 								Start: ast.Position{
 									Column: 12,
-									Line:   102,
+									Line:   129,
 								},
 							},
 						},
@@ -4544,17 +5572,17 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 25,
-										Line:   102,
+										Line:   129,
 									},
 									File:   "universe.flux",
-									Source: "cumulativeSum",
+									Source: "movingAverage",
 									Start: ast.Position{
 										Column: 12,
-										Line:   102,
+										Line:   129,
 									},
 								},
 							},
-							Name: "cumulativeSum",
+							Name: "movingAverage",
 						},
 					},
 				},
@@ -4563,14 +5591,14 @@ var pkgAST = &ast.Package{
 						Errors: nil,
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
-								Column: 22,
-								Line:   99,
+								Column: 30,
+								Line:   127,
 							},
 							File:   "universe.flux",
-							Source: "tables=<-",
+							Source: "n",
 							Start: ast.Position{
-								Column: 13,
-								Line:   99,
+								Column: 29,
+								Line:   127,
 							},
 						},
 					},
@@ -4579,47 +5607,85 @@ var pkgAST = &ast.Package{
 							Errors: nil,
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
-									Column: 19,
-									Line:   99,
+									Column: 30,
+									Line:   127,
 								},
 								File:   "universe.flux",
-								Source: "tables",
+								Source: "n",
 								Start: ast.Position{
-									Column: 13,
-									Line:   99,
+									Column: 29,
+									Line:   127,
 								},
 							},
 						},
-						Name: "tables",
+						Name: "n",
 					},
-					Value: &ast.PipeLiteral{BaseNode: ast.BaseNode{
+					Value: nil,
+				}, &ast.Property{
+					BaseNode: ast.BaseNode{
 						Errors: nil,
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
-								Column: 22,
-								Line:   99,
+								Column: 47,
+								Line:   127,
 							},
 							File:   "universe.flux",
-							Source: "<-",
+							Source: "column=\"_value\"",
 							Start: ast.Position{
-								Column: 20,
-								Line:   99,
+								Column: 32,
+								Line:   127,
+							},
+						},
+					},
+					Key: &ast.Identifier{
+						BaseNode: ast.BaseNode{
+							Errors: nil,
+							Loc: &ast.SourceLocation{
+								End: ast.Position{
+									Column: 38,
+									Line:   127,
+								},
+								File:   "universe.flux",
+								Source: "column",
+								Start: ast.Position{
+									Column: 32,
+									Line:   127,
+								},
+							},
+						},
+						Name: "column",
+					},
+					Value: &ast.StringLiteral{
+						BaseNode: ast.BaseNode{
+							Errors: nil,
+							Loc: &ast.SourceLocation{
+								End: ast.Position{
+									Column: 47,
+									Line:   127,
+								},
+								File:   "universe.flux",
+								Source: "\"_value\"",
+								Start: ast.Position{
+									Column: 39,
+									Line:   127,
+								},
 							},
 						},
-					}},
+						Value: "_value",
+					},
 				}, &ast.Property{
 					BaseNode: ast.BaseNode{
 						Errors: nil,
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
-								Column: 42,
-								Line:   99,
+								Column: 58,
+								Line:   127,
 							},
 							File:   "universe.flux",
-							Source: "columns=[\"_value\"]",
+							Source: "tables=<-",
 							Start: ast.Position{
-								Column: 24,
-								Line:   99,
+								Column: 49,
+								Line:   127,
 							},
 						},
 					},
@@ -4628,54 +5694,34 @@ var pkgAST = &ast.Package{
 							Errors: nil,
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
-									Column: 31,
-									Line:   99,
+									Column: 55,
+									Line:   127,
 								},
 								File:   "universe.flux",
-								Source: "columns",
+								Source: "tables",
 								Start: ast.Position{
-									Column: 24,
-									Line:   99,
+									Column: 49,
+									Line:   127,
 								},
 							},
 						},
-						Name: "columns",
+						Name: "tables",
 					},
-					Value: &ast.ArrayExpression{
-						BaseNode: ast.BaseNode{
-							Errors: nil,
-							Loc: &ast.SourceLocation{
-								End: ast.Position{
-									Column: 42,
-									Line:   99,
-								},
-								File:   "universe.flux",
-								Source: "[\"_value\"]",
-								Start: ast.Position{
-									Column: 32,
-									Line:   99,
-								},
+					Value: &ast.PipeLiteral{BaseNode: ast.BaseNode{
+						Errors: nil,
+						Loc: &ast.SourceLocation{
+							End: ast.Position{
+								Column: 58,
+								Line:   127,
 							},
-						},
-						Elements: []ast.Expression{&ast.StringLiteral{
-							BaseNode: ast.BaseNode{
-								Errors: nil,
-								Loc: &ast.SourceLocation{
-									End: ast.Position{
-										Column: 41,
-										Line:   99,
-									},
-									File:   "universe.flux",
-									Source: "\"_value\"",
-									Start: ast.Position{
-										Column: 33,
-										Line:   99,
-									},
-								},
+							File:   "universe.flux",
+							Source: "<-",
+							Start: ast.Position{
+								Column: 56,
+								Line:   127,
 							},
-							Value: "_value",
-						}},
-					},
+						},
+					}},
 				}},
 			},
 		}, &ast.VariableAssignment{
@@ -4684,13 +5730,13 @@ var pkgAST = &ast.Package{
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
 						Column: 78,
-						Line:   109,
+						Line:   136,
 					},
 					File:   "universe.flux",
 					Source: "median = (method=\"estimate_tdigest\", compression=0.0, tables=<-) =>\n    tables\n        |> percentile(percentile:0.5, method:method, compression:compression)",
 					Start: ast.Position{
 						Column: 1,
-						Line:   107,
+						Line:   134,
 					},
 				},
 			},
@@ -4700,13 +5746,13 @@ var pkgAST = &ast.Package{
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
 							Column: 7,
-							Line:   107,
+							Line:   134,
 						},
 						File:   "universe.flux",
 						Source: "median",
 						Start: ast.Position{
 							Column: 1,
-							Line:   107,
+							Line:   134,
 						},
 					},
 				},
@@ -4718,13 +5764,13 @@ var pkgAST = &ast.Package{
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
 							Column: 78,
-							Line:   109,
+							Line:   136,
 						},
 						File:   "universe.flux",
 						Source: "(method=\"estimate_tdigest\", compression=0.0, tables=<-) =>\n    tables\n        |> percentile(percentile:0.5, method:method, compression:compression)",
 						Start: ast.Position{
 							Column: 10,
-							Line:   107,
+							Line:   134,
 						},
 					},
 				},
@@ -4735,13 +5781,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 11,
-									Line:   108,
+									Line:   135,
 								},
 								File:   "universe.flux",
 								Source: "tables",
 								Start: ast.Position{
 									Column: 5,
-									Line:   108,
+									Line:   135,
 								},
 							},
 						},
@@ -4752,13 +5798,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 78,
-								Line:   109,
+								Line:   136,
 							},
 							File:   "universe.flux",
 							Source: "tables\n        |> percentile(percentile:0.5, method:method, compression:compression)",
 							Start: ast.Position{
 								Column: 5,
-								Line:   108,
+								Line:   135,
 							},
 						},
 					},
@@ -4769,13 +5815,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 77,
-										Line:   109,
+										Line:   136,
 									},
 									File:   "universe.flux",
 									Source: "percentile:0.5, method:method, compression:compression",
 									Start: ast.Position{
 										Column: 23,
-										Line:   109,
+										Line:   136,
 									},
 								},
 							},
@@ -4785,13 +5831,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 37,
-											Line:   109,
+											Line:   136,
 										},
 										File:   "universe.flux",
 										Source: "percentile:0.5",
 										Start: ast.Position{
 											Column: 23,
-											Line:   109,
+											Line:   136,
 										},
 									},
 								},
@@ -4801,13 +5847,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 33,
-												Line:   109,
+												Line:   136,
 											},
 											File:   "universe.flux",
 											Source: "percentile",
 											Start: ast.Position{
 												Column: 23,
-												Line:   109,
+												Line:   136,
 											},
 										},
 									},
@@ -4819,13 +5865,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 37,
-												Line:   109,
+												Line:   136,
 											},
 											File:   "universe.flux",
 											Source: "0.5",
 											Start: ast.Position{
 												Column: 34,
-												Line:   109,
+												Line:   136,
 											},
 										},
 									},
@@ -4837,13 +5883,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 52,
-											Line:   109,
+											Line:   136,
 										},
 										File:   "universe.flux",
 										Source: "method:method",
 										Start: ast.Position{
 											Column: 39,
-											Line:   109,
+											Line:   136,
 										},
 									},
 								},
@@ -4853,13 +5899,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 45,
-												Line:   109,
+												Line:   136,
 											},
 											File:   "universe.flux",
 											Source: "method",
 											Start: ast.Position{
 												Column: 39,
-												Line:   109,
+												Line:   136,
 											},
 										},
 									},
@@ -4871,13 +5917,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 52,
-												Line:   109,
+												Line:   136,
 											},
 											File:   "universe.flux",
 											Source: "method",
 											Start: ast.Position{
 												Column: 46,
-												Line:   109,
+												Line:   136,
 											},
 										},
 									},
@@ -4889,13 +5935,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 77,
-											Line:   109,
+											Line:   136,
 										},
 										File:   "universe.flux",
 										Source: "compression:compression",
 										Start: ast.Position{
 											Column: 54,
-											Line:   109,
+											Line:   136,
 										},
 									},
 								},
@@ -4905,13 +5951,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 65,
-												Line:   109,
+												Line:   136,
 											},
 											File:   "universe.flux",
 											Source: "compression",
 											Start: ast.Position{
 												Column: 54,
-												Line:   109,
+												Line:   136,
 											},
 										},
 									},
@@ -4923,13 +5969,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 77,
-												Line:   109,
+												Line:   136,
 											},
 											File:   "universe.flux",
 											Source: "compression",
 											Start: ast.Position{
 												Column: 66,
-												Line:   109,
+												Line:   136,
 											},
 										},
 									},
@@ -4942,13 +5988,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 78,
-									Line:   109,
+									Line:   136,
 								},
 								File:   "universe.flux",
 								Source: "percentile(percentile:0.5, method:method, compression:compression)",
 								Start: ast.Position{
 									Column: 12,
-									Line:   109,
+									Line:   136,
 								},
 							},
 						},
@@ -4958,13 +6004,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 22,
-										Line:   109,
+										Line:   136,
 									},
 									File:   "universe.flux",
 									Source: "percentile",
 									Start: ast.Position{
 										Column: 12,
-										Line:   109,
+										Line:   136,
 									},
 								},
 							},
@@ -4978,13 +6024,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 36,
-								Line:   107,
+								Line:   134,
 							},
 							File:   "universe.flux",
 							Source: "method=\"estimate_tdigest\"",
 							Start: ast.Position{
 								Column: 11,
-								Line:   107,
+								Line:   134,
 							},
 						},
 					},
@@ -4994,13 +6040,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 17,
-									Line:   107,
+									Line:   134,
 								},
 								File:   "universe.flux",
 								Source: "method",
 								Start: ast.Position{
 									Column: 11,
-									Line:   107,
+									Line:   134,
 								},
 							},
 						},
@@ -5012,13 +6058,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 36,
-									Line:   107,
+									Line:   134,
 								},
 								File:   "universe.flux",
 								Source: "\"estimate_tdigest\"",
 								Start: ast.Position{
 									Column: 18,
-									Line:   107,
+									Line:   134,
 								},
 							},
 						},
@@ -5030,13 +6076,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 53,
-								Line:   107,
+								Line:   134,
 							},
 							File:   "universe.flux",
 							Source: "compression=0.0",
 							Start: ast.Position{
 								Column: 38,
-								Line:   107,
+								Line:   134,
 							},
 						},
 					},
@@ -5046,13 +6092,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 49,
-									Line:   107,
+									Line:   134,
 								},
 								File:   "universe.flux",
 								Source: "compression",
 								Start: ast.Position{
 									Column: 38,
-									Line:   107,
+									Line:   134,
 								},
 							},
 						},
@@ -5064,13 +6110,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 53,
-									Line:   107,
+									Line:   134,
 								},
 								File:   "universe.flux",
 								Source: "0.0",
 								Start: ast.Position{
 									Column: 50,
-									Line:   107,
+									Line:   134,
 								},
 							},
 						},
@@ -5082,13 +6128,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 64,
-								Line:   107,
+								Line:   134,
 							},
 							File:   "universe.flux",
 							Source: "tables=<-",
 							Start: ast.Position{
 								Column: 55,
-								Line:   107,
+								Line:   134,
 							},
 						},
 					},
@@ -5098,13 +6144,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 61,
-									Line:   107,
+									Line:   134,
 								},
 								File:   "universe.flux",
 								Source: "tables",
 								Start: ast.Position{
 									Column: 55,
-									Line:   107,
+									Line:   134,
 								},
 							},
 						},
@@ -5115,13 +6161,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 64,
-								Line:   107,
+								Line:   134,
 							},
 							File:   "universe.flux",
 							Source: "<-",
 							Start: ast.Position{
 								Column: 62,
-								Line:   107,
+								Line:   134,
 							},
 						},
 					}},
@@ -5133,13 +6179,13 @@ var pkgAST = &ast.Package{
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
 						Column: 52,
-						Line:   122,
+						Line:   149,
 					},
 					File:   "universe.flux",
 					Source: "stateCount = (fn, column=\"stateCount\", tables=<-) =>\n    tables\n        |> stateTracking(countColumn:column, fn:fn)",
 					Start: ast.Position{
 						Column: 1,
-						Line:   120,
+						Line:   147,
 					},
 				},
 			},
@@ -5149,13 +6195,13 @@ var pkgAST = &ast.Package{
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
 							Column: 11,
-							Line:   120,
+							Line:   147,
 						},
 						File:   "universe.flux",
 						Source: "stateCount",
 						Start: ast.Position{
 							Column: 1,
-							Line:   120,
+							Line:   147,
 						},
 					},
 				},
@@ -5167,13 +6213,13 @@ var pkgAST = &ast.Package{
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
 							Column: 52,
-							Line:   122,
+							Line:   149,
 						},
 						File:   "universe.flux",
 						Source: "(fn, column=\"stateCount\", tables=<-) =>\n    tables\n        |> stateTracking(countColumn:column, fn:fn)",
 						Start: ast.Position{
 							Column: 14,
-							Line:   120,
+							Line:   147,
 						},
 					},
 				},
@@ -5184,13 +6230,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 11,
-									Line:   121,
+									Line:   148,
 								},
 								File:   "universe.flux",
 								Source: "tables",
 								Start: ast.Position{
 									Column: 5,
-									Line:   121,
+									Line:   148,
 								},
 							},
 						},
@@ -5201,13 +6247,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 52,
-								Line:   122,
+								Line:   149,
 							},
 							File:   "universe.flux",
 							Source: "tables\n        |> stateTracking(countColumn:column, fn:fn)",
 							Start: ast.Position{
 								Column: 5,
-								Line:   121,
+								Line:   148,
 							},
 						},
 					},
@@ -5218,13 +6264,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 51,
-										Line:   122,
+										Line:   149,
 									},
 									File:   "universe.flux",
 									Source: "countColumn:column, fn:fn",
 									Start: ast.Position{
 										Column: 26,
-										Line:   122,
+										Line:   149,
 									},
 								},
 							},
@@ -5234,13 +6280,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 44,
-											Line:   122,
+											Line:   149,
 										},
 										File:   "universe.flux",
 										Source: "countColumn:column",
 										Start: ast.Position{
 											Column: 26,
-											Line:   122,
+											Line:   149,
 										},
 									},
 								},
@@ -5250,13 +6296,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 37,
-												Line:   122,
+												Line:   149,
 											},
 											File:   "universe.flux",
 											Source: "countColumn",
 											Start: ast.Position{
 												Column: 26,
-												Line:   122,
+												Line:   149,
 											},
 										},
 									},
@@ -5268,13 +6314,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 44,
-												Line:   122,
+												Line:   149,
 											},
 											File:   "universe.flux",
 											Source: "column",
 											Start: ast.Position{
 												Column: 38,
-												Line:   122,
+												Line:   149,
 											},
 										},
 									},
@@ -5286,13 +6332,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 51,
-											Line:   122,
+											Line:   149,
 										},
 										File:   "universe.flux",
 										Source: "fn:fn",
 										Start: ast.Position{
 											Column: 46,
-											Line:   122,
+											Line:   149,
 										},
 									},
 								},
@@ -5302,13 +6348,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 48,
-												Line:   122,
+												Line:   149,
 											},
 											File:   "universe.flux",
 											Source: "fn",
 											Start: ast.Position{
 												Column: 46,
-												Line:   122,
+												Line:   149,
 											},
 										},
 									},
@@ -5320,13 +6366,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 51,
-												Line:   122,
+												Line:   149,
 											},
 											File:   "universe.flux",
 											Source: "fn",
 											Start: ast.Position{
 												Column: 49,
-												Line:   122,
+												Line:   149,
 											},
 										},
 									},
@@ -5339,13 +6385,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 52,
-									Line:   122,
+									Line:   149,
 								},
 								File:   "universe.flux",
 								Source: "stateTracking(countColumn:column, fn:fn)",
 								Start: ast.Position{
 									Column: 12,
-									Line:   122,
+									Line:   149,
 								},
 							},
 						},
@@ -5355,13 +6401,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 25,
-										Line:   122,
+										Line:   149,
 									},
 									File:   "universe.flux",
 									Source: "stateTracking",
 									Start: ast.Position{
 										Column: 12,
-										Line:   122,
+										Line:   149,
 									},
 								},
 							},
@@ -5375,13 +6421,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 17,
-								Line:   120,
+								Line:   147,
 							},
 							File:   "universe.flux",
 							Source: "fn",
 							Start: ast.Position{
 								Column: 15,
-								Line:   120,
+								Line:   147,
 							},
 						},
 					},
@@ -5391,13 +6437,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 17,
-									Line:   120,
+									Line:   147,
 								},
 								File:   "universe.flux",
 								Source: "fn",
 								Start: ast.Position{
 									Column: 15,
-									Line:   120,
+									Line:   147,
 								},
 							},
 						},
@@ -5410,13 +6456,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 38,
-								Line:   120,
+								Line:   147,
 							},
 							File:   "universe.flux",
 							Source: "column=\"stateCount\"",
 							Start: ast.Position{
 								Column: 19,
-								Line:   120,
+								Line:   147,
 							},
 						},
 					},
@@ -5426,13 +6472,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 25,
-									Line:   120,
+									Line:   147,
 								},
 								File:   "universe.flux",
 								Source: "column",
 								Start: ast.Position{
 									Column: 19,
-									Line:   120,
+									Line:   147,
 								},
 							},
 						},
@@ -5444,13 +6490,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 38,
-									Line:   120,
+									Line:   147,
 								},
 								File:   "universe.flux",
 								Source: "\"stateCount\"",
 								Start: ast.Position{
 									Column: 26,
-									Line:   120,
+									Line:   147,
 								},
 							},
 						},
@@ -5462,13 +6508,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 49,
-								Line:   120,
+								Line:   147,
 							},
 							File:   "universe.flux",
 							Source: "tables=<-",
 							Start: ast.Position{
 								Column: 40,
-								Line:   120,
+								Line:   147,
 							},
 						},
 					},
@@ -5478,13 +6524,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 46,
-									Line:   120,
+									Line:   147,
 								},
 								File:   "universe.flux",
 								Source: "tables",
 								Start: ast.Position{
 									Column: 40,
-									Line:   120,
+									Line:   147,
 								},
 							},
 						},
@@ -5495,13 +6541,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 49,
-								Line:   120,
+								Line:   147,
 							},
 							File:   "universe.flux",
 							Source: "<-",
 							Start: ast.Position{
 								Column: 47,
-								Line:   120,
+								Line:   147,
 							},
 						},
 					}},
@@ -5513,13 +6559,13 @@ var pkgAST = &ast.Package{
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
 						Column: 97,
-						Line:   141,
+						Line:   168,
 					},
 					File:   "universe.flux",
 					Source: "stateDuration = (fn, column=\"stateDuration\", timeColumn=\"_time\", unit=1s, tables=<-) =>\n    tables\n        |> stateTracking(durationColumn:column, timeColumn:timeColumn, fn:fn, durationUnit:unit)",
 					Start: ast.Position{
 						Column: 1,
-						Line:   139,
+						Line:   166,
 					},
 				},
 			},
@@ -5529,13 +6575,13 @@ var pkgAST = &ast.Package{
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
 							Column: 14,
-							Line:   139,
+							Line:   166,
 						},
 						File:   "universe.flux",
 						Source: "stateDuration",
 						Start: ast.Position{
 							Column: 1,
-							Line:   139,
+							Line:   166,
 						},
 					},
 				},
@@ -5547,13 +6593,13 @@ var pkgAST = &ast.Package{
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
 							Column: 97,
-							Line:   141,
+							Line:   168,
 						},
 						File:   "universe.flux",
 						Source: "(fn, column=\"stateDuration\", timeColumn=\"_time\", unit=1s, tables=<-) =>\n    tables\n        |> stateTracking(durationColumn:column, timeColumn:timeColumn, fn:fn, durationUnit:unit)",
 						Start: ast.Position{
 							Column: 17,
-							Line:   139,
+							Line:   166,
 						},
 					},
 				},
@@ -5564,13 +6610,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 11,
-									Line:   140,
+									Line:   167,
 								},
 								File:   "universe.flux",
 								Source: "tables",
 								Start: ast.Position{
 									Column: 5,
-									Line:   140,
+									Line:   167,
 								},
 							},
 						},
@@ -5581,13 +6627,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 97,
-								Line:   141,
+								Line:   168,
 							},
 							File:   "universe.flux",
 							Source: "tables\n        |> stateTracking(durationColumn:column, timeColumn:timeColumn, fn:fn, durationUnit:unit)",
 							Start: ast.Position{
 								Column: 5,
-								Line:   140,
+								Line:   167,
 							},
 						},
 					},
@@ -5598,13 +6644,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 96,
-										Line:   141,
+										Line:   168,
 									},
 									File:   "universe.flux",
 									Source: "durationColumn:column, timeColumn:timeColumn, fn:fn, durationUnit:unit",
 									Start: ast.Position{
 										Column: 26,
-										Line:   141,
+										Line:   168,
 									},
 								},
 							},
@@ -5614,13 +6660,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 47,
-											Line:   141,
+											Line:   168,
 										},
 										File:   "universe.flux",
 										Source: "durationColumn:column",
 										Start: ast.Position{
 											Column: 26,
-											Line:   141,
+											Line:   168,
 										},
 									},
 								},
@@ -5630,13 +6676,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 40,
-												Line:   141,
+												Line:   168,
 											},
 											File:   "universe.flux",
 											Source: "durationColumn",
 											Start: ast.Position{
 												Column: 26,
-												Line:   141,
+												Line:   168,
 											},
 										},
 									},
@@ -5648,13 +6694,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 47,
-												Line:   141,
+												Line:   168,
 											},
 											File:   "universe.flux",
 											Source: "column",
 											Start: ast.Position{
 												Column: 41,
-												Line:   141,
+												Line:   168,
 											},
 										},
 									},
@@ -5666,13 +6712,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 70,
-											Line:   141,
+											Line:   168,
 										},
 										File:   "universe.flux",
 										Source: "timeColumn:timeColumn",
 										Start: ast.Position{
 											Column: 49,
-											Line:   141,
+											Line:   168,
 										},
 									},
 								},
@@ -5682,13 +6728,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 59,
-												Line:   141,
+												Line:   168,
 											},
 											File:   "universe.flux",
 											Source: "timeColumn",
 											Start: ast.Position{
 												Column: 49,
-												Line:   141,
+												Line:   168,
 											},
 										},
 									},
@@ -5700,13 +6746,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 70,
-												Line:   141,
+												Line:   168,
 											},
 											File:   "universe.flux",
 											Source: "timeColumn",
 											Start: ast.Position{
 												Column: 60,
-												Line:   141,
+												Line:   168,
 											},
 										},
 									},
@@ -5718,13 +6764,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 77,
-											Line:   141,
+											Line:   168,
 										},
 										File:   "universe.flux",
 										Source: "fn:fn",
 										Start: ast.Position{
 											Column: 72,
-											Line:   141,
+											Line:   168,
 										},
 									},
 								},
@@ -5734,13 +6780,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 74,
-												Line:   141,
+												Line:   168,
 											},
 											File:   "universe.flux",
 											Source: "fn",
 											Start: ast.Position{
 												Column: 72,
-												Line:   141,
+												Line:   168,
 											},
 										},
 									},
@@ -5752,13 +6798,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 77,
-												Line:   141,
+												Line:   168,
 											},
 											File:   "universe.flux",
 											Source: "fn",
 											Start: ast.Position{
 												Column: 75,
-												Line:   141,
+												Line:   168,
 											},
 										},
 									},
@@ -5770,13 +6816,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 96,
-											Line:   141,
+											Line:   168,
 										},
 										File:   "universe.flux",
 										Source: "durationUnit:unit",
 										Start: ast.Position{
 											Column: 79,
-											Line:   141,
+											Line:   168,
 										},
 									},
 								},
@@ -5786,13 +6832,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 91,
-												Line:   141,
+												Line:   168,
 											},
 											File:   "universe.flux",
 											Source: "durationUnit",
 											Start: ast.Position{
 												Column: 79,
-												Line:   141,
+												Line:   168,
 											},
 										},
 									},
@@ -5804,13 +6850,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 96,
-												Line:   141,
+												Line:   168,
 											},
 											File:   "universe.flux",
 											Source: "unit",
 											Start: ast.Position{
 												Column: 92,
-												Line:   141,
+												Line:   168,
 											},
 										},
 									},
@@ -5823,13 +6869,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 97,
-									Line:   141,
+									Line:   168,
 								},
 								File:   "universe.flux",
 								Source: "stateTracking(durationColumn:column, timeColumn:timeColumn, fn:fn, durationUnit:unit)",
 								Start: ast.Position{
 									Column: 12,
-									Line:   141,
+									Line:   168,
 								},
 							},
 						},
@@ -5839,13 +6885,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 25,
-										Line:   141,
+										Line:   168,
 									},
 									File:   "universe.flux",
 									Source: "stateTracking",
 									Start: ast.Position{
 										Column: 12,
-										Line:   141,
+										Line:   168,
 									},
 								},
 							},
@@ -5859,13 +6905,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 20,
-								Line:   139,
+								Line:   166,
 							},
 							File:   "universe.flux",
 							Source: "fn",
 							Start: ast.Position{
 								Column: 18,
-								Line:   139,
+								Line:   166,
 							},
 						},
 					},
@@ -5875,13 +6921,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 20,
-									Line:   139,
+									Line:   166,
 								},
 								File:   "universe.flux",
 								Source: "fn",
 								Start: ast.Position{
 									Column: 18,
-									Line:   139,
+									Line:   166,
 								},
 							},
 						},
@@ -5894,13 +6940,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 44,
-								Line:   139,
+								Line:   166,
 							},
 							File:   "universe.flux",
 							Source: "column=\"stateDuration\"",
 							Start: ast.Position{
 								Column: 22,
-								Line:   139,
+								Line:   166,
 							},
 						},
 					},
@@ -5910,13 +6956,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 28,
-									Line:   139,
+									Line:   166,
 								},
 								File:   "universe.flux",
 								Source: "column",
 								Start: ast.Position{
 									Column: 22,
-									Line:   139,
+									Line:   166,
 								},
 							},
 						},
@@ -5928,13 +6974,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 44,
-									Line:   139,
+									Line:   166,
 								},
 								File:   "universe.flux",
 								Source: "\"stateDuration\"",
 								Start: ast.Position{
 									Column: 29,
-									Line:   139,
+									Line:   166,
 								},
 							},
 						},
@@ -5946,13 +6992,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 64,
-								Line:   139,
+								Line:   166,
 							},
 							File:   "universe.flux",
 							Source: "timeColumn=\"_time\"",
 							Start: ast.Position{
 								Column: 46,
-								Line:   139,
+								Line:   166,
 							},
 						},
 					},
@@ -5962,13 +7008,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 56,
-									Line:   139,
+									Line:   166,
 								},
 								File:   "universe.flux",
 								Source: "timeColumn",
 								Start: ast.Position{
 									Column: 46,
-									Line:   139,
+									Line:   166,
 								},
 							},
 						},
@@ -5980,13 +7026,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 64,
-									Line:   139,
+									Line:   166,
 								},
 								File:   "universe.flux",
 								Source: "\"_time\"",
 								Start: ast.Position{
 									Column: 57,
-									Line:   139,
+									Line:   166,
 								},
 							},
 						},
@@ -5998,13 +7044,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 73,
-								Line:   139,
+								Line:   166,
 							},
 							File:   "universe.flux",
 							Source: "unit=1s",
 							Start: ast.Position{
 								Column: 66,
-								Line:   139,
+								Line:   166,
 							},
 						},
 					},
@@ -6014,13 +7060,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 70,
-									Line:   139,
+									Line:   166,
 								},
 								File:   "universe.flux",
 								Source: "unit",
 								Start: ast.Position{
 									Column: 66,
-									Line:   139,
+									Line:   166,
 								},
 							},
 						},
@@ -6032,13 +7078,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 73,
-									Line:   139,
+									Line:   166,
 								},
 								File:   "universe.flux",
 								Source: "1s",
 								Start: ast.Position{
 									Column: 71,
-									Line:   139,
+									Line:   166,
 								},
 							},
 						},
@@ -6053,13 +7099,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 84,
-								Line:   139,
+								Line:   166,
 							},
 							File:   "universe.flux",
 							Source: "tables=<-",
 							Start: ast.Position{
 								Column: 75,
-								Line:   139,
+								Line:   166,
 							},
 						},
 					},
@@ -6069,13 +7115,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 81,
-									Line:   139,
+									Line:   166,
 								},
 								File:   "universe.flux",
 								Source: "tables",
 								Start: ast.Position{
 									Column: 75,
-									Line:   139,
+									Line:   166,
 								},
 							},
 						},
@@ -6086,13 +7132,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 84,
-								Line:   139,
+								Line:   166,
 							},
 							File:   "universe.flux",
 							Source: "<-",
 							Start: ast.Position{
 								Column: 82,
-								Line:   139,
+								Line:   166,
 							},
 						},
 					}},
@@ -6104,13 +7150,13 @@ var pkgAST = &ast.Package{
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
 						Column: 22,
-						Line:   147,
+						Line:   174,
 					},
 					File:   "universe.flux",
 					Source: "_sortLimit = (n, desc, columns=[\"_value\"], tables=<-) =>\n    tables\n        |> sort(columns:columns, desc:desc)\n        |> limit(n:n)",
 					Start: ast.Position{
 						Column: 1,
-						Line:   144,
+						Line:   171,
 					},
 				},
 			},
@@ -6120,13 +7166,13 @@ var pkgAST = &ast.Package{
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
 							Column: 11,
-							Line:   144,
+							Line:   171,
 						},
 						File:   "universe.flux",
 						Source: "_sortLimit",
 						Start: ast.Position{
 							Column: 1,
-							Line:   144,
+							Line:   171,
 						},
 					},
 				},
@@ -6138,13 +7184,13 @@ var pkgAST = &ast.Package{
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
 							Column: 22,
-							Line:   147,
+							Line:   174,
 						},
 						File:   "universe.flux",
 						Source: "(n, desc, columns=[\"_value\"], tables=<-) =>\n    tables\n        |> sort(columns:columns, desc:desc)\n        |> limit(n:n)",
 						Start: ast.Position{
 							Column: 14,
-							Line:   144,
+							Line:   171,
 						},
 					},
 				},
@@ -6156,13 +7202,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 11,
-										Line:   145,
+										Line:   172,
 									},
 									File:   "universe.flux",
 									Source: "tables",
 									Start: ast.Position{
 										Column: 5,
-										Line:   145,
+										Line:   172,
 									},
 								},
 							},
@@ -6173,13 +7219,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 44,
-									Line:   146,
+									Line:   173,
 								},
 								File:   "universe.flux",
 								Source: "tables\n        |> sort(columns:columns, desc:desc)",
 								Start: ast.Position{
 									Column: 5,
-									Line:   145,
+									Line:   172,
 								},
 							},
 						},
@@ -6190,13 +7236,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 43,
-											Line:   146,
+											Line:   173,
 										},
 										File:   "universe.flux",
 										Source: "columns:columns, desc:desc",
 										Start: ast.Position{
 											Column: 17,
-											Line:   146,
+											Line:   173,
 										},
 									},
 								},
@@ -6206,13 +7252,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 32,
-												Line:   146,
+												Line:   173,
 											},
 											File:   "universe.flux",
 											Source: "columns:columns",
 											Start: ast.Position{
 												Column: 17,
-												Line:   146,
+												Line:   173,
 											},
 										},
 									},
@@ -6222,13 +7268,13 @@ var pkgAST = &ast.Package{
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 24,
-													Line:   146,
+													Line:   173,
 												},
 												File:   "universe.flux",
 												Source: "columns",
 												Start: ast.Position{
 													Column: 17,
-													Line:   146,
+													Line:   173,
 												},
 											},
 										},
@@ -6240,13 +7286,13 @@ var pkgAST = &ast.Package{
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 32,
-													Line:   146,
+													Line:   173,
 												},
 												File:   "universe.flux",
 												Source: "columns",
 												Start: ast.Position{
 													Column: 25,
-													Line:   146,
+													Line:   173,
 												},
 											},
 										},
@@ -6258,13 +7304,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 43,
-												Line:   146,
+												Line:   173,
 											},
 											File:   "universe.flux",
 											Source: "desc:desc",
 											Start: ast.Position{
 												Column: 34,
-												Line:   146,
+												Line:   173,
 											},
 										},
 									},
@@ -6274,13 +7320,13 @@ var pkgAST = &ast.Package{
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 38,
-													Line:   146,
+													Line:   173,
 												},
 												File:   "universe.flux",
 												Source: "desc",
 												Start: ast.Position{
 													Column: 34,
-													Line:   146,
+													Line:   173,
 												},
 											},
 										},
@@ -6292,13 +7338,13 @@ var pkgAST = &ast.Package{
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 43,
-													Line:   146,
+													Line:   173,
 												},
 												File:   "universe.flux",
 												Source: "desc",
 												Start: ast.Position{
 													Column: 39,
-													Line:   146,
+													Line:   173,
 												},
 											},
 										},
@@ -6311,13 +7357,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 44,
-										Line:   146,
+										Line:   173,
 									},
 									File:   "universe.flux",
 									Source: "sort(columns:columns, desc:desc)",
 									Start: ast.Position{
 										Column: 12,
-										Line:   146,
+										Line:   173,
 									},
 								},
 							},
@@ -6327,13 +7373,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 16,
-											Line:   146,
+											Line:   173,
 										},
 										File:   "universe.flux",
 										Source: "sort",
 										Start: ast.Position{
 											Column: 12,
-											Line:   146,
+											Line:   173,
 										},
 									},
 								},
@@ -6346,13 +7392,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 22,
-								Line:   147,
+								Line:   174,
 							},
 							File:   "universe.flux",
 							Source: "tables\n        |> sort(columns:columns, desc:desc)\n        |> limit(n:n)",
 							Start: ast.Position{
 								Column: 5,
-								Line:   145,
+								Line:   172,
 							},
 						},
 					},
@@ -6363,13 +7409,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 21,
-										Line:   147,
+										Line:   174,
 									},
 									File:   "universe.flux",
 									Source: "n:n",
 									Start: ast.Position{
 										Column: 18,
-										Line:   147,
+										Line:   174,
 									},
 								},
 							},
@@ -6379,13 +7425,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 21,
-											Line:   147,
+											Line:   174,
 										},
 										File:   "universe.flux",
 										Source: "n:n",
 										Start: ast.Position{
 											Column: 18,
-											Line:   147,
+											Line:   174,
 										},
 									},
 								},
@@ -6395,13 +7441,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 19,
-												Line:   147,
+												Line:   174,
 											},
 											File:   "universe.flux",
 											Source: "n",
 											Start: ast.Position{
 												Column: 18,
-												Line:   147,
+												Line:   174,
 											},
 										},
 									},
@@ -6413,13 +7459,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 21,
-												Line:   147,
+												Line:   174,
 											},
 											File:   "universe.flux",
 											Source: "n",
 											Start: ast.Position{
 												Column: 20,
-												Line:   147,
+												Line:   174,
 											},
 										},
 									},
@@ -6432,13 +7478,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 22,
-									Line:   147,
+									Line:   174,
 								},
 								File:   "universe.flux",
 								Source: "limit(n:n)",
 								Start: ast.Position{
 									Column: 12,
-									Line:   147,
+									Line:   174,
 								},
 							},
 						},
@@ -6448,13 +7494,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 17,
-										Line:   147,
+										Line:   174,
 									},
 									File:   "universe.flux",
 									Source: "limit",
 									Start: ast.Position{
 										Column: 12,
-										Line:   147,
+										Line:   174,
 									},
 								},
 							},
@@ -6468,13 +7514,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 16,
-								Line:   144,
+								Line:   171,
 							},
 							File:   "universe.flux",
 							Source: "n",
 							Start: ast.Position{
 								Column: 15,
-								Line:   144,
+								Line:   171,
 							},
 						},
 					},
@@ -6484,13 +7530,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 16,
-									Line:   144,
+									Line:   171,
 								},
 								File:   "universe.flux",
 								Source: "n",
 								Start: ast.Position{
 									Column: 15,
-									Line:   144,
+									Line:   171,
 								},
 							},
 						},
@@ -6503,13 +7549,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 22,
-								Line:   144,
+								Line:   171,
 							},
 							File:   "universe.flux",
 							Source: "desc",
 							Start: ast.Position{
 								Column: 18,
-								Line:   144,
+								Line:   171,
 							},
 						},
 					},
@@ -6519,13 +7565,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 22,
-									Line:   144,
+									Line:   171,
 								},
 								File:   "universe.flux",
 								Source: "desc",
 								Start: ast.Position{
 									Column: 18,
-									Line:   144,
+									Line:   171,
 								},
 							},
 						},
@@ -6538,13 +7584,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 42,
-								Line:   144,
+								Line:   171,
 							},
 							File:   "universe.flux",
 							Source: "columns=[\"_value\"]",
 							Start: ast.Position{
 								Column: 24,
-								Line:   144,
+								Line:   171,
 							},
 						},
 					},
@@ -6554,13 +7600,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 31,
-									Line:   144,
+									Line:   171,
 								},
 								File:   "universe.flux",
 								Source: "columns",
 								Start: ast.Position{
 									Column: 24,
-									Line:   144,
+									Line:   171,
 								},
 							},
 						},
@@ -6572,13 +7618,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 42,
-									Line:   144,
+									Line:   171,
 								},
 								File:   "universe.flux",
 								Source: "[\"_value\"]",
 								Start: ast.Position{
 									Column: 32,
-									Line:   144,
+									Line:   171,
 								},
 							},
 						},
@@ -6588,13 +7634,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 41,
-										Line:   144,
+										Line:   171,
 									},
 									File:   "universe.flux",
 									Source: "\"_value\"",
 									Start: ast.Position{
 										Column: 33,
-										Line:   144,
+										Line:   171,
 									},
 								},
 							},
@@ -6607,13 +7653,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 53,
-								Line:   144,
+								Line:   171,
 							},
 							File:   "universe.flux",
 							Source: "tables=<-",
 							Start: ast.Position{
 								Column: 44,
-								Line:   144,
+								Line:   171,
 							},
 						},
 					},
@@ -6623,13 +7669,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 50,
-									Line:   144,
+									Line:   171,
 								},
 								File:   "universe.flux",
 								Source: "tables",
 								Start: ast.Position{
 									Column: 44,
-									Line:   144,
+									Line:   171,
 								},
 							},
 						},
@@ -6640,13 +7686,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 53,
-								Line:   144,
+								Line:   171,
 							},
 							File:   "universe.flux",
 							Source: "<-",
 							Start: ast.Position{
 								Column: 51,
-								Line:   144,
+								Line:   171,
 							},
 						},
 					}},
@@ -6658,13 +7704,13 @@ var pkgAST = &ast.Package{
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
 						Column: 55,
-						Line:   152,
+						Line:   179,
 					},
 					File:   "universe.flux",
 					Source: "top = (n, columns=[\"_value\"], tables=<-) =>\n    tables\n        |> _sortLimit(n:n, columns:columns, desc:true)",
 					Start: ast.Position{
 						Column: 1,
-						Line:   150,
+						Line:   177,
 					},
 				},
 			},
@@ -6674,13 +7720,13 @@ var pkgAST = &ast.Package{
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
 							Column: 4,
-							Line:   150,
+							Line:   177,
 						},
 						File:   "universe.flux",
 						Source: "top",
 						Start: ast.Position{
 							Column: 1,
-							Line:   150,
+							Line:   177,
 						},
 					},
 				},
@@ -6692,13 +7738,13 @@ var pkgAST = &ast.Package{
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
 							Column: 55,
-							Line:   152,
+							Line:   179,
 						},
 						File:   "universe.flux",
 						Source: "(n, columns=[\"_value\"], tables=<-) =>\n    tables\n        |> _sortLimit(n:n, columns:columns, desc:true)",
 						Start: ast.Position{
 							Column: 7,
-							Line:   150,
+							Line:   177,
 						},
 					},
 				},
@@ -6709,13 +7755,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 11,
-									Line:   151,
+									Line:   178,
 								},
 								File:   "universe.flux",
 								Source: "tables",
 								Start: ast.Position{
 									Column: 5,
-									Line:   151,
+									Line:   178,
 								},
 							},
 						},
@@ -6726,13 +7772,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 55,
-								Line:   152,
+								Line:   179,
 							},
 							File:   "universe.flux",
 							Source: "tables\n        |> _sortLimit(n:n, columns:columns, desc:true)",
 							Start: ast.Position{
 								Column: 5,
-								Line:   151,
+								Line:   178,
 							},
 						},
 					},
@@ -6743,13 +7789,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 54,
-										Line:   152,
+										Line:   179,
 									},
 									File:   "universe.flux",
 									Source: "n:n, columns:columns, desc:true",
 									Start: ast.Position{
 										Column: 23,
-										Line:   152,
+										Line:   179,
 									},
 								},
 							},
@@ -6759,13 +7805,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 26,
-											Line:   152,
+											Line:   179,
 										},
 										File:   "universe.flux",
 										Source: "n:n",
 										Start: ast.Position{
 											Column: 23,
-											Line:   152,
+											Line:   179,
 										},
 									},
 								},
@@ -6775,13 +7821,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 24,
-												Line:   152,
+												Line:   179,
 											},
 											File:   "universe.flux",
 											Source: "n",
 											Start: ast.Position{
 												Column: 23,
-												Line:   152,
+												Line:   179,
 											},
 										},
 									},
@@ -6793,13 +7839,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 26,
-												Line:   152,
+												Line:   179,
 											},
 											File:   "universe.flux",
 											Source: "n",
 											Start: ast.Position{
 												Column: 25,
-												Line:   152,
+												Line:   179,
 											},
 										},
 									},
@@ -6811,13 +7857,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 43,
-											Line:   152,
+											Line:   179,
 										},
 										File:   "universe.flux",
 										Source: "columns:columns",
 										Start: ast.Position{
 											Column: 28,
-											Line:   152,
+											Line:   179,
 										},
 									},
 								},
@@ -6827,13 +7873,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 35,
-												Line:   152,
+												Line:   179,
 											},
 											File:   "universe.flux",
 											Source: "columns",
 											Start: ast.Position{
 												Column: 28,
-												Line:   152,
+												Line:   179,
 											},
 										},
 									},
@@ -6845,13 +7891,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 43,
-												Line:   152,
+												Line:   179,
 											},
 											File:   "universe.flux",
 											Source: "columns",
 											Start: ast.Position{
 												Column: 36,
-												Line:   152,
+												Line:   179,
 											},
 										},
 									},
@@ -6863,13 +7909,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 54,
-											Line:   152,
+											Line:   179,
 										},
 										File:   "universe.flux",
 										Source: "desc:true",
 										Start: ast.Position{
 											Column: 45,
-											Line:   152,
+											Line:   179,
 										},
 									},
 								},
@@ -6879,13 +7925,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 49,
-												Line:   152,
+												Line:   179,
 											},
 											File:   "universe.flux",
 											Source: "desc",
 											Start: ast.Position{
 												Column: 45,
-												Line:   152,
+												Line:   179,
 											},
 										},
 									},
@@ -6897,13 +7943,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 54,
-												Line:   152,
+												Line:   179,
 											},
 											File:   "universe.flux",
 											Source: "true",
 											Start: ast.Position{
 												Column: 50,
-												Line:   152,
+												Line:   179,
 											},
 										},
 									},
@@ -6916,13 +7962,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 55,
-									Line:   152,
+									Line:   179,
 								},
 								File:   "universe.flux",
 								Source: "_sortLimit(n:n, columns:columns, desc:true)",
 								Start: ast.Position{
 									Column: 12,
-									Line:   152,
+									Line:   179,
 								},
 							},
 						},
@@ -6932,13 +7978,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 22,
-										Line:   152,
+										Line:   179,
 									},
 									File:   "universe.flux",
 									Source: "_sortLimit",
 									Start: ast.Position{
 										Column: 12,
-										Line:   152,
+										Line:   179,
 									},
 								},
 							},
@@ -6952,13 +7998,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 9,
-								Line:   150,
+								Line:   177,
 							},
 							File:   "universe.flux",
 							Source: "n",
 							Start: ast.Position{
 								Column: 8,
-								Line:   150,
+								Line:   177,
 							},
 						},
 					},
@@ -6968,13 +8014,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 9,
-									Line:   150,
+									Line:   177,
 								},
 								File:   "universe.flux",
 								Source: "n",
 								Start: ast.Position{
 									Column: 8,
-									Line:   150,
+									Line:   177,
 								},
 							},
 						},
@@ -6987,13 +8033,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 29,
-								Line:   150,
+								Line:   177,
 							},
 							File:   "universe.flux",
 							Source: "columns=[\"_value\"]",
 							Start: ast.Position{
 								Column: 11,
-								Line:   150,
+								Line:   177,
 							},
 						},
 					},
@@ -7003,13 +8049,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 18,
-									Line:   150,
+									Line:   177,
 								},
 								File:   "universe.flux",
 								Source: "columns",
 								Start: ast.Position{
 									Column: 11,
-									Line:   150,
+									Line:   177,
 								},
 							},
 						},
@@ -7021,13 +8067,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 29,
-									Line:   150,
+									Line:   177,
 								},
 								File:   "universe.flux",
 								Source: "[\"_value\"]",
 								Start: ast.Position{
 									Column: 19,
-									Line:   150,
+									Line:   177,
 								},
 							},
 						},
@@ -7037,13 +8083,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 28,
-										Line:   150,
+										Line:   177,
 									},
 									File:   "universe.flux",
 									Source: "\"_value\"",
 									Start: ast.Position{
 										Column: 20,
-										Line:   150,
+										Line:   177,
 									},
 								},
 							},
@@ -7056,13 +8102,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 40,
-								Line:   150,
+								Line:   177,
 							},
 							File:   "universe.flux",
 							Source: "tables=<-",
 							Start: ast.Position{
 								Column: 31,
-								Line:   150,
+								Line:   177,
 							},
 						},
 					},
@@ -7072,13 +8118,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 37,
-									Line:   150,
+									Line:   177,
 								},
 								File:   "universe.flux",
 								Source: "tables",
 								Start: ast.Position{
 									Column: 31,
-									Line:   150,
+									Line:   177,
 								},
 							},
 						},
@@ -7089,13 +8135,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 40,
-								Line:   150,
+								Line:   177,
 							},
 							File:   "universe.flux",
 							Source: "<-",
 							Start: ast.Position{
 								Column: 38,
-								Line:   150,
+								Line:   177,
 							},
 						},
 					}},
@@ -7107,13 +8153,13 @@ var pkgAST = &ast.Package{
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
 						Column: 56,
-						Line:   157,
+						Line:   184,
 					},
 					File:   "universe.flux",
 					Source: "bottom = (n, columns=[\"_value\"], tables=<-) =>\n    tables\n        |> _sortLimit(n:n, columns:columns, desc:false)",
 					Start: ast.Position{
 						Column: 1,
-						Line:   155,
+						Line:   182,
 					},
 				},
 			},
@@ -7123,13 +8169,13 @@ var pkgAST = &ast.Package{
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
 							Column: 7,
-							Line:   155,
+							Line:   182,
 						},
 						File:   "universe.flux",
 						Source: "bottom",
 						Start: ast.Position{
 							Column: 1,
-							Line:   155,
+							Line:   182,
 						},
 					},
 				},
@@ -7141,13 +8187,13 @@ var pkgAST = &ast.Package{
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
 							Column: 56,
-							Line:   157,
+							Line:   184,
 						},
 						File:   "universe.flux",
 						Source: "(n, columns=[\"_value\"], tables=<-) =>\n    tables\n        |> _sortLimit(n:n, columns:columns, desc:false)",
 						Start: ast.Position{
 							Column: 10,
-							Line:   155,
+							Line:   182,
 						},
 					},
 				},
@@ -7158,13 +8204,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 11,
-									Line:   156,
+									Line:   183,
 								},
 								File:   "universe.flux",
 								Source: "tables",
 								Start: ast.Position{
 									Column: 5,
-									Line:   156,
+									Line:   183,
 								},
 							},
 						},
@@ -7175,13 +8221,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 56,
-								Line:   157,
+								Line:   184,
 							},
 							File:   "universe.flux",
 							Source: "tables\n        |> _sortLimit(n:n, columns:columns, desc:false)",
 							Start: ast.Position{
 								Column: 5,
-								Line:   156,
+								Line:   183,
 							},
 						},
 					},
@@ -7192,13 +8238,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 55,
-										Line:   157,
+										Line:   184,
 									},
 									File:   "universe.flux",
 									Source: "n:n, columns:columns, desc:false",
 									Start: ast.Position{
 										Column: 23,
-										Line:   157,
+										Line:   184,
 									},
 								},
 							},
@@ -7208,13 +8254,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 26,
-											Line:   157,
+											Line:   184,
 										},
 										File:   "universe.flux",
 										Source: "n:n",
 										Start: ast.Position{
 											Column: 23,
-											Line:   157,
+											Line:   184,
 										},
 									},
 								},
@@ -7224,13 +8270,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 24,
-												Line:   157,
+												Line:   184,
 											},
 											File:   "universe.flux",
 											Source: "n",
 											Start: ast.Position{
 												Column: 23,
-												Line:   157,
+												Line:   184,
 											},
 										},
 									},
@@ -7242,13 +8288,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 26,
-												Line:   157,
+												Line:   184,
 											},
 											File:   "universe.flux",
 											Source: "n",
 											Start: ast.Position{
 												Column: 25,
-												Line:   157,
+												Line:   184,
 											},
 										},
 									},
@@ -7260,13 +8306,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 43,
-											Line:   157,
+											Line:   184,
 										},
 										File:   "universe.flux",
 										Source: "columns:columns",
 										Start: ast.Position{
 											Column: 28,
-											Line:   157,
+											Line:   184,
 										},
 									},
 								},
@@ -7276,13 +8322,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 35,
-												Line:   157,
+												Line:   184,
 											},
 											File:   "universe.flux",
 											Source: "columns",
 											Start: ast.Position{
 												Column: 28,
-												Line:   157,
+												Line:   184,
 											},
 										},
 									},
@@ -7294,13 +8340,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 43,
-												Line:   157,
+												Line:   184,
 											},
 											File:   "universe.flux",
 											Source: "columns",
 											Start: ast.Position{
 												Column: 36,
-												Line:   157,
+												Line:   184,
 											},
 										},
 									},
@@ -7312,13 +8358,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 55,
-											Line:   157,
+											Line:   184,
 										},
 										File:   "universe.flux",
 										Source: "desc:false",
 										Start: ast.Position{
 											Column: 45,
-											Line:   157,
+											Line:   184,
 										},
 									},
 								},
@@ -7328,13 +8374,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 49,
-												Line:   157,
+												Line:   184,
 											},
 											File:   "universe.flux",
 											Source: "desc",
 											Start: ast.Position{
 												Column: 45,
-												Line:   157,
+												Line:   184,
 											},
 										},
 									},
@@ -7346,13 +8392,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 55,
-												Line:   157,
+												Line:   184,
 											},
 											File:   "universe.flux",
 											Source: "false",
 											Start: ast.Position{
 												Column: 50,
-												Line:   157,
+												Line:   184,
 											},
 										},
 									},
@@ -7365,13 +8411,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 56,
-									Line:   157,
+									Line:   184,
 								},
 								File:   "universe.flux",
 								Source: "_sortLimit(n:n, columns:columns, desc:false)",
 								Start: ast.Position{
 									Column: 12,
-									Line:   157,
+									Line:   184,
 								},
 							},
 						},
@@ -7381,13 +8427,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 22,
-										Line:   157,
+										Line:   184,
 									},
 									File:   "universe.flux",
 									Source: "_sortLimit",
 									Start: ast.Position{
 										Column: 12,
-										Line:   157,
+										Line:   184,
 									},
 								},
 							},
@@ -7401,13 +8447,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 12,
-								Line:   155,
+								Line:   182,
 							},
 							File:   "universe.flux",
 							Source: "n",
 							Start: ast.Position{
 								Column: 11,
-								Line:   155,
+								Line:   182,
 							},
 						},
 					},
@@ -7417,13 +8463,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 12,
-									Line:   155,
+									Line:   182,
 								},
 								File:   "universe.flux",
 								Source: "n",
 								Start: ast.Position{
 									Column: 11,
-									Line:   155,
+									Line:   182,
 								},
 							},
 						},
@@ -7436,13 +8482,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 32,
-								Line:   155,
+								Line:   182,
 							},
 							File:   "universe.flux",
 							Source: "columns=[\"_value\"]",
 							Start: ast.Position{
 								Column: 14,
-								Line:   155,
+								Line:   182,
 							},
 						},
 					},
@@ -7452,13 +8498,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 21,
-									Line:   155,
+									Line:   182,
 								},
 								File:   "universe.flux",
 								Source: "columns",
 								Start: ast.Position{
 									Column: 14,
-									Line:   155,
+									Line:   182,
 								},
 							},
 						},
@@ -7470,13 +8516,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 32,
-									Line:   155,
+									Line:   182,
 								},
 								File:   "universe.flux",
 								Source: "[\"_value\"]",
 								Start: ast.Position{
 									Column: 22,
-									Line:   155,
+									Line:   182,
 								},
 							},
 						},
@@ -7486,13 +8532,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 31,
-										Line:   155,
+										Line:   182,
 									},
 									File:   "universe.flux",
 									Source: "\"_value\"",
 									Start: ast.Position{
 										Column: 23,
-										Line:   155,
+										Line:   182,
 									},
 								},
 							},
@@ -7505,13 +8551,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 43,
-								Line:   155,
+								Line:   182,
 							},
 							File:   "universe.flux",
 							Source: "tables=<-",
 							Start: ast.Position{
 								Column: 34,
-								Line:   155,
+								Line:   182,
 							},
 						},
 					},
@@ -7521,13 +8567,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 40,
-									Line:   155,
+									Line:   182,
 								},
 								File:   "universe.flux",
 								Source: "tables",
 								Start: ast.Position{
 									Column: 34,
-									Line:   155,
+									Line:   182,
 								},
 							},
 						},
@@ -7538,13 +8584,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 43,
-								Line:   155,
+								Line:   182,
 							},
 							File:   "universe.flux",
 							Source: "<-",
 							Start: ast.Position{
 								Column: 41,
-								Line:   155,
+								Line:   182,
 							},
 						},
 					}},
@@ -7556,13 +8602,13 @@ var pkgAST = &ast.Package{
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
 						Column: 44,
-						Line:   167,
+						Line:   194,
 					},
 					File:   "universe.flux",
 					Source: "_highestOrLowest = (n, _sortLimit, reducer, columns=[\"_value\"], groupColumns=[], tables=<-) =>\n    tables\n        |> group(columns:groupColumns)\n        |> reducer()\n        |> group(columns:[])\n        |> _sortLimit(n:n, columns:columns)",
 					Start: ast.Position{
 						Column: 1,
-						Line:   162,
+						Line:   189,
 					},
 				},
 			},
@@ -7572,13 +8618,13 @@ var pkgAST = &ast.Package{
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
 							Column: 17,
-							Line:   162,
+							Line:   189,
 						},
 						File:   "universe.flux",
 						Source: "_highestOrLowest",
 						Start: ast.Position{
 							Column: 1,
-							Line:   162,
+							Line:   189,
 						},
 					},
 				},
@@ -7590,13 +8636,13 @@ var pkgAST = &ast.Package{
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
 							Column: 44,
-							Line:   167,
+							Line:   194,
 						},
 						File:   "universe.flux",
 						Source: "(n, _sortLimit, reducer, columns=[\"_value\"], groupColumns=[], tables=<-) =>\n    tables\n        |> group(columns:groupColumns)\n        |> reducer()\n        |> group(columns:[])\n        |> _sortLimit(n:n, columns:columns)",
 						Start: ast.Position{
 							Column: 20,
-							Line:   162,
+							Line:   189,
 						},
 					},
 				},
@@ -7610,13 +8656,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 11,
-												Line:   163,
+												Line:   190,
 											},
 											File:   "universe.flux",
 											Source: "tables",
 											Start: ast.Position{
 												Column: 5,
-												Line:   163,
+												Line:   190,
 											},
 										},
 									},
@@ -7627,13 +8673,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 39,
-											Line:   164,
+											Line:   191,
 										},
 										File:   "universe.flux",
 										Source: "tables\n        |> group(columns:groupColumns)",
 										Start: ast.Position{
 											Column: 5,
-											Line:   163,
+											Line:   190,
 										},
 									},
 								},
@@ -7644,13 +8690,13 @@ var pkgAST = &ast.Package{
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 38,
-													Line:   164,
+													Line:   191,
 												},
 												File:   "universe.flux",
 												Source: "columns:groupColumns",
 												Start: ast.Position{
 													Column: 18,
-													Line:   164,
+													Line:   191,
 												},
 											},
 										},
@@ -7660,13 +8706,13 @@ var pkgAST = &ast.Package{
 												Loc: &ast.SourceLocation{
 													End: ast.Position{
 														Column: 38,
-														Line:   164,
+														Line:   191,
 													},
 													File:   "universe.flux",
 													Source: "columns:groupColumns",
 													Start: ast.Position{
 														Column: 18,
-														Line:   164,
+														Line:   191,
 													},
 												},
 											},
@@ -7676,13 +8722,13 @@ var pkgAST = &ast.Package{
 													Loc: &ast.SourceLocation{
 														End: ast.Position{
 															Column: 25,
-															Line:   164,
+															Line:   191,
 														},
 														File:   "universe.flux",
 														Source: "columns",
 														Start: ast.Position{
 															Column: 18,
-															Line:   164,
+															Line:   191,
 														},
 													},
 												},
@@ -7694,13 +8740,13 @@ var pkgAST = &ast.Package{
 													Loc: &ast.SourceLocation{
 														End: ast.Position{
 															Column: 38,
-															Line:   164,
+															Line:   191,
 														},
 														File:   "universe.flux",
 														Source: "groupColumns",
 														Start: ast.Position{
 															Column: 26,
-															Line:   164,
+															Line:   191,
 														},
 													},
 												},
@@ -7713,13 +8759,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 39,
-												Line:   164,
+												Line:   191,
 											},
 											File:   "universe.flux",
 											Source: "group(columns:groupColumns)",
 											Start: ast.Position{
 												Column: 12,
-												Line:   164,
+												Line:   191,
 											},
 										},
 									},
@@ -7729,13 +8775,13 @@ var pkgAST = &ast.Package{
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 17,
-													Line:   164,
+													Line:   191,
 												},
 												File:   "universe.flux",
 												Source: "group",
 												Start: ast.Position{
 													Column: 12,
-													Line:   164,
+													Line:   191,
 												},
 											},
 										},
@@ -7748,13 +8794,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 21,
-										Line:   165,
+										Line:   192,
 									},
 									File:   "universe.flux",
 									Source: "tables\n        |> group(columns:groupColumns)\n        |> reducer()",
 									Start: ast.Position{
 										Column: 5,
-										Line:   163,
+										Line:   190,
 									},
 								},
 							},
@@ -7765,13 +8811,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 21,
-											Line:   165,
+											Line:   192,
 										},
 										File:   "universe.flux",
 										Source: "reducer()",
 										Start: ast.Position{
 											Column: 12,
-											Line:   165,
+											Line:   192,
 										},
 									},
 								},
@@ -7781,13 +8827,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 19,
-												Line:   165,
+												Line:   192,
 											},
 											File:   "universe.flux",
 											Source: "reducer",
 											Start: ast.Position{
 												Column: 12,
-												Line:   165,
+												Line:   192,
 											},
 										},
 									},
@@ -7800,13 +8846,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 29,
-									Line:   166,
+									Line:   193,
 								},
 								File:   "universe.flux",
 								Source: "tables\n        |> group(columns:groupColumns)\n        |> reducer()\n        |> group(columns:[])",
 								Start: ast.Position{
 									Column: 5,
-									Line:   163,
+									Line:   190,
 								},
 							},
 						},
@@ -7817,13 +8863,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 28,
-											Line:   166,
+											Line:   193,
 										},
 										File:   "universe.flux",
 										Source: "columns:[]",
 										Start: ast.Position{
 											Column: 18,
-											Line:   166,
+											Line:   193,
 										},
 									},
 								},
@@ -7833,13 +8879,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 28,
-												Line:   166,
+												Line:   193,
 											},
 											File:   "universe.flux",
 											Source: "columns:[]",
 											Start: ast.Position{
 												Column: 18,
-												Line:   166,
+												Line:   193,
 											},
 										},
 									},
@@ -7849,13 +8895,13 @@ var pkgAST = &ast.Package{
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 25,
-													Line:   166,
+													Line:   193,
 												},
 												File:   "universe.flux",
 												Source: "columns",
 												Start: ast.Position{
 													Column: 18,
-													Line:   166,
+													Line:   193,
 												},
 											},
 										},
@@ -7867,13 +8913,13 @@ var pkgAST = &ast.Package{
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 28,
-													Line:   166,
+													Line:   193,
 												},
 												File:   "universe.flux",
 												Source: "[]",
 												Start: ast.Position{
 													Column: 26,
-													Line:   166,
+													Line:   193,
 												},
 											},
 										},
@@ -7886,13 +8932,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 29,
-										Line:   166,
+										Line:   193,
 									},
 									File:   "universe.flux",
 									Source: "group(columns:[])",
 									Start: ast.Position{
 										Column: 12,
-										Line:   166,
+										Line:   193,
 									},
 								},
 							},
@@ -7902,13 +8948,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 17,
-											Line:   166,
+											Line:   193,
 										},
 										File:   "universe.flux",
 										Source: "group",
 										Start: ast.Position{
 											Column: 12,
-											Line:   166,
+											Line:   193,
 										},
 									},
 								},
@@ -7921,13 +8967,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 44,
-								Line:   167,
+								Line:   194,
 							},
 							File:   "universe.flux",
 							Source: "tables\n        |> group(columns:groupColumns)\n        |> reducer()\n        |> group(columns:[])\n        |> _sortLimit(n:n, columns:columns)",
 							Start: ast.Position{
 								Column: 5,
-								Line:   163,
+								Line:   190,
 							},
 						},
 					},
@@ -7938,13 +8984,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 43,
-										Line:   167,
+										Line:   194,
 									},
 									File:   "universe.flux",
 									Source: "n:n, columns:columns",
 									Start: ast.Position{
 										Column: 23,
-										Line:   167,
+										Line:   194,
 									},
 								},
 							},
@@ -7954,13 +9000,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 26,
-											Line:   167,
+											Line:   194,
 										},
 										File:   "universe.flux",
 										Source: "n:n",
 										Start: ast.Position{
 											Column: 23,
-											Line:   167,
+											Line:   194,
 										},
 									},
 								},
@@ -7970,13 +9016,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 24,
-												Line:   167,
+												Line:   194,
 											},
 											File:   "universe.flux",
 											Source: "n",
 											Start: ast.Position{
 												Column: 23,
-												Line:   167,
+												Line:   194,
 											},
 										},
 									},
@@ -7988,13 +9034,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 26,
-												Line:   167,
+												Line:   194,
 											},
 											File:   "universe.flux",
 											Source: "n",
 											Start: ast.Position{
 												Column: 25,
-												Line:   167,
+												Line:   194,
 											},
 										},
 									},
@@ -8006,13 +9052,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 43,
-											Line:   167,
+											Line:   194,
 										},
 										File:   "universe.flux",
 										Source: "columns:columns",
 										Start: ast.Position{
 											Column: 28,
-											Line:   167,
+											Line:   194,
 										},
 									},
 								},
@@ -8022,13 +9068,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 35,
-												Line:   167,
+												Line:   194,
 											},
 											File:   "universe.flux",
 											Source: "columns",
 											Start: ast.Position{
 												Column: 28,
-												Line:   167,
+												Line:   194,
 											},
 										},
 									},
@@ -8040,13 +9086,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 43,
-												Line:   167,
+												Line:   194,
 											},
 											File:   "universe.flux",
 											Source: "columns",
 											Start: ast.Position{
 												Column: 36,
-												Line:   167,
+												Line:   194,
 											},
 										},
 									},
@@ -8059,13 +9105,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 44,
-									Line:   167,
+									Line:   194,
 								},
 								File:   "universe.flux",
 								Source: "_sortLimit(n:n, columns:columns)",
 								Start: ast.Position{
 									Column: 12,
-									Line:   167,
+									Line:   194,
 								},
 							},
 						},
@@ -8075,13 +9121,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 22,
-										Line:   167,
+										Line:   194,
 									},
 									File:   "universe.flux",
 									Source: "_sortLimit",
 									Start: ast.Position{
 										Column: 12,
-										Line:   167,
+										Line:   194,
 									},
 								},
 							},
@@ -8095,13 +9141,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 22,
-								Line:   162,
+								Line:   189,
 							},
 							File:   "universe.flux",
 							Source: "n",
 							Start: ast.Position{
 								Column: 21,
-								Line:   162,
+								Line:   189,
 							},
 						},
 					},
@@ -8111,13 +9157,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 22,
-									Line:   162,
+									Line:   189,
 								},
 								File:   "universe.flux",
 								Source: "n",
 								Start: ast.Position{
 									Column: 21,
-									Line:   162,
+									Line:   189,
 								},
 							},
 						},
@@ -8130,13 +9176,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 34,
-								Line:   162,
+								Line:   189,
 							},
 							File:   "universe.flux",
 							Source: "_sortLimit",
 							Start: ast.Position{
 								Column: 24,
-								Line:   162,
+								Line:   189,
 							},
 						},
 					},
@@ -8146,13 +9192,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 34,
-									Line:   162,
+									Line:   189,
 								},
 								File:   "universe.flux",
 								Source: "_sortLimit",
 								Start: ast.Position{
 									Column: 24,
-									Line:   162,
+									Line:   189,
 								},
 							},
 						},
@@ -8165,13 +9211,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 43,
-								Line:   162,
+								Line:   189,
 							},
 							File:   "universe.flux",
 							Source: "reducer",
 							Start: ast.Position{
 								Column: 36,
-								Line:   162,
+								Line:   189,
 							},
 						},
 					},
@@ -8181,13 +9227,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 43,
-									Line:   162,
+									Line:   189,
 								},
 								File:   "universe.flux",
 								Source: "reducer",
 								Start: ast.Position{
 									Column: 36,
-									Line:   162,
+									Line:   189,
 								},
 							},
 						},
@@ -8200,13 +9246,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 63,
-								Line:   162,
+								Line:   189,
 							},
 							File:   "universe.flux",
 							Source: "columns=[\"_value\"]",
 							Start: ast.Position{
 								Column: 45,
-								Line:   162,
+								Line:   189,
 							},
 						},
 					},
@@ -8216,13 +9262,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 52,
-									Line:   162,
+									Line:   189,
 								},
 								File:   "universe.flux",
 								Source: "columns",
 								Start: ast.Position{
 									Column: 45,
-									Line:   162,
+									Line:   189,
 								},
 							},
 						},
@@ -8234,13 +9280,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 63,
-									Line:   162,
+									Line:   189,
 								},
 								File:   "universe.flux",
 								Source: "[\"_value\"]",
 								Start: ast.Position{
 									Column: 53,
-									Line:   162,
+									Line:   189,
 								},
 							},
 						},
@@ -8250,13 +9296,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 62,
-										Line:   162,
+										Line:   189,
 									},
 									File:   "universe.flux",
 									Source: "\"_value\"",
 									Start: ast.Position{
 										Column: 54,
-										Line:   162,
+										Line:   189,
 									},
 								},
 							},
@@ -8269,13 +9315,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 80,
-								Line:   162,
+								Line:   189,
 							},
 							File:   "universe.flux",
 							Source: "groupColumns=[]",
 							Start: ast.Position{
 								Column: 65,
-								Line:   162,
+								Line:   189,
 							},
 						},
 					},
@@ -8285,13 +9331,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 77,
-									Line:   162,
+									Line:   189,
 								},
 								File:   "universe.flux",
 								Source: "groupColumns",
 								Start: ast.Position{
 									Column: 65,
-									Line:   162,
+									Line:   189,
 								},
 							},
 						},
@@ -8303,13 +9349,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 80,
-									Line:   162,
+									Line:   189,
 								},
 								File:   "universe.flux",
 								Source: "[]",
 								Start: ast.Position{
 									Column: 78,
-									Line:   162,
+									Line:   189,
 								},
 							},
 						},
@@ -8321,13 +9367,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 91,
-								Line:   162,
+								Line:   189,
 							},
 							File:   "universe.flux",
 							Source: "tables=<-",
 							Start: ast.Position{
 								Column: 82,
-								Line:   162,
+								Line:   189,
 							},
 						},
 					},
@@ -8337,13 +9383,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 88,
-									Line:   162,
+									Line:   189,
 								},
 								File:   "universe.flux",
 								Source: "tables",
 								Start: ast.Position{
 									Column: 82,
-									Line:   162,
+									Line:   189,
 								},
 							},
 						},
@@ -8354,13 +9400,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 91,
-								Line:   162,
+								Line:   189,
 							},
 							File:   "universe.flux",
 							Source: "<-",
 							Start: ast.Position{
 								Column: 89,
-								Line:   162,
+								Line:   189,
 							},
 						},
 					}},
@@ -8372,13 +9418,13 @@ var pkgAST = &ast.Package{
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
 						Column: 14,
-						Line:   179,
+						Line:   206,
 					},
 					File:   "universe.flux",
 					Source: "highestMax = (n, columns=[\"_value\"], groupColumns=[], tables=<-) =>\n    tables\n        |> _highestOrLowest(\n                n:n,\n                columns:columns,\n                groupColumns:groupColumns,\n                // TODO(nathanielc): Once max/min support selecting based on multiple columns change this to pass all columns.\n                reducer: (tables=<-) => tables |> max(column:columns[0]),\n                _sortLimit: top,\n            )",
 					Start: ast.Position{
 						Column: 1,
-						Line:   170,
+						Line:   197,
 					},
 				},
 			},
@@ -8388,13 +9434,13 @@ var pkgAST = &ast.Package{
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
 							Column: 11,
-							Line:   170,
+							Line:   197,
 						},
 						File:   "universe.flux",
 						Source: "highestMax",
 						Start: ast.Position{
 							Column: 1,
-							Line:   170,
+							Line:   197,
 						},
 					},
 				},
@@ -8406,13 +9452,13 @@ var pkgAST = &ast.Package{
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
 							Column: 14,
-							Line:   179,
+							Line:   206,
 						},
 						File:   "universe.flux",
 						Source: "(n, columns=[\"_value\"], groupColumns=[], tables=<-) =>\n    tables\n        |> _highestOrLowest(\n                n:n,\n                columns:columns,\n                groupColumns:groupColumns,\n                // TODO(nathanielc): Once max/min support selecting based on multiple columns change this to pass all columns.\n                reducer: (tables=<-) => tables |> max(column:columns[0]),\n                _sortLimit: top,\n            )",
 						Start: ast.Position{
 							Column: 14,
-							Line:   170,
+							Line:   197,
 						},
 					},
 				},
@@ -8423,13 +9469,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 11,
-									Line:   171,
+									Line:   198,
 								},
 								File:   "universe.flux",
 								Source: "tables",
 								Start: ast.Position{
 									Column: 5,
-									Line:   171,
+									Line:   198,
 								},
 							},
 						},
@@ -8440,13 +9486,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 14,
-								Line:   179,
+								Line:   206,
 							},
 							File:   "universe.flux",
 							Source: "tables\n        |> _highestOrLowest(\n                n:n,\n                columns:columns,\n                groupColumns:groupColumns,\n                // TODO(nathanielc): Once max/min support selecting based on multiple columns change this to pass all columns.\n                reducer: (tables=<-) => tables |> max(column:columns[0]),\n                _sortLimit: top,\n            )",
 							Start: ast.Position{
 								Column: 5,
-								Line:   171,
+								Line:   198,
 							},
 						},
 					},
@@ -8457,13 +9503,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 32,
-										Line:   178,
+										Line:   205,
 									},
 									File:   "universe.flux",
 									Source: "n:n,\n                columns:columns,\n                groupColumns:groupColumns,\n                // TODO(nathanielc): Once max/min support selecting based on multiple columns change this to pass all columns.\n                reducer: (tables=<-) => tables |> max(column:columns[0]),\n                _sortLimit: top",
 									Start: ast.Position{
 										Column: 17,
-										Line:   173,
+										Line:   200,
 									},
 								},
 							},
@@ -8473,13 +9519,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 20,
-											Line:   173,
+											Line:   200,
 										},
 										File:   "universe.flux",
 										Source: "n:n",
 										Start: ast.Position{
 											Column: 17,
-											Line:   173,
+											Line:   200,
 										},
 									},
 								},
@@ -8489,13 +9535,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 18,
-												Line:   173,
+												Line:   200,
 											},
 											File:   "universe.flux",
 											Source: "n",
 											Start: ast.Position{
 												Column: 17,
-												Line:   173,
+												Line:   200,
 											},
 										},
 									},
@@ -8507,13 +9553,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 20,
-												Line:   173,
+												Line:   200,
 											},
 											File:   "universe.flux",
 											Source: "n",
 											Start: ast.Position{
 												Column: 19,
-												Line:   173,
+												Line:   200,
 											},
 										},
 									},
@@ -8525,13 +9571,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 32,
-											Line:   174,
+											Line:   201,
 										},
 										File:   "universe.flux",
 										Source: "columns:columns",
 										Start: ast.Position{
 											Column: 17,
-											Line:   174,
+											Line:   201,
 										},
 									},
 								},
@@ -8541,13 +9587,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 24,
-												Line:   174,
+												Line:   201,
 											},
 											File:   "universe.flux",
 											Source: "columns",
 											Start: ast.Position{
 												Column: 17,
-												Line:   174,
+												Line:   201,
 											},
 										},
 									},
@@ -8559,13 +9605,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 32,
-												Line:   174,
+												Line:   201,
 											},
 											File:   "universe.flux",
 											Source: "columns",
 											Start: ast.Position{
 												Column: 25,
-												Line:   174,
+												Line:   201,
 											},
 										},
 									},
@@ -8577,13 +9623,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 42,
-											Line:   175,
+											Line:   202,
 										},
 										File:   "universe.flux",
 										Source: "groupColumns:groupColumns",
 										Start: ast.Position{
 											Column: 17,
-											Line:   175,
+											Line:   202,
 										},
 									},
 								},
@@ -8593,13 +9639,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 29,
-												Line:   175,
+												Line:   202,
 											},
 											File:   "universe.flux",
 											Source: "groupColumns",
 											Start: ast.Position{
 												Column: 17,
-												Line:   175,
+												Line:   202,
 											},
 										},
 									},
@@ -8611,13 +9657,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 42,
-												Line:   175,
+												Line:   202,
 											},
 											File:   "universe.flux",
 											Source: "groupColumns",
 											Start: ast.Position{
 												Column: 30,
-												Line:   175,
+												Line:   202,
 											},
 										},
 									},
@@ -8629,13 +9675,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 73,
-											Line:   177,
+											Line:   204,
 										},
 										File:   "universe.flux",
 										Source: "reducer: (tables=<-) => tables |> max(column:columns[0])",
 										Start: ast.Position{
 											Column: 17,
-											Line:   177,
+											Line:   204,
 										},
 									},
 								},
@@ -8645,13 +9691,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 24,
-												Line:   177,
+												Line:   204,
 											},
 											File:   "universe.flux",
 											Source: "reducer",
 											Start: ast.Position{
 												Column: 17,
-												Line:   177,
+												Line:   204,
 											},
 										},
 									},
@@ -8663,13 +9709,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 73,
-												Line:   177,
+												Line:   204,
 											},
 											File:   "universe.flux",
 											Source: "(tables=<-) => tables |> max(column:columns[0])",
 											Start: ast.Position{
 												Column: 26,
-												Line:   177,
+												Line:   204,
 											},
 										},
 									},
@@ -8680,13 +9726,13 @@ var pkgAST = &ast.Package{
 												Loc: &ast.SourceLocation{
 													End: ast.Position{
 														Column: 47,
-														Line:   177,
+														Line:   204,
 													},
 													File:   "universe.flux",
 													Source: "tables",
 													Start: ast.Position{
 														Column: 41,
-														Line:   177,
+														Line:   204,
 													},
 												},
 											},
@@ -8697,13 +9743,13 @@ var pkgAST = &ast.Package{
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 73,
-													Line:   177,
+													Line:   204,
 												},
 												File:   "universe.flux",
 												Source: "tables |> max(column:columns[0])",
 												Start: ast.Position{
 													Column: 41,
-													Line:   177,
+													Line:   204,
 												},
 											},
 										},
@@ -8714,13 +9760,13 @@ var pkgAST = &ast.Package{
 													Loc: &ast.SourceLocation{
 														End: ast.Position{
 															Column: 72,
-															Line:   177,
+															Line:   204,
 														},
 														File:   "universe.flux",
 														Source: "column:columns[0]",
 														Start: ast.Position{
 															Column: 55,
-															Line:   177,
+															Line:   204,
 														},
 													},
 												},
@@ -8730,13 +9776,13 @@ var pkgAST = &ast.Package{
 														Loc: &ast.SourceLocation{
 															End: ast.Position{
 																Column: 72,
-																Line:   177,
+																Line:   204,
 															},
 															File:   "universe.flux",
 															Source: "column:columns[0]",
 															Start: ast.Position{
 																Column: 55,
-																Line:   177,
+																Line:   204,
 															},
 														},
 													},
@@ -8746,13 +9792,13 @@ var pkgAST = &ast.Package{
 															Loc: &ast.SourceLocation{
 																End: ast.Position{
 																	Column: 61,
-																	Line:   177,
+																	Line:   204,
 																},
 																File:   "universe.flux",
 																Source: "column",
 																Start: ast.Position{
 																	Column: 55,
-																	Line:   177,
+																	Line:   204,
 																},
 															},
 														},
@@ -8765,13 +9811,13 @@ var pkgAST = &ast.Package{
 																Loc: &ast.SourceLocation{
 																	End: ast.Position{
 																		Column: 69,
-																		Line:   177,
+																		Line:   204,
 																	},
 																	File:   "universe.flux",
 																	Source: "columns",
 																	Start: ast.Position{
 																		Column: 62,
-																		Line:   177,
+																		Line:   204,
 																	},
 																},
 															},
@@ -8782,13 +9828,13 @@ var pkgAST = &ast.Package{
 															Loc: &ast.SourceLocation{
 																End: ast.Position{
 																	Column: 72,
-																	Line:   177,
+																	Line:   204,
 																},
 																File:   "universe.flux",
 																Source: "columns[0]",
 																Start: ast.Position{
 																	Column: 62,
-																	Line:   177,
+																	Line:   204,
 																},
 															},
 														},
@@ -8798,13 +9844,13 @@ var pkgAST = &ast.Package{
 																Loc: &ast.SourceLocation{
 																	End: ast.Position{
 																		Column: 71,
-																		Line:   177,
+																		Line:   204,
 																	},
 																	File:   "universe.flux",
 																	Source: "0",
 																	Start: ast.Position{
 																		Column: 70,
-																		Line:   177,
+																		Line:   204,
 																	},
 																},
 															},
@@ -8818,13 +9864,13 @@ var pkgAST = &ast.Package{
 												Loc: &ast.SourceLocation{
 													End: ast.Position{
 														Column: 73,
-														Line:   177,
+														Line:   204,
 													},
 													File:   "universe.flux",
 													Source: "max(column:columns[0])",
 													Start: ast.Position{
 														Column: 51,
-														Line:   177,
+														Line:   204,
 													},
 												},
 											},
@@ -8834,13 +9880,13 @@ var pkgAST = &ast.Package{
 													Loc: &ast.SourceLocation{
 														End: ast.Position{
 															Column: 54,
-															Line:   177,
+															Line:   204,
 														},
 														File:   "universe.flux",
 														Source: "max",
 														Start: ast.Position{
 															Column: 51,
-															Line:   177,
+															Line:   204,
 														},
 													},
 												},
@@ -8854,13 +9900,13 @@ var pkgAST = &ast.Package{
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 36,
-													Line:   177,
+													Line:   204,
 												},
 												File:   "universe.flux",
 												Source: "tables=<-",
 												Start: ast.Position{
 													Column: 27,
-													Line:   177,
+													Line:   204,
 												},
 											},
 										},
@@ -8870,13 +9916,13 @@ var pkgAST = &ast.Package{
 												Loc: &ast.SourceLocation{
 													End: ast.Position{
 														Column: 33,
-														Line:   177,
+														Line:   204,
 													},
 													File:   "universe.flux",
 													Source: "tables",
 													Start: ast.Position{
 														Column: 27,
-														Line:   177,
+														Line:   204,
 													},
 												},
 											},
@@ -8887,13 +9933,13 @@ var pkgAST = &ast.Package{
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 36,
-													Line:   177,
+													Line:   204,
 												},
 												File:   "universe.flux",
 												Source: "<-",
 												Start: ast.Position{
 													Column: 34,
-													Line:   177,
+													Line:   204,
 												},
 											},
 										}},
@@ -8905,13 +9951,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 32,
-											Line:   178,
+											Line:   205,
 										},
 										File:   "universe.flux",
 										Source: "_sortLimit: top",
 										Start: ast.Position{
 											Column: 17,
-											Line:   178,
+											Line:   205,
 										},
 									},
 								},
@@ -8921,13 +9967,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 27,
-												Line:   178,
+												Line:   205,
 											},
 											File:   "universe.flux",
 											Source: "_sortLimit",
 											Start: ast.Position{
 												Column: 17,
-												Line:   178,
+												Line:   205,
 											},
 										},
 									},
@@ -8939,13 +9985,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 32,
-												Line:   178,
+												Line:   205,
 											},
 											File:   "universe.flux",
 											Source: "top",
 											Start: ast.Position{
 												Column: 29,
-												Line:   178,
+												Line:   205,
 											},
 										},
 									},
@@ -8958,13 +10004,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 14,
-									Line:   179,
+									Line:   206,
 								},
 								File:   "universe.flux",
 								Source: "_highestOrLowest(\n                n:n,\n                columns:columns,\n                groupColumns:groupColumns,\n                // TODO(nathanielc): Once max/min support selecting based on multiple columns change this to pass all columns.\n                reducer: (tables=<-) => tables |> max(column:columns[0]),\n                _sortLimit: top,\n            )",
 								Start: ast.Position{
 									Column: 12,
-									Line:   172,
+									Line:   199,
 								},
 							},
 						},
@@ -8974,13 +10020,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 28,
-										Line:   172,
+										Line:   199,
 									},
 									File:   "universe.flux",
 									Source: "_highestOrLowest",
 									Start: ast.Position{
 										Column: 12,
-										Line:   172,
+										Line:   199,
 									},
 								},
 							},
@@ -8994,13 +10040,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 16,
-								Line:   170,
+								Line:   197,
 							},
 							File:   "universe.flux",
 							Source: "n",
 							Start: ast.Position{
 								Column: 15,
-								Line:   170,
+								Line:   197,
 							},
 						},
 					},
@@ -9010,13 +10056,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 16,
-									Line:   170,
+									Line:   197,
 								},
 								File:   "universe.flux",
 								Source: "n",
 								Start: ast.Position{
 									Column: 15,
-									Line:   170,
+									Line:   197,
 								},
 							},
 						},
@@ -9029,13 +10075,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 36,
-								Line:   170,
+								Line:   197,
 							},
 							File:   "universe.flux",
 							Source: "columns=[\"_value\"]",
 							Start: ast.Position{
 								Column: 18,
-								Line:   170,
+								Line:   197,
 							},
 						},
 					},
@@ -9045,13 +10091,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 25,
-									Line:   170,
+									Line:   197,
 								},
 								File:   "universe.flux",
 								Source: "columns",
 								Start: ast.Position{
 									Column: 18,
-									Line:   170,
+									Line:   197,
 								},
 							},
 						},
@@ -9063,13 +10109,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 36,
-									Line:   170,
+									Line:   197,
 								},
 								File:   "universe.flux",
 								Source: "[\"_value\"]",
 								Start: ast.Position{
 									Column: 26,
-									Line:   170,
+									Line:   197,
 								},
 							},
 						},
@@ -9079,13 +10125,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 35,
-										Line:   170,
+										Line:   197,
 									},
 									File:   "universe.flux",
 									Source: "\"_value\"",
 									Start: ast.Position{
 										Column: 27,
-										Line:   170,
+										Line:   197,
 									},
 								},
 							},
@@ -9098,13 +10144,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 53,
-								Line:   170,
+								Line:   197,
 							},
 							File:   "universe.flux",
 							Source: "groupColumns=[]",
 							Start: ast.Position{
 								Column: 38,
-								Line:   170,
+								Line:   197,
 							},
 						},
 					},
@@ -9114,13 +10160,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 50,
-									Line:   170,
+									Line:   197,
 								},
 								File:   "universe.flux",
 								Source: "groupColumns",
 								Start: ast.Position{
 									Column: 38,
-									Line:   170,
+									Line:   197,
 								},
 							},
 						},
@@ -9132,13 +10178,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 53,
-									Line:   170,
+									Line:   197,
 								},
 								File:   "universe.flux",
 								Source: "[]",
 								Start: ast.Position{
 									Column: 51,
-									Line:   170,
+									Line:   197,
 								},
 							},
 						},
@@ -9150,13 +10196,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 64,
-								Line:   170,
+								Line:   197,
 							},
 							File:   "universe.flux",
 							Source: "tables=<-",
 							Start: ast.Position{
 								Column: 55,
-								Line:   170,
+								Line:   197,
 							},
 						},
 					},
@@ -9166,13 +10212,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 61,
-									Line:   170,
+									Line:   197,
 								},
 								File:   "universe.flux",
 								Source: "tables",
 								Start: ast.Position{
 									Column: 55,
-									Line:   170,
+									Line:   197,
 								},
 							},
 						},
@@ -9183,13 +10229,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 64,
-								Line:   170,
+								Line:   197,
 							},
 							File:   "universe.flux",
 							Source: "<-",
 							Start: ast.Position{
 								Column: 62,
-								Line:   170,
+								Line:   197,
 							},
 						},
 					}},
@@ -9201,13 +10247,13 @@ var pkgAST = &ast.Package{
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
 						Column: 14,
-						Line:   190,
+						Line:   217,
 					},
 					File:   "universe.flux",
 					Source: "highestAverage = (n, columns=[\"_value\"], groupColumns=[], tables=<-) =>\n    tables\n        |> _highestOrLowest(\n                n:n,\n                columns:columns,\n                groupColumns:groupColumns,\n                reducer: (tables=<-) => tables |> mean(columns:[columns[0]]),\n                _sortLimit: top,\n            )",
 					Start: ast.Position{
 						Column: 1,
-						Line:   182,
+						Line:   209,
 					},
 				},
 			},
@@ -9217,13 +10263,13 @@ var pkgAST = &ast.Package{
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
 							Column: 15,
-							Line:   182,
+							Line:   209,
 						},
 						File:   "universe.flux",
 						Source: "highestAverage",
 						Start: ast.Position{
 							Column: 1,
-							Line:   182,
+							Line:   209,
 						},
 					},
 				},
@@ -9235,13 +10281,13 @@ var pkgAST = &ast.Package{
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
 							Column: 14,
-							Line:   190,
+							Line:   217,
 						},
 						File:   "universe.flux",
 						Source: "(n, columns=[\"_value\"], groupColumns=[], tables=<-) =>\n    tables\n        |> _highestOrLowest(\n                n:n,\n                columns:columns,\n                groupColumns:groupColumns,\n                reducer: (tables=<-) => tables |> mean(columns:[columns[0]]),\n                _sortLimit: top,\n            )",
 						Start: ast.Position{
 							Column: 18,
-							Line:   182,
+							Line:   209,
 						},
 					},
 				},
@@ -9252,13 +10298,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 11,
-									Line:   183,
+									Line:   210,
 								},
 								File:   "universe.flux",
 								Source: "tables",
 								Start: ast.Position{
 									Column: 5,
-									Line:   183,
+									Line:   210,
 								},
 							},
 						},
@@ -9269,13 +10315,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 14,
-								Line:   190,
+								Line:   217,
 							},
 							File:   "universe.flux",
 							Source: "tables\n        |> _highestOrLowest(\n                n:n,\n                columns:columns,\n                groupColumns:groupColumns,\n                reducer: (tables=<-) => tables |> mean(columns:[columns[0]]),\n                _sortLimit: top,\n            )",
 							Start: ast.Position{
 								Column: 5,
-								Line:   183,
+								Line:   210,
 							},
 						},
 					},
@@ -9286,13 +10332,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 32,
-										Line:   189,
+										Line:   216,
 									},
 									File:   "universe.flux",
 									Source: "n:n,\n                columns:columns,\n                groupColumns:groupColumns,\n                reducer: (tables=<-) => tables |> mean(columns:[columns[0]]),\n                _sortLimit: top",
 									Start: ast.Position{
 										Column: 17,
-										Line:   185,
+										Line:   212,
 									},
 								},
 							},
@@ -9302,13 +10348,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 20,
-											Line:   185,
+											Line:   212,
 										},
 										File:   "universe.flux",
 										Source: "n:n",
 										Start: ast.Position{
 											Column: 17,
-											Line:   185,
+											Line:   212,
 										},
 									},
 								},
@@ -9318,13 +10364,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 18,
-												Line:   185,
+												Line:   212,
 											},
 											File:   "universe.flux",
 											Source: "n",
 											Start: ast.Position{
 												Column: 17,
-												Line:   185,
+												Line:   212,
 											},
 										},
 									},
@@ -9336,13 +10382,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 20,
-												Line:   185,
+												Line:   212,
 											},
 											File:   "universe.flux",
 											Source: "n",
 											Start: ast.Position{
 												Column: 19,
-												Line:   185,
+												Line:   212,
 											},
 										},
 									},
@@ -9354,13 +10400,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 32,
-											Line:   186,
+											Line:   213,
 										},
 										File:   "universe.flux",
 										Source: "columns:columns",
 										Start: ast.Position{
 											Column: 17,
-											Line:   186,
+											Line:   213,
 										},
 									},
 								},
@@ -9370,13 +10416,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 24,
-												Line:   186,
+												Line:   213,
 											},
 											File:   "universe.flux",
 											Source: "columns",
 											Start: ast.Position{
 												Column: 17,
-												Line:   186,
+												Line:   213,
 											},
 										},
 									},
@@ -9388,13 +10434,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 32,
-												Line:   186,
+												Line:   213,
 											},
 											File:   "universe.flux",
 											Source: "columns",
 											Start: ast.Position{
 												Column: 25,
-												Line:   186,
+												Line:   213,
 											},
 										},
 									},
@@ -9406,13 +10452,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 42,
-											Line:   187,
+											Line:   214,
 										},
 										File:   "universe.flux",
 										Source: "groupColumns:groupColumns",
 										Start: ast.Position{
 											Column: 17,
-											Line:   187,
+											Line:   214,
 										},
 									},
 								},
@@ -9422,13 +10468,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 29,
-												Line:   187,
+												Line:   214,
 											},
 											File:   "universe.flux",
 											Source: "groupColumns",
 											Start: ast.Position{
 												Column: 17,
-												Line:   187,
+												Line:   214,
 											},
 										},
 									},
@@ -9440,13 +10486,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 42,
-												Line:   187,
+												Line:   214,
 											},
 											File:   "universe.flux",
 											Source: "groupColumns",
 											Start: ast.Position{
 												Column: 30,
-												Line:   187,
+												Line:   214,
 											},
 										},
 									},
@@ -9458,13 +10504,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 77,
-											Line:   188,
+											Line:   215,
 										},
 										File:   "universe.flux",
 										Source: "reducer: (tables=<-) => tables |> mean(columns:[columns[0]])",
 										Start: ast.Position{
 											Column: 17,
-											Line:   188,
+											Line:   215,
 										},
 									},
 								},
@@ -9474,13 +10520,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 24,
-												Line:   188,
+												Line:   215,
 											},
 											File:   "universe.flux",
 											Source: "reducer",
 											Start: ast.Position{
 												Column: 17,
-												Line:   188,
+												Line:   215,
 											},
 										},
 									},
@@ -9492,13 +10538,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 77,
-												Line:   188,
+												Line:   215,
 											},
 											File:   "universe.flux",
 											Source: "(tables=<-) => tables |> mean(columns:[columns[0]])",
 											Start: ast.Position{
 												Column: 26,
-												Line:   188,
+												Line:   215,
 											},
 										},
 									},
@@ -9509,13 +10555,13 @@ var pkgAST = &ast.Package{
 												Loc: &ast.SourceLocation{
 													End: ast.Position{
 														Column: 47,
-														Line:   188,
+														Line:   215,
 													},
 													File:   "universe.flux",
 													Source: "tables",
 													Start: ast.Position{
 														Column: 41,
-														Line:   188,
+														Line:   215,
 													},
 												},
 											},
@@ -9526,13 +10572,13 @@ var pkgAST = &ast.Package{
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 77,
-													Line:   188,
+													Line:   215,
 												},
 												File:   "universe.flux",
 												Source: "tables |> mean(columns:[columns[0]])",
 												Start: ast.Position{
 													Column: 41,
-													Line:   188,
+													Line:   215,
 												},
 											},
 										},
@@ -9543,13 +10589,13 @@ var pkgAST = &ast.Package{
 													Loc: &ast.SourceLocation{
 														End: ast.Position{
 															Column: 76,
-															Line:   188,
+															Line:   215,
 														},
 														File:   "universe.flux",
 														Source: "columns:[columns[0]]",
 														Start: ast.Position{
 															Column: 56,
-															Line:   188,
+															Line:   215,
 														},
 													},
 												},
@@ -9559,13 +10605,13 @@ var pkgAST = &ast.Package{
 														Loc: &ast.SourceLocation{
 															End: ast.Position{
 																Column: 76,
-																Line:   188,
+																Line:   215,
 															},
 															File:   "universe.flux",
 															Source: "columns:[columns[0]]",
 															Start: ast.Position{
 																Column: 56,
-																Line:   188,
+																Line:   215,
 															},
 														},
 													},
@@ -9575,13 +10621,13 @@ var pkgAST = &ast.Package{
 															Loc: &ast.SourceLocation{
 																End: ast.Position{
 																	Column: 63,
-																	Line:   188,
+																	Line:   215,
 																},
 																File:   "universe.flux",
 																Source: "columns",
 																Start: ast.Position{
 																	Column: 56,
-																	Line:   188,
+																	Line:   215,
 																},
 															},
 														},
@@ -9593,13 +10639,13 @@ var pkgAST = &ast.Package{
 															Loc: &ast.SourceLocation{
 																End: ast.Position{
 																	Column: 76,
-																	Line:   188,
+																	Line:   215,
 																},
 																File:   "universe.flux",
 																Source: "[columns[0]]",
 																Start: ast.Position{
 																	Column: 64,
-																	Line:   188,
+																	Line:   215,
 																},
 															},
 														},
@@ -9610,13 +10656,13 @@ var pkgAST = &ast.Package{
 																	Loc: &ast.SourceLocation{
 																		End: ast.Position{
 																			Column: 72,
-																			Line:   188,
+																			Line:   215,
 																		},
 																		File:   "universe.flux",
 																		Source: "columns",
 																		Start: ast.Position{
 																			Column: 65,
-																			Line:   188,
+																			Line:   215,
 																		},
 																	},
 																},
@@ -9627,13 +10673,13 @@ var pkgAST = &ast.Package{
 																Loc: &ast.SourceLocation{
 																	End: ast.Position{
 																		Column: 75,
-																		Line:   188,
+																		Line:   215,
 																	},
 																	File:   "universe.flux",
 																	Source: "columns[0]",
 																	Start: ast.Position{
 																		Column: 65,
-																		Line:   188,
+																		Line:   215,
 																	},
 																},
 															},
@@ -9643,13 +10689,13 @@ var pkgAST = &ast.Package{
 																	Loc: &ast.SourceLocation{
 																		End: ast.Position{
 																			Column: 74,
-																			Line:   188,
+																			Line:   215,
 																		},
 																		File:   "universe.flux",
 																		Source: "0",
 																		Start: ast.Position{
 																			Column: 73,
-																			Line:   188,
+																			Line:   215,
 																		},
 																	},
 																},
@@ -9664,13 +10710,13 @@ var pkgAST = &ast.Package{
 												Loc: &ast.SourceLocation{
 													End: ast.Position{
 														Column: 77,
-														Line:   188,
+														Line:   215,
 													},
 													File:   "universe.flux",
 													Source: "mean(columns:[columns[0]])",
 													Start: ast.Position{
 														Column: 51,
-														Line:   188,
+														Line:   215,
 													},
 												},
 											},
@@ -9680,13 +10726,13 @@ var pkgAST = &ast.Package{
 													Loc: &ast.SourceLocation{
 														End: ast.Position{
 															Column: 55,
-															Line:   188,
+															Line:   215,
 														},
 														File:   "universe.flux",
 														Source: "mean",
 														Start: ast.Position{
 															Column: 51,
-															Line:   188,
+															Line:   215,
 														},
 													},
 												},
@@ -9700,13 +10746,13 @@ var pkgAST = &ast.Package{
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 36,
-													Line:   188,
+													Line:   215,
 												},
 												File:   "universe.flux",
 												Source: "tables=<-",
 												Start: ast.Position{
 													Column: 27,
-													Line:   188,
+													Line:   215,
 												},
 											},
 										},
@@ -9716,13 +10762,13 @@ var pkgAST = &ast.Package{
 												Loc: &ast.SourceLocation{
 													End: ast.Position{
 														Column: 33,
-														Line:   188,
+														Line:   215,
 													},
 													File:   "universe.flux",
 													Source: "tables",
 													Start: ast.Position{
 														Column: 27,
-														Line:   188,
+														Line:   215,
 													},
 												},
 											},
@@ -9733,13 +10779,13 @@ var pkgAST = &ast.Package{
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 36,
-													Line:   188,
+													Line:   215,
 												},
 												File:   "universe.flux",
 												Source: "<-",
 												Start: ast.Position{
 													Column: 34,
-													Line:   188,
+													Line:   215,
 												},
 											},
 										}},
@@ -9751,13 +10797,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 32,
-											Line:   189,
+											Line:   216,
 										},
 										File:   "universe.flux",
 										Source: "_sortLimit: top",
 										Start: ast.Position{
 											Column: 17,
-											Line:   189,
+											Line:   216,
 										},
 									},
 								},
@@ -9767,13 +10813,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 27,
-												Line:   189,
+												Line:   216,
 											},
 											File:   "universe.flux",
 											Source: "_sortLimit",
 											Start: ast.Position{
 												Column: 17,
-												Line:   189,
+												Line:   216,
 											},
 										},
 									},
@@ -9785,13 +10831,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 32,
-												Line:   189,
+												Line:   216,
 											},
 											File:   "universe.flux",
 											Source: "top",
 											Start: ast.Position{
 												Column: 29,
-												Line:   189,
+												Line:   216,
 											},
 										},
 									},
@@ -9804,13 +10850,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 14,
-									Line:   190,
+									Line:   217,
 								},
 								File:   "universe.flux",
 								Source: "_highestOrLowest(\n                n:n,\n                columns:columns,\n                groupColumns:groupColumns,\n                reducer: (tables=<-) => tables |> mean(columns:[columns[0]]),\n                _sortLimit: top,\n            )",
 								Start: ast.Position{
 									Column: 12,
-									Line:   184,
+									Line:   211,
 								},
 							},
 						},
@@ -9820,13 +10866,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 28,
-										Line:   184,
+										Line:   211,
 									},
 									File:   "universe.flux",
 									Source: "_highestOrLowest",
 									Start: ast.Position{
 										Column: 12,
-										Line:   184,
+										Line:   211,
 									},
 								},
 							},
@@ -9840,13 +10886,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 20,
-								Line:   182,
+								Line:   209,
 							},
 							File:   "universe.flux",
 							Source: "n",
 							Start: ast.Position{
 								Column: 19,
-								Line:   182,
+								Line:   209,
 							},
 						},
 					},
@@ -9856,13 +10902,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 20,
-									Line:   182,
+									Line:   209,
 								},
 								File:   "universe.flux",
 								Source: "n",
 								Start: ast.Position{
 									Column: 19,
-									Line:   182,
+									Line:   209,
 								},
 							},
 						},
@@ -9875,13 +10921,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 40,
-								Line:   182,
+								Line:   209,
 							},
 							File:   "universe.flux",
 							Source: "columns=[\"_value\"]",
 							Start: ast.Position{
 								Column: 22,
-								Line:   182,
+								Line:   209,
 							},
 						},
 					},
@@ -9891,13 +10937,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 29,
-									Line:   182,
+									Line:   209,
 								},
 								File:   "universe.flux",
 								Source: "columns",
 								Start: ast.Position{
 									Column: 22,
-									Line:   182,
+									Line:   209,
 								},
 							},
 						},
@@ -9909,13 +10955,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 40,
-									Line:   182,
+									Line:   209,
 								},
 								File:   "universe.flux",
 								Source: "[\"_value\"]",
 								Start: ast.Position{
 									Column: 30,
-									Line:   182,
+									Line:   209,
 								},
 							},
 						},
@@ -9925,13 +10971,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 39,
-										Line:   182,
+										Line:   209,
 									},
 									File:   "universe.flux",
 									Source: "\"_value\"",
 									Start: ast.Position{
 										Column: 31,
-										Line:   182,
+										Line:   209,
 									},
 								},
 							},
@@ -9944,13 +10990,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 57,
-								Line:   182,
+								Line:   209,
 							},
 							File:   "universe.flux",
 							Source: "groupColumns=[]",
 							Start: ast.Position{
 								Column: 42,
-								Line:   182,
+								Line:   209,
 							},
 						},
 					},
@@ -9960,13 +11006,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 54,
-									Line:   182,
+									Line:   209,
 								},
 								File:   "universe.flux",
 								Source: "groupColumns",
 								Start: ast.Position{
 									Column: 42,
-									Line:   182,
+									Line:   209,
 								},
 							},
 						},
@@ -9978,13 +11024,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 57,
-									Line:   182,
+									Line:   209,
 								},
 								File:   "universe.flux",
 								Source: "[]",
 								Start: ast.Position{
 									Column: 55,
-									Line:   182,
+									Line:   209,
 								},
 							},
 						},
@@ -9996,13 +11042,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 68,
-								Line:   182,
+								Line:   209,
 							},
 							File:   "universe.flux",
 							Source: "tables=<-",
 							Start: ast.Position{
 								Column: 59,
-								Line:   182,
+								Line:   209,
 							},
 						},
 					},
@@ -10012,13 +11058,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 65,
-									Line:   182,
+									Line:   209,
 								},
 								File:   "universe.flux",
 								Source: "tables",
 								Start: ast.Position{
 									Column: 59,
-									Line:   182,
+									Line:   209,
 								},
 							},
 						},
@@ -10029,13 +11075,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 68,
-								Line:   182,
+								Line:   209,
 							},
 							File:   "universe.flux",
 							Source: "<-",
 							Start: ast.Position{
 								Column: 66,
-								Line:   182,
+								Line:   209,
 							},
 						},
 					}},
@@ -10047,13 +11093,13 @@ var pkgAST = &ast.Package{
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
 						Column: 14,
-						Line:   201,
+						Line:   228,
 					},
 					File:   "universe.flux",
 					Source: "highestCurrent = (n, columns=[\"_value\"], groupColumns=[], tables=<-) =>\n    tables\n        |> _highestOrLowest(\n                n:n,\n                columns:columns,\n                groupColumns:groupColumns,\n                reducer: (tables=<-) => tables |> last(column:columns[0]),\n                _sortLimit: top,\n            )",
 					Start: ast.Position{
 						Column: 1,
-						Line:   193,
+						Line:   220,
 					},
 				},
 			},
@@ -10063,13 +11109,13 @@ var pkgAST = &ast.Package{
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
 							Column: 15,
-							Line:   193,
+							Line:   220,
 						},
 						File:   "universe.flux",
 						Source: "highestCurrent",
 						Start: ast.Position{
 							Column: 1,
-							Line:   193,
+							Line:   220,
 						},
 					},
 				},
@@ -10081,13 +11127,13 @@ var pkgAST = &ast.Package{
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
 							Column: 14,
-							Line:   201,
+							Line:   228,
 						},
 						File:   "universe.flux",
 						Source: "(n, columns=[\"_value\"], groupColumns=[], tables=<-) =>\n    tables\n        |> _highestOrLowest(\n                n:n,\n                columns:columns,\n                groupColumns:groupColumns,\n                reducer: (tables=<-) => tables |> last(column:columns[0]),\n                _sortLimit: top,\n            )",
 						Start: ast.Position{
 							Column: 18,
-							Line:   193,
+							Line:   220,
 						},
 					},
 				},
@@ -10098,13 +11144,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 11,
-									Line:   194,
+									Line:   221,
 								},
 								File:   "universe.flux",
 								Source: "tables",
 								Start: ast.Position{
 									Column: 5,
-									Line:   194,
+									Line:   221,
 								},
 							},
 						},
@@ -10115,13 +11161,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 14,
-								Line:   201,
+								Line:   228,
 							},
 							File:   "universe.flux",
 							Source: "tables\n        |> _highestOrLowest(\n                n:n,\n                columns:columns,\n                groupColumns:groupColumns,\n                reducer: (tables=<-) => tables |> last(column:columns[0]),\n                _sortLimit: top,\n            )",
 							Start: ast.Position{
 								Column: 5,
-								Line:   194,
+								Line:   221,
 							},
 						},
 					},
@@ -10132,13 +11178,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 32,
-										Line:   200,
+										Line:   227,
 									},
 									File:   "universe.flux",
 									Source: "n:n,\n                columns:columns,\n                groupColumns:groupColumns,\n                reducer: (tables=<-) => tables |> last(column:columns[0]),\n                _sortLimit: top",
 									Start: ast.Position{
 										Column: 17,
-										Line:   196,
+										Line:   223,
 									},
 								},
 							},
@@ -10148,13 +11194,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 20,
-											Line:   196,
+											Line:   223,
 										},
 										File:   "universe.flux",
 										Source: "n:n",
 										Start: ast.Position{
 											Column: 17,
-											Line:   196,
+											Line:   223,
 										},
 									},
 								},
@@ -10164,13 +11210,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 18,
-												Line:   196,
+												Line:   223,
 											},
 											File:   "universe.flux",
 											Source: "n",
 											Start: ast.Position{
 												Column: 17,
-												Line:   196,
+												Line:   223,
 											},
 										},
 									},
@@ -10182,13 +11228,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 20,
-												Line:   196,
+												Line:   223,
 											},
 											File:   "universe.flux",
 											Source: "n",
 											Start: ast.Position{
 												Column: 19,
-												Line:   196,
+												Line:   223,
 											},
 										},
 									},
@@ -10200,13 +11246,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 32,
-											Line:   197,
+											Line:   224,
 										},
 										File:   "universe.flux",
 										Source: "columns:columns",
 										Start: ast.Position{
 											Column: 17,
-											Line:   197,
+											Line:   224,
 										},
 									},
 								},
@@ -10216,13 +11262,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 24,
-												Line:   197,
+												Line:   224,
 											},
 											File:   "universe.flux",
 											Source: "columns",
 											Start: ast.Position{
 												Column: 17,
-												Line:   197,
+												Line:   224,
 											},
 										},
 									},
@@ -10234,13 +11280,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 32,
-												Line:   197,
+												Line:   224,
 											},
 											File:   "universe.flux",
 											Source: "columns",
 											Start: ast.Position{
 												Column: 25,
-												Line:   197,
+												Line:   224,
 											},
 										},
 									},
@@ -10252,13 +11298,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 42,
-											Line:   198,
+											Line:   225,
 										},
 										File:   "universe.flux",
 										Source: "groupColumns:groupColumns",
 										Start: ast.Position{
 											Column: 17,
-											Line:   198,
+											Line:   225,
 										},
 									},
 								},
@@ -10268,13 +11314,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 29,
-												Line:   198,
+												Line:   225,
 											},
 											File:   "universe.flux",
 											Source: "groupColumns",
 											Start: ast.Position{
 												Column: 17,
-												Line:   198,
+												Line:   225,
 											},
 										},
 									},
@@ -10286,13 +11332,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 42,
-												Line:   198,
+												Line:   225,
 											},
 											File:   "universe.flux",
 											Source: "groupColumns",
 											Start: ast.Position{
 												Column: 30,
-												Line:   198,
+												Line:   225,
 											},
 										},
 									},
@@ -10304,13 +11350,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 74,
-											Line:   199,
+											Line:   226,
 										},
 										File:   "universe.flux",
 										Source: "reducer: (tables=<-) => tables |> last(column:columns[0])",
 										Start: ast.Position{
 											Column: 17,
-											Line:   199,
+											Line:   226,
 										},
 									},
 								},
@@ -10320,13 +11366,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 24,
-												Line:   199,
+												Line:   226,
 											},
 											File:   "universe.flux",
 											Source: "reducer",
 											Start: ast.Position{
 												Column: 17,
-												Line:   199,
+												Line:   226,
 											},
 										},
 									},
@@ -10338,13 +11384,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 74,
-												Line:   199,
+												Line:   226,
 											},
 											File:   "universe.flux",
 											Source: "(tables=<-) => tables |> last(column:columns[0])",
 											Start: ast.Position{
 												Column: 26,
-												Line:   199,
+												Line:   226,
 											},
 										},
 									},
@@ -10355,13 +11401,13 @@ var pkgAST = &ast.Package{
 												Loc: &ast.SourceLocation{
 													End: ast.Position{
 														Column: 47,
-														Line:   199,
+														Line:   226,
 													},
 													File:   "universe.flux",
 													Source: "tables",
 													Start: ast.Position{
 														Column: 41,
-														Line:   199,
+														Line:   226,
 													},
 												},
 											},
@@ -10372,13 +11418,13 @@ var pkgAST = &ast.Package{
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 74,
-													Line:   199,
+													Line:   226,
 												},
 												File:   "universe.flux",
 												Source: "tables |> last(column:columns[0])",
 												Start: ast.Position{
 													Column: 41,
-													Line:   199,
+													Line:   226,
 												},
 											},
 										},
@@ -10389,13 +11435,13 @@ var pkgAST = &ast.Package{
 													Loc: &ast.SourceLocation{
 														End: ast.Position{
 															Column: 73,
-															Line:   199,
+															Line:   226,
 														},
 														File:   "universe.flux",
 														Source: "column:columns[0]",
 														Start: ast.Position{
 															Column: 56,
-															Line:   199,
+															Line:   226,
 														},
 													},
 												},
@@ -10405,13 +11451,13 @@ var pkgAST = &ast.Package{
 														Loc: &ast.SourceLocation{
 															End: ast.Position{
 																Column: 73,
-																Line:   199,
+																Line:   226,
 															},
 															File:   "universe.flux",
 															Source: "column:columns[0]",
 															Start: ast.Position{
 																Column: 56,
-																Line:   199,
+																Line:   226,
 															},
 														},
 													},
@@ -10421,13 +11467,13 @@ var pkgAST = &ast.Package{
 															Loc: &ast.SourceLocation{
 																End: ast.Position{
 																	Column: 62,
-																	Line:   199,
+																	Line:   226,
 																},
 																File:   "universe.flux",
 																Source: "column",
 																Start: ast.Position{
 																	Column: 56,
-																	Line:   199,
+																	Line:   226,
 																},
 															},
 														},
@@ -10440,13 +11486,13 @@ var pkgAST = &ast.Package{
 																Loc: &ast.SourceLocation{
 																	End: ast.Position{
 																		Column: 70,
-																		Line:   199,
+																		Line:   226,
 																	},
 																	File:   "universe.flux",
 																	Source: "columns",
 																	Start: ast.Position{
 																		Column: 63,
-																		Line:   199,
+																		Line:   226,
 																	},
 																},
 															},
@@ -10457,13 +11503,13 @@ var pkgAST = &ast.Package{
 															Loc: &ast.SourceLocation{
 																End: ast.Position{
 																	Column: 73,
-																	Line:   199,
+																	Line:   226,
 																},
 																File:   "universe.flux",
 																Source: "columns[0]",
 																Start: ast.Position{
 																	Column: 63,
-																	Line:   199,
+																	Line:   226,
 																},
 															},
 														},
@@ -10473,13 +11519,13 @@ var pkgAST = &ast.Package{
 																Loc: &ast.SourceLocation{
 																	End: ast.Position{
 																		Column: 72,
-																		Line:   199,
+																		Line:   226,
 																	},
 																	File:   "universe.flux",
 																	Source: "0",
 																	Start: ast.Position{
 																		Column: 71,
-																		Line:   199,
+																		Line:   226,
 																	},
 																},
 															},
@@ -10493,13 +11539,13 @@ var pkgAST = &ast.Package{
 												Loc: &ast.SourceLocation{
 													End: ast.Position{
 														Column: 74,
-														Line:   199,
+														Line:   226,
 													},
 													File:   "universe.flux",
 													Source: "last(column:columns[0])",
 													Start: ast.Position{
 														Column: 51,
-														Line:   199,
+														Line:   226,
 													},
 												},
 											},
@@ -10509,13 +11555,13 @@ var pkgAST = &ast.Package{
 													Loc: &ast.SourceLocation{
 														End: ast.Position{
 															Column: 55,
-															Line:   199,
+															Line:   226,
 														},
 														File:   "universe.flux",
 														Source: "last",
 														Start: ast.Position{
 															Column: 51,
-															Line:   199,
+															Line:   226,
 														},
 													},
 												},
@@ -10529,13 +11575,13 @@ var pkgAST = &ast.Package{
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 36,
-													Line:   199,
+													Line:   226,
 												},
 												File:   "universe.flux",
 												Source: "tables=<-",
 												Start: ast.Position{
 													Column: 27,
-													Line:   199,
+													Line:   226,
 												},
 											},
 										},
@@ -10545,13 +11591,13 @@ var pkgAST = &ast.Package{
 												Loc: &ast.SourceLocation{
 													End: ast.Position{
 														Column: 33,
-														Line:   199,
+														Line:   226,
 													},
 													File:   "universe.flux",
 													Source: "tables",
 													Start: ast.Position{
 														Column: 27,
-														Line:   199,
+														Line:   226,
 													},
 												},
 											},
@@ -10562,13 +11608,13 @@ var pkgAST = &ast.Package{
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 36,
-													Line:   199,
+													Line:   226,
 												},
 												File:   "universe.flux",
 												Source: "<-",
 												Start: ast.Position{
 													Column: 34,
-													Line:   199,
+													Line:   226,
 												},
 											},
 										}},
@@ -10580,13 +11626,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 32,
-											Line:   200,
+											Line:   227,
 										},
 										File:   "universe.flux",
 										Source: "_sortLimit: top",
 										Start: ast.Position{
 											Column: 17,
-											Line:   200,
+											Line:   227,
 										},
 									},
 								},
@@ -10596,13 +11642,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 27,
-												Line:   200,
+												Line:   227,
 											},
 											File:   "universe.flux",
 											Source: "_sortLimit",
 											Start: ast.Position{
 												Column: 17,
-												Line:   200,
+												Line:   227,
 											},
 										},
 									},
@@ -10614,13 +11660,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 32,
-												Line:   200,
+												Line:   227,
 											},
 											File:   "universe.flux",
 											Source: "top",
 											Start: ast.Position{
 												Column: 29,
-												Line:   200,
+												Line:   227,
 											},
 										},
 									},
@@ -10633,13 +11679,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 14,
-									Line:   201,
+									Line:   228,
 								},
 								File:   "universe.flux",
 								Source: "_highestOrLowest(\n                n:n,\n                columns:columns,\n                groupColumns:groupColumns,\n                reducer: (tables=<-) => tables |> last(column:columns[0]),\n                _sortLimit: top,\n            )",
 								Start: ast.Position{
 									Column: 12,
-									Line:   195,
+									Line:   222,
 								},
 							},
 						},
@@ -10649,13 +11695,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 28,
-										Line:   195,
+										Line:   222,
 									},
 									File:   "universe.flux",
 									Source: "_highestOrLowest",
 									Start: ast.Position{
 										Column: 12,
-										Line:   195,
+										Line:   222,
 									},
 								},
 							},
@@ -10669,13 +11715,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 20,
-								Line:   193,
+								Line:   220,
 							},
 							File:   "universe.flux",
 							Source: "n",
 							Start: ast.Position{
 								Column: 19,
-								Line:   193,
+								Line:   220,
 							},
 						},
 					},
@@ -10685,13 +11731,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 20,
-									Line:   193,
+									Line:   220,
 								},
 								File:   "universe.flux",
 								Source: "n",
 								Start: ast.Position{
 									Column: 19,
-									Line:   193,
+									Line:   220,
 								},
 							},
 						},
@@ -10704,13 +11750,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 40,
-								Line:   193,
+								Line:   220,
 							},
 							File:   "universe.flux",
 							Source: "columns=[\"_value\"]",
 							Start: ast.Position{
 								Column: 22,
-								Line:   193,
+								Line:   220,
 							},
 						},
 					},
@@ -10720,13 +11766,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 29,
-									Line:   193,
+									Line:   220,
 								},
 								File:   "universe.flux",
 								Source: "columns",
 								Start: ast.Position{
 									Column: 22,
-									Line:   193,
+									Line:   220,
 								},
 							},
 						},
@@ -10738,13 +11784,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 40,
-									Line:   193,
+									Line:   220,
 								},
 								File:   "universe.flux",
 								Source: "[\"_value\"]",
 								Start: ast.Position{
 									Column: 30,
-									Line:   193,
+									Line:   220,
 								},
 							},
 						},
@@ -10754,13 +11800,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 39,
-										Line:   193,
+										Line:   220,
 									},
 									File:   "universe.flux",
 									Source: "\"_value\"",
 									Start: ast.Position{
 										Column: 31,
-										Line:   193,
+										Line:   220,
 									},
 								},
 							},
@@ -10773,13 +11819,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 57,
-								Line:   193,
+								Line:   220,
 							},
 							File:   "universe.flux",
 							Source: "groupColumns=[]",
 							Start: ast.Position{
 								Column: 42,
-								Line:   193,
+								Line:   220,
 							},
 						},
 					},
@@ -10789,13 +11835,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 54,
-									Line:   193,
+									Line:   220,
 								},
 								File:   "universe.flux",
 								Source: "groupColumns",
 								Start: ast.Position{
 									Column: 42,
-									Line:   193,
+									Line:   220,
 								},
 							},
 						},
@@ -10807,13 +11853,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 57,
-									Line:   193,
+									Line:   220,
 								},
 								File:   "universe.flux",
 								Source: "[]",
 								Start: ast.Position{
 									Column: 55,
-									Line:   193,
+									Line:   220,
 								},
 							},
 						},
@@ -10825,13 +11871,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 68,
-								Line:   193,
+								Line:   220,
 							},
 							File:   "universe.flux",
 							Source: "tables=<-",
 							Start: ast.Position{
 								Column: 59,
-								Line:   193,
+								Line:   220,
 							},
 						},
 					},
@@ -10841,13 +11887,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 65,
-									Line:   193,
+									Line:   220,
 								},
 								File:   "universe.flux",
 								Source: "tables",
 								Start: ast.Position{
 									Column: 59,
-									Line:   193,
+									Line:   220,
 								},
 							},
 						},
@@ -10858,13 +11904,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 68,
-								Line:   193,
+								Line:   220,
 							},
 							File:   "universe.flux",
 							Source: "<-",
 							Start: ast.Position{
 								Column: 66,
-								Line:   193,
+								Line:   220,
 							},
 						},
 					}},
@@ -10876,13 +11922,13 @@ var pkgAST = &ast.Package{
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
 						Column: 14,
-						Line:   213,
+						Line:   240,
 					},
 					File:   "universe.flux",
 					Source: "lowestMin = (n, columns=[\"_value\"], groupColumns=[], tables=<-) =>\n    tables\n        |> _highestOrLowest(\n                n:n,\n                columns:columns,\n                groupColumns:groupColumns,\n                // TODO(nathanielc): Once max/min support selecting based on multiple columns change this to pass all columns.\n                reducer: (tables=<-) => tables |> min(column:columns[0]),\n                _sortLimit: bottom,\n            )",
 					Start: ast.Position{
 						Column: 1,
-						Line:   204,
+						Line:   231,
 					},
 				},
 			},
@@ -10892,13 +11938,13 @@ var pkgAST = &ast.Package{
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
 							Column: 10,
-							Line:   204,
+							Line:   231,
 						},
 						File:   "universe.flux",
 						Source: "lowestMin",
 						Start: ast.Position{
 							Column: 1,
-							Line:   204,
+							Line:   231,
 						},
 					},
 				},
@@ -10910,13 +11956,13 @@ var pkgAST = &ast.Package{
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
 							Column: 14,
-							Line:   213,
+							Line:   240,
 						},
 						File:   "universe.flux",
 						Source: "(n, columns=[\"_value\"], groupColumns=[], tables=<-) =>\n    tables\n        |> _highestOrLowest(\n                n:n,\n                columns:columns,\n                groupColumns:groupColumns,\n                // TODO(nathanielc): Once max/min support selecting based on multiple columns change this to pass all columns.\n                reducer: (tables=<-) => tables |> min(column:columns[0]),\n                _sortLimit: bottom,\n            )",
 						Start: ast.Position{
 							Column: 13,
-							Line:   204,
+							Line:   231,
 						},
 					},
 				},
@@ -10927,13 +11973,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 11,
-									Line:   205,
+									Line:   232,
 								},
 								File:   "universe.flux",
 								Source: "tables",
 								Start: ast.Position{
 									Column: 5,
-									Line:   205,
+									Line:   232,
 								},
 							},
 						},
@@ -10944,13 +11990,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 14,
-								Line:   213,
+								Line:   240,
 							},
 							File:   "universe.flux",
 							Source: "tables\n        |> _highestOrLowest(\n                n:n,\n                columns:columns,\n                groupColumns:groupColumns,\n                // TODO(nathanielc): Once max/min support selecting based on multiple columns change this to pass all columns.\n                reducer: (tables=<-) => tables |> min(column:columns[0]),\n                _sortLimit: bottom,\n            )",
 							Start: ast.Position{
 								Column: 5,
-								Line:   205,
+								Line:   232,
 							},
 						},
 					},
@@ -10961,13 +12007,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 35,
-										Line:   212,
+										Line:   239,
 									},
 									File:   "universe.flux",
 									Source: "n:n,\n                columns:columns,\n                groupColumns:groupColumns,\n                // TODO(nathanielc): Once max/min support selecting based on multiple columns change this to pass all columns.\n                reducer: (tables=<-) => tables |> min(column:columns[0]),\n                _sortLimit: bottom",
 									Start: ast.Position{
 										Column: 17,
-										Line:   207,
+										Line:   234,
 									},
 								},
 							},
@@ -10977,13 +12023,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 20,
-											Line:   207,
+											Line:   234,
 										},
 										File:   "universe.flux",
 										Source: "n:n",
 										Start: ast.Position{
 											Column: 17,
-											Line:   207,
+											Line:   234,
 										},
 									},
 								},
@@ -10993,13 +12039,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 18,
-												Line:   207,
+												Line:   234,
 											},
 											File:   "universe.flux",
 											Source: "n",
 											Start: ast.Position{
 												Column: 17,
-												Line:   207,
+												Line:   234,
 											},
 										},
 									},
@@ -11011,13 +12057,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 20,
-												Line:   207,
+												Line:   234,
 											},
 											File:   "universe.flux",
 											Source: "n",
 											Start: ast.Position{
 												Column: 19,
-												Line:   207,
+												Line:   234,
 											},
 										},
 									},
@@ -11029,13 +12075,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 32,
-											Line:   208,
+											Line:   235,
 										},
 										File:   "universe.flux",
 										Source: "columns:columns",
 										Start: ast.Position{
 											Column: 17,
-											Line:   208,
+											Line:   235,
 										},
 									},
 								},
@@ -11045,13 +12091,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 24,
-												Line:   208,
+												Line:   235,
 											},
 											File:   "universe.flux",
 											Source: "columns",
 											Start: ast.Position{
 												Column: 17,
-												Line:   208,
+												Line:   235,
 											},
 										},
 									},
@@ -11063,13 +12109,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 32,
-												Line:   208,
+												Line:   235,
 											},
 											File:   "universe.flux",
 											Source: "columns",
 											Start: ast.Position{
 												Column: 25,
-												Line:   208,
+												Line:   235,
 											},
 										},
 									},
@@ -11081,13 +12127,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 42,
-											Line:   209,
+											Line:   236,
 										},
 										File:   "universe.flux",
 										Source: "groupColumns:groupColumns",
 										Start: ast.Position{
 											Column: 17,
-											Line:   209,
+											Line:   236,
 										},
 									},
 								},
@@ -11097,13 +12143,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 29,
-												Line:   209,
+												Line:   236,
 											},
 											File:   "universe.flux",
 											Source: "groupColumns",
 											Start: ast.Position{
 												Column: 17,
-												Line:   209,
+												Line:   236,
 											},
 										},
 									},
@@ -11115,13 +12161,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 42,
-												Line:   209,
+												Line:   236,
 											},
 											File:   "universe.flux",
 											Source: "groupColumns",
 											Start: ast.Position{
 												Column: 30,
-												Line:   209,
+												Line:   236,
 											},
 										},
 									},
@@ -11133,13 +12179,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 73,
-											Line:   211,
+											Line:   238,
 										},
 										File:   "universe.flux",
 										Source: "reducer: (tables=<-) => tables |> min(column:columns[0])",
 										Start: ast.Position{
 											Column: 17,
-											Line:   211,
+											Line:   238,
 										},
 									},
 								},
@@ -11149,13 +12195,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 24,
-												Line:   211,
+												Line:   238,
 											},
 											File:   "universe.flux",
 											Source: "reducer",
 											Start: ast.Position{
 												Column: 17,
-												Line:   211,
+												Line:   238,
 											},
 										},
 									},
@@ -11167,13 +12213,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 73,
-												Line:   211,
+												Line:   238,
 											},
 											File:   "universe.flux",
 											Source: "(tables=<-) => tables |> min(column:columns[0])",
 											Start: ast.Position{
 												Column: 26,
-												Line:   211,
+												Line:   238,
 											},
 										},
 									},
@@ -11184,13 +12230,13 @@ var pkgAST = &ast.Package{
 												Loc: &ast.SourceLocation{
 													End: ast.Position{
 														Column: 47,
-														Line:   211,
+														Line:   238,
 													},
 													File:   "universe.flux",
 													Source: "tables",
 													Start: ast.Position{
 														Column: 41,
-														Line:   211,
+														Line:   238,
 													},
 												},
 											},
@@ -11201,13 +12247,13 @@ var pkgAST = &ast.Package{
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 73,
-													Line:   211,
+													Line:   238,
 												},
 												File:   "universe.flux",
 												Source: "tables |> min(column:columns[0])",
 												Start: ast.Position{
 													Column: 41,
-													Line:   211,
+													Line:   238,
 												},
 											},
 										},
@@ -11218,13 +12264,13 @@ var pkgAST = &ast.Package{
 													Loc: &ast.SourceLocation{
 														End: ast.Position{
 															Column: 72,
-															Line:   211,
+															Line:   238,
 														},
 														File:   "universe.flux",
 														Source: "column:columns[0]",
 														Start: ast.Position{
 															Column: 55,
-															Line:   211,
+															Line:   238,
 														},
 													},
 												},
@@ -11234,13 +12280,13 @@ var pkgAST = &ast.Package{
 														Loc: &ast.SourceLocation{
 															End: ast.Position{
 																Column: 72,
-																Line:   211,
+																Line:   238,
 															},
 															File:   "universe.flux",
 															Source: "column:columns[0]",
 															Start: ast.Position{
 																Column: 55,
-																Line:   211,
+																Line:   238,
 															},
 														},
 													},
@@ -11250,13 +12296,13 @@ var pkgAST = &ast.Package{
 															Loc: &ast.SourceLocation{
 																End: ast.Position{
 																	Column: 61,
-																	Line:   211,
+																	Line:   238,
 																},
 																File:   "universe.flux",
 																Source: "column",
 																Start: ast.Position{
 																	Column: 55,
-																	Line:   211,
+																	Line:   238,
 																},
 															},
 														},
@@ -11269,13 +12315,13 @@ var pkgAST = &ast.Package{
 																Loc: &ast.SourceLocation{
 																	End: ast.Position{
 																		Column: 69,
-																		Line:   211,
+																		Line:   238,
 																	},
 																	File:   "universe.flux",
 																	Source: "columns",
 																	Start: ast.Position{
 																		Column: 62,
-																		Line:   211,
+																		Line:   238,
 																	},
 																},
 															},
@@ -11286,13 +12332,13 @@ var pkgAST = &ast.Package{
 															Loc: &ast.SourceLocation{
 																End: ast.Position{
 																	Column: 72,
-																	Line:   211,
+																	Line:   238,
 																},
 																File:   "universe.flux",
 																Source: "columns[0]",
 																Start: ast.Position{
 																	Column: 62,
-																	Line:   211,
+																	Line:   238,
 																},
 															},
 														},
@@ -11302,13 +12348,13 @@ var pkgAST = &ast.Package{
 																Loc: &ast.SourceLocation{
 																	End: ast.Position{
 																		Column: 71,
-																		Line:   211,
+																		Line:   238,
 																	},
 																	File:   "universe.flux",
 																	Source: "0",
 																	Start: ast.Position{
 																		Column: 70,
-																		Line:   211,
+																		Line:   238,
 																	},
 																},
 															},
@@ -11322,13 +12368,13 @@ var pkgAST = &ast.Package{
 												Loc: &ast.SourceLocation{
 													End: ast.Position{
 														Column: 73,
-														Line:   211,
+														Line:   238,
 													},
 													File:   "universe.flux",
 													Source: "min(column:columns[0])",
 													Start: ast.Position{
 														Column: 51,
-														Line:   211,
+														Line:   238,
 													},
 												},
 											},
@@ -11338,13 +12384,13 @@ var pkgAST = &ast.Package{
 													Loc: &ast.SourceLocation{
 														End: ast.Position{
 															Column: 54,
-															Line:   211,
+															Line:   238,
 														},
 														File:   "universe.flux",
 														Source: "min",
 														Start: ast.Position{
 															Column: 51,
-															Line:   211,
+															Line:   238,
 														},
 													},
 												},
@@ -11358,13 +12404,13 @@ var pkgAST = &ast.Package{
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 36,
-													Line:   211,
+													Line:   238,
 												},
 												File:   "universe.flux",
 												Source: "tables=<-",
 												Start: ast.Position{
 													Column: 27,
-													Line:   211,
+													Line:   238,
 												},
 											},
 										},
@@ -11374,13 +12420,13 @@ var pkgAST = &ast.Package{
 												Loc: &ast.SourceLocation{
 													End: ast.Position{
 														Column: 33,
-														Line:   211,
+														Line:   238,
 													},
 													File:   "universe.flux",
 													Source: "tables",
 													Start: ast.Position{
 														Column: 27,
-														Line:   211,
+														Line:   238,
 													},
 												},
 											},
@@ -11391,13 +12437,13 @@ var pkgAST = &ast.Package{
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 36,
-													Line:   211,
+													Line:   238,
 												},
 												File:   "universe.flux",
 												Source: "<-",
 												Start: ast.Position{
 													Column: 34,
-													Line:   211,
+													Line:   238,
 												},
 											},
 										}},
@@ -11409,13 +12455,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 35,
-											Line:   212,
+											Line:   239,
 										},
 										File:   "universe.flux",
 										Source: "_sortLimit: bottom",
 										Start: ast.Position{
 											Column: 17,
-											Line:   212,
+											Line:   239,
 										},
 									},
 								},
@@ -11425,13 +12471,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 27,
-												Line:   212,
+												Line:   239,
 											},
 											File:   "universe.flux",
 											Source: "_sortLimit",
 											Start: ast.Position{
 												Column: 17,
-												Line:   212,
+												Line:   239,
 											},
 										},
 									},
@@ -11443,13 +12489,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 35,
-												Line:   212,
+												Line:   239,
 											},
 											File:   "universe.flux",
 											Source: "bottom",
 											Start: ast.Position{
 												Column: 29,
-												Line:   212,
+												Line:   239,
 											},
 										},
 									},
@@ -11462,13 +12508,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 14,
-									Line:   213,
+									Line:   240,
 								},
 								File:   "universe.flux",
 								Source: "_highestOrLowest(\n                n:n,\n                columns:columns,\n                groupColumns:groupColumns,\n                // TODO(nathanielc): Once max/min support selecting based on multiple columns change this to pass all columns.\n                reducer: (tables=<-) => tables |> min(column:columns[0]),\n                _sortLimit: bottom,\n            )",
 								Start: ast.Position{
 									Column: 12,
-									Line:   206,
+									Line:   233,
 								},
 							},
 						},
@@ -11478,13 +12524,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 28,
-										Line:   206,
+										Line:   233,
 									},
 									File:   "universe.flux",
 									Source: "_highestOrLowest",
 									Start: ast.Position{
 										Column: 12,
-										Line:   206,
+										Line:   233,
 									},
 								},
 							},
@@ -11498,13 +12544,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 15,
-								Line:   204,
+								Line:   231,
 							},
 							File:   "universe.flux",
 							Source: "n",
 							Start: ast.Position{
 								Column: 14,
-								Line:   204,
+								Line:   231,
 							},
 						},
 					},
@@ -11514,13 +12560,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 15,
-									Line:   204,
+									Line:   231,
 								},
 								File:   "universe.flux",
 								Source: "n",
 								Start: ast.Position{
 									Column: 14,
-									Line:   204,
+									Line:   231,
 								},
 							},
 						},
@@ -11533,13 +12579,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 35,
-								Line:   204,
+								Line:   231,
 							},
 							File:   "universe.flux",
 							Source: "columns=[\"_value\"]",
 							Start: ast.Position{
 								Column: 17,
-								Line:   204,
+								Line:   231,
 							},
 						},
 					},
@@ -11549,13 +12595,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 24,
-									Line:   204,
+									Line:   231,
 								},
 								File:   "universe.flux",
 								Source: "columns",
 								Start: ast.Position{
 									Column: 17,
-									Line:   204,
+									Line:   231,
 								},
 							},
 						},
@@ -11567,13 +12613,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 35,
-									Line:   204,
+									Line:   231,
 								},
 								File:   "universe.flux",
 								Source: "[\"_value\"]",
 								Start: ast.Position{
 									Column: 25,
-									Line:   204,
+									Line:   231,
 								},
 							},
 						},
@@ -11583,13 +12629,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 34,
-										Line:   204,
+										Line:   231,
 									},
 									File:   "universe.flux",
 									Source: "\"_value\"",
 									Start: ast.Position{
 										Column: 26,
-										Line:   204,
+										Line:   231,
 									},
 								},
 							},
@@ -11602,13 +12648,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 52,
-								Line:   204,
+								Line:   231,
 							},
 							File:   "universe.flux",
 							Source: "groupColumns=[]",
 							Start: ast.Position{
 								Column: 37,
-								Line:   204,
+								Line:   231,
 							},
 						},
 					},
@@ -11618,13 +12664,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 49,
-									Line:   204,
+									Line:   231,
 								},
 								File:   "universe.flux",
 								Source: "groupColumns",
 								Start: ast.Position{
 									Column: 37,
-									Line:   204,
+									Line:   231,
 								},
 							},
 						},
@@ -11636,13 +12682,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 52,
-									Line:   204,
+									Line:   231,
 								},
 								File:   "universe.flux",
 								Source: "[]",
 								Start: ast.Position{
 									Column: 50,
-									Line:   204,
+									Line:   231,
 								},
 							},
 						},
@@ -11654,13 +12700,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 63,
-								Line:   204,
+								Line:   231,
 							},
 							File:   "universe.flux",
 							Source: "tables=<-",
 							Start: ast.Position{
 								Column: 54,
-								Line:   204,
+								Line:   231,
 							},
 						},
 					},
@@ -11670,13 +12716,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 60,
-									Line:   204,
+									Line:   231,
 								},
 								File:   "universe.flux",
 								Source: "tables",
 								Start: ast.Position{
 									Column: 54,
-									Line:   204,
+									Line:   231,
 								},
 							},
 						},
@@ -11687,13 +12733,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 63,
-								Line:   204,
+								Line:   231,
 							},
 							File:   "universe.flux",
 							Source: "<-",
 							Start: ast.Position{
 								Column: 61,
-								Line:   204,
+								Line:   231,
 							},
 						},
 					}},
@@ -11705,13 +12751,13 @@ var pkgAST = &ast.Package{
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
 						Column: 14,
-						Line:   224,
+						Line:   251,
 					},
 					File:   "universe.flux",
 					Source: "lowestAverage = (n, columns=[\"_value\"], groupColumns=[], tables=<-) =>\n    tables\n        |> _highestOrLowest(\n                n:n,\n                columns:columns,\n                groupColumns:groupColumns,\n                reducer: (tables=<-) => tables |> mean(columns:[columns[0]]),\n                _sortLimit: bottom,\n            )",
 					Start: ast.Position{
 						Column: 1,
-						Line:   216,
+						Line:   243,
 					},
 				},
 			},
@@ -11721,13 +12767,13 @@ var pkgAST = &ast.Package{
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
 							Column: 14,
-							Line:   216,
+							Line:   243,
 						},
 						File:   "universe.flux",
 						Source: "lowestAverage",
 						Start: ast.Position{
 							Column: 1,
-							Line:   216,
+							Line:   243,
 						},
 					},
 				},
@@ -11739,13 +12785,13 @@ var pkgAST = &ast.Package{
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
 							Column: 14,
-							Line:   224,
+							Line:   251,
 						},
 						File:   "universe.flux",
 						Source: "(n, columns=[\"_value\"], groupColumns=[], tables=<-) =>\n    tables\n        |> _highestOrLowest(\n                n:n,\n                columns:columns,\n                groupColumns:groupColumns,\n                reducer: (tables=<-) => tables |> mean(columns:[columns[0]]),\n                _sortLimit: bottom,\n            )",
 						Start: ast.Position{
 							Column: 17,
-							Line:   216,
+							Line:   243,
 						},
 					},
 				},
@@ -11756,13 +12802,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 11,
-									Line:   217,
+									Line:   244,
 								},
 								File:   "universe.flux",
 								Source: "tables",
 								Start: ast.Position{
 									Column: 5,
-									Line:   217,
+									Line:   244,
 								},
 							},
 						},
@@ -11773,13 +12819,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 14,
-								Line:   224,
+								Line:   251,
 							},
 							File:   "universe.flux",
 							Source: "tables\n        |> _highestOrLowest(\n                n:n,\n                columns:columns,\n                groupColumns:groupColumns,\n                reducer: (tables=<-) => tables |> mean(columns:[columns[0]]),\n                _sortLimit: bottom,\n            )",
 							Start: ast.Position{
 								Column: 5,
-								Line:   217,
+								Line:   244,
 							},
 						},
 					},
@@ -11790,13 +12836,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 35,
-										Line:   223,
+										Line:   250,
 									},
 									File:   "universe.flux",
 									Source: "n:n,\n                columns:columns,\n                groupColumns:groupColumns,\n                reducer: (tables=<-) => tables |> mean(columns:[columns[0]]),\n                _sortLimit: bottom",
 									Start: ast.Position{
 										Column: 17,
-										Line:   219,
+										Line:   246,
 									},
 								},
 							},
@@ -11806,13 +12852,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 20,
-											Line:   219,
+											Line:   246,
 										},
 										File:   "universe.flux",
 										Source: "n:n",
 										Start: ast.Position{
 											Column: 17,
-											Line:   219,
+											Line:   246,
 										},
 									},
 								},
@@ -11822,13 +12868,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 18,
-												Line:   219,
+												Line:   246,
 											},
 											File:   "universe.flux",
 											Source: "n",
 											Start: ast.Position{
 												Column: 17,
-												Line:   219,
+												Line:   246,
 											},
 										},
 									},
@@ -11840,13 +12886,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 20,
-												Line:   219,
+												Line:   246,
 											},
 											File:   "universe.flux",
 											Source: "n",
 											Start: ast.Position{
 												Column: 19,
-												Line:   219,
+												Line:   246,
 											},
 										},
 									},
@@ -11858,13 +12904,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 32,
-											Line:   220,
+											Line:   247,
 										},
 										File:   "universe.flux",
 										Source: "columns:columns",
 										Start: ast.Position{
 											Column: 17,
-											Line:   220,
+											Line:   247,
 										},
 									},
 								},
@@ -11874,13 +12920,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 24,
-												Line:   220,
+												Line:   247,
 											},
 											File:   "universe.flux",
 											Source: "columns",
 											Start: ast.Position{
 												Column: 17,
-												Line:   220,
+												Line:   247,
 											},
 										},
 									},
@@ -11892,13 +12938,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 32,
-												Line:   220,
+												Line:   247,
 											},
 											File:   "universe.flux",
 											Source: "columns",
 											Start: ast.Position{
 												Column: 25,
-												Line:   220,
+												Line:   247,
 											},
 										},
 									},
@@ -11910,13 +12956,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 42,
-											Line:   221,
+											Line:   248,
 										},
 										File:   "universe.flux",
 										Source: "groupColumns:groupColumns",
 										Start: ast.Position{
 											Column: 17,
-											Line:   221,
+											Line:   248,
 										},
 									},
 								},
@@ -11926,13 +12972,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 29,
-												Line:   221,
+												Line:   248,
 											},
 											File:   "universe.flux",
 											Source: "groupColumns",
 											Start: ast.Position{
 												Column: 17,
-												Line:   221,
+												Line:   248,
 											},
 										},
 									},
@@ -11944,13 +12990,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 42,
-												Line:   221,
+												Line:   248,
 											},
 											File:   "universe.flux",
 											Source: "groupColumns",
 											Start: ast.Position{
 												Column: 30,
-												Line:   221,
+												Line:   248,
 											},
 										},
 									},
@@ -11962,13 +13008,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 77,
-											Line:   222,
+											Line:   249,
 										},
 										File:   "universe.flux",
 										Source: "reducer: (tables=<-) => tables |> mean(columns:[columns[0]])",
 										Start: ast.Position{
 											Column: 17,
-											Line:   222,
+											Line:   249,
 										},
 									},
 								},
@@ -11978,13 +13024,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 24,
-												Line:   222,
+												Line:   249,
 											},
 											File:   "universe.flux",
 											Source: "reducer",
 											Start: ast.Position{
 												Column: 17,
-												Line:   222,
+												Line:   249,
 											},
 										},
 									},
@@ -11996,13 +13042,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 77,
-												Line:   222,
+												Line:   249,
 											},
 											File:   "universe.flux",
 											Source: "(tables=<-) => tables |> mean(columns:[columns[0]])",
 											Start: ast.Position{
 												Column: 26,
-												Line:   222,
+												Line:   249,
 											},
 										},
 									},
@@ -12013,13 +13059,13 @@ var pkgAST = &ast.Package{
 												Loc: &ast.SourceLocation{
 													End: ast.Position{
 														Column: 47,
-														Line:   222,
+														Line:   249,
 													},
 													File:   "universe.flux",
 													Source: "tables",
 													Start: ast.Position{
 														Column: 41,
-														Line:   222,
+														Line:   249,
 													},
 												},
 											},
@@ -12030,13 +13076,13 @@ var pkgAST = &ast.Package{
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 77,
-													Line:   222,
+													Line:   249,
 												},
 												File:   "universe.flux",
 												Source: "tables |> mean(columns:[columns[0]])",
 												Start: ast.Position{
 													Column: 41,
-													Line:   222,
+													Line:   249,
 												},
 											},
 										},
@@ -12047,13 +13093,13 @@ var pkgAST = &ast.Package{
 													Loc: &ast.SourceLocation{
 														End: ast.Position{
 															Column: 76,
-															Line:   222,
+															Line:   249,
 														},
 														File:   "universe.flux",
 														Source: "columns:[columns[0]]",
 														Start: ast.Position{
 															Column: 56,
-															Line:   222,
+															Line:   249,
 														},
 													},
 												},
@@ -12063,13 +13109,13 @@ var pkgAST = &ast.Package{
 														Loc: &ast.SourceLocation{
 															End: ast.Position{
 																Column: 76,
-																Line:   222,
+																Line:   249,
 															},
 															File:   "universe.flux",
 															Source: "columns:[columns[0]]",
 															Start: ast.Position{
 																Column: 56,
-																Line:   222,
+																Line:   249,
 															},
 														},
 													},
@@ -12079,13 +13125,13 @@ var pkgAST = &ast.Package{
 															Loc: &ast.SourceLocation{
 																End: ast.Position{
 																	Column: 63,
-																	Line:   222,
+																	Line:   249,
 																},
 																File:   "universe.flux",
 																Source: "columns",
 																Start: ast.Position{
 																	Column: 56,
-																	Line:   222,
+																	Line:   249,
 																},
 															},
 														},
@@ -12097,13 +13143,13 @@ var pkgAST = &ast.Package{
 															Loc: &ast.SourceLocation{
 																End: ast.Position{
 																	Column: 76,
-																	Line:   222,
+																	Line:   249,
 																},
 																File:   "universe.flux",
 																Source: "[columns[0]]",
 																Start: ast.Position{
 																	Column: 64,
-																	Line:   222,
+																	Line:   249,
 																},
 															},
 														},
@@ -12114,13 +13160,13 @@ var pkgAST = &ast.Package{
 																	Loc: &ast.SourceLocation{
 																		End: ast.Position{
 																			Column: 72,
-																			Line:   222,
+																			Line:   249,
 																		},
 																		File:   "universe.flux",
 																		Source: "columns",
 																		Start: ast.Position{
 																			Column: 65,
-																			Line:   222,
+																			Line:   249,
 																		},
 																	},
 																},
@@ -12131,13 +13177,13 @@ var pkgAST = &ast.Package{
 																Loc: &ast.SourceLocation{
 																	End: ast.Position{
 																		Column: 75,
-																		Line:   222,
+																		Line:   249,
 																	},
 																	File:   "universe.flux",
 																	Source: "columns[0]",
 																	Start: ast.Position{
 																		Column: 65,
-																		Line:   222,
+																		Line:   249,
 																	},
 																},
 															},
@@ -12147,13 +13193,13 @@ var pkgAST = &ast.Package{
 																	Loc: &ast.SourceLocation{
 																		End: ast.Position{
 																			Column: 74,
-																			Line:   222,
+																			Line:   249,
 																		},
 																		File:   "universe.flux",
 																		Source: "0",
 																		Start: ast.Position{
 																			Column: 73,
-																			Line:   222,
+																			Line:   249,
 																		},
 																	},
 																},
@@ -12168,13 +13214,13 @@ var pkgAST = &ast.Package{
 												Loc: &ast.SourceLocation{
 													End: ast.Position{
 														Column: 77,
-														Line:   222,
+														Line:   249,
 													},
 													File:   "universe.flux",
 													Source: "mean(columns:[columns[0]])",
 													Start: ast.Position{
 														Column: 51,
-														Line:   222,
+														Line:   249,
 													},
 												},
 											},
@@ -12184,13 +13230,13 @@ var pkgAST = &ast.Package{
 													Loc: &ast.SourceLocation{
 														End: ast.Position{
 															Column: 55,
-															Line:   222,
+															Line:   249,
 														},
 														File:   "universe.flux",
 														Source: "mean",
 														Start: ast.Position{
 															Column: 51,
-															Line:   222,
+															Line:   249,
 														},
 													},
 												},
@@ -12204,13 +13250,13 @@ var pkgAST = &ast.Package{
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 36,
-													Line:   222,
+													Line:   249,
 												},
 												File:   "universe.flux",
 												Source: "tables=<-",
 												Start: ast.Position{
 													Column: 27,
-													Line:   222,
+													Line:   249,
 												},
 											},
 										},
@@ -12220,13 +13266,13 @@ var pkgAST = &ast.Package{
 												Loc: &ast.SourceLocation{
 													End: ast.Position{
 														Column: 33,
-														Line:   222,
+														Line:   249,
 													},
 													File:   "universe.flux",
 													Source: "tables",
 													Start: ast.Position{
 														Column: 27,
-														Line:   222,
+														Line:   249,
 													},
 												},
 											},
@@ -12237,13 +13283,13 @@ var pkgAST = &ast.Package{
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 36,
-													Line:   222,
+													Line:   249,
 												},
 												File:   "universe.flux",
 												Source: "<-",
 												Start: ast.Position{
 													Column: 34,
-													Line:   222,
+													Line:   249,
 												},
 											},
 										}},
@@ -12255,13 +13301,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 35,
-											Line:   223,
+											Line:   250,
 										},
 										File:   "universe.flux",
 										Source: "_sortLimit: bottom",
 										Start: ast.Position{
 											Column: 17,
-											Line:   223,
+											Line:   250,
 										},
 									},
 								},
@@ -12271,13 +13317,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 27,
-												Line:   223,
+												Line:   250,
 											},
 											File:   "universe.flux",
 											Source: "_sortLimit",
 											Start: ast.Position{
 												Column: 17,
-												Line:   223,
+												Line:   250,
 											},
 										},
 									},
@@ -12289,13 +13335,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 35,
-												Line:   223,
+												Line:   250,
 											},
 											File:   "universe.flux",
 											Source: "bottom",
 											Start: ast.Position{
 												Column: 29,
-												Line:   223,
+												Line:   250,
 											},
 										},
 									},
@@ -12308,13 +13354,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 14,
-									Line:   224,
+									Line:   251,
 								},
 								File:   "universe.flux",
 								Source: "_highestOrLowest(\n                n:n,\n                columns:columns,\n                groupColumns:groupColumns,\n                reducer: (tables=<-) => tables |> mean(columns:[columns[0]]),\n                _sortLimit: bottom,\n            )",
 								Start: ast.Position{
 									Column: 12,
-									Line:   218,
+									Line:   245,
 								},
 							},
 						},
@@ -12324,13 +13370,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 28,
-										Line:   218,
+										Line:   245,
 									},
 									File:   "universe.flux",
 									Source: "_highestOrLowest",
 									Start: ast.Position{
 										Column: 12,
-										Line:   218,
+										Line:   245,
 									},
 								},
 							},
@@ -12344,13 +13390,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 19,
-								Line:   216,
+								Line:   243,
 							},
 							File:   "universe.flux",
 							Source: "n",
 							Start: ast.Position{
 								Column: 18,
-								Line:   216,
+								Line:   243,
 							},
 						},
 					},
@@ -12360,13 +13406,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 19,
-									Line:   216,
+									Line:   243,
 								},
 								File:   "universe.flux",
 								Source: "n",
 								Start: ast.Position{
 									Column: 18,
-									Line:   216,
+									Line:   243,
 								},
 							},
 						},
@@ -12379,13 +13425,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 39,
-								Line:   216,
+								Line:   243,
 							},
 							File:   "universe.flux",
 							Source: "columns=[\"_value\"]",
 							Start: ast.Position{
 								Column: 21,
-								Line:   216,
+								Line:   243,
 							},
 						},
 					},
@@ -12395,13 +13441,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 28,
-									Line:   216,
+									Line:   243,
 								},
 								File:   "universe.flux",
 								Source: "columns",
 								Start: ast.Position{
 									Column: 21,
-									Line:   216,
+									Line:   243,
 								},
 							},
 						},
@@ -12413,13 +13459,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 39,
-									Line:   216,
+									Line:   243,
 								},
 								File:   "universe.flux",
 								Source: "[\"_value\"]",
 								Start: ast.Position{
 									Column: 29,
-									Line:   216,
+									Line:   243,
 								},
 							},
 						},
@@ -12429,13 +13475,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 38,
-										Line:   216,
+										Line:   243,
 									},
 									File:   "universe.flux",
 									Source: "\"_value\"",
 									Start: ast.Position{
 										Column: 30,
-										Line:   216,
+										Line:   243,
 									},
 								},
 							},
@@ -12448,13 +13494,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 56,
-								Line:   216,
+								Line:   243,
 							},
 							File:   "universe.flux",
 							Source: "groupColumns=[]",
 							Start: ast.Position{
 								Column: 41,
-								Line:   216,
+								Line:   243,
 							},
 						},
 					},
@@ -12464,13 +13510,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 53,
-									Line:   216,
+									Line:   243,
 								},
 								File:   "universe.flux",
 								Source: "groupColumns",
 								Start: ast.Position{
 									Column: 41,
-									Line:   216,
+									Line:   243,
 								},
 							},
 						},
@@ -12482,13 +13528,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 56,
-									Line:   216,
+									Line:   243,
 								},
 								File:   "universe.flux",
 								Source: "[]",
 								Start: ast.Position{
 									Column: 54,
-									Line:   216,
+									Line:   243,
 								},
 							},
 						},
@@ -12500,13 +13546,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 67,
-								Line:   216,
+								Line:   243,
 							},
 							File:   "universe.flux",
 							Source: "tables=<-",
 							Start: ast.Position{
 								Column: 58,
-								Line:   216,
+								Line:   243,
 							},
 						},
 					},
@@ -12516,13 +13562,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 64,
-									Line:   216,
+									Line:   243,
 								},
 								File:   "universe.flux",
 								Source: "tables",
 								Start: ast.Position{
 									Column: 58,
-									Line:   216,
+									Line:   243,
 								},
 							},
 						},
@@ -12533,13 +13579,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 67,
-								Line:   216,
+								Line:   243,
 							},
 							File:   "universe.flux",
 							Source: "<-",
 							Start: ast.Position{
 								Column: 65,
-								Line:   216,
+								Line:   243,
 							},
 						},
 					}},
@@ -12551,13 +13597,13 @@ var pkgAST = &ast.Package{
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
 						Column: 14,
-						Line:   235,
+						Line:   262,
 					},
 					File:   "universe.flux",
 					Source: "lowestCurrent = (n, columns=[\"_value\"], groupColumns=[], tables=<-) =>\n    tables\n        |> _highestOrLowest(\n                n:n,\n                columns:columns,\n                groupColumns:groupColumns,\n                reducer: (tables=<-) => tables |> last(column:columns[0]),\n                _sortLimit: bottom,\n            )",
 					Start: ast.Position{
 						Column: 1,
-						Line:   227,
+						Line:   254,
 					},
 				},
 			},
@@ -12567,13 +13613,13 @@ var pkgAST = &ast.Package{
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
 							Column: 14,
-							Line:   227,
+							Line:   254,
 						},
 						File:   "universe.flux",
 						Source: "lowestCurrent",
 						Start: ast.Position{
 							Column: 1,
-							Line:   227,
+							Line:   254,
 						},
 					},
 				},
@@ -12585,13 +13631,13 @@ var pkgAST = &ast.Package{
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
 							Column: 14,
-							Line:   235,
+							Line:   262,
 						},
 						File:   "universe.flux",
 						Source: "(n, columns=[\"_value\"], groupColumns=[], tables=<-) =>\n    tables\n        |> _highestOrLowest(\n                n:n,\n                columns:columns,\n                groupColumns:groupColumns,\n                reducer: (tables=<-) => tables |> last(column:columns[0]),\n                _sortLimit: bottom,\n            )",
 						Start: ast.Position{
 							Column: 17,
-							Line:   227,
+							Line:   254,
 						},
 					},
 				},
@@ -12602,13 +13648,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 11,
-									Line:   228,
+									Line:   255,
 								},
 								File:   "universe.flux",
 								Source: "tables",
 								Start: ast.Position{
 									Column: 5,
-									Line:   228,
+									Line:   255,
 								},
 							},
 						},
@@ -12619,13 +13665,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 14,
-								Line:   235,
+								Line:   262,
 							},
 							File:   "universe.flux",
 							Source: "tables\n        |> _highestOrLowest(\n                n:n,\n                columns:columns,\n                groupColumns:groupColumns,\n                reducer: (tables=<-) => tables |> last(column:columns[0]),\n                _sortLimit: bottom,\n            )",
 							Start: ast.Position{
 								Column: 5,
-								Line:   228,
+								Line:   255,
 							},
 						},
 					},
@@ -12636,13 +13682,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 35,
-										Line:   234,
+										Line:   261,
 									},
 									File:   "universe.flux",
 									Source: "n:n,\n                columns:columns,\n                groupColumns:groupColumns,\n                reducer: (tables=<-) => tables |> last(column:columns[0]),\n                _sortLimit: bottom",
 									Start: ast.Position{
 										Column: 17,
-										Line:   230,
+										Line:   257,
 									},
 								},
 							},
@@ -12652,13 +13698,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 20,
-											Line:   230,
+											Line:   257,
 										},
 										File:   "universe.flux",
 										Source: "n:n",
 										Start: ast.Position{
 											Column: 17,
-											Line:   230,
+											Line:   257,
 										},
 									},
 								},
@@ -12668,13 +13714,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 18,
-												Line:   230,
+												Line:   257,
 											},
 											File:   "universe.flux",
 											Source: "n",
 											Start: ast.Position{
 												Column: 17,
-												Line:   230,
+												Line:   257,
 											},
 										},
 									},
@@ -12686,13 +13732,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 20,
-												Line:   230,
+												Line:   257,
 											},
 											File:   "universe.flux",
 											Source: "n",
 											Start: ast.Position{
 												Column: 19,
-												Line:   230,
+												Line:   257,
 											},
 										},
 									},
@@ -12704,13 +13750,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 32,
-											Line:   231,
+											Line:   258,
 										},
 										File:   "universe.flux",
 										Source: "columns:columns",
 										Start: ast.Position{
 											Column: 17,
-											Line:   231,
+											Line:   258,
 										},
 									},
 								},
@@ -12720,13 +13766,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 24,
-												Line:   231,
+												Line:   258,
 											},
 											File:   "universe.flux",
 											Source: "columns",
 											Start: ast.Position{
 												Column: 17,
-												Line:   231,
+												Line:   258,
 											},
 										},
 									},
@@ -12738,13 +13784,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 32,
-												Line:   231,
+												Line:   258,
 											},
 											File:   "universe.flux",
 											Source: "columns",
 											Start: ast.Position{
 												Column: 25,
-												Line:   231,
+												Line:   258,
 											},
 										},
 									},
@@ -12756,13 +13802,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 42,
-											Line:   232,
+											Line:   259,
 										},
 										File:   "universe.flux",
 										Source: "groupColumns:groupColumns",
 										Start: ast.Position{
 											Column: 17,
-											Line:   232,
+											Line:   259,
 										},
 									},
 								},
@@ -12772,13 +13818,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 29,
-												Line:   232,
+												Line:   259,
 											},
 											File:   "universe.flux",
 											Source: "groupColumns",
 											Start: ast.Position{
 												Column: 17,
-												Line:   232,
+												Line:   259,
 											},
 										},
 									},
@@ -12790,13 +13836,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 42,
-												Line:   232,
+												Line:   259,
 											},
 											File:   "universe.flux",
 											Source: "groupColumns",
 											Start: ast.Position{
 												Column: 30,
-												Line:   232,
+												Line:   259,
 											},
 										},
 									},
@@ -12808,13 +13854,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 74,
-											Line:   233,
+											Line:   260,
 										},
 										File:   "universe.flux",
 										Source: "reducer: (tables=<-) => tables |> last(column:columns[0])",
 										Start: ast.Position{
 											Column: 17,
-											Line:   233,
+											Line:   260,
 										},
 									},
 								},
@@ -12824,13 +13870,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 24,
-												Line:   233,
+												Line:   260,
 											},
 											File:   "universe.flux",
 											Source: "reducer",
 											Start: ast.Position{
 												Column: 17,
-												Line:   233,
+												Line:   260,
 											},
 										},
 									},
@@ -12842,13 +13888,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 74,
-												Line:   233,
+												Line:   260,
 											},
 											File:   "universe.flux",
 											Source: "(tables=<-) => tables |> last(column:columns[0])",
 											Start: ast.Position{
 												Column: 26,
-												Line:   233,
+												Line:   260,
 											},
 										},
 									},
@@ -12859,13 +13905,13 @@ var pkgAST = &ast.Package{
 												Loc: &ast.SourceLocation{
 													End: ast.Position{
 														Column: 47,
-														Line:   233,
+														Line:   260,
 													},
 													File:   "universe.flux",
 													Source: "tables",
 													Start: ast.Position{
 														Column: 41,
-														Line:   233,
+														Line:   260,
 													},
 												},
 											},
@@ -12876,13 +13922,13 @@ var pkgAST = &ast.Package{
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 74,
-													Line:   233,
+													Line:   260,
 												},
 												File:   "universe.flux",
 												Source: "tables |> last(column:columns[0])",
 												Start: ast.Position{
 													Column: 41,
-													Line:   233,
+													Line:   260,
 												},
 											},
 										},
@@ -12893,13 +13939,13 @@ var pkgAST = &ast.Package{
 													Loc: &ast.SourceLocation{
 														End: ast.Position{
 															Column: 73,
-															Line:   233,
+															Line:   260,
 														},
 														File:   "universe.flux",
 														Source: "column:columns[0]",
 														Start: ast.Position{
 															Column: 56,
-															Line:   233,
+															Line:   260,
 														},
 													},
 												},
@@ -12909,13 +13955,13 @@ var pkgAST = &ast.Package{
 														Loc: &ast.SourceLocation{
 															End: ast.Position{
 																Column: 73,
-																Line:   233,
+																Line:   260,
 															},
 															File:   "universe.flux",
 															Source: "column:columns[0]",
 															Start: ast.Position{
 																Column: 56,
-																Line:   233,
+																Line:   260,
 															},
 														},
 													},
@@ -12925,13 +13971,13 @@ var pkgAST = &ast.Package{
 															Loc: &ast.SourceLocation{
 																End: ast.Position{
 																	Column: 62,
-																	Line:   233,
+																	Line:   260,
 																},
 																File:   "universe.flux",
 																Source: "column",
 																Start: ast.Position{
 																	Column: 56,
-																	Line:   233,
+																	Line:   260,
 																},
 															},
 														},
@@ -12944,13 +13990,13 @@ var pkgAST = &ast.Package{
 																Loc: &ast.SourceLocation{
 																	End: ast.Position{
 																		Column: 70,
-																		Line:   233,
+																		Line:   260,
 																	},
 																	File:   "universe.flux",
 																	Source: "columns",
 																	Start: ast.Position{
 																		Column: 63,
-																		Line:   233,
+																		Line:   260,
 																	},
 																},
 															},
@@ -12961,13 +14007,13 @@ var pkgAST = &ast.Package{
 															Loc: &ast.SourceLocation{
 																End: ast.Position{
 																	Column: 73,
-																	Line:   233,
+																	Line:   260,
 																},
 																File:   "universe.flux",
 																Source: "columns[0]",
 																Start: ast.Position{
 																	Column: 63,
-																	Line:   233,
+																	Line:   260,
 																},
 															},
 														},
@@ -12977,13 +14023,13 @@ var pkgAST = &ast.Package{
 																Loc: &ast.SourceLocation{
 																	End: ast.Position{
 																		Column: 72,
-																		Line:   233,
+																		Line:   260,
 																	},
 																	File:   "universe.flux",
 																	Source: "0",
 																	Start: ast.Position{
 																		Column: 71,
-																		Line:   233,
+																		Line:   260,
 																	},
 																},
 															},
@@ -12997,13 +14043,13 @@ var pkgAST = &ast.Package{
 												Loc: &ast.SourceLocation{
 													End: ast.Position{
 														Column: 74,
-														Line:   233,
+														Line:   260,
 													},
 													File:   "universe.flux",
 													Source: "last(column:columns[0])",
 													Start: ast.Position{
 														Column: 51,
-														Line:   233,
+														Line:   260,
 													},
 												},
 											},
@@ -13013,13 +14059,13 @@ var pkgAST = &ast.Package{
 													Loc: &ast.SourceLocation{
 														End: ast.Position{
 															Column: 55,
-															Line:   233,
+															Line:   260,
 														},
 														File:   "universe.flux",
 														Source: "last",
 														Start: ast.Position{
 															Column: 51,
-															Line:   233,
+															Line:   260,
 														},
 													},
 												},
@@ -13033,13 +14079,13 @@ var pkgAST = &ast.Package{
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 36,
-													Line:   233,
+													Line:   260,
 												},
 												File:   "universe.flux",
 												Source: "tables=<-",
 												Start: ast.Position{
 													Column: 27,
-													Line:   233,
+													Line:   260,
 												},
 											},
 										},
@@ -13049,13 +14095,13 @@ var pkgAST = &ast.Package{
 												Loc: &ast.SourceLocation{
 													End: ast.Position{
 														Column: 33,
-														Line:   233,
+														Line:   260,
 													},
 													File:   "universe.flux",
 													Source: "tables",
 													Start: ast.Position{
 														Column: 27,
-														Line:   233,
+														Line:   260,
 													},
 												},
 											},
@@ -13066,13 +14112,13 @@ var pkgAST = &ast.Package{
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 36,
-													Line:   233,
+													Line:   260,
 												},
 												File:   "universe.flux",
 												Source: "<-",
 												Start: ast.Position{
 													Column: 34,
-													Line:   233,
+													Line:   260,
 												},
 											},
 										}},
@@ -13084,13 +14130,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 35,
-											Line:   234,
+											Line:   261,
 										},
 										File:   "universe.flux",
 										Source: "_sortLimit: bottom",
 										Start: ast.Position{
 											Column: 17,
-											Line:   234,
+											Line:   261,
 										},
 									},
 								},
@@ -13100,13 +14146,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 27,
-												Line:   234,
+												Line:   261,
 											},
 											File:   "universe.flux",
 											Source: "_sortLimit",
 											Start: ast.Position{
 												Column: 17,
-												Line:   234,
+												Line:   261,
 											},
 										},
 									},
@@ -13118,13 +14164,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 35,
-												Line:   234,
+												Line:   261,
 											},
 											File:   "universe.flux",
 											Source: "bottom",
 											Start: ast.Position{
 												Column: 29,
-												Line:   234,
+												Line:   261,
 											},
 										},
 									},
@@ -13137,13 +14183,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 14,
-									Line:   235,
+									Line:   262,
 								},
 								File:   "universe.flux",
 								Source: "_highestOrLowest(\n                n:n,\n                columns:columns,\n                groupColumns:groupColumns,\n                reducer: (tables=<-) => tables |> last(column:columns[0]),\n                _sortLimit: bottom,\n            )",
 								Start: ast.Position{
 									Column: 12,
-									Line:   229,
+									Line:   256,
 								},
 							},
 						},
@@ -13153,13 +14199,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 28,
-										Line:   229,
+										Line:   256,
 									},
 									File:   "universe.flux",
 									Source: "_highestOrLowest",
 									Start: ast.Position{
 										Column: 12,
-										Line:   229,
+										Line:   256,
 									},
 								},
 							},
@@ -13173,13 +14219,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 19,
-								Line:   227,
+								Line:   254,
 							},
 							File:   "universe.flux",
 							Source: "n",
 							Start: ast.Position{
 								Column: 18,
-								Line:   227,
+								Line:   254,
 							},
 						},
 					},
@@ -13189,13 +14235,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 19,
-									Line:   227,
+									Line:   254,
 								},
 								File:   "universe.flux",
 								Source: "n",
 								Start: ast.Position{
 									Column: 18,
-									Line:   227,
+									Line:   254,
 								},
 							},
 						},
@@ -13208,13 +14254,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 39,
-								Line:   227,
+								Line:   254,
 							},
 							File:   "universe.flux",
 							Source: "columns=[\"_value\"]",
 							Start: ast.Position{
 								Column: 21,
-								Line:   227,
+								Line:   254,
 							},
 						},
 					},
@@ -13224,13 +14270,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 28,
-									Line:   227,
+									Line:   254,
 								},
 								File:   "universe.flux",
 								Source: "columns",
 								Start: ast.Position{
 									Column: 21,
-									Line:   227,
+									Line:   254,
 								},
 							},
 						},
@@ -13242,13 +14288,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 39,
-									Line:   227,
+									Line:   254,
 								},
 								File:   "universe.flux",
 								Source: "[\"_value\"]",
 								Start: ast.Position{
 									Column: 29,
-									Line:   227,
+									Line:   254,
 								},
 							},
 						},
@@ -13258,13 +14304,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 38,
-										Line:   227,
+										Line:   254,
 									},
 									File:   "universe.flux",
 									Source: "\"_value\"",
 									Start: ast.Position{
 										Column: 30,
-										Line:   227,
+										Line:   254,
 									},
 								},
 							},
@@ -13277,13 +14323,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 56,
-								Line:   227,
+								Line:   254,
 							},
 							File:   "universe.flux",
 							Source: "groupColumns=[]",
 							Start: ast.Position{
 								Column: 41,
-								Line:   227,
+								Line:   254,
 							},
 						},
 					},
@@ -13293,13 +14339,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 53,
-									Line:   227,
+									Line:   254,
 								},
 								File:   "universe.flux",
 								Source: "groupColumns",
 								Start: ast.Position{
 									Column: 41,
-									Line:   227,
+									Line:   254,
 								},
 							},
 						},
@@ -13311,13 +14357,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 56,
-									Line:   227,
+									Line:   254,
 								},
 								File:   "universe.flux",
 								Source: "[]",
 								Start: ast.Position{
 									Column: 54,
-									Line:   227,
+									Line:   254,
 								},
 							},
 						},
@@ -13329,13 +14375,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 67,
-								Line:   227,
+								Line:   254,
 							},
 							File:   "universe.flux",
 							Source: "tables=<-",
 							Start: ast.Position{
 								Column: 58,
-								Line:   227,
+								Line:   254,
 							},
 						},
 					},
@@ -13345,13 +14391,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 64,
-									Line:   227,
+									Line:   254,
 								},
 								File:   "universe.flux",
 								Source: "tables",
 								Start: ast.Position{
 									Column: 58,
-									Line:   227,
+									Line:   254,
 								},
 							},
 						},
@@ -13362,13 +14408,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 67,
-								Line:   227,
+								Line:   254,
 							},
 							File:   "universe.flux",
 							Source: "<-",
 							Start: ast.Position{
 								Column: 65,
-								Line:   227,
+								Line:   254,
 							},
 						},
 					}},
@@ -13380,13 +14426,13 @@ var pkgAST = &ast.Package{
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
 						Column: 70,
-						Line:   237,
+						Line:   264,
 					},
 					File:   "universe.flux",
 					Source: "toString = (tables=<-) => tables |> map(fn:(r) => string(v:r._value))",
 					Start: ast.Position{
 						Column: 1,
-						Line:   237,
+						Line:   264,
 					},
 				},
 			},
@@ -13396,13 +14442,13 @@ var pkgAST = &ast.Package{
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
 							Column: 9,
-							Line:   237,
+							Line:   264,
 						},
 						File:   "universe.flux",
 						Source: "toString",
 						Start: ast.Position{
 							Column: 1,
-							Line:   237,
+							Line:   264,
 						},
 					},
 				},
@@ -13414,13 +14460,13 @@ var pkgAST = &ast.Package{
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
 							Column: 70,
-							Line:   237,
+							Line:   264,
 						},
 						File:   "universe.flux",
 						Source: "(tables=<-) => tables |> map(fn:(r) => string(v:r._value))",
 						Start: ast.Position{
 							Column: 12,
-							Line:   237,
+							Line:   264,
 						},
 					},
 				},
@@ -13431,13 +14477,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 33,
-									Line:   237,
+									Line:   264,
 								},
 								File:   "universe.flux",
 								Source: "tables",
 								Start: ast.Position{
 									Column: 27,
-									Line:   237,
+									Line:   264,
 								},
 							},
 						},
@@ -13448,13 +14494,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 70,
-								Line:   237,
+								Line:   264,
 							},
 							File:   "universe.flux",
 							Source: "tables |> map(fn:(r) => string(v:r._value))",
 							Start: ast.Position{
 								Column: 27,
-								Line:   237,
+								Line:   264,
 							},
 						},
 					},
@@ -13465,13 +14511,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 69,
-										Line:   237,
+										Line:   264,
 									},
 									File:   "universe.flux",
 									Source: "fn:(r) => string(v:r._value)",
 									Start: ast.Position{
 										Column: 41,
-										Line:   237,
+										Line:   264,
 									},
 								},
 							},
@@ -13481,13 +14527,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 69,
-											Line:   237,
+											Line:   264,
 										},
 										File:   "universe.flux",
 										Source: "fn:(r) => string(v:r._value)",
 										Start: ast.Position{
 											Column: 41,
-											Line:   237,
+											Line:   264,
 										},
 									},
 								},
@@ -13497,13 +14543,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 43,
-												Line:   237,
+												Line:   264,
 											},
 											File:   "universe.flux",
 											Source: "fn",
 											Start: ast.Position{
 												Column: 41,
-												Line:   237,
+												Line:   264,
 											},
 										},
 									},
@@ -13515,13 +14561,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 69,
-												Line:   237,
+												Line:   264,
 											},
 											File:   "universe.flux",
 											Source: "(r) => string(v:r._value)",
 											Start: ast.Position{
 												Column: 44,
-												Line:   237,
+												Line:   264,
 											},
 										},
 									},
@@ -13532,13 +14578,13 @@ var pkgAST = &ast.Package{
 												Loc: &ast.SourceLocation{
 													End: ast.Position{
 														Column: 68,
-														Line:   237,
+														Line:   264,
 													},
 													File:   "universe.flux",
 													Source: "v:r._value",
 													Start: ast.Position{
 														Column: 58,
-														Line:   237,
+														Line:   264,
 													},
 												},
 											},
@@ -13548,13 +14594,13 @@ var pkgAST = &ast.Package{
 													Loc: &ast.SourceLocation{
 														End: ast.Position{
 															Column: 68,
-															Line:   237,
+															Line:   264,
 														},
 														File:   "universe.flux",
 														Source: "v:r._value",
 														Start: ast.Position{
 															Column: 58,
-															Line:   237,
+															Line:   264,
 														},
 													},
 												},
@@ -13564,13 +14610,13 @@ var pkgAST = &ast.Package{
 														Loc: &ast.SourceLocation{
 															End: ast.Position{
 																Column: 59,
-																Line:   237,
+																Line:   264,
 															},
 															File:   "universe.flux",
 															Source: "v",
 															Start: ast.Position{
 																Column: 58,
-																Line:   237,
+																Line:   264,
 															},
 														},
 													},
@@ -13582,13 +14628,13 @@ var pkgAST = &ast.Package{
 														Loc: &ast.SourceLocation{
 															End: ast.Position{
 																Column: 68,
-																Line:   237,
+																Line:   264,
 															},
 															File:   "universe.flux",
 															Source: "r._value",
 															Start: ast.Position{
 																Column: 60,
-																Line:   237,
+																Line:   264,
 															},
 														},
 													},
@@ -13598,13 +14644,13 @@ var pkgAST = &ast.Package{
 															Loc: &ast.SourceLocation{
 																End: ast.Position{
 																	Column: 61,
-																	Line:   237,
+																	Line:   264,
 																},
 																File:   "universe.flux",
 																Source: "r",
 																Start: ast.Position{
 																	Column: 60,
-																	Line:   237,
+																	Line:   264,
 																},
 															},
 														},
@@ -13616,13 +14662,13 @@ var pkgAST = &ast.Package{
 															Loc: &ast.SourceLocation{
 																End: ast.Position{
 																	Column: 68,
-																	Line:   237,
+																	Line:   264,
 																},
 																File:   "universe.flux",
 																Source: "_value",
 																Start: ast.Position{
 																	Column: 62,
-																	Line:   237,
+																	Line:   264,
 																},
 															},
 														},
@@ -13636,13 +14682,13 @@ var pkgAST = &ast.Package{
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 69,
-													Line:   237,
+													Line:   264,
 												},
 												File:   "universe.flux",
 												Source: "string(v:r._value)",
 												Start: ast.Position{
 													Column: 51,
-													Line:   237,
+													Line:   264,
 												},
 											},
 										},
@@ -13652,13 +14698,13 @@ var pkgAST = &ast.Package{
 												Loc: &ast.SourceLocation{
 													End: ast.Position{
 														Column: 57,
-														Line:   237,
+														Line:   264,
 													},
 													File:   "universe.flux",
 													Source: "string",
 													Start: ast.Position{
 														Column: 51,
-														Line:   237,
+														Line:   264,
 													},
 												},
 											},
@@ -13671,13 +14717,13 @@ var pkgAST = &ast.Package{
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 46,
-													Line:   237,
+													Line:   264,
 												},
 												File:   "universe.flux",
 												Source: "r",
 												Start: ast.Position{
 													Column: 45,
-													Line:   237,
+													Line:   264,
 												},
 											},
 										},
@@ -13687,13 +14733,13 @@ var pkgAST = &ast.Package{
 												Loc: &ast.SourceLocation{
 													End: ast.Position{
 														Column: 46,
-														Line:   237,
+														Line:   264,
 													},
 													File:   "universe.flux",
 													Source: "r",
 													Start: ast.Position{
 														Column: 45,
-														Line:   237,
+														Line:   264,
 													},
 												},
 											},
@@ -13709,13 +14755,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 70,
-									Line:   237,
+									Line:   264,
 								},
 								File:   "universe.flux",
 								Source: "map(fn:(r) => string(v:r._value))",
 								Start: ast.Position{
 									Column: 37,
-									Line:   237,
+									Line:   264,
 								},
 							},
 						},
@@ -13725,13 +14771,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 40,
-										Line:   237,
+										Line:   264,
 									},
 									File:   "universe.flux",
 									Source: "map",
 									Start: ast.Position{
 										Column: 37,
-										Line:   237,
+										Line:   264,
 									},
 								},
 							},
@@ -13745,13 +14791,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 22,
-								Line:   237,
+								Line:   264,
 							},
 							File:   "universe.flux",
 							Source: "tables=<-",
 							Start: ast.Position{
 								Column: 13,
-								Line:   237,
+								Line:   264,
 							},
 						},
 					},
@@ -13761,13 +14807,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 19,
-									Line:   237,
+									Line:   264,
 								},
 								File:   "universe.flux",
 								Source: "tables",
 								Start: ast.Position{
 									Column: 13,
-									Line:   237,
+									Line:   264,
 								},
 							},
 						},
@@ -13778,13 +14824,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 22,
-								Line:   237,
+								Line:   264,
 							},
 							File:   "universe.flux",
 							Source: "<-",
 							Start: ast.Position{
 								Column: 20,
-								Line:   237,
+								Line:   264,
 							},
 						},
 					}},
@@ -13796,13 +14842,13 @@ var pkgAST = &ast.Package{
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
 						Column: 64,
-						Line:   238,
+						Line:   265,
 					},
 					File:   "universe.flux",
 					Source: "toInt = (tables=<-) => tables |> map(fn:(r) => int(v:r._value))",
 					Start: ast.Position{
 						Column: 1,
-						Line:   238,
+						Line:   265,
 					},
 				},
 			},
@@ -13812,13 +14858,13 @@ var pkgAST = &ast.Package{
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
 							Column: 6,
-							Line:   238,
+							Line:   265,
 						},
 						File:   "universe.flux",
 						Source: "toInt",
 						Start: ast.Position{
 							Column: 1,
-							Line:   238,
+							Line:   265,
 						},
 					},
 				},
@@ -13830,13 +14876,13 @@ var pkgAST = &ast.Package{
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
 							Column: 64,
-							Line:   238,
+							Line:   265,
 						},
 						File:   "universe.flux",
 						Source: "(tables=<-) => tables |> map(fn:(r) => int(v:r._value))",
 						Start: ast.Position{
 							Column: 9,
-							Line:   238,
+							Line:   265,
 						},
 					},
 				},
@@ -13847,13 +14893,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 30,
-									Line:   238,
+									Line:   265,
 								},
 								File:   "universe.flux",
 								Source: "tables",
 								Start: ast.Position{
 									Column: 24,
-									Line:   238,
+									Line:   265,
 								},
 							},
 						},
@@ -13864,13 +14910,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 64,
-								Line:   238,
+								Line:   265,
 							},
 							File:   "universe.flux",
 							Source: "tables |> map(fn:(r) => int(v:r._value))",
 							Start: ast.Position{
 								Column: 24,
-								Line:   238,
+								Line:   265,
 							},
 						},
 					},
@@ -13881,13 +14927,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 63,
-										Line:   238,
+										Line:   265,
 									},
 									File:   "universe.flux",
 									Source: "fn:(r) => int(v:r._value)",
 									Start: ast.Position{
 										Column: 38,
-										Line:   238,
+										Line:   265,
 									},
 								},
 							},
@@ -13897,13 +14943,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 63,
-											Line:   238,
+											Line:   265,
 										},
 										File:   "universe.flux",
 										Source: "fn:(r) => int(v:r._value)",
 										Start: ast.Position{
 											Column: 38,
-											Line:   238,
+											Line:   265,
 										},
 									},
 								},
@@ -13913,13 +14959,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 40,
-												Line:   238,
+												Line:   265,
 											},
 											File:   "universe.flux",
 											Source: "fn",
 											Start: ast.Position{
 												Column: 38,
-												Line:   238,
+												Line:   265,
 											},
 										},
 									},
@@ -13931,13 +14977,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 63,
-												Line:   238,
+												Line:   265,
 											},
 											File:   "universe.flux",
 											Source: "(r) => int(v:r._value)",
 											Start: ast.Position{
 												Column: 41,
-												Line:   238,
+												Line:   265,
 											},
 										},
 									},
@@ -13948,13 +14994,13 @@ var pkgAST = &ast.Package{
 												Loc: &ast.SourceLocation{
 													End: ast.Position{
 														Column: 62,
-														Line:   238,
+														Line:   265,
 													},
 													File:   "universe.flux",
 													Source: "v:r._value",
 													Start: ast.Position{
 														Column: 52,
-														Line:   238,
+														Line:   265,
 													},
 												},
 											},
@@ -13964,13 +15010,13 @@ var pkgAST = &ast.Package{
 													Loc: &ast.SourceLocation{
 														End: ast.Position{
 															Column: 62,
-															Line:   238,
+															Line:   265,
 														},
 														File:   "universe.flux",
 														Source: "v:r._value",
 														Start: ast.Position{
 															Column: 52,
-															Line:   238,
+															Line:   265,
 														},
 													},
 												},
@@ -13980,13 +15026,13 @@ var pkgAST = &ast.Package{
 														Loc: &ast.SourceLocation{
 															End: ast.Position{
 																Column: 53,
-																Line:   238,
+																Line:   265,
 															},
 															File:   "universe.flux",
 															Source: "v",
 															Start: ast.Position{
 																Column: 52,
-																Line:   238,
+																Line:   265,
 															},
 														},
 													},
@@ -13998,13 +15044,13 @@ var pkgAST = &ast.Package{
 														Loc: &ast.SourceLocation{
 															End: ast.Position{
 																Column: 62,
-																Line:   238,
+																Line:   265,
 															},
 															File:   "universe.flux",
 															Source: "r._value",
 															Start: ast.Position{
 																Column: 54,
-																Line:   238,
+																Line:   265,
 															},
 														},
 													},
@@ -14014,13 +15060,13 @@ var pkgAST = &ast.Package{
 															Loc: &ast.SourceLocation{
 																End: ast.Position{
 																	Column: 55,
-																	Line:   238,
+																	Line:   265,
 																},
 																File:   "universe.flux",
 																Source: "r",
 																Start: ast.Position{
 																	Column: 54,
-																	Line:   238,
+																	Line:   265,
 																},
 															},
 														},
@@ -14032,13 +15078,13 @@ var pkgAST = &ast.Package{
 															Loc: &ast.SourceLocation{
 																End: ast.Position{
 																	Column: 62,
-																	Line:   238,
+																	Line:   265,
 																},
 																File:   "universe.flux",
 																Source: "_value",
 																Start: ast.Position{
 																	Column: 56,
-																	Line:   238,
+																	Line:   265,
 																},
 															},
 														},
@@ -14052,13 +15098,13 @@ var pkgAST = &ast.Package{
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 63,
-													Line:   238,
+													Line:   265,
 												},
 												File:   "universe.flux",
 												Source: "int(v:r._value)",
 												Start: ast.Position{
 													Column: 48,
-													Line:   238,
+													Line:   265,
 												},
 											},
 										},
@@ -14068,13 +15114,13 @@ var pkgAST = &ast.Package{
 												Loc: &ast.SourceLocation{
 													End: ast.Position{
 														Column: 51,
-														Line:   238,
+														Line:   265,
 													},
 													File:   "universe.flux",
 													Source: "int",
 													Start: ast.Position{
 														Column: 48,
-														Line:   238,
+														Line:   265,
 													},
 												},
 											},
@@ -14087,13 +15133,13 @@ var pkgAST = &ast.Package{
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 43,
-													Line:   238,
+													Line:   265,
 												},
 												File:   "universe.flux",
 												Source: "r",
 												Start: ast.Position{
 													Column: 42,
-													Line:   238,
+													Line:   265,
 												},
 											},
 										},
@@ -14103,13 +15149,13 @@ var pkgAST = &ast.Package{
 												Loc: &ast.SourceLocation{
 													End: ast.Position{
 														Column: 43,
-														Line:   238,
+														Line:   265,
 													},
 													File:   "universe.flux",
 													Source: "r",
 													Start: ast.Position{
 														Column: 42,
-														Line:   238,
+														Line:   265,
 													},
 												},
 											},
@@ -14125,13 +15171,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 64,
-									Line:   238,
+									Line:   265,
 								},
 								File:   "universe.flux",
 								Source: "map(fn:(r) => int(v:r._value))",
 								Start: ast.Position{
 									Column: 34,
-									Line:   238,
+									Line:   265,
 								},
 							},
 						},
@@ -14141,13 +15187,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 37,
-										Line:   238,
+										Line:   265,
 									},
 									File:   "universe.flux",
 									Source: "map",
 									Start: ast.Position{
 										Column: 34,
-										Line:   238,
+										Line:   265,
 									},
 								},
 							},
@@ -14161,13 +15207,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 19,
-								Line:   238,
+								Line:   265,
 							},
 							File:   "universe.flux",
 							Source: "tables=<-",
 							Start: ast.Position{
 								Column: 10,
-								Line:   238,
+								Line:   265,
 							},
 						},
 					},
@@ -14177,13 +15223,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 16,
-									Line:   238,
+									Line:   265,
 								},
 								File:   "universe.flux",
 								Source: "tables",
 								Start: ast.Position{
 									Column: 10,
-									Line:   238,
+									Line:   265,
 								},
 							},
 						},
@@ -14194,13 +15240,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 19,
-								Line:   238,
+								Line:   265,
 							},
 							File:   "universe.flux",
 							Source: "<-",
 							Start: ast.Position{
 								Column: 17,
-								Line:   238,
+								Line:   265,
 							},
 						},
 					}},
@@ -14212,13 +15258,13 @@ var pkgAST = &ast.Package{
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
 						Column: 66,
-						Line:   239,
+						Line:   266,
 					},
 					File:   "universe.flux",
 					Source: "toUInt = (tables=<-) => tables |> map(fn:(r) => uint(v:r._value))",
 					Start: ast.Position{
 						Column: 1,
-						Line:   239,
+						Line:   266,
 					},
 				},
 			},
@@ -14228,13 +15274,13 @@ var pkgAST = &ast.Package{
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
 							Column: 7,
-							Line:   239,
+							Line:   266,
 						},
 						File:   "universe.flux",
 						Source: "toUInt",
 						Start: ast.Position{
 							Column: 1,
-							Line:   239,
+							Line:   266,
 						},
 					},
 				},
@@ -14246,13 +15292,13 @@ var pkgAST = &ast.Package{
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
 							Column: 66,
-							Line:   239,
+							Line:   266,
 						},
 						File:   "universe.flux",
 						Source: "(tables=<-) => tables |> map(fn:(r) => uint(v:r._value))",
 						Start: ast.Position{
 							Column: 10,
-							Line:   239,
+							Line:   266,
 						},
 					},
 				},
@@ -14263,13 +15309,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 31,
-									Line:   239,
+									Line:   266,
 								},
 								File:   "universe.flux",
 								Source: "tables",
 								Start: ast.Position{
 									Column: 25,
-									Line:   239,
+									Line:   266,
 								},
 							},
 						},
@@ -14280,13 +15326,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 66,
-								Line:   239,
+								Line:   266,
 							},
 							File:   "universe.flux",
 							Source: "tables |> map(fn:(r) => uint(v:r._value))",
 							Start: ast.Position{
 								Column: 25,
-								Line:   239,
+								Line:   266,
 							},
 						},
 					},
@@ -14297,13 +15343,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 65,
-										Line:   239,
+										Line:   266,
 									},
 									File:   "universe.flux",
 									Source: "fn:(r) => uint(v:r._value)",
 									Start: ast.Position{
 										Column: 39,
-										Line:   239,
+										Line:   266,
 									},
 								},
 							},
@@ -14313,13 +15359,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 65,
-											Line:   239,
+											Line:   266,
 										},
 										File:   "universe.flux",
 										Source: "fn:(r) => uint(v:r._value)",
 										Start: ast.Position{
 											Column: 39,
-											Line:   239,
+											Line:   266,
 										},
 									},
 								},
@@ -14329,13 +15375,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 41,
-												Line:   239,
+												Line:   266,
 											},
 											File:   "universe.flux",
 											Source: "fn",
 											Start: ast.Position{
 												Column: 39,
-												Line:   239,
+												Line:   266,
 											},
 										},
 									},
@@ -14347,13 +15393,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 65,
-												Line:   239,
+												Line:   266,
 											},
 											File:   "universe.flux",
 											Source: "(r) => uint(v:r._value)",
 											Start: ast.Position{
 												Column: 42,
-												Line:   239,
+												Line:   266,
 											},
 										},
 									},
@@ -14364,13 +15410,13 @@ var pkgAST = &ast.Package{
 												Loc: &ast.SourceLocation{
 													End: ast.Position{
 														Column: 64,
-														Line:   239,
+														Line:   266,
 													},
 													File:   "universe.flux",
 													Source: "v:r._value",
 													Start: ast.Position{
 														Column: 54,
-														Line:   239,
+														Line:   266,
 													},
 												},
 											},
@@ -14380,13 +15426,13 @@ var pkgAST = &ast.Package{
 													Loc: &ast.SourceLocation{
 														End: ast.Position{
 															Column: 64,
-															Line:   239,
+															Line:   266,
 														},
 														File:   "universe.flux",
 														Source: "v:r._value",
 														Start: ast.Position{
 															Column: 54,
-															Line:   239,
+															Line:   266,
 														},
 													},
 												},
@@ -14396,13 +15442,13 @@ var pkgAST = &ast.Package{
 														Loc: &ast.SourceLocation{
 															End: ast.Position{
 																Column: 55,
-																Line:   239,
+																Line:   266,
 															},
 															File:   "universe.flux",
 															Source: "v",
 															Start: ast.Position{
 																Column: 54,
-																Line:   239,
+																Line:   266,
 															},
 														},
 													},
@@ -14414,13 +15460,13 @@ var pkgAST = &ast.Package{
 														Loc: &ast.SourceLocation{
 															End: ast.Position{
 																Column: 64,
-																Line:   239,
+																Line:   266,
 															},
 															File:   "universe.flux",
 															Source: "r._value",
 															Start: ast.Position{
 																Column: 56,
-																Line:   239,
+																Line:   266,
 															},
 														},
 													},
@@ -14430,13 +15476,13 @@ var pkgAST = &ast.Package{
 															Loc: &ast.SourceLocation{
 																End: ast.Position{
 																	Column: 57,
-																	Line:   239,
+																	Line:   266,
 																},
 																File:   "universe.flux",
 																Source: "r",
 																Start: ast.Position{
 																	Column: 56,
-																	Line:   239,
+																	Line:   266,
 																},
 															},
 														},
@@ -14448,13 +15494,13 @@ var pkgAST = &ast.Package{
 															Loc: &ast.SourceLocation{
 																End: ast.Position{
 																	Column: 64,
-																	Line:   239,
+																	Line:   266,
 																},
 																File:   "universe.flux",
 																Source: "_value",
 																Start: ast.Position{
 																	Column: 58,
-																	Line:   239,
+																	Line:   266,
 																},
 															},
 														},
@@ -14468,13 +15514,13 @@ var pkgAST = &ast.Package{
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 65,
-													Line:   239,
+													Line:   266,
 												},
 												File:   "universe.flux",
 												Source: "uint(v:r._value)",
 												Start: ast.Position{
 													Column: 49,
-													Line:   239,
+													Line:   266,
 												},
 											},
 										},
@@ -14484,13 +15530,13 @@ var pkgAST = &ast.Package{
 												Loc: &ast.SourceLocation{
 													End: ast.Position{
 														Column: 53,
-														Line:   239,
+														Line:   266,
 													},
 													File:   "universe.flux",
 													Source: "uint",
 													Start: ast.Position{
 														Column: 49,
-														Line:   239,
+														Line:   266,
 													},
 												},
 											},
@@ -14503,13 +15549,13 @@ var pkgAST = &ast.Package{
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 44,
-													Line:   239,
+													Line:   266,
 												},
 												File:   "universe.flux",
 												Source: "r",
 												Start: ast.Position{
 													Column: 43,
-													Line:   239,
+													Line:   266,
 												},
 											},
 										},
@@ -14519,13 +15565,13 @@ var pkgAST = &ast.Package{
 												Loc: &ast.SourceLocation{
 													End: ast.Position{
 														Column: 44,
-														Line:   239,
+														Line:   266,
 													},
 													File:   "universe.flux",
 													Source: "r",
 													Start: ast.Position{
 														Column: 43,
-														Line:   239,
+														Line:   266,
 													},
 												},
 											},
@@ -14541,13 +15587,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 66,
-									Line:   239,
+									Line:   266,
 								},
 								File:   "universe.flux",
 								Source: "map(fn:(r) => uint(v:r._value))",
 								Start: ast.Position{
 									Column: 35,
-									Line:   239,
+									Line:   266,
 								},
 							},
 						},
@@ -14557,13 +15603,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 38,
-										Line:   239,
+										Line:   266,
 									},
 									File:   "universe.flux",
 									Source: "map",
 									Start: ast.Position{
 										Column: 35,
-										Line:   239,
+										Line:   266,
 									},
 								},
 							},
@@ -14577,13 +15623,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 20,
-								Line:   239,
+								Line:   266,
 							},
 							File:   "universe.flux",
 							Source: "tables=<-",
 							Start: ast.Position{
 								Column: 11,
-								Line:   239,
+								Line:   266,
 							},
 						},
 					},
@@ -14593,13 +15639,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 17,
-									Line:   239,
+									Line:   266,
 								},
 								File:   "universe.flux",
 								Source: "tables",
 								Start: ast.Position{
 									Column: 11,
-									Line:   239,
+									Line:   266,
 								},
 							},
 						},
@@ -14610,13 +15656,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 20,
-								Line:   239,
+								Line:   266,
 							},
 							File:   "universe.flux",
 							Source: "<-",
 							Start: ast.Position{
 								Column: 18,
-								Line:   239,
+								Line:   266,
 							},
 						},
 					}},
@@ -14628,13 +15674,13 @@ var pkgAST = &ast.Package{
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
 						Column: 68,
-						Line:   240,
+						Line:   267,
 					},
 					File:   "universe.flux",
 					Source: "toFloat = (tables=<-) => tables |> map(fn:(r) => float(v:r._value))",
 					Start: ast.Position{
 						Column: 1,
-						Line:   240,
+						Line:   267,
 					},
 				},
 			},
@@ -14644,13 +15690,13 @@ var pkgAST = &ast.Package{
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
 							Column: 8,
-							Line:   240,
+							Line:   267,
 						},
 						File:   "universe.flux",
 						Source: "toFloat",
 						Start: ast.Position{
 							Column: 1,
-							Line:   240,
+							Line:   267,
 						},
 					},
 				},
@@ -14662,13 +15708,13 @@ var pkgAST = &ast.Package{
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
 							Column: 68,
-							Line:   240,
+							Line:   267,
 						},
 						File:   "universe.flux",
 						Source: "(tables=<-) => tables |> map(fn:(r) => float(v:r._value))",
 						Start: ast.Position{
 							Column: 11,
-							Line:   240,
+							Line:   267,
 						},
 					},
 				},
@@ -14679,13 +15725,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 32,
-									Line:   240,
+									Line:   267,
 								},
 								File:   "universe.flux",
 								Source: "tables",
 								Start: ast.Position{
 									Column: 26,
-									Line:   240,
+									Line:   267,
 								},
 							},
 						},
@@ -14696,13 +15742,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 68,
-								Line:   240,
+								Line:   267,
 							},
 							File:   "universe.flux",
 							Source: "tables |> map(fn:(r) => float(v:r._value))",
 							Start: ast.Position{
 								Column: 26,
-								Line:   240,
+								Line:   267,
 							},
 						},
 					},
@@ -14713,13 +15759,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 67,
-										Line:   240,
+										Line:   267,
 									},
 									File:   "universe.flux",
 									Source: "fn:(r) => float(v:r._value)",
 									Start: ast.Position{
 										Column: 40,
-										Line:   240,
+										Line:   267,
 									},
 								},
 							},
@@ -14729,13 +15775,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 67,
-											Line:   240,
+											Line:   267,
 										},
 										File:   "universe.flux",
 										Source: "fn:(r) => float(v:r._value)",
 										Start: ast.Position{
 											Column: 40,
-											Line:   240,
+											Line:   267,
 										},
 									},
 								},
@@ -14745,13 +15791,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 42,
-												Line:   240,
+												Line:   267,
 											},
 											File:   "universe.flux",
 											Source: "fn",
 											Start: ast.Position{
 												Column: 40,
-												Line:   240,
+												Line:   267,
 											},
 										},
 									},
@@ -14763,13 +15809,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 67,
-												Line:   240,
+												Line:   267,
 											},
 											File:   "universe.flux",
 											Source: "(r) => float(v:r._value)",
 											Start: ast.Position{
 												Column: 43,
-												Line:   240,
+												Line:   267,
 											},
 										},
 									},
@@ -14780,13 +15826,13 @@ var pkgAST = &ast.Package{
 												Loc: &ast.SourceLocation{
 													End: ast.Position{
 														Column: 66,
-														Line:   240,
+														Line:   267,
 													},
 													File:   "universe.flux",
 													Source: "v:r._value",
 													Start: ast.Position{
 														Column: 56,
-														Line:   240,
+														Line:   267,
 													},
 												},
 											},
@@ -14796,13 +15842,13 @@ var pkgAST = &ast.Package{
 													Loc: &ast.SourceLocation{
 														End: ast.Position{
 															Column: 66,
-															Line:   240,
+															Line:   267,
 														},
 														File:   "universe.flux",
 														Source: "v:r._value",
 														Start: ast.Position{
 															Column: 56,
-															Line:   240,
+															Line:   267,
 														},
 													},
 												},
@@ -14812,13 +15858,13 @@ var pkgAST = &ast.Package{
 														Loc: &ast.SourceLocation{
 															End: ast.Position{
 																Column: 57,
-																Line:   240,
+																Line:   267,
 															},
 															File:   "universe.flux",
 															Source: "v",
 															Start: ast.Position{
 																Column: 56,
-																Line:   240,
+																Line:   267,
 															},
 														},
 													},
@@ -14830,13 +15876,13 @@ var pkgAST = &ast.Package{
 														Loc: &ast.SourceLocation{
 															End: ast.Position{
 																Column: 66,
-																Line:   240,
+																Line:   267,
 															},
 															File:   "universe.flux",
 															Source: "r._value",
 															Start: ast.Position{
 																Column: 58,
-																Line:   240,
+																Line:   267,
 															},
 														},
 													},
@@ -14846,13 +15892,13 @@ var pkgAST = &ast.Package{
 															Loc: &ast.SourceLocation{
 																End: ast.Position{
 																	Column: 59,
-																	Line:   240,
+																	Line:   267,
 																},
 																File:   "universe.flux",
 																Source: "r",
 																Start: ast.Position{
 																	Column: 58,
-																	Line:   240,
+																	Line:   267,
 																},
 															},
 														},
@@ -14864,13 +15910,13 @@ var pkgAST = &ast.Package{
 															Loc: &ast.SourceLocation{
 																End: ast.Position{
 																	Column: 66,
-																	Line:   240,
+																	Line:   267,
 																},
 																File:   "universe.flux",
 																Source: "_value",
 																Start: ast.Position{
 																	Column: 60,
-																	Line:   240,
+																	Line:   267,
 																},
 															},
 														},
@@ -14884,13 +15930,13 @@ var pkgAST = &ast.Package{
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 67,
-													Line:   240,
+													Line:   267,
 												},
 												File:   "universe.flux",
 												Source: "float(v:r._value)",
 												Start: ast.Position{
 													Column: 50,
-													Line:   240,
+													Line:   267,
 												},
 											},
 										},
@@ -14900,13 +15946,13 @@ var pkgAST = &ast.Package{
 												Loc: &ast.SourceLocation{
 													End: ast.Position{
 														Column: 55,
-														Line:   240,
+														Line:   267,
 													},
 													File:   "universe.flux",
 													Source: "float",
 													Start: ast.Position{
 														Column: 50,
-														Line:   240,
+														Line:   267,
 													},
 												},
 											},
@@ -14919,13 +15965,13 @@ var pkgAST = &ast.Package{
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 45,
-													Line:   240,
+													Line:   267,
 												},
 												File:   "universe.flux",
 												Source: "r",
 												Start: ast.Position{
 													Column: 44,
-													Line:   240,
+													Line:   267,
 												},
 											},
 										},
@@ -14935,13 +15981,13 @@ var pkgAST = &ast.Package{
 												Loc: &ast.SourceLocation{
 													End: ast.Position{
 														Column: 45,
-														Line:   240,
+														Line:   267,
 													},
 													File:   "universe.flux",
 													Source: "r",
 													Start: ast.Position{
 														Column: 44,
-														Line:   240,
+														Line:   267,
 													},
 												},
 											},
@@ -14957,13 +16003,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 68,
-									Line:   240,
+									Line:   267,
 								},
 								File:   "universe.flux",
 								Source: "map(fn:(r) => float(v:r._value))",
 								Start: ast.Position{
 									Column: 36,
-									Line:   240,
+									Line:   267,
 								},
 							},
 						},
@@ -14973,13 +16019,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 39,
-										Line:   240,
+										Line:   267,
 									},
 									File:   "universe.flux",
 									Source: "map",
 									Start: ast.Position{
 										Column: 36,
-										Line:   240,
+										Line:   267,
 									},
 								},
 							},
@@ -14993,13 +16039,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 21,
-								Line:   240,
+								Line:   267,
 							},
 							File:   "universe.flux",
 							Source: "tables=<-",
 							Start: ast.Position{
 								Column: 12,
-								Line:   240,
+								Line:   267,
 							},
 						},
 					},
@@ -15009,13 +16055,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 18,
-									Line:   240,
+									Line:   267,
 								},
 								File:   "universe.flux",
 								Source: "tables",
 								Start: ast.Position{
 									Column: 12,
-									Line:   240,
+									Line:   267,
 								},
 							},
 						},
@@ -15026,13 +16072,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 21,
-								Line:   240,
+								Line:   267,
 							},
 							File:   "universe.flux",
 							Source: "<-",
 							Start: ast.Position{
 								Column: 19,
-								Line:   240,
+								Line:   267,
 							},
 						},
 					}},
@@ -15044,13 +16090,13 @@ var pkgAST = &ast.Package{
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
 						Column: 66,
-						Line:   241,
+						Line:   268,
 					},
 					File:   "universe.flux",
 					Source: "toBool = (tables=<-) => tables |> map(fn:(r) => bool(v:r._value))",
 					Start: ast.Position{
 						Column: 1,
-						Line:   241,
+						Line:   268,
 					},
 				},
 			},
@@ -15060,13 +16106,13 @@ var pkgAST = &ast.Package{
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
 							Column: 7,
-							Line:   241,
+							Line:   268,
 						},
 						File:   "universe.flux",
 						Source: "toBool",
 						Start: ast.Position{
 							Column: 1,
-							Line:   241,
+							Line:   268,
 						},
 					},
 				},
@@ -15078,13 +16124,13 @@ var pkgAST = &ast.Package{
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
 							Column: 66,
-							Line:   241,
+							Line:   268,
 						},
 						File:   "universe.flux",
 						Source: "(tables=<-) => tables |> map(fn:(r) => bool(v:r._value))",
 						Start: ast.Position{
 							Column: 10,
-							Line:   241,
+							Line:   268,
 						},
 					},
 				},
@@ -15095,13 +16141,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 31,
-									Line:   241,
+									Line:   268,
 								},
 								File:   "universe.flux",
 								Source: "tables",
 								Start: ast.Position{
 									Column: 25,
-									Line:   241,
+									Line:   268,
 								},
 							},
 						},
@@ -15112,13 +16158,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 66,
-								Line:   241,
+								Line:   268,
 							},
 							File:   "universe.flux",
 							Source: "tables |> map(fn:(r) => bool(v:r._value))",
 							Start: ast.Position{
 								Column: 25,
-								Line:   241,
+								Line:   268,
 							},
 						},
 					},
@@ -15129,13 +16175,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 65,
-										Line:   241,
+										Line:   268,
 									},
 									File:   "universe.flux",
 									Source: "fn:(r) => bool(v:r._value)",
 									Start: ast.Position{
 										Column: 39,
-										Line:   241,
+										Line:   268,
 									},
 								},
 							},
@@ -15145,13 +16191,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 65,
-											Line:   241,
+											Line:   268,
 										},
 										File:   "universe.flux",
 										Source: "fn:(r) => bool(v:r._value)",
 										Start: ast.Position{
 											Column: 39,
-											Line:   241,
+											Line:   268,
 										},
 									},
 								},
@@ -15161,13 +16207,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 41,
-												Line:   241,
+												Line:   268,
 											},
 											File:   "universe.flux",
 											Source: "fn",
 											Start: ast.Position{
 												Column: 39,
-												Line:   241,
+												Line:   268,
 											},
 										},
 									},
@@ -15179,13 +16225,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 65,
-												Line:   241,
+												Line:   268,
 											},
 											File:   "universe.flux",
 											Source: "(r) => bool(v:r._value)",
 											Start: ast.Position{
 												Column: 42,
-												Line:   241,
+												Line:   268,
 											},
 										},
 									},
@@ -15196,13 +16242,13 @@ var pkgAST = &ast.Package{
 												Loc: &ast.SourceLocation{
 													End: ast.Position{
 														Column: 64,
-														Line:   241,
+														Line:   268,
 													},
 													File:   "universe.flux",
 													Source: "v:r._value",
 													Start: ast.Position{
 														Column: 54,
-														Line:   241,
+														Line:   268,
 													},
 												},
 											},
@@ -15212,13 +16258,13 @@ var pkgAST = &ast.Package{
 													Loc: &ast.SourceLocation{
 														End: ast.Position{
 															Column: 64,
-															Line:   241,
+															Line:   268,
 														},
 														File:   "universe.flux",
 														Source: "v:r._value",
 														Start: ast.Position{
 															Column: 54,
-															Line:   241,
+															Line:   268,
 														},
 													},
 												},
@@ -15228,13 +16274,13 @@ var pkgAST = &ast.Package{
 														Loc: &ast.SourceLocation{
 															End: ast.Position{
 																Column: 55,
-																Line:   241,
+																Line:   268,
 															},
 															File:   "universe.flux",
 															Source: "v",
 															Start: ast.Position{
 																Column: 54,
-																Line:   241,
+																Line:   268,
 															},
 														},
 													},
@@ -15246,13 +16292,13 @@ var pkgAST = &ast.Package{
 														Loc: &ast.SourceLocation{
 															End: ast.Position{
 																Column: 64,
-																Line:   241,
+																Line:   268,
 															},
 															File:   "universe.flux",
 															Source: "r._value",
 															Start: ast.Position{
 																Column: 56,
-																Line:   241,
+																Line:   268,
 															},
 														},
 													},
@@ -15262,13 +16308,13 @@ var pkgAST = &ast.Package{
 															Loc: &ast.SourceLocation{
 																End: ast.Position{
 																	Column: 57,
-																	Line:   241,
+																	Line:   268,
 																},
 																File:   "universe.flux",
 																Source: "r",
 																Start: ast.Position{
 																	Column: 56,
-																	Line:   241,
+																	Line:   268,
 																},
 															},
 														},
@@ -15280,13 +16326,13 @@ var pkgAST = &ast.Package{
 															Loc: &ast.SourceLocation{
 																End: ast.Position{
 																	Column: 64,
-																	Line:   241,
+																	Line:   268,
 																},
 																File:   "universe.flux",
 																Source: "_value",
 																Start: ast.Position{
 																	Column: 58,
-																	Line:   241,
+																	Line:   268,
 																},
 															},
 														},
@@ -15300,13 +16346,13 @@ var pkgAST = &ast.Package{
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 65,
-													Line:   241,
+													Line:   268,
 												},
 												File:   "universe.flux",
 												Source: "bool(v:r._value)",
 												Start: ast.Position{
 													Column: 49,
-													Line:   241,
+													Line:   268,
 												},
 											},
 										},
@@ -15316,13 +16362,13 @@ var pkgAST = &ast.Package{
 												Loc: &ast.SourceLocation{
 													End: ast.Position{
 														Column: 53,
-														Line:   241,
+														Line:   268,
 													},
 													File:   "universe.flux",
 													Source: "bool",
 													Start: ast.Position{
 														Column: 49,
-														Line:   241,
+														Line:   268,
 													},
 												},
 											},
@@ -15335,13 +16381,13 @@ var pkgAST = &ast.Package{
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 44,
-													Line:   241,
+													Line:   268,
 												},
 												File:   "universe.flux",
 												Source: "r",
 												Start: ast.Position{
 													Column: 43,
-													Line:   241,
+													Line:   268,
 												},
 											},
 										},
@@ -15351,13 +16397,13 @@ var pkgAST = &ast.Package{
 												Loc: &ast.SourceLocation{
 													End: ast.Position{
 														Column: 44,
-														Line:   241,
+														Line:   268,
 													},
 													File:   "universe.flux",
 													Source: "r",
 													Start: ast.Position{
 														Column: 43,
-														Line:   241,
+														Line:   268,
 													},
 												},
 											},
@@ -15373,13 +16419,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 66,
-									Line:   241,
+									Line:   268,
 								},
 								File:   "universe.flux",
 								Source: "map(fn:(r) => bool(v:r._value))",
 								Start: ast.Position{
 									Column: 35,
-									Line:   241,
+									Line:   268,
 								},
 							},
 						},
@@ -15389,13 +16435,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 38,
-										Line:   241,
+										Line:   268,
 									},
 									File:   "universe.flux",
 									Source: "map",
 									Start: ast.Position{
 										Column: 35,
-										Line:   241,
+										Line:   268,
 									},
 								},
 							},
@@ -15409,13 +16455,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 20,
-								Line:   241,
+								Line:   268,
 							},
 							File:   "universe.flux",
 							Source: "tables=<-",
 							Start: ast.Position{
 								Column: 11,
-								Line:   241,
+								Line:   268,
 							},
 						},
 					},
@@ -15425,13 +16471,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 17,
-									Line:   241,
+									Line:   268,
 								},
 								File:   "universe.flux",
 								Source: "tables",
 								Start: ast.Position{
 									Column: 11,
-									Line:   241,
+									Line:   268,
 								},
 							},
 						},
@@ -15442,13 +16488,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 20,
-								Line:   241,
+								Line:   268,
 							},
 							File:   "universe.flux",
 							Source: "<-",
 							Start: ast.Position{
 								Column: 18,
-								Line:   241,
+								Line:   268,
 							},
 						},
 					}},
@@ -15460,13 +16506,13 @@ var pkgAST = &ast.Package{
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
 						Column: 66,
-						Line:   242,
+						Line:   269,
 					},
 					File:   "universe.flux",
 					Source: "toTime = (tables=<-) => tables |> map(fn:(r) => time(v:r._value))",
 					Start: ast.Position{
 						Column: 1,
-						Line:   242,
+						Line:   269,
 					},
 				},
 			},
@@ -15476,13 +16522,13 @@ var pkgAST = &ast.Package{
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
 							Column: 7,
-							Line:   242,
+							Line:   269,
 						},
 						File:   "universe.flux",
 						Source: "toTime",
 						Start: ast.Position{
 							Column: 1,
-							Line:   242,
+							Line:   269,
 						},
 					},
 				},
@@ -15494,13 +16540,13 @@ var pkgAST = &ast.Package{
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
 							Column: 66,
-							Line:   242,
+							Line:   269,
 						},
 						File:   "universe.flux",
 						Source: "(tables=<-) => tables |> map(fn:(r) => time(v:r._value))",
 						Start: ast.Position{
 							Column: 10,
-							Line:   242,
+							Line:   269,
 						},
 					},
 				},
@@ -15511,13 +16557,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 31,
-									Line:   242,
+									Line:   269,
 								},
 								File:   "universe.flux",
 								Source: "tables",
 								Start: ast.Position{
 									Column: 25,
-									Line:   242,
+									Line:   269,
 								},
 							},
 						},
@@ -15528,13 +16574,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 66,
-								Line:   242,
+								Line:   269,
 							},
 							File:   "universe.flux",
 							Source: "tables |> map(fn:(r) => time(v:r._value))",
 							Start: ast.Position{
 								Column: 25,
-								Line:   242,
+								Line:   269,
 							},
 						},
 					},
@@ -15545,13 +16591,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 65,
-										Line:   242,
+										Line:   269,
 									},
 									File:   "universe.flux",
 									Source: "fn:(r) => time(v:r._value)",
 									Start: ast.Position{
 										Column: 39,
-										Line:   242,
+										Line:   269,
 									},
 								},
 							},
@@ -15561,13 +16607,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 65,
-											Line:   242,
+											Line:   269,
 										},
 										File:   "universe.flux",
 										Source: "fn:(r) => time(v:r._value)",
 										Start: ast.Position{
 											Column: 39,
-											Line:   242,
+											Line:   269,
 										},
 									},
 								},
@@ -15577,13 +16623,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 41,
-												Line:   242,
+												Line:   269,
 											},
 											File:   "universe.flux",
 											Source: "fn",
 											Start: ast.Position{
 												Column: 39,
-												Line:   242,
+												Line:   269,
 											},
 										},
 									},
@@ -15595,13 +16641,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 65,
-												Line:   242,
+												Line:   269,
 											},
 											File:   "universe.flux",
 											Source: "(r) => time(v:r._value)",
 											Start: ast.Position{
 												Column: 42,
-												Line:   242,
+												Line:   269,
 											},
 										},
 									},
@@ -15612,13 +16658,13 @@ var pkgAST = &ast.Package{
 												Loc: &ast.SourceLocation{
 													End: ast.Position{
 														Column: 64,
-														Line:   242,
+														Line:   269,
 													},
 													File:   "universe.flux",
 													Source: "v:r._value",
 													Start: ast.Position{
 														Column: 54,
-														Line:   242,
+														Line:   269,
 													},
 												},
 											},
@@ -15628,13 +16674,13 @@ var pkgAST = &ast.Package{
 													Loc: &ast.SourceLocation{
 														End: ast.Position{
 															Column: 64,
-															Line:   242,
+															Line:   269,
 														},
 														File:   "universe.flux",
 														Source: "v:r._value",
 														Start: ast.Position{
 															Column: 54,
-															Line:   242,
+															Line:   269,
 														},
 													},
 												},
@@ -15644,13 +16690,13 @@ var pkgAST = &ast.Package{
 														Loc: &ast.SourceLocation{
 															End: ast.Position{
 																Column: 55,
-																Line:   242,
+																Line:   269,
 															},
 															File:   "universe.flux",
 															Source: "v",
 															Start: ast.Position{
 																Column: 54,
-																Line:   242,
+																Line:   269,
 															},
 														},
 													},
@@ -15662,13 +16708,13 @@ var pkgAST = &ast.Package{
 														Loc: &ast.SourceLocation{
 															End: ast.Position{
 																Column: 64,
-																Line:   242,
+																Line:   269,
 															},
 															File:   "universe.flux",
 															Source: "r._value",
 															Start: ast.Position{
 																Column: 56,
-																Line:   242,
+																Line:   269,
 															},
 														},
 													},
@@ -15678,13 +16724,13 @@ var pkgAST = &ast.Package{
 															Loc: &ast.SourceLocation{
 																End: ast.Position{
 																	Column: 57,
-																	Line:   242,
+																	Line:   269,
 																},
 																File:   "universe.flux",
 																Source: "r",
 																Start: ast.Position{
 																	Column: 56,
-																	Line:   242,
+																	Line:   269,
 																},
 															},
 														},
@@ -15696,13 +16742,13 @@ var pkgAST = &ast.Package{
 															Loc: &ast.SourceLocation{
 																End: ast.Position{
 																	Column: 64,
-																	Line:   242,
+																	Line:   269,
 																},
 																File:   "universe.flux",
 																Source: "_value",
 																Start: ast.Position{
 																	Column: 58,
-																	Line:   242,
+																	Line:   269,
 																},
 															},
 														},
@@ -15716,13 +16762,13 @@ var pkgAST = &ast.Package{
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 65,
-													Line:   242,
+													Line:   269,
 												},
 												File:   "universe.flux",
 												Source: "time(v:r._value)",
 												Start: ast.Position{
 													Column: 49,
-													Line:   242,
+													Line:   269,
 												},
 											},
 										},
@@ -15732,13 +16778,13 @@ var pkgAST = &ast.Package{
 												Loc: &ast.SourceLocation{
 													End: ast.Position{
 														Column: 53,
-														Line:   242,
+														Line:   269,
 													},
 													File:   "universe.flux",
 													Source: "time",
 													Start: ast.Position{
 														Column: 49,
-														Line:   242,
+														Line:   269,
 													},
 												},
 											},
@@ -15751,13 +16797,13 @@ var pkgAST = &ast.Package{
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 44,
-													Line:   242,
+													Line:   269,
 												},
 												File:   "universe.flux",
 												Source: "r",
 												Start: ast.Position{
 													Column: 43,
-													Line:   242,
+													Line:   269,
 												},
 											},
 										},
@@ -15767,13 +16813,13 @@ var pkgAST = &ast.Package{
 												Loc: &ast.SourceLocation{
 													End: ast.Position{
 														Column: 44,
-														Line:   242,
+														Line:   269,
 													},
 													File:   "universe.flux",
 													Source: "r",
 													Start: ast.Position{
 														Column: 43,
-														Line:   242,
+														Line:   269,
 													},
 												},
 											},
@@ -15789,13 +16835,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 66,
-									Line:   242,
+									Line:   269,
 								},
 								File:   "universe.flux",
 								Source: "map(fn:(r) => time(v:r._value))",
 								Start: ast.Position{
 									Column: 35,
-									Line:   242,
+									Line:   269,
 								},
 							},
 						},
@@ -15805,13 +16851,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 38,
-										Line:   242,
+										Line:   269,
 									},
 									File:   "universe.flux",
 									Source: "map",
 									Start: ast.Position{
 										Column: 35,
-										Line:   242,
+										Line:   269,
 									},
 								},
 							},
@@ -15825,13 +16871,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 20,
-								Line:   242,
+								Line:   269,
 							},
 							File:   "universe.flux",
 							Source: "tables=<-",
 							Start: ast.Position{
 								Column: 11,
-								Line:   242,
+								Line:   269,
 							},
 						},
 					},
@@ -15841,13 +16887,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 17,
-									Line:   242,
+									Line:   269,
 								},
 								File:   "universe.flux",
 								Source: "tables",
 								Start: ast.Position{
 									Column: 11,
-									Line:   242,
+									Line:   269,
 								},
 							},
 						},
@@ -15858,13 +16904,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 20,
-								Line:   242,
+								Line:   269,
 							},
 							File:   "universe.flux",
 							Source: "<-",
 							Start: ast.Position{
 								Column: 18,
-								Line:   242,
+								Line:   269,
 							},
 						},
 					}},
@@ -15876,13 +16922,13 @@ var pkgAST = &ast.Package{
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
 						Column: 74,
-						Line:   243,
+						Line:   270,
 					},
 					File:   "universe.flux",
 					Source: "toDuration = (tables=<-) => tables |> map(fn:(r) => duration(v:r._value))",
 					Start: ast.Position{
 						Column: 1,
-						Line:   243,
+						Line:   270,
 					},
 				},
 			},
@@ -15892,13 +16938,13 @@ var pkgAST = &ast.Package{
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
 							Column: 11,
-							Line:   243,
+							Line:   270,
 						},
 						File:   "universe.flux",
 						Source: "toDuration",
 						Start: ast.Position{
 							Column: 1,
-							Line:   243,
+							Line:   270,
 						},
 					},
 				},
@@ -15910,13 +16956,13 @@ var pkgAST = &ast.Package{
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
 							Column: 74,
-							Line:   243,
+							Line:   270,
 						},
 						File:   "universe.flux",
 						Source: "(tables=<-) => tables |> map(fn:(r) => duration(v:r._value))",
 						Start: ast.Position{
 							Column: 14,
-							Line:   243,
+							Line:   270,
 						},
 					},
 				},
@@ -15927,13 +16973,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 35,
-									Line:   243,
+									Line:   270,
 								},
 								File:   "universe.flux",
 								Source: "tables",
 								Start: ast.Position{
 									Column: 29,
-									Line:   243,
+									Line:   270,
 								},
 							},
 						},
@@ -15944,13 +16990,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 74,
-								Line:   243,
+								Line:   270,
 							},
 							File:   "universe.flux",
 							Source: "tables |> map(fn:(r) => duration(v:r._value))",
 							Start: ast.Position{
 								Column: 29,
-								Line:   243,
+								Line:   270,
 							},
 						},
 					},
@@ -15961,13 +17007,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 73,
-										Line:   243,
+										Line:   270,
 									},
 									File:   "universe.flux",
 									Source: "fn:(r) => duration(v:r._value)",
 									Start: ast.Position{
 										Column: 43,
-										Line:   243,
+										Line:   270,
 									},
 								},
 							},
@@ -15977,13 +17023,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 73,
-											Line:   243,
+											Line:   270,
 										},
 										File:   "universe.flux",
 										Source: "fn:(r) => duration(v:r._value)",
 										Start: ast.Position{
 											Column: 43,
-											Line:   243,
+											Line:   270,
 										},
 									},
 								},
@@ -15993,13 +17039,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 45,
-												Line:   243,
+												Line:   270,
 											},
 											File:   "universe.flux",
 											Source: "fn",
 											Start: ast.Position{
 												Column: 43,
-												Line:   243,
+												Line:   270,
 											},
 										},
 									},
@@ -16011,13 +17057,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 73,
-												Line:   243,
+												Line:   270,
 											},
 											File:   "universe.flux",
 											Source: "(r) => duration(v:r._value)",
 											Start: ast.Position{
 												Column: 46,
-												Line:   243,
+												Line:   270,
 											},
 										},
 									},
@@ -16028,13 +17074,13 @@ var pkgAST = &ast.Package{
 												Loc: &ast.SourceLocation{
 													End: ast.Position{
 														Column: 72,
-														Line:   243,
+														Line:   270,
 													},
 													File:   "universe.flux",
 													Source: "v:r._value",
 													Start: ast.Position{
 														Column: 62,
-														Line:   243,
+														Line:   270,
 													},
 												},
 											},
@@ -16044,13 +17090,13 @@ var pkgAST = &ast.Package{
 													Loc: &ast.SourceLocation{
 														End: ast.Position{
 															Column: 72,
-															Line:   243,
+															Line:   270,
 														},
 														File:   "universe.flux",
 														Source: "v:r._value",
 														Start: ast.Position{
 															Column: 62,
-															Line:   243,
+															Line:   270,
 														},
 													},
 												},
@@ -16060,13 +17106,13 @@ var pkgAST = &ast.Package{
 														Loc: &ast.SourceLocation{
 															End: ast.Position{
 																Column: 63,
-																Line:   243,
+																Line:   270,
 															},
 															File:   "universe.flux",
 															Source: "v",
 															Start: ast.Position{
 																Column: 62,
-																Line:   243,
+																Line:   270,
 															},
 														},
 													},
@@ -16078,13 +17124,13 @@ var pkgAST = &ast.Package{
 														Loc: &ast.SourceLocation{
 															End: ast.Position{
 																Column: 72,
-																Line:   243,
+																Line:   270,
 															},
 															File:   "universe.flux",
 															Source: "r._value",
 															Start: ast.Position{
 																Column: 64,
-																Line:   243,
+																Line:   270,
 															},
 														},
 													},
@@ -16094,13 +17140,13 @@ var pkgAST = &ast.Package{
 															Loc: &ast.SourceLocation{
 																End: ast.Position{
 																	Column: 65,
-																	Line:   243,
+																	Line:   270,
 																},
 																File:   "universe.flux",
 																Source: "r",
 																Start: ast.Position{
 																	Column: 64,
-																	Line:   243,
+																	Line:   270,
 																},
 															},
 														},
@@ -16112,13 +17158,13 @@ var pkgAST = &ast.Package{
 															Loc: &ast.SourceLocation{
 																End: ast.Position{
 																	Column: 72,
-																	Line:   243,
+																	Line:   270,
 																},
 																File:   "universe.flux",
 																Source: "_value",
 																Start: ast.Position{
 																	Column: 66,
-																	Line:   243,
+																	Line:   270,
 																},
 															},
 														},
@@ -16132,13 +17178,13 @@ var pkgAST = &ast.Package{
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 73,
-													Line:   243,
+													Line:   270,
 												},
 												File:   "universe.flux",
 												Source: "duration(v:r._value)",
 												Start: ast.Position{
 													Column: 53,
-													Line:   243,
+													Line:   270,
 												},
 											},
 										},
@@ -16148,13 +17194,13 @@ var pkgAST = &ast.Package{
 												Loc: &ast.SourceLocation{
 													End: ast.Position{
 														Column: 61,
-														Line:   243,
+														Line:   270,
 													},
 													File:   "universe.flux",
 													Source: "duration",
 													Start: ast.Position{
 														Column: 53,
-														Line:   243,
+														Line:   270,
 													},
 												},
 											},
@@ -16167,13 +17213,13 @@ var pkgAST = &ast.Package{
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 48,
-													Line:   243,
+													Line:   270,
 												},
 												File:   "universe.flux",
 												Source: "r",
 												Start: ast.Position{
 													Column: 47,
-													Line:   243,
+													Line:   270,
 												},
 											},
 										},
@@ -16183,13 +17229,13 @@ var pkgAST = &ast.Package{
 												Loc: &ast.SourceLocation{
 													End: ast.Position{
 														Column: 48,
-														Line:   243,
+														Line:   270,
 													},
 													File:   "universe.flux",
 													Source: "r",
 													Start: ast.Position{
 														Column: 47,
-														Line:   243,
+														Line:   270,
 													},
 												},
 											},
@@ -16205,13 +17251,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 74,
-									Line:   243,
+									Line:   270,
 								},
 								File:   "universe.flux",
 								Source: "map(fn:(r) => duration(v:r._value))",
 								Start: ast.Position{
 									Column: 39,
-									Line:   243,
+									Line:   270,
 								},
 							},
 						},
@@ -16221,13 +17267,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 42,
-										Line:   243,
+										Line:   270,
 									},
 									File:   "universe.flux",
 									Source: "map",
 									Start: ast.Position{
 										Column: 39,
-										Line:   243,
+										Line:   270,
 									},
 								},
 							},
@@ -16241,13 +17287,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 24,
-								Line:   243,
+								Line:   270,
 							},
 							File:   "universe.flux",
 							Source: "tables=<-",
 							Start: ast.Position{
 								Column: 15,
-								Line:   243,
+								Line:   270,
 							},
 						},
 					},
@@ -16257,13 +17303,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 21,
-									Line:   243,
+									Line:   270,
 								},
 								File:   "universe.flux",
 								Source: "tables",
 								Start: ast.Position{
 									Column: 15,
-									Line:   243,
+									Line:   270,
 								},
 							},
 						},
@@ -16274,13 +17320,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 24,
-								Line:   243,
+								Line:   270,
 							},
 							File:   "universe.flux",
 							Source: "<-",
 							Start: ast.Position{
 								Column: 22,
-								Line:   243,
+								Line:   270,
 							},
 						},
 					}},
@@ -16363,3 +17409,25 @@ var pkgAST = &ast.Package{
 	Package: "universe",
 	Path:    "universe",
 }
+var pkgComments = docs.Comments{
+	Package: "",
+	Values: map[string]string{
+		"_highestOrLowest":         "_highestOrLowest is a helper function, which reduces all groups into a single group by specific tags and a reducer function,\nthen it selects the highest or lowest records based on the columns and the _sortLimit function.\nThe default reducer assumes no reducing needs to be performed.",
+		"_sortLimit":               "_sortLimit is a helper function, which sorts and limits a table.",
+		"aggregateWindow":          "AggregateWindow applies an aggregate function to fixed windows of time.\nThe procedure is to window the data, perform an aggregate operation,\nand then undo the windowing to produce an output table for every input table.",
+		"contains":                 "contains function",
+		"exponentialMovingAverage": "exponentialMovingAverage computes the exponential moving average of the last n values of a column.",
+		"highestAverage":           "highestAverage returns the top N records from all groups using the average of each group.",
+		"highestCurrent":           "highestCurrent returns the top N records from all groups using the last value of each group.",
+		"highestMax":               "highestMax returns the top N records from all groups using the maximum of each group.",
+		"increase":                 "Increase returns the total non-negative difference between values in a table.\nA main usage case is tracking changes in counter values which may wrap over time when they hit\na threshold or are reset. In the case of a wrap/reset,\nwe can assume that the absolute delta between two points will be at least their non-negative difference.",
+		"lowestAverage":            "lowestAverage returns the bottom N records from all groups using the average of each group.",
+		"lowestCurrent":            "lowestCurrent returns the bottom N records from all groups using the last value of each group.",
+		"lowestMin":                "lowestMin returns the bottom N records from all groups using the minimum of each group.",
+		"median":                   "median returns the 50th percentile.\nBy default an approximate percentile is computed, this can be disabled by passing exact:true.\nUsing the exact method requires that the entire data set can fit in memory.",
+		"now":                      "now is a function option whose default behaviour is to return the current system time",
+		"stateCount":               "stateCount computes the number of consecutive records in a given state.\nThe state is defined via the function fn. For each consecutive point for\nwhich the expression evaluates as true, the state count will be incremented\nWhen a point evaluates as false, the state count is reset.\n\nThe state count will be added as an additional column to each record. If the\nexpression evaluates as false, the value will be -1. If the expression\ngenerates an error during evaluation, the point is discarded, and does not\naffect the state count.",
+		"stateDuration":            "stateDuration computes the duration of a given state.\nThe state is defined via the function fn. For each consecutive point for\nwhich the expression evaluates as true, the state duration will be\nincremented by the duration between points. When a point evaluates as false,\nthe state duration is reset.\n\nThe state duration will be added as an additional column to each record. If the\nexpression evaluates as false, the value will be -1. If the expression\ngenerates an error during evaluation, the point is discarded, and does not\naffect the state duration.\n\nNote that as the first point in the given state has no previous point, its\nstate duration will be 0.\n\nThe duration is represented as an integer in the units specified.",
+		"top":                      "top sorts a table by columns and keeps only the top n records.",
+	},
+}
@@ -1,15 +1,22 @@
 package universe
 
 import (
+	"context"
+	"encoding/binary"
 	"errors"
 	"fmt"
+	"hash"
+	"math"
 	"sort"
 
+	"github.com/cespare/xxhash"
 	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/arrow"
 	"github.com/influxdata/flux/execute"
 	"github.com/influxdata/flux/interpreter"
 	"github.com/influxdata/flux/plan"
 	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/flux/values"
 )
 
 const GroupKind = "group"
@@ -130,6 +137,11 @@ func (s *GroupProcedureSpec) Copy() plan.ProcedureSpec {
 	return ns
 }
 
+// OutputSchema implements plan.SchemaProcedureSpec, group keeps the columns of its input.
+func (s *GroupProcedureSpec) OutputSchema(predecessors []*plan.Schema) *plan.Schema {
+	return predecessors[0]
+}
+
 func createGroupTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
 	s, ok := spec.(*GroupProcedureSpec)
 	if !ok {
@@ -138,28 +150,90 @@ func createGroupTransformation(id execute.DatasetID, mode execute.AccumulationMo
 	cache := execute.NewTableBuilderCache(a.Allocator())
 	d := execute.NewDataset(id, mode, cache)
 	t := NewGroupTransformation(d, cache, s)
+	t.ctx = a.Context()
 	return t, d, nil
 }
 
 type groupTransformation struct {
+	// ctx is the context of the execution, with which the statistics are reported
+	// once the transformation has finished.
+	ctx   context.Context
 	d     execute.Dataset
 	cache execute.TableBuilderCache
 
 	mode flux.GroupMode
 	keys []string
+
+	// groups is a hash table of the output groups, indexed by the hash of their key values.
+	// It is shared by all input tables so that the group key of a row
+	// is only materialized the first time the group is seen.
+	groups map[uint64][]*outputGroup
+	hash   hash.Hash64
+	buf    []byte
+	// ibuf holds the dictionary indices of the key columns of a row.
+	ibuf  []byte
+	stats GroupStatistics
+}
+
+// GroupStatistics reports the cardinality of the data processed by a group transformation.
+type GroupStatistics struct {
+	// Tables is the number of input tables.
+	Tables int
+	// Rows is the number of rows that were routed to the output groups.
+	Rows int
+	// Groups is the number of distinct output groups.
+	Groups int
+}
+
+// The keys of the metadata that reports the statistics of the group transformations of a query.
+// Every group transformation adds a value to each of them once it has finished.
+const (
+	GroupTablesMetadataKey = "group/tables"
+	GroupRowsMetadataKey   = "group/rows"
+	GroupGroupsMetadataKey = "group/groups"
+)
+
+// Metadata reports the statistics under the keys GroupTablesMetadataKey,
+// GroupRowsMetadataKey and GroupGroupsMetadataKey.
+func (s GroupStatistics) Metadata() flux.Metadata {
+	md := make(flux.Metadata, 3)
+	md.Add(GroupTablesMetadataKey, s.Tables)
+	md.Add(GroupRowsMetadataKey, s.Rows)
+	md.Add(GroupGroupsMetadataKey, s.Groups)
+	return md
+}
+
+// outputGroup is an output group of the hash table.
+type outputGroup struct {
+	key flux.GroupKey
+
+	// colMap maps the columns of the builder of the group to the columns of the
+	// current input table, it is valid for the table with sequence number table.
+	colMap []int
+	table  int
+	// rows are the rows of the current chunk routed to the group.
+	rows []int
 }
 
 func NewGroupTransformation(d execute.Dataset, cache execute.TableBuilderCache, spec *GroupProcedureSpec) *groupTransformation {
 	t := &groupTransformation{
-		d:     d,
-		cache: cache,
-		mode:  spec.GroupMode,
-		keys:  spec.GroupKeys,
+		ctx:    context.Background(),
+		d:      d,
+		cache:  cache,
+		mode:   spec.GroupMode,
+		keys:   spec.GroupKeys,
+		groups: make(map[uint64][]*outputGroup),
+		hash:   xxhash.New(),
 	}
 	sort.Strings(t.keys)
 	return t
 }
 
+// Statistics reports the cardinality of the data processed so far.
+func (t *groupTransformation) Statistics() GroupStatistics {
+	return t.stats
+}
+
 func (t *groupTransformation) RetractTable(id execute.DatasetID, key flux.GroupKey) (err error) {
 	panic("not implemented")
 }
@@ -182,29 +256,328 @@ func (t *groupTransformation) Process(id execute.DatasetID, tbl flux.Table) erro
 			}
 			on[c.Label] = true
 		}
+	case flux.GroupModeExtend:
+		for _, c := range tbl.Key().Cols() {
+			on[c.Label] = true
+		}
+		for _, k := range t.keys {
+			on[k] = true
+		}
 	default:
 		panic("unimplemented group mode")
 	}
 
-	colMap := make([]int, 0, len(tbl.Cols()))
+	t.stats.Tables++
+	seq := t.stats.Tables
+
+	// onIdxs are the indexes of the columns of the new group key, in the order of the table columns.
+	onIdxs := make([]int, 0, len(on))
+	inKey := true
+	for j, c := range cols {
+		if on[c.Label] {
+			onIdxs = append(onIdxs, j)
+			inKey = inKey && tbl.Key().HasCol(c.Label)
+		}
+	}
+
+	var routed []*outputGroup
 	return tbl.Do(func(cr flux.ColReader) error {
-		l := cr.Len()
-		for i := 0; i < l; i++ {
-			key := execute.GroupKeyForRowOn(i, cr, on)
-			builder, _ := t.cache.TableBuilder(key)
+		if cr.Len() == 0 {
+			return nil
+		}
+		if inKey {
+			// All the rows of the table belong to the same group,
+			// its columns are appended as a whole unless some rows disagree with the key of the table.
+			g := t.lookup(cr, 0, onIdxs)
+			if rowsHaveKey(cr, onIdxs, g.key) {
+				builder, err := t.builder(g, tbl, seq)
+				if err != nil {
+					return err
+				}
+				if err := execute.AppendMappedCols(cr, builder, g.colMap); err != nil {
+					return err
+				}
+				t.stats.Rows += cr.Len()
+				return builder.LevelColumns()
+			}
+		}
 
-			colMap, err := execute.AddNewTableCols(tbl, builder, colMap)
+		// Route the rows of the chunk to their group before appending them,
+		// so that the builder of every group is looked up once per chunk.
+		routed = routed[:0]
+		dicts := dictionaries(cr, onIdxs)
+		var byIndices map[string]*outputGroup
+		if dicts != nil {
+			byIndices = make(map[string]*outputGroup)
+		}
+		for i := 0; i < cr.Len(); i++ {
+			var g *outputGroup
+			if dicts != nil {
+				// The rows with the same dictionary indices belong to the same group,
+				// which is looked up once per chunk.
+				t.ibuf = t.ibuf[:0]
+				for _, d := range dicts {
+					idx := int64(-1)
+					if d.IsValid(i) {
+						idx = int64(d.Index(i))
+					}
+					t.ibuf = appendUint64(t.ibuf, uint64(idx))
+				}
+				var ok bool
+				if g, ok = byIndices[string(t.ibuf)]; !ok {
+					g = t.lookup(cr, i, onIdxs)
+					byIndices[string(t.ibuf)] = g
+				}
+			} else {
+				g = t.lookup(cr, i, onIdxs)
+			}
+			if len(g.rows) == 0 {
+				routed = append(routed, g)
+			}
+			g.rows = append(g.rows, i)
+		}
+		for _, g := range routed {
+			builder, err := t.builder(g, tbl, seq)
 			if err != nil {
 				return err
 			}
+			for j, cj := range g.colMap {
+				if err := appendRows(builder, j, cj, cr, g.rows); err != nil {
+					return err
+				}
+			}
+			t.stats.Rows += len(g.rows)
+			g.rows = g.rows[:0]
+		}
+		return nil
+	})
+}
 
-			err = execute.AppendMappedRecordWithNulls(i, cr, builder, colMap)
-			if err != nil {
+// dictionaries returns the dictionary-encoded values of the key columns of cr,
+// or nil if some of the key columns are not dictionary-encoded.
+func dictionaries(cr flux.ColReader, onIdxs []int) []*arrow.Dictionary {
+	if len(onIdxs) == 0 {
+		return nil
+	}
+	dicts := make([]*arrow.Dictionary, len(onIdxs))
+	for k, j := range onIdxs {
+		if cr.Cols()[j].Type != flux.TString {
+			return nil
+		}
+		if dicts[k] = execute.Dictionary(cr, j); dicts[k] == nil {
+			return nil
+		}
+	}
+	return dicts
+}
+
+// lookup returns the output group of row i of cr, creating it if it does not exist yet.
+func (t *groupTransformation) lookup(cr flux.ColReader, i int, onIdxs []int) *outputGroup {
+	h := t.hashRow(cr, i, onIdxs)
+	for _, g := range t.groups[h] {
+		if rowHasKey(cr, i, onIdxs, g.key) {
+			return g
+		}
+	}
+
+	cols := make([]flux.ColMeta, len(onIdxs))
+	vs := make([]values.Value, len(onIdxs))
+	for k, j := range onIdxs {
+		cols[k] = cr.Cols()[j]
+		vs[k] = execute.ValueForRow(cr, i, j)
+	}
+	g := &outputGroup{key: execute.NewGroupKey(cols, vs)}
+	t.groups[h] = append(t.groups[h], g)
+	t.stats.Groups++
+	return g
+}
+
+// builder returns the table builder of the group and makes sure that it has
+// the columns of the table with sequence number seq.
+func (t *groupTransformation) builder(g *outputGroup, tbl flux.Table, seq int) (execute.TableBuilder, error) {
+	builder, _ := t.cache.TableBuilder(g.key)
+	if g.table == seq && len(g.colMap) == len(builder.Cols()) {
+		return builder, nil
+	}
+	colMap, err := execute.AddNewTableCols(tbl, builder, g.colMap)
+	if err != nil {
+		return nil, err
+	}
+	g.colMap = colMap
+	g.table = seq
+	return builder, nil
+}
+
+// hashRow returns the hash of the labels, types and values of the key columns of row i.
+func (t *groupTransformation) hashRow(cr flux.ColReader, i int, onIdxs []int) uint64 {
+	t.hash.Reset()
+	for _, j := range onIdxs {
+		c := cr.Cols()[j]
+		t.buf = append(t.buf[:0], c.Label...)
+		t.buf = append(t.buf, 0, byte(c.Type))
+		switch c.Type {
+		case flux.TBool:
+			if vs := cr.Bools(j); vs.IsValid(i) {
+				if vs.Value(i) {
+					t.buf = append(t.buf, 1, 1)
+				} else {
+					t.buf = append(t.buf, 1, 0)
+				}
+			} else {
+				t.buf = append(t.buf, 0)
+			}
+		case flux.TInt:
+			if vs := cr.Ints(j); vs.IsValid(i) {
+				t.buf = appendUint64(append(t.buf, 1), uint64(vs.Value(i)))
+			} else {
+				t.buf = append(t.buf, 0)
+			}
+		case flux.TUInt:
+			if vs := cr.UInts(j); vs.IsValid(i) {
+				t.buf = appendUint64(append(t.buf, 1), vs.Value(i))
+			} else {
+				t.buf = append(t.buf, 0)
+			}
+		case flux.TFloat:
+			if vs := cr.Floats(j); vs.IsValid(i) {
+				t.buf = appendUint64(append(t.buf, 1), math.Float64bits(vs.Value(i)))
+			} else {
+				t.buf = append(t.buf, 0)
+			}
+		case flux.TString:
+			if vs := execute.StringColumn(cr, j); vs.IsValid(i) {
+				t.buf = append(append(t.buf, 1), vs.Value(i)...)
+			} else {
+				t.buf = append(t.buf, 0)
+			}
+		case flux.TTime:
+			if vs := cr.Times(j); vs.IsValid(i) {
+				t.buf = appendUint64(append(t.buf, 1), uint64(vs.Value(i)))
+			} else {
+				t.buf = append(t.buf, 0)
+			}
+		default:
+			execute.PanicUnknownType(c.Type)
+		}
+		_, _ = t.hash.Write(t.buf)
+	}
+	return t.hash.Sum64()
+}
+
+func appendUint64(buf []byte, v uint64) []byte {
+	var b [8]byte
+	binary.LittleEndian.PutUint64(b[:], v)
+	return append(buf, b[:]...)
+}
+
+// rowHasKey reports whether the key columns of row i have the same labels, types and values as key.
+// Null values are considered equal, the same way as group keys.
+func rowHasKey(cr flux.ColReader, i int, onIdxs []int, key flux.GroupKey) bool {
+	if len(onIdxs) != len(key.Cols()) {
+		return false
+	}
+	for k, j := range onIdxs {
+		c := cr.Cols()[j]
+		if c != key.Cols()[k] {
+			return false
+		}
+		null := !isValid(cr, i, j)
+		if null || key.IsNull(k) {
+			if null != key.IsNull(k) {
+				return false
+			}
+			continue
+		}
+		var equal bool
+		switch c.Type {
+		case flux.TBool:
+			equal = cr.Bools(j).Value(i) == key.ValueBool(k)
+		case flux.TInt:
+			equal = cr.Ints(j).Value(i) == key.ValueInt(k)
+		case flux.TUInt:
+			equal = cr.UInts(j).Value(i) == key.ValueUInt(k)
+		case flux.TFloat:
+			equal = cr.Floats(j).Value(i) == key.ValueFloat(k)
+		case flux.TString:
+			equal = execute.StringColumn(cr, j).ValueString(i) == key.ValueString(k)
+		case flux.TTime:
+			equal = execute.Time(cr.Times(j).Value(i)) == key.ValueTime(k)
+		}
+		if !equal {
+			return false
+		}
+	}
+	return true
+}
+
+// rowsHaveKey reports whether the key columns of every row of cr have the values of key.
+func rowsHaveKey(cr flux.ColReader, onIdxs []int, key flux.GroupKey) bool {
+	for i := 1; i < cr.Len(); i++ {
+		if !rowHasKey(cr, i, onIdxs, key) {
+			return false
+		}
+	}
+	return true
+}
+
+func isValid(cr flux.ColReader, i, j int) bool {
+	switch cr.Cols()[j].Type {
+	case flux.TBool:
+		return cr.Bools(j).IsValid(i)
+	case flux.TInt:
+		return cr.Ints(j).IsValid(i)
+	case flux.TUInt:
+		return cr.UInts(j).IsValid(i)
+	case flux.TFloat:
+		return cr.Floats(j).IsValid(i)
+	case flux.TString:
+		return execute.StringColumn(cr, j).IsValid(i)
+	case flux.TTime:
+		return cr.Times(j).IsValid(i)
+	default:
+		execute.PanicUnknownType(cr.Cols()[j].Type)
+		return false
+	}
+}
+
+// appendRows appends the rows of column cj of cr onto column j of builder.
+// Nulls are appended if cj is negative.
+func appendRows(builder execute.TableBuilder, j, cj int, cr flux.ColReader, rows []int) error {
+	if cj < 0 {
+		for range rows {
+			if err := builder.AppendNil(j); err != nil {
 				return err
 			}
 		}
 		return nil
-	})
+	}
+	for _, i := range rows {
+		if !isValid(cr, i, cj) {
+			if err := builder.AppendNil(j); err != nil {
+				return err
+			}
+			continue
+		}
+		var err error
+		switch cr.Cols()[cj].Type {
+		case flux.TBool:
+			err = builder.AppendBool(j, cr.Bools(cj).Value(i))
+		case flux.TInt:
+			err = builder.AppendInt(j, cr.Ints(cj).Value(i))
+		case flux.TUInt:
+			err = builder.AppendUInt(j, cr.UInts(cj).Value(i))
+		case flux.TFloat:
+			err = builder.AppendFloat(j, cr.Floats(cj).Value(i))
+		case flux.TString:
+			err = builder.AppendString(j, execute.StringColumn(cr, cj).ValueString(i))
+		case flux.TTime:
+			err = builder.AppendTime(j, execute.Time(cr.Times(cj).Value(i)))
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func (t *groupTransformation) UpdateWatermark(id execute.DatasetID, mark execute.Time) error {
@@ -214,6 +587,7 @@ func (t *groupTransformation) UpdateProcessingTime(id execute.DatasetID, pt exec
 	return t.d.UpdateProcessingTime(pt)
 }
 func (t *groupTransformation) Finish(id execute.DatasetID, err error) {
+	execute.ReportMetadata(t.ctx, t.stats.Metadata())
 	t.d.Finish(err)
 }
 
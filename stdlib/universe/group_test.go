@@ -306,6 +306,80 @@ func TestGroup_Process(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "extend",
+			spec: &universe.GroupProcedureSpec{
+				GroupMode: flux.GroupModeExtend,
+				GroupKeys: []string{"t2", "missing"},
+			},
+			data: []flux.Table{
+				&executetest.Table{
+					KeyCols: []string{"t1"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_value", Type: flux.TFloat},
+						{Label: "t1", Type: flux.TString},
+						{Label: "t2", Type: flux.TString},
+					},
+					Data: [][]interface{}{
+						{execute.Time(1), 2.0, "a", "x"},
+						{execute.Time(2), 1.0, "a", "y"},
+						{execute.Time(3), 3.0, "a", "x"},
+					},
+				},
+				&executetest.Table{
+					KeyCols: []string{"t1"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_value", Type: flux.TFloat},
+						{Label: "t1", Type: flux.TString},
+						{Label: "t2", Type: flux.TString},
+					},
+					Data: [][]interface{}{
+						{execute.Time(1), 4.0, "b", "x"},
+					},
+				},
+			},
+			want: []*executetest.Table{
+				{
+					KeyCols: []string{"t1", "t2"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_value", Type: flux.TFloat},
+						{Label: "t1", Type: flux.TString},
+						{Label: "t2", Type: flux.TString},
+					},
+					Data: [][]interface{}{
+						{execute.Time(1), 2.0, "a", "x"},
+						{execute.Time(3), 3.0, "a", "x"},
+					},
+				},
+				{
+					KeyCols: []string{"t1", "t2"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_value", Type: flux.TFloat},
+						{Label: "t1", Type: flux.TString},
+						{Label: "t2", Type: flux.TString},
+					},
+					Data: [][]interface{}{
+						{execute.Time(2), 1.0, "a", "y"},
+					},
+				},
+				{
+					KeyCols: []string{"t1", "t2"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_value", Type: flux.TFloat},
+						{Label: "t1", Type: flux.TString},
+						{Label: "t2", Type: flux.TString},
+					},
+					Data: [][]interface{}{
+						{execute.Time(1), 4.0, "b", "x"},
+					},
+				},
+			},
+		},
 		{
 			name: "fan in ignoring",
 			spec: &universe.GroupProcedureSpec{
@@ -751,6 +825,70 @@ func TestGroup_Process(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "fan out across tables",
+			spec: &universe.GroupProcedureSpec{
+				GroupMode: flux.GroupModeBy,
+				GroupKeys: []string{"t2"},
+			},
+			data: []flux.Table{
+				&executetest.Table{
+					KeyCols: []string{"t1"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_value", Type: flux.TFloat},
+						{Label: "t1", Type: flux.TString},
+						{Label: "t2", Type: flux.TString},
+					},
+					Data: [][]interface{}{
+						{execute.Time(1), 1.0, "a", "x"},
+						{execute.Time(2), 2.0, "a", "y"},
+						{execute.Time(3), 3.0, "a", "x"},
+					},
+				},
+				&executetest.Table{
+					KeyCols: []string{"t1"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "t1", Type: flux.TString},
+						{Label: "t2", Type: flux.TString},
+					},
+					Data: [][]interface{}{
+						{execute.Time(4), "b", "y"},
+						{execute.Time(5), "b", "x"},
+					},
+				},
+			},
+			want: []*executetest.Table{
+				{
+					KeyCols: []string{"t2"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_value", Type: flux.TFloat},
+						{Label: "t1", Type: flux.TString},
+						{Label: "t2", Type: flux.TString},
+					},
+					Data: [][]interface{}{
+						{execute.Time(1), 1.0, "a", "x"},
+						{execute.Time(3), 3.0, "a", "x"},
+						{execute.Time(5), nil, "b", "x"},
+					},
+				},
+				{
+					KeyCols: []string{"t2"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_value", Type: flux.TFloat},
+						{Label: "t1", Type: flux.TString},
+						{Label: "t2", Type: flux.TString},
+					},
+					Data: [][]interface{}{
+						{execute.Time(2), 2.0, "a", "y"},
+						{execute.Time(4), nil, "b", "y"},
+					},
+				},
+			},
+		},
 	}
 	for _, tc := range testCases {
 		tc := tc
@@ -768,193 +906,181 @@ func TestGroup_Process(t *testing.T) {
 	}
 }
 
+func TestGroup_Statistics(t *testing.T) {
+	d := executetest.NewDataset(executetest.RandomDatasetID())
+	c := execute.NewTableBuilderCache(executetest.UnlimitedAllocator)
+	c.SetTriggerSpec(execute.DefaultTriggerSpec)
+	g := universe.NewGroupTransformation(d, c, &universe.GroupProcedureSpec{
+		GroupMode: flux.GroupModeBy,
+		GroupKeys: []string{"t2"},
+	})
+
+	for _, t1 := range []string{"a", "b", "c"} {
+		tbl := &executetest.Table{
+			KeyCols: []string{"t1"},
+			ColMeta: []flux.ColMeta{
+				{Label: "_time", Type: flux.TTime},
+				{Label: "t1", Type: flux.TString},
+				{Label: "t2", Type: flux.TString},
+			},
+			Data: [][]interface{}{
+				{execute.Time(1), t1, "x"},
+				{execute.Time(2), t1, "y"},
+			},
+		}
+		if err := g.Process(executetest.RandomDatasetID(), tbl); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want := universe.GroupStatistics{Tables: 3, Rows: 6, Groups: 2}
+	if got := g.Statistics(); got != want {
+		t.Errorf("unexpected statistics: want %+v, got %+v", want, got)
+	}
+}
+
+func TestGroup_Dictionary(t *testing.T) {
+	// Copying the table dictionary-encodes its repeated tags.
+	tbl, err := execute.CopyTable(&executetest.Table{
+		KeyCols: []string{"t1"},
+		ColMeta: []flux.ColMeta{
+			{Label: "_time", Type: flux.TTime},
+			{Label: "t1", Type: flux.TString},
+			{Label: "t2", Type: flux.TString},
+		},
+		Data: [][]interface{}{
+			{execute.Time(1), "a", "x"},
+			{execute.Time(2), "a", "y"},
+			{execute.Time(3), "a", "x"},
+			{execute.Time(4), "a", nil},
+			{execute.Time(5), "a", "y"},
+			{execute.Time(6), "a", "x"},
+		},
+	}, executetest.UnlimitedAllocator)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tbl.Do(func(cr flux.ColReader) error {
+		if execute.Dictionary(cr, 2) == nil {
+			t.Fatal("expected the tag to be dictionary-encoded")
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	cols := []flux.ColMeta{
+		{Label: "_time", Type: flux.TTime},
+		{Label: "t1", Type: flux.TString},
+		{Label: "t2", Type: flux.TString},
+	}
+	executetest.ProcessTestHelper(
+		t,
+		[]flux.Table{tbl},
+		[]*executetest.Table{
+			{
+				KeyCols: []string{"t2"},
+				ColMeta: cols,
+				Data: [][]interface{}{
+					{execute.Time(1), "a", "x"},
+					{execute.Time(3), "a", "x"},
+					{execute.Time(6), "a", "x"},
+				},
+			},
+			{
+				KeyCols: []string{"t2"},
+				ColMeta: cols,
+				Data: [][]interface{}{
+					{execute.Time(2), "a", "y"},
+					{execute.Time(5), "a", "y"},
+				},
+			},
+			{
+				KeyCols: []string{"t2"},
+				ColMeta: cols,
+				Data: [][]interface{}{
+					{execute.Time(4), "a", nil},
+				},
+			},
+		},
+		nil,
+		func(d execute.Dataset, c execute.TableBuilderCache) execute.Transformation {
+			return universe.NewGroupTransformation(d, c, &universe.GroupProcedureSpec{
+				GroupMode: flux.GroupModeBy,
+				GroupKeys: []string{"t2"},
+			})
+		},
+	)
+}
+
 func TestMergeGroupRule(t *testing.T) {
-	var (
-		from      = &influxdb.FromProcedureSpec{}
-		groupNone = &universe.GroupProcedureSpec{
+	specs := plantest.Specs{
+		"from": &influxdb.FromProcedureSpec{},
+		"groupNone": &universe.GroupProcedureSpec{
 			GroupMode: flux.GroupModeBy,
 			GroupKeys: []string{},
-		}
-		groupBy = &universe.GroupProcedureSpec{
+		},
+		"groupBy": &universe.GroupProcedureSpec{
 			GroupMode: flux.GroupModeBy,
 			GroupKeys: []string{"foo", "bar", "buz"},
-		}
-		groupExcept = &universe.GroupProcedureSpec{
+		},
+		"groupExcept": &universe.GroupProcedureSpec{
 			GroupMode: flux.GroupModeExcept,
 			GroupKeys: []string{"foo", "bar", "buz"},
-		}
-		groupNotByNorExcept = &universe.GroupProcedureSpec{
+		},
+		"groupNotByNorExcept": &universe.GroupProcedureSpec{
 			GroupMode: flux.GroupModeNone,
 			GroupKeys: []string{},
-		}
-		filter = &universe.FilterProcedureSpec{}
-	)
+		},
+		"filter": &universe.FilterProcedureSpec{},
+	}
 
 	tests := []plantest.RuleTestCase{
 		{
-			Name:  "single group",
-			Rules: []plan.Rule{&universe.MergeGroupRule{}},
-			Before: &plantest.PlanSpec{
-				Nodes: []plan.PlanNode{
-					plan.CreateLogicalNode("from", from),
-					plan.CreateLogicalNode("group", groupBy),
-				},
-				Edges: [][2]int{
-					{0, 1},
-				},
-			},
+			Name:     "single group",
+			Rules:    []plan.Rule{&universe.MergeGroupRule{}},
+			Before:   plantest.MustParsePlan("from = from -> group = groupBy", specs),
 			NoChange: true,
 		},
 		{
-			Name:  "double group",
-			Rules: []plan.Rule{&universe.MergeGroupRule{}},
-			Before: &plantest.PlanSpec{
-				Nodes: []plan.PlanNode{
-					plan.CreateLogicalNode("from", from),
-					plan.CreateLogicalNode("group0", groupNone),
-					plan.CreateLogicalNode("group1", groupBy),
-				},
-				Edges: [][2]int{
-					{0, 1},
-					{1, 2},
-				},
-			},
-			After: &plantest.PlanSpec{
-				Nodes: []plan.PlanNode{
-					plan.CreateLogicalNode("from", from),
-					plan.CreateLogicalNode("merged_group0_group1", groupBy),
-				},
-				Edges: [][2]int{
-					{0, 1},
-				},
-			},
+			Name:   "double group",
+			Rules:  []plan.Rule{&universe.MergeGroupRule{}},
+			Before: plantest.MustParsePlan("from = from -> group0 = groupNone -> group1 = groupBy", specs),
+			After:  plantest.MustParsePlan("from = from -> merged_group0_group1 = groupBy", specs),
 		},
 		{
-			Name:  "triple group",
-			Rules: []plan.Rule{&universe.MergeGroupRule{}},
-			Before: &plantest.PlanSpec{
-				Nodes: []plan.PlanNode{
-					plan.CreateLogicalNode("from", from),
-					plan.CreateLogicalNode("group0", groupNone),
-					plan.CreateLogicalNode("group1", groupBy),
-					plan.CreateLogicalNode("group2", groupExcept),
-				},
-				Edges: [][2]int{
-					{0, 1},
-					{1, 2},
-					{2, 3},
-				},
-			},
-			After: &plantest.PlanSpec{
-				Nodes: []plan.PlanNode{
-					plan.CreateLogicalNode("from", from),
-					plan.CreateLogicalNode("merged_group0_group1_group2", groupExcept),
-				},
-				Edges: [][2]int{
-					{0, 1},
-				},
-			},
+			Name:   "triple group",
+			Rules:  []plan.Rule{&universe.MergeGroupRule{}},
+			Before: plantest.MustParsePlan("from = from -> group0 = groupNone -> group1 = groupBy -> group2 = groupExcept", specs),
+			After:  plantest.MustParsePlan("from = from -> merged_group0_group1_group2 = groupExcept", specs),
 		},
 		{
-			Name:  "double group not by nor except",
-			Rules: []plan.Rule{&universe.MergeGroupRule{}},
-			Before: &plantest.PlanSpec{
-				Nodes: []plan.PlanNode{
-					plan.CreateLogicalNode("from", from),
-					plan.CreateLogicalNode("group0", groupNone),
-					plan.CreateLogicalNode("group1", groupNotByNorExcept),
-				},
-				Edges: [][2]int{
-					{0, 1},
-					{1, 2},
-				},
-			},
+			Name:     "double group not by nor except",
+			Rules:    []plan.Rule{&universe.MergeGroupRule{}},
+			Before:   plantest.MustParsePlan("from = from -> group0 = groupNone -> group1 = groupNotByNorExcept", specs),
 			NoChange: true,
 		},
 		{
 			// the last group by/except always overrides the group key
-			Name:  "triple group not by nor except",
-			Rules: []plan.Rule{&universe.MergeGroupRule{}},
-			Before: &plantest.PlanSpec{
-				Nodes: []plan.PlanNode{
-					plan.CreateLogicalNode("from", from),
-					plan.CreateLogicalNode("group0", groupNone),
-					plan.CreateLogicalNode("group1", groupNotByNorExcept),
-					plan.CreateLogicalNode("group2", groupExcept),
-				},
-				Edges: [][2]int{
-					{0, 1},
-					{1, 2},
-					{2, 3},
-				},
-			},
-			After: &plantest.PlanSpec{
-				Nodes: []plan.PlanNode{
-					plan.CreateLogicalNode("from", from),
-					plan.CreateLogicalNode("merged_group0_group1_group2", groupExcept),
-				},
-				Edges: [][2]int{
-					{0, 1},
-				},
-			},
+			Name:   "triple group not by nor except",
+			Rules:  []plan.Rule{&universe.MergeGroupRule{}},
+			Before: plantest.MustParsePlan("from = from -> group0 = groupNone -> group1 = groupNotByNorExcept -> group2 = groupExcept", specs),
+			After:  plantest.MustParsePlan("from = from -> merged_group0_group1_group2 = groupExcept", specs),
 		},
 		{
 			Name:  "quad group not by nor except",
 			Rules: []plan.Rule{&universe.MergeGroupRule{}},
-			Before: &plantest.PlanSpec{
-				Nodes: []plan.PlanNode{
-					plan.CreateLogicalNode("from", from),
-					plan.CreateLogicalNode("group0", groupNone),
-					plan.CreateLogicalNode("group1", groupNotByNorExcept),
-					plan.CreateLogicalNode("group2", groupExcept),
-					plan.CreateLogicalNode("group3", groupNotByNorExcept),
-				},
-				Edges: [][2]int{
-					{0, 1},
-					{1, 2},
-					{2, 3},
-					{3, 4},
-				},
-			},
-			After: &plantest.PlanSpec{
-				Nodes: []plan.PlanNode{
-					plan.CreateLogicalNode("from", from),
-					plan.CreateLogicalNode("merged_group0_group1_group2", groupExcept),
-					plan.CreateLogicalNode("group3", groupNotByNorExcept),
-				},
-				Edges: [][2]int{
-					{0, 1},
-					{1, 2},
-				},
-			},
+			Before: plantest.MustParsePlan(`
+				from = from -> group0 = groupNone -> group1 = groupNotByNorExcept
+				group1 -> group2 = groupExcept -> group3 = groupNotByNorExcept
+			`, specs),
+			After: plantest.MustParsePlan("from = from -> merged_group0_group1_group2 = groupExcept -> group3 = groupNotByNorExcept", specs),
 		},
 		{
-			Name:  "from group group filter",
-			Rules: []plan.Rule{universe.MergeGroupRule{}},
-			Before: &plantest.PlanSpec{
-				Nodes: []plan.PlanNode{
-					plan.CreateLogicalNode("from", from),
-					plan.CreateLogicalNode("group0", groupExcept),
-					plan.CreateLogicalNode("group1", groupBy),
-					plan.CreateLogicalNode("filter", filter),
-				},
-				Edges: [][2]int{
-					{0, 1},
-					{1, 2},
-					{2, 3},
-				},
-			},
-			After: &plantest.PlanSpec{
-				Nodes: []plan.PlanNode{
-					plan.CreateLogicalNode("from", from),
-					plan.CreateLogicalNode("merged_group0_group1", groupBy),
-					plan.CreateLogicalNode("filter", filter),
-				},
-				Edges: [][2]int{
-					{0, 1},
-					{1, 2},
-				},
-			},
+			Name:   "from group group filter",
+			Rules:  []plan.Rule{universe.MergeGroupRule{}},
+			Before: plantest.MustParsePlan("from = from -> group0 = groupExcept -> group1 = groupBy -> filter = filter", specs),
+			After:  plantest.MustParsePlan("from = from -> merged_group0_group1 = groupBy -> filter = filter", specs),
 		},
 	}
 
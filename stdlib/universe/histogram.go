@@ -6,6 +6,7 @@ import (
 	"regexp"
 	"sort"
 
+	"github.com/apache/arrow/go/arrow/array"
 	"github.com/influxdata/flux"
 	"github.com/influxdata/flux/execute"
 	"github.com/influxdata/flux/interpreter"
@@ -167,8 +168,9 @@ func (t *histogramTransformation) Process(id execute.DatasetID, tbl flux.Table)
 	if valueIdx < 0 {
 		return fmt.Errorf("column %q is missing", t.spec.Column)
 	}
-	if col := tbl.Cols()[valueIdx]; col.Type != flux.TFloat {
-		return fmt.Errorf("column %q must be a float got %v", t.spec.Column, col.Type)
+	typ := tbl.Cols()[valueIdx].Type
+	if typ != flux.TFloat && typ != flux.TInt && typ != flux.TUInt {
+		return fmt.Errorf("column %q must be a float, an int or a uint got %v", t.spec.Column, typ)
 	}
 
 	err := execute.AddTableKeyCols(tbl.Key(), builder)
@@ -192,7 +194,7 @@ func (t *histogramTransformation) Process(id execute.DatasetID, tbl flux.Table)
 	totalRows := 0.0
 	counts := make([]float64, len(t.spec.Bins))
 	err = tbl.Do(func(cr flux.ColReader) error {
-		vs := cr.Floats(valueIdx)
+		vs := floatValues(cr, valueIdx, typ)
 		totalRows += float64(vs.Len() - vs.NullN())
 		for i := 0; i < vs.Len(); i++ {
 			if vs.IsNull(i) {
@@ -237,6 +239,34 @@ func (t *histogramTransformation) Process(id execute.DatasetID, tbl flux.Table)
 	return nil
 }
 
+// numericValues reads the values of a float, int or uint column as floats.
+type numericValues interface {
+	Len() int
+	NullN() int
+	IsNull(i int) bool
+	Value(i int) float64
+}
+
+type intValues struct{ *array.Int64 }
+
+func (vs intValues) Value(i int) float64 { return float64(vs.Int64.Value(i)) }
+
+type uintValues struct{ *array.Uint64 }
+
+func (vs uintValues) Value(i int) float64 { return float64(vs.Uint64.Value(i)) }
+
+// floatValues returns the values of the column j of a reader, which must be a float, int or uint column.
+func floatValues(cr flux.ColReader, j int, typ flux.ColType) numericValues {
+	switch typ {
+	case flux.TInt:
+		return intValues{cr.Ints(j)}
+	case flux.TUInt:
+		return uintValues{cr.UInts(j)}
+	default:
+		return cr.Floats(j)
+	}
+}
+
 func (t *histogramTransformation) UpdateWatermark(id execute.DatasetID, mark execute.Time) error {
 	return t.d.UpdateWatermark(mark)
 }
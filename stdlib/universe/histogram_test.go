@@ -86,6 +86,45 @@ func TestHistogram_Process(t *testing.T) {
 				},
 			}},
 		},
+		{
+			name: "linear int",
+			spec: &universe.HistogramProcedureSpec{HistogramOpSpec: universe.HistogramOpSpec{
+				Column:           "_value",
+				UpperBoundColumn: "le",
+				CountColumn:      "_value",
+				Bins:             []float64{0, 10, 20},
+			}},
+			data: []flux.Table{&executetest.Table{
+				KeyCols: []string{"_start", "_stop"},
+				ColMeta: []flux.ColMeta{
+					{Label: "_start", Type: flux.TTime},
+					{Label: "_stop", Type: flux.TTime},
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_value", Type: flux.TInt},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), execute.Time(3), execute.Time(1), int64(2)},
+					{execute.Time(1), execute.Time(3), execute.Time(2), int64(12)},
+					{execute.Time(1), execute.Time(3), execute.Time(2), int64(10)},
+					{execute.Time(1), execute.Time(3), execute.Time(2), nil},
+					{execute.Time(1), execute.Time(3), execute.Time(2), int64(-4)},
+				},
+			}},
+			want: []*executetest.Table{{
+				KeyCols: []string{"_start", "_stop"},
+				ColMeta: []flux.ColMeta{
+					{Label: "_start", Type: flux.TTime},
+					{Label: "_stop", Type: flux.TTime},
+					{Label: "le", Type: flux.TFloat},
+					{Label: "_value", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), execute.Time(3), 0.0, 1.0},
+					{execute.Time(1), execute.Time(3), 10.0, 3.0},
+					{execute.Time(1), execute.Time(3), 20.0, 4.0},
+				},
+			}},
+		},
 		{
 			name: "linear+infinity",
 			spec: &universe.HistogramProcedureSpec{HistogramOpSpec: universe.HistogramOpSpec{
@@ -0,0 +1,481 @@
+package universe
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/flux/values"
+	"github.com/pkg/errors"
+)
+
+const HoltWintersKind = "holtWinters"
+
+type HoltWintersOpSpec struct {
+	N           int64         `json:"n"`
+	Seasonality int64         `json:"seasonality"`
+	Interval    flux.Duration `json:"interval"`
+	WithFit     bool          `json:"withFit"`
+	Column      string        `json:"column"`
+	TimeColumn  string        `json:"timeColumn"`
+}
+
+func init() {
+	holtWintersSignature := flux.FunctionSignature(
+		map[string]semantic.PolyType{
+			"n":           semantic.Int,
+			"seasonality": semantic.Int,
+			"interval":    semantic.Duration,
+			"withFit":     semantic.Bool,
+			"column":      semantic.String,
+			"timeColumn":  semantic.String,
+		},
+		[]string{"n", "interval"},
+	)
+
+	flux.RegisterPackageValue("universe", HoltWintersKind, flux.FunctionValue(HoltWintersKind, createHoltWintersOpSpec, holtWintersSignature))
+	flux.RegisterOpSpec(HoltWintersKind, newHoltWintersOp)
+	plan.RegisterProcedureSpec(HoltWintersKind, newHoltWintersProcedure, HoltWintersKind)
+	execute.RegisterTransformation(HoltWintersKind, createHoltWintersTransformation)
+}
+
+func createHoltWintersOpSpec(args flux.Arguments, a *flux.Administration) (flux.OperationSpec, error) {
+	if err := a.AddParentFromArgs(args); err != nil {
+		return nil, err
+	}
+
+	spec := new(HoltWintersOpSpec)
+
+	n, err := args.GetRequiredInt("n")
+	if err != nil {
+		return nil, err
+	}
+	if n <= 0 {
+		return nil, errors.New("n must be greater than zero")
+	}
+	spec.N = n
+
+	interval, err := args.GetRequiredDuration("interval")
+	if err != nil {
+		return nil, err
+	}
+	if !interval.IsPositive() {
+		return nil, errors.New("interval must be greater than zero")
+	}
+	spec.Interval = interval
+
+	if seasonality, ok, err := args.GetInt("seasonality"); err != nil {
+		return nil, err
+	} else if ok {
+		if seasonality < 0 {
+			return nil, errors.New("seasonality must not be negative")
+		}
+		spec.Seasonality = seasonality
+	}
+
+	if withFit, ok, err := args.GetBool("withFit"); err != nil {
+		return nil, err
+	} else if ok {
+		spec.WithFit = withFit
+	}
+
+	if col, ok, err := args.GetString("column"); err != nil {
+		return nil, err
+	} else if ok {
+		spec.Column = col
+	} else {
+		spec.Column = execute.DefaultValueColLabel
+	}
+
+	if timeCol, ok, err := args.GetString("timeColumn"); err != nil {
+		return nil, err
+	} else if ok {
+		spec.TimeColumn = timeCol
+	} else {
+		spec.TimeColumn = execute.DefaultTimeColLabel
+	}
+	return spec, nil
+}
+
+func newHoltWintersOp() flux.OperationSpec {
+	return new(HoltWintersOpSpec)
+}
+
+func (s *HoltWintersOpSpec) Kind() flux.OperationKind {
+	return HoltWintersKind
+}
+
+type HoltWintersProcedureSpec struct {
+	plan.DefaultCost
+	N           int64         `json:"n"`
+	Seasonality int64         `json:"seasonality"`
+	Interval    flux.Duration `json:"interval"`
+	WithFit     bool          `json:"withFit"`
+	Column      string        `json:"column"`
+	TimeColumn  string        `json:"timeColumn"`
+}
+
+func newHoltWintersProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	spec, ok := qs.(*HoltWintersOpSpec)
+	if !ok {
+		return nil, fmt.Errorf("invalid spec type %T", qs)
+	}
+
+	return &HoltWintersProcedureSpec{
+		N:           spec.N,
+		Seasonality: spec.Seasonality,
+		Interval:    spec.Interval,
+		WithFit:     spec.WithFit,
+		Column:      spec.Column,
+		TimeColumn:  spec.TimeColumn,
+	}, nil
+}
+
+func (s *HoltWintersProcedureSpec) Kind() plan.ProcedureKind {
+	return HoltWintersKind
+}
+func (s *HoltWintersProcedureSpec) Copy() plan.ProcedureSpec {
+	ns := new(HoltWintersProcedureSpec)
+	*ns = *s
+	return ns
+}
+
+func createHoltWintersTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	s, ok := spec.(*HoltWintersProcedureSpec)
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid spec type %T", spec)
+	}
+	cache := execute.NewTableBuilderCache(a.Allocator())
+	d := execute.NewDataset(id, mode, cache)
+	t := NewHoltWintersTransformation(d, cache, s)
+	return t, d, nil
+}
+
+type holtWintersTransformation struct {
+	d     execute.Dataset
+	cache execute.TableBuilderCache
+
+	spec HoltWintersProcedureSpec
+}
+
+// NewHoltWintersTransformation creates a transformation that forecasts the values of a column
+// with the Holt-Winters method, the same way as the HOLT_WINTERS function of InfluxQL.
+// The rows of a table are expected to be sorted by time and spaced by the interval of the spec.
+// Every table produces the N forecast values following its last row, preceded by the
+// fitted values of its rows if the spec requests them.
+func NewHoltWintersTransformation(d execute.Dataset, cache execute.TableBuilderCache, spec *HoltWintersProcedureSpec) *holtWintersTransformation {
+	return &holtWintersTransformation{
+		d:     d,
+		cache: cache,
+		spec:  *spec,
+	}
+}
+
+func (t *holtWintersTransformation) RetractTable(id execute.DatasetID, key flux.GroupKey) error {
+	return t.d.RetractTable(key)
+}
+
+func (t *holtWintersTransformation) Process(id execute.DatasetID, tbl flux.Table) error {
+	builder, created := t.cache.TableBuilder(tbl.Key())
+	if !created {
+		return fmt.Errorf("holtWinters found duplicate table with key: %v", tbl.Key())
+	}
+
+	cols := tbl.Cols()
+	timeIdx := execute.ColIdx(t.spec.TimeColumn, cols)
+	if timeIdx < 0 {
+		return fmt.Errorf("no column %q exists", t.spec.TimeColumn)
+	}
+	valueIdx := execute.ColIdx(t.spec.Column, cols)
+	if valueIdx < 0 {
+		return fmt.Errorf("no column %q exists", t.spec.Column)
+	}
+	if tbl.Key().HasCol(t.spec.Column) {
+		return errors.New("cannot forecast columns that are part of the group key")
+	}
+	if typ := cols[valueIdx].Type; typ != flux.TFloat && typ != flux.TInt && typ != flux.TUInt {
+		return fmt.Errorf("cannot perform holtWinters over %v", typ)
+	}
+
+	if err := execute.AddTableKeyCols(tbl.Key(), builder); err != nil {
+		return err
+	}
+	outTimeIdx, err := builder.AddCol(flux.ColMeta{Label: t.spec.TimeColumn, Type: flux.TTime})
+	if err != nil {
+		return err
+	}
+	outValueIdx, err := builder.AddCol(flux.ColMeta{Label: t.spec.Column, Type: flux.TFloat})
+	if err != nil {
+		return err
+	}
+
+	var (
+		times []values.Time
+		ys    []float64
+	)
+	if err := tbl.Do(func(cr flux.ColReader) error {
+		ts := cr.Times(timeIdx)
+		for i := 0; i < cr.Len(); i++ {
+			if ts.IsNull(i) {
+				continue
+			}
+			var v float64
+			switch cols[valueIdx].Type {
+			case flux.TFloat:
+				if vs := cr.Floats(valueIdx); vs.IsValid(i) {
+					v = vs.Value(i)
+				} else {
+					continue
+				}
+			case flux.TInt:
+				if vs := cr.Ints(valueIdx); vs.IsValid(i) {
+					v = float64(vs.Value(i))
+				} else {
+					continue
+				}
+			case flux.TUInt:
+				if vs := cr.UInts(valueIdx); vs.IsValid(i) {
+					v = float64(vs.Value(i))
+				} else {
+					continue
+				}
+			}
+			times = append(times, values.Time(ts.Value(i)))
+			ys = append(ys, v)
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	m := int(t.spec.Seasonality)
+	if len(ys) < 2 || (m > 0 && len(ys) < 2*m) {
+		// There are not enough values to fit the model, the table is left empty.
+		return nil
+	}
+	hw := fitHoltWinters(ys, m)
+
+	appendRow := func(tm values.Time, v float64) error {
+		if err := execute.AppendKeyValues(tbl.Key(), builder); err != nil {
+			return err
+		}
+		if err := builder.AppendTime(outTimeIdx, tm); err != nil {
+			return err
+		}
+		return builder.AppendFloat(outValueIdx, v)
+	}
+	if t.spec.WithFit {
+		for i, v := range hw.fitted {
+			if err := appendRow(times[i], v); err != nil {
+				return err
+			}
+		}
+	}
+	last := times[len(times)-1]
+	for i, v := range hw.forecast(int(t.spec.N)) {
+		tm := last.Add(t.spec.Interval.Mul(int64(i + 1)))
+		if err := appendRow(tm, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *holtWintersTransformation) UpdateWatermark(id execute.DatasetID, mark execute.Time) error {
+	return t.d.UpdateWatermark(mark)
+}
+func (t *holtWintersTransformation) UpdateProcessingTime(id execute.DatasetID, pt execute.Time) error {
+	return t.d.UpdateProcessingTime(pt)
+}
+func (t *holtWintersTransformation) Finish(id execute.DatasetID, err error) {
+	t.d.Finish(err)
+}
+
+// holtWinters is a Holt-Winters model with an additive trend and a multiplicative seasonality,
+// fitted to a series of values.
+type holtWinters struct {
+	m int
+	// level, trend and seasonals are the components of the model after the last value.
+	level, trend float64
+	seasonals    []float64
+	// fitted are the values the model predicts for the values of the series.
+	fitted []float64
+}
+
+// fitHoltWinters fits a model with seasonality m to ys, choosing the smoothing parameters
+// that minimize the sum of squared errors of the fitted values.
+// A zero m fits a model without seasonality.
+func fitHoltWinters(ys []float64, m int) *holtWinters {
+	params := []float64{0.3, 0.1}
+	if m > 0 {
+		params = append(params, 0.1)
+	}
+	best := nelderMead(func(p []float64) float64 {
+		return holtWintersSSE(ys, m, p)
+	}, params)
+	return runHoltWinters(ys, m, best)
+}
+
+func holtWintersSSE(ys []float64, m int, params []float64) float64 {
+	hw := runHoltWinters(ys, m, params)
+	sse := 0.0
+	for i, y := range ys {
+		d := y - hw.fitted[i]
+		sse += d * d
+	}
+	if math.IsNaN(sse) {
+		return math.Inf(1)
+	}
+	return sse
+}
+
+// runHoltWinters runs the smoothing equations over ys with the parameters alpha, beta and,
+// for a seasonal model, gamma, which are constrained to [0, 1].
+func runHoltWinters(ys []float64, m int, params []float64) *holtWinters {
+	alpha, beta, gamma := clamp01(params[0]), clamp01(params[1]), 0.0
+	hw := &holtWinters{m: m, fitted: make([]float64, len(ys))}
+	if m > 0 {
+		gamma = clamp01(params[2])
+		first, second := mean(ys[:m]), mean(ys[m:2*m])
+		hw.level = first
+		hw.trend = (second - first) / float64(m)
+		hw.seasonals = make([]float64, m)
+		for i := range hw.seasonals {
+			hw.seasonals[i] = ys[i] / first
+		}
+	} else {
+		hw.level = ys[0]
+		hw.trend = ys[1] - ys[0]
+	}
+
+	for i, y := range ys {
+		s := 1.0
+		if m > 0 {
+			s = hw.seasonals[i%m]
+		}
+		hw.fitted[i] = (hw.level + hw.trend) * s
+		level := alpha*(y/s) + (1-alpha)*(hw.level+hw.trend)
+		hw.trend = beta*(level-hw.level) + (1-beta)*hw.trend
+		if m > 0 {
+			hw.seasonals[i%m] = gamma*(y/level) + (1-gamma)*s
+		}
+		hw.level = level
+	}
+	// Rotate the seasonals so that the first one applies to the value following the series.
+	if m > 0 {
+		n := len(ys) % m
+		hw.seasonals = append(hw.seasonals[n:], hw.seasonals[:n]...)
+	}
+	return hw
+}
+
+// forecast returns the n values following the series.
+func (hw *holtWinters) forecast(n int) []float64 {
+	vs := make([]float64, n)
+	for h := range vs {
+		s := 1.0
+		if hw.m > 0 {
+			s = hw.seasonals[h%hw.m]
+		}
+		vs[h] = (hw.level + float64(h+1)*hw.trend) * s
+	}
+	return vs
+}
+
+func clamp01(v float64) float64 {
+	return math.Max(0, math.Min(1, v))
+}
+
+func mean(vs []float64) float64 {
+	sum := 0.0
+	for _, v := range vs {
+		sum += v
+	}
+	return sum / float64(len(vs))
+}
+
+const (
+	nelderMeadMaxIterations = 1000
+	nelderMeadTolerance     = 1e-10
+)
+
+// nelderMead minimizes f starting from start with the Nelder-Mead simplex method.
+func nelderMead(f func([]float64) float64, start []float64) []float64 {
+	n := len(start)
+	points := make([][]float64, n+1)
+	scores := make([]float64, n+1)
+	for i := range points {
+		points[i] = append([]float64(nil), start...)
+		if i > 0 {
+			points[i][i-1] += 0.1
+		}
+		scores[i] = f(points[i])
+	}
+
+	// combine returns a + c*(a-b), where a is the centroid of all points but the worst.
+	combine := func(centroid, worst []float64, c float64) []float64 {
+		p := make([]float64, n)
+		for i := range p {
+			p[i] = centroid[i] + c*(centroid[i]-worst[i])
+		}
+		return p
+	}
+
+	for iter := 0; iter < nelderMeadMaxIterations; iter++ {
+		// Order the points from best to worst.
+		for i := 1; i < len(points); i++ {
+			for j := i; j > 0 && scores[j] < scores[j-1]; j-- {
+				points[j], points[j-1] = points[j-1], points[j]
+				scores[j], scores[j-1] = scores[j-1], scores[j]
+			}
+		}
+		if math.Abs(scores[n]-scores[0]) < nelderMeadTolerance {
+			break
+		}
+
+		centroid := make([]float64, n)
+		for _, p := range points[:n] {
+			for i := range centroid {
+				centroid[i] += p[i] / float64(n)
+			}
+		}
+
+		reflected := combine(centroid, points[n], 1)
+		rScore := f(reflected)
+		switch {
+		case rScore < scores[0]:
+			expanded := combine(centroid, points[n], 2)
+			if eScore := f(expanded); eScore < rScore {
+				points[n], scores[n] = expanded, eScore
+			} else {
+				points[n], scores[n] = reflected, rScore
+			}
+		case rScore < scores[n-1]:
+			points[n], scores[n] = reflected, rScore
+		default:
+			contracted := combine(centroid, points[n], -0.5)
+			if cScore := f(contracted); cScore < scores[n] {
+				points[n], scores[n] = contracted, cScore
+				continue
+			}
+			// Shrink the simplex towards the best point.
+			for i := 1; i < len(points); i++ {
+				for j := range points[i] {
+					points[i][j] = points[0][j] + 0.5*(points[i][j]-points[0][j])
+				}
+				scores[i] = f(points[i])
+			}
+		}
+	}
+
+	best := 0
+	for i := range scores {
+		if scores[i] < scores[best] {
+			best = i
+		}
+	}
+	return points[best]
+}
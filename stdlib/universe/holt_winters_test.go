@@ -0,0 +1,159 @@
+package universe_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/flux/querytest"
+	"github.com/influxdata/flux/stdlib/universe"
+	"github.com/influxdata/flux/values"
+)
+
+func TestHoltWintersOperation_Marshaling(t *testing.T) {
+	data := []byte(`{"id":"holtWinters","kind":"holtWinters","spec":{"n":10,"seasonality":4,"interval":"1m","withFit":true,"column":"_value","timeColumn":"_time"}}`)
+	op := &flux.Operation{
+		ID: "holtWinters",
+		Spec: &universe.HoltWintersOpSpec{
+			N:           10,
+			Seasonality: 4,
+			Interval:    values.ConvertDuration(time.Minute),
+			WithFit:     true,
+			Column:      "_value",
+			TimeColumn:  "_time",
+		},
+	}
+	querytest.OperationMarshalingTestHelper(t, data, op)
+}
+
+func TestHoltWinters_PassThrough(t *testing.T) {
+	executetest.TransformationPassThroughTestHelper(t, func(d execute.Dataset, c execute.TableBuilderCache) execute.Transformation {
+		s := universe.NewHoltWintersTransformation(
+			d,
+			c,
+			&universe.HoltWintersProcedureSpec{},
+		)
+		return s
+	})
+}
+
+func TestHoltWinters_Process(t *testing.T) {
+	testCases := []struct {
+		name    string
+		spec    *universe.HoltWintersProcedureSpec
+		data    []flux.Table
+		want    []*executetest.Table
+		wantErr error
+	}{
+		{
+			name: "trend",
+			spec: &universe.HoltWintersProcedureSpec{
+				N:          3,
+				Interval:   values.ConvertDuration(1),
+				Column:     execute.DefaultValueColLabel,
+				TimeColumn: execute.DefaultTimeColLabel,
+			},
+			data: []flux.Table{&executetest.Table{
+				KeyCols: []string{"t1"},
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_value", Type: flux.TFloat},
+					{Label: "t1", Type: flux.TString},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), 2.0, "a"},
+					{execute.Time(2), 4.0, "a"},
+					{execute.Time(3), 6.0, "a"},
+					{execute.Time(4), 8.0, "a"},
+				},
+			}},
+			want: []*executetest.Table{{
+				KeyCols: []string{"t1"},
+				ColMeta: []flux.ColMeta{
+					{Label: "t1", Type: flux.TString},
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_value", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{"a", execute.Time(5), 10.0},
+					{"a", execute.Time(6), 12.0},
+					{"a", execute.Time(7), 14.0},
+				},
+			}},
+		},
+		{
+			name: "seasonal with fit",
+			spec: &universe.HoltWintersProcedureSpec{
+				N:           2,
+				Seasonality: 2,
+				Interval:    values.ConvertDuration(10),
+				WithFit:     true,
+				Column:      execute.DefaultValueColLabel,
+				TimeColumn:  execute.DefaultTimeColLabel,
+			},
+			data: []flux.Table{&executetest.Table{
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_value", Type: flux.TInt},
+				},
+				Data: [][]interface{}{
+					{execute.Time(10), int64(1)},
+					{execute.Time(20), int64(2)},
+					{execute.Time(30), nil},
+					{execute.Time(40), int64(1)},
+					{execute.Time(50), int64(2)},
+				},
+			}},
+			want: []*executetest.Table{{
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_value", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{execute.Time(10), 1.0},
+					{execute.Time(20), 2.0},
+					{execute.Time(40), 1.0},
+					{execute.Time(50), 2.0},
+					{execute.Time(60), 1.0},
+					{execute.Time(70), 2.0},
+				},
+			}},
+		},
+		{
+			name: "string",
+			spec: &universe.HoltWintersProcedureSpec{
+				N:          1,
+				Interval:   values.ConvertDuration(1),
+				Column:     execute.DefaultValueColLabel,
+				TimeColumn: execute.DefaultTimeColLabel,
+			},
+			data: []flux.Table{&executetest.Table{
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_value", Type: flux.TString},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), "a"},
+				},
+			}},
+			wantErr: errors.New("cannot perform holtWinters over string"),
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			executetest.ProcessTestHelper(
+				t,
+				tc.data,
+				tc.want,
+				tc.wantErr,
+				func(d execute.Dataset, c execute.TableBuilderCache) execute.Transformation {
+					return universe.NewHoltWintersTransformation(d, c, tc.spec)
+				},
+			)
+		})
+	}
+}
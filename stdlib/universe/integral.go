@@ -47,7 +47,7 @@ func createIntegralOpSpec(args flux.Arguments, a *flux.Administration) (flux.Ope
 		spec.Unit = unit
 	} else {
 		//Default is 1s
-		spec.Unit = flux.Duration(time.Second)
+		spec.Unit = values.ConvertDuration(time.Second)
 	}
 
 	if timeValue, ok, err := args.GetString("timeColumn"); err != nil {
@@ -61,6 +61,9 @@ func createIntegralOpSpec(args flux.Arguments, a *flux.Administration) (flux.Ope
 	if err := spec.AggregateConfig.ReadArgs(args); err != nil {
 		return nil, err
 	}
+	if spec.NaN != "" {
+		return nil, fmt.Errorf("integral does not support a NaN policy")
+	}
 	return spec, nil
 }
 
@@ -163,7 +166,7 @@ func (t *integralTransformation) Process(id execute.DatasetID, tbl flux.Table) e
 			return fmt.Errorf("cannot perform integral over %v", typ)
 		}
 
-		integrals[idx] = newIntegral(time.Duration(t.spec.Unit))
+		integrals[idx] = newIntegral(t.spec.Unit.Duration())
 		newIdx, err := builder.AddCol(flux.ColMeta{
 			Label: c,
 			Type:  flux.TFloat,
@@ -10,6 +10,7 @@ import (
 	"github.com/influxdata/flux/execute/executetest"
 	"github.com/influxdata/flux/querytest"
 	"github.com/influxdata/flux/stdlib/universe"
+	"github.com/influxdata/flux/values"
 )
 
 func TestIntegralOperation_Marshaling(t *testing.T) {
@@ -17,7 +18,7 @@ func TestIntegralOperation_Marshaling(t *testing.T) {
 	op := &flux.Operation{
 		ID: "integral",
 		Spec: &universe.IntegralOpSpec{
-			Unit: flux.Duration(time.Minute),
+			Unit: values.ConvertDuration(time.Minute),
 		},
 	}
 	querytest.OperationMarshalingTestHelper(t, data, op)
@@ -45,7 +46,7 @@ func TestIntegral_Process(t *testing.T) {
 		{
 			name: "float",
 			spec: &universe.IntegralProcedureSpec{
-				Unit:            1,
+				Unit:            values.ConvertDuration(1),
 				TimeColumn:      execute.DefaultTimeColLabel,
 				AggregateConfig: execute.DefaultAggregateConfig,
 			},
@@ -77,7 +78,7 @@ func TestIntegral_Process(t *testing.T) {
 		{
 			name: "int",
 			spec: &universe.IntegralProcedureSpec{
-				Unit:            1,
+				Unit:            values.ConvertDuration(1),
 				TimeColumn:      execute.DefaultTimeColLabel,
 				AggregateConfig: execute.DefaultAggregateConfig,
 			},
@@ -109,7 +110,7 @@ func TestIntegral_Process(t *testing.T) {
 		{
 			name: "uint",
 			spec: &universe.IntegralProcedureSpec{
-				Unit:            1,
+				Unit:            values.ConvertDuration(1),
 				TimeColumn:      execute.DefaultTimeColLabel,
 				AggregateConfig: execute.DefaultAggregateConfig,
 			},
@@ -141,7 +142,7 @@ func TestIntegral_Process(t *testing.T) {
 		{
 			name: "float with units",
 			spec: &universe.IntegralProcedureSpec{
-				Unit:            flux.Duration(time.Second),
+				Unit:            values.ConvertDuration(time.Second),
 				TimeColumn:      execute.DefaultTimeColLabel,
 				AggregateConfig: execute.DefaultAggregateConfig,
 			},
@@ -173,7 +174,7 @@ func TestIntegral_Process(t *testing.T) {
 		{
 			name: "float with tags",
 			spec: &universe.IntegralProcedureSpec{
-				Unit:            1,
+				Unit:            values.ConvertDuration(1),
 				TimeColumn:      execute.DefaultTimeColLabel,
 				AggregateConfig: execute.DefaultAggregateConfig,
 			},
@@ -206,7 +207,7 @@ func TestIntegral_Process(t *testing.T) {
 		{
 			name: "float with multiple values",
 			spec: &universe.IntegralProcedureSpec{
-				Unit:       1,
+				Unit:       values.ConvertDuration(1),
 				TimeColumn: execute.DefaultTimeColLabel,
 				AggregateConfig: execute.AggregateConfig{
 					Columns: []string{"x", "y"},
@@ -244,7 +245,7 @@ func TestIntegral_Process(t *testing.T) {
 		{
 			name: "float with null timestamps",
 			spec: &universe.IntegralProcedureSpec{
-				Unit:            1,
+				Unit:            values.ConvertDuration(1),
 				TimeColumn:      execute.DefaultTimeColLabel,
 				AggregateConfig: execute.DefaultAggregateConfig,
 			},
@@ -268,7 +269,7 @@ func TestIntegral_Process(t *testing.T) {
 		{
 			name: "float with null values",
 			spec: &universe.IntegralProcedureSpec{
-				Unit:            1,
+				Unit:            values.ConvertDuration(1),
 				TimeColumn:      execute.DefaultTimeColLabel,
 				AggregateConfig: execute.DefaultAggregateConfig,
 			},
@@ -303,7 +304,7 @@ func TestIntegral_Process(t *testing.T) {
 		{
 			name: "float with out-of-order timestamps",
 			spec: &universe.IntegralProcedureSpec{
-				Unit:            1,
+				Unit:            values.ConvertDuration(1),
 				TimeColumn:      execute.DefaultTimeColLabel,
 				AggregateConfig: execute.DefaultAggregateConfig,
 			},
@@ -327,7 +328,7 @@ func TestIntegral_Process(t *testing.T) {
 		{
 			name: "integral over string",
 			spec: &universe.IntegralProcedureSpec{
-				Unit:       1,
+				Unit:       values.ConvertDuration(1),
 				TimeColumn: execute.DefaultTimeColLabel,
 				AggregateConfig: execute.AggregateConfig{
 					Columns: []string{"t"},
@@ -352,7 +353,7 @@ func TestIntegral_Process(t *testing.T) {
 		{
 			name: "float repeated times",
 			spec: &universe.IntegralProcedureSpec{
-				Unit:            1,
+				Unit:            values.ConvertDuration(1),
 				TimeColumn:      execute.DefaultTimeColLabel,
 				AggregateConfig: execute.DefaultAggregateConfig,
 			},
@@ -0,0 +1,461 @@
+package universe
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/interpreter"
+	"github.com/influxdata/flux/memory"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/flux/values"
+)
+
+const IntervalJoinKind = "intervalJoin"
+
+// IntervalJoinOpSpec joins every row of the left stream with the rows of the right stream
+// that have the same values in the on columns and whose interval contains its time.
+type IntervalJoinOpSpec struct {
+	On          []string `json:"on"`
+	TimeColumn  string   `json:"timeColumn"`
+	StartColumn string   `json:"startColumn"`
+	StopColumn  string   `json:"stopColumn"`
+}
+
+func init() {
+	intervalJoinSignature := semantic.FunctionPolySignature{
+		Parameters: map[string]semantic.PolyType{
+			"left":        flux.TableObjectType,
+			"right":       flux.TableObjectType,
+			"on":          semantic.NewArrayPolyType(semantic.String),
+			"timeColumn":  semantic.String,
+			"startColumn": semantic.String,
+			"stopColumn":  semantic.String,
+		},
+		Required: semantic.LabelSet{"left", "right"},
+		Return:   flux.TableObjectType,
+	}
+
+	flux.RegisterPackageValue("universe", IntervalJoinKind, flux.FunctionValue(IntervalJoinKind, createIntervalJoinOpSpec, intervalJoinSignature))
+	flux.RegisterOpSpec(IntervalJoinKind, newIntervalJoinOp)
+	plan.RegisterProcedureSpec(IntervalJoinKind, newIntervalJoinProcedure, IntervalJoinKind)
+	execute.RegisterTransformation(IntervalJoinKind, createIntervalJoinTransformation)
+}
+
+func createIntervalJoinOpSpec(args flux.Arguments, a *flux.Administration) (flux.OperationSpec, error) {
+	for _, name := range []string{"left", "right"} {
+		v, err := args.GetRequiredObject(name)
+		if err != nil {
+			return nil, err
+		}
+		p, ok := v.(*flux.TableObject)
+		if !ok {
+			return nil, fmt.Errorf("argument %s is not a table object: got %T", name, v)
+		}
+		a.AddParent(p)
+	}
+
+	spec := &IntervalJoinOpSpec{
+		On:          []string{},
+		TimeColumn:  execute.DefaultTimeColLabel,
+		StartColumn: execute.DefaultStartColLabel,
+		StopColumn:  execute.DefaultStopColLabel,
+	}
+	if array, ok, err := args.GetArray("on", semantic.String); err != nil {
+		return nil, err
+	} else if ok {
+		spec.On, err = interpreter.ToStringArray(array)
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, param := range []struct {
+		name string
+		col  *string
+	}{
+		{name: "timeColumn", col: &spec.TimeColumn},
+		{name: "startColumn", col: &spec.StartColumn},
+		{name: "stopColumn", col: &spec.StopColumn},
+	} {
+		if col, ok, err := args.GetString(param.name); err != nil {
+			return nil, err
+		} else if ok {
+			*param.col = col
+		}
+	}
+	if spec.StartColumn == spec.StopColumn {
+		return nil, errors.New("the start and stop columns must be different")
+	}
+	return spec, nil
+}
+
+func newIntervalJoinOp() flux.OperationSpec {
+	return new(IntervalJoinOpSpec)
+}
+
+func (s *IntervalJoinOpSpec) Kind() flux.OperationKind {
+	return IntervalJoinKind
+}
+
+type IntervalJoinProcedureSpec struct {
+	plan.DefaultCost
+	On          []string
+	TimeColumn  string
+	StartColumn string
+	StopColumn  string
+}
+
+func newIntervalJoinProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	spec, ok := qs.(*IntervalJoinOpSpec)
+	if !ok {
+		return nil, fmt.Errorf("invalid spec type %T", qs)
+	}
+	return &IntervalJoinProcedureSpec{
+		On:          spec.On,
+		TimeColumn:  spec.TimeColumn,
+		StartColumn: spec.StartColumn,
+		StopColumn:  spec.StopColumn,
+	}, nil
+}
+
+func (s *IntervalJoinProcedureSpec) Kind() plan.ProcedureKind {
+	return IntervalJoinKind
+}
+
+func (s *IntervalJoinProcedureSpec) Copy() plan.ProcedureSpec {
+	ns := new(IntervalJoinProcedureSpec)
+	*ns = *s
+	ns.On = make([]string, len(s.On))
+	copy(ns.On, s.On)
+	return ns
+}
+
+func createIntervalJoinTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	s, ok := spec.(*IntervalJoinProcedureSpec)
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid spec type %T", spec)
+	}
+	cache := execute.NewTableBuilderCache(a.Allocator())
+	d := execute.NewDataset(id, mode, cache)
+	t, err := NewIntervalJoinTransformation(d, cache, s, a.Parents(), a.Allocator())
+	if err != nil {
+		return nil, nil, err
+	}
+	return t, d, nil
+}
+
+// intervalJoinTransformation buffers the tables of the left parent and indexes the intervals of the
+// right parent by the values of their on columns. Once both parents have finished, it emits
+// a row for every pair of a row of the left tables and an interval that contains its time.
+type intervalJoinTransformation struct {
+	mu sync.Mutex
+
+	d     execute.Dataset
+	cache execute.TableBuilderCache
+	alloc *memory.Allocator
+
+	on          []string
+	timeColumn  string
+	startColumn string
+	stopColumn  string
+
+	left, right execute.DatasetID
+	parentState map[execute.DatasetID]*joinParentState
+	done        bool
+
+	// tables are the buffered tables of the left parent.
+	tables []flux.Table
+	// rightCols are the columns of the right parent, other than the on columns.
+	rightCols []flux.ColMeta
+	// intervals are the rows of the right parent indexed by the values of their on columns.
+	intervals map[string][]interval
+}
+
+// interval is a row of the right parent. Its values are indexed like rightCols.
+// A null start or stop leaves the interval unbounded on that side.
+type interval struct {
+	start, stop int64
+	values      []values.Value
+}
+
+func NewIntervalJoinTransformation(d execute.Dataset, cache execute.TableBuilderCache, spec *IntervalJoinProcedureSpec, parents []execute.DatasetID, a *memory.Allocator) (*intervalJoinTransformation, error) {
+	if len(parents) != 2 {
+		return nil, errors.New("intervalJoin must have two different streams as input")
+	}
+	return &intervalJoinTransformation{
+		d:           d,
+		cache:       cache,
+		alloc:       a,
+		on:          spec.On,
+		timeColumn:  spec.TimeColumn,
+		startColumn: spec.StartColumn,
+		stopColumn:  spec.StopColumn,
+		left:        parents[0],
+		right:       parents[1],
+		parentState: map[execute.DatasetID]*joinParentState{
+			parents[0]: {},
+			parents[1]: {},
+		},
+		intervals: make(map[string][]interval),
+	}, nil
+}
+
+func (t *intervalJoinTransformation) RetractTable(id execute.DatasetID, key flux.GroupKey) error {
+	panic("not implemented")
+}
+
+func (t *intervalJoinTransformation) Process(id execute.DatasetID, tbl flux.Table) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if id == t.left {
+		cpy, err := execute.CopyTable(tbl, t.alloc)
+		if err != nil {
+			return err
+		}
+		t.tables = append(t.tables, cpy)
+		return nil
+	}
+
+	cols := tbl.Cols()
+	startIdx, err := t.timeIdx(cols, t.startColumn, "right")
+	if err != nil {
+		return err
+	}
+	stopIdx, err := t.timeIdx(cols, t.stopColumn, "right")
+	if err != nil {
+		return err
+	}
+	onIdxs, err := t.onIdxs(cols, "right")
+	if err != nil {
+		return err
+	}
+
+	// Map the columns of the table to the columns of the right parent.
+	colMap := make([]int, len(cols))
+	for j, c := range cols {
+		colMap[j] = -1
+		if execute.ContainsStr(t.on, c.Label) {
+			continue
+		}
+		k := execute.ColIdx(c.Label, t.rightCols)
+		if k < 0 {
+			k = len(t.rightCols)
+			t.rightCols = append(t.rightCols, c)
+		} else if t.rightCols[k].Type != c.Type {
+			return fmt.Errorf("intervalJoin: column %q of the right stream has both types %v and %v", c.Label, t.rightCols[k].Type, c.Type)
+		}
+		colMap[j] = k
+	}
+	n := len(t.rightCols)
+
+	return tbl.Do(func(cr flux.ColReader) error {
+		starts, stops := cr.Times(startIdx), cr.Times(stopIdx)
+		for i := 0; i < cr.Len(); i++ {
+			key, ok := joinKey(cr, i, onIdxs)
+			if !ok {
+				continue
+			}
+			iv := interval{
+				start:  math.MinInt64,
+				stop:   math.MaxInt64,
+				values: make([]values.Value, n),
+			}
+			if starts.IsValid(i) {
+				iv.start = starts.Value(i)
+			}
+			if stops.IsValid(i) {
+				iv.stop = stops.Value(i)
+			}
+			for j, k := range colMap {
+				if k >= 0 {
+					iv.values[k] = execute.ValueForRow(cr, i, j)
+				}
+			}
+			t.intervals[key] = append(t.intervals[key], iv)
+		}
+		return nil
+	})
+}
+
+// timeIdx returns the index of the time column label in cols.
+func (t *intervalJoinTransformation) timeIdx(cols []flux.ColMeta, label, side string) (int, error) {
+	j := execute.ColIdx(label, cols)
+	if j < 0 {
+		return -1, fmt.Errorf("intervalJoin: %s table has no column %q", side, label)
+	}
+	if typ := cols[j].Type; typ != flux.TTime {
+		return -1, fmt.Errorf("intervalJoin: column %q of the %s table is of type %v, not time", label, side, typ)
+	}
+	return j, nil
+}
+
+// onIdxs returns the indexes of the on columns in cols.
+func (t *intervalJoinTransformation) onIdxs(cols []flux.ColMeta, side string) ([]int, error) {
+	idxs := make([]int, len(t.on))
+	for k, label := range t.on {
+		idxs[k] = execute.ColIdx(label, cols)
+		if idxs[k] < 0 {
+			return nil, fmt.Errorf("intervalJoin: %s table has no column %q", side, label)
+		}
+	}
+	return idxs, nil
+}
+
+// emit appends the rows of the buffered tables joined with their intervals to their builders.
+func (t *intervalJoinTransformation) emit() error {
+	for _, intervals := range t.intervals {
+		sort.SliceStable(intervals, func(i, j int) bool { return intervals[i].start < intervals[j].start })
+	}
+
+	for _, tbl := range t.tables {
+		cols := tbl.Cols()
+		timeIdx, err := t.timeIdx(cols, t.timeColumn, "left")
+		if err != nil {
+			return err
+		}
+		onIdxs, err := t.onIdxs(cols, "left")
+		if err != nil {
+			return err
+		}
+
+		// The builder is only created once a row is matched,
+		// so that no empty tables are produced.
+		var (
+			builder   execute.TableBuilder
+			rightIdxs []int
+		)
+		if err := tbl.Do(func(cr flux.ColReader) error {
+			times := cr.Times(timeIdx)
+			for i := 0; i < cr.Len(); i++ {
+				if times.IsNull(i) {
+					continue
+				}
+				key, ok := joinKey(cr, i, onIdxs)
+				if !ok {
+					continue
+				}
+				ts := times.Value(i)
+				intervals := t.intervals[key]
+				// The intervals that start after ts cannot contain it.
+				n := sort.Search(len(intervals), func(k int) bool { return intervals[k].start > ts })
+				for _, iv := range intervals[:n] {
+					if ts >= iv.stop {
+						continue
+					}
+					if builder == nil {
+						if builder, rightIdxs, err = t.builder(tbl); err != nil {
+							return err
+						}
+					}
+					for j := range cols {
+						if err := builder.AppendValue(j, execute.ValueForRow(cr, i, j)); err != nil {
+							return err
+						}
+					}
+					for k, j := range rightIdxs {
+						v := values.Value(values.NewNull(execute.ConvertToKind(t.rightCols[k].Type)))
+						if k < len(iv.values) && iv.values[k] != nil {
+							v = iv.values[k]
+						}
+						if err := builder.AppendValue(j, v); err != nil {
+							return err
+						}
+					}
+				}
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+	t.tables = nil
+	t.intervals = nil
+	return nil
+}
+
+// builder returns the builder of the output table of tbl, and the indexes of the columns of the
+// right stream in it. The columns of the right stream that are also columns of tbl are suffixed with _right.
+func (t *intervalJoinTransformation) builder(tbl flux.Table) (execute.TableBuilder, []int, error) {
+	builder, created := t.cache.TableBuilder(tbl.Key())
+	if !created {
+		return nil, nil, fmt.Errorf("intervalJoin found duplicate table with key: %v", tbl.Key())
+	}
+	if err := execute.AddTableCols(tbl, builder); err != nil {
+		return nil, nil, err
+	}
+	idxs := make([]int, len(t.rightCols))
+	for k, c := range t.rightCols {
+		if execute.ColIdx(c.Label, tbl.Cols()) >= 0 {
+			c.Label += "_right"
+		}
+		j, err := builder.AddCol(c)
+		if err != nil {
+			return nil, nil, err
+		}
+		idxs[k] = j
+	}
+	return builder, idxs, nil
+}
+
+func (t *intervalJoinTransformation) UpdateWatermark(id execute.DatasetID, mark execute.Time) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.parentState[id].mark = mark
+
+	min := execute.Time(math.MaxInt64)
+	for _, state := range t.parentState {
+		if state.mark < min {
+			min = state.mark
+		}
+	}
+
+	return t.d.UpdateWatermark(min)
+}
+
+func (t *intervalJoinTransformation) UpdateProcessingTime(id execute.DatasetID, pt execute.Time) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.parentState[id].processing = pt
+
+	min := execute.Time(math.MaxInt64)
+	for _, state := range t.parentState {
+		if state.processing < min {
+			min = state.processing
+		}
+	}
+
+	return t.d.UpdateProcessingTime(min)
+}
+
+func (t *intervalJoinTransformation) Finish(id execute.DatasetID, err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.parentState[id].finished = true
+	if t.done {
+		return
+	}
+
+	if err != nil {
+		t.done = true
+		t.d.Finish(err)
+		return
+	}
+
+	for _, state := range t.parentState {
+		if !state.finished {
+			return
+		}
+	}
+
+	t.done = true
+	t.d.Finish(t.emit())
+}
@@ -0,0 +1,140 @@
+package universe_test
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/flux/querytest"
+	"github.com/influxdata/flux/stdlib/universe"
+)
+
+func TestIntervalJoinOperation_Marshaling(t *testing.T) {
+	data := []byte(`{"id":"intervalJoin","kind":"intervalJoin","spec":{"on":["host"],"timeColumn":"_time","startColumn":"start","stopColumn":"stop"}}`)
+	op := &flux.Operation{
+		ID: "intervalJoin",
+		Spec: &universe.IntervalJoinOpSpec{
+			On:          []string{"host"},
+			TimeColumn:  "_time",
+			StartColumn: "start",
+			StopColumn:  "stop",
+		},
+	}
+	querytest.OperationMarshalingTestHelper(t, data, op)
+}
+
+func TestIntervalJoin_Process(t *testing.T) {
+	events := []flux.Table{
+		&executetest.Table{
+			KeyCols: []string{"host"},
+			ColMeta: []flux.ColMeta{
+				{Label: "host", Type: flux.TString},
+				{Label: "_time", Type: flux.TTime},
+				{Label: "_value", Type: flux.TFloat},
+			},
+			Data: [][]interface{}{
+				{"a", execute.Time(1), 1.0},
+				{"a", execute.Time(5), 2.0},
+				{"a", execute.Time(10), 3.0},
+				{"a", execute.Time(20), 4.0},
+				{"a", nil, 5.0},
+			},
+		},
+		&executetest.Table{
+			KeyCols: []string{"host"},
+			ColMeta: []flux.ColMeta{
+				{Label: "host", Type: flux.TString},
+				{Label: "_time", Type: flux.TTime},
+				{Label: "_value", Type: flux.TFloat},
+			},
+			Data: [][]interface{}{
+				{"b", execute.Time(5), 6.0},
+			},
+		},
+	}
+	intervals := []flux.Table{
+		&executetest.Table{
+			ColMeta: []flux.ColMeta{
+				{Label: "host", Type: flux.TString},
+				{Label: "start", Type: flux.TTime},
+				{Label: "stop", Type: flux.TTime},
+				{Label: "reason", Type: flux.TString},
+			},
+			Data: [][]interface{}{
+				{"a", execute.Time(4), execute.Time(10), "maintenance"},
+				{"a", execute.Time(8), execute.Time(12), "deploy"},
+				{"a", execute.Time(15), nil, "outage"},
+				{"c", execute.Time(0), execute.Time(100), "other"},
+			},
+		},
+	}
+	want := []*executetest.Table{
+		{
+			KeyCols: []string{"host"},
+			ColMeta: []flux.ColMeta{
+				{Label: "host", Type: flux.TString},
+				{Label: "_time", Type: flux.TTime},
+				{Label: "_value", Type: flux.TFloat},
+				{Label: "start", Type: flux.TTime},
+				{Label: "stop", Type: flux.TTime},
+				{Label: "reason", Type: flux.TString},
+			},
+			Data: [][]interface{}{
+				{"a", execute.Time(5), 2.0, execute.Time(4), execute.Time(10), "maintenance"},
+				{"a", execute.Time(10), 3.0, execute.Time(8), execute.Time(12), "deploy"},
+				{"a", execute.Time(20), 4.0, execute.Time(15), nil, "outage"},
+			},
+		},
+	}
+
+	spec := &universe.IntervalJoinProcedureSpec{
+		On:          []string{"host"},
+		TimeColumn:  "_time",
+		StartColumn: "start",
+		StopColumn:  "stop",
+	}
+	leftID := executetest.RandomDatasetID()
+	rightID := executetest.RandomDatasetID()
+
+	d := executetest.NewDataset(executetest.RandomDatasetID())
+	c := execute.NewTableBuilderCache(executetest.UnlimitedAllocator)
+	c.SetTriggerSpec(execute.DefaultTriggerSpec)
+	jt, err := universe.NewIntervalJoinTransformation(d, c, spec, []execute.DatasetID{leftID, rightID}, executetest.UnlimitedAllocator)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tbl := range events {
+		if err := jt.Process(leftID, tbl); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, tbl := range intervals {
+		if err := jt.Process(rightID, tbl); err != nil {
+			t.Fatal(err)
+		}
+	}
+	jt.Finish(leftID, nil)
+	jt.Finish(rightID, nil)
+	if d.FinishedErr != nil {
+		t.Fatal(d.FinishedErr)
+	}
+
+	got, err := executetest.TablesFromCache(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	executetest.NormalizeTables(got)
+	executetest.NormalizeTables(want)
+
+	sort.Sort(executetest.SortedTables(got))
+	sort.Sort(executetest.SortedTables(want))
+
+	if !cmp.Equal(want, got) {
+		t.Errorf("unexpected tables -want/+got\n%s", cmp.Diff(want, got))
+	}
+}
@@ -3,7 +3,9 @@ package universe
 import (
 	"fmt"
 	"math"
+	"reflect"
 	"sort"
+	"strings"
 	"sync"
 
 	"github.com/influxdata/flux"
@@ -22,9 +24,11 @@ const MergeJoinKind = "merge-join"
 func init() {
 	joinSignature := semantic.FunctionPolySignature{
 		Parameters: map[string]semantic.PolyType{
-			"tables": semantic.NewObjectPolyType(nil, nil, semantic.AllLabels()),
-			"on":     semantic.NewArrayPolyType(semantic.String),
-			"method": semantic.String,
+			"tables":   semantic.NewObjectPolyType(nil, nil, semantic.AllLabels()),
+			"on":       semantic.NewArrayPolyType(semantic.String),
+			"method":   semantic.String,
+			"missing":  semantic.String,
+			"defaults": semantic.NewObjectPolyType(nil, nil, semantic.AllLabels()),
 		},
 		Required: semantic.LabelSet{"tables"},
 		Return:   flux.TableObjectType,
@@ -34,6 +38,7 @@ func init() {
 	//TODO(nathanielc): Allow for other types of join implementations
 	plan.RegisterProcedureSpec(MergeJoinKind, newMergeJoinProcedure, JoinKind)
 	execute.RegisterTransformation(MergeJoinKind, createMergeJoinTransformation)
+	plan.RegisterPhysicalRules(MergeJoinSortedInputsRule{})
 }
 
 // All supported join types in Flux
@@ -46,6 +51,9 @@ type JoinOpSpec struct {
 	TableNames map[flux.OperationID]string `json:"tableNames"`
 	On         []string                    `json:"on"`
 	Method     string                      `json:"method"`
+	Missing    string                      `json:"missing,omitempty"`
+	// Defaults are the values of the missing cells of the columns with the default missing mode.
+	Defaults values.Object `json:"-"`
 
 	// Note: this field below is non-exported and is not part of the public Flux.Spec
 	// interface (used by the transpiler).  It should not be assumed to be populated
@@ -107,6 +115,14 @@ func createJoinOpSpec(args flux.Arguments, a *flux.Administration) (flux.Operati
 		spec.Method = "inner"
 	}
 
+	// Missing specifies what becomes of the rows of a table that have no matching row
+	// in the other table. They are dropped unless specified otherwise.
+	var err error
+	spec.Missing, spec.Defaults, err = getMissing(args, JoinKind, MissingDrop)
+	if err != nil {
+		return nil, err
+	}
+
 	// It is not valid to specify a list of 'on' columns for a cross product
 	if spec.Method == "cross" && spec.On != nil {
 		return nil, errors.New("cross product and 'on' are mutually exclusive")
@@ -169,6 +185,11 @@ type MergeJoinProcedureSpec struct {
 	plan.DefaultCost
 	TableNames []string `json:"table_names"`
 	On         []string `json:"keys"`
+	// SortedBy lists, for every parent, the columns by which the rows of its tables
+	// are known to be sorted, see MergeJoinSortedInputsRule.
+	SortedBy [][]string    `json:"sorted_by,omitempty"`
+	Missing  string        `json:"missing,omitempty"`
+	Defaults values.Object `json:"-"`
 }
 
 func newMergeJoinProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
@@ -195,6 +216,8 @@ func newMergeJoinProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.
 	return &MergeJoinProcedureSpec{
 		On:         on,
 		TableNames: tableNames,
+		Missing:    spec.Missing,
+		Defaults:   spec.Defaults,
 	}, nil
 }
 
@@ -204,12 +227,137 @@ func (s *MergeJoinProcedureSpec) Kind() plan.ProcedureKind {
 func (s *MergeJoinProcedureSpec) Copy() plan.ProcedureSpec {
 	ns := new(MergeJoinProcedureSpec)
 
+	ns.TableNames = make([]string, len(s.TableNames))
+	copy(ns.TableNames, s.TableNames)
+
 	ns.On = make([]string, len(s.On))
 	copy(ns.On, s.On)
 
+	if s.SortedBy != nil {
+		ns.SortedBy = make([][]string, len(s.SortedBy))
+		for i, sorted := range s.SortedBy {
+			ns.SortedBy[i] = append([]string(nil), sorted...)
+		}
+	}
+	ns.Missing = s.Missing
+	ns.Defaults = s.Defaults
+
 	return ns
 }
 
+// ValidateSchema implements plan.SchemaValidator.
+// It fails when a column of the join is known to be missing from a parent,
+// or to have different types in the parents, instead of failing on every table at runtime.
+func (s *MergeJoinProcedureSpec) ValidateSchema(predecessors []*plan.Schema) error {
+	for _, label := range s.On {
+		var (
+			typ       flux.ColType
+			typeKnown bool
+		)
+		for i, schema := range predecessors {
+			if schema == nil {
+				continue
+			}
+			c, ok := schema.Column(label)
+			if !ok {
+				if schema.Open {
+					continue
+				}
+				return fmt.Errorf("join column %q does not exist in table %q; %s", label, s.TableNames[i], s.schemasString(predecessors))
+			}
+			if typeKnown && c.Type != typ {
+				return fmt.Errorf("join column %q has different types in the tables; %s", label, s.schemasString(predecessors))
+			}
+			typ, typeKnown = c.Type, true
+		}
+	}
+	return nil
+}
+
+// OutputSchema implements plan.SchemaProcedureSpec.
+// The columns that the parents share and that are not joined on are suffixed with the name of their table,
+// so the schema is only known when the schemas of both parents are closed.
+func (s *MergeJoinProcedureSpec) OutputSchema(predecessors []*plan.Schema) *plan.Schema {
+	if len(predecessors) != 2 || len(s.TableNames) != 2 {
+		return nil
+	}
+	left, right := predecessors[0], predecessors[1]
+	if left == nil || right == nil || left.Open || right.Open {
+		return nil
+	}
+
+	shared := make(map[string]bool, len(left.Columns))
+	for _, c := range left.Columns {
+		if _, ok := right.Column(c.Label); ok {
+			shared[c.Label] = true
+		}
+	}
+	on := toStringSet(s.On)
+	if len(on) == 0 {
+		on = shared
+	}
+
+	out := &plan.Schema{}
+	added := make(map[string]bool, len(left.Columns)+len(right.Columns))
+	for i, schema := range []*plan.Schema{left, right} {
+		for _, c := range schema.Columns {
+			c.Label = renameColumn(tableCol{table: s.TableNames[i], col: c.Label}, shared, on)
+			if !added[c.Label] {
+				out.Columns = append(out.Columns, c)
+				added[c.Label] = true
+			}
+		}
+	}
+	sort.Slice(out.Columns, func(i, j int) bool {
+		return out.Columns[i].Label < out.Columns[j].Label
+	})
+	return out
+}
+
+// schemasString formats the schemas of the parents of the join for errors.
+func (s *MergeJoinProcedureSpec) schemasString(predecessors []*plan.Schema) string {
+	strs := make([]string, len(predecessors))
+	for i, schema := range predecessors {
+		str := "unknown"
+		if schema != nil {
+			str = schema.String()
+		}
+		strs[i] = fmt.Sprintf("table %q has schema %s", s.TableNames[i], str)
+	}
+	return strings.Join(strs, " and ")
+}
+
+// MergeJoinSortedInputsRule records in a join the order of the rows of its parents,
+// so that it does not sort the tables that are already sorted by the columns of the join.
+type MergeJoinSortedInputsRule struct{}
+
+func (MergeJoinSortedInputsRule) Name() string {
+	return "MergeJoinSortedInputsRule"
+}
+
+func (MergeJoinSortedInputsRule) Pattern() plan.Pattern {
+	return plan.Pat(MergeJoinKind, plan.Any(), plan.Any())
+}
+
+func (MergeJoinSortedInputsRule) Rewrite(node plan.PlanNode) (plan.PlanNode, bool, error) {
+	spec := node.ProcedureSpec().(*MergeJoinProcedureSpec)
+	preds := node.Predecessors()
+	sortedBy := make([][]string, len(preds))
+	known := false
+	for i, pred := range preds {
+		sortedBy[i] = plan.SortedBy(pred)
+		known = known || sortedBy[i] != nil
+	}
+	if !known {
+		sortedBy = nil
+	}
+	if reflect.DeepEqual(spec.SortedBy, sortedBy) {
+		return node, false, nil
+	}
+	spec.SortedBy = sortedBy
+	return node, true, nil
+}
+
 func createMergeJoinTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
 	s, ok := spec.(*MergeJoinProcedureSpec)
 	if !ok {
@@ -227,6 +375,12 @@ func createMergeJoinTransformation(id execute.DatasetID, mode execute.Accumulati
 	}
 
 	cache := NewMergeJoinCache(a.Allocator(), parents, tableNames, s.On)
+	for i, sorted := range s.SortedBy {
+		cache.sortedBy[parents[i]] = sorted
+	}
+	if s.Missing != "" {
+		cache.SetMissing(s.Missing, s.Defaults)
+	}
 	d := execute.NewDataset(id, mode, cache)
 	t := NewMergeJoinTransformation(d, cache, s, parents, tableNames)
 	return t, d, nil
@@ -286,7 +440,9 @@ func (t *mergeJoinTransformation) Process(id execute.DatasetID, tbl flux.Table)
 
 	// Check if enough data sources have been seen to produce an output schema
 	if !t.cache.isBufferEmpty(t.leftID) && !t.cache.isBufferEmpty(t.rightID) && !t.cache.postJoinSchemaBuilt() {
-		t.cache.buildPostJoinSchema()
+		if err := t.cache.buildPostJoinSchema(); err != nil {
+			return err
+		}
 	}
 
 	// Register any new output group keys that can be constructed from the new table
@@ -338,7 +494,7 @@ func (t *mergeJoinTransformation) Finish(id execute.DatasetID, err error) {
 	}
 
 	if finished {
-		t.d.Finish(nil)
+		t.d.Finish(t.cache.registerUnmatched())
 	}
 }
 
@@ -370,10 +526,22 @@ type MergeJoinCache struct {
 	on           map[string]bool
 	intersection map[string]bool
 
+	// sortedBy holds the columns by which the rows of the tables of each parent are known to be sorted.
+	sortedBy map[execute.DatasetID][]string
+
 	schema    schema
 	colIndex  map[flux.ColMeta]int
 	schemaMap map[tableCol]flux.ColMeta
 
+	// missing is the missing mode of the join, with the defaults of the default mode.
+	// missingValues holds the values of the missing cells of the columns of the schema,
+	// unless the rows with missing cells are dropped.
+	missing       string
+	defaults      values.Object
+	missingValues []values.Value
+	// matched holds the keys of the tables that have been paired with a table of the other stream.
+	matched map[flux.GroupKey]bool
+
 	postJoinKeys  *execute.GroupLookup
 	reverseLookup map[flux.GroupKey]preJoinGroupKeys
 
@@ -436,7 +604,7 @@ func (buf *streamBuffer) insert(table flux.Table) {
 }
 
 func (buf *streamBuffer) expire(key flux.GroupKey) {
-	if !buf.stale[key] && len(key.Cols()) > 0 {
+	if key != nil && !buf.stale[key] && len(key.Cols()) > 0 {
 		leftKeyValue := key.Value(0)
 		consumedTables := buf.consumed[leftKeyValue]
 		buf.consumed[leftKeyValue] = consumedTables - 1
@@ -518,6 +686,7 @@ func NewMergeJoinCache(alloc *memory.Allocator, datasetIDs []execute.DatasetID,
 	return &MergeJoinCache{
 		on:            on,
 		intersection:  intersection,
+		sortedBy:      make(map[execute.DatasetID][]string, len(datasetIDs)),
 		leftID:        datasetIDs[0],
 		rightID:       datasetIDs[1],
 		names:         names,
@@ -525,6 +694,8 @@ func NewMergeJoinCache(alloc *memory.Allocator, datasetIDs []execute.DatasetID,
 		buffers:       buffers,
 		reverseLookup: make(map[flux.GroupKey]preJoinGroupKeys),
 		postJoinKeys:  execute.NewGroupLookup(),
+		missing:       MissingDrop,
+		matched:       make(map[flux.GroupKey]bool),
 		tables:        make(map[flux.GroupKey]flux.Table),
 		alloc:         alloc,
 	}
@@ -540,13 +711,14 @@ func (c *MergeJoinCache) Table(key flux.GroupKey) (flux.Table, error) {
 
 	if _, ok := c.tables[key]; !ok {
 
+		// One of the tables is missing if the other table has not been matched.
 		left := c.buffers[c.leftID].table(preJoinGroupKeys.left)
-		if left == nil {
+		if left == nil && preJoinGroupKeys.left != nil {
 			return nil, fmt.Errorf("no table in left join buffer with key: %v", key)
 		}
 
 		right := c.buffers[c.rightID].table(preJoinGroupKeys.right)
-		if left == nil {
+		if right == nil && preJoinGroupKeys.right != nil {
 			return nil, fmt.Errorf("no table in right join buffer with key: %v", key)
 		}
 
@@ -612,12 +784,17 @@ func (c *MergeJoinCache) ForEachWithContext(f func(flux.GroupKey, execute.Trigge
 			c.tables[key] = table
 		}
 
-		leftsize := leftBuilder.NRows()
-		rightsize := rightBuilder.NRows()
+		var count int
+		if leftBuilder != nil {
+			count += leftBuilder.NRows()
+		}
+		if rightBuilder != nil {
+			count += rightBuilder.NRows()
+		}
 
 		ctx := execute.TableContext{
 			Key:   key,
-			Count: leftsize + rightsize,
+			Count: count,
 		}
 
 		f(key, trigger, ctx)
@@ -666,6 +843,12 @@ func (c *MergeJoinCache) SetTriggerSpec(spec flux.TriggerSpec) {
 	c.triggerSpec = spec
 }
 
+// SetMissing sets the missing mode of the join, and the defaults of the default mode.
+// The rows that have no match are dropped unless it is set otherwise.
+func (c *MergeJoinCache) SetMissing(missing string, defaults values.Object) {
+	c.missing, c.defaults = missing, defaults
+}
+
 // Currently tables are the smallest unit of data that can be evicted from the join's internal
 // buffers. This is the rule that specifies whether a data cache can early evict tables.
 func (c *MergeJoinCache) canEvictTables() bool {
@@ -702,10 +885,11 @@ func (c *MergeJoinCache) insertIntoBuffer(id execute.DatasetID, tbl flux.Table)
 
 	// Optimization: if any group key columns overlap join key columns,
 	// and there are any nulls in those columns, we can discard this table,
-	// since null != null for joining purposes.
+	// since null != null for joining purposes, unless its rows are output
+	// when they have no match.
 	k := tbl.Key()
 	for j, col := range k.Cols() {
-		if c.on[col.Label] {
+		if c.on[col.Label] && c.missing == MissingDrop {
 			if k.IsNull(j) {
 				// Discard the table and return.  Note: we need to iterate over the
 				// table at least once:
@@ -752,6 +936,7 @@ func (c *MergeJoinCache) registerKey(id execute.DatasetID, key flux.GroupKey) {
 				left:  key,
 				right: groupKey,
 			}
+			c.matched[key], c.matched[groupKey] = true, true
 		})
 
 	case c.rightID:
@@ -776,6 +961,7 @@ func (c *MergeJoinCache) registerKey(id execute.DatasetID, key flux.GroupKey) {
 				left:  groupKey,
 				right: key,
 			}
+			c.matched[key], c.matched[groupKey] = true, true
 		})
 	}
 }
@@ -788,7 +974,7 @@ func (c *MergeJoinCache) postJoinSchemaBuilt() bool {
 	return c.schemaMap != nil
 }
 
-func (c *MergeJoinCache) buildPostJoinSchema() {
+func (c *MergeJoinCache) buildPostJoinSchema() error {
 	left := c.schemas[c.leftID].columns
 	right := c.schemas[c.rightID].columns
 
@@ -828,6 +1014,79 @@ func (c *MergeJoinCache) buildPostJoinSchema() {
 	for j, column := range c.schema.columns {
 		c.colIndex[column] = j
 	}
+
+	if c.missing == MissingDrop {
+		return nil
+	}
+	c.missingValues = make([]values.Value, len(c.schema.columns))
+	for j, column := range c.schema.columns {
+		v, err := missingValue(c.defaults, column)
+		if err != nil {
+			return fmt.Errorf("%s: %v", JoinKind, err)
+		}
+		c.missingValues[j] = v
+	}
+	return nil
+}
+
+// registerUnmatched registers the output group keys of the tables that have not been paired
+// with a table of the other stream, once both streams have finished, unless their rows are dropped.
+// The table of the other stream is missing from the join of such a table.
+func (c *MergeJoinCache) registerUnmatched() error {
+	if c.missing == MissingDrop || (c.isBufferEmpty(c.leftID) && c.isBufferEmpty(c.rightID)) {
+		return nil
+	}
+	// The schema is not built yet if a stream has no tables.
+	if !c.postJoinSchemaBuilt() {
+		if err := c.buildPostJoinSchema(); err != nil {
+			return err
+		}
+	}
+	var empty struct{}
+	for _, id := range []execute.DatasetID{c.leftID, c.rightID} {
+		other := c.rightID
+		if id == c.rightID {
+			other = c.leftID
+		}
+		c.buffers[id].iterate(func(key flux.GroupKey) {
+			if c.matched[key] {
+				return
+			}
+			outputGroupKey := c.postJoinGroupKey(map[execute.DatasetID]flux.GroupKey{
+				id:    key,
+				other: c.missingGroupKey(other),
+			})
+			c.postJoinKeys.Set(outputGroupKey, empty)
+
+			var keys preJoinGroupKeys
+			if id == c.leftID {
+				keys.left = key
+			} else {
+				keys.right = key
+			}
+			c.reverseLookup[outputGroupKey] = keys
+		})
+	}
+	return nil
+}
+
+// missingGroupKey returns the group key of the missing table of a stream,
+// with the columns of the group key of the tables of the stream that are not joined on.
+// The values of its columns are those of their missing cells.
+func (c *MergeJoinCache) missingGroupKey(id execute.DatasetID) flux.GroupKey {
+	var (
+		cols []flux.ColMeta
+		vals []values.Value
+	)
+	for _, col := range c.schemas[id].key {
+		if c.on[col.Label] {
+			continue
+		}
+		column := c.schemaMap[tableCol{table: c.names[id], col: col.Label}]
+		cols = append(cols, col)
+		vals = append(vals, c.missingValues[c.colIndex[column]])
+	}
+	return execute.NewGroupKey(cols, vals)
 }
 
 // equalJoinKeys compares two keys for equality.
@@ -844,26 +1103,19 @@ func equalJoinkeys(left, right flux.GroupKey) bool {
 }
 
 func (c *MergeJoinCache) join(left, right *execute.ColListTableBuilder) (flux.Table, error) {
-	// Determine sort order for the joining tables
-	on := make([]string, len(c.on))
-
-	for k := range c.on {
-		on = append(on, k)
-	}
-
-	// Sort input tables
-	left.Sort(on, false)
-	right.Sort(on, false)
-
-	var leftSet, rightSet subset
-	var leftKey, rightKey flux.GroupKey
-
-	leftSet, leftKey = c.advance(leftSet.Stop, left)
-	rightSet, rightKey = c.advance(rightSet.Stop, right)
-
-	keys := map[execute.DatasetID]flux.GroupKey{
-		c.leftID:  left.Key(),
-		c.rightID: right.Key(),
+	// Either table is missing if the other has not been matched.
+	keys := make(map[execute.DatasetID]flux.GroupKey, 2)
+	for id, table := range map[execute.DatasetID]*execute.ColListTableBuilder{c.leftID: left, c.rightID: right} {
+		if table == nil {
+			keys[id] = c.missingGroupKey(id)
+			continue
+		}
+		// Sort input tables by the columns of the join in the order in which
+		// their values are compared, unless they are sorted by them already.
+		if on := c.sortColumns(table); !plan.IsSortedBy(c.sortedBy[id], on) {
+			table.Sort(on, false)
+		}
+		keys[id] = table.Key()
 	}
 
 	// Instantiate a builder for the output table
@@ -877,54 +1129,120 @@ func (c *MergeJoinCache) join(left, right *execute.ColListTableBuilder) (flux.Ta
 		}
 	}
 
+	if left == nil || right == nil {
+		for id, table := range map[execute.DatasetID]*execute.ColListTableBuilder{c.leftID: left, c.rightID: right} {
+			if table == nil {
+				continue
+			}
+			if err := c.appendUnmatched(builder, id, table, subset{Start: 0, Stop: table.NRows()}); err != nil {
+				return nil, err
+			}
+		}
+		return builder.Table()
+	}
+
+	var leftSet, rightSet subset
+	var leftKey, rightKey flux.GroupKey
+
+	leftSet, leftKey = c.advance(leftSet.Stop, left)
+	rightSet, rightKey = c.advance(rightSet.Stop, right)
+
 	// Perform sort merge join
 	for !leftSet.Empty() && !rightSet.Empty() {
 		if equalJoinkeys(leftKey, rightKey) {
 
 			for l := leftSet.Start; l < leftSet.Stop; l++ {
 				for r := rightSet.Start; r < rightSet.Stop; r++ {
-
-					leftRecord := left.GetRow(l)
-					rightRecord := right.GetRow(r)
-
-					leftRecord.Range(func(columnName string, columnVal values.Value) {
-						column := tableCol{
-							table: c.names[c.leftID],
-							col:   columnName,
-						}
-						newColumn := c.schemaMap[column]
-						newColumnIdx := c.colIndex[newColumn]
-						_ = builder.AppendValue(newColumnIdx, columnVal)
-					})
-
-					rightRecord.Range(func(columnName string, columnVal values.Value) {
-						column := tableCol{
-							table: c.names[c.rightID],
-							col:   columnName,
-						}
-						newColumn := c.schemaMap[column]
-						newColumnIdx := c.colIndex[newColumn]
-
-						// No need to append value if column is part of the join key.
-						// Because value already appended when iterating over left record.
-						if !c.on[newColumn.Label] {
-							_ = builder.AppendValue(newColumnIdx, columnVal)
-						}
-					})
+					if err := c.appendRow(builder, left.GetRow(l), right.GetRow(r)); err != nil {
+						return nil, err
+					}
 				}
 			}
 			leftSet, leftKey = c.advance(leftSet.Stop, left)
 			rightSet, rightKey = c.advance(rightSet.Stop, right)
 		} else if leftKey.Less(rightKey) {
+			if err := c.appendUnmatched(builder, c.leftID, left, leftSet); err != nil {
+				return nil, err
+			}
 			leftSet, leftKey = c.advance(leftSet.Stop, left)
 		} else {
+			if err := c.appendUnmatched(builder, c.rightID, right, rightSet); err != nil {
+				return nil, err
+			}
 			rightSet, rightKey = c.advance(rightSet.Stop, right)
 		}
 	}
 
+	// The rows that remain in either table have no match.
+	if err := c.appendUnmatched(builder, c.leftID, left, subset{Start: leftSet.Start, Stop: left.NRows()}); err != nil {
+		return nil, err
+	}
+	if err := c.appendUnmatched(builder, c.rightID, right, subset{Start: rightSet.Start, Stop: right.NRows()}); err != nil {
+		return nil, err
+	}
 	return builder.Table()
 }
 
+// appendRow appends the join of a left and a right row to the output table.
+// Either row is nil if the other row has no match. The cells of the output row that neither row has a value for
+// are set to their missing value, or the row is dropped with the drop missing mode.
+func (c *MergeJoinCache) appendRow(builder *execute.ColListTableBuilder, left, right values.Object) error {
+	row := make([]values.Value, len(c.schema.columns))
+	set := func(id execute.DatasetID, record values.Object) {
+		if record == nil {
+			return
+		}
+		record.Range(func(columnName string, columnVal values.Value) {
+			newColumn, ok := c.schemaMap[tableCol{table: c.names[id], col: columnName}]
+			if !ok {
+				// The column is not part of the schema that the first tables of the streams have built.
+				return
+			}
+			// The value of a column of the join key is the same in both rows.
+			if j := c.colIndex[newColumn]; row[j] == nil {
+				row[j] = columnVal
+			}
+		})
+	}
+	set(c.leftID, left)
+	set(c.rightID, right)
+	for j, v := range row {
+		if v != nil {
+			continue
+		}
+		if c.missing == MissingDrop {
+			return nil
+		}
+		row[j] = c.missingValues[j]
+	}
+	for j, v := range row {
+		if err := builder.AppendValue(j, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// appendUnmatched appends the rows of a table that have no match in the other table to the output table,
+// unless the rows with missing cells are dropped.
+func (c *MergeJoinCache) appendUnmatched(builder *execute.ColListTableBuilder, id execute.DatasetID, table *execute.ColListTableBuilder, rows subset) error {
+	if c.missing == MissingDrop {
+		return nil
+	}
+	for i := rows.Start; i < rows.Stop; i++ {
+		var err error
+		if id == c.leftID {
+			err = c.appendRow(builder, table.GetRow(i), nil)
+		} else {
+			err = c.appendRow(builder, nil, table.GetRow(i))
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // postJoinGroupKey produces a new group key value from a left and a right group key value
 func (c *MergeJoinCache) postJoinGroupKey(keys map[execute.DatasetID]flux.GroupKey) flux.GroupKey {
 	key := groupKey{
@@ -959,6 +1277,18 @@ func (c *MergeJoinCache) postJoinGroupKey(keys map[execute.DatasetID]flux.GroupK
 	return execute.NewGroupKey(key.cols, key.vals)
 }
 
+// sortColumns returns the columns of the join in the order of the columns of the table,
+// which is the order of the columns of the keys that advance returns.
+func (c *MergeJoinCache) sortColumns(table *execute.ColListTableBuilder) []string {
+	on := make([]string, 0, len(c.on))
+	for _, col := range table.Cols() {
+		if c.on[col.Label] {
+			on = append(on, col.Label)
+		}
+	}
+	return on
+}
+
 // advance advances the row pointer of a sorted table that is being joined
 func (c *MergeJoinCache) advance(offset int, table *execute.ColListTableBuilder) (subset, flux.GroupKey) {
 	// TODO(jlapacik): this is a temporary hack
@@ -1012,7 +1342,12 @@ func equalRowKeys(x, y int, cr flux.ColReader, on map[string]bool) bool {
 				return false
 			}
 		case flux.TString:
-			if xv, yv := cr.Strings(j).ValueString(x), cr.Strings(j).ValueString(y); xv != yv {
+			// The values of a dictionary-encoded column are equal if their indices are.
+			if d := execute.Dictionary(cr, j); d != nil {
+				if d.IsValid(x) != d.IsValid(y) || d.IsValid(x) && d.Index(x) != d.Index(y) {
+					return false
+				}
+			} else if xv, yv := cr.Strings(j).ValueString(x), cr.Strings(j).ValueString(y); xv != yv {
 				return false
 			}
 		case flux.TTime:
@@ -9,9 +9,12 @@ import (
 	"github.com/influxdata/flux"
 	"github.com/influxdata/flux/execute"
 	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/plan/plantest"
 	"github.com/influxdata/flux/querytest"
 	"github.com/influxdata/flux/stdlib/influxdata/influxdb"
 	"github.com/influxdata/flux/stdlib/universe"
+	"github.com/influxdata/flux/values"
 )
 
 func TestJoin_NewQuery(t *testing.T) {
@@ -72,6 +75,7 @@ func TestJoin_NewQuery(t *testing.T) {
 							On:         []string{"host"},
 							TableNames: map[flux.OperationID]string{"range1": "a", "range3": "b"},
 							Method:     "inner",
+							Missing:    "drop",
 						},
 					},
 				},
@@ -140,6 +144,7 @@ func TestJoin_NewQuery(t *testing.T) {
 							On:         []string{"t1"},
 							TableNames: map[flux.OperationID]string{"range1": "a", "range3": "b"},
 							Method:     "inner",
+							Missing:    "drop",
 						},
 					},
 				},
@@ -151,6 +156,16 @@ func TestJoin_NewQuery(t *testing.T) {
 				},
 			},
 		},
+		{
+			Name:    "missing default without defaults",
+			Raw:     `join(tables: {a: from(bucket: "dbA"), b: from(bucket: "dbB")}, on: ["_time"], missing: "default")`,
+			WantErr: true,
+		},
+		{
+			Name:    "defaults without missing default",
+			Raw:     `join(tables: {a: from(bucket: "dbA"), b: from(bucket: "dbB")}, on: ["_time"], defaults: {_value_a: 0.0})`,
+			WantErr: true,
+		},
 	}
 	for _, tc := range tests {
 		tc := tc
@@ -1562,3 +1577,312 @@ func TestMergeJoin_Process(t *testing.T) {
 		})
 	}
 }
+
+func TestMergeJoin_Missing(t *testing.T) {
+	cols := []flux.ColMeta{
+		{Label: "_time", Type: flux.TTime},
+		{Label: "_value", Type: flux.TFloat},
+		{Label: "host", Type: flux.TString},
+	}
+	left := []*executetest.Table{
+		{
+			KeyCols: []string{"host"},
+			ColMeta: cols,
+			Data: [][]interface{}{
+				{execute.Time(1), 1.0, "a"},
+				{execute.Time(2), 2.0, "a"},
+			},
+		},
+		{
+			KeyCols: []string{"host"},
+			ColMeta: cols,
+			Data: [][]interface{}{
+				{execute.Time(1), 10.0, "b"},
+			},
+		},
+	}
+	right := []*executetest.Table{
+		{
+			KeyCols: []string{"host"},
+			ColMeta: cols,
+			Data: [][]interface{}{
+				{execute.Time(2), 20.0, "a"},
+				{execute.Time(3), 30.0, "a"},
+			},
+		},
+	}
+	joined := func(host string, data ...[]interface{}) *executetest.Table {
+		return &executetest.Table{
+			KeyCols: []string{"host"},
+			ColMeta: []flux.ColMeta{
+				{Label: "_time", Type: flux.TTime},
+				{Label: "_value_a", Type: flux.TFloat},
+				{Label: "_value_b", Type: flux.TFloat},
+				{Label: "host", Type: flux.TString},
+			},
+			Data: data,
+		}
+	}
+	testCases := []struct {
+		name     string
+		missing  string
+		defaults map[string]values.Value
+		want     []*executetest.Table
+		wantErr  string
+	}{
+		{
+			name:    "drop",
+			missing: "drop",
+			want: []*executetest.Table{
+				joined("a", []interface{}{execute.Time(2), 2.0, 20.0, "a"}),
+			},
+		},
+		{
+			name:    "null",
+			missing: "null",
+			want: []*executetest.Table{
+				joined("a",
+					[]interface{}{execute.Time(1), 1.0, nil, "a"},
+					[]interface{}{execute.Time(2), 2.0, 20.0, "a"},
+					[]interface{}{execute.Time(3), nil, 30.0, "a"},
+				),
+				joined("b", []interface{}{execute.Time(1), 10.0, nil, "b"}),
+			},
+		},
+		{
+			name:     "default",
+			missing:  "default",
+			defaults: map[string]values.Value{"_value_b": values.NewFloat(0)},
+			want: []*executetest.Table{
+				joined("a",
+					[]interface{}{execute.Time(1), 1.0, 0.0, "a"},
+					[]interface{}{execute.Time(2), 2.0, 20.0, "a"},
+					[]interface{}{execute.Time(3), nil, 30.0, "a"},
+				),
+				joined("b", []interface{}{execute.Time(1), 10.0, 0.0, "b"}),
+			},
+		},
+		{
+			name:     "default of another type",
+			missing:  "default",
+			defaults: map[string]values.Value{"_value_a": values.NewString("none")},
+			wantErr:  `join: default type mismatch for column "_value_a": float/string`,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			parents := []execute.DatasetID{executetest.RandomDatasetID(), executetest.RandomDatasetID()}
+			tableNames := map[execute.DatasetID]string{parents[0]: "a", parents[1]: "b"}
+			spec := &universe.MergeJoinProcedureSpec{
+				TableNames: []string{"a", "b"},
+				On:         []string{"_time", "host"},
+			}
+
+			d := executetest.NewDataset(executetest.RandomDatasetID())
+			c := universe.NewMergeJoinCache(executetest.UnlimitedAllocator, parents, tableNames, spec.On)
+			c.SetTriggerSpec(execute.DefaultTriggerSpec)
+			var defaults values.Object
+			if tc.defaults != nil {
+				defaults = values.NewObjectWithValues(tc.defaults)
+			}
+			c.SetMissing(tc.missing, defaults)
+			jt := universe.NewMergeJoinTransformation(d, c, spec, parents, tableNames)
+
+			var err error
+			for i, tables := range [][]*executetest.Table{left, right} {
+				for _, tbl := range tables {
+					if err == nil {
+						err = jt.Process(parents[i], tbl)
+					}
+				}
+			}
+			if err == nil {
+				jt.Finish(parents[0], nil)
+				jt.Finish(parents[1], nil)
+				err = d.FinishedErr
+			}
+			if tc.wantErr != "" {
+				if err == nil || err.Error() != tc.wantErr {
+					t.Fatalf("unexpected error: want %q, got %v", tc.wantErr, err)
+				}
+				return
+			} else if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := executetest.TablesFromCache(c)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			executetest.NormalizeTables(got)
+			executetest.NormalizeTables(tc.want)
+
+			sort.Sort(executetest.SortedTables(got))
+			sort.Sort(executetest.SortedTables(tc.want))
+
+			if !cmp.Equal(tc.want, got) {
+				t.Errorf("unexpected tables -want/+got\n%s", cmp.Diff(tc.want, got))
+			}
+		})
+	}
+}
+
+func TestMergeJoinSortedInputsRule(t *testing.T) {
+	specs := plantest.Specs{
+		"from":   &influxdb.FromProcedureSpec{},
+		"byTime": &universe.SortProcedureSpec{Columns: []string{"_time"}},
+		"join": &universe.MergeJoinProcedureSpec{
+			TableNames: []string{"a", "b"},
+			On:         []string{"_time"},
+		},
+		"sortedJoin": &universe.MergeJoinProcedureSpec{
+			TableNames: []string{"a", "b"},
+			On:         []string{"_time"},
+			SortedBy:   [][]string{{"_time"}, nil},
+		},
+	}
+
+	tests := []plantest.RuleTestCase{
+		{
+			Name:  "unknown order",
+			Rules: []plan.Rule{universe.MergeJoinSortedInputsRule{}},
+			Before: plantest.MustParsePlan(`
+				physical
+				from0 = from -> join = join
+				from1 = from -> join
+			`, specs),
+			NoChange: true,
+		},
+		{
+			Name:  "sorted",
+			Rules: []plan.Rule{universe.MergeJoinSortedInputsRule{}},
+			Before: plantest.MustParsePlan(`
+				physical
+				from0 = from -> sort = byTime -> join = join
+				from1 = from -> join
+			`, specs),
+			After: plantest.MustParsePlan(`
+				physical
+				from0 = from -> sort = byTime -> join = sortedJoin
+				from1 = from -> join
+			`, specs),
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			plantest.PhysicalRuleTestHelper(t, &tc)
+		})
+	}
+}
+
+func TestMergeJoin_ValidateSchema(t *testing.T) {
+	var (
+		timeValue = &plan.Schema{
+			Columns: []flux.ColMeta{
+				{Label: "_time", Type: flux.TTime},
+				{Label: "_value", Type: flux.TFloat},
+			},
+		}
+		intTime = &plan.Schema{
+			Columns: []flux.ColMeta{
+				{Label: "_time", Type: flux.TInt},
+				{Label: "_value", Type: flux.TFloat},
+			},
+		}
+		valueOnly = &plan.Schema{
+			Columns: []flux.ColMeta{
+				{Label: "_value", Type: flux.TFloat},
+			},
+		}
+		open = &plan.Schema{Open: true}
+	)
+	testCases := []struct {
+		name    string
+		schemas []*plan.Schema
+		wantErr string
+	}{
+		{
+			name:    "same types",
+			schemas: []*plan.Schema{timeValue, timeValue},
+		},
+		{
+			name:    "unknown schemas",
+			schemas: []*plan.Schema{nil, intTime},
+		},
+		{
+			name:    "open schema",
+			schemas: []*plan.Schema{open, timeValue},
+		},
+		{
+			name:    "missing column",
+			schemas: []*plan.Schema{timeValue, valueOnly},
+			wantErr: `join column "_time" does not exist in table "b"; table "a" has schema [_time: time, _value: float] and table "b" has schema [_value: float]`,
+		},
+		{
+			name:    "different types",
+			schemas: []*plan.Schema{nil, timeValue, intTime},
+			wantErr: `join column "_time" has different types in the tables; table "a" has schema unknown and table "b" has schema [_time: time, _value: float] and table "c" has schema [_time: int, _value: float]`,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			spec := &universe.MergeJoinProcedureSpec{
+				TableNames: []string{"a", "b", "c"}[:len(tc.schemas)],
+				On:         []string{"_time"},
+			}
+			err := spec.ValidateSchema(tc.schemas)
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tc.wantErr {
+				t.Fatalf("unexpected error: want %q, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestMergeJoin_OutputSchema(t *testing.T) {
+	left := &plan.Schema{
+		Columns: []flux.ColMeta{
+			{Label: "_time", Type: flux.TTime},
+			{Label: "_value", Type: flux.TFloat},
+			{Label: "host", Type: flux.TString},
+		},
+	}
+	right := &plan.Schema{
+		Columns: []flux.ColMeta{
+			{Label: "_time", Type: flux.TTime},
+			{Label: "_value", Type: flux.TInt},
+			{Label: "region", Type: flux.TString},
+		},
+	}
+	spec := &universe.MergeJoinProcedureSpec{
+		TableNames: []string{"a", "b"},
+		On:         []string{"_time"},
+	}
+
+	want := &plan.Schema{
+		Columns: []flux.ColMeta{
+			{Label: "_time", Type: flux.TTime},
+			{Label: "_value_a", Type: flux.TFloat},
+			{Label: "_value_b", Type: flux.TInt},
+			{Label: "host", Type: flux.TString},
+			{Label: "region", Type: flux.TString},
+		},
+	}
+	if got := spec.OutputSchema([]*plan.Schema{left, right}); !cmp.Equal(want, got) {
+		t.Errorf("unexpected schema -want/+got:\n%s", cmp.Diff(want, got))
+	}
+	if got := spec.OutputSchema([]*plan.Schema{left, {Open: true}}); got != nil {
+		t.Errorf("expected an unknown schema with an open parent, got %v", got)
+	}
+}
@@ -154,7 +154,7 @@ func (t *keyValuesTransformation) Process(id execute.DatasetID, tbl flux.Table)
 		keyColIndex = execute.ColIdx(t.spec.KeyColumns[i], cols)
 		i++
 	}
-	if keyColIndex < 1 {
+	if keyColIndex < 0 {
 		columnNames := make([]string, len(cols))
 		for i, column := range cols {
 			columnNames[i] = column.Label
@@ -163,6 +163,10 @@ func (t *keyValuesTransformation) Process(id execute.DatasetID, tbl flux.Table)
 	}
 
 	keyColIndices := make([]int, len(t.spec.KeyColumns))
+	for j := 0; j < i-1; j++ {
+		// The key columns before the first one found are missing from the table.
+		keyColIndices[j] = -1
+	}
 	keyColIndices[i-1] = keyColIndex
 	keyColType := cols[keyColIndex].Type
 	for j, v := range t.spec.KeyColumns[i:] {
@@ -195,31 +199,34 @@ func (t *keyValuesTransformation) Process(id execute.DatasetID, tbl flux.Table)
 		return err
 	}
 
+	// Values are distinct per key column, so that each (column, value) pair is reported once.
 	var (
-		nullDistinct   bool
-		boolDistinct   map[bool]bool
-		intDistinct    map[int64]bool
-		uintDistinct   map[uint64]bool
-		floatDistinct  map[float64]bool
-		stringDistinct map[string]bool
-		timeDistinct   map[execute.Time]bool
+		nullDistinct   = make([]bool, len(keyColIndices))
+		boolDistinct   []map[bool]bool
+		intDistinct    []map[int64]bool
+		uintDistinct   []map[uint64]bool
+		floatDistinct  []map[float64]bool
+		stringDistinct []map[string]bool
+		timeDistinct   []map[execute.Time]bool
 	)
 
 	// TODO(adam): implement planner logic that will push down a matching call to distinct() into this call, setting t.distinct to true
 	if t.distinct {
-		switch keyColType {
-		case flux.TBool:
-			boolDistinct = make(map[bool]bool)
-		case flux.TInt:
-			intDistinct = make(map[int64]bool)
-		case flux.TUInt:
-			uintDistinct = make(map[uint64]bool)
-		case flux.TFloat:
-			floatDistinct = make(map[float64]bool)
-		case flux.TString:
-			stringDistinct = make(map[string]bool)
-		case flux.TTime:
-			timeDistinct = make(map[execute.Time]bool)
+		for range keyColIndices {
+			switch keyColType {
+			case flux.TBool:
+				boolDistinct = append(boolDistinct, make(map[bool]bool))
+			case flux.TInt:
+				intDistinct = append(intDistinct, make(map[int64]bool))
+			case flux.TUInt:
+				uintDistinct = append(uintDistinct, make(map[uint64]bool))
+			case flux.TFloat:
+				floatDistinct = append(floatDistinct, make(map[float64]bool))
+			case flux.TString:
+				stringDistinct = append(stringDistinct, make(map[string]bool))
+			case flux.TTime:
+				timeDistinct = append(timeDistinct, make(map[execute.Time]bool))
+			}
 		}
 	}
 
@@ -236,16 +243,16 @@ func (t *keyValuesTransformation) Process(id execute.DatasetID, tbl flux.Table)
 					vs := cr.Bools(rowIdx)
 					if t.distinct {
 						if vs.IsNull(i) {
-							if nullDistinct {
+							if nullDistinct[j] {
 								continue
 							}
-							nullDistinct = true
+							nullDistinct[j] = true
 						} else {
 							v := vs.Value(i)
-							if boolDistinct[v] {
+							if boolDistinct[j][v] {
 								continue
 							}
-							boolDistinct[v] = true
+							boolDistinct[j][v] = true
 						}
 					}
 					if err := builder.AppendString(keyColIdx, t.spec.KeyColumns[j]); err != nil {
@@ -266,16 +273,16 @@ func (t *keyValuesTransformation) Process(id execute.DatasetID, tbl flux.Table)
 					vs := cr.Ints(rowIdx)
 					if t.distinct {
 						if vs.IsNull(i) {
-							if nullDistinct {
+							if nullDistinct[j] {
 								continue
 							}
-							nullDistinct = true
+							nullDistinct[j] = true
 						} else {
 							v := vs.Value(i)
-							if intDistinct[v] {
+							if intDistinct[j][v] {
 								continue
 							}
-							intDistinct[v] = true
+							intDistinct[j][v] = true
 						}
 					}
 					if err := builder.AppendString(keyColIdx, t.spec.KeyColumns[j]); err != nil {
@@ -296,16 +303,16 @@ func (t *keyValuesTransformation) Process(id execute.DatasetID, tbl flux.Table)
 					vs := cr.UInts(rowIdx)
 					if t.distinct {
 						if vs.IsNull(i) {
-							if nullDistinct {
+							if nullDistinct[j] {
 								continue
 							}
-							nullDistinct = true
+							nullDistinct[j] = true
 						} else {
 							v := vs.Value(i)
-							if uintDistinct[v] {
+							if uintDistinct[j][v] {
 								continue
 							}
-							uintDistinct[v] = true
+							uintDistinct[j][v] = true
 						}
 					}
 					if err := builder.AppendString(keyColIdx, t.spec.KeyColumns[j]); err != nil {
@@ -326,16 +333,16 @@ func (t *keyValuesTransformation) Process(id execute.DatasetID, tbl flux.Table)
 					vs := cr.Floats(rowIdx)
 					if t.distinct {
 						if vs.IsNull(i) {
-							if nullDistinct {
+							if nullDistinct[j] {
 								continue
 							}
-							nullDistinct = true
+							nullDistinct[j] = true
 						} else {
 							v := vs.Value(i)
-							if floatDistinct[v] {
+							if floatDistinct[j][v] {
 								continue
 							}
-							floatDistinct[v] = true
+							floatDistinct[j][v] = true
 						}
 					}
 					if err := builder.AppendString(keyColIdx, t.spec.KeyColumns[j]); err != nil {
@@ -355,16 +362,16 @@ func (t *keyValuesTransformation) Process(id execute.DatasetID, tbl flux.Table)
 					vs := cr.Strings(rowIdx)
 					if t.distinct {
 						if vs.IsNull(i) {
-							if nullDistinct {
+							if nullDistinct[j] {
 								continue
 							}
-							nullDistinct = true
+							nullDistinct[j] = true
 						} else {
 							v := vs.ValueString(i)
-							if stringDistinct[v] {
+							if stringDistinct[j][v] {
 								continue
 							}
-							stringDistinct[v] = true
+							stringDistinct[j][v] = true
 						}
 					}
 					if err := builder.AppendString(keyColIdx, t.spec.KeyColumns[j]); err != nil {
@@ -384,16 +391,16 @@ func (t *keyValuesTransformation) Process(id execute.DatasetID, tbl flux.Table)
 					vs := cr.Times(rowIdx)
 					if t.distinct {
 						if vs.IsNull(i) {
-							if nullDistinct {
+							if nullDistinct[j] {
 								continue
 							}
-							nullDistinct = true
+							nullDistinct[j] = true
 						} else {
 							v := execute.Time(vs.Value(i))
-							if timeDistinct[v] {
+							if timeDistinct[j][v] {
 								continue
 							}
-							timeDistinct[v] = true
+							timeDistinct[j][v] = true
 						}
 					}
 					if err := builder.AppendString(keyColIdx, t.spec.KeyColumns[j]); err != nil {
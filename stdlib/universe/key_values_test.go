@@ -244,6 +244,38 @@ func TestKeyValues_Process(t *testing.T) {
 				},
 			}},
 		},
+		{
+			name: "same value in two columns",
+			spec: &universe.KeyValuesProcedureSpec{KeyColumns: []string{"tagX", "tag0", "tag1"}},
+			data: []flux.Table{
+				&executetest.Table{
+					ColMeta: []flux.ColMeta{
+						{Label: "tag0", Type: flux.TString},
+						{Label: "tag1", Type: flux.TString},
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_value", Type: flux.TFloat},
+					},
+					Data: [][]interface{}{
+						{"a", "b", execute.Time(1), 2.0},
+						{"a", "a", execute.Time(2), 2.0},
+						{nil, nil, execute.Time(3), 2.0},
+					},
+				},
+			},
+			want: []*executetest.Table{{
+				ColMeta: []flux.ColMeta{
+					{Label: "_key", Type: flux.TString},
+					{Label: "_value", Type: flux.TString},
+				},
+				Data: [][]interface{}{
+					{"tag0", "a"},
+					{"tag1", "b"},
+					{"tag1", "a"},
+					{"tag0", nil},
+					{"tag1", nil},
+				},
+			}},
+		},
 		{
 			name: "no matching columns",
 			spec: &universe.KeyValuesProcedureSpec{KeyColumns: []string{"tagX", "tagY"}},
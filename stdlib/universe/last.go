@@ -68,6 +68,11 @@ func (s *LastProcedureSpec) Copy() plan.ProcedureSpec {
 	return ns
 }
 
+// SortedBy implements plan.OrderedProcedureSpec, last selects rows in the order of its input.
+func (s *LastProcedureSpec) SortedBy(predecessors [][]string) []string {
+	return predecessors[0]
+}
+
 type LastSelector struct {
 	rows []execute.Row
 }
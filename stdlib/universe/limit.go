@@ -91,6 +91,16 @@ func (s *LimitProcedureSpec) Copy() plan.ProcedureSpec {
 	return ns
 }
 
+// SortedBy implements plan.OrderedProcedureSpec, limit keeps the order of the rows of its input.
+func (s *LimitProcedureSpec) SortedBy(predecessors [][]string) []string {
+	return predecessors[0]
+}
+
+// OutputSchema implements plan.SchemaProcedureSpec, limit keeps the columns of its input.
+func (s *LimitProcedureSpec) OutputSchema(predecessors []*plan.Schema) *plan.Schema {
+	return predecessors[0]
+}
+
 func createLimitTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
 	s, ok := spec.(*LimitProcedureSpec)
 	if !ok {
@@ -2,15 +2,17 @@ package universe
 
 import (
 	"fmt"
-	"log"
 	"sort"
 
 	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/dependencies"
+	fluxerrors "github.com/influxdata/flux/errors"
 	"github.com/influxdata/flux/execute"
 	"github.com/influxdata/flux/interpreter"
 	"github.com/influxdata/flux/plan"
 	"github.com/influxdata/flux/semantic"
 	"github.com/influxdata/flux/values"
+	"go.uber.org/zap"
 )
 
 const MapKind = "map"
@@ -115,6 +117,7 @@ func createMapTransformation(id execute.DatasetID, mode execute.AccumulationMode
 	if err != nil {
 		return nil, nil, err
 	}
+	t.logger = dependencies.Get(a.Context()).Logger
 	return t, d, nil
 }
 
@@ -124,6 +127,12 @@ type mapTransformation struct {
 
 	fn       *execute.RowMapFn
 	mergeKey bool
+
+	// key is the group key of the current row.
+	key execute.ScratchGroupKey
+
+	// logger logs the rows the function fails to evaluate.
+	logger *zap.Logger
 }
 
 func NewMapTransformation(d execute.Dataset, cache execute.TableBuilderCache, spec *MapProcedureSpec) (*mapTransformation, error) {
@@ -136,6 +145,7 @@ func NewMapTransformation(d execute.Dataset, cache execute.TableBuilderCache, sp
 		cache:    cache,
 		fn:       fn,
 		mergeKey: spec.MergeKey,
+		logger:   zap.NewNop(),
 	}, nil
 }
 
@@ -169,12 +179,14 @@ func (t *mapTransformation) Process(id execute.DatasetID, tbl flux.Table) error
 		l := cr.Len()
 		for i := 0; i < l; i++ {
 			m, err := t.fn.Eval(i, cr)
-			if err != nil {
-				log.Printf("failed to evaluate map expression: %v", err)
+			if fluxerrors.CodeOf(err) == fluxerrors.Aborted {
+				return err
+			} else if err != nil {
+				t.logger.Debug("failed to evaluate map expression", zap.Error(err))
 				continue
 			}
-			key := groupKeyForObject(i, cr, m, on)
-			builder, created := t.cache.TableBuilder(key)
+			groupKeyForObject(&t.key, i, cr, m, on)
+			builder, created := t.cache.TableBuilder(&t.key)
 			if created {
 				if t.mergeKey {
 					if err := execute.AddTableKeyCols(tbl.Key(), builder); err != nil {
@@ -203,6 +215,10 @@ func (t *mapTransformation) Process(id execute.DatasetID, tbl flux.Table) error
 						// This should be unreachable
 						return fmt.Errorf("could not find value for column %q", c.Label)
 					}
+				} else if typ := execute.ConvertFromKind(v.Type().Nature()); !v.IsNull() && typ != c.Type {
+					// The table was built for a table whose rows produced another type.
+					return fmt.Errorf("type conflict in map(): column %q is of type %v, but the expression at %v evaluates to %v",
+						c.Label, c.Type, t.fn.Location(c.Label), typ)
 				}
 				if err := builder.AppendValue(j, v); err != nil {
 					return err
@@ -213,22 +229,19 @@ func (t *mapTransformation) Process(id execute.DatasetID, tbl flux.Table) error
 	})
 }
 
-func groupKeyForObject(i int, cr flux.ColReader, obj values.Object, on map[string]bool) flux.GroupKey {
-	cols := make([]flux.ColMeta, 0, len(on))
-	vs := make([]values.Value, 0, len(on))
+// groupKeyForObject rebuilds key as the group key of the object computed for row i.
+func groupKeyForObject(key *execute.ScratchGroupKey, i int, cr flux.ColReader, obj values.Object, on map[string]bool) {
+	key.Reset()
 	for j, c := range cr.Cols() {
 		if !on[c.Label] {
 			continue
 		}
-		cols = append(cols, c)
 		v, ok := obj.Get(c.Label)
-		if ok {
-			vs = append(vs, v)
-		} else {
-			vs = append(vs, execute.ValueForRow(cr, i, j))
+		if !ok {
+			v = execute.ValueForRow(cr, i, j)
 		}
+		key.Append(c, v)
 	}
-	return execute.NewGroupKey(cols, vs)
 }
 
 func (t *mapTransformation) UpdateWatermark(id execute.DatasetID, mark execute.Time) error {
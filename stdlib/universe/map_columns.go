@@ -0,0 +1,253 @@
+package universe
+
+import (
+	"fmt"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/compiler"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/interpreter"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/flux/values"
+	"github.com/pkg/errors"
+)
+
+const MapColumnsKind = "mapColumns"
+
+// MapColumnsOpSpec applies Fn to the values of every column of the given Type.
+type MapColumnsOpSpec struct {
+	Type string                       `json:"type"`
+	Fn   *semantic.FunctionExpression `json:"fn"`
+}
+
+func init() {
+	mapColumnsSignature := flux.FunctionSignature(
+		map[string]semantic.PolyType{
+			"type": semantic.String,
+			"fn": semantic.NewFunctionPolyType(semantic.FunctionPolySignature{
+				Parameters: map[string]semantic.PolyType{
+					"v": semantic.Tvar(1),
+				},
+				Required: semantic.LabelSet{"v"},
+				Return:   semantic.Tvar(2),
+			}),
+		},
+		[]string{"type", "fn"},
+	)
+
+	flux.RegisterPackageValue("universe", MapColumnsKind, flux.FunctionValue(MapColumnsKind, createMapColumnsOpSpec, mapColumnsSignature))
+	flux.RegisterOpSpec(MapColumnsKind, newMapColumnsOp)
+	plan.RegisterProcedureSpec(MapColumnsKind, newMapColumnsProcedure, MapColumnsKind)
+	execute.RegisterTransformation(MapColumnsKind, createMapColumnsTransformation)
+}
+
+func createMapColumnsOpSpec(args flux.Arguments, a *flux.Administration) (flux.OperationSpec, error) {
+	if err := a.AddParentFromArgs(args); err != nil {
+		return nil, err
+	}
+
+	spec := new(MapColumnsOpSpec)
+
+	if typ, err := args.GetRequiredString("type"); err != nil {
+		return nil, err
+	} else if _, err := columnTypeFromString(typ); err != nil {
+		return nil, err
+	} else {
+		spec.Type = typ
+	}
+
+	if f, err := args.GetRequiredFunction("fn"); err != nil {
+		return nil, err
+	} else {
+		fn, err := interpreter.ResolveFunction(f)
+		if err != nil {
+			return nil, err
+		}
+		spec.Fn = fn
+	}
+	return spec, nil
+}
+
+// columnTypeFromString returns the column type with the given name.
+func columnTypeFromString(typ string) (flux.ColType, error) {
+	for t := flux.TBool; t <= flux.TTime; t++ {
+		if t.String() == typ {
+			return t, nil
+		}
+	}
+	return flux.TInvalid, fmt.Errorf("mapColumns error: unsupported column type %q", typ)
+}
+
+func newMapColumnsOp() flux.OperationSpec {
+	return new(MapColumnsOpSpec)
+}
+
+func (s *MapColumnsOpSpec) Kind() flux.OperationKind {
+	return MapColumnsKind
+}
+
+type MapColumnsProcedureSpec struct {
+	plan.DefaultCost
+	Type flux.ColType
+	Fn   *semantic.FunctionExpression
+}
+
+func newMapColumnsProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	spec, ok := qs.(*MapColumnsOpSpec)
+	if !ok {
+		return nil, fmt.Errorf("invalid spec type %T", qs)
+	}
+
+	typ, err := columnTypeFromString(spec.Type)
+	if err != nil {
+		return nil, err
+	}
+	return &MapColumnsProcedureSpec{
+		Type: typ,
+		Fn:   spec.Fn,
+	}, nil
+}
+
+func (s *MapColumnsProcedureSpec) Kind() plan.ProcedureKind {
+	return MapColumnsKind
+}
+func (s *MapColumnsProcedureSpec) Copy() plan.ProcedureSpec {
+	ns := new(MapColumnsProcedureSpec)
+	*ns = *s
+	ns.Fn = s.Fn.Copy().(*semantic.FunctionExpression)
+	return ns
+}
+
+func createMapColumnsTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	s, ok := spec.(*MapColumnsProcedureSpec)
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid spec type %T", spec)
+	}
+	cache := execute.NewTableBuilderCache(a.Allocator())
+	d := execute.NewDataset(id, mode, cache)
+	t, err := NewMapColumnsTransformation(d, cache, s)
+	if err != nil {
+		return nil, nil, err
+	}
+	return t, d, nil
+}
+
+type mapColumnsTransformation struct {
+	d     execute.Dataset
+	cache execute.TableBuilderCache
+
+	typ     flux.ColType
+	outType flux.ColType
+	fn      compiler.Func
+	param   string
+	input   values.Object
+}
+
+func NewMapColumnsTransformation(d execute.Dataset, cache execute.TableBuilderCache, spec *MapColumnsProcedureSpec) (*mapColumnsTransformation, error) {
+	if spec.Fn.Block.Parameters == nil || len(spec.Fn.Block.Parameters.List) != 1 {
+		return nil, errors.New("mapColumns error: function should only have a single parameter")
+	}
+	param := spec.Fn.Block.Parameters.List[0].Key.Name
+
+	fn, err := compiler.Compile(spec.Fn, semantic.NewObjectType(map[string]semantic.Type{
+		param: flux.SemanticType(spec.Type),
+	}), flux.BuiltIns())
+	if err != nil {
+		return nil, errors.Wrap(err, "mapColumns error")
+	}
+	outType := flux.ColumnType(fn.Type())
+	if outType == flux.TInvalid {
+		return nil, fmt.Errorf("mapColumns error: function must return a basic type, got %v", fn.Type())
+	}
+
+	return &mapColumnsTransformation{
+		d:       d,
+		cache:   cache,
+		typ:     spec.Type,
+		outType: outType,
+		fn:      fn,
+		param:   param,
+		input:   values.NewObject(),
+	}, nil
+}
+
+func (t *mapColumnsTransformation) RetractTable(id execute.DatasetID, key flux.GroupKey) error {
+	return t.d.RetractTable(key)
+}
+
+// eval applies the function to a single value, preserving nulls.
+func (t *mapColumnsTransformation) eval(v values.Value) (values.Value, error) {
+	if v.IsNull() {
+		return values.NewNull(flux.SemanticType(t.outType)), nil
+	}
+	t.input.Set(t.param, v)
+	return t.fn.Eval(t.input)
+}
+
+func (t *mapColumnsTransformation) Process(id execute.DatasetID, tbl flux.Table) error {
+	cols := make([]flux.ColMeta, len(tbl.Cols()))
+	mapped := make([]bool, len(cols))
+	for j, c := range tbl.Cols() {
+		cols[j] = c
+		if c.Type == t.typ {
+			cols[j].Type = t.outType
+			mapped[j] = true
+		}
+	}
+
+	keyCols := make([]flux.ColMeta, len(tbl.Key().Cols()))
+	keyValues := make([]values.Value, len(keyCols))
+	for j, c := range tbl.Key().Cols() {
+		keyCols[j] = c
+		keyValues[j] = tbl.Key().Value(j)
+		if c.Type == t.typ {
+			v, err := t.eval(keyValues[j])
+			if err != nil {
+				return errors.Wrap(err, "mapColumns error")
+			}
+			keyCols[j].Type = t.outType
+			keyValues[j] = v
+		}
+	}
+
+	builder, created := t.cache.TableBuilder(execute.NewGroupKey(keyCols, keyValues))
+	if created {
+		for _, c := range cols {
+			if _, err := builder.AddCol(c); err != nil {
+				return err
+			}
+		}
+	}
+
+	return tbl.Do(func(cr flux.ColReader) error {
+		for j := range cols {
+			if !mapped[j] {
+				if err := execute.AppendCol(j, j, cr, builder); err != nil {
+					return err
+				}
+				continue
+			}
+			for i := 0; i < cr.Len(); i++ {
+				v, err := t.eval(execute.ValueForRow(cr, i, j))
+				if err != nil {
+					return errors.Wrap(err, "mapColumns error")
+				}
+				if err := builder.AppendValue(j, v); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
+
+func (t *mapColumnsTransformation) UpdateWatermark(id execute.DatasetID, mark execute.Time) error {
+	return t.d.UpdateWatermark(mark)
+}
+func (t *mapColumnsTransformation) UpdateProcessingTime(id execute.DatasetID, pt execute.Time) error {
+	return t.d.UpdateProcessingTime(pt)
+}
+func (t *mapColumnsTransformation) Finish(id execute.DatasetID, err error) {
+	t.d.Finish(err)
+}
@@ -0,0 +1,199 @@
+package universe_test
+
+import (
+	"testing"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/ast"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/flux/querytest"
+	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/flux/stdlib/influxdata/influxdb"
+	"github.com/influxdata/flux/stdlib/universe"
+)
+
+func TestMapColumns_NewQuery(t *testing.T) {
+	tests := []querytest.NewQueryTestCase{
+		{
+			Name: "double ints",
+			Raw:  `from(bucket:"mybucket") |> mapColumns(type: "int", fn: (v) => v * 2)`,
+			Want: &flux.Spec{
+				Operations: []*flux.Operation{
+					{
+						ID: "from0",
+						Spec: &influxdb.FromOpSpec{
+							Bucket: "mybucket",
+						},
+					},
+					{
+						ID: "mapColumns1",
+						Spec: &universe.MapColumnsOpSpec{
+							Type: "int",
+							Fn: &semantic.FunctionExpression{
+								Block: &semantic.FunctionBlock{
+									Parameters: &semantic.FunctionParameters{
+										List: []*semantic.FunctionParameter{{Key: &semantic.Identifier{Name: "v"}}},
+									},
+									Body: &semantic.BinaryExpression{
+										Operator: ast.MultiplicationOperator,
+										Left:     &semantic.IdentifierExpression{Name: "v"},
+										Right:    &semantic.IntegerLiteral{Value: 2},
+									},
+								},
+							},
+						},
+					},
+				},
+				Edges: []flux.Edge{
+					{Parent: "from0", Child: "mapColumns1"},
+				},
+			},
+		},
+		{
+			Name:    "unsupported type",
+			Raw:     `from(bucket:"mybucket") |> mapColumns(type: "duration", fn: (v) => v)`,
+			WantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			querytest.NewQueryTestHelper(t, tc)
+		})
+	}
+}
+
+func TestMapColumns_Process(t *testing.T) {
+	floatFn := &semantic.FunctionExpression{
+		Block: &semantic.FunctionBlock{
+			Parameters: &semantic.FunctionParameters{
+				List: []*semantic.FunctionParameter{{Key: &semantic.Identifier{Name: "v"}}},
+			},
+			Body: &semantic.CallExpression{
+				Callee: &semantic.IdentifierExpression{Name: "float"},
+				Arguments: &semantic.ObjectExpression{
+					Properties: []*semantic.Property{{
+						Key:   &semantic.Identifier{Name: "v"},
+						Value: &semantic.IdentifierExpression{Name: "v"},
+					}},
+				},
+			},
+		},
+	}
+	testCases := []struct {
+		name string
+		spec *universe.MapColumnsProcedureSpec
+		data []flux.Table
+		want []*executetest.Table
+	}{
+		{
+			name: "ints to floats",
+			spec: &universe.MapColumnsProcedureSpec{
+				Type: flux.TInt,
+				Fn:   floatFn,
+			},
+			data: []flux.Table{&executetest.Table{
+				KeyCols: []string{"host"},
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "host", Type: flux.TString},
+					{Label: "used", Type: flux.TInt},
+					{Label: "free", Type: flux.TInt},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), "a", int64(1), int64(9)},
+					{execute.Time(2), "a", nil, int64(8)},
+					{execute.Time(3), "a", int64(3), nil},
+				},
+			}},
+			want: []*executetest.Table{{
+				KeyCols: []string{"host"},
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "host", Type: flux.TString},
+					{Label: "used", Type: flux.TFloat},
+					{Label: "free", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), "a", 1.0, 9.0},
+					{execute.Time(2), "a", nil, 8.0},
+					{execute.Time(3), "a", 3.0, nil},
+				},
+			}},
+		},
+		{
+			name: "group key column",
+			spec: &universe.MapColumnsProcedureSpec{
+				Type: flux.TInt,
+				Fn:   floatFn,
+			},
+			data: []flux.Table{&executetest.Table{
+				KeyCols: []string{"id"},
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "id", Type: flux.TInt},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), int64(7)},
+					{execute.Time(2), int64(7)},
+				},
+			}},
+			want: []*executetest.Table{{
+				KeyCols: []string{"id"},
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "id", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), 7.0},
+					{execute.Time(2), 7.0},
+				},
+			}},
+		},
+		{
+			name: "no matching columns",
+			spec: &universe.MapColumnsProcedureSpec{
+				Type: flux.TInt,
+				Fn:   floatFn,
+			},
+			data: []flux.Table{&executetest.Table{
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_value", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), 1.5},
+				},
+			}},
+			want: []*executetest.Table{{
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_value", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), 1.5},
+				},
+			}},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			executetest.ProcessTestHelper(
+				t,
+				tc.data,
+				tc.want,
+				nil,
+				func(d execute.Dataset, c execute.TableBuilderCache) execute.Transformation {
+					f, err := universe.NewMapColumnsTransformation(d, c, tc.spec)
+					if err != nil {
+						t.Fatal(err)
+					}
+					return f
+				},
+			)
+		})
+	}
+}
@@ -1,12 +1,14 @@
 package universe_test
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/influxdata/flux"
 	"github.com/influxdata/flux/ast"
 	"github.com/influxdata/flux/execute"
 	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/flux/parser"
 	"github.com/influxdata/flux/querytest"
 	"github.com/influxdata/flux/semantic"
 	"github.com/influxdata/flux/stdlib/influxdata/influxdb"
@@ -823,3 +825,36 @@ func TestMap_Process(t *testing.T) {
 		})
 	}
 }
+
+func TestMap_ProcessTypeConflict(t *testing.T) {
+	pkg, err := semantic.New(parser.ParseSource(`(r) => ({_value: r._value})`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	spec := &universe.MapProcedureSpec{
+		Fn: pkg.Files[0].Body[0].(*semantic.ExpressionStatement).Expression.(*semantic.FunctionExpression),
+	}
+	data := []flux.Table{
+		&executetest.Table{
+			ColMeta: []flux.ColMeta{{Label: "_value", Type: flux.TFloat}},
+			Data:    [][]interface{}{{1.0}},
+		},
+		&executetest.Table{
+			ColMeta: []flux.ColMeta{{Label: "_value", Type: flux.TInt}},
+			Data:    [][]interface{}{{int64(1)}},
+		},
+	}
+	executetest.ProcessTestHelper(
+		t,
+		data,
+		nil,
+		errors.New(`type conflict in map(): column "_value" is of type float, but the expression at 1:18-1:26 evaluates to int`),
+		func(d execute.Dataset, c execute.TableBuilderCache) execute.Transformation {
+			f, err := universe.NewMapTransformation(d, c, spec)
+			if err != nil {
+				t.Fatal(err)
+			}
+			return f
+		},
+	)
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/apache/arrow/go/arrow/array"
 	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/arrow"
 	"github.com/influxdata/flux/execute"
 	"github.com/influxdata/flux/plan"
 )
@@ -131,39 +132,39 @@ func (s *MaxSelector) selectRow(idx int, cr flux.ColReader) {
 
 func (s *MaxIntSelector) DoInt(vs *array.Int64, cr flux.ColReader) {
 	maxIdx := -1
-	for i := 0; i < vs.Len(); i++ {
-		if vs.IsValid(i) {
-			if v := vs.Value(i); !s.set || v > s.max {
-				s.set = true
-				s.max = v
-				maxIdx = i
-			}
+	if i := arrow.MaxInt64(vs); i >= 0 {
+		if v := vs.Value(i); !s.set || v > s.max {
+			s.set = true
+			s.max = v
+			maxIdx = i
 		}
 	}
 	s.selectRow(maxIdx, cr)
 }
 func (s *MaxUIntSelector) DoUInt(vs *array.Uint64, cr flux.ColReader) {
 	maxIdx := -1
-	for i := 0; i < vs.Len(); i++ {
-		if vs.IsValid(i) {
-			if v := vs.Value(i); !s.set || v > s.max {
-				s.set = true
-				s.max = v
-				maxIdx = i
-			}
+	if i := arrow.MaxUint64(vs); i >= 0 {
+		if v := vs.Value(i); !s.set || v > s.max {
+			s.set = true
+			s.max = v
+			maxIdx = i
 		}
 	}
 	s.selectRow(maxIdx, cr)
 }
 func (s *MaxFloatSelector) DoFloat(vs *array.Float64, cr flux.ColReader) {
 	maxIdx := -1
-	for i := 0; i < vs.Len(); i++ {
-		if vs.IsValid(i) {
-			if v := vs.Value(i); !s.set || v > s.max {
-				s.set = true
-				s.max = v
-				maxIdx = i
-			}
+	i := arrow.MaxFloat64(vs)
+	if !s.set {
+		if j := firstNaN(vs); j >= 0 {
+			i = j
+		}
+	}
+	if i >= 0 {
+		if v := vs.Value(i); !s.set || v > s.max {
+			s.set = true
+			s.max = v
+			maxIdx = i
 		}
 	}
 	s.selectRow(maxIdx, cr)
@@ -1,17 +1,23 @@
 package universe
 
 import (
+	"encoding/binary"
+	"errors"
 	"fmt"
 	"math"
 
 	"github.com/apache/arrow/go/arrow/array"
-	arrowmath "github.com/apache/arrow/go/arrow/math"
 	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/arrow"
 	"github.com/influxdata/flux/execute"
 	"github.com/influxdata/flux/plan"
 )
 
-const MeanKind = "mean"
+const (
+	MeanKind        = "mean"
+	MeanPartialKind = "meanPartial"
+	MeanFinalKind   = "meanFinal"
+)
 
 type MeanOpSpec struct {
 	execute.AggregateConfig
@@ -24,6 +30,8 @@ func init() {
 	flux.RegisterOpSpec(MeanKind, newMeanOp)
 	plan.RegisterProcedureSpec(MeanKind, newMeanProcedure, MeanKind)
 	execute.RegisterTransformation(MeanKind, createMeanTransformation)
+	execute.RegisterTransformation(MeanPartialKind, createMeanPartialTransformation)
+	execute.RegisterTransformation(MeanFinalKind, createMeanFinalTransformation)
 }
 func createMeanOpSpec(args flux.Arguments, a *flux.Administration) (flux.OperationSpec, error) {
 	if err := a.AddParentFromArgs(args); err != nil {
@@ -68,6 +76,39 @@ func (s *MeanProcedureSpec) Copy() plan.ProcedureSpec {
 	}
 }
 
+// SplitAggregate implements plan.SplittableAggregateSpec.
+// The partial phase outputs the sum and count of the values, which the final phase adds up.
+func (s *MeanProcedureSpec) SplitAggregate() (partial, final plan.PhysicalProcedureSpec, ok bool) {
+	return &MeanPartialProcedureSpec{AggregateConfig: s.AggregateConfig},
+		&MeanFinalProcedureSpec{AggregateConfig: s.AggregateConfig}, true
+}
+
+type MeanPartialProcedureSpec struct {
+	execute.AggregateConfig
+}
+
+func (s *MeanPartialProcedureSpec) Kind() plan.ProcedureKind {
+	return MeanPartialKind
+}
+func (s *MeanPartialProcedureSpec) Copy() plan.ProcedureSpec {
+	return &MeanPartialProcedureSpec{
+		AggregateConfig: s.AggregateConfig,
+	}
+}
+
+type MeanFinalProcedureSpec struct {
+	execute.AggregateConfig
+}
+
+func (s *MeanFinalProcedureSpec) Kind() plan.ProcedureKind {
+	return MeanFinalKind
+}
+func (s *MeanFinalProcedureSpec) Copy() plan.ProcedureSpec {
+	return &MeanFinalProcedureSpec{
+		AggregateConfig: s.AggregateConfig,
+	}
+}
+
 type MeanAgg struct {
 	count int64
 	sum   float64
@@ -103,48 +144,21 @@ func (a *MeanAgg) NewStringAgg() execute.DoStringAgg {
 }
 
 func (a *MeanAgg) DoInt(vs *array.Int64) {
-	// https://issues.apache.org/jira/browse/ARROW-4081
 	if l := vs.Len() - vs.NullN(); l > 0 {
 		a.count += int64(l)
-		if vs.NullN() == 0 {
-			a.sum += float64(arrowmath.Int64.Sum(vs))
-		} else {
-			for i := 0; i < vs.Len(); i++ {
-				if vs.IsValid(i) {
-					a.sum += float64(vs.Value(i))
-				}
-			}
-		}
+		a.sum += float64(arrow.SumInt64(vs))
 	}
 }
 func (a *MeanAgg) DoUInt(vs *array.Uint64) {
-	// https://issues.apache.org/jira/browse/ARROW-4081
 	if l := vs.Len() - vs.NullN(); l > 0 {
 		a.count += int64(l)
-		if vs.NullN() == 0 {
-			a.sum += float64(arrowmath.Uint64.Sum(vs))
-		} else {
-			for i := 0; i < vs.Len(); i++ {
-				if vs.IsValid(i) {
-					a.sum += float64(vs.Value(i))
-				}
-			}
-		}
+		a.sum += float64(arrow.SumUint64(vs))
 	}
 }
 func (a *MeanAgg) DoFloat(vs *array.Float64) {
-	// https://issues.apache.org/jira/browse/ARROW-4081
 	if l := vs.Len() - vs.NullN(); l > 0 {
 		a.count += int64(l)
-		if vs.NullN() == 0 {
-			a.sum += arrowmath.Float64.Sum(vs)
-		} else {
-			for i := 0; i < vs.Len(); i++ {
-				if vs.IsValid(i) {
-					a.sum += float64(vs.Value(i))
-				}
-			}
-		}
+		a.sum += arrow.SumFloat64(vs)
 	}
 }
 func (a *MeanAgg) Type() flux.ColType {
@@ -159,3 +173,98 @@ func (a *MeanAgg) ValueFloat() float64 {
 func (a *MeanAgg) IsNull() bool {
 	return a.count == 0
 }
+
+func createMeanPartialTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	s, ok := spec.(*MeanPartialProcedureSpec)
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid spec type %T", spec)
+	}
+	t, d := execute.NewAggregateTransformationAndDataset(id, mode, new(MeanPartialAgg), s.AggregateConfig, a.Allocator())
+	return t, d, nil
+}
+
+// meanStateLen is the length of the sum and count of a partial mean.
+const meanStateLen = 16
+
+// MeanPartialAgg outputs the sum and count of the values as a string, instead of their mean.
+type MeanPartialAgg struct {
+	MeanAgg
+}
+
+func (a *MeanPartialAgg) NewIntAgg() execute.DoIntAgg {
+	return new(MeanPartialAgg)
+}
+
+func (a *MeanPartialAgg) NewUIntAgg() execute.DoUIntAgg {
+	return new(MeanPartialAgg)
+}
+
+func (a *MeanPartialAgg) NewFloatAgg() execute.DoFloatAgg {
+	return new(MeanPartialAgg)
+}
+
+func (a *MeanPartialAgg) Type() flux.ColType {
+	return flux.TString
+}
+func (a *MeanPartialAgg) ValueString() string {
+	var state [meanStateLen]byte
+	binary.LittleEndian.PutUint64(state[:8], math.Float64bits(a.sum))
+	binary.LittleEndian.PutUint64(state[8:], uint64(a.count))
+	return string(state[:])
+}
+func (a *MeanPartialAgg) IsNull() bool {
+	return false
+}
+
+func createMeanFinalTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	s, ok := spec.(*MeanFinalProcedureSpec)
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid spec type %T", spec)
+	}
+	t, d := execute.NewAggregateTransformationAndDataset(id, mode, new(MeanFinalAgg), s.AggregateConfig, a.Allocator())
+	return t, d, nil
+}
+
+// MeanFinalAgg computes the mean of the values from the partial means output by MeanPartialAgg.
+type MeanFinalAgg struct {
+	MeanAgg
+	err error
+}
+
+func (a *MeanFinalAgg) NewBoolAgg() execute.DoBoolAgg {
+	return nil
+}
+
+func (a *MeanFinalAgg) NewIntAgg() execute.DoIntAgg {
+	return nil
+}
+
+func (a *MeanFinalAgg) NewUIntAgg() execute.DoUIntAgg {
+	return nil
+}
+
+func (a *MeanFinalAgg) NewFloatAgg() execute.DoFloatAgg {
+	return nil
+}
+
+func (a *MeanFinalAgg) NewStringAgg() execute.DoStringAgg {
+	return new(MeanFinalAgg)
+}
+
+func (a *MeanFinalAgg) DoString(vs *array.Binary) {
+	for i := 0; i < vs.Len(); i++ {
+		if vs.IsNull(i) {
+			continue
+		}
+		state := vs.Value(i)
+		if len(state) != meanStateLen {
+			a.err = errors.New("invalid partial mean")
+			return
+		}
+		a.sum += math.Float64frombits(binary.LittleEndian.Uint64(state[:8]))
+		a.count += int64(binary.LittleEndian.Uint64(state[8:]))
+	}
+}
+func (a *MeanFinalAgg) Err() error {
+	return a.err
+}
@@ -92,6 +92,58 @@ func TestMean_Process(t *testing.T) {
 	}
 }
 
+func TestMean_SplitAggregate(t *testing.T) {
+	testCases := []struct {
+		name string
+		data func() *array.Float64
+		want interface{}
+	}{
+		{
+			name: "nonzero",
+			data: func() *array.Float64 {
+				return arrow.NewFloat([]float64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, nil)
+			},
+			want: 4.5,
+		},
+		{
+			name: "empty",
+			data: func() *array.Float64 {
+				return arrow.NewFloat(nil, nil)
+			},
+			want: nil,
+		},
+		{
+			name: "with nulls",
+			data: func() *array.Float64 {
+				b := arrow.NewFloatBuilder(nil)
+				defer b.Release()
+				b.AppendValues([]float64{0, 1, 2, 3}, nil)
+				b.AppendNull()
+				b.AppendValues([]float64{5, 6}, nil)
+				b.AppendNull()
+				b.AppendValues([]float64{8, 9}, nil)
+				return b.NewFloat64Array()
+			},
+			want: 4.25,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			data := tc.data()
+			defer data.Release()
+
+			executetest.SplitAggFuncTestHelper(
+				t,
+				new(universe.MeanPartialAgg),
+				new(universe.MeanFinalAgg),
+				data,
+				tc.want,
+			)
+		})
+	}
+}
+
 func BenchmarkMean(b *testing.B) {
 	data := arrow.NewFloat(NormalData, &memory.Allocator{})
 	executetest.AggFuncBenchmarkHelper(
@@ -2,9 +2,11 @@ package universe
 
 import (
 	"fmt"
+	"math"
 
 	"github.com/apache/arrow/go/arrow/array"
 	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/arrow"
 	"github.com/influxdata/flux/execute"
 	"github.com/influxdata/flux/plan"
 )
@@ -131,40 +133,55 @@ func (s *MinSelector) selectRow(idx int, cr flux.ColReader) {
 
 func (s *MinIntSelector) DoInt(vs *array.Int64, cr flux.ColReader) {
 	minIdx := -1
-	for i := 0; i < vs.Len(); i++ {
-		if vs.IsValid(i) {
-			if v := vs.Value(i); !s.set || v < s.min {
-				s.set = true
-				s.min = v
-				minIdx = i
-			}
+	if i := arrow.MinInt64(vs); i >= 0 {
+		if v := vs.Value(i); !s.set || v < s.min {
+			s.set = true
+			s.min = v
+			minIdx = i
 		}
 	}
 	s.selectRow(minIdx, cr)
 }
 func (s *MinUIntSelector) DoUInt(vs *array.Uint64, cr flux.ColReader) {
 	minIdx := -1
-	for i := 0; i < vs.Len(); i++ {
-		if vs.IsValid(i) {
-			if v := vs.Value(i); !s.set || v < s.min {
-				s.set = true
-				s.min = v
-				minIdx = i
-			}
+	if i := arrow.MinUint64(vs); i >= 0 {
+		if v := vs.Value(i); !s.set || v < s.min {
+			s.set = true
+			s.min = v
+			minIdx = i
 		}
 	}
 	s.selectRow(minIdx, cr)
 }
 func (s *MinFloatSelector) DoFloat(vs *array.Float64, cr flux.ColReader) {
 	minIdx := -1
+	i := arrow.MinFloat64(vs)
+	if !s.set {
+		if j := firstNaN(vs); j >= 0 {
+			i = j
+		}
+	}
+	if i >= 0 {
+		if v := vs.Value(i); !s.set || v < s.min {
+			s.set = true
+			s.min = v
+			minIdx = i
+		}
+	}
+	s.selectRow(minIdx, cr)
+}
+
+// firstNaN returns the index of the first valid value of vs if it is NaN, and -1 otherwise.
+// A selector selects its first value if it is NaN, since no value compares less or greater than NaN,
+// whereas the kernels that find the smallest and largest values ignore NaN.
+func firstNaN(vs *array.Float64) int {
 	for i := 0; i < vs.Len(); i++ {
 		if vs.IsValid(i) {
-			if v := vs.Value(i); !s.set || v < s.min {
-				s.set = true
-				s.min = v
-				minIdx = i
+			if math.IsNaN(vs.Value(i)) {
+				return i
 			}
+			return -1
 		}
 	}
-	s.selectRow(minIdx, cr)
+	return -1
 }
@@ -0,0 +1,68 @@
+package universe
+
+import (
+	"fmt"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/values"
+)
+
+// The ways in which pivot() and join() handle the missing combinations of their output,
+// that is the cells of an output row for which there is no value in their input.
+const (
+	// MissingNull sets the missing cells to null.
+	MissingNull = "null"
+	// MissingDrop drops the rows that have missing cells.
+	MissingDrop = "drop"
+	// MissingDefault sets the missing cells to the value of their column in a record of defaults,
+	// or to null if the record has no value for their column, as fill() does for the nulls of a column.
+	MissingDefault = "default"
+)
+
+// getMissing reads the missing and defaults parameters of a function.
+// The record of defaults is required with the default mode and invalid with the others.
+func getMissing(args flux.Arguments, kind string, def string) (string, values.Object, error) {
+	missing, ok, err := args.GetString("missing")
+	if err != nil {
+		return "", nil, err
+	} else if !ok {
+		missing = def
+	}
+	switch missing {
+	case MissingNull, MissingDrop, MissingDefault:
+	default:
+		return "", nil, fmt.Errorf("%s does not support missing %q, expected %q, %q or %q", kind, missing, MissingNull, MissingDrop, MissingDefault)
+	}
+
+	defaults, ok, err := args.GetObject("defaults")
+	if err != nil {
+		return "", nil, err
+	}
+	if ok != (missing == MissingDefault) {
+		return "", nil, fmt.Errorf("%s requires defaults if and only if missing is %q", kind, MissingDefault)
+	}
+	if ok {
+		defaults.Range(func(label string, v values.Value) {
+			if err == nil && flux.ColumnType(v.Type()) == flux.TInvalid {
+				err = fmt.Errorf("default of column %q for %s must be a valid primitive type (bool, int, uint, float, string, time)", label, kind)
+			}
+		})
+		if err != nil {
+			return "", nil, err
+		}
+	}
+	return missing, defaults, nil
+}
+
+// missingValue returns the value of a missing cell of col, that is its default if there is one, or null.
+func missingValue(defaults values.Object, col flux.ColMeta) (values.Value, error) {
+	if defaults != nil {
+		if v, ok := defaults.Get(col.Label); ok {
+			if typ := flux.ColumnType(v.Type()); typ != col.Type {
+				return nil, fmt.Errorf("default type mismatch for column %q: %s/%s", col.Label, col.Type, typ)
+			}
+			return v, nil
+		}
+	}
+	return values.NewNull(flux.SemanticType(col.Type)), nil
+}
@@ -0,0 +1,427 @@
+package universe
+
+import (
+	"fmt"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/flux/values"
+)
+
+const MovingAverageKind = "movingAverage"
+
+// The kernels of the moving average.
+const (
+	SimpleKernel            = "simple"
+	ExponentialKernel       = "exponential"
+	DoubleExponentialKernel = "doubleExponential"
+	TripleExponentialKernel = "tripleExponential"
+	WeightedKernel          = "weighted"
+)
+
+// The alignments of the moving average with the records of its window.
+const (
+	TrailingAlignment = "trailing"
+	CenteredAlignment = "centered"
+)
+
+type MovingAverageOpSpec struct {
+	N         int64  `json:"n"`
+	Kernel    string `json:"kernel"`
+	Alignment string `json:"alignment"`
+	Column    string `json:"column"`
+}
+
+func init() {
+	movingAverageSignature := flux.FunctionSignature(
+		map[string]semantic.PolyType{
+			"n":         semantic.Int,
+			"kernel":    semantic.String,
+			"alignment": semantic.String,
+			"column":    semantic.String,
+		},
+		[]string{"n"},
+	)
+
+	flux.RegisterPackageValue("universe", MovingAverageKind, flux.FunctionValue(MovingAverageKind, createMovingAverageOpSpec, movingAverageSignature))
+	flux.RegisterOpSpec(MovingAverageKind, newMovingAverageOp)
+	plan.RegisterProcedureSpec(MovingAverageKind, newMovingAverageProcedure, MovingAverageKind)
+	execute.RegisterTransformation(MovingAverageKind, createMovingAverageTransformation)
+}
+
+func createMovingAverageOpSpec(args flux.Arguments, a *flux.Administration) (flux.OperationSpec, error) {
+	if err := a.AddParentFromArgs(args); err != nil {
+		return nil, err
+	}
+
+	spec := new(MovingAverageOpSpec)
+
+	if n, err := args.GetRequiredInt("n"); err != nil {
+		return nil, err
+	} else if n < 1 {
+		return nil, fmt.Errorf("movingAverage error: n must be positive, got %d", n)
+	} else {
+		spec.N = n
+	}
+
+	if kernel, ok, err := args.GetString("kernel"); err != nil {
+		return nil, err
+	} else if ok {
+		switch kernel {
+		case SimpleKernel, ExponentialKernel, DoubleExponentialKernel, TripleExponentialKernel, WeightedKernel:
+			spec.Kernel = kernel
+		default:
+			return nil, fmt.Errorf("movingAverage error: unknown kernel %q", kernel)
+		}
+	} else {
+		spec.Kernel = SimpleKernel
+	}
+
+	if alignment, ok, err := args.GetString("alignment"); err != nil {
+		return nil, err
+	} else if ok {
+		switch alignment {
+		case TrailingAlignment, CenteredAlignment:
+			spec.Alignment = alignment
+		default:
+			return nil, fmt.Errorf("movingAverage error: unknown alignment %q, must be %q or %q", alignment, TrailingAlignment, CenteredAlignment)
+		}
+	} else {
+		spec.Alignment = TrailingAlignment
+	}
+
+	if col, ok, err := args.GetString("column"); err != nil {
+		return nil, err
+	} else if ok {
+		spec.Column = col
+	} else {
+		spec.Column = execute.DefaultValueColLabel
+	}
+
+	return spec, nil
+}
+
+func newMovingAverageOp() flux.OperationSpec {
+	return new(MovingAverageOpSpec)
+}
+
+func (s *MovingAverageOpSpec) Kind() flux.OperationKind {
+	return MovingAverageKind
+}
+
+type MovingAverageProcedureSpec struct {
+	plan.DefaultCost
+	N         int64
+	Kernel    string
+	Alignment string
+	Column    string
+}
+
+func newMovingAverageProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	spec, ok := qs.(*MovingAverageOpSpec)
+	if !ok {
+		return nil, fmt.Errorf("invalid spec type %T", qs)
+	}
+
+	return &MovingAverageProcedureSpec{
+		N:         spec.N,
+		Kernel:    spec.Kernel,
+		Alignment: spec.Alignment,
+		Column:    spec.Column,
+	}, nil
+}
+
+func (s *MovingAverageProcedureSpec) Kind() plan.ProcedureKind {
+	return MovingAverageKind
+}
+
+func (s *MovingAverageProcedureSpec) Copy() plan.ProcedureSpec {
+	ns := new(MovingAverageProcedureSpec)
+	*ns = *s
+	return ns
+}
+
+func createMovingAverageTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	s, ok := spec.(*MovingAverageProcedureSpec)
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid spec type %T", spec)
+	}
+	cache := execute.NewTableBuilderCache(a.Allocator())
+	d := execute.NewDataset(id, mode, cache)
+	t := NewMovingAverageTransformation(d, cache, s)
+	return t, d, nil
+}
+
+// movingAverageTransformation replaces the values of a column with their moving average.
+// Records with a null value are dropped, and so are the records before the first full window.
+//
+// The average of a window is assigned to its last record when trailing,
+// and to its middle record when centered, which delays the output by (n-1)/2 records.
+type movingAverageTransformation struct {
+	d     execute.Dataset
+	cache execute.TableBuilderCache
+
+	n         int
+	kernel    string
+	alignment string
+	column    string
+}
+
+func NewMovingAverageTransformation(d execute.Dataset, cache execute.TableBuilderCache, spec *MovingAverageProcedureSpec) *movingAverageTransformation {
+	return &movingAverageTransformation{
+		d:         d,
+		cache:     cache,
+		n:         int(spec.N),
+		kernel:    spec.Kernel,
+		alignment: spec.Alignment,
+		column:    spec.Column,
+	}
+}
+
+func (t *movingAverageTransformation) RetractTable(id execute.DatasetID, key flux.GroupKey) error {
+	return t.d.RetractTable(key)
+}
+
+func (t *movingAverageTransformation) Process(id execute.DatasetID, tbl flux.Table) error {
+	builder, created := t.cache.TableBuilder(tbl.Key())
+	if !created {
+		return fmt.Errorf("movingAverage found duplicate table with key: %v", tbl.Key())
+	}
+
+	cols := tbl.Cols()
+	valueIdx := execute.ColIdx(t.column, cols)
+	if valueIdx < 0 {
+		return fmt.Errorf("movingAverage error: column %q does not exist", t.column)
+	}
+	if tbl.Key().HasCol(t.column) {
+		return fmt.Errorf("movingAverage error: cannot average group key column %q", t.column)
+	}
+	switch typ := cols[valueIdx].Type; typ {
+	case flux.TInt, flux.TUInt, flux.TFloat:
+	default:
+		return fmt.Errorf("movingAverage error: cannot average column %q of type %v", t.column, typ)
+	}
+
+	for j, c := range cols {
+		if j == valueIdx {
+			c.Type = flux.TFloat
+		}
+		if _, err := builder.AddCol(c); err != nil {
+			return err
+		}
+	}
+
+	k := newMovingAverageKernel(t.kernel, t.n)
+	delay := 0
+	if t.alignment == CenteredAlignment {
+		delay = (t.n - 1) / 2
+	}
+	// pending holds the last delay+1 records, the average of a window
+	// is assigned to the oldest of them.
+	pending := make([][]values.Value, 0, delay+1)
+
+	return tbl.Do(func(cr flux.ColReader) error {
+		for i := 0; i < cr.Len(); i++ {
+			v, ok := floatValue(cr, i, valueIdx)
+			if !ok {
+				continue
+			}
+			record := make([]values.Value, len(cols))
+			for j := range cols {
+				if j != valueIdx {
+					record[j] = execute.ValueForRow(cr, i, j)
+				}
+			}
+			pending = append(pending, record)
+
+			if avg, ok := k.Update(v); ok {
+				for j, v := range pending[0] {
+					var err error
+					if j == valueIdx {
+						err = builder.AppendFloat(j, avg)
+					} else {
+						err = builder.AppendValue(j, v)
+					}
+					if err != nil {
+						return err
+					}
+				}
+			}
+			if len(pending) > delay {
+				pending = pending[1:]
+			}
+		}
+		return nil
+	})
+}
+
+// floatValue returns the value of row i of the numeric column j as a float, or false if it is null.
+func floatValue(cr flux.ColReader, i, j int) (float64, bool) {
+	switch cr.Cols()[j].Type {
+	case flux.TInt:
+		if vs := cr.Ints(j); vs.IsValid(i) {
+			return float64(vs.Value(i)), true
+		}
+	case flux.TUInt:
+		if vs := cr.UInts(j); vs.IsValid(i) {
+			return float64(vs.Value(i)), true
+		}
+	case flux.TFloat:
+		if vs := cr.Floats(j); vs.IsValid(i) {
+			return vs.Value(i), true
+		}
+	}
+	return 0, false
+}
+
+func (t *movingAverageTransformation) UpdateWatermark(id execute.DatasetID, mark execute.Time) error {
+	return t.d.UpdateWatermark(mark)
+}
+func (t *movingAverageTransformation) UpdateProcessingTime(id execute.DatasetID, pt execute.Time) error {
+	return t.d.UpdateProcessingTime(pt)
+}
+func (t *movingAverageTransformation) Finish(id execute.DatasetID, err error) {
+	t.d.Finish(err)
+}
+
+// movingAverageKernel computes a moving average one value at a time.
+type movingAverageKernel interface {
+	// Update adds the next value to the window and returns the average of the window,
+	// or false if not enough values have been seen yet.
+	Update(v float64) (float64, bool)
+}
+
+func newMovingAverageKernel(kernel string, n int) movingAverageKernel {
+	switch kernel {
+	case ExponentialKernel:
+		return newEMA(n)
+	case DoubleExponentialKernel:
+		return &dema{e1: newEMA(n), e2: newEMA(n)}
+	case TripleExponentialKernel:
+		return &tema{e1: newEMA(n), e2: newEMA(n), e3: newEMA(n)}
+	case WeightedKernel:
+		return &wma{window: make([]float64, 0, n), n: n}
+	default:
+		return &sma{window: make([]float64, 0, n), n: n}
+	}
+}
+
+// sma is the simple moving average, the mean of the last n values.
+type sma struct {
+	window []float64
+	n      int
+	next   int
+	sum    float64
+}
+
+func (k *sma) Update(v float64) (float64, bool) {
+	if len(k.window) < k.n {
+		k.window = append(k.window, v)
+		k.sum += v
+	} else {
+		k.sum += v - k.window[k.next]
+		k.window[k.next] = v
+		k.next = (k.next + 1) % k.n
+	}
+	if len(k.window) < k.n {
+		return 0, false
+	}
+	return k.sum / float64(k.n), true
+}
+
+// wma is the weighted moving average of the last n values,
+// with linearly decreasing weights from n for the newest value to 1 for the oldest.
+type wma struct {
+	window []float64
+	n      int
+	next   int
+}
+
+func (k *wma) Update(v float64) (float64, bool) {
+	if len(k.window) < k.n {
+		k.window = append(k.window, v)
+		if len(k.window) < k.n {
+			return 0, false
+		}
+	} else {
+		k.window[k.next] = v
+		k.next = (k.next + 1) % k.n
+	}
+
+	// The oldest value is at index next.
+	var sum float64
+	for w := 1; w <= k.n; w++ {
+		sum += float64(w) * k.window[(k.next+w-1)%k.n]
+	}
+	return sum / float64(k.n*(k.n+1)/2), true
+}
+
+// ema is the exponential moving average with a smoothing factor of 2/(n+1).
+// It is seeded with the simple moving average of the first n values.
+type ema struct {
+	n     int
+	count int
+	alpha float64
+	value float64
+}
+
+func newEMA(n int) *ema {
+	return &ema{
+		n:     n,
+		alpha: 2 / float64(n+1),
+	}
+}
+
+func (k *ema) Update(v float64) (float64, bool) {
+	if k.count < k.n {
+		k.count++
+		k.value += v
+		if k.count < k.n {
+			return 0, false
+		}
+		k.value /= float64(k.n)
+		return k.value, true
+	}
+	k.value = k.alpha*v + (1-k.alpha)*k.value
+	return k.value, true
+}
+
+// dema is the double exponential moving average, 2*EMA - EMA(EMA).
+type dema struct {
+	e1, e2 *ema
+}
+
+func (k *dema) Update(v float64) (float64, bool) {
+	v1, ok := k.e1.Update(v)
+	if !ok {
+		return 0, false
+	}
+	v2, ok := k.e2.Update(v1)
+	if !ok {
+		return 0, false
+	}
+	return 2*v1 - v2, true
+}
+
+// tema is the triple exponential moving average, 3*EMA - 3*EMA(EMA) + EMA(EMA(EMA)).
+type tema struct {
+	e1, e2, e3 *ema
+}
+
+func (k *tema) Update(v float64) (float64, bool) {
+	v1, ok := k.e1.Update(v)
+	if !ok {
+		return 0, false
+	}
+	v2, ok := k.e2.Update(v1)
+	if !ok {
+		return 0, false
+	}
+	v3, ok := k.e3.Update(v2)
+	if !ok {
+		return 0, false
+	}
+	return 3*v1 - 3*v2 + v3, true
+}
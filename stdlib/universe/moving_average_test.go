@@ -0,0 +1,260 @@
+package universe_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/flux/querytest"
+	"github.com/influxdata/flux/stdlib/influxdata/influxdb"
+	"github.com/influxdata/flux/stdlib/universe"
+)
+
+func TestMovingAverage_NewQuery(t *testing.T) {
+	tests := []querytest.NewQueryTestCase{
+		{
+			Name: "defaults",
+			Raw:  `from(bucket:"mybucket") |> movingAverage(n: 5)`,
+			Want: &flux.Spec{
+				Operations: []*flux.Operation{
+					{
+						ID: "from0",
+						Spec: &influxdb.FromOpSpec{
+							Bucket: "mybucket",
+						},
+					},
+					{
+						ID: "movingAverage1",
+						Spec: &universe.MovingAverageOpSpec{
+							N:         5,
+							Kernel:    "simple",
+							Alignment: "trailing",
+							Column:    "_value",
+						},
+					},
+				},
+				Edges: []flux.Edge{
+					{Parent: "from0", Child: "movingAverage1"},
+				},
+			},
+		},
+		{
+			Name: "centered weighted",
+			Raw:  `from(bucket:"mybucket") |> movingAverage(n: 3, kernel: "weighted", alignment: "centered", column: "x")`,
+			Want: &flux.Spec{
+				Operations: []*flux.Operation{
+					{
+						ID: "from0",
+						Spec: &influxdb.FromOpSpec{
+							Bucket: "mybucket",
+						},
+					},
+					{
+						ID: "movingAverage1",
+						Spec: &universe.MovingAverageOpSpec{
+							N:         3,
+							Kernel:    "weighted",
+							Alignment: "centered",
+							Column:    "x",
+						},
+					},
+				},
+				Edges: []flux.Edge{
+					{Parent: "from0", Child: "movingAverage1"},
+				},
+			},
+		},
+		{
+			Name:    "zero n",
+			Raw:     `from(bucket:"mybucket") |> movingAverage(n: 0)`,
+			WantErr: true,
+		},
+		{
+			Name:    "unknown kernel",
+			Raw:     `from(bucket:"mybucket") |> movingAverage(n: 2, kernel: "median")`,
+			WantErr: true,
+		},
+		{
+			Name:    "unknown alignment",
+			Raw:     `from(bucket:"mybucket") |> movingAverage(n: 2, alignment: "leading")`,
+			WantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			querytest.NewQueryTestHelper(t, tc)
+		})
+	}
+}
+
+func TestMovingAverageOperation_Marshaling(t *testing.T) {
+	data := []byte(`{"id":"movingAverage","kind":"movingAverage","spec":{"n":5,"kernel":"exponential","alignment":"trailing","column":"_value"}}`)
+	op := &flux.Operation{
+		ID: "movingAverage",
+		Spec: &universe.MovingAverageOpSpec{
+			N:         5,
+			Kernel:    "exponential",
+			Alignment: "trailing",
+			Column:    "_value",
+		},
+	}
+	querytest.OperationMarshalingTestHelper(t, data, op)
+}
+
+func TestMovingAverage_Process(t *testing.T) {
+	input := func() []flux.Table {
+		return []flux.Table{&executetest.Table{
+			KeyCols: []string{"host"},
+			ColMeta: []flux.ColMeta{
+				{Label: "_time", Type: flux.TTime},
+				{Label: "host", Type: flux.TString},
+				{Label: "_value", Type: flux.TFloat},
+			},
+			Data: [][]interface{}{
+				{execute.Time(1), "a", 1.0},
+				{execute.Time(2), "a", 2.0},
+				{execute.Time(3), "a", 3.0},
+				{execute.Time(4), "a", 4.0},
+				{execute.Time(5), "a", 5.0},
+				{execute.Time(6), "a", 6.0},
+				{execute.Time(7), "a", 7.0},
+			},
+		}}
+	}
+	// output builds the expected table from the time of the first
+	// average followed by the averages of the consecutive records.
+	output := func(start int, avgs ...float64) []*executetest.Table {
+		data := make([][]interface{}, len(avgs))
+		for i, avg := range avgs {
+			data[i] = []interface{}{execute.Time(start + i), "a", avg}
+		}
+		return []*executetest.Table{{
+			KeyCols: []string{"host"},
+			ColMeta: []flux.ColMeta{
+				{Label: "_time", Type: flux.TTime},
+				{Label: "host", Type: flux.TString},
+				{Label: "_value", Type: flux.TFloat},
+			},
+			Data: data,
+		}}
+	}
+	spec := func(n int64, kernel, alignment string) *universe.MovingAverageProcedureSpec {
+		return &universe.MovingAverageProcedureSpec{
+			N:         n,
+			Kernel:    kernel,
+			Alignment: alignment,
+			Column:    "_value",
+		}
+	}
+	testCases := []struct {
+		name    string
+		spec    *universe.MovingAverageProcedureSpec
+		data    []flux.Table
+		want    []*executetest.Table
+		wantErr error
+	}{
+		{
+			name: "simple",
+			spec: spec(3, "simple", "trailing"),
+			data: input(),
+			want: output(3, 2, 3, 4, 5, 6),
+		},
+		{
+			name: "simple centered",
+			spec: spec(3, "simple", "centered"),
+			data: input(),
+			want: output(2, 2, 3, 4, 5, 6),
+		},
+		{
+			name: "simple n=1",
+			spec: spec(1, "simple", "centered"),
+			data: input(),
+			want: output(1, 1, 2, 3, 4, 5, 6, 7),
+		},
+		{
+			name: "weighted",
+			spec: spec(3, "weighted", "trailing"),
+			data: input(),
+			want: output(3, 14.0/6.0, 20.0/6.0, 26.0/6.0, 32.0/6.0, 38.0/6.0),
+		},
+		{
+			name: "exponential",
+			spec: spec(3, "exponential", "trailing"),
+			data: input(),
+			want: output(3, 2, 3, 4, 5, 6),
+		},
+		{
+			name: "exponential centered",
+			spec: spec(3, "exponential", "centered"),
+			data: input(),
+			want: output(2, 2, 3, 4, 5, 6),
+		},
+		{
+			name: "double exponential",
+			spec: spec(3, "doubleExponential", "trailing"),
+			data: input(),
+			want: output(5, 5, 6, 7),
+		},
+		{
+			name: "triple exponential",
+			spec: spec(3, "tripleExponential", "trailing"),
+			data: input(),
+			want: output(7, 7),
+		},
+		{
+			name: "ints with nulls",
+			spec: spec(2, "simple", "trailing"),
+			data: []flux.Table{&executetest.Table{
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_value", Type: flux.TInt},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), int64(1)},
+					{execute.Time(2), nil},
+					{execute.Time(3), int64(3)},
+					{execute.Time(4), int64(5)},
+				},
+			}},
+			want: []*executetest.Table{{
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_value", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{execute.Time(3), 2.0},
+					{execute.Time(4), 4.0},
+				},
+			}},
+		},
+		{
+			name: "group key column",
+			spec: &universe.MovingAverageProcedureSpec{
+				N:         2,
+				Kernel:    "simple",
+				Alignment: "trailing",
+				Column:    "host",
+			},
+			data:    input(),
+			wantErr: errors.New(`movingAverage error: cannot average group key column "host"`),
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			executetest.ProcessTestHelper(
+				t,
+				tc.data,
+				tc.want,
+				tc.wantErr,
+				func(d execute.Dataset, c execute.TableBuilderCache) execute.Transformation {
+					return universe.NewMovingAverageTransformation(d, c, tc.spec)
+				},
+			)
+		})
+	}
+}
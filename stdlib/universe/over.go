@@ -0,0 +1,330 @@
+package universe
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/flux/values"
+)
+
+const OverKind = "over"
+
+// The window functions that over can compute.
+const (
+	LagFunction         = "lag"
+	LeadFunction        = "lead"
+	RowNumberFunction   = "rowNumber"
+	PercentRankFunction = "percentRank"
+)
+
+// OverOpSpec adds a column holding the result of a window function
+// computed over the records of each table.
+type OverOpSpec struct {
+	Function string `json:"function"`
+	Column   string `json:"column"`
+	As       string `json:"as"`
+	Offset   int64  `json:"offset"`
+}
+
+func init() {
+	overSignature := flux.FunctionSignature(
+		map[string]semantic.PolyType{
+			"function": semantic.String,
+			"column":   semantic.String,
+			"as":       semantic.String,
+			"offset":   semantic.Int,
+		},
+		[]string{"function"},
+	)
+
+	flux.RegisterPackageValue("universe", OverKind, flux.FunctionValue(OverKind, createOverOpSpec, overSignature))
+	flux.RegisterOpSpec(OverKind, newOverOp)
+	plan.RegisterProcedureSpec(OverKind, newOverProcedure, OverKind)
+	execute.RegisterTransformation(OverKind, createOverTransformation)
+}
+
+func createOverOpSpec(args flux.Arguments, a *flux.Administration) (flux.OperationSpec, error) {
+	if err := a.AddParentFromArgs(args); err != nil {
+		return nil, err
+	}
+
+	spec := new(OverOpSpec)
+
+	fn, err := args.GetRequiredString("function")
+	if err != nil {
+		return nil, err
+	}
+	switch fn {
+	case LagFunction, LeadFunction, RowNumberFunction, PercentRankFunction:
+		spec.Function = fn
+	default:
+		return nil, fmt.Errorf("over error: unknown function %q, must be one of %q, %q, %q or %q", fn, LagFunction, LeadFunction, RowNumberFunction, PercentRankFunction)
+	}
+
+	if col, ok, err := args.GetString("column"); err != nil {
+		return nil, err
+	} else if ok {
+		spec.Column = col
+	} else {
+		spec.Column = execute.DefaultValueColLabel
+	}
+
+	if as, ok, err := args.GetString("as"); err != nil {
+		return nil, err
+	} else if ok {
+		spec.As = as
+	} else {
+		spec.As = spec.Function
+	}
+
+	if offset, ok, err := args.GetInt("offset"); err != nil {
+		return nil, err
+	} else if ok {
+		if offset < 0 {
+			return nil, fmt.Errorf("over error: offset must not be negative, got %d", offset)
+		}
+		spec.Offset = offset
+	} else {
+		spec.Offset = 1
+	}
+
+	return spec, nil
+}
+
+func newOverOp() flux.OperationSpec {
+	return new(OverOpSpec)
+}
+
+func (s *OverOpSpec) Kind() flux.OperationKind {
+	return OverKind
+}
+
+type OverProcedureSpec struct {
+	plan.DefaultCost
+	Function string
+	Column   string
+	As       string
+	Offset   int64
+}
+
+func newOverProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	spec, ok := qs.(*OverOpSpec)
+	if !ok {
+		return nil, fmt.Errorf("invalid spec type %T", qs)
+	}
+
+	return &OverProcedureSpec{
+		Function: spec.Function,
+		Column:   spec.Column,
+		As:       spec.As,
+		Offset:   spec.Offset,
+	}, nil
+}
+
+func (s *OverProcedureSpec) Kind() plan.ProcedureKind {
+	return OverKind
+}
+
+func (s *OverProcedureSpec) Copy() plan.ProcedureSpec {
+	ns := new(OverProcedureSpec)
+	*ns = *s
+	return ns
+}
+
+func createOverTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	s, ok := spec.(*OverProcedureSpec)
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid spec type %T", spec)
+	}
+	cache := execute.NewTableBuilderCache(a.Allocator())
+	d := execute.NewDataset(id, mode, cache)
+	t := NewOverTransformation(d, cache, s)
+	return t, d, nil
+}
+
+// overTransformation copies its input tables with an additional column holding the result of
+// the window function. The window of every record is the whole table, in the order of its records.
+type overTransformation struct {
+	d     execute.Dataset
+	cache execute.TableBuilderCache
+
+	function string
+	column   string
+	as       string
+	offset   int
+}
+
+func NewOverTransformation(d execute.Dataset, cache execute.TableBuilderCache, spec *OverProcedureSpec) *overTransformation {
+	return &overTransformation{
+		d:        d,
+		cache:    cache,
+		function: spec.Function,
+		column:   spec.Column,
+		as:       spec.As,
+		offset:   int(spec.Offset),
+	}
+}
+
+func (t *overTransformation) RetractTable(id execute.DatasetID, key flux.GroupKey) error {
+	return t.d.RetractTable(key)
+}
+
+func (t *overTransformation) Process(id execute.DatasetID, tbl flux.Table) error {
+	builder, created := t.cache.TableBuilder(tbl.Key())
+	if !created {
+		return fmt.Errorf("over found duplicate table with key: %v", tbl.Key())
+	}
+
+	cols := tbl.Cols()
+	if execute.ColIdx(t.as, cols) >= 0 {
+		return fmt.Errorf("over error: column %q already exists", t.as)
+	}
+	valueIdx := -1
+	if t.function != RowNumberFunction {
+		if valueIdx = execute.ColIdx(t.column, cols); valueIdx < 0 {
+			return fmt.Errorf("over error: column %q does not exist", t.column)
+		}
+	}
+
+	var typ flux.ColType
+	switch t.function {
+	case LagFunction, LeadFunction:
+		typ = cols[valueIdx].Type
+	case RowNumberFunction:
+		typ = flux.TInt
+	case PercentRankFunction:
+		typ = flux.TFloat
+	}
+
+	if err := execute.AddTableCols(tbl, builder); err != nil {
+		return err
+	}
+	asIdx, err := builder.AddCol(flux.ColMeta{Label: t.as, Type: typ})
+	if err != nil {
+		return err
+	}
+
+	// The result of the function depends on records that may not have been read yet,
+	// the values are collected and the column is appended once the table is read.
+	var vs []values.Value
+	n := 0
+	if err := tbl.Do(func(cr flux.ColReader) error {
+		for j := range cols {
+			if err := execute.AppendCol(j, j, cr, builder); err != nil {
+				return err
+			}
+		}
+		if valueIdx >= 0 {
+			for i := 0; i < cr.Len(); i++ {
+				vs = append(vs, execute.ValueForRow(cr, i, valueIdx))
+			}
+		}
+		n += cr.Len()
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	switch t.function {
+	case LagFunction:
+		return t.appendShifted(builder, asIdx, typ, vs, -t.offset)
+	case LeadFunction:
+		return t.appendShifted(builder, asIdx, typ, vs, t.offset)
+	case RowNumberFunction:
+		for i := 0; i < n; i++ {
+			if err := builder.AppendInt(asIdx, int64(i+1)); err != nil {
+				return err
+			}
+		}
+		return nil
+	case PercentRankFunction:
+		for _, r := range percentRanks(vs) {
+			if err := builder.AppendValue(asIdx, r); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return nil
+}
+
+// appendShifted appends the value that is offset records away from every record,
+// or null if there is no such record.
+func (t *overTransformation) appendShifted(builder execute.TableBuilder, j int, typ flux.ColType, vs []values.Value, offset int) error {
+	for i := range vs {
+		v := values.NewNull(flux.SemanticType(typ))
+		if k := i + offset; k >= 0 && k < len(vs) {
+			v = vs[k]
+		}
+		if err := builder.AppendValue(j, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// percentRanks returns the relative rank of every value, (rank - 1) / (count - 1),
+// where the rank of a value is one plus the number of values lower than it.
+// Null values are not ranked and have a null percent rank.
+func percentRanks(vs []values.Value) []values.Value {
+	idxs := make([]int, 0, len(vs))
+	for i, v := range vs {
+		if !v.IsNull() {
+			idxs = append(idxs, i)
+		}
+	}
+	sort.SliceStable(idxs, func(a, b int) bool {
+		return valueLess(vs[idxs[a]], vs[idxs[b]])
+	})
+
+	ranks := make([]values.Value, len(vs))
+	for i := range ranks {
+		ranks[i] = values.NewNull(semantic.Float)
+	}
+	rank := 0
+	for k, i := range idxs {
+		if k > 0 && valueLess(vs[idxs[k-1]], vs[i]) {
+			rank = k
+		}
+		r := 0.0
+		if len(idxs) > 1 {
+			r = float64(rank) / float64(len(idxs)-1)
+		}
+		ranks[i] = values.NewFloat(r)
+	}
+	return ranks
+}
+
+// valueLess reports whether the non-null value a is lower than the non-null value b of the same type.
+func valueLess(a, b values.Value) bool {
+	switch a.Type().Nature() {
+	case semantic.Bool:
+		return !a.Bool() && b.Bool()
+	case semantic.Int:
+		return a.Int() < b.Int()
+	case semantic.UInt:
+		return a.UInt() < b.UInt()
+	case semantic.Float:
+		return a.Float() < b.Float()
+	case semantic.String:
+		return a.Str() < b.Str()
+	case semantic.Time:
+		return a.Time() < b.Time()
+	default:
+		panic(fmt.Errorf("cannot compare values of type %v", a.Type()))
+	}
+}
+
+func (t *overTransformation) UpdateWatermark(id execute.DatasetID, mark execute.Time) error {
+	return t.d.UpdateWatermark(mark)
+}
+func (t *overTransformation) UpdateProcessingTime(id execute.DatasetID, pt execute.Time) error {
+	return t.d.UpdateProcessingTime(pt)
+}
+func (t *overTransformation) Finish(id execute.DatasetID, err error) {
+	t.d.Finish(err)
+}
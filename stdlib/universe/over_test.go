@@ -0,0 +1,207 @@
+package universe_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/flux/querytest"
+	"github.com/influxdata/flux/stdlib/influxdata/influxdb"
+	"github.com/influxdata/flux/stdlib/universe"
+)
+
+func TestOver_NewQuery(t *testing.T) {
+	tests := []querytest.NewQueryTestCase{
+		{
+			Name: "lag defaults",
+			Raw:  `from(bucket:"mybucket") |> over(function: "lag")`,
+			Want: &flux.Spec{
+				Operations: []*flux.Operation{
+					{
+						ID: "from0",
+						Spec: &influxdb.FromOpSpec{
+							Bucket: "mybucket",
+						},
+					},
+					{
+						ID: "over1",
+						Spec: &universe.OverOpSpec{
+							Function: "lag",
+							Column:   "_value",
+							As:       "lag",
+							Offset:   1,
+						},
+					},
+				},
+				Edges: []flux.Edge{
+					{Parent: "from0", Child: "over1"},
+				},
+			},
+		},
+		{
+			Name: "lead with options",
+			Raw:  `from(bucket:"mybucket") |> over(function: "lead", column: "x", as: "next", offset: 2)`,
+			Want: &flux.Spec{
+				Operations: []*flux.Operation{
+					{
+						ID: "from0",
+						Spec: &influxdb.FromOpSpec{
+							Bucket: "mybucket",
+						},
+					},
+					{
+						ID: "over1",
+						Spec: &universe.OverOpSpec{
+							Function: "lead",
+							Column:   "x",
+							As:       "next",
+							Offset:   2,
+						},
+					},
+				},
+				Edges: []flux.Edge{
+					{Parent: "from0", Child: "over1"},
+				},
+			},
+		},
+		{
+			Name:    "unknown function",
+			Raw:     `from(bucket:"mybucket") |> over(function: "rank")`,
+			WantErr: true,
+		},
+		{
+			Name:    "negative offset",
+			Raw:     `from(bucket:"mybucket") |> over(function: "lag", offset: -1)`,
+			WantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			querytest.NewQueryTestHelper(t, tc)
+		})
+	}
+}
+
+func TestOverOperation_Marshaling(t *testing.T) {
+	data := []byte(`{"id":"over","kind":"over","spec":{"function":"lag","column":"_value","as":"prev","offset":1}}`)
+	op := &flux.Operation{
+		ID: "over",
+		Spec: &universe.OverOpSpec{
+			Function: "lag",
+			Column:   "_value",
+			As:       "prev",
+			Offset:   1,
+		},
+	}
+	querytest.OperationMarshalingTestHelper(t, data, op)
+}
+
+func TestOver_Process(t *testing.T) {
+	input := func() []flux.Table {
+		return []flux.Table{&executetest.Table{
+			KeyCols: []string{"t1"},
+			ColMeta: []flux.ColMeta{
+				{Label: "_time", Type: flux.TTime},
+				{Label: "t1", Type: flux.TString},
+				{Label: "_value", Type: flux.TFloat},
+			},
+			Data: [][]interface{}{
+				{execute.Time(1), "a", 2.0},
+				{execute.Time(2), "a", nil},
+				{execute.Time(3), "a", 1.0},
+				{execute.Time(4), "a", 2.0},
+				{execute.Time(5), "a", 5.0},
+			},
+		}}
+	}
+	output := func(as string, typ flux.ColType, vs ...interface{}) []*executetest.Table {
+		data := [][]interface{}{
+			{execute.Time(1), "a", 2.0},
+			{execute.Time(2), "a", nil},
+			{execute.Time(3), "a", 1.0},
+			{execute.Time(4), "a", 2.0},
+			{execute.Time(5), "a", 5.0},
+		}
+		for i := range data {
+			data[i] = append(data[i], vs[i])
+		}
+		return []*executetest.Table{{
+			KeyCols: []string{"t1"},
+			ColMeta: []flux.ColMeta{
+				{Label: "_time", Type: flux.TTime},
+				{Label: "t1", Type: flux.TString},
+				{Label: "_value", Type: flux.TFloat},
+				{Label: as, Type: typ},
+			},
+			Data: data,
+		}}
+	}
+	testCases := []struct {
+		name    string
+		spec    *universe.OverProcedureSpec
+		data    []flux.Table
+		want    []*executetest.Table
+		wantErr error
+	}{
+		{
+			name: "lag",
+			spec: &universe.OverProcedureSpec{Function: "lag", Column: "_value", As: "prev", Offset: 1},
+			data: input(),
+			want: output("prev", flux.TFloat, nil, 2.0, nil, 1.0, 2.0),
+		},
+		{
+			name: "lead by two",
+			spec: &universe.OverProcedureSpec{Function: "lead", Column: "_value", As: "next", Offset: 2},
+			data: input(),
+			want: output("next", flux.TFloat, 1.0, 2.0, 5.0, nil, nil),
+		},
+		{
+			name: "lag of time",
+			spec: &universe.OverProcedureSpec{Function: "lag", Column: "_time", As: "prevTime", Offset: 1},
+			data: input(),
+			want: output("prevTime", flux.TTime, nil, execute.Time(1), execute.Time(2), execute.Time(3), execute.Time(4)),
+		},
+		{
+			name: "row number",
+			spec: &universe.OverProcedureSpec{Function: "rowNumber", As: "n"},
+			data: input(),
+			want: output("n", flux.TInt, int64(1), int64(2), int64(3), int64(4), int64(5)),
+		},
+		{
+			name: "percent rank",
+			spec: &universe.OverProcedureSpec{Function: "percentRank", Column: "_value", As: "rank"},
+			data: input(),
+			want: output("rank", flux.TFloat, 1.0/3.0, nil, 0.0, 1.0/3.0, 1.0),
+		},
+		{
+			name:    "existing column",
+			spec:    &universe.OverProcedureSpec{Function: "rowNumber", As: "t1"},
+			data:    input(),
+			wantErr: errors.New(`over error: column "t1" already exists`),
+		},
+		{
+			name:    "missing column",
+			spec:    &universe.OverProcedureSpec{Function: "lag", Column: "x", As: "prev", Offset: 1},
+			data:    input(),
+			wantErr: errors.New(`over error: column "x" does not exist`),
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			executetest.ProcessTestHelper(
+				t,
+				tc.data,
+				tc.want,
+				tc.wantErr,
+				func(d execute.Dataset, c execute.TableBuilderCache) execute.Transformation {
+					return universe.NewOverTransformation(d, c, tc.spec)
+				},
+			)
+		})
+	}
+}
@@ -11,12 +11,14 @@ import (
 	"github.com/influxdata/flux/memory"
 	"github.com/influxdata/flux/plan"
 	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/flux/sketch"
 	"github.com/influxdata/flux/values"
-	"github.com/influxdata/tdigest"
 	"github.com/pkg/errors"
 )
 
 const PercentileKind = "percentile"
+const PercentilePartialKind = "percentilePartial"
+const PercentileFinalKind = "percentileFinal"
 const ExactPercentileAggKind = "exact-percentile-aggregate"
 const ExactPercentileSelectKind = "exact-percentile-selector"
 
@@ -32,7 +34,8 @@ type PercentileOpSpec struct {
 	Method      string  `json:"method"`
 	// percentile is either an aggregate, or a selector based on the options
 	execute.AggregateConfig
-	execute.SelectorConfig
+	// Column is the column of the selector.
+	Column string `json:"column"`
 }
 
 func init() {
@@ -42,6 +45,7 @@ func init() {
 			"percentile":  semantic.Float,
 			"compression": semantic.Float,
 			"method":      semantic.String,
+			"nan":         semantic.String,
 		},
 		[]string{"percentile"},
 	)
@@ -53,6 +57,8 @@ func init() {
 	execute.RegisterTransformation(PercentileKind, createPercentileTransformation)
 	execute.RegisterTransformation(ExactPercentileAggKind, createExactPercentileAggTransformation)
 	execute.RegisterTransformation(ExactPercentileSelectKind, createExactPercentileSelectTransformation)
+	execute.RegisterTransformation(PercentilePartialKind, createPercentilePartialTransformation)
+	execute.RegisterTransformation(PercentileFinalKind, createPercentileFinalTransformation)
 }
 
 func createPercentileOpSpec(args flux.Arguments, a *flux.Administration) (flux.OperationSpec, error) {
@@ -96,8 +102,10 @@ func createPercentileOpSpec(args flux.Arguments, a *flux.Administration) (flux.O
 		return nil, err
 	}
 
-	if err := spec.SelectorConfig.ReadArgs(args); err != nil {
+	if col, ok, err := args.GetString("column"); err != nil {
 		return nil, err
+	} else if ok {
+		spec.Column = col
 	}
 
 	return spec, nil
@@ -128,6 +136,57 @@ func (s *TDigestPercentileProcedureSpec) Copy() plan.ProcedureSpec {
 	}
 }
 
+// SplitAggregate implements plan.SplittableAggregateSpec.
+// The partial phase outputs t-digest sketches, which the final phase merges.
+// Only percentiles estimated with an explicit compression can be split.
+func (s *TDigestPercentileProcedureSpec) SplitAggregate() (partial, final plan.PhysicalProcedureSpec, ok bool) {
+	if s.Compression <= 0 {
+		return nil, nil, false
+	}
+	partial = &TDigestPercentilePartialProcedureSpec{
+		Compression:     s.Compression,
+		AggregateConfig: s.AggregateConfig,
+	}
+	final = &TDigestPercentileFinalProcedureSpec{
+		Percentile:      s.Percentile,
+		Compression:     s.Compression,
+		AggregateConfig: s.AggregateConfig,
+	}
+	return partial, final, true
+}
+
+type TDigestPercentilePartialProcedureSpec struct {
+	Compression float64 `json:"compression"`
+	execute.AggregateConfig
+}
+
+func (s *TDigestPercentilePartialProcedureSpec) Kind() plan.ProcedureKind {
+	return PercentilePartialKind
+}
+func (s *TDigestPercentilePartialProcedureSpec) Copy() plan.ProcedureSpec {
+	return &TDigestPercentilePartialProcedureSpec{
+		Compression:     s.Compression,
+		AggregateConfig: s.AggregateConfig,
+	}
+}
+
+type TDigestPercentileFinalProcedureSpec struct {
+	Percentile  float64 `json:"percentile"`
+	Compression float64 `json:"compression"`
+	execute.AggregateConfig
+}
+
+func (s *TDigestPercentileFinalProcedureSpec) Kind() plan.ProcedureKind {
+	return PercentileFinalKind
+}
+func (s *TDigestPercentileFinalProcedureSpec) Copy() plan.ProcedureSpec {
+	return &TDigestPercentileFinalProcedureSpec{
+		Percentile:      s.Percentile,
+		Compression:     s.Compression,
+		AggregateConfig: s.AggregateConfig,
+	}
+}
+
 type ExactPercentileAggProcedureSpec struct {
 	Percentile float64 `json:"percentile"`
 	execute.AggregateConfig
@@ -149,7 +208,7 @@ func (s *ExactPercentileSelectProcedureSpec) Kind() plan.ProcedureKind {
 	return ExactPercentileSelectKind
 }
 func (s *ExactPercentileSelectProcedureSpec) Copy() plan.ProcedureSpec {
-	return &ExactPercentileSelectProcedureSpec{Percentile: s.Percentile}
+	return &ExactPercentileSelectProcedureSpec{Percentile: s.Percentile, SelectorConfig: s.SelectorConfig}
 }
 
 func newPercentileProcedure(qs flux.OperationSpec, a plan.Administration) (plan.ProcedureSpec, error) {
@@ -166,7 +225,8 @@ func newPercentileProcedure(qs flux.OperationSpec, a plan.Administration) (plan.
 		}, nil
 	case methodExactSelector:
 		return &ExactPercentileSelectProcedureSpec{
-			Percentile: spec.Percentile,
+			Percentile:     spec.Percentile,
+			SelectorConfig: execute.SelectorConfig{Column: spec.Column},
 		}, nil
 	case methodEstimateTdigest:
 		fallthrough
@@ -184,7 +244,7 @@ type PercentileAgg struct {
 	Quantile,
 	Compression float64
 
-	digest *tdigest.TDigest
+	digest *sketch.TDigest
 	ok     bool
 }
 
@@ -203,7 +263,7 @@ func createPercentileTransformation(id execute.DatasetID, mode execute.Accumulat
 func (a *PercentileAgg) Copy() *PercentileAgg {
 	na := new(PercentileAgg)
 	*na = *a
-	na.digest = tdigest.NewWithCompression(na.Compression)
+	na.digest = sketch.NewTDigest(na.Compression)
 	return na
 }
 
@@ -212,11 +272,11 @@ func (a *PercentileAgg) NewBoolAgg() execute.DoBoolAgg {
 }
 
 func (a *PercentileAgg) NewIntAgg() execute.DoIntAgg {
-	return nil
+	return a.Copy()
 }
 
 func (a *PercentileAgg) NewUIntAgg() execute.DoUIntAgg {
-	return nil
+	return a.Copy()
 }
 
 func (a *PercentileAgg) NewFloatAgg() execute.DoFloatAgg {
@@ -227,15 +287,31 @@ func (a *PercentileAgg) NewStringAgg() execute.DoStringAgg {
 	return nil
 }
 
-func (a *PercentileAgg) DoFloat(vs *array.Float64) {
+func (a *PercentileAgg) DoInt(vs *array.Int64) {
 	for i := 0; i < vs.Len(); i++ {
 		if vs.IsValid(i) {
-			a.digest.Add(vs.Value(i), 1)
+			a.digest.Add(float64(vs.Value(i)), 1)
 			a.ok = true
 		}
 	}
 }
 
+func (a *PercentileAgg) DoUInt(vs *array.Uint64) {
+	for i := 0; i < vs.Len(); i++ {
+		if vs.IsValid(i) {
+			a.digest.Add(float64(vs.Value(i)), 1)
+			a.ok = true
+		}
+	}
+}
+
+func (a *PercentileAgg) DoFloat(vs *array.Float64) {
+	a.digest.AddFloats(vs)
+	if vs.Len() > vs.NullN() {
+		a.ok = true
+	}
+}
+
 func (a *PercentileAgg) Type() flux.ColType {
 	return flux.TFloat
 }
@@ -248,6 +324,101 @@ func (a *PercentileAgg) IsNull() bool {
 	return !a.ok
 }
 
+func createPercentilePartialTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	ps, ok := spec.(*TDigestPercentilePartialProcedureSpec)
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid spec type %T", ps)
+	}
+	agg := &PercentilePartialAgg{PercentileAgg{Compression: ps.Compression}}
+	t, d := execute.NewAggregateTransformationAndDataset(id, mode, agg, ps.AggregateConfig, a.Allocator())
+	return t, d, nil
+}
+
+// PercentilePartialAgg outputs the serialized t-digest sketch of the values instead of a percentile.
+type PercentilePartialAgg struct {
+	PercentileAgg
+}
+
+func (a *PercentilePartialAgg) NewIntAgg() execute.DoIntAgg {
+	return &PercentilePartialAgg{*a.Copy()}
+}
+
+func (a *PercentilePartialAgg) NewUIntAgg() execute.DoUIntAgg {
+	return &PercentilePartialAgg{*a.Copy()}
+}
+
+func (a *PercentilePartialAgg) NewFloatAgg() execute.DoFloatAgg {
+	return &PercentilePartialAgg{*a.Copy()}
+}
+
+func (a *PercentilePartialAgg) Type() flux.ColType {
+	return flux.TString
+}
+
+func (a *PercentilePartialAgg) ValueString() string {
+	data, _ := a.digest.MarshalBinary()
+	return string(data)
+}
+
+func (a *PercentilePartialAgg) IsNull() bool {
+	return false
+}
+
+func createPercentileFinalTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	ps, ok := spec.(*TDigestPercentileFinalProcedureSpec)
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid spec type %T", ps)
+	}
+	agg := &PercentileFinalAgg{
+		PercentileAgg: PercentileAgg{
+			Quantile:    ps.Percentile,
+			Compression: ps.Compression,
+		},
+	}
+	t, d := execute.NewAggregateTransformationAndDataset(id, mode, agg, ps.AggregateConfig, a.Allocator())
+	return t, d, nil
+}
+
+// PercentileFinalAgg merges the t-digest sketches output by PercentilePartialAgg
+// and estimates the percentile of the values they have seen.
+type PercentileFinalAgg struct {
+	PercentileAgg
+	err error
+}
+
+func (a *PercentileFinalAgg) NewIntAgg() execute.DoIntAgg {
+	return nil
+}
+
+func (a *PercentileFinalAgg) NewUIntAgg() execute.DoUIntAgg {
+	return nil
+}
+
+func (a *PercentileFinalAgg) NewFloatAgg() execute.DoFloatAgg {
+	return nil
+}
+
+func (a *PercentileFinalAgg) NewStringAgg() execute.DoStringAgg {
+	return &PercentileFinalAgg{PercentileAgg: *a.Copy()}
+}
+
+func (a *PercentileFinalAgg) DoString(vs *array.Binary) {
+	// The sketches are merged from their serialized centroids, without unmarshaling them first.
+	for i := 0; i < vs.Len() && a.err == nil; i++ {
+		if vs.IsValid(i) {
+			a.err = a.digest.MergeBinary(vs.Value(i))
+		}
+	}
+}
+
+func (a *PercentileFinalAgg) IsNull() bool {
+	return a.digest.Count() == 0
+}
+
+func (a *PercentileFinalAgg) Err() error {
+	return a.err
+}
+
 type ExactPercentileAgg struct {
 	Quantile float64
 	data     []float64
@@ -276,11 +447,11 @@ func (a *ExactPercentileAgg) NewBoolAgg() execute.DoBoolAgg {
 }
 
 func (a *ExactPercentileAgg) NewIntAgg() execute.DoIntAgg {
-	return nil
+	return a.Copy()
 }
 
 func (a *ExactPercentileAgg) NewUIntAgg() execute.DoUIntAgg {
-	return nil
+	return a.Copy()
 }
 
 func (a *ExactPercentileAgg) NewFloatAgg() execute.DoFloatAgg {
@@ -291,6 +462,22 @@ func (a *ExactPercentileAgg) NewStringAgg() execute.DoStringAgg {
 	return nil
 }
 
+func (a *ExactPercentileAgg) DoInt(vs *array.Int64) {
+	for i := 0; i < vs.Len(); i++ {
+		if vs.IsValid(i) {
+			a.data = append(a.data, float64(vs.Value(i)))
+		}
+	}
+}
+
+func (a *ExactPercentileAgg) DoUInt(vs *array.Uint64) {
+	for i := 0; i < vs.Len(); i++ {
+		if vs.IsValid(i) {
+			a.data = append(a.data, float64(vs.Value(i)))
+		}
+	}
+}
+
 func (a *ExactPercentileAgg) DoFloat(vs *array.Float64) {
 	if vs.NullN() == 0 {
 		a.data = append(a.data, vs.Float64Values()...)
@@ -191,6 +191,83 @@ func TestPercentile_Process(t *testing.T) {
 	}
 }
 
+func TestPercentile_ProcessIntegers(t *testing.T) {
+	for _, exact := range []bool{false, true} {
+		var agg execute.Aggregate
+		if exact {
+			agg = &universe.ExactPercentileAgg{Quantile: 0.75}
+		} else {
+			agg = &universe.PercentileAgg{Quantile: 0.75, Compression: 1000}
+		}
+
+		ints := arrow.NewInt([]int64{1, -2, 3, -4, 5, 5, -4, 3, -2, 1}, nil)
+		ia := agg.NewIntAgg()
+		ia.DoInt(ints)
+		ints.Release()
+		if got, want := ia.(execute.FloatValueFunc).ValueFloat(), 3.0; got != want {
+			t.Errorf("unexpected int percentile, exact=%v: want %v, got %v", exact, want, got)
+		}
+
+		uints := arrow.NewUint([]uint64{1, 2, 3, 4, 5, 5, 4, 3, 2, 1}, nil)
+		ua := agg.NewUIntAgg()
+		ua.DoUInt(uints)
+		uints.Release()
+		if got, want := ua.(execute.FloatValueFunc).ValueFloat(), 4.0; got != want {
+			t.Errorf("unexpected uint percentile, exact=%v: want %v, got %v", exact, want, got)
+		}
+	}
+}
+
+func TestPercentile_SplitAggregate(t *testing.T) {
+	testCases := []struct {
+		name       string
+		data       func() *array.Float64
+		percentile float64
+		want       interface{}
+	}{
+		{
+			name: "50th",
+			data: func() *array.Float64 {
+				return arrow.NewFloat([]float64{1, 2, 3, 4, 5, 5, 4, 3, 2, 1}, nil)
+			},
+			percentile: 0.5,
+			want:       3.0,
+		},
+		{
+			name: "90th",
+			data: func() *array.Float64 {
+				return arrow.NewFloat([]float64{1, 2, 3, 4, 5, 5, 4, 3, 2, 1}, nil)
+			},
+			percentile: 0.9,
+			want:       5.0,
+		},
+		{
+			name: "empty",
+			data: func() *array.Float64 {
+				return arrow.NewFloat(nil, nil)
+			},
+			percentile: 0.5,
+			want:       nil,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			agg := universe.PercentileAgg{
+				Quantile:    tc.percentile,
+				Compression: 1000,
+			}
+			executetest.SplitAggFuncTestHelper(
+				t,
+				&universe.PercentilePartialAgg{PercentileAgg: agg},
+				&universe.PercentileFinalAgg{PercentileAgg: agg},
+				tc.data(),
+				tc.want,
+			)
+		})
+	}
+}
+
 func TestPercentileSelector_Process(t *testing.T) {
 	testCases := []struct {
 		name     string
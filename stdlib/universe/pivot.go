@@ -21,6 +21,9 @@ type PivotOpSpec struct {
 	RowKey      []string `json:"rowKey"`
 	ColumnKey   []string `json:"columnKey"`
 	ValueColumn string   `json:"valueColumn"`
+	Missing     string   `json:"missing,omitempty"`
+	// Defaults are the values of the missing cells of the columns with the default missing mode.
+	Defaults values.Object `json:"-"`
 }
 
 func init() {
@@ -29,6 +32,8 @@ func init() {
 			"rowKey":      semantic.NewArrayPolyType(semantic.String),
 			"columnKey":   semantic.NewArrayPolyType(semantic.String),
 			"valueColumn": semantic.String,
+			"missing":     semantic.String,
+			"defaults":    semantic.NewObjectPolyType(nil, nil, semantic.AllLabels()),
 		},
 		[]string{"rowKey", "columnKey", "valueColumn"},
 	)
@@ -84,6 +89,11 @@ func createPivotOpSpec(args flux.Arguments, a *flux.Administration) (flux.Operat
 	}
 	spec.ValueColumn = valueCol
 
+	spec.Missing, spec.Defaults, err = getMissing(args, PivotKind, MissingNull)
+	if err != nil {
+		return nil, err
+	}
+
 	return spec, nil
 }
 
@@ -100,6 +110,8 @@ type PivotProcedureSpec struct {
 	RowKey      []string
 	ColumnKey   []string
 	ValueColumn string
+	Missing     string
+	Defaults    values.Object
 }
 
 func newPivotProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
@@ -112,6 +124,8 @@ func newPivotProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.Proc
 		RowKey:      spec.RowKey,
 		ColumnKey:   spec.ColumnKey,
 		ValueColumn: spec.ValueColumn,
+		Missing:     spec.Missing,
+		Defaults:    spec.Defaults,
 	}
 
 	return p, nil
@@ -127,9 +141,28 @@ func (s *PivotProcedureSpec) Copy() plan.ProcedureSpec {
 	ns.ColumnKey = make([]string, len(s.ColumnKey))
 	copy(ns.ColumnKey, s.ColumnKey)
 	ns.ValueColumn = s.ValueColumn
+	ns.Missing = s.Missing
+	ns.Defaults = s.Defaults
 	return ns
 }
 
+// OutputSchema implements plan.SchemaProcedureSpec.
+// The columns created from the values of the column key are only known at runtime,
+// so the schema is open and has the row key columns of the input.
+func (s *PivotProcedureSpec) OutputSchema(predecessors []*plan.Schema) *plan.Schema {
+	in := predecessors[0]
+	if in == nil {
+		return nil
+	}
+	out := &plan.Schema{Open: true}
+	for _, label := range s.RowKey {
+		if c, ok := in.Column(label); ok {
+			out.Columns = append(out.Columns, c)
+		}
+	}
+	return out
+}
+
 func createPivotTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
 	s, ok := spec.(*PivotProcedureSpec)
 	if !ok {
@@ -147,6 +180,10 @@ type rowCol struct {
 	nextRow int
 }
 
+type pivotCell struct {
+	row, col int
+}
+
 type pivotTransformation struct {
 	d     execute.Dataset
 	cache execute.TableBuilderCache
@@ -155,6 +192,9 @@ type pivotTransformation struct {
 	colKeyMaps map[string]map[string]int
 	rowKeyMaps map[string]map[string]int
 	nextRowCol map[string]rowCol
+	// cells records the pivoted cells that have a value in the input of each table,
+	// unless the missing cells are null, which they are when they are created.
+	cells map[string]map[pivotCell]bool
 }
 
 func NewPivotTransformation(d execute.Dataset, cache execute.TableBuilderCache, spec *PivotProcedureSpec) *pivotTransformation {
@@ -166,6 +206,9 @@ func NewPivotTransformation(d execute.Dataset, cache execute.TableBuilderCache,
 		rowKeyMaps: make(map[string]map[string]int),
 		nextRowCol: make(map[string]rowCol),
 	}
+	if spec.Missing == MissingDrop || spec.Missing == MissingDefault {
+		t.cells = make(map[string]map[pivotCell]bool)
+	}
 	return t
 }
 
@@ -241,6 +284,9 @@ func (t *pivotTransformation) Process(id execute.DatasetID, tbl flux.Table) erro
 		t.colKeyMaps[groupKeyString] = make(map[string]int)
 		t.rowKeyMaps[groupKeyString] = make(map[string]int)
 		t.nextRowCol[groupKeyString] = rowCol{nextCol: len(cols), nextRow: 0}
+		if t.cells != nil {
+			t.cells[groupKeyString] = make(map[pivotCell]bool)
+		}
 	}
 
 	return tbl.Do(func(cr flux.ColReader) error {
@@ -300,9 +346,13 @@ func (t *pivotTransformation) Process(id execute.DatasetID, tbl flux.Table) erro
 			// if we found a new row key, we added a new row with zeroes set for all the value columns
 			// so in all cases we know the row exists, and the column exists.  we need to grab the
 			// value from valueCol and assign it to its pivoted position.
-			if err := builder.SetValue(t.rowKeyMaps[groupKeyString][rowKey], t.colKeyMaps[groupKeyString][colKey], execute.ValueForRow(cr, row, valueColIndex)); err != nil {
+			cell := pivotCell{row: t.rowKeyMaps[groupKeyString][rowKey], col: t.colKeyMaps[groupKeyString][colKey]}
+			if err := builder.SetValue(cell.row, cell.col, execute.ValueForRow(cr, row, valueColIndex)); err != nil {
 				return err
 			}
+			if t.cells != nil {
+				t.cells[groupKeyString][cell] = true
+			}
 
 		}
 		return nil
@@ -373,6 +423,73 @@ func (t *pivotTransformation) UpdateProcessingTime(id execute.DatasetID, pt exec
 }
 
 func (t *pivotTransformation) Finish(id execute.DatasetID, err error) {
-
+	if err == nil && t.cells != nil {
+		t.cache.ForEachBuilder(func(key flux.GroupKey, builder execute.TableBuilder) {
+			if err == nil {
+				err = t.fillMissing(key.String(), builder)
+			}
+		})
+	}
 	t.d.Finish(err)
 }
+
+// fillMissing sets the missing cells of a table to their default, or drops the rows that have missing cells,
+// once the table has all its rows.
+func (t *pivotTransformation) fillMissing(groupKeyString string, builder execute.TableBuilder) error {
+	cells := t.cells[groupKeyString]
+	pivoted := make([]int, 0, len(t.colKeyMaps[groupKeyString]))
+	for _, j := range t.colKeyMaps[groupKeyString] {
+		pivoted = append(pivoted, j)
+	}
+	complete := func(row int) bool {
+		for _, j := range pivoted {
+			if !cells[pivotCell{row: row, col: j}] {
+				return false
+			}
+		}
+		return true
+	}
+
+	if t.spec.Missing == MissingDefault {
+		for _, j := range pivoted {
+			v, err := missingValue(t.spec.Defaults, builder.Cols()[j])
+			if err != nil {
+				return fmt.Errorf("%s: %v", PivotKind, err)
+			}
+			if v.IsNull() {
+				continue
+			}
+			for row := 0; row < builder.NRows(); row++ {
+				if cells[pivotCell{row: row, col: j}] {
+					continue
+				}
+				if err := builder.SetValue(row, j, v); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+
+	// The rows are dropped by appending the complete rows of a copy of the table to the cleared builder.
+	tbl, err := builder.Table()
+	if err != nil {
+		return err
+	}
+	builder.ClearData()
+	offset := 0
+	return tbl.Do(func(cr flux.ColReader) error {
+		for row := 0; row < cr.Len(); row++ {
+			if !complete(offset + row) {
+				continue
+			}
+			for j := range cr.Cols() {
+				if err := builder.AppendValue(j, execute.ValueForRow(cr, row, j)); err != nil {
+					return err
+				}
+			}
+		}
+		offset += cr.Len()
+		return nil
+	})
+}
@@ -1,6 +1,7 @@
 package universe_test
 
 import (
+	"errors"
 	"testing"
 	"time"
 
@@ -10,6 +11,7 @@ import (
 	"github.com/influxdata/flux/querytest"
 	"github.com/influxdata/flux/stdlib/influxdata/influxdb"
 	"github.com/influxdata/flux/stdlib/universe"
+	"github.com/influxdata/flux/values"
 )
 
 func TestPivot_NewQuery(t *testing.T) {
@@ -46,6 +48,7 @@ func TestPivot_NewQuery(t *testing.T) {
 							RowKey:      []string{"_time"},
 							ColumnKey:   []string{"_measurement", "_field"},
 							ValueColumn: "_value",
+							Missing:     "null",
 						},
 					},
 				},
@@ -60,6 +63,11 @@ func TestPivot_NewQuery(t *testing.T) {
 			Raw:     `from(bucket:"testdb") |> range(start: -1h) |> pivot(rowKey: ["_time", "a"], columnKey: ["_measurement", "_field", "a"], valueColumn: "_value")`,
 			WantErr: true,
 		},
+		{
+			Name:    "unknown missing mode",
+			Raw:     `from(bucket:"testdb") |> range(start: -1h) |> pivot(rowKey: ["_time"], columnKey: ["_field"], valueColumn: "_value", missing: "zero")`,
+			WantErr: true,
+		},
 	}
 	for _, tc := range tests {
 		tc := tc
@@ -93,10 +101,11 @@ func TestPivotOperation_Marshaling(t *testing.T) {
 
 func TestPivot_Process(t *testing.T) {
 	testCases := []struct {
-		name string
-		spec *universe.PivotProcedureSpec
-		data []flux.Table
-		want []*executetest.Table
+		name    string
+		spec    *universe.PivotProcedureSpec
+		data    []flux.Table
+		want    []*executetest.Table
+		wantErr error
 	}{
 		{
 			name: "_field flatten case one table",
@@ -669,6 +678,164 @@ func TestPivot_Process(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "missing null",
+			spec: &universe.PivotProcedureSpec{
+				RowKey:      []string{"_time"},
+				ColumnKey:   []string{"_field"},
+				ValueColumn: "_value",
+				Missing:     "null",
+			},
+			data: []flux.Table{
+				&executetest.Table{
+					KeyCols: []string{"_measurement"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_value", Type: flux.TFloat},
+						{Label: "_measurement", Type: flux.TString},
+						{Label: "_field", Type: flux.TString},
+					},
+					Data: [][]interface{}{
+						{execute.Time(1), 1.0, "m1", "f1"},
+						{execute.Time(1), 2.0, "m1", "f2"},
+						{execute.Time(2), 3.0, "m1", "f1"},
+						{execute.Time(3), 4.0, "m1", "f1"},
+						{execute.Time(3), nil, "m1", "f2"},
+					},
+				},
+			},
+			want: []*executetest.Table{
+				{
+					KeyCols: []string{"_measurement"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_measurement", Type: flux.TString},
+						{Label: "f1", Type: flux.TFloat},
+						{Label: "f2", Type: flux.TFloat},
+					},
+					Data: [][]interface{}{
+						{execute.Time(1), "m1", 1.0, 2.0},
+						{execute.Time(2), "m1", 3.0, nil},
+						{execute.Time(3), "m1", 4.0, nil},
+					},
+				},
+			},
+		},
+		{
+			name: "missing drop",
+			spec: &universe.PivotProcedureSpec{
+				RowKey:      []string{"_time"},
+				ColumnKey:   []string{"_field"},
+				ValueColumn: "_value",
+				Missing:     "drop",
+			},
+			data: []flux.Table{
+				&executetest.Table{
+					KeyCols: []string{"_measurement"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_value", Type: flux.TFloat},
+						{Label: "_measurement", Type: flux.TString},
+						{Label: "_field", Type: flux.TString},
+					},
+					Data: [][]interface{}{
+						{execute.Time(1), 1.0, "m1", "f1"},
+						{execute.Time(1), 2.0, "m1", "f2"},
+						{execute.Time(2), 3.0, "m1", "f1"},
+						{execute.Time(3), 4.0, "m1", "f1"},
+						{execute.Time(3), nil, "m1", "f2"},
+					},
+				},
+			},
+			want: []*executetest.Table{
+				{
+					KeyCols: []string{"_measurement"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_measurement", Type: flux.TString},
+						{Label: "f1", Type: flux.TFloat},
+						{Label: "f2", Type: flux.TFloat},
+					},
+					Data: [][]interface{}{
+						{execute.Time(1), "m1", 1.0, 2.0},
+						{execute.Time(3), "m1", 4.0, nil},
+					},
+				},
+			},
+		},
+		{
+			name: "missing default",
+			spec: &universe.PivotProcedureSpec{
+				RowKey:      []string{"_time"},
+				ColumnKey:   []string{"_field"},
+				ValueColumn: "_value",
+				Missing:     "default",
+				Defaults:    values.NewObjectWithValues(map[string]values.Value{"f2": values.NewFloat(-1)}),
+			},
+			data: []flux.Table{
+				&executetest.Table{
+					KeyCols: []string{"_measurement"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_value", Type: flux.TFloat},
+						{Label: "_measurement", Type: flux.TString},
+						{Label: "_field", Type: flux.TString},
+					},
+					Data: [][]interface{}{
+						{execute.Time(1), 1.0, "m1", "f1"},
+						{execute.Time(1), 2.0, "m1", "f2"},
+						{execute.Time(2), 3.0, "m1", "f1"},
+						{execute.Time(3), 4.0, "m1", "f1"},
+						{execute.Time(3), nil, "m1", "f2"},
+					},
+				},
+			},
+			want: []*executetest.Table{
+				{
+					KeyCols: []string{"_measurement"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_measurement", Type: flux.TString},
+						{Label: "f1", Type: flux.TFloat},
+						{Label: "f2", Type: flux.TFloat},
+					},
+					Data: [][]interface{}{
+						{execute.Time(1), "m1", 1.0, 2.0},
+						{execute.Time(2), "m1", 3.0, -1.0},
+						{execute.Time(3), "m1", 4.0, nil},
+					},
+				},
+			},
+		},
+		{
+			name: "missing default of another type",
+			spec: &universe.PivotProcedureSpec{
+				RowKey:      []string{"_time"},
+				ColumnKey:   []string{"_field"},
+				ValueColumn: "_value",
+				Missing:     "default",
+				Defaults:    values.NewObjectWithValues(map[string]values.Value{"f2": values.NewString("none")}),
+			},
+			data: []flux.Table{
+				&executetest.Table{
+					KeyCols: []string{"_measurement"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_value", Type: flux.TFloat},
+						{Label: "_measurement", Type: flux.TString},
+						{Label: "_field", Type: flux.TString},
+					},
+					Data: [][]interface{}{
+						{execute.Time(1), 1.0, "m1", "f1"},
+						{execute.Time(1), 2.0, "m1", "f2"},
+						{execute.Time(2), 3.0, "m1", "f1"},
+						{execute.Time(3), 4.0, "m1", "f1"},
+						{execute.Time(3), nil, "m1", "f2"},
+					},
+				},
+			},
+			wantErr: errors.New(`pivot: default type mismatch for column "f2": float/string`),
+		},
 	}
 	for _, tc := range testCases {
 		tc := tc
@@ -677,7 +844,7 @@ func TestPivot_Process(t *testing.T) {
 				t,
 				tc.data,
 				tc.want,
-				nil,
+				tc.wantErr,
 				func(d execute.Dataset, c execute.TableBuilderCache) execute.Transformation {
 					return universe.NewPivotTransformation(d, c, tc.spec)
 				},
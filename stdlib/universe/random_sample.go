@@ -0,0 +1,186 @@
+package universe
+
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/semantic"
+)
+
+const RandomSampleKind = "randomSample"
+
+type RandomSampleOpSpec struct {
+	Fraction float64 `json:"fraction"`
+	// Seed seeds the random selection of the records.
+	// When nil, the selection is not reproducible.
+	Seed *int64 `json:"seed,omitempty"`
+	execute.SelectorConfig
+}
+
+func init() {
+	randomSampleSignature := execute.SelectorSignature(
+		map[string]semantic.PolyType{
+			"fraction": semantic.Float,
+			"seed":     semantic.Int,
+		},
+		[]string{"fraction"},
+	)
+
+	flux.RegisterPackageValue("universe", RandomSampleKind, flux.FunctionValue(RandomSampleKind, createRandomSampleOpSpec, randomSampleSignature))
+	flux.RegisterOpSpec(RandomSampleKind, newRandomSampleOp)
+	plan.RegisterProcedureSpec(RandomSampleKind, newRandomSampleProcedure, RandomSampleKind)
+	execute.RegisterTransformation(RandomSampleKind, createRandomSampleTransformation)
+}
+
+func createRandomSampleOpSpec(args flux.Arguments, a *flux.Administration) (flux.OperationSpec, error) {
+	if err := a.AddParentFromArgs(args); err != nil {
+		return nil, err
+	}
+
+	spec := new(RandomSampleOpSpec)
+
+	fraction, err := args.GetRequiredFloat("fraction")
+	if err != nil {
+		return nil, err
+	} else if fraction <= 0 || fraction > 1 {
+		return nil, fmt.Errorf("fraction must be in the range (0, 1], but was %v", fraction)
+	}
+	spec.Fraction = fraction
+
+	if seed, ok, err := args.GetInt("seed"); err != nil {
+		return nil, err
+	} else if ok {
+		spec.Seed = &seed
+	}
+
+	if err := spec.SelectorConfig.ReadArgs(args); err != nil {
+		return nil, err
+	}
+
+	return spec, nil
+}
+
+func newRandomSampleOp() flux.OperationSpec {
+	return new(RandomSampleOpSpec)
+}
+
+func (s *RandomSampleOpSpec) Kind() flux.OperationKind {
+	return RandomSampleKind
+}
+
+type RandomSampleProcedureSpec struct {
+	Fraction float64
+	Seed     *int64
+	execute.SelectorConfig
+}
+
+func newRandomSampleProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	spec, ok := qs.(*RandomSampleOpSpec)
+	if !ok {
+		return nil, fmt.Errorf("invalid spec type %T", qs)
+	}
+	return &RandomSampleProcedureSpec{
+		Fraction:       spec.Fraction,
+		Seed:           spec.Seed,
+		SelectorConfig: spec.SelectorConfig,
+	}, nil
+}
+
+func (s *RandomSampleProcedureSpec) Kind() plan.ProcedureKind {
+	return RandomSampleKind
+}
+func (s *RandomSampleProcedureSpec) Copy() plan.ProcedureSpec {
+	ns := new(RandomSampleProcedureSpec)
+	ns.Fraction = s.Fraction
+	if s.Seed != nil {
+		seed := *s.Seed
+		ns.Seed = &seed
+	}
+	ns.SelectorConfig = s.SelectorConfig
+	return ns
+}
+
+// RandomSampleSelector selects each record independently with a probability of Fraction.
+type RandomSampleSelector struct {
+	Fraction float64
+	// Rand is the source of the random selection.
+	// When nil, the default source of the math/rand package is used.
+	Rand *rand.Rand
+
+	selected []int
+}
+
+func createRandomSampleTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	ps, ok := spec.(*RandomSampleProcedureSpec)
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid spec type %T", ps)
+	}
+
+	ss := &RandomSampleSelector{
+		Fraction: ps.Fraction,
+	}
+	if ps.Seed != nil {
+		ss.Rand = rand.New(rand.NewSource(*ps.Seed))
+	}
+	t, d := execute.NewIndexSelectorTransformationAndDataset(id, mode, ss, ps.SelectorConfig, a.Allocator())
+	return t, d, nil
+}
+
+func (s *RandomSampleSelector) NewBoolSelector() execute.DoBoolIndexSelector {
+	return s
+}
+
+func (s *RandomSampleSelector) NewIntSelector() execute.DoIntIndexSelector {
+	return s
+}
+
+func (s *RandomSampleSelector) NewUIntSelector() execute.DoUIntIndexSelector {
+	return s
+}
+
+func (s *RandomSampleSelector) NewFloatSelector() execute.DoFloatIndexSelector {
+	return s
+}
+
+func (s *RandomSampleSelector) NewStringSelector() execute.DoStringIndexSelector {
+	return s
+}
+
+func (s *RandomSampleSelector) float64() float64 {
+	if s.Rand != nil {
+		return s.Rand.Float64()
+	}
+	return rand.Float64()
+}
+
+func (s *RandomSampleSelector) selectSample(l int) []int {
+	s.selected = s.selected[0:0]
+	for i := 0; i < l; i++ {
+		// A random number is drawn for every record, even when Fraction is 1,
+		// so that the selection only depends on the seed and on the input.
+		if s.float64() < s.Fraction {
+			s.selected = append(s.selected, i)
+		}
+	}
+	return s.selected
+}
+
+func (s *RandomSampleSelector) DoBool(vs *array.Boolean) []int {
+	return s.selectSample(vs.Len())
+}
+func (s *RandomSampleSelector) DoInt(vs *array.Int64) []int {
+	return s.selectSample(vs.Len())
+}
+func (s *RandomSampleSelector) DoUInt(vs *array.Uint64) []int {
+	return s.selectSample(vs.Len())
+}
+func (s *RandomSampleSelector) DoFloat(vs *array.Float64) []int {
+	return s.selectSample(vs.Len())
+}
+func (s *RandomSampleSelector) DoString(vs *array.Binary) []int {
+	return s.selectSample(vs.Len())
+}
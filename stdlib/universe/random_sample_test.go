@@ -0,0 +1,101 @@
+package universe_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/flux/querytest"
+	"github.com/influxdata/flux/stdlib/universe"
+)
+
+func TestRandomSampleOperation_Marshaling(t *testing.T) {
+	seed := int64(42)
+	data := []byte(`{"id":"randomSample","kind":"randomSample","spec":{"fraction":0.5,"seed":42}}`)
+	op := &flux.Operation{
+		ID: "randomSample",
+		Spec: &universe.RandomSampleOpSpec{
+			Fraction: 0.5,
+			Seed:     &seed,
+		},
+	}
+
+	querytest.OperationMarshalingTestHelper(t, data, op)
+}
+
+func TestRandomSample_Process(t *testing.T) {
+	data := &executetest.Table{
+		KeyCols: []string{"t1"},
+		ColMeta: []flux.ColMeta{
+			{Label: "_time", Type: flux.TTime},
+			{Label: "_value", Type: flux.TFloat},
+			{Label: "t1", Type: flux.TString},
+		},
+		Data: [][]interface{}{
+			{execute.Time(0), 7.0, "a"},
+			{execute.Time(10), 5.0, "a"},
+			{execute.Time(20), 9.0, "a"},
+			{execute.Time(30), 4.0, "a"},
+			{execute.Time(40), 6.0, "a"},
+		},
+	}
+	t.Run("fraction of one", func(t *testing.T) {
+		executetest.IndexSelectorFuncTestHelper(
+			t,
+			&universe.RandomSampleSelector{Fraction: 1},
+			data,
+			[][]int{{0, 1, 2, 3, 4}},
+		)
+	})
+	t.Run("same seed", func(t *testing.T) {
+		// The selection for a seed is computed the same way the selector draws its numbers.
+		r := rand.New(rand.NewSource(7))
+		var want []int
+		for i := 0; i < len(data.Data); i++ {
+			if r.Float64() < 0.5 {
+				want = append(want, i)
+			}
+		}
+		executetest.IndexSelectorFuncTestHelper(
+			t,
+			&universe.RandomSampleSelector{Fraction: 0.5, Rand: rand.New(rand.NewSource(7))},
+			data,
+			[][]int{want},
+		)
+	})
+}
+
+func TestRandomSample_Reproducible(t *testing.T) {
+	sample := func() []int {
+		s := (&universe.RandomSampleSelector{
+			Fraction: 0.3,
+			Rand:     rand.New(rand.NewSource(1)),
+		}).NewFloatSelector()
+		var selected []int
+		if err := NormalTable.Do(func(cr flux.ColReader) error {
+			selected = append(selected, s.DoFloat(cr.Floats(execute.ColIdx(execute.DefaultValueColLabel, cr.Cols())))...)
+			return nil
+		}); err != nil {
+			t.Fatal(err)
+		}
+		return selected
+	}
+	first, second := sample(), sample()
+	if len(first) == 0 {
+		t.Fatal("expected some records to be selected")
+	}
+	if !cmp.Equal(first, second) {
+		t.Errorf("unexpected selection for the same seed -first/+second\n%s", cmp.Diff(first, second))
+	}
+}
+
+func BenchmarkRandomSample(b *testing.B) {
+	ss := &universe.RandomSampleSelector{
+		Fraction: 0.1,
+		Rand:     rand.New(rand.NewSource(0)),
+	}
+	executetest.IndexSelectorFuncBenchmarkHelper(b, ss, NormalTable)
+}
@@ -162,6 +162,28 @@ func (s *RangeProcedureSpec) Copy() plan.ProcedureSpec {
 	return ns
 }
 
+// SortedBy implements plan.OrderedProcedureSpec, range keeps the order of the rows of its input.
+func (s *RangeProcedureSpec) SortedBy(predecessors [][]string) []string {
+	return predecessors[0]
+}
+
+// OutputSchema implements plan.SchemaProcedureSpec,
+// range adds the start and stop columns to its input if they are missing.
+func (s *RangeProcedureSpec) OutputSchema(predecessors []*plan.Schema) *plan.Schema {
+	in := predecessors[0]
+	if in == nil {
+		return nil
+	}
+	out := &plan.Schema{Open: in.Open}
+	for _, label := range []string{s.StartColumn, s.StopColumn} {
+		if _, ok := in.Column(label); !ok {
+			out.Columns = append(out.Columns, flux.ColMeta{Label: label, Type: flux.TTime})
+		}
+	}
+	out.Columns = append(out.Columns, in.Columns...)
+	return out
+}
+
 func createRangeTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
 	s, ok := spec.(*RangeProcedureSpec)
 	if !ok {
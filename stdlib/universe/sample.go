@@ -17,14 +17,18 @@ const SampleKind = "sample"
 type SampleOpSpec struct {
 	N   int64 `json:"n"`
 	Pos int64 `json:"pos"`
+	// Seed seeds the random offset used when no pos is given.
+	// When nil, the offset is not reproducible.
+	Seed *int64 `json:"seed,omitempty"`
 	execute.SelectorConfig
 }
 
 func init() {
 	sampleSignature := execute.SelectorSignature(
 		map[string]semantic.PolyType{
-			"n":   semantic.Int,
-			"pos": semantic.Int,
+			"n":    semantic.Int,
+			"pos":  semantic.Int,
+			"seed": semantic.Int,
 		},
 		[]string{"n"},
 	)
@@ -61,6 +65,12 @@ func createSampleOpSpec(args flux.Arguments, a *flux.Administration) (flux.Opera
 		spec.Pos = -1
 	}
 
+	if seed, ok, err := args.GetInt("seed"); err != nil {
+		return nil, err
+	} else if ok {
+		spec.Seed = &seed
+	}
+
 	if err := spec.SelectorConfig.ReadArgs(args); err != nil {
 		return nil, err
 	}
@@ -77,8 +87,9 @@ func (s *SampleOpSpec) Kind() flux.OperationKind {
 }
 
 type SampleProcedureSpec struct {
-	N   int64
-	Pos int64
+	N    int64
+	Pos  int64
+	Seed *int64
 	execute.SelectorConfig
 }
 
@@ -90,6 +101,7 @@ func newSampleProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.Pro
 	return &SampleProcedureSpec{
 		N:              spec.N,
 		Pos:            spec.Pos,
+		Seed:           spec.Seed,
 		SelectorConfig: spec.SelectorConfig,
 	}, nil
 }
@@ -101,6 +113,10 @@ func (s *SampleProcedureSpec) Copy() plan.ProcedureSpec {
 	ns := new(SampleProcedureSpec)
 	ns.N = s.N
 	ns.Pos = s.Pos
+	if s.Seed != nil {
+		seed := *s.Seed
+		ns.Seed = &seed
+	}
 	ns.SelectorConfig = s.SelectorConfig
 	return ns
 }
@@ -108,6 +124,9 @@ func (s *SampleProcedureSpec) Copy() plan.ProcedureSpec {
 type SampleSelector struct {
 	N   int
 	Pos int
+	// Rand is the source of the random offsets used when Pos is negative.
+	// When nil, the default source of the math/rand package is used.
+	Rand *rand.Rand
 
 	offset   int
 	selected []int
@@ -123,6 +142,9 @@ func createSampleTransformation(id execute.DatasetID, mode execute.AccumulationM
 		N:   int(ps.N),
 		Pos: int(ps.Pos),
 	}
+	if ps.Seed != nil {
+		ss.Rand = rand.New(rand.NewSource(*ps.Seed))
+	}
 	t, d := execute.NewIndexSelectorTransformationAndDataset(id, mode, ss, ps.SelectorConfig, a.Allocator())
 	return t, d, nil
 }
@@ -130,7 +152,11 @@ func createSampleTransformation(id execute.DatasetID, mode execute.AccumulationM
 func (s *SampleSelector) reset() {
 	pos := s.Pos
 	if pos < 0 {
-		pos = rand.Intn(s.N)
+		if s.Rand != nil {
+			pos = s.Rand.Intn(s.N)
+		} else {
+			pos = rand.Intn(s.N)
+		}
 	}
 	s.offset = pos
 }
@@ -23,6 +23,21 @@ func TestSampleOperation_Marshaling(t *testing.T) {
 	querytest.OperationMarshalingTestHelper(t, data, op)
 }
 
+func TestSampleOperation_MarshalingSeed(t *testing.T) {
+	seed := int64(42)
+	data := []byte(`{"id":"sample","kind":"sample","spec":{"n":5, "pos":-1, "seed":42}}`)
+	op := &flux.Operation{
+		ID: "sample",
+		Spec: &universe.SampleOpSpec{
+			N:    5,
+			Pos:  -1,
+			Seed: &seed,
+		},
+	}
+
+	querytest.OperationMarshalingTestHelper(t, data, op)
+}
+
 func TestSample_Process(t *testing.T) {
 	testCases := []struct {
 		name   string
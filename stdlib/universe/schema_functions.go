@@ -13,6 +13,7 @@ import (
 )
 
 const RenameKind = "rename"
+const RenameAllKind = "renameAll"
 const DropKind = "drop"
 const KeepKind = "keep"
 const DuplicateKind = "duplicate"
@@ -22,6 +23,13 @@ type RenameOpSpec struct {
 	Fn      *semantic.FunctionExpression `json:"fn"`
 }
 
+// RenameAllOpSpec renames every column whose label matches Pattern,
+// replacing the matches with Replacement.
+type RenameAllOpSpec struct {
+	Pattern     string `json:"pattern"`
+	Replacement string `json:"replacement"`
+}
+
 type DropOpSpec struct {
 	Columns   []string                     `json:"columns"`
 	Predicate *semantic.FunctionExpression `json:"fn"`
@@ -86,6 +94,15 @@ var Registrars = []MutationRegistrar{
 		Create: createRenameOpSpec,
 		New:    newRenameOp,
 	},
+	{
+		Kind: RenameAllKind,
+		Args: map[string]semantic.PolyType{
+			"pattern":     semantic.Regexp,
+			"replacement": semantic.String,
+		},
+		Create: createRenameAllOpSpec,
+		New:    newRenameAllOp,
+	},
 	{
 		Kind: DropKind,
 		Args: map[string]semantic.PolyType{
@@ -193,6 +210,27 @@ func createRenameOpSpec(args flux.Arguments, a *flux.Administration) (flux.Opera
 	return spec, nil
 }
 
+func createRenameAllOpSpec(args flux.Arguments, a *flux.Administration) (flux.OperationSpec, error) {
+	if err := a.AddParentFromArgs(args); err != nil {
+		return nil, err
+	}
+
+	pattern, err := args.GetRequired("pattern")
+	if err != nil {
+		return nil, err
+	}
+
+	replacement, err := args.GetRequiredString("replacement")
+	if err != nil {
+		return nil, err
+	}
+
+	return &RenameAllOpSpec{
+		Pattern:     pattern.Regexp().String(),
+		Replacement: replacement,
+	}, nil
+}
+
 func createDropOpSpec(args flux.Arguments, a *flux.Administration) (flux.OperationSpec, error) {
 	if err := a.AddParentFromArgs(args); err != nil {
 		return nil, err
@@ -316,6 +354,14 @@ func (s *RenameOpSpec) Kind() flux.OperationKind {
 	return RenameKind
 }
 
+func newRenameAllOp() flux.OperationSpec {
+	return new(RenameAllOpSpec)
+}
+
+func (s *RenameAllOpSpec) Kind() flux.OperationKind {
+	return RenameAllKind
+}
+
 func newDropOp() flux.OperationSpec {
 	return new(DropOpSpec)
 }
@@ -352,6 +398,11 @@ func (s *RenameOpSpec) Copy() SchemaMutation {
 	}
 }
 
+func (s *RenameAllOpSpec) Copy() SchemaMutation {
+	ns := *s
+	return &ns
+}
+
 func (s *DropOpSpec) Copy() SchemaMutation {
 	newCols := make([]string, len(s.Columns))
 	copy(newCols, s.Columns)
@@ -387,6 +438,14 @@ func (s *RenameOpSpec) Mutator() (SchemaMutator, error) {
 	return m, nil
 }
 
+func (s *RenameAllOpSpec) Mutator() (SchemaMutator, error) {
+	m, err := NewRenameAllMutator(s)
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (s *DropOpSpec) Mutator() (SchemaMutator, error) {
 	m, err := NewDropKeepMutator(s)
 	if err != nil {
@@ -422,7 +481,7 @@ func (s *SchemaMutationProcedureSpec) Kind() plan.ProcedureKind {
 
 func (s *SchemaMutationProcedureSpec) Copy() plan.ProcedureSpec {
 	newMutations := make([]SchemaMutation, len(s.Mutations))
-	for i, m := range newMutations {
+	for i, m := range s.Mutations {
 		newMutations[i] = m.Copy()
 	}
 
@@ -431,6 +490,19 @@ func (s *SchemaMutationProcedureSpec) Copy() plan.ProcedureSpec {
 	}
 }
 
+// OutputSchema implements plan.SchemaProcedureSpec.
+// The schema is not known after a mutation that calls a function on the labels of the columns.
+func (s *SchemaMutationProcedureSpec) OutputSchema(predecessors []*plan.Schema) *plan.Schema {
+	schema := predecessors[0]
+	for _, m := range s.Mutations {
+		if schema == nil {
+			return nil
+		}
+		schema = mutateSchema(m, schema)
+	}
+	return schema
+}
+
 func newSchemaMutationProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
 	s, ok := qs.(SchemaMutation)
 	if !ok {
@@ -442,14 +514,16 @@ func newSchemaMutationProcedure(qs flux.OperationSpec, pa plan.Administration) (
 	}, nil
 }
 
+// schemaMutationTransformation shares the arrays of the columns it keeps, renames or duplicates
+// with its input tables.
 type schemaMutationTransformation struct {
 	d        execute.Dataset
-	cache    execute.TableBuilderCache
+	cache    *execute.SharedTableCache
 	mutators []SchemaMutator
 }
 
 func createSchemaMutationTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
-	cache := execute.NewTableBuilderCache(a.Allocator())
+	cache := execute.NewSharedTableCache(a.Allocator())
 	d := execute.NewDataset(id, mode, cache)
 
 	t, err := NewSchemaMutationTransformation(d, cache, spec)
@@ -459,7 +533,7 @@ func createSchemaMutationTransformation(id execute.DatasetID, mode execute.Accum
 	return t, d, nil
 }
 
-func NewSchemaMutationTransformation(d execute.Dataset, cache execute.TableBuilderCache, spec plan.ProcedureSpec) (*schemaMutationTransformation, error) {
+func NewSchemaMutationTransformation(d execute.Dataset, cache *execute.SharedTableCache, spec plan.ProcedureSpec) (*schemaMutationTransformation, error) {
 	s, ok := spec.(*SchemaMutationProcedureSpec)
 	if !ok {
 		return nil, fmt.Errorf("invalid spec type %T", spec)
@@ -500,13 +574,19 @@ func (t *schemaMutationTransformation) Process(id execute.DatasetID, tbl flux.Ta
 		}
 	}
 
-	return tbl.Do(func(cr flux.ColReader) error {
-		for i := 0; i < cr.Len(); i++ {
-			if err := execute.AppendMappedRecordWithNulls(i, cr, builder, ctx.ColMap()); err != nil {
-				return err
+	// Tables that share the key after the mutation may not have all the columns of the builder.
+	colMap := make([]int, len(builder.Cols()))
+	for j, c := range builder.Cols() {
+		colMap[j] = -1
+		if idx := execute.ColIdx(c.Label, ctx.Cols()); idx >= 0 {
+			if typ := ctx.Cols()[idx].Type; typ != c.Type {
+				return fmt.Errorf("schema mutation found column %s of type %v, expected %v", c.Label, typ, c.Type)
 			}
+			colMap[j] = ctx.ColMap()[idx]
 		}
-		return nil
+	}
+	return tbl.Do(func(cr flux.ColReader) error {
+		return builder.AppendColReader(cr, colMap)
 	})
 }
 
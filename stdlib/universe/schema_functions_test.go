@@ -4,6 +4,7 @@ import (
 	"regexp"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/influxdata/flux"
 	"github.com/influxdata/flux/ast"
 	"github.com/influxdata/flux/execute"
@@ -50,6 +51,30 @@ func TestSchemaMutions_NewQueries(t *testing.T) {
 				},
 			},
 		},
+		{
+			Name: "test renameAll query",
+			Raw:  `from(bucket:"mybucket") |> renameAll(pattern: /^cpu_(.*)$/, replacement: "${1}")`,
+			Want: &flux.Spec{
+				Operations: []*flux.Operation{
+					{
+						ID: "from0",
+						Spec: &influxdb.FromOpSpec{
+							Bucket: "mybucket",
+						},
+					},
+					{
+						ID: "renameAll1",
+						Spec: &universe.RenameAllOpSpec{
+							Pattern:     "^cpu_(.*)$",
+							Replacement: "${1}",
+						},
+					},
+				},
+				Edges: []flux.Edge{
+					{Parent: "from0", Child: "renameAll1"},
+				},
+			},
+		},
 		{
 			Name: "test drop query",
 			Raw:  `from(bucket:"mybucket") |> drop(columns:["col1", "col2", "col3"]) |> sum()`,
@@ -352,6 +377,63 @@ func TestDropRenameKeep_Process(t *testing.T) {
 			}},
 		},
 
+		{
+			name: "renameAll with submatch",
+			spec: &universe.SchemaMutationProcedureSpec{
+				Mutations: []universe.SchemaMutation{
+					&universe.RenameAllOpSpec{
+						Pattern:     "^cpu_(.*)$",
+						Replacement: "${1}_cpu",
+					},
+				},
+			},
+			data: []flux.Table{&executetest.Table{
+				KeyCols: []string{"cpu_host"},
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "cpu_host", Type: flux.TString},
+					{Label: "cpu_user", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), "a", 2.0},
+					{execute.Time(2), "a", 3.0},
+				},
+			}},
+			want: []*executetest.Table{{
+				KeyCols: []string{"host_cpu"},
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "host_cpu", Type: flux.TString},
+					{Label: "user_cpu", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), "a", 2.0},
+					{execute.Time(2), "a", 3.0},
+				},
+			}},
+		},
+		{
+			name: "renameAll conflicting labels",
+			spec: &universe.SchemaMutationProcedureSpec{
+				Mutations: []universe.SchemaMutation{
+					&universe.RenameAllOpSpec{
+						Pattern:     "_[ab]$",
+						Replacement: "",
+					},
+				},
+			},
+			data: []flux.Table{&executetest.Table{
+				ColMeta: []flux.ColMeta{
+					{Label: "x_a", Type: flux.TFloat},
+					{Label: "x_b", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{1.0, 2.0},
+				},
+			}},
+			want:    []*executetest.Table(nil),
+			wantErr: errors.New(`renameAll error: column "x" already exists`),
+		},
 		{
 			name: "drop multiple cols",
 			spec: &universe.SchemaMutationProcedureSpec{
@@ -1219,12 +1301,12 @@ func TestDropRenameKeep_Process(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			executetest.ProcessTestHelper(
+			executetest.SharedProcessTestHelper(
 				t,
 				tc.data,
 				tc.want,
 				tc.wantErr,
-				func(d execute.Dataset, c execute.TableBuilderCache) execute.Transformation {
+				func(d execute.Dataset, c *execute.SharedTableCache) execute.Transformation {
 					tr, err := universe.NewSchemaMutationTransformation(d, c, tc.spec)
 					if err != nil {
 						t.Fatal(err)
@@ -1269,3 +1351,72 @@ func TestRenameDrop_PushDown(t *testing.T) {
 	plantest.PhysicalPlan_PushDown_TestHelper(t, spec, root, false, want)
 }
 */
+
+func TestSchemaMutationProcedureSpec_OutputSchema(t *testing.T) {
+	in := &plan.Schema{
+		Columns: []flux.ColMeta{
+			{Label: "_time", Type: flux.TTime},
+			{Label: "_value", Type: flux.TFloat},
+			{Label: "host", Type: flux.TString},
+		},
+	}
+	testCases := []struct {
+		name      string
+		mutations []universe.SchemaMutation
+		in        *plan.Schema
+		want      *plan.Schema
+	}{
+		{
+			name: "rename drop duplicate",
+			mutations: []universe.SchemaMutation{
+				&universe.RenameOpSpec{Columns: map[string]string{"host": "server"}},
+				&universe.DropOpSpec{Columns: []string{"_time"}},
+				&universe.DuplicateOpSpec{Column: "_value", As: "copy"},
+			},
+			in: in,
+			want: &plan.Schema{
+				Columns: []flux.ColMeta{
+					{Label: "_value", Type: flux.TFloat},
+					{Label: "copy", Type: flux.TFloat},
+					{Label: "server", Type: flux.TString},
+				},
+			},
+		},
+		{
+			name: "keep closes open schema",
+			mutations: []universe.SchemaMutation{
+				&universe.KeepOpSpec{Columns: []string{"_time", "_value"}},
+			},
+			in: &plan.Schema{Columns: in.Columns, Open: true},
+			want: &plan.Schema{
+				Columns: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_value", Type: flux.TFloat},
+				},
+			},
+		},
+		{
+			name: "drop predicate",
+			mutations: []universe.SchemaMutation{
+				&universe.DropOpSpec{Predicate: &semantic.FunctionExpression{}},
+			},
+			in: in,
+		},
+		{
+			name: "unknown input",
+			mutations: []universe.SchemaMutation{
+				&universe.DropOpSpec{Columns: []string{"_time"}},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			spec := &universe.SchemaMutationProcedureSpec{Mutations: tc.mutations}
+			got := spec.OutputSchema([]*plan.Schema{tc.in})
+			if !cmp.Equal(tc.want, got) {
+				t.Errorf("unexpected schema -want/+got:\n%s", cmp.Diff(tc.want, got))
+			}
+		})
+	}
+}
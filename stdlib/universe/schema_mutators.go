@@ -2,10 +2,12 @@ package universe
 
 import (
 	"fmt"
+	"regexp"
 
 	"github.com/influxdata/flux"
 	"github.com/influxdata/flux/compiler"
 	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/plan"
 	"github.com/influxdata/flux/semantic"
 	"github.com/influxdata/flux/values"
 	"github.com/pkg/errors"
@@ -157,6 +159,54 @@ func (m *RenameMutator) Mutate(ctx *BuilderContext) error {
 	return nil
 }
 
+type RenameAllMutator struct {
+	Pattern     *regexp.Regexp
+	Replacement string
+}
+
+func NewRenameAllMutator(qs flux.OperationSpec) (*RenameAllMutator, error) {
+	s, ok := qs.(*RenameAllOpSpec)
+	if !ok {
+		return nil, fmt.Errorf("invalid spec type %T", qs)
+	}
+
+	pattern, err := regexp.Compile(s.Pattern)
+	if err != nil {
+		return nil, errors.Wrap(err, "renameAll error")
+	}
+	return &RenameAllMutator{
+		Pattern:     pattern,
+		Replacement: s.Replacement,
+	}, nil
+}
+
+func (m *RenameAllMutator) Mutate(ctx *BuilderContext) error {
+	keyCols := make([]flux.ColMeta, 0, len(ctx.Key().Cols()))
+	keyValues := make([]values.Value, 0, len(ctx.Key().Cols()))
+	labels := make(map[string]bool, len(ctx.Cols()))
+
+	for i := range ctx.Cols() {
+		keyIdx := execute.ColIdx(ctx.TableColumns[i].Label, ctx.Key().Cols())
+
+		// Replacements may expand submatches of the pattern, e.g. "${1}_total".
+		label := m.Pattern.ReplaceAllString(ctx.TableColumns[i].Label, m.Replacement)
+		if labels[label] {
+			return fmt.Errorf(`renameAll error: column "%s" already exists`, label)
+		}
+		labels[label] = true
+		ctx.TableColumns[i].Label = label
+
+		if keyIdx >= 0 {
+			keyCols = append(keyCols, ctx.TableColumns[i])
+			keyValues = append(keyValues, ctx.Key().Value(keyIdx))
+		}
+	}
+
+	ctx.TableKey = execute.NewGroupKey(keyCols, keyValues)
+
+	return nil
+}
+
 type DropKeepMutator struct {
 	KeepCols      map[string]bool
 	DropCols      map[string]bool
@@ -340,6 +390,65 @@ func duplicate(col flux.ColMeta, dupName string) flux.ColMeta {
 	}
 }
 
+// mutateSchema returns the schema of the tables that the mutation produces
+// from tables with the schema in, or nil if it is not known.
+func mutateSchema(m SchemaMutation, in *plan.Schema) *plan.Schema {
+	out := &plan.Schema{Open: in.Open}
+	switch m := m.(type) {
+	case *RenameOpSpec:
+		if m.Fn != nil {
+			return nil
+		}
+		for _, c := range in.Columns {
+			if label, ok := m.Columns[c.Label]; ok {
+				c.Label = label
+			}
+			out.Columns = append(out.Columns, c)
+		}
+	case *RenameAllOpSpec:
+		pattern, err := regexp.Compile(m.Pattern)
+		if err != nil {
+			return nil
+		}
+		for _, c := range in.Columns {
+			c.Label = pattern.ReplaceAllString(c.Label, m.Replacement)
+			out.Columns = append(out.Columns, c)
+		}
+	case *DropOpSpec:
+		if m.Predicate != nil {
+			return nil
+		}
+		drop := toStringSet(m.Columns)
+		for _, c := range in.Columns {
+			if !drop[c.Label] {
+				out.Columns = append(out.Columns, c)
+			}
+		}
+	case *KeepOpSpec:
+		if m.Predicate != nil {
+			return nil
+		}
+		keep := toStringSet(m.Columns)
+		for _, c := range in.Columns {
+			if keep[c.Label] {
+				out.Columns = append(out.Columns, c)
+			}
+		}
+		// The tables of an open schema may have kept columns whose types are not known.
+		out.Open = in.Open && len(out.Columns) < len(keep)
+	case *DuplicateOpSpec:
+		for _, c := range in.Columns {
+			out.Columns = append(out.Columns, c)
+			if c.Label == m.Column {
+				out.Columns = append(out.Columns, duplicate(c, m.As))
+			}
+		}
+	default:
+		return nil
+	}
+	return out
+}
+
 // TODO: determine pushdown rules
 /*
 func (s *SchemaMutationProcedureSpec) PushDownRules() []plan.PushDownRule {
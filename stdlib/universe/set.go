@@ -3,7 +3,9 @@ package universe
 import (
 	"fmt"
 
+	"github.com/apache/arrow/go/arrow/array"
 	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/arrow"
 	"github.com/influxdata/flux/execute"
 	"github.com/influxdata/flux/plan"
 	"github.com/influxdata/flux/semantic"
@@ -93,22 +95,23 @@ func createSetTransformation(id execute.DatasetID, mode execute.AccumulationMode
 	if !ok {
 		return nil, nil, fmt.Errorf("invalid spec type %T", spec)
 	}
-	cache := execute.NewTableBuilderCache(a.Allocator())
+	cache := execute.NewSharedTableCache(a.Allocator())
 	d := execute.NewDataset(id, mode, cache)
 	t := NewSetTransformation(d, cache, s)
 	return t, d, nil
 }
 
+// setTransformation shares the arrays of the columns it does not set with its input tables.
 type setTransformation struct {
 	d     execute.Dataset
-	cache execute.TableBuilderCache
+	cache *execute.SharedTableCache
 
 	key, value string
 }
 
 func NewSetTransformation(
 	d execute.Dataset,
-	cache execute.TableBuilderCache,
+	cache *execute.SharedTableCache,
 	spec *SetProcedureSpec,
 ) execute.Transformation {
 	return &setTransformation{
@@ -142,12 +145,13 @@ func (t *setTransformation) Process(id execute.DatasetID, tbl flux.Table) error
 	}
 	builder, created := t.cache.TableBuilder(key)
 	if created {
-		err := execute.AddTableCols(tbl, builder)
-		if err != nil {
-			return err
+		for _, c := range tbl.Cols() {
+			if _, err := builder.AddCol(c); err != nil {
+				return err
+			}
 		}
 		if !execute.HasCol(t.key, builder.Cols()) {
-			if _, err = builder.AddCol(flux.ColMeta{
+			if _, err := builder.AddCol(flux.ColMeta{
 				Label: t.key,
 				Type:  flux.TString,
 			}); err != nil {
@@ -157,22 +161,23 @@ func (t *setTransformation) Process(id execute.DatasetID, tbl flux.Table) error
 	}
 	idx := execute.ColIdx(t.key, builder.Cols())
 	return tbl.Do(func(cr flux.ColReader) error {
-		for j := range cr.Cols() {
-			if j == idx {
-				continue
-			}
-			if err := execute.AppendCol(j, j, cr, builder); err != nil {
-				return err
+		l := cr.Len()
+		arrs := make([]array.Interface, len(builder.Cols()))
+		for j := range arrs {
+			if j != idx {
+				arrs[j] = execute.ColReaderArray(cr, j)
 			}
 		}
 		// Set new value
-		l := cr.Len()
+		b := arrow.NewStringBuilder(builder.Allocator())
+		b.Reserve(l)
 		for i := 0; i < l; i++ {
-			if err := builder.AppendString(idx, t.value); err != nil {
-				return err
-			}
+			b.AppendString(t.value)
 		}
-		return nil
+		arrs[idx] = b.NewBinaryArray()
+		b.Release()
+		defer arrs[idx].Release()
+		return builder.AppendArrays(arrs)
 	})
 }
 
@@ -289,12 +289,12 @@ func TestSet_Process(t *testing.T) {
 	for _, tc := range testCases {
 		tc := tc
 		t.Run(tc.name, func(t *testing.T) {
-			executetest.ProcessTestHelper(
+			executetest.SharedProcessTestHelper(
 				t,
 				tc.data,
 				tc.want,
 				nil,
-				func(d execute.Dataset, c execute.TableBuilderCache) execute.Transformation {
+				func(d execute.Dataset, c *execute.SharedTableCache) execute.Transformation {
 					return universe.NewSetTransformation(d, c, tc.spec)
 				},
 			)
@@ -83,7 +83,7 @@ type ShiftProcedureSpec struct {
 // TimeBounds implements plan.BoundsAwareProcedureSpec
 func (s *ShiftProcedureSpec) TimeBounds(predecessorBounds *plan.Bounds) *plan.Bounds {
 	if predecessorBounds != nil {
-		return predecessorBounds.Shift(values.Duration(s.Shift))
+		return predecessorBounds.Shift(s.Shift)
 	}
 	return nil
 }
@@ -138,7 +138,7 @@ func NewShiftTransformation(d execute.Dataset, cache execute.TableBuilderCache,
 	return &shiftTransformation{
 		d:       d,
 		cache:   cache,
-		shift:   execute.Duration(spec.Shift),
+		shift:   spec.Shift,
 		columns: spec.Columns,
 	}
 }
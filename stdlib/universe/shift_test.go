@@ -9,6 +9,7 @@ import (
 	"github.com/influxdata/flux/execute/executetest"
 	"github.com/influxdata/flux/querytest"
 	"github.com/influxdata/flux/stdlib/universe"
+	"github.com/influxdata/flux/values"
 )
 
 func TestShiftOperation_Marshaling(t *testing.T) {
@@ -16,7 +17,7 @@ func TestShiftOperation_Marshaling(t *testing.T) {
 	op := &flux.Operation{
 		ID: "shift",
 		Spec: &universe.ShiftOpSpec{
-			Shift: flux.Duration(1 * time.Hour),
+			Shift: values.ConvertDuration(1 * time.Hour),
 		},
 	}
 	querytest.OperationMarshalingTestHelper(t, data, op)
@@ -39,7 +40,7 @@ func TestShift_Process(t *testing.T) {
 			name: "one table",
 			spec: &universe.ShiftProcedureSpec{
 				Columns: []string{execute.DefaultTimeColLabel},
-				Shift:   flux.Duration(1),
+				Shift:   values.ConvertDuration(1),
 			},
 			data: []flux.Table{
 				&executetest.Table{
@@ -66,7 +67,7 @@ func TestShift_Process(t *testing.T) {
 			name: "multiple tables",
 			spec: &universe.ShiftProcedureSpec{
 				Columns: []string{execute.DefaultTimeColLabel},
-				Shift:   flux.Duration(2),
+				Shift:   values.ConvertDuration(2),
 			},
 			data: []flux.Table{
 				&executetest.Table{
@@ -109,7 +110,7 @@ func TestShift_Process(t *testing.T) {
 			name: "null time",
 			spec: &universe.ShiftProcedureSpec{
 				Columns: []string{execute.DefaultTimeColLabel},
-				Shift:   flux.Duration(1),
+				Shift:   values.ConvertDuration(1),
 			},
 			data: []flux.Table{
 				&executetest.Table{
@@ -136,7 +137,7 @@ func TestShift_Process(t *testing.T) {
 			name: "null value",
 			spec: &universe.ShiftProcedureSpec{
 				Columns: []string{execute.DefaultTimeColLabel},
-				Shift:   flux.Duration(1),
+				Shift:   values.ConvertDuration(1),
 			},
 			data: []flux.Table{
 				&executetest.Table{
@@ -31,6 +31,7 @@ func init() {
 	flux.RegisterOpSpec(SortKind, newSortOp)
 	plan.RegisterProcedureSpec(SortKind, newSortProcedure, SortKind)
 	execute.RegisterTransformation(SortKind, createSortTransformation)
+	plan.RegisterPhysicalRules(RemoveRedundantSortRule{})
 }
 
 func createSortOpSpec(args flux.Arguments, a *flux.Administration) (flux.OperationSpec, error) {
@@ -100,6 +101,39 @@ func (s *SortProcedureSpec) Copy() plan.ProcedureSpec {
 	return ns
 }
 
+// SortedBy implements plan.OrderedProcedureSpec.
+func (s *SortProcedureSpec) SortedBy(predecessors [][]string) []string {
+	if s.Desc {
+		return nil
+	}
+	return s.Columns
+}
+
+// OutputSchema implements plan.SchemaProcedureSpec, sort keeps the columns of its input.
+func (s *SortProcedureSpec) OutputSchema(predecessors []*plan.Schema) *plan.Schema {
+	return predecessors[0]
+}
+
+// RemoveRedundantSortRule removes sort nodes whose input is already sorted by the columns of the sort.
+type RemoveRedundantSortRule struct{}
+
+func (RemoveRedundantSortRule) Name() string {
+	return "RemoveRedundantSortRule"
+}
+
+func (RemoveRedundantSortRule) Pattern() plan.Pattern {
+	return plan.Pat(SortKind, plan.Any())
+}
+
+func (RemoveRedundantSortRule) Rewrite(sortNode plan.PlanNode) (plan.PlanNode, bool, error) {
+	sortSpec := sortNode.ProcedureSpec().(*SortProcedureSpec)
+	anyNode := sortNode.Predecessors()[0]
+	if sortSpec.Desc || !plan.IsSortedBy(plan.SortedBy(anyNode), sortSpec.Columns) {
+		return sortNode, false, nil
+	}
+	return anyNode, true, nil
+}
+
 func createSortTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
 	s, ok := spec.(*SortProcedureSpec)
 	if !ok {
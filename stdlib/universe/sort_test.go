@@ -6,7 +6,9 @@ import (
 	"github.com/influxdata/flux"
 	"github.com/influxdata/flux/execute"
 	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/flux/plan/plantest"
 	"github.com/influxdata/flux/querytest"
+	"github.com/influxdata/flux/stdlib/influxdata/influxdb"
 	"github.com/influxdata/flux/stdlib/universe"
 )
 
@@ -36,6 +38,17 @@ func TestSort_PassThrough(t *testing.T) {
 	})
 }
 
+func TestRemoveRedundantSortRule(t *testing.T) {
+	plantest.RuleFixturesTestHelper(t, universe.RemoveRedundantSortRule{}, plantest.Specs{
+		"from":       &influxdb.FromProcedureSpec{},
+		"filter":     &universe.FilterProcedureSpec{},
+		"byTime":     &universe.SortProcedureSpec{Columns: []string{"_time"}},
+		"byTimeTag":  &universe.SortProcedureSpec{Columns: []string{"_time", "t0"}},
+		"byTag":      &universe.SortProcedureSpec{Columns: []string{"t0"}},
+		"byTimeDesc": &universe.SortProcedureSpec{Columns: []string{"_time"}, Desc: true},
+	})
+}
+
 func TestSort_Process(t *testing.T) {
 	testCases := []struct {
 		name string
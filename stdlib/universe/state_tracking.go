@@ -2,16 +2,18 @@ package universe
 
 import (
 	"fmt"
-	"log"
 	"time"
 
 	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/dependencies"
+	fluxerrors "github.com/influxdata/flux/errors"
 	"github.com/influxdata/flux/execute"
 	"github.com/influxdata/flux/interpreter"
 	"github.com/influxdata/flux/plan"
 	"github.com/influxdata/flux/semantic"
 	"github.com/influxdata/flux/values"
 	"github.com/pkg/errors"
+	"go.uber.org/zap"
 )
 
 const StateTrackingKind = "stateTracking"
@@ -65,7 +67,7 @@ func createStateTrackingOpSpec(args flux.Arguments, a *flux.Administration) (flu
 
 	spec := &StateTrackingOpSpec{
 		Fn:           fn,
-		DurationUnit: flux.Duration(time.Second),
+		DurationUnit: values.ConvertDuration(time.Second),
 	}
 
 	if label, ok, err := args.GetString("countColumn"); err != nil {
@@ -91,7 +93,7 @@ func createStateTrackingOpSpec(args flux.Arguments, a *flux.Administration) (flu
 		spec.TimeColumn = execute.DefaultTimeColLabel
 	}
 
-	if spec.DurationColumn != "" && spec.DurationUnit <= 0 {
+	if spec.DurationColumn != "" && !spec.DurationUnit.IsPositive() {
 		return nil, errors.New("state tracking duration unit must be greater than zero")
 	}
 	return spec, nil
@@ -152,6 +154,7 @@ func createStateTrackingTransformation(id execute.DatasetID, mode execute.Accumu
 	if err != nil {
 		return nil, nil, err
 	}
+	t.logger = dependencies.Get(a.Context()).Logger
 	return t, d, nil
 }
 
@@ -166,6 +169,9 @@ type stateTrackingTransformation struct {
 	durationColumn string
 
 	durationUnit int64
+
+	// logger logs the rows the function fails to evaluate.
+	logger *zap.Logger
 }
 
 func NewStateTrackingTransformation(d execute.Dataset, cache execute.TableBuilderCache, spec *StateTrackingProcedureSpec) (*stateTrackingTransformation, error) {
@@ -179,8 +185,9 @@ func NewStateTrackingTransformation(d execute.Dataset, cache execute.TableBuilde
 		fn:             fn,
 		countColumn:    spec.CountColumn,
 		durationColumn: spec.DurationColumn,
-		durationUnit:   int64(spec.DurationUnit),
+		durationUnit:   int64(spec.DurationUnit.Duration()),
 		timeCol:        spec.TimeCol,
+		logger:         zap.NewNop(),
 	}, nil
 }
 
@@ -246,8 +253,10 @@ func (t *stateTrackingTransformation) Process(id execute.DatasetID, tbl flux.Tab
 		l := cr.Len()
 		for i := 0; i < l; i++ {
 			match, err := t.fn.Eval(i, cr)
-			if err != nil {
-				log.Printf("failed to evaluate state tracking expression: %v", err)
+			if fluxerrors.CodeOf(err) == fluxerrors.Aborted {
+				return err
+			} else if err != nil {
+				t.logger.Debug("failed to evaluate state tracking expression", zap.Error(err))
 				continue
 			}
 
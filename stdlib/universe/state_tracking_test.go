@@ -12,6 +12,7 @@ import (
 	"github.com/influxdata/flux/semantic"
 	"github.com/influxdata/flux/stdlib/influxdata/influxdb"
 	"github.com/influxdata/flux/stdlib/universe"
+	"github.com/influxdata/flux/values"
 	"github.com/pkg/errors"
 )
 
@@ -46,7 +47,7 @@ func TestStateTracking_NewQuery(t *testing.T) {
 						Spec: &universe.StateTrackingOpSpec{
 							CountColumn:    "stateCount",
 							DurationColumn: "",
-							DurationUnit:   flux.Duration(time.Second),
+							DurationUnit:   values.ConvertDuration(time.Second),
 							TimeColumn:     "_time",
 							Fn: &semantic.FunctionExpression{
 								Block: &semantic.FunctionBlock{
@@ -86,7 +87,7 @@ func TestStateTracking_NewQuery(t *testing.T) {
 						Spec: &universe.StateTrackingOpSpec{
 							CountColumn:    "",
 							DurationColumn: "stateDuration",
-							DurationUnit:   flux.Duration(time.Second),
+							DurationUnit:   values.ConvertDuration(time.Second),
 							TimeColumn:     "ts",
 							Fn: &semantic.FunctionExpression{
 								Block: &semantic.FunctionBlock{
@@ -122,7 +123,7 @@ func TestStateTrackingOperation_Marshaling(t *testing.T) {
 		Spec: &universe.StateTrackingOpSpec{
 			CountColumn:    "c",
 			DurationColumn: "d",
-			DurationUnit:   flux.Duration(time.Minute),
+			DurationUnit:   values.ConvertDuration(time.Minute),
 			TimeColumn:     "t",
 		},
 	}
@@ -157,7 +158,7 @@ func TestStateTracking_Process(t *testing.T) {
 			name: "only duration",
 			spec: &universe.StateTrackingProcedureSpec{
 				DurationColumn: "duration",
-				DurationUnit:   1,
+				DurationUnit:   values.ConvertDuration(1),
 				Fn:             gt5,
 				TimeCol:        "_time",
 			},
@@ -195,7 +196,7 @@ func TestStateTracking_Process(t *testing.T) {
 			name: "only duration, null timestamps",
 			spec: &universe.StateTrackingProcedureSpec{
 				DurationColumn: "duration",
-				DurationUnit:   1,
+				DurationUnit:   values.ConvertDuration(1),
 				Fn:             gt5,
 				TimeCol:        "_time",
 			},
@@ -219,7 +220,7 @@ func TestStateTracking_Process(t *testing.T) {
 			name: "only duration, out of order timestamps",
 			spec: &universe.StateTrackingProcedureSpec{
 				DurationColumn: "duration",
-				DurationUnit:   1,
+				DurationUnit:   values.ConvertDuration(1),
 				Fn:             gt5,
 				TimeCol:        "_time",
 			},
@@ -318,7 +319,7 @@ func TestStateTracking_Process(t *testing.T) {
 			spec: &universe.StateTrackingProcedureSpec{
 				CountColumn:    "count",
 				DurationColumn: "duration",
-				DurationUnit:   1,
+				DurationUnit:   values.ConvertDuration(1),
 				Fn:             gt5,
 				TimeCol:        "_time",
 			},
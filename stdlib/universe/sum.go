@@ -4,8 +4,8 @@ import (
 	"fmt"
 
 	"github.com/apache/arrow/go/arrow/array"
-	"github.com/apache/arrow/go/arrow/math"
 	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/arrow"
 	"github.com/influxdata/flux/execute"
 	"github.com/influxdata/flux/plan"
 )
@@ -75,6 +75,11 @@ func (s *SumProcedureSpec) ReAggregateSpec() plan.ProcedureSpec {
 	return new(SumProcedureSpec)
 }
 
+// SplitAggregate implements plan.SplittableAggregateSpec, the partial sums are summed.
+func (s *SumProcedureSpec) SplitAggregate() (partial, final plan.PhysicalProcedureSpec, ok bool) {
+	return s.Copy().(*SumProcedureSpec), s.Copy().(*SumProcedureSpec), true
+}
+
 type SumAgg struct{}
 
 func createSumTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
@@ -109,19 +114,9 @@ type SumIntAgg struct {
 }
 
 func (a *SumIntAgg) DoInt(vs *array.Int64) {
-	// https://issues.apache.org/jira/browse/ARROW-4081
 	if l := vs.Len() - vs.NullN(); l > 0 {
-		if vs.NullN() == 0 {
-			a.sum += math.Int64.Sum(vs)
-			a.ok = true
-		} else {
-			for i := 0; i < vs.Len(); i++ {
-				if vs.IsValid(i) {
-					a.sum += vs.Value(i)
-					a.ok = true
-				}
-			}
-		}
+		a.sum += arrow.SumInt64(vs)
+		a.ok = true
 	}
 }
 func (a *SumIntAgg) Type() flux.ColType {
@@ -140,19 +135,9 @@ type SumUIntAgg struct {
 }
 
 func (a *SumUIntAgg) DoUInt(vs *array.Uint64) {
-	// https://issues.apache.org/jira/browse/ARROW-4081
 	if l := vs.Len() - vs.NullN(); l > 0 {
-		if vs.NullN() == 0 {
-			a.sum += math.Uint64.Sum(vs)
-			a.ok = true
-		} else {
-			for i := 0; i < vs.Len(); i++ {
-				if vs.IsValid(i) {
-					a.sum += vs.Value(i)
-					a.ok = true
-				}
-			}
-		}
+		a.sum += arrow.SumUint64(vs)
+		a.ok = true
 	}
 }
 func (a *SumUIntAgg) Type() flux.ColType {
@@ -171,19 +156,9 @@ type SumFloatAgg struct {
 }
 
 func (a *SumFloatAgg) DoFloat(vs *array.Float64) {
-	// https://issues.apache.org/jira/browse/ARROW-4081
 	if l := vs.Len() - vs.NullN(); l > 0 {
-		if vs.NullN() == 0 {
-			a.sum += math.Float64.Sum(vs)
-			a.ok = true
-		} else {
-			for i := 0; i < vs.Len(); i++ {
-				if vs.IsValid(i) {
-					a.sum += vs.Value(i)
-					a.ok = true
-				}
-			}
-		}
+		a.sum += arrow.SumFloat64(vs)
+		a.ok = true
 	}
 }
 func (a *SumFloatAgg) Type() flux.ColType {
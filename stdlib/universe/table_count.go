@@ -0,0 +1,141 @@
+package universe
+
+import (
+	"fmt"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/semantic"
+)
+
+const TableCountKind = "tableCount"
+
+type TableCountOpSpec struct {
+	Column string `json:"column"`
+}
+
+func init() {
+	tableCountSignature := flux.FunctionSignature(map[string]semantic.PolyType{
+		"column": semantic.String,
+	}, nil)
+
+	flux.RegisterPackageValue("universe", TableCountKind, flux.FunctionValue(TableCountKind, createTableCountOpSpec, tableCountSignature))
+	flux.RegisterOpSpec(TableCountKind, newTableCountOp)
+	plan.RegisterProcedureSpec(TableCountKind, newTableCountProcedure, TableCountKind)
+	execute.RegisterTransformation(TableCountKind, createTableCountTransformation)
+}
+
+func createTableCountOpSpec(args flux.Arguments, a *flux.Administration) (flux.OperationSpec, error) {
+	if err := a.AddParentFromArgs(args); err != nil {
+		return nil, err
+	}
+
+	spec := new(TableCountOpSpec)
+
+	if col, found, err := args.GetString("column"); err != nil {
+		return nil, err
+	} else if found {
+		spec.Column = col
+	} else {
+		spec.Column = execute.DefaultValueColLabel
+	}
+
+	return spec, nil
+}
+
+func newTableCountOp() flux.OperationSpec {
+	return new(TableCountOpSpec)
+}
+
+func (s *TableCountOpSpec) Kind() flux.OperationKind {
+	return TableCountKind
+}
+
+type TableCountProcedureSpec struct {
+	plan.DefaultCost
+	Column string
+}
+
+func newTableCountProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	spec, ok := qs.(*TableCountOpSpec)
+	if !ok {
+		return nil, fmt.Errorf("invalid spec type %T", qs)
+	}
+
+	return &TableCountProcedureSpec{
+		Column: spec.Column,
+	}, nil
+}
+
+func (s *TableCountProcedureSpec) Kind() plan.ProcedureKind {
+	return TableCountKind
+}
+
+func (s *TableCountProcedureSpec) Copy() plan.ProcedureSpec {
+	ns := new(TableCountProcedureSpec)
+	*ns = *s
+	return ns
+}
+
+func createTableCountTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	s, ok := spec.(*TableCountProcedureSpec)
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid spec type %T", spec)
+	}
+	cache := execute.NewTableBuilderCache(a.Allocator())
+	d := execute.NewDataset(id, mode, cache)
+	t := NewTableCountTransformation(d, cache, s)
+	return t, d, nil
+}
+
+// tableCountTransformation counts its input tables and produces
+// a single table with an empty group key holding the count once all input is finished.
+type tableCountTransformation struct {
+	d     execute.Dataset
+	cache execute.TableBuilderCache
+
+	column string
+	count  int64
+}
+
+func NewTableCountTransformation(d execute.Dataset, cache execute.TableBuilderCache, spec *TableCountProcedureSpec) *tableCountTransformation {
+	return &tableCountTransformation{
+		d:      d,
+		cache:  cache,
+		column: spec.Column,
+	}
+}
+
+func (t *tableCountTransformation) RetractTable(id execute.DatasetID, key flux.GroupKey) error {
+	return t.d.RetractTable(key)
+}
+
+func (t *tableCountTransformation) Process(id execute.DatasetID, tbl flux.Table) error {
+	t.count++
+	return tbl.Do(func(flux.ColReader) error {
+		return nil
+	})
+}
+
+func (t *tableCountTransformation) UpdateWatermark(id execute.DatasetID, mark execute.Time) error {
+	return t.d.UpdateWatermark(mark)
+}
+func (t *tableCountTransformation) UpdateProcessingTime(id execute.DatasetID, pt execute.Time) error {
+	return t.d.UpdateProcessingTime(pt)
+}
+func (t *tableCountTransformation) Finish(id execute.DatasetID, err error) {
+	if err == nil {
+		err = t.appendCount()
+	}
+	t.d.Finish(err)
+}
+
+func (t *tableCountTransformation) appendCount() error {
+	builder, _ := t.cache.TableBuilder(execute.NewGroupKey(nil, nil))
+	j, err := builder.AddCol(flux.ColMeta{Label: t.column, Type: flux.TInt})
+	if err != nil {
+		return err
+	}
+	return builder.AppendInt(j, t.count)
+}
@@ -0,0 +1,130 @@
+package universe_test
+
+import (
+	"testing"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/flux/querytest"
+	"github.com/influxdata/flux/stdlib/influxdata/influxdb"
+	"github.com/influxdata/flux/stdlib/universe"
+)
+
+func TestTableCount_NewQuery(t *testing.T) {
+	tests := []querytest.NewQueryTestCase{
+		{
+			Name: "from tableCount",
+			Raw:  `from(bucket:"mydb") |> tableCount()`,
+			Want: &flux.Spec{
+				Operations: []*flux.Operation{
+					{
+						ID: "from0",
+						Spec: &influxdb.FromOpSpec{
+							Bucket: "mydb",
+						},
+					},
+					{
+						ID: "tableCount1",
+						Spec: &universe.TableCountOpSpec{
+							Column: "_value",
+						},
+					},
+				},
+				Edges: []flux.Edge{
+					{Parent: "from0", Child: "tableCount1"},
+				},
+			},
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			querytest.NewQueryTestHelper(t, tc)
+		})
+	}
+}
+
+func TestTableCount_Process(t *testing.T) {
+	testCases := []struct {
+		name string
+		spec *universe.TableCountProcedureSpec
+		data []flux.Table
+		want []*executetest.Table
+	}{
+		{
+			name: "three tables",
+			spec: &universe.TableCountProcedureSpec{
+				Column: "count",
+			},
+			data: []flux.Table{
+				&executetest.Table{
+					KeyCols: []string{"tag0"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "tag0", Type: flux.TString},
+					},
+					Data: [][]interface{}{
+						{execute.Time(1), "a"},
+						{execute.Time(2), "a"},
+					},
+				},
+				&executetest.Table{
+					KeyCols: []string{"tag0"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "tag0", Type: flux.TString},
+					},
+					Data: [][]interface{}{
+						{execute.Time(1), "b"},
+					},
+				},
+				&executetest.Table{
+					KeyCols:   []string{"tag0"},
+					KeyValues: []interface{}{"c"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "tag0", Type: flux.TString},
+					},
+				},
+			},
+			want: []*executetest.Table{{
+				ColMeta: []flux.ColMeta{
+					{Label: "count", Type: flux.TInt},
+				},
+				Data: [][]interface{}{
+					{int64(3)},
+				},
+			}},
+		},
+		{
+			name: "no tables",
+			spec: &universe.TableCountProcedureSpec{
+				Column: "_value",
+			},
+			want: []*executetest.Table{{
+				ColMeta: []flux.ColMeta{
+					{Label: "_value", Type: flux.TInt},
+				},
+				Data: [][]interface{}{
+					{int64(0)},
+				},
+			}},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			executetest.ProcessTestHelper(
+				t,
+				tc.data,
+				tc.want,
+				nil,
+				func(d execute.Dataset, c execute.TableBuilderCache) execute.Transformation {
+					return universe.NewTableCountTransformation(d, c, tc.spec)
+				},
+			)
+		})
+	}
+}
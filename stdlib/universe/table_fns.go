@@ -0,0 +1,214 @@
+package universe
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/flux/values"
+)
+
+// The functions below extract values from a stream of tables while a script is evaluated.
+// A table is represented as an array of records, one per row.
+
+const (
+	tableFindKind = "tableFind"
+	getColumnKind = "getColumn"
+	getRecordKind = "getRecord"
+)
+
+var tableFindSignature = semantic.FunctionPolySignature{
+	Parameters: map[string]semantic.PolyType{
+		"tables": flux.TableObjectType,
+		"fn": semantic.NewFunctionPolyType(semantic.FunctionPolySignature{
+			Parameters: map[string]semantic.PolyType{
+				"key": semantic.Tvar(1),
+			},
+			Required: semantic.LabelSet{"key"},
+			Return:   semantic.Bool,
+		}),
+	},
+	Required:     semantic.LabelSet{"fn"},
+	Return:       semantic.NewArrayPolyType(semantic.Tvar(2)),
+	PipeArgument: "tables",
+}
+
+var getColumnSignature = semantic.FunctionPolySignature{
+	Parameters: map[string]semantic.PolyType{
+		"table":  semantic.NewArrayPolyType(semantic.Tvar(1)),
+		"column": semantic.String,
+	},
+	Required:     semantic.LabelSet{"column"},
+	Return:       semantic.NewArrayPolyType(semantic.Tvar(2)),
+	PipeArgument: "table",
+}
+
+var getRecordSignature = semantic.FunctionPolySignature{
+	Parameters: map[string]semantic.PolyType{
+		"table": semantic.NewArrayPolyType(semantic.Tvar(1)),
+		"idx":   semantic.Int,
+	},
+	Required:     semantic.LabelSet{"idx"},
+	Return:       semantic.Tvar(1),
+	PipeArgument: "table",
+}
+
+func init() {
+	flux.RegisterPackageValue("universe", tableFindKind, values.NewContextFunction(
+		tableFindKind,
+		semantic.NewFunctionPolyType(tableFindSignature),
+		tableFind,
+		false,
+	))
+	flux.RegisterPackageValue("universe", getColumnKind, values.NewFunction(
+		getColumnKind,
+		semantic.NewFunctionPolyType(getColumnSignature),
+		getColumn,
+		false,
+	))
+	flux.RegisterPackageValue("universe", getRecordKind, values.NewFunction(
+		getRecordKind,
+		semantic.NewFunctionPolyType(getRecordSignature),
+		getRecord,
+		false,
+	))
+}
+
+// tableFind executes the pipeline of its tables and returns the rows of the first table
+// whose group key satisfies the predicate fn.
+func tableFind(ctx context.Context, args values.Object) (values.Value, error) {
+	v, ok := args.Get("tables")
+	if !ok {
+		return nil, errors.New("missing argument tables")
+	}
+	to, ok := v.(*flux.TableObject)
+	if !ok {
+		return nil, fmt.Errorf("argument tables must be a stream of tables, got %v", v.Type().Nature())
+	}
+	v, ok = args.Get("fn")
+	if !ok {
+		return nil, errors.New("missing argument fn")
+	}
+	if v.PolyType().Nature() != semantic.Function {
+		return nil, fmt.Errorf("argument fn must be a function, got %v", v.PolyType().Nature())
+	}
+	fn := v.Function()
+
+	var found values.Array
+	if err := flux.EvalTables(ctx, to, func(tbl flux.Table) error {
+		if found != nil {
+			return nil
+		}
+		match, err := fn.Call(values.NewObjectWithValues(map[string]values.Value{
+			"key": groupKeyObject(tbl.Key()),
+		}))
+		if err != nil {
+			return err
+		}
+		if match.Type() != semantic.Bool {
+			return fmt.Errorf("fn must return a bool, got %v", match.Type())
+		}
+		if match.IsNull() || !match.Bool() {
+			return nil
+		}
+		found, err = tableRecords(tbl)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	if found == nil {
+		return nil, fmt.Errorf("%s: no table found", tableFindKind)
+	}
+	return found, nil
+}
+
+// getColumn returns the values of a column of a table.
+func getColumn(args values.Object) (values.Value, error) {
+	table, err := tableArg(args)
+	if err != nil {
+		return nil, err
+	}
+	v, ok := args.Get("column")
+	if !ok {
+		return nil, errors.New("missing argument column")
+	}
+	if v.Type() != semantic.String {
+		return nil, fmt.Errorf("argument column must be a string, got %v", v.Type())
+	}
+	column := v.Str()
+	typ, ok := table.Type().ElementType().Properties()[column]
+	if !ok {
+		return nil, fmt.Errorf("%s: table has no column %q", getColumnKind, column)
+	}
+	vs := make([]values.Value, table.Len())
+	table.Range(func(i int, r values.Value) {
+		vs[i], _ = r.Object().Get(column)
+	})
+	return values.NewArrayWithBacking(typ, vs), nil
+}
+
+// getRecord returns the row of a table at an index.
+func getRecord(args values.Object) (values.Value, error) {
+	table, err := tableArg(args)
+	if err != nil {
+		return nil, err
+	}
+	v, ok := args.Get("idx")
+	if !ok {
+		return nil, errors.New("missing argument idx")
+	}
+	if v.Type() != semantic.Int {
+		return nil, fmt.Errorf("argument idx must be an int, got %v", v.Type())
+	}
+	idx := v.Int()
+	if idx < 0 || idx >= int64(table.Len()) {
+		return nil, fmt.Errorf("%s: index %d out of bounds for a table of %d records", getRecordKind, idx, table.Len())
+	}
+	return table.Get(int(idx)), nil
+}
+
+// tableArg returns the table argument of getColumn and getRecord.
+func tableArg(args values.Object) (values.Array, error) {
+	v, ok := args.Get("table")
+	if !ok {
+		return nil, errors.New("missing argument table")
+	}
+	if v.Type().Nature() != semantic.Array || v.Type().ElementType().Nature() != semantic.Object {
+		return nil, fmt.Errorf("argument table must be an array of records, got %v", v.Type())
+	}
+	return v.Array(), nil
+}
+
+// groupKeyObject returns the columns of a group key as an object.
+func groupKeyObject(key flux.GroupKey) values.Object {
+	// The object is created with its values, so that it has a type even if the key is empty.
+	vs := make(map[string]values.Value, len(key.Cols()))
+	for j, c := range key.Cols() {
+		vs[c.Label] = key.Value(j)
+	}
+	return values.NewObjectWithValues(vs)
+}
+
+// tableRecords reads the rows of a table as an array of records.
+func tableRecords(tbl flux.Table) (values.Array, error) {
+	cols := tbl.Cols()
+	props := make(map[string]semantic.Type, len(cols))
+	for _, c := range cols {
+		props[c.Label] = execute.ConvertToKind(c.Type)
+	}
+	records := values.NewArray(semantic.NewObjectType(props))
+	err := tbl.Do(func(cr flux.ColReader) error {
+		for i := 0; i < cr.Len(); i++ {
+			record := values.NewObject()
+			for j, c := range cols {
+				record.Set(c.Label, execute.ValueForRow(cr, i, j))
+			}
+			records.Append(record)
+		}
+		return nil
+	})
+	return records, err
+}
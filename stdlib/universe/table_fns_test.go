@@ -0,0 +1,157 @@
+package universe_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/flux/stdlib/influxdata/influxdb"
+)
+
+type tablesEvaluator struct {
+	tables []*executetest.Table
+	now    time.Time
+}
+
+func (e *tablesEvaluator) EvalTables(ctx context.Context, spec *flux.Spec, f func(flux.Table) error) error {
+	e.now = spec.Now
+	for _, tbl := range e.tables {
+		if err := f(tbl); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func newTablesEvaluator() *tablesEvaluator {
+	cols := []flux.ColMeta{
+		{Label: "_time", Type: flux.TTime},
+		{Label: "_value", Type: flux.TFloat},
+		{Label: "host", Type: flux.TString},
+		{Label: "bucket", Type: flux.TString},
+	}
+	return &tablesEvaluator{
+		tables: []*executetest.Table{
+			{
+				KeyCols: []string{"host"},
+				ColMeta: cols,
+				Data: [][]interface{}{
+					{execute.Time(1), 1.0, "a", "bucket-a"},
+				},
+			},
+			{
+				KeyCols: []string{"host"},
+				ColMeta: cols,
+				Data: [][]interface{}{
+					{execute.Time(1), 2.0, "b", "bucket-b1"},
+					{execute.Time(2), 3.0, "b", "bucket-b2"},
+				},
+			},
+		},
+	}
+}
+
+func TestTableFns(t *testing.T) {
+	testCases := []struct {
+		name    string
+		script  string
+		tables  []*executetest.Table
+		bucket  string
+		wantErr string
+	}{
+		{
+			name: "get record",
+			script: `
+t = from(bucket: "db") |> range(start: -1h) |> tableFind(fn: (key) => key.host == "b")
+from(bucket: getRecord(table: t, idx: 1).bucket)`,
+			bucket: "bucket-b2",
+		},
+		{
+			name: "get column",
+			script: `
+buckets = from(bucket: "db") |> range(start: -1h) |> tableFind(fn: (key) => key.host == "a") |> getColumn(column: "bucket")
+from(bucket: buckets[0])`,
+			bucket: "bucket-a",
+		},
+		{
+			name: "no table found",
+			script: `
+t = from(bucket: "db") |> range(start: -1h) |> tableFind(fn: (key) => key.host == "c")
+from(bucket: getRecord(table: t, idx: 0).bucket)`,
+			wantErr: "no table found",
+		},
+		{
+			name: "missing column",
+			script: `
+buckets = from(bucket: "db") |> range(start: -1h) |> tableFind(fn: (key) => key.host == "a") |> getColumn(column: "region")
+from(bucket: buckets[0])`,
+			wantErr: `table has no column "region"`,
+		},
+		{
+			name: "index out of bounds",
+			script: `
+t = from(bucket: "db") |> range(start: -1h) |> tableFind(fn: (key) => key.host == "a")
+from(bucket: getRecord(table: t, idx: 1).bucket)`,
+			wantErr: "index 1 out of bounds",
+		},
+		{
+			name: "empty group key",
+			script: `
+t = from(bucket: "db") |> range(start: -1h) |> tableFind(fn: (key) => true)
+from(bucket: getRecord(table: t, idx: 0).bucket)`,
+			tables: []*executetest.Table{{
+				ColMeta: []flux.ColMeta{
+					{Label: "_value", Type: flux.TFloat},
+					{Label: "bucket", Type: flux.TString},
+				},
+				Data: [][]interface{}{
+					{1.0, "bucket-all"},
+				},
+			}},
+			bucket: "bucket-all",
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			now := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+			e := newTablesEvaluator()
+			if tc.tables != nil {
+				e.tables = tc.tables
+			}
+			ctx := flux.WithTablesEvaluator(context.Background(), e)
+			spec, err := flux.Compile(ctx, tc.script, now)
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("unexpected error: want %q got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !e.now.Equal(now) {
+				t.Errorf("unexpected now: want %v got %v", now, e.now)
+			}
+			if len(spec.Operations) != 1 {
+				t.Fatalf("unexpected operations: %v", spec.Operations)
+			}
+			if got := spec.Operations[0].Spec.(*influxdb.FromOpSpec).Bucket; got != tc.bucket {
+				t.Errorf("unexpected bucket: want %q got %q", tc.bucket, got)
+			}
+		})
+	}
+}
+
+func TestTableFind_NoEvaluator(t *testing.T) {
+	script := `
+t = from(bucket: "db") |> range(start: -1h) |> tableFind(fn: (key) => true)
+from(bucket: getRecord(table: t, idx: 0).bucket)`
+	if _, err := flux.Compile(context.Background(), script, time.Now()); err == nil {
+		t.Fatal("expected error")
+	}
+}
@@ -12,13 +12,15 @@ import (
 const UniqueKind = "unique"
 
 type UniqueOpSpec struct {
-	Column string `json:"column"`
+	Column  string   `json:"column"`
+	Columns []string `json:"columns,omitempty"`
 }
 
 func init() {
 	uniqueSignature := flux.FunctionSignature(
 		map[string]semantic.PolyType{
-			"column": semantic.String,
+			"column":  semantic.String,
+			"columns": semantic.NewArrayPolyType(semantic.String),
 		},
 		nil,
 	)
@@ -36,6 +38,15 @@ func createUniqueOpSpec(args flux.Arguments, a *flux.Administration) (flux.Opera
 
 	spec := new(UniqueOpSpec)
 
+	columns, err := getDistinctColumns(args, UniqueKind)
+	if err != nil {
+		return nil, err
+	}
+	if columns != nil {
+		spec.Columns = columns
+		return spec, nil
+	}
+
 	if col, ok, err := args.GetString("column"); err != nil {
 		return nil, err
 	} else if ok {
@@ -58,6 +69,8 @@ func (s *UniqueOpSpec) Kind() flux.OperationKind {
 type UniqueProcedureSpec struct {
 	plan.DefaultCost
 	Column string
+	// Columns are the columns of which the combinations of values must be unique, instead of Column.
+	Columns []string
 }
 
 func newUniqueProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
@@ -67,7 +80,8 @@ func newUniqueProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.Pro
 	}
 
 	return &UniqueProcedureSpec{
-		Column: spec.Column,
+		Column:  spec.Column,
+		Columns: spec.Columns,
 	}, nil
 }
 
@@ -78,6 +92,9 @@ func (s *UniqueProcedureSpec) Copy() plan.ProcedureSpec {
 	ns := new(UniqueProcedureSpec)
 
 	*ns = *s
+	if s.Columns != nil {
+		ns.Columns = append([]string(nil), s.Columns...)
+	}
 
 	return ns
 }
@@ -97,14 +114,16 @@ type uniqueTransformation struct {
 	d     execute.Dataset
 	cache execute.TableBuilderCache
 
-	column string
+	column  string
+	columns []string
 }
 
 func NewUniqueTransformation(d execute.Dataset, cache execute.TableBuilderCache, spec *UniqueProcedureSpec) *uniqueTransformation {
 	return &uniqueTransformation{
-		d:      d,
-		cache:  cache,
-		column: spec.Column,
+		d:       d,
+		cache:   cache,
+		column:  spec.Column,
+		columns: spec.Columns,
 	}
 }
 
@@ -121,6 +140,26 @@ func (t *uniqueTransformation) Process(id execute.DatasetID, tbl flux.Table) err
 		return err
 	}
 
+	if t.columns != nil {
+		// Keep the first row of every combination of values of the columns.
+		cols, err := distinctColumnIndexes(t.columns, builder.Cols(), UniqueKind)
+		if err != nil {
+			return err
+		}
+		set := newRowSet(cols)
+		return tbl.Do(func(cr flux.ColReader) error {
+			for i := 0; i < cr.Len(); i++ {
+				if !set.add(cr, i) {
+					continue
+				}
+				if err := execute.AppendRecord(i, cr, builder); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	}
+
 	colIdx := execute.ColIdx(t.column, builder.Cols())
 	if colIdx < 0 {
 		return fmt.Errorf("no column %q exists", t.column)
@@ -168,6 +168,41 @@ func TestUnique_Process(t *testing.T) {
 				},
 			}},
 		},
+		{
+			name: "columns",
+			spec: &universe.UniqueProcedureSpec{
+				Columns: []string{"host", "_value"},
+			},
+			data: []flux.Table{&executetest.Table{
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_value", Type: flux.TFloat},
+					{Label: "host", Type: flux.TString},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), 2.0, "a"},
+					{execute.Time(2), 1.0, "a"},
+					{execute.Time(3), 2.0, "b"},
+					{execute.Time(4), 2.0, "a"},
+					{execute.Time(5), nil, "b"},
+					{execute.Time(6), 1.0, "a"},
+					{execute.Time(7), nil, "b"},
+				},
+			}},
+			want: []*executetest.Table{{
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_value", Type: flux.TFloat},
+					{Label: "host", Type: flux.TString},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), 2.0, "a"},
+					{execute.Time(2), 1.0, "a"},
+					{execute.Time(3), 2.0, "b"},
+					{execute.Time(5), nil, "b"},
+				},
+			}},
+		},
 	}
 	for _, tc := range testCases {
 		tc := tc
@@ -0,0 +1,286 @@
+package universe
+
+import (
+	"fmt"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/interpreter"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/flux/values"
+)
+
+const UnpivotKind = "unpivot"
+
+// UnpivotOpSpec turns columns of a table into rows of field and value pairs. It is the inverse of pivot.
+type UnpivotOpSpec struct {
+	Columns     []string `json:"columns"`
+	FieldColumn string   `json:"fieldColumn"`
+	ValueColumn string   `json:"valueColumn"`
+}
+
+func init() {
+	unpivotSignature := flux.FunctionSignature(
+		map[string]semantic.PolyType{
+			"columns":     semantic.NewArrayPolyType(semantic.String),
+			"fieldColumn": semantic.String,
+			"valueColumn": semantic.String,
+		},
+		nil,
+	)
+
+	flux.RegisterPackageValue("universe", UnpivotKind, flux.FunctionValue(UnpivotKind, createUnpivotOpSpec, unpivotSignature))
+	// melt is the name of unpivot in other data processing tools.
+	flux.RegisterPackageValue("universe", "melt", flux.FunctionValue(UnpivotKind, createUnpivotOpSpec, unpivotSignature))
+	flux.RegisterOpSpec(UnpivotKind, newUnpivotOp)
+	plan.RegisterProcedureSpec(UnpivotKind, newUnpivotProcedure, UnpivotKind)
+	execute.RegisterTransformation(UnpivotKind, createUnpivotTransformation)
+}
+
+func createUnpivotOpSpec(args flux.Arguments, a *flux.Administration) (flux.OperationSpec, error) {
+	if err := a.AddParentFromArgs(args); err != nil {
+		return nil, err
+	}
+
+	spec := &UnpivotOpSpec{
+		FieldColumn: execute.DefaultFieldColLabel,
+		ValueColumn: execute.DefaultValueColLabel,
+	}
+	if array, ok, err := args.GetArray("columns", semantic.String); err != nil {
+		return nil, err
+	} else if ok {
+		spec.Columns, err = interpreter.ToStringArray(array)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if col, ok, err := args.GetString("fieldColumn"); err != nil {
+		return nil, err
+	} else if ok {
+		spec.FieldColumn = col
+	}
+	if col, ok, err := args.GetString("valueColumn"); err != nil {
+		return nil, err
+	} else if ok {
+		spec.ValueColumn = col
+	}
+	if spec.FieldColumn == spec.ValueColumn {
+		return nil, fmt.Errorf("the field and value columns must be different, both are %q", spec.FieldColumn)
+	}
+	return spec, nil
+}
+
+func newUnpivotOp() flux.OperationSpec {
+	return new(UnpivotOpSpec)
+}
+
+func (s *UnpivotOpSpec) Kind() flux.OperationKind {
+	return UnpivotKind
+}
+
+type UnpivotProcedureSpec struct {
+	plan.DefaultCost
+	// Columns are the columns to unpivot.
+	// When nil, all the columns that are not part of the group key are unpivoted, except for the time column.
+	Columns     []string
+	FieldColumn string
+	ValueColumn string
+}
+
+func newUnpivotProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	spec, ok := qs.(*UnpivotOpSpec)
+	if !ok {
+		return nil, fmt.Errorf("invalid spec type %T", qs)
+	}
+	return &UnpivotProcedureSpec{
+		Columns:     spec.Columns,
+		FieldColumn: spec.FieldColumn,
+		ValueColumn: spec.ValueColumn,
+	}, nil
+}
+
+func (s *UnpivotProcedureSpec) Kind() plan.ProcedureKind {
+	return UnpivotKind
+}
+
+func (s *UnpivotProcedureSpec) Copy() plan.ProcedureSpec {
+	ns := new(UnpivotProcedureSpec)
+	*ns = *s
+	if s.Columns != nil {
+		ns.Columns = make([]string, len(s.Columns))
+		copy(ns.Columns, s.Columns)
+	}
+	return ns
+}
+
+func createUnpivotTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	s, ok := spec.(*UnpivotProcedureSpec)
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid spec type %T", spec)
+	}
+	cache := execute.NewTableBuilderCache(a.Allocator())
+	d := execute.NewDataset(id, mode, cache)
+	t := NewUnpivotTransformation(d, cache, s)
+	return t, d, nil
+}
+
+type unpivotTransformation struct {
+	d     execute.Dataset
+	cache execute.TableBuilderCache
+
+	columns     []string
+	fieldColumn string
+	valueColumn string
+}
+
+func NewUnpivotTransformation(d execute.Dataset, cache execute.TableBuilderCache, spec *UnpivotProcedureSpec) *unpivotTransformation {
+	return &unpivotTransformation{
+		d:           d,
+		cache:       cache,
+		columns:     spec.Columns,
+		fieldColumn: spec.FieldColumn,
+		valueColumn: spec.ValueColumn,
+	}
+}
+
+func (t *unpivotTransformation) RetractTable(id execute.DatasetID, key flux.GroupKey) error {
+	return t.d.RetractTable(key)
+}
+
+// Process adds a row to the table of every unpivoted column for each of its non null values.
+// The table of a column has the group key of tbl extended with the field column, set to the label of the column.
+func (t *unpivotTransformation) Process(id execute.DatasetID, tbl flux.Table) error {
+	cols := tbl.Cols()
+	key := tbl.Key()
+
+	// The indexes of the unpivoted columns and of the kept columns in tbl.
+	var unpivoted, kept []int
+	if t.columns == nil {
+		for j, c := range cols {
+			if key.HasCol(c.Label) || c.Label == execute.DefaultTimeColLabel {
+				kept = append(kept, j)
+			} else {
+				unpivoted = append(unpivoted, j)
+			}
+		}
+	} else {
+		for _, label := range t.columns {
+			j := execute.ColIdx(label, cols)
+			if j < 0 {
+				// A column that is missing has no values to unpivot.
+				continue
+			}
+			if key.HasCol(label) {
+				return fmt.Errorf("unpivot: cannot unpivot column %q of the group key", label)
+			}
+			unpivoted = append(unpivoted, j)
+		}
+		for j, c := range cols {
+			if !execute.ContainsStr(t.columns, c.Label) {
+				kept = append(kept, j)
+			}
+		}
+	}
+	for _, j := range kept {
+		if label := cols[j].Label; label == t.fieldColumn || label == t.valueColumn {
+			return fmt.Errorf("unpivot: column %q of the table is kept and cannot be overwritten", label)
+		}
+	}
+
+	// The builders of the unpivoted columns, and the indexes of the kept columns in each of them.
+	builders := make([]execute.TableBuilder, len(unpivoted))
+	colMaps := make([][]int, len(unpivoted))
+	for k, j := range unpivoted {
+		c := cols[j]
+		fieldKey := execute.NewGroupKeyBuilder(key).
+			AddKeyValue(t.fieldColumn, values.NewString(c.Label))
+		outKey, err := fieldKey.Build()
+		if err != nil {
+			return err
+		}
+		builder, created := t.cache.TableBuilder(outKey)
+		if created {
+			for _, j := range kept {
+				if _, err := builder.AddCol(cols[j]); err != nil {
+					return err
+				}
+			}
+			if _, err := builder.AddCol(flux.ColMeta{Label: t.fieldColumn, Type: flux.TString}); err != nil {
+				return err
+			}
+			if _, err := builder.AddCol(flux.ColMeta{Label: t.valueColumn, Type: c.Type}); err != nil {
+				return err
+			}
+		}
+		colMap, err := t.colMap(builder, cols, kept, c)
+		if err != nil {
+			return err
+		}
+		builders[k] = builder
+		colMaps[k] = colMap
+	}
+
+	return tbl.Do(func(cr flux.ColReader) error {
+		for i := 0; i < cr.Len(); i++ {
+			for k, j := range unpivoted {
+				v := execute.ValueForRow(cr, i, j)
+				if v.IsNull() {
+					continue
+				}
+				builder, colMap := builders[k], colMaps[k]
+				for n, jj := range kept {
+					if err := builder.AppendValue(colMap[n], execute.ValueForRow(cr, i, jj)); err != nil {
+						return err
+					}
+				}
+				if err := builder.AppendString(colMap[len(kept)], cols[j].Label); err != nil {
+					return err
+				}
+				if err := builder.AppendValue(colMap[len(kept)+1], v); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// colMap returns the indexes in builder of the kept columns, followed by the field and value columns.
+// The builder may have been created for another table, so its schema is checked.
+func (t *unpivotTransformation) colMap(builder execute.TableBuilder, cols []flux.ColMeta, kept []int, c flux.ColMeta) ([]int, error) {
+	bcols := builder.Cols()
+	if len(bcols) != len(kept)+2 {
+		return nil, fmt.Errorf("unpivot: column %q is unpivoted from tables with different columns", c.Label)
+	}
+	colMap := make([]int, 0, len(kept)+2)
+	for _, j := range kept {
+		colMap = append(colMap, execute.ColIdx(cols[j].Label, bcols))
+	}
+	colMap = append(colMap, execute.ColIdx(t.fieldColumn, bcols), execute.ColIdx(t.valueColumn, bcols))
+	for n, idx := range colMap {
+		want := c.Type
+		switch {
+		case n < len(kept):
+			want = cols[kept[n]].Type
+		case n == len(kept):
+			want = flux.TString
+		}
+		if idx < 0 || bcols[idx].Type != want {
+			return nil, fmt.Errorf("unpivot: column %q is unpivoted from tables with different columns", c.Label)
+		}
+	}
+	return colMap, nil
+}
+
+func (t *unpivotTransformation) UpdateWatermark(id execute.DatasetID, mark execute.Time) error {
+	return t.d.UpdateWatermark(mark)
+}
+
+func (t *unpivotTransformation) UpdateProcessingTime(id execute.DatasetID, pt execute.Time) error {
+	return t.d.UpdateProcessingTime(pt)
+}
+
+func (t *unpivotTransformation) Finish(id execute.DatasetID, err error) {
+	t.d.Finish(err)
+}
@@ -0,0 +1,209 @@
+package universe_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/flux/querytest"
+	"github.com/influxdata/flux/stdlib/influxdata/influxdb"
+	"github.com/influxdata/flux/stdlib/universe"
+)
+
+func TestUnpivot_NewQuery(t *testing.T) {
+	tests := []querytest.NewQueryTestCase{
+		{
+			Name: "unpivot defaults",
+			Raw:  `from(bucket:"testdb") |> unpivot()`,
+			Want: &flux.Spec{
+				Operations: []*flux.Operation{
+					{
+						ID:   "from0",
+						Spec: &influxdb.FromOpSpec{Bucket: "testdb"},
+					},
+					{
+						ID: "unpivot1",
+						Spec: &universe.UnpivotOpSpec{
+							FieldColumn: "_field",
+							ValueColumn: "_value",
+						},
+					},
+				},
+				Edges: []flux.Edge{
+					{Parent: "from0", Child: "unpivot1"},
+				},
+			},
+		},
+		{
+			Name: "melt with columns",
+			Raw:  `from(bucket:"testdb") |> melt(columns: ["cpu", "mem"], fieldColumn: "metric", valueColumn: "v")`,
+			Want: &flux.Spec{
+				Operations: []*flux.Operation{
+					{
+						ID:   "from0",
+						Spec: &influxdb.FromOpSpec{Bucket: "testdb"},
+					},
+					{
+						ID: "unpivot1",
+						Spec: &universe.UnpivotOpSpec{
+							Columns:     []string{"cpu", "mem"},
+							FieldColumn: "metric",
+							ValueColumn: "v",
+						},
+					},
+				},
+				Edges: []flux.Edge{
+					{Parent: "from0", Child: "unpivot1"},
+				},
+			},
+		},
+		{
+			Name:    "same field and value column",
+			Raw:     `from(bucket:"testdb") |> unpivot(fieldColumn: "x", valueColumn: "x")`,
+			WantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			querytest.NewQueryTestHelper(t, tc)
+		})
+	}
+}
+
+func TestUnpivot_Process(t *testing.T) {
+	wide := []flux.ColMeta{
+		{Label: "host", Type: flux.TString},
+		{Label: "_time", Type: flux.TTime},
+		{Label: "cpu", Type: flux.TFloat},
+		{Label: "mem", Type: flux.TInt},
+	}
+	testCases := []struct {
+		name    string
+		spec    *universe.UnpivotProcedureSpec
+		data    []flux.Table
+		want    []*executetest.Table
+		wantErr error
+	}{
+		{
+			name: "default columns",
+			spec: &universe.UnpivotProcedureSpec{FieldColumn: "_field", ValueColumn: "_value"},
+			data: []flux.Table{
+				&executetest.Table{
+					KeyCols: []string{"host"},
+					ColMeta: wide,
+					Data: [][]interface{}{
+						{"a", execute.Time(1), 0.5, int64(10)},
+						{"a", execute.Time(2), nil, int64(20)},
+					},
+				},
+			},
+			want: []*executetest.Table{
+				{
+					KeyCols: []string{"host", "_field"},
+					ColMeta: []flux.ColMeta{
+						{Label: "host", Type: flux.TString},
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_field", Type: flux.TString},
+						{Label: "_value", Type: flux.TFloat},
+					},
+					Data: [][]interface{}{
+						{"a", execute.Time(1), "cpu", 0.5},
+					},
+				},
+				{
+					KeyCols: []string{"host", "_field"},
+					ColMeta: []flux.ColMeta{
+						{Label: "host", Type: flux.TString},
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_field", Type: flux.TString},
+						{Label: "_value", Type: flux.TInt},
+					},
+					Data: [][]interface{}{
+						{"a", execute.Time(1), "mem", int64(10)},
+						{"a", execute.Time(2), "mem", int64(20)},
+					},
+				},
+			},
+		},
+		{
+			name: "selected columns",
+			spec: &universe.UnpivotProcedureSpec{Columns: []string{"cpu", "disk"}, FieldColumn: "_field", ValueColumn: "_value"},
+			data: []flux.Table{
+				&executetest.Table{
+					KeyCols: []string{"host"},
+					ColMeta: wide,
+					Data: [][]interface{}{
+						{"a", execute.Time(1), 0.5, int64(10)},
+					},
+				},
+				&executetest.Table{
+					KeyCols: []string{"host"},
+					ColMeta: wide,
+					Data: [][]interface{}{
+						{"b", execute.Time(1), 0.25, int64(30)},
+					},
+				},
+			},
+			want: []*executetest.Table{
+				{
+					KeyCols: []string{"host", "_field"},
+					ColMeta: []flux.ColMeta{
+						{Label: "host", Type: flux.TString},
+						{Label: "_time", Type: flux.TTime},
+						{Label: "mem", Type: flux.TInt},
+						{Label: "_field", Type: flux.TString},
+						{Label: "_value", Type: flux.TFloat},
+					},
+					Data: [][]interface{}{
+						{"a", execute.Time(1), int64(10), "cpu", 0.5},
+					},
+				},
+				{
+					KeyCols: []string{"host", "_field"},
+					ColMeta: []flux.ColMeta{
+						{Label: "host", Type: flux.TString},
+						{Label: "_time", Type: flux.TTime},
+						{Label: "mem", Type: flux.TInt},
+						{Label: "_field", Type: flux.TString},
+						{Label: "_value", Type: flux.TFloat},
+					},
+					Data: [][]interface{}{
+						{"b", execute.Time(1), int64(30), "cpu", 0.25},
+					},
+				},
+			},
+		},
+		{
+			name: "group key column",
+			spec: &universe.UnpivotProcedureSpec{Columns: []string{"host"}, FieldColumn: "_field", ValueColumn: "_value"},
+			data: []flux.Table{
+				&executetest.Table{
+					KeyCols: []string{"host"},
+					ColMeta: wide,
+					Data: [][]interface{}{
+						{"a", execute.Time(1), 0.5, int64(10)},
+					},
+				},
+			},
+			wantErr: errors.New(`unpivot: cannot unpivot column "host" of the group key`),
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			executetest.ProcessTestHelper(
+				t,
+				tc.data,
+				tc.want,
+				tc.wantErr,
+				func(d execute.Dataset, c execute.TableBuilderCache) execute.Transformation {
+					return universe.NewUnpivotTransformation(d, c, tc.spec)
+				},
+			)
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/influxdata/flux"
 	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/memory"
 	"github.com/influxdata/flux/plan"
 	"github.com/influxdata/flux/semantic"
 	"github.com/influxdata/flux/values"
@@ -22,9 +23,10 @@ type WindowOpSpec struct {
 	StopColumn  string        `json:"stopColumn"`
 	StartColumn string        `json:"startColumn"`
 	CreateEmpty bool          `json:"createEmpty"`
+	Duplicates  string        `json:"duplicates,omitempty"`
 }
 
-var infinityVar = values.NewDuration(math.MaxInt64)
+var infinityVar = values.NewDuration(values.ConvertDuration(math.MaxInt64))
 
 func init() {
 	windowSignature := flux.FunctionSignature(
@@ -36,6 +38,7 @@ func init() {
 			"startColumn": semantic.String,
 			"stopColumn":  semantic.String,
 			"createEmpty": semantic.Bool,
+			"duplicates":  semantic.String,
 		},
 		nil,
 	)
@@ -58,7 +61,7 @@ func createWindowOpSpec(args flux.Arguments, a *flux.Administration) (flux.Opera
 		return nil, err
 	}
 	if everySet {
-		spec.Every = flux.Duration(every)
+		spec.Every = every
 	}
 	period, periodSet, err := args.GetDuration("period")
 	if err != nil {
@@ -105,6 +108,14 @@ func createWindowOpSpec(args flux.Arguments, a *flux.Administration) (flux.Opera
 	} else {
 		spec.CreateEmpty = false
 	}
+	if duplicates, ok, err := args.GetString("duplicates"); err != nil {
+		return nil, err
+	} else if ok {
+		if _, err := execute.ParseDuplicatePolicy(duplicates); err != nil {
+			return nil, err
+		}
+		spec.Duplicates = duplicates
+	}
 
 	// Apply defaults
 	if !everySet {
@@ -113,6 +124,9 @@ func createWindowOpSpec(args flux.Arguments, a *flux.Administration) (flux.Opera
 	if !periodSet {
 		spec.Period = spec.Every
 	}
+	if spec.Every.Months() != 0 && spec.Every.Nanoseconds() != 0 {
+		return nil, errors.New(`window function requires "every" to be a number of months or a duration without months, not both`)
+	}
 	return spec, nil
 }
 
@@ -131,6 +145,8 @@ type WindowProcedureSpec struct {
 	StartColumn,
 	StopColumn string
 	CreateEmpty bool
+	// Duplicates is the policy for the rows of the input tables that have the same time.
+	Duplicates execute.DuplicatePolicy
 }
 
 func newWindowProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
@@ -138,6 +154,10 @@ func newWindowProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.Pro
 	if !ok {
 		return nil, fmt.Errorf("invalid spec type %T", qs)
 	}
+	duplicates, err := execute.ParseDuplicatePolicy(s.Duplicates)
+	if err != nil {
+		return nil, err
+	}
 	p := &WindowProcedureSpec{
 		Window: plan.WindowSpec{
 			Every:  s.Every,
@@ -148,6 +168,7 @@ func newWindowProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.Pro
 		StartColumn: s.StartColumn,
 		StopColumn:  s.StopColumn,
 		CreateEmpty: s.CreateEmpty,
+		Duplicates:  duplicates,
 	}
 	return p, nil
 }
@@ -157,10 +178,34 @@ func (s *WindowProcedureSpec) Kind() plan.ProcedureKind {
 }
 func (s *WindowProcedureSpec) Copy() plan.ProcedureSpec {
 	ns := new(WindowProcedureSpec)
-	ns.Window = s.Window
+	*ns = *s
 	return ns
 }
 
+// SortedBy implements plan.OrderedProcedureSpec, window keeps the order of the rows of its input.
+func (s *WindowProcedureSpec) SortedBy(predecessors [][]string) []string {
+	return predecessors[0]
+}
+
+// OutputSchema implements plan.SchemaProcedureSpec,
+// window adds the start and stop columns to its input if they are missing.
+func (s *WindowProcedureSpec) OutputSchema(predecessors []*plan.Schema) *plan.Schema {
+	in := predecessors[0]
+	if in == nil {
+		return nil
+	}
+	out := &plan.Schema{
+		Columns: append([]flux.ColMeta(nil), in.Columns...),
+		Open:    in.Open,
+	}
+	for _, label := range []string{s.StartColumn, s.StopColumn} {
+		if _, ok := in.Column(label); !ok {
+			out.Columns = append(out.Columns, flux.ColMeta{Label: label, Type: flux.TTime})
+		}
+	}
+	return out
+}
+
 func createWindowTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
 	s, ok := spec.(*WindowProcedureSpec)
 	if !ok {
@@ -179,13 +224,15 @@ func createWindowTransformation(id execute.DatasetID, mode execute.AccumulationM
 		cache,
 		*bounds,
 		execute.NewWindow(
-			execute.Duration(s.Window.Every),
-			execute.Duration(s.Window.Period),
-			execute.Duration(s.Window.Offset)),
+			s.Window.Every,
+			s.Window.Period,
+			s.Window.Offset),
 		s.TimeColumn,
 		s.StartColumn,
 		s.StopColumn,
 		s.CreateEmpty,
+		s.Duplicates,
+		a.Allocator(),
 	)
 	return t, d, nil
 }
@@ -201,6 +248,8 @@ type fixedWindowTransformation struct {
 	startCol,
 	stopCol string
 	createEmpty bool
+	duplicates  execute.DuplicatePolicy
+	alloc       *memory.Allocator
 }
 
 func NewFixedWindowTransformation(
@@ -212,6 +261,8 @@ func NewFixedWindowTransformation(
 	startCol,
 	stopCol string,
 	createEmpty bool,
+	duplicates execute.DuplicatePolicy,
+	alloc *memory.Allocator,
 ) execute.Transformation {
 	t := &fixedWindowTransformation{
 		d:           d,
@@ -222,6 +273,8 @@ func NewFixedWindowTransformation(
 		startCol:    startCol,
 		stopCol:     stopCol,
 		createEmpty: createEmpty,
+		duplicates:  duplicates,
+		alloc:       alloc,
 	}
 
 	if createEmpty {
@@ -289,6 +342,11 @@ func (t *fixedWindowTransformation) Process(id execute.DatasetID, tbl flux.Table
 		return nil
 	}
 
+	tbl, err := execute.ResolveDuplicates(tbl, t.timeCol, t.duplicates, t.alloc)
+	if err != nil {
+		return err
+	}
+
 	for _, bnds := range t.allBounds {
 		key := t.newWindowGroupKey(tbl, keyCols, bnds, keyColMap)
 		builder, created := t.cache.TableBuilder(key)
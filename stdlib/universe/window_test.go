@@ -1,6 +1,7 @@
 package universe_test
 
 import (
+	"errors"
 	"sort"
 	"strconv"
 	"testing"
@@ -32,9 +33,9 @@ func TestWindow_NewQuery(t *testing.T) {
 					{
 						ID: "window1",
 						Spec: &universe.WindowOpSpec{
-							Every:       flux.Duration(time.Hour),
-							Period:      flux.Duration(time.Hour),
-							Offset:      flux.Duration(time.Minute * -5),
+							Every:       values.ConvertDuration(time.Hour),
+							Period:      values.ConvertDuration(time.Hour),
+							Offset:      values.ConvertDuration(time.Minute * -5),
 							TimeColumn:  execute.DefaultTimeColLabel,
 							StartColumn: execute.DefaultStartColLabel,
 							StopColumn:  execute.DefaultStopColLabel,
@@ -46,6 +47,72 @@ func TestWindow_NewQuery(t *testing.T) {
 				},
 			},
 		},
+		{
+			Name: "from with calendar window",
+			Raw:  `from(bucket:"mybucket") |> window(every:1mo, period:1y, offset: 2w)`,
+			Want: &flux.Spec{
+				Operations: []*flux.Operation{
+					{
+						ID: "from0",
+						Spec: &influxdb.FromOpSpec{
+							Bucket: "mybucket",
+						},
+					},
+					{
+						ID: "window1",
+						Spec: &universe.WindowOpSpec{
+							Every:       values.MakeDuration(1, 0),
+							Period:      values.MakeDuration(12, 0),
+							Offset:      values.ConvertDuration(14 * 24 * time.Hour),
+							TimeColumn:  execute.DefaultTimeColLabel,
+							StartColumn: execute.DefaultStartColLabel,
+							StopColumn:  execute.DefaultStopColLabel,
+						},
+					},
+				},
+				Edges: []flux.Edge{
+					{Parent: "from0", Child: "window1"},
+				},
+			},
+		},
+		{
+			Name:    "window every with months and days",
+			Raw:     `from(bucket:"mybucket") |> window(every:1mo1d)`,
+			WantErr: true,
+		},
+		{
+			Name: "window with duplicates",
+			Raw:  `from(bucket:"mybucket") |> window(every:1h, duplicates: "last")`,
+			Want: &flux.Spec{
+				Operations: []*flux.Operation{
+					{
+						ID: "from0",
+						Spec: &influxdb.FromOpSpec{
+							Bucket: "mybucket",
+						},
+					},
+					{
+						ID: "window1",
+						Spec: &universe.WindowOpSpec{
+							Every:       values.ConvertDuration(time.Hour),
+							Period:      values.ConvertDuration(time.Hour),
+							TimeColumn:  execute.DefaultTimeColLabel,
+							StartColumn: execute.DefaultStartColLabel,
+							StopColumn:  execute.DefaultStopColLabel,
+							Duplicates:  "last",
+						},
+					},
+				},
+				Edges: []flux.Edge{
+					{Parent: "from0", Child: "window1"},
+				},
+			},
+		},
+		{
+			Name:    "window with unknown duplicates",
+			Raw:     `from(bucket:"mybucket") |> window(every:1h, duplicates: "sum")`,
+			WantErr: true,
+		},
 	}
 	for _, tc := range tests {
 		tc := tc
@@ -61,15 +128,97 @@ func TestWindowOperation_Marshaling(t *testing.T) {
 	op := &flux.Operation{
 		ID: "window",
 		Spec: &universe.WindowOpSpec{
-			Every:  flux.Duration(time.Minute),
-			Period: flux.Duration(time.Hour),
-			Offset: flux.Duration(30 * time.Minute),
+			Every:  values.ConvertDuration(time.Minute),
+			Period: values.ConvertDuration(time.Hour),
+			Offset: values.ConvertDuration(30 * time.Minute),
 		},
 	}
 
 	querytest.OperationMarshalingTestHelper(t, data, op)
 }
 
+func TestFixedWindow_Duplicates(t *testing.T) {
+	data := []flux.Table{&executetest.Table{
+		ColMeta: []flux.ColMeta{
+			{Label: "_time", Type: flux.TTime},
+			{Label: "_value", Type: flux.TFloat},
+		},
+		Data: [][]interface{}{
+			{execute.Time(1), 1.0},
+			{execute.Time(2), 2.0},
+			{execute.Time(2), 4.0},
+			{execute.Time(3), 3.0},
+		},
+	}}
+	want := func(rows ...[]interface{}) []*executetest.Table {
+		return []*executetest.Table{{
+			KeyCols: []string{"_start", "_stop"},
+			ColMeta: []flux.ColMeta{
+				{Label: "_time", Type: flux.TTime},
+				{Label: "_value", Type: flux.TFloat},
+				{Label: "_start", Type: flux.TTime},
+				{Label: "_stop", Type: flux.TTime},
+			},
+			Data: rows,
+		}}
+	}
+	testCases := []struct {
+		policy  execute.DuplicatePolicy
+		want    []*executetest.Table
+		wantErr error
+	}{
+		{
+			policy: execute.KeepDuplicates,
+			want: want(
+				[]interface{}{execute.Time(1), 1.0, execute.Time(0), execute.Time(10)},
+				[]interface{}{execute.Time(2), 2.0, execute.Time(0), execute.Time(10)},
+				[]interface{}{execute.Time(2), 4.0, execute.Time(0), execute.Time(10)},
+				[]interface{}{execute.Time(3), 3.0, execute.Time(0), execute.Time(10)},
+			),
+		},
+		{
+			policy: execute.KeepLastDuplicate,
+			want: want(
+				[]interface{}{execute.Time(1), 1.0, execute.Time(0), execute.Time(10)},
+				[]interface{}{execute.Time(2), 4.0, execute.Time(0), execute.Time(10)},
+				[]interface{}{execute.Time(3), 3.0, execute.Time(0), execute.Time(10)},
+			),
+		},
+		{
+			policy:  execute.ErrorOnDuplicates,
+			wantErr: errors.New("duplicate _time 1970-01-01T00:00:00.000000002Z in table {}"),
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(string(tc.policy), func(t *testing.T) {
+			executetest.ProcessTestHelper(
+				t,
+				data,
+				tc.want,
+				tc.wantErr,
+				func(d execute.Dataset, c execute.TableBuilderCache) execute.Transformation {
+					return universe.NewFixedWindowTransformation(
+						d,
+						c,
+						execute.Bounds{Start: 0, Stop: 10},
+						execute.NewWindow(
+							values.ConvertDuration(10),
+							values.ConvertDuration(10),
+							values.Duration{}),
+						execute.DefaultTimeColLabel,
+						execute.DefaultStartColLabel,
+						execute.DefaultStopColLabel,
+						false,
+						tc.policy,
+						executetest.UnlimitedAllocator,
+					)
+				},
+			)
+		})
+	}
+}
+
 func TestFixedWindow_PassThrough(t *testing.T) {
 	executetest.TransformationPassThroughTestHelper(t, func(d execute.Dataset, c execute.TableBuilderCache) execute.Transformation {
 		fw := universe.NewFixedWindowTransformation(
@@ -77,13 +226,15 @@ func TestFixedWindow_PassThrough(t *testing.T) {
 			c,
 			execute.Bounds{},
 			execute.NewWindow(
-				execute.Duration(time.Minute),
-				execute.Duration(time.Minute),
-				0),
+				values.ConvertDuration(time.Minute),
+				values.ConvertDuration(time.Minute),
+				values.Duration{}),
 			execute.DefaultTimeColLabel,
 			execute.DefaultStartColLabel,
 			execute.DefaultStopColLabel,
 			false,
+			execute.KeepDuplicates,
+			executetest.UnlimitedAllocator,
 		)
 		return fw
 	})
@@ -139,9 +290,9 @@ func TestFixedWindow_Process(t *testing.T) {
 			valueCol: flux.ColMeta{Label: "_value", Type: flux.TFloat},
 			// Use bounds and offset that is *not* aligned with the every/period durations of the window
 			bounds:      nonalignedBounds,
-			offset:      execute.Duration(10*time.Second + 10*time.Nanosecond),
-			every:       execute.Duration(time.Minute),
-			period:      execute.Duration(time.Minute),
+			offset:      values.ConvertDuration(10*time.Second + 10*time.Nanosecond),
+			every:       values.ConvertDuration(time.Minute),
+			period:      values.ConvertDuration(time.Minute),
 			createEmpty: true,
 			num:         15,
 			want: func(start execute.Time) []*executetest.Table {
@@ -209,8 +360,8 @@ func TestFixedWindow_Process(t *testing.T) {
 			valueCol: flux.ColMeta{Label: "_value", Type: flux.TFloat},
 			// Use bounds that are aligned with period and duration of window
 			bounds:      alignedBounds,
-			every:       execute.Duration(time.Minute),
-			period:      execute.Duration(time.Minute),
+			every:       values.ConvertDuration(time.Minute),
+			period:      values.ConvertDuration(time.Minute),
 			createEmpty: true,
 			num:         15,
 			want: func(start execute.Time) []*executetest.Table {
@@ -297,9 +448,9 @@ func TestFixedWindow_Process(t *testing.T) {
 			valueCol: flux.ColMeta{Label: "_value", Type: flux.TFloat},
 			// Use a time that is *not* aligned with the every/period durations of the window
 			bounds:      nonalignedBounds,
-			offset:      execute.Duration(time.Second*10 + time.Nanosecond*10),
-			every:       execute.Duration(time.Minute),
-			period:      execute.Duration(2 * time.Minute),
+			offset:      values.ConvertDuration(time.Second*10 + time.Nanosecond*10),
+			every:       values.ConvertDuration(time.Minute),
+			period:      values.ConvertDuration(2 * time.Minute),
 			createEmpty: true,
 			num:         15,
 			want: func(start execute.Time) []*executetest.Table {
@@ -393,8 +544,8 @@ func TestFixedWindow_Process(t *testing.T) {
 			valueCol: flux.ColMeta{Label: "_value", Type: flux.TFloat},
 			// Use a bounds that are aligned with the every/period durations of the window
 			bounds:      alignedBounds,
-			every:       execute.Duration(time.Minute),
-			period:      execute.Duration(2 * time.Minute),
+			every:       values.ConvertDuration(time.Minute),
+			period:      values.ConvertDuration(2 * time.Minute),
 			createEmpty: true,
 			num:         15,
 			want: func(start execute.Time) []*executetest.Table {
@@ -488,9 +639,9 @@ func TestFixedWindow_Process(t *testing.T) {
 			valueCol: flux.ColMeta{Label: "_value", Type: flux.TFloat},
 			// Use a time that is *not* aligned with the every/period durations of the window
 			bounds:      nonalignedBounds,
-			every:       execute.Duration(2 * time.Minute),
-			period:      execute.Duration(time.Minute),
-			offset:      execute.Duration(10*time.Second + 10*time.Nanosecond),
+			every:       values.ConvertDuration(2 * time.Minute),
+			period:      values.ConvertDuration(time.Minute),
+			offset:      values.ConvertDuration(10*time.Second + 10*time.Nanosecond),
 			createEmpty: true,
 			num:         24,
 			want: func(start execute.Time) []*executetest.Table {
@@ -540,8 +691,8 @@ func TestFixedWindow_Process(t *testing.T) {
 			valueCol: flux.ColMeta{Label: "_value", Type: flux.TFloat},
 			// Use a time that is  aligned with the every/period durations of the window
 			bounds:      alignedBounds,
-			every:       execute.Duration(2 * time.Minute),
-			period:      execute.Duration(time.Minute),
+			every:       values.ConvertDuration(2 * time.Minute),
+			period:      values.ConvertDuration(time.Minute),
 			createEmpty: true,
 			num:         24,
 			want: func(start execute.Time) []*executetest.Table {
@@ -591,8 +742,8 @@ func TestFixedWindow_Process(t *testing.T) {
 			valueCol: flux.ColMeta{Label: "_value", Type: flux.TInt},
 			// Use bounds that are aligned with the every/period durations of the window
 			bounds:      alignedBounds,
-			every:       execute.Duration(time.Minute),
-			period:      execute.Duration(time.Minute),
+			every:       values.ConvertDuration(time.Minute),
+			period:      values.ConvertDuration(time.Minute),
 			createEmpty: true,
 			num:         15,
 			want: func(start execute.Time) []*executetest.Table {
@@ -662,8 +813,8 @@ func TestFixedWindow_Process(t *testing.T) {
 			valueCol: flux.ColMeta{Label: "_value", Type: flux.TInt},
 			// Use bounds that are aligned with the every/period durations of the window
 			bounds:      alignedBounds,
-			every:       execute.Duration(time.Minute),
-			period:      execute.Duration(time.Minute),
+			every:       values.ConvertDuration(time.Minute),
+			period:      values.ConvertDuration(time.Minute),
 			createEmpty: false,
 			num:         15,
 			want: func(start execute.Time) []*executetest.Table {
@@ -724,8 +875,8 @@ func TestFixedWindow_Process(t *testing.T) {
 		{
 			name:     "empty bounds start == stop",
 			valueCol: flux.ColMeta{Label: "_value", Type: flux.TInt},
-			every:    execute.Duration(time.Minute),
-			period:   execute.Duration(time.Minute),
+			every:    values.ConvertDuration(time.Minute),
+			period:   values.ConvertDuration(time.Minute),
 			num:      15,
 			bounds: execute.Bounds{
 				Start: execute.Time(time.Date(2017, 10, 10, 0, 0, 0, 0, time.UTC).UnixNano()),
@@ -740,9 +891,9 @@ func TestFixedWindow_Process(t *testing.T) {
 			valueCol: flux.ColMeta{Label: "_value", Type: flux.TFloat},
 			// Use bounds that are aligned with the every/period durations of the window
 			bounds:      alignedBounds,
-			every:       execute.Duration(time.Minute),
-			period:      execute.Duration(time.Minute),
-			offset:      execute.Duration(-15 * time.Second),
+			every:       values.ConvertDuration(time.Minute),
+			period:      values.ConvertDuration(time.Minute),
+			offset:      values.ConvertDuration(-15 * time.Second),
 			createEmpty: true,
 			num:         15,
 			want: func(start execute.Time) []*executetest.Table {
@@ -813,8 +964,8 @@ func TestFixedWindow_Process(t *testing.T) {
 		{
 			name:     "empty bounds start > stop",
 			valueCol: flux.ColMeta{Label: "_value", Type: flux.TInt},
-			every:    execute.Duration(time.Minute),
-			period:   execute.Duration(time.Minute),
+			every:    values.ConvertDuration(time.Minute),
+			period:   values.ConvertDuration(time.Minute),
 			num:      15,
 			bounds: execute.Bounds{
 				Start: execute.Time(time.Date(2017, 10, 10, 0, 0, 0, 0, time.UTC).UnixNano()),
@@ -842,6 +993,8 @@ func TestFixedWindow_Process(t *testing.T) {
 				execute.DefaultStartColLabel,
 				execute.DefaultStopColLabel,
 				tc.createEmpty,
+				execute.KeepDuplicates,
+				executetest.UnlimitedAllocator,
 			)
 
 			table0 := &executetest.Table{
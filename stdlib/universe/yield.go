@@ -78,3 +78,8 @@ func (s *YieldProcedureSpec) Copy() plan.ProcedureSpec {
 func (s *YieldProcedureSpec) YieldName() string {
 	return s.Name
 }
+
+// OutputSchema implements plan.SchemaProcedureSpec, yield keeps the columns of its input.
+func (s *YieldProcedureSpec) OutputSchema(predecessors []*plan.Schema) *plan.Schema {
+	return predecessors[0]
+}
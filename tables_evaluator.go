@@ -0,0 +1,73 @@
+package flux
+
+import (
+	"context"
+	"time"
+
+	fluxerrors "github.com/influxdata/flux/errors"
+	"github.com/influxdata/flux/interpreter"
+	"github.com/influxdata/flux/semantic"
+)
+
+// TablesEvaluator executes the pipeline of a table object to completion while the script
+// that refers to it is evaluated, so that functions such as tableFind can read its tables.
+type TablesEvaluator interface {
+	// EvalTables executes spec and calls f for every table it produces.
+	// The tables are only valid for the duration of f.
+	EvalTables(ctx context.Context, spec *Spec, f func(Table) error) error
+}
+
+type contextKey int
+
+const (
+	tablesEvaluatorKey contextKey = iota
+	nowScopeKey
+)
+
+// WithTablesEvaluator returns a context that carries the evaluator of the table objects
+// read while compiling a script with it.
+func WithTablesEvaluator(ctx context.Context, e TablesEvaluator) context.Context {
+	return context.WithValue(ctx, tablesEvaluatorKey, e)
+}
+
+// withNowScope returns a context that carries the scope in which the now option of the script being evaluated is looked up.
+func withNowScope(ctx context.Context, scope interpreter.Scope) context.Context {
+	return context.WithValue(ctx, nowScopeKey, scope)
+}
+
+// nowFromContext returns the value of the now option of the script being evaluated with ctx,
+// or the current time if it is unknown.
+func nowFromContext(ctx context.Context) time.Time {
+	scope, ok := ctx.Value(nowScopeKey).(interpreter.Scope)
+	if !ok {
+		return time.Now()
+	}
+	v, ok := scope.Lookup(nowOption)
+	if !ok || v.Type().Nature() != semantic.Function {
+		return time.Now()
+	}
+	now, err := v.Function().Call(nil)
+	if err != nil || now.Type().Nature() != semantic.Time {
+		return time.Now()
+	}
+	return now.Time().Time()
+}
+
+// EvalTables executes the pipeline that produces t with the evaluator carried by ctx,
+// and calls f for every table it produces.
+// The relative times of the pipeline are resolved against the now option of the script being evaluated.
+func EvalTables(ctx context.Context, t *TableObject, f func(Table) error) error {
+	return EvalTablesAt(ctx, t, nowFromContext(ctx), f)
+}
+
+// EvalTablesAt is like EvalTables, but resolves the relative times of the pipeline against now.
+// It is used to execute pipelines while a query is executed, for example one per table of its input.
+func EvalTablesAt(ctx context.Context, t *TableObject, now time.Time, f func(Table) error) error {
+	e, ok := ctx.Value(tablesEvaluatorKey).(TablesEvaluator)
+	if !ok {
+		return fluxerrors.New(fluxerrors.Unimplemented, "tables cannot be read in this context")
+	}
+	spec := t.ToSpec()
+	spec.Now = now
+	return e.EvalTables(ctx, spec, f)
+}
@@ -0,0 +1,372 @@
+// Package tableutil provides utilities to inspect the tables of results.
+package tableutil
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/flux/values"
+)
+
+// Options configures how tables are compared.
+type Options struct {
+	// Epsilon is the largest absolute difference between two floats that are considered equal.
+	Epsilon float64
+}
+
+// Op tells which side of a comparison a difference comes from.
+type Op string
+
+const (
+	// Removed marks what is only in the wanted results.
+	Removed Op = "-"
+	// Added marks what is only in the results that were got.
+	Added Op = "+"
+)
+
+// RowDiff is a row that differs from the row at the same index of the other table.
+type RowDiff struct {
+	Op    Op
+	Index int
+	// Values are the values of the row for the columns of the table diff.
+	// The value of a column that the table of the row does not have is nil.
+	Values []values.Value
+}
+
+// TableDiff is the difference between the two tables of a group key.
+type TableDiff struct {
+	Key flux.GroupKey
+	// Op is set when the table is only in one of the results.
+	Op Op
+	// Columns are the columns of both tables that are not part of the group key, sorted by label.
+	Columns []flux.ColMeta
+	Rows    []RowDiff
+}
+
+// ResultDiff is the difference between the two results of a name.
+type ResultDiff struct {
+	Name string
+	// Op is set when the result is only in one of the result iterators.
+	Op     Op
+	Tables []TableDiff
+}
+
+// Report lists the differences between two result iterators.
+// An empty report means the results are equal.
+type Report []ResultDiff
+
+// String formats the report as a human-readable diff.
+func (r Report) String() string {
+	var b strings.Builder
+	for _, rd := range r {
+		if rd.Op != "" {
+			fmt.Fprintf(&b, "%s result %q\n", rd.Op, rd.Name)
+			continue
+		}
+		for _, td := range rd.Tables {
+			fmt.Fprintf(&b, "result %q, table %v:\n", rd.Name, td.Key)
+			td.format(&b)
+		}
+	}
+	return b.String()
+}
+
+// String formats the table diff as a human-readable diff.
+func (td *TableDiff) String() string {
+	var b strings.Builder
+	td.format(&b)
+	return b.String()
+}
+
+func (td *TableDiff) format(b *strings.Builder) {
+	if td.Op != "" && len(td.Rows) == 0 {
+		fmt.Fprintf(b, "%s empty table\n", td.Op)
+		return
+	}
+	for _, row := range td.Rows {
+		fmt.Fprintf(b, "%s %d:", row.Op, row.Index)
+		for j, c := range td.Columns {
+			v := row.Values[j]
+			switch {
+			case v == nil:
+				continue
+			case v.IsNull():
+				fmt.Fprintf(b, " %s=null", c.Label)
+			default:
+				fmt.Fprintf(b, " %s=%v", c.Label, v)
+			}
+		}
+		b.WriteByte('\n')
+	}
+}
+
+// Diff compares the results that are wanted with the results that were got,
+// matching results by name and tables by group key. It consumes both iterators.
+func Diff(want, got flux.ResultIterator, opts Options) (Report, error) {
+	wantResults, err := readResults(want)
+	if err != nil {
+		return nil, err
+	}
+	gotResults, err := readResults(got)
+	if err != nil {
+		return nil, err
+	}
+
+	var report Report
+	for _, w := range wantResults {
+		g := findResult(gotResults, w.name)
+		if g == nil {
+			report = append(report, ResultDiff{Name: w.name, Op: Removed})
+			continue
+		}
+		tables, err := diffResults(w, g, opts)
+		if err != nil {
+			return nil, err
+		}
+		if len(tables) > 0 {
+			report = append(report, ResultDiff{Name: w.name, Tables: tables})
+		}
+	}
+	for _, g := range gotResults {
+		if findResult(wantResults, g.name) == nil {
+			report = append(report, ResultDiff{Name: g.name, Op: Added})
+		}
+	}
+	return report, nil
+}
+
+// DiffTables compares two tables with the same group key row by row.
+// A nil table stands for a missing table. It returns nil if the tables are equal.
+func DiffTables(want, got flux.Table, opts Options) (*TableDiff, error) {
+	var w, g *table
+	if want != nil {
+		t, err := readTable(want)
+		if err != nil {
+			return nil, err
+		}
+		w = t
+	}
+	if got != nil {
+		t, err := readTable(got)
+		if err != nil {
+			return nil, err
+		}
+		g = t
+	}
+	return diffTables(w, g, opts)
+}
+
+// result is a result read into memory.
+type result struct {
+	name   string
+	tables []*table
+}
+
+// table is a table read into memory.
+type table struct {
+	key  flux.GroupKey
+	cols []flux.ColMeta
+	rows [][]values.Value
+}
+
+func readResults(results flux.ResultIterator) ([]*result, error) {
+	defer results.Release()
+	var rs []*result
+	for results.More() {
+		res := results.Next()
+		r := &result{name: res.Name()}
+		if err := res.Tables().Do(func(tbl flux.Table) error {
+			t, err := readTable(tbl)
+			if err != nil {
+				return err
+			}
+			r.tables = append(r.tables, t)
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+		rs = append(rs, r)
+	}
+	if err := results.Err(); err != nil {
+		return nil, err
+	}
+	return rs, nil
+}
+
+func readTable(tbl flux.Table) (*table, error) {
+	t := &table{
+		key:  tbl.Key(),
+		cols: tbl.Cols(),
+	}
+	if err := tbl.Do(func(cr flux.ColReader) error {
+		for i := 0; i < cr.Len(); i++ {
+			row := make([]values.Value, len(t.cols))
+			for j := range t.cols {
+				row[j] = execute.ValueForRow(cr, i, j)
+			}
+			t.rows = append(t.rows, row)
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+func findResult(results []*result, name string) *result {
+	for _, r := range results {
+		if r.name == name {
+			return r
+		}
+	}
+	return nil
+}
+
+func findTable(tables []*table, key flux.GroupKey) *table {
+	for _, t := range tables {
+		if t.key.Equal(key) {
+			return t
+		}
+	}
+	return nil
+}
+
+func diffResults(want, got *result, opts Options) ([]TableDiff, error) {
+	var diffs []TableDiff
+	add := func(w, g *table) error {
+		td, err := diffTables(w, g, opts)
+		if err != nil {
+			return err
+		}
+		if td != nil {
+			diffs = append(diffs, *td)
+		}
+		return nil
+	}
+	for _, w := range want.tables {
+		if err := add(w, findTable(got.tables, w.key)); err != nil {
+			return nil, err
+		}
+	}
+	for _, g := range got.tables {
+		if findTable(want.tables, g.key) == nil {
+			if err := add(nil, g); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return diffs, nil
+}
+
+func diffTables(want, got *table, opts Options) (*TableDiff, error) {
+	td := &TableDiff{}
+	switch {
+	case want == nil && got == nil:
+		return nil, nil
+	case want == nil:
+		td.Key, td.Op = got.key, Added
+	case got == nil:
+		td.Key, td.Op = want.key, Removed
+	default:
+		td.Key = want.key
+	}
+
+	// The columns of the diff are the union of the value columns of both tables.
+	types := make(map[string]flux.ColType)
+	for _, t := range []*table{want, got} {
+		if t == nil {
+			continue
+		}
+		for _, c := range t.cols {
+			if t.key.HasCol(c.Label) {
+				continue
+			}
+			if typ, ok := types[c.Label]; ok && typ != c.Type {
+				return nil, fmt.Errorf("column types differ: want=%s got=%s", typ, c.Type)
+			}
+			types[c.Label] = c.Type
+		}
+	}
+	for label, typ := range types {
+		td.Columns = append(td.Columns, flux.ColMeta{Label: label, Type: typ})
+	}
+	sort.Slice(td.Columns, func(i, j int) bool {
+		return td.Columns[i].Label < td.Columns[j].Label
+	})
+
+	wantRows, gotRows := td.rows(want), td.rows(got)
+	n := len(wantRows)
+	if len(gotRows) < n {
+		n = len(gotRows)
+	}
+	for i := 0; i < n; i++ {
+		if !rowsEqual(wantRows[i], gotRows[i], opts) {
+			td.Rows = append(td.Rows,
+				RowDiff{Op: Removed, Index: i, Values: wantRows[i]},
+				RowDiff{Op: Added, Index: i, Values: gotRows[i]},
+			)
+		}
+	}
+	for i := n; i < len(wantRows); i++ {
+		td.Rows = append(td.Rows, RowDiff{Op: Removed, Index: i, Values: wantRows[i]})
+	}
+	for i := n; i < len(gotRows); i++ {
+		td.Rows = append(td.Rows, RowDiff{Op: Added, Index: i, Values: gotRows[i]})
+	}
+	if td.Op == "" && len(td.Rows) == 0 {
+		return nil, nil
+	}
+	return td, nil
+}
+
+// rows returns the rows of a table with the values of the columns of the diff.
+func (td *TableDiff) rows(t *table) [][]values.Value {
+	if t == nil {
+		return nil
+	}
+	idxs := make([]int, len(td.Columns))
+	for j, c := range td.Columns {
+		idxs[j] = execute.ColIdx(c.Label, t.cols)
+	}
+	rows := make([][]values.Value, len(t.rows))
+	for i, row := range t.rows {
+		rows[i] = make([]values.Value, len(idxs))
+		for j, idx := range idxs {
+			if idx >= 0 {
+				rows[i][j] = row[idx]
+			}
+		}
+	}
+	return rows
+}
+
+func rowsEqual(want, got []values.Value, opts Options) bool {
+	for j := range want {
+		if !valuesEqual(want[j], got[j], opts) {
+			return false
+		}
+	}
+	return true
+}
+
+func valuesEqual(want, got values.Value, opts Options) bool {
+	switch {
+	case want == nil || got == nil:
+		return false
+	case want.IsNull() || got.IsNull():
+		return want.IsNull() && got.IsNull()
+	case want.Type() == semantic.Float && got.Type() == semantic.Float:
+		w, g := want.Float(), got.Float()
+		if math.IsNaN(w) || math.IsNaN(g) {
+			return math.IsNaN(w) && math.IsNaN(g)
+		}
+		return w == g || math.Abs(w-g) <= opts.Epsilon
+	default:
+		return want.Equal(got)
+	}
+}
@@ -0,0 +1,131 @@
+package tableutil_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/flux/tableutil"
+)
+
+func results(rs ...*executetest.Result) flux.ResultIterator {
+	results := make([]flux.Result, len(rs))
+	for i, r := range rs {
+		r.Normalize()
+		results[i] = r
+	}
+	return flux.NewSliceResultIterator(results)
+}
+
+func cpu(host string, data ...[]interface{}) *executetest.Table {
+	return &executetest.Table{
+		KeyCols: []string{"host"},
+		ColMeta: []flux.ColMeta{
+			{Label: "_time", Type: flux.TTime},
+			{Label: "host", Type: flux.TString},
+			{Label: "_value", Type: flux.TFloat},
+		},
+		Data: data,
+	}
+}
+
+func TestDiff(t *testing.T) {
+	testCases := []struct {
+		name string
+		want []*executetest.Result
+		got  []*executetest.Result
+		opts tableutil.Options
+		diff string
+	}{
+		{
+			name: "equal",
+			want: []*executetest.Result{{Nm: "_result", Tbls: []*executetest.Table{
+				cpu("a", []interface{}{execute.Time(1), "a", 1.0}, []interface{}{execute.Time(2), "a", math.NaN()}),
+			}}},
+			got: []*executetest.Result{{Nm: "_result", Tbls: []*executetest.Table{
+				cpu("a", []interface{}{execute.Time(1), "a", 1.0}, []interface{}{execute.Time(2), "a", math.NaN()}),
+			}}},
+		},
+		{
+			name: "float tolerance",
+			want: []*executetest.Result{{Nm: "_result", Tbls: []*executetest.Table{
+				cpu("a", []interface{}{execute.Time(1), "a", 1.0}, []interface{}{execute.Time(2), "a", 2.0}),
+			}}},
+			got: []*executetest.Result{{Nm: "_result", Tbls: []*executetest.Table{
+				cpu("a", []interface{}{execute.Time(1), "a", 1.0000001}, []interface{}{execute.Time(2), "a", 2.1}),
+			}}},
+			opts: tableutil.Options{Epsilon: 1e-6},
+			diff: `result "_result", table {host=a}:
+- 1: _time=1970-01-01T00:00:00.000000002Z _value=2
++ 1: _time=1970-01-01T00:00:00.000000002Z _value=2.1
+`,
+		},
+		{
+			name: "rows and tables",
+			want: []*executetest.Result{{Nm: "_result", Tbls: []*executetest.Table{
+				cpu("a", []interface{}{execute.Time(1), "a", 1.0}, []interface{}{execute.Time(2), "a", 2.0}),
+				cpu("b", []interface{}{execute.Time(1), "b", 1.0}),
+			}}},
+			got: []*executetest.Result{{Nm: "_result", Tbls: []*executetest.Table{
+				cpu("a", []interface{}{execute.Time(1), "a", nil}),
+				cpu("c", []interface{}{execute.Time(1), "c", 3.0}),
+			}}},
+			diff: `result "_result", table {host=a}:
+- 0: _time=1970-01-01T00:00:00.000000001Z _value=1
++ 0: _time=1970-01-01T00:00:00.000000001Z _value=null
+- 1: _time=1970-01-01T00:00:00.000000002Z _value=2
+result "_result", table {host=b}:
+- 0: _time=1970-01-01T00:00:00.000000001Z _value=1
+result "_result", table {host=c}:
++ 0: _time=1970-01-01T00:00:00.000000001Z _value=3
+`,
+		},
+		{
+			name: "results",
+			want: []*executetest.Result{
+				{Nm: "a", Tbls: []*executetest.Table{cpu("a")}},
+				{Nm: "b", Tbls: []*executetest.Table{cpu("a")}},
+			},
+			got: []*executetest.Result{
+				{Nm: "a", Tbls: []*executetest.Table{cpu("a")}},
+				{Nm: "c", Tbls: []*executetest.Table{cpu("a")}},
+			},
+			diff: `- result "b"
++ result "c"
+`,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			report, err := tableutil.Diff(results(tc.want...), results(tc.got...), tc.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := report.String(); !cmp.Equal(tc.diff, got) {
+				t.Errorf("unexpected diff -want/+got\n%s", cmp.Diff(tc.diff, got))
+			}
+			if (len(report) == 0) != (tc.diff == "") {
+				t.Errorf("unexpected report length %d", len(report))
+			}
+		})
+	}
+}
+
+func TestDiffTables_ColumnTypes(t *testing.T) {
+	want := &executetest.Table{
+		ColMeta: []flux.ColMeta{{Label: "_value", Type: flux.TFloat}},
+		Data:    [][]interface{}{{1.0}},
+	}
+	got := &executetest.Table{
+		ColMeta: []flux.ColMeta{{Label: "_value", Type: flux.TInt}},
+		Data:    [][]interface{}{{int64(1)}},
+	}
+	executetest.NormalizeTables([]*executetest.Table{want, got})
+	if _, err := tableutil.DiffTables(want, got, tableutil.Options{}); err == nil {
+		t.Fatal("expected an error")
+	}
+}
@@ -0,0 +1,405 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/stdlib/universe"
+	"github.com/influxdata/flux/values"
+)
+
+const (
+	SkipCachedWindowsKind = "skipCachedWindows"
+	CacheWindowStatesKind = "cacheWindowStates"
+)
+
+func init() {
+	execute.RegisterTransformation(SkipCachedWindowsKind, createSkipCachedWindowsTransformation)
+	execute.RegisterTransformation(CacheWindowStatesKind, createCacheWindowStatesTransformation)
+}
+
+type runContextKey int
+
+const runKey runContextKey = iota
+
+// runContext is shared by the nodes of the plan of a run of a task.
+type runContext struct {
+	task    string
+	storage StorageProvider
+
+	mu    sync.Mutex
+	nodes map[string]*aggregateStates
+}
+
+// NewRunContext returns a context for executing a run of a task.
+// The windowed aggregates of the task reuse the states that the previous runs
+// have written to the storage, instead of aggregating the data of their windows again.
+func NewRunContext(ctx context.Context, task string, storage StorageProvider) context.Context {
+	return context.WithValue(ctx, runKey, &runContext{
+		task:    task,
+		storage: storage,
+		nodes:   make(map[string]*aggregateStates),
+	})
+}
+
+// aggregateStatesFromContext returns the states of an aggregate during the run of a task,
+// or nil if the query is not the run of a task with storage.
+func aggregateStatesFromContext(ctx context.Context, node string) (*aggregateStates, error) {
+	rc, ok := ctx.Value(runKey).(*runContext)
+	if !ok || rc.storage == nil {
+		return nil, nil
+	}
+	rc.mu.Lock()
+	s, ok := rc.nodes[node]
+	if !ok {
+		s = &aggregateStates{
+			task:    rc.task,
+			node:    node,
+			storage: rc.storage,
+		}
+		rc.nodes[node] = s
+	}
+	rc.mu.Unlock()
+
+	s.once.Do(func() {
+		var states []State
+		states, s.err = s.storage.ReadStates(ctx, s.task, s.node)
+		s.stored = make(map[string]State, len(states))
+		for _, st := range states {
+			s.stored[st.Key.String()] = st
+		}
+	})
+	return s, s.err
+}
+
+// aggregateStates are the states of an aggregate during the run of a task.
+type aggregateStates struct {
+	task    string
+	node    string
+	storage StorageProvider
+
+	once   sync.Once
+	err    error
+	stored map[string]State
+
+	mu       sync.Mutex
+	reused   []State
+	computed []State
+}
+
+// reuse returns whether a state is stored for the table with the key,
+// and marks the state as reused in the run.
+func (s *aggregateStates) reuse(key flux.GroupKey) bool {
+	st, ok := s.stored[key.String()]
+	if !ok {
+		return false
+	}
+	s.mu.Lock()
+	s.reused = append(s.reused, st)
+	s.mu.Unlock()
+	return true
+}
+
+func (s *aggregateStates) reusedStates() []State {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.reused
+}
+
+func (s *aggregateStates) add(st State) {
+	s.mu.Lock()
+	s.computed = append(s.computed, st)
+	s.mu.Unlock()
+}
+
+// write replaces the stored states with the states of the windows of the run,
+// so that the states of the windows the run has not processed are discarded.
+func (s *aggregateStates) write(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	states := make([]State, 0, len(s.reused)+len(s.computed))
+	states = append(states, s.reused...)
+	states = append(states, s.computed...)
+	return s.storage.WriteStates(ctx, s.task, s.node, states)
+}
+
+// IncrementalAggregateRule splits the aggregates of windows into two phases,
+// and stores the partial aggregates of the windows between the phases
+// when the query is the run of a task with storage.
+// When a later run processes the same windows, for example because the time range of the task
+// spans several intervals, the stored partial aggregates are reused
+// instead of aggregating the data of the windows again.
+// The data of the windows is still read.
+//
+//	                  final
+//	                    |
+//	                cacheStates
+//	agg                 |
+//	 |      ==>      partial
+//	window              |
+//	                skipCached
+//	                    |
+//	                 window
+//
+// The rule is not registered by default, tasks planners add it with plan.AddPhysicalRules.
+type IncrementalAggregateRule struct{}
+
+func (IncrementalAggregateRule) Name() string {
+	return "IncrementalAggregateRule"
+}
+
+func (IncrementalAggregateRule) Pattern() plan.Pattern {
+	return plan.Any()
+}
+
+func (IncrementalAggregateRule) Rewrite(node plan.PlanNode) (plan.PlanNode, bool, error) {
+	if _, ok := node.ProcedureSpec().(plan.SplittableAggregateSpec); !ok || len(node.Predecessors()) != 1 {
+		return node, false, nil
+	}
+	window := node.Predecessors()[0]
+	ws, ok := window.ProcedureSpec().(*universe.WindowProcedureSpec)
+	if !ok || len(window.Successors()) != 1 {
+		return node, false, nil
+	}
+
+	id := node.ID()
+	final, ok := plan.SplitAggregate(node)
+	if !ok {
+		return node, false, nil
+	}
+	partial := final.Predecessors()[0]
+
+	skip := plan.CreatePhysicalNode(id+"_skip_cached", &SkipCachedWindowsProcedureSpec{
+		Aggregate: string(id),
+	})
+	cache := plan.CreatePhysicalNode(id+"_cache_states", &CacheWindowStatesProcedureSpec{
+		Aggregate:   string(id),
+		StartColumn: ws.StartColumn,
+		StopColumn:  ws.StopColumn,
+	})
+	skip.SetLocation(node.Location())
+	cache.SetLocation(node.Location())
+
+	window.ClearSuccessors()
+	window.AddSuccessors(skip)
+	skip.AddPredecessors(window)
+	skip.AddSuccessors(partial)
+	partial.ClearPredecessors()
+	partial.AddPredecessors(skip)
+	partial.ClearSuccessors()
+	partial.AddSuccessors(cache)
+	cache.AddPredecessors(partial)
+	cache.AddSuccessors(final)
+	final.ClearPredecessors()
+	final.AddPredecessors(cache)
+	return final, true, nil
+}
+
+// SkipCachedWindowsProcedureSpec drops the tables of the windows
+// whose partial aggregates are stored by a previous run of the task.
+type SkipCachedWindowsProcedureSpec struct {
+	plan.DefaultCost
+	// Aggregate is the ID of the node of the aggregate.
+	Aggregate string
+}
+
+func (s *SkipCachedWindowsProcedureSpec) Kind() plan.ProcedureKind {
+	return SkipCachedWindowsKind
+}
+
+func (s *SkipCachedWindowsProcedureSpec) Copy() plan.ProcedureSpec {
+	ns := *s
+	return &ns
+}
+
+func createSkipCachedWindowsTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	s, ok := spec.(*SkipCachedWindowsProcedureSpec)
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid spec type %T", spec)
+	}
+	states, err := aggregateStatesFromContext(a.Context(), s.Aggregate)
+	if err != nil {
+		return nil, nil, err
+	}
+	cache := execute.NewTableBuilderCache(a.Allocator())
+	d := execute.NewDataset(id, mode, cache)
+	t := &skipCachedWindowsTransformation{
+		d:      d,
+		cache:  cache,
+		states: states,
+	}
+	return t, d, nil
+}
+
+type skipCachedWindowsTransformation struct {
+	d      execute.Dataset
+	cache  execute.TableBuilderCache
+	states *aggregateStates
+}
+
+func (t *skipCachedWindowsTransformation) RetractTable(id execute.DatasetID, key flux.GroupKey) error {
+	return t.d.RetractTable(key)
+}
+
+func (t *skipCachedWindowsTransformation) Process(id execute.DatasetID, tbl flux.Table) error {
+	if t.states != nil && t.states.reuse(tbl.Key()) {
+		return nil
+	}
+	builder, created := t.cache.TableBuilder(tbl.Key())
+	if !created {
+		return fmt.Errorf("skipCachedWindows found duplicate table with key: %v", tbl.Key())
+	}
+	if err := execute.AddTableCols(tbl, builder); err != nil {
+		return err
+	}
+	return execute.AppendTable(tbl, builder)
+}
+
+func (t *skipCachedWindowsTransformation) UpdateWatermark(id execute.DatasetID, mark execute.Time) error {
+	return t.d.UpdateWatermark(mark)
+}
+
+func (t *skipCachedWindowsTransformation) UpdateProcessingTime(id execute.DatasetID, pt execute.Time) error {
+	return t.d.UpdateProcessingTime(pt)
+}
+
+func (t *skipCachedWindowsTransformation) Finish(id execute.DatasetID, err error) {
+	t.d.Finish(err)
+}
+
+// CacheWindowStatesProcedureSpec stores the partial aggregates of the windows
+// computed by the run of the task, and adds the stored partial aggregates
+// of the windows that were skipped.
+type CacheWindowStatesProcedureSpec struct {
+	plan.DefaultCost
+	// Aggregate is the ID of the node of the aggregate.
+	Aggregate string
+	// StartColumn and StopColumn hold the bounds of the windows.
+	StartColumn string
+	StopColumn  string
+}
+
+func (s *CacheWindowStatesProcedureSpec) Kind() plan.ProcedureKind {
+	return CacheWindowStatesKind
+}
+
+func (s *CacheWindowStatesProcedureSpec) Copy() plan.ProcedureSpec {
+	ns := *s
+	return &ns
+}
+
+func createCacheWindowStatesTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	s, ok := spec.(*CacheWindowStatesProcedureSpec)
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid spec type %T", spec)
+	}
+	states, err := aggregateStatesFromContext(a.Context(), s.Aggregate)
+	if err != nil {
+		return nil, nil, err
+	}
+	cache := execute.NewTableBuilderCache(a.Allocator())
+	d := execute.NewDataset(id, mode, cache)
+	t := &cacheWindowStatesTransformation{
+		d:      d,
+		cache:  cache,
+		ctx:    a.Context(),
+		spec:   s,
+		states: states,
+	}
+	return t, d, nil
+}
+
+type cacheWindowStatesTransformation struct {
+	d      execute.Dataset
+	cache  execute.TableBuilderCache
+	ctx    context.Context
+	spec   *CacheWindowStatesProcedureSpec
+	states *aggregateStates
+}
+
+func (t *cacheWindowStatesTransformation) RetractTable(id execute.DatasetID, key flux.GroupKey) error {
+	return t.d.RetractTable(key)
+}
+
+func (t *cacheWindowStatesTransformation) Process(id execute.DatasetID, tbl flux.Table) error {
+	builder, created := t.cache.TableBuilder(tbl.Key())
+	if !created {
+		return fmt.Errorf("cacheWindowStates found duplicate table with key: %v", tbl.Key())
+	}
+	if err := execute.AddTableCols(tbl, builder); err != nil {
+		return err
+	}
+	if t.states == nil {
+		return execute.AppendTable(tbl, builder)
+	}
+
+	start, stop := tbl.Key().LabelValue(t.spec.StartColumn), tbl.Key().LabelValue(t.spec.StopColumn)
+	if start == nil || stop == nil {
+		return fmt.Errorf("cacheWindowStates found table without window bounds: %v", tbl.Key())
+	}
+	st := State{
+		Key:   tbl.Key(),
+		Start: start.Time().Time(),
+		Stop:  stop.Time().Time(),
+		Cols:  tbl.Cols(),
+	}
+	if err := tbl.Do(func(cr flux.ColReader) error {
+		for i := 0; i < cr.Len(); i++ {
+			row := make([]values.Value, len(cr.Cols()))
+			for j := range row {
+				row[j] = execute.ValueForRow(cr, i, j)
+			}
+			st.Rows = append(st.Rows, row)
+		}
+		return execute.AppendCols(cr, builder)
+	}); err != nil {
+		return err
+	}
+	t.states.add(st)
+	return nil
+}
+
+// addReused adds the tables of the stored partial aggregates reused by the run.
+func (t *cacheWindowStatesTransformation) addReused() error {
+	for _, st := range t.states.reusedStates() {
+		builder, created := t.cache.TableBuilder(st.Key)
+		if !created {
+			return fmt.Errorf("cacheWindowStates found duplicate table with key: %v", st.Key)
+		}
+		for _, c := range st.Cols {
+			if _, err := builder.AddCol(c); err != nil {
+				return err
+			}
+		}
+		for _, row := range st.Rows {
+			for j, v := range row {
+				if err := builder.AppendValue(j, v); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (t *cacheWindowStatesTransformation) UpdateWatermark(id execute.DatasetID, mark execute.Time) error {
+	return t.d.UpdateWatermark(mark)
+}
+
+func (t *cacheWindowStatesTransformation) UpdateProcessingTime(id execute.DatasetID, pt execute.Time) error {
+	return t.d.UpdateProcessingTime(pt)
+}
+
+func (t *cacheWindowStatesTransformation) Finish(id execute.DatasetID, err error) {
+	// The skipped windows are known once all the tables have been processed.
+	if err == nil && t.states != nil {
+		if err = t.addReused(); err == nil {
+			err = t.states.write(t.ctx)
+		}
+	}
+	t.d.Finish(err)
+}
@@ -0,0 +1,215 @@
+package task_test
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/plan/plantest"
+	"github.com/influxdata/flux/stdlib/universe"
+	"github.com/influxdata/flux/task"
+	"github.com/influxdata/flux/values"
+)
+
+func TestIncrementalAggregateRule(t *testing.T) {
+	window := &universe.WindowProcedureSpec{
+		Window: plan.WindowSpec{
+			Every:  values.ConvertDuration(time.Hour),
+			Period: values.ConvertDuration(time.Hour),
+		},
+		TimeColumn:  execute.DefaultTimeColLabel,
+		StartColumn: execute.DefaultStartColLabel,
+		StopColumn:  execute.DefaultStopColLabel,
+	}
+	count := &universe.CountProcedureSpec{AggregateConfig: execute.DefaultAggregateConfig}
+
+	tests := []plantest.RuleTestCase{
+		{
+			Name:  "windowed aggregate",
+			Rules: []plan.Rule{task.IncrementalAggregateRule{}},
+			Before: &plantest.PlanSpec{
+				Nodes: []plan.PlanNode{
+					plantest.CreatePhysicalMockNode("0"),
+					plan.CreatePhysicalNode("window", window),
+					plan.CreatePhysicalNode("count", count),
+					plantest.CreatePhysicalMockNode("3"),
+				},
+				Edges: [][2]int{{0, 1}, {1, 2}, {2, 3}},
+			},
+			After: &plantest.PlanSpec{
+				Nodes: []plan.PlanNode{
+					plantest.CreatePhysicalMockNode("0"),
+					plan.CreatePhysicalNode("window", window),
+					plan.CreatePhysicalNode("count_skip_cached", &task.SkipCachedWindowsProcedureSpec{
+						Aggregate: "count",
+					}),
+					plan.CreatePhysicalNode("count_partial", count),
+					plan.CreatePhysicalNode("count_cache_states", &task.CacheWindowStatesProcedureSpec{
+						Aggregate:   "count",
+						StartColumn: execute.DefaultStartColLabel,
+						StopColumn:  execute.DefaultStopColLabel,
+					}),
+					plan.CreatePhysicalNode("count_final", &universe.SumProcedureSpec{AggregateConfig: execute.DefaultAggregateConfig}),
+					plantest.CreatePhysicalMockNode("3"),
+				},
+				Edges: [][2]int{{0, 1}, {1, 2}, {2, 3}, {3, 4}, {4, 5}, {5, 6}},
+			},
+		},
+		{
+			Name:  "aggregate without window",
+			Rules: []plan.Rule{task.IncrementalAggregateRule{}},
+			Before: &plantest.PlanSpec{
+				Nodes: []plan.PlanNode{
+					plantest.CreatePhysicalMockNode("0"),
+					plan.CreatePhysicalNode("count", count),
+				},
+				Edges: [][2]int{{0, 1}},
+			},
+			NoChange: true,
+		},
+		{
+			Name:  "window with several successors",
+			Rules: []plan.Rule{task.IncrementalAggregateRule{}},
+			Before: &plantest.PlanSpec{
+				Nodes: []plan.PlanNode{
+					plantest.CreatePhysicalMockNode("0"),
+					plan.CreatePhysicalNode("window", window),
+					plan.CreatePhysicalNode("count", count),
+					plantest.CreatePhysicalMockNode("3"),
+				},
+				Edges: [][2]int{{0, 1}, {1, 2}, {1, 3}},
+			},
+			NoChange: true,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			plantest.PhysicalRuleTestHelper(t, &tc)
+		})
+	}
+}
+
+// windowCount returns the table of the partial count of a window starting at the hour.
+func windowCount(hour int, count int64) *executetest.Table {
+	start := execute.Time(time.Duration(hour) * time.Hour)
+	return &executetest.Table{
+		KeyCols: []string{"_start", "_stop"},
+		ColMeta: []flux.ColMeta{
+			{Label: "_start", Type: flux.TTime},
+			{Label: "_stop", Type: flux.TTime},
+			{Label: "_value", Type: flux.TInt},
+		},
+		Data: [][]interface{}{
+			{start, start.Add(values.ConvertDuration(time.Hour)), count},
+		},
+	}
+}
+
+func windowState(hour int, count int64) task.State {
+	tbl := windowCount(hour, count)
+	row := make([]values.Value, len(tbl.Data[0]))
+	for j, v := range tbl.Data[0] {
+		row[j] = values.New(v)
+	}
+	return task.State{
+		Key:   tbl.Key(),
+		Start: row[0].Time().Time(),
+		Stop:  row[1].Time().Time(),
+		Cols:  tbl.Cols(),
+		Rows:  [][]values.Value{row},
+	}
+}
+
+func TestIncrementalAggregate_Process(t *testing.T) {
+	storage := task.NewMemoryStorage()
+	ctx := context.Background()
+	if err := storage.WriteStates(ctx, "test", "count", []task.State{
+		windowState(0, 5),
+		windowState(1, 7),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	// The nodes of a run share the run context.
+	ctx = task.NewRunContext(ctx, "test", storage)
+
+	// The window of hour 1 is skipped, as its state is stored.
+	windows := []*executetest.Table{
+		windowCount(1, 1),
+		windowCount(2, 1),
+	}
+	windows[0].ColMeta[2] = flux.ColMeta{Label: "_value", Type: flux.TFloat}
+	windows[0].Data[0][2] = 1.0
+	windows[1].ColMeta[2] = flux.ColMeta{Label: "_value", Type: flux.TFloat}
+	windows[1].Data[0][2] = 1.0
+	got, err := executetest.ExecuteTransformation(ctx, &task.SkipCachedWindowsProcedureSpec{Aggregate: "count"}, windows)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []*executetest.Table{windows[1]}
+	executetest.NormalizeTables(want)
+	if !cmp.Equal(want, got) {
+		t.Errorf("unexpected windows -want/+got:\n%s", cmp.Diff(want, got))
+	}
+
+	// The partial count of hour 2 is computed, and the one of hour 1 is reused.
+	got, err = executetest.ExecuteTransformation(ctx, &task.CacheWindowStatesProcedureSpec{
+		Aggregate:   "count",
+		StartColumn: "_start",
+		StopColumn:  "_stop",
+	}, []*executetest.Table{windowCount(2, 3)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = []*executetest.Table{windowCount(1, 7), windowCount(2, 3)}
+	executetest.NormalizeTables(want)
+	sort.Sort(executetest.SortedTables(got))
+	if !cmp.Equal(want, got) {
+		t.Errorf("unexpected partial counts -want/+got:\n%s", cmp.Diff(want, got))
+	}
+
+	// The state of hour 0, which the run has not processed, is discarded.
+	states, err := storage.ReadStates(context.Background(), "test", "count")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var hours []time.Time
+	for _, st := range states {
+		hours = append(hours, st.Start)
+	}
+	if want := []time.Time{time.Unix(0, 0).Add(time.Hour).UTC(), time.Unix(0, 0).Add(2 * time.Hour).UTC()}; !cmp.Equal(want, hours) {
+		t.Errorf("unexpected stored windows -want/+got:\n%s", cmp.Diff(want, hours))
+	}
+}
+
+func TestMemoryStorage_InvalidateStates(t *testing.T) {
+	storage := task.NewMemoryStorage()
+	ctx := context.Background()
+	if err := storage.WriteStates(ctx, "test", "count", []task.State{
+		windowState(0, 1),
+		windowState(1, 1),
+		windowState(2, 1),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	start := time.Unix(0, 0).Add(90 * time.Minute)
+	if err := storage.InvalidateStates(ctx, "test", start, start.Add(time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+	states, err := storage.ReadStates(ctx, "test", "count")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(states) != 2 || !states[0].Start.Equal(time.Unix(0, 0)) || !states[1].Start.Equal(time.Unix(0, 0).Add(2*time.Hour)) {
+		t.Errorf("unexpected states after invalidating hour 1: %v", states)
+	}
+}
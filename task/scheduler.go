@@ -0,0 +1,219 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/influxdata/flux"
+	"go.uber.org/zap"
+)
+
+// DefaultCheckInterval is the default interval at which the scheduler checks for due runs.
+const DefaultCheckInterval = time.Second
+
+// Querier executes queries.
+// A *control.Controller is a Querier.
+type Querier interface {
+	Query(ctx context.Context, compiler flux.Compiler) (flux.Query, error)
+}
+
+type Config struct {
+	// Querier executes the runs of the tasks.
+	Querier Querier
+	// Now returns the current time. It defaults to time.Now.
+	Now func() time.Time
+	// CheckInterval is the interval at which Run checks for due runs.
+	// It defaults to DefaultCheckInterval.
+	CheckInterval time.Duration
+	// MaxCatchUp is the maximum number of scheduled runs a task executes at once
+	// when it has fallen behind, for example while the scheduler was stopped.
+	// The older runs are skipped. Zero means that all the missed runs are executed.
+	MaxCatchUp int
+	// Storage persists the partial aggregates of the windows of the tasks between runs,
+	// when their plans include the IncrementalAggregateRule.
+	Storage StorageProvider
+	Logger  *zap.Logger
+}
+
+// Scheduler executes the runs of tasks on their schedule, and when they are notified of new data.
+//
+// The runs of a task never overlap: they are executed one at a time, and each scheduled run
+// starts where the previous one stopped, so that every interval is materialized once.
+// A run that fails is retried at the next check.
+type Scheduler struct {
+	querier       Querier
+	now           func() time.Time
+	checkInterval time.Duration
+	maxCatchUp    int
+	storage       StorageProvider
+	logger        *zap.Logger
+
+	mu    sync.RWMutex
+	tasks map[string]*Task
+}
+
+func NewScheduler(c Config) *Scheduler {
+	s := &Scheduler{
+		querier:       c.Querier,
+		now:           c.Now,
+		checkInterval: c.CheckInterval,
+		maxCatchUp:    c.MaxCatchUp,
+		storage:       c.Storage,
+		logger:        c.Logger,
+		tasks:         make(map[string]*Task),
+	}
+	if s.now == nil {
+		s.now = time.Now
+	}
+	if s.checkInterval <= 0 {
+		s.checkInterval = DefaultCheckInterval
+	}
+	if s.logger == nil {
+		s.logger = zap.NewNop()
+	}
+	return s
+}
+
+// Register registers the task declared by a script.
+//
+// latest is the stop of the most recent time range the task has materialized,
+// for example before the scheduler was restarted, so that the task catches up from there.
+// If it is zero, the task materializes the data from the current time onwards.
+func (s *Scheduler) Register(script string, latest time.Time) (*Task, error) {
+	now := s.now()
+	t, err := newTask(script, now)
+	if err != nil {
+		return nil, err
+	}
+	t.latest = latest
+	if t.latest.IsZero() {
+		t.latest = now
+		if t.Every > 0 {
+			t.latest = now.Add(-t.Delay).Truncate(t.Every)
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.tasks[t.Name]; ok {
+		return nil, fmt.Errorf("task %q is already registered", t.Name)
+	}
+	s.tasks[t.Name] = t
+	return t, nil
+}
+
+// Unregister removes a task. A run of the task in progress is not interrupted.
+func (s *Scheduler) Unregister(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.tasks, name)
+}
+
+// Latest returns the stop of the most recent time range a task has materialized.
+func (s *Scheduler) Latest(name string) (time.Time, bool) {
+	t, ok := s.lookup(name)
+	if !ok {
+		return time.Time{}, false
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.latest, true
+}
+
+func (s *Scheduler) lookup(name string) (*Task, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	t, ok := s.tasks[name]
+	return t, ok
+}
+
+// Run checks for due runs until the context is canceled.
+func (s *Scheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.checkInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.Check(ctx); err != nil {
+				s.logger.Info("Task run failed", zap.Error(err))
+			}
+		}
+	}
+}
+
+// Check executes the scheduled runs that are due, and returns the first error encountered.
+// Tasks that are already running are skipped: their due runs are executed at the next check.
+func (s *Scheduler) Check(ctx context.Context) error {
+	s.mu.RLock()
+	tasks := make([]*Task, 0, len(s.tasks))
+	for _, t := range s.tasks {
+		tasks = append(tasks, t)
+	}
+	s.mu.RUnlock()
+
+	now := s.now()
+	var firstErr error
+	for _, t := range tasks {
+		if !t.mu.TryLock() {
+			continue
+		}
+		for _, r := range t.due(now, s.maxCatchUp) {
+			if err := s.execute(ctx, t, r); err != nil {
+				if firstErr == nil {
+					firstErr = err
+				}
+				break
+			}
+			t.latest = r.Stop
+		}
+		t.mu.Unlock()
+	}
+	return firstErr
+}
+
+// Notify executes the runs of a task for the data written in the time range [start, stop).
+// It waits for a run of the task in progress to finish.
+func (s *Scheduler) Notify(ctx context.Context, name string, start, stop time.Time) error {
+	t, ok := s.lookup(name)
+	if !ok {
+		return fmt.Errorf("task %q is not registered", name)
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	// The stored partial aggregates of the windows that contain the data are outdated.
+	if s.storage != nil {
+		if err := s.storage.InvalidateStates(ctx, name, start, stop); err != nil {
+			return err
+		}
+	}
+	r, ok := t.notified(start, stop)
+	if !ok {
+		return nil
+	}
+	if err := s.execute(ctx, t, r); err != nil {
+		return err
+	}
+	if t.Every <= 0 && t.latest.Before(r.Stop) {
+		t.latest = r.Stop
+	}
+	return nil
+}
+
+func (s *Scheduler) execute(ctx context.Context, t *Task, r Run) error {
+	s.logger.Debug("Executing task run",
+		zap.String("task", r.Task),
+		zap.Time("start", r.Start),
+		zap.Time("stop", r.Stop),
+	)
+	if s.storage != nil {
+		ctx = NewRunContext(ctx, r.Task, s.storage)
+	}
+	if err := t.execute(ctx, s.querier, r); err != nil {
+		return fmt.Errorf("run of task %q over [%v, %v) failed: %v", r.Task, r.Start, r.Stop, err)
+	}
+	return nil
+}
@@ -0,0 +1,298 @@
+package task_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/flux"
+	_ "github.com/influxdata/flux/builtin" // We need to import the builtins for the tests to work.
+	"github.com/influxdata/flux/stdlib/universe"
+	"github.com/influxdata/flux/task"
+)
+
+const script = `
+option task = {name: "test", every: 1h, delay: 10m}
+
+from(bucket: "in")
+    |> range(start: task.start, stop: task.stop)
+    |> to(bucket: "out")
+`
+
+func mustParseTime(s string) time.Time {
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// querier records the time range of the queries it compiles.
+type querier struct {
+	runs []task.Run
+	err  error
+	// onQuery is called before a query is compiled.
+	onQuery func()
+}
+
+func (q *querier) Query(ctx context.Context, c flux.Compiler) (flux.Query, error) {
+	if q.onQuery != nil {
+		q.onQuery()
+	}
+	if q.err != nil {
+		return nil, q.err
+	}
+	spec, err := c.Compile(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, op := range spec.Operations {
+		if r, ok := op.Spec.(*universe.RangeOpSpec); ok {
+			q.runs = append(q.runs, task.Run{
+				Task:  "test",
+				Start: r.Start.Time(spec.Now),
+				Stop:  r.Stop.Time(spec.Now),
+			})
+		}
+	}
+	return &query{spec: spec}, nil
+}
+
+// query is a query without results.
+type query struct {
+	spec *flux.Spec
+}
+
+func (q *query) Spec() *flux.Spec {
+	return q.spec
+}
+
+func (q *query) Ready() <-chan map[string]flux.Result {
+	ch := make(chan map[string]flux.Result)
+	close(ch)
+	return ch
+}
+
+func (q *query) Done()                       {}
+func (q *query) Cancel()                     {}
+func (q *query) Err() error                  { return nil }
+func (q *query) Statistics() flux.Statistics { return flux.Statistics{} }
+
+type clock struct {
+	now time.Time
+}
+
+func (c *clock) Now() time.Time {
+	return c.now
+}
+
+func TestScheduler_Register(t *testing.T) {
+	testCases := []struct {
+		name   string
+		script string
+	}{
+		{
+			name:   "no task option",
+			script: `from(bucket: "in") |> range(start: -1h) |> to(bucket: "out")`,
+		},
+		{
+			name:   "no name",
+			script: `option task = {every: 1h} from(bucket: "in") |> range(start: -1h) |> to(bucket: "out")`,
+		},
+		{
+			name:   "negative interval",
+			script: `option task = {name: "test", every: -1h} from(bucket: "in") |> range(start: -1h) |> to(bucket: "out")`,
+		},
+		{
+			name:   "no output",
+			script: `option task = {name: "test", every: 1h} from(bucket: "in") |> range(start: -1h) |> yield()`,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			s := task.NewScheduler(task.Config{Querier: &querier{}})
+			if _, err := s.Register(tc.script, time.Time{}); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+
+	s := task.NewScheduler(task.Config{Querier: &querier{}})
+	if _, err := s.Register(script, time.Time{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Register(script, time.Time{}); err == nil {
+		t.Error("expected an error registering a task twice")
+	}
+}
+
+func TestScheduler_Check(t *testing.T) {
+	c := &clock{now: mustParseTime("2019-01-01T12:05:00Z")}
+	q := &querier{}
+	s := task.NewScheduler(task.Config{Querier: q, Now: c.Now})
+	if _, err := s.Register(script, time.Time{}); err != nil {
+		t.Fatal(err)
+	}
+
+	check := func(now string, want []task.Run) {
+		t.Helper()
+		c.now = mustParseTime(now)
+		q.runs = nil
+		if err := s.Check(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+		if !cmp.Equal(want, q.runs) {
+			t.Errorf("unexpected runs at %s -want/+got:\n%s", now, cmp.Diff(want, q.runs))
+		}
+	}
+
+	// The run of the current interval is not due before the delay has elapsed.
+	check("2019-01-01T12:05:00Z", nil)
+	check("2019-01-01T12:10:00Z", []task.Run{
+		{Task: "test", Start: mustParseTime("2019-01-01T11:00:00Z"), Stop: mustParseTime("2019-01-01T12:00:00Z")},
+	})
+	check("2019-01-01T12:30:00Z", nil)
+	// The task catches up with the missed runs.
+	check("2019-01-01T15:20:00Z", []task.Run{
+		{Task: "test", Start: mustParseTime("2019-01-01T12:00:00Z"), Stop: mustParseTime("2019-01-01T13:00:00Z")},
+		{Task: "test", Start: mustParseTime("2019-01-01T13:00:00Z"), Stop: mustParseTime("2019-01-01T14:00:00Z")},
+		{Task: "test", Start: mustParseTime("2019-01-01T14:00:00Z"), Stop: mustParseTime("2019-01-01T15:00:00Z")},
+	})
+
+	// A failed run is retried at the next check.
+	q.err = errors.New("expected error")
+	c.now = mustParseTime("2019-01-01T16:10:00Z")
+	if err := s.Check(context.Background()); err == nil {
+		t.Fatal("expected an error")
+	}
+	if latest, _ := s.Latest("test"); !latest.Equal(mustParseTime("2019-01-01T15:00:00Z")) {
+		t.Errorf("unexpected latest time after a failed run: %v", latest)
+	}
+	q.err = nil
+	check("2019-01-01T16:10:00Z", []task.Run{
+		{Task: "test", Start: mustParseTime("2019-01-01T15:00:00Z"), Stop: mustParseTime("2019-01-01T16:00:00Z")},
+	})
+}
+
+func TestScheduler_CheckMaxCatchUp(t *testing.T) {
+	c := &clock{now: mustParseTime("2019-01-01T12:15:00Z")}
+	q := &querier{}
+	s := task.NewScheduler(task.Config{Querier: q, Now: c.Now, MaxCatchUp: 2})
+	if _, err := s.Register(script, mustParseTime("2019-01-01T08:00:00Z")); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Check(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	want := []task.Run{
+		{Task: "test", Start: mustParseTime("2019-01-01T10:00:00Z"), Stop: mustParseTime("2019-01-01T11:00:00Z")},
+		{Task: "test", Start: mustParseTime("2019-01-01T11:00:00Z"), Stop: mustParseTime("2019-01-01T12:00:00Z")},
+	}
+	if !cmp.Equal(want, q.runs) {
+		t.Errorf("unexpected runs -want/+got:\n%s", cmp.Diff(want, q.runs))
+	}
+}
+
+func TestScheduler_CheckOverlap(t *testing.T) {
+	c := &clock{now: mustParseTime("2019-01-01T12:15:00Z")}
+	q := &querier{}
+	s := task.NewScheduler(task.Config{Querier: q, Now: c.Now})
+	if _, err := s.Register(script, mustParseTime("2019-01-01T10:00:00Z")); err != nil {
+		t.Fatal(err)
+	}
+	// A check while the task is running skips it.
+	checked := false
+	q.onQuery = func() {
+		if !checked {
+			checked = true
+			if err := s.Check(context.Background()); err != nil {
+				t.Error(err)
+			}
+		}
+	}
+	if err := s.Check(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	want := []task.Run{
+		{Task: "test", Start: mustParseTime("2019-01-01T10:00:00Z"), Stop: mustParseTime("2019-01-01T11:00:00Z")},
+		{Task: "test", Start: mustParseTime("2019-01-01T11:00:00Z"), Stop: mustParseTime("2019-01-01T12:00:00Z")},
+	}
+	if !cmp.Equal(want, q.runs) {
+		t.Errorf("unexpected runs -want/+got:\n%s", cmp.Diff(want, q.runs))
+	}
+}
+
+func TestScheduler_Notify(t *testing.T) {
+	const notifiedScript = `
+option task = {name: "test"}
+
+from(bucket: "in")
+    |> range(start: task.start, stop: task.stop)
+    |> to(bucket: "out")
+`
+	testCases := []struct {
+		name        string
+		script      string
+		start, stop string
+		want        []task.Run
+	}{
+		{
+			name:   "materialized interval",
+			script: script,
+			start:  "2019-01-01T10:30:00Z",
+			stop:   "2019-01-01T10:40:00Z",
+			want: []task.Run{
+				{Task: "test", Start: mustParseTime("2019-01-01T10:00:00Z"), Stop: mustParseTime("2019-01-01T11:00:00Z")},
+			},
+		},
+		{
+			name:   "partly materialized intervals",
+			script: script,
+			start:  "2019-01-01T11:30:00Z",
+			stop:   "2019-01-01T12:30:00Z",
+			want: []task.Run{
+				{Task: "test", Start: mustParseTime("2019-01-01T11:00:00Z"), Stop: mustParseTime("2019-01-01T12:00:00Z")},
+			},
+		},
+		{
+			name:   "scheduled interval",
+			script: script,
+			start:  "2019-01-01T12:10:00Z",
+			stop:   "2019-01-01T12:20:00Z",
+		},
+		{
+			name:   "notified task",
+			script: notifiedScript,
+			start:  "2019-01-01T12:10:00Z",
+			stop:   "2019-01-01T12:20:00Z",
+			want: []task.Run{
+				{Task: "test", Start: mustParseTime("2019-01-01T12:10:00Z"), Stop: mustParseTime("2019-01-01T12:20:00Z")},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			c := &clock{now: mustParseTime("2019-01-01T12:25:00Z")}
+			q := &querier{}
+			s := task.NewScheduler(task.Config{Querier: q, Now: c.Now})
+			if _, err := s.Register(tc.script, mustParseTime("2019-01-01T12:00:00Z")); err != nil {
+				t.Fatal(err)
+			}
+			if err := s.Notify(context.Background(), "test", mustParseTime(tc.start), mustParseTime(tc.stop)); err != nil {
+				t.Fatal(err)
+			}
+			if !cmp.Equal(tc.want, q.runs) {
+				t.Errorf("unexpected runs -want/+got:\n%s", cmp.Diff(tc.want, q.runs))
+			}
+		})
+	}
+
+	s := task.NewScheduler(task.Config{Querier: &querier{}})
+	if err := s.Notify(context.Background(), "missing", time.Time{}, time.Time{}); err == nil {
+		t.Error("expected an error notifying a task that is not registered")
+	}
+}
@@ -0,0 +1,79 @@
+package task
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/values"
+)
+
+// State is the partial aggregate of a table in a window, computed by a run of a task.
+type State struct {
+	// Key is the group key of the table. It includes the bounds of the window.
+	Key flux.GroupKey
+	// Start and Stop are the bounds of the window.
+	Start time.Time
+	Stop  time.Time
+	// Cols and Rows hold the table of partial aggregates.
+	Cols []flux.ColMeta
+	Rows [][]values.Value
+}
+
+// StorageProvider persists the states of the windowed aggregates of tasks between runs.
+// An aggregate is identified by the name of its task and the ID of its node in the plan of the task.
+type StorageProvider interface {
+	// ReadStates returns the states stored for an aggregate.
+	ReadStates(ctx context.Context, task, node string) ([]State, error)
+	// WriteStates replaces the states stored for an aggregate.
+	WriteStates(ctx context.Context, task, node string, states []State) error
+	// InvalidateStates deletes the states of the aggregates of a task
+	// whose windows overlap the time range [start, stop).
+	InvalidateStates(ctx context.Context, task string, start, stop time.Time) error
+}
+
+// MemoryStorage is a StorageProvider that keeps the states in memory.
+type MemoryStorage struct {
+	mu     sync.Mutex
+	states map[string]map[string][]State
+}
+
+func NewMemoryStorage() *MemoryStorage {
+	return &MemoryStorage{
+		states: make(map[string]map[string][]State),
+	}
+}
+
+func (s *MemoryStorage) ReadStates(ctx context.Context, task, node string) ([]State, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.states[task][node], nil
+}
+
+func (s *MemoryStorage) WriteStates(ctx context.Context, task, node string, states []State) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	nodes, ok := s.states[task]
+	if !ok {
+		nodes = make(map[string][]State)
+		s.states[task] = nodes
+	}
+	nodes[node] = states
+	return nil
+}
+
+func (s *MemoryStorage) InvalidateStates(ctx context.Context, task string, start, stop time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for node, states := range s.states[task] {
+		valid := states[:0:0]
+		for _, st := range states {
+			if !st.Start.Before(stop) || !start.Before(st.Stop) {
+				valid = append(valid, st)
+			}
+		}
+		s.states[task][node] = valid
+	}
+	return nil
+}
@@ -0,0 +1,233 @@
+// Package task runs Flux scripts incrementally to maintain materialized views.
+//
+// A task is a script that declares the task option and writes its output with to():
+//
+//	option task = {name: "cpu_1h", every: 1h, delay: 5m}
+//
+//	from(bucket: "telegraf")
+//	    |> range(start: task.start, stop: task.stop)
+//	    |> filter(fn: (r) => r._measurement == "cpu")
+//	    |> aggregateWindow(every: 1h, fn: mean)
+//	    |> to(bucket: "cpu_1h", org: "my-org")
+//
+// Every run of a task processes a time range that is set as the start and stop
+// properties of the task option, and the now option is set to the stop of the range,
+// so scripts may equally filter their input with range(start: -task.every).
+package task
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/ast"
+	"github.com/influxdata/flux/ast/edit"
+	"github.com/influxdata/flux/lang"
+	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/flux/stdlib/influxdata/influxdb"
+	"github.com/influxdata/flux/values"
+	"github.com/pkg/errors"
+)
+
+const optionName = "task"
+
+// Options are the options of a task, declared in its script with the task option.
+type Options struct {
+	// Name identifies the task.
+	Name string
+	// Every is the interval at which the task is scheduled.
+	// Tasks without an interval only run when they are notified of new data.
+	Every time.Duration
+	// Delay delays the scheduled runs, to give late data time to arrive.
+	Delay time.Duration
+}
+
+// Run is a single execution of a task over the time range [Start, Stop).
+type Run struct {
+	Task  string
+	Start time.Time
+	Stop  time.Time
+}
+
+// Task is a script registered with a Scheduler.
+type Task struct {
+	Options
+	script string
+
+	// mu is held while the task runs, so that the runs of a task never overlap.
+	mu sync.Mutex
+	// latest is the stop of the most recent time range the task has materialized.
+	latest time.Time
+}
+
+// newTask creates a task from its script.
+// The script must declare the task option and write its output with to().
+func newTask(script string, now time.Time) (*Task, error) {
+	astPkg, err := parse(script, Run{Start: now, Stop: now})
+	if err != nil {
+		return nil, err
+	}
+	sideEffects, scope, err := flux.EvalAST(astPkg)
+	if err != nil {
+		return nil, err
+	}
+	v, ok := scope.Lookup(optionName)
+	if !ok {
+		return nil, fmt.Errorf("script does not declare the %q option", optionName)
+	}
+	opts, err := optionsFrom(v)
+	if err != nil {
+		return nil, err
+	}
+
+	spec, err := flux.ToSpec(sideEffects, now)
+	if err != nil {
+		return nil, err
+	}
+	materialized := false
+	for _, op := range spec.Operations {
+		if op.Spec.Kind() == influxdb.ToKind {
+			materialized = true
+		}
+	}
+	if !materialized {
+		return nil, fmt.Errorf("task %q does not write its output with to()", opts.Name)
+	}
+
+	return &Task{
+		Options: opts,
+		script:  script,
+	}, nil
+}
+
+func optionsFrom(v values.Value) (Options, error) {
+	if v.Type().Nature() != semantic.Object {
+		return Options{}, fmt.Errorf("%q option must be an object", optionName)
+	}
+	obj := v.Object()
+
+	var opts Options
+	name, ok := obj.Get("name")
+	if !ok || name.Type().Nature() != semantic.String || name.Str() == "" {
+		return Options{}, errors.New("task name must be a non-empty string")
+	}
+	opts.Name = name.Str()
+	for _, d := range []struct {
+		key string
+		dst *time.Duration
+	}{
+		{key: "every", dst: &opts.Every},
+		{key: "delay", dst: &opts.Delay},
+	} {
+		v, ok := obj.Get(d.key)
+		if !ok {
+			continue
+		}
+		if v.Type().Nature() != semantic.Duration {
+			return Options{}, fmt.Errorf("task %s must be a duration", d.key)
+		}
+		if *d.dst = v.Duration().Duration(); *d.dst < 0 {
+			return Options{}, fmt.Errorf("task %s must not be negative", d.key)
+		}
+	}
+	return opts, nil
+}
+
+// parse parses a script and sets the time range of the run in its task option.
+func parse(script string, r Run) (*ast.Package, error) {
+	astPkg, err := flux.Parse(script)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := edit.Option(astPkg, optionName, edit.OptionObjectFn(map[string]ast.Expression{
+		"start": &ast.DateTimeLiteral{Value: r.Start},
+		"stop":  &ast.DateTimeLiteral{Value: r.Stop},
+	})); err != nil {
+		return nil, errors.Wrap(err, "failed to set the time range of the task")
+	}
+	return astPkg, nil
+}
+
+// compiler returns the compiler of the script of the task for a run.
+func (t *Task) compiler(r Run) (flux.Compiler, error) {
+	astPkg, err := parse(t.script, r)
+	if err != nil {
+		return nil, err
+	}
+	return lang.ASTCompiler{
+		AST: astPkg,
+		Now: func() time.Time { return r.Stop },
+	}, nil
+}
+
+// due returns the scheduled runs of the task that are due at now, oldest first.
+// Every run covers one interval of the task, aligned to its duration.
+// When more than max runs are due, the oldest ones are skipped, unless max is zero.
+func (t *Task) due(now time.Time, max int) []Run {
+	if t.Every <= 0 {
+		return nil
+	}
+	stop := now.Add(-t.Delay).Truncate(t.Every)
+	start := t.latest
+	if max > 0 {
+		if earliest := stop.Add(-time.Duration(max) * t.Every); start.Before(earliest) {
+			start = earliest
+		}
+	}
+	var runs []Run
+	for start.Before(stop) {
+		end := start.Truncate(t.Every).Add(t.Every)
+		runs = append(runs, Run{Task: t.Name, Start: start, Stop: end})
+		start = end
+	}
+	return runs
+}
+
+// notified returns the run that materializes again the data written in [start, stop),
+// or false if there is nothing to run.
+//
+// Tasks without an interval run over the time range of the data.
+// Scheduled tasks run over the intervals that contain the data, but only over those
+// they have already materialized: the other intervals are left to the scheduled runs.
+// The run overwrites the output of the intervals, as to() replaces the points it writes
+// at the same time and series.
+func (t *Task) notified(start, stop time.Time) (Run, bool) {
+	if t.Every > 0 {
+		start = start.Truncate(t.Every)
+		if aligned := stop.Truncate(t.Every); aligned.Before(stop) {
+			stop = aligned.Add(t.Every)
+		}
+		if t.latest.Before(stop) {
+			stop = t.latest
+		}
+	}
+	if !start.Before(stop) {
+		return Run{}, false
+	}
+	return Run{Task: t.Name, Start: start, Stop: stop}, true
+}
+
+// execute runs the script of the task over the time range of the run,
+// and consumes its results so that the output is written.
+func (t *Task) execute(ctx context.Context, q Querier, r Run) error {
+	c, err := t.compiler(r)
+	if err != nil {
+		return err
+	}
+	query, err := q.Query(ctx, c)
+	if err != nil {
+		return err
+	}
+	results := flux.NewResultIteratorFromQuery(query)
+	defer results.Release()
+	for results.More() {
+		if err := results.Next().Tables().Do(func(tbl flux.Table) error {
+			return tbl.Do(func(flux.ColReader) error { return nil })
+		}); err != nil {
+			return err
+		}
+	}
+	return results.Err()
+}
@@ -3,6 +3,8 @@ package flux
 import (
 	"math"
 	"time"
+
+	"github.com/influxdata/flux/values"
 )
 
 var (
@@ -23,17 +25,26 @@ var (
 type Time struct {
 	IsRelative bool
 	Relative   time.Duration
-	Absolute   time.Time
+	// RelativeMonths are added to now before Relative.
+	RelativeMonths int64
+	Absolute       time.Time
 }
 
 // Time returns the time specified relative to now.
 func (t Time) Time(now time.Time) time.Time {
 	if t.IsRelative {
+		if t.RelativeMonths != 0 {
+			return values.ConvertTime(now).Add(t.relative()).Time().In(now.Location())
+		}
 		return now.Add(t.Relative)
 	}
 	return t.Absolute
 }
 
+func (t Time) relative() values.Duration {
+	return values.MakeDuration(t.RelativeMonths, int64(t.Relative))
+}
+
 func (t Time) IsZero() bool {
 	return !t.IsRelative && t.Absolute.IsZero()
 }
@@ -42,6 +53,7 @@ func (t *Time) UnmarshalText(data []byte) error {
 	if len(data) == 0 {
 		t.Absolute = time.Time{}
 		t.Relative = 0
+		t.RelativeMonths = 0
 		t.IsRelative = false
 		return nil
 	}
@@ -49,13 +61,15 @@ func (t *Time) UnmarshalText(data []byte) error {
 	str := string(data)
 	if str == "now" {
 		t.Relative = 0
+		t.RelativeMonths = 0
 		t.Absolute = time.Time{}
 		t.IsRelative = true
 		return nil
 	}
-	d, err := time.ParseDuration(str)
+	d, err := values.ParseDuration(str)
 	if err == nil {
-		t.Relative = d
+		t.Relative = time.Duration(d.Nanoseconds())
+		t.RelativeMonths = d.Months()
 		t.Absolute = time.Time{}
 		t.IsRelative = true
 		return nil
@@ -67,32 +81,37 @@ func (t *Time) UnmarshalText(data []byte) error {
 	t.Absolute = ts.UTC()
 	t.IsRelative = false
 	t.Relative = 0
+	t.RelativeMonths = 0
 	return nil
 }
 
 func (t Time) MarshalText() ([]byte, error) {
 	if t.IsRelative {
-		if t.Relative == 0 {
+		if t.Relative == 0 && t.RelativeMonths == 0 {
 			return []byte("now"), nil
 		}
-		return []byte(t.Relative.String()), nil
+		return []byte(t.relative().String()), nil
 	}
 	return []byte(t.Absolute.Format(time.RFC3339Nano)), nil
 }
 
 // Duration is a marshalable duration type.
-//TODO make this the real duration parsing not just time.ParseDuration
-type Duration time.Duration
+//
+// Duration is an alias of values.Duration, which carries months separately
+// from nanoseconds. This is a breaking change from when Duration was defined
+// as a time.Duration: a conversion such as flux.Duration(time.Second) does not compile,
+// and neither does arithmetic that mixes a Duration with a time.Duration.
+// Use ConvertDuration or MakeDuration to build a Duration, and the Duration,
+// Months and Nanoseconds methods to read one.
+type Duration = values.Duration
 
-func (d *Duration) UnmarshalText(data []byte) error {
-	dur, err := time.ParseDuration(string(data))
-	if err != nil {
-		return err
-	}
-	*d = Duration(dur)
-	return nil
+// ConvertDuration returns the Duration of a time.Duration.
+// It replaces the conversion flux.Duration(d).
+func ConvertDuration(d time.Duration) Duration {
+	return values.ConvertDuration(d)
 }
 
-func (d Duration) MarshalText() ([]byte, error) {
-	return []byte(time.Duration(d).String()), nil
+// MakeDuration returns the Duration of a number of months and a number of nanoseconds.
+func MakeDuration(months int64, nsecs time.Duration) Duration {
+	return values.MakeDuration(months, int64(nsecs))
 }
@@ -34,6 +34,14 @@ func TestTime_MarshalText(t *testing.T) {
 			},
 			want: "1m0s",
 		},
+		{
+			ts: flux.Time{
+				Relative:       -time.Hour,
+				RelativeMonths: -1,
+				IsRelative:     true,
+			},
+			want: "-1mo1h0m0s",
+		},
 		{
 			ts: flux.Time{
 				Absolute: time.Unix(0, 0).UTC(),
@@ -93,6 +101,14 @@ func TestTime_UnmarshalText(t *testing.T) {
 				IsRelative: true,
 			},
 		},
+		{
+			s: "-1y2d",
+			want: flux.Time{
+				Relative:       -48 * time.Hour,
+				RelativeMonths: -12,
+				IsRelative:     true,
+			},
+		},
 		{
 			s: "1970-01-01T00:00:00Z",
 			want: flux.Time{
@@ -126,3 +142,38 @@ func TestTime_UnmarshalText(t *testing.T) {
 		})
 	}
 }
+
+func TestTime_Time(t *testing.T) {
+	now := time.Date(2019, 3, 31, 12, 0, 0, 0, time.UTC)
+	ts := flux.Time{
+		Relative:       -time.Hour,
+		RelativeMonths: -1,
+		IsRelative:     true,
+	}
+	if want, got := time.Date(2019, 2, 28, 11, 0, 0, 0, time.UTC), ts.Time(now); !want.Equal(got) {
+		t.Fatalf("unexpected time -want/+got\n\t- %s\n\t+ %s", want, got)
+	}
+}
+
+func TestConvertDuration(t *testing.T) {
+	d := flux.ConvertDuration(90 * time.Second)
+	if want, got := 90*time.Second, d.Duration(); want != got {
+		t.Fatalf("unexpected duration: want %s, got %s", want, got)
+	}
+	if want, got := int64(0), d.Months(); want != got {
+		t.Fatalf("unexpected months: want %d, got %d", want, got)
+	}
+}
+
+func TestMakeDuration(t *testing.T) {
+	d := flux.MakeDuration(14, time.Hour)
+	if want, got := int64(14), d.Months(); want != got {
+		t.Fatalf("unexpected months: want %d, got %d", want, got)
+	}
+	if want, got := int64(time.Hour), d.Nanoseconds(); want != got {
+		t.Fatalf("unexpected nanoseconds: want %d, got %d", want, got)
+	}
+	if want, got := "1y2mo1h0m0s", d.String(); want != got {
+		t.Fatalf("unexpected string: want %s, got %s", want, got)
+	}
+}
@@ -44,6 +44,11 @@ var binaryFuncLookup = map[BinaryFuncSignature]BinaryFunction{
 		r := rv.Float()
 		return NewFloat(l + r)
 	},
+	{Operator: ast.AdditionOperator, Left: semantic.Duration, Right: semantic.Duration}: func(lv, rv Value) Value {
+		l := lv.Duration()
+		r := rv.Duration()
+		return NewDuration(l.Add(r))
+	},
 	{Operator: ast.SubtractionOperator, Left: semantic.Int, Right: semantic.Int}: func(lv, rv Value) Value {
 		l := lv.Int()
 		r := rv.Int()
@@ -59,6 +64,11 @@ var binaryFuncLookup = map[BinaryFuncSignature]BinaryFunction{
 		r := rv.Float()
 		return NewFloat(l - r)
 	},
+	{Operator: ast.SubtractionOperator, Left: semantic.Duration, Right: semantic.Duration}: func(lv, rv Value) Value {
+		l := lv.Duration()
+		r := rv.Duration()
+		return NewDuration(l.Sub(r))
+	},
 	{Operator: ast.MultiplicationOperator, Left: semantic.Int, Right: semantic.Int}: func(lv, rv Value) Value {
 		l := lv.Int()
 		r := rv.Int()
@@ -159,6 +169,11 @@ var binaryFuncLookup = map[BinaryFuncSignature]BinaryFunction{
 		r := rv.Float()
 		return NewBool(l <= r)
 	},
+	{Operator: ast.LessThanEqualOperator, Left: semantic.Duration, Right: semantic.Duration}: func(lv, rv Value) Value {
+		l := lv.Duration()
+		r := rv.Duration()
+		return NewBool(l.Compare(r) <= 0)
+	},
 	{Operator: ast.LessThanEqualOperator, Left: semantic.String, Right: semantic.String}: func(lv, rv Value) Value {
 		l := lv.Str()
 		r := rv.Str()
@@ -218,6 +233,11 @@ var binaryFuncLookup = map[BinaryFuncSignature]BinaryFunction{
 		r := rv.Float()
 		return NewBool(l < r)
 	},
+	{Operator: ast.LessThanOperator, Left: semantic.Duration, Right: semantic.Duration}: func(lv, rv Value) Value {
+		l := lv.Duration()
+		r := rv.Duration()
+		return NewBool(l.Compare(r) < 0)
+	},
 	{Operator: ast.LessThanOperator, Left: semantic.String, Right: semantic.String}: func(lv, rv Value) Value {
 		l := lv.Str()
 		r := rv.Str()
@@ -277,6 +297,11 @@ var binaryFuncLookup = map[BinaryFuncSignature]BinaryFunction{
 		r := rv.Float()
 		return NewBool(l >= r)
 	},
+	{Operator: ast.GreaterThanEqualOperator, Left: semantic.Duration, Right: semantic.Duration}: func(lv, rv Value) Value {
+		l := lv.Duration()
+		r := rv.Duration()
+		return NewBool(l.Compare(r) >= 0)
+	},
 	{Operator: ast.GreaterThanEqualOperator, Left: semantic.String, Right: semantic.String}: func(lv, rv Value) Value {
 		l := lv.Str()
 		r := rv.Str()
@@ -336,6 +361,11 @@ var binaryFuncLookup = map[BinaryFuncSignature]BinaryFunction{
 		r := rv.Float()
 		return NewBool(l > r)
 	},
+	{Operator: ast.GreaterThanOperator, Left: semantic.Duration, Right: semantic.Duration}: func(lv, rv Value) Value {
+		l := lv.Duration()
+		r := rv.Duration()
+		return NewBool(l.Compare(r) > 0)
+	},
 	{Operator: ast.GreaterThanOperator, Left: semantic.String, Right: semantic.String}: func(lv, rv Value) Value {
 		l := lv.Str()
 		r := rv.Str()
@@ -395,6 +425,11 @@ var binaryFuncLookup = map[BinaryFuncSignature]BinaryFunction{
 		r := rv.Float()
 		return NewBool(l == r)
 	},
+	{Operator: ast.EqualOperator, Left: semantic.Duration, Right: semantic.Duration}: func(lv, rv Value) Value {
+		l := lv.Duration()
+		r := rv.Duration()
+		return NewBool(l == r)
+	},
 	{Operator: ast.EqualOperator, Left: semantic.String, Right: semantic.String}: func(lv, rv Value) Value {
 		l := lv.Str()
 		r := rv.Str()
@@ -454,6 +489,11 @@ var binaryFuncLookup = map[BinaryFuncSignature]BinaryFunction{
 		r := rv.Float()
 		return NewBool(l != r)
 	},
+	{Operator: ast.NotEqualOperator, Left: semantic.Duration, Right: semantic.Duration}: func(lv, rv Value) Value {
+		l := lv.Duration()
+		r := rv.Duration()
+		return NewBool(l != r)
+	},
 	{Operator: ast.NotEqualOperator, Left: semantic.String, Right: semantic.String}: func(lv, rv Value) Value {
 		l := lv.Str()
 		r := rv.Str()
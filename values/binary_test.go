@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/influxdata/flux/ast"
 	"github.com/influxdata/flux/values"
@@ -281,6 +282,17 @@ func TestBinaryOperator(t *testing.T) {
 		{lhs: regexp.MustCompile(`b{2}`), op: "!~", rhs: "abc", want: true},
 		// string + string
 		{lhs: "a", op: "+", rhs: "b", want: "ab"},
+		// duration + duration
+		{lhs: values.MakeDuration(1, 0), op: "+", rhs: values.ConvertDuration(time.Hour), want: values.MakeDuration(1, int64(time.Hour))},
+		// duration - duration
+		{lhs: values.MakeDuration(2, 0), op: "-", rhs: values.MakeDuration(1, int64(time.Hour)), want: values.MakeDuration(1, -int64(time.Hour))},
+		// duration < duration
+		{lhs: values.ConvertDuration(time.Hour), op: "<", rhs: values.ConvertDuration(time.Minute), want: false},
+		{lhs: values.MakeDuration(1, 0), op: "<", rhs: values.ConvertDuration(32 * 24 * time.Hour), want: true},
+		{lhs: values.MakeDuration(1, 0), op: "<", rhs: values.ConvertDuration(28 * 24 * time.Hour), want: false},
+		// duration == duration
+		{lhs: values.MakeDuration(1, 0), op: "==", rhs: values.MakeDuration(1, 0), want: true},
+		{lhs: values.MakeDuration(1, 0), op: "==", rhs: values.ConvertDuration(30 * 24 * time.Hour), want: false},
 	} {
 		t.Run(fmt.Sprintf("%v %s %v", tt.lhs, tt.op, tt.rhs), func(t *testing.T) {
 			left, right := values.New(tt.lhs), values.New(tt.rhs)
@@ -0,0 +1,434 @@
+package values
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/influxdata/flux/semantic"
+)
+
+// Encode converts a Go value into a Value.
+//
+// Strings, booleans, integers, unsigned integers and floats become the flux basic types of the same kind.
+// A time.Time becomes a time and a time.Duration becomes a duration.
+// Slices and arrays become arrays, maps with string keys and structs become objects.
+// Pointers and interfaces are converted to the value they refer to, a nil one is an error.
+// A Value is returned as is.
+//
+// The properties of an object converted from a struct are named after the exported fields of the struct.
+// The name can be changed with a `flux:"name"` field tag, and a field tagged `flux:"-"` is skipped.
+func Encode(v interface{}) (Value, error) {
+	if v == nil {
+		return nil, fmt.Errorf("cannot encode nil value")
+	}
+	return encode(reflect.ValueOf(v))
+}
+
+var (
+	valueType    = reflect.TypeOf((*Value)(nil)).Elem()
+	timeType     = reflect.TypeOf(time.Time{})
+	durationType = reflect.TypeOf(time.Duration(0))
+	fluxTimeType = reflect.TypeOf(Time(0))
+	fluxDurType  = reflect.TypeOf(Duration{})
+	regexpType   = reflect.TypeOf((*regexp.Regexp)(nil))
+)
+
+func encode(rv reflect.Value) (Value, error) {
+	if rv.Type().Implements(valueType) {
+		if rv.Kind() == reflect.Interface || rv.Kind() == reflect.Ptr {
+			if rv.IsNil() {
+				return nil, fmt.Errorf("cannot encode nil %v", rv.Type())
+			}
+		}
+		return rv.Interface().(Value), nil
+	}
+	switch rv.Type() {
+	case timeType:
+		return NewTime(ConvertTime(rv.Interface().(time.Time))), nil
+	case durationType:
+		return NewDuration(ConvertDuration(time.Duration(rv.Int()))), nil
+	case fluxDurType:
+		return NewDuration(rv.Interface().(Duration)), nil
+	case fluxTimeType:
+		return NewTime(Time(rv.Int())), nil
+	case regexpType:
+		if rv.IsNil() {
+			return nil, fmt.Errorf("cannot encode nil %v", rv.Type())
+		}
+		return NewRegexp(rv.Interface().(*regexp.Regexp)), nil
+	}
+
+	switch rv.Kind() {
+	case reflect.String:
+		return NewString(rv.String()), nil
+	case reflect.Bool:
+		return NewBool(rv.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return NewInt(rv.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return NewUInt(rv.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return NewFloat(rv.Float()), nil
+	case reflect.Ptr, reflect.Interface:
+		if rv.IsNil() {
+			return nil, fmt.Errorf("cannot encode nil %v", rv.Type())
+		}
+		return encode(rv.Elem())
+	case reflect.Slice, reflect.Array:
+		return encodeArray(rv)
+	case reflect.Map:
+		if rv.Type().Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("cannot encode map with keys of type %v, keys must be strings", rv.Type().Key())
+		}
+		obj := NewObjectWithBacking(rv.Len())
+		for _, k := range rv.MapKeys() {
+			e, err := encode(rv.MapIndex(k))
+			if err != nil {
+				return nil, fmt.Errorf("property %q: %v", k.String(), err)
+			}
+			obj.Set(k.String(), e)
+		}
+		return obj, nil
+	case reflect.Struct:
+		fields := structFields(rv.Type())
+		obj := NewObjectWithBacking(len(fields))
+		for _, f := range fields {
+			e, err := encode(rv.Field(f.index))
+			if err != nil {
+				return nil, fmt.Errorf("property %q: %v", f.name, err)
+			}
+			obj.Set(f.name, e)
+		}
+		return obj, nil
+	default:
+		return nil, fmt.Errorf("cannot encode value of type %v", rv.Type())
+	}
+}
+
+func encodeArray(rv reflect.Value) (Value, error) {
+	elements := make([]Value, rv.Len())
+	for i := range elements {
+		e, err := encode(rv.Index(i))
+		if err != nil {
+			return nil, fmt.Errorf("element %d: %v", i, err)
+		}
+		if i > 0 && e.Type() != elements[0].Type() {
+			return nil, fmt.Errorf("element %d: array elements must have the same type, got %v and %v", i, elements[0].Type(), e.Type())
+		}
+		elements[i] = e
+	}
+	var elemType semantic.Type
+	if len(elements) > 0 {
+		elemType = elements[0].Type()
+	} else {
+		t, err := semanticType(rv.Type().Elem())
+		if err != nil {
+			return nil, err
+		}
+		elemType = t
+	}
+	return NewArrayWithBacking(elemType, elements), nil
+}
+
+// semanticType reports the type of the values that Encode produces for Go values of type t.
+func semanticType(t reflect.Type) (semantic.Type, error) {
+	switch t {
+	case timeType, fluxTimeType:
+		return semantic.Time, nil
+	case durationType, fluxDurType:
+		return semantic.Duration, nil
+	case regexpType:
+		return semantic.Regexp, nil
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return semantic.String, nil
+	case reflect.Bool:
+		return semantic.Bool, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return semantic.Int, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return semantic.UInt, nil
+	case reflect.Float32, reflect.Float64:
+		return semantic.Float, nil
+	case reflect.Ptr:
+		return semanticType(t.Elem())
+	case reflect.Slice, reflect.Array:
+		e, err := semanticType(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return semantic.NewArrayType(e), nil
+	case reflect.Struct:
+		fields := structFields(t)
+		properties := make(map[string]semantic.Type, len(fields))
+		for _, f := range fields {
+			p, err := semanticType(t.Field(f.index).Type)
+			if err != nil {
+				return nil, err
+			}
+			properties[f.name] = p
+		}
+		return semantic.NewObjectType(properties), nil
+	default:
+		return nil, fmt.Errorf("the flux type of %v cannot be determined without a value", t)
+	}
+}
+
+type structField struct {
+	name  string
+	index int
+}
+
+// structFields lists the exported fields of a struct type that are encoded as object properties.
+func structFields(t reflect.Type) []structField {
+	fields := make([]structField, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" {
+			// unexported
+			continue
+		}
+		name := f.Name
+		if tag, ok := f.Tag.Lookup("flux"); ok {
+			if tag == "-" {
+				continue
+			}
+			if n := strings.Split(tag, ",")[0]; n != "" {
+				name = n
+			}
+		}
+		fields = append(fields, structField{name: name, index: i})
+	}
+	return fields
+}
+
+// Decode converts a Value into the Go value pointed to by dst.
+//
+// It is the inverse of Encode: the flux basic types can be decoded into Go values of the same kind,
+// an array into a slice or an array and an object into a map with string keys or a struct.
+// A time can be decoded into a time.Time or a Time and a duration into a time.Duration or a Duration.
+// Decoding into an empty interface produces string, bool, int64, uint64, float64, time.Time,
+// time.Duration, *regexp.Regexp, []interface{} or map[string]interface{} values.
+// A null value leaves the destination untouched.
+//
+// Properties of an object that have no matching struct field are an error,
+// struct fields that have no matching property are left untouched.
+func Decode(v Value, dst interface{}) error {
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return fmt.Errorf("cannot decode into %T, it must be a non nil pointer", dst)
+	}
+	return decode(v, rv.Elem())
+}
+
+func decode(v Value, rv reflect.Value) error {
+	if v.IsNull() {
+		return nil
+	}
+	n := v.Type().Nature()
+	if rv.Type() == valueType {
+		rv.Set(reflect.ValueOf(v))
+		return nil
+	}
+	switch rv.Type() {
+	case timeType:
+		if n != semantic.Time {
+			return decodeError(n, rv.Type())
+		}
+		rv.Set(reflect.ValueOf(v.Time().Time()))
+		return nil
+	case regexpType:
+		if n != semantic.Regexp {
+			return decodeError(n, rv.Type())
+		}
+		rv.Set(reflect.ValueOf(v.Regexp()))
+		return nil
+	case fluxTimeType:
+		if n != semantic.Time {
+			return decodeError(n, rv.Type())
+		}
+		rv.SetInt(int64(v.Time()))
+		return nil
+	case durationType:
+		if n != semantic.Duration {
+			return decodeError(n, rv.Type())
+		}
+		rv.SetInt(int64(v.Duration().Duration()))
+		return nil
+	case fluxDurType:
+		if n != semantic.Duration {
+			return decodeError(n, rv.Type())
+		}
+		rv.Set(reflect.ValueOf(v.Duration()))
+		return nil
+	}
+
+	switch rv.Kind() {
+	case reflect.Interface:
+		if rv.NumMethod() != 0 {
+			return decodeError(n, rv.Type())
+		}
+		i, err := decodeInterface(v)
+		if err != nil {
+			return err
+		}
+		rv.Set(reflect.ValueOf(i))
+	case reflect.Ptr:
+		if rv.IsNil() {
+			rv.Set(reflect.New(rv.Type().Elem()))
+		}
+		return decode(v, rv.Elem())
+	case reflect.String:
+		if n != semantic.String {
+			return decodeError(n, rv.Type())
+		}
+		rv.SetString(v.Str())
+	case reflect.Bool:
+		if n != semantic.Bool {
+			return decodeError(n, rv.Type())
+		}
+		rv.SetBool(v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if n != semantic.Int {
+			return decodeError(n, rv.Type())
+		}
+		i := v.Int()
+		if rv.OverflowInt(i) {
+			return fmt.Errorf("value %d overflows %v", i, rv.Type())
+		}
+		rv.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if n != semantic.UInt {
+			return decodeError(n, rv.Type())
+		}
+		u := v.UInt()
+		if rv.OverflowUint(u) {
+			return fmt.Errorf("value %d overflows %v", u, rv.Type())
+		}
+		rv.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		if n != semantic.Float {
+			return decodeError(n, rv.Type())
+		}
+		rv.SetFloat(v.Float())
+	case reflect.Slice:
+		if n != semantic.Array {
+			return decodeError(n, rv.Type())
+		}
+		arr := v.Array()
+		s := reflect.MakeSlice(rv.Type(), arr.Len(), arr.Len())
+		if err := decodeElements(arr, s); err != nil {
+			return err
+		}
+		rv.Set(s)
+	case reflect.Array:
+		if n != semantic.Array {
+			return decodeError(n, rv.Type())
+		}
+		arr := v.Array()
+		if arr.Len() != rv.Len() {
+			return fmt.Errorf("cannot decode array of length %d into %v", arr.Len(), rv.Type())
+		}
+		return decodeElements(arr, rv)
+	case reflect.Map:
+		if n != semantic.Object || rv.Type().Key().Kind() != reflect.String {
+			return decodeError(n, rv.Type())
+		}
+		obj := v.Object()
+		if rv.IsNil() {
+			rv.Set(reflect.MakeMapWithSize(rv.Type(), obj.Len()))
+		}
+		var err error
+		obj.Range(func(k string, p Value) {
+			if err != nil {
+				return
+			}
+			e := reflect.New(rv.Type().Elem()).Elem()
+			if err = decode(p, e); err != nil {
+				err = fmt.Errorf("property %q: %v", k, err)
+				return
+			}
+			rv.SetMapIndex(reflect.ValueOf(k).Convert(rv.Type().Key()), e)
+		})
+		return err
+	case reflect.Struct:
+		if n != semantic.Object {
+			return decodeError(n, rv.Type())
+		}
+		fields := structFields(rv.Type())
+		index := make(map[string]int, len(fields))
+		for _, f := range fields {
+			index[f.name] = f.index
+		}
+		var err error
+		v.Object().Range(func(k string, p Value) {
+			if err != nil {
+				return
+			}
+			i, ok := index[k]
+			if !ok {
+				err = fmt.Errorf("property %q has no matching field in %v", k, rv.Type())
+				return
+			}
+			if err = decode(p, rv.Field(i)); err != nil {
+				err = fmt.Errorf("property %q: %v", k, err)
+			}
+		})
+		return err
+	default:
+		return decodeError(n, rv.Type())
+	}
+	return nil
+}
+
+func decodeElements(arr Array, rv reflect.Value) error {
+	var err error
+	arr.Range(func(i int, e Value) {
+		if err != nil {
+			return
+		}
+		if err = decode(e, rv.Index(i)); err != nil {
+			err = fmt.Errorf("element %d: %v", i, err)
+		}
+	})
+	return err
+}
+
+func decodeInterface(v Value) (interface{}, error) {
+	switch n := v.Type().Nature(); n {
+	case semantic.String:
+		return v.Str(), nil
+	case semantic.Bool:
+		return v.Bool(), nil
+	case semantic.Int:
+		return v.Int(), nil
+	case semantic.UInt:
+		return v.UInt(), nil
+	case semantic.Float:
+		return v.Float(), nil
+	case semantic.Time:
+		return v.Time().Time(), nil
+	case semantic.Duration:
+		return v.Duration().Duration(), nil
+	case semantic.Regexp:
+		return v.Regexp(), nil
+	case semantic.Array:
+		s := make([]interface{}, v.Array().Len())
+		err := decodeElements(v.Array(), reflect.ValueOf(s))
+		return s, err
+	case semantic.Object:
+		m := make(map[string]interface{}, v.Object().Len())
+		err := decode(v, reflect.ValueOf(&m).Elem())
+		return m, err
+	default:
+		return nil, fmt.Errorf("cannot decode value of type %v", n)
+	}
+}
+
+func decodeError(n semantic.Nature, t reflect.Type) error {
+	return fmt.Errorf("cannot decode value of type %v into %v", n, t)
+}
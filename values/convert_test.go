@@ -0,0 +1,167 @@
+package values_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/flux/values"
+)
+
+type convertConfig struct {
+	Bucket  string        `flux:"bucket"`
+	Limit   int           `flux:"limit"`
+	Every   time.Duration `flux:"every"`
+	Start   time.Time     `flux:"start"`
+	Tags    []string      `flux:"tags"`
+	Skipped string        `flux:"-"`
+	secret  string
+}
+
+func TestEncode(t *testing.T) {
+	start := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	got, err := values.Encode(convertConfig{
+		Bucket:  "telegraf",
+		Limit:   10,
+		Every:   time.Minute,
+		Start:   start,
+		Tags:    []string{"host", "region"},
+		Skipped: "skipped",
+		secret:  "secret",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tags := values.NewArray(semantic.String)
+	tags.Append(values.NewString("host"))
+	tags.Append(values.NewString("region"))
+	want := values.NewObjectWithValues(map[string]values.Value{
+		"bucket": values.NewString("telegraf"),
+		"limit":  values.NewInt(10),
+		"every":  values.NewDuration(values.ConvertDuration(time.Minute)),
+		"start":  values.NewTime(values.ConvertTime(start)),
+		"tags":   tags,
+	})
+	if !want.Equal(got) {
+		t.Errorf("unexpected value: want %v got %v", want, got)
+	}
+}
+
+func TestEncode_EmptySlice(t *testing.T) {
+	got, err := values.Encode([]float64{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := semantic.NewArrayType(semantic.Float); got.Type() != want {
+		t.Errorf("unexpected type: want %v got %v", want, got.Type())
+	}
+}
+
+func TestEncode_Errors(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		v    interface{}
+		want string
+	}{
+		{name: "nil", v: nil, want: "cannot encode nil value"},
+		{name: "nil pointer", v: (*int)(nil), want: "cannot encode nil *int"},
+		{name: "int keys", v: map[int]string{1: "a"}, want: "keys must be strings"},
+		{name: "mixed array", v: []interface{}{1, "a"}, want: "array elements must have the same type"},
+		{name: "channel", v: make(chan int), want: "cannot encode value of type chan int"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := values.Encode(tc.v)
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			if !strings.Contains(err.Error(), tc.want) {
+				t.Errorf("unexpected error: want %q in %q", tc.want, err)
+			}
+		})
+	}
+}
+
+func TestDecode(t *testing.T) {
+	start := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	v, err := values.Encode(map[string]interface{}{
+		"bucket": "telegraf",
+		"limit":  int64(10),
+		"every":  time.Minute,
+		"start":  start,
+		"tags":   []string{"host"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got convertConfig
+	if err := values.Decode(v, &got); err != nil {
+		t.Fatal(err)
+	}
+	want := convertConfig{
+		Bucket: "telegraf",
+		Limit:  10,
+		Every:  time.Minute,
+		Start:  start,
+		Tags:   []string{"host"},
+	}
+	if !cmp.Equal(want, got, cmp.AllowUnexported(convertConfig{})) {
+		t.Errorf("unexpected value -want/+got\n%s", cmp.Diff(want, got, cmp.AllowUnexported(convertConfig{})))
+	}
+
+	var generic interface{}
+	if err := values.Decode(v, &generic); err != nil {
+		t.Fatal(err)
+	}
+	wantGeneric := map[string]interface{}{
+		"bucket": "telegraf",
+		"limit":  int64(10),
+		"every":  time.Minute,
+		"start":  start,
+		"tags":   []interface{}{"host"},
+	}
+	if !cmp.Equal(wantGeneric, generic) {
+		t.Errorf("unexpected value -want/+got\n%s", cmp.Diff(wantGeneric, generic))
+	}
+}
+
+func TestDecode_Errors(t *testing.T) {
+	var i int8
+	if err := values.Decode(values.NewInt(1000), &i); err == nil || !strings.Contains(err.Error(), "overflows int8") {
+		t.Errorf("unexpected error: %v", err)
+	}
+	var s string
+	if err := values.Decode(values.NewInt(1), &s); err == nil || !strings.Contains(err.Error(), "cannot decode value of type int into string") {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := values.Decode(values.NewInt(1), s); err == nil || !strings.Contains(err.Error(), "must be a non nil pointer") {
+		t.Errorf("unexpected error: %v", err)
+	}
+	obj := values.NewObjectWithValues(map[string]values.Value{
+		"unknown": values.NewString("a"),
+	})
+	var c convertConfig
+	if err := values.Decode(obj, &c); err == nil || !strings.Contains(err.Error(), `property "unknown" has no matching field`) {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestWalk(t *testing.T) {
+	v, err := values.Encode(map[string]interface{}{
+		"b": []int64{1, 2},
+		"a": "x",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	values.Walk(values.CreateVisitor(func(v values.Value) {
+		got = append(got, v.Type().Nature().String())
+	}), v)
+	want := []string{"object", "string", "array", "int", "int"}
+	if !cmp.Equal(want, got) {
+		t.Errorf("unexpected visit order -want/+got\n%s", cmp.Diff(want, got))
+	}
+}
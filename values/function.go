@@ -1,6 +1,7 @@
 package values
 
 import (
+	"context"
 	"fmt"
 	"regexp"
 
@@ -14,6 +15,13 @@ type Function interface {
 	Call(args Object) (Value, error)
 }
 
+// ContextFunction is a Function that uses the context the script calling it is evaluated with.
+// The interpreter calls CallContext instead of Call.
+type ContextFunction interface {
+	Function
+	CallContext(ctx context.Context, args Object) (Value, error)
+}
+
 // NewFunction returns a new function value
 func NewFunction(name string, typ semantic.PolyType, call func(args Object) (Value, error), sideEffect bool) Function {
 	return &function{
@@ -109,3 +117,40 @@ func (f *function) HasSideEffect() bool {
 func (f *function) Call(args Object) (Value, error) {
 	return f.call(args)
 }
+
+// NewContextFunction returns a new function value that uses the context the script calling it is evaluated with.
+// When it is called without a context, it is called with context.Background().
+func NewContextFunction(name string, typ semantic.PolyType, call func(ctx context.Context, args Object) (Value, error), sideEffect bool) ContextFunction {
+	return &contextFunction{
+		function: function{
+			name: name,
+			t:    typ,
+			call: func(args Object) (Value, error) {
+				return call(context.Background(), args)
+			},
+			hasSideEffect: sideEffect,
+		},
+		callContext: call,
+	}
+}
+
+type contextFunction struct {
+	function
+	callContext func(ctx context.Context, args Object) (Value, error)
+}
+
+func (f *contextFunction) Function() Function {
+	return f
+}
+
+func (f *contextFunction) Equal(rhs Value) bool {
+	if f.Type() != rhs.Type() {
+		return false
+	}
+	v, ok := rhs.(*contextFunction)
+	return ok && (f == v)
+}
+
+func (f *contextFunction) CallContext(ctx context.Context, args Object) (Value, error) {
+	return f.callContext(ctx, args)
+}
@@ -1,52 +1,138 @@
 package values
 
 import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
 	"time"
 )
 
 type Time int64
-type Duration int64
+
+// Duration is a length of time made of a number of months and a number of nanoseconds.
+// The months of a duration have no fixed length, they are applied to a time with the calendar.
+// The zero value is a zero length duration.
+//
+// Duration used to be an int64 count of nanoseconds. Conversions from integers and
+// time.Duration values go through ConvertDuration or MakeDuration, and the
+// time.Duration of a Duration is read with its Duration method.
+type Duration struct {
+	months int64
+	nsecs  int64
+}
 
 const (
 	fixedWidthTimeFmt = "2006-01-02T15:04:05.000000000Z"
+
+	// avgMonth is the average length of a month of the Gregorian calendar.
+	// It is used to compare durations that cannot be compared exactly.
+	avgMonth = 365.2425 / 12 * 24 * float64(time.Hour)
 )
 
 func ConvertTime(t time.Time) Time {
 	return Time(t.UnixNano())
 }
 
+// ConvertDuration returns the duration of a number of nanoseconds.
+func ConvertDuration(d time.Duration) Duration {
+	return Duration{nsecs: int64(d)}
+}
+
+// MakeDuration returns the duration of a number of months and a number of nanoseconds.
+func MakeDuration(months, nsecs int64) Duration {
+	return Duration{months: months, nsecs: nsecs}
+}
+
+// Round returns the result of rounding t to the nearest multiple of d since the zero time.
+// Durations with months round to the calendar months of UTC.
 func (t Time) Round(d Duration) Time {
-	if d <= 0 {
+	if !d.IsPositive() {
 		return t
 	}
+	if d.months != 0 {
+		lo := t.Truncate(d)
+		hi := lo.Add(d)
+		if t-lo < hi-t {
+			return lo
+		}
+		return hi
+	}
 	r := t.Remainder(d)
 	if lessThanHalf(r, d) {
-		return t - Time(r)
+		return t - Time(r.nsecs)
 	}
-	return t + Time(d-r)
+	return t + Time(d.nsecs-r.nsecs)
 }
 
+// Truncate returns the result of rounding t down to a multiple of d since the zero time.
+// Durations with months truncate to the calendar months of UTC and their nanoseconds are ignored.
 func (t Time) Truncate(d Duration) Time {
-	if d <= 0 {
+	if !d.IsPositive() {
 		return t
 	}
+	if d.months != 0 {
+		tm := t.Time()
+		month := int64(tm.Year()-1970)*12 + int64(tm.Month()-1)
+		month -= floorMod(month, d.months)
+		return ConvertTime(time.Date(int(floorDiv(month, 12))+1970, time.Month(floorMod(month, 12)+1), 1, 0, 0, 0, 0, time.UTC))
+	}
 	r := t.Remainder(d)
-	return t - Time(r)
+	return t - Time(r.nsecs)
 }
 
+// Add returns t+d. The months of d are added first, and when the resulting
+// day is past the end of its month, it is rolled back to the last day of the month.
 func (t Time) Add(d Duration) Time {
-	return t + Time(d)
+	if d.months != 0 {
+		t = t.addMonths(d.months)
+	}
+	return t + Time(d.nsecs)
 }
 
-// remainder divides t by d and returns the remainder.
+func (t Time) addMonths(months int64) Time {
+	tm := t.Time()
+	year, month, day := tm.Date()
+	month0 := int64(year)*12 + int64(month-1) + months
+	year, month = int(floorDiv(month0, 12)), time.Month(floorMod(month0, 12)+1)
+	// The last day of the month is the day before the first day of the next month.
+	if last := time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day(); day > last {
+		day = last
+	}
+	h, m, s := tm.Clock()
+	return ConvertTime(time.Date(year, month, day, h, m, s, tm.Nanosecond(), time.UTC))
+}
+
+// Sub returns the duration t-u, in nanoseconds.
+func (t Time) Sub(u Time) Duration {
+	return Duration{nsecs: int64(t - u)}
+}
+
+// Remainder divides t by d and returns the remainder.
+// Durations with months have the remainder of t since the start of its months.
 func (t Time) Remainder(d Duration) (r Duration) {
-	return Duration(int64(t) % int64(d))
+	if d.months != 0 {
+		return t.Sub(t.Truncate(d))
+	}
+	return Duration{nsecs: int64(t) % d.nsecs}
 }
 
 // lessThanHalf reports whether x+x < y but avoids overflow,
 // assuming x and y are both positive (Duration is signed).
 func lessThanHalf(x, y Duration) bool {
-	return uint64(x)+uint64(x) < uint64(y)
+	return uint64(x.nsecs)+uint64(x.nsecs) < uint64(y.nsecs)
+}
+
+func floorDiv(x, y int64) int64 {
+	q := x / y
+	if (x%y != 0) && ((x < 0) != (y < 0)) {
+		q--
+	}
+	return q
+}
+
+func floorMod(x, y int64) int64 {
+	return x - floorDiv(x, y)*y
 }
 
 func (t Time) String() string {
@@ -65,16 +151,228 @@ func (t Time) Time() time.Time {
 	return time.Unix(0, int64(t)).UTC()
 }
 
+// Months returns the months of the duration.
+func (d Duration) Months() int64 {
+	return d.months
+}
+
+// Nanoseconds returns the nanoseconds of the duration, which do not include its months.
+func (d Duration) Nanoseconds() int64 {
+	return d.nsecs
+}
+
+// IsZero reports whether the duration has no length.
+func (d Duration) IsZero() bool {
+	return d.months == 0 && d.nsecs == 0
+}
+
+// IsPositive reports whether the duration is longer than zero.
+func (d Duration) IsPositive() bool {
+	return d.Compare(Duration{}) > 0
+}
+
+// IsNegative reports whether the duration is shorter than zero.
+func (d Duration) IsNegative() bool {
+	return d.Compare(Duration{}) < 0
+}
+
+// Add returns d+o, the months and the nanoseconds are added separately.
+func (d Duration) Add(o Duration) Duration {
+	return Duration{months: d.months + o.months, nsecs: d.nsecs + o.nsecs}
+}
+
+// Sub returns d-o, the months and the nanoseconds are subtracted separately.
+func (d Duration) Sub(o Duration) Duration {
+	return d.Add(o.Neg())
+}
+
+// Mul returns the duration multiplied by n.
+func (d Duration) Mul(n int64) Duration {
+	return Duration{months: d.months * n, nsecs: d.nsecs * n}
+}
+
+// Neg returns -d.
+func (d Duration) Neg() Duration {
+	return Duration{months: -d.months, nsecs: -d.nsecs}
+}
+
+// Equal reports whether the durations have the same months and the same nanoseconds.
+// 1mo and 30d are not equal.
+func (d Duration) Equal(o Duration) bool {
+	return d == o
+}
+
+// Compare returns -1, 0 or +1 when d is shorter than, as long as or longer than o.
+// When the durations differ in both their months and their nanoseconds,
+// they are compared with the average length of a month.
+func (d Duration) Compare(o Duration) int {
+	switch {
+	case d.months == o.months:
+		return compareInt64(d.nsecs, o.nsecs)
+	case d.nsecs == o.nsecs:
+		return compareInt64(d.months, o.months)
+	}
+	x := float64(d.months-o.months)*avgMonth + float64(d.nsecs-o.nsecs)
+	switch {
+	case x < 0:
+		return -1
+	case x > 0:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func compareInt64(x, y int64) int {
+	switch {
+	case x < y:
+		return -1
+	case x > y:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Duration returns the duration as a time.Duration.
+// The months are converted with the average length of a month, so the result is an approximation.
 func (d Duration) Duration() time.Duration {
-	return time.Duration(d)
+	if d.months == 0 {
+		return time.Duration(d.nsecs)
+	}
+	ns := float64(d.months)*avgMonth + float64(d.nsecs)
+	if ns >= math.MaxInt64 {
+		return math.MaxInt64
+	} else if ns <= math.MinInt64 {
+		return math.MinInt64
+	}
+	return time.Duration(ns)
 }
+
+// String formats the duration with the years and months first, as in 1y2mo, followed by the nanoseconds
+// formatted as a time.Duration. The nanoseconds have a sign of their own when it differs
+// from the sign of the months, as in 1mo-1h0m0s.
 func (d Duration) String() string {
-	return time.Duration(d).String()
+	if d.months == 0 {
+		return time.Duration(d.nsecs).String()
+	}
+	var b strings.Builder
+	months, nsecs := d.months, d.nsecs
+	if months < 0 {
+		b.WriteByte('-')
+		months, nsecs = -months, -nsecs
+	}
+	if y := months / 12; y > 0 {
+		b.WriteString(strconv.FormatInt(y, 10))
+		b.WriteByte('y')
+	}
+	if mo := months % 12; mo > 0 {
+		b.WriteString(strconv.FormatInt(mo, 10))
+		b.WriteString("mo")
+	}
+	if nsecs != 0 {
+		b.WriteString(time.Duration(nsecs).String())
+	}
+	return b.String()
 }
-func ParseDuration(s string) (Duration, error) {
-	d, err := time.ParseDuration(s)
+
+func (d *Duration) UnmarshalText(data []byte) error {
+	dur, err := ParseDuration(string(data))
 	if err != nil {
-		return 0, err
+		return err
+	}
+	*d = dur
+	return nil
+}
+
+func (d Duration) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// ParseDuration parses a duration formatted as a Flux duration literal, such as 1mo2d,
+// or as a time.Duration, such as 1h30m0s. It also parses the output of Duration.String.
+func ParseDuration(s string) (Duration, error) {
+	if d, err := time.ParseDuration(s); err == nil {
+		return ConvertDuration(d), nil
+	}
+	if s == "" || s == "-" {
+		return Duration{}, fmt.Errorf("invalid duration %q", s)
+	}
+	rest, neg := s, false
+	if strings.HasPrefix(rest, "-") {
+		rest, neg = rest[1:], true
+	}
+
+	var d Duration
+	for rest != "" && rest[0] != '-' {
+		mag, unit, tail, err := nextDurationPart(rest)
+		if err != nil {
+			return Duration{}, fmt.Errorf("invalid duration %q: %v", s, err)
+		}
+		switch unit {
+		case "y":
+			d.months += mag * 12
+		case "mo":
+			d.months += mag
+		default:
+			// The nanoseconds part may be formatted as a time.Duration.
+			if ns, err := time.ParseDuration(rest); err == nil {
+				d.nsecs += int64(ns)
+				tail = ""
+				break
+			}
+			ns, ok := durationUnits[unit]
+			if !ok {
+				return Duration{}, fmt.Errorf("invalid duration %q: unknown unit %q", s, unit)
+			}
+			d.nsecs += mag * int64(ns)
+		}
+		rest = tail
+	}
+	if rest != "" {
+		// The nanoseconds have a sign of their own.
+		ns, err := time.ParseDuration(rest)
+		if err != nil || d.months == 0 || d.nsecs != 0 {
+			return Duration{}, fmt.Errorf("invalid duration %q", s)
+		}
+		d.nsecs = int64(ns)
+	}
+	if neg {
+		d = d.Neg()
+	}
+	return d, nil
+}
+
+var durationUnits = map[string]time.Duration{
+	"w":  7 * 24 * time.Hour,
+	"d":  24 * time.Hour,
+	"h":  time.Hour,
+	"m":  time.Minute,
+	"s":  time.Second,
+	"ms": time.Millisecond,
+	"us": time.Microsecond,
+	"µs": time.Microsecond,
+	"ns": time.Nanosecond,
+}
+
+// nextDurationPart splits the integer magnitude and the unit at the start of s from the rest of s.
+func nextDurationPart(s string) (mag int64, unit, rest string, err error) {
+	i := 0
+	for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+		i++
+	}
+	if i == 0 {
+		return 0, "", "", fmt.Errorf("expected a magnitude at %q", s)
+	}
+	if mag, err = strconv.ParseInt(s[:i], 10, 64); err != nil {
+		return 0, "", "", err
+	}
+	j := i
+	for j < len(s) && (s[j] < '0' || s[j] > '9') && s[j] != '-' {
+		j++
+	}
+	if j == i {
+		return 0, "", "", fmt.Errorf("missing unit after %q", s[:i])
 	}
-	return Duration(d), nil
+	return mag, s[i:j], s[j:], nil
 }
@@ -15,22 +15,22 @@ func TestTime_Round(t *testing.T) {
 	}{
 		{
 			ts:   values.Time(time.Second + 500*time.Millisecond),
-			d:    values.Duration(time.Second),
+			d:    values.ConvertDuration(time.Second),
 			want: values.Time(2 * time.Second),
 		},
 		{
 			ts:   values.Time(time.Second + 501*time.Millisecond),
-			d:    values.Duration(time.Second),
+			d:    values.ConvertDuration(time.Second),
 			want: values.Time(2 * time.Second),
 		},
 		{
 			ts:   values.Time(time.Second + 499*time.Millisecond),
-			d:    values.Duration(time.Second),
+			d:    values.ConvertDuration(time.Second),
 			want: values.Time(time.Second),
 		},
 		{
 			ts:   values.Time(time.Second + 0*time.Millisecond),
-			d:    values.Duration(time.Second),
+			d:    values.ConvertDuration(time.Second),
 			want: values.Time(time.Second),
 		},
 	} {
@@ -50,27 +50,27 @@ func TestTime_Truncate(t *testing.T) {
 	}{
 		{
 			ts:   values.Time(time.Second + 500*time.Millisecond),
-			d:    values.Duration(time.Second),
+			d:    values.ConvertDuration(time.Second),
 			want: values.Time(time.Second),
 		},
 		{
 			ts:   values.Time(time.Second + 501*time.Millisecond),
-			d:    values.Duration(time.Second),
+			d:    values.ConvertDuration(time.Second),
 			want: values.Time(time.Second),
 		},
 		{
 			ts:   values.Time(time.Second + 499*time.Millisecond),
-			d:    values.Duration(time.Second),
+			d:    values.ConvertDuration(time.Second),
 			want: values.Time(time.Second),
 		},
 		{
 			ts:   values.Time(time.Second + 0*time.Millisecond),
-			d:    values.Duration(time.Second),
+			d:    values.ConvertDuration(time.Second),
 			want: values.Time(time.Second),
 		},
 		{
 			ts:   values.Time(time.Second + 999*time.Millisecond),
-			d:    values.Duration(time.Second),
+			d:    values.ConvertDuration(time.Second),
 			want: values.Time(time.Second),
 		},
 	} {
@@ -81,3 +81,99 @@ func TestTime_Truncate(t *testing.T) {
 		})
 	}
 }
+
+func TestTime_AddMonths(t *testing.T) {
+	for _, tt := range []struct {
+		ts   string
+		d    string
+		want string
+	}{
+		{ts: "2018-01-01T00:00:00Z", d: "1d", want: "2018-01-02T00:00:00Z"},
+		{ts: "2018-01-01T00:00:00Z", d: "1mo", want: "2018-02-01T00:00:00Z"},
+		{ts: "2018-01-31T00:00:00Z", d: "2mo", want: "2018-03-31T00:00:00Z"},
+		{ts: "2018-01-31T00:00:00Z", d: "1mo", want: "2018-02-28T00:00:00Z"},
+		{ts: "2018-01-28T00:00:00Z", d: "1mo2d", want: "2018-03-02T00:00:00Z"},
+		{ts: "2018-01-31T00:00:00Z", d: "1mo1d", want: "2018-03-01T00:00:00Z"},
+		{ts: "2018-07-01T00:00:00Z", d: "2y", want: "2020-07-01T00:00:00Z"},
+		{ts: "2018-03-31T12:30:00Z", d: "-1mo", want: "2018-02-28T12:30:00Z"},
+		{ts: "2018-01-15T00:00:00Z", d: "-1y1mo", want: "2016-12-15T00:00:00Z"},
+	} {
+		t.Run(tt.ts+"+"+tt.d, func(t *testing.T) {
+			ts, err := time.Parse(time.RFC3339, tt.ts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			d, err := values.ParseDuration(tt.d)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want, got := tt.want, values.ConvertTime(ts).Add(d).Time().Format(time.RFC3339); want != got {
+				t.Fatalf("unexpected time -want/+got\n\t- %s\n\t+ %s", want, got)
+			}
+		})
+	}
+}
+
+func TestTime_TruncateMonths(t *testing.T) {
+	for _, tt := range []struct {
+		ts   string
+		d    values.Duration
+		want string
+	}{
+		{ts: "2018-02-14T13:00:00Z", d: values.MakeDuration(1, 0), want: "2018-02-01T00:00:00Z"},
+		{ts: "2018-05-31T23:59:59Z", d: values.MakeDuration(3, 0), want: "2018-04-01T00:00:00Z"},
+		{ts: "2018-05-31T23:59:59Z", d: values.MakeDuration(12, 0), want: "2018-01-01T00:00:00Z"},
+		{ts: "1969-11-15T00:00:00Z", d: values.MakeDuration(3, 0), want: "1969-10-01T00:00:00Z"},
+	} {
+		t.Run(tt.ts, func(t *testing.T) {
+			ts, err := time.Parse(time.RFC3339, tt.ts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want, got := tt.want, values.ConvertTime(ts).Truncate(tt.d).Time().Format(time.RFC3339); want != got {
+				t.Fatalf("unexpected time -want/+got\n\t- %s\n\t+ %s", want, got)
+			}
+		})
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	for _, tt := range []struct {
+		s    string
+		want values.Duration
+		str  string
+	}{
+		{s: "1h0m0s", want: values.ConvertDuration(time.Hour), str: "1h0m0s"},
+		{s: "1h", want: values.ConvertDuration(time.Hour), str: "1h0m0s"},
+		{s: "2w1d", want: values.ConvertDuration(15 * 24 * time.Hour), str: "360h0m0s"},
+		{s: "1mo", want: values.MakeDuration(1, 0), str: "1mo"},
+		{s: "1y2mo3d", want: values.MakeDuration(14, int64(72*time.Hour)), str: "1y2mo72h0m0s"},
+		{s: "1mo1h30m0s", want: values.MakeDuration(1, int64(90*time.Minute)), str: "1mo1h30m0s"},
+		{s: "-1mo1h", want: values.MakeDuration(-1, -int64(time.Hour)), str: "-1mo1h0m0s"},
+		{s: "1mo-1h0m0s", want: values.MakeDuration(1, -int64(time.Hour)), str: "1mo-1h0m0s"},
+		{s: "-1mo-1h0m0s", want: values.MakeDuration(-1, int64(time.Hour)), str: "-1mo-1h0m0s"},
+	} {
+		t.Run(tt.s, func(t *testing.T) {
+			got, err := values.ParseDuration(tt.s)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Fatalf("unexpected duration -want/+got\n\t- %v\n\t+ %v", tt.want, got)
+			}
+			if want, got := tt.str, got.String(); want != got {
+				t.Fatalf("unexpected string -want/+got\n\t- %s\n\t+ %s", want, got)
+			}
+			if back, err := values.ParseDuration(got.String()); err != nil {
+				t.Fatal(err)
+			} else if back != tt.want {
+				t.Fatalf("unexpected duration from string -want/+got\n\t- %v\n\t+ %v", tt.want, back)
+			}
+		})
+	}
+	for _, s := range []string{"", "-", "1", "mo", "1x", "1h-1m"} {
+		if _, err := values.ParseDuration(s); err == nil {
+			t.Errorf("expected an error parsing %q", s)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package values
+
+import (
+	"sort"
+
+	"github.com/influxdata/flux/semantic"
+)
+
+// Visitor visits the values nested in a value.
+// The elements of an array are visited in order and
+// the properties of an object are visited in the order of their names.
+type Visitor interface {
+	// Visit is called for each value before its nested values.
+	// If the returned visitor is nil the nested values are not visited.
+	Visit(v Value) Visitor
+	// Done is called for each value after its nested values have been visited.
+	Done(v Value)
+}
+
+// Walk traverses v and its nested values with the visitor.
+func Walk(visitor Visitor, v Value) {
+	if v == nil {
+		return
+	}
+	w := visitor.Visit(v)
+	if w != nil && !v.IsNull() {
+		switch v.Type().Nature() {
+		case semantic.Array:
+			v.Array().Range(func(i int, e Value) {
+				Walk(w, e)
+			})
+		case semantic.Object:
+			obj := v.Object()
+			keys := make([]string, 0, obj.Len())
+			obj.Range(func(k string, _ Value) {
+				keys = append(keys, k)
+			})
+			sort.Strings(keys)
+			for _, k := range keys {
+				p, _ := obj.Get(k)
+				Walk(w, p)
+			}
+		}
+	}
+	visitor.Done(v)
+}
+
+// CreateVisitor returns a visitor that calls f for every value.
+func CreateVisitor(f func(Value)) Visitor {
+	return &visitor{f: f}
+}
+
+type visitor struct {
+	f func(Value)
+}
+
+func (v *visitor) Visit(value Value) Visitor {
+	v.f(value)
+	return v
+}
+
+func (v *visitor) Done(Value) {}